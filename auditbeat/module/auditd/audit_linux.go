@@ -235,33 +235,19 @@ func (ms *MetricSet) addRules(reporter mb.PushReporterV2) error {
 		return errors.New("Skipping rule configuration: Audit rules are locked")
 	}
 
-	// Delete existing rules.
-	n, err := client.DeleteRules()
-	if err != nil {
-		return errors.Wrap(err, "failed to delete existing rules")
-	}
-	ms.log.Infof("Deleted %v pre-existing audit rules.", n)
-
 	// Add rule to ignore syscalls from this process
+	var selfRule *auditRule
 	if rule, err := buildPIDIgnoreRule(os.Getpid()); err == nil {
-		rules = append([]auditRule{rule}, rules...)
+		selfRule = &rule
 	} else {
 		ms.log.Errorf("Failed to build a rule to ignore self: %v", err)
 	}
-	// Add rules from config.
-	var failCount int
-	for _, rule := range rules {
-		if err = client.AddRule(rule.data); err != nil {
-			// Treat rule add errors as warnings and continue.
-			err = errors.Wrapf(err, "failed to add audit rule '%v'", rule.flags)
-			reporter.Error(err)
-			ms.log.Warnw("Failure adding audit rule", "error", err)
-			failCount++
-		}
+
+	updater := &ruleUpdater{client: client, reporter: reporter, log: ms.log}
+	if ms.config.RuleUpdate == ruleUpdateReplace {
+		return updater.replace(selfRule, rules)
 	}
-	ms.log.Infof("Successfully added %d of %d audit rules.",
-		len(rules)-failCount, len(rules))
-	return nil
+	return updater.diff(selfRule, rules)
 }
 
 func (ms *MetricSet) initClient() error {
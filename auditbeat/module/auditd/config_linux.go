@@ -44,7 +44,8 @@ type Config struct {
 	RawMessage   bool     `config:"include_raw_message"` // Include the list of raw audit messages in the event.
 	Warnings     bool     `config:"include_warnings"`    // Include warnings in the event (for dev/debug purposes only).
 	RulesBlob    string   `config:"audit_rules"`         // Audit rules. One rule per line.
-	RuleFiles    []string `config:"audit_rule_files"`    // List of rule files.
+	RuleFiles    []string `config:"audit_rule_files"`    // List of rule files or directories.
+	RuleUpdate   string   `config:"audit_rule_update"`   // How loaded rules are updated (diff or replace).
 	SocketType   string   `config:"socket_type"`         // Socket type to use with the kernel (unicast or multicast).
 
 	// Tuning options (advanced, use with care)
@@ -62,8 +63,9 @@ type Config struct {
 }
 
 type auditRule struct {
-	flags string
-	data  []byte
+	flags  string
+	data   []byte
+	source string // Location of the rule in the configuration.
 }
 
 type ruleWithSource struct {
@@ -73,6 +75,20 @@ type ruleWithSource struct {
 
 type ruleSet map[string]ruleWithSource
 
+// Rule update modes.
+const (
+	// ruleUpdateDiff only deletes and adds the rules that differ between the
+	// kernel and the configuration.
+	ruleUpdateDiff = "diff"
+	// ruleUpdateReplace deletes all the rules loaded in the kernel before
+	// adding the configured rules.
+	ruleUpdateReplace = "replace"
+)
+
+// ruleFileExtension is the extension of the rule files loaded from
+// directories configured in audit_rule_files.
+const ruleFileExtension = ".rules"
+
 var defaultConfig = Config{
 	ResolveIDs:             true,
 	FailureMode:            "silent",
@@ -80,6 +96,7 @@ var defaultConfig = Config{
 	RateLimit:              0,
 	RawMessage:             false,
 	Warnings:               false,
+	RuleUpdate:             ruleUpdateDiff,
 	ReassemblerMaxInFlight: 50,
 	ReassemblerTimeout:     2 * time.Second,
 	StreamBufferQueueSize:  8192,
@@ -98,6 +115,14 @@ func (c *Config) Validate() error {
 		errs = append(errs, err)
 	}
 
+	c.RuleUpdate = strings.ToLower(c.RuleUpdate)
+	switch c.RuleUpdate {
+	case ruleUpdateDiff, ruleUpdateReplace:
+	default:
+		errs = append(errs, errors.Errorf("invalid audit_rule_update "+
+			"'%v' (use diff or replace)", c.RuleUpdate))
+	}
+
 	c.SocketType = strings.ToLower(c.SocketType)
 	switch c.SocketType {
 	case "", "unicast", "multicast":
@@ -126,7 +151,13 @@ func (c *Config) loadRules() error {
 			return err
 		}
 		sort.Strings(files)
-		paths = append(paths, files...)
+		for _, file := range files {
+			ruleFiles, err := expandRuleDir(file)
+			if err != nil {
+				return err
+			}
+			paths = append(paths, ruleFiles...)
+		}
 	}
 
 	knownRules := ruleSet{}
@@ -152,6 +183,31 @@ func (c *Config) loadRules() error {
 	return nil
 }
 
+// expandRuleDir returns the rule files in path, sorted by name, if path is a
+// directory. Otherwise it returns path.
+func expandRuleDir(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat rule file '%s': %v", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*"+ruleFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			paths = append(paths, file)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
 func (c Config) failureMode() (uint32, error) {
 	switch strings.ToLower(c.FailureMode) {
 	case "silent":
@@ -196,7 +252,7 @@ func readRules(reader io.Reader, source string, knownRules ruleSet) (rules []aud
 			errs = append(errs, errors.Errorf("at %s: rule '%v' is a duplicate of '%v' at %s", location, line, existing.rule.flags, existing.source))
 			continue
 		}
-		rule := auditRule{flags: line, data: []byte(data)}
+		rule := auditRule{flags: line, data: []byte(data), source: location}
 		knownRules[string(data)] = ruleWithSource{rule, location}
 
 		rules = append(rules, rule)
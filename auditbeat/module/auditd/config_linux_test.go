@@ -20,6 +20,7 @@ package auditd
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -190,6 +191,54 @@ func TestConfigRuleOrdering(t *testing.T) {
 	}
 }
 
+func TestConfigRuleDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules.d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, content := range map[string]string{
+		"20_exec.rules":  makeRuleFlags(2, 0),
+		"10_auth.rules":  makeRuleFlags(1, 0),
+		"30_other.conf":  makeRuleFlags(3, 0),
+		"99_last.rules~": makeRuleFlags(4, 0),
+	} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := defaultConfig
+	config.RuleFiles = []string{dir}
+	if err = config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.EqualValues(t, []string{
+		makeRuleFlags(1, 0),
+		makeRuleFlags(2, 0),
+	}, commands(config.rules()))
+}
+
+func TestConfigValidateRuleUpdate(t *testing.T) {
+	config := defaultConfig
+	assert.Equal(t, ruleUpdateDiff, config.RuleUpdate)
+
+	config.RuleUpdate = "Replace"
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ruleUpdateReplace, config.RuleUpdate)
+
+	config.RuleUpdate = "flush"
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	t.Log(err)
+}
+
 func makeRuleFlags(fileID, ruleID int) string {
 	return fmt.Sprintf("-w /path/%d/%d -p rwxa -k rule:%d:%d", fileID, ruleID, fileID, ruleID)
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetAuditd returns asset data.
 // This is the base64 encoded gzipped contents of module/auditd.
 func AssetAuditd() string {
-	return "eJzMXVuPHLdyftevIPwiG7DWznGQBz0cQMfOwyJOIsRSkCAIRlyyuofabrJFsmd2zq8Pipdu9m2ma6QcHD8I3tlh8Vb11VfFIvcNe4bLW8Z7qbx8xZhXvoG37F3+WYITVnVeGf2WfTiCA8YtMH8EVilopGM1aLDcg2RPl/B5lMVaI/sGHl6x9MW3r14x9oZp3sJb1juwrxhjzF86eMtqa/ou/Jy/i/+fv8x7JcMH+eu8UdylTzruj1HeQ+j4Yfhuq2rL48C97WEicqfEXbJgpzCoKhBenWCf2MrtlFupBtzFeWj3Cd4r1/ETyH0i630SwzbvXNSdIsdFJQh3O4XHFSAIrvZKLvaMIB7/PbS8m/Qw2s7MWv+cPmTssWKfLDjTnOCgpPvElGMOPPMm9MGUDoYrjK5U3cfu8RPNPuUuPw3Czqpp8KueK804a3nXKV0zU6FRRfsNA3VB/BFY6jl+Ooj5Hh7qh2Da7M2fmTXG//CQflliwCoKrC3uChJM+ltb1zU8uCmbIBUIYkdFJnRQYsTNHgqdI3RB6SHaC0F4vV92NBPK4hOEz1GEsj6Ebko8Ie0yoY8FstzsaJgJNEr3L/vA5cMR2B///Ds2YEqC9spfEAMCARDe2OvGPPj/satnuJyNHec56Y4LYXrtmeufWuWRbFTGMt4jSHklwpRmXVjTAKULHNJrN0yqaJ4lStNypSkyP+TVKARHKczYIOBh1kcDJ2hudAEvvO2Qpbmf93cbBM97E9xDbezla+eU5eCshGk7bn0L2ruHkvN11ghwbpX2TXp4H7/IuPdWPfUe3MMmNxTnW/42dftwNvZZ6foglQUc/uWKw12ZsuitBe1ZEsMGMZM5OtNbAben+Ef4HvNH7pm3qq7BggzGAyfQfnu+OKdX23s1G7Zy6OpRLLZj3DkjVODqZ4U/s16rF+aMeAY/mYcE55UezerqZH4bv8y4lBb37u92ZkOksy/2aME5XsMBv3tldKNJXpzgTXOdj33IUVIWH2QmSpQkoCnxruO2NfYgQSuQiSCNeP2lBy2mw2qMrm93npsy3bdPYDNwhx1i3OFyqlqPId0zWA3NA/tj2iVL7V2ICZ03uNXYnPVK+1/+lIllbM64lkxwjdDamBPY+XScG1H8pgrMJxQas8ffxrF7wzhrTK30A3vXNHF2jllogp6Mvx4kZSmB4h75Kca5jrfATrzpYTpgC65v/C6l6EUANGNZxdWqcnyIRLlv/OBFUUFAMtNBYuTfJzk/oZAfglaXPK1t+QBqS++9QaoRwmboX7bawv5sfOg012w9andh8cshlI5BFUPfWs0rGpC1IAnaICQT1MgezFhVK82bmSbgf4+/PbBHH5VBG8/Ekes6GglT1Tj9+HkId7g2/gg205WHxVQdCKPlHZONSp4a35zgpVOIIZfJfAZd5gk442r9yOBFQOcDozpj0DfM7Mgd/o9kn1z/ac4dzNNnEH6/7pTbVe6EP4bw0SaB7AnwZy5Q9/vO6AwiO5WpAOo7Neld+cu8wueg40dg34Xxfoejj3iNhPvH5Gl+nAjCNXyTMOWHpS7cqfbffffttGqQlSUdzZnCBRd7Gn/7BA4lZdUMkMod68BWxrYgH9hH16N+4uYPigAv5cZFJtmizwj4ggKiasALiD4g+CbHWBALty+q+V25gL+hyYJULLRwHVOVNpIUeYQGyZXOQw44USRJOCn06snKK9Nrifbz0yinMN8DNQpbqAsKocZZq0LoodWqmD3R000pEwS5S8YyZXJVACIIM2cNNsLu42/zUJaoBqioSRWip6gUWPa960Ao3oT+HDO6ufww6wj/pU7/WWmJNhNnMZCVaKsWKrDIGOV8jZYpjb1rFKx3uUjKQ0uReD4qcQytEIDycIWxcjHYlmjQ+H1WNbzGVWY8DH5lpcnzD3atNOMn4aYYJ7nn+yAOiQR+m1XWtAVXS5GIu5GEFUWmZdfANfhKNR4s6zi6SCaV64xTKxmbVukFGd0Dd6HdOn5ysaAo1/NMOQ2UE07FPg0ypSSNMubAW+NhFh1nmqUcE0ZrEB51EPdl1qNQ3ZGG1EFTTMWEvXTeJAHMQQPIq+aWQ7JE2dtI0OICJfY7EwnaWwWONOQhFE2NM/EbNcjzp4UhIfxQunFgT9iNZaJRGO4qnVdpAK95F079lQaJcGHYJlDqG8JdR1t+h2NOOa0lCHIrjlTdhKYK7ZQH4XubkGshuBZUweOOclv3ISEYg6RA4U4wS5YMMMA/3wcD2G4dBnqtSDAQE+iybDfEF4UOEgEwNF1DFA+2xSCUtMCpTTT1EktsrzVaaGdNbXmLPmjWX2259saSzLPjbTrSd4x3nTUn7GNk+HPiGtKjRF8xNCqga8tuTEdVxzk3GcKRCMfK5ZTLfHc8KT/u/YX1SSVp2zI1h72KGpKFydSUZvEoawzkY07PiJC/nk+toA37cv8f/pt5eJnbRMVb1ZAWqWAF1ngjzBIESHCTlOVf3/3KeFMbq/yx3XJ3XUVTfLBhRStjz9xKDLYtiAtrwR/NwpV6aEnSpzgZctmRhLpJ3mOOxj9/VYDC/+Hrmv/p65r/8lXNj8Z5KnvGdcztqOSr6YwlOY/GCIRl8Gdjn1nRegjnqBITe8Rm6/4NXsj+LcAGtmNijG4GgyaBazI+ZD2V0nUwarXQ2YbKm6frmKjzgj3dtTnbKyl4x59Uo2iYjwHNy9hWLfiw5rb+ClyY8qeUbGQr3EnD+Q1o5BkkUqnhHLA9pe2jAObAo1HMzRe/c3ji4rkx9aFRLU31YheRYL12LMlhX3rogRVEuyASdAph7GWNZwneHWjJjUy0hwKRQkHYWIlVJgpo/htEb4MsbJmISUi5Y+hwUgvs0HA+dCTrxL3N0+iQNIbyiavTMI28R4s6CyE62atJZqWk62oH8YTgtYuHNRvJMtPQZDYyHu7NxDxxrYFGkVOTuH9GRxQEyTpeLwAWOIZatKAyHKKmlklVYvQ9ZyGn9o3wL1S4OaE9CqOR4DHn7cqGEWPVYb8241UHqJKkVc41J0GXw9mKK+xmIX9ySkvq4N1//sokCBWOhEPIBPKneBK/grp2WC1ypt5UzOo6H3xJidZvTcs4O83JCFqmBtJ0slUGbpzyT+UZ+aIPp2o4HfALhgRlqg7h6JpXVTRRVw5ETCMPoCtjhaItONo5LkFsDOhNmfPc93NXjUt8El1/zxqPHvvX9x+ZMHZBBCzaK0X0UJbGUl1aIaCoRKDRyat1BtMyg7n3l5JILYYlQUcUjx4k+LXArOKkBH5iQVmnJ0HoYJmNfn5DRK1OyThc3yj9nNPWDrRcaKPrnz6T2KfrutAowuJVsOX/8/ObX/6XCuJzpriaYRPTY/pd2R9/RImxJYam7uKquXJHF0V3aq8dO4ENOLtu9MK0JNUw4wrHI+RG6dXMGyI3EU9LHE2QfQ1Ns5s6kax+KK0NaYBRRnkUNexmwx3V/mP5YWh5ffihmpO0PsodYqOD5+55WiU1WLqiSAxnX8MolT6CVTc57JQZEaEqNY5wNef4juR3YonipTFcThA3pXnm0c43Tw2ghjugnvQjVmcdLNqO/vzEGyUPCcDu0exp02H+xLxfmn5hk/ORdi9UA398/19D1mGdzRDDFtWJEZHWg5aWi5AIpYgFf0T09NlDhRrSx9/iye0cQe/wU6ke66qTUkTf+vg+5Jxry1tWWV4HGjbWKKzoLi1ZG6AiJhcmfHoN2k4tlRmECGkzlgnZBRKwZVE7IQ2ZKTVk7iyclOldvGm1FugaR2OPgyaPNbdzdKdXduRPtg7wFLFcZGJyGwUjqF1SuWdS2Kbc803F6rgdU/a7nUQZ9GRPESWt1Y80QDpZa0DXQ3n9sPNVw2kxVAd6yB2vnRL3RO3k7OPHpR0Rq20cCCQcudJwJZ0dooDaEtNmkf5H5dnKFR9omfKg6zuTcKieLa2kCb02b0NZhqlYC62xF+SP//KXedwS0i73uO0x65LMQIJQMuS7Zn3cFaXjDHZF6bg64siplXEoH5tx4cHmJMwOFo/Q+60yPMhZTtdyPL1XFckse8+U9mArLjbSJqIl2WUOm6YFtzOZR2NI2Dme+WLLeAyXqIvARVo5e8NdJtd04h7bXk+u0g2KT/WfIa1zLd3sFEkrrmXJpicrZ678wSvaQefG6QrKYoWswVOFDDUpMkhN1oJpNBIiYuXE2S7UomJWfKQiI24JWiuZYtNIInszjaQyOISyO/ZTcmiDZyvv58fAGmdTSh04mLEtLW6PXTWmfu2mrcujJyINQ1PM7GsGszPh/o5QMtwe4bYGHy7ylFmfKyFLy0mh0O3isjP3tKK7aS1vbL+RDgid3+l1ZrKI/CfxnqWcJ2NocJwcYGYQ2B74nC0o0XblJct9CoARr6lC41zAvKK0jRFER3WOkVIoa0/nbYWQCWSMTmuvE4xlIda0yokecaKg4WPdMmmNebxfGOrOkWvkBN9VTqOofLiswwhVCTP/MTmjJkFpeUa9E0olVLxv/Js7cCM1DURzPQ0VYjEq2A1Al4K3IASR6axXMxvo5Dvu3JkMqVE5K2PDUXuQYaxE7K6XeBd2g1ixeqYWPuRSu3u2I3P+jNql0k4vBU8CrxONt5SZobJQ5/rJQxF506B9sQnEJYnGtp3XLEpD9h20pFs62K7njfrrrls6IaVFOyiiV5qQj0QzaRye6lg/ErXETHLCzKs0JZz0U8dbJtPXxmqePn8N1kwu4o7MnphNLhzTWj0ubtRXJMsmMe/CE8XAp+KqIdbEzMKdJGHtkExpWkyt9K2Q+kTy+8nuWMvFceMsskyo7ZLZtry7mo4LeS/iqfpw7J30YLMM46sz89d1AnsgZqaHXLdDWF9JdTv4Qqxpmb6TMd+wv4kL+vJ08bR1GJPfjn3p+fBSQCloXBLq0eC8ICWkRYp7dhMnTXeeg/ffyTwQkanlOgjIt0t1UAPJySfTyM3kU4gXSN60TDHs9KiSWmadjlAf328wjeCiiXe8Fx56+ZiWMsI3xLRkfCTlSw/OD4Uu+ZXBIG+j3sURT5Vjkfg2+crv8pBcVWqTLuQoHXMsS3L7N7AZVKVDy2nefJ5Jw/bzJxhyMmPtzgoRzGPOdfOMMaALvYpk70nr0+cDMVGcssObhMwCd7TbcEUKi0nQJuT6eBIUor/N23eNcuRM2pxLxVQ9StquxKRmVjIu7MmuPPW068MpzeT6p8wyeheOV0pq3xhdOzYw4wkyk+yuRGaCp6IC6SR0WILo/1P2qbcky/r4H4+sM0oHBQ1lh+t5oUjz77hYUKqme+3StYKfpHLhRu1mGe99GZapku7MskS6SuVScz+5UmrV0SQ2+ZTWGc2La0wDCt+ZZ99ZtOrJxYhjAj+VG6EAVa2/6Ukk3OXpya0aUw2kl4+icsRAxkF4RWB6p2MYMu1ad3hNXYkkf+0Foq0L0aqt7771ESJ11fIablwAaeQ9p+5ZzRcn77fO3A/E1cuPdA7+5fqhQyp0p/RwPoayu2gTKfQ+c5dFVX14uFGb9Yzy/ZziVkI5BdMkZzBEQBIa8BvlrrHKfiZ3Wj+++a5fWcu6NaQVil8Us668oDeJbwhSLT/Prww4b/vw5MWym7t7mTz0spQ7uyq/LXl4PLLX6uVaj2lOUTD7Hr/+I1Pd6R/Dv//0Y87orL1ANz65Spgk+enV4uw1PTL0quywfLJ3/THe+JBvfHotP9j4amVs43N07zQzVoaYpElvuvmkA3kMzIIANSSgindKMagZJJ3BQrzR5w2CY9SZ+HCdNCLEoOnlxfj2vXID3qkqPHZibREq5pcYMnvMr2iEN5mMxTaflBZNL+Fg+fmQhpvf7B/kTN7snz5MeuZWK13fuco42ofpYerGIqP25b6WfwbkL8B9fiwojTSuXfGq4uxNx/HviyRqF4Tl4zquZfjdcD1Wwgka04UsAP5SwlM/FuN0ve2MSw+dTZ4ArsGks885mq1OFKcZmuS/eBIGCJXS+bVbYfQJtAqZQqWZ4A7YxfSpNm4MN0BbJY7jdvcuhnRZeoi4lGa/m9p57o6oD4+6BufZvxkJy7eTy6JJpN+g/UET30aYvuE4FqylPYtSFw+HK3/5tj0pf5l3YqFWRn/TbqLIxWxMr729HJQzB2r1adnbr1EOe/zj30MZ6uJxdzNhtYP+gTmE+GnffDCGVb6XEJS+4T788PDq/wIAAP//fdJUmQ=="
+	return "eJzNXd2P3LYRf/dfQeQlCeA7p03RBz8EcOI+HJq2Rm0XLYpizZW4u8xJokxKu7f56zszJCXqa1ez5wYNAuNuVxx+zfzmN8MR7048qvNrIdtcN/kLIRrdFOq1eBN/z5XLrK4bbarX4sNBOSWkVaI5KLHTqsid2KtKWdmoXGzP9LmXJUqTt4W6Bxn+wdcv4Mc7UckS5LdOWfgV+jvX8Ovemram3+Oz+HN8WLY6pw/i47LQ0oVPatkcvLx76vi+e7bUexgXDbyxrRqIXClxlSy1Upja7VTW6KNaJ3bnVsrd6UK5s2tUuU7wWrlOHlW+TuR+nUTa5pWLulJkv6gM4W6lcL8CDMG7tZKTPWOIx383pawHPfS2M7LWH8KHQjzsxCernCmOaqNz90loJ5xqRGOoD6ErMtzMVDu9b333+EklPsUuP3XCTroo8NFGQjMp4LtaV3thdmhU3n5poI7Eg9jQs/+0E/MN7Ng9mba4+0FYY5pv78OXKQbMosDc4s4gwaC/uXWdw4OrshlSFUNsr8iMDlKMuNpDonOMLjg9eHthCN+vl+3NhLP4DOFjFOGsD6ObFE9Yu8zoY4IsVzvqZqIKXbVP68AFqIB4/6efsYHQuaqAN5wRA4gAZI2xl4258/99V8BDTsb28xx0J7PMtFUD2rgtdYNkY2csQAKCVKMzmtKoC2sKxekCh/S16yaVNI8Sc1MC5nFkfoirkQj2UgQMHwXcj/oo1FEVV7pQT7KskaW579Z3S4LHvcHCqb2x5+fOKcrBWWWmrKVtStgYd59yvtqaTDk3S/sGPbzzDwrZNFZv20a5+0VumJ2u+dvQ7T3M6BEc1SbXVuHw46TnbW8y5ay1FqYkghjRiRnM0ZnWZur6FN/Tc2AtEryw1fu9sqDTaDywTVWzPF+c02DCw70aDRv8PPyPYrGdkM6ZTBNXP2n8XbSVfoJBZ4+qGcwD5DS66s3q4mTe9g8LmecW9+7/dmZdpLMu9gAe4+RebfDZC6PrTfLsMlkUc4Pu+diHGCVF8SQzUKIgAU0JqJW0pbEbAFit8kCQerz+3KoqGw6rMNX+euexqajacqtsBG7aIVhLXE69r/qQ7lHZCtBDvB92KUJ7RzGhA2uAJtgc+FTVfP/7SCx9cyGrHJCiQmgtzFHZ8XSg0w7Fr6rAeELUWDy87ccO9FPCeux1dS/ewILS7BzQ0YL0pP+6kxSlEMU9gLOm0TsYnjjKolXDAYOet0WzSinajAANtnQn9axyfPBEGQR2XhQVBMZpahUY+TdBzisUMlIG2xZDRVgRFvRqiK29yaJovzhbBasjc5W/xG2kJ/B3eqK1QVncZTe/K+S+ZylXvNndSbxSTfaqhh08QVRQi5MUd48dvZh3R/10/IRwoC+j4gFJKGFS4NpJlT/RbLOm+DR2hAlurxgpDZNkbZVs/E/32LG7z1/F4foPXn+/btiF8SQm7j6t91xcRmCW0vOylJ0vm279QiyFnms027TVksuPmItcaQ7ivTYlQD8dQsoHdDL05WW/sHJ+7YKgBR46cBaRuBgYNrisYgQA+N/D23vx0HgMqEwjsoOs9l7dhd710/efU5Qr4bkDfBRY6v1kqk7BHuY3TNZjW2h8dYLnWqPrOA/m00GYDP7Sr9ZLUOdM1Q0R6RPG+t3MDvAkmcwn104sxWx/Ad6zXneGit7vRHOgrIENAgFu8HeYEfTb1mAIQflXKlPin2/UpDfpl3GFT6Tj8MNXNN6vcPTeTWOc9TIQjJcDQbiGd8GVfDvVhRvV/quvvpxWdbKipIM5cUKAyZ76b7dgDSApqiZ5UlAl8F+IwxD5io+uRf3Eze8UQT2lG+cDiBKpAuELCvCqAc9lLTnuRWo54ZPR91xxiT9rR26Xmky45EQL5zFVVyZnBZzUIDCocaSpjhxJ8LhGMhesfAcglKP9vOrlJOa74QbfE3VBIdzwelYIP6KeFbMmaL4qZYAgN8mYZsouCkAEEeZUAeIS7D68HQm0TDVARQ2q4D0FKKoF1lgrUOeC+gPPVxXnb0cd4b/c6UMQnKPN+Fl0HNXbqlU7sE0IFPLxGk0zWWvXiKx3ukjAkUuOxNNBZwdqhQAUh5vB85PBlkyDxuc95RUYCdPgZ1aaPX+ya0y2HzM3xLhcNnIdxCGRwKfFzpoy4WohAL3C4z2acwZeqQbG3sC+1RJdpMi1q43TM4m6EoCQhUdBx6ndPH4CERtTlMvpxZj9i3nGZJ86mXnOGqU/+ihNo0ZJkUizQP3Af1eYeAYdxH0Z9Zjp+sBDatIUMMrMnmvgpV4AJnYV8qqx5bAsMW+tJ2h+gQL7HYkE0LFaXYv4hkPuMhChcSR+vQY1cjsxJIQfTjew4EfsBuhFoTHLAb2EVerAa9yF07/yIFGdBbYhSn1FuKt5y+9wzCGVOQVBabMDVzdVsaN2AIVZgwF9Gqz3gvcZV3C/o9C6pTywD5KIwh3VKEfWwYD85TYYwHbzMNBWmgUD/twkT9t18UWig0wApKZziAJflhiEshY4tPGmnmKJbasKLRSUZG9liT5o1B98XEHAyDLPWpahksNhOtKaI/bRM/wxcaWsONNXdI0S6FqyG1Nz1XHMTbpwxMMxrFzItI13p2Edi8Djog0qyduWoTmsVVTKEQdTAyzzJ5h9IO9TuSajY4vx1BLasO7I58O/QPGexjaxk6UuWIuUsAJrGpOZKQiw4CYoy1/e/CRksTcWwrVyyd3VO57ig5rgioKunKTNMdgGLT0DYWoOZuJKATZY0oc4SUcYnoS6Qd5jjMbfcfqYBCjyd89r/vvnNf/+Wc0PxjVc9ozrGNtxyVdRG8tyHpjBLVDD8YRQJK27cI4rMbBHbDbv39QT278RbGA7mH4+KTRggWswPmQ9O1hEMmo90dmCy5uH6xio84Q93bQ5yyuZyVpudaF5mI8BzVPfVk/4cAUM6Bm4MORPIdkoZrhTpU53qkKewSKV0IywPaTtvQCsDEOjGJsvPrPZQjBXmP2m0CVP9XwXnmBBnBXkiM+tapVIiHZCJPgUAisQZngWbNCGl9yIRLurC0oURPQFeGmigOe/FfhlkoUtAzGhlDuGDkc9wQ7YqU3Nsk7c2ziNGkkjVc1cnIYp8lu0qAYDRbKxVpPMTCXfxQ78CQFoDR3WLCTLYPAsmUXuz3RHYrYSnAKPIocmfv+AZhIKwo+13E8AVkkMtXhBJZ2dh5ZBVXz0PWYhx/Iua544whFujmiPWM8JBE+4xs5sGDNW7fZrMV51ClWStcqx1Ih0mc5WXGI3E/mDw3lWB2/+8RN8kWmqBKCQSeWvfAHGDOrabrXYmXoAeGgdD77yHK0fk3MStmTGMsEj3mKVxI1D/iktjZj0AV+p4wYfMCwogwaymPeqmifqwoEILMBGVQCOmeYtONo5LoFvrNCbgpKDLY1dNS7xMavbW9a499g/vfsItmQnRMCivXJEd9WIIpQjJgKSAhQenbxYXjKsLhl7/zxnUotuSdAR+aOHXDVzgRmEkSyF8ywo6vQgCO0ss6ge75ioBY/74TaFrh5j2hr2N59oo2u3v7DYp6trauRh8SLYyn9/d/f9f7ggPmaKsxm2bHhMvyr7g+efJrTE0BQk78bK7V0U36nBUhzBcSLOzhs9ngTf5HjiETLs5GzmDZGbiacpjgbIvoSm0U0dWVbfVVRTGqCXkR5FdbtZSMe1f191Si0vD5+KeFnro93GN9o00j0Oi+M6S9cciXT21Y1SVwdl9VUOO2RGTKgKjT1cjTm+Y/kdX5l6poq5FHFDmmcc7Xzx1ABquFPck37E6qiDSdvenwNl1vkmANgtmj1s2s2fmfcL009scjzS+olr4A/v/tllHebZDDNs0XXWI9J80ALTpkQoR6zCUjMYaPRQVDr88Naf3I4R9AY/FeqxLjopzfStD+8o54wJcOC4ck80rK9RmNFdXrKWoMInFwZ8eg7aIEpijp4ipMVYhrILLGCLolZCGjJTbsgMzPSoTev8C3Zzga5xPPbYaXJfaj1Gd9YIacviJ0sHeJpZLjIwuYWCEdSuXLtHVtgGz19VrFraPmW/2kmkQU/0FF7SXP1IoVgna/D4vnurott5POhlbX2tqi53PHdK3DK1U4qPH6d2xKy2cSpDwhErDWfS2RQF7C0zbebpv1eepVzxhpcpJ11fmYRD9Sx5JU3otWVJZRmgQNAY86DAH//84zhuobTLLW67z7oEM8AMSU75rlEfN0XpOINVUTquDrA0bmUcysdmWF5sYxJmBYtH6P1SGR7kLMdLOZ4WnCHLLFusYoEJ7WS2kDbJSpZdxrBpWHA7knkwhoWd/ZkvtvTHcIG6ZLhIM2dvuMvsmk7cY9tWgzcoO8Xn+k9K61xKN4PSsORdyJINT1ZOEn5pNO+gc+F0BWWJRFbnqShDzYoMQpO5YBqNhIlYMXG2CrW4mOXvJomIm4LWTKYYE4s89oYZRSaDQyi7YT9zCYtSja5l8IE1ziaV2nEweuGJ3xVoy9du2Do9emLSMDTFyL5GMDsS3twQStLbI9LuVUMv8qRZnwshSylZodD14rKTbHhFd8NaXt9+IR1And/odUaymPwn8J6pnK0xPDgODjAyCGyv5Jgt6Kys03dr1ykARrwgGhvHAuYZpS1MxnRUJx8pUVl7OG9LhAwgo3daa52gLwuBdtplLeJEQsP7umXWGkv/WinVnSPXiAm+i5xGc/lwWodBVQkj/zE4o2ZBaXpGvRJKc7WTgH93N+BGaEpEcz4NRbEYF+w6oAvBGwlBZMK3FuaIDTh5/4rrbcqJFQJ41E4y4HnE7v0U72g3mBWrJ27hQyy1u2U7IuePqJ0q7fBd8EHgdeTxljQzlBbqXD55SCJvHrRPNoG5JN7YlvOaSWnIuoOW8JYOtmtloX9d9ZYOpbR4B0X8ShP2kWgkjd0NLfNHopaZSQ6YeZGm0Ek/d7xpMn1urPjm2jOwZvAibs/smdnkxDHN1ePiRj0jWTaIeSeeyAc+4T6BZ4Q78UaCmUMyXfFial1dC6mPLL8f7A4T/YeFs8g1dyQMZEKMXl9Mx1Hei3mq3h17Bz1YLMN4dmb+sk5gD8zMdJfrdgjrM6lupz4za1qG16OMN+w3cUGft+eGtw598tuJz63sbgpIBfVLwj0aHBekUFokec9u4KT5zrPz/iuZByIyt1wHAfl6qQ5qIDv5hEmBpeQTxQssb5qmGFZ61JxbZh2OUB/eLTANctHMd7wnHnp6h5o2WVMw05L+bhwwSdd0hS7xckmSt1Dv4pinyr5IfJl8xeuYWK4qtAkv5OjK51im5PY3sBlUpU0ped58nEnD9uMrGGIyY+6dFSaY+5zr4hkjoQu/imTtSStejsBjZCE7vEjIrJKO9zZcksKCLypDuT4ZBFH0t/j2XaEdO5M25lI+VY+SlisxuZmViAtrsivblqUvMc3k2m1kGXjqDcQipfZ4KxqWxs0hM8vuUmRmeCoukA5ChymI/o+yT61lWdbHvz+I2gBdpjdCzWJeyNP8G14sSFXTgW56Ea8gvqA3ahfLeG/LsAyVdGWWxdNVLpca+8mZUquaJ7GIp7SAETJ5jalD4Rvz7CuLVht2MWKfwA/lRihA7+avcmUS7vT05FqNKVgaK54j5fCBDN6gDb5q+E5HN2Tea910ib7Ogvy5G4iWXojW5f7mtz4oUtclXkB5+QUQ0PIbTt2jmk9O3q+duW+YqxfvZu38y+VDh1DozunhdKCyO28TIfTGq6mCqF1L93WCsx7vDmWUb+cU1xLKIZhmOYMuAspVoZqFcldfZT+SO6wfX7zXL61lXRrSDMVPillnbtAbxDcMqVaexq8MgI63dOXFtJubexlc9DKVO3pVfllyd8ck3l97qccwJy9YfIOPvxS6Pv6B/v3jy5jRmbuBrr9plzFJ9o27ydlruGToRdphelPz/B3M/v5mf/VavLDxxczY+uvo3uC92jnFJEW4060JOhDHgCeuSncJqOR6WgxqOkknEOLf6MPXH0zldcZfXJebjGLQcPOi/5MH2nV4p3d02Ym1SagYb2KI7DHeokF3MuHFmjvxSVdZ0QJcwHA3YbjxTzV0cgZXgg6vgD1JizdN3LjKONr74WHqwiKj9sW+pn/95Udwh/GyoDBSv3a6v1VxdKdj/2dlArUjYfG4DguSZNwMpGU55lhMTVkA/DJX27YvxqlbW2NtK110Nrj5ea9MOPsco9nsRHGa1CT+oRsaoNrpKl5yDFM74nuBmCnE64QkzOJs2lAb14cbqrJ451i33a3zIV2UThEXCPjZ7IHTugPqwwPertSIvwL0T6/MTosmkX7DSmySzPIqNzC+gDYGUmHPvNTJffHALL9sT3hR7sSn7aHdF+3Gi5zMBouP7Hmjndlwq0/T3n7ycsTD+79RGerkTn8zYLWd/imzofhp3XwwhtUN4AMpfQES8Zf7F/8FC4HsPA=="
 }
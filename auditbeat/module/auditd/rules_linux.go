@@ -0,0 +1,256 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auditd
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/go-libaudit/v2/rule"
+	"github.com/elastic/go-libaudit/v2/rule/flags"
+)
+
+// auditFilterPrepend is AUDIT_FILTER_PREPEND. Rules with this flag are added
+// at the start of their filter list instead of being appended.
+const auditFilterPrepend = 0x10
+
+// ruleClient is the part of the audit client used to manage rules.
+type ruleClient interface {
+	GetRules() ([][]byte, error)
+	DeleteRules() (int, error)
+	DeleteRule(rule []byte) error
+	AddRule(rule []byte) error
+}
+
+// ruleUpdater loads the configured rules into the kernel.
+type ruleUpdater struct {
+	client   ruleClient
+	reporter mb.PushReporterV2
+	log      *logp.Logger
+}
+
+// replace deletes all the rules loaded in the kernel and adds the configured
+// rules, after the rule ignoring this process.
+func (u *ruleUpdater) replace(selfRule *auditRule, rules []auditRule) error {
+	n, err := u.client.DeleteRules()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete existing rules")
+	}
+	u.log.Infof("Deleted %v pre-existing audit rules.", n)
+
+	if selfRule != nil {
+		rules = append([]auditRule{*selfRule}, rules...)
+	}
+	added := u.addRules(rules)
+	u.log.Infof("Successfully added %d of %d audit rules.", added, len(rules))
+	return nil
+}
+
+// diff updates the rules loaded in the kernel to match the configured rules.
+// The kernel evaluates rules in order and rules can only be appended, so the
+// loaded rules are kept up to the first one that differs from the
+// configuration, the following ones are replaced. The rule ignoring this
+// process is added at the start of the list and replaces those of previous
+// processes.
+func (u *ruleUpdater) diff(selfRule *auditRule, rules []auditRule) error {
+	existing, err := u.client.GetRules()
+	if err != nil {
+		return errors.Wrap(err, "failed to list existing rules")
+	}
+
+	var selfRules, loaded [][]byte
+	for _, data := range existing {
+		if isSelfIgnoreRule(data) {
+			selfRules = append(selfRules, data)
+		} else {
+			loaded = append(loaded, data)
+		}
+	}
+
+	kept := commonPrefix(loaded, rules)
+	if hasDuplicates(loaded, kept) {
+		// Rules are deleted by value, so a duplicate of a kept rule could be
+		// deleted instead of the intended one.
+		u.log.Info("Pre-existing audit rules contain duplicates, replacing all rules.")
+		return u.replace(selfRule, rules)
+	}
+
+	var deleted, added, failed int
+	if selfRule != nil {
+		// Keep the rule if it was added by this process already.
+		found := false
+		for i, data := range selfRules {
+			if ruleKey(data) == ruleKey(selfRule.data) {
+				selfRules = append(selfRules[:i], selfRules[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			prepended := *selfRule
+			prepended.data = prependRule(selfRule.data)
+			if u.addRules([]auditRule{prepended}) == 1 {
+				added++
+			} else {
+				failed++
+			}
+		}
+	}
+
+	for _, data := range append(selfRules, loaded[kept:]...) {
+		if err := u.client.DeleteRule(data); err != nil {
+			flags, _ := rule.ToCommandLine(data, false)
+			err = errors.Wrapf(err, "failed to delete audit rule '%v'", flags)
+			u.reportFailure(auditRule{flags: flags, data: data}, "deleted-audit-rule", err)
+			failed++
+			continue
+		}
+		deleted++
+	}
+
+	n := u.addRules(rules[kept:])
+	added += n
+	failed += len(rules) - kept - n
+
+	u.log.Infof("Kept %d, deleted %d and added %d audit rules, %d failed.", kept, deleted, added, failed)
+	return nil
+}
+
+// addRules adds rules to the kernel and returns the number of rules that were
+// added. Failures are reported and don't stop the following rules from being
+// added.
+func (u *ruleUpdater) addRules(rules []auditRule) int {
+	var added int
+	for _, r := range rules {
+		if err := u.client.AddRule(r.data); err != nil {
+			err = errors.Wrapf(err, "failed to add audit rule '%v'", r.flags)
+			u.reportFailure(r, "added-audit-rule", err)
+			continue
+		}
+		added++
+	}
+	return added
+}
+
+// reportFailure publishes an event for a rule that failed to load, so the
+// failure can be found along with the audit events.
+func (u *ruleUpdater) reportFailure(r auditRule, action string, err error) {
+	u.log.Warnw("Failure updating audit rule", "error", err)
+
+	ruleFields := common.MapStr{"flags": r.flags}
+	if r.source != "" {
+		ruleFields["source"] = r.source
+	}
+	u.reporter.Event(mb.Event{
+		RootFields: common.MapStr{
+			"event": common.MapStr{
+				"category": "audit-rule",
+				"action":   action,
+				"outcome":  "failure",
+			},
+			"error": common.MapStr{
+				"message": err.Error(),
+			},
+		},
+		ModuleFields: common.MapStr{
+			"rule": ruleFields,
+		},
+	})
+}
+
+// commonPrefix returns the number of leading loaded rules that match the
+// configured rules.
+func commonPrefix(loaded [][]byte, rules []auditRule) int {
+	n := 0
+	for n < len(loaded) && n < len(rules) && ruleKey(loaded[n]) == ruleKey(rules[n].data) {
+		n++
+	}
+	return n
+}
+
+// hasDuplicates returns true if any of the loaded rules that are not kept is
+// identical to a kept rule.
+func hasDuplicates(loaded [][]byte, kept int) bool {
+	keys := map[string]bool{}
+	for _, data := range loaded[:kept] {
+		keys[ruleKey(data)] = true
+	}
+	for _, data := range loaded[kept:] {
+		if keys[ruleKey(data)] {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleKey returns a normalized representation of a rule in wire format, used
+// to compare the configured rules with the rules returned by the kernel.
+func ruleKey(data []byte) string {
+	if cmd, err := rule.ToCommandLine(data, false); err == nil {
+		return cmd
+	}
+	return string(data)
+}
+
+// isSelfIgnoreRule returns true if data is a rule built by buildPIDIgnoreRule,
+// for this or any other process.
+func isSelfIgnoreRule(data []byte) bool {
+	cmd, err := rule.ToCommandLine(data, false)
+	if err != nil {
+		return false
+	}
+	r, err := flags.Parse(cmd)
+	if err != nil {
+		return false
+	}
+	syscallRule, ok := r.(*rule.SyscallRule)
+	if !ok || syscallRule.Action != "never" || len(syscallRule.Filters) != 1 {
+		return false
+	}
+	filter := syscallRule.Filters[0]
+	if filter.LHS != "pid" || filter.Comparator != "=" {
+		return false
+	}
+	pid, err := strconv.Atoi(filter.RHS)
+	if err != nil {
+		return false
+	}
+	selfRule, err := buildPIDIgnoreRule(pid)
+	return err == nil && ruleKey(selfRule.data) == cmd
+}
+
+// prependRule returns a copy of a rule in wire format that the kernel adds at
+// the start of its filter list. The rule flags are the first field of the
+// rule, in host byte order, and hold the filter list, which is never zero. The
+// flag is set in the byte holding it, to not depend on the byte order.
+func prependRule(data []byte) []byte {
+	out := append([]byte(nil), data...)
+	if len(out) < 4 {
+		return out
+	}
+	if out[0] != 0 {
+		out[0] |= auditFilterPrepend
+	} else {
+		out[3] |= auditFilterPrepend
+	}
+	return out
+}
@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auditd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/go-libaudit/v2/rule"
+)
+
+// fakeRuleClient keeps the rules in memory like the kernel does.
+type fakeRuleClient struct {
+	rules     [][]byte
+	ops       []string
+	failAdd   string // Command line of a rule that fails to be added.
+	deleteAll int
+}
+
+func (c *fakeRuleClient) GetRules() ([][]byte, error) {
+	return append([][]byte(nil), c.rules...), nil
+}
+
+func (c *fakeRuleClient) DeleteRules() (int, error) {
+	n := len(c.rules)
+	c.rules = nil
+	c.deleteAll++
+	return n, nil
+}
+
+func (c *fakeRuleClient) DeleteRule(data []byte) error {
+	for i, r := range c.rules {
+		if bytes.Equal(r, data) {
+			c.rules = append(c.rules[:i], c.rules[i+1:]...)
+			c.ops = append(c.ops, "delete "+ruleKey(data))
+			return nil
+		}
+	}
+	return errors.New("no such rule")
+}
+
+func (c *fakeRuleClient) AddRule(data []byte) error {
+	data = append([]byte(nil), data...)
+	prepend := data[0]&auditFilterPrepend != 0
+	data[0] &^= auditFilterPrepend
+	if ruleKey(data) == c.failAdd {
+		return errors.New("invalid argument")
+	}
+	if prepend {
+		c.rules = append([][]byte{data}, c.rules...)
+		c.ops = append(c.ops, "prepend "+ruleKey(data))
+	} else {
+		c.rules = append(c.rules, data)
+		c.ops = append(c.ops, "add "+ruleKey(data))
+	}
+	return nil
+}
+
+type fakeReporter struct {
+	events []mb.Event
+}
+
+func (r *fakeReporter) Done() <-chan struct{} { return nil }
+func (r *fakeReporter) Event(e mb.Event) bool { r.events = append(r.events, e); return true }
+func (r *fakeReporter) Error(err error) bool  { return true }
+
+func mustReadRules(t *testing.T, lines ...string) []auditRule {
+	t.Helper()
+	rules, err := readRules(strings.NewReader(strings.Join(lines, "\n")), "test", ruleSet{})
+	require.NoError(t, err)
+	return rules
+}
+
+func mustSelfRule(t *testing.T, pid int) *auditRule {
+	t.Helper()
+	r, err := buildPIDIgnoreRule(pid)
+	require.NoError(t, err)
+	return &r
+}
+
+func newTestRuleUpdater(client ruleClient, reporter mb.PushReporterV2) *ruleUpdater {
+	return &ruleUpdater{client: client, reporter: reporter, log: logp.NewLogger(moduleName)}
+}
+
+func loadedRules(c *fakeRuleClient) []string {
+	var keys []string
+	for _, data := range c.rules {
+		keys = append(keys, ruleKey(data))
+	}
+	return keys
+}
+
+func TestRuleUpdateDiff(t *testing.T) {
+	old := mustReadRules(t,
+		"-w /etc/passwd -p wa -k auth",
+		"-w /etc/shadow -p wa -k auth",
+		"-a always,exit -S mount -k mount",
+	)
+	configured := mustReadRules(t,
+		"-w /etc/passwd -p wa -k auth",
+		"-w /etc/shadow -p wa -k auth",
+		"-a always,exit -S execve -k exec",
+	)
+	oldSelf, self := mustSelfRule(t, 100), mustSelfRule(t, 200)
+
+	client := &fakeRuleClient{}
+	for _, r := range append([]auditRule{*oldSelf}, old...) {
+		client.rules = append(client.rules, r.data)
+	}
+
+	var reporter fakeReporter
+	require.NoError(t, newTestRuleUpdater(client, &reporter).diff(self, configured))
+
+	assert.Zero(t, client.deleteAll)
+	assert.Empty(t, reporter.events)
+	assert.Equal(t, []string{
+		"prepend " + ruleKey(self.data),
+		"delete " + ruleKey(oldSelf.data),
+		"delete " + ruleKey(old[2].data),
+		"add " + ruleKey(configured[2].data),
+	}, client.ops)
+	assert.Equal(t, []string{
+		ruleKey(self.data),
+		ruleKey(configured[0].data),
+		ruleKey(configured[1].data),
+		ruleKey(configured[2].data),
+	}, loadedRules(client))
+
+	t.Run("unchanged rules are kept", func(t *testing.T) {
+		client.ops = nil
+		require.NoError(t, newTestRuleUpdater(client, &reporter).diff(self, configured))
+		assert.Empty(t, client.ops)
+		assert.Zero(t, client.deleteAll)
+	})
+}
+
+func TestRuleUpdateDiffWithDuplicates(t *testing.T) {
+	rules := mustReadRules(t,
+		"-w /etc/passwd -p wa -k auth",
+		"-a always,exit -S execve -k exec",
+	)
+
+	// The duplicate of the kept rule can't be deleted by value.
+	client := &fakeRuleClient{rules: [][]byte{rules[0].data, rules[0].data}}
+	require.NoError(t, newTestRuleUpdater(client, &fakeReporter{}).diff(nil, rules))
+
+	assert.Equal(t, 1, client.deleteAll)
+	assert.Equal(t, []string{ruleKey(rules[0].data), ruleKey(rules[1].data)}, loadedRules(client))
+}
+
+func TestRuleUpdateReplace(t *testing.T) {
+	rules := mustReadRules(t, "-w /etc/passwd -p wa -k auth")
+	self := mustSelfRule(t, 200)
+
+	client := &fakeRuleClient{rules: [][]byte{rules[0].data}}
+	require.NoError(t, newTestRuleUpdater(client, &fakeReporter{}).replace(self, rules))
+
+	assert.Equal(t, 1, client.deleteAll)
+	assert.Equal(t, []string{ruleKey(self.data), ruleKey(rules[0].data)}, loadedRules(client))
+}
+
+func TestRuleUpdateReportsFailures(t *testing.T) {
+	rules := mustReadRules(t,
+		"-w /etc/passwd -p wa -k auth",
+		"-a always,exit -S execve -k exec",
+	)
+
+	client := &fakeRuleClient{failAdd: ruleKey(rules[0].data)}
+	var reporter fakeReporter
+	require.NoError(t, newTestRuleUpdater(client, &reporter).diff(nil, rules))
+
+	assert.Equal(t, []string{ruleKey(rules[1].data)}, loadedRules(client))
+	require.Len(t, reporter.events, 1)
+	event := reporter.events[0]
+	assert.Equal(t, common.MapStr{
+		"flags":  "-w /etc/passwd -p wa -k auth",
+		"source": "test:1",
+	}, event.ModuleFields["rule"])
+	action, _ := event.RootFields.GetValue("event.action")
+	assert.Equal(t, "added-audit-rule", action)
+	outcome, _ := event.RootFields.GetValue("event.outcome")
+	assert.Equal(t, "failure", outcome)
+	msg, _ := event.RootFields.GetValue("error.message")
+	assert.Contains(t, msg, "invalid argument")
+}
+
+func TestIsSelfIgnoreRule(t *testing.T) {
+	assert.True(t, isSelfIgnoreRule(mustSelfRule(t, 1234).data))
+
+	for _, flags := range []string{
+		"-a never,exit -S all -F uid=1234",
+		"-a always,exit -S all -F pid=1234",
+		"-w /etc/passwd -p wa -k auth",
+	} {
+		rules := mustReadRules(t, flags)
+		assert.False(t, isSelfIgnoreRule(rules[0].data), flags)
+	}
+}
+
+func TestPrependRule(t *testing.T) {
+	data := mustSelfRule(t, 1234).data
+	prepended := prependRule(data)
+
+	assert.NotEqual(t, data, prepended)
+	flags := prepended[0] | prepended[3]
+	assert.NotZero(t, flags&auditFilterPrepend)
+
+	// The rule is otherwise unchanged.
+	prepended[0] &^= auditFilterPrepend
+	prepended[3] &^= auditFilterPrepend
+	assert.Equal(t, []byte(data), prepended)
+	_, err := rule.ToCommandLine(data, false)
+	assert.NoError(t, err)
+}
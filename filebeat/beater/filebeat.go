@@ -53,6 +53,7 @@ import (
 	// Add filebeat level processors
 	_ "github.com/elastic/beats/v7/filebeat/processor/add_kubernetes_metadata"
 	_ "github.com/elastic/beats/v7/libbeat/processors/decode_csv_fields"
+	_ "github.com/elastic/beats/v7/libbeat/processors/log_templates"
 
 	// include all filebeat specific builders
 	_ "github.com/elastic/beats/v7/filebeat/autodiscover/builder/hints"
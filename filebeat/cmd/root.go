@@ -40,6 +40,9 @@ func Filebeat(inputs beater.PluginFactory) *cmd.BeatsRootCmd {
 	var runFlags = pflag.NewFlagSet(Name, pflag.ExitOnError)
 	runFlags.AddGoFlag(flag.CommandLine.Lookup("once"))
 	runFlags.AddGoFlag(flag.CommandLine.Lookup("modules"))
+	runFlags.AddGoFlag(flag.CommandLine.Lookup("backfill.paths"))
+	runFlags.AddGoFlag(flag.CommandLine.Lookup("backfill.offset"))
+	runFlags.AddGoFlag(flag.CommandLine.Lookup("backfill.modified_since"))
 	settings := instance.Settings{
 		RunFlags:      runFlags,
 		Name:          Name,
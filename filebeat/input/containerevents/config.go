@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package containerevents
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	runtimeDocker     = "docker"
+	runtimeContainerd = "containerd"
+)
+
+// config stores the options of a container_events input.
+type config struct {
+	// Runtime is the container runtime to collect the events of.
+	Runtime string `config:"runtime"`
+
+	// Host is the address of the Docker daemon, or of the containerd socket.
+	Host string `config:"host"`
+
+	// CtrPath is the path of the containerd `ctr` command.
+	CtrPath string `config:"ctr_path"`
+
+	// Types filters the events by object type, for example container or image.
+	Types []string `config:"types"`
+
+	// Actions filters the events by action, for example start, die or oom.
+	Actions []string `config:"actions"`
+
+	// Backoff is the interval to wait before reconnecting after an error.
+	Backoff time.Duration `config:"backoff" validate:"min=0,nonzero"`
+
+	// MaxBackoff is the limit of the backoff time.
+	MaxBackoff time.Duration `config:"max_backoff" validate:"min=0,nonzero"`
+}
+
+var defaultHosts = map[string]string{
+	runtimeDocker:     "unix:///var/run/docker.sock",
+	runtimeContainerd: "/run/containerd/containerd.sock",
+}
+
+func defaultConfig() config {
+	return config{
+		Runtime:    runtimeDocker,
+		CtrPath:    "ctr",
+		Backoff:    1 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	c.Runtime = strings.ToLower(c.Runtime)
+	host, ok := defaultHosts[c.Runtime]
+	if !ok {
+		return fmt.Errorf("unknown runtime '%v', expecting %v or %v", c.Runtime, runtimeDocker, runtimeContainerd)
+	}
+	if c.Host == "" {
+		c.Host = host
+	}
+	if c.MaxBackoff < c.Backoff {
+		return fmt.Errorf("max_backoff must be greater than or equal to backoff")
+	}
+	return nil
+}
+
+// matches returns true if the event passes the types and actions filters.
+func (c *config) matches(typ, action string) bool {
+	return matchesAny(c.Types, typ) && matchesAny(c.Actions, action)
+}
+
+func matchesAny(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if strings.EqualFold(f, value) {
+			return true
+		}
+	}
+	return false
+}
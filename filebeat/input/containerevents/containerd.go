@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package containerevents
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// ctrTimeLayout is the format of the timestamps printed by `ctr events`.
+const ctrTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// containerdTypes maps the first element of containerd topics to the object
+// types used for filtering. Task events describe the processes of containers.
+var containerdTypes = map[string]string{
+	"containers": "container",
+	"tasks":      "container",
+	"images":     "image",
+	"snapshot":   "snapshot",
+	"content":    "content",
+	"namespaces": "namespace",
+}
+
+// startCtr starts `ctr events` and returns its output. It can be replaced in
+// tests.
+var startCtr = func(ctx context.Context, config config) (io.ReadCloser, func() error, error) {
+	cmd := exec.CommandContext(ctx, config.CtrPath, "--address", config.Host, "events")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	return stdout, cmd.Wait, nil
+}
+
+func testContainerd(config config) error {
+	out, err := exec.Command(config.CtrPath, "--address", config.Host, "version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to connect to containerd: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// runContainerd publishes the events printed by `ctr events` until the
+// command exits or the input is stopped. containerd does not support reading
+// past events, so no checkpoint is kept.
+func (inp *containerEvents) runContainerd(ctx input.Context, publisher cursor.Publisher) error {
+	cmdCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Cancelation.Done():
+			cancel()
+		case <-cmdCtx.Done():
+		}
+	}()
+
+	out, wait, err := startCtr(cmdCtx, inp.config)
+	if err != nil {
+		return fmt.Errorf("failed to run ctr: %w", err)
+	}
+
+	scanner := bufio.NewScanner(out)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		event, typ, action, err := parseCtrEvent(scanner.Text())
+		if err != nil {
+			ctx.Logger.Warnf("Failed to parse containerd event: %v", err)
+			continue
+		}
+		if !inp.matches(typ, action) {
+			continue
+		}
+		if err := publisher.Publish(event, nil); err != nil {
+			cancel()
+			wait()
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		cancel()
+		wait()
+		return err
+	}
+	if err := wait(); err != nil {
+		return fmt.Errorf("ctr exited: %w", err)
+	}
+	return fmt.Errorf("ctr exited")
+}
+
+// parseCtrEvent parses a line printed by `ctr events`:
+// "<timestamp> <namespace> <topic> <json data>". It returns the event and
+// the object type and action used for filtering.
+func parseCtrEvent(line string) (beat.Event, string, string, error) {
+	fields := strings.SplitN(strings.TrimSpace(line), " ", 7)
+	if len(fields) < 6 {
+		return beat.Event{}, "", "", fmt.Errorf("unexpected format: %q", line)
+	}
+	ts, err := time.Parse(ctrTimeLayout, strings.Join(fields[:4], " "))
+	if err != nil {
+		return beat.Event{}, "", "", fmt.Errorf("invalid timestamp: %w", err)
+	}
+	namespace, topic := fields[4], fields[5]
+
+	parts := strings.Split(strings.Trim(topic, "/"), "/")
+	typ := containerdTypes[parts[0]]
+	if typ == "" {
+		typ = parts[0]
+	}
+	action := parts[len(parts)-1]
+
+	var data common.MapStr
+	if len(fields) == 7 {
+		if err := json.Unmarshal([]byte(fields[6]), &data); err != nil {
+			return beat.Event{}, "", "", fmt.Errorf("invalid event data: %w", err)
+		}
+	}
+
+	event := common.MapStr{
+		"message": fmt.Sprintf("%s %s", namespace, topic),
+		"event": common.MapStr{
+			"kind":     "event",
+			"action":   action,
+			"provider": runtimeContainerd,
+		},
+		"containerd": common.MapStr{
+			"event": common.MapStr{
+				"topic":     topic,
+				"namespace": namespace,
+			},
+		},
+	}
+	if len(data) > 0 {
+		event.Put("containerd.event.data", data)
+	}
+
+	switch parts[0] {
+	case "containers":
+		putString(event, "container.id", data, "id")
+		putString(event, "container.image.name", data, "image")
+		event.Put("container.runtime", runtimeContainerd)
+	case "tasks":
+		putString(event, "container.id", data, "container_id")
+		event.Put("container.runtime", runtimeContainerd)
+		if code, ok := data["exit_status"].(float64); ok {
+			event.Put("process.exit_code", int(code))
+		}
+	case "images":
+		putString(event, "container.image.name", data, "name")
+	}
+
+	return beat.Event{Timestamp: ts.UTC(), Fields: event}, typ, action, nil
+}
+
+func putString(event common.MapStr, key string, data common.MapStr, from string) {
+	if s, ok := data[from].(string); ok && s != "" {
+		event.Put(key, s)
+	}
+}
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package containerevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestParseCtrEvent(t *testing.T) {
+	cases := map[string]struct {
+		line   string
+		typ    string
+		action string
+		fields common.MapStr
+	}{
+		"container create": {
+			line:   `2021-03-04 10:20:30.123456789 +0000 UTC default /containers/create {"id":"abc","image":"docker.io/library/nginx:latest","runtime":{"name":"io.containerd.runc.v2"}}`,
+			typ:    "container",
+			action: "create",
+			fields: common.MapStr{
+				"container.id":         "abc",
+				"container.image.name": "docker.io/library/nginx:latest",
+				"container.runtime":    "containerd",
+			},
+		},
+		"task oom": {
+			line:   `2021-03-04 10:20:30.123456789 +0000 UTC k8s.io /tasks/oom {"container_id":"abc"}`,
+			typ:    "container",
+			action: "oom",
+			fields: common.MapStr{
+				"container.id":                       "abc",
+				"containerd.event.namespace":         "k8s.io",
+				"containerd.event.topic":             "/tasks/oom",
+				"containerd.event.data.container_id": "abc",
+			},
+		},
+		"task exit": {
+			line:   `2021-03-04 10:20:30.123456789 +0000 UTC default /tasks/exit {"container_id":"abc","id":"abc","pid":42,"exit_status":137}`,
+			typ:    "container",
+			action: "exit",
+			fields: common.MapStr{
+				"container.id":      "abc",
+				"process.exit_code": 137,
+			},
+		},
+		"image create": {
+			line:   `2021-03-04 10:20:30.123456789 +0000 UTC default /images/create {"name":"docker.io/library/redis:6"}`,
+			typ:    "image",
+			action: "create",
+			fields: common.MapStr{
+				"container.image.name": "docker.io/library/redis:6",
+				"event.provider":       "containerd",
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			event, typ, action, err := parseCtrEvent(c.line)
+			require.NoError(t, err)
+			assert.Equal(t, c.typ, typ)
+			assert.Equal(t, c.action, action)
+			assert.Equal(t, time.Date(2021, 3, 4, 10, 20, 30, 123456789, time.UTC), event.Timestamp)
+			for k, expected := range c.fields {
+				v, err := event.Fields.GetValue(k)
+				if assert.NoError(t, err, k) {
+					assert.Equal(t, expected, v, k)
+				}
+			}
+			action, _ = event.Fields.GetValue("event.action")
+			assert.Equal(t, c.action, action)
+		})
+	}
+}
+
+func TestParseCtrEventErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"not an event",
+		`2021-03-04 10:20:30 +0000 UTC default`,
+		`yesterday at noon +0000 UTC default /tasks/oom {}`,
+		`2021-03-04 10:20:30 +0000 UTC default /tasks/oom {invalid`,
+	} {
+		_, _, _, err := parseCtrEvent(line)
+		assert.Error(t, err, line)
+	}
+}
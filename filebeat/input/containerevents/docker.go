@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux darwin windows
+
+package containerevents
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/docker"
+)
+
+// eventsClient is the part of the Docker client used by the input.
+type eventsClient interface {
+	Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error)
+	Close() error
+}
+
+// newDockerClient can be replaced in tests.
+var newDockerClient = func(host string) (eventsClient, error) {
+	return docker.NewClient(host, nil, nil)
+}
+
+func testDocker(config config) error {
+	client, err := newDockerClient(config.Host)
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+// runDocker publishes the events of the Docker daemon until the stream fails
+// or the input is stopped.
+func (inp *containerEvents) runDocker(ctx input.Context, cp *checkpoint, publisher cursor.Publisher) error {
+	client, err := newDockerClient(inp.Host)
+	if err != nil {
+		return fmt.Errorf("failed to create docker client: %w", err)
+	}
+	defer client.Close()
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Cancelation.Done():
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	msgs, errs := client.Events(streamCtx, dockerEventsOptions(inp.config, cp.since(time.Now())))
+	for {
+		select {
+		case <-ctx.Cancelation.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case msg := <-msgs:
+			if !inp.matches(msg.Type, msg.Action) {
+				continue
+			}
+			cp.TimeNano = msg.TimeNano
+			if err := publisher.Publish(dockerEvent(msg), *cp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func dockerEventsOptions(config config, since time.Time) types.EventsOptions {
+	args := filters.NewArgs()
+	for _, t := range config.Types {
+		args.Add("type", t)
+	}
+	for _, a := range config.Actions {
+		args.Add("event", a)
+	}
+	return types.EventsOptions{
+		Since:   fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+		Filters: args,
+	}
+}
+
+// dockerEvent converts a message of the Docker events API to an event.
+func dockerEvent(msg events.Message) beat.Event {
+	fields := common.MapStr{
+		"message": fmt.Sprintf("%s %s %s", msg.Type, msg.Action, msg.Actor.ID),
+		"event": common.MapStr{
+			"kind":     "event",
+			"action":   msg.Action,
+			"provider": runtimeDocker,
+		},
+		"docker": common.MapStr{
+			"event": common.MapStr{
+				"type":  msg.Type,
+				"scope": msg.Scope,
+			},
+		},
+	}
+	attributes := common.MapStr{}
+	for k, v := range msg.Actor.Attributes {
+		attributes[k] = v
+	}
+	if len(attributes) > 0 {
+		fields.Put("docker.event.attributes", attributes)
+	}
+
+	switch msg.Type {
+	case events.ContainerEventType:
+		fields.Put("container.id", msg.Actor.ID)
+		fields.Put("container.runtime", runtimeDocker)
+		if name := msg.Actor.Attributes["name"]; name != "" {
+			fields.Put("container.name", name)
+		}
+		if image := msg.Actor.Attributes["image"]; image != "" {
+			fields.Put("container.image.name", image)
+		}
+		if code, err := strconv.Atoi(msg.Actor.Attributes["exitCode"]); err == nil {
+			fields.Put("process.exit_code", code)
+		}
+	case events.ImageEventType:
+		fields.Put("container.image.name", msg.Actor.ID)
+	}
+
+	return beat.Event{
+		Timestamp: time.Unix(0, msg.TimeNano).UTC(),
+		Fields:    fields,
+	}
+}
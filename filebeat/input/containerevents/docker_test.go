@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin || windows
+// +build linux darwin windows
+
+package containerevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestDockerEvent(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 10, 20, 30, 123456789, time.UTC)
+
+	t.Run("container die", func(t *testing.T) {
+		event := dockerEvent(events.Message{
+			Type:   events.ContainerEventType,
+			Action: "die",
+			Scope:  "local",
+			Actor: events.Actor{
+				ID: "abc",
+				Attributes: map[string]string{
+					"name":     "web",
+					"image":    "nginx:latest",
+					"exitCode": "137",
+				},
+			},
+			TimeNano: ts.UnixNano(),
+		})
+
+		assert.Equal(t, ts, event.Timestamp)
+		assert.Equal(t, common.MapStr{
+			"message": "container die abc",
+			"event": common.MapStr{
+				"kind":     "event",
+				"action":   "die",
+				"provider": "docker",
+			},
+			"container": common.MapStr{
+				"id":      "abc",
+				"name":    "web",
+				"runtime": "docker",
+				"image":   common.MapStr{"name": "nginx:latest"},
+			},
+			"process": common.MapStr{"exit_code": 137},
+			"docker": common.MapStr{
+				"event": common.MapStr{
+					"type":  "container",
+					"scope": "local",
+					"attributes": common.MapStr{
+						"name":     "web",
+						"image":    "nginx:latest",
+						"exitCode": "137",
+					},
+				},
+			},
+		}, event.Fields)
+	})
+
+	t.Run("image pull", func(t *testing.T) {
+		event := dockerEvent(events.Message{
+			Type:     events.ImageEventType,
+			Action:   "pull",
+			Actor:    events.Actor{ID: "redis:6"},
+			TimeNano: ts.UnixNano(),
+		})
+
+		name, _ := event.Fields.GetValue("container.image.name")
+		assert.Equal(t, "redis:6", name)
+		_, err := event.Fields.GetValue("container.id")
+		assert.Error(t, err)
+	})
+}
+
+func TestDockerEventsOptions(t *testing.T) {
+	config := defaultConfig()
+	config.Types = []string{"container", "image"}
+	config.Actions = []string{"oom"}
+
+	options := dockerEventsOptions(config, time.Unix(1614853230, 5))
+	assert.Equal(t, "1614853230.000000005", options.Since)
+	assert.Equal(t, []string{"container", "image"}, options.Filters.Get("type"))
+	assert.Equal(t, []string{"oom"}, options.Filters.Get("event"))
+}
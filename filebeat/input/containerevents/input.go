@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package containerevents
+
+import (
+	"time"
+
+	input "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+const pluginName = "container_events"
+
+// containerEvents collects the events of the container runtime, like
+// container lifecycle changes and image pulls, as structured events.
+type containerEvents struct {
+	config
+}
+
+// checkpoint is the time of the last published event, events are collected
+// again from it after a restart. Only the Docker events API supports it.
+type checkpoint struct {
+	Version  int
+	TimeNano int64
+}
+
+var cursorVersion = 1
+
+// Plugin creates a new container_events input plugin.
+func Plugin(log *logp.Logger, store cursor.StateStore) input.Plugin {
+	return input.Plugin{
+		Name:       pluginName,
+		Stability:  feature.Experimental,
+		Deprecated: false,
+		Info:       "container runtime events",
+		Doc:        "The container_events input collects the events of the Docker or containerd runtime",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       pluginName,
+			Configure:  configure,
+		},
+	}
+}
+
+type hostSource struct {
+	runtime, host string
+}
+
+func (s hostSource) Name() string { return s.runtime + "::" + s.host }
+
+func configure(cfg *common.Config) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, err
+	}
+	return []cursor.Source{hostSource{runtime: config.Runtime, host: config.Host}}, &containerEvents{config: config}, nil
+}
+
+func (inp *containerEvents) Name() string { return pluginName }
+
+func (inp *containerEvents) Test(src cursor.Source, ctx input.TestContext) error {
+	if inp.Runtime == runtimeContainerd {
+		return testContainerd(inp.config)
+	}
+	return testDocker(inp.config)
+}
+
+// Run collects the events until the input is stopped, reconnecting with a
+// backoff on errors.
+func (inp *containerEvents) Run(
+	ctx input.Context,
+	src cursor.Source,
+	cursor cursor.Cursor,
+	publisher cursor.Publisher,
+) error {
+	log := ctx.Logger.With("runtime", inp.Runtime, "host", inp.Host)
+	log.Info("Starting container events input")
+	defer log.Info("Container events input stopped")
+
+	cp := initCheckpoint(log, cursor)
+	reconnect := backoff.NewEqualJitterBackoff(ctx.Cancelation.Done(), inp.Backoff, inp.MaxBackoff)
+	for ctx.Cancelation.Err() == nil {
+		var err error
+		if inp.Runtime == runtimeContainerd {
+			err = inp.runContainerd(ctx, publisher)
+		} else {
+			err = inp.runDocker(ctx, &cp, publisher)
+		}
+		if ctx.Cancelation.Err() != nil {
+			break
+		}
+		log.Errorf("Failed to collect container events, reconnecting: %v", err)
+		reconnect.Wait()
+	}
+	return nil
+}
+
+func initCheckpoint(log *logp.Logger, c cursor.Cursor) checkpoint {
+	if c.IsNew() {
+		return checkpoint{Version: cursorVersion}
+	}
+
+	var cp checkpoint
+	if err := c.Unpack(&cp); err != nil || cp.Version != cursorVersion {
+		log.Errorf("Collecting events from now. Failed to read checkpoint from registry: %v", err)
+		return checkpoint{Version: cursorVersion}
+	}
+	return cp
+}
+
+// since returns the time to collect events from: the last published event,
+// or now if there is none.
+func (cp checkpoint) since(now time.Time) time.Time {
+	if cp.TimeNano == 0 {
+		return now
+	}
+	return time.Unix(0, cp.TimeNano)
+}
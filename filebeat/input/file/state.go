@@ -39,6 +39,7 @@ type State struct {
 	Meta           map[string]string `json:"meta" struct:"meta,omitempty"`
 	FileStateOS    file.StateOS      `json:"FileStateOS" struct:"FileStateOS"`
 	IdentifierName string            `json:"identifier_name" struct:"identifier_name"`
+	Backfill       string            `json:"backfill,omitempty" struct:"backfill,omitempty"` // ID of the last backfill of the file
 }
 
 // NewState creates a new file state
@@ -68,6 +69,12 @@ func (s *State) IsEqual(c *State) bool {
 	return s.Id == c.Id
 }
 
+// SourcePosition implements beat.SourcePositioner. The offset of the state
+// forwarded with an event is the offset right after the event.
+func (s State) SourcePosition() (string, int64) {
+	return s.Id, s.Offset
+}
+
 // String returns string representation of the struct
 func (s *State) String() string {
 	return fmt.Sprintf(
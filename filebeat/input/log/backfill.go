@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log
+
+import (
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/filebeat/input/file"
+)
+
+var (
+	backfillPaths = flag.String("backfill.paths", "",
+		"Comma separated glob patterns of files to read again from backfill.offset, ignoring the registry")
+	backfillOffset        = flag.Int64("backfill.offset", 0, "Byte offset to read the backfilled files from")
+	backfillModifiedSince = flag.String("backfill.modified_since", "",
+		"Only backfill files modified since the given RFC3339 timestamp")
+)
+
+// backfillConfig selects files to read again from a given offset on the first
+// scan of the input, overriding the offsets stored in the registry. Each file
+// is backfilled once per settings, the ID of the settings is stored in the
+// state of the backfilled files.
+type backfillConfig struct {
+	Paths  []string `config:"paths"`
+	Offset int64    `config:"offset" validate:"min=0"`
+	// ModifiedSince filters the files by modification time, it doesn't seek to
+	// a timestamp inside the files.
+	ModifiedSince backfillTime `config:"modified_since"`
+}
+
+// backfillTime is a timestamp in RFC3339 format.
+type backfillTime time.Time
+
+// Unpack parses the timestamp.
+func (t *backfillTime) Unpack(s string) error {
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid backfill timestamp '%v', expecting RFC3339 format: %v", s, err)
+	}
+	*t = backfillTime(ts)
+	return nil
+}
+
+// Validate checks the glob patterns are valid.
+func (c *backfillConfig) Validate() error {
+	for _, pattern := range c.Paths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid backfill path pattern '%v': %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// backfillFromFlags returns the backfill settings passed on the command line,
+// or nil if none were passed.
+func backfillFromFlags() (*backfillConfig, error) {
+	if *backfillPaths == "" {
+		return nil, nil
+	}
+
+	c := &backfillConfig{Offset: *backfillOffset}
+	for _, pattern := range strings.Split(*backfillPaths, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			c.Paths = append(c.Paths, pattern)
+		}
+	}
+	if *backfillModifiedSince != "" {
+		if err := c.ModifiedSince.Unpack(*backfillModifiedSince); err != nil {
+			return nil, err
+		}
+	}
+	if c.Offset < 0 {
+		return nil, fmt.Errorf("backfill offset must not be negative, got %d", c.Offset)
+	}
+	return c, c.Validate()
+}
+
+// enabled returns true if any file is to be backfilled.
+func (c *backfillConfig) enabled() bool {
+	return c != nil && len(c.Paths) > 0
+}
+
+// id identifies the backfill settings, so files are backfilled again only if
+// the settings change.
+func (c *backfillConfig) id() string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d\x00%d\x00%s", c.Offset, time.Time(c.ModifiedSince).UnixNano(), strings.Join(c.Paths, "\x00"))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// matches returns true if the file of the state must be backfilled, and
+// wasn't backfilled already with the same settings.
+func (c *backfillConfig) matches(state file.State) bool {
+	if !c.enabled() || state.Backfill == c.id() {
+		return false
+	}
+	if since := time.Time(c.ModifiedSince); !since.IsZero() && state.Fileinfo.ModTime().Before(since) {
+		return false
+	}
+	for _, pattern := range c.Paths {
+		if ok, _ := filepath.Match(pattern, state.Source); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// offsetFor returns the offset to read the file from, which is never past the
+// end of the file so it isn't considered truncated.
+func (c *backfillConfig) offsetFor(state file.State) int64 {
+	if size := state.Fileinfo.Size(); c.Offset > size {
+		return size
+	}
+	return c.Offset
+}
@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/filebeat/input/file"
+)
+
+func newBackfillState(t *testing.T, dir, name string, size int) file.State {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, size), 0644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return file.State{Source: path, Fileinfo: info}
+}
+
+func TestBackfillMatches(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	state := newBackfillState(t, dir, "app.log", 10)
+
+	var disabled *backfillConfig
+	assert.False(t, disabled.matches(state))
+
+	c := &backfillConfig{Paths: []string{filepath.Join(dir, "*.log")}}
+	assert.True(t, c.matches(state))
+
+	c.Paths = []string{filepath.Join(dir, "*.txt")}
+	assert.False(t, c.matches(state))
+
+	c.Paths = []string{filepath.Join(dir, "*.log")}
+	c.ModifiedSince = backfillTime(time.Now().Add(time.Hour))
+	assert.False(t, c.matches(state))
+
+	c.ModifiedSince = backfillTime(time.Now().Add(-time.Hour))
+	assert.True(t, c.matches(state))
+}
+
+func TestBackfillOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	state := newBackfillState(t, dir, "app.log", 10)
+
+	c := &backfillConfig{Paths: []string{filepath.Join(dir, "*.log")}}
+	assert.True(t, c.matches(state))
+
+	// The file isn't backfilled again with the same settings
+	state.Backfill = c.id()
+	assert.False(t, c.matches(state))
+
+	// but is backfilled again when the settings change
+	c.Offset = 4
+	assert.NotEqual(t, state.Backfill, c.id())
+	assert.True(t, c.matches(state))
+}
+
+func TestBackfillOffsetFor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backfill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	state := newBackfillState(t, dir, "app.log", 10)
+
+	c := &backfillConfig{Offset: 4}
+	assert.Equal(t, int64(4), c.offsetFor(state))
+
+	c.Offset = 20
+	assert.Equal(t, int64(10), c.offsetFor(state))
+}
+
+func TestBackfillTimeUnpack(t *testing.T) {
+	var ts backfillTime
+	assert.NoError(t, ts.Unpack("2020-06-01T10:00:00Z"))
+	assert.Equal(t, time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), time.Time(ts))
+
+	assert.Error(t, ts.Unpack("yesterday"))
+}
+
+func TestBackfillFromFlags(t *testing.T) {
+	defer func() {
+		*backfillPaths, *backfillOffset, *backfillModifiedSince = "", 0, ""
+	}()
+
+	c, err := backfillFromFlags()
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	*backfillPaths = "/var/log/a.log, /var/log/b*.log"
+	*backfillOffset = 100
+	*backfillModifiedSince = "2020-06-01T10:00:00Z"
+	c, err = backfillFromFlags()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/var/log/a.log", "/var/log/b*.log"}, c.Paths)
+	assert.Equal(t, int64(100), c.Offset)
+	assert.Equal(t, time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC), time.Time(c.ModifiedSince))
+
+	*backfillOffset = -1
+	_, err = backfillFromFlags()
+	assert.Error(t, err)
+
+	*backfillOffset = 0
+	*backfillPaths = "/var/log/[.log"
+	_, err = backfillFromFlags()
+	assert.Error(t, err)
+}
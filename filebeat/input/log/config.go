@@ -33,6 +33,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/reader/multiline"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
+	"github.com/elastic/beats/v7/libbeat/reader/readiis"
 	"github.com/elastic/beats/v7/libbeat/reader/readjson"
 )
 
@@ -56,6 +57,9 @@ type config struct {
 	TailFiles      bool                    `config:"tail_files"`
 	RecursiveGlob  bool                    `config:"recursive_glob.enabled"`
 	FileIdentity   *common.ConfigNamespace `config:"file_identity"`
+	Backfill       *backfillConfig         `config:"backfill"`
+	ID             string                  `config:"id"`
+	DroppedSummary droppedSummaryConfig    `config:"dropped_summary"`
 
 	// Harvester
 	BufferSize int    `config:"harvester_buffer_size"`
@@ -69,6 +73,7 @@ type config struct {
 	MaxBytes       int                     `config:"max_bytes" validate:"min=0,nonzero"`
 	Multiline      *multiline.Config       `config:"multiline"`
 	JSON           *readjson.Config        `config:"json"`
+	IIS            *readiis.Config         `config:"iis"`
 
 	// Hidden on purpose, used by the docker input:
 	DockerJSON *struct {
@@ -180,6 +185,10 @@ func (c *config) Validate() error {
 		return fmt.Errorf("When using the JSON decoder and line filtering together, you need to specify a message_key value")
 	}
 
+	if c.IIS != nil && (c.JSON != nil || c.Multiline != nil) {
+		return fmt.Errorf("The IIS reader can not be used together with the JSON decoder or multiline")
+	}
+
 	if c.ScanSort != "" {
 		cfgwarn.Experimental("scan_sort is used.")
 
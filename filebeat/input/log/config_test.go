@@ -26,6 +26,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/elastic/beats/v7/filebeat/harvester"
+	"github.com/elastic/beats/v7/libbeat/reader/multiline"
+	"github.com/elastic/beats/v7/libbeat/reader/readiis"
 )
 
 func TestCleanOlderError(t *testing.T) {
@@ -70,3 +72,18 @@ func TestCleanOlderIgnoreOlder(t *testing.T) {
 	err := config.Validate()
 	assert.NoError(t, err)
 }
+
+func TestIISMultilineError(t *testing.T) {
+	iis := readiis.DefaultConfig()
+	config := config{
+		Paths: []string{"hello"},
+		ForwarderConfig: harvester.ForwarderConfig{
+			Type: "log",
+		},
+		IIS:       &iis,
+		Multiline: &multiline.Config{},
+	}
+
+	err := config.Validate()
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+)
+
+var inputsMetrics = monitoring.GetNamespace("dataset").GetRegistry()
+
+// droppedSummaryConfig configures the summary events of the lines dropped or
+// truncated by the input. Summaries are disabled if the period is 0.
+type droppedSummaryConfig struct {
+	Period time.Duration `config:"period" validate:"min=0"`
+}
+
+// droppedLines counts the lines of an input that were dropped by the
+// include_lines and exclude_lines filters, truncated to max_bytes, or that
+// could not be decoded as JSON. The counters are shared by the harvesters of
+// the input and exposed in the dataset monitoring namespace under the ID of the
+// input.
+type droppedLines struct {
+	id       string
+	registry *monitoring.Registry
+
+	includeLines *monitoring.Int
+	excludeLines *monitoring.Int
+	truncated    *monitoring.Int
+	jsonErrors   *monitoring.Int
+
+	// values of the counters at the last summary
+	last       droppedCounts
+	lastReport time.Time
+}
+
+type droppedCounts struct {
+	includeLines, excludeLines, truncated, jsonErrors int64
+}
+
+func (c droppedCounts) sub(o droppedCounts) droppedCounts {
+	return droppedCounts{
+		includeLines: c.includeLines - o.includeLines,
+		excludeLines: c.excludeLines - o.excludeLines,
+		truncated:    c.truncated - o.truncated,
+		jsonErrors:   c.jsonErrors - o.jsonErrors,
+	}
+}
+
+// newDroppedLines registers the counters of the input with the given ID, a
+// random ID is generated if none is configured.
+func newDroppedLines(id string) (*droppedLines, error) {
+	if id == "" {
+		uid, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		id = uid.String()
+	}
+
+	var r *monitoring.Registry
+	if inputsMetrics.Get(id) == nil {
+		r = inputsMetrics.NewRegistry(id)
+	} else {
+		// The counters of an input with a duplicate ID are not exposed, but
+		// still reported in the summary events
+		logp.Warn("Lines dropped by log input %v are not monitored, an input with this ID exists already", id)
+		r = monitoring.NewRegistry()
+	}
+	monitoring.NewString(r, "input").Set("log")
+
+	return &droppedLines{
+		id:           id,
+		registry:     r,
+		includeLines: monitoring.NewInt(r, "lines.dropped.include_lines"),
+		excludeLines: monitoring.NewInt(r, "lines.dropped.exclude_lines"),
+		truncated:    monitoring.NewInt(r, "lines.truncated"),
+		jsonErrors:   monitoring.NewInt(r, "lines.json_errors"),
+		lastReport:   time.Now(),
+	}, nil
+}
+
+// unregister removes the counters from the monitoring namespace.
+func (d *droppedLines) unregister() {
+	if inputsMetrics.Get(d.id) == d.registry {
+		inputsMetrics.Remove(d.id)
+	}
+}
+
+func (d *droppedLines) counts() droppedCounts {
+	return droppedCounts{
+		includeLines: d.includeLines.Get(),
+		excludeLines: d.excludeLines.Get(),
+		truncated:    d.truncated.Get(),
+		jsonErrors:   d.jsonErrors.Get(),
+	}
+}
+
+// summary returns an event summarizing the lines dropped since the last
+// summary, if the period passed and lines were dropped.
+func (d *droppedLines) summary(now time.Time, period time.Duration) (beat.Event, bool) {
+	elapsed := now.Sub(d.lastReport)
+	if period <= 0 || elapsed < period {
+		return beat.Event{}, false
+	}
+
+	counts := d.counts()
+	delta := counts.sub(d.last)
+	d.last, d.lastReport = counts, now
+	if delta == (droppedCounts{}) {
+		return beat.Event{}, false
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		count  int64
+		format string
+	}{
+		{delta.includeLines, "dropped %d lines not matching include_lines"},
+		{delta.excludeLines, "dropped %d lines matching exclude_lines"},
+		{delta.truncated, "truncated %d lines"},
+		{delta.jsonErrors, "failed to decode %d lines as JSON"},
+	} {
+		if part.count > 0 {
+			parts = append(parts, fmt.Sprintf(part.format, part.count))
+		}
+	}
+	message := fmt.Sprintf("Log input %v %v in the last %v", d.id, strings.Join(parts, ", "), elapsed.Round(time.Second))
+
+	return beat.Event{
+		Timestamp: now,
+		Fields: common.MapStr{
+			"message": message,
+			"event": common.MapStr{
+				"kind":     "metric",
+				"duration": elapsed.Nanoseconds(),
+			},
+			"input": common.MapStr{
+				"id": d.id,
+			},
+			"log": common.MapStr{
+				"dropped": common.MapStr{
+					"include_lines": delta.includeLines,
+					"exclude_lines": delta.excludeLines,
+					"truncated":     delta.truncated,
+					"json_errors":   delta.jsonErrors,
+				},
+			},
+		},
+	}, true
+}
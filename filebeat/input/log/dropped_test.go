@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/reader"
+)
+
+func TestDroppedLinesSummary(t *testing.T) {
+	d, err := newDroppedLines("dropped-summary-test")
+	require.NoError(t, err)
+	defer d.unregister()
+	assert.Equal(t, d.registry, inputsMetrics.Get("dropped-summary-test"))
+
+	h := &Harvester{
+		config: config{
+			IncludeLines: []match.Matcher{match.MustCompile(`^app`)},
+			ExcludeLines: []match.Matcher{match.MustCompile(`DEBUG`)},
+		},
+		dropped: d,
+	}
+	assert.False(t, h.shouldExportLine("sys INFO started"))
+	assert.False(t, h.shouldExportLine("app DEBUG tick"))
+	assert.False(t, h.shouldExportLine("app DEBUG tock"))
+	assert.True(t, h.shouldExportLine("app INFO started"))
+	d.truncated.Inc()
+
+	start := d.lastReport
+	_, ok := d.summary(start.Add(30*time.Second), time.Minute)
+	assert.False(t, ok, "no summary before the period passed")
+
+	event, ok := d.summary(start.Add(time.Minute), time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, "Log input dropped-summary-test dropped 1 lines not matching include_lines, "+
+		"dropped 2 lines matching exclude_lines, truncated 1 lines in the last 1m0s", event.Fields["message"])
+	dropped, err := event.Fields.GetValue("log.dropped")
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"include_lines": int64(1),
+		"exclude_lines": int64(2),
+		"truncated":     int64(1),
+		"json_errors":   int64(0),
+	}, dropped)
+	id, _ := event.Fields.GetValue("input.id")
+	assert.Equal(t, "dropped-summary-test", id)
+
+	_, ok = d.summary(start.Add(2*time.Minute), time.Minute)
+	assert.False(t, ok, "no summary without dropped lines")
+
+	d.jsonErrors.Inc()
+	event, ok = d.summary(start.Add(3*time.Minute), time.Minute)
+	require.True(t, ok)
+	jsonErrors, _ := event.Fields.GetValue("log.dropped.json_errors")
+	assert.Equal(t, int64(1), jsonErrors)
+	assert.Equal(t, int64(2), d.jsonErrors.Get()+d.includeLines.Get(), "counters are not reset")
+}
+
+func TestDroppedLinesDuplicateID(t *testing.T) {
+	d1, err := newDroppedLines("dropped-duplicate-test")
+	require.NoError(t, err)
+	d2, err := newDroppedLines("dropped-duplicate-test")
+	require.NoError(t, err)
+
+	d2.unregister()
+	assert.Equal(t, d1.registry, inputsMetrics.Get("dropped-duplicate-test"))
+	d1.unregister()
+	assert.Nil(t, inputsMetrics.Get("dropped-duplicate-test"))
+}
+
+func TestIsTruncated(t *testing.T) {
+	var message reader.Message
+	assert.False(t, isTruncated(message))
+
+	message.AddFlagsWithKey("log.flags", "multiline")
+	assert.False(t, isTruncated(message))
+
+	message.AddFlagsWithKey("log.flags", "truncated")
+	assert.True(t, isTruncated(message))
+}
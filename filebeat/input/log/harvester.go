@@ -53,6 +53,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/reader/multiline"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile/encoding"
+	"github.com/elastic/beats/v7/libbeat/reader/readiis"
 	"github.com/elastic/beats/v7/libbeat/reader/readjson"
 )
 
@@ -102,6 +103,7 @@ type Harvester struct {
 	publishState  func(file.State) bool
 
 	metrics *harvesterProgressMetrics
+	dropped *droppedLines
 
 	onTerminate func()
 }
@@ -410,6 +412,10 @@ func (h *Harvester) onMessage(
 		h.states.Update(state)
 	}
 
+	if h.dropped != nil && isTruncated(message) {
+		h.dropped.truncated.Inc()
+	}
+
 	text := string(message.Content)
 	if message.IsEmpty() || !h.shouldExportLine(text) {
 		// No data or event is filtered out -> send empty event with state update
@@ -489,6 +495,9 @@ func (h *Harvester) shouldExportLine(line string) bool {
 		if !harvester.MatchAny(h.config.IncludeLines, line) {
 			// drop line
 			logp.Debug("harvester", "Drop line as it does not match any of the include patterns %s", line)
+			if h.dropped != nil {
+				h.dropped.includeLines.Inc()
+			}
 			return false
 		}
 	}
@@ -496,6 +505,9 @@ func (h *Harvester) shouldExportLine(line string) bool {
 		if harvester.MatchAny(h.config.ExcludeLines, line) {
 			// drop line
 			logp.Debug("harvester", "Drop line as it does match one of the exclude patterns%s", line)
+			if h.dropped != nil {
+				h.dropped.excludeLines.Inc()
+			}
 			return false
 		}
 	}
@@ -503,6 +515,22 @@ func (h *Harvester) shouldExportLine(line string) bool {
 	return true
 }
 
+// isTruncated returns true if the message was truncated to max_bytes or
+// multiline.max_lines.
+func isTruncated(message reader.Message) bool {
+	value, err := message.Fields.GetValue("log.flags")
+	if err != nil {
+		return false
+	}
+	flags, _ := value.([]string)
+	for _, flag := range flags {
+		if flag == "truncated" {
+			return true
+		}
+	}
+	return false
+}
+
 // openFile opens a file and checks for the encoding. In case the encoding cannot be detected
 // or the file cannot be opened because for example of failing read permissions, an error
 // is returned and the harvester is closed. The file will be picked up again the next time
@@ -585,6 +613,24 @@ func (h *Harvester) initFileOffset(file *os.File) (int64, error) {
 	return file.Seek(0, os.SEEK_CUR)
 }
 
+// iisFields returns the fields declared by the last W3C #Fields directive
+// before the offset the harvester resumes from, as the header of the file is
+// not read again.
+func (h *Harvester) iisFields() ([]string, error) {
+	if h.config.IIS.Format != readiis.FormatW3C || h.state.Offset <= 0 {
+		return nil, nil
+	}
+	f, ok := h.source.(File)
+	if !ok {
+		return nil, nil
+	}
+	fields, err := readiis.ScanFields(io.NewSectionReader(f.File, 0, h.state.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the IIS fields of %s: %v", h.state.Source, err)
+	}
+	return fields, nil
+}
+
 // getState returns an updated copy of the harvester state
 func (h *Harvester) getState() file.State {
 	if !h.source.HasState() {
@@ -629,12 +675,12 @@ func (h *Harvester) cleanup() {
 //
 // It creates a chain of readers which looks as following:
 //
-//   limit -> (multiline -> timeout) -> strip_newline -> json -> encode -> line -> log_file
+//   limit -> (multiline -> timeout) -> iis -> strip_newline -> json -> encode -> line -> log_file
 //
 // Each reader on the left, contains the reader on the right and calls `Next()` to fetch more data.
 // At the base of all readers the the log_file reader. That means in the data is flowing in the opposite direction:
 //
-//   log_file -> line -> encode -> json -> strip_newline -> (timeout -> multiline) -> limit
+//   log_file -> line -> encode -> json -> strip_newline -> iis -> (timeout -> multiline) -> limit
 //
 // log_file implements io.Reader interface and encode reader is an adapter for io.Reader to
 // reader.Reader also handling file encodings. All other readers implement reader.Reader
@@ -679,11 +725,23 @@ func (h *Harvester) newLogFileReader() (reader.Reader, error) {
 	}
 
 	if h.config.JSON != nil {
-		r = readjson.NewJSONReader(r, h.config.JSON)
+		jsonReader := readjson.NewJSONReader(r, h.config.JSON)
+		if h.dropped != nil {
+			jsonReader.OnDecodeError(h.dropped.jsonErrors.Inc)
+		}
+		r = jsonReader
 	}
 
 	r = readfile.NewStripNewline(r, h.config.LineTerminator)
 
+	if h.config.IIS != nil {
+		fields, err := h.iisFields()
+		if err != nil {
+			return nil, err
+		}
+		r = readiis.New(r, h.config.IIS, fields)
+	}
+
 	if h.config.Multiline != nil {
 		r, err = multiline.New(r, "\n", h.config.MaxBytes, h.config.Multiline)
 		if err != nil {
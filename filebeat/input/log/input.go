@@ -71,6 +71,7 @@ type Input struct {
 	meta                map[string]string
 	stopOnce            sync.Once
 	fileStateIdentifier file.StateIdentifier
+	dropped             *droppedLines
 }
 
 // NewInput instantiates a new Log
@@ -102,6 +103,15 @@ func NewInput(
 		return nil, fmt.Errorf("each input must have at least one path defined")
 	}
 
+	// Backfill settings passed on the command line override the configured ones
+	backfill, err := backfillFromFlags()
+	if err != nil {
+		return nil, err
+	}
+	if backfill != nil {
+		inputConfig.Backfill = backfill
+	}
+
 	identifier, err := file.NewStateIdentifier(inputConfig.FileIdentity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize file identity generator: %+v", err)
@@ -130,6 +140,15 @@ func NewInput(
 		meta = nil
 	}
 
+	dropped, err := newDroppedLines(inputConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanupIfNeeded(func() error {
+		dropped.unregister()
+		return nil
+	})
+
 	p := &Input{
 		config:              inputConfig,
 		cfg:                 cfg,
@@ -140,6 +159,7 @@ func NewInput(
 		done:                context.Done,
 		meta:                meta,
 		fileStateIdentifier: identifier,
+		dropped:             dropped,
 	}
 
 	// Create empty harvester to check if configs are fine
@@ -217,8 +237,18 @@ func (p *Input) Run() {
 			p.config.TailFiles = false
 		}()
 	}
+	// Backfill is only done on startup
+	if p.config.Backfill.enabled() {
+		defer func() {
+			p.config.Backfill = nil
+		}()
+	}
 	p.scan()
 
+	if p.config.DroppedSummary.Period > 0 {
+		p.reportDropped()
+	}
+
 	// It is important that a first scan is run before cleanup to make sure all new states are read first
 	if p.config.CleanInactive > 0 || p.config.CleanRemoved {
 		beforeCount := p.states.Count()
@@ -497,6 +527,19 @@ func (p *Input) scan() {
 
 		// Load last state
 		isNewState := p.states.IsNew(newState)
+		var lastState file.State
+		if !isNewState {
+			lastState = p.states.FindPrevious(newState)
+			// Keep the last backfill of the file, so it is backfilled only once
+			newState.Backfill = lastState.Backfill
+		}
+
+		// Backfilled files are read from the configured offset, regardless of
+		// their state and of ignore_older
+		if p.config.Backfill.matches(newState) {
+			p.backfillFile(isNewState, newState, lastState)
+			continue
+		}
 
 		// Ignores all files which fall under ignore_older
 		if p.isIgnoreOlder(newState) {
@@ -519,12 +562,34 @@ func (p *Input) scan() {
 				logp.Err(harvesterErrMsg, newState.Source, err)
 			}
 		} else {
-			lastState := p.states.FindPrevious(newState)
 			p.harvestExistingFile(newState, lastState)
 		}
 	}
 }
 
+// backfillFile starts harvesting a file from the backfill offset, overriding
+// the offset of its previous state. The ID of the backfill is stored in the
+// state of the file, so it isn't backfilled again on the next start.
+func (p *Input) backfillFile(isNewState bool, newState, lastState file.State) {
+	if !isNewState && !lastState.Finished {
+		// A harvester is still running for the file
+		p.harvestExistingFile(newState, lastState)
+		return
+	}
+
+	offset := p.config.Backfill.offsetFor(newState)
+	newState.Backfill = p.config.Backfill.id()
+	logp.Info("Backfilling file %s from offset %d", newState.Source, offset)
+	err := p.startHarvester(newState, offset)
+	if err == errHarvesterLimit {
+		logp.Debug("input", harvesterErrMsg, newState.Source, err)
+		return
+	}
+	if err != nil {
+		logp.Err(harvesterErrMsg, newState.Source, err)
+	}
+}
+
 // harvestExistingFile continues harvesting a file with a known state if needed
 func (p *Input) harvestExistingFile(newState file.State, oldState file.State) {
 	logp.Debug("input", "Update existing file for harvesting: %s, offset: %v", newState.Source, oldState.Offset)
@@ -681,6 +746,7 @@ func (p *Input) createHarvester(state file.State, onTerminate func()) (*Harveste
 	)
 	if err == nil {
 		h.onTerminate = onTerminate
+		h.dropped = p.dropped
 	}
 	return h, err
 }
@@ -763,6 +829,19 @@ func (p *Input) doUpdate(state file.State) error {
 	return nil
 }
 
+// reportDropped publishes a summary of the lines dropped or truncated by the
+// harvesters once per dropped_summary.period, if there were any.
+func (p *Input) reportDropped() {
+	event, ok := p.dropped.summary(time.Now(), p.config.DroppedSummary.Period)
+	if !ok {
+		return
+	}
+	logp.Info("%v", event.Fields["message"])
+	if !p.outlet.OnEvent(event) {
+		logp.Info("input outlet closed")
+	}
+}
+
 // Wait waits for the all harvesters to complete and only then call stop
 func (p *Input) Wait() {
 	p.harvesters.WaitForCompletion()
@@ -782,6 +861,8 @@ func (p *Input) Stop() {
 
 		// stop all communication between harvesters and publisher pipeline
 		p.outlet.Close()
+
+		p.dropped.unregister()
 	})
 }
 
@@ -403,6 +403,7 @@ func mergeStates(st, other *file.State) {
 		st.Timestamp = other.Timestamp
 		st.TTL = other.TTL
 		st.FileStateOS = other.FileStateOS
+		st.Backfill = other.Backfill
 
 		metaOld, metaNew = st.Meta, other.Meta
 	} else {
@@ -25,9 +25,15 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/elastic/beats/v7/heartbeat/blackout"
 	"github.com/elastic/beats/v7/heartbeat/config"
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/heartbeat/notifier"
 	"github.com/elastic/beats/v7/heartbeat/scheduler"
+	"github.com/elastic/beats/v7/heartbeat/stateindex"
+	"github.com/elastic/beats/v7/heartbeat/statuspage"
+	"github.com/elastic/beats/v7/heartbeat/tracing"
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
@@ -46,6 +52,9 @@ type Heartbeat struct {
 	monitorReloader *cfgfile.Reloader
 	dynamicFactory  *monitors.RunnerFactory
 	autodiscover    *autodiscover.Autodiscover
+	// pipeline is the beat's publisher, notifying the blackout, state index,
+	// notifier, tracing and status page publishers if enabled.
+	pipeline beat.Pipeline
 }
 
 // New creates a new heartbeat.
@@ -81,6 +90,53 @@ func New(b *beat.Beat, rawConfig *common.Config) (beat.Beater, error) {
 func (bt *Heartbeat) Run(b *beat.Beat) error {
 	logp.Info("heartbeat is running! Hit CTRL-C to stop it.")
 
+	pipeline := status.NewPipeline(b.Publisher)
+	bt.pipeline = pipeline
+	if bt.config.Blackout.Enabled {
+		blackoutPublisher := blackout.New(bt.config.Blackout)
+		if bt.config.Blackout.Mode == config.BlackoutPause {
+			bt.scheduler.SetPause(blackoutPublisher.Blocked)
+		}
+		blackoutPublisher.Subscribe(pipeline)
+	}
+	if bt.config.State.Enabled {
+		statePublisher := stateindex.New(b.Publisher, bt.config.State)
+		if err := statePublisher.Start(); err != nil {
+			return errors.Wrap(err, "could not start state index publisher")
+		}
+		defer statePublisher.Stop()
+		pipeline.Subscribe(statePublisher)
+	}
+	if bt.config.Notifier.Enabled {
+		notifierPublisher, err := notifier.New(bt.config.Notifier, b.Info)
+		if err != nil {
+			return errors.Wrap(err, "could not create notifier")
+		}
+		notifierPublisher.Start()
+		defer notifierPublisher.Stop()
+		pipeline.Subscribe(notifierPublisher)
+	}
+	if bt.config.Tracing.Enabled {
+		tracingPublisher, err := tracing.New(bt.config.Tracing, b.Info)
+		if err != nil {
+			return errors.Wrap(err, "could not create tracing exporter")
+		}
+		tracingPublisher.Start()
+		defer tracingPublisher.Stop()
+		pipeline.OnEvent(tracingPublisher.Export)
+	}
+	if bt.config.StatusPage.Enabled {
+		statusPagePublisher, err := statuspage.New(bt.config.StatusPage)
+		if err != nil {
+			return errors.Wrap(err, "could not create status page")
+		}
+		if err := statusPagePublisher.Start(); err != nil {
+			return errors.Wrap(err, "could not start status page")
+		}
+		defer statusPagePublisher.Stop()
+		pipeline.Subscribe(statusPagePublisher)
+	}
+
 	err := bt.RunStaticMonitors(b)
 	if err != nil {
 		return err
@@ -91,7 +147,7 @@ func (bt *Heartbeat) Run(b *beat.Beat) error {
 	}
 
 	if bt.config.ConfigMonitors.Enabled() {
-		bt.monitorReloader = cfgfile.NewReloader(b.Publisher, bt.config.ConfigMonitors)
+		bt.monitorReloader = cfgfile.NewReloader(bt.pipeline, bt.config.ConfigMonitors)
 		defer bt.monitorReloader.Stop()
 
 		err := bt.RunReloadableMonitors(b)
@@ -126,7 +182,7 @@ func (bt *Heartbeat) RunStaticMonitors(b *beat.Beat) error {
 	factory := monitors.NewFactory(b.Info, bt.scheduler, true)
 
 	for _, cfg := range bt.config.Monitors {
-		created, err := factory.Create(b.Publisher, cfg)
+		created, err := factory.Create(bt.pipeline, cfg)
 		if err != nil {
 			return errors.Wrap(err, "could not create monitor")
 		}
@@ -137,9 +193,9 @@ func (bt *Heartbeat) RunStaticMonitors(b *beat.Beat) error {
 
 // RunCentralMgmtMonitors loads any central management configured configs.
 func (bt *Heartbeat) RunCentralMgmtMonitors(b *beat.Beat) {
-	monitors := cfgfile.NewRunnerList(management.DebugK, bt.dynamicFactory, b.Publisher)
+	monitors := cfgfile.NewRunnerList(management.DebugK, bt.dynamicFactory, bt.pipeline)
 	reload.Register.MustRegisterList(b.Info.Beat+".monitors", monitors)
-	inputs := cfgfile.NewRunnerList(management.DebugK, bt.dynamicFactory, b.Publisher)
+	inputs := cfgfile.NewRunnerList(management.DebugK, bt.dynamicFactory, bt.pipeline)
 	reload.Register.MustRegisterList("inputs", inputs)
 }
 
@@ -160,7 +216,7 @@ func (bt *Heartbeat) RunReloadableMonitors(b *beat.Beat) (err error) {
 func (bt *Heartbeat) makeAutodiscover(b *beat.Beat) (*autodiscover.Autodiscover, error) {
 	autodiscover, err := autodiscover.NewAutodiscover(
 		"heartbeat",
-		b.Publisher,
+		bt.pipeline,
 		bt.dynamicFactory,
 		autodiscover.QueryConfig(),
 		bt.config.Autodiscover,
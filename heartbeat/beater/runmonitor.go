@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/paths"
+)
+
+// FindMonitor returns the config of the monitor with the given ID, looking in
+// `heartbeat.monitors` and in the files of `heartbeat.config.monitors`.
+func FindMonitor(rawConfig *common.Config, id string) (*common.Config, error) {
+	parsedConfig := config.DefaultConfig
+	if err := rawConfig.Unpack(&parsedConfig); err != nil {
+		return nil, fmt.Errorf("Error reading config file: %v", err)
+	}
+
+	monitors := parsedConfig.Monitors
+	if parsedConfig.ConfigMonitors.Enabled() {
+		dynamic := cfgfile.DefaultDynamicConfig
+		if err := parsedConfig.ConfigMonitors.Unpack(&dynamic); err != nil {
+			return nil, err
+		}
+
+		path := dynamic.Path
+		if !filepath.IsAbs(path) {
+			path = paths.Resolve(paths.Config, path)
+		}
+		files, err := filepath.Glob(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			configs, err := cfgfile.LoadList(file)
+			if err != nil {
+				return nil, err
+			}
+			monitors = append(monitors, configs...)
+		}
+	}
+
+	for _, cfg := range monitors {
+		var monitor struct {
+			ID string `config:"id"`
+		}
+		if err := cfg.Unpack(&monitor); err != nil {
+			return nil, err
+		}
+		if monitor.ID == id {
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("no monitor with id '%s' is configured", id)
+}
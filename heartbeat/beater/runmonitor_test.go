@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package beater
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestFindMonitor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "heartbeat-monitors")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "dynamic.yml"), []byte(`
+- type: tcp
+  id: dynamic
+  hosts: ["localhost:9200"]
+`), 0644)
+	require.NoError(t, err)
+
+	rawConfig := common.MustNewConfigFrom(map[string]interface{}{
+		"monitors": []map[string]interface{}{
+			{"type": "http", "id": "static", "urls": []string{"http://localhost"}},
+		},
+		"config.monitors.path": filepath.Join(dir, "*.yml"),
+	})
+
+	for id, monitorType := range map[string]string{"static": "http", "dynamic": "tcp"} {
+		cfg, err := FindMonitor(rawConfig, id)
+		if assert.NoError(t, err, id) {
+			found, err := cfg.String("type", -1)
+			require.NoError(t, err)
+			assert.Equal(t, monitorType, found)
+		}
+	}
+
+	_, err = FindMonitor(rawConfig, "missing")
+	assert.Error(t, err)
+}
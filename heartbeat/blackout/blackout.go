@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package blackout
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// Publisher tracks the events published by the monitors that the output did
+// not acknowledge yet. The output is considered blocked
+// while events are pending and none were acknowledged for longer than the
+// threshold, for example because it is down. With the unknown mode the status
+// of the events published while the output is blocked is set to unknown.
+type Publisher struct {
+	config config.Blackout
+	log    *logp.Logger
+	now    func() time.Time
+
+	mtx     sync.Mutex
+	pending int
+	// progress is the time events were last acknowledged, or were published
+	// while none were pending
+	progress time.Time
+	blocked  bool
+}
+
+// New creates a Publisher, which must be subscribed to the monitors'
+// status.Pipeline.
+func New(config config.Blackout) *Publisher {
+	return &Publisher{
+		config: config,
+		log:    logp.NewLogger("blackout"),
+		now:    time.Now,
+	}
+}
+
+// Subscribe tracks the acknowledgements of the events published through the
+// pipeline. With the unknown mode the events are marked before the other
+// subscribers of the pipeline are notified of them.
+func (p *Publisher) Subscribe(pipeline *status.Pipeline) {
+	pipeline.OnACK(&tracker{publisher: p})
+	if p.config.Mode == config.BlackoutUnknown {
+		pipeline.OnEvent(p.mark)
+	}
+}
+
+// Blocked reports whether events are pending and none were acknowledged for
+// longer than the threshold.
+func (p *Publisher) Blocked() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	blocked := p.pending > 0 && p.now().Sub(p.progress) > p.config.Threshold
+	if blocked != p.blocked {
+		p.blocked = blocked
+		if blocked {
+			p.log.Warnf("No events were acknowledged by the output for %v, %d events are pending. Checks are %v until the output recovers.",
+				p.config.Threshold, p.pending, modeDescription(p.config.Mode))
+		} else {
+			p.log.Info("The output acknowledges events again, checks are resumed.")
+		}
+	}
+	return blocked
+}
+
+func modeDescription(mode string) string {
+	if mode == config.BlackoutUnknown {
+		return "reported as unknown"
+	}
+	return "paused"
+}
+
+func (p *Publisher) addEvent() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.pending == 0 {
+		p.progress = p.now()
+	}
+	p.pending++
+}
+
+func (p *Publisher) ackEvents(n int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.pending -= n
+	if p.pending < 0 {
+		p.pending = 0
+	}
+	p.progress = p.now()
+}
+
+// tracker counts the published and acknowledged events of a client. Events
+// dropped by processors are never acknowledged, they are not counted.
+type tracker struct {
+	publisher *Publisher
+}
+
+func (t *tracker) AddEvent(_ beat.Event, published bool) {
+	if published {
+		t.publisher.addEvent()
+	}
+}
+
+func (t *tracker) ACKEvents(n int) {
+	if n > 0 {
+		t.publisher.ackEvents(n)
+	}
+}
+
+func (t *tracker) Close() {}
+
+// mark sets the status of the events published while the output is blocked to
+// unknown.
+func (p *Publisher) mark(event *beat.Event) {
+	if has, _ := event.Fields.HasKey("monitor.status"); !has || !p.Blocked() {
+		return
+	}
+	event.Fields.Put("monitor.status", "unknown")
+	event.Fields.Put("monitor.status_detail", look.StatusOutputBlocked)
+}
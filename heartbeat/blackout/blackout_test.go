@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package blackout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+func newTestPublisher(mode string) (*Publisher, *status.Pipeline, *hbtest.MockPipeline, *time.Time) {
+	output := &hbtest.MockPipeline{}
+	pipeline := status.NewPipeline(output)
+	p := New(config.Blackout{Enabled: true, Threshold: time.Minute, Mode: mode})
+	p.Subscribe(pipeline)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+	return p, pipeline, output, &now
+}
+
+func monitorEvent(status string) beat.Event {
+	return beat.Event{Fields: common.MapStr{
+		"monitor": common.MapStr{"id": "test", "status": status},
+	}}
+}
+
+func TestBlocked(t *testing.T) {
+	p, pipeline, output, now := newTestPublisher(config.BlackoutPause)
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+
+	// Without pending events the output is never blocked
+	*now = now.Add(time.Hour)
+	assert.False(t, p.Blocked())
+
+	client.Publish(monitorEvent("up"))
+	*now = now.Add(30 * time.Second)
+	assert.False(t, p.Blocked())
+
+	// Acknowledging some events is progress
+	client.Publish(monitorEvent("up"))
+	output.Clients()[0].ACK(1)
+	*now = now.Add(45 * time.Second)
+	assert.False(t, p.Blocked())
+
+	*now = now.Add(30 * time.Second)
+	assert.True(t, p.Blocked())
+
+	output.Clients()[0].ACK(1)
+	assert.False(t, p.Blocked())
+}
+
+func TestUnknownMode(t *testing.T) {
+	_, pipeline, output, now := newTestPublisher(config.BlackoutUnknown)
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+
+	client.Publish(monitorEvent("up"))
+	*now = now.Add(2 * time.Minute)
+	client.Publish(monitorEvent("down"))
+	client.Publish(beat.Event{Fields: common.MapStr{"state": "other"}})
+
+	publishes := output.Clients()[0].Published()
+	require.Len(t, publishes, 3)
+	assert.Equal(t, common.MapStr{"id": "test", "status": "up"}, publishes[0].Fields["monitor"])
+	assert.Equal(t, common.MapStr{"id": "test", "status": "unknown", "status_detail": "output_blocked"}, publishes[1].Fields["monitor"])
+	assert.Equal(t, common.MapStr{"state": "other"}, publishes[2].Fields)
+}
+
+func TestConnectWithKeepsACKHandler(t *testing.T) {
+	p, pipeline, output, _ := newTestPublisher(config.BlackoutPause)
+
+	var acked int
+	client, err := pipeline.ConnectWith(beat.ClientConfig{ACKHandler: acker.RawCounting(func(n int) { acked += n })})
+	require.NoError(t, err)
+
+	client.Publish(monitorEvent("up"))
+	output.Clients()[0].ACK(1)
+	assert.Equal(t, 1, acked)
+	assert.Equal(t, 0, p.pending)
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/heartbeat/beater"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Exit codes of the run-monitor command.
+const (
+	runMonitorUp    = 0
+	runMonitorDown  = 1
+	runMonitorError = 2
+)
+
+func genRunMonitorCmd(settings instance.Settings) *cobra.Command {
+	runMonitorCmd := &cobra.Command{
+		Use:   "run-monitor",
+		Short: "Run a configured monitor once and print its events",
+		Long: `Runs the monitor with the given ID once and prints the events to stdout.
+The monitor's processors are not applied and no events are published.
+
+Exits with 0 if the monitor is up, 1 if it is down, and 2 on errors.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			id, _ := cmd.Flags().GetString("id")
+			asJSON, _ := cmd.Flags().GetBool("json")
+
+			if id == "" {
+				fmt.Fprintf(os.Stderr, "The --id flag is required\n")
+				os.Exit(runMonitorError)
+			}
+
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(runMonitorError)
+			}
+
+			rawConfig, err := b.BeatConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading config: %s\n", err)
+				os.Exit(runMonitorError)
+			}
+
+			cfg, err := beater.FindMonitor(rawConfig, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				os.Exit(runMonitorError)
+			}
+
+			events, err := monitors.RunOnce(cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error running monitor '%s': %s\n", id, err)
+				os.Exit(runMonitorError)
+			}
+
+			if err := printEvents(os.Stdout, events, asJSON); err != nil {
+				fmt.Fprintf(os.Stderr, "Error printing events: %s\n", err)
+				os.Exit(runMonitorError)
+			}
+			os.Exit(eventsStatus(events))
+		},
+	}
+
+	runMonitorCmd.Flags().String("id", "", "ID of the monitor to run")
+	runMonitorCmd.Flags().Bool("json", false, "Print each event as a JSON document on a single line")
+
+	return runMonitorCmd
+}
+
+// printEvents writes the events either as JSON documents, one per line, or as
+// sorted lists of flattened fields separated by empty lines.
+func printEvents(w io.Writer, events []beat.Event, asJSON bool) error {
+	for i, event := range events {
+		fields := event.Fields.Clone()
+		fields["@timestamp"] = common.Time(event.Timestamp)
+
+		if asJSON {
+			b, err := json.Marshal(fields)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s\n", b)
+			continue
+		}
+
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		flat := fields.Flatten()
+		keys := make([]string, 0, len(flat))
+		for k := range flat {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s: %v\n", k, flat[k])
+		}
+	}
+	return nil
+}
+
+// eventsStatus returns the exit code for the events, down if any event is down.
+func eventsStatus(events []beat.Event) int {
+	if len(events) == 0 {
+		return runMonitorError
+	}
+	for _, event := range events {
+		if status, _ := event.Fields.GetValue("monitor.status"); status == "down" {
+			return runMonitorDown
+		}
+	}
+	return runMonitorUp
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestPrintEvents(t *testing.T) {
+	ts := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := []beat.Event{
+		{Timestamp: ts, Fields: common.MapStr{"monitor": common.MapStr{"id": "foo", "status": "up"}}},
+		{Timestamp: ts, Fields: common.MapStr{"monitor": common.MapStr{"id": "foo", "status": "down"}, "error.message": "400 Bad Request"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printEvents(&buf, events, false))
+	assert.Equal(t, `@timestamp: 2020-06-01T12:00:00.000Z
+monitor.id: foo
+monitor.status: up
+
+@timestamp: 2020-06-01T12:00:00.000Z
+error.message: 400 Bad Request
+monitor.id: foo
+monitor.status: down
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, printEvents(&buf, events[:1], true))
+	assert.Equal(t, `{"@timestamp":"2020-06-01T12:00:00.000Z","monitor":{"id":"foo","status":"up"}}`+"\n", buf.String())
+}
+
+func TestEventsStatus(t *testing.T) {
+	up := beat.Event{Fields: common.MapStr{"monitor": common.MapStr{"status": "up"}}}
+	down := beat.Event{Fields: common.MapStr{"monitor": common.MapStr{"status": "down"}}}
+
+	assert.Equal(t, runMonitorUp, eventsStatus([]beat.Event{up, up}))
+	assert.Equal(t, runMonitorDown, eventsStatus([]beat.Event{up, down}))
+	assert.Equal(t, runMonitorError, eventsStatus(nil))
+}
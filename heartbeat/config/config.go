@@ -21,8 +21,12 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/elastic/beats/v7/libbeat/autodiscover"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
 
 // Config defines the structure of heartbeat.yml.
@@ -32,6 +36,11 @@ type Config struct {
 	ConfigMonitors *common.Config       `config:"config.monitors"`
 	Scheduler      Scheduler            `config:"scheduler"`
 	Autodiscover   *autodiscover.Config `config:"autodiscover"`
+	State          StateIndex           `config:"state"`
+	Blackout       Blackout             `config:"blackout"`
+	Notifier       Notifier             `config:"notifier"`
+	Tracing        Tracing              `config:"tracing"`
+	StatusPage     StatusPage           `config:"status_page"`
 }
 
 // Scheduler defines the syntax of a heartbeat.yml scheduler block.
@@ -40,5 +49,143 @@ type Scheduler struct {
 	Location string `config:"location"`
 }
 
+// StateIndex defines the syntax of a heartbeat.yml state block. When enabled,
+// the latest summary of every monitor is periodically written to Index.
+type StateIndex struct {
+	Enabled bool          `config:"enabled"`
+	Index   string        `config:"index" validate:"required"`
+	Period  time.Duration `config:"period" validate:"positive"`
+}
+
+// Blackout defines the syntax of a heartbeat.yml blackout block. When enabled,
+// checks are paused, or their results marked as unknown, while the events
+// published have not been acknowledged by the output for longer than Threshold.
+type Blackout struct {
+	Enabled   bool          `config:"enabled"`
+	Threshold time.Duration `config:"threshold" validate:"positive"`
+	Mode      string        `config:"mode"`
+}
+
+// Blackout modes.
+const (
+	BlackoutPause   = "pause"
+	BlackoutUnknown = "unknown"
+)
+
+// Validate checks the blackout mode is known.
+func (b *Blackout) Validate() error {
+	if b.Mode != BlackoutPause && b.Mode != BlackoutUnknown {
+		return fmt.Errorf("invalid blackout mode '%v', expecting '%v' or '%v'", b.Mode, BlackoutPause, BlackoutUnknown)
+	}
+	return nil
+}
+
+// Notifier defines the syntax of a heartbeat.yml notifier block. When enabled,
+// a digest of the monitors whose status changed is posted to the webhooks every
+// Period.
+type Notifier struct {
+	Enabled  bool          `config:"enabled"`
+	Period   time.Duration `config:"period" validate:"positive"`
+	Webhooks []Webhook     `config:"webhooks"`
+}
+
+// Webhook defines an endpoint the digests are posted to, either in one of the
+// built-in formats or rendered from a JSON template.
+type Webhook struct {
+	URL      string            `config:"url" validate:"required"`
+	Format   string            `config:"format"`
+	Template string            `config:"template"`
+	Headers  map[string]string `config:"headers"`
+	// RoutingKey is the integration key of PagerDuty events.
+	RoutingKey string            `config:"routing_key"`
+	TLS        *tlscommon.Config `config:"ssl"`
+	Timeout    time.Duration     `config:"timeout"`
+}
+
+// Webhook formats.
+const (
+	WebhookJSON      = "json"
+	WebhookSlack     = "slack"
+	WebhookTeams     = "teams"
+	WebhookPagerDuty = "pagerduty"
+)
+
+// Validate checks the webhook format is known.
+func (w *Webhook) Validate() error {
+	switch w.Format {
+	case "", WebhookJSON, WebhookSlack, WebhookTeams:
+	case WebhookPagerDuty:
+		if w.RoutingKey == "" {
+			return fmt.Errorf("routing_key is required by the %v format", WebhookPagerDuty)
+		}
+	default:
+		return fmt.Errorf("invalid webhook format '%v', expecting '%v', '%v', '%v' or '%v'",
+			w.Format, WebhookJSON, WebhookSlack, WebhookTeams, WebhookPagerDuty)
+	}
+	if w.Template != "" && w.Format != "" {
+		return fmt.Errorf("template can not be combined with format")
+	}
+	return nil
+}
+
+// Tracing defines the syntax of a heartbeat.yml tracing block. When enabled,
+// every check is exported as an OpenTelemetry trace to an OTLP/HTTP endpoint,
+// with a span per phase of the check. Spans are sent in batches of BatchSize
+// or every FlushInterval, and dropped if more than QueueSize are pending.
+type Tracing struct {
+	Enabled       bool              `config:"enabled"`
+	Endpoint      string            `config:"endpoint" validate:"required"`
+	Headers       map[string]string `config:"headers"`
+	TLS           *tlscommon.Config `config:"ssl"`
+	Timeout       time.Duration     `config:"timeout" validate:"positive"`
+	FlushInterval time.Duration     `config:"flush_interval" validate:"positive"`
+	BatchSize     int               `config:"batch_size" validate:"min=1"`
+	QueueSize     int               `config:"queue_size" validate:"min=1"`
+	// ServiceName is the service.name resource attribute of the spans.
+	ServiceName string `config:"service_name" validate:"required"`
+}
+
+// StatusPage defines the syntax of a heartbeat.yml status_page block. When
+// enabled, the latest status of the monitors is rendered every Period into a
+// JSON document and an HTML page, written to Path and uploaded to S3 if set.
+type StatusPage struct {
+	Enabled bool          `config:"enabled"`
+	Period  time.Duration `config:"period" validate:"positive"`
+	Title   string        `config:"title"`
+	// Path is the directory the files are written to, the status_page
+	// directory of the data path if neither Path nor S3 are set.
+	Path string `config:"path"`
+	// Monitors are the IDs of the monitors listed, in order. All monitors
+	// are listed if empty.
+	Monitors []string `config:"monitors"`
+	// S3 is the configuration of the upload to S3, which requires the
+	// uploader registered by x-pack.
+	S3 *common.Config `config:"s3"`
+}
+
 // DefaultConfig is the canonical instantiation of Config.
-var DefaultConfig = Config{}
+var DefaultConfig = Config{
+	State: StateIndex{
+		Index:  "heartbeat-state",
+		Period: 30 * time.Second,
+	},
+	Blackout: Blackout{
+		Threshold: time.Minute,
+		Mode:      BlackoutPause,
+	},
+	Notifier: Notifier{
+		Period: time.Minute,
+	},
+	Tracing: Tracing{
+		Endpoint:      "http://localhost:4318/v1/traces",
+		Timeout:       10 * time.Second,
+		FlushInterval: 5 * time.Second,
+		BatchSize:     512,
+		QueueSize:     2048,
+		ServiceName:   "heartbeat",
+	},
+	StatusPage: StatusPage{
+		Period: time.Minute,
+		Title:  "Status",
+	},
+}
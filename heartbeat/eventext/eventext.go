@@ -18,6 +18,8 @@
 package eventext
 
 import (
+	"time"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 )
@@ -52,3 +54,100 @@ func IsEventCancelled(event *beat.Event) bool {
 	v, err := event.Meta.GetValue(EventCancelledMetaKey)
 	return err == nil && v == true
 }
+
+// EventRetryAfterMetaKey is the path to the @metadata key holding the time the
+// monitored service asked to be checked again at.
+const EventRetryAfterMetaKey = "__hb_evt_retry_after__"
+
+// SetRetryAfter marks the event with the time the next check should not run
+// before.
+func SetRetryAfter(event *beat.Event, until time.Time) {
+	if event != nil {
+		if event.Meta == nil {
+			event.Meta = common.MapStr{}
+		}
+		event.Meta.Put(EventRetryAfterMetaKey, until)
+	}
+}
+
+// TakeRetryAfter returns and removes the marker left by SetRetryAfter.
+func TakeRetryAfter(event *beat.Event) (time.Time, bool) {
+	if event == nil || event.Meta == nil {
+		return time.Time{}, false
+	}
+	v, err := event.Meta.GetValue(EventRetryAfterMetaKey)
+	if err != nil {
+		return time.Time{}, false
+	}
+	event.Meta.Delete(EventRetryAfterMetaKey)
+	until, ok := v.(time.Time)
+	return until, ok
+}
+
+// EventDoneMetaKey is the path to the @metadata key holding the channel closed
+// when the monitor running the job producing the event is stopped.
+const EventDoneMetaKey = "__hb_evt_done__"
+
+// SetDone records the channel closed when the monitor is stopped, so jobs can
+// stop waiting.
+func SetDone(event *beat.Event, done <-chan struct{}) {
+	if event != nil {
+		if event.Meta == nil {
+			event.Meta = common.MapStr{}
+		}
+		event.Meta.Put(EventDoneMetaKey, done)
+	}
+}
+
+// Done returns the channel recorded by SetDone, or nil if there is none.
+func Done(event *beat.Event) <-chan struct{} {
+	if event == nil || event.Meta == nil {
+		return nil
+	}
+	v, err := event.Meta.GetValue(EventDoneMetaKey)
+	if err != nil {
+		return nil
+	}
+	done, _ := v.(<-chan struct{})
+	return done
+}
+
+// TakeDone removes the channel recorded by SetDone, before the event is
+// published.
+func TakeDone(event *beat.Event) {
+	if event == nil || event.Meta == nil {
+		return
+	}
+	event.Meta.Delete(EventDoneMetaKey)
+	if len(event.Meta) == 0 {
+		event.Meta = nil
+	}
+}
+
+// EventAttemptMetaKey is the path to the @metadata key holding the attempt a
+// retried job finished on.
+const EventAttemptMetaKey = "__hb_evt_attempt__"
+
+// SetAttempt records the attempt the job producing the event finished on.
+func SetAttempt(event *beat.Event, attempt int) {
+	if event != nil {
+		if event.Meta == nil {
+			event.Meta = common.MapStr{}
+		}
+		event.Meta.Put(EventAttemptMetaKey, attempt)
+	}
+}
+
+// TakeAttempt returns and removes the marker left by SetAttempt.
+func TakeAttempt(event *beat.Event) (int, bool) {
+	if event == nil || event.Meta == nil {
+		return 0, false
+	}
+	v, err := event.Meta.GetValue(EventAttemptMetaKey)
+	if err != nil {
+		return 0, false
+	}
+	event.Meta.Delete(EventAttemptMetaKey)
+	attempt, ok := v.(int)
+	return attempt, ok
+}
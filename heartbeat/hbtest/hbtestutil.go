@@ -28,6 +28,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
@@ -124,6 +125,7 @@ func TLSChecks(chainIndex, certIndex int, certificate *x509.Certificate) validat
 	}, time.Duration(1))
 
 	expected.Put("tls.rtt.handshake.us", isdef.IsDuration)
+	expected.Put("tls.server.ja3s", isdef.IsNonEmptyString)
 
 	return lookslike.MustCompile(expected)
 }
@@ -165,8 +167,10 @@ func BaseChecks(ip string, status string, typ string) validator.Validator {
 func SummaryChecks(up int, down int) validator.Validator {
 	return lookslike.MustCompile(map[string]interface{}{
 		"summary": map[string]interface{}{
-			"up":   uint16(up),
-			"down": uint16(down),
+			"up":       uint16(up),
+			"down":     uint16(down),
+			"up_ips":   isdef.Optional(isdef.IsAny),
+			"down_ips": isdef.Optional(isdef.IsAny),
 		},
 	})
 }
@@ -205,15 +209,26 @@ func URLChecks(t *testing.T, u *url.URL) validator.Validator {
 // ErrorChecks checks the standard heartbeat error hierarchy, which should
 // consist of a message (or a lookslike isdef that can match the message) and a type under the error key.
 // The message is checked only as a substring since exact string matches can be fragile due to platform differences.
+// The code of socket level errors is optional, as it depends on the platform.
 func ErrorChecks(msgSubstr string, errType string) validator.Validator {
 	return lookslike.MustCompile(map[string]interface{}{
 		"error": map[string]interface{}{
 			"message": isdef.IsStringContaining(msgSubstr),
 			"type":    errType,
+			"code":    isdef.Optional(isdef.IsNonEmptyString),
 		},
 	})
 }
 
+// ConnRefusedErrorCodeChecks checks the error code of refused connections. The
+// code is not checked on Windows, where socket errors use different numbers.
+func ConnRefusedErrorCodeChecks() validator.Validator {
+	if runtime.GOOS == "windows" {
+		return lookslike.MustCompile(map[string]interface{}{})
+	}
+	return lookslike.MustCompile(map[string]interface{}{"error.code": "ECONNREFUSED"})
+}
+
 func ExpiredCertChecks(cert *x509.Certificate) validator.Validator {
 	msg := x509.CertificateInvalidError{Cert: cert, Reason: x509.Expired}.Error()
 	return lookslike.Compose(
@@ -225,7 +240,11 @@ func ExpiredCertChecks(cert *x509.Certificate) validator.Validator {
 // RespondingTCPChecks creates a skima.Validator that represents the "tcp" field present
 // in all heartbeat events that use a Tcp connection as part of their DialChain
 func RespondingTCPChecks() validator.Validator {
-	return lookslike.MustCompile(map[string]interface{}{"tcp.rtt.connect.us": isdef.IsDuration})
+	return lookslike.MustCompile(map[string]interface{}{
+		"tcp.rtt.connect.us": isdef.IsDuration,
+		// only reported on Linux
+		"tcp.syn_retransmits": isdef.Optional(isdef.IsEqual(0)),
+	})
 }
 
 // CertToTempFile takes a certificate and returns an *os.File with a PEM encoded
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hbtest
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// MockPipeline is a beat.Pipeline recording the clients connected to it.
+type MockPipeline struct {
+	mtx     sync.Mutex
+	clients []*MockClient
+}
+
+// Connect implements beat.Pipeline.
+func (p *MockPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+// ConnectWith implements beat.Pipeline.
+func (p *MockPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	c := &MockClient{acker: cfg.ACKHandler}
+	p.clients = append(p.clients, c)
+	return c, nil
+}
+
+// Clients returns the clients connected so far.
+func (p *MockPipeline) Clients() []*MockClient {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]*MockClient(nil), p.clients...)
+}
+
+// MockClient is a beat.Client recording the events published. Events are
+// reported as published to the ACK handler of the client, if any, the tests
+// acknowledge them.
+type MockClient struct {
+	acker beat.ACKer
+
+	mtx       sync.Mutex
+	publishes []beat.Event
+}
+
+// Publish implements beat.Client.
+func (c *MockClient) Publish(e beat.Event) {
+	c.PublishAll([]beat.Event{e})
+}
+
+// PublishAll implements beat.Client.
+func (c *MockClient) PublishAll(evs []beat.Event) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.acker != nil {
+		for _, e := range evs {
+			c.acker.AddEvent(e, true)
+		}
+	}
+	c.publishes = append(c.publishes, evs...)
+}
+
+// Close implements beat.Client.
+func (c *MockClient) Close() error { return nil }
+
+// ACK acknowledges the n oldest events reported as published and not
+// acknowledged yet.
+func (c *MockClient) ACK(n int) {
+	c.acker.ACKEvents(n)
+}
+
+// Published returns the events published so far.
+func (c *MockClient) Published() []beat.Event {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]beat.Event(nil), c.publishes...)
+}
+
+// Take returns the events published since the last call.
+func (c *MockClient) Take() []beat.Event {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	evs := c.publishes
+	c.publishes = nil
+	return evs
+}
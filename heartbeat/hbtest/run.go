@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hbtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// PluginBuilder creates the jobs of a monitor, like the functions registered
+// with monitors.RegisterActive.
+type PluginBuilder func(name string, cfg *common.Config) ([]jobs.Job, int, error)
+
+// RunJobs creates the jobs of a monitor with the given settings and runs each
+// of them once, returning the events and errors of the jobs.
+func RunJobs(t *testing.T, create PluginBuilder, settings map[string]interface{}) ([]*beat.Event, []error) {
+	cfg, err := common.NewConfigFrom(settings)
+	require.NoError(t, err)
+
+	js, endpoints, err := create("test", cfg)
+	require.NoError(t, err)
+	require.Equal(t, len(js), endpoints)
+
+	var events []*beat.Event
+	var errs []error
+	for _, job := range js {
+		event := &beat.Event{Fields: common.MapStr{}}
+		_, err := job(event)
+		events = append(events, event)
+		errs = append(errs, err)
+	}
+	return events, errs
+}
+
+// RunJob runs the job of a monitor checking a single endpoint once.
+func RunJob(t *testing.T, create PluginBuilder, settings map[string]interface{}) (*beat.Event, error) {
+	events, errs := RunJobs(t, create, settings)
+	require.Len(t, events, 1)
+	return events[0], errs[0]
+}
@@ -53,6 +53,18 @@ func Timestamp(t time.Time) common.Time {
 	return common.Time(t)
 }
 
+// StatusThrottled is the status detail of checks failing because the service
+// throttles them.
+const StatusThrottled = "throttled"
+
+// StatusDegraded is the status detail of checks passing, but with failing
+// warn-level validations.
+const StatusDegraded = "degraded"
+
+// StatusOutputBlocked is the status detail of checks marked as unknown,
+// because the output did not acknowledge the published events for too long.
+const StatusOutputBlocked = "output_blocked"
+
 // Status creates a service status message from an error value.
 func Status(err error) string {
 	if err == nil {
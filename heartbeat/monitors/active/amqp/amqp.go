@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	goamqp "github.com/streadway/amqp"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("amqp", create)
+	monitors.RegisterActive("synthetics/amqp", create)
+}
+
+var debugf = logp.MakeDebug("amqp")
+
+var amqpProtocol = endpoint.Protocol{Scheme: "amqp", Port: 5672, TLSScheme: "amqps", TLSPort: 5671}
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	js = endpoint.Jobs(config.Hosts, amqpProtocol, config.TLS != nil, func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, u)
+	})
+	return js, len(js), nil
+}
+
+// watchdog closes the connection to the broker when a step of the check
+// takes longer than the timeout. The AMQP client has no deadline once the
+// handshake is done, closing the socket fails the pending operations.
+type watchdog struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	timer   *time.Timer
+	expired bool
+}
+
+// dial returns the dial function of the AMQP client, connecting through the
+// dialer of the check and keeping the connection for the watchdog. Like the
+// default dial function of the client, the deadline covers the handshake.
+func (w *watchdog) dial(dialer transport.Dialer, timeout time.Duration) func(network, addr string) (net.Conn, error) {
+	return func(network, addr string) (net.Conn, error) {
+		conn, err := dialer.Dial(network, addr)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetDeadline(time.Now().Add(timeout))
+		w.mu.Lock()
+		w.conn = conn
+		w.mu.Unlock()
+		return conn, nil
+	}
+}
+
+// reset gives the next step of the check the full timeout.
+func (w *watchdog) reset(timeout time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer == nil {
+		w.timer = time.AfterFunc(timeout, w.expire)
+		return
+	}
+	w.timer.Reset(timeout)
+}
+
+func (w *watchdog) expire() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expired = true
+	if w.conn != nil {
+		w.conn.Close()
+	}
+}
+
+func (w *watchdog) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+// err returns the reason of a failed step, a timeout if the watchdog closed
+// the connection.
+func (w *watchdog) err(step string, timeout time.Duration, err error) reason.Reason {
+	w.mu.Lock()
+	expired := w.expired
+	w.mu.Unlock()
+	if expired {
+		return reason.IOFailed(fmt.Errorf("%s timed out after %v", step, timeout))
+	}
+	return brokerError(err)
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"amqp": fields})
+
+		w := &watchdog{}
+		defer w.stop()
+
+		checkDialer, err := dialer.Build(event, config.TLS != nil)
+		if err != nil {
+			return reason.IOFailed(err)
+		}
+		amqpConfig := goamqp.Config{
+			SASL:  []goamqp.Authentication{&goamqp.PlainAuth{Username: config.Username, Password: config.Password}},
+			Vhost: config.Vhost,
+			Dial:  w.dial(checkDialer, config.Timeout),
+		}
+
+		// the dialer encrypts the connection, the client must not do it again
+		brokerURL := *u
+		brokerURL.Scheme = amqpProtocol.Scheme
+		start := time.Now()
+		conn, err := goamqp.DialConfig(brokerURL.String(), amqpConfig)
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return brokerError(err)
+		}
+		defer func() {
+			w.reset(config.Timeout)
+			conn.Close()
+		}()
+		fields.Put("rtt.connect", look.RTT(time.Since(start)))
+		for _, property := range []string{"product", "version"} {
+			if value, ok := conn.Properties[property].(string); ok {
+				fields.Put("server."+property, value)
+			}
+		}
+
+		w.reset(config.Timeout)
+		start = time.Now()
+		ch, err := conn.Channel()
+		if err != nil {
+			return w.err("channel open", config.Timeout, err)
+		}
+		fields.Put("rtt.channel", look.RTT(time.Since(start)))
+
+		if !config.Probe.Enabled {
+			return nil
+		}
+		return roundTrip(ch, w, config, fields)
+	})
+}
+
+// roundTrip declares a transient queue, publishes a unique message to it
+// and consumes it, the check fails if the message is not delivered in time.
+func roundTrip(ch *goamqp.Channel, w *watchdog, config *config, fields common.MapStr) error {
+	w.reset(config.Timeout)
+	start := time.Now()
+	// server named, exclusive and auto-deleted, the queue disappears with the
+	// connection
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return w.err("queue declaration", config.Timeout, err)
+	}
+	fields.Put("rtt.declare", look.RTT(time.Since(start)))
+
+	if err := ch.Confirm(false); err != nil {
+		return w.err("confirm mode selection", config.Timeout, err)
+	}
+	confirms := ch.NotifyPublish(make(chan goamqp.Confirmation, 1))
+	deliveries, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return w.err("consume", config.Timeout, err)
+	}
+
+	payload := uuid.Must(uuid.NewV4()).String()
+	w.reset(config.Timeout)
+	start = time.Now()
+	err = ch.Publish("", queue.Name, false, false, goamqp.Publishing{
+		ContentType: "text/plain",
+		Body:        []byte(payload),
+	})
+	if err != nil {
+		return w.err("publish", config.Timeout, err)
+	}
+	confirm, ok := <-confirms
+	if !ok {
+		return w.err("publish", config.Timeout, goamqp.ErrClosed)
+	}
+	if !confirm.Ack {
+		return reason.ValidateFailed(errors.New("message rejected by the broker"))
+	}
+	fields.Put("rtt.publish", look.RTT(time.Since(start)))
+
+	maxRTT := config.maxRTT()
+	w.reset(maxRTT + config.Timeout)
+	timer := time.NewTimer(maxRTT - time.Since(start))
+	defer timer.Stop()
+	for {
+		select {
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return w.err("consume", config.Timeout, goamqp.ErrClosed)
+			}
+			if string(delivery.Body) != payload {
+				continue
+			}
+			fields.Put("rtt.round_trip", look.RTT(time.Since(start)))
+			return nil
+		case <-timer.C:
+			return reason.ValidateFailed(fmt.Errorf("message not delivered within %v", maxRTT))
+		}
+	}
+}
+
+// brokerError returns the reason of a failed operation. Errors sent by the
+// broker, and refused authentications, fail the validation.
+func brokerError(err error) reason.Reason {
+	var amqpErr *goamqp.Error
+	if errors.As(err, &amqpErr) && (amqpErr.Server || amqpErr.Code == goamqp.AccessRefused) {
+		return reason.ValidateFailed(err)
+	}
+	return reason.IOFailed(err)
+}
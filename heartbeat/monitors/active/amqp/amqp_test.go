@@ -0,0 +1,351 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+)
+
+// mockBroker is an AMQP 0-9-1 broker implementing the methods used by the
+// check, delivering the messages published by the client to its consumer.
+type mockBroker struct {
+	listener net.Listener
+
+	refuse  bool // close the connection after start-ok, like a failed authentication
+	wedged  bool // never answer queue.declare
+	forward bool
+}
+
+func newMockBroker(t *testing.T) *mockBroker {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return startMockBroker(l)
+}
+
+// newTLSMockBroker returns a mock broker accepting TLS connections only.
+func newTLSMockBroker(t *testing.T) *mockBroker {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	// Borrow the self-signed certificate of the httptest package
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	return startMockBroker(tls.NewListener(l, srv.TLS))
+}
+
+func startMockBroker(l net.Listener) *mockBroker {
+	b := &mockBroker{listener: l, forward: true}
+	go b.serve()
+	return b
+}
+
+func (b *mockBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *mockBroker) Close() {
+	b.listener.Close()
+}
+
+func (b *mockBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+type frame struct {
+	typ     byte
+	channel uint16
+	payload []byte
+}
+
+func (f frame) method() (uint16, uint16) {
+	return binary.BigEndian.Uint16(f.payload), binary.BigEndian.Uint16(f.payload[2:])
+}
+
+func readFrame(r io.Reader) (frame, error) {
+	var header [7]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return frame{}, err
+	}
+	f := frame{typ: header[0], channel: binary.BigEndian.Uint16(header[1:])}
+	f.payload = make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return frame{}, err
+	}
+	f.payload = f.payload[:len(f.payload)-1]
+	return f, nil
+}
+
+func writeFrame(w io.Writer, typ byte, channel uint16, payload []byte) error {
+	var buf bytes.Buffer
+	buf.WriteByte(typ)
+	binary.Write(&buf, binary.BigEndian, channel)
+	binary.Write(&buf, binary.BigEndian, uint32(len(payload)))
+	buf.Write(payload)
+	buf.WriteByte(frameEnd)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// args encodes the arguments of a method, strings are encoded as short
+// strings.
+func args(values ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			buf.WriteByte(byte(len(v)))
+			buf.WriteString(v)
+		default:
+			binary.Write(&buf, binary.BigEndian, v)
+		}
+	}
+	return buf.Bytes()
+}
+
+// longString encodes a long string argument.
+func longString(s string) []byte {
+	return append(args(uint32(len(s))), s...)
+}
+
+// table encodes a field table of string values.
+func table(fields map[string]string) []byte {
+	var buf bytes.Buffer
+	for k, v := range fields {
+		buf.Write(args(k, byte('S')))
+		buf.Write(longString(v))
+	}
+	return append(args(uint32(buf.Len())), buf.Bytes()...)
+}
+
+func writeMethod(w io.Writer, channel, class, method uint16, arguments ...[]byte) error {
+	payload := args(class, method)
+	for _, a := range arguments {
+		payload = append(payload, a...)
+	}
+	return writeFrame(w, frameMethod, channel, payload)
+}
+
+func (b *mockBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	var protocol [8]byte
+	if _, err := io.ReadFull(r, protocol[:]); err != nil {
+		return
+	}
+	writeMethod(conn, 0, 10, 10, args(byte(0), byte(9)),
+		table(map[string]string{"product": "RabbitMQ", "version": "3.8.9"}),
+		longString("PLAIN AMQPLAIN"), longString("en_US"))
+	if _, err := readFrame(r); err != nil || b.refuse {
+		return
+	}
+	writeMethod(conn, 0, 10, 30, args(uint16(2047), uint32(131072), uint16(0)))
+
+	var consumerTag string
+	var published uint64
+	for {
+		f, err := readFrame(r)
+		if err != nil {
+			return
+		}
+		if f.typ == frameHeartbeat {
+			continue
+		}
+		class, method := f.method()
+		switch {
+		case class == 10 && method == 31: // connection.tune-ok
+		case class == 10 && method == 40: // connection.open
+			writeMethod(conn, 0, 10, 41, args(""))
+		case class == 10 && method == 50: // connection.close
+			writeMethod(conn, 0, 10, 51)
+			return
+		case class == 20 && method == 10: // channel.open
+			writeMethod(conn, f.channel, 20, 11, longString(""))
+		case class == 20 && method == 40: // channel.close
+			writeMethod(conn, f.channel, 20, 41)
+		case class == 85 && method == 10: // confirm.select
+			writeMethod(conn, f.channel, 85, 11)
+		case class == 50 && method == 10: // queue.declare
+			if !b.wedged {
+				writeMethod(conn, f.channel, 50, 11, args("amq.gen-probe", uint32(0), uint32(0)))
+			}
+		case class == 60 && method == 20: // basic.consume
+			// reserved short, queue, consumer tag
+			queueLen := int(f.payload[6])
+			tagLen := int(f.payload[7+queueLen])
+			consumerTag = string(f.payload[8+queueLen : 8+queueLen+tagLen])
+			writeMethod(conn, f.channel, 60, 21, args(consumerTag))
+		case class == 60 && method == 40: // basic.publish, followed by the content
+			header, err := readFrame(r)
+			if err != nil || header.typ != frameHeader {
+				return
+			}
+			size := binary.BigEndian.Uint64(header.payload[4:])
+			var body []byte
+			for uint64(len(body)) < size {
+				f, err := readFrame(r)
+				if err != nil || f.typ != frameBody {
+					return
+				}
+				body = append(body, f.payload...)
+			}
+			published++
+			writeMethod(conn, f.channel, 60, 80, args(published, byte(0)))
+			if b.forward {
+				writeMethod(conn, f.channel, 60, 60, args(consumerTag, published, byte(0), "", "amq.gen-probe"))
+				writeFrame(conn, frameHeader, f.channel, args(uint16(60), uint16(0), uint64(len(body)), uint16(0)))
+				writeFrame(conn, frameBody, f.channel, body)
+			}
+		}
+	}
+}
+
+func TestConnect(t *testing.T) {
+	broker := newMockBroker(t)
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": broker.Addr(), "timeout": "1s"})
+	require.NoError(t, err)
+
+	fields, err := event.Fields.GetValue("amqp")
+	require.NoError(t, err)
+	amqpFields := fields.(common.MapStr)
+	assert.Equal(t, common.MapStr{"product": "RabbitMQ", "version": "3.8.9"}, amqpFields["server"])
+	assert.Contains(t, amqpFields["rtt"], "connect")
+	assert.Contains(t, amqpFields["rtt"], "channel")
+	assert.NotContains(t, amqpFields["rtt"], "round_trip")
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "amqp://"+broker.Addr(), full)
+}
+
+func TestRoundTrip(t *testing.T) {
+	broker := newMockBroker(t)
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":         broker.Addr(),
+		"probe.enabled": true,
+		"timeout":       "1s",
+	})
+	require.NoError(t, err)
+
+	rtt, err := event.Fields.GetValue("amqp.rtt")
+	require.NoError(t, err)
+	for _, step := range []string{"connect", "channel", "declare", "publish", "round_trip"} {
+		assert.Contains(t, rtt, step)
+	}
+}
+
+func TestFailures(t *testing.T) {
+	for name, test := range map[string]struct {
+		setup  func(*mockBroker)
+		reason string
+	}{
+		"refused":      {func(b *mockBroker) { b.refuse = true }, "validate"},
+		"message lost": {func(b *mockBroker) { b.forward = false }, "validate"},
+		"wedged":       {func(b *mockBroker) { b.wedged = true }, "io"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			broker := newMockBroker(t)
+			defer broker.Close()
+			test.setup(broker)
+
+			start := time.Now()
+			_, err := hbtest.RunJob(t, create, map[string]interface{}{
+				"hosts":         broker.Addr(),
+				"probe.enabled": true,
+				"probe.max_rtt": "200ms",
+				"timeout":       "1s",
+			})
+			require.Error(t, err)
+			assert.Equal(t, test.reason, err.(reason.Reason).Type())
+			assert.Less(t, int64(time.Since(start)), int64(3*time.Second))
+		})
+	}
+}
+
+func TestBrokerDown(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "1s"})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestTLS(t *testing.T) {
+	broker := newTLSMockBroker(t)
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 broker.Addr(),
+		"ssl.verification_mode": "none",
+		"probe.enabled":         true,
+		"timeout":               "1s",
+	})
+	require.NoError(t, err)
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "amqps://"+broker.Addr(), full)
+}
+
+func TestURL(t *testing.T) {
+	config := defaultConfig()
+	assert.Equal(t, "amqp://rabbitmq:5672", amqpProtocol.URL("rabbitmq", config.TLS != nil).String())
+	assert.Equal(t, "amqp://rabbitmq:5673", amqpProtocol.URL("rabbitmq:5673", config.TLS != nil).String())
+
+	config.TLS = &tlscommon.Config{}
+	assert.Equal(t, "amqps://rabbitmq:5671", amqpProtocol.URL("rabbitmq", config.TLS != nil).String())
+}
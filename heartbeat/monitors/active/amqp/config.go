@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+)
+
+type config struct {
+	// AMQP brokers, the port defaults to 5672, or 5671 with TLS
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	// PLAIN credentials and virtual host to open
+	Username string `config:"username" validate:"required"`
+	Password string `config:"password"`
+	Vhost    string `config:"vhost"`
+
+	// round trip through a transient queue, the check only opens a channel
+	// if not enabled
+	Probe probeConfig `config:"probe"`
+}
+
+type probeConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// maximum time between the publication of the message and its delivery,
+	// the timeout by default
+	MaxRTT time.Duration `config:"max_rtt" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config:   endpoint.DefaultConfig,
+		Username: "guest",
+		Password: "guest",
+		Vhost:    "/",
+	}
+}
+
+// maxRTT returns the maximum round trip time of the probe message.
+func (c *config) maxRTT() time.Duration {
+	if c.Probe.MaxRTT > 0 {
+		return c.Probe.MaxRTT
+	}
+	return c.Timeout
+}
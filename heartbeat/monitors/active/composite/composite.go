@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package composite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+func init() {
+	monitors.RegisterActive("composite", create)
+}
+
+const statusUnknown = "unknown"
+
+func create(
+	name string,
+	cfg *common.Config,
+) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	var condition conditions.Condition
+	if config.Condition != nil {
+		condition, err = conditions.NewCondition(config.Condition)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return []jobs.Job{makeJob(&config, condition, status.Latest, time.Now)}, 1, nil
+}
+
+// makeJob returns the job evaluating the latest statuses of the monitors. The
+// composite is up if the condition matches, or without condition if at least
+// min_up monitors are up.
+func makeJob(config *config, condition conditions.Condition, registry *status.Registry, now func() time.Time) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		counts := map[string]int{"up": 0, "down": 0, statusUnknown: 0}
+		statuses := common.MapStr{}
+		byStatus := map[string][]string{}
+		for _, id := range config.Monitors {
+			s := statusUnknown
+			if latest, found := registry.Get(id); found && (config.MaxAge == 0 || now().Sub(latest.Time) <= config.MaxAge) {
+				s = latest.Status
+			}
+			if s != "up" && s != "down" {
+				s = statusUnknown
+			}
+			counts[s]++
+			statuses[id] = s
+			byStatus[s] = append(byStatus[s], id)
+		}
+
+		fields := common.MapStr{
+			"up":      counts["up"],
+			"down":    counts["down"],
+			"unknown": counts[statusUnknown],
+		}
+		if ids := byStatus["down"]; len(ids) > 0 {
+			fields["down_monitors"] = ids
+		}
+		if ids := byStatus[statusUnknown]; len(ids) > 0 {
+			fields["unknown_monitors"] = ids
+		}
+		eventext.MergeEventFields(event, common.MapStr{"composite": fields})
+
+		if condition != nil {
+			values := common.MapStr{
+				"up":       counts["up"],
+				"down":     counts["down"],
+				"unknown":  counts[statusUnknown],
+				"total":    len(config.Monitors),
+				"monitors": statuses,
+			}
+			if !condition.Check(values) {
+				return reason.ValidateFailed(fmt.Errorf("condition '%v' not met with %d of %d monitors up", condition, counts["up"], len(config.Monitors)))
+			}
+			return nil
+		}
+
+		minUp := len(config.Monitors)
+		if config.MinUp != nil {
+			minUp = *config.MinUp
+		}
+		if counts["up"] < minUp {
+			return reason.ValidateFailed(fmt.Errorf("%d of %d monitors up, expected at least %d", counts["up"], len(config.Monitors), minUp))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package composite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+func runJob(t *testing.T, settings map[string]interface{}, registry *status.Registry, now time.Time) (common.MapStr, error) {
+	cfg, err := common.NewConfigFrom(settings)
+	require.NoError(t, err)
+	config := defaultConfig()
+	require.NoError(t, cfg.Unpack(&config))
+
+	var condition conditions.Condition
+	if config.Condition != nil {
+		condition, err = conditions.NewCondition(config.Condition)
+		require.NoError(t, err)
+	}
+
+	job := makeJob(&config, condition, registry, func() time.Time { return now })
+	event := &beat.Event{Fields: common.MapStr{}}
+	_, err = job(event)
+	fields, ferr := event.Fields.GetValue("composite")
+	require.NoError(t, ferr)
+	return fields.(common.MapStr), err
+}
+
+func TestComposite(t *testing.T) {
+	now := time.Now()
+	registry := status.NewRegistry()
+	registry.Set("us", "up", now.Add(-time.Minute))
+	registry.Set("eu", "up", now.Add(-time.Minute))
+	registry.Set("ap", "down", now.Add(-time.Minute))
+	registry.Set("old", "up", now.Add(-time.Hour))
+
+	// All monitors must be up by default
+	fields, err := runJob(t, map[string]interface{}{"monitors": []string{"us", "eu", "ap"}}, registry, now)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Equal(t, common.MapStr{"up": 2, "down": 1, "unknown": 0, "down_monitors": []string{"ap"}}, fields)
+
+	_, err = runJob(t, map[string]interface{}{"monitors": []string{"us", "eu", "ap"}, "min_up": 2}, registry, now)
+	require.NoError(t, err)
+
+	// Missing and expired statuses are unknown
+	fields, err = runJob(t, map[string]interface{}{
+		"monitors": []string{"us", "old", "missing"},
+		"min_up":   2,
+		"max_age":  "10m",
+	}, registry, now)
+	require.Error(t, err)
+	assert.Equal(t, common.MapStr{"up": 1, "down": 0, "unknown": 2, "unknown_monitors": []string{"old", "missing"}}, fields)
+
+	// Conditions on the counts and statuses
+	_, err = runJob(t, map[string]interface{}{
+		"monitors": []string{"us", "eu", "ap"},
+		"condition": map[string]interface{}{
+			"and": []map[string]interface{}{
+				{"range": map[string]interface{}{"up.gte": 2}},
+				{"equals": map[string]interface{}{"monitors.us": "up"}},
+			},
+		},
+	}, registry, now)
+	require.NoError(t, err)
+
+	_, err = runJob(t, map[string]interface{}{
+		"monitors":  []string{"us", "eu", "ap"},
+		"condition": map[string]interface{}{"equals": map[string]interface{}{"down": 0}},
+	}, registry, now)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"min_up above the monitors": {"monitors": []string{"a", "b"}, "min_up": 3},
+		"min_up and condition": {
+			"monitors":  []string{"a", "b"},
+			"min_up":    1,
+			"condition": map[string]interface{}{"equals": map[string]interface{}{"up": 1}},
+		},
+		"no monitors": {"min_up": 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			config := defaultConfig()
+			assert.Error(t, cfg.Unpack(&config))
+		})
+	}
+}
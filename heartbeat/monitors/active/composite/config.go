@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+type config struct {
+	// IDs of the monitors the status is computed from
+	Monitors []string `config:"monitors" validate:"required"`
+
+	// minimum number of monitors up, all of them by default
+	MinUp *int `config:"min_up"`
+
+	// condition on the counts and statuses of the monitors, instead of min_up
+	Condition *conditions.Config `config:"condition"`
+
+	// statuses older than this are unknown, they never expire by default
+	MaxAge time.Duration `config:"max_age" validate:"min=0"`
+}
+
+func defaultConfig() config {
+	return config{}
+}
+
+func (c *config) Validate() error {
+	if c.MinUp != nil && c.Condition != nil {
+		return errors.New("min_up can not be combined with condition")
+	}
+	if c.MinUp != nil && (*c.MinUp < 1 || *c.MinUp > len(c.Monitors)) {
+		return fmt.Errorf("min_up must be between 1 and the number of monitors (%d)", len(c.Monitors))
+	}
+	return nil
+}
@@ -41,7 +41,8 @@ import (
 //  {
 //    "tcp": {
 //      "port": ...,
-//      "rtt": { "connect": { "us": ... }}
+//      "rtt": { "connect": { "us": ... }},
+//      "syn_retransmits": ... // Linux only
 //    }
 //  }
 func TCPDialer(to time.Duration) NetDialer {
@@ -67,6 +68,32 @@ func UDPDialer(to time.Duration) NetDialer {
 
 // CreateNetDialer returns a NetDialer with the given timeout.
 func CreateNetDialer(timeout time.Duration) NetDialer {
+	return CreateFamilyNetDialer(timeout, transport.IPFamilyAuto)
+}
+
+// CreateFamilyNetDialer returns a NetDialer with the given timeout, only
+// connecting to addresses of the given IP family.
+func CreateFamilyNetDialer(timeout time.Duration, family transport.IPFamily) NetDialer {
+	return createNetDialer(timeout, family, net.LookupHost)
+}
+
+// CreateResolverNetDialer returns a NetDialer like CreateFamilyNetDialer,
+// resolving host names with lookupIP instead of the system resolver.
+func CreateResolverNetDialer(timeout time.Duration, family transport.IPFamily, lookupIP func(host string) ([]net.IP, error)) NetDialer {
+	return createNetDialer(timeout, family, func(host string) ([]string, error) {
+		ips, err := lookupIP(host)
+		if err != nil {
+			return nil, err
+		}
+		addresses := make([]string, len(ips))
+		for i, ip := range ips {
+			addresses[i] = ip.String()
+		}
+		return addresses, nil
+	})
+}
+
+func createNetDialer(timeout time.Duration, family transport.IPFamily, lookupHost func(host string) ([]string, error)) NetDialer {
 	return func(event *beat.Event) (transport.Dialer, error) {
 		return makeDialer(func(network, address string) (net.Conn, error) {
 			namespace := ""
@@ -90,12 +117,17 @@ func CreateNetDialer(timeout time.Duration) NetDialer {
 				return nil, fmt.Errorf("invalid port number '%v' used", port)
 			}
 
-			addresses, err := net.LookupHost(host)
+			addresses, err := lookupHost(host)
 			if err != nil {
 				logp.Warn(`DNS lookup failure "%s": %v`, host, err)
 				return nil, err
 			}
 
+			addresses = family.FilterAddresses(addresses)
+			if len(addresses) == 0 {
+				return nil, fmt.Errorf("no %v address found for host %v", family, host)
+			}
+
 			// dial via host IP by randomized iteration of known IPs
 			dialer := &net.Dialer{Timeout: timeout}
 
@@ -106,13 +138,15 @@ func CreateNetDialer(timeout time.Duration) NetDialer {
 			}
 
 			end := time.Now()
-			eventext.MergeEventFields(event, common.MapStr{
-				namespace: common.MapStr{
-					"rtt": common.MapStr{
-						"connect": look.RTT(end.Sub(start)),
-					},
+			fields := common.MapStr{
+				"rtt": common.MapStr{
+					"connect": look.RTT(end.Sub(start)),
 				},
-			})
+			}
+			if retransmits, ok := synRetransmits(conn); ok {
+				fields["syn_retransmits"] = retransmits
+			}
+			eventext.MergeEventFields(event, common.MapStr{namespace: fields})
 
 			return conn, nil
 		}), nil
@@ -0,0 +1,182 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dialchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+)
+
+// proxyV2Signature starts the binary header of the PROXY protocol version 2.
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+const (
+	proxyV2Command = 0x21 // version 2, PROXY command
+	proxyV2TCP4    = 0x11 // AF_INET, STREAM
+	proxyV2TCP6    = 0x21 // AF_INET6, STREAM
+)
+
+// ProxyProtocolConfig configures the HAProxy PROXY protocol header sent at the
+// start of each connection. Addresses that are not configured are taken from
+// the connection.
+type ProxyProtocolConfig struct {
+	Version     int               `config:"version"`
+	Source      ProxyProtocolAddr `config:"source"`
+	Destination ProxyProtocolAddr `config:"destination"`
+}
+
+// ProxyProtocolAddr overrides an address of the PROXY protocol header.
+type ProxyProtocolAddr struct {
+	IP   string `config:"ip"`
+	Port uint16 `config:"port"`
+}
+
+// Unpack sets the default PROXY protocol version before unpacking.
+func (c *ProxyProtocolConfig) Unpack(cfg *common.Config) error {
+	type proxyProtocolConfig ProxyProtocolConfig
+	tmp := proxyProtocolConfig{Version: 1}
+	if err := cfg.Unpack(&tmp); err != nil {
+		return err
+	}
+	*c = ProxyProtocolConfig(tmp)
+	return nil
+}
+
+// Validate checks the version and the addresses.
+func (c *ProxyProtocolConfig) Validate() error {
+	if c.Version != 1 && c.Version != 2 {
+		return fmt.Errorf("unsupported PROXY protocol version %d, expecting 1 or 2", c.Version)
+	}
+	for _, ip := range []string{c.Source.IP, c.Destination.IP} {
+		if ip != "" && net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid PROXY protocol address '%v'", ip)
+		}
+	}
+	return nil
+}
+
+// ProxyProtocolLayer configures a layer in a DialerChain that sends the PROXY
+// protocol header once connected. It must be added below the TLS layer.
+func ProxyProtocolLayer(config *ProxyProtocolConfig) Layer {
+	return func(event *beat.Event, next transport.Dialer) (transport.Dialer, error) {
+		return ProxyProtocolDialer(config, next), nil
+	}
+}
+
+// ProxyProtocolDialer wraps dialer to send the PROXY protocol header once
+// connected.
+func ProxyProtocolDialer(config *ProxyProtocolConfig, dialer transport.Dialer) transport.Dialer {
+	return afterDial(dialer, func(conn net.Conn) (net.Conn, error) {
+		header, err := proxyHeader(config, conn.LocalAddr(), conn.RemoteAddr())
+		if err == nil {
+			_, err = conn.Write(header)
+		}
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to send PROXY protocol header: %w", err)
+		}
+		return conn, nil
+	})
+}
+
+// proxyHeader builds the PROXY protocol header for a connection from local to
+// remote, applying the configured overrides.
+func proxyHeader(config *ProxyProtocolConfig, local, remote net.Addr) ([]byte, error) {
+	srcIP, srcPort, err := proxyAddr(config.Source, local)
+	if err != nil {
+		return nil, err
+	}
+	dstIP, dstPort, err := proxyAddr(config.Destination, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both addresses must be of the same family, IPv4 addresses are mapped to
+	// IPv6 if the other one is IPv6.
+	src4, dst4 := srcIP.To4(), dstIP.To4()
+	ipv4 := src4 != nil && dst4 != nil
+	if ipv4 {
+		srcIP, dstIP = src4, dst4
+	} else {
+		srcIP, dstIP = srcIP.To16(), dstIP.To16()
+	}
+
+	if config.Version == 1 {
+		family := "TCP6"
+		if ipv4 {
+			family = "TCP4"
+		}
+		return []byte(fmt.Sprintf("PROXY %s %s %s %d %d\r\n",
+			family, proxyV1IP(srcIP, ipv4), proxyV1IP(dstIP, ipv4), srcPort, dstPort)), nil
+	}
+
+	var buf bytes.Buffer
+	buf.Write(proxyV2Signature)
+	buf.WriteByte(proxyV2Command)
+	if ipv4 {
+		buf.WriteByte(proxyV2TCP4)
+	} else {
+		buf.WriteByte(proxyV2TCP6)
+	}
+	binary.Write(&buf, binary.BigEndian, uint16(2*len(srcIP)+4))
+	buf.Write(srcIP)
+	buf.Write(dstIP)
+	binary.Write(&buf, binary.BigEndian, srcPort)
+	binary.Write(&buf, binary.BigEndian, dstPort)
+	return buf.Bytes(), nil
+}
+
+// proxyV1IP formats an address of the version 1 header. IPv4 addresses
+// mapped to IPv6 must be written in the IPv6 format.
+func proxyV1IP(ip net.IP, ipv4 bool) string {
+	if !ipv4 && ip.To4() != nil {
+		return "::ffff:" + ip.To4().String()
+	}
+	return ip.String()
+}
+
+func proxyAddr(override ProxyProtocolAddr, addr net.Addr) (net.IP, uint16, error) {
+	var ip net.IP
+	var port uint16
+	if addr != nil {
+		host, p, err := net.SplitHostPort(addr.String())
+		if err == nil {
+			ip = net.ParseIP(host)
+			n, _ := strconv.ParseUint(p, 10, 16)
+			port = uint16(n)
+		}
+	}
+	if override.IP != "" {
+		ip = net.ParseIP(override.IP)
+	}
+	if override.Port != 0 {
+		port = override.Port
+	}
+	if ip == nil {
+		return nil, 0, errors.New("no IP address for the PROXY protocol header, configure it")
+	}
+	return ip, port, nil
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dialchain
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestProxyHeader(t *testing.T) {
+	local := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 51000}
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 443}
+	remote6 := &net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 443}
+
+	cases := map[string]struct {
+		config   ProxyProtocolConfig
+		remote   net.Addr
+		expected []byte
+	}{
+		"v1 from connection": {
+			config:   ProxyProtocolConfig{Version: 1},
+			remote:   remote,
+			expected: []byte("PROXY TCP4 10.0.0.1 10.0.0.2 51000 443\r\n"),
+		},
+		"v1 with source override": {
+			config: ProxyProtocolConfig{
+				Version: 1,
+				Source:  ProxyProtocolAddr{IP: "192.0.2.10", Port: 4000},
+			},
+			remote:   remote,
+			expected: []byte("PROXY TCP4 192.0.2.10 10.0.0.2 4000 443\r\n"),
+		},
+		"v1 mixed families": {
+			config:   ProxyProtocolConfig{Version: 1},
+			remote:   remote6,
+			expected: []byte("PROXY TCP6 ::ffff:10.0.0.1 2001:db8::2 51000 443\r\n"),
+		},
+		"v2 ipv4": {
+			config: ProxyProtocolConfig{Version: 2},
+			remote: remote,
+			expected: append([]byte("\r\n\r\n\x00\r\nQUIT\n"),
+				0x21, 0x11, 0x00, 0x0c,
+				10, 0, 0, 1, 10, 0, 0, 2,
+				0xc7, 0x38, 0x01, 0xbb),
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			header, err := proxyHeader(&c.config, local, c.remote)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, header)
+		})
+	}
+
+	t.Run("v2 ipv6", func(t *testing.T) {
+		header, err := proxyHeader(&ProxyProtocolConfig{Version: 2}, local, remote6)
+		require.NoError(t, err)
+		assert.Equal(t, byte(0x21), header[13])
+		assert.Equal(t, []byte{0x00, 0x24}, header[14:16])
+		assert.Len(t, header, 16+36)
+	})
+}
+
+func TestProxyProtocolConfig(t *testing.T) {
+	var config ProxyProtocolConfig
+	require.NoError(t, common.MustNewConfigFrom(map[string]interface{}{
+		"source.ip": "192.0.2.10",
+	}).Unpack(&config))
+	assert.Equal(t, 1, config.Version)
+	assert.Equal(t, "192.0.2.10", config.Source.IP)
+
+	for _, invalid := range []map[string]interface{}{
+		{"version": 3},
+		{"destination.ip": "not an ip"},
+	} {
+		assert.Error(t, common.MustNewConfigFrom(invalid).Unpack(&ProxyProtocolConfig{}), invalid)
+	}
+}
+
+func TestProxyProtocolDialer(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+
+	config := &ProxyProtocolConfig{
+		Version:     1,
+		Source:      ProxyProtocolAddr{IP: "192.0.2.10", Port: 4000},
+		Destination: ProxyProtocolAddr{IP: "192.0.2.20", Port: 80},
+	}
+	dialer := ProxyProtocolDialer(config, makeDialer(func(network, address string) (net.Conn, error) {
+		return client, nil
+	}))
+
+	received := make(chan []byte)
+	go func() {
+		buf := make([]byte, 128)
+		n, _ := server.Read(buf)
+		received <- buf[:n]
+	}()
+
+	conn, err := dialer.Dial("tcp", "192.0.2.20:80")
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, "PROXY TCP4 192.0.2.10 192.0.2.20 4000 80\r\n", string(<-received))
+}
@@ -29,9 +29,12 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
 
+// maxServerHelloSize limits the data recorded while waiting for the ServerHello.
+const maxServerHelloSize = 64 * 1024
+
 // TLSLayer configures the TLS layer in a DialerChain.
-// The layer will update the active event with the TLS RTT and
-// crypto/cert details.
+// The layer will update the active event with the TLS RTT,
+// crypto/cert details and the JA3S fingerprint of the server.
 func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
 	return func(event *beat.Event, next transport.Dialer) (transport.Dialer, error) {
 		var timer timer
@@ -40,6 +43,13 @@ func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
 		// This gets us the timestamp for when the TLS layer will start the handshake.
 		next = startTimerAfterDial(&timer, next)
 
+		// Record the ServerHello sent by the server to fingerprint it.
+		var recorder *helloRecorder
+		next = afterDial(next, func(conn net.Conn) (net.Conn, error) {
+			recorder = &helloRecorder{Conn: conn}
+			return recorder, nil
+		})
+
 		dialer, err := transport.TLSDialer(next, cfg, to)
 		if err != nil {
 			return nil, err
@@ -54,8 +64,34 @@ func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
 			timer.stop()
 
 			tlsmeta.AddTLSMetadata(event.Fields, connState, timer.duration())
+			if recorder != nil && recorder.hello != nil {
+				if hash, _, err := tlsmeta.JA3S(recorder.hello); err == nil {
+					event.Fields.Put("tls.server.ja3s", hash)
+				}
+			}
 
 			return conn, nil
 		}), nil
 	}
 }
+
+// helloRecorder records the data read from a connection until the ServerHello
+// message is complete.
+type helloRecorder struct {
+	net.Conn
+	buf   []byte
+	hello []byte
+	done  bool
+}
+
+func (r *helloRecorder) Read(b []byte) (int, error) {
+	n, err := r.Conn.Read(b)
+	if !r.done && n > 0 {
+		r.buf = append(r.buf, b[:n]...)
+		hello, helloErr := tlsmeta.ServerHello(r.buf)
+		if helloErr != tlsmeta.ErrIncompleteServerHello || len(r.buf) > maxServerHelloSize {
+			r.hello, r.buf, r.done = hello, nil, true
+		}
+	}
+	return n, err
+}
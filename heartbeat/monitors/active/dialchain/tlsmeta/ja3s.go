@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	recordTypeHandshake      = 22
+	handshakeTypeServerHello = 2
+	recordHeaderLen          = 5
+	handshakeHeaderLen       = 4
+)
+
+var (
+	// ErrIncompleteServerHello is returned when the data doesn't contain a full
+	// ServerHello message yet.
+	ErrIncompleteServerHello = errors.New("incomplete TLS ServerHello")
+
+	errNotServerHello = errors.New("not a TLS ServerHello")
+	errMalformedHello = errors.New("malformed TLS ServerHello")
+)
+
+// ServerHello returns the body of the ServerHello handshake message found at
+// the beginning of the raw TLS records sent by a server.
+func ServerHello(records []byte) ([]byte, error) {
+	var msg []byte
+	for len(records) > 0 {
+		if len(records) < recordHeaderLen {
+			return nil, ErrIncompleteServerHello
+		}
+		if records[0] != recordTypeHandshake {
+			return nil, errNotServerHello
+		}
+		length := int(binary.BigEndian.Uint16(records[3:5]))
+		if len(records) < recordHeaderLen+length {
+			return nil, ErrIncompleteServerHello
+		}
+		msg = append(msg, records[recordHeaderLen:recordHeaderLen+length]...)
+		records = records[recordHeaderLen+length:]
+
+		if len(msg) < handshakeHeaderLen {
+			continue
+		}
+		if msg[0] != handshakeTypeServerHello {
+			return nil, errNotServerHello
+		}
+		bodyLen := int(msg[1])<<16 | int(msg[2])<<8 | int(msg[3])
+		if len(msg) >= handshakeHeaderLen+bodyLen {
+			return msg[handshakeHeaderLen : handshakeHeaderLen+bodyLen], nil
+		}
+	}
+	return nil, ErrIncompleteServerHello
+}
+
+// JA3S computes the JA3S fingerprint of a ServerHello message body, returning
+// the MD5 hash and the fingerprint string it is computed from.
+func JA3S(hello []byte) (hash string, ja3s string, err error) {
+	// version(2) + random(32) + session id length(1)
+	if len(hello) < 35 {
+		return "", "", errMalformedHello
+	}
+	version := binary.BigEndian.Uint16(hello)
+	hello = hello[34:]
+
+	sessionIDLen := int(hello[0])
+	// session id + cipher suite(2) + compression method(1)
+	if len(hello) < 1+sessionIDLen+3 {
+		return "", "", errMalformedHello
+	}
+	hello = hello[1+sessionIDLen:]
+	cipher := binary.BigEndian.Uint16(hello)
+	hello = hello[3:]
+
+	var extensions []string
+	if len(hello) >= 2 {
+		extLen := int(binary.BigEndian.Uint16(hello))
+		hello = hello[2:]
+		if len(hello) < extLen {
+			return "", "", errMalformedHello
+		}
+		for exts := hello[:extLen]; len(exts) > 0; {
+			if len(exts) < 4 {
+				return "", "", errMalformedHello
+			}
+			dataLen := int(binary.BigEndian.Uint16(exts[2:4]))
+			if len(exts) < 4+dataLen {
+				return "", "", errMalformedHello
+			}
+			extensions = append(extensions, strconv.Itoa(int(binary.BigEndian.Uint16(exts))))
+			exts = exts[4+dataLen:]
+		}
+	}
+
+	ja3s = strings.Join([]string{
+		strconv.Itoa(int(version)),
+		strconv.Itoa(int(cipher)),
+		strings.Join(extensions, "-"),
+	}, ",")
+	sum := md5.Sum([]byte(ja3s))
+	return hex.EncodeToString(sum[:]), ja3s, nil
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serverHello builds a ServerHello message body with the given extensions.
+func serverHello(cipher uint16, extensions ...[]byte) []byte {
+	hello := []byte{0x03, 0x03}
+	hello = append(hello, make([]byte, 32)...)
+	hello = append(hello, 1, 0xaa) // session id
+	hello = append(hello, byte(cipher>>8), byte(cipher), 0)
+	if len(extensions) == 0 {
+		return hello
+	}
+	var exts []byte
+	for _, ext := range extensions {
+		exts = append(exts, ext...)
+	}
+	hello = append(hello, byte(len(exts)>>8), byte(len(exts)))
+	return append(hello, exts...)
+}
+
+// handshakeRecords wraps a ServerHello body in handshake records of at most
+// size bytes.
+func handshakeRecords(hello []byte, size int) []byte {
+	msg := append([]byte{handshakeTypeServerHello, 0, byte(len(hello) >> 8), byte(len(hello))}, hello...)
+	var records []byte
+	for len(msg) > 0 {
+		n := size
+		if len(msg) < n {
+			n = len(msg)
+		}
+		records = append(records, recordTypeHandshake, 0x03, 0x03, byte(n>>8), byte(n))
+		records = append(records, msg[:n]...)
+		msg = msg[n:]
+	}
+	return records
+}
+
+func TestServerHello(t *testing.T) {
+	hello := serverHello(0x1301, []byte{0x00, 0x2b, 0x00, 0x02, 0x03, 0x04})
+
+	for _, size := range []int{1 << 14, 16, 3} {
+		records := handshakeRecords(hello, size)
+
+		// Data following the ServerHello is ignored
+		got, err := ServerHello(append(records, 20, 0x03, 0x03, 0x00, 0x01, 0x01))
+		require.NoError(t, err)
+		assert.Equal(t, hello, got)
+
+		_, err = ServerHello(records[:len(records)-1])
+		assert.Equal(t, ErrIncompleteServerHello, err)
+	}
+
+	_, err := ServerHello([]byte{21, 0x03, 0x03, 0x00, 0x02, 0x02, 0x28})
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrIncompleteServerHello, err)
+}
+
+func TestJA3S(t *testing.T) {
+	tests := []struct {
+		name  string
+		hello []byte
+		ja3s  string
+		hash  string
+	}{
+		{
+			"with extensions",
+			serverHello(0x1301,
+				[]byte{0x00, 0x2b, 0x00, 0x02, 0x03, 0x04},
+				[]byte{0x00, 0x33, 0x00, 0x04, 0x00, 0x1d, 0x00, 0x00},
+			),
+			"771,4865,43-51",
+			"f4febc55ea12b31ae17cfb7e614afda8",
+		},
+		{
+			"without extensions",
+			serverHello(0xc02f),
+			"771,49199,",
+			"174e7e4992a63f6d419626d97363adb8",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hash, ja3s, err := JA3S(test.hello)
+			require.NoError(t, err)
+			assert.Equal(t, test.ja3s, ja3s)
+			assert.Equal(t, test.hash, hash)
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		hello := serverHello(0x1301, []byte{0x00, 0x2b, 0x00, 0x02, 0x03, 0x04})
+		_, _, err := JA3S(hello[:len(hello)-1])
+		assert.Error(t, err)
+		_, _, err = JA3S(hello[:20])
+		assert.Error(t, err)
+	})
+}
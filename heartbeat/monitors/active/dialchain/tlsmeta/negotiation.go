@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	cryptoTLS "crypto/tls"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// Negotiation lists the TLS versions and cipher suites a host is expected to
+// negotiate. Cipher suites are matched by the names reported in `tls.cipher`,
+// for example `ECDHE-RSA-AES-128-GCM-SHA256`.
+type Negotiation struct {
+	Versions        []tlscommon.TLSVersion `config:"versions"`
+	Ciphers         []match.Matcher        `config:"ciphers"`
+	ExcludedCiphers []match.Matcher        `config:"excluded_ciphers"`
+}
+
+// Enabled returns true if any expectation is configured.
+func (n Negotiation) Enabled() bool {
+	return len(n.Versions) > 0 || len(n.Ciphers) > 0 || len(n.ExcludedCiphers) > 0
+}
+
+// Check validates the negotiated TLS version is one of the expected versions,
+// and the cipher suite matches one of the expected ciphers and none of the
+// excluded ones.
+func (n Negotiation) Check(connState *cryptoTLS.ConnectionState) error {
+	if !n.Enabled() {
+		return nil
+	}
+	if connState == nil {
+		return errors.New("no TLS connection established, expecting a TLS handshake")
+	}
+
+	if len(n.Versions) > 0 {
+		version := tlscommon.TLSVersion(connState.Version)
+		if !containsVersion(n.Versions, version) {
+			return fmt.Errorf("negotiated TLS version %v, expecting one of %v", version, n.Versions)
+		}
+	}
+
+	cipher := tlscommon.ResolveCipherSuite(connState.CipherSuite)
+	if len(n.Ciphers) > 0 && !matchAny(n.Ciphers, cipher) {
+		return fmt.Errorf("negotiated cipher suite %v does not match any expected cipher suite", cipher)
+	}
+	if matchAny(n.ExcludedCiphers, cipher) {
+		return fmt.Errorf("negotiated cipher suite %v is excluded", cipher)
+	}
+	return nil
+}
+
+func containsVersion(versions []tlscommon.TLSVersion, version tlscommon.TLSVersion) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAny(matchers []match.Matcher, s string) bool {
+	for _, m := range matchers {
+		if m.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
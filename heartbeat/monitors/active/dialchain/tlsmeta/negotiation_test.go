@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	cryptoTLS "crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+func TestNegotiationCheck(t *testing.T) {
+	tls12CBC := &cryptoTLS.ConnectionState{
+		Version:     cryptoTLS.VersionTLS12,
+		CipherSuite: cryptoTLS.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	}
+	tls13 := &cryptoTLS.ConnectionState{
+		Version:     cryptoTLS.VersionTLS13,
+		CipherSuite: cryptoTLS.TLS_AES_128_GCM_SHA256,
+	}
+
+	tests := []struct {
+		name        string
+		negotiation Negotiation
+		connState   *cryptoTLS.ConnectionState
+		ok          bool
+	}{
+		{"no expectations", Negotiation{}, nil, true},
+		{"no TLS", Negotiation{Versions: []tlscommon.TLSVersion{tlscommon.TLSVersion13}}, nil, false},
+		{"version matches", Negotiation{Versions: []tlscommon.TLSVersion{tlscommon.TLSVersion13}}, tls13, true},
+		{"version mismatch", Negotiation{Versions: []tlscommon.TLSVersion{tlscommon.TLSVersion13}}, tls12CBC, false},
+		{
+			"any listed version",
+			Negotiation{Versions: []tlscommon.TLSVersion{tlscommon.TLSVersion12, tlscommon.TLSVersion13}},
+			tls12CBC,
+			true,
+		},
+		{"cipher matches", Negotiation{Ciphers: []match.Matcher{match.MustCompile("GCM|CHACHA20")}}, tls13, true},
+		{"cipher mismatch", Negotiation{Ciphers: []match.Matcher{match.MustCompile("GCM|CHACHA20")}}, tls12CBC, false},
+		{"cipher excluded", Negotiation{ExcludedCiphers: []match.Matcher{match.MustCompile("CBC")}}, tls12CBC, false},
+		{"cipher not excluded", Negotiation{ExcludedCiphers: []match.Matcher{match.MustCompile("CBC")}}, tls13, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.negotiation.Check(test.connState)
+			if test.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"bytes"
+	cryptoTLS "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// OCSP status values reported in the `tls.server.ocsp.status` field, in
+// addition to the statuses of OCSP responses.
+const (
+	OCSPStatusMissing = "missing"
+	OCSPStatusError   = "error"
+)
+
+// OCSP configures the revocation checking of the certificate served by a
+// host. The response stapled by the host during the TLS handshake is used if
+// present, otherwise the OCSP responder of the certificate can be queried.
+type OCSP struct {
+	Enabled bool `config:"enabled"`
+
+	// Query the OCSP responder if the host did not staple a response.
+	Query   bool          `config:"query"`
+	Timeout time.Duration `config:"timeout" validate:"min=0"`
+
+	// Fail the check if the certificate is revoked, or if no valid response
+	// was stapled.
+	FailOnRevoked bool `config:"fail_on_revoked"`
+	RequireStaple bool `config:"require_staple"`
+}
+
+// DefaultOCSP returns the default OCSP settings, revocation checking being
+// disabled.
+func DefaultOCSP() OCSP {
+	return OCSP{
+		Query:   true,
+		Timeout: 5 * time.Second,
+	}
+}
+
+// Check verifies the revocation status of the leaf certificate of the
+// connection. It returns the fields describing the status, and an error if
+// the check must fail.
+func (o OCSP) Check(connState cryptoTLS.ConnectionState) (common.MapStr, error) {
+	return o.check(connState, nil)
+}
+
+func (o OCSP) check(
+	connState cryptoTLS.ConnectionState,
+	do func(*http.Request) (*http.Response, error),
+) (common.MapStr, error) {
+	stapled := len(connState.OCSPResponse) > 0
+	fields := common.MapStr{"stapled": stapled}
+
+	if o.RequireStaple && !stapled {
+		fields["status"] = OCSPStatusMissing
+		return fields, errors.New("no OCSP response stapled by the host")
+	}
+
+	leaf, issuer, err := leafAndIssuer(connState)
+	if err != nil {
+		fields["status"] = OCSPStatusError
+		if o.RequireStaple {
+			return fields, err
+		}
+		return fields, nil
+	}
+
+	raw := connState.OCSPResponse
+	if !stapled {
+		if !o.Query {
+			fields["status"] = OCSPStatusMissing
+			return fields, nil
+		}
+		if raw, err = o.query(leaf, issuer, do); err != nil {
+			fields["status"] = OCSPStatusError
+			return fields, nil
+		}
+	}
+
+	resp, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		fields["status"] = OCSPStatusError
+		if stapled && o.RequireStaple {
+			return fields, fmt.Errorf("invalid OCSP response stapled: %v", err)
+		}
+		return fields, nil
+	}
+
+	fields["produced_at"] = resp.ProducedAt
+	fields["this_update"] = resp.ThisUpdate
+	if !resp.NextUpdate.IsZero() {
+		fields["next_update"] = resp.NextUpdate
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		fields["status"] = "good"
+	case ocsp.Revoked:
+		fields["status"] = "revoked"
+		fields["revoked_at"] = resp.RevokedAt
+		if o.FailOnRevoked {
+			return fields, fmt.Errorf("certificate revoked at %v", resp.RevokedAt.UTC().Format(time.RFC3339))
+		}
+	default:
+		fields["status"] = "unknown"
+	}
+	return fields, nil
+}
+
+// query requests the status of the certificate from the first OCSP
+// responder listed in the certificate. Requests are sent with do if set.
+func (o OCSP) query(
+	leaf, issuer *x509.Certificate,
+	do func(*http.Request) (*http.Response, error),
+) ([]byte, error) {
+	if len(leaf.OCSPServer) == 0 {
+		return nil, errors.New("certificate lists no OCSP responder")
+	}
+
+	body, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", leaf.OCSPServer[0], bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+
+	if do == nil {
+		do = (&http.Client{Timeout: o.Timeout}).Do
+	}
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder returned status %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// leafAndIssuer returns the certificate served by the host and the
+// certificate of its issuer, preferring the verified chain.
+func leafAndIssuer(connState cryptoTLS.ConnectionState) (leaf, issuer *x509.Certificate, err error) {
+	chain := connState.PeerCertificates
+	if len(connState.VerifiedChains) > 0 {
+		chain = connState.VerifiedChains[0]
+	}
+	if len(chain) < 2 {
+		return nil, nil, errors.New("issuer certificate not available to check the OCSP response")
+	}
+	return chain[0], chain[1], nil
+}
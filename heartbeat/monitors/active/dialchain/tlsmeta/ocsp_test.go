@@ -0,0 +1,185 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	cryptoTLS "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type ocspFixture struct {
+	ca, leaf *x509.Certificate
+	caKey    crypto.Signer
+}
+
+func newOCSPFixture(t *testing.T) ocspFixture {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		OCSPServer:   []string{"http://ocsp.example.com"},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	return ocspFixture{ca: ca, leaf: leaf, caKey: caKey}
+}
+
+func (f ocspFixture) response(t *testing.T, status int) []byte {
+	now := time.Now().Truncate(time.Second)
+	resp, err := ocsp.CreateResponse(f.ca, f.ca, ocsp.Response{
+		Status:       status,
+		SerialNumber: f.leaf.SerialNumber,
+		ThisUpdate:   now.Add(-time.Minute),
+		NextUpdate:   now.Add(time.Hour),
+		RevokedAt:    now.Add(-time.Minute),
+	}, f.caKey)
+	require.NoError(t, err)
+	return resp
+}
+
+func (f ocspFixture) connState(staple []byte) cryptoTLS.ConnectionState {
+	return cryptoTLS.ConnectionState{
+		PeerCertificates: []*x509.Certificate{f.leaf, f.ca},
+		OCSPResponse:     staple,
+	}
+}
+
+func TestOCSPStapled(t *testing.T) {
+	f := newOCSPFixture(t)
+	o := OCSP{Enabled: true, FailOnRevoked: true}
+
+	fields, err := o.Check(f.connState(f.response(t, ocsp.Good)))
+	require.NoError(t, err)
+	assert.Equal(t, "good", fields["status"])
+	assert.Equal(t, true, fields["stapled"])
+	assert.Contains(t, fields, "next_update")
+
+	fields, err = o.Check(f.connState(f.response(t, ocsp.Revoked)))
+	assert.Error(t, err)
+	assert.Equal(t, "revoked", fields["status"])
+	assert.Contains(t, fields, "revoked_at")
+
+	o.FailOnRevoked = false
+	fields, err = o.Check(f.connState(f.response(t, ocsp.Revoked)))
+	assert.NoError(t, err)
+	assert.Equal(t, "revoked", fields["status"])
+
+	fields, err = o.Check(f.connState(f.response(t, ocsp.Unknown)))
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", fields["status"])
+}
+
+func TestOCSPInvalidStaple(t *testing.T) {
+	f := newOCSPFixture(t)
+
+	fields, err := OCSP{Enabled: true}.Check(f.connState([]byte("invalid")))
+	assert.NoError(t, err)
+	assert.Equal(t, OCSPStatusError, fields["status"])
+
+	_, err = OCSP{Enabled: true, RequireStaple: true}.Check(f.connState([]byte("invalid")))
+	assert.Error(t, err)
+}
+
+func TestOCSPRequireStaple(t *testing.T) {
+	f := newOCSPFixture(t)
+
+	fields, err := OCSP{Enabled: true, RequireStaple: true}.Check(f.connState(nil))
+	assert.Error(t, err)
+	assert.Equal(t, OCSPStatusMissing, fields["status"])
+	assert.Equal(t, false, fields["stapled"])
+
+	fields, err = OCSP{Enabled: true}.Check(f.connState(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, OCSPStatusMissing, fields["status"])
+}
+
+func TestOCSPQuery(t *testing.T) {
+	f := newOCSPFixture(t)
+	o := OCSP{Enabled: true, Query: true}
+
+	var requested string
+	do := func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		assert.Equal(t, "application/ocsp-request", req.Header.Get("Content-Type"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(f.response(t, ocsp.Good))),
+		}, nil
+	}
+	fields, err := o.check(f.connState(nil), do)
+	require.NoError(t, err)
+	assert.Equal(t, "http://ocsp.example.com", requested)
+	assert.Equal(t, "good", fields["status"])
+	assert.Equal(t, false, fields["stapled"])
+
+	failing := func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}
+	fields, err = o.check(f.connState(nil), failing)
+	assert.NoError(t, err)
+	assert.Equal(t, OCSPStatusError, fields["status"])
+}
+
+func TestOCSPMissingIssuer(t *testing.T) {
+	f := newOCSPFixture(t)
+	connState := cryptoTLS.ConnectionState{PeerCertificates: []*x509.Certificate{f.leaf}}
+
+	fields, err := OCSP{Enabled: true, Query: true}.Check(connState)
+	assert.NoError(t, err)
+	assert.Equal(t, OCSPStatusError, fields["status"])
+}
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Pins lists the expected SHA-256 fingerprints of the certificate served by a
+// host, or of its subject public key info (SPKI). Fingerprints are hex
+// encoded, optionally separated by colons, or base64 encoded.
+type Pins struct {
+	SHA256     []string `config:"sha256"`
+	SPKISHA256 []string `config:"spki_sha256"`
+
+	// PinnedSHA256 is an alias of SPKISHA256, its fingerprints are merged.
+	PinnedSHA256 []string `config:"pinned_sha256"`
+}
+
+// Validate checks all configured fingerprints can be decoded.
+func (p *Pins) Validate() error {
+	for _, pin := range append(append([]string{}, p.SHA256...), p.spki()...) {
+		if _, err := decodeFingerprint(pin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enabled returns true if any fingerprint is configured.
+func (p Pins) Enabled() bool {
+	return len(p.SHA256) > 0 || len(p.spki()) > 0
+}
+
+// Check validates the leaf certificate of the chain matches one of the
+// configured certificate fingerprints and one of the SPKI fingerprints.
+func (p Pins) Check(certs []*x509.Certificate) error {
+	if !p.Enabled() {
+		return nil
+	}
+	if len(certs) == 0 {
+		return errors.New("no certificate served, expecting a pinned certificate")
+	}
+
+	leaf := certs[0]
+	if len(p.SHA256) > 0 {
+		hash := sha256.Sum256(leaf.Raw)
+		if !matchFingerprint(p.SHA256, hash[:]) {
+			return fmt.Errorf("certificate SHA-256 fingerprint %x does not match any pinned fingerprint", hash)
+		}
+	}
+	if spki := p.spki(); len(spki) > 0 {
+		hash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		if !matchFingerprint(spki, hash[:]) {
+			return fmt.Errorf("SPKI SHA-256 fingerprint %v does not match any pinned fingerprint",
+				base64.StdEncoding.EncodeToString(hash[:]))
+		}
+	}
+	return nil
+}
+
+func (p Pins) spki() []string {
+	if len(p.PinnedSHA256) == 0 {
+		return p.SPKISHA256
+	}
+	return append(append([]string{}, p.SPKISHA256...), p.PinnedSHA256...)
+}
+
+func matchFingerprint(pins []string, hash []byte) bool {
+	for _, pin := range pins {
+		if expected, err := decodeFingerprint(pin); err == nil && bytes.Equal(expected, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeFingerprint(pin string) ([]byte, error) {
+	if b, err := hex.DecodeString(strings.Replace(pin, ":", "", -1)); err == nil && len(b) == sha256.Size {
+		return b, nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(pin); err == nil && len(b) == sha256.Size {
+		return b, nil
+	}
+	return nil, fmt.Errorf("invalid SHA-256 fingerprint '%v', expecting hex or base64 encoding", pin)
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlsmeta
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinsCheck(t *testing.T) {
+	cert := parseCert(t, elasticCert)
+	certHash := sha256.Sum256(cert.Raw)
+	spkiHash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	colonHex := strings.ToUpper(fmt.Sprintf("% x", certHash))
+	colonHex = strings.Replace(colonHex, " ", ":", -1)
+	other := fmt.Sprintf("%x", sha256.Sum256([]byte("other")))
+
+	tests := []struct {
+		name  string
+		pins  Pins
+		certs []*x509.Certificate
+		ok    bool
+	}{
+		{"no pins", Pins{}, nil, true},
+		{"certificate hex", Pins{SHA256: []string{fmt.Sprintf("%x", certHash)}}, []*x509.Certificate{cert}, true},
+		{"certificate colon separated hex", Pins{SHA256: []string{other, colonHex}}, []*x509.Certificate{cert}, true},
+		{"certificate mismatch", Pins{SHA256: []string{other}}, []*x509.Certificate{cert}, false},
+		{"spki base64", Pins{SPKISHA256: []string{base64.StdEncoding.EncodeToString(spkiHash[:])}}, []*x509.Certificate{cert}, true},
+		{"spki mismatch", Pins{SPKISHA256: []string{other}}, []*x509.Certificate{cert}, false},
+		{"pinned spki", Pins{SPKISHA256: []string{other}, PinnedSHA256: []string{fmt.Sprintf("%x", spkiHash)}}, []*x509.Certificate{cert}, true},
+		{"pinned spki mismatch", Pins{PinnedSHA256: []string{other}}, []*x509.Certificate{cert}, false},
+		{
+			"certificate and spki",
+			Pins{SHA256: []string{fmt.Sprintf("%x", certHash)}, SPKISHA256: []string{other}},
+			[]*x509.Certificate{cert},
+			false,
+		},
+		{"no certificate", Pins{SHA256: []string{other}}, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.pins.Check(test.certs)
+			if test.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPinsValidate(t *testing.T) {
+	valid := Pins{
+		SHA256:     []string{fmt.Sprintf("%x", sha256.Sum256(nil))},
+		SPKISHA256: []string{base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))},
+	}
+	require.NoError(t, valid.Validate())
+
+	require.Error(t, (&Pins{SHA256: []string{"abcd"}}).Validate())
+	require.Error(t, (&Pins{SPKISHA256: []string{"not a fingerprint"}}).Validate())
+	require.Error(t, (&Pins{PinnedSHA256: []string{"abcd"}}).Validate())
+}
@@ -25,6 +25,7 @@ import (
 	"crypto/sha256"
 	cryptoTLS "crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -96,6 +97,23 @@ func AddCertMetadata(fields common.MapStr, certs []*x509.Certificate) {
 	fields.DeepUpdate(common.MapStr{"tls": tlsFields})
 }
 
+// AddCertPEM adds the PEM encoding of the certificate served by the host to
+// tls.server.certificate, and of the full chain to tls.server.certificate_chain.
+func AddCertPEM(fields common.MapStr, certs []*x509.Certificate) {
+	if len(certs) == 0 {
+		return
+	}
+
+	chain := make([]string, len(certs))
+	for i, cert := range certs {
+		chain[i] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	fields.DeepUpdate(common.MapStr{"tls": common.MapStr{"server": common.MapStr{
+		"certificate":       chain[0],
+		"certificate_chain": chain,
+	}}})
+}
+
 func calculateCertTimestamps(certs []*x509.Certificate) (chainNotBefore time.Time, chainNotAfter *time.Time) {
 	// The behavior here might seem strange. We *always* set a notBefore, but only optionally set a notAfter.
 	// Why might we do this?
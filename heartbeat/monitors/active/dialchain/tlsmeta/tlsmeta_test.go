@@ -165,6 +165,28 @@ func TestAddCertMetadata(t *testing.T) {
 	}
 }
 
+func TestAddCertPEM(t *testing.T) {
+	cert := parseCert(t, elasticCert)
+	chainCert := parseCert(t, elasticChainCert)
+
+	fields := common.MapStr{}
+	AddCertPEM(fields, []*x509.Certificate{cert, chainCert})
+
+	leaf, err := fields.GetValue("tls.server.certificate")
+	require.NoError(t, err)
+	block, _ := pem.Decode([]byte(leaf.(string)))
+	require.NotNil(t, block)
+	require.Equal(t, cert.Raw, block.Bytes)
+
+	chain, err := fields.GetValue("tls.server.certificate_chain")
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+
+	fields = common.MapStr{}
+	AddCertPEM(fields, nil)
+	require.Empty(t, fields)
+}
+
 // TestCertExpirationMetadata exhaustively tests not before / not after calculation.
 func TestCertExpirationMetadata(t *testing.T) {
 	goodNotBefore := time.Now().Add(-time.Hour)
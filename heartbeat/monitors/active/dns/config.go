@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+type config struct {
+	// names to query
+	Hosts     []string `config:"hosts" validate:"required"`
+	QueryType string   `config:"query_type"`
+
+	// nameservers to query, each one is checked by a separate job
+	Nameservers []string `config:"nameservers"`
+
+	Timeout time.Duration `config:"timeout"`
+
+	// request DNSSEC records and require the answer to be authenticated by
+	// the nameserver
+	DNSSEC bool `config:"dnssec"`
+
+	// expected response code and RDATA of the answers
+	RCode   string   `config:"check.response.rcode"`
+	Answers []string `config:"check.response.answers"`
+}
+
+func defaultConfig() config {
+	return config{
+		QueryType: "A",
+		Timeout:   16 * time.Second,
+		RCode:     "NOERROR",
+	}
+}
+
+func (c *config) Validate() error {
+	if _, found := dns.StringToType[strings.ToUpper(c.QueryType)]; !found {
+		return fmt.Errorf("unknown query_type '%v'", c.QueryType)
+	}
+	if _, found := dns.StringToRcode[strings.ToUpper(c.RCode)]; !found {
+		return fmt.Errorf("unknown check.response.rcode '%v'", c.RCode)
+	}
+	return nil
+}
+
+// qtype returns the DNS type of the query.
+func (c *config) qtype() uint16 {
+	return dns.StringToType[strings.ToUpper(c.QueryType)]
+}
+
+// rcode returns the expected response code.
+func (c *config) rcode() int {
+	return dns.StringToRcode[strings.ToUpper(c.RCode)]
+}
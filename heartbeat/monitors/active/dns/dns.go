@@ -0,0 +1,295 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("dns", create)
+	monitors.RegisterActive("synthetics/dns", create)
+}
+
+var debugf = logp.MakeDebug("dns")
+
+// resolvConf is the file the nameservers are read from if none are configured.
+const resolvConf = "/etc/resolv.conf"
+
+func create(
+	name string,
+	cfg *common.Config,
+) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	nameservers := config.Nameservers
+	if len(nameservers) == 0 {
+		nameservers, err = systemNameservers(resolvConf)
+		if err != nil {
+			return nil, 0, fmt.Errorf("no nameservers configured and %v", err)
+		}
+	}
+
+	for _, ns := range nameservers {
+		resolver, err := monitors.NewNameserverResolver([]string{ns}, config.Timeout)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, host := range config.Hosts {
+			u := queryURL(resolver.Nameservers()[0], host, config.QueryType)
+			js = append(js, wrappers.WithURLField(u, makeQueryJob(&config, resolver, host)))
+		}
+	}
+	return js, len(js), nil
+}
+
+// systemNameservers reads the nameservers of the system resolver.
+func systemNameservers(path string) ([]string, error) {
+	conf, err := dns.ClientConfigFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the system nameservers: %v", err)
+	}
+	if len(conf.Servers) == 0 {
+		return nil, fmt.Errorf("no nameservers found in %v", path)
+	}
+
+	nameservers := make([]string, len(conf.Servers))
+	for i, server := range conf.Servers {
+		nameservers[i] = server + ":" + conf.Port
+		if strings.Contains(server, ":") {
+			nameservers[i] = "[" + server + "]:" + conf.Port
+		}
+	}
+	return nameservers, nil
+}
+
+// queryURL returns the URL identifying the query of the host to the
+// nameserver, like dns://10.0.0.53:53/example.com?type=A.
+func queryURL(nameserver, host, qtype string) *url.URL {
+	u := &url.URL{Host: nameserver}
+	if parsed, err := url.Parse(nameserver); err == nil && parsed.Host != "" {
+		u.Host = parsed.Host
+	}
+	u.Scheme = "dns"
+	u.Path = "/" + strings.TrimSuffix(host, ".")
+	u.RawQuery = url.Values{"type": []string{strings.ToUpper(qtype)}}.Encode()
+	return u
+}
+
+func makeQueryJob(config *config, resolver *monitors.NameserverResolver, host string) jobs.Job {
+	qtype, rcode := config.qtype(), config.rcode()
+
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(host), qtype)
+		m.RecursionDesired = true
+		if config.DNSSEC {
+			m.SetEdns0(4096, true)
+			m.AuthenticatedData = true
+		}
+
+		start := time.Now()
+		resp, server, err := resolver.Exchange(m)
+		rtt := time.Since(start)
+		if err != nil {
+			debugf("query of %v failed with: %v", host, err)
+			return reason.IOFailed(err)
+		}
+
+		fields, rdata := responseFields(resp, qtype)
+		fields.Put("question.name", dns.Fqdn(host))
+		fields.Put("question.type", dns.TypeToString[qtype])
+		fields.Put("rtt", look.RTT(rtt))
+		eventext.MergeEventFields(event, common.MapStr{"dns": fields})
+
+		if resp.Rcode != rcode {
+			return reason.ValidateFailed(fmt.Errorf("nameserver %v returned %v, expected %v",
+				server, rcodeName(resp.Rcode), rcodeName(rcode)))
+		}
+		if config.DNSSEC && !resp.AuthenticatedData {
+			return reason.ValidateFailed(fmt.Errorf("nameserver %v did not authenticate the answer with DNSSEC", server))
+		}
+		return checkAnswers(config.Answers, rdata, qtype)
+	})
+}
+
+// responseFields returns the ECS dns fields of the response, and the RDATA of
+// the answers of the query type.
+func responseFields(resp *dns.Msg, qtype uint16) (common.MapStr, []string) {
+	fields := common.MapStr{
+		"response_code": rcodeName(resp.Rcode),
+		"header_flags":  headerFlags(resp),
+	}
+
+	var answers []common.MapStr
+	var rdata, resolved []string
+	for _, rr := range resp.Answer {
+		hdr := rr.Header()
+		data := rrData(rr)
+		answers = append(answers, common.MapStr{
+			"name":  hdr.Name,
+			"type":  dns.TypeToString[hdr.Rrtype],
+			"class": dns.ClassToString[hdr.Class],
+			"ttl":   hdr.Ttl,
+			"data":  data,
+		})
+
+		switch rr.(type) {
+		case *dns.A, *dns.AAAA:
+			resolved = append(resolved, data)
+		}
+		if hdr.Rrtype == qtype {
+			rdata = append(rdata, data)
+		}
+	}
+
+	if len(answers) > 0 {
+		fields["answers"] = answers
+	}
+	if len(resolved) > 0 {
+		fields["resolved_ip"] = resolved
+	}
+	return fields, rdata
+}
+
+// rrData returns the RDATA of the record. Names are returned without the
+// trailing dot, and the strings of TXT records are joined.
+func rrData(rr dns.RR) string {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String()
+	case *dns.AAAA:
+		return rr.AAAA.String()
+	case *dns.CNAME:
+		return strings.TrimSuffix(rr.Target, ".")
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", rr.Preference, strings.TrimSuffix(rr.Mx, "."))
+	case *dns.TXT:
+		return strings.Join(rr.Txt, "")
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %d %s", rr.Priority, rr.Weight, rr.Port, strings.TrimSuffix(rr.Target, "."))
+	default:
+		return strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+}
+
+// checkAnswers verifies that all expected values are in the RDATA of the
+// answers of the query type.
+func checkAnswers(expected []string, rdata []string, qtype uint16) error {
+	var missing []string
+	for _, want := range expected {
+		found := false
+		for _, data := range rdata {
+			if answerMatches(qtype, want, data) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+
+	if len(missing) > 0 {
+		if len(rdata) == 0 {
+			return reason.ValidateFailed(errors.New("no answers received"))
+		}
+		return reason.ValidateFailed(fmt.Errorf("answers %v do not contain %v", rdata, missing))
+	}
+	return nil
+}
+
+// answerMatches compares an expected value with the RDATA of an answer.
+// Addresses are compared as IPs, so that any notation of an IPv6 address
+// matches, the strings of TXT records exactly, and the other records
+// case-insensitively, without the trailing dots of their names.
+func answerMatches(qtype uint16, want, data string) bool {
+	switch qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		ip := net.ParseIP(want)
+		return ip != nil && ip.Equal(net.ParseIP(data))
+	case dns.TypeTXT:
+		return want == data
+	default:
+		return strings.EqualFold(trimNameDots(want), trimNameDots(data))
+	}
+}
+
+// trimNameDots removes the trailing dots of the names in the fields of s.
+func trimNameDots(s string) string {
+	fields := strings.Fields(s)
+	for i, field := range fields {
+		fields[i] = strings.TrimSuffix(field, ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+func headerFlags(m *dns.Msg) []string {
+	var flags []string
+	if m.Authoritative {
+		flags = append(flags, "AA")
+	}
+	if m.Truncated {
+		flags = append(flags, "TC")
+	}
+	if m.RecursionDesired {
+		flags = append(flags, "RD")
+	}
+	if m.RecursionAvailable {
+		flags = append(flags, "RA")
+	}
+	if m.AuthenticatedData {
+		flags = append(flags, "AD")
+	}
+	if m.CheckingDisabled {
+		flags = append(flags, "CD")
+	}
+	if opt := m.IsEdns0(); opt != nil && opt.Do() {
+		flags = append(flags, "DO")
+	}
+	return flags
+}
+
+func rcodeName(rcode int) string {
+	if name, found := dns.RcodeToString[rcode]; found {
+		return name
+	}
+	return strconv.Itoa(rcode)
+}
@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func serveDNS(t *testing.T) (stop func() error, addr string) {
+	l, err := net.ListenPacket("udp4", "localhost:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{PacketConn: l, Handler: dns.HandlerFunc(fakeDNSHandler)}
+	go server.ActivateAndServe()
+	return server.Shutdown, l.LocalAddr().String()
+}
+
+func fakeDNSHandler(w dns.ResponseWriter, msg *dns.Msg) {
+	records := map[string][]string{
+		"www.example.com.": {
+			"www.example.com. 60 IN CNAME web.example.com.",
+			"web.example.com. 60 IN A 10.0.0.1",
+			"web.example.com. 60 IN A 10.0.0.2",
+		},
+		"example.com.": {
+			"example.com. 300 IN MX 10 mail.example.com.",
+			"example.com. 300 IN TXT \"v=spf1 \" \"-all\"",
+			"example.com. 300 IN NS ns1.example.com.",
+		},
+		"ipv6.example.com.": {
+			"ipv6.example.com. 60 IN AAAA fd00::1",
+		},
+		"signed.example.com.": {
+			"signed.example.com. 60 IN A 10.0.0.3",
+		},
+	}
+
+	m := new(dns.Msg)
+	m.SetReply(msg)
+	q := msg.Question[0]
+	rrs, found := records[q.Name]
+	if !found {
+		m.Rcode = dns.RcodeNameError
+	}
+	for _, record := range rrs {
+		rr, _ := dns.NewRR(record)
+		if rr.Header().Rrtype == q.Qtype || rr.Header().Rrtype == dns.TypeCNAME {
+			m.Answer = append(m.Answer, rr)
+		}
+	}
+	if opt := msg.IsEdns0(); opt != nil {
+		m.SetEdns0(opt.UDPSize(), opt.Do())
+		m.AuthenticatedData = opt.Do() && q.Name == "signed.example.com."
+	}
+	w.WriteMsg(m)
+}
+
+func runQuery(t *testing.T, settings map[string]interface{}) (*beat.Event, error) {
+	cfg, err := common.NewConfigFrom(settings)
+	require.NoError(t, err)
+
+	js, endpoints, err := create("dns", cfg)
+	require.NoError(t, err)
+	require.Equal(t, 1, endpoints)
+
+	event := &beat.Event{Fields: common.MapStr{}}
+	_, err = js[0](event)
+	return event, err
+}
+
+func TestQuery(t *testing.T) {
+	stop, addr := serveDNS(t)
+	defer stop()
+
+	event, err := runQuery(t, map[string]interface{}{
+		"hosts":                  "www.example.com",
+		"nameservers":            addr,
+		"check.response.answers": []string{"10.0.0.2"},
+	})
+	require.NoError(t, err)
+
+	fields, err := event.Fields.GetValue("dns")
+	require.NoError(t, err)
+	dnsFields := fields.(common.MapStr)
+	assert.Equal(t, "www.example.com.", dnsFields["question"].(common.MapStr)["name"])
+	assert.Equal(t, "A", dnsFields["question"].(common.MapStr)["type"])
+	assert.Equal(t, "NOERROR", dnsFields["response_code"])
+	assert.Equal(t, []string{"RD"}, dnsFields["header_flags"])
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, dnsFields["resolved_ip"])
+	assert.Len(t, dnsFields["answers"], 3)
+	assert.Equal(t, "web.example.com", dnsFields["answers"].([]common.MapStr)[0]["data"])
+	assert.Contains(t, dnsFields, "rtt")
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "dns://"+addr+"/www.example.com?type=A", full)
+}
+
+func TestQueryChecks(t *testing.T) {
+	stop, addr := serveDNS(t)
+	defer stop()
+
+	tests := map[string]struct {
+		settings map[string]interface{}
+		err      string
+	}{
+		"mx":                {map[string]interface{}{"hosts": "example.com", "query_type": "mx", "check.response.answers": "10 mail.example.com."}, ""},
+		"txt":               {map[string]interface{}{"hosts": "example.com", "query_type": "TXT", "check.response.answers": "v=spf1 -all"}, ""},
+		"mx case":           {map[string]interface{}{"hosts": "example.com", "query_type": "mx", "check.response.answers": "10 MAIL.example.com"}, ""},
+		"ns":                {map[string]interface{}{"hosts": "example.com", "query_type": "NS", "check.response.answers": "ns1.example.com"}, ""},
+		"txt case":          {map[string]interface{}{"hosts": "example.com", "query_type": "TXT", "check.response.answers": "V=SPF1 -ALL"}, "do not contain [V=SPF1 -ALL]"},
+		"aaaa":              {map[string]interface{}{"hosts": "ipv6.example.com", "query_type": "AAAA", "check.response.answers": "FD00:0:0:0:0:0:0:1"}, ""},
+		"aaaa mismatch":     {map[string]interface{}{"hosts": "ipv6.example.com", "query_type": "AAAA", "check.response.answers": "fd00::2"}, "do not contain [fd00::2]"},
+		"ipv4 mapped":       {map[string]interface{}{"hosts": "www.example.com", "check.response.answers": "::ffff:10.0.0.2"}, ""},
+		"missing answer":    {map[string]interface{}{"hosts": "www.example.com", "check.response.answers": "10.0.0.9"}, "do not contain [10.0.0.9]"},
+		"no answers":        {map[string]interface{}{"hosts": "www.example.com", "query_type": "AAAA", "check.response.answers": "fd00::1"}, "no answers received"},
+		"nxdomain":          {map[string]interface{}{"hosts": "missing.example.com"}, "returned NXDOMAIN, expected NOERROR"},
+		"expected nxdomain": {map[string]interface{}{"hosts": "missing.example.com", "check.response.rcode": "NXDOMAIN"}, ""},
+		"dnssec":            {map[string]interface{}{"hosts": "signed.example.com", "dnssec": true}, ""},
+		"dnssec unsigned":   {map[string]interface{}{"hosts": "www.example.com", "dnssec": true}, "did not authenticate the answer"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.settings["nameservers"] = addr
+			_, err := runQuery(t, test.settings)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+				assert.IsType(t, reason.ValidateError{}, err)
+			}
+		})
+	}
+}
+
+func TestQueryUnreachable(t *testing.T) {
+	l, err := net.ListenPacket("udp4", "localhost:0")
+	require.NoError(t, err)
+	addr := l.LocalAddr().String()
+	l.Close()
+
+	_, err = runQuery(t, map[string]interface{}{"hosts": "www.example.com", "nameservers": addr, "timeout": "1s"})
+	assert.IsType(t, reason.IOError{}, err)
+}
+
+func TestRRData(t *testing.T) {
+	for record, data := range map[string]string{
+		"example.com. 60 IN AAAA fd00::1":                             "fd00::1",
+		"_sip._tcp.example.com. 60 IN SRV 10 5 5060 sip.example.com.": "10 5 5060 sip.example.com",
+		"example.com. 60 IN NS ns1.example.com.":                      "ns1.example.com.",
+	} {
+		rr, err := dns.NewRR(record)
+		require.NoError(t, err)
+		assert.Equal(t, data, rrData(rr))
+	}
+}
+
+func TestSystemNameservers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dns")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "resolv.conf")
+	require.NoError(t, ioutil.WriteFile(path, []byte("nameserver 10.0.0.53\nnameserver fd00::53\n"), 0644))
+
+	nameservers, err := systemNameservers(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.53:53", "[fd00::53]:53"}, nameservers)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("search example.com\n"), 0644))
+	_, err = systemNameservers(path)
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{"hosts": "example.com", "query_type": "BOGUS"},
+		{"hosts": "example.com", "check.response.rcode": "BOGUS"},
+		{"query_type": "A"},
+	} {
+		cfg, err := common.NewConfigFrom(settings)
+		require.NoError(t, err)
+		c := defaultConfig()
+		assert.Error(t, cfg.Unpack(&c), settings)
+	}
+}
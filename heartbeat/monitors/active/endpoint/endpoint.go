@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package endpoint connects the monitors of protocols running over TCP, like
+// redis or ldap, to the servers they check. The connections are made through a
+// dialer chain honoring the IP settings, the resolver and the SOCKS5 proxy of
+// the monitor, and report the TLS metadata of the checks.
+package endpoint
+
+import (
+	cryptoTLS "crypto/tls"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// Config holds the connection settings of the monitors, it is inlined in their
+// configuration.
+type Config struct {
+	Mode monitors.IPSettings `config:",inline"`
+
+	Socks5 transport.ProxyConfig `config:",inline"`
+
+	// configure tls
+	TLS *tlscommon.Config `config:"ssl"`
+
+	Timeout time.Duration `config:"timeout"`
+}
+
+// DefaultConfig is the default connection settings of the monitors.
+var DefaultConfig = Config{
+	Mode:    monitors.DefaultIPSettings,
+	Timeout: 16 * time.Second,
+}
+
+// Protocol describes the URLs of the servers of a protocol.
+type Protocol struct {
+	Scheme string
+	Port   int
+
+	// scheme and port of the servers encrypting the connections from the
+	// start, they default to the plain ones
+	TLSScheme string
+	TLSPort   int
+}
+
+// URL returns the URL of the server at host, with the default port of the
+// protocol if the host has none, like rediss://redis.example.net:6379.
+func (p Protocol) URL(host string, tls bool) *url.URL {
+	scheme, port := p.Scheme, p.Port
+	if tls {
+		if p.TLSScheme != "" {
+			scheme = p.TLSScheme
+		}
+		if p.TLSPort != 0 {
+			port = p.TLSPort
+		}
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	return &url.URL{Scheme: scheme, Host: host}
+}
+
+// Jobs returns a job per host, checking the URL of the host with the job
+// returned by makeJob.
+func Jobs(hosts []string, protocol Protocol, tls bool, makeJob func(u *url.URL) jobs.Job) []jobs.Job {
+	js := make([]jobs.Job, 0, len(hosts))
+	for _, host := range hosts {
+		u := protocol.URL(host, tls)
+		js = append(js, wrappers.WithURLField(u, makeJob(u)))
+	}
+	return js
+}
+
+// Dialer connects to the servers with the settings of a monitor.
+type Dialer struct {
+	config    *Config
+	tlsConfig *tlscommon.TLSConfig
+	resolver  monitors.Resolver
+}
+
+// NewDialer loads the TLS settings and the resolver of the configuration.
+func NewDialer(config *Config) (*Dialer, error) {
+	tlsConfig, err := tlscommon.LoadTLSConfigWithReload(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	d := &Dialer{config: config, tlsConfig: tlsConfig}
+	if len(config.Mode.Resolver) > 0 {
+		d.resolver, err = config.Mode.MakeResolver(config.Timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// TLSConfig returns the TLS settings of the monitor, nil if TLS is not
+// configured.
+func (d *Dialer) TLSConfig() *tlscommon.TLSConfig {
+	return d.tlsConfig
+}
+
+// Resolver returns the resolver of the monitor, the system resolver if no
+// nameservers are configured.
+func (d *Dialer) Resolver() monitors.Resolver {
+	if d.resolver == nil {
+		return monitors.NewStdResolver()
+	}
+	return d.resolver
+}
+
+// Build returns the dialer of a check, reporting the connection details to the
+// event. The connections are encrypted from the start if tls is set.
+func (d *Dialer) Build(event *beat.Event, tls bool) (transport.Dialer, error) {
+	dc := &dialchain.DialerChain{
+		Net: dialchain.CreateFamilyNetDialer(d.config.Timeout, d.config.Mode.IPFamily()),
+	}
+	if d.resolver != nil {
+		dc.Net = dialchain.CreateResolverNetDialer(d.config.Timeout, d.config.Mode.IPFamily(), d.resolver.LookupIP)
+	}
+	if d.config.Socks5.URL != "" {
+		dc.AddLayer(dialchain.SOCKS5Layer(&d.config.Socks5))
+	}
+	if tls {
+		dc.AddLayer(dialchain.TLSLayer(d.tlsConfig, d.config.Timeout))
+	}
+	return dc.Build(event)
+}
+
+// Dial connects to the server at address for a check.
+func (d *Dialer) Dial(event *beat.Event, address string, tls bool) (net.Conn, error) {
+	dialer, err := d.Build(event, tls)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.Dial("tcp", address)
+}
+
+// StartTLS encrypts an established connection to host, for protocols
+// negotiating TLS once connected.
+func (d *Dialer) StartTLS(event *beat.Event, conn net.Conn, host string) (*cryptoTLS.Conn, error) {
+	start := time.Now()
+	tlsConn := cryptoTLS.Client(conn, d.tlsConfig.BuildModuleConfig(host))
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	tlsmeta.AddTLSMetadata(event.Fields, tlsConn.ConnectionState(), time.Since(start))
+	return tlsConn, nil
+}
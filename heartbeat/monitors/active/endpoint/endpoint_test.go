@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package endpoint
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+func TestProtocolURL(t *testing.T) {
+	protocol := Protocol{Scheme: "redis", Port: 6379, TLSScheme: "rediss"}
+
+	cases := []struct {
+		host     string
+		tls      bool
+		expected string
+	}{
+		{"localhost", false, "redis://localhost:6379"},
+		{"localhost:6380", false, "redis://localhost:6380"},
+		{"localhost", true, "rediss://localhost:6379"},
+		{"::1", false, "redis://[::1]:6379"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, protocol.URL(c.host, c.tls).String(), c.host)
+	}
+
+	implicit := Protocol{Scheme: "smtp", Port: 25, TLSPort: 465}
+	assert.Equal(t, "smtp://localhost:465", implicit.URL("localhost", true).String())
+}
+
+func TestDial(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+
+	config := DefaultConfig
+	dialer, err := NewDialer(&config)
+	require.NoError(t, err)
+
+	event := &beat.Event{Fields: common.MapStr{}}
+	conn, err := dialer.Dial(event, listener.Addr().String(), false)
+	require.NoError(t, err)
+	conn.Close()
+
+	rtt, err := event.Fields.GetValue("tcp.rtt.connect.us")
+	require.NoError(t, err)
+	assert.NotNil(t, rtt)
+	_, err = event.Fields.GetValue("tls")
+	assert.Error(t, err)
+}
+
+func TestDialTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	config := DefaultConfig
+	config.TLS = &tlscommon.Config{VerificationMode: tlscommon.VerifyNone}
+	dialer, err := NewDialer(&config)
+	require.NoError(t, err)
+
+	t.Run("from the start", func(t *testing.T) {
+		event := &beat.Event{Fields: common.MapStr{}}
+		conn, err := dialer.Dial(event, address, true)
+		require.NoError(t, err)
+		conn.Close()
+
+		established, err := event.Fields.GetValue("tls.established")
+		require.NoError(t, err)
+		assert.Equal(t, true, established)
+	})
+
+	t.Run("once connected", func(t *testing.T) {
+		event := &beat.Event{Fields: common.MapStr{}}
+		conn, err := dialer.Dial(event, address, false)
+		require.NoError(t, err)
+		tlsConn, err := dialer.StartTLS(event, conn, "127.0.0.1")
+		require.NoError(t, err)
+		tlsConn.Close()
+
+		established, err := event.Fields.GetValue("tls.established")
+		require.NoError(t, err)
+		assert.Equal(t, true, established)
+	})
+}
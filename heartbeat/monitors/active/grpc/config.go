@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+)
+
+type config struct {
+	endpoint.Config `config:",inline"`
+
+	// host:port of the gRPC servers
+	Hosts []string `config:"hosts" validate:"required"`
+
+	// names of the services to check, each one is checked by a separate job.
+	// The empty name checks the overall health of the server.
+	Services []string `config:"services"`
+
+	// metadata sent with each health check call
+	Metadata map[string]string `config:"metadata"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	for _, host := range c.Hosts {
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			return fmt.Errorf("invalid host '%v', use host:port: %v", host, err)
+		}
+	}
+	return nil
+}
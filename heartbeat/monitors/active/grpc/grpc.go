@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("grpc", create)
+	monitors.RegisterActive("synthetics/grpc", create)
+}
+
+var debugf = logp.MakeDebug("grpc")
+
+func create(
+	name string,
+	cfg *common.Config,
+) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	services := config.Services
+	if len(services) == 0 {
+		services = []string{""}
+	}
+	for _, host := range config.Hosts {
+		for _, service := range services {
+			job, err := makeHostJob(&config, dialer, host, service)
+			if err != nil {
+				return nil, 0, err
+			}
+			u := checkURL(host, service, dialer.TLSConfig() != nil)
+			js = append(js, wrappers.WithURLField(u, job))
+		}
+	}
+	return js, len(js), nil
+}
+
+// checkURL returns the URL identifying the health check of the service, like
+// grpcs://10.0.0.1:50051/payments.
+func checkURL(host, service string, secure bool) *url.URL {
+	u := &url.URL{Scheme: "grpc", Host: host, Path: "/" + service}
+	if secure {
+		u.Scheme = "grpcs"
+	}
+	return u
+}
+
+// makeHostJob returns the job checking the service on host. Like the tcp
+// monitor, the host name is resolved first, following the IP settings and the
+// resolver of the monitor, and the resolved addresses are checked, unless the
+// SOCKS5 proxy resolves it.
+func makeHostJob(config *config, dialer *endpoint.Dialer, host, service string) (jobs.Job, error) {
+	if config.Socks5.URL != "" && !config.Socks5.LocalResolve {
+		return jobs.MakeSimpleJob(func(event *beat.Event) error {
+			return check(event, config, dialer, host, host, service)
+		}), nil
+	}
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return nil, err
+	}
+	return monitors.MakeByHostJob(hostname, config.Mode, dialer.Resolver(), monitors.MakePingIPFactory(
+		func(event *beat.Event, ip *net.IPAddr) error {
+			return check(event, config, dialer, net.JoinHostPort(ip.String(), port), host, service)
+		}))
+}
+
+// dialOptions returns the options connecting to addr through the dialer chain
+// of the monitor, and authenticating host if TLS is enabled. gRPC may connect
+// again in the background, only the first connection is reported to the
+// event.
+func dialOptions(event *beat.Event, dialer *endpoint.Dialer, host string) []grpc.DialOption {
+	var dialed int32
+	opts := []grpc.DialOption{
+		grpc.WithAuthority(host),
+		grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+			target := event
+			if !atomic.CompareAndSwapInt32(&dialed, 0, 1) {
+				target = &beat.Event{Fields: common.MapStr{}}
+			}
+			d, err := dialer.Build(target, false)
+			if err != nil {
+				return nil, err
+			}
+			return d.Dial("tcp", addr)
+		}),
+	}
+
+	tlsConfig := dialer.TLSConfig()
+	if tlsConfig == nil {
+		return append(opts, grpc.WithInsecure())
+	}
+	name, _, err := net.SplitHostPort(host)
+	if err != nil {
+		name = host
+	}
+	creds := credentials.NewTLS(tlsConfig.BuildModuleConfig(name))
+	return append(opts, grpc.WithTransportCredentials(creds))
+}
+
+// check calls the health service of the server at addr, host is the
+// configured address of the server.
+func check(event *beat.Event, config *config, dialer *endpoint.Dialer, addr, host, service string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, dialOptions(event, dialer, host)...)
+	if err != nil {
+		return reason.IOFailed(err)
+	}
+	defer conn.Close()
+
+	if len(config.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(config.Metadata))
+	}
+
+	// The connection is established by the first call, which fails with
+	// the Unavailable code if the server can not be reached
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	rtt := time.Since(start)
+
+	fields := common.MapStr{
+		"health":      common.MapStr{"service": service},
+		"status_code": status.Code(err).String(),
+		"rtt":         look.RTT(rtt),
+	}
+	if resp != nil {
+		fields.Put("health.status", resp.Status.String())
+	}
+	eventext.MergeEventFields(event, common.MapStr{"grpc": fields})
+
+	if err != nil {
+		debugf("health check of %v on %v failed with: %v", service, addr, err)
+		return checkError(service, err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return reason.ValidateFailed(fmt.Errorf("service '%v' is %v", service, resp.Status))
+	}
+	return nil
+}
+
+// checkError returns the reason of a failed health check call. Calls that
+// could not reach the server are IO failures, the others are answers of the
+// server.
+func checkError(service string, err error) reason.Reason {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return reason.IOFailed(err)
+	case codes.ResourceExhausted:
+		return reason.Throttled(err, time.Time{})
+	case codes.NotFound:
+		return reason.ValidateFailed(fmt.Errorf("service '%v' is unknown to the health service", service))
+	case codes.Unimplemented:
+		return reason.ValidateFailed(errors.New("the server does not implement the grpc.health.v1.Health service"))
+	}
+	return reason.ValidateFailed(err)
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/armon/go-socks5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// metadataRecorder records the metadata of the calls to the server.
+type metadataRecorder struct {
+	mtx  sync.Mutex
+	last metadata.MD
+}
+
+func (r *metadataRecorder) intercept(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r.mtx.Lock()
+	r.last, _ = metadata.FromIncomingContext(ctx)
+	r.mtx.Unlock()
+	return handler(ctx, req)
+}
+
+func serveHealth(t *testing.T) (stop func(), addr string, recorder *metadataRecorder) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	recorder = &metadataRecorder{}
+	server := grpc.NewServer(grpc.UnaryInterceptor(recorder.intercept))
+	hs := health.NewServer()
+	hs.SetServingStatus("payments", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("ledger", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, hs)
+
+	go server.Serve(l)
+	return server.Stop, l.Addr().String(), recorder
+}
+
+func TestCheck(t *testing.T) {
+	stop, addr, recorder := serveHealth(t)
+	defer stop()
+
+	events, errs := hbtest.RunJobs(t, create, map[string]interface{}{
+		"hosts":    addr,
+		"services": []string{"", "payments", "ledger", "unknown"},
+		"metadata": map[string]string{"authorization": "Bearer secret"},
+	})
+	require.Len(t, events, 4)
+
+	// The overall health of the server and the serving service are up
+	for i, service := range []string{"", "payments"} {
+		require.NoError(t, errs[i])
+		fields, err := events[i].Fields.GetValue("grpc")
+		require.NoError(t, err)
+		grpcFields := fields.(common.MapStr)
+		assert.Equal(t, "OK", grpcFields["status_code"])
+		assert.Equal(t, common.MapStr{"service": service, "status": "SERVING"}, grpcFields["health"])
+		assert.Contains(t, grpcFields, "rtt")
+
+		full, err := events[i].Fields.GetValue("url.full")
+		require.NoError(t, err)
+		assert.Equal(t, "grpc://"+addr+"/"+service, full)
+	}
+
+	require.Error(t, errs[2])
+	assert.Equal(t, "validate", errs[2].(reason.Reason).Type())
+	assert.Contains(t, errs[2].Error(), "NOT_SERVING")
+	status, err := events[2].Fields.GetValue("grpc.health.status")
+	require.NoError(t, err)
+	assert.Equal(t, "NOT_SERVING", status)
+
+	require.Error(t, errs[3])
+	assert.Equal(t, "validate", errs[3].(reason.Reason).Type())
+	code, err := events[3].Fields.GetValue("grpc.status_code")
+	require.NoError(t, err)
+	assert.Equal(t, "NotFound", code)
+
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+	assert.Equal(t, []string{"Bearer secret"}, recorder.last.Get("authorization"))
+}
+
+func TestCheckConnection(t *testing.T) {
+	stop, addr, _ := serveHealth(t)
+	defer stop()
+	_, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	host := net.JoinHostPort("localhost", port)
+
+	proxy, err := socks5.New(&socks5.Config{})
+	require.NoError(t, err)
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer proxyListener.Close()
+	go proxy.Serve(proxyListener)
+	proxyURL := "socks5://" + proxyListener.Addr().String()
+
+	tests := map[string]struct {
+		settings map[string]interface{}
+		fields   []string
+		missing  []string
+	}{
+		"resolved": {
+			settings: map[string]interface{}{"hosts": host, "ipv6": false},
+			fields:   []string{"resolve.ip", "resolve.rtt.us", "monitor.ip", "tcp.rtt.connect.us"},
+		},
+		"resolved by the proxy": {
+			settings: map[string]interface{}{"hosts": host, "proxy_url": proxyURL},
+			fields:   []string{"socks5.rtt.connect.us", "tcp.rtt.connect.us"},
+			missing:  []string{"resolve", "monitor.ip"},
+		},
+		"resolved locally with a proxy": {
+			settings: map[string]interface{}{"hosts": host, "ipv6": false, "proxy_url": proxyURL, "proxy_use_local_resolver": true},
+			fields:   []string{"resolve.ip", "socks5.rtt.connect.us", "tcp.rtt.connect.us"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, err := hbtest.RunJob(t, create, test.settings)
+			require.NoError(t, err)
+
+			for _, field := range test.fields {
+				_, err := event.Fields.GetValue(field)
+				assert.NoError(t, err, field)
+			}
+			for _, field := range test.missing {
+				_, err := event.Fields.GetValue(field)
+				assert.Error(t, err, field)
+			}
+			if ip, err := event.Fields.GetValue("resolve.ip"); err == nil {
+				assert.Equal(t, "127.0.0.1", ip)
+			}
+		})
+	}
+
+	// Hosts of another IP family are not checked
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": host, "ipv4": false, "timeout": "1s"})
+	assert.Error(t, err)
+}
+
+func TestCheckUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	events, errs := hbtest.RunJobs(t, create, map[string]interface{}{
+		"hosts":   addr,
+		"timeout": "1s",
+	})
+	require.Error(t, errs[0])
+	assert.Equal(t, "io", errs[0].(reason.Reason).Type())
+	code, err := events[0].Fields.GetValue("grpc.status_code")
+	require.NoError(t, err)
+	assert.Equal(t, "Unavailable", code)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Hosts = []string{"localhost:50051"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Hosts = []string{"localhost"}
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// authorizer adds authentication to check requests. It is called on every
+// check, right before the request is sent.
+type authorizer interface {
+	authorize(req *http.Request, body []byte) error
+}
+
+// makeAuthorizer returns the authorizer configured for the monitor, or nil if
+// requests are not authorized beyond basic auth.
+func makeAuthorizer(config *Config, tls *tlscommon.TLSConfig) (authorizer, error) {
+	switch {
+	case config.OAuth2 != nil:
+		return newOAuth2Authorizer(config, tls)
+	case config.AWS != nil:
+		return newRegisteredAuthorizer("aws", config.AWS)
+	case config.Kerberos.IsEnabled():
+		return newKerberosAuthorizer(config.Kerberos), nil
+	default:
+		return nil, nil
+	}
+}
+
+// Authorizer adds authentication to check requests, for the authentication
+// settings registered by other packages, like aws in x-pack. The request
+// context is the context of the check.
+type Authorizer interface {
+	Authorize(req *http.Request, body []byte) error
+}
+
+// AuthorizerFactory creates an Authorizer from its configuration.
+type AuthorizerFactory func(config *common.Config) (Authorizer, error)
+
+var authorizers = map[string]AuthorizerFactory{}
+
+// RegisterAuthorizer registers the factory of the authorizers configured by
+// the name setting of HTTP monitors. It must be called from init.
+func RegisterAuthorizer(name string, factory AuthorizerFactory) {
+	if _, found := authorizers[name]; found {
+		panic(fmt.Sprintf("HTTP authorizer '%v' is already registered", name))
+	}
+	authorizers[name] = factory
+}
+
+func newRegisteredAuthorizer(name string, config *common.Config) (authorizer, error) {
+	factory, found := authorizers[name]
+	if !found {
+		return nil, fmt.Errorf("%v is not supported by this distribution of Heartbeat", name)
+	}
+	a, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v configuration: %w", name, err)
+	}
+	return registeredAuthorizer{a}, nil
+}
+
+type registeredAuthorizer struct {
+	Authorizer
+}
+
+func (a registeredAuthorizer) authorize(req *http.Request, body []byte) error {
+	return a.Authorize(req, body)
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+type headerAuthorizer struct {
+	token string
+}
+
+func (a headerAuthorizer) Authorize(req *http.Request, _ []byte) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func TestRegisteredAuthorizer(t *testing.T) {
+	config := &Config{AWS: common.MustNewConfigFrom(common.MapStr{"token": "secret"})}
+
+	// aws is registered by x-pack
+	_, err := makeAuthorizer(config, nil)
+	assert.Error(t, err)
+
+	RegisterAuthorizer("aws", func(cfg *common.Config) (Authorizer, error) {
+		var settings struct {
+			Token string `config:"token"`
+		}
+		if err := cfg.Unpack(&settings); err != nil {
+			return nil, err
+		}
+		if settings.Token == "" {
+			return nil, errors.New("missing token")
+		}
+		return headerAuthorizer{settings.Token}, nil
+	})
+	defer delete(authorizers, "aws")
+
+	auth, err := makeAuthorizer(config, nil)
+	require.NoError(t, err)
+	req, err := http.NewRequest("GET", "http://localhost", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.authorize(req, nil))
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+	config.AWS = common.NewConfig()
+	_, err = makeAuthorizer(config, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// requestBody builds the encoded body of the check requests. Bodies read from
+// a file or rendered from a template are built for each check, so that a
+// regenerated file is sent without restarting the monitor.
+type requestBody struct {
+	// encoded is the body of every request if it is static
+	encoded []byte
+
+	file       string
+	isTemplate bool
+	// template is the parsed inline body, if it is a template
+	template *template.Template
+
+	// encoders reuse their compression state, building bodies of concurrent
+	// checks is serialized
+	mu  sync.Mutex
+	enc contentEncoder
+}
+
+// bodyTemplateData is the data body templates are rendered with.
+type bodyTemplateData struct {
+	Now time.Time
+	URL string
+}
+
+func newRequestBody(r *requestParameters, enc contentEncoder) (*requestBody, error) {
+	b := &requestBody{file: r.SendBodyFile, isTemplate: r.BodyTemplate, enc: enc}
+
+	switch {
+	case b.file != "":
+		// Fail early if the file can not be read, or is not a valid template
+		content, err := ioutil.ReadFile(b.file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read body_file: %v", err)
+		}
+		if b.isTemplate {
+			if _, err := parseBodyTemplate(string(content)); err != nil {
+				return nil, err
+			}
+		}
+	case b.isTemplate:
+		tmpl, err := parseBodyTemplate(r.SendBody)
+		if err != nil {
+			return nil, err
+		}
+		b.template = tmpl
+	case r.SendBody != "":
+		encoded, err := b.encode(r.SendBody)
+		if err != nil {
+			return nil, err
+		}
+		b.encoded = encoded
+	}
+	return b, nil
+}
+
+// build returns the encoded body of a request to the given URL.
+func (b *requestBody) build(url string) ([]byte, error) {
+	if b.file == "" && b.template == nil {
+		return b.encoded, nil
+	}
+
+	tmpl := b.template
+	var src string
+	if b.file != "" {
+		content, err := ioutil.ReadFile(b.file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read body_file: %v", err)
+		}
+		src = string(content)
+		if b.isTemplate {
+			if tmpl, err = parseBodyTemplate(src); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if tmpl != nil {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, bodyTemplateData{Now: time.Now().UTC(), URL: url}); err != nil {
+			return nil, fmt.Errorf("could not render the request body template: %v", err)
+		}
+		src = sb.String()
+	}
+	return b.encode(src)
+}
+
+func (b *requestBody) encode(body string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	buf := bytes.NewBuffer(nil)
+	if err := b.enc.Encode(buf, strings.NewReader(body)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parseBodyTemplate(src string) (*template.Template, error) {
+	tmpl, err := template.New("body").Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request body template: %v", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBodyStatic(t *testing.T) {
+	b, err := newRequestBody(&requestParameters{SendBody: `{"id": 1}`}, plainEncoder)
+	require.NoError(t, err)
+
+	body, err := b.build("http://localhost")
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": 1}`, string(body))
+
+	b, err = newRequestBody(&requestParameters{}, plainEncoder)
+	require.NoError(t, err)
+	body, err = b.build("http://localhost")
+	require.NoError(t, err)
+	assert.Empty(t, body)
+}
+
+func TestRequestBodyFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "hb_body")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(`{"target": "{{.URL}}"}`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	b, err := newRequestBody(&requestParameters{SendBodyFile: file.Name()}, plainEncoder)
+	require.NoError(t, err)
+	body, err := b.build("http://localhost/api")
+	require.NoError(t, err)
+	assert.Equal(t, `{"target": "{{.URL}}"}`, string(body))
+
+	b, err = newRequestBody(&requestParameters{SendBodyFile: file.Name(), BodyTemplate: true}, plainEncoder)
+	require.NoError(t, err)
+	body, err = b.build("http://localhost/api")
+	require.NoError(t, err)
+	assert.Equal(t, `{"target": "http://localhost/api"}`, string(body))
+
+	// The file is read for each check
+	require.NoError(t, ioutil.WriteFile(file.Name(), []byte(`{"id": 2}`), 0600))
+	body, err = b.build("http://localhost/api")
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": 2}`, string(body))
+
+	_, err = newRequestBody(&requestParameters{SendBodyFile: "/nonexistent/body.json"}, plainEncoder)
+	assert.Error(t, err)
+}
+
+func TestRequestBodyTemplate(t *testing.T) {
+	b, err := newRequestBody(&requestParameters{SendBody: `{{.Now.Year}}`, BodyTemplate: true}, plainEncoder)
+	require.NoError(t, err)
+	body, err := b.build("http://localhost")
+	require.NoError(t, err)
+	assert.Len(t, body, 4)
+
+	_, err = newRequestBody(&requestParameters{SendBody: `{{.Now`, BodyTemplate: true}, plainEncoder)
+	assert.Error(t, err)
+
+	b, err = newRequestBody(&requestParameters{SendBody: `{{.Missing}}`, BodyTemplate: true}, plainEncoder)
+	require.NoError(t, err)
+	_, err = b.build("http://localhost")
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	// charsetAuto detects the charset from the byte order mark of the body,
+	// or else from the Content-Type header. It is the default.
+	charsetAuto = "auto"
+	// charsetNone disables the decoding, the body is validated as received.
+	charsetNone = "none"
+)
+
+var byteOrderMarks = []struct {
+	bom      []byte
+	encoding encoding.Encoding
+}{
+	{[]byte{0xEF, 0xBB, 0xBF}, unicode.UTF8},
+	{[]byte{0xFF, 0xFE}, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)},
+	{[]byte{0xFE, 0xFF}, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)},
+}
+
+// validateCharset checks the charset configured for the response body is
+// auto, none or a known charset.
+func validateCharset(charset string) error {
+	switch strings.ToLower(charset) {
+	case "", charsetAuto, charsetNone:
+		return nil
+	}
+	if _, err := htmlindex.Get(charset); err != nil {
+		return fmt.Errorf("unknown charset '%s'", charset)
+	}
+	return nil
+}
+
+// decodeBody converts the body to UTF-8 so the validators work on text. A
+// byte order mark takes precedence over the configured charset, which takes
+// precedence over the charset of the Content-Type header. The body is returned
+// unchanged if it is UTF-8 or its charset is unknown.
+func decodeBody(resp *http.Response, body string, charset string) string {
+	if strings.EqualFold(charset, charsetNone) {
+		return body
+	}
+
+	for _, m := range byteOrderMarks {
+		if strings.HasPrefix(body, string(m.bom)) {
+			return decodeWith(m.encoding, body[len(m.bom):])
+		}
+	}
+
+	if charset == "" || strings.EqualFold(charset, charsetAuto) {
+		charset = contentTypeCharset(resp)
+		if charset == "" {
+			return body
+		}
+	}
+
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		debugf("not decoding the body in the unknown charset '%s'", charset)
+		return body
+	}
+	return decodeWith(enc, body)
+}
+
+func decodeWith(enc encoding.Encoding, body string) string {
+	if enc == unicode.UTF8 {
+		return body
+	}
+	decoded, err := enc.NewDecoder().String(body)
+	if err != nil {
+		debugf("failed to decode the body: %v", err)
+		return body
+	}
+	return decoded
+}
+
+// contentTypeCharset returns the charset parameter of the Content-Type header
+// of the response, if any.
+func contentTypeCharset(resp *http.Response) string {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
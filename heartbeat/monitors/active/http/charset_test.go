@@ -0,0 +1,109 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+func charsetResponse(contentType string, body string) *http.Response {
+	resp := simpleHTTPResponse(body)
+	resp.Header = http.Header{}
+	if contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	return resp
+}
+
+func TestDecodeBody(t *testing.T) {
+	utf16LE, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(`{"name": "café"}`)
+	require.NoError(t, err)
+	utf16BE, err := unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder().String("café")
+	require.NoError(t, err)
+	latin1, err := charmap.ISO8859_1.NewEncoder().String("café")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		contentType string
+		charset     string
+		body        string
+		want        string
+	}{
+		{"utf-8", "text/plain; charset=utf-8", "", "café", "café"},
+		{"no charset", "text/plain", "", "café", "café"},
+		{"no content type", "", "", "café", "café"},
+		{"utf-8 bom", "application/json", "", "\xEF\xBB\xBF{}", "{}"},
+		{"utf-16le bom", "application/json", "", utf16LE, `{"name": "café"}`},
+		{"utf-16be bom", "text/plain", "", utf16BE, "café"},
+		{"bom over content type", "text/plain; charset=iso-8859-1", "", utf16BE, "café"},
+		{"iso-8859-1", "text/html; charset=ISO-8859-1", "", latin1, "café"},
+		{"quoted charset", `text/html; charset="latin1"`, "auto", latin1, "café"},
+		{"configured charset", "text/plain; charset=utf-8", "iso-8859-1", latin1, "café"},
+		{"configured charset without content type", "", "windows-1252", latin1, "café"},
+		{"unknown charset", "text/plain; charset=x-unknown", "", latin1, latin1},
+		{"none", "text/plain; charset=iso-8859-1", "none", latin1, latin1},
+		{"none keeps the bom", "text/plain", "none", "\xEF\xBB\xBFcafé", "\xEF\xBB\xBFcafé"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := charsetResponse(tt.contentType, tt.body)
+			assert.Equal(t, tt.want, decodeBody(resp, tt.body, tt.charset))
+		})
+	}
+}
+
+func TestValidateCharset(t *testing.T) {
+	for _, charset := range []string{"", "auto", "none", "NONE", "utf-8", "ISO-8859-1", "utf-16le", "shift_jis"} {
+		assert.NoError(t, validateCharset(charset), charset)
+	}
+	assert.Error(t, validateCharset("x-unknown"))
+}
+
+func TestProcessBodyDecodesCharset(t *testing.T) {
+	body, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(`{"status": "green"}`)
+	require.NoError(t, err)
+
+	condition := &conditions.Config{}
+	require.NoError(t, common.MustNewConfigFrom(map[string]interface{}{
+		"equals": map[string]interface{}{"status": "green"},
+	}).Unpack(condition))
+	jsonCheck, err := checkJSON([]*jsonResponseCheck{{Description: "green", Condition: condition}})
+	require.NoError(t, err)
+	validator := multiValidator{bodyValidators: []bodyValidator{jsonCheck}}
+
+	resp := charsetResponse("application/json; charset=utf-16", body)
+	fields, _, _, errReason := processBody(resp, responseConfig{IncludeBody: "always", IncludeBodyMaxBytes: 1024}, validator)
+	require.Nil(t, errReason)
+	assert.Equal(t, `{"status": "green"}`, fields["content"])
+	assert.Equal(t, len(body), fields["bytes"])
+
+	// Without decoding the JSON can't be parsed
+	resp = charsetResponse("application/json; charset=utf-16", body)
+	_, _, _, errReason = processBody(resp, responseConfig{Charset: "none"}, validator)
+	require.NotNil(t, errReason)
+}
@@ -18,15 +18,19 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	pkgerrors "github.com/pkg/errors"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 	"github.com/elastic/beats/v7/heartbeat/reason"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
@@ -38,13 +42,31 @@ import (
 type multiValidator struct {
 	respValidators []respValidator
 	bodyValidators []bodyValidator
+	// faultValidators detect errors reported in the body, they run before all
+	// other validators so the reported error is not hidden by a status mismatch.
+	faultValidators []bodyValidator
+	// failures of the warn validators are reported as warnings, they don't
+	// mark the monitor down
+	warnRespValidators []respValidator
+	warnBodyValidators []bodyValidator
+	// maxRTT is the maximum total duration of the request, if set
+	maxRTT  time.Duration
+	warnRTT bool
+	// drift normalizes the body, its hash is reported with the body fields
+	drift *driftCheck
 }
 
 func (rv multiValidator) wantsBody() bool {
-	return len(rv.bodyValidators) > 0
+	return len(rv.bodyValidators) > 0 || len(rv.faultValidators) > 0 || len(rv.warnBodyValidators) > 0
 }
 
 func (rv multiValidator) validate(resp *http.Response, body string) reason.Reason {
+	for _, faultValidator := range rv.faultValidators {
+		if err := faultValidator(resp, body); err != nil {
+			return reason.ValidateFailed(err)
+		}
+	}
+
 	for _, respValidator := range rv.respValidators {
 		if err := respValidator(resp); err != nil {
 			return reason.ValidateFailed(err)
@@ -60,6 +82,30 @@ func (rv multiValidator) validate(resp *http.Response, body string) reason.Reaso
 	return nil
 }
 
+// warnings runs the warn validators and returns the messages of their failures.
+func (rv multiValidator) warnings(resp *http.Response, body string) []string {
+	var warnings []string
+	for _, respValidator := range rv.warnRespValidators {
+		if err := respValidator(resp); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	for _, bodyValidator := range rv.warnBodyValidators {
+		if err := bodyValidator(resp, body); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// checkRTT returns an error if the request took longer than the configured maximum.
+func (rv multiValidator) checkRTT(rtt time.Duration) error {
+	if rv.maxRTT > 0 && rtt > rv.maxRTT {
+		return fmt.Errorf("request took %v, exceeding the maximum of %v", rtt, rv.maxRTT)
+	}
+	return nil
+}
+
 // respValidator is used for validating using only the non-body fields of the *http.Response.
 // Accessing the body of the response in such a validator should not be done due, use bodyValidator
 // for those purposes instead.
@@ -69,26 +115,90 @@ type respValidator func(*http.Response) error
 // *http.Response.
 type bodyValidator func(*http.Response, string) error
 
+// dialedIPKey is the context key of the IP address the request of a monitor
+// checking each IP of a host is sent to.
+type dialedIPKey struct{}
+
+func withDialedIP(ctx context.Context, ip net.IP) context.Context {
+	return context.WithValue(ctx, dialedIPKey{}, ip)
+}
+
+// responseOrigin identifies the origin of a response by its URL and, if the
+// monitor checks each IP of the host, the IP address it was received from.
+// Checks comparing a response with the previous one keep their state per
+// origin, so that the responses of different IPs are not compared.
+func responseOrigin(r *http.Response) string {
+	origin := r.Request.URL.String()
+	if ip, ok := r.Request.Context().Value(dialedIPKey{}).(net.IP); ok {
+		origin += " " + ip.String()
+	}
+	return origin
+}
+
 var (
 	errBodyMismatch = errors.New("body mismatch")
 )
 
 func makeValidateResponse(config *responseParameters) (multiValidator, error) {
-	var respValidators []respValidator
-	var bodyValidators []bodyValidator
+	var mv multiValidator
+
+	// Validators are added by name, so the configured severity decides
+	// whether their failures are errors or warnings
+	addResp := func(name string, v respValidator) {
+		if config.Severity.warns(name) {
+			mv.warnRespValidators = append(mv.warnRespValidators, v)
+		} else {
+			mv.respValidators = append(mv.respValidators, v)
+		}
+	}
+	addBody := func(name string, v bodyValidator) {
+		if config.Severity.warns(name) {
+			mv.warnBodyValidators = append(mv.warnBodyValidators, v)
+		} else {
+			mv.bodyValidators = append(mv.bodyValidators, v)
+		}
+	}
 
 	if len(config.Status) > 0 {
-		respValidators = append(respValidators, checkStatus(config.Status))
+		addResp("status", checkStatus(config.Status))
 	} else {
-		respValidators = append(respValidators, checkStatusOK)
+		addResp("status", checkStatusOK)
 	}
 
 	if len(config.RecvHeaders) > 0 {
-		respValidators = append(respValidators, checkHeaders(config.RecvHeaders))
+		addResp("headers", checkHeaders(config.RecvHeaders))
+	}
+
+	if config.ALPN != "" {
+		addResp("alpn", checkALPN(config.ALPN))
+	}
+
+	if config.Certificate.NotValidAfterMin > 0 {
+		addResp("certificate", checkCertificateExpiry(config.Certificate.NotValidAfterMin, time.Now))
+	}
+
+	if config.Certificate.Pins.Enabled() {
+		addResp("certificate", checkCertificatePins(config.Certificate.Pins))
+	}
+
+	if config.TLS.Enabled() {
+		addResp("tls", checkTLSNegotiation(config.TLS))
+	}
+
+	if config.SecurityHeaders.Enabled {
+		addResp("security_headers", checkSecurityHeaders(config.SecurityHeaders))
 	}
 
 	if len(config.RecvBody) > 0 {
-		bodyValidators = append(bodyValidators, checkBody(config.RecvBody, config.PositiveCheckOnHTTPBody))
+		addBody("body", checkBody(config.RecvBody, config.PositiveCheckOnHTTPBody))
+	}
+
+	if config.BodyFileEquals != "" {
+		golden, err := newGoldenCheck(config.BodyFileEquals, config.BodyFileWhitespace)
+		if err != nil {
+			return multiValidator{}, err
+		}
+		addBody("body_file", golden.check)
 	}
 
 	if len(config.RecvJSON) > 0 {
@@ -96,10 +206,30 @@ func makeValidateResponse(config *responseParameters) (multiValidator, error) {
 		if err != nil {
 			return multiValidator{}, err
 		}
-		bodyValidators = append(bodyValidators, jsonChecks)
+		addBody("json", jsonChecks)
+	}
+
+	if len(config.RecvXPath) > 0 {
+		xpathChecks, err := checkXPath(config.RecvXPath)
+		if err != nil {
+			return multiValidator{}, err
+		}
+		addBody("xpath", xpathChecks)
+	}
+
+	if config.Drift.Enabled {
+		drift, err := newDriftCheck(config.Drift)
+		if err != nil {
+			return multiValidator{}, err
+		}
+		mv.drift = drift
+		addBody("drift", drift.check)
 	}
 
-	return multiValidator{respValidators, bodyValidators}, nil
+	mv.maxRTT = config.MaxRTT
+	mv.warnRTT = config.Severity.warns("rtt")
+
+	return mv, nil
 }
 
 func checkStatus(status []uint16) respValidator {
@@ -132,6 +262,66 @@ func checkHeaders(headers map[string]string) respValidator {
 	}
 }
 
+// checkALPN validates the protocol negotiated with the server, so a server
+// silently falling back to HTTP/1.1 is reported as down.
+func checkALPN(protocol string) respValidator {
+	return func(r *http.Response) error {
+		if negotiated := negotiatedProtocol(r); negotiated != protocol {
+			return fmt.Errorf("negotiated protocol '%v' expecting '%v'", negotiated, protocol)
+		}
+		return nil
+	}
+}
+
+// checkCertificateExpiry validates the certificate chain served by the host
+// is valid for at least the given duration.
+func checkCertificateExpiry(min time.Duration, now func() time.Time) respValidator {
+	return func(r *http.Response) error {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			return fmt.Errorf("no certificate served, expecting a certificate valid for at least %v", min)
+		}
+
+		var notAfter time.Time
+		for _, cert := range r.TLS.PeerCertificates {
+			if !cert.NotAfter.IsZero() && (notAfter.IsZero() || cert.NotAfter.Before(notAfter)) {
+				notAfter = cert.NotAfter
+			}
+		}
+		if notAfter.IsZero() {
+			return nil
+		}
+
+		remaining := notAfter.Sub(now())
+		if remaining <= 0 {
+			return fmt.Errorf("certificate expired at %v", notAfter.UTC().Format(time.RFC3339))
+		}
+		if remaining < min {
+			return fmt.Errorf("certificate expires at %v in %v, expecting at least %v",
+				notAfter.UTC().Format(time.RFC3339), remaining.Truncate(time.Second), min)
+		}
+		return nil
+	}
+}
+
+// checkCertificatePins validates the certificate served by the host matches
+// the pinned fingerprints.
+func checkCertificatePins(pins tlsmeta.Pins) respValidator {
+	return func(r *http.Response) error {
+		if r.TLS == nil {
+			return pins.Check(nil)
+		}
+		return pins.Check(r.TLS.PeerCertificates)
+	}
+}
+
+// checkTLSNegotiation validates the TLS version and cipher suite negotiated
+// with the host.
+func checkTLSNegotiation(negotiation tlsmeta.Negotiation) respValidator {
+	return func(r *http.Response) error {
+		return negotiation.Check(r.TLS)
+	}
+}
+
 func checkBody(matcher []match.Matcher, positiveCheck bool) bodyValidator {
 	return func(r *http.Response, body string) error {
 		for _, m := range matcher {
@@ -155,16 +345,24 @@ func checkJSON(checks []*jsonResponseCheck) (bodyValidator, error) {
 	type compiledCheck struct {
 		description string
 		condition   conditions.Condition
+		delta       *jsonDelta
 	}
 
 	var compiledChecks []compiledCheck
 
 	for _, check := range checks {
-		cond, err := conditions.NewCondition(check.Condition)
-		if err != nil {
-			return nil, err
+		compiled := compiledCheck{description: check.Description}
+		if check.Condition != nil || check.Delta == nil {
+			cond, err := conditions.NewCondition(check.Condition)
+			if err != nil {
+				return nil, err
+			}
+			compiled.condition = cond
+		}
+		if check.Delta != nil {
+			compiled.delta = newJSONDelta(check.Delta)
 		}
-		compiledChecks = append(compiledChecks, compiledCheck{check.Description, cond})
+		compiledChecks = append(compiledChecks, compiled)
 	}
 
 	return func(r *http.Response, body string) error {
@@ -182,9 +380,17 @@ func checkJSON(checks []*jsonResponseCheck) (bodyValidator, error) {
 
 		var errorDescs []string
 		for _, compiledCheck := range compiledChecks {
-			ok := compiledCheck.condition.Check(decoded)
-			if !ok {
+			// Deltas are always checked, to remember the value for the next run
+			var deltaErr error
+			if compiledCheck.delta != nil {
+				deltaErr = compiledCheck.delta.check(responseOrigin(r), *decoded)
+			}
+
+			switch {
+			case compiledCheck.condition != nil && !compiledCheck.condition.Check(decoded):
 				errorDescs = append(errorDescs, compiledCheck.description)
+			case deltaErr != nil:
+				errorDescs = append(errorDescs, fmt.Sprintf("%s (%v)", compiledCheck.description, deltaErr))
 			}
 		}
 
@@ -200,3 +406,62 @@ func checkJSON(checks []*jsonResponseCheck) (bodyValidator, error) {
 		return nil
 	}, nil
 }
+
+func checkXPath(checks []*xpathResponseCheck) (bodyValidator, error) {
+	type compiledCheck struct {
+		description string
+		expr        *xpathExpr
+		equals      *string
+		pattern     *match.Matcher
+	}
+
+	var compiledChecks []compiledCheck
+
+	for _, check := range checks {
+		expr, err := compileXPath(check.Expression, check.Namespaces)
+		if err != nil {
+			return nil, err
+		}
+		description := check.Description
+		if description == "" {
+			description = check.Expression
+		}
+		compiledChecks = append(compiledChecks, compiledCheck{description, expr, check.Equals, check.Pattern})
+	}
+
+	return func(r *http.Response, body string) error {
+		doc, err := parseXML(strings.NewReader(body))
+		if err != nil {
+			return pkgerrors.Wrap(err, "could not parse XML for body check with xpath")
+		}
+
+		var errorDescs []string
+		for _, compiledCheck := range compiledChecks {
+			matched := false
+			for _, node := range compiledCheck.expr.evaluate(doc) {
+				value := strings.TrimSpace(node.InnerText())
+				if compiledCheck.equals != nil && value != *compiledCheck.equals {
+					continue
+				}
+				if compiledCheck.pattern != nil && !compiledCheck.pattern.MatchString(value) {
+					continue
+				}
+				matched = true
+				break
+			}
+			if !matched {
+				errorDescs = append(errorDescs, compiledCheck.description)
+			}
+		}
+
+		if len(errorDescs) > 0 {
+			return fmt.Errorf(
+				"XML body did not match %d xpath checks '%s' for monitor",
+				len(errorDescs),
+				strings.Join(errorDescs, ","),
+			)
+		}
+
+		return nil
+	}, nil
+}
@@ -18,15 +18,20 @@
 package http
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/match"
 	"github.com/elastic/beats/v7/libbeat/conditions"
@@ -221,7 +226,7 @@ func TestCheckJson(t *testing.T) {
 				log.Fatal(err)
 			}
 
-			checker, err := checkJSON([]*jsonResponseCheck{{test.condDesc, test.condConf}})
+			checker, err := checkJSON([]*jsonResponseCheck{{Description: test.condDesc, Condition: test.condConf}})
 			require.NoError(t, err)
 			body, err := ioutil.ReadAll(res.Body)
 			require.NoError(t, err)
@@ -289,7 +294,7 @@ func TestCheckJsonWithIntegerComparison(t *testing.T) {
 				log.Fatal(err)
 			}
 
-			checker, err := checkJSON([]*jsonResponseCheck{{test.condDesc, test.condConf}})
+			checker, err := checkJSON([]*jsonResponseCheck{{Description: test.condDesc, Condition: test.condConf}})
 			require.NoError(t, err)
 			body, err := ioutil.ReadAll(res.Body)
 			require.NoError(t, err)
@@ -365,3 +370,256 @@ func TestCheckStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckALPN(t *testing.T) {
+	var tests = []struct {
+		description string
+		resp        *http.Response
+		expected    string
+		result      bool
+	}{
+		{
+			"h2 negotiated",
+			&http.Response{ProtoMajor: 2, TLS: &tls.ConnectionState{NegotiatedProtocol: "h2"}},
+			"h2",
+			true,
+		},
+		{
+			"fallback to http/1.1",
+			&http.Response{ProtoMajor: 1, ProtoMinor: 1, TLS: &tls.ConnectionState{}},
+			"h2",
+			false,
+		},
+		{
+			"http/1.1 negotiated",
+			&http.Response{ProtoMajor: 1, ProtoMinor: 1, TLS: &tls.ConnectionState{NegotiatedProtocol: "http/1.1"}},
+			"http/1.1",
+			true,
+		},
+		{
+			"h2c",
+			&http.Response{ProtoMajor: 2},
+			"h2c",
+			true,
+		},
+		{
+			"h3",
+			&http.Response{ProtoMajor: 3},
+			"h3",
+			true,
+		},
+		{
+			"plain http/1.1",
+			&http.Response{ProtoMajor: 1, ProtoMinor: 1},
+			"h2c",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			err := checkALPN(test.expected)(test.resp)
+			if test.result {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	chain := func(notAfter ...time.Time) *tls.ConnectionState {
+		state := &tls.ConnectionState{}
+		for _, na := range notAfter {
+			state.PeerCertificates = append(state.PeerCertificates, &x509.Certificate{NotAfter: na})
+		}
+		return state
+	}
+
+	var tests = []struct {
+		description string
+		tls         *tls.ConnectionState
+		result      bool
+	}{
+		{
+			"valid long enough",
+			chain(now.Add(30 * 24 * time.Hour)),
+			true,
+		},
+		{
+			"expires soon",
+			chain(now.Add(24 * time.Hour)),
+			false,
+		},
+		{
+			"expired",
+			chain(now.Add(-time.Hour)),
+			false,
+		},
+		{
+			"intermediate expires soon",
+			chain(now.Add(30*24*time.Hour), now.Add(24*time.Hour)),
+			false,
+		},
+		{
+			"no expiry",
+			chain(time.Time{}),
+			true,
+		},
+		{
+			"plain http",
+			nil,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			check := checkCertificateExpiry(7*24*time.Hour, func() time.Time { return now })
+			err := check(&http.Response{TLS: test.tls})
+			if test.result {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCertificatePins(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("certificate"), RawSubjectPublicKeyInfo: []byte("spki")}
+	certHash := sha256.Sum256(cert.Raw)
+	pins := tlsmeta.Pins{SHA256: []string{fmt.Sprintf("%x", certHash)}}
+
+	err := checkCertificatePins(pins)(&http.Response{TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}})
+	require.NoError(t, err)
+
+	other := &x509.Certificate{Raw: []byte("other")}
+	err = checkCertificatePins(pins)(&http.Response{TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{other}}})
+	require.Error(t, err)
+
+	err = checkCertificatePins(pins)(&http.Response{})
+	require.Error(t, err)
+}
+
+func TestCheckTLSNegotiation(t *testing.T) {
+	negotiation := tlsmeta.Negotiation{ExcludedCiphers: []match.Matcher{match.MustCompile("CBC")}}
+
+	err := checkTLSNegotiation(negotiation)(&http.Response{TLS: &tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+	}})
+	require.NoError(t, err)
+
+	err = checkTLSNegotiation(negotiation)(&http.Response{TLS: &tls.ConnectionState{
+		Version:     tls.VersionTLS12,
+		CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	}})
+	require.Error(t, err)
+
+	// Plain HTTP responses fail any TLS expectation
+	err = checkTLSNegotiation(negotiation)(&http.Response{})
+	require.Error(t, err)
+}
+
+func TestCheckXPath(t *testing.T) {
+	up, pending := "up", "pending"
+	pattern := match.MustCompile(`^\d{1,2}$`)
+
+	var tests = []struct {
+		description string
+		body        string
+		check       xpathResponseCheck
+		result      bool
+	}{
+		{
+			"node exists",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service[@name='db']"},
+			true,
+		},
+		{
+			"node missing",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service[@name='queue']"},
+			false,
+		},
+		{
+			"value equals",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service/@state", Equals: &up},
+			true,
+		},
+		{
+			"value not equals",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service/@state", Equals: &pending},
+			false,
+		},
+		{
+			"value matches pattern",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service[@name='db']/Latency", Pattern: &pattern},
+			true,
+		},
+		{
+			"value does not match pattern",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//Service[@name='cache']/Latency", Pattern: &pattern},
+			false,
+		},
+		{
+			"namespaced",
+			xpathTestDoc,
+			xpathResponseCheck{Expression: "//m:Message", Namespaces: map[string]string{"m": "urn:status"}, Equals: &[]string{"all good"}[0]},
+			true,
+		},
+		{
+			"unparseable",
+			`notxml`,
+			xpathResponseCheck{Expression: "//Service"},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			check := test.check
+			checker, err := checkXPath([]*xpathResponseCheck{&check})
+			require.NoError(t, err)
+
+			checkRes := checker(nil, test.body)
+			if result := checkRes == nil; result != test.result {
+				t.Fatalf("Expected xpath check '%s' result %v on body: %s. got: %s", check.Expression, test.result, test.body, checkRes)
+			}
+		})
+	}
+
+	_, err := checkXPath([]*xpathResponseCheck{{Expression: "//x:Service"}})
+	require.Error(t, err)
+}
+
+func TestWarnValidators(t *testing.T) {
+	validator, err := makeValidateResponse(&responseParameters{
+		RecvHeaders: map[string]string{"X-Frame-Options": "DENY"},
+		RecvBody:    []match.Matcher{match.MustCompile("hello")},
+		MaxRTT:      time.Second,
+		Severity:    severities{"headers": "warn", "rtt": "warn"},
+	})
+	require.NoError(t, err)
+
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+
+	// The failing header check is only a warning
+	require.NoError(t, validator.validate(resp, "hello"))
+	require.Len(t, validator.warnings(resp, "hello"), 1)
+
+	// The body check still marks the monitor down
+	require.Error(t, validator.validate(resp, "bye"))
+
+	require.NoError(t, validator.checkRTT(time.Millisecond))
+	require.Error(t, validator.checkRTT(2*time.Second))
+	require.True(t, validator.warnRTT)
+}
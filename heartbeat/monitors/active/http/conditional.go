@@ -0,0 +1,217 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// conditionalMode selects how conditional requests are validated.
+type conditionalMode string
+
+const (
+	// conditionalOff disables conditional requests.
+	conditionalOff conditionalMode = ""
+	// conditionalPrevious sends the validators of the previous check.
+	conditionalPrevious conditionalMode = "previous"
+	// conditionalReplay replays each request with the validators of its
+	// response and expects 304 Not Modified.
+	conditionalReplay conditionalMode = "replay"
+)
+
+// Unpack accepts `true` for the previous mode, `false` to disable conditional
+// requests, or the name of a mode.
+func (m *conditionalMode) Unpack(v interface{}) error {
+	switch v := v.(type) {
+	case bool:
+		*m = conditionalOff
+		if v {
+			*m = conditionalPrevious
+		}
+		return nil
+	case string:
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			return m.Unpack(enabled)
+		}
+		switch mode := conditionalMode(strings.ToLower(v)); mode {
+		case conditionalPrevious, conditionalReplay:
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown option for `conditional`: '%v', please use one of true, false, '%v' or '%v'", v, conditionalPrevious, conditionalReplay)
+}
+
+// conditionalRequests keeps the ETag and Last-Modified validators of the
+// previous response. They are sent with the next check, which verifies the
+// server, or any cache in front of it, answers conditional requests correctly.
+// In the replay mode, the validators are sent right away with a second request.
+type conditionalRequests struct {
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+
+	replay bool
+}
+
+// newConditionalRequests returns nil if conditional requests are disabled.
+func newConditionalRequests(config *Config) *conditionalRequests {
+	switch config.Check.Request.Conditional {
+	case conditionalPrevious:
+		return &conditionalRequests{}
+	case conditionalReplay:
+		return &conditionalRequests{replay: true}
+	}
+	return nil
+}
+
+// previous returns true if the validators of the previous check are sent.
+func (c *conditionalRequests) previous() bool {
+	return c != nil && !c.replay
+}
+
+// replays returns true if each request is replayed with the validators of
+// its response.
+func (c *conditionalRequests) replays() bool {
+	return c != nil && c.replay
+}
+
+// prepare adds the validators of the previous response to the request and
+// returns them. The headers are copied, as the request is reused by all checks.
+func (c *conditionalRequests) prepare(req *http.Request) (etag, lastModified string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.etag == "" && c.lastModified == "" {
+		return "", ""
+	}
+
+	req.Header = req.Header.Clone()
+	if c.etag != "" {
+		req.Header.Set("If-None-Match", c.etag)
+	}
+	if c.lastModified != "" {
+		req.Header.Set("If-Modified-Since", c.lastModified)
+	}
+	return c.etag, c.lastModified
+}
+
+// check validates the response to a request sent with the given validators and
+// remembers the validators of the response for the next check.
+func (c *conditionalRequests) check(resp *http.Response, etag, lastModified string) error {
+	respETag := resp.Header.Get("ETag")
+	respLastModified := resp.Header.Get("Last-Modified")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		if etag == "" && lastModified == "" {
+			return fmt.Errorf("received %v for an unconditional request", resp.Status)
+		}
+		if etag != "" && respETag != "" && !etagsMatch(etag, respETag) {
+			return fmt.Errorf("received %v with ETag %v, expecting %v", resp.Status, respETag, etag)
+		}
+		if respETag != "" {
+			c.etag = respETag
+		}
+
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		c.etag, c.lastModified = respETag, respLastModified
+
+		if etag != "" {
+			if respETag != "" && etagsMatch(etag, respETag) {
+				return fmt.Errorf("received %v for unchanged ETag %v, expecting %v", resp.Status, etag, http.StatusNotModified)
+			}
+		} else if lastModified != "" && respLastModified == lastModified {
+			return fmt.Errorf("received %v for unchanged Last-Modified %v, expecting %v", resp.Status, lastModified, http.StatusNotModified)
+		}
+	}
+
+	return nil
+}
+
+// replayRequest sends the request again with the validators of the response
+// to the first request, and validates the server answers 304 Not Modified.
+// It returns the fields describing the replayed request.
+func (c *conditionalRequests) replayRequest(
+	client *http.Client,
+	req *http.Request,
+	body []byte,
+	auth authorizer,
+	first *http.Response,
+) (common.MapStr, reason.Reason) {
+	etag, lastModified := first.Header.Get("ETag"), first.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil, reason.ValidateFailed(fmt.Errorf("response has no ETag or Last-Modified header to replay a conditional request"))
+	}
+
+	replay := req.Clone(req.Context())
+	if etag != "" {
+		replay.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		replay.Header.Set("If-Modified-Since", lastModified)
+	}
+	if len(body) > 0 {
+		replay.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	if auth != nil {
+		if err := auth.authorize(replay, body); err != nil {
+			return nil, reason.IOFailed(err)
+		}
+	}
+
+	start, resp, errReason := execRequest(client, replay)
+	if errReason != nil {
+		return nil, errReason
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	fields := common.MapStr{
+		"status_code": resp.StatusCode,
+		"rtt":         look.RTT(time.Since(start)),
+	}
+	if resp.StatusCode != http.StatusNotModified && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return fields, reason.ValidateFailed(fmt.Errorf("replayed conditional request received %v, expecting %v", resp.Status, http.StatusNotModified))
+	}
+	if err := c.check(resp, etag, lastModified); err != nil {
+		return fields, reason.ValidateFailed(err)
+	}
+	return fields, nil
+}
+
+// etagsMatch compares two entity tags using the weak comparison of RFC 7232,
+// which is used for If-None-Match.
+func etagsMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
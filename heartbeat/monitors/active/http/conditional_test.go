@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalRequestsDisabled(t *testing.T) {
+	assert.Nil(t, newConditionalRequests(&Config{}))
+}
+
+func TestConditionalModeUnpack(t *testing.T) {
+	for value, expected := range map[interface{}]conditionalMode{
+		true:       conditionalPrevious,
+		false:      conditionalOff,
+		"true":     conditionalPrevious,
+		"previous": conditionalPrevious,
+		"Replay":   conditionalReplay,
+	} {
+		var mode conditionalMode
+		require.NoError(t, mode.Unpack(value), value)
+		assert.Equal(t, expected, mode, value)
+	}
+
+	var mode conditionalMode
+	assert.Error(t, mode.Unpack("always"))
+	assert.Error(t, mode.Unpack(1))
+}
+
+func TestConditionalRequests(t *testing.T) {
+	lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+	respond := func(status int, etag, lastModified string) *http.Response {
+		resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}}
+		if etag != "" {
+			resp.Header.Set("ETag", etag)
+		}
+		if lastModified != "" {
+			resp.Header.Set("Last-Modified", lastModified)
+		}
+		return resp
+	}
+	type step struct {
+		resp    *http.Response
+		wantErr bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			"304 for unchanged ETag",
+			[]step{{respond(200, `"v1"`, ""), false}, {respond(304, `"v1"`, ""), false}, {respond(304, "", ""), false}},
+		},
+		{
+			"200 for unchanged ETag",
+			[]step{{respond(200, `"v1"`, ""), false}, {respond(200, `"v1"`, ""), true}},
+		},
+		{
+			"200 for weak ETag",
+			[]step{{respond(200, `W/"v1"`, ""), false}, {respond(200, `"v1"`, ""), true}},
+		},
+		{
+			"200 for changed ETag",
+			[]step{{respond(200, `"v1"`, ""), false}, {respond(200, `"v2"`, ""), false}, {respond(304, `"v2"`, ""), false}},
+		},
+		{
+			"304 with other ETag",
+			[]step{{respond(200, `"v1"`, ""), false}, {respond(304, `"v2"`, ""), true}},
+		},
+		{
+			"304 for unchanged Last-Modified",
+			[]step{{respond(200, "", lastModified), false}, {respond(304, "", ""), false}},
+		},
+		{
+			"200 for unchanged Last-Modified",
+			[]step{{respond(200, "", lastModified), false}, {respond(200, "", lastModified), true}},
+		},
+		{
+			"304 for unconditional request",
+			[]step{{respond(304, "", ""), true}},
+		},
+		{
+			"no validators",
+			[]step{{respond(200, "", ""), false}, {respond(200, "", ""), false}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cond := newConditionalRequests(&Config{Check: checkConfig{Request: requestParameters{Conditional: conditionalPrevious}}})
+			require.NotNil(t, cond)
+
+			for i, step := range test.steps {
+				req, err := http.NewRequest("GET", "http://localhost", nil)
+				require.NoError(t, err)
+				header := req.Header
+
+				etag, lastModified := cond.prepare(req)
+				assert.Equal(t, etag, req.Header.Get("If-None-Match"))
+				assert.Equal(t, lastModified, req.Header.Get("If-Modified-Since"))
+				assert.Empty(t, header, "the shared request headers must not be modified")
+
+				err = cond.check(step.resp, etag, lastModified)
+				if step.wantErr {
+					assert.Error(t, err, "step %d", i)
+				} else {
+					assert.NoError(t, err, "step %d", i)
+				}
+			}
+		})
+	}
+}
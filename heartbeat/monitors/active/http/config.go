@@ -18,13 +18,21 @@
 package http
 
 import (
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 	"github.com/elastic/beats/v7/libbeat/conditions"
 )
@@ -33,15 +41,35 @@ type Config struct {
 	URLs         []string       `config:"urls"`
 	Hosts        []string       `config:"hosts"`
 	ProxyURL     string         `config:"proxy_url"`
+	SocketPath   string         `config:"socket_path"`
 	Timeout      time.Duration  `config:"timeout"`
 	MaxRedirects int            `config:"max_redirects"`
 	Response     responseConfig `config:"response"`
 
+	// resolve names locally instead of on the SOCKS5 proxy
+	ProxyUseLocalResolver bool `config:"proxy_use_local_resolver"`
+
+	// select the proxy per request from the environment or a PAC file
+	ProxyFromEnvironment bool   `config:"proxy_from_environment"`
+	ProxyPAC             string `config:"proxy_pac"`
+
+	// proxyPAC is loaded from ProxyPAC when the monitor is created
+	proxyPAC *pacScript
+
+	// send a PROXY protocol header before the request
+	ProxyProtocol *dialchain.ProxyProtocolConfig `config:"proxy_protocol"`
+
+	// resolver is created from the nameservers in Mode when the monitor is created
+	resolver monitors.Resolver
+
 	Mode monitors.IPSettings `config:",inline"`
 
 	// authentication
-	Username string `config:"username"`
-	Password string `config:"password"`
+	Username string           `config:"username"`
+	Password string           `config:"password"`
+	OAuth2   *oauth2Config    `config:"oauth2"`
+	AWS      *common.Config   `config:"aws"`
+	Kerberos *kerberos.Config `config:"kerberos"`
 
 	// configure tls (if not configured HTTPS will use system defaults)
 	TLS *tlscommon.Config `config:"ssl"`
@@ -51,9 +79,17 @@ type Config struct {
 }
 
 type responseConfig struct {
-	IncludeBody         string `config:"include_body"`
-	IncludeBodyMaxBytes int    `config:"include_body_max_bytes"`
-	IncludeHeaders      bool   `config:"include_headers"`
+	IncludeBody         string            `config:"include_body"`
+	IncludeBodyMaxBytes int               `config:"include_body_max_bytes"`
+	IncludeHeaders      includeHeaders    `config:"include_headers"`
+	JSONFields          []jsonFieldConfig `config:"json_fields"`
+	HonorRetryAfter     bool              `config:"honor_retry_after"`
+
+	// charset the body is decoded from before validation, auto or none
+	Charset string `config:"charset"`
+
+	// jsonFields is compiled from JSONFields when the monitor is created
+	jsonFields *jsonFieldsExtractor
 }
 
 type checkConfig struct {
@@ -63,29 +99,114 @@ type checkConfig struct {
 
 type requestParameters struct {
 	// HTTP request configuration
-	Method      string            `config:"method"`      // http request method
-	SendHeaders map[string]string `config:"headers"`     // http request headers
-	SendBody    string            `config:"body"`        // send body payload
-	Compression compressionConfig `config:"compression"` // optionally compress payload
+	Method       string            `config:"method"`        // http request method
+	SendHeaders  map[string]string `config:"headers"`       // http request headers
+	SendBody     string            `config:"body"`          // send body payload
+	SendBodyFile string            `config:"body_file"`     // send the content of the file as body
+	BodyTemplate bool              `config:"body_template"` // render the body as template for each check
+	Compression  compressionConfig `config:"compression"`   // optionally compress payload
+	Protocol     string            `config:"protocol"`      // force the HTTP protocol, one of http/1.1, h2, h2c or h3
+	Conditional  conditionalMode   `config:"conditional"`   // send the ETag and Last-Modified of the previous or replayed response
+	GraphQL      *graphQLRequest   `config:"graphql"`       // send a GraphQL operation and check the response for errors
+	SOAP         *soapRequest      `config:"soap"`          // send a SOAP envelope and check the response for faults
+	Multipart    *multipartRequest `config:"multipart"`     // send a multipart/form-data body of fields and files
+	CORS         *corsPreflight    `config:"cors"`          // send a CORS preflight and check the allowed origin, method and headers
 
 	// TODO:
 	//  - add support for cookies
-	//  - select HTTP version. golang lib will either use 1.1 or 2.0 if HTTPS is used, otherwise HTTP 1.1 . => implement/use specific http.RoundTripper implementation to change wire protocol/version being used
 }
 
 type responseParameters struct {
 	// expected HTTP response configuration
-	Status      []uint16             `config:"status"`
-	RecvHeaders map[string]string    `config:"headers"`
-	RecvBody    []match.Matcher      `config:"body"`
-	RecvJSON    []*jsonResponseCheck `config:"json"`
+	Status      []uint16              `config:"status"`
+	RecvHeaders map[string]string     `config:"headers"`
+	RecvBody    []match.Matcher       `config:"body"`
+	RecvJSON    []*jsonResponseCheck  `config:"json"`
+	RecvXPath   []*xpathResponseCheck `config:"xpath"`
+	Drift       driftConfig           `config:"drift"` // detect changes of the normalized body
+	ALPN        string                `config:"alpn"`  // expected negotiated protocol
+	Certificate certificateCheck      `config:"certificate"`
+	TLS         tlsmeta.Negotiation   `config:"tls"`     // expected TLS versions and cipher suites
+	MaxRTT      time.Duration         `config:"max_rtt"` // maximum total duration of the request
+	Severity    severities            `config:"severity"`
+	// path of a file the body must be equal to, optionally after collapsing whitespace
+	BodyFileEquals     string `config:"body_file_equals"`
+	BodyFileWhitespace bool   `config:"body_file_normalize_whitespace"`
+	// recommended security headers, with per header overrides
+	SecurityHeaders securityHeadersCheck `config:"security_headers"`
 	// add this option to control the match on http body is positive check or negative check
 	PositiveCheckOnHTTPBody bool `config:"positive_check_on_http_body"`
 }
 
+// severities sets the severity of the failures of each validator, by name.
+// Failures of validators with the `warn` severity are recorded in the event
+// without marking the monitor down.
+type severities map[string]string
+
+const (
+	severityError = "error"
+	severityWarn  = "warn"
+)
+
+var validatorNames = []string{
+	"status", "headers", "alpn", "certificate", "tls", "body", "body_file", "json", "xpath", "drift", "rtt", "cors", "security_headers",
+}
+
+// Validate checks the validator names and severities are known
+func (s severities) Validate() error {
+	for name, severity := range s {
+		known := false
+		for _, validator := range validatorNames {
+			known = known || name == validator
+		}
+		if !known {
+			return fmt.Errorf("unknown validator '%v' in `severity`, expecting one of %v", name, strings.Join(validatorNames, ", "))
+		}
+		if severity != severityError && severity != severityWarn {
+			return fmt.Errorf("invalid severity '%v' of validator '%v', expecting 'error' or 'warn'", severity, name)
+		}
+	}
+	return nil
+}
+
+func (s severities) warns(name string) bool {
+	return s[name] == severityWarn
+}
+
+type certificateCheck struct {
+	// minimum remaining validity of the served certificate chain
+	NotValidAfterMin time.Duration `config:"not_valid_after_min" validate:"min=0"`
+
+	// expected fingerprints of the served certificate
+	Pins tlsmeta.Pins `config:",inline"`
+
+	// revocation checking of the served certificate
+	OCSP tlsmeta.OCSP `config:"ocsp"`
+
+	// when to record the PEM of the served certificate chain
+	IncludePEM string `config:"include_pem"`
+}
+
 type jsonResponseCheck struct {
 	Description string             `config:"description"`
 	Condition   *conditions.Config `config:"condition"`
+	Delta       *jsonDeltaConfig   `config:"delta"` // bounds of the change since the previous run
+}
+
+// Validate checks a condition or a delta is configured
+func (c *jsonResponseCheck) Validate() error {
+	if c.Condition == nil && c.Delta == nil {
+		return errors.New("JSON check requires a `condition` or a `delta`")
+	}
+	return nil
+}
+
+type xpathResponseCheck struct {
+	Description string            `config:"description"`
+	Expression  string            `config:"expression" validate:"required"`
+	Namespaces  map[string]string `config:"namespaces"`
+	Equals      *string           `config:"equals"`
+	Pattern     *match.Matcher    `config:"pattern"`
 }
 
 type compressionConfig struct {
@@ -99,20 +220,27 @@ var defaultConfig = Config{
 	Response: responseConfig{
 		IncludeBody:         "on_error",
 		IncludeBodyMaxBytes: 2048,
-		IncludeHeaders:      true,
+		IncludeHeaders:      includeHeaders{all: true},
 	},
 	Mode: monitors.DefaultIPSettings,
 	Check: checkConfig{
 		Request: requestParameters{
-			Method:      "GET",
 			SendHeaders: nil,
 			SendBody:    "",
+			Compression: compressionConfig{
+				Level: gzip.DefaultCompression,
+			},
 		},
 		Response: responseParameters{
 			RecvHeaders:             nil,
 			RecvBody:                []match.Matcher{},
 			RecvJSON:                nil,
 			PositiveCheckOnHTTPBody: true,
+			Certificate: certificateCheck{
+				OCSP:       tlsmeta.DefaultOCSP(),
+				IncludePEM: "never",
+			},
+			SecurityHeaders: defaultSecurityHeaders,
 		},
 	},
 }
@@ -129,32 +257,195 @@ func (r *responseConfig) Validate() error {
 		return fmt.Errorf("include_body_max_bytes must be a positive integer, got %d", r.IncludeBodyMaxBytes)
 	}
 
+	if err := validateCharset(r.Charset); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// Validate checks the certificate checks are valid
+func (c *certificateCheck) Validate() error {
+	switch strings.ToLower(c.IncludePEM) {
+	case "always", "on_error", "never":
+	default:
+		return fmt.Errorf("unknown option for `include_pem`: '%s', please use one of 'always', 'on_error', 'never'", c.IncludePEM)
+	}
+	return nil
+}
+
+// includesPEM returns true if the PEM of the served certificate chain is
+// recorded for a check that failed or not.
+func (c certificateCheck) includesPEM(failed bool) bool {
+	switch strings.ToLower(c.IncludePEM) {
+	case "always":
+		return true
+	case "on_error":
+		return failed
+	}
+	return false
+}
+
 // Validate validates of the requestParameters object is valid or not
 func (r *requestParameters) Validate() error {
-	switch strings.ToUpper(r.Method) {
-	case "HEAD", "GET", "POST":
+	if r.Method != "" && !validMethod(r.Method) {
+		return fmt.Errorf("HTTP method '%v' is not a valid method name", r.Method)
+	}
+
+	switch r.Protocol {
+	case "", protocolHTTP11, protocolH2, protocolH2C, protocolH3:
 	default:
-		return fmt.Errorf("HTTP method '%v' not supported", r.Method)
+		return fmt.Errorf("unknown option for `protocol`: '%s', please use one of '%s', '%s', '%s', '%s'", r.Protocol, protocolHTTP11, protocolH2, protocolH2C, protocolH3)
+	}
+
+	if r.Conditional != conditionalOff {
+		switch r.method() {
+		case http.MethodGet, http.MethodHead:
+		default:
+			return fmt.Errorf("conditional requests require the GET or HEAD method, got '%v'", r.method())
+		}
+	}
+
+	if r.SendBody != "" && r.SendBodyFile != "" {
+		return fmt.Errorf("body can not be combined with body_file")
+	}
+	if r.BodyTemplate && r.SendBody == "" && r.SendBodyFile == "" {
+		return fmt.Errorf("body_template requires body or body_file")
+	}
+	if r.SendBodyFile != "" || r.BodyTemplate {
+		if r.GraphQL != nil || r.SOAP != nil || r.Multipart != nil {
+			return fmt.Errorf("body_file and body_template can not be combined with graphql, soap or multipart")
+		}
+	}
+
+	if r.GraphQL != nil {
+		if r.SendBody != "" {
+			return fmt.Errorf("graphql can not be combined with body")
+		}
+		if r.Conditional != conditionalOff {
+			return fmt.Errorf("graphql can not be combined with conditional")
+		}
+		// GraphQL operations are posted
+		if r.Method != "" && normalizeMethod(r.Method) != http.MethodPost {
+			return fmt.Errorf("graphql requests require the POST method, got '%v'", r.Method)
+		}
+	}
+
+	if r.SOAP != nil {
+		if r.SendBody != "" {
+			return fmt.Errorf("soap can not be combined with body")
+		}
+		if r.Conditional != conditionalOff {
+			return fmt.Errorf("soap can not be combined with conditional")
+		}
+		if r.GraphQL != nil {
+			return fmt.Errorf("soap can not be combined with graphql")
+		}
+		// SOAP envelopes are posted
+		if r.Method != "" && normalizeMethod(r.Method) != http.MethodPost {
+			return fmt.Errorf("soap requests require the POST method, got '%v'", r.Method)
+		}
+	}
+
+	if r.Multipart != nil {
+		if r.SendBody != "" {
+			return fmt.Errorf("multipart can not be combined with body")
+		}
+		if r.Conditional != conditionalOff {
+			return fmt.Errorf("multipart can not be combined with conditional")
+		}
+		if r.GraphQL != nil || r.SOAP != nil {
+			return fmt.Errorf("multipart can not be combined with graphql or soap")
+		}
+		// Forms are posted by default, uploads may also use PUT or PATCH
+		switch r.method() {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			return fmt.Errorf("multipart requests require the POST, PUT or PATCH method, got '%v'", r.Method)
+		}
+	}
+
+	if r.CORS != nil {
+		if r.SendBody != "" || r.SendBodyFile != "" || r.Conditional != conditionalOff ||
+			r.GraphQL != nil || r.SOAP != nil || r.Multipart != nil {
+			return fmt.Errorf("cors can not be combined with body, body_file, conditional, graphql, soap or multipart")
+		}
+		// Preflights use the OPTIONS method
+		if r.Method != "" && normalizeMethod(r.Method) != http.MethodOptions {
+			return fmt.Errorf("cors preflight requests require the OPTIONS method, got '%v'", r.Method)
+		}
 	}
 
 	return nil
 }
 
+// method returns the method of the request. Unless configured, GraphQL
+// operations, SOAP envelopes and forms are posted, CORS preflights use OPTIONS
+// and other requests use GET.
+func (r *requestParameters) method() string {
+	switch {
+	case r.Method != "":
+		return normalizeMethod(r.Method)
+	case r.GraphQL != nil || r.SOAP != nil || r.Multipart != nil:
+		return http.MethodPost
+	case r.CORS != nil:
+		return http.MethodOptions
+	}
+	return http.MethodGet
+}
+
+// standardMethods are the methods defined by RFC 7231 and RFC 5789. These are
+// matched case insensitively for backwards compatibility, all other methods are
+// sent as configured.
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// normalizeMethod upper cases standard HTTP methods. Extension methods, like
+// WebDAV's PROPFIND or custom verbs, are case sensitive and returned unchanged.
+func normalizeMethod(method string) string {
+	for _, m := range standardMethods {
+		if strings.EqualFold(m, method) {
+			return m
+		}
+	}
+	return method
+}
+
+// validMethod checks the method is a valid token as defined by RFC 7230.
+func validMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+	return strings.IndexFunc(method, func(r rune) bool {
+		return !isTokenChar(r)
+	}) == -1
+}
+
+func isTokenChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
+
 // Validate validates of the compressionConfig object is valid or not
 func (c *compressionConfig) Validate() error {
 	t := strings.ToLower(c.Type)
-	if t != "" && t != "gzip" {
-		return fmt.Errorf("compression type '%v' not supported", c.Type)
-	}
-
-	if t == "" {
+	switch t {
+	case "":
 		return nil
+	case "gzip":
+	case "zstd":
+		// zstd is always compressed with the default level
+		return nil
+	default:
+		return fmt.Errorf("compression type '%v' not supported", c.Type)
 	}
 
-	if !(0 <= c.Level && c.Level <= 9) {
+	if !(gzip.DefaultCompression <= c.Level && c.Level <= gzip.BestCompression) {
 		return fmt.Errorf("compression level %v invalid", c.Level)
 	}
 
@@ -171,6 +462,16 @@ func (c *Config) Validate() error {
 		c.Hosts = append(c.Hosts, c.URLs...)
 	}
 
+	if c.AWS != nil && (c.OAuth2 != nil || c.Username != "") {
+		return fmt.Errorf("aws can not be combined with oauth2 or username")
+	}
+	if c.OAuth2 != nil && c.Username != "" {
+		return fmt.Errorf("oauth2 can not be combined with username")
+	}
+	if c.Kerberos.IsEnabled() && (c.AWS != nil || c.OAuth2 != nil || c.Username != "") {
+		return fmt.Errorf("kerberos can not be combined with aws, oauth2 or username")
+	}
+
 	// updateScheme looks at TLS config to decide if http or https should be used to update the host
 	updateScheme := func(host string) string {
 		if c.TLS != nil && *c.TLS.Enabled == true {
@@ -192,5 +493,66 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.ProxyFromEnvironment || c.ProxyPAC != "" {
+		if c.ProxyFromEnvironment && c.ProxyPAC != "" {
+			return fmt.Errorf("proxy_from_environment can not be combined with proxy_pac")
+		}
+		if c.ProxyURL != "" || c.SocketPath != "" {
+			return fmt.Errorf("proxy_from_environment and proxy_pac can not be combined with proxy_url or socket_path")
+		}
+		if c.Check.Request.Protocol != "" && c.Check.Request.Protocol != protocolHTTP11 {
+			return fmt.Errorf("proxy_from_environment and proxy_pac can not be combined with protocol %v", c.Check.Request.Protocol)
+		}
+	}
+
+	if c.SocketPath != "" {
+		if c.ProxyURL != "" {
+			return fmt.Errorf("socket_path can not be combined with proxy_url")
+		}
+		if isHTTP3(c.Check.Request.Protocol) {
+			return fmt.Errorf("socket_path can not be combined with protocol %v", c.Check.Request.Protocol)
+		}
+	}
+
+	// The PROXY protocol header is sent to the monitored hosts, so the
+	// connections must be made to them directly or through a SOCKS5 proxy.
+	if c.ProxyProtocol != nil {
+		if (c.ProxyURL != "" && !isSOCKS5Proxy(c.ProxyURL)) || c.ProxyFromEnvironment || c.ProxyPAC != "" {
+			return fmt.Errorf("proxy_protocol can only be combined with SOCKS5 proxies")
+		}
+		if c.SocketPath != "" {
+			return fmt.Errorf("proxy_protocol can not be combined with socket_path")
+		}
+		if isHTTP3(c.Check.Request.Protocol) {
+			return fmt.Errorf("proxy_protocol can not be combined with protocol %v", c.Check.Request.Protocol)
+		}
+	}
+
+	if isSOCKS5Proxy(c.ProxyURL) {
+		proxyConfig := transport.ProxyConfig{URL: c.ProxyURL}
+		if err := proxyConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid proxy_url: %v", err)
+		}
+	}
+
+	switch c.Check.Request.Protocol {
+	case protocolH2, protocolH2C, protocolH3:
+		// HTTP/2 connections can only be made through SOCKS5 proxies
+		if c.ProxyURL != "" && (!isSOCKS5Proxy(c.ProxyURL) || isHTTP3(c.Check.Request.Protocol)) {
+			return fmt.Errorf("protocol %v can not be combined with proxy_url", c.Check.Request.Protocol)
+		}
+
+		// h2 and h3 are only negotiated over TLS, h2c only without it
+		scheme := urlSchemaHTTPS
+		if c.Check.Request.Protocol == protocolH2C {
+			scheme = urlSchemaHTTP
+		}
+		for _, host := range c.Hosts {
+			if u, err := url.Parse(host); err == nil && u.Scheme != scheme {
+				return fmt.Errorf("protocol %v requires %v URLs, got '%v'", c.Check.Request.Protocol, scheme, host)
+			}
+		}
+	}
+
 	return nil
 }
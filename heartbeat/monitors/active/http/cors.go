@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// corsPreflight is a CORS preflight request, sent instead of the configured
+// request, whose Access-Control-Allow-* response headers must allow the
+// described cross-origin request.
+type corsPreflight struct {
+	Origin      string   `config:"origin" validate:"required"`
+	Method      string   `config:"method"`
+	Headers     []string `config:"headers"`
+	Credentials bool     `config:"credentials"`
+}
+
+// Validate checks the method of the cross-origin request.
+func (c *corsPreflight) Validate() error {
+	if c.Method != "" && !validMethod(c.Method) {
+		return fmt.Errorf("HTTP method '%v' of the CORS request is not a valid method name", c.Method)
+	}
+	return nil
+}
+
+// method returns the method of the cross-origin request, GET by default.
+func (c *corsPreflight) method() string {
+	if c.Method == "" {
+		return http.MethodGet
+	}
+	return normalizeMethod(c.Method)
+}
+
+// setHeaders sets the preflight request headers.
+func (c *corsPreflight) setHeaders(h http.Header) {
+	h.Set("Origin", c.Origin)
+	h.Set("Access-Control-Request-Method", c.method())
+	if len(c.Headers) > 0 {
+		// Browsers send the lowercased, sorted header names
+		names := make([]string, len(c.Headers))
+		for i, name := range c.Headers {
+			names[i] = strings.ToLower(name)
+		}
+		sort.Strings(names)
+		h.Set("Access-Control-Request-Headers", strings.Join(names, ","))
+	}
+}
+
+// corsSafelistedMethods are allowed without being listed in
+// Access-Control-Allow-Methods.
+var corsSafelistedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// checkCORS validates the response to the preflight the way browsers do,
+// failing if the cross-origin request would be blocked.
+func checkCORS(c *corsPreflight) respValidator {
+	method := c.method()
+	return func(r *http.Response) error {
+		if r.StatusCode < 200 || r.StatusCode > 299 {
+			return fmt.Errorf("CORS preflight failed with status %v", r.Status)
+		}
+
+		allowOrigin := r.Header.Get("Access-Control-Allow-Origin")
+		switch {
+		case allowOrigin == "":
+			return fmt.Errorf("CORS preflight response has no Access-Control-Allow-Origin header")
+		case allowOrigin == "*" && c.Credentials:
+			return fmt.Errorf("CORS preflight response allows any origin, which is not allowed with credentials")
+		case allowOrigin != "*" && allowOrigin != c.Origin:
+			return fmt.Errorf("CORS preflight response allows origin '%v', expecting '%v'", allowOrigin, c.Origin)
+		}
+
+		if c.Credentials && r.Header.Get("Access-Control-Allow-Credentials") != "true" {
+			return fmt.Errorf("CORS preflight response does not allow credentials")
+		}
+
+		allowMethods := headerTokens(r.Header, "Access-Control-Allow-Methods")
+		if !containsToken(corsSafelistedMethods, method, false) &&
+			!containsToken(allowMethods, method, false) &&
+			!(containsToken(allowMethods, "*", false) && !c.Credentials) {
+			return fmt.Errorf("CORS preflight response does not allow method %v, allowed methods are '%v'", method, strings.Join(allowMethods, ", "))
+		}
+
+		allowHeaders := headerTokens(r.Header, "Access-Control-Allow-Headers")
+		wildcard := containsToken(allowHeaders, "*", false) && !c.Credentials
+		for _, name := range c.Headers {
+			// The wildcard does not cover the Authorization header
+			if wildcard && !strings.EqualFold(name, "Authorization") {
+				continue
+			}
+			if !containsToken(allowHeaders, name, true) {
+				return fmt.Errorf("CORS preflight response does not allow header %v, allowed headers are '%v'", name, strings.Join(allowHeaders, ", "))
+			}
+		}
+
+		return nil
+	}
+}
+
+// headerTokens returns the comma separated values of all header lines.
+func headerTokens(h http.Header, name string) []string {
+	var tokens []string
+	for _, value := range h.Values(name) {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
+
+func containsToken(tokens []string, token string, ignoreCase bool) bool {
+	for _, t := range tokens {
+		if t == token || (ignoreCase && strings.EqualFold(t, token)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSPreflightHeaders(t *testing.T) {
+	c := &corsPreflight{Origin: "https://app.example.com", Method: "put", Headers: []string{"X-Request-Id", "Content-Type"}}
+	h := http.Header{}
+	c.setHeaders(h)
+	assert.Equal(t, "https://app.example.com", h.Get("Origin"))
+	assert.Equal(t, "PUT", h.Get("Access-Control-Request-Method"))
+	assert.Equal(t, "content-type,x-request-id", h.Get("Access-Control-Request-Headers"))
+}
+
+func TestCheckCORS(t *testing.T) {
+	const origin = "https://app.example.com"
+	response := func(status int, headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name    string
+		cors    corsPreflight
+		resp    *http.Response
+		wantErr bool
+	}{
+		{
+			name: "simple method any origin",
+			cors: corsPreflight{Origin: origin, Method: "GET"},
+			resp: response(204, map[string]string{"Access-Control-Allow-Origin": "*"}),
+		},
+		{
+			name:    "failed status",
+			cors:    corsPreflight{Origin: origin, Method: "GET"},
+			resp:    response(403, map[string]string{"Access-Control-Allow-Origin": "*"}),
+			wantErr: true,
+		},
+		{
+			name:    "missing origin",
+			cors:    corsPreflight{Origin: origin, Method: "GET"},
+			resp:    response(200, nil),
+			wantErr: true,
+		},
+		{
+			name:    "other origin",
+			cors:    corsPreflight{Origin: origin, Method: "GET"},
+			resp:    response(200, map[string]string{"Access-Control-Allow-Origin": "https://other.example.com"}),
+			wantErr: true,
+		},
+		{
+			name: "listed method and headers",
+			cors: corsPreflight{Origin: origin, Method: "DELETE", Headers: []string{"X-Request-Id"}},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":  origin,
+				"Access-Control-Allow-Methods": "GET, DELETE",
+				"Access-Control-Allow-Headers": "x-request-id",
+			}),
+		},
+		{
+			name: "unlisted method",
+			cors: corsPreflight{Origin: origin, Method: "DELETE"},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":  origin,
+				"Access-Control-Allow-Methods": "GET, PUT",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unlisted header",
+			cors: corsPreflight{Origin: origin, Method: "GET", Headers: []string{"X-Request-Id"}},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":  origin,
+				"Access-Control-Allow-Headers": "Content-Type",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "wildcards without credentials",
+			cors: corsPreflight{Origin: origin, Method: "PATCH", Headers: []string{"X-Request-Id"}},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "*",
+				"Access-Control-Allow-Headers": "*",
+			}),
+		},
+		{
+			name: "wildcard does not cover authorization",
+			cors: corsPreflight{Origin: origin, Method: "GET", Headers: []string{"Authorization"}},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Headers": "*",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "credentials with origin wildcard",
+			cors: corsPreflight{Origin: origin, Method: "GET", Credentials: true},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "true",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "credentials not allowed",
+			cors: corsPreflight{Origin: origin, Method: "GET", Credentials: true},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin": origin,
+			}),
+			wantErr: true,
+		},
+		{
+			name: "credentials allowed",
+			cors: corsPreflight{Origin: origin, Method: "GET", Credentials: true},
+			resp: response(200, map[string]string{
+				"Access-Control-Allow-Origin":      origin,
+				"Access-Control-Allow-Credentials": "true",
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkCORS(&test.cors)(test.resp)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
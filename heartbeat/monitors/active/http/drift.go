@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// driftConfig configures detecting changes of the response body, by comparing
+// the hash of the normalized body with a baseline or the previous response.
+type driftConfig struct {
+	Enabled   bool                 `config:"enabled"`
+	Baseline  string               `config:"baseline"`
+	Normalize driftNormalizeConfig `config:"normalize"`
+}
+
+type driftNormalizeConfig struct {
+	// collapse whitespace, so reformatting the body is not reported
+	Whitespace bool `config:"whitespace"`
+	// regular expressions matching volatile content, such as timestamps
+	Exclude []string `config:"exclude"`
+}
+
+// Validate checks the baseline is a SHA-256 hash and the exclude patterns compile.
+func (c *driftConfig) Validate() error {
+	if c.Baseline != "" {
+		if b, err := hex.DecodeString(c.Baseline); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("invalid drift baseline '%v', expecting a hex encoded SHA-256 hash", c.Baseline)
+		}
+	}
+	for _, pattern := range c.Normalize.Exclude {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid drift exclude pattern '%v': %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// driftCheck remembers the hash of the normalized body of the previous
+// response of each origin of the monitor.
+type driftCheck struct {
+	baseline   string
+	whitespace bool
+	exclude    []*regexp.Regexp
+
+	mu       sync.Mutex
+	previous map[string]string
+}
+
+func newDriftCheck(config driftConfig) (*driftCheck, error) {
+	d := &driftCheck{
+		baseline:   strings.ToLower(config.Baseline),
+		whitespace: config.Normalize.Whitespace,
+		previous:   map[string]string{},
+	}
+	for _, pattern := range config.Normalize.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		d.exclude = append(d.exclude, re)
+	}
+	return d, nil
+}
+
+// hash returns the hex encoded SHA-256 hash of the normalized body.
+func (d *driftCheck) hash(body string) string {
+	for _, re := range d.exclude {
+		body = re.ReplaceAllString(body, "")
+	}
+	if d.whitespace {
+		body = normalizeWhitespace(body)
+	}
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// check fails if the normalized body differs from the baseline or, without a
+// baseline, from the previous response of the origin. A changed body fails one
+// check only, the next check compares with the changed body.
+func (d *driftCheck) check(r *http.Response, body string) error {
+	hash := d.hash(body)
+	if d.baseline != "" {
+		if hash != d.baseline {
+			return fmt.Errorf("body drifted from baseline %v, normalized body hash is %v", d.baseline, hash)
+		}
+		return nil
+	}
+
+	origin := responseOrigin(r)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	previous, found := d.previous[origin]
+	d.previous[origin] = hash
+	if found && hash != previous {
+		return fmt.Errorf("body changed since the previous check, normalized body hash is %v, was %v", hash, previous)
+	}
+	return nil
+}
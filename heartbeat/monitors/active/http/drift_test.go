@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func driftResponse(host string) *http.Response {
+	return &http.Response{Request: &http.Request{URL: &url.URL{Scheme: "https", Host: host, Path: "/terms"}}}
+}
+
+func TestDriftCheckPrevious(t *testing.T) {
+	drift, err := newDriftCheck(driftConfig{Enabled: true})
+	require.NoError(t, err)
+
+	a, b := driftResponse("a.example.com"), driftResponse("b.example.com")
+	assert.NoError(t, drift.check(a, "terms v1"))
+	assert.NoError(t, drift.check(a, "terms v1"))
+	assert.NoError(t, drift.check(b, "other terms"))
+	assert.Error(t, drift.check(a, "terms v2"))
+	assert.NoError(t, drift.check(a, "terms v2"))
+}
+
+func TestDriftCheckModeAll(t *testing.T) {
+	drift, err := newDriftCheck(driftConfig{Enabled: true})
+	require.NoError(t, err)
+
+	// with `mode: all` the requests of each IP of the host share the URL
+	resp := driftResponse("a.example.com")
+	respFrom := func(ip string) *http.Response {
+		ctx := withDialedIP(context.Background(), net.ParseIP(ip))
+		return &http.Response{Request: resp.Request.WithContext(ctx)}
+	}
+	a, b := respFrom("10.0.0.1"), respFrom("10.0.0.2")
+
+	assert.NoError(t, drift.check(a, "terms v1"))
+	assert.NoError(t, drift.check(b, "terms v2"))
+	assert.NoError(t, drift.check(a, "terms v1"))
+	assert.NoError(t, drift.check(b, "terms v2"))
+	assert.Error(t, drift.check(b, "terms v3"))
+}
+
+func TestDriftNormalizedHash(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	drift, err := newDriftCheck(driftConfig{
+		Enabled:   true,
+		Baseline:  hex.EncodeToString(sum[:]),
+		Normalize: driftNormalizeConfig{Exclude: []string{`l+`}},
+	})
+	require.NoError(t, err)
+
+	validator := multiValidator{bodyValidators: []bodyValidator{drift.check}, drift: drift}
+	fields, _, _, errReason := processBody(simpleHTTPResponse("hello"), responseConfig{}, validator)
+	require.Error(t, errReason)
+
+	normalized := sha256.Sum256([]byte("heo"))
+	assert.Equal(t, hex.EncodeToString(normalized[:]), fields["normalized_hash"])
+}
+
+func TestDriftCheckBaseline(t *testing.T) {
+	sum := sha256.Sum256([]byte("terms v1"))
+	drift, err := newDriftCheck(driftConfig{Enabled: true, Baseline: hex.EncodeToString(sum[:])})
+	require.NoError(t, err)
+
+	resp := driftResponse("a.example.com")
+	assert.NoError(t, drift.check(resp, "terms v1"))
+	assert.Error(t, drift.check(resp, "terms v2"))
+	assert.Error(t, drift.check(resp, "terms v2"))
+}
+
+func TestDriftCheckNormalize(t *testing.T) {
+	drift, err := newDriftCheck(driftConfig{
+		Enabled: true,
+		Normalize: driftNormalizeConfig{
+			Whitespace: true,
+			Exclude:    []string{`Generated at \d+:\d+`},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		drift.hash("<p>Terms</p>\n  <p>Generated at 10:15</p>"),
+		drift.hash("<p>Terms</p> <p>Generated at 11:30</p>\n"))
+	assert.NotEqual(t, drift.hash("<p>Terms</p>"), drift.hash("<p>New terms</p>"))
+}
+
+func TestDriftConfigValidate(t *testing.T) {
+	sum := sha256.Sum256(nil)
+	valid := driftConfig{Baseline: hex.EncodeToString(sum[:]), Normalize: driftNormalizeConfig{Exclude: []string{`\d+`}}}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, (&driftConfig{Baseline: "abcd"}).Validate())
+	assert.Error(t, (&driftConfig{Normalize: driftNormalizeConfig{Exclude: []string{`(`}}}).Validate())
+}
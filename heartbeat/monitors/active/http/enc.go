@@ -23,6 +23,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 type contentEncoder interface {
@@ -36,12 +38,19 @@ type gzipEncoder struct {
 	gz *gzip.Writer
 }
 
+type zstdEncoder struct {
+	zw *zstd.Encoder
+}
+
 var plainEncoder = nilEncoder{}
 
 func getContentEncoder(name string, level int) (contentEncoder, error) {
 	name = strings.ToLower(name)
-	if name == "gzip" {
+	switch name {
+	case "gzip":
 		return newGZIPEncoder(level)
+	case "zstd":
+		return newZSTDEncoder()
 	}
 	if name != "" {
 		return nil, errors.New("invalid content encoder")
@@ -67,8 +76,10 @@ func newGZIPEncoder(level int) (*gzipEncoder, error) {
 }
 
 func (e *gzipEncoder) AddHeaders(h *http.Header) {
-	h.Add("Content-Type", "application/json; charset=UTF-8")
-	h.Add("Content-Encoding", "gzip")
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", "application/json; charset=UTF-8")
+	}
+	h.Set("Content-Encoding", "gzip")
 }
 
 func (e *gzipEncoder) Encode(to io.Writer, from io.Reader) error {
@@ -76,8 +87,28 @@ func (e *gzipEncoder) Encode(to io.Writer, from io.Reader) error {
 	if _, err := io.Copy(e.gz, from); err != nil {
 		return err
 	}
-	if err := e.gz.Flush(); err != nil {
+	// Close terminates the stream, writing the gzip footer. It does not close
+	// the underlying writer.
+	return e.gz.Close()
+}
+
+func newZSTDEncoder() (*zstdEncoder, error) {
+	w, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zstdEncoder{w}, nil
+}
+
+func (e *zstdEncoder) AddHeaders(h *http.Header) {
+	h.Set("Content-Encoding", "zstd")
+}
+
+func (e *zstdEncoder) Encode(to io.Writer, from io.Reader) error {
+	e.zw.Reset(to)
+	if _, err := io.Copy(e.zw, from); err != nil {
 		return err
 	}
-	return nil
+	return e.zw.Close()
 }
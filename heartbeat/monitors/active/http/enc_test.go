@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentEncoders(t *testing.T) {
+	payload := strings.Repeat(`{"message":"hello"}`, 100)
+
+	decoders := map[string]func(*bytes.Buffer) ([]byte, error){
+		"gzip": func(b *bytes.Buffer) ([]byte, error) {
+			r, err := gzip.NewReader(b)
+			if err != nil {
+				return nil, err
+			}
+			return ioutil.ReadAll(r)
+		},
+		"zstd": func(b *bytes.Buffer) ([]byte, error) {
+			r, err := zstd.NewReader(b)
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+			return ioutil.ReadAll(r)
+		},
+	}
+
+	for name, decode := range decoders {
+		t.Run(name, func(t *testing.T) {
+			enc, err := getContentEncoder(name, gzip.DefaultCompression)
+			require.NoError(t, err)
+
+			// encoders are reused for every request of the monitor
+			for i := 0; i < 2; i++ {
+				buf := bytes.NewBuffer(nil)
+				require.NoError(t, enc.Encode(buf, strings.NewReader(payload)))
+				assert.True(t, buf.Len() < len(payload))
+
+				decoded, err := decode(buf)
+				require.NoError(t, err)
+				assert.Equal(t, payload, string(decoded))
+			}
+
+			h := http.Header{}
+			enc.AddHeaders(&h)
+			assert.Equal(t, name, h.Get("Content-Encoding"))
+		})
+	}
+
+	_, err := getContentEncoder("br", 0)
+	assert.Error(t, err)
+}
+
+func TestGZIPEncoderContentType(t *testing.T) {
+	enc, err := getContentEncoder("gzip", gzip.DefaultCompression)
+	require.NoError(t, err)
+
+	h := http.Header{}
+	enc.AddHeaders(&h)
+	assert.Equal(t, "application/json; charset=UTF-8", h.Get("Content-Type"))
+
+	h = http.Header{"Content-Type": []string{"text/plain"}}
+	enc.AddHeaders(&h)
+	assert.Equal(t, []string{"text/plain"}, h["Content-Type"])
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// goldenCheck compares the response body with the content of a file. The file
+// is read for each check, so that an updated file is used without restarting
+// the monitor.
+type goldenCheck struct {
+	file       string
+	whitespace bool
+}
+
+func newGoldenCheck(file string, whitespace bool) (*goldenCheck, error) {
+	// Fail early if the file can not be read
+	if _, err := ioutil.ReadFile(file); err != nil {
+		return nil, fmt.Errorf("could not read body_file_equals: %v", err)
+	}
+	return &goldenCheck{file: file, whitespace: whitespace}, nil
+}
+
+// check fails if the body differs from the file, reporting the offset of the
+// first difference.
+func (g *goldenCheck) check(r *http.Response, body string) error {
+	content, err := ioutil.ReadFile(g.file)
+	if err != nil {
+		return fmt.Errorf("could not read body_file_equals: %v", err)
+	}
+
+	expected := string(content)
+	if g.whitespace {
+		expected = normalizeWhitespace(expected)
+		body = normalizeWhitespace(body)
+	}
+	if body == expected {
+		return nil
+	}
+	return fmt.Errorf("body differs from '%v' at offset %d (body is %d bytes, file is %d bytes)",
+		g.file, firstDifference(body, expected), len(body), len(expected))
+}
+
+// normalizeWhitespace collapses runs of whitespace into a single space and
+// trims leading and trailing whitespace.
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func firstDifference(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGolden(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "golden")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "index.html")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestGoldenCheck(t *testing.T) {
+	path := writeGolden(t, "<html>\n  <body>maintenance</body>\n</html>\n")
+
+	exact, err := newGoldenCheck(path, false)
+	require.NoError(t, err)
+	assert.NoError(t, exact.check(nil, "<html>\n  <body>maintenance</body>\n</html>\n"))
+	assert.EqualError(t, exact.check(nil, "<html>\n  <body>welcome</body>\n</html>\n"),
+		"body differs from '"+path+"' at offset 15 (body is 38 bytes, file is 42 bytes)")
+	assert.Error(t, exact.check(nil, "<html> <body>maintenance</body> </html>"))
+
+	normalized, err := newGoldenCheck(path, true)
+	require.NoError(t, err)
+	assert.NoError(t, normalized.check(nil, "<html> <body>maintenance</body> </html>"))
+	assert.Error(t, normalized.check(nil, "<html> <body>welcome</body> </html>"))
+}
+
+func TestGoldenCheckRereadsFile(t *testing.T) {
+	path := writeGolden(t, "v1")
+
+	golden, err := newGoldenCheck(path, false)
+	require.NoError(t, err)
+	assert.NoError(t, golden.check(nil, "v1"))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("v2"), 0600))
+	assert.Error(t, golden.check(nil, "v1"))
+	assert.NoError(t, golden.check(nil, "v2"))
+}
+
+func TestGoldenCheckMissingFile(t *testing.T) {
+	_, err := newGoldenCheck(filepath.Join(os.TempDir(), "does-not-exist.html"), false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// graphQLRequest is a GraphQL operation sent as JSON in the request body.
+type graphQLRequest struct {
+	Query         string                 `config:"query" validate:"required"`
+	Variables     map[string]interface{} `config:"variables"`
+	OperationName string                 `config:"operation_name"`
+}
+
+// body returns the JSON envelope of the operation.
+func (g *graphQLRequest) body() (string, error) {
+	envelope := struct {
+		Query         string                 `json:"query"`
+		Variables     map[string]interface{} `json:"variables,omitempty"`
+		OperationName string                 `json:"operationName,omitempty"`
+	}{g.Query, g.Variables, g.OperationName}
+
+	b, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("could not encode GraphQL request: %v", err)
+	}
+	return string(b), nil
+}
+
+// checkGraphQLErrors fails if the response reports GraphQL errors, which
+// servers usually do with a 200 status.
+func checkGraphQLErrors(_ *http.Response, body string) error {
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return fmt.Errorf("could not parse GraphQL response: %v", err)
+	}
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(resp.Errors))
+	for i, e := range resp.Errors {
+		messages[i] = e.Message
+	}
+	return fmt.Errorf("GraphQL response contains %d errors: %s", len(resp.Errors), strings.Join(messages, "; "))
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLRequestBody(t *testing.T) {
+	g := &graphQLRequest{Query: "{ health }"}
+	body, err := g.body()
+	require.NoError(t, err)
+	assert.Equal(t, `{"query":"{ health }"}`, body)
+
+	g = &graphQLRequest{
+		Query:         "query User($id: ID!) { user(id: $id) { name } }",
+		Variables:     map[string]interface{}{"id": "42"},
+		OperationName: "User",
+	}
+	body, err = g.body()
+	require.NoError(t, err)
+	assert.Equal(t, `{"query":"query User($id: ID!) { user(id: $id) { name } }","variables":{"id":"42"},"operationName":"User"}`, body)
+}
+
+func TestCheckGraphQLErrors(t *testing.T) {
+	assert.NoError(t, checkGraphQLErrors(nil, `{"data":{"health":"ok"}}`))
+	assert.NoError(t, checkGraphQLErrors(nil, `{"data":{"health":"ok"},"errors":[]}`))
+
+	err := checkGraphQLErrors(nil, `{"data":null,"errors":[{"message":"db down"},{"message":"timeout"}]}`)
+	if assert.Error(t, err) {
+		assert.Equal(t, "GraphQL response contains 2 errors: db down; timeout", err.Error())
+	}
+
+	assert.Error(t, checkGraphQLErrors(nil, `<html>Bad Gateway</html>`))
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// includeHeaders selects the response headers recorded in
+// http.response.headers, either all of them or only the listed ones.
+type includeHeaders struct {
+	all   bool
+	names []string
+}
+
+// Unpack accepts a boolean to include all or no headers, or a list of header
+// names to include only these.
+func (h *includeHeaders) Unpack(v interface{}) error {
+	switch v := v.(type) {
+	case bool:
+		*h = includeHeaders{all: v}
+	case string:
+		if all, err := strconv.ParseBool(v); err == nil {
+			*h = includeHeaders{all: all}
+		} else {
+			*h = includeHeaders{names: []string{http.CanonicalHeaderKey(v)}}
+		}
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, name := range v {
+			s, ok := name.(string)
+			if !ok || s == "" {
+				return fmt.Errorf("invalid header name '%v' in `include_headers`", name)
+			}
+			names = append(names, http.CanonicalHeaderKey(s))
+		}
+		*h = includeHeaders{names: names}
+	default:
+		return fmt.Errorf("`include_headers` must be a boolean or a list of header names, got '%v'", v)
+	}
+	return nil
+}
+
+// fields returns the selected headers of the response, keyed by their
+// canonical name. Headers sent multiple times are returned as a list. Returns
+// nil if no headers are selected.
+func (h includeHeaders) fields(header http.Header) common.MapStr {
+	if !h.all && len(h.names) == 0 {
+		return nil
+	}
+
+	headerFields := common.MapStr{}
+	add := func(canonicalHeaderKey string, vals []string) {
+		if len(vals) > 1 {
+			headerFields[canonicalHeaderKey] = vals
+		} else if len(vals) == 1 {
+			headerFields[canonicalHeaderKey] = vals[0]
+		}
+	}
+
+	if h.all {
+		for canonicalHeaderKey, vals := range header {
+			add(canonicalHeaderKey, vals)
+		}
+	} else {
+		for _, name := range h.names {
+			add(name, header[name])
+		}
+	}
+	return headerFields
+}
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestIncludeHeadersUnpack(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected includeHeaders
+	}{
+		{"all", true, includeHeaders{all: true}},
+		{"none", false, includeHeaders{}},
+		{"list", []string{"x-request-id", "X-Cache", "via"}, includeHeaders{names: []string{"X-Request-Id", "X-Cache", "Via"}}},
+		{"single", "x-cache", includeHeaders{names: []string{"X-Cache"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := struct {
+				IncludeHeaders includeHeaders `config:"include_headers"`
+			}{}
+			cfg := common.MustNewConfigFrom(map[string]interface{}{"include_headers": test.value})
+			require.NoError(t, cfg.Unpack(&config))
+			assert.Equal(t, test.expected, config.IncludeHeaders)
+		})
+	}
+
+	var h includeHeaders
+	assert.Error(t, h.Unpack(42))
+	assert.Error(t, h.Unpack([]interface{}{"X-Cache", ""}))
+}
+
+func TestIncludeHeadersFields(t *testing.T) {
+	header := http.Header{
+		"X-Request-Id":  []string{"abc"},
+		"Via":           []string{"1.1 a", "1.1 b"},
+		"Authorization": []string{"secret"},
+	}
+
+	assert.Nil(t, includeHeaders{}.fields(header))
+	assert.Equal(t, common.MapStr{
+		"X-Request-Id":  "abc",
+		"Via":           []string{"1.1 a", "1.1 b"},
+		"Authorization": "secret",
+	}, includeHeaders{all: true}.fields(header))
+	assert.Equal(t, common.MapStr{
+		"X-Request-Id": "abc",
+		"Via":          []string{"1.1 a", "1.1 b"},
+	}, includeHeaders{names: []string{"X-Request-Id", "X-Cache", "Via"}}.fields(header))
+}
@@ -18,11 +18,14 @@
 package http
 
 import (
-	"bytes"
+	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -48,54 +51,120 @@ func create(
 		return nil, 0, err
 	}
 
-	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	tls, err := tlscommon.LoadTLSConfigWithReload(config.TLS)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	var body []byte
-	var enc contentEncoder
+	var enc contentEncoder = plainEncoder
 
-	if config.Check.Request.SendBody != "" {
+	if config.Check.Request.GraphQL != nil {
+		config.Check.Request.SendBody, err = config.Check.Request.GraphQL.body()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if config.Check.Request.SOAP != nil {
+		config.Check.Request.SendBody = config.Check.Request.SOAP.envelope()
+	}
+	if config.Check.Request.Multipart != nil {
+		config.Check.Request.SendBody, err = config.Check.Request.Multipart.body()
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if config.Check.Request.SendBody != "" || config.Check.Request.SendBodyFile != "" {
 		var err error
 		compression := config.Check.Request.Compression
 		enc, err = getContentEncoder(compression.Type, compression.Level)
 		if err != nil {
 			return nil, 0, err
 		}
+	}
+
+	body, err := newRequestBody(&config.Check.Request, enc)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	validator, err := makeValidateResponse(&config.Check.Response)
+	if err != nil {
+		return nil, 0, err
+	}
+	if config.Check.Request.GraphQL != nil {
+		validator.bodyValidators = append(validator.bodyValidators, checkGraphQLErrors)
+	}
+	if config.Check.Request.SOAP != nil {
+		validator.faultValidators = append(validator.faultValidators, checkSOAPFault)
+	}
+	if config.Check.Request.CORS != nil {
+		if config.Check.Response.Severity.warns("cors") {
+			validator.warnRespValidators = append(validator.warnRespValidators, checkCORS(config.Check.Request.CORS))
+		} else {
+			validator.respValidators = append(validator.respValidators, checkCORS(config.Check.Request.CORS))
+		}
+	}
 
-		buf := bytes.NewBuffer(nil)
-		err = enc.Encode(buf, bytes.NewBufferString(config.Check.Request.SendBody))
+	config.Response.jsonFields, err = makeJSONFieldsExtractor(config.Response.JSONFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	config.resolver, err = config.Mode.MakeResolver(config.Timeout)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if config.ProxyPAC != "" {
+		config.proxyPAC, err = loadPACScript(config.ProxyPAC, config.Timeout)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		body = buf.Bytes()
 	}
 
-	validator, err := makeValidateResponse(&config.Check.Response)
+	auth, err := makeAuthorizer(&config, tls)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	// The monitored hosts advertise the forced protocol, if any, via ALPN.
+	// The authorizer keeps the plain TLS config for its own requests.
+	protoTLS := protocolTLSConfig(tls, config.Check.Request.Protocol)
+
 	// Determine whether we're using a proxy or not and then use that to figure out how to
 	// run the job
 	var makeJob func(string) (jobs.Job, error)
-	// In the event that a ProxyURL is present, or redirect support is enabled
+	// In the event that a proxy is configured, redirect support is enabled, or a unix socket is used
 	// we execute DNS resolution requests inline with the request, not running them as a separate job, and not returning
 	// separate DNS rtt data.
-	if config.ProxyURL != "" || config.MaxRedirects > 0 {
-		transport, err := newRoundTripper(&config, tls)
+	if isHTTP3(config.Check.Request.Protocol) {
+		// QUIC runs over UDP, DNS resolution and connection setup are part of
+		// the request. Each host gets its own transport, as the QUIC sessions
+		// are closed after each check.
+		makeJob = func(urlStr string) (jobs.Job, error) {
+			transport := newHTTP3RoundTripper(&config, tls)
+			return newHTTPMonitorHostJob(urlStr, &config, transport, auth, enc, body, validator)
+		}
+	} else if config.ProxyURL != "" || config.ProxyFromEnvironment || config.ProxyPAC != "" ||
+		config.MaxRedirects > 0 || config.SocketPath != "" {
+		var transport http.RoundTripper
+		var err error
+		if isHTTP2(config.Check.Request.Protocol) {
+			transport, err = newHTTP2RoundTripper(&config, protoTLS)
+		} else {
+			transport, err = newRoundTripper(&config, protoTLS)
+		}
 		if err != nil {
 			return nil, 0, err
 		}
 
 		makeJob = func(urlStr string) (jobs.Job, error) {
-			return newHTTPMonitorHostJob(urlStr, &config, transport, enc, body, validator)
+			return newHTTPMonitorHostJob(urlStr, &config, transport, auth, enc, body, validator)
 		}
 	} else {
 		makeJob = func(urlStr string) (jobs.Job, error) {
-			return newHTTPMonitorIPsJob(&config, urlStr, tls, enc, body, validator)
+			return newHTTPMonitorIPsJob(&config, urlStr, protoTLS, auth, enc, body, validator)
 		}
 	}
 
@@ -121,15 +190,23 @@ func create(
 
 func newRoundTripper(config *Config, tls *tlscommon.TLSConfig) (*http.Transport, error) {
 	var proxy func(*http.Request) (*url.URL, error)
-	if config.ProxyURL != "" {
+	switch {
+	case config.ProxyURL != "" && !isSOCKS5Proxy(config.ProxyURL):
 		url, err := url.Parse(config.ProxyURL)
 		if err != nil {
 			return nil, err
 		}
 		proxy = http.ProxyURL(url)
+	case config.proxyPAC != nil:
+		proxy = config.proxyPAC.proxy
+	case config.ProxyFromEnvironment:
+		proxy = http.ProxyFromEnvironment
 	}
 
-	dialer := transport.NetDialer(config.Timeout)
+	dialer, err := netDialer(config)
+	if err != nil {
+		return nil, err
+	}
 	tlsDialer, err := transport.TLSDialer(dialer, tls, config.Timeout)
 	if err != nil {
 		return nil, err
@@ -139,7 +216,72 @@ func newRoundTripper(config *Config, tls *tlscommon.TLSConfig) (*http.Transport,
 		Proxy:             proxy,
 		Dial:              dialer.Dial,
 		DialTLS:           tlsDialer.Dial,
-		TLSClientConfig:   tls.ToConfig(),
+		TLSClientConfig:   clientTLSConfig(tls),
 		DisableKeepAlives: true,
 	}, nil
 }
+
+// netDialer creates the dialer used to connect to the monitored hosts. If a
+// socket path is configured all connections are made to the unix socket. If a
+// SOCKS5 proxy is configured all connections are made through the proxy. The
+// PROXY protocol header is sent once connected, if configured.
+func netDialer(config *Config) (transport.Dialer, error) {
+	if config.SocketPath != "" {
+		return transport.UnixDialer(config.Timeout, config.SocketPath), nil
+	}
+
+	dialer := transport.FamilyNetDialer(config.Timeout, config.Mode.IPFamily())
+	if len(config.Mode.Resolver) > 0 && config.resolver != nil {
+		dialer = resolvingDialer(config.resolver, config.Mode.Network(), config.Timeout)
+	}
+	if isSOCKS5Proxy(config.ProxyURL) {
+		proxyConfig := &transport.ProxyConfig{
+			URL:          config.ProxyURL,
+			LocalResolve: config.ProxyUseLocalResolver,
+		}
+		var err error
+		dialer, err = transport.ProxyDialer(logp.NewLogger("http"), proxyConfig, dialer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.ProxyProtocol != nil {
+		dialer = dialchain.ProxyProtocolDialer(config.ProxyProtocol, dialer)
+	}
+	return dialer, nil
+}
+
+// resolvingDialer creates a dialer resolving host names with the given
+// resolver, instead of the system resolver.
+func resolvingDialer(resolver monitors.Resolver, network string, timeout time.Duration) transport.Dialer {
+	dialer := &net.Dialer{Timeout: timeout}
+	return transport.DialerFunc(func(dialNetwork, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := resolver.LookupIP(host)
+		if err != nil {
+			return nil, err
+		}
+		var addresses []string
+		for _, ip := range ips {
+			if network == "ip" || (network == "ip4") == (ip.To4() != nil) {
+				addresses = append(addresses, ip.String())
+			}
+		}
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("no %v address resolvable for host %v", network, host)
+		}
+
+		return transport.DialWith(dialer, dialNetwork, host, addresses, port)
+	})
+}
+
+// isSOCKS5Proxy reports whether the proxy URL refers to a SOCKS5 proxy. SOCKS5
+// proxies are dialed through, instead of being used as HTTP proxy.
+func isSOCKS5Proxy(proxyURL string) bool {
+	u, err := url.Parse(proxyURL)
+	return err == nil && u.Scheme == "socks5"
+}
@@ -18,9 +18,13 @@
 package http
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -28,13 +32,23 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/armon/go-socks5"
+	"github.com/lucas-clemente/quic-go/http3"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
+	"github.com/elastic/beats/v7/heartbeat/eventext"
 	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
 	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
@@ -135,6 +149,7 @@ func minimalRespondingHTTPChecks(url string, statusCode int) validator.Validator
 		httpBodyChecks(),
 		lookslike.MustCompile(map[string]interface{}{
 			"http": map[string]interface{}{
+				"version":              "1.1",
 				"response.status_code": statusCode,
 				"rtt.total.us":         isdef.IsDuration,
 			},
@@ -386,6 +401,67 @@ func TestHTTPSServer(t *testing.T) {
 	runHTTPSServerCheck(t, server, nil)
 }
 
+func TestHTTPSOCSPRequireStaple(t *testing.T) {
+	server := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer server.Close()
+
+	cert, err := x509.ParseCertificate(server.TLS.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	certFile := hbtest.CertToTempFile(t, cert)
+	require.NoError(t, certFile.Close())
+	defer os.Remove(certFile.Name())
+
+	event := sendTLSRequest(t, server.URL, true, map[string]interface{}{
+		"ssl.certificate_authorities":                    certFile.Name(),
+		"check.response.certificate.ocsp.enabled":        true,
+		"check.response.certificate.ocsp.require_staple": true,
+	})
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.SummaryChecks(0, 1),
+			lookslike.MustCompile(map[string]interface{}{
+				"monitor.status":          "down",
+				"error.type":              "validate",
+				"tls.server.ocsp.status":  "missing",
+				"tls.server.ocsp.stapled": false,
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestHTTPSPinnedSPKIIncludePEM(t *testing.T) {
+	server := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer server.Close()
+
+	cert, err := x509.ParseCertificate(server.TLS.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	certFile := hbtest.CertToTempFile(t, cert)
+	require.NoError(t, certFile.Close())
+	defer os.Remove(certFile.Name())
+
+	event := sendTLSRequest(t, server.URL, true, map[string]interface{}{
+		"ssl.certificate_authorities":              certFile.Name(),
+		"check.response.certificate.pinned_sha256": []string{fmt.Sprintf("%x", sha256.Sum256([]byte("other")))},
+		"check.response.certificate.include_pem":   "on_error",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status": "down",
+			"error.type":     "validate",
+			"tls.server.certificate": string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: cert.Raw,
+			})),
+		}),
+		event.Fields,
+	)
+}
+
 func TestExpiredHTTPSServer(t *testing.T) {
 	tlsCert, err := tls.LoadX509KeyPair("../fixtures/expired.cert", "../fixtures/expired.key")
 	require.NoError(t, err)
@@ -464,6 +540,7 @@ func TestConnRefusedJob(t *testing.T) {
 			hbtest.SummaryChecks(0, 1),
 			hbtest.ErrorChecks(url, "io"),
 			urlChecks(url),
+			hbtest.ConnRefusedErrorCodeChecks(),
 		)),
 		event.Fields,
 	)
@@ -535,6 +612,7 @@ func TestRedirect(t *testing.T) {
 					// For redirects that are followed we shouldn't record this header because there's no sensible
 					// value
 					"http.response.headers.Location": isdef.KeyMissing,
+					"http.connection.reused":         false,
 					"http.response.redirects": []string{
 						server.URL + redirectingPaths["/redirect_one"],
 						server.URL + redirectingPaths["/redirect_two"],
@@ -546,6 +624,24 @@ func TestRedirect(t *testing.T) {
 	}
 }
 
+func TestSelectedHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Cache", "HIT")
+		w.Header().Set("X-Request-Id", "abc")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+		"response.include_headers": []string{"X-Request-Id", "x-cache", "Via"},
+	})
+
+	headers, err := event.GetValue("http.response.headers")
+	require.NoError(t, err)
+	require.Equal(t, common.MapStr{"X-Cache": "HIT", "X-Request-Id": "abc"}, headers)
+}
+
 func TestNoHeaders(t *testing.T) {
 	server := httptest.NewServer(hbtest.HelloWorldHandler(200))
 	defer server.Close()
@@ -586,8 +682,9 @@ func TestNoHeaders(t *testing.T) {
 
 func TestNewRoundTripper(t *testing.T) {
 	configs := map[string]Config{
-		"Plain":      {Timeout: time.Second},
-		"With Proxy": {Timeout: time.Second, ProxyURL: "http://localhost:1234"},
+		"Plain":             {Timeout: time.Second},
+		"With Proxy":        {Timeout: time.Second, ProxyURL: "http://localhost:1234"},
+		"With SOCKS5 Proxy": {Timeout: time.Second, ProxyURL: "socks5://localhost:1234"},
 	}
 
 	for name, config := range configs {
@@ -595,7 +692,7 @@ func TestNewRoundTripper(t *testing.T) {
 			transp, err := newRoundTripper(&config, &tlscommon.TLSConfig{})
 			require.NoError(t, err)
 
-			if config.ProxyURL == "" {
+			if config.ProxyURL == "" || isSOCKS5Proxy(config.ProxyURL) {
 				require.Nil(t, transp.Proxy)
 			} else {
 				require.NotNil(t, transp.Proxy)
@@ -611,3 +708,710 @@ func TestNewRoundTripper(t *testing.T) {
 	}
 
 }
+
+func TestCustomMethod(t *testing.T) {
+	var receivedMethod string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedMethod = r.Method
+		w.WriteHeader(207)
+	}))
+	defer server.Close()
+
+	event := sendTLSRequest(t, server.URL, true, map[string]interface{}{
+		"check.request.method":  "PROPFIND",
+		"check.response.status": []uint16{207},
+	})
+
+	require.Equal(t, "PROPFIND", receivedMethod)
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "http"),
+			hbtest.SummaryChecks(1, 0),
+			minimalRespondingHTTPChecks(server.URL, 207),
+		),
+		event.Fields,
+	)
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var tokenRequests int
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		require.Equal(t, "monitoring", r.Form.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"abc123","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config, err := common.NewConfigFrom(map[string]interface{}{
+		"hosts":                server.URL,
+		"timeout":              "1s",
+		"oauth2.token_url":     tokenServer.URL,
+		"oauth2.client.id":     "heartbeat",
+		"oauth2.client.secret": "secret",
+		"oauth2.scopes":        []string{"monitoring"},
+	})
+	require.NoError(t, err)
+
+	jobs, _, err := create("oauth2", config)
+	require.NoError(t, err)
+
+	sched := schedule.MustParse("@every 1s")
+	job := wrappers.WrapCommon(jobs, stdfields.StdMonitorFields{ID: "oauth2", Type: "http", Schedule: sched, Timeout: 1})[0]
+
+	// The token is cached between runs of the monitor.
+	for i := 0; i < 2; i++ {
+		event := &beat.Event{}
+		_, err = job(event)
+		require.NoError(t, err)
+
+		testslike.Test(
+			t,
+			lookslike.Compose(
+				hbtest.BaseChecks("127.0.0.1", "up", "http"),
+				hbtest.SummaryChecks(1, 0),
+				minimalRespondingHTTPChecks(server.URL, 200),
+			),
+			event.Fields,
+		)
+	}
+	require.Equal(t, 1, tokenRequests)
+}
+
+// sendProtocolRequest checks the TLS server with the given protocol settings.
+func sendProtocolRequest(t *testing.T, server *httptest.Server, extraConfig map[string]interface{}) *beat.Event {
+	cert, err := x509.ParseCertificate(server.TLS.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	certFile := hbtest.CertToTempFile(t, cert)
+	require.NoError(t, certFile.Close())
+	defer os.Remove(certFile.Name())
+
+	config := map[string]interface{}{"ssl.certificate_authorities": certFile.Name()}
+	for k, v := range extraConfig {
+		config[k] = v
+	}
+	return sendTLSRequest(t, server.URL, false, config)
+}
+
+func TestHTTP2Protocol(t *testing.T) {
+	server := httptest.NewUnstartedServer(hbtest.HelloWorldHandler(http.StatusOK))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, maxRedirects := range []int{0, 1} {
+		t.Run(fmt.Sprintf("max_redirects %d", maxRedirects), func(t *testing.T) {
+			event := sendProtocolRequest(t, server, map[string]interface{}{
+				"max_redirects":          maxRedirects,
+				"check.request.protocol": "h2",
+				"check.response.alpn":    "h2",
+			})
+
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":            "up",
+					"http.version":              "2.0",
+					"http.response.status_code": http.StatusOK,
+				}),
+				event.Fields,
+			)
+		})
+	}
+}
+
+func TestHTTP2ProtocolNoFallback(t *testing.T) {
+	// The test server only speaks HTTP/1.1
+	server := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer server.Close()
+
+	event := sendProtocolRequest(t, server, map[string]interface{}{
+		"check.request.protocol": "h2",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status": "down",
+			"error.type":     "io",
+		}),
+		event.Fields,
+	)
+}
+
+func TestALPNMismatch(t *testing.T) {
+	server := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer server.Close()
+
+	event := sendProtocolRequest(t, server, map[string]interface{}{
+		"check.response.alpn": "h2",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status": "down",
+			"error.type":     "validate",
+			"http.version":   "1.1",
+		}),
+		event.Fields,
+	)
+}
+
+func TestH2CProtocol(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(hbtest.HelloWorldHandler(http.StatusOK), &http2.Server{}))
+	defer server.Close()
+
+	event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+		"check.request.protocol": "h2c",
+		"check.response.alpn":    "h2c",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status": "up",
+			"http.version":   "2.0",
+		}),
+		event.Fields,
+	)
+}
+
+func TestHTTP3Protocol(t *testing.T) {
+	// Reuse the certificate of a TLS test server for the QUIC listener
+	tlsServer := httptest.NewTLSServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer tlsServer.Close()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &http3.Server{Server: &http.Server{
+		Handler:   hbtest.HelloWorldHandler(http.StatusOK),
+		TLSConfig: &tls.Config{Certificates: tlsServer.TLS.Certificates},
+	}}
+	go server.Serve(conn)
+	defer server.Close()
+
+	cert, err := x509.ParseCertificate(tlsServer.TLS.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	certFile := hbtest.CertToTempFile(t, cert)
+	require.NoError(t, certFile.Close())
+	defer os.Remove(certFile.Name())
+
+	event := sendTLSRequest(t, "https://"+conn.LocalAddr().String(), false, map[string]interface{}{
+		"ssl.certificate_authorities": certFile.Name(),
+		"check.request.protocol":      "h3",
+		"check.response.alpn":         "h3",
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status":            "up",
+			"http.version":              "3.0",
+			"http.response.status_code": http.StatusOK,
+		}),
+		event.Fields,
+	)
+}
+
+func TestHTTP3DialerNetwork(t *testing.T) {
+	dial := quicDialer(monitors.NewStdResolver(), "ip6")
+	_, err := dial("udp", "127.0.0.1:443", &tls.Config{}, nil)
+	assert.Error(t, err, "IPv4 addresses must not be dialed with ipv4 disabled")
+
+	dial = quicDialer(monitors.NewStdResolver(), "")
+	_, err = dial("udp", "127.0.0.1:443", &tls.Config{}, nil)
+	assert.Error(t, err)
+}
+
+func TestJSONFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"build": {"version": "1.2.3"}, "queue": {"depth": 42}}`)
+	}))
+	defer server.Close()
+
+	event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+		"response.json_fields": []map[string]interface{}{
+			{"path": "$.build.version", "target": "service.version"},
+			{"path": "$.queue.depth", "target": "queue.depth"},
+		},
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status":  "up",
+			"service.version": "1.2.3",
+			"queue.depth":     int64(42),
+		}),
+		event.Fields,
+	)
+}
+
+func TestUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "heartbeat-http")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	listener, err := net.Listen("unix", filepath.Join(dir, "app.sock"))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", hbtest.HelloWorldHandler(http.StatusOK))
+	server := httptest.NewUnstartedServer(mux)
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	event := sendTLSRequest(t, "http://localhost/health", false, map[string]interface{}{
+		"socket_path": filepath.Join(dir, "app.sock"),
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status":            "up",
+			"http.response.status_code": http.StatusOK,
+		}),
+		event.Fields,
+	)
+}
+
+func TestSOCKS5Proxy(t *testing.T) {
+	server := httptest.NewServer(hbtest.HelloWorldHandler(http.StatusOK))
+	defer server.Close()
+
+	proxy, err := socks5.New(&socks5.Config{})
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go proxy.Serve(listener)
+
+	for _, localResolver := range []bool{false, true} {
+		t.Run(fmt.Sprintf("proxy_use_local_resolver %v", localResolver), func(t *testing.T) {
+			event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+				"proxy_url":                "socks5://" + listener.Addr().String(),
+				"proxy_use_local_resolver": localResolver,
+			})
+
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":            "up",
+					"http.response.status_code": http.StatusOK,
+				}),
+				event.Fields,
+			)
+		})
+	}
+}
+
+func TestConditionalRequest(t *testing.T) {
+	modTime := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	handlers := map[string]http.HandlerFunc{
+		"valid": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			http.ServeContent(w, r, "", modTime, strings.NewReader("hello world"))
+		},
+		"broken cache": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte("hello world"))
+		},
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			config, err := common.NewConfigFrom(map[string]interface{}{
+				"hosts":                     server.URL,
+				"timeout":                   "1s",
+				"check.request.conditional": true,
+			})
+			require.NoError(t, err)
+
+			js, _, err := create("conditional", config)
+			require.NoError(t, err)
+
+			sched := schedule.MustParse("@every 1s")
+			job := wrappers.WrapCommon(js, stdfields.StdMonitorFields{ID: "conditional", Type: "http", Schedule: sched, Timeout: 1})[0]
+
+			event := &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":            "up",
+					"http.request.conditional":  false,
+					"http.response.status_code": http.StatusOK,
+				}),
+				event.Fields,
+			)
+
+			event = &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+			if name == "valid" {
+				testslike.Test(
+					t,
+					lookslike.MustCompile(map[string]interface{}{
+						"monitor.status":            "up",
+						"http.request.conditional":  true,
+						"http.response.status_code": http.StatusNotModified,
+					}),
+					event.Fields,
+				)
+			} else {
+				testslike.Test(
+					t,
+					lookslike.MustCompile(map[string]interface{}{
+						"monitor.status":            "down",
+						"http.request.conditional":  true,
+						"http.response.status_code": http.StatusOK,
+						"error.type":                "validate",
+					}),
+					event.Fields,
+				)
+			}
+		})
+	}
+}
+
+func TestConditionalReplay(t *testing.T) {
+	modTime := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	handlers := map[string]struct {
+		handler http.HandlerFunc
+		status  string
+		replay  int
+	}{
+		"valid": {
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				http.ServeContent(w, r, "", modTime, strings.NewReader("hello world"))
+			},
+			"up", http.StatusNotModified,
+		},
+		"broken cache": {
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				w.Write([]byte("hello world"))
+			},
+			"down", http.StatusOK,
+		},
+	}
+
+	for name, test := range handlers {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(test.handler)
+			defer server.Close()
+
+			config, err := common.NewConfigFrom(map[string]interface{}{
+				"hosts":                     server.URL,
+				"timeout":                   "1s",
+				"check.request.conditional": "replay",
+			})
+			require.NoError(t, err)
+
+			js, _, err := create("conditional", config)
+			require.NoError(t, err)
+
+			sched := schedule.MustParse("@every 1s")
+			job := wrappers.WrapCommon(js, stdfields.StdMonitorFields{ID: "conditional", Type: "http", Schedule: sched, Timeout: 1})[0]
+
+			event := &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":               test.status,
+					"http.response.status_code":    http.StatusOK,
+					"http.conditional.status_code": test.replay,
+				}),
+				event.Fields,
+			)
+			_, err = event.Fields.GetValue("http.request.conditional")
+			assert.Error(t, err, "the previous mode fields are not reported")
+		})
+	}
+}
+
+func TestGraphQLRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" ||
+			json.NewDecoder(r.Body).Decode(&req) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req["operationName"] == "Broken" {
+			io.WriteString(w, `{"data":null,"errors":[{"message":"resolver failed"}]}`)
+			return
+		}
+		io.WriteString(w, `{"data":{"health":"ok"}}`)
+	}))
+	defer server.Close()
+
+	for operation, status := range map[string]string{"Health": "up", "Broken": "down"} {
+		t.Run(operation, func(t *testing.T) {
+			config, err := common.NewConfigFrom(map[string]interface{}{
+				"hosts":                                server.URL,
+				"timeout":                              "1s",
+				"check.request.graphql.query":          "query " + operation + " { health }",
+				"check.request.graphql.operation_name": operation,
+			})
+			require.NoError(t, err)
+
+			js, _, err := create("graphql", config)
+			require.NoError(t, err)
+
+			sched := schedule.MustParse("@every 1s")
+			job := wrappers.WrapCommon(js, stdfields.StdMonitorFields{ID: "graphql", Type: "http", Schedule: sched, Timeout: 1})[0]
+
+			event := &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":            status,
+					"http.response.status_code": http.StatusOK,
+				}),
+				event.Fields,
+			)
+		})
+	}
+}
+
+func TestSOAPRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("SOAPAction") != `"urn:status#GetStatus"` ||
+			r.Header.Get("Content-Type") != "text/xml; charset=utf-8" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		if strings.Contains(string(body), "broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`+
+				`<soap:Fault><faultcode>soap:Server</faultcode><faultstring>database unavailable</faultstring></soap:Fault>`+
+				`</soap:Body></soap:Envelope>`)
+			return
+		}
+		io.WriteString(w, xpathTestDoc)
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		service string
+		state   string
+		status  string
+		message string
+	}{
+		"up":         {"db", "up", "up", ""},
+		"down state": {"cache", "up", "down", "XML body did not match 1 xpath checks 'service state' for monitor"},
+		"fault":      {"broken", "up", "down", "SOAP fault 'soap:Server': database unavailable"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := common.NewConfigFrom(map[string]interface{}{
+				"hosts":                     server.URL,
+				"timeout":                   "1s",
+				"check.request.soap.action": "urn:status#GetStatus",
+				"check.request.soap.body":   `<m:GetStatus xmlns:m="urn:status"><m:Service>` + test.service + `</m:Service></m:GetStatus>`,
+				"check.response.xpath": []map[string]interface{}{{
+					"description": "service state",
+					"expression":  "//m:Service[@name='" + test.service + "']/@state",
+					"namespaces":  map[string]interface{}{"m": "urn:status"},
+					"equals":      test.state,
+				}},
+			})
+			require.NoError(t, err)
+
+			js, _, err := create("soap", config)
+			require.NoError(t, err)
+
+			sched := schedule.MustParse("@every 1s")
+			job := wrappers.WrapCommon(js, stdfields.StdMonitorFields{ID: "soap", Type: "http", Schedule: sched, Timeout: 1})[0]
+
+			event := &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+
+			expected := map[string]interface{}{"monitor.status": test.status}
+			if test.message != "" {
+				expected["error.message"] = test.message
+			}
+			testslike.Test(t, lookslike.MustCompile(expected), event.Fields)
+		})
+	}
+}
+
+func TestExpectDown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocked" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		url     string
+		status  string
+		message string
+	}{
+		"blocked status": {server.URL + "/blocked", "up", ""},
+		"unreachable":    {"http://127.0.0.1:1/", "up", ""},
+		"reachable":      {server.URL + "/open", "down", "target is reachable, but is expected to be down"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := common.NewConfigFrom(map[string]interface{}{
+				"hosts":   test.url,
+				"timeout": "1s",
+			})
+			require.NoError(t, err)
+
+			js, _, err := create("expect-down", config)
+			require.NoError(t, err)
+
+			sched := schedule.MustParse("@every 1s")
+			job := wrappers.WrapCommon(js, stdfields.StdMonitorFields{ID: "expect-down", Type: "http", Schedule: sched, Timeout: 1, Expect: stdfields.ExpectDown})[0]
+
+			event := &beat.Event{}
+			_, err = job(event)
+			require.NoError(t, err)
+
+			expected := map[string]interface{}{"monitor.status": test.status}
+			if test.message != "" {
+				expected["error.message"] = test.message
+			}
+			testslike.Test(t, lookslike.MustCompile(expected), event.Fields)
+		})
+	}
+}
+
+func TestProxyPAC(t *testing.T) {
+	// The proxy receives the requests for the external hosts
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "www.external.test" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Proxied", "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	dir, err := ioutil.TempDir("", "heartbeat-pac")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	pacFile := filepath.Join(dir, "proxy.pac")
+	script := fmt.Sprintf(`function FindProxyForURL(url, host) {
+		return dnsDomainIs(host, ".external.test") ? "PROXY %s" : "DIRECT";
+	}`, proxyURL.Host)
+	require.NoError(t, ioutil.WriteFile(pacFile, []byte(script), 0644))
+
+	event := sendTLSRequest(t, "http://www.external.test/", false, map[string]interface{}{
+		"proxy_pac":              pacFile,
+		"check.response.headers": map[string]interface{}{"X-Proxied": "true"},
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status":            "up",
+			"http.response.status_code": http.StatusOK,
+		}),
+		event.Fields,
+	)
+}
+
+func TestServerTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Server-Timing", `db;dur=53.2, cache;desc="Cache Read"`)
+		w.Header().Add("Server-Timing", "app;dur=12")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+		"response.include_headers": false,
+	})
+
+	testslike.Test(
+		t,
+		lookslike.MustCompile(map[string]interface{}{
+			"monitor.status": "up",
+			"http.response.server_timing": map[string]interface{}{
+				"db":    map[string]interface{}{"dur": 53.2},
+				"cache": map[string]interface{}{"desc": "Cache Read"},
+				"app":   map[string]interface{}{"dur": float64(12)},
+			},
+		}),
+		event.Fields,
+	)
+}
+
+func TestThrottled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	for _, honor := range []bool{false, true} {
+		t.Run(fmt.Sprintf("honor_retry_after %v", honor), func(t *testing.T) {
+			event := sendTLSRequest(t, server.URL, false, map[string]interface{}{
+				"response.honor_retry_after": honor,
+			})
+
+			testslike.Test(
+				t,
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor.status":            "down",
+					"monitor.status_detail":     "throttled",
+					"error.type":                "validate",
+					"http.response.status_code": http.StatusTooManyRequests,
+				}),
+				event.Fields,
+			)
+
+			until, ok := eventext.TakeRetryAfter(event)
+			require.Equal(t, honor, ok)
+			if honor {
+				require.WithinDuration(t, time.Now().Add(2*time.Minute), until, 10*time.Second)
+			}
+		})
+	}
+}
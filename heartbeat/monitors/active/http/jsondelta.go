@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// jsonDeltaConfig bounds the change of a numeric JSON field between two runs
+// of a monitor, for example to check a counter increases with `min: 1`.
+type jsonDeltaConfig struct {
+	Field string   `config:"field" validate:"required"`
+	Min   *float64 `config:"min"`
+	Max   *float64 `config:"max"`
+}
+
+// Validate checks at least one bound is configured.
+func (c *jsonDeltaConfig) Validate() error {
+	if c.Min == nil && c.Max == nil {
+		return errors.New("`delta` requires `min` or `max`")
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return fmt.Errorf("`delta.min` (%v) must not be greater than `delta.max` (%v)", *c.Min, *c.Max)
+	}
+	return nil
+}
+
+// jsonDelta keeps the value of the field in the previous response of each
+// origin of the monitor, to check the change of the value in the next response.
+type jsonDelta struct {
+	config   jsonDeltaConfig
+	mu       sync.Mutex
+	previous map[string]float64
+}
+
+func newJSONDelta(config *jsonDeltaConfig) *jsonDelta {
+	return &jsonDelta{config: *config, previous: map[string]float64{}}
+}
+
+// check validates the change of the field since the previous response of the
+// origin and remembers its value. The first response of an origin always
+// passes.
+func (d *jsonDelta) check(origin string, decoded common.MapStr) error {
+	raw, err := decoded.GetValue(d.config.Field)
+	if err != nil {
+		return fmt.Errorf("field %v not found", d.config.Field)
+	}
+
+	var value float64
+	switch v := raw.(type) {
+	case int64:
+		value = float64(v)
+	case float64:
+		value = v
+	default:
+		return fmt.Errorf("field %v is not a number: %v", d.config.Field, raw)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	previous, found := d.previous[origin]
+	d.previous[origin] = value
+	if !found {
+		return nil
+	}
+
+	delta := value - previous
+	if d.config.Min != nil && delta < *d.config.Min {
+		return fmt.Errorf("%v changed by %v from %v to %v, expecting at least %v",
+			d.config.Field, delta, previous, value, *d.config.Min)
+	}
+	if d.config.Max != nil && delta > *d.config.Max {
+		return fmt.Errorf("%v changed by %v from %v to %v, expecting at most %v",
+			d.config.Field, delta, previous, value, *d.config.Max)
+	}
+	return nil
+}
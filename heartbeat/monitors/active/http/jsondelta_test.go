@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestJSONDeltaCheck(t *testing.T) {
+	one, hundred := 1.0, 100.0
+	delta := newJSONDelta(&jsonDeltaConfig{Field: "queue.depth", Max: &hundred})
+	depth := func(v interface{}) common.MapStr {
+		return common.MapStr{"queue": common.MapStr{"depth": v}}
+	}
+
+	assert.NoError(t, delta.check("http://a", depth(int64(10))))
+	assert.NoError(t, delta.check("http://a", depth(int64(110))))
+	assert.NoError(t, delta.check("http://a", depth(5.5)))
+	assert.Error(t, delta.check("http://a", depth(int64(200))))
+
+	// the value is remembered per URL, and after failed checks
+	assert.NoError(t, delta.check("http://b", depth(int64(1000))))
+	assert.NoError(t, delta.check("http://a", depth(int64(250))))
+
+	assert.Error(t, delta.check("http://a", common.MapStr{}))
+	assert.Error(t, delta.check("http://a", depth("10")))
+
+	counter := newJSONDelta(&jsonDeltaConfig{Field: "build_counter", Min: &one})
+	assert.NoError(t, counter.check("http://a", common.MapStr{"build_counter": int64(7)}))
+	assert.NoError(t, counter.check("http://a", common.MapStr{"build_counter": int64(8)}))
+	assert.Error(t, counter.check("http://a", common.MapStr{"build_counter": int64(8)}))
+}
+
+func TestJSONDeltaConfigValidate(t *testing.T) {
+	zero, one := 0.0, 1.0
+
+	assert.NoError(t, (&jsonDeltaConfig{Field: "a", Min: &zero}).Validate())
+	assert.NoError(t, (&jsonDeltaConfig{Field: "a", Min: &zero, Max: &one}).Validate())
+	assert.Error(t, (&jsonDeltaConfig{Field: "a"}).Validate())
+	assert.Error(t, (&jsonDeltaConfig{Field: "a", Min: &one, Max: &zero}).Validate())
+}
+
+func TestCheckJSONDelta(t *testing.T) {
+	zero := 0.0
+	checker, err := checkJSON([]*jsonResponseCheck{{
+		Description: "counter increasing",
+		Delta:       &jsonDeltaConfig{Field: "counter", Min: &zero},
+	}})
+	require.NoError(t, err)
+
+	resp := &http.Response{Request: &http.Request{URL: &url.URL{Scheme: "http", Host: "localhost"}}}
+	assert.NoError(t, checker(resp, `{"counter": 2}`))
+	assert.NoError(t, checker(resp, `{"counter": 3}`))
+	err = checker(resp, `{"counter": 1}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "counter increasing (counter changed by -2 from 3 to 1, expecting at least 0)")
+}
+
+func TestCheckJSONDeltaModeAll(t *testing.T) {
+	zero := 0.0
+	checker, err := checkJSON([]*jsonResponseCheck{{
+		Description: "counter increasing",
+		Delta:       &jsonDeltaConfig{Field: "counter", Min: &zero},
+	}})
+	require.NoError(t, err)
+
+	// with `mode: all` the requests of each IP of the host share the URL
+	req := &http.Request{URL: &url.URL{Scheme: "http", Host: "example.com"}}
+	respFrom := func(ip string) *http.Response {
+		ctx := withDialedIP(context.Background(), net.ParseIP(ip))
+		return &http.Response{Request: req.WithContext(ctx)}
+	}
+	a, b := respFrom("10.0.0.1"), respFrom("10.0.0.2")
+
+	assert.NoError(t, checker(a, `{"counter": 10}`))
+	assert.NoError(t, checker(b, `{"counter": 2}`))
+	assert.NoError(t, checker(a, `{"counter": 11}`))
+	assert.NoError(t, checker(b, `{"counter": 3}`))
+
+	err = checker(b, `{"counter": 1}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "counter changed by -2 from 3 to 1")
+}
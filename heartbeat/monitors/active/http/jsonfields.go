@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+)
+
+type jsonFieldConfig struct {
+	Path   string `config:"path" validate:"required"`
+	Target string `config:"target" validate:"required"`
+}
+
+// jsonFieldsExtractor copies values selected by JSONPath expressions from a
+// JSON response body into event fields.
+type jsonFieldsExtractor struct {
+	fields []jsonField
+}
+
+type jsonField struct {
+	path   *jsonpath.JSONPath
+	target string
+}
+
+// makeJSONFieldsExtractor compiles the JSONPath expressions. It returns nil if
+// no fields are configured.
+func makeJSONFieldsExtractor(configs []jsonFieldConfig) (*jsonFieldsExtractor, error) {
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	extractor := &jsonFieldsExtractor{}
+	for _, c := range configs {
+		path := jsonpath.New(c.Target).AllowMissingKeys(true)
+		if err := path.Parse(jsonPathTemplate(c.Path)); err != nil {
+			return nil, fmt.Errorf("invalid JSONPath '%v' in json_fields: %v", c.Path, err)
+		}
+		extractor.fields = append(extractor.fields, jsonField{path, c.Target})
+	}
+	return extractor, nil
+}
+
+// jsonPathTemplate converts a JSONPath expression, like `$.build.version`, into
+// the template syntax used by the jsonpath package, like `{.build.version}`.
+func jsonPathTemplate(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+
+	path = strings.TrimPrefix(path, "$")
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+// extract returns the event fields selected from the body. Paths not matching
+// any value are skipped. If a path matches multiple values, all values are
+// stored as a list.
+func (e *jsonFieldsExtractor) extract(body string) (common.MapStr, error) {
+	var decoded interface{}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("could not parse JSON body to extract json_fields: %v", err)
+	}
+
+	// Wrap the body, so numbers are also converted for arrays and scalars.
+	root := common.MapStr{"body": decoded}
+	jsontransform.TransformNumbers(root)
+	decoded = root["body"]
+
+	fields := common.MapStr{}
+	for _, f := range e.fields {
+		results, err := f.path.FindResults(decoded)
+		if err != nil {
+			return nil, fmt.Errorf("could not extract json_fields target '%v': %v", f.target, err)
+		}
+
+		var values []interface{}
+		for _, result := range results {
+			for _, v := range result {
+				values = append(values, v.Interface())
+			}
+		}
+
+		switch len(values) {
+		case 0:
+		case 1:
+			fields.Put(f.target, values[0])
+		default:
+			fields.Put(f.target, values)
+		}
+	}
+	return fields, nil
+}
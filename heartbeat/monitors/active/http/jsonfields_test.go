@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestJSONPathTemplate(t *testing.T) {
+	assert.Equal(t, "{.build.version}", jsonPathTemplate("$.build.version"))
+	assert.Equal(t, "{.build.version}", jsonPathTemplate("build.version"))
+	assert.Equal(t, "{.build.version}", jsonPathTemplate(".build.version"))
+	assert.Equal(t, "{[0].name}", jsonPathTemplate("$[0].name"))
+	assert.Equal(t, "{.items[*].name}", jsonPathTemplate("{.items[*].name}"))
+}
+
+func TestJSONFieldsExtract(t *testing.T) {
+	extractor, err := makeJSONFieldsExtractor([]jsonFieldConfig{
+		{Path: "$.build.version", Target: "service.version"},
+		{Path: "$.queue.depth", Target: "queue.depth"},
+		{Path: "$.nodes[*].name", Target: "cluster.nodes"},
+		{Path: "$.missing", Target: "missing"},
+	})
+	require.NoError(t, err)
+
+	fields, err := extractor.extract(`{
+		"build": {"version": "1.2.3"},
+		"queue": {"depth": 42},
+		"nodes": [{"name": "a"}, {"name": "b"}]
+	}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, common.MapStr{
+		"service": common.MapStr{"version": "1.2.3"},
+		"queue":   common.MapStr{"depth": int64(42)},
+		"cluster": common.MapStr{"nodes": []interface{}{"a", "b"}},
+	}, fields)
+}
+
+func TestJSONFieldsExtractNotJSON(t *testing.T) {
+	extractor, err := makeJSONFieldsExtractor([]jsonFieldConfig{
+		{Path: "$.status", Target: "status"},
+	})
+	require.NoError(t, err)
+
+	_, err = extractor.extract("hello world")
+	assert.Error(t, err)
+}
+
+func TestMakeJSONFieldsExtractor(t *testing.T) {
+	extractor, err := makeJSONFieldsExtractor(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, extractor)
+
+	_, err = makeJSONFieldsExtractor([]jsonFieldConfig{{Path: "$.items[", Target: "items"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	krbclient "gopkg.in/jcmturner/gokrb5.v7/client"
+	"gopkg.in/jcmturner/gokrb5.v7/spnego"
+
+	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+)
+
+const defaultKerberosService = "HTTP"
+
+// kerberosAuthorizer authenticates requests using SPNEGO. The Negotiate token
+// is sent with the first request, so the server does not need to challenge
+// the client first.
+type kerberosAuthorizer struct {
+	config  *kerberos.Config
+	service string
+
+	mu     sync.Mutex
+	client *krbclient.Client
+	// modification time of the credential cache the client was created from
+	loaded time.Time
+}
+
+// newKerberosAuthorizer creates an authorizer for the configured principal.
+// Logging in is delayed until the first check, so that a KDC or credential
+// cache not being available yet fails the check instead of the monitor.
+func newKerberosAuthorizer(config *kerberos.Config) *kerberosAuthorizer {
+	service := config.ServiceName
+	if service == "" {
+		service = defaultKerberosService
+	}
+	return &kerberosAuthorizer{config: config, service: service}
+}
+
+// authorize adds the SPNEGO token to the request. The request headers are
+// copied, as the request is shared between check runs.
+func (a *kerberosAuthorizer) authorize(req *http.Request, _ []byte) error {
+	client, err := a.krbClient()
+	if err != nil {
+		return err
+	}
+
+	req.Header = req.Header.Clone()
+	if err := spnego.SetSPNEGOHeader(client, req, a.servicePrincipal(req)); err != nil {
+		return fmt.Errorf("could not create kerberos SPNEGO token: %w", err)
+	}
+	return nil
+}
+
+// servicePrincipal returns the principal of the service the request is sent
+// to. The hostname is taken from the Host header, as the request URL might
+// point to an IP address.
+func (a *kerberosAuthorizer) servicePrincipal(req *http.Request) string {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return a.service + "/" + host
+}
+
+// krbClient returns the Kerberos client, creating it on first use. Credential
+// caches are renewed outside of Heartbeat (e.g. by kinit or k5start), so the
+// client is recreated whenever the cache file changes. Clients using a keytab
+// or password renew their tickets themselves.
+func (a *kerberosAuthorizer) krbClient() (*krbclient.Client, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var modTime time.Time
+	if a.config.UsesCCache() {
+		info, err := os.Stat(a.config.CCachePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read kerberos credential cache: %w", err)
+		}
+		modTime = info.ModTime()
+	}
+
+	if a.client != nil && modTime.Equal(a.loaded) {
+		return a.client, nil
+	}
+
+	client, err := kerberos.NewKrbClient(a.config)
+	if err != nil {
+		return nil, err
+	}
+	a.client, a.loaded = client, modTime
+	return client, nil
+}
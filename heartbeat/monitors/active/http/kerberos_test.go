@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
+)
+
+func TestKerberosServicePrincipal(t *testing.T) {
+	tests := []struct {
+		service string
+		url     string
+		host    string
+		want    string
+	}{
+		{"", "http://intranet.example.com/health", "", "HTTP/intranet.example.com"},
+		{"", "https://intranet.example.com:8443/health", "", "HTTP/intranet.example.com"},
+		{"", "http://10.0.0.1:8088/cluster", "namenode.example.com:8088", "HTTP/namenode.example.com"},
+		{"HOST", "http://intranet.example.com/", "", "HOST/intranet.example.com"},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", test.url, nil)
+		require.NoError(t, err)
+		if test.host != "" {
+			req.Host = test.host
+		}
+
+		a := newKerberosAuthorizer(&kerberos.Config{ServiceName: test.service})
+		assert.Equal(t, test.want, a.servicePrincipal(req))
+	}
+}
+
+func TestKerberosMissingCCacheFailsCheck(t *testing.T) {
+	config, err := common.NewConfigFrom(map[string]interface{}{
+		"auth_type":   "ccache",
+		"ccache":      filepath.Join("testdata", "missing-krb5cc"),
+		"config_path": "/etc/krb5.conf",
+	})
+	require.NoError(t, err)
+
+	var krb kerberos.Config
+	require.NoError(t, config.Unpack(&krb))
+
+	req, err := http.NewRequest("GET", "http://intranet.example.com/", nil)
+	require.NoError(t, err)
+	header := req.Header
+
+	a := newKerberosAuthorizer(&krb)
+	assert.Error(t, a.authorize(req, nil))
+	assert.Empty(t, header.Get("Authorization"))
+}
+
+func TestKerberosConfigValidate(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"ccache without path": {
+			"auth_type":   "ccache",
+			"config_path": "/etc/krb5.conf",
+		},
+		"keytab without realm": {
+			"auth_type":   "keytab",
+			"keytab":      "/etc/heartbeat.keytab",
+			"username":    "heartbeat",
+			"config_path": "/etc/krb5.conf",
+		},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+
+			var krb kerberos.Config
+			assert.Error(t, config.Unpack(&krb))
+		})
+	}
+}
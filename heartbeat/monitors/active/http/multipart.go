@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// multipartRequest is a multipart/form-data body of form fields and files,
+// for example to check upload endpoints.
+type multipartRequest struct {
+	Fields map[string]string `config:"fields"`
+	Files  []multipartFile   `config:"files"`
+
+	// contentType holds the boundary of the body, it is set when the body is
+	// built
+	contentType string
+}
+
+// multipartFile is a file part whose content is loaded from disk.
+type multipartFile struct {
+	Name        string `config:"name" validate:"required"`
+	Path        string `config:"path" validate:"required"`
+	Filename    string `config:"filename"`     // defaults to the base name of the path
+	ContentType string `config:"content_type"` // defaults to application/octet-stream
+}
+
+// Validate checks at least one part is configured.
+func (m *multipartRequest) Validate() error {
+	if len(m.Fields) == 0 && len(m.Files) == 0 {
+		return fmt.Errorf("multipart requires `fields` or `files`")
+	}
+	return nil
+}
+
+// body builds the multipart body, reading the files. The fields are written
+// first, sorted by name, followed by the files in the configured order.
+func (m *multipartRequest) body() (string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	names := make([]string, 0, len(m.Fields))
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := w.WriteField(name, m.Fields[name]); err != nil {
+			return "", err
+		}
+	}
+
+	for _, file := range m.Files {
+		content, err := ioutil.ReadFile(file.Path)
+		if err != nil {
+			return "", fmt.Errorf("could not read multipart file: %v", err)
+		}
+
+		filename := file.Filename
+		if filename == "" {
+			filename = filepath.Base(file.Path)
+		}
+		contentType := file.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.Name), escapeQuotes(filename)))
+		h.Set("Content-Type", contentType)
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return "", err
+		}
+		if _, err := part.Write(content); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	m.contentType = w.FormDataContentType()
+	return buf.String(), nil
+}
+
+// setHeaders sets the content type with the boundary of the body, unless it
+// is configured explicitly.
+func (m *multipartRequest) setHeaders(h http.Header) {
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", m.contentType)
+	}
+}
+
+// escapeQuotes escapes a parameter value of the Content-Disposition header,
+// like the multipart package does for form fields.
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartRequestBody(t *testing.T) {
+	file, err := ioutil.TempFile("", "hb_multipart")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("report content")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	m := &multipartRequest{
+		Fields: map[string]string{"b": "2", "a": "1"},
+		Files: []multipartFile{
+			{Name: "report", Path: file.Name(), Filename: "report.csv", ContentType: "text/csv"},
+			{Name: "raw", Path: file.Name()},
+		},
+	}
+	body, err := m.body()
+	require.NoError(t, err)
+
+	h := http.Header{}
+	m.setHeaders(h)
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	type part struct{ name, filename, contentType, content string }
+	var parts []part
+	r := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		content, err := ioutil.ReadAll(p)
+		require.NoError(t, err)
+		parts = append(parts, part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(content)})
+	}
+
+	assert.Equal(t, []part{
+		{"a", "", "", "1"},
+		{"b", "", "", "2"},
+		{"report", "report.csv", "text/csv", "report content"},
+		{"raw", filepath.Base(file.Name()), "application/octet-stream", "report content"},
+	}, parts)
+
+	// Explicitly configured content types are kept
+	h = http.Header{"Content-Type": []string{"multipart/mixed"}}
+	m.setHeaders(h)
+	assert.Equal(t, "multipart/mixed", h.Get("Content-Type"))
+}
+
+func TestMultipartRequestMissingFile(t *testing.T) {
+	m := &multipartRequest{Files: []multipartFile{{Name: "report", Path: "/nonexistent/report.csv"}}}
+	_, err := m.body()
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// oauth2Config configures the OAuth2 client credentials flow used to fetch a
+// bearer token for check requests.
+type oauth2Config struct {
+	TokenURL       string              `config:"token_url"`
+	ClientID       string              `config:"client.id"`
+	ClientSecret   string              `config:"client.secret"`
+	Scopes         []string            `config:"scopes"`
+	EndpointParams map[string][]string `config:"endpoint_params"`
+}
+
+// Validate validates of the oauth2Config object is valid or not
+func (c *oauth2Config) Validate() error {
+	if c.TokenURL == "" {
+		return errors.New("oauth2 requires token_url to be set")
+	}
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return errors.New("oauth2 requires client.id and client.secret to be set")
+	}
+	return nil
+}
+
+// oauth2Authorizer sends a bearer token fetched with the client credentials
+// flow with every request.
+type oauth2Authorizer struct {
+	tokens oauth2.TokenSource
+}
+
+// newOAuth2Authorizer creates an authorizer fetching tokens from the token
+// endpoint using the monitor's TLS and proxy settings. The token source is
+// shared by all hosts of the monitor, tokens are cached and only refreshed
+// shortly before they expire.
+func newOAuth2Authorizer(config *Config, tls *tlscommon.TLSConfig) (*oauth2Authorizer, error) {
+	// The token endpoint is not served by the monitored unix socket
+	tokenConfig := *config
+	tokenConfig.SocketPath = ""
+
+	transport, err := newRoundTripper(&tokenConfig, tls)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Transport: transport, Timeout: config.Timeout}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+
+	c := config.OAuth2
+	creds := clientcredentials.Config{
+		ClientID:       c.ClientID,
+		ClientSecret:   c.ClientSecret,
+		TokenURL:       c.TokenURL,
+		Scopes:         c.Scopes,
+		EndpointParams: c.EndpointParams,
+	}
+	return &oauth2Authorizer{tokens: creds.TokenSource(ctx)}, nil
+}
+
+// authorize adds the bearer token to the request. The request headers are
+// copied, as the request is shared between check runs.
+func (a *oauth2Authorizer) authorize(req *http.Request, _ []byte) error {
+	token, err := a.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("could not fetch oauth2 token: %w", err)
+	}
+
+	req.Header = req.Header.Clone()
+	token.SetAuthHeader(req)
+	return nil
+}
@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// pacScript evaluates the FindProxyForURL function of a proxy auto-config
+// (PAC) file to select the proxy for each request.
+type pacScript struct {
+	// the runtime is not safe for concurrent use
+	mu        sync.Mutex
+	vm        *goja.Runtime
+	findProxy goja.Callable
+}
+
+// loadPACScript reads the PAC file from a local path or a http(s) URL.
+func loadPACScript(location string, timeout time.Duration) (*pacScript, error) {
+	var source []byte
+	if u, err := url.Parse(location); err == nil && (u.Scheme == urlSchemaHTTP || u.Scheme == urlSchemaHTTPS) {
+		client := &http.Client{Timeout: timeout}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch PAC file: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("could not fetch PAC file: %v", resp.Status)
+		}
+		if source, err = ioutil.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("could not fetch PAC file: %w", err)
+		}
+	} else {
+		if source, err = ioutil.ReadFile(location); err != nil {
+			return nil, fmt.Errorf("could not read PAC file: %w", err)
+		}
+	}
+
+	return newPACScript(string(source))
+}
+
+func newPACScript(source string) (*pacScript, error) {
+	vm := goja.New()
+	for name, fn := range pacFunctions {
+		vm.Set(name, fn)
+	}
+
+	if _, err := vm.RunString(source); err != nil {
+		return nil, fmt.Errorf("invalid PAC file: %w", err)
+	}
+
+	fn := vm.Get("FindProxyForURL")
+	if fn == nil || fn.ExportType().Kind() != reflect.Func {
+		return nil, errors.New("invalid PAC file: FindProxyForURL function not found")
+	}
+	p := &pacScript{vm: vm}
+	if err := vm.ExportTo(fn, &p.findProxy); err != nil {
+		return nil, fmt.Errorf("invalid PAC file: %w", err)
+	}
+	return p, nil
+}
+
+// proxy returns the proxy to use for the request, or nil to connect directly.
+// It can be used as http.Transport.Proxy.
+func (p *pacScript) proxy(req *http.Request) (*url.URL, error) {
+	result, err := p.findProxyForURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	return parsePACResult(result)
+}
+
+func (p *pacScript) findProxyForURL(u *url.URL) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result, err := p.findProxy(goja.Undefined(), p.vm.ToValue(u.String()), p.vm.ToValue(u.Hostname()))
+	if err != nil {
+		return "", fmt.Errorf("PAC file FindProxyForURL failed: %w", err)
+	}
+	return result.String(), nil
+}
+
+// parsePACResult converts the first entry of the result of FindProxyForURL to
+// a proxy URL. Fallback entries are ignored, as the check reports the state of
+// the selected path.
+func parsePACResult(result string) (*url.URL, error) {
+	entry := strings.TrimSpace(strings.Split(result, ";")[0])
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	var scheme string
+	switch strings.ToUpper(fields[0]) {
+	case "DIRECT":
+		return nil, nil
+	case "PROXY", "HTTP":
+		scheme = "http"
+	case "HTTPS":
+		scheme = "https"
+	case "SOCKS", "SOCKS5":
+		scheme = "socks5"
+	default:
+		return nil, fmt.Errorf("unsupported PAC result '%v'", entry)
+	}
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid PAC result '%v'", entry)
+	}
+	return &url.URL{Scheme: scheme, Host: fields[1]}, nil
+}
+
+// pacFunctions are the predefined functions available to PAC files. The date
+// and time based functions are not supported.
+var pacFunctions = map[string]interface{}{
+	"isPlainHostName": func(host string) bool {
+		return !strings.Contains(host, ".")
+	},
+	"dnsDomainIs": func(host, domain string) bool {
+		return strings.HasSuffix(strings.ToLower(host), strings.ToLower(domain))
+	},
+	"localHostOrDomainIs": func(host, hostdom string) bool {
+		host, hostdom = strings.ToLower(host), strings.ToLower(hostdom)
+		return host == hostdom || (!strings.Contains(host, ".") && strings.HasPrefix(hostdom, host+"."))
+	},
+	"isResolvable": func(host string) bool {
+		return resolveIPv4(host) != nil
+	},
+	"isInNet": func(host, pattern, mask string) bool {
+		ip := resolveIPv4(host)
+		patternIP := net.ParseIP(pattern).To4()
+		maskIP := net.ParseIP(mask).To4()
+		if ip == nil || patternIP == nil || maskIP == nil {
+			return false
+		}
+		m := net.IPMask(maskIP)
+		return ip.Mask(m).Equal(patternIP.Mask(m))
+	},
+	"dnsResolve": func(host string) interface{} {
+		if ip := resolveIPv4(host); ip != nil {
+			return ip.String()
+		}
+		return nil
+	},
+	"myIpAddress": myIPAddress,
+	"dnsDomainLevels": func(host string) int {
+		return strings.Count(host, ".")
+	},
+	"shExpMatch": shExpMatch,
+}
+
+func resolveIPv4(host string) net.IP {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.To4()
+	}
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil
+	}
+	for _, ip := range ips {
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4
+		}
+	}
+	return nil
+}
+
+func myIPAddress() string {
+	addrs, err := net.InterfaceAddrs()
+	if err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+				return ipNet.IP.String()
+			}
+		}
+	}
+	return "127.0.0.1"
+}
+
+// shExpMatch matches the string against a shell expression, where * matches
+// any number of characters and ? a single character.
+func shExpMatch(str, shexp string) bool {
+	pattern := regexp.QuoteMeta(shexp)
+	pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+	pattern = strings.ReplaceAll(pattern, `\?`, ".")
+	matched, err := regexp.MatchString("^"+pattern+"$", str)
+	return err == nil && matched
+}
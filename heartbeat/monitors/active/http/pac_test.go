@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPACScript = `
+function FindProxyForURL(url, host) {
+	if (isPlainHostName(host) || dnsDomainIs(host, ".internal.example.com")) {
+		return "DIRECT";
+	}
+	if (shExpMatch(url, "https://*.socks.example.com/*")) {
+		return "SOCKS5 bastion:1080";
+	}
+	if (isInNet(host, "10.0.0.0", "255.0.0.0")) {
+		return "HTTPS secure-proxy:3129; DIRECT";
+	}
+	return "PROXY proxy:3128; DIRECT";
+}
+`
+
+func TestPACScriptProxy(t *testing.T) {
+	pac, err := newPACScript(testPACScript)
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"http://localhost/health":              "",
+		"http://app.internal.example.com/":     "",
+		"https://app.socks.example.com/health": "socks5://bastion:1080",
+		"http://10.1.2.3:8080/":                "https://secure-proxy:3129",
+		"https://www.elastic.co/":              "http://proxy:3128",
+	}
+
+	for target, expected := range tests {
+		t.Run(target, func(t *testing.T) {
+			req, err := http.NewRequest("GET", target, nil)
+			require.NoError(t, err)
+
+			proxy, err := pac.proxy(req)
+			require.NoError(t, err)
+			if expected == "" {
+				assert.Nil(t, proxy)
+			} else {
+				require.NotNil(t, proxy)
+				assert.Equal(t, expected, proxy.String())
+			}
+		})
+	}
+}
+
+func TestPACScriptInvalid(t *testing.T) {
+	_, err := newPACScript("function FindProxyForURL(url, host) {")
+	assert.Error(t, err)
+
+	_, err = newPACScript("var FindProxy = 1;")
+	assert.Error(t, err)
+
+	pac, err := newPACScript(`function FindProxyForURL(url, host) { return "FTP ftp-proxy:21"; }`)
+	require.NoError(t, err)
+	_, err = pac.proxy(&http.Request{URL: &url.URL{Scheme: "http", Host: "localhost"}})
+	assert.Error(t, err)
+}
+
+func TestLoadPACScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "heartbeat-pac")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "proxy.pac")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testPACScript), 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path)
+	}))
+	defer server.Close()
+
+	for _, location := range []string{path, server.URL + "/proxy.pac"} {
+		pac, err := loadPACScript(location, time.Second)
+		require.NoError(t, err, location)
+		assert.NotNil(t, pac.findProxy)
+	}
+
+	_, err = loadPACScript(filepath.Join(dir, "missing.pac"), time.Second)
+	assert.Error(t, err)
+}
+
+func TestParsePACResult(t *testing.T) {
+	tests := map[string]string{
+		"":                             "",
+		"DIRECT":                       "",
+		"PROXY proxy:3128":             "http://proxy:3128",
+		"proxy proxy:3128; DIRECT":     "http://proxy:3128",
+		"HTTPS proxy:443":              "https://proxy:443",
+		"SOCKS bastion:1080":           "socks5://bastion:1080",
+		" SOCKS5 bastion:1080 ; PROXY": "socks5://bastion:1080",
+	}
+
+	for result, expected := range tests {
+		proxy, err := parsePACResult(result)
+		require.NoError(t, err, result)
+		if expected == "" {
+			assert.Nil(t, proxy, result)
+		} else {
+			assert.Equal(t, expected, proxy.String(), result)
+		}
+	}
+
+	for _, result := range []string{"PROXY", "PROXY a b", "FTP proxy:21"} {
+		_, err := parsePACResult(result)
+		assert.Error(t, err, result)
+	}
+}
+
+func TestShExpMatch(t *testing.T) {
+	assert.True(t, shExpMatch("http://home.netscape.com/people/ari/index.html", "*/ari/*"))
+	assert.False(t, shExpMatch("http://home.netscape.com/people/montulli/index.html", "*/ari/*"))
+	assert.True(t, shExpMatch("a.b", "?.b"))
+	assert.False(t, shExpMatch("axb", "a.b"))
+}
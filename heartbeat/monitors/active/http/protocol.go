@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/lucas-clemente/quic-go"
+	"github.com/lucas-clemente/quic-go/http3"
+	"golang.org/x/net/http2"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+const (
+	protocolHTTP11 = "http/1.1"
+	protocolH2     = "h2"
+	protocolH2C    = "h2c"
+	protocolH3     = "h3"
+)
+
+// isHTTP2 reports whether the monitor is configured to force HTTP/2.
+func isHTTP2(protocol string) bool {
+	return protocol == protocolH2 || protocol == protocolH2C
+}
+
+// isHTTP3 reports whether the monitor is configured to use HTTP/3 over QUIC.
+func isHTTP3(protocol string) bool {
+	return protocol == protocolH3
+}
+
+// protocolTLSConfig returns a copy of the TLS config advertising the forced
+// protocol via ALPN. Without a forced protocol, for h2c which does not use
+// TLS, or for h3 where the QUIC transport sets the ALPN protocols, the TLS
+// config is returned unchanged.
+func protocolTLSConfig(config *tlscommon.TLSConfig, protocol string) *tlscommon.TLSConfig {
+	if protocol == "" || protocol == protocolH2C || protocol == protocolH3 {
+		return config
+	}
+
+	var c tlscommon.TLSConfig
+	if config != nil {
+		c = *config
+	}
+	c.NextProtos = []string{protocol}
+	return &c
+}
+
+// clientTLSConfig returns the TLS config of the connections not established
+// by a TLS dialer, like connections through HTTP proxies or QUIC sessions. The
+// configured server name and verification hostname are applied, without a
+// server name the transport sends the host of the request.
+func clientTLSConfig(config *tlscommon.TLSConfig) *tls.Config {
+	if config != nil && (config.ServerName != "" || config.VerificationHostname != "") {
+		return config.BuildModuleConfig(config.ServerName)
+	}
+	return config.ToConfig()
+}
+
+// newHTTP2RoundTripper creates a transport speaking HTTP/2 only. Unlike
+// http.Transport it does not fall back to HTTP/1.1 if the server does not
+// negotiate h2, the request fails instead.
+func newHTTP2RoundTripper(config *Config, tls *tlscommon.TLSConfig) (*http2.Transport, error) {
+	dialer, err := netDialer(config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Check.Request.Protocol == protocolH2 {
+		tlsDialer, err := transport.TLSDialer(dialer, tls, config.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		dialer = tlsDialer
+	}
+
+	return newHTTP2Transport(dialer, config.Check.Request.Protocol), nil
+}
+
+// newHTTP2Transport creates a HTTP/2 transport establishing connections
+// with dialer. For h2 the dialer must establish the TLS connection.
+func newHTTP2Transport(dialer transport.Dialer, protocol string) *http2.Transport {
+	return &http2.Transport{
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dialer.Dial(network, addr)
+		},
+		AllowHTTP: protocol == protocolH2C,
+	}
+}
+
+// http3Transport sends requests via HTTP/3 over QUIC. Closing idle connections
+// closes all QUIC sessions, so a transport must not be shared between hosts.
+type http3Transport struct {
+	*http3.RoundTripper
+}
+
+// newHTTP3RoundTripper creates a transport speaking HTTP/3 only. The TLS
+// config, including the ALPN protocols, is managed by the QUIC transport.
+// Hosts are resolved like for the other protocols, with the resolver and the
+// address family of the monitor.
+func newHTTP3RoundTripper(config *Config, tls *tlscommon.TLSConfig) *http3Transport {
+	return &http3Transport{&http3.RoundTripper{
+		TLSClientConfig: clientTLSConfig(tls),
+		Dial:            quicDialer(config.resolver, config.Mode.Network()),
+	}}
+}
+
+// quicDialer creates the function establishing the QUIC sessions of the
+// HTTP/3 transport. The host is resolved to an address of the given network,
+// one of ip, ip4 or ip6, and keeps being used as TLS server name.
+func quicDialer(
+	resolver monitors.Resolver,
+	network string,
+) func(string, string, *tls.Config, *quic.Config) (quic.EarlySession, error) {
+	return func(_, addr string, tlsConfig *tls.Config, quicConfig *quic.Config) (quic.EarlySession, error) {
+		if network == "" {
+			return nil, errors.New("pinging hosts requires ipv4 or ipv6 mode enabled")
+		}
+
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := resolver.ResolveIPAddr(network, host)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = host
+		}
+		return quic.DialAddrEarly(net.JoinHostPort(ip.String(), port), tlsConfig, quicConfig)
+	}
+}
+
+func (t *http3Transport) CloseIdleConnections() {
+	t.Close()
+}
+
+// negotiatedProtocol returns the protocol used for the response. This is h3
+// for QUIC, the protocol negotiated via ALPN for TLS connections, or h2c and
+// http/1.1 for plain connections.
+func negotiatedProtocol(resp *http.Response) string {
+	// QUIC may negotiate a draft version, like h3-29, report these as h3
+	if resp.ProtoMajor == 3 {
+		return protocolH3
+	}
+
+	if resp.TLS != nil && resp.TLS.NegotiatedProtocol != "" {
+		return resp.TLS.NegotiatedProtocol
+	}
+
+	switch {
+	case resp.ProtoMajor == 2 && resp.TLS != nil:
+		return protocolH2
+	case resp.ProtoMajor == 2:
+		return protocolH2C
+	default:
+		return protocolHTTP11
+	}
+}
@@ -35,10 +35,13 @@ import (
 // 100MiB out to be enough for everybody.
 const maxBufferBodyBytes = 100 * units.MiB
 
-func processBody(resp *http.Response, config responseConfig, validator multiValidator) (common.MapStr, reason.Reason) {
+// processBody reads the response body, validates it and returns the body fields.
+// The fields extracted with json_fields are returned separately, as they are
+// added to the root of the event, as are the failures of warn validators.
+func processBody(resp *http.Response, config responseConfig, validator multiValidator) (bodyFields common.MapStr, jsonFields common.MapStr, warnings []string, errReason reason.Reason) {
 	// Determine how much of the body to actually buffer in memory
 	var bufferBodyBytes int
-	if validator.wantsBody() {
+	if validator.wantsBody() || config.jsonFields != nil {
 		bufferBodyBytes = maxBufferBodyBytes
 	} else if config.IncludeBody == "always" || config.IncludeBody == "on_error" {
 		// If the user has asked for bodies to be recorded we only need to buffer that much
@@ -51,16 +54,32 @@ func processBody(resp *http.Response, config responseConfig, validator multiVali
 	respBody, bodyLenBytes, bodyHash, respErr := readBody(resp, bufferBodyBytes)
 	// If we encounter an error while reading the body just fail early
 	if respErr != nil {
-		return nil, reason.IOFailed(respErr)
+		return nil, nil, nil, reason.IOFailed(respErr)
 	}
 
+	// Validate the text of the body, whatever its charset
+	respBody = decodeBody(resp, respBody, config.Charset)
+
 	// Run any validations
-	errReason := validator.validate(resp, respBody)
+	errReason = validator.validate(resp, respBody)
+	warnings = validator.warnings(resp, respBody)
+
+	// Extracting fields does not affect the monitor status, bodies which are
+	// not JSON are skipped
+	if config.jsonFields != nil {
+		var err error
+		if jsonFields, err = config.jsonFields.extract(respBody); err != nil {
+			debugf("%v", err)
+		}
+	}
 
-	bodyFields := common.MapStr{
+	bodyFields = common.MapStr{
 		"hash":  bodyHash,
 		"bytes": bodyLenBytes,
 	}
+	if validator.drift != nil {
+		bodyFields["normalized_hash"] = validator.drift.hash(respBody)
+	}
 	if config.IncludeBody == "always" ||
 		(config.IncludeBody == "on_error" && errReason != nil) {
 
@@ -77,7 +96,7 @@ func processBody(resp *http.Response, config responseConfig, validator multiVali
 		bodyFields["content"] = respBody[0:sampleNumBytes]
 	}
 
-	return bodyFields, errReason
+	return bodyFields, jsonFields, warnings, errReason
 }
 
 // readBody reads the first sampleSize bytes from the httpResponse,
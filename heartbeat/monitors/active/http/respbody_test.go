@@ -117,7 +117,7 @@ func Test_handleRespBody(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fields, err := processBody(tt.args.resp, tt.args.responseConfig, tt.args.validator)
+			fields, _, _, err := processBody(tt.args.resp, tt.args.responseConfig, tt.args.validator)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handleRespBody() error = %v, wantErr %v", err, tt.wantErr)
 			}
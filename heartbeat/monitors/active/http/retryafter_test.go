@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsThrottled(t *testing.T) {
+	assert.True(t, isThrottled(&http.Response{StatusCode: 429, Header: http.Header{}}))
+	assert.True(t, isThrottled(&http.Response{StatusCode: 503, Header: http.Header{"Retry-After": {"120"}}}))
+	assert.False(t, isThrottled(&http.Response{StatusCode: 503, Header: http.Header{}}))
+	assert.False(t, isThrottled(&http.Response{StatusCode: 500, Header: http.Header{"Retry-After": {"120"}}}))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now.Add(2*time.Minute), parseRetryAfter("120", now))
+	assert.Equal(t, now.Add(maxRetryAfter), parseRetryAfter("86400", now))
+	assert.Equal(t, now.Add(30*time.Second), parseRetryAfter("Thu, 01 Oct 2020 12:00:30 GMT", now).In(time.UTC))
+	assert.Equal(t, now.Add(maxRetryAfter), parseRetryAfter("Fri, 02 Oct 2020 12:00:00 GMT", now).In(time.UTC))
+	assert.True(t, parseRetryAfter("", now).IsZero())
+	assert.True(t, parseRetryAfter("-1", now).IsZero())
+	assert.True(t, parseRetryAfter("soon", now).IsZero())
+}
@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// securityHeadersCheck validates the security headers of the response with
+// recommended defaults, so a single switch replaces a list of header
+// assertions. Each header can be disabled or its expectation changed.
+type securityHeadersCheck struct {
+	Enabled            bool              `config:"enabled"`
+	HSTS               hstsCheck         `config:"hsts"`
+	ContentTypeOptions headerPresetCheck `config:"content_type_options"`
+	FrameOptions       headerPresetCheck `config:"frame_options"`
+	ReferrerPolicy     headerPresetCheck `config:"referrer_policy"`
+}
+
+// hstsCheck validates the Strict-Transport-Security header of HTTPS responses.
+type hstsCheck struct {
+	Enabled           bool          `config:"enabled"`
+	MinMaxAge         time.Duration `config:"min_max_age" validate:"min=0"`
+	IncludeSubdomains bool          `config:"include_subdomains"`
+	Preload           bool          `config:"preload"`
+}
+
+// headerPresetCheck validates a header has one of the allowed values,
+// compared case-insensitively. The recommended values are allowed if none are
+// configured.
+type headerPresetCheck struct {
+	Enabled bool     `config:"enabled"`
+	Allowed []string `config:"allowed"`
+}
+
+var defaultSecurityHeaders = securityHeadersCheck{
+	HSTS: hstsCheck{
+		Enabled:   true,
+		MinMaxAge: 180 * 24 * time.Hour,
+	},
+	ContentTypeOptions: headerPresetCheck{Enabled: true},
+	FrameOptions:       headerPresetCheck{Enabled: true},
+	ReferrerPolicy:     headerPresetCheck{Enabled: true},
+}
+
+var (
+	defaultContentTypeOptions = []string{"nosniff"}
+	defaultFrameOptions       = []string{"DENY", "SAMEORIGIN"}
+	defaultReferrerPolicies   = []string{"no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin"}
+)
+
+// checkSecurityHeaders validates the enabled security headers, reporting all
+// missing or weak headers at once.
+func checkSecurityHeaders(c securityHeadersCheck) respValidator {
+	c.ContentTypeOptions.setDefault(defaultContentTypeOptions)
+	c.FrameOptions.setDefault(defaultFrameOptions)
+	c.ReferrerPolicy.setDefault(defaultReferrerPolicies)
+
+	return func(r *http.Response) error {
+		var problems []string
+		report := func(err error) {
+			if err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+
+		// Browsers ignore HSTS on plain HTTP responses
+		if c.HSTS.Enabled && r.TLS != nil {
+			report(checkHSTS(c.HSTS, r.Header))
+		}
+		if c.ContentTypeOptions.Enabled {
+			report(checkHeaderPreset("X-Content-Type-Options", c.ContentTypeOptions.Allowed, r.Header.Get("X-Content-Type-Options")))
+		}
+		if c.FrameOptions.Enabled && !hasFrameAncestors(r.Header) {
+			report(checkHeaderPreset("X-Frame-Options", c.FrameOptions.Allowed, r.Header.Get("X-Frame-Options")))
+		}
+		if c.ReferrerPolicy.Enabled {
+			// Browsers apply the last policy they support, the header can
+			// list fallbacks before it
+			tokens := headerTokens(r.Header, "Referrer-Policy")
+			var policy string
+			if len(tokens) > 0 {
+				policy = tokens[len(tokens)-1]
+			}
+			report(checkHeaderPreset("Referrer-Policy", c.ReferrerPolicy.Allowed, policy))
+		}
+
+		if len(problems) > 0 {
+			return fmt.Errorf("security headers check failed: %v", strings.Join(problems, "; "))
+		}
+		return nil
+	}
+}
+
+func checkHSTS(c hstsCheck, h http.Header) error {
+	value := h.Get("Strict-Transport-Security")
+	if value == "" {
+		return fmt.Errorf("Strict-Transport-Security is missing")
+	}
+
+	maxAge := int64(-1)
+	var includeSubdomains, preload bool
+	for _, directive := range strings.Split(value, ";") {
+		directive = strings.TrimSpace(directive)
+		name, arg := directive, ""
+		if i := strings.IndexByte(directive, '='); i >= 0 {
+			name, arg = strings.TrimSpace(directive[:i]), strings.Trim(strings.TrimSpace(directive[i+1:]), `"`)
+		}
+		switch strings.ToLower(name) {
+		case "max-age":
+			if v, err := strconv.ParseInt(arg, 10, 64); err == nil {
+				maxAge = v
+			}
+		case "includesubdomains":
+			includeSubdomains = true
+		case "preload":
+			preload = true
+		}
+	}
+
+	switch {
+	case maxAge < 0:
+		return fmt.Errorf("Strict-Transport-Security '%v' has no valid max-age", value)
+	case time.Duration(maxAge)*time.Second < c.MinMaxAge:
+		return fmt.Errorf("Strict-Transport-Security max-age %v is less than %v", time.Duration(maxAge)*time.Second, c.MinMaxAge)
+	case c.IncludeSubdomains && !includeSubdomains:
+		return fmt.Errorf("Strict-Transport-Security '%v' is missing includeSubDomains", value)
+	case c.Preload && !preload:
+		return fmt.Errorf("Strict-Transport-Security '%v' is missing preload", value)
+	}
+	return nil
+}
+
+// hasFrameAncestors returns true if a Content-Security-Policy restricts the
+// framing of the page, which supersedes X-Frame-Options.
+func hasFrameAncestors(h http.Header) bool {
+	for _, policy := range h.Values("Content-Security-Policy") {
+		for _, directive := range strings.Split(policy, ";") {
+			fields := strings.Fields(directive)
+			if len(fields) > 1 && strings.EqualFold(fields[0], "frame-ancestors") && !containsToken(fields[1:], "*", false) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *headerPresetCheck) setDefault(allowed []string) {
+	if len(c.Allowed) == 0 {
+		c.Allowed = allowed
+	}
+}
+
+func checkHeaderPreset(name string, allowed []string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%v is missing", name)
+	}
+	if !containsToken(allowed, strings.TrimSpace(value), true) {
+		return fmt.Errorf("%v is '%v' expecting one of '%v'", name, value, strings.Join(allowed, "', '"))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestCheckSecurityHeaders(t *testing.T) {
+	secure := map[string]string{
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+	}
+	with := func(headers map[string]string, overrides map[string]string) http.Header {
+		h := http.Header{}
+		for k, v := range headers {
+			h.Set(k, v)
+		}
+		for k, v := range overrides {
+			if v == "" {
+				h.Del(k)
+			} else {
+				h.Set(k, v)
+			}
+		}
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		check   func(*securityHeadersCheck)
+		header  http.Header
+		plain   bool
+		wantErr string
+	}{
+		{
+			name:   "recommended headers",
+			header: with(secure, nil),
+		},
+		{
+			name:    "no headers",
+			header:  http.Header{},
+			wantErr: "Strict-Transport-Security is missing; X-Content-Type-Options is missing; X-Frame-Options is missing; Referrer-Policy is missing",
+		},
+		{
+			name:   "no HSTS over plain HTTP",
+			header: with(secure, map[string]string{"Strict-Transport-Security": ""}),
+			plain:  true,
+		},
+		{
+			name:    "short HSTS max-age",
+			header:  with(secure, map[string]string{"Strict-Transport-Security": "max-age=3600"}),
+			wantErr: "max-age 1h0m0s is less than 4320h0m0s",
+		},
+		{
+			name:    "invalid HSTS max-age",
+			header:  with(secure, map[string]string{"Strict-Transport-Security": "max-age=forever"}),
+			wantErr: "has no valid max-age",
+		},
+		{
+			name:    "HSTS without preload",
+			check:   func(c *securityHeadersCheck) { c.HSTS.Preload = true },
+			header:  with(secure, nil),
+			wantErr: "is missing preload",
+		},
+		{
+			name:    "sniffing allowed",
+			header:  with(secure, map[string]string{"X-Content-Type-Options": "sniff"}),
+			wantErr: "X-Content-Type-Options is 'sniff' expecting one of 'nosniff'",
+		},
+		{
+			name:   "CSP frame-ancestors instead of X-Frame-Options",
+			header: with(secure, map[string]string{"X-Frame-Options": "", "Content-Security-Policy": "default-src 'self'; frame-ancestors 'self'"}),
+		},
+		{
+			name:    "CSP frame-ancestors wildcard",
+			header:  with(secure, map[string]string{"X-Frame-Options": "", "Content-Security-Policy": "frame-ancestors *"}),
+			wantErr: "X-Frame-Options is missing",
+		},
+		{
+			name:   "referrer policy fallbacks",
+			header: with(secure, map[string]string{"Referrer-Policy": "unsafe-url, no-referrer"}),
+		},
+		{
+			name:    "unsafe referrer policy",
+			header:  with(secure, map[string]string{"Referrer-Policy": "no-referrer, unsafe-url"}),
+			wantErr: "Referrer-Policy is 'unsafe-url'",
+		},
+		{
+			name: "overridden and disabled headers",
+			check: func(c *securityHeadersCheck) {
+				c.FrameOptions.Allowed = []string{"SAMEORIGIN"}
+				c.ReferrerPolicy.Enabled = false
+			},
+			header: with(secure, map[string]string{"X-Frame-Options": "sameorigin", "Referrer-Policy": ""}),
+		},
+		{
+			name:    "overridden value",
+			check:   func(c *securityHeadersCheck) { c.FrameOptions.Allowed = []string{"SAMEORIGIN"} },
+			header:  with(secure, nil),
+			wantErr: "X-Frame-Options is 'DENY' expecting one of 'SAMEORIGIN'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := defaultSecurityHeaders
+			c.Enabled = true
+			if test.check != nil {
+				test.check(&c)
+			}
+			resp := &http.Response{StatusCode: 200, Header: test.header}
+			if !test.plain {
+				resp.TLS = &tls.ConnectionState{}
+			}
+
+			err := checkSecurityHeaders(c)(resp)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersConfig(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"security_headers.enabled":                 true,
+		"security_headers.hsts.min_max_age":        "24h",
+		"security_headers.referrer_policy.enabled": false,
+	})
+	params := defaultConfig.Check.Response
+	require.NoError(t, cfg.Unpack(&params))
+
+	c := params.SecurityHeaders
+	assert.True(t, c.Enabled)
+	assert.True(t, c.HSTS.Enabled)
+	assert.Equal(t, 24*time.Hour, c.HSTS.MinMaxAge)
+	assert.True(t, c.ContentTypeOptions.Enabled)
+	assert.True(t, c.FrameOptions.Enabled)
+	assert.False(t, c.ReferrerPolicy.Enabled)
+	assert.False(t, defaultConfig.Check.Response.SecurityHeaders.Enabled)
+}
@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// parseServerTiming returns the metrics of Server-Timing headers, keyed by
+// metric name, with the duration in milliseconds in `dur` and the description
+// in `desc`. Metrics with neither are skipped, as are repeated metrics. Dots in
+// metric names are replaced by underscores to keep the fields flat.
+func parseServerTiming(values []string) common.MapStr {
+	metrics := common.MapStr{}
+	for _, value := range values {
+		for _, entry := range splitQuoted(value, ',') {
+			params := splitQuoted(entry, ';')
+			name := strings.Replace(strings.TrimSpace(params[0]), ".", "_", -1)
+			if name == "" {
+				continue
+			}
+			if _, exists := metrics[name]; exists {
+				continue
+			}
+
+			metric := common.MapStr{}
+			for _, param := range params[1:] {
+				kv := strings.SplitN(param, "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				key := strings.ToLower(strings.TrimSpace(kv[0]))
+				val := unquote(strings.TrimSpace(kv[1]))
+				switch key {
+				case "dur":
+					if _, found := metric["dur"]; found {
+						continue
+					}
+					if dur, err := strconv.ParseFloat(val, 64); err == nil {
+						metric["dur"] = dur
+					}
+				case "desc":
+					if _, found := metric["desc"]; !found {
+						metric["desc"] = val
+					}
+				}
+			}
+			if len(metric) > 0 {
+				metrics[name] = metric
+			}
+		}
+	}
+	return metrics
+}
+
+// splitQuoted splits s at sep, ignoring separators in quoted strings.
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	quoted, escaped := false, false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote returns the content of a quoted string, or s if it is not quoted.
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	var sb strings.Builder
+	escaped := false
+	for _, c := range s[1 : len(s)-1] {
+		if !escaped && c == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestParseServerTiming(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected common.MapStr
+	}{
+		{"none", nil, common.MapStr{}},
+		{
+			"single",
+			[]string{"db;dur=53.2"},
+			common.MapStr{"db": common.MapStr{"dur": 53.2}},
+		},
+		{
+			"multiple with descriptions",
+			[]string{`cache;desc="Cache Read";dur=23.2, app;dur=47.2,db ; dur = 12`},
+			common.MapStr{
+				"cache": common.MapStr{"dur": 23.2, "desc": "Cache Read"},
+				"app":   common.MapStr{"dur": 47.2},
+				"db":    common.MapStr{"dur": float64(12)},
+			},
+		},
+		{
+			"multiple headers",
+			[]string{"db;dur=1", "app;dur=2"},
+			common.MapStr{"db": common.MapStr{"dur": float64(1)}, "app": common.MapStr{"dur": float64(2)}},
+		},
+		{
+			"quoted separators",
+			[]string{`edge;desc="a, \"b\"; c";dur=5`},
+			common.MapStr{"edge": common.MapStr{"dur": float64(5), "desc": `a, "b"; c`}},
+		},
+		{
+			"case insensitive params, first value wins",
+			[]string{"db;DUR=1;dur=2;Desc=primary", "db;dur=3"},
+			common.MapStr{"db": common.MapStr{"dur": float64(1), "desc": "primary"}},
+		},
+		{
+			"dotted names",
+			[]string{"db.query;dur=4"},
+			common.MapStr{"db_query": common.MapStr{"dur": float64(4)}},
+		},
+		{
+			"invalid and empty metrics",
+			[]string{"miss, db;dur=abc, ;dur=1, total;dur=9"},
+			common.MapStr{"total": common.MapStr{"dur": float64(9)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseServerTiming(test.values))
+		})
+	}
+}
@@ -20,6 +20,7 @@ package http
 import (
 	"bufio"
 	"compress/gzip"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -188,6 +189,10 @@ func (t *SimpleTransport) readResponse(
 		return nil, err
 	}
 	resp.Body = comboConnReadCloser{conn, resp.Body}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		state := tlsConn.ConnectionState()
+		resp.TLS = &state
+	}
 
 	t.sigStartRead()
 
@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+)
+
+const (
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+var (
+	soapFaultPath = mustCompileXPath("/Envelope/Body/Fault")
+
+	// fault details of SOAP 1.1 and 1.2 respectively
+	soapFaultCode   = []*xpathExpr{mustCompileXPath("faultcode"), mustCompileXPath("Code/Value")}
+	soapFaultReason = []*xpathExpr{mustCompileXPath("faultstring"), mustCompileXPath("Reason/Text")}
+)
+
+// soapRequest is a SOAP operation whose header and body are sent wrapped in a
+// SOAP envelope.
+type soapRequest struct {
+	Version string `config:"version"`
+	Action  string `config:"action"`
+	Header  string `config:"header"`
+	Body    string `config:"body" validate:"required"`
+}
+
+// Validate checks the version and that the envelope is well-formed.
+func (s *soapRequest) Validate() error {
+	switch s.Version {
+	case "":
+		s.Version = "1.1"
+	case "1.1", "1.2":
+	default:
+		return fmt.Errorf("unknown SOAP version '%s', please use one of '1.1', '1.2'", s.Version)
+	}
+
+	if _, err := parseXML(strings.NewReader(s.envelope())); err != nil {
+		return fmt.Errorf("invalid SOAP header or body: %v", err)
+	}
+	return nil
+}
+
+// envelope returns the SOAP envelope of the operation.
+func (s *soapRequest) envelope() string {
+	ns := soap11Namespace
+	if s.Version == "1.2" {
+		ns = soap12Namespace
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	fmt.Fprintf(&sb, `<soap:Envelope xmlns:soap="%s">`, ns)
+	if s.Header != "" {
+		fmt.Fprintf(&sb, "<soap:Header>%s</soap:Header>", s.Header)
+	}
+	fmt.Fprintf(&sb, "<soap:Body>%s</soap:Body></soap:Envelope>", s.Body)
+	return sb.String()
+}
+
+// setHeaders sets the content type and action headers for the SOAP version,
+// unless they are configured explicitly.
+func (s *soapRequest) setHeaders(h http.Header) {
+	if s.Version == "1.2" {
+		if h.Get("Content-Type") == "" {
+			contentType := "application/soap+xml; charset=utf-8"
+			if s.Action != "" {
+				contentType += fmt.Sprintf(`; action="%s"`, s.Action)
+			}
+			h.Set("Content-Type", contentType)
+		}
+		return
+	}
+
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", "text/xml; charset=utf-8")
+	}
+	if h.Get("SOAPAction") == "" {
+		h.Set("SOAPAction", fmt.Sprintf(`"%s"`, s.Action))
+	}
+}
+
+// checkSOAPFault fails if the response is a SOAP fault. Servers respond to
+// faults with a 500 status, this reports the fault instead of the status.
+func checkSOAPFault(_ *http.Response, body string) error {
+	doc, err := parseXML(strings.NewReader(body))
+	if err != nil {
+		// non-XML responses are reported by the other checks
+		return nil
+	}
+
+	faults := soapFaultPath.evaluate(doc)
+	if len(faults) == 0 {
+		return nil
+	}
+
+	code := firstValue(faults[0], soapFaultCode)
+	reason := firstValue(faults[0], soapFaultReason)
+	return fmt.Errorf("SOAP fault '%s': %s", code, reason)
+}
+
+// firstValue returns the trimmed value of the first node selected by any of
+// the expressions.
+func firstValue(n *xmlquery.Node, exprs []*xpathExpr) string {
+	for _, x := range exprs {
+		if nodes := x.evaluate(n); len(nodes) > 0 {
+			return strings.TrimSpace(nodes[0].InnerText())
+		}
+	}
+	return ""
+}
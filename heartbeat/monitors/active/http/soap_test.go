@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSOAPRequestEnvelope(t *testing.T) {
+	s := &soapRequest{Body: `<m:GetStatus xmlns:m="urn:status"/>`}
+	assert.NoError(t, s.Validate())
+	assert.Equal(t, "1.1", s.Version)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">`+
+		`<soap:Body><m:GetStatus xmlns:m="urn:status"/></soap:Body></soap:Envelope>`, s.envelope())
+
+	s = &soapRequest{Version: "1.2", Header: `<Token>abc</Token>`, Body: `<GetStatus/>`}
+	assert.NoError(t, s.Validate())
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">`+
+		`<soap:Header><Token>abc</Token></soap:Header>`+
+		`<soap:Body><GetStatus/></soap:Body></soap:Envelope>`, s.envelope())
+
+	assert.Error(t, (&soapRequest{Version: "2.0", Body: `<GetStatus/>`}).Validate())
+	assert.Error(t, (&soapRequest{Body: `<GetStatus>`}).Validate())
+}
+
+func TestSOAPRequestHeaders(t *testing.T) {
+	h := http.Header{}
+	(&soapRequest{Version: "1.1", Action: "urn:status#GetStatus"}).setHeaders(h)
+	assert.Equal(t, "text/xml; charset=utf-8", h.Get("Content-Type"))
+	assert.Equal(t, `"urn:status#GetStatus"`, h.Get("SOAPAction"))
+
+	h = http.Header{}
+	(&soapRequest{Version: "1.2", Action: "urn:status#GetStatus"}).setHeaders(h)
+	assert.Equal(t, `application/soap+xml; charset=utf-8; action="urn:status#GetStatus"`, h.Get("Content-Type"))
+	assert.Equal(t, "", h.Get("SOAPAction"))
+
+	h = http.Header{"Content-Type": []string{"text/xml"}, "Soapaction": []string{"custom"}}
+	(&soapRequest{Version: "1.1", Action: "urn:status#GetStatus"}).setHeaders(h)
+	assert.Equal(t, "text/xml", h.Get("Content-Type"))
+	assert.Equal(t, "custom", h.Get("SOAPAction"))
+}
+
+func TestCheckSOAPFault(t *testing.T) {
+	assert.NoError(t, checkSOAPFault(nil, xpathTestDoc))
+	assert.NoError(t, checkSOAPFault(nil, "Internal Server Error"))
+
+	err := checkSOAPFault(nil, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+		<soap:Fault><faultcode>soap:Server</faultcode><faultstring> database unavailable </faultstring></soap:Fault>
+	</soap:Body></soap:Envelope>`)
+	if assert.Error(t, err) {
+		assert.Equal(t, "SOAP fault 'soap:Server': database unavailable", err.Error())
+	}
+
+	err = checkSOAPFault(nil, `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body>
+		<env:Fault>
+			<env:Code><env:Value>env:Receiver</env:Value></env:Code>
+			<env:Reason><env:Text xml:lang="en">database unavailable</env:Text></env:Reason>
+		</env:Fault>
+	</env:Body></env:Envelope>`)
+	if assert.Error(t, err) {
+		assert.Equal(t, "SOAP fault 'env:Receiver': database unavailable", err.Error())
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strconv"
 	"strings"
@@ -50,9 +51,10 @@ var userAgent = useragent.UserAgent("Heartbeat")
 func newHTTPMonitorHostJob(
 	addr string,
 	config *Config,
-	transport *http.Transport,
+	transport http.RoundTripper,
+	auth authorizer,
 	enc contentEncoder,
-	body []byte,
+	body *requestBody,
 	validator multiValidator,
 ) (jobs.Job, error) {
 
@@ -62,6 +64,7 @@ func newHTTPMonitorHostJob(
 	}
 
 	timeout := config.Timeout
+	cond := newConditionalRequests(config)
 
 	return jobs.MakeSimpleJob(func(event *beat.Event) error {
 		var redirects []string
@@ -71,7 +74,9 @@ func newHTTPMonitorHostJob(
 			Transport:     transport,
 			Timeout:       config.Timeout,
 		}
-		_, _, err := execPing(event, client, request, body, timeout, validator, config.Response)
+		_, _, err := execPing(event, client, request, auth, body, timeout, validator, cond, config.Check.Response.Certificate, config.Response)
+		// HTTP/2 and HTTP/3 connections are kept open by the transport, close them after each check
+		client.CloseIdleConnections()
 		if len(redirects) > 0 {
 			event.PutValue("http.response.redirects", redirects)
 		}
@@ -83,8 +88,9 @@ func newHTTPMonitorIPsJob(
 	config *Config,
 	addr string,
 	tls *tlscommon.TLSConfig,
+	auth authorizer,
 	enc contentEncoder,
-	body []byte,
+	body *requestBody,
 	validator multiValidator,
 ) (jobs.Job, error) {
 
@@ -98,8 +104,9 @@ func newHTTPMonitorIPsJob(
 		return nil, err
 	}
 
-	pingFactory := createPingFactory(config, port, tls, req, body, validator)
-	job, err := monitors.MakeByHostJob(hostname, config.Mode, monitors.NewStdResolver(), pingFactory)
+	cond := newConditionalRequests(config)
+	pingFactory := createPingFactory(config, port, tls, auth, req, body, validator, cond)
+	job, err := monitors.MakeByHostJob(hostname, config.Mode, config.resolver, pingFactory)
 
 	return job, err
 }
@@ -108,9 +115,11 @@ func createPingFactory(
 	config *Config,
 	port uint16,
 	tls *tlscommon.TLSConfig,
+	auth authorizer,
 	request *http.Request,
-	body []byte,
+	body *requestBody,
 	validator multiValidator,
+	cond *conditionalRequests,
 ) func(*net.IPAddr) jobs.Job {
 	timeout := config.Timeout
 	isTLS := request.URL.Scheme == "https"
@@ -123,6 +132,10 @@ func createPingFactory(
 
 		// TODO: add socks5 proxy?
 
+		if config.ProxyProtocol != nil {
+			d.AddLayer(dialchain.ProxyProtocolLayer(config.ProxyProtocol))
+		}
+
 		if isTLS {
 			d.AddLayer(dialchain.TLSLayer(tls, timeout))
 		}
@@ -146,7 +159,13 @@ func createPingFactory(
 		client := &http.Client{
 			CheckRedirect: checkRedirect,
 			Timeout:       timeout,
-			Transport: &SimpleTransport{
+		}
+		if isHTTP2(config.Check.Request.Protocol) {
+			// The HTTP/2 transport does not report the write and read
+			// timings, only the total RTT is recorded.
+			client.Transport = newHTTP2Transport(dialer, config.Check.Request.Protocol)
+		} else {
+			client.Transport = &SimpleTransport{
 				Dialer: dialer,
 				OnStartWrite: func() {
 					cbMutex.Lock()
@@ -163,10 +182,12 @@ func createPingFactory(
 					readStart = time.Now()
 					cbMutex.Unlock()
 				},
-			},
+			}
 		}
 
-		_, end, err := execPing(event, client, request, body, timeout, validator, config.Response)
+		req := request.WithContext(withDialedIP(request.Context(), ip.IP))
+		_, end, err := execPing(event, client, req, auth, body, timeout, validator, cond, config.Check.Response.Certificate, config.Response)
+		client.CloseIdleConnections()
 		cbMutex.Lock()
 		defer cbMutex.Unlock()
 
@@ -190,7 +211,7 @@ func createPingFactory(
 }
 
 func buildRequest(addr string, config *Config, enc contentEncoder) (*http.Request, error) {
-	method := strings.ToUpper(config.Check.Request.Method)
+	method := config.Check.Request.method()
 	request, err := http.NewRequest(method, addr, nil)
 	if err != nil {
 		return nil, err
@@ -212,6 +233,18 @@ func buildRequest(addr string, config *Config, enc contentEncoder) (*http.Reques
 		request.Header.Set("User-Agent", userAgent)
 	}
 
+	if config.Check.Request.GraphQL != nil && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if config.Check.Request.SOAP != nil {
+		config.Check.Request.SOAP.setHeaders(request.Header)
+	}
+	if config.Check.Request.Multipart != nil {
+		config.Check.Request.Multipart.setHeaders(request.Header)
+	}
+	if config.Check.Request.CORS != nil {
+		config.Check.Request.CORS.setHeaders(request.Header)
+	}
 	if enc != nil {
 		enc.AddHeaders(&request.Header)
 	}
@@ -223,16 +256,44 @@ func execPing(
 	event *beat.Event,
 	client *http.Client,
 	req *http.Request,
-	reqBody []byte,
+	auth authorizer,
+	body *requestBody,
 	timeout time.Duration,
 	validator multiValidator,
+	cond *conditionalRequests,
+	certificate certificateCheck,
 	responseConfig responseConfig,
 ) (start, end time.Time, err reason.Reason) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	reqBody, buildErr := body.build(req.URL.String())
+	if buildErr != nil {
+		return time.Now(), time.Now(), reason.IOFailed(buildErr)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
 	defer cancel()
 
 	req = attachRequestBody(&ctx, req, reqBody)
 
+	// Record whether the connection was reused, if the transport reports it
+	var gotConn, connReused bool
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			gotConn, connReused = true, info.Reused
+		},
+	}))
+
+	// Send the validators of the previous response, if any
+	var etag, lastModified string
+	if cond.previous() {
+		etag, lastModified = cond.prepare(req)
+	}
+
+	if auth != nil {
+		if err := auth.authorize(req, reqBody); err != nil {
+			return time.Now(), time.Now(), reason.IOFailed(err)
+		}
+	}
+
 	// Send the HTTP request. We don't immediately return on error since
 	// we may want to add additional fields to contextualize the error.
 	start, resp, errReason := execRequest(client, req)
@@ -243,34 +304,77 @@ func execPing(
 		if urlErr, ok := errReason.Unwrap().(*url.Error); ok {
 			if certErr, ok := urlErr.Err.(x509.CertificateInvalidError); ok {
 				tlsmeta.AddCertMetadata(event.Fields, []*x509.Certificate{certErr.Cert})
+				if certificate.includesPEM(true) {
+					tlsmeta.AddCertPEM(event.Fields, []*x509.Certificate{certErr.Cert})
+				}
 			}
 		}
 
 		return start, time.Now(), errReason
 	}
 
-	bodyFields, errReason := processBody(resp, responseConfig, validator)
+	bodyFields, jsonFields, warnings, errReason := processBody(resp, responseConfig, validator)
+
+	if cond.previous() {
+		if err := cond.check(resp, etag, lastModified); err != nil && errReason == nil {
+			errReason = reason.ValidateFailed(err)
+		}
+	}
+
+	if certificate.OCSP.Enabled && resp.TLS != nil {
+		ocspFields, err := certificate.OCSP.Check(*resp.TLS)
+		eventext.MergeEventFields(event, common.MapStr{
+			"tls": common.MapStr{"server": common.MapStr{"ocsp": ocspFields}},
+		})
+		if err != nil && errReason == nil {
+			errReason = reason.ValidateFailed(err)
+		}
+	}
+
+	if resp.TLS != nil && certificate.includesPEM(errReason != nil) {
+		tlsmeta.AddCertPEM(event.Fields, resp.TLS.PeerCertificates)
+	}
+
+	// Failures of throttled requests are reported separately, as the service
+	// asked us to back off
+	if errReason != nil && isThrottled(resp) {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		errReason = reason.Throttled(errReason, retryAfter)
+		if responseConfig.HonorRetryAfter && !retryAfter.IsZero() {
+			eventext.SetRetryAfter(event, retryAfter)
+		}
+	}
 
 	responseFields := common.MapStr{
 		"status_code": resp.StatusCode,
 		"body":        bodyFields,
 	}
 
-	if responseConfig.IncludeHeaders {
-		headerFields := common.MapStr{}
-		for canonicalHeaderKey, vals := range resp.Header {
-			if len(vals) > 1 {
-				headerFields[canonicalHeaderKey] = vals
-			} else {
-				headerFields[canonicalHeaderKey] = vals[0]
-			}
-		}
+	if headerFields := responseConfig.IncludeHeaders.fields(resp.Header); headerFields != nil {
 		responseFields["headers"] = headerFields
 	}
 
-	httpFields := common.MapStr{"response": responseFields}
+	if serverTiming := parseServerTiming(resp.Header.Values("Server-Timing")); len(serverTiming) > 0 {
+		responseFields["server_timing"] = serverTiming
+	}
+
+	httpFields := common.MapStr{
+		"version":  fmt.Sprintf("%d.%d", resp.ProtoMajor, resp.ProtoMinor),
+		"response": responseFields,
+	}
+	if gotConn {
+		httpFields["connection"] = common.MapStr{"reused": connReused}
+	}
+	if cond.previous() {
+		httpFields["request"] = common.MapStr{
+			"conditional": etag != "" || lastModified != "",
+		}
+	}
 
 	eventext.MergeEventFields(event, common.MapStr{"http": httpFields})
+	if len(jsonFields) > 0 {
+		eventext.MergeEventFields(event, jsonFields)
+	}
 
 	// Mark the end time as now, since we've finished downloading
 	end = time.Now()
@@ -282,6 +386,34 @@ func execPing(
 		},
 	}})
 
+	// The replayed conditional request is not part of the RTT of the check
+	if cond.replays() && errReason == nil {
+		replayFields, replayReason := cond.replayRequest(client, req, reqBody, auth, resp)
+		if replayFields != nil {
+			eventext.MergeEventFields(event, common.MapStr{"http": common.MapStr{"conditional": replayFields}})
+		}
+		errReason = replayReason
+	}
+
+	if err := validator.checkRTT(end.Sub(start)); err != nil {
+		if validator.warnRTT {
+			warnings = append(warnings, err.Error())
+		} else if errReason == nil {
+			errReason = reason.ValidateFailed(err)
+		}
+	}
+
+	// Checks passing all validators but warn validators are reported as
+	// degraded, the monitor is still up
+	if len(warnings) > 0 {
+		eventext.MergeEventFields(event, common.MapStr{"http": common.MapStr{"warnings": warnings}})
+		if errReason == nil {
+			eventext.MergeEventFields(event, common.MapStr{
+				"monitor": common.MapStr{"status_detail": look.StatusDegraded},
+			})
+		}
+	}
+
 	return start, end, errReason
 }
 
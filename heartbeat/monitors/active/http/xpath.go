@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"errors"
+	"io"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// xpathExpr is a compiled XPath 1.0 expression of an XML response check.
+type xpathExpr struct {
+	expr *xpath.Expr
+
+	// prefixes maps the namespace URIs declared by the check to the prefixes
+	// used in the expression.
+	prefixes map[string]string
+}
+
+// compileXPath compiles the expression, with namespaces mapping the prefixes
+// used in the expression to namespace URIs.
+func compileXPath(expr string, namespaces map[string]string) (*xpathExpr, error) {
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make(map[string]string, len(namespaces))
+	for prefix, uri := range namespaces {
+		prefixes[uri] = prefix
+	}
+	return &xpathExpr{expr: compiled, prefixes: prefixes}, nil
+}
+
+func mustCompileXPath(expr string) *xpathExpr {
+	x, err := compileXPath(expr, nil)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+// evaluate returns the nodes selected by the expression, with n as context
+// node. The prefixes of the elements below n are replaced by those declared
+// for their namespace, elements of other namespaces have no prefix.
+func (x *xpathExpr) evaluate(n *xmlquery.Node) []*xmlquery.Node {
+	var setPrefixes func(*xmlquery.Node)
+	setPrefixes = func(n *xmlquery.Node) {
+		if n.Type == xmlquery.ElementNode {
+			n.Prefix = x.prefixes[n.NamespaceURI]
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			setPrefixes(c)
+		}
+	}
+	setPrefixes(n)
+
+	return xmlquery.QuerySelectorAll(n, x.expr)
+}
+
+// parseXML parses a document with a root element. Attributes are selected by
+// their local name, namespace declarations are removed.
+func parseXML(r io.Reader) (*xmlquery.Node, error) {
+	doc, err := xmlquery.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root bool
+	var walk func(*xmlquery.Node)
+	walk = func(n *xmlquery.Node) {
+		if n.Type == xmlquery.ElementNode {
+			root = true
+
+			attrs := n.Attr[:0]
+			for _, attr := range n.Attr {
+				if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+					continue
+				}
+				attr.Name.Space = ""
+				attrs = append(attrs, attr)
+			}
+			n.Attr = attrs
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if !root {
+		return nil, errors.New("XML document has no root element")
+	}
+	return doc, nil
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xpathTestDoc = `<?xml version="1.0" encoding="UTF-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+  <s:Body>
+    <m:StatusResponse xmlns:m="urn:status">
+      <m:Service name="db" state="up">
+        <m:Latency>12</m:Latency>
+      </m:Service>
+      <m:Service name="cache" state="down">
+        <m:Latency>250</m:Latency>
+      </m:Service>
+      <m:Message>all <![CDATA[good]]></m:Message>
+    </m:StatusResponse>
+  </s:Body>
+</s:Envelope>`
+
+func TestXPathEvaluate(t *testing.T) {
+	statusNamespaces := map[string]string{"m": "urn:status", "soap": soap11Namespace}
+	tests := []struct {
+		expr       string
+		namespaces map[string]string
+		values     []string
+	}{
+		{"/Envelope/Body/StatusResponse/Service/@name", nil, []string{"db", "cache"}},
+		{"//Service[@state='down']/@name", nil, []string{"cache"}},
+		{"//Service[2]/Latency", nil, []string{"250"}},
+		{"//Service[Latency='12']/@state", nil, []string{"up"}},
+		{"//m:Service[@name=\"db\"]/m:Latency/text()", statusNamespaces, []string{"12"}},
+		{"//soap:Body/*/m:Message", statusNamespaces, []string{"all good"}},
+		{"//Latency[.='250']/../@name", nil, []string{"cache"}},
+		{"//Service/@*", nil, []string{"db", "up", "cache", "down"}},
+		{"//soap:Service", statusNamespaces, nil},
+		{"//Service", statusNamespaces, nil},
+		{"//Missing", nil, nil},
+		{"//Service[3]", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			doc, err := parseXML(strings.NewReader(xpathTestDoc))
+			require.NoError(t, err)
+
+			x, err := compileXPath(test.expr, test.namespaces)
+			require.NoError(t, err)
+
+			var values []string
+			for _, n := range x.evaluate(doc) {
+				values = append(values, n.InnerText())
+			}
+			assert.Equal(t, test.values, values)
+		})
+	}
+}
+
+func TestXPathCompileErrors(t *testing.T) {
+	for _, expr := range []string{"", "//a[", "//a[@b='c]"} {
+		_, err := compileXPath(expr, nil)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseXMLErrors(t *testing.T) {
+	for _, doc := range []string{"", "not xml", "<a><b></a>", "<a>"} {
+		_, err := parseXML(strings.NewReader(doc))
+		assert.Error(t, err, doc)
+	}
+
+	doc, err := parseXML(strings.NewReader("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>"))
+	require.NoError(t, err)
+	assert.Equal(t, "caf\u00e9", doc.InnerText())
+}
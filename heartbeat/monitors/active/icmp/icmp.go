@@ -54,7 +54,12 @@ func create(
 		return nil, 0, err
 	}
 
-	jf, err := newJobFactory(config, monitors.NewStdResolver(), loop)
+	resolver, err := config.Mode.MakeResolver(config.Timeout)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jf, err := newJobFactory(config, resolver, loop)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -97,7 +102,7 @@ func (jf *jobFactory) makeJobs() (j []jobs.Job, endpoints int, err error) {
 	pingFactory := jf.pingIPFactory(&jf.config)
 
 	for _, host := range jf.config.Hosts {
-		job, err := monitors.MakeByHostJob(host, jf.config.Mode, monitors.NewStdResolver(), pingFactory)
+		job, err := monitors.MakeByHostJob(host, jf.config.Mode, jf.resolver, pingFactory)
 
 		if err != nil {
 			return nil, 0, err
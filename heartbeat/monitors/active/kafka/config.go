@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/common/kafka"
+)
+
+type config struct {
+	// bootstrap brokers, the port defaults to 9092
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	Version  kafka.Version `config:"version"`
+	ClientID string        `config:"client_id"`
+
+	// SASL credentials, the mechanism defaults to PLAIN
+	Username string     `config:"username"`
+	Password string     `config:"password"`
+	Sasl     saslConfig `config:"sasl"`
+
+	Check checkConfig `config:"check"`
+}
+
+type saslConfig struct {
+	Mechanism string `config:"mechanism"`
+}
+
+type checkConfig struct {
+	// minimum number of brokers in the cluster metadata
+	MinBrokers int `config:"min_brokers" validate:"min=0"`
+
+	// whether a controller must be elected
+	Controller bool `config:"controller"`
+
+	// topics that must exist with a leader for each partition
+	Topics []string `config:"topics"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config:   endpoint.DefaultConfig,
+		Version:  kafka.Version("1.0.0"),
+		ClientID: "heartbeat",
+	}
+}
+
+func (c *config) Validate() error {
+	if (c.Username == "") != (c.Password == "") {
+		return errors.New("username and password must be configured together")
+	}
+	if c.Username == "" && c.Sasl.Mechanism != "" {
+		return errors.New("sasl.mechanism requires a username and a password")
+	}
+	if c.Check.Controller {
+		if version, _ := c.Version.Get(); !version.IsAtLeast(sarama.V0_10_0_0) {
+			return errors.New("check.controller requires a version of at least 0.10.0")
+		}
+	}
+	return nil
+}
+
+func (c *saslConfig) Validate() error {
+	switch strings.ToUpper(c.Mechanism) {
+	case "", sarama.SASLTypePlaintext, sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512:
+		return nil
+	}
+	return fmt.Errorf("invalid sasl.mechanism '%v', must be one of PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", c.Mechanism)
+}
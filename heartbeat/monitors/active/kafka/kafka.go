@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	kafkaout "github.com/elastic/beats/v7/libbeat/outputs/kafka"
+)
+
+func init() {
+	monitors.RegisterActive("kafka", create)
+	monitors.RegisterActive("synthetics/kafka", create)
+}
+
+var debugf = logp.MakeDebug("kafka")
+
+var kafkaProtocol = endpoint.Protocol{Scheme: "kafka", Port: 9092}
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hosts := make([]string, len(config.Hosts))
+	for i, host := range config.Hosts {
+		hosts[i] = strings.TrimPrefix(host, "kafka://")
+	}
+	js = endpoint.Jobs(hosts, kafkaProtocol, false, func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, u)
+	})
+	return js, len(js), nil
+}
+
+// saramaConfig returns the configuration of the connection to the broker. The
+// connection is made by dialer, sarama is configured to use it as a proxy and
+// its own TLS settings stay disabled.
+func saramaConfig(config *config, dialer transport.Dialer) *sarama.Config {
+	cfg := sarama.NewConfig()
+	cfg.ClientID = config.ClientID
+	cfg.Version, _ = config.Version.Get()
+	cfg.Net.DialTimeout = config.Timeout
+	cfg.Net.ReadTimeout = config.Timeout
+	cfg.Net.WriteTimeout = config.Timeout
+	cfg.Net.Proxy.Enable = true
+	cfg.Net.Proxy.Dialer = dialer
+
+	if config.Username != "" {
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = config.Username
+		cfg.Net.SASL.Password = config.Password
+		switch strings.ToUpper(config.Sasl.Mechanism) {
+		case sarama.SASLTypeSCRAMSHA256:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &kafkaout.XDGSCRAMClient{HashGeneratorFcn: kafkaout.SHA256}
+			}
+		case sarama.SASLTypeSCRAMSHA512:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &kafkaout.XDGSCRAMClient{HashGeneratorFcn: kafkaout.SHA512}
+			}
+		default:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		}
+	}
+	return cfg
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"kafka": fields})
+
+		netDialer, err := dialer.Build(event, dialer.TLSConfig() != nil)
+		if err != nil {
+			return reason.IOFailed(err)
+		}
+		cfg := saramaConfig(config, netDialer)
+		broker := sarama.NewBroker(u.Host)
+
+		// Open connects in the background, Connected waits for the connection
+		// and the SASL authentication.
+		start := time.Now()
+		if err := broker.Open(cfg); err != nil {
+			return reason.IOFailed(err)
+		}
+		defer broker.Close()
+		if _, err := broker.Connected(); err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return brokerError(err)
+		}
+		fields.Put("rtt.connect", look.RTT(time.Since(start)))
+
+		request := &sarama.MetadataRequest{Topics: config.Check.Topics}
+		if cfg.Version.IsAtLeast(sarama.V0_10_0_0) {
+			// The controller is reported since version 1
+			request.Version = 1
+		}
+		start = time.Now()
+		metadata, err := broker.GetMetadata(request)
+		if err != nil {
+			debugf("failed to fetch the metadata from %v: %v", u, err)
+			return brokerError(err)
+		}
+		fields.Put("rtt.metadata", look.RTT(time.Since(start)))
+		fields["brokers"] = len(metadata.Brokers)
+		if request.Version >= 1 && metadata.ControllerID >= 0 {
+			fields.Put("controller.id", metadata.ControllerID)
+		}
+
+		return checkMetadata(config, request.Version, metadata)
+	})
+}
+
+// brokerError returns the reason of a failed request, the errors returned by
+// the broker, like failed authentications, fail the validation.
+func brokerError(err error) reason.Reason {
+	var kerr sarama.KError
+	if errors.As(err, &kerr) {
+		return reason.ValidateFailed(err)
+	}
+	return reason.IOFailed(err)
+}
+
+func checkMetadata(config *config, version int16, metadata *sarama.MetadataResponse) error {
+	if brokers := len(metadata.Brokers); brokers < config.Check.MinBrokers {
+		return reason.ValidateFailed(fmt.Errorf("%d brokers in the cluster, expected at least %d", brokers, config.Check.MinBrokers))
+	}
+
+	if config.Check.Controller && (version < 1 || metadata.ControllerID < 0) {
+		return reason.ValidateFailed(errors.New("no controller elected"))
+	}
+
+	topics := make(map[string]*sarama.TopicMetadata, len(metadata.Topics))
+	for _, topic := range metadata.Topics {
+		topics[topic.Name] = topic
+	}
+	for _, name := range config.Check.Topics {
+		if err := checkTopic(name, topics[name]); err != nil {
+			return reason.ValidateFailed(err)
+		}
+	}
+	return nil
+}
+
+// checkTopic checks the topic exists and each of its partitions has a leader.
+func checkTopic(name string, topic *sarama.TopicMetadata) error {
+	if topic == nil || topic.Err == sarama.ErrUnknownTopicOrPartition {
+		return fmt.Errorf("topic %s does not exist", name)
+	}
+	if topic.Err != sarama.ErrNoError {
+		return fmt.Errorf("topic %s is not available: %v", name, topic.Err)
+	}
+	if len(topic.Partitions) == 0 {
+		return fmt.Errorf("topic %s has no partitions", name)
+	}
+
+	var unavailable []int
+	for _, partition := range topic.Partitions {
+		if partition.Leader < 0 || partition.Err == sarama.ErrLeaderNotAvailable {
+			unavailable = append(unavailable, int(partition.ID))
+		}
+	}
+	if len(unavailable) > 0 {
+		sort.Ints(unavailable)
+		return fmt.Errorf("partitions %v of topic %s have no leader", unavailable, name)
+	}
+	return nil
+}
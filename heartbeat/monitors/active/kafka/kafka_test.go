@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func newMockBroker(t *testing.T, metadata *sarama.MockMetadataResponse) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": metadata.SetBroker(broker.Addr(), 1),
+	})
+	return broker
+}
+
+func TestMetadata(t *testing.T) {
+	broker := newMockBroker(t, sarama.NewMockMetadataResponse(t).
+		SetController(1).
+		SetLeader("orders", 0, 1).
+		SetLeader("orders", 1, 1))
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":             broker.Addr(),
+		"check.min_brokers": 1,
+		"check.controller":  true,
+		"check.topics":      []string{"orders"},
+	})
+	require.NoError(t, err)
+
+	fields, err := event.Fields.GetValue("kafka")
+	require.NoError(t, err)
+	kafkaFields := fields.(common.MapStr)
+	assert.Equal(t, 1, kafkaFields["brokers"])
+	assert.Equal(t, common.MapStr{"id": int32(1)}, kafkaFields["controller"])
+	for _, rtt := range []string{"connect", "metadata"} {
+		assert.Contains(t, kafkaFields["rtt"], rtt)
+	}
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "kafka://"+broker.Addr(), full)
+}
+
+func TestMetadataChecks(t *testing.T) {
+	broker := newMockBroker(t, sarama.NewMockMetadataResponse(t).
+		SetController(-1).
+		SetLeader("orders", 0, 1).
+		SetLeader("orders", 1, -1))
+	defer broker.Close()
+
+	for name, check := range map[string]map[string]interface{}{
+		"not enough brokers": {"check.min_brokers": 3},
+		"no controller":      {"check.controller": true},
+		"unknown topic":      {"check.topics": []string{"payments"}},
+		"partition offline":  {"check.topics": []string{"orders"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			check["hosts"] = broker.Addr()
+			_, err := hbtest.RunJob(t, create, check)
+			require.Error(t, err)
+			assert.Equal(t, "validate", err.(reason.Reason).Type())
+		})
+	}
+}
+
+func TestBrokerDown(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestCheckTopic(t *testing.T) {
+	available := &sarama.TopicMetadata{Name: "orders", Partitions: []*sarama.PartitionMetadata{
+		{ID: 0, Leader: 1}, {ID: 1, Leader: 2},
+	}}
+	assert.NoError(t, checkTopic("orders", available))
+
+	assert.Error(t, checkTopic("orders", nil))
+	assert.Error(t, checkTopic("orders", &sarama.TopicMetadata{Name: "orders", Err: sarama.ErrUnknownTopicOrPartition}))
+	assert.Error(t, checkTopic("orders", &sarama.TopicMetadata{Name: "orders"}))
+
+	offline := &sarama.TopicMetadata{Name: "orders", Partitions: []*sarama.PartitionMetadata{
+		{ID: 2, Leader: -1, Err: sarama.ErrLeaderNotAvailable}, {ID: 0, Leader: 1}, {ID: 1, Leader: -1},
+	}}
+	err := checkTopic("orders", offline)
+	require.Error(t, err)
+	assert.Equal(t, "partitions [1 2] of topic orders have no leader", err.Error())
+}
+
+func TestSaramaConfig(t *testing.T) {
+	config := defaultConfig()
+	config.Username = "heartbeat"
+	config.Password = "secret"
+	config.Sasl.Mechanism = "scram-sha-512"
+
+	cfg := saramaConfig(&config, &net.Dialer{})
+	assert.True(t, cfg.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), cfg.Net.SASL.Mechanism)
+	assert.NotNil(t, cfg.Net.SASL.SCRAMClientGeneratorFunc)
+	assert.False(t, cfg.Net.TLS.Enable)
+	assert.True(t, cfg.Net.Proxy.Enable)
+	assert.NoError(t, cfg.Validate())
+
+	config.Sasl.Mechanism = ""
+	cfg = saramaConfig(&config, &net.Dialer{})
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypePlaintext), cfg.Net.SASL.Mechanism)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"username without password":   {"username": "heartbeat"},
+		"mechanism without username":  {"sasl.mechanism": "PLAIN"},
+		"unknown mechanism":           {"username": "heartbeat", "password": "secret", "sasl.mechanism": "GSSAPI"},
+		"controller with old version": {"version": "0.9", "check.controller": true},
+		"unknown version":             {"version": "0.7"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings["hosts"] = "localhost"
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			_, _, err = create("kafka", cfg)
+			assert.Error(t, err)
+		})
+	}
+}
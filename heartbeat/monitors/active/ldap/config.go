@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+)
+
+type config struct {
+	// LDAP servers, the port defaults to 389, or 636 with implicit TLS
+	Hosts []string `config:"hosts" validate:"required"`
+
+	// connection settings, implicit TLS is used if ssl is configured unless
+	// starttls is enabled
+	endpoint.Config `config:",inline"`
+	StartTLS        bool `config:"starttls"`
+
+	// credentials of the simple bind, the bind is anonymous without them
+	BindDN   string `config:"bind_dn"`
+	Password string `config:"password"`
+
+	// search run once bound, no search is run if not set
+	Search *searchConfig `config:"search"`
+
+	Check checkConfig `config:"check"`
+}
+
+type searchConfig struct {
+	BaseDN string `config:"base_dn"`
+	Filter string `config:"filter"`
+	Scope  string `config:"scope"`
+}
+
+type checkConfig struct {
+	Bind   resultCheck `config:"bind"`
+	Search searchCheck `config:"search"`
+}
+
+type resultCheck struct {
+	// expected LDAP result code, 0 (success) by default
+	ResultCode int `config:"result_code" validate:"min=0"`
+
+	// maximum round trip time of the operation, not checked if 0
+	MaxRTT time.Duration `config:"max_rtt" validate:"positive"`
+}
+
+type searchCheck struct {
+	resultCheck `config:",inline"`
+
+	// minimum number of entries the search must return
+	MinEntries int `config:"min_entries" validate:"min=0"`
+}
+
+var searchScopes = map[string]int{
+	"base": goldap.ScopeBaseObject,
+	"one":  goldap.ScopeSingleLevel,
+	"sub":  goldap.ScopeWholeSubtree,
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.BindDN == "" && c.Password != "" {
+		return errors.New("a bind_dn is required to bind with a password")
+	}
+	if c.BindDN != "" && c.Password == "" {
+		return errors.New("a password is required to bind as bind_dn")
+	}
+	if c.Search == nil && (c.Check.Search != searchCheck{}) {
+		return errors.New("check.search requires a search")
+	}
+	return nil
+}
+
+func (c *searchConfig) Validate() error {
+	if _, ok := searchScopes[c.scope()]; !ok {
+		return fmt.Errorf("invalid search scope '%v', must be one of base, one or sub", c.Scope)
+	}
+	if _, err := goldap.CompileFilter(c.filter()); err != nil {
+		return fmt.Errorf("invalid search filter '%v': %v", c.Filter, err)
+	}
+	return nil
+}
+
+// scope returns the scope of the search, the base object by default.
+func (c *searchConfig) scope() string {
+	if c.Scope == "" {
+		return "base"
+	}
+	return c.Scope
+}
+
+// filter returns the filter of the search, matching any entry by default.
+func (c *searchConfig) filter() string {
+	if c.Filter == "" {
+		return "(objectClass=*)"
+	}
+	return c.Filter
+}
+
+// implicitTLS returns whether the connection is encrypted from the start.
+func (c *config) implicitTLS() bool {
+	return c.TLS != nil && !c.StartTLS
+}
@@ -0,0 +1,170 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("ldap", create)
+	monitors.RegisterActive("synthetics/ldap", create)
+}
+
+var debugf = logp.MakeDebug("ldap")
+
+var ldapProtocol = endpoint.Protocol{Scheme: "ldap", Port: 389, TLSScheme: "ldaps", TLSPort: 636}
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	js = endpoint.Jobs(config.Hosts, ldapProtocol, config.implicitTLS(), func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, u)
+	})
+	return js, len(js), nil
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"ldap": fields})
+
+		deadline := time.Now().Add(config.Timeout)
+		conn, err := dialer.Dial(event, u.Host, config.implicitTLS())
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		conn.SetDeadline(deadline)
+
+		client := goldap.NewConn(conn, config.implicitTLS())
+		client.Start()
+		defer client.Close()
+
+		if config.StartTLS {
+			start := time.Now()
+			if err := client.StartTLS(dialer.TLSConfig().BuildModuleConfig(u.Hostname())); err != nil {
+				return reason.IOFailed(err)
+			}
+			if state, ok := client.TLSConnectionState(); ok {
+				tlsmeta.AddTLSMetadata(event.Fields, state, time.Since(start))
+			}
+		}
+
+		start := time.Now()
+		_, err = client.SimpleBind(&goldap.SimpleBindRequest{
+			Username:           config.BindDN,
+			Password:           config.Password,
+			AllowEmptyPassword: true,
+		})
+		rtt := time.Since(start)
+		code, ok := resultCode(err)
+		if !ok {
+			debugf("bind to %v failed: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		fields.Put("rtt.bind", look.RTT(rtt))
+		fields.Put("bind.result_code", code)
+		if err := config.Check.Bind.check("bind", code, err, rtt); err != nil {
+			return err
+		}
+
+		if config.Search == nil {
+			return nil
+		}
+
+		search := config.Search
+		request := goldap.NewSearchRequest(
+			search.BaseDN, searchScopes[search.scope()], goldap.NeverDerefAliases,
+			0, int(config.Timeout/time.Second), false, search.filter(),
+			// request no attributes, only the entries matter
+			[]string{"1.1"}, nil,
+		)
+		start = time.Now()
+		result, err := client.Search(request)
+		rtt = time.Since(start)
+		code, ok = resultCode(err)
+		if !ok {
+			debugf("search on %v failed: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		fields.Put("rtt.search", look.RTT(rtt))
+		fields.Put("search.result_code", code)
+		fields.Put("search.entries", len(result.Entries))
+		if err := config.Check.Search.check("search", code, err, rtt); err != nil {
+			return err
+		}
+		if len(result.Entries) < config.Check.Search.MinEntries {
+			return reason.ValidateFailed(fmt.Errorf("search returned %d entries, expected at least %d",
+				len(result.Entries), config.Check.Search.MinEntries))
+		}
+		return nil
+	})
+}
+
+// resultCode returns the LDAP result code of an operation, and false if the
+// operation failed without a result from the server.
+func resultCode(err error) (int, bool) {
+	if err == nil {
+		return goldap.LDAPResultSuccess, true
+	}
+	var ldapErr *goldap.Error
+	if !errors.As(err, &ldapErr) || ldapErr.ResultCode >= goldap.ErrorNetwork {
+		return 0, false
+	}
+	return int(ldapErr.ResultCode), true
+}
+
+// check checks the result code and the round trip time of an operation, err
+// is the error returned for result codes other than success.
+func (c *resultCheck) check(op string, code int, err error, rtt time.Duration) error {
+	if code != c.ResultCode {
+		if err != nil {
+			return reason.ValidateFailed(fmt.Errorf("%s failed, expected result code %d: %v", op, c.ResultCode, err))
+		}
+		return reason.ValidateFailed(fmt.Errorf("%s succeeded, expected result code %d", op, c.ResultCode))
+	}
+	if c.MaxRTT > 0 && rtt > c.MaxRTT {
+		return reason.ValidateFailed(fmt.Errorf("%s took %v, more than %v", op, rtt, c.MaxRTT))
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// fakeServer answers bind and search requests, accepting the binds with
+// the password of the DN and returning the configured entries of the base
+// DN of a search.
+type fakeServer struct {
+	passwords map[string]string
+	entries   map[string][]string
+
+	tlsConfig *tls.Config
+	requests  chan string
+}
+
+func (f *fakeServer) serve(t *testing.T) (addr string, stop func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	// Borrow the self-signed certificate of the httptest package
+	srv := httptest.NewTLSServer(nil)
+	f.tlsConfig = srv.TLS
+	srv.Close()
+	f.requests = make(chan string, 100)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func (f *fakeServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			f.requests <- "bind " + dn
+			password, found := f.passwords[dn]
+			if found && password == op.Children[2].Data.String() {
+				f.respond(conn, id, goldap.ApplicationBindResponse, goldap.LDAPResultSuccess, "")
+			} else {
+				f.respond(conn, id, goldap.ApplicationBindResponse, goldap.LDAPResultInvalidCredentials, "invalid credentials")
+			}
+
+		case goldap.ApplicationSearchRequest:
+			base := op.Children[0].Value.(string)
+			filter, _ := goldap.DecompileFilter(op.Children[6])
+			f.requests <- fmt.Sprintf("search %s %d %s", base, op.Children[1].Value, filter)
+			entries, found := f.entries[base]
+			if !found {
+				f.respond(conn, id, goldap.ApplicationSearchResultDone, goldap.LDAPResultNoSuchObject, "no such object")
+				continue
+			}
+			for _, dn := range entries {
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+				entry.AppendChild(ber.NewSequence(""))
+				f.send(conn, id, entry)
+			}
+			f.respond(conn, id, goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess, "")
+
+		case goldap.ApplicationExtendedRequest:
+			f.requests <- "starttls"
+			f.respond(conn, id, goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess, "")
+			conn = tls.Server(conn, f.tlsConfig)
+
+		default:
+			return
+		}
+	}
+}
+
+func (f *fakeServer) respond(conn net.Conn, id int64, tag ber.Tag, code int, message string) {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, ""))
+	f.send(conn, id, response)
+}
+
+func (f *fakeServer) send(conn net.Conn, id int64, op *ber.Packet) {
+	packet := ber.NewSequence("")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	packet.AppendChild(op)
+	conn.Write(packet.Bytes())
+}
+
+func (f *fakeServer) received() []string {
+	var requests []string
+	for {
+		select {
+		case r := <-f.requests:
+			requests = append(requests, r)
+		default:
+			return requests
+		}
+	}
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		passwords: map[string]string{
+			"":                               "",
+			"cn=heartbeat,dc=example,dc=net": "secret",
+		},
+		entries: map[string][]string{
+			"ou=people,dc=example,dc=net": {
+				"uid=jane,ou=people,dc=example,dc=net",
+				"uid=john,ou=people,dc=example,dc=net",
+			},
+		},
+	}
+}
+
+func TestBindAndSearch(t *testing.T) {
+	server := newFakeServer()
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                    addr,
+		"starttls":                 true,
+		"ssl.verification_mode":    "none",
+		"bind_dn":                  "cn=heartbeat,dc=example,dc=net",
+		"password":                 "secret",
+		"search.base_dn":           "ou=people,dc=example,dc=net",
+		"search.scope":             "one",
+		"search.filter":            "(uid=*)",
+		"check.search.min_entries": 2,
+		"check.search.max_rtt":     "1s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"starttls",
+		"bind cn=heartbeat,dc=example,dc=net",
+		"search ou=people,dc=example,dc=net 1 (uid=*)",
+	}, server.received())
+
+	fields, err := event.Fields.GetValue("ldap")
+	require.NoError(t, err)
+	ldapFields := fields.(common.MapStr)
+	assert.Equal(t, common.MapStr{"result_code": 0}, ldapFields["bind"])
+	assert.Equal(t, common.MapStr{"result_code": 0, "entries": 2}, ldapFields["search"])
+	for _, rtt := range []string{"bind", "search"} {
+		assert.Contains(t, ldapFields["rtt"], rtt)
+	}
+
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "ldap://"+addr, full)
+}
+
+func TestAnonymousBind(t *testing.T) {
+	server := newFakeServer()
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bind "}, server.received())
+	_, err = event.Fields.GetValue("ldap.search")
+	assert.Error(t, err)
+
+	// The base object is searched by default
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "search.base_dn": "ou=people,dc=example,dc=net"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bind ", "search ou=people,dc=example,dc=net 0 (objectClass=*)"}, server.received())
+}
+
+func TestResultCodes(t *testing.T) {
+	server := newFakeServer()
+	addr, stop := server.serve(t)
+	defer stop()
+
+	// The credentials are rejected
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":    addr,
+		"bind_dn":  "cn=heartbeat,dc=example,dc=net",
+		"password": "wrong",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "invalid credentials")
+	assert.NotContains(t, err.Error(), "wrong")
+	code, err := event.Fields.GetValue("ldap.bind.result_code")
+	require.NoError(t, err)
+	assert.Equal(t, goldap.LDAPResultInvalidCredentials, code)
+
+	// The rejection is expected
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                  addr,
+		"bind_dn":                "cn=heartbeat,dc=example,dc=net",
+		"password":               "wrong",
+		"check.bind.result_code": goldap.LDAPResultInvalidCredentials,
+	})
+	require.NoError(t, err)
+
+	// The base DN does not exist
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "search.base_dn": "ou=groups,dc=example,dc=net"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+
+	// Not enough entries
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                    addr,
+		"search.base_dn":           "ou=people,dc=example,dc=net",
+		"check.search.min_entries": 3,
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestImplicitTLS(t *testing.T) {
+	server := newFakeServer()
+	addr, stop := server.serve(t)
+	defer stop()
+
+	// The server does not speak TLS
+	_, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 addr,
+		"ssl.verification_mode": "none",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestURL(t *testing.T) {
+	cfg := defaultConfig()
+	assert.Equal(t, "ldap://ldap.example.net:389", ldapProtocol.URL("ldap.example.net", cfg.implicitTLS()).String())
+
+	cfg.TLS = &tlscommon.Config{}
+	assert.Equal(t, "ldaps://ldap.example.net:636", ldapProtocol.URL("ldap.example.net", cfg.implicitTLS()).String())
+	cfg.StartTLS = true
+	assert.Equal(t, "ldap://ldap.example.net:389", ldapProtocol.URL("ldap.example.net", cfg.implicitTLS()).String())
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"password without bind_dn": {"password": "secret"},
+		"bind_dn without password": {"bind_dn": "cn=heartbeat,dc=example,dc=net"},
+		"invalid scope":            {"search.scope": "tree"},
+		"invalid filter":           {"search.filter": "uid=*"},
+		"check without search":     {"check.search.min_entries": 1},
+		"negative max_rtt":         {"check.bind.max_rtt": "-1s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings["hosts"] = "localhost"
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			_, _, err = create("ldap", cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+)
+
+type config struct {
+	// mail servers, the port defaults to the standard port of the protocol
+	Hosts []string `config:"hosts" validate:"required"`
+
+	// connection settings, implicit TLS is used if ssl is configured unless
+	// starttls is enabled
+	endpoint.Config `config:",inline"`
+	StartTLS        bool `config:"starttls"`
+
+	// credentials to log in with, the check does not log in without them
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	// IMAP mailbox selected once logged in, NOOP is sent if empty
+	Mailbox string `config:"mailbox"`
+
+	// patterns the greeting of the server must match one of
+	Greeting []match.Matcher `config:"check.greeting"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	if (c.Username == "") != (c.Password == "") {
+		return errors.New("username and password must be configured together")
+	}
+	return nil
+}
+
+// implicitTLS returns whether the connection is encrypted from the start.
+func (c *config) implicitTLS() bool {
+	return c.TLS != nil && !c.StartTLS
+}
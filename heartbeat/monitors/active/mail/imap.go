@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+)
+
+var imapProtocol = &protocol{
+	name:     "imap",
+	endpoint: endpoint.Protocol{Scheme: "imap", Port: 143, TLSScheme: "imaps", TLSPort: 993},
+	newDialog: func(s *session) dialog {
+		return &imapDialog{session: s}
+	},
+}
+
+type imapDialog struct {
+	*session
+	tag int
+}
+
+func (d *imapDialog) greeting(line string) error {
+	switch {
+	case strings.HasPrefix(line, "* OK"):
+		return nil
+	case strings.HasPrefix(line, "* PREAUTH"):
+		d.authd = true
+		return nil
+	}
+	return reason.ValidateFailed(fmt.Errorf("unexpected greeting '%v'", line))
+}
+
+func (d *imapDialog) startTLS() error {
+	_, err := d.command("STARTTLS")
+	return err
+}
+
+func (d *imapDialog) login(username, password string) error {
+	_, err := d.command("LOGIN %s %s", quote(username), quote(password))
+	return err
+}
+
+func (d *imapDialog) probe(config *config) (int, bool, error) {
+	if config.Mailbox == "" {
+		_, err := d.command("NOOP")
+		return 0, false, err
+	}
+
+	untagged, err := d.command("SELECT %s", quote(config.Mailbox))
+	if err != nil {
+		return 0, false, err
+	}
+	for _, line := range untagged {
+		// * 172 EXISTS
+		if parts := strings.Fields(line); len(parts) == 3 && parts[2] == "EXISTS" {
+			if n, err := strconv.Atoi(parts[1]); err == nil {
+				return n, true, nil
+			}
+		}
+	}
+	return 0, false, nil
+}
+
+func (d *imapDialog) quit() {
+	d.command("LOGOUT")
+}
+
+// command sends a tagged command and reads the responses until the tagged
+// one, returning the untagged responses if the command succeeded.
+func (d *imapDialog) command(format string, args ...interface{}) ([]string, error) {
+	d.tag++
+	tag := fmt.Sprintf("a%d", d.tag)
+	if err := d.cmd(tag+" "+format, args...); err != nil {
+		return nil, err
+	}
+
+	var untagged []string
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, tag+" ") {
+			untagged = append(untagged, line)
+			continue
+		}
+
+		status := strings.TrimPrefix(line, tag+" ")
+		if strings.HasPrefix(status, "OK") {
+			return untagged, nil
+		}
+		name := strings.Fields(format)[0]
+		return nil, reason.ValidateFailed(fmt.Errorf("%v failed: %v", name, status))
+	}
+}
+
+// quote returns the IMAP quoted string of s.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
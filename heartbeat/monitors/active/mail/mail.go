@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	for _, p := range []*protocol{imapProtocol, pop3Protocol} {
+		monitors.RegisterActive(p.name, makeCreate(p))
+		monitors.RegisterActive("synthetics/"+p.name, makeCreate(p))
+	}
+}
+
+var debugf = logp.MakeDebug("mail")
+
+// protocol describes how to check a mail protocol.
+type protocol struct {
+	name     string
+	endpoint endpoint.Protocol
+
+	// newDialog starts the protocol dialog on the session
+	newDialog func(s *session) dialog
+}
+
+// dialog runs the commands of a protocol. Negative answers of the server are
+// reported as validation failures.
+type dialog interface {
+	// greeting checks the greeting of the server
+	greeting(line string) error
+	startTLS() error
+	// login authenticates, it is skipped if the session is pre-authenticated
+	login(username, password string) error
+	// probe proves the mailbox backend is alive, returning the number of
+	// messages of the mailbox if known
+	probe(config *config) (messages int, known bool, err error)
+	quit()
+}
+
+// session is the connection to a mail server.
+type session struct {
+	conn  net.Conn
+	text  *textproto.Conn
+	host  string
+	authd bool
+}
+
+func (s *session) cmd(format string, args ...interface{}) error {
+	if err := s.text.PrintfLine(format, args...); err != nil {
+		return reason.IOFailed(err)
+	}
+	return nil
+}
+
+func (s *session) readLine() (string, error) {
+	line, err := s.text.ReadLine()
+	if err != nil {
+		return "", reason.IOFailed(err)
+	}
+	return line, nil
+}
+
+// upgrade performs the TLS handshake on the connection after STARTTLS.
+func (s *session) upgrade(event *beat.Event, dialer *endpoint.Dialer) error {
+	tlsConn, err := dialer.StartTLS(event, s.conn, s.host)
+	if err != nil {
+		return reason.IOFailed(err)
+	}
+	s.conn = tlsConn
+	s.text = textproto.NewConn(tlsConn)
+	return nil
+}
+
+func makeCreate(p *protocol) func(string, *common.Config) ([]jobs.Job, int, error) {
+	return func(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+		config := defaultConfig()
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, 0, err
+		}
+
+		dialer, err := endpoint.NewDialer(&config.Config)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		js = endpoint.Jobs(config.Hosts, p.endpoint, config.implicitTLS(), func(u *url.URL) jobs.Job {
+			return makeCheckJob(p, &config, dialer, u)
+		})
+		return js, len(js), nil
+	}
+}
+
+func makeCheckJob(p *protocol, config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"mail": fields})
+
+		deadline := time.Now().Add(config.Timeout)
+		start := time.Now()
+		conn, err := dialer.Dial(event, u.Host, config.implicitTLS())
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(deadline)
+
+		s := &session{conn: conn, text: textproto.NewConn(conn), host: u.Hostname()}
+		d := p.newDialog(s)
+
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		fields.Put("rtt.greeting", look.RTT(time.Since(start)))
+		fields["greeting"] = line
+		if err := d.greeting(line); err != nil {
+			return err
+		}
+		if err := checkGreeting(config, line); err != nil {
+			return err
+		}
+
+		if config.StartTLS {
+			if err := d.startTLS(); err != nil {
+				return err
+			}
+			if err := s.upgrade(event, dialer); err != nil {
+				return err
+			}
+		}
+
+		if config.Username == "" {
+			d.quit()
+			return nil
+		}
+
+		if !s.authd {
+			start = time.Now()
+			if err := d.login(config.Username, config.Password); err != nil {
+				return err
+			}
+			fields.Put("rtt.login", look.RTT(time.Since(start)))
+		}
+
+		start = time.Now()
+		messages, known, err := d.probe(config)
+		if err != nil {
+			return err
+		}
+		fields.Put("rtt.command", look.RTT(time.Since(start)))
+		if known {
+			fields["messages"] = messages
+		}
+		d.quit()
+		return nil
+	})
+}
+
+func checkGreeting(config *config, line string) error {
+	if len(config.Greeting) == 0 {
+		return nil
+	}
+	for _, m := range config.Greeting {
+		if m.MatchString(line) {
+			return nil
+		}
+	}
+	return reason.ValidateFailed(fmt.Errorf("greeting '%v' does not match", line))
+}
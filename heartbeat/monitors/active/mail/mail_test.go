@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// fakeServer answers the commands of a connection from a script, upgrading
+// the connection to TLS after the upgrade command.
+type fakeServer struct {
+	greeting string
+	// answers by command, without the IMAP tag
+	answers map[string][]string
+	upgrade string
+
+	tlsConfig *tls.Config
+	commands  chan string
+}
+
+func (f *fakeServer) serve(t *testing.T) (addr string, stop func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	// Borrow the self-signed certificate of the httptest package
+	srv := httptest.NewTLSServer(nil)
+	f.tlsConfig = srv.TLS
+	srv.Close()
+	f.commands = make(chan string, 100)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func (f *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("%s", f.greeting)
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		tag, command := "", line
+		if strings.HasPrefix(line, "a") {
+			parts := strings.SplitN(line, " ", 2)
+			tag, command = parts[0]+" ", parts[1]
+		}
+		f.commands <- command
+
+		answers, found := f.answers[strings.Fields(command)[0]]
+		if !found {
+			answers = []string{"OK"}
+		}
+		for i, answer := range answers {
+			if i == len(answers)-1 {
+				answer = tag + answer
+			}
+			text.PrintfLine("%s", answer)
+		}
+
+		if command == f.upgrade {
+			tlsConn := tls.Server(conn, f.tlsConfig)
+			text = textproto.NewConn(tlsConn)
+		}
+	}
+}
+
+func (f *fakeServer) received() []string {
+	var commands []string
+	for {
+		select {
+		case c := <-f.commands:
+			commands = append(commands, c)
+		default:
+			return commands
+		}
+	}
+}
+
+func TestIMAP(t *testing.T) {
+	server := &fakeServer{
+		greeting: "* OK IMAP4rev1 ready",
+		answers: map[string][]string{
+			"STARTTLS": {"OK begin TLS"},
+			"SELECT":   {"* FLAGS (\\Seen)", "* 172 EXISTS", "OK [READ-WRITE] SELECT completed"},
+		},
+		upgrade: "STARTTLS",
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, makeCreate(imapProtocol), map[string]interface{}{
+		"hosts":                 addr,
+		"starttls":              true,
+		"ssl.verification_mode": "none",
+		"username":              `jane"doe`,
+		"password":              "secret",
+		"mailbox":               "INBOX",
+		"timeout":               "2s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"STARTTLS",
+		`LOGIN "jane\"doe" "secret"`,
+		`SELECT "INBOX"`,
+		"LOGOUT",
+	}, server.received())
+
+	fields, err := event.Fields.GetValue("mail")
+	require.NoError(t, err)
+	mailFields := fields.(common.MapStr)
+	assert.Equal(t, "* OK IMAP4rev1 ready", mailFields["greeting"])
+	assert.Equal(t, 172, mailFields["messages"])
+	for _, rtt := range []string{"greeting", "login", "command"} {
+		assert.Contains(t, mailFields["rtt"], rtt)
+	}
+
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "imap://"+addr, full)
+
+	// Without credentials the check stops after the greeting
+	_, err = hbtest.RunJob(t, makeCreate(imapProtocol), map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"LOGOUT"}, server.received())
+
+	// The greeting does not match
+	_, err = hbtest.RunJob(t, makeCreate(imapProtocol), map[string]interface{}{"hosts": addr, "check.greeting": []string{"Dovecot"}, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestIMAPLoginFailed(t *testing.T) {
+	server := &fakeServer{
+		greeting: "* OK ready",
+		answers:  map[string][]string{"LOGIN": {"NO [AUTHENTICATIONFAILED] Invalid credentials"}},
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	_, err := hbtest.RunJob(t, makeCreate(imapProtocol), map[string]interface{}{
+		"hosts":    addr,
+		"username": "jane",
+		"password": "wrong",
+		"timeout":  "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "LOGIN failed")
+	assert.NotContains(t, err.Error(), "wrong")
+}
+
+func TestPOP3(t *testing.T) {
+	server := &fakeServer{
+		greeting: "+OK POP3 ready",
+		answers: map[string][]string{
+			"STLS": {"+OK begin TLS"},
+			"USER": {"+OK"},
+			"PASS": {"+OK maildrop locked"},
+			"STAT": {"+OK 2 320"},
+			"QUIT": {"+OK bye"},
+		},
+		upgrade: "STLS",
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, makeCreate(pop3Protocol), map[string]interface{}{
+		"hosts":                 addr,
+		"starttls":              true,
+		"ssl.verification_mode": "none",
+		"username":              "jane",
+		"password":              "secret",
+		"timeout":               "2s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"STLS", "USER jane", "PASS secret", "STAT", "QUIT"}, server.received())
+	messages, err := event.Fields.GetValue("mail.messages")
+	require.NoError(t, err)
+	assert.Equal(t, 2, messages)
+
+	// The server is unavailable
+	unavailable := &fakeServer{greeting: "-ERR maintenance"}
+	addr, stop = unavailable.serve(t)
+	defer stop()
+	_, err = hbtest.RunJob(t, makeCreate(pop3Protocol), map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestImplicitTLS(t *testing.T) {
+	server := &fakeServer{greeting: "+OK POP3 ready"}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	// The server does not speak TLS
+	_, err := hbtest.RunJob(t, makeCreate(pop3Protocol), map[string]interface{}{
+		"hosts":                 addr,
+		"ssl.verification_mode": "none",
+		"timeout":               "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestURL(t *testing.T) {
+	cfg := defaultConfig()
+	assert.Equal(t, "imap://mail.example.net:143", imapProtocol.endpoint.URL("mail.example.net", cfg.implicitTLS()).String())
+
+	cfg.TLS = &tlscommon.Config{}
+	assert.Equal(t, "imaps://mail.example.net:993", imapProtocol.endpoint.URL("mail.example.net", cfg.implicitTLS()).String())
+	cfg.StartTLS = true
+	assert.Equal(t, "pop3://mail.example.net:110", pop3Protocol.endpoint.URL("mail.example.net", cfg.implicitTLS()).String())
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+)
+
+var pop3Protocol = &protocol{
+	name:     "pop3",
+	endpoint: endpoint.Protocol{Scheme: "pop3", Port: 110, TLSScheme: "pop3s", TLSPort: 995},
+	newDialog: func(s *session) dialog {
+		return &pop3Dialog{session: s}
+	},
+}
+
+type pop3Dialog struct {
+	*session
+}
+
+func (d *pop3Dialog) greeting(line string) error {
+	if !strings.HasPrefix(line, "+OK") {
+		return reason.ValidateFailed(fmt.Errorf("unexpected greeting '%v'", line))
+	}
+	return nil
+}
+
+func (d *pop3Dialog) startTLS() error {
+	_, err := d.command("STLS")
+	return err
+}
+
+func (d *pop3Dialog) login(username, password string) error {
+	if _, err := d.command("USER %s", username); err != nil {
+		return err
+	}
+	_, err := d.command("PASS %s", password)
+	return err
+}
+
+// probe reads the status of the maildrop, which the server can only answer
+// once it locked the mailbox.
+func (d *pop3Dialog) probe(*config) (int, bool, error) {
+	// +OK 2 320
+	status, err := d.command("STAT")
+	if err != nil {
+		return 0, false, err
+	}
+	if parts := strings.Fields(status); len(parts) >= 1 {
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			return n, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (d *pop3Dialog) quit() {
+	d.command("QUIT")
+}
+
+// command sends a command and returns the text of the positive response.
+func (d *pop3Dialog) command(format string, args ...interface{}) (string, error) {
+	if err := d.cmd(format, args...); err != nil {
+		return "", err
+	}
+	line, err := d.readLine()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		name := strings.Fields(format)[0]
+		return "", reason.ValidateFailed(fmt.Errorf("%v failed: %v", name, line))
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, "+OK")), nil
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+)
+
+type config struct {
+	// MQTT brokers, the port defaults to 1883, or 8883 with TLS
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	// MQTT protocol version, 3.1 or 3.1.1
+	ProtocolVersion string `config:"protocol_version"`
+
+	// client identifier, a random one is used for each check if not set
+	ClientID string `config:"client_id"`
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	// round trip through a topic, the check only connects if not set
+	Probe *probeConfig `config:"probe"`
+}
+
+type probeConfig struct {
+	Topic string `config:"topic" validate:"required"`
+	QoS   int    `config:"qos" validate:"min=0,max=2"`
+
+	// maximum time between the publication of the message and its reception,
+	// the timeout by default
+	MaxRTT time.Duration `config:"max_rtt" validate:"positive"`
+}
+
+var protocolVersions = map[string]uint{
+	"3.1":   3,
+	"3.1.1": 4,
+}
+
+func defaultConfig() config {
+	return config{
+		Config:          endpoint.DefaultConfig,
+		ProtocolVersion: "3.1.1",
+	}
+}
+
+func (c *config) Validate() error {
+	if _, ok := protocolVersions[c.ProtocolVersion]; !ok {
+		return fmt.Errorf("invalid protocol_version '%v', must be one of 3.1 or 3.1.1", c.ProtocolVersion)
+	}
+	// MQTT 3.1 limits client identifiers to 23 characters
+	if c.ProtocolVersion == "3.1" && len(c.ClientID) > 23 {
+		return errors.New("client_id must be at most 23 characters long with protocol_version 3.1")
+	}
+	if c.Username == "" && c.Password != "" {
+		return errors.New("a username is required to authenticate with a password")
+	}
+	return nil
+}
+
+func (c *probeConfig) Validate() error {
+	if strings.ContainsAny(c.Topic, "+#") {
+		return fmt.Errorf("invalid probe topic '%v', wildcards are not allowed", c.Topic)
+	}
+	return nil
+}
+
+// maxRTT returns the maximum round trip time of the probe message.
+func (c *config) maxRTT() time.Duration {
+	if c.Probe.MaxRTT > 0 {
+		return c.Probe.MaxRTT
+	}
+	return c.Timeout
+}
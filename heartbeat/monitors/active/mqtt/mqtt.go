@@ -0,0 +1,322 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/gofrs/uuid"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("mqtt", create)
+	monitors.RegisterActive("synthetics/mqtt", create)
+}
+
+var debugf = logp.MakeDebug("mqtt")
+
+var mqttProtocol = endpoint.Protocol{Scheme: "mqtt", Port: 1883, TLSScheme: "mqtts", TLSPort: 8883}
+
+const (
+	// keep alive interval announced to the broker, in seconds, the default of
+	// the paho client
+	keepAlive = 30
+
+	// identifiers of the SUBSCRIBE and PUBLISH packets of the probe
+	subscribeID = 1
+	publishID   = 2
+
+	// suback return code of a refused subscription
+	subscriptionFailure = 0x80
+)
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	js = endpoint.Jobs(config.Hosts, mqttProtocol, config.TLS != nil, func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, u)
+	})
+	return js, len(js), nil
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"mqtt": fields})
+
+		deadline := time.Now().Add(config.Timeout)
+		start := time.Now()
+		conn, err := dialer.Dial(event, u.Host, config.TLS != nil)
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(deadline)
+
+		s := &session{conn: conn}
+		if err := s.connect(config); err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return err
+		}
+		defer s.send(packets.NewControlPacket(packets.Disconnect))
+		fields.Put("rtt.connect", look.RTT(time.Since(start)))
+
+		if config.Probe == nil {
+			return nil
+		}
+		return s.roundTrip(config, deadline, fields)
+	})
+}
+
+// session is the MQTT session of a check, with a clean session on the broker.
+type session struct {
+	conn net.Conn
+
+	// payload of the probe message and the time it was received back
+	payload  []byte
+	received time.Time
+}
+
+func (s *session) send(p packets.ControlPacket) error {
+	if err := p.Write(s.conn); err != nil {
+		return reason.IOFailed(err)
+	}
+	return nil
+}
+
+// read returns the next packet from the broker, the messages published to the
+// client are acknowledged and recorded as they arrive.
+func (s *session) read() (packets.ControlPacket, error) {
+	packet, err := packets.ReadPacket(s.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var ack packets.ControlPacket
+	switch p := packet.(type) {
+	case *packets.PublishPacket:
+		// other clients may publish to the topic as well, only the
+		// message of this check completes the round trip
+		if s.payload != nil && s.received.IsZero() && bytes.Equal(p.Payload, s.payload) {
+			s.received = time.Now()
+		}
+		switch p.Qos {
+		case 1:
+			puback := packets.NewControlPacket(packets.Puback).(*packets.PubackPacket)
+			puback.MessageID = p.MessageID
+			ack = puback
+		case 2:
+			pubrec := packets.NewControlPacket(packets.Pubrec).(*packets.PubrecPacket)
+			pubrec.MessageID = p.MessageID
+			ack = pubrec
+		}
+	case *packets.PubrelPacket:
+		pubcomp := packets.NewControlPacket(packets.Pubcomp).(*packets.PubcompPacket)
+		pubcomp.MessageID = p.MessageID
+		ack = pubcomp
+	}
+
+	if ack != nil {
+		if err := ack.Write(s.conn); err != nil {
+			return nil, err
+		}
+	}
+	return packet, nil
+}
+
+// await reads the packets until the one completing an operation, what is the
+// name of the packet.
+func (s *session) await(what string, completes func(packets.ControlPacket) bool) (packets.ControlPacket, error) {
+	for {
+		p, err := s.read()
+		if err != nil {
+			return nil, reason.IOFailed(fmt.Errorf("no %s received: %v", what, err))
+		}
+		if completes(p) {
+			return p, nil
+		}
+	}
+}
+
+// connect opens the session, a connection refused by the broker, like a failed
+// authentication, fails the validation.
+func (s *session) connect(config *config) error {
+	connect := packets.NewControlPacket(packets.Connect).(*packets.ConnectPacket)
+	connect.ProtocolVersion = byte(protocolVersions[config.ProtocolVersion])
+	connect.ProtocolName = "MQTT"
+	if connect.ProtocolVersion == 3 {
+		connect.ProtocolName = "MQIsdp"
+	}
+	connect.CleanSession = true
+	connect.Keepalive = keepAlive
+	connect.ClientIdentifier = config.ClientID
+	if connect.ClientIdentifier == "" {
+		connect.ClientIdentifier = randomClientID()
+	}
+	if config.Username != "" {
+		connect.UsernameFlag = true
+		connect.Username = config.Username
+	}
+	if config.Password != "" {
+		connect.PasswordFlag = true
+		connect.Password = []byte(config.Password)
+	}
+	if err := s.send(connect); err != nil {
+		return err
+	}
+
+	p, err := s.await("CONNACK", func(p packets.ControlPacket) bool {
+		_, ok := p.(*packets.ConnackPacket)
+		return ok
+	})
+	if err != nil {
+		return err
+	}
+	if code := p.(*packets.ConnackPacket).ReturnCode; code != packets.Accepted {
+		refusal, known := packets.ConnErrors[code]
+		if !known {
+			refusal = fmt.Errorf("return code %d", code)
+		}
+		return reason.ValidateFailed(fmt.Errorf("connection refused: %v", refusal))
+	}
+	return nil
+}
+
+// roundTrip subscribes to the probe topic and publishes a unique message to
+// it, the check fails if the message is not received back in time.
+func (s *session) roundTrip(config *config, deadline time.Time, fields common.MapStr) error {
+	topic, qos := config.Probe.Topic, byte(config.Probe.QoS)
+	s.payload = []byte(uuid.Must(uuid.NewV4()).String())
+
+	start := time.Now()
+	subscribe := packets.NewControlPacket(packets.Subscribe).(*packets.SubscribePacket)
+	subscribe.MessageID = subscribeID
+	subscribe.Topics = []string{topic}
+	subscribe.Qoss = []byte{qos}
+	if err := s.send(subscribe); err != nil {
+		return err
+	}
+	p, err := s.await("SUBACK", func(p packets.ControlPacket) bool {
+		suback, ok := p.(*packets.SubackPacket)
+		return ok && suback.MessageID == subscribeID
+	})
+	if err != nil {
+		return err
+	}
+	if codes := p.(*packets.SubackPacket).ReturnCodes; len(codes) != 1 || codes[0] == subscriptionFailure {
+		return reason.ValidateFailed(fmt.Errorf("subscription to topic %s refused", topic))
+	}
+	fields.Put("rtt.subscribe", look.RTT(time.Since(start)))
+
+	start = time.Now()
+	if err := s.publish(topic, qos); err != nil {
+		return err
+	}
+	if qos > 0 {
+		fields.Put("rtt.publish", look.RTT(time.Since(start)))
+	}
+
+	maxRTT := config.maxRTT()
+	if limit := start.Add(maxRTT); limit.Before(deadline) {
+		s.conn.SetReadDeadline(limit)
+	}
+	for s.received.IsZero() {
+		if _, err := s.read(); err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return reason.ValidateFailed(fmt.Errorf("message not received on topic %s within %v", topic, maxRTT))
+			}
+			return reason.IOFailed(err)
+		}
+	}
+	fields.Put("rtt.round_trip", look.RTT(s.received.Sub(start)))
+	return nil
+}
+
+// publish publishes the probe message, waiting for its acknowledgement with
+// QoS 1 and 2.
+func (s *session) publish(topic string, qos byte) error {
+	publish := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+	publish.TopicName = topic
+	publish.Qos = qos
+	publish.Payload = s.payload
+	if qos > 0 {
+		publish.MessageID = publishID
+	}
+	if err := s.send(publish); err != nil {
+		return err
+	}
+
+	switch qos {
+	case 1:
+		_, err := s.await("PUBACK", func(p packets.ControlPacket) bool {
+			puback, ok := p.(*packets.PubackPacket)
+			return ok && puback.MessageID == publishID
+		})
+		return err
+	case 2:
+		_, err := s.await("PUBREC", func(p packets.ControlPacket) bool {
+			pubrec, ok := p.(*packets.PubrecPacket)
+			return ok && pubrec.MessageID == publishID
+		})
+		if err != nil {
+			return err
+		}
+		pubrel := packets.NewControlPacket(packets.Pubrel).(*packets.PubrelPacket)
+		pubrel.MessageID = publishID
+		if err := s.send(pubrel); err != nil {
+			return err
+		}
+		_, err = s.await("PUBCOMP", func(p packets.ControlPacket) bool {
+			pubcomp, ok := p.(*packets.PubcompPacket)
+			return ok && pubcomp.MessageID == publishID
+		})
+		return err
+	}
+	return nil
+}
+
+// randomClientID returns a client identifier unique to a check, so that
+// concurrent checks of the same broker do not take over each other's session.
+func randomClientID() string {
+	id := uuid.Must(uuid.NewV4())
+	return fmt.Sprintf("heartbeat-%x", id[:6])
+}
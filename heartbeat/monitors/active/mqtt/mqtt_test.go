@@ -0,0 +1,279 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mqtt
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// mockBroker is a single session MQTT broker, forwarding the messages
+// published by the client back to it.
+type mockBroker struct {
+	listener net.Listener
+
+	connackCode byte
+	subackCode  byte
+	forward     bool
+}
+
+func newMockBroker(t *testing.T) *mockBroker {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return startMockBroker(l)
+}
+
+// newTLSMockBroker returns a mock broker accepting TLS connections only.
+func newTLSMockBroker(t *testing.T) *mockBroker {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	// Borrow the self-signed certificate of the httptest package
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	return startMockBroker(tls.NewListener(l, srv.TLS))
+}
+
+func startMockBroker(l net.Listener) *mockBroker {
+	b := &mockBroker{listener: l, forward: true}
+	go b.serve()
+	return b
+}
+
+func (b *mockBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+func (b *mockBroker) Close() {
+	b.listener.Close()
+}
+
+func (b *mockBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *mockBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		var responses []packets.ControlPacket
+		switch p := packet.(type) {
+		case *packets.ConnectPacket:
+			connack := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+			connack.ReturnCode = b.connackCode
+			responses = append(responses, connack)
+		case *packets.SubscribePacket:
+			suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			suback.MessageID = p.MessageID
+			for _, qos := range p.Qoss {
+				code := qos
+				if b.subackCode != 0 {
+					code = b.subackCode
+				}
+				suback.ReturnCodes = append(suback.ReturnCodes, code)
+			}
+			responses = append(responses, suback)
+		case *packets.PublishPacket:
+			switch p.Qos {
+			case 1:
+				puback := packets.NewControlPacket(packets.Puback).(*packets.PubackPacket)
+				puback.MessageID = p.MessageID
+				responses = append(responses, puback)
+			case 2:
+				pubrec := packets.NewControlPacket(packets.Pubrec).(*packets.PubrecPacket)
+				pubrec.MessageID = p.MessageID
+				responses = append(responses, pubrec)
+			}
+			if b.forward {
+				publish := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				publish.TopicName = p.TopicName
+				publish.Payload = p.Payload
+				responses = append(responses, publish)
+			}
+		case *packets.PubrelPacket:
+			pubcomp := packets.NewControlPacket(packets.Pubcomp).(*packets.PubcompPacket)
+			pubcomp.MessageID = p.MessageID
+			responses = append(responses, pubcomp)
+		case *packets.PingreqPacket:
+			responses = append(responses, packets.NewControlPacket(packets.Pingresp))
+		case *packets.DisconnectPacket:
+			return
+		}
+
+		for _, response := range responses {
+			if err := response.Write(conn); err != nil {
+				return
+			}
+		}
+		// the broker closes the connection after refusing it
+		if _, ok := packet.(*packets.ConnectPacket); ok && b.connackCode != packets.Accepted {
+			return
+		}
+	}
+}
+
+func TestConnect(t *testing.T) {
+	broker := newMockBroker(t)
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": broker.Addr(), "timeout": "2s"})
+	require.NoError(t, err)
+
+	rtt, err := event.Fields.GetValue("mqtt.rtt")
+	require.NoError(t, err)
+	assert.Contains(t, rtt, "connect")
+	assert.NotContains(t, rtt, "round_trip")
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "mqtt://"+broker.Addr(), full)
+}
+
+func TestRoundTrip(t *testing.T) {
+	broker := newMockBroker(t)
+	defer broker.Close()
+
+	for _, qos := range []int{0, 1, 2} {
+		event, err := hbtest.RunJob(t, create, map[string]interface{}{
+			"hosts":       broker.Addr(),
+			"probe.topic": "heartbeat/probe",
+			"probe.qos":   qos,
+			"timeout":     "2s",
+		})
+		require.NoError(t, err)
+
+		rtt, err := event.Fields.GetValue("mqtt.rtt")
+		require.NoError(t, err)
+		for _, step := range []string{"connect", "subscribe", "round_trip"} {
+			assert.Contains(t, rtt, step)
+		}
+	}
+}
+
+func TestTLS(t *testing.T) {
+	broker := newTLSMockBroker(t)
+	defer broker.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 broker.Addr(),
+		"ssl.verification_mode": "none",
+		"probe.topic":           "heartbeat/probe",
+		"timeout":               "2s",
+	})
+	require.NoError(t, err)
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "mqtts://"+broker.Addr(), full)
+}
+
+func TestRoundTripFailures(t *testing.T) {
+	for name, setup := range map[string]func(*mockBroker){
+		"bad credentials":      func(b *mockBroker) { b.connackCode = packets.ErrRefusedBadUsernameOrPassword },
+		"subscription refused": func(b *mockBroker) { b.subackCode = subscriptionFailure },
+		"message lost":         func(b *mockBroker) { b.forward = false },
+	} {
+		t.Run(name, func(t *testing.T) {
+			broker := newMockBroker(t)
+			defer broker.Close()
+			setup(broker)
+
+			start := time.Now()
+			_, err := hbtest.RunJob(t, create, map[string]interface{}{
+				"hosts":         broker.Addr(),
+				"username":      "heartbeat",
+				"password":      "secret",
+				"probe.topic":   "heartbeat/probe",
+				"probe.qos":     1,
+				"probe.max_rtt": "200ms",
+				"timeout":       "2s",
+			})
+			require.Error(t, err)
+			assert.Equal(t, "validate", err.(reason.Reason).Type())
+			assert.Less(t, int64(time.Since(start)), int64(2*time.Second))
+		})
+	}
+}
+
+func TestBrokerDown(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestURL(t *testing.T) {
+	config := defaultConfig()
+	assert.Equal(t, "mqtt://broker:1883", mqttProtocol.URL("broker", config.TLS != nil).String())
+
+	config.TLS = &tlscommon.Config{}
+	assert.Equal(t, "mqtts://broker:8883", mqttProtocol.URL("broker", config.TLS != nil).String())
+}
+
+func TestRandomClientID(t *testing.T) {
+	id := randomClientID()
+	assert.LessOrEqual(t, len(id), 23)
+	assert.NotEqual(t, id, randomClientID())
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"unknown protocol version": {"protocol_version": "5"},
+		"long client id with 3.1":  {"protocol_version": "3.1", "client_id": "heartbeat-with-a-long-client-id"},
+		"password without user":    {"password": "secret"},
+		"probe without topic":      {"probe.qos": 1},
+		"wildcard probe topic":     {"probe.topic": "heartbeat/#"},
+		"invalid qos":              {"probe.topic": "heartbeat/probe", "probe.qos": 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings["hosts"] = "localhost"
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			_, _, err = create("mqtt", cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+)
+
+type config struct {
+	// Redis servers, the port defaults to 6379
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	// credentials sent with AUTH, the username requires Redis 6 ACLs
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// expected replication role, master or slave, primary and replica are
+	// accepted as aliases
+	Role string `config:"role"`
+
+	// patterns the fields of the INFO reply must match, by field name
+	Info map[string]match.Matcher `config:"info"`
+}
+
+// roles maps the accepted roles to the roles reported by INFO.
+var roles = map[string]string{
+	"master":  "master",
+	"primary": "master",
+	"slave":   "slave",
+	"replica": "slave",
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Username != "" && c.Password == "" {
+		return errors.New("a password is required to authenticate")
+	}
+	if _, ok := roles[c.Check.Role]; c.Check.Role != "" && !ok {
+		return fmt.Errorf("invalid check.role '%v', must be master, primary, slave or replica", c.Check.Role)
+	}
+	return nil
+}
+
+// needsInfo returns whether the check sends INFO after PING.
+func (c *config) needsInfo() bool {
+	return c.Check.Role != "" || len(c.Check.Info) > 0
+}
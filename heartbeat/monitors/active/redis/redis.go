@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("redis", create)
+	monitors.RegisterActive("synthetics/redis", create)
+}
+
+var debugf = logp.MakeDebug("redis")
+
+var redisProtocol = endpoint.Protocol{Scheme: "redis", Port: 6379, TLSScheme: "rediss"}
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	js = endpoint.Jobs(config.Hosts, redisProtocol, config.TLS != nil, func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, u)
+	})
+	return js, len(js), nil
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"redis": fields})
+
+		deadline := time.Now().Add(config.Timeout)
+		start := time.Now()
+		nc, err := dialer.Dial(event, u.Host, config.TLS != nil)
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		defer nc.Close()
+		nc.SetDeadline(deadline)
+		fields.Put("rtt.connect", look.RTT(time.Since(start)))
+		c := newConn(nc)
+
+		if config.Password != "" {
+			args := []string{"AUTH", config.Password}
+			if config.Username != "" {
+				args = []string{"AUTH", config.Username, config.Password}
+			}
+			start = time.Now()
+			if _, err := command(c, args...); err != nil {
+				return err
+			}
+			fields.Put("rtt.auth", look.RTT(time.Since(start)))
+		}
+
+		start = time.Now()
+		reply, err := command(c, "PING")
+		if err != nil {
+			return err
+		}
+		fields.Put("rtt.ping", look.RTT(time.Since(start)))
+		if reply != "PONG" {
+			return reason.ValidateFailed(fmt.Errorf("unexpected PING reply '%v'", reply))
+		}
+
+		if !config.needsInfo() {
+			c.do("QUIT")
+			return nil
+		}
+
+		start = time.Now()
+		reply, err = command(c, "INFO")
+		if err != nil {
+			return err
+		}
+		fields.Put("rtt.info", look.RTT(time.Since(start)))
+		text, ok := reply.(string)
+		if !ok {
+			return reason.ValidateFailed(fmt.Errorf("unexpected INFO reply of type %T", reply))
+		}
+		info := parseInfo(text)
+		if version := info["redis_version"]; version != "" {
+			fields["version"] = version
+		}
+		if role := info["role"]; role != "" {
+			fields["role"] = role
+		}
+		c.do("QUIT")
+
+		return checkInfo(config, info)
+	})
+}
+
+// command sends a command. Error replies of the server, like a wrong password
+// or a server loading its dataset, are reported as validation failures.
+func command(c *conn, args ...string) (interface{}, error) {
+	reply, err := c.do(args...)
+	switch err.(type) {
+	case nil:
+		return reply, nil
+	case replyError:
+		// The arguments are not reported, they can hold the password
+		return nil, reason.ValidateFailed(fmt.Errorf("%v failed: %v", args[0], err))
+	case *protocolError:
+		return nil, reason.ValidateFailed(err)
+	default:
+		return nil, reason.IOFailed(err)
+	}
+}
+
+// parseInfo returns the fields of an INFO reply, made of sections of
+// name:value lines.
+func parseInfo(text string) map[string]string {
+	info := map[string]string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			info[parts[0]] = parts[1]
+		}
+	}
+	return info
+}
+
+// checkInfo validates the role and the fields of the INFO reply.
+func checkInfo(config *config, info map[string]string) error {
+	if config.Check.Role != "" {
+		if expected := roles[config.Check.Role]; info["role"] != expected {
+			return reason.ValidateFailed(fmt.Errorf("role is '%v', expected %v", info["role"], expected))
+		}
+	}
+
+	names := make([]string, 0, len(config.Check.Info))
+	for name := range config.Check.Info {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, found := info[name]
+		if !found {
+			return reason.ValidateFailed(fmt.Errorf("INFO field '%v' not found", name))
+		}
+		if m := config.Check.Info[name]; !m.MatchString(value) {
+			return reason.ValidateFailed(fmt.Errorf("INFO field %v '%v' does not match", name, value))
+		}
+	}
+	return nil
+}
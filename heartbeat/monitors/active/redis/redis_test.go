@@ -0,0 +1,264 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+const masterInfo = "# Server\r\nredis_version:6.0.9\r\nredis_mode:standalone\r\n\r\n" +
+	"# Replication\r\nrole:master\r\nconnected_slaves:1\r\n"
+
+// fakeServer answers the commands of a connection with the raw RESP replies
+// of a script, by command name.
+type fakeServer struct {
+	replies map[string]string
+	tls     bool
+
+	commands chan []string
+}
+
+func (f *fakeServer) serve(t *testing.T) (addr string, stop func()) {
+	var l net.Listener
+	var err error
+	if f.tls {
+		// Borrow the self-signed certificate of the httptest package
+		srv := httptest.NewTLSServer(nil)
+		l, err = tls.Listen("tcp", "localhost:0", srv.TLS)
+		srv.Close()
+	} else {
+		l, err = net.Listen("tcp", "localhost:0")
+	}
+	require.NoError(t, err)
+	f.commands = make(chan []string, 100)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn)
+		}
+	}()
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func (f *fakeServer) handle(nc net.Conn) {
+	defer nc.Close()
+	c := &conn{Conn: nc, r: bufio.NewReader(nc)}
+	for {
+		// Commands are arrays of bulk strings
+		reply, err := c.readReply()
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]interface{}) {
+			args = append(args, arg.(string))
+		}
+		f.commands <- args
+
+		answer, found := f.replies[args[0]]
+		if !found {
+			answer = "+OK\r\n"
+		}
+		nc.Write([]byte(answer))
+		if args[0] == "QUIT" {
+			return
+		}
+	}
+}
+
+func (f *fakeServer) received() []string {
+	var commands []string
+	for {
+		select {
+		case c := <-f.commands:
+			commands = append(commands, strings.Join(c, " "))
+		default:
+			return commands
+		}
+	}
+}
+
+func bulk(s string) string {
+	return "$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n"
+}
+
+func TestCheck(t *testing.T) {
+	server := &fakeServer{
+		replies: map[string]string{
+			"PING": "+PONG\r\n",
+			"INFO": bulk(masterInfo),
+		},
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                       addr,
+		"username":                    "heartbeat",
+		"password":                    "secret",
+		"check.role":                  "primary",
+		"check.info.redis_mode":       "standalone",
+		"check.info.connected_slaves": "^[1-9]",
+		"timeout":                     "2s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH heartbeat secret", "PING", "INFO", "QUIT"}, server.received())
+
+	fields, err := event.Fields.GetValue("redis")
+	require.NoError(t, err)
+	redisFields := fields.(common.MapStr)
+	assert.Equal(t, "6.0.9", redisFields["version"])
+	assert.Equal(t, "master", redisFields["role"])
+	for _, rtt := range []string{"connect", "auth", "ping", "info"} {
+		assert.Contains(t, redisFields["rtt"], rtt)
+	}
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "redis://"+addr, full)
+
+	// Without checks, only PING is sent
+	event, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "password": "secret", "timeout": "2s"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AUTH secret", "PING", "QUIT"}, server.received())
+	assert.NotContains(t, event.Fields["redis"], "role")
+}
+
+func TestCheckInfoFailed(t *testing.T) {
+	server := &fakeServer{
+		replies: map[string]string{
+			"PING": "+PONG\r\n",
+			"INFO": bulk(masterInfo),
+		},
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	// A replica is expected
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "check.role": "replica", "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "role is 'master', expected slave")
+	// The role is reported anyway
+	role, _ := event.Fields.GetValue("redis.role")
+	assert.Equal(t, "master", role)
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "check.info.master_link_status": "up", "timeout": "2s"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'master_link_status' not found")
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "check.info.redis_mode": "cluster", "timeout": "2s"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match")
+}
+
+func TestCheckErrorReplies(t *testing.T) {
+	server := &fakeServer{
+		replies: map[string]string{
+			"AUTH": "-WRONGPASS invalid username-password pair\r\n",
+			"PING": "-NOAUTH Authentication required.\r\n",
+		},
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	_, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "password": "wrong", "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Equal(t, "AUTH failed: WRONGPASS invalid username-password pair", err.Error())
+	assert.NotContains(t, err.Error(), "wrong")
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "PING failed: NOAUTH")
+
+	// Not a Redis server
+	server.replies["PING"] = "HTTP/1.1 400 Bad Request\r\n"
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "invalid reply")
+}
+
+func TestTLS(t *testing.T) {
+	server := &fakeServer{
+		replies: map[string]string{"PING": "+PONG\r\n"},
+		tls:     true,
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 addr,
+		"ssl.verification_mode": "none",
+		"timeout":               "2s",
+	})
+	require.NoError(t, err)
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "rediss://"+addr, full)
+
+	// The server does not speak plain text
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+}
+
+func TestURL(t *testing.T) {
+	cfg := defaultConfig()
+	assert.Equal(t, "redis://redis.example.net:6379", redisProtocol.URL("redis.example.net", cfg.TLS != nil).String())
+	assert.Equal(t, "redis://redis.example.net:6380", redisProtocol.URL("redis.example.net:6380", cfg.TLS != nil).String())
+
+	cfg.TLS = &tlscommon.Config{}
+	assert.Equal(t, "rediss://redis.example.net:6379", redisProtocol.URL("redis.example.net", cfg.TLS != nil).String())
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"username without password": {"hosts": "localhost", "username": "heartbeat"},
+		"invalid role":              {"hosts": "localhost", "check.role": "sentinel"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			_, _, err = create("redis", cfg)
+			assert.Error(t, err)
+		})
+	}
+}
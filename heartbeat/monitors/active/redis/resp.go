@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// maxBulkLen limits the size of the strings read from the server, INFO
+// replies are a few kilobytes.
+const maxBulkLen = 1 << 20
+
+// replyError is an error reply of the server, like
+// "NOAUTH Authentication required.".
+type replyError string
+
+func (e replyError) Error() string { return string(e) }
+
+// protocolError is a reply that is not valid RESP, the server is likely not
+// a Redis server.
+type protocolError struct {
+	msg string
+}
+
+func (e *protocolError) Error() string { return "invalid reply: " + e.msg }
+
+// conn sends commands to a Redis server with the RESP protocol.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func newConn(c net.Conn) *conn {
+	return &conn{Conn: c, r: bufio.NewReader(c)}
+}
+
+// do sends a command and reads its reply, which is a string, an int64, nil
+// or a slice of replies. Error replies are returned as replyError.
+func (c *conn) do(args ...string) (interface{}, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+func (c *conn) readReply() (interface{}, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, &protocolError{"empty line"}
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, replyError(line[1:])
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, &protocolError{"invalid integer " + strconv.Quote(line[1:])}
+		}
+		return n, nil
+	case '$':
+		n, err := c.readLength(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		if n > maxBulkLen {
+			return nil, &protocolError{fmt.Sprintf("string of %d bytes is too long", n)}
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		if !bytes.HasSuffix(data, []byte("\r\n")) {
+			return nil, &protocolError{"string not terminated by CRLF"}
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := c.readLength(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := c.readReply()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	default:
+		return nil, &protocolError{"unexpected " + strconv.Quote(truncate(line))}
+	}
+}
+
+// readLength parses the length of a bulk string or an array, -1 for nil.
+func (c *conn) readLength(line string) (int, error) {
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < -1 || n > maxBulkLen {
+		return 0, &protocolError{"invalid length " + strconv.Quote(truncate(line[1:]))}
+	}
+	return n, nil
+}
+
+// readLine reads a line terminated by CRLF, without the terminator.
+func (c *conn) readLine() (string, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return "", &protocolError{"line too long"}
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", &protocolError{"line not terminated by CRLF"}
+	}
+	return string(line[:len(line)-2]), nil
+}
+
+func truncate(s string) string {
+	if len(s) > 32 {
+		return s[:32] + "..."
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+)
+
+type config struct {
+	// database driver, postgres or mysql
+	Driver string `config:"driver" validate:"required"`
+
+	// database servers, the port defaults to the port of the driver
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	// database and credentials of the connection
+	Database string `config:"database"`
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	// query run once connected
+	Query string `config:"query" validate:"required"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// expected value of the first column of the first row, not checked if
+	// not set
+	Value *string `config:"value"`
+
+	// maximum duration of the connection and the query, not checked if 0
+	MaxRTT time.Duration `config:"max_rtt" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+		Query:  "SELECT 1",
+	}
+}
+
+func (c *config) Validate() error {
+	if _, ok := drivers[c.Driver]; !ok {
+		return fmt.Errorf("invalid driver '%v', must be postgres or mysql", c.Driver)
+	}
+	return nil
+}
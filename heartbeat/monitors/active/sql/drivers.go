@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	sqldriver "database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// driver connects to the servers of a database.
+type driver struct {
+	// scheme and default port of the URLs of the servers
+	protocol endpoint.Protocol
+
+	// connector returns the connector of the checks of a server
+	connector func(config *config, tlsConfig *tlscommon.TLSConfig, u *url.URL) (connector, error)
+
+	// serverError returns whether an error was returned by the server, like
+	// a failed authentication or an invalid query, rather than by the
+	// connection
+	serverError func(err error) bool
+}
+
+// connector opens the connections of the checks of a server.
+type connector interface {
+	// connect opens a connection with the dialer of the monitor, reporting
+	// the connection details to the event of the check
+	connect(event *beat.Event, dialer *endpoint.Dialer) (sqldriver.Conn, error)
+
+	driver() sqldriver.Driver
+}
+
+var drivers = map[string]*driver{
+	"postgres": {
+		protocol:  endpoint.Protocol{Scheme: "postgres", Port: 5432},
+		connector: newPostgresConnector,
+		serverError: func(err error) bool {
+			var pqErr *pq.Error
+			return errors.As(err, &pqErr)
+		},
+	},
+	"mysql": {
+		protocol:  endpoint.Protocol{Scheme: "mysql", Port: 3306},
+		connector: newMySQLConnector,
+		serverError: func(err error) bool {
+			var mysqlErr *mysql.MySQLError
+			return errors.As(err, &mysqlErr)
+		},
+	},
+}
+
+type postgresConnector struct {
+	dsn string
+	tls bool
+}
+
+func newPostgresConnector(config *config, tlsConfig *tlscommon.TLSConfig, u *url.URL) (connector, error) {
+	return &postgresConnector{dsn: postgresDSN(config, u), tls: tlsConfig != nil}, nil
+}
+
+func (c *postgresConnector) connect(event *beat.Event, dialer *endpoint.Dialer) (sqldriver.Conn, error) {
+	return pq.DialOpen(&postgresDialer{event: event, dialer: dialer, tls: c.tls}, c.dsn)
+}
+
+func (c *postgresConnector) driver() sqldriver.Driver {
+	return &pq.Driver{}
+}
+
+// postgresDSN returns a connection URL. The driver doesn't negotiate TLS, the
+// dialer does it with the ssl settings of the monitor.
+func postgresDSN(config *config, u *url.URL) string {
+	params := url.Values{}
+	params.Set("connect_timeout", strconv.Itoa(int(math.Ceil(config.Timeout.Seconds()))))
+	params.Set("sslmode", "disable")
+
+	dsn := url.URL{Scheme: "postgres", Host: u.Host, RawQuery: params.Encode()}
+	if config.Database != "" {
+		dsn.Path = "/" + config.Database
+	}
+	if config.Username != "" {
+		dsn.User = url.UserPassword(config.Username, config.Password)
+	}
+	return dsn.String()
+}
+
+// postgresSSLRequest asks the server to encrypt the connection before the
+// startup message, the server answers S if it accepts and N otherwise.
+var postgresSSLRequest = []byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}
+
+// postgresDialer connects the postgres driver to a server.
+type postgresDialer struct {
+	event  *beat.Event
+	dialer *endpoint.Dialer
+	tls    bool
+}
+
+func (d *postgresDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialTimeout(network, address, 0)
+}
+
+// DialTimeout connects to the server and negotiates TLS if enabled, the
+// timeout bounds the negotiation.
+func (d *postgresDialer) DialTimeout(_, address string, timeout time.Duration) (net.Conn, error) {
+	conn, err := d.dialer.Dial(d.event, address, false)
+	if err != nil || !d.tls {
+		return conn, err
+	}
+
+	if timeout > 0 {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+	tlsConn, err := d.startTLS(conn, address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	tlsConn.SetDeadline(time.Time{})
+	return tlsConn, nil
+}
+
+func (d *postgresDialer) startTLS(conn net.Conn, address string) (net.Conn, error) {
+	if _, err := conn.Write(postgresSSLRequest); err != nil {
+		return nil, err
+	}
+	var reply [1]byte
+	if _, err := io.ReadFull(conn, reply[:]); err != nil {
+		return nil, err
+	}
+	if reply[0] != 'S' {
+		return nil, errors.New("the server does not support TLS")
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	return d.dialer.StartTLS(d.event, conn, host)
+}
+
+// mysqlRegistrations counts the TLS configurations and the dial functions
+// registered with the mysql driver, which refers to them by name in the data
+// source names.
+var mysqlRegistrations uint64
+
+// mysqlConnector connects to a server through the dial function registered
+// for it with the mysql driver.
+type mysqlConnector struct {
+	dsn string
+
+	// dialer of the check connecting, the lock is held during the connection
+	mu     sync.Mutex
+	event  *beat.Event
+	dialer *endpoint.Dialer
+}
+
+func newMySQLConnector(config *config, tlsConfig *tlscommon.TLSConfig, u *url.URL) (connector, error) {
+	name := "heartbeat-" + strconv.FormatUint(atomic.AddUint64(&mysqlRegistrations, 1), 10)
+	dsn, err := mysqlDSN(config, tlsConfig, u, name)
+	if err != nil {
+		return nil, err
+	}
+	c := &mysqlConnector{dsn: dsn}
+	mysql.RegisterDial(name, c.dial)
+	return c, nil
+}
+
+func (c *mysqlConnector) connect(event *beat.Event, dialer *endpoint.Dialer) (sqldriver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.event, c.dialer = event, dialer
+	defer func() { c.event, c.dialer = nil, nil }()
+	return c.driver().Open(c.dsn)
+}
+
+func (c *mysqlConnector) dial(address string) (net.Conn, error) {
+	if c.dialer == nil {
+		return nil, errors.New("no check is connecting")
+	}
+	// The driver negotiates TLS itself once connected
+	return c.dialer.Dial(c.event, address, false)
+}
+
+func (c *mysqlConnector) driver() sqldriver.Driver {
+	return &mysql.MySQLDriver{}
+}
+
+// mysqlDSN returns the data source name of a server, connecting through the
+// dial function and with the TLS configuration registered with the name.
+func mysqlDSN(config *config, tlsConfig *tlscommon.TLSConfig, u *url.URL, name string) (string, error) {
+	cfg := mysql.NewConfig()
+	cfg.Net = name
+	cfg.Addr = u.Host
+	cfg.DBName = config.Database
+	cfg.User = config.Username
+	cfg.Passwd = config.Password
+	cfg.Timeout = config.Timeout
+	cfg.ReadTimeout = config.Timeout
+	cfg.WriteTimeout = config.Timeout
+
+	if tlsConfig != nil {
+		if err := mysql.RegisterTLSConfig(name, tlsConfig.BuildModuleConfig(u.Hostname())); err != nil {
+			return "", err
+		}
+		cfg.TLSConfig = name
+	}
+	return cfg.FormatDSN(), nil
+}
@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("sql", create)
+	monitors.RegisterActive("synthetics/sql", create)
+}
+
+var debugf = logp.MakeDebug("sql")
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	d := drivers[config.Driver]
+	for _, host := range config.Hosts {
+		// The connections are encrypted once connected, the URL doesn't
+		// change with TLS
+		u := d.protocol.URL(host, false)
+		c, err := d.connector(&config, dialer.TLSConfig(), u)
+		if err != nil {
+			return nil, 0, err
+		}
+		js = append(js, wrappers.WithURLField(u, makeCheckJob(&config, d, dialer, c, u)))
+	}
+	return js, len(js), nil
+}
+
+func makeCheckJob(config *config, d *driver, dialer *endpoint.Dialer, c connector, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"sql": fields})
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+
+		// Opening the database doesn't connect, the connection is made by
+		// the check.
+		db := sql.OpenDB(&checkConnector{connector: c, event: event, dialer: dialer})
+		defer db.Close()
+
+		start := time.Now()
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return checkError(d, "connection", err)
+		}
+		defer conn.Close()
+		connectRTT := time.Since(start)
+		fields.Put("rtt.connect", look.RTT(connectRTT))
+
+		start = time.Now()
+		value, found, err := queryScalar(ctx, conn, config.Query)
+		if err != nil {
+			return checkError(d, "query", err)
+		}
+		queryRTT := time.Since(start)
+		fields.Put("rtt.query", look.RTT(queryRTT))
+		if found {
+			fields["value"] = value
+		}
+
+		if expected := config.Check.Value; expected != nil {
+			if !found {
+				return reason.ValidateFailed(fmt.Errorf("query returned no value, expected '%v'", *expected))
+			}
+			if value != *expected {
+				return reason.ValidateFailed(fmt.Errorf("query returned '%v', expected '%v'", value, *expected))
+			}
+		}
+		if rtt := connectRTT + queryRTT; config.Check.MaxRTT > 0 && rtt > config.Check.MaxRTT {
+			return reason.ValidateFailed(fmt.Errorf("connection and query took %v, more than %v", rtt, config.Check.MaxRTT))
+		}
+		return nil
+	})
+}
+
+// checkConnector opens the connection of a check for database/sql.
+type checkConnector struct {
+	connector
+	event  *beat.Event
+	dialer *endpoint.Dialer
+}
+
+func (c *checkConnector) Connect(context.Context) (sqldriver.Conn, error) {
+	return c.connect(c.event, c.dialer)
+}
+
+func (c *checkConnector) Driver() sqldriver.Driver {
+	return c.driver()
+}
+
+// checkError reports the errors returned by the server, like a failed
+// authentication or an invalid query, as validation failures.
+func checkError(d *driver, op string, err error) error {
+	if d.serverError(err) {
+		return reason.ValidateFailed(fmt.Errorf("%s failed: %v", op, err))
+	}
+	return reason.IOFailed(err)
+}
+
+// queryScalar runs the query and returns the first column of the first row,
+// and false if the query returned no row or a NULL value.
+func queryScalar(ctx context.Context, conn *sql.Conn, query string) (string, bool, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return "", false, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", false, err
+	}
+	if len(columns) == 0 || !rows.Next() {
+		return "", false, rows.Err()
+	}
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+	if err := rows.Scan(values...); err != nil {
+		return "", false, err
+	}
+	value, found := formatValue(*values[0].(*interface{}))
+	return value, found, rows.Close()
+}
+
+// formatValue returns the value of a column as a string.
+func formatValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case []byte:
+		return string(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
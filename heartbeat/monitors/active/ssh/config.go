@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ssh
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+)
+
+type config struct {
+	// SSH servers, the port defaults to 22
+	Hosts []string `config:"hosts" validate:"required"`
+
+	endpoint.Config `config:",inline"`
+
+	// SHA256 fingerprints the host key of the server must match one of
+	Fingerprints []string `config:"host_key.fingerprints"`
+
+	// credentials to authenticate with, the check stops after the handshake
+	// without them
+	Username   string `config:"username"`
+	Password   string `config:"password"`
+	PrivateKey string `config:"private_key"`
+	Passphrase string `config:"private_key_passphrase"`
+
+	// command run once authenticated
+	Command string `config:"command"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// expected exit code of the command, 0 if not set
+	ExitCode *int `config:"exit_code"`
+
+	// patterns the output of the command, stdout and stderr, must all match
+	Output []match.Matcher `config:"output"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.TLS != nil {
+		return errors.New("ssl is not supported, SSH encrypts the connections itself")
+	}
+	if c.Username == "" && (c.Password != "" || c.PrivateKey != "") {
+		return errors.New("a username is required to authenticate")
+	}
+	if c.Username != "" && c.Password == "" && c.PrivateKey == "" {
+		return errors.New("a password or a private key is required to authenticate")
+	}
+	if c.Command != "" && c.Username == "" {
+		return errors.New("a username is required to run a command")
+	}
+	if c.Command == "" && (c.Check.ExitCode != nil || len(c.Check.Output) > 0) {
+		return errors.New("check.exit_code and check.output require a command")
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("ssh", create)
+	monitors.RegisterActive("synthetics/ssh", create)
+}
+
+var debugf = logp.MakeDebug("ssh")
+
+var sshProtocol = endpoint.Protocol{Scheme: "ssh", Port: 22}
+
+const (
+	// maxOutputSize is the maximum number of bytes of the command output
+	// added to events.
+	maxOutputSize = 1024
+
+	// maxVersionSize limits the data recorded while looking for the version
+	// line of the server, which may be preceded by other lines.
+	maxVersionSize = 8192
+)
+
+func create(name string, cfg *common.Config) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	auth, err := authMethods(&config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hosts := make([]string, len(config.Hosts))
+	for i, host := range config.Hosts {
+		hosts[i] = strings.TrimPrefix(host, "ssh://")
+	}
+	js = endpoint.Jobs(hosts, sshProtocol, false, func(u *url.URL) jobs.Job {
+		return makeCheckJob(&config, dialer, auth, u)
+	})
+	return js, len(js), nil
+}
+
+func authMethods(config *config) ([]gossh.AuthMethod, error) {
+	var methods []gossh.AuthMethod
+	if config.PrivateKey != "" {
+		pem, err := ioutil.ReadFile(config.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the private key: %v", err)
+		}
+		var signer gossh.Signer
+		if config.Passphrase != "" {
+			signer, err = gossh.ParsePrivateKeyWithPassphrase(pem, []byte(config.Passphrase))
+		} else {
+			signer, err = gossh.ParsePrivateKey(pem)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the private key %s: %v", config.PrivateKey, err)
+		}
+		methods = append(methods, gossh.PublicKeys(signer))
+	}
+	if config.Password != "" {
+		methods = append(methods, gossh.Password(config.Password))
+	}
+	return methods, nil
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, auth []gossh.AuthMethod, u *url.URL) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"ssh": fields})
+
+		deadline := time.Now().Add(config.Timeout)
+		start := time.Now()
+		conn, err := dialer.Dial(event, u.Host, false)
+		if err != nil {
+			debugf("could not connect to %v: %v", u, err)
+			return reason.IOFailed(err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(deadline)
+
+		vc := &versionConn{Conn: conn}
+		var keyReceived bool
+		var hostKeyErr error
+		clientConfig := &gossh.ClientConfig{
+			User: config.Username,
+			Auth: auth,
+			HostKeyCallback: func(_ string, _ net.Addr, key gossh.PublicKey) error {
+				keyReceived = true
+				fingerprint := gossh.FingerprintSHA256(key)
+				fields.Put("rtt.handshake", look.RTT(time.Since(start)))
+				fields.Put("host_key.type", key.Type())
+				fields.Put("host_key.fingerprint", fingerprint)
+				hostKeyErr = checkFingerprint(config, fingerprint)
+				return hostKeyErr
+			},
+		}
+
+		sshConn, chans, reqs, err := gossh.NewClientConn(vc, u.Host, clientConfig)
+		if version := vc.version(); version != "" {
+			fields["banner"] = version
+		}
+		switch {
+		case hostKeyErr != nil:
+			return reason.ValidateFailed(hostKeyErr)
+		case err != nil && !keyReceived:
+			debugf("handshake with %v failed: %v", u, err)
+			return reason.IOFailed(err)
+		case err != nil && len(auth) == 0:
+			// The key exchange succeeded, without credentials the check
+			// stops there.
+			return nil
+		case err != nil:
+			return reason.ValidateFailed(fmt.Errorf("authentication failed: %v", err))
+		}
+		client := gossh.NewClient(sshConn, chans, reqs)
+		defer client.Close()
+
+		if config.Command == "" {
+			return nil
+		}
+
+		session, err := client.NewSession()
+		if err != nil {
+			return reason.IOFailed(err)
+		}
+		defer session.Close()
+
+		start = time.Now()
+		output, err := session.CombinedOutput(config.Command)
+		exitCode := 0
+		if err != nil {
+			var exitErr *gossh.ExitError
+			if !errors.As(err, &exitErr) {
+				return reason.IOFailed(err)
+			}
+			exitCode = exitErr.ExitStatus()
+		}
+		fields.Put("rtt.command", look.RTT(time.Since(start)))
+		fields.Put("command.exit_code", exitCode)
+		if len(output) > maxOutputSize {
+			fields.Put("command.output", string(output[:maxOutputSize]))
+		} else {
+			fields.Put("command.output", string(output))
+		}
+
+		return checkCommand(config, exitCode, output)
+	})
+}
+
+// checkFingerprint checks the fingerprint of the host key is one of the
+// pinned ones. The SHA256: prefix and padding are optional in the config.
+func checkFingerprint(config *config, fingerprint string) error {
+	if len(config.Fingerprints) == 0 {
+		return nil
+	}
+	for _, pinned := range config.Fingerprints {
+		if !strings.HasPrefix(pinned, "SHA256:") {
+			pinned = "SHA256:" + pinned
+		}
+		if strings.TrimRight(pinned, "=") == fingerprint {
+			return nil
+		}
+	}
+	return fmt.Errorf("host key fingerprint %s is not pinned", fingerprint)
+}
+
+func checkCommand(config *config, exitCode int, output []byte) error {
+	expected := 0
+	if config.Check.ExitCode != nil {
+		expected = *config.Check.ExitCode
+	}
+	if exitCode != expected {
+		return reason.ValidateFailed(fmt.Errorf("command exited with code %d, expected %d", exitCode, expected))
+	}
+	for _, m := range config.Check.Output {
+		if !m.Match(output) {
+			return reason.ValidateFailed(fmt.Errorf("command output does not match '%v'", m))
+		}
+	}
+	return nil
+}
+
+// versionConn records the data read until the version line of the server,
+// which is not exposed by the client if the authentication fails.
+type versionConn struct {
+	net.Conn
+
+	mtx  sync.Mutex
+	buf  []byte
+	done bool
+}
+
+func (c *versionConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !c.done {
+		c.buf = append(c.buf, p[:n]...)
+		c.done = c.findVersion() != "" || len(c.buf) >= maxVersionSize
+	}
+	return n, err
+}
+
+func (c *versionConn) version() string {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.findVersion()
+}
+
+func (c *versionConn) findVersion() string {
+	for _, line := range bytes.SplitAfter(c.buf, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("SSH-")) && bytes.HasSuffix(line, []byte("\n")) {
+			return string(bytes.TrimRight(line, "\r\n"))
+		}
+	}
+	return ""
+}
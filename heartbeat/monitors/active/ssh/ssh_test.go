@@ -0,0 +1,296 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ssh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+const serverVersion = "SSH-2.0-OpenSSH_8.2p1 Ubuntu-4ubuntu0.1"
+
+// fakeServer is an SSH server accepting the password of jane or the client
+// key, and running commands with run.
+type fakeServer struct {
+	hostKey   gossh.Signer
+	clientKey gossh.PublicKey
+	run       func(command string) (output string, exitCode int)
+}
+
+func (f *fakeServer) serve(t *testing.T) (addr string, stop func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	config := &gossh.ServerConfig{
+		ServerVersion: serverVersion,
+		PasswordCallback: func(c gossh.ConnMetadata, password []byte) (*gossh.Permissions, error) {
+			if c.User() == "jane" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, errors.New("access denied")
+		},
+		PublicKeyCallback: func(c gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			if f.clientKey != nil && gossh.FingerprintSHA256(key) == gossh.FingerprintSHA256(f.clientKey) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown key")
+		},
+	}
+	config.AddHostKey(f.hostKey)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.handle(conn, config)
+		}
+	}()
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func (f *fakeServer) handle(conn net.Conn, config *gossh.ServerConfig) {
+	defer conn.Close()
+	_, chans, reqs, err := gossh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go gossh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(gossh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				var exec struct{ Command string }
+				gossh.Unmarshal(req.Payload, &exec)
+				req.Reply(true, nil)
+
+				output, exitCode := f.run(exec.Command)
+				channel.Write([]byte(output))
+				channel.SendRequest("exit-status", false, gossh.Marshal(struct{ Status uint32 }{uint32(exitCode)}))
+				return
+			}
+		}()
+	}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newSigner(t *testing.T, key *ecdsa.PrivateKey) gossh.Signer {
+	signer, err := gossh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestHandshake(t *testing.T) {
+	server := &fakeServer{hostKey: newSigner(t, newKey(t))}
+	addr, stop := server.serve(t)
+	defer stop()
+	fingerprint := gossh.FingerprintSHA256(server.hostKey.PublicKey())
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.NoError(t, err)
+
+	fields, err := event.Fields.GetValue("ssh")
+	require.NoError(t, err)
+	sshFields := fields.(common.MapStr)
+	assert.Equal(t, serverVersion, sshFields["banner"])
+	assert.Equal(t, common.MapStr{"type": "ecdsa-sha2-nistp256", "fingerprint": fingerprint}, sshFields["host_key"])
+	assert.Contains(t, sshFields["rtt"], "handshake")
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, "ssh://"+addr, full)
+
+	// Pinned fingerprint, without prefix
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 addr,
+		"host_key.fingerprints": []string{"SHA256:unknown", fingerprint[len("SHA256:"):]},
+		"timeout":               "2s",
+	})
+	require.NoError(t, err)
+
+	// Unknown fingerprint
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":                 addr,
+		"host_key.fingerprints": []string{"SHA256:unknown"},
+		"timeout":               "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestCommand(t *testing.T) {
+	server := &fakeServer{
+		hostKey: newSigner(t, newKey(t)),
+		run: func(command string) (string, int) {
+			if command == "systemctl is-active nginx" {
+				return "active\n", 0
+			}
+			return "inactive\n", 3
+		},
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":        addr,
+		"username":     "jane",
+		"password":     "secret",
+		"command":      "systemctl is-active nginx",
+		"check.output": []string{"^active"},
+		"timeout":      "2s",
+	})
+	require.NoError(t, err)
+	command, err := event.Fields.GetValue("ssh.command")
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"exit_code": 0, "output": "active\n"}, command)
+	rtt, err := event.Fields.GetValue("ssh.rtt")
+	require.NoError(t, err)
+	assert.Contains(t, rtt, "command")
+
+	// Unexpected exit code
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":    addr,
+		"username": "jane",
+		"password": "secret",
+		"command":  "systemctl is-active apache2",
+		"timeout":  "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+
+	// Expected exit code, unexpected output
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":           addr,
+		"username":        "jane",
+		"password":        "secret",
+		"command":         "systemctl is-active apache2",
+		"check.exit_code": 3,
+		"check.output":    []string{"^active"},
+		"timeout":         "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestPrivateKey(t *testing.T) {
+	clientKey := newKey(t)
+	server := &fakeServer{
+		hostKey:   newSigner(t, newKey(t)),
+		clientKey: newSigner(t, clientKey).PublicKey(),
+		run:       func(string) (string, int) { return "", 0 },
+	}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	der, err := x509.MarshalECPrivateKey(clientKey)
+	require.NoError(t, err)
+	dir, err := ioutil.TempDir("", "heartbeat-ssh")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "id_ecdsa")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":       addr,
+		"username":    "monitor",
+		"private_key": keyFile,
+		"command":     "true",
+		"timeout":     "2s",
+	})
+	require.NoError(t, err)
+}
+
+func TestAuthenticationFailed(t *testing.T) {
+	server := &fakeServer{hostKey: newSigner(t, newKey(t))}
+	addr, stop := server.serve(t)
+	defer stop()
+
+	_, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"hosts":    addr,
+		"username": "jane",
+		"password": "wrong",
+		"timeout":  "2s",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+}
+
+func TestConnectionRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	_, err = hbtest.RunJob(t, create, map[string]interface{}{"hosts": addr, "timeout": "2s"})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]map[string]interface{}{
+		"password without username": {"password": "secret"},
+		"username without secret":   {"username": "jane"},
+		"command without username":  {"command": "uptime"},
+		"check without command":     {"username": "jane", "password": "secret", "check.exit_code": 1},
+		"ssl":                       {"ssl.verification_mode": "none"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings["hosts"] = "localhost"
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+			config := defaultConfig()
+			assert.Error(t, cfg.Unpack(&config))
+		})
+	}
+}
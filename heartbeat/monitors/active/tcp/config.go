@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
 	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
@@ -35,6 +37,9 @@ type config struct {
 
 	Socks5 transport.ProxyConfig `config:",inline"`
 
+	// send a PROXY protocol header before any data
+	ProxyProtocol *dialchain.ProxyProtocolConfig `config:"proxy_protocol"`
+
 	// configure tls
 	TLS *tlscommon.Config `config:"ssl"`
 
@@ -43,12 +48,22 @@ type config struct {
 	// validate connection
 	SendString    string `config:"check.send"`
 	ReceiveString string `config:"check.receive"`
+
+	// expected fingerprints of the certificate served via TLS
+	Certificate tlsmeta.Pins `config:"check.certificate"`
+
+	// revocation checking of the certificate served via TLS
+	OCSP tlsmeta.OCSP `config:"check.certificate.ocsp"`
+
+	// expected TLS versions and cipher suites
+	TLSCheck tlsmeta.Negotiation `config:"check.tls"`
 }
 
 func defaultConfig() config {
 	return config{
 		Timeout: 16 * time.Second,
 		Mode:    monitors.DefaultIPSettings,
+		OCSP:    tlsmeta.DefaultOCSP(),
 	}
 }
 
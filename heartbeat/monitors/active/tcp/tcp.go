@@ -18,6 +18,7 @@
 package tcp
 
 import (
+	"crypto/tls"
 	"crypto/x509"
 	"net"
 	"net/url"
@@ -99,11 +100,19 @@ func (jf *jobFactory) loadConfig(commonCfg *common.Config) error {
 		return err
 	}
 
-	jf.tlsConfig, err = tlscommon.LoadTLSConfig(jf.config.TLS)
+	jf.tlsConfig, err = tlscommon.LoadTLSConfigWithReload(jf.config.TLS)
 	if err != nil {
 		return err
 	}
 
+	// Nameservers configured for the monitor replace the default resolver
+	if len(jf.config.Mode.Resolver) > 0 {
+		jf.resolver, err = jf.config.Mode.MakeResolver(jf.config.Timeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	jf.defaultScheme = "tcp"
 	if jf.tlsConfig != nil {
 		jf.defaultScheme = "ssl"
@@ -186,7 +195,7 @@ func (jf *jobFactory) makeSocksLookupEndpointJob(endpointURL *url.URL) (jobs.Job
 func (jf *jobFactory) dial(event *beat.Event, dialAddr string, canonicalURL *url.URL) error {
 	// First, create a plain dialer that can connect directly to either hostnames or IPs
 	dc := &dialchain.DialerChain{
-		Net: dialchain.CreateNetDialer(jf.config.Timeout),
+		Net: dialchain.CreateFamilyNetDialer(jf.config.Timeout, jf.config.Mode.IPFamily()),
 	}
 
 	// If Socks5 is configured make that the next layer, since everything needs to go through the proxy first.
@@ -200,6 +209,12 @@ func (jf *jobFactory) dial(event *beat.Event, dialAddr string, canonicalURL *url
 	// hostname we want the server to resolve for us.
 	dc.AddLayer(dialchain.ConstAddrLayer(dialAddr))
 
+	// The PROXY protocol header is sent first on the connection, before the
+	// TLS handshake.
+	if jf.config.ProxyProtocol != nil {
+		dc.AddLayer(dialchain.ProxyProtocolLayer(jf.config.ProxyProtocol))
+	}
+
 	// If we're using TLS we need to add a fake layer so that the TLS layer knows the hostname we're connecting to
 	// So, the canonical URL is fixed via a ConstAddrLayer to override the TLS layer's x509 logic so it doesn't
 	// try and directly match the IP from the prior ConstAddrLayer to the cert.
@@ -234,6 +249,38 @@ func (jf *jobFactory) execDialer(
 		return reason.IOFailed(err)
 	}
 	defer conn.Close()
+
+	if jf.config.Certificate.Enabled() {
+		var certs []*x509.Certificate
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			certs = tlsConn.ConnectionState().PeerCertificates
+		}
+		if err := jf.config.Certificate.Check(certs); err != nil {
+			return reason.MakeValidateError(err)
+		}
+	}
+
+	if jf.config.TLSCheck.Enabled() {
+		var connState *tls.ConnectionState
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			state := tlsConn.ConnectionState()
+			connState = &state
+		}
+		if err := jf.config.TLSCheck.Check(connState); err != nil {
+			return reason.MakeValidateError(err)
+		}
+	}
+
+	if tlsConn, ok := conn.(*tls.Conn); ok && jf.config.OCSP.Enabled {
+		ocspFields, err := jf.config.OCSP.Check(tlsConn.ConnectionState())
+		eventext.MergeEventFields(event, common.MapStr{
+			"tls": common.MapStr{"server": common.MapStr{"ocsp": ocspFields}},
+		})
+		if err != nil {
+			return reason.MakeValidateError(err)
+		}
+	}
+
 	if jf.dataCheck == nil {
 		// no additional validation step => ping success
 		return nil
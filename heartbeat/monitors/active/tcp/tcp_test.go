@@ -131,6 +131,7 @@ func TestConnectionRefusedEndpointJob(t *testing.T) {
 			hbtest.SummaryChecks(0, 1),
 			hbtest.SimpleURLChecks(t, "tcp", ip, port),
 			hbtest.ErrorChecks(dialErr, "io"),
+			hbtest.ConnRefusedErrorCodeChecks(),
 		)),
 		event.Fields,
 	)
@@ -222,6 +223,38 @@ func TestCheckDown(t *testing.T) {
 		)), event.Fields)
 }
 
+func TestCheckProxyProtocol(t *testing.T) {
+	host, port, ip, closeEcho, err := startEchoServer(t)
+	require.NoError(t, err)
+	defer closeEcho()
+
+	// The echo server returns the PROXY protocol header sent before the data.
+	header := fmt.Sprintf("PROXY TCP4 192.0.2.10 %s 4000 %d\r\n", ip, port)
+	if net.ParseIP(ip).To4() == nil {
+		header = fmt.Sprintf("PROXY TCP6 ::ffff:192.0.2.10 %s 4000 %d\r\n", ip, port)
+	}
+	configMap := common.MapStr{
+		"hosts":                      host,
+		"ports":                      port,
+		"timeout":                    "1s",
+		"proxy_protocol.source.ip":   "192.0.2.10",
+		"proxy_protocol.source.port": 4000,
+		"check.send":                 "echo123",
+		"check.receive":              header,
+	}
+
+	event := testTCPConfigCheck(t, configMap, host, port)
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks(ip, "up", "tcp"),
+			hbtest.SummaryChecks(1, 0),
+		),
+		event.Fields,
+	)
+}
+
 func TestNXDomainJob(t *testing.T) {
 	host := "notadomainatallforsure.notadomain.notatldreally"
 	port := uint16(1234)
@@ -18,8 +18,11 @@
 package tcp
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -143,6 +146,103 @@ func TestTLSExpiredCert(t *testing.T) {
 	)
 }
 
+func TestTLSCertificatePins(t *testing.T) {
+	ip, port, cert, certFile, teardown := setupTLSTestServer(t)
+	defer teardown()
+
+	spki := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	pinned := common.MapStr{
+		"check.certificate.spki_sha256": []string{base64.StdEncoding.EncodeToString(spki[:])},
+	}
+	event := testTLSTCPCheckWithConfig(t, ip, port, certFile.Name(), monitors.NewStdResolver(), pinned)
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.TLSChecks(0, 0, cert),
+			hbtest.RespondingTCPChecks(),
+			hbtest.BaseChecks(ip, "up", "tcp"),
+			hbtest.SummaryChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "ssl", ip, port),
+		)),
+		event.Fields,
+	)
+
+	mismatched := common.MapStr{
+		"check.certificate.sha256": []string{fmt.Sprintf("%x", sha256.Sum256([]byte("other")))},
+	}
+	event = testTLSTCPCheckWithConfig(t, ip, port, certFile.Name(), monitors.NewStdResolver(), mismatched)
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks(ip, "down", "tcp"),
+			hbtest.SummaryChecks(0, 1),
+			lookslike.MustCompile(map[string]interface{}{
+				"error.type": "validate",
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestTLSOCSPRequireStaple(t *testing.T) {
+	ip, port, _, certFile, teardown := setupTLSTestServer(t)
+	defer teardown()
+
+	requireStaple := common.MapStr{
+		"check.certificate.ocsp.enabled":        true,
+		"check.certificate.ocsp.require_staple": true,
+	}
+	event := testTLSTCPCheckWithConfig(t, ip, port, certFile.Name(), monitors.NewStdResolver(), requireStaple)
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks(ip, "down", "tcp"),
+			hbtest.SummaryChecks(0, 1),
+			lookslike.MustCompile(map[string]interface{}{
+				"error.type":             "validate",
+				"tls.server.ocsp.status": "missing",
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestTLSNegotiationCheck(t *testing.T) {
+	ip, port, _, certFile, teardown := setupTLSTestServer(t)
+	defer teardown()
+
+	// The test server negotiates TLS 1.3 with Go's default settings
+	matching := common.MapStr{
+		"check.tls.versions":         []string{"TLSv1.3"},
+		"check.tls.excluded_ciphers": []string{"CBC"},
+	}
+	event := testTLSTCPCheckWithConfig(t, ip, port, certFile.Name(), monitors.NewStdResolver(), matching)
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks(ip, "up", "tcp"),
+			hbtest.SummaryChecks(1, 0),
+		),
+		event.Fields,
+	)
+
+	mismatched := common.MapStr{
+		"check.tls.versions": []string{"TLSv1.2"},
+	}
+	event = testTLSTCPCheckWithConfig(t, ip, port, certFile.Name(), monitors.NewStdResolver(), mismatched)
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks(ip, "down", "tcp"),
+			hbtest.SummaryChecks(0, 1),
+			lookslike.MustCompile(map[string]interface{}{
+				"error.type": "validate",
+			}),
+		),
+		event.Fields,
+	)
+}
+
 func setupTLSTestServer(t *testing.T) (ip string, port uint16, cert *x509.Certificate, certFile *os.File, teardown func()) {
 	// Start up a TLS Server
 	server, port, err := setupServer(t, func(handler http.Handler) (*httptest.Server, error) {
@@ -176,6 +276,10 @@ func setupTLSTestServer(t *testing.T) (ip string, port uint16, cert *x509.Certif
 }
 
 func testTLSTCPCheck(t *testing.T, host string, port uint16, certFileName string, resolver monitors.Resolver) *beat.Event {
+	return testTLSTCPCheckWithConfig(t, host, port, certFileName, resolver, nil)
+}
+
+func testTLSTCPCheckWithConfig(t *testing.T, host string, port uint16, certFileName string, resolver monitors.Resolver, extra common.MapStr) *beat.Event {
 	config, err := common.NewConfigFrom(common.MapStr{
 		"hosts":   host,
 		"ports":   int64(port),
@@ -183,6 +287,9 @@ func testTLSTCPCheck(t *testing.T, host string, port uint16, certFileName string
 		"timeout": "1s",
 	})
 	require.NoError(t, err)
+	if extra != nil {
+		require.NoError(t, config.Merge(extra))
+	}
 
 	jobs, endpoints, err := createWithResolver(config, resolver)
 	require.NoError(t, err)
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+)
+
+type config struct {
+	// ws:// or wss:// URLs of the endpoints
+	URLs []string `config:"urls" validate:"required"`
+
+	// headers and subprotocols requested in the handshake
+	Headers      map[string]string `config:"headers"`
+	Subprotocols []string          `config:"subprotocols"`
+
+	endpoint.Config `config:",inline"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// text message sent once connected
+	Send string `config:"send"`
+
+	// the first message received must match one of the patterns and all the
+	// JSON conditions
+	Receive []match.Matcher `config:"receive"`
+	JSON    []*jsonCheck    `config:"json"`
+}
+
+type jsonCheck struct {
+	Description string             `config:"description"`
+	Condition   *conditions.Config `config:"condition" validate:"required"`
+}
+
+func defaultConfig() config {
+	return config{
+		Config: endpoint.DefaultConfig,
+	}
+}
+
+func (c *config) Validate() error {
+	for _, rawURL := range c.URLs {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("invalid url '%v': %v", rawURL, err)
+		}
+		if u.Scheme != "ws" && u.Scheme != "wss" {
+			return fmt.Errorf("invalid url '%v', the scheme must be ws or wss", rawURL)
+		}
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+// waitsMessage returns whether the check waits for the first message.
+func (c *checkConfig) waitsMessage() bool {
+	return len(c.Receive) > 0 || len(c.JSON) > 0
+}
@@ -0,0 +1,243 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/endpoint"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func init() {
+	monitors.RegisterActive("websocket", create)
+	monitors.RegisterActive("synthetics/websocket", create)
+}
+
+var debugf = logp.MakeDebug("websocket")
+
+var errMessageMismatch = errors.New("received message mismatch")
+
+// messageValidator checks the first message received.
+type messageValidator func(data []byte) error
+
+func create(
+	name string,
+	cfg *common.Config,
+) (js []jobs.Job, endpoints int, err error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, 0, err
+	}
+
+	dialer, err := endpoint.NewDialer(&config.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	validate, err := makeValidateMessage(&config.Check)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	header := http.Header{}
+	for k, v := range config.Headers {
+		header.Set(k, v)
+	}
+
+	for _, rawURL := range config.URLs {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, 0, err
+		}
+		js = append(js, wrappers.WithURLField(u, makeCheckJob(&config, dialer, header, u, validate)))
+	}
+	return js, len(js), nil
+}
+
+// makeValidateMessage returns the validator of the first message, nil if the
+// check does not wait for a message.
+func makeValidateMessage(config *checkConfig) (messageValidator, error) {
+	if !config.waitsMessage() {
+		return nil, nil
+	}
+
+	type compiledCheck struct {
+		description string
+		condition   conditions.Condition
+	}
+	var checks []compiledCheck
+	for _, check := range config.JSON {
+		cond, err := conditions.NewCondition(check.Condition)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, compiledCheck{check.Description, cond})
+	}
+
+	return func(data []byte) error {
+		if len(config.Receive) > 0 {
+			matched := false
+			for _, m := range config.Receive {
+				matched = matched || m.Match(data)
+			}
+			if !matched {
+				return errMessageMismatch
+			}
+		}
+		if len(checks) == 0 {
+			return nil
+		}
+
+		decoded := common.MapStr{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			return fmt.Errorf("could not parse the received message as JSON: %v", err)
+		}
+		jsontransform.TransformNumbers(decoded)
+
+		var failed []string
+		for _, check := range checks {
+			if !check.condition.Check(decoded) {
+				failed = append(failed, check.description)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("received JSON message did not match %d conditions '%s'", len(failed), strings.Join(failed, ","))
+		}
+		return nil
+	}, nil
+}
+
+func makeCheckJob(config *config, dialer *endpoint.Dialer, header http.Header, u *url.URL, validate messageValidator) jobs.Job {
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		deadline := time.Now().Add(config.Timeout)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		fields := common.MapStr{}
+		defer eventext.MergeEventFields(event, common.MapStr{"websocket": fields})
+
+		netDialer, err := dialer.Build(event, u.Scheme == "wss")
+		if err != nil {
+			return reason.IOFailed(err)
+		}
+		// The connection is encrypted by the dialer, recording the TLS
+		// metadata, the handshake is then sent on it as on a ws:// URL.
+		wsDialer := &websocket.Dialer{
+			NetDial:          netDialer.Dial,
+			HandshakeTimeout: config.Timeout,
+			Subprotocols:     config.Subprotocols,
+		}
+		handshakeURL := *u
+		handshakeURL.Scheme = "ws"
+
+		start := time.Now()
+		conn, resp, err := wsDialer.DialContext(ctx, handshakeURL.String(), header)
+		if resp != nil {
+			eventext.MergeEventFields(event, common.MapStr{
+				"http": common.MapStr{"response": common.MapStr{"status_code": resp.StatusCode}},
+			})
+		}
+		if err != nil {
+			debugf("handshake with %v failed: %v", u, err)
+			if err == websocket.ErrBadHandshake && resp != nil {
+				return reason.ValidateFailed(fmt.Errorf("handshake failed with status %v", resp.Status))
+			}
+			return reason.IOFailed(err)
+		}
+		defer conn.Close()
+		fields.Put("handshake.rtt", look.RTT(time.Since(start)))
+		if protocol := conn.Subprotocol(); protocol != "" {
+			fields["subprotocol"] = protocol
+		}
+
+		conn.SetWriteDeadline(deadline)
+		conn.SetReadDeadline(deadline)
+		if err := exchange(config, conn, fields, validate); err != nil {
+			return err
+		}
+
+		// Close the connection gracefully, without waiting for the answer
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+		return nil
+	})
+}
+
+// exchange sends the configured message and validates the first message
+// received, recording its latency since the message was sent or the handshake
+// completed.
+func exchange(config *config, conn *websocket.Conn, fields common.MapStr, validate messageValidator) error {
+	start := time.Now()
+	if config.Check.Send != "" {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(config.Check.Send)); err != nil {
+			return reason.IOFailed(err)
+		}
+	}
+	if validate == nil {
+		return nil
+	}
+
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return reason.ValidateFailed(errors.New("no message received before the timeout"))
+		}
+		if closeErr, ok := err.(*websocket.CloseError); ok {
+			return reason.ValidateFailed(fmt.Errorf("connection closed by the server: %v", closeErr))
+		}
+		return reason.IOFailed(err)
+	}
+	fields.Put("message.rtt", look.RTT(time.Since(start)))
+	fields.Put("message.type", messageTypeName(messageType))
+	fields.Put("message.size", len(data))
+
+	if err := validate(data); err != nil {
+		return reason.ValidateFailed(err)
+	}
+	return nil
+}
+
+func messageTypeName(messageType int) string {
+	if messageType == websocket.BinaryMessage {
+		return "binary"
+	}
+	return "text"
+}
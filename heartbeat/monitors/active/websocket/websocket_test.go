@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// serveEcho starts a server answering each message with the given response.
+func serveEcho(t *testing.T, response func(msg []byte) []byte) *httptest.Server {
+	return httptest.NewServer(echoHandler(response))
+}
+
+// echoHandler answers each message with the given response, and rejects the
+// handshakes without the token header.
+func echoHandler(response func(msg []byte) []byte) http.Handler {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"v1.events"}}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if resp := response(msg); resp != nil {
+				conn.WriteMessage(websocket.TextMessage, resp)
+			}
+		}
+	})
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestCheck(t *testing.T) {
+	server := serveEcho(t, func(msg []byte) []byte {
+		if string(msg) == "ping" {
+			return []byte(`{"type": "pong", "lag": 3}`)
+		}
+		return nil
+	})
+	defer server.Close()
+
+	settings := map[string]interface{}{
+		"urls":          wsURL(server),
+		"headers":       map[string]string{"X-Token": "secret"},
+		"subprotocols":  []string{"v1.events"},
+		"timeout":       "1s",
+		"check.send":    "ping",
+		"check.receive": []string{`"pong"`},
+		"check.json": []map[string]interface{}{{
+			"description": "low lag",
+			"condition":   map[string]interface{}{"range": map[string]interface{}{"lag": map[string]interface{}{"lt": 10}}},
+		}},
+	}
+	event, err := hbtest.RunJob(t, create, settings)
+	require.NoError(t, err)
+
+	fields, err := event.Fields.GetValue("websocket")
+	require.NoError(t, err)
+	wsFields := fields.(common.MapStr)
+	assert.Equal(t, "v1.events", wsFields["subprotocol"])
+	assert.Contains(t, wsFields, "handshake")
+	message := wsFields["message"].(common.MapStr)
+	assert.Equal(t, "text", message["type"])
+	assert.Equal(t, 26, message["size"])
+	assert.Contains(t, message, "rtt")
+
+	status, err := event.Fields.GetValue("http.response.status_code")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, status)
+
+	full, err := event.Fields.GetValue("url.full")
+	require.NoError(t, err)
+	assert.Equal(t, wsURL(server), full)
+
+	// The JSON condition fails
+	settings["check.json"] = []map[string]interface{}{{
+		"description": "no lag",
+		"condition":   map[string]interface{}{"equals": map[string]interface{}{"lag": 0}},
+	}}
+	_, err = hbtest.RunJob(t, create, settings)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "no lag")
+
+	// The message does not match
+	delete(settings, "check.json")
+	settings["check.receive"] = []string{"error"}
+	_, err = hbtest.RunJob(t, create, settings)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+
+	// No answer
+	settings["check.send"] = "hello"
+	_, err = hbtest.RunJob(t, create, settings)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	assert.Contains(t, err.Error(), "timeout")
+
+	// The handshake is rejected
+	delete(settings, "headers")
+	event, err = hbtest.RunJob(t, create, settings)
+	require.Error(t, err)
+	assert.Equal(t, "validate", err.(reason.Reason).Type())
+	status, err = event.Fields.GetValue("http.response.status_code")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestCheckUnreachable(t *testing.T) {
+	server := serveEcho(t, func([]byte) []byte { return nil })
+	url := wsURL(server)
+	server.Close()
+
+	_, err := hbtest.RunJob(t, create, map[string]interface{}{"urls": url, "timeout": "1s"})
+	require.Error(t, err)
+	assert.Equal(t, "io", err.(reason.Reason).Type())
+}
+
+func TestCheckTLS(t *testing.T) {
+	server := httptest.NewTLSServer(echoHandler(func([]byte) []byte { return nil }))
+	defer server.Close()
+
+	event, err := hbtest.RunJob(t, create, map[string]interface{}{
+		"urls":                  "wss" + strings.TrimPrefix(server.URL, "https"),
+		"headers":               map[string]string{"X-Token": "secret"},
+		"ssl.verification_mode": "none",
+		"timeout":               "1s",
+	})
+	require.NoError(t, err)
+
+	established, err := event.Fields.GetValue("tls.established")
+	require.NoError(t, err)
+	assert.Equal(t, true, established)
+	status, err := event.Fields.GetValue("http.response.status_code")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, status)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.URLs = []string{"wss://example.net/events"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.URLs = []string{"https://example.net/events"}
+	assert.Error(t, cfg.Validate())
+}
@@ -21,7 +21,19 @@ package defaults
 
 import (
 	// Import packages that need to register themselves.
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/amqp"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/composite"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/dns"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/grpc"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/http"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/icmp"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/kafka"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/ldap"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/mail"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/mqtt"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/redis"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/sql"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/ssh"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/tcp"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/websocket"
 )
@@ -18,7 +18,20 @@
 package monitors
 
 import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
 )
 
 // Resolver lets us define custom DNS resolvers similar to what the go stdlib provides, but
@@ -44,3 +57,252 @@ func (s StdResolver) ResolveIPAddr(network string, host string) (*net.IPAddr, er
 func (s StdResolver) LookupIP(host string) ([]net.IP, error) {
 	return net.LookupIP(host)
 }
+
+// NameserverResolver sends DNS queries directly to the configured nameservers,
+// bypassing the system resolver, its caches, the hosts file and search domains.
+// The nameservers are tried in order, the next one is only queried if a
+// nameserver can not be reached.
+type NameserverResolver struct {
+	nameservers []nameserver
+}
+
+// nameserver sends queries to a single nameserver over UDP, DNS over TLS or
+// DNS over HTTPS.
+type nameserver struct {
+	addr     string
+	exchange func(m *dns.Msg) (*dns.Msg, error)
+}
+
+// NewNameserverResolver creates a resolver querying the given nameservers. A
+// nameserver is either an IP address, queried over UDP on port 53 if it has no
+// port, a tls:// URL queried over DNS over TLS on port 853 if it has no port,
+// or an https:// URL queried over DNS over HTTPS.
+func NewNameserverResolver(nameservers []string, timeout time.Duration) (*NameserverResolver, error) {
+	return newNameserverResolver(nameservers, timeout, nil)
+}
+
+func newNameserverResolver(nameservers []string, timeout time.Duration, tlsConfig *tls.Config) (*NameserverResolver, error) {
+	if len(nameservers) == 0 {
+		return nil, errors.New("no nameservers configured")
+	}
+
+	servers := make([]nameserver, len(nameservers))
+	for i, ns := range nameservers {
+		server, err := newNameserver(ns, timeout, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		servers[i] = server
+	}
+
+	return &NameserverResolver{nameservers: servers}, nil
+}
+
+func newNameserver(ns string, timeout time.Duration, tlsConfig *tls.Config) (nameserver, error) {
+	if !strings.Contains(ns, "://") {
+		addr := ns
+		host, _, err := net.SplitHostPort(ns)
+		if err != nil {
+			host, addr = ns, net.JoinHostPort(ns, "53")
+		}
+		if net.ParseIP(host) == nil {
+			return nameserver{}, fmt.Errorf("nameserver '%v' is not an IP address", ns)
+		}
+		return newDNSNameserver(addr, &dns.Client{Net: "udp", Timeout: timeout}), nil
+	}
+
+	u, err := url.Parse(ns)
+	if err != nil {
+		return nameserver{}, fmt.Errorf("invalid nameserver '%v': %v", ns, err)
+	}
+	if u.Hostname() == "" {
+		return nameserver{}, fmt.Errorf("nameserver '%v' has no host", ns)
+	}
+
+	switch u.Scheme {
+	case "tls":
+		port := u.Port()
+		if port == "" {
+			port = "853"
+		}
+		config := &tls.Config{}
+		if tlsConfig != nil {
+			config = tlsConfig.Clone()
+		}
+		config.ServerName = u.Hostname()
+		client := &dns.Client{Net: "tcp-tls", Timeout: timeout, TLSConfig: config}
+		return newDNSNameserver(net.JoinHostPort(u.Hostname(), port), client), nil
+	case "https":
+		client := &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+		return nameserver{
+			addr:     u.String(),
+			exchange: func(m *dns.Msg) (*dns.Msg, error) { return exchangeHTTPS(client, u.String(), m) },
+		}, nil
+	default:
+		return nameserver{}, fmt.Errorf("nameserver '%v' has unsupported scheme '%v', use tls or https", ns, u.Scheme)
+	}
+}
+
+func newDNSNameserver(addr string, client *dns.Client) nameserver {
+	return nameserver{
+		addr: addr,
+		exchange: func(m *dns.Msg) (*dns.Msg, error) {
+			resp, _, err := client.Exchange(m, addr)
+			return resp, err
+		},
+	}
+}
+
+// exchangeHTTPS sends the query as a DNS over HTTPS POST request (RFC 8484).
+func exchangeHTTPS(client *http.Client, endpoint string, m *dns.Msg) (*dns.Msg, error) {
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dnsMessageContentType)
+	req.Header.Set("Accept", dnsMessageContentType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %v", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, err
+	}
+
+	answer := new(dns.Msg)
+	if err := answer.Unpack(body); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+const dnsMessageContentType = "application/dns-message"
+
+func (r *NameserverResolver) ResolveIPAddr(network string, host string) (*net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return &net.IPAddr{IP: ip}, nil
+	}
+
+	// Like the std library, IPv4 addresses are preferred
+	var qtypes []uint16
+	switch network {
+	case "ip4":
+		qtypes = []uint16{dns.TypeA}
+	case "ip6":
+		qtypes = []uint16{dns.TypeAAAA}
+	case "ip":
+		qtypes = []uint16{dns.TypeA, dns.TypeAAAA}
+	default:
+		return nil, net.UnknownNetworkError(network)
+	}
+
+	for _, qtype := range qtypes {
+		ips, err := r.lookup(host, qtype)
+		if err != nil {
+			return nil, err
+		}
+		if len(ips) > 0 {
+			return &net.IPAddr{IP: ips[0]}, nil
+		}
+	}
+	return nil, &net.DNSError{Err: "no suitable address found", Name: host}
+}
+
+func (r *NameserverResolver) LookupIP(host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	var ips []net.IP
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		found, err := r.lookup(host, qtype)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, found...)
+	}
+	if len(ips) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return ips, nil
+}
+
+// lookup queries the addresses of the given type. An error response from a
+// nameserver, like NXDOMAIN, is returned without querying the next nameserver.
+func (r *NameserverResolver) lookup(host string, qtype uint16) ([]net.IP, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(host), qtype)
+	m.RecursionDesired = true
+
+	resp, server, err := r.Exchange(m)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: host, IsTimeout: isTimeout(err)}
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		name, found := dns.RcodeToString[resp.Rcode]
+		if !found {
+			name = "response code " + strconv.Itoa(resp.Rcode)
+		}
+		return nil, &net.DNSError{
+			Err:        fmt.Sprintf("nameserver %v returned %v", server, name),
+			Name:       host,
+			Server:     server,
+			IsNotFound: resp.Rcode == dns.RcodeNameError,
+		}
+	}
+
+	var ips []net.IP
+	for _, rr := range resp.Answer {
+		switch rr := rr.(type) {
+		case *dns.A:
+			ips = append(ips, rr.A)
+		case *dns.AAAA:
+			ips = append(ips, rr.AAAA)
+		}
+	}
+	return ips, nil
+}
+
+// Exchange sends the query to the nameservers in order, and returns the answer
+// of the first nameserver that can be reached along with its address.
+func (r *NameserverResolver) Exchange(m *dns.Msg) (resp *dns.Msg, server string, err error) {
+	for _, ns := range r.nameservers {
+		resp, err = ns.exchange(m)
+		if err == nil {
+			return resp, ns.addr, nil
+		}
+	}
+	return nil, "", err
+}
+
+// Nameservers returns the addresses of the nameservers, in the order they are
+// queried.
+func (r *NameserverResolver) Nameservers() []string {
+	addrs := make([]string, len(r.nameservers))
+	for i, ns := range r.nameservers {
+		addrs[i] = ns.addr
+	}
+	return addrs
+}
+
+func isTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
@@ -0,0 +1,245 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package monitors
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Resolver = (*NameserverResolver)(nil)
+
+func TestNameserverResolver(t *testing.T) {
+	stop, addr := serveDNS(t, fakeDNSHandler)
+	defer stop()
+
+	// The first nameserver is not reachable, the second one is queried instead
+	unreachable, err := net.ListenPacket("udp4", "localhost:0")
+	require.NoError(t, err)
+	unreachableAddr := unreachable.LocalAddr().String()
+	unreachable.Close()
+
+	resolver, err := NewNameserverResolver([]string{unreachableAddr, addr}, time.Second)
+	require.NoError(t, err)
+
+	ips, err := resolver.LookupIP("dual.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, ipStrings(ips))
+
+	ip, err := resolver.ResolveIPAddr("ip", "dual.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip.String())
+
+	ip, err = resolver.ResolveIPAddr("ip6", "dual.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "fd00::1", ip.String())
+
+	ip, err = resolver.ResolveIPAddr("ip", "v6.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "fd00::2", ip.String())
+
+	_, err = resolver.ResolveIPAddr("ip4", "v6.example.com")
+	assert.Error(t, err)
+
+	_, err = resolver.LookupIP("missing.example.com")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "NXDOMAIN")
+		assert.True(t, err.(*net.DNSError).IsNotFound)
+	}
+
+	ips, err = resolver.LookupIP("192.168.1.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.1"}, ipStrings(ips))
+}
+
+func TestNameserverResolverUnreachable(t *testing.T) {
+	unreachable, err := net.ListenPacket("udp4", "localhost:0")
+	require.NoError(t, err)
+	addr := unreachable.LocalAddr().String()
+	unreachable.Close()
+
+	resolver, err := NewNameserverResolver([]string{addr}, time.Second)
+	require.NoError(t, err)
+
+	_, err = resolver.LookupIP("dual.example.com")
+	assert.Error(t, err)
+}
+
+func TestNameserverResolverHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		msg := new(dns.Msg)
+		if r.Method != "POST" || r.Header.Get("Content-Type") != dnsMessageContentType || err != nil || msg.Unpack(body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fakeDNSHandler(&httpResponseWriter{w}, msg)
+	}))
+	defer server.Close()
+
+	resolver, err := newNameserverResolver([]string{server.URL + "/dns-query"}, time.Second, server.Client().Transport.(*http.Transport).TLSClientConfig)
+	require.NoError(t, err)
+
+	ips, err := resolver.LookupIP("dual.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, ipStrings(ips))
+
+	_, err = resolver.LookupIP("missing.example.com")
+	if assert.Error(t, err) {
+		assert.True(t, err.(*net.DNSError).IsNotFound)
+	}
+
+	// The certificate is not trusted by default
+	resolver, err = NewNameserverResolver([]string{server.URL + "/dns-query"}, time.Second)
+	require.NoError(t, err)
+	_, err = resolver.LookupIP("dual.example.com")
+	assert.Error(t, err)
+}
+
+func TestNameserverResolverTLS(t *testing.T) {
+	// Reuse the certificate of the test HTTPS server, which is valid for 127.0.0.1
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer certServer.Close()
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", certServer.TLS)
+	require.NoError(t, err)
+	server := &dns.Server{Listener: l, Net: "tcp-tls", Handler: dns.HandlerFunc(fakeDNSHandler)}
+	go server.ActivateAndServe()
+	defer server.Shutdown()
+
+	resolver, err := newNameserverResolver([]string{"tls://" + l.Addr().String()}, time.Second, certServer.Client().Transport.(*http.Transport).TLSClientConfig)
+	require.NoError(t, err)
+
+	ips, err := resolver.LookupIP("dual.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, ipStrings(ips))
+}
+
+func TestNewNameserverResolver(t *testing.T) {
+	resolver, err := NewNameserverResolver([]string{
+		"10.0.0.53", "10.0.0.54:5353", "fd00::53", "[fd00::54]:53",
+		"tls://dns.example.com", "tls://10.0.0.55:8853", "https://dns.example.com/dns-query",
+	}, time.Second)
+	require.NoError(t, err)
+	var addrs []string
+	for _, ns := range resolver.nameservers {
+		addrs = append(addrs, ns.addr)
+	}
+	assert.Equal(t, []string{
+		"10.0.0.53:53", "10.0.0.54:5353", "[fd00::53]:53", "[fd00::54]:53",
+		"dns.example.com:853", "10.0.0.55:8853", "https://dns.example.com/dns-query",
+	}, addrs)
+
+	for _, ns := range []string{"dns.example.com", "http://dns.example.com/dns-query", "tls://"} {
+		_, err = NewNameserverResolver([]string{ns}, time.Second)
+		assert.Error(t, err, ns)
+	}
+
+	_, err = NewNameserverResolver(nil, time.Second)
+	assert.Error(t, err)
+}
+
+func TestIPSettingsMakeResolver(t *testing.T) {
+	resolver, err := DefaultIPSettings.MakeResolver(time.Second)
+	require.NoError(t, err)
+	assert.IsType(t, StdResolver{}, resolver)
+
+	settings := DefaultIPSettings
+	settings.Resolver = []string{"10.0.0.53:53"}
+	resolver, err = settings.MakeResolver(time.Second)
+	require.NoError(t, err)
+	assert.IsType(t, &NameserverResolver{}, resolver)
+}
+
+func serveDNS(t *testing.T, h dns.HandlerFunc) (stop func() error, addr string) {
+	l, err := net.ListenPacket("udp4", "localhost:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{PacketConn: l, Handler: h}
+	go server.ActivateAndServe()
+	return server.Shutdown, l.LocalAddr().String()
+}
+
+func fakeDNSHandler(w dns.ResponseWriter, msg *dns.Msg) {
+	records := map[uint16]map[string]string{
+		dns.TypeA: {
+			"dual.example.com.": "dual.example.com. 60 IN A 10.0.0.1",
+		},
+		dns.TypeAAAA: {
+			"dual.example.com.": "dual.example.com. 60 IN AAAA fd00::1",
+			"v6.example.com.":   "v6.example.com. 60 IN AAAA fd00::2",
+		},
+	}
+
+	m := new(dns.Msg)
+	m.SetReply(msg)
+	q := msg.Question[0]
+	switch q.Name {
+	case "dual.example.com.", "v6.example.com.":
+		if record, found := records[q.Qtype][q.Name]; found {
+			rr, _ := dns.NewRR(record)
+			m.Answer = append(m.Answer, rr)
+		}
+	default:
+		m.SetRcode(msg, dns.RcodeNameError)
+	}
+	w.WriteMsg(m)
+}
+
+// httpResponseWriter writes DNS responses as DNS over HTTPS responses.
+type httpResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *httpResponseWriter) WriteMsg(m *dns.Msg) error {
+	packed, err := m.Pack()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(packed)
+	return err
+}
+
+func (w *httpResponseWriter) Write(b []byte) (int, error) {
+	w.Header().Set("Content-Type", dnsMessageContentType)
+	return w.ResponseWriter.Write(b)
+}
+
+func (*httpResponseWriter) LocalAddr() net.Addr  { return nil }
+func (*httpResponseWriter) RemoteAddr() net.Addr { return nil }
+func (*httpResponseWriter) Close() error         { return nil }
+func (*httpResponseWriter) TsigStatus() error    { return nil }
+func (*httpResponseWriter) TsigTimersOnly(bool)  {}
+func (*httpResponseWriter) Hijack()              {}
+
+func ipStrings(ips []net.IP) []string {
+	strs := make([]string, len(ips))
+	for i, ip := range ips {
+		strs[i] = ip.String()
+	}
+	return strs
+}
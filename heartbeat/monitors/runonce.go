@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package monitors
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// RunOnce executes the jobs of the monitor configured by c a single time,
+// including all of their continuations, without scheduling or publishing
+// them. The events are returned in the order they were produced, the monitor's
+// processors are not applied.
+func RunOnce(c *common.Config) ([]beat.Event, error) {
+	return runOnce(c, globalPluginsReg)
+}
+
+func runOnce(c *common.Config, registrar *pluginsReg) ([]beat.Event, error) {
+	stdFields, err := stdfields.ConfigToStdMonitorFields(c)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorPlugin, found := registrar.get(stdFields.Type)
+	if !found {
+		return nil, fmt.Errorf("monitor type %v does not exist, valid types are %v", stdFields.Type, registrar.monitorNames())
+	}
+
+	if stdFields.ID == "" {
+		hash, err := (&Monitor{config: c}).configHash()
+		if err != nil {
+			return nil, err
+		}
+		stdFields.ID = fmt.Sprintf("auto-%s-%#X", stdFields.Type, hash)
+	}
+
+	rawJobs, _, err := monitorPlugin.create(c)
+	if err != nil {
+		return nil, fmt.Errorf("job err %v", err)
+	}
+
+	var events []beat.Event
+	var run func(job jobs.Job)
+	run = func(job jobs.Job) {
+		event := &beat.Event{
+			Fields: common.MapStr{},
+		}
+
+		conts, err := job(event)
+		if err != nil {
+			logp.Err("Job %v failed with: %v", stdFields.ID, err)
+		}
+
+		if event.Fields != nil && !eventext.IsEventCancelled(event) {
+			events = append(events, *event)
+		}
+		for _, cont := range conts {
+			run(cont)
+		}
+	}
+
+	for _, job := range wrappers.WrapCommon(rawJobs, stdFields) {
+		run(job)
+	}
+	return events, nil
+}
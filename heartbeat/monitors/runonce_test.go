@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package monitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/go-lookslike/testslike"
+)
+
+func TestRunOnce(t *testing.T) {
+	for _, id := range []string{"myid", ""} {
+		conf := mockPluginConf(t, id, "@every 1ms", "http://example.net")
+
+		events, err := runOnce(conf, mockPluginsReg())
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		testslike.Test(t, mockEventMonitorValidator(id), events[0].Fields)
+	}
+
+	// The monitor ID is not reserved, the monitor can be run while scheduled
+	conf := mockPluginConf(t, "myid", "@every 1ms", "http://example.net")
+	mon, err := newMonitor(conf, mockPluginsReg(), &MockPipelineConnector{}, nil, false)
+	require.NoError(t, err)
+	defer mon.Stop()
+	_, err = runOnce(conf, mockPluginsReg())
+	assert.NoError(t, err)
+
+	_, err = runOnce(mockBadPluginConf(t, "myid", "@every 1ms"), mockPluginsReg())
+	assert.Error(t, err)
+}
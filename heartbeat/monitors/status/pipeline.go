@@ -0,0 +1,175 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+// Observer is notified of the summary events published by the monitors.
+type Observer interface {
+	// Summary is called with the summary event of every run of a monitor,
+	// before it is published.
+	Summary(id string, event beat.Event)
+
+	// Forget is called with the IDs of the monitors that stopped publishing.
+	Forget(ids []string)
+}
+
+// Pipeline wraps a beat.Pipeline, notifying its subscribers of the events
+// published by the monitors. Heartbeat features following the monitors
+// subscribe to it rather than wrapping the pipeline themselves. Subscriptions
+// must be done before the monitors connect.
+type Pipeline struct {
+	pipeline beat.Pipeline
+
+	mtx       sync.RWMutex
+	observers []Observer
+	handlers  []func(event *beat.Event)
+	ackers    []beat.ACKer
+}
+
+// NewPipeline creates a Pipeline forwarding all clients to the given pipeline.
+func NewPipeline(pipeline beat.Pipeline) *Pipeline {
+	return &Pipeline{pipeline: pipeline}
+}
+
+// Subscribe notifies the observer of the summary events.
+func (p *Pipeline) Subscribe(o Observer) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.observers = append(p.observers, o)
+}
+
+// OnEvent calls the handler with every event published, before the observers
+// are notified. Handlers may modify the event, they are called in the order
+// they were added.
+func (p *Pipeline) OnEvent(handler func(event *beat.Event)) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.handlers = append(p.handlers, handler)
+}
+
+// OnACK notifies the acker of the events published and acknowledged.
+func (p *Pipeline) OnACK(a beat.ACKer) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.ackers = append(p.ackers, a)
+}
+
+// Connect implements beat.Pipeline.
+func (p *Pipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+// ConnectWith implements beat.Pipeline, wrapping the client so that the
+// subscribers are notified of the events it publishes.
+func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	p.mtx.RLock()
+	c := &client{
+		observers: p.observers,
+		handlers:  p.handlers,
+		ids:       map[string]struct{}{},
+	}
+	ackers := p.ackers
+	p.mtx.RUnlock()
+
+	for _, a := range ackers {
+		if cfg.ACKHandler == nil {
+			cfg.ACKHandler = a
+		} else {
+			cfg.ACKHandler = acker.Combine(cfg.ACKHandler, a)
+		}
+	}
+
+	var err error
+	c.Client, err = p.pipeline.ConnectWith(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SummaryID returns the monitor ID of a summary event.
+func SummaryID(event beat.Event) (string, bool) {
+	if has, _ := event.Fields.HasKey("summary"); !has {
+		return "", false
+	}
+	v, _ := event.Fields.GetValue("monitor.id")
+	id, ok := v.(string)
+	return id, ok && id != ""
+}
+
+// client notifies the subscribers of the events published by a monitor.
+type client struct {
+	beat.Client
+	observers []Observer
+	handlers  []func(event *beat.Event)
+
+	mtx sync.Mutex
+	ids map[string]struct{}
+}
+
+func (c *client) Publish(event beat.Event) {
+	c.observe(&event)
+	c.Client.Publish(event)
+}
+
+func (c *client) PublishAll(evs []beat.Event) {
+	for i := range evs {
+		c.observe(&evs[i])
+	}
+	c.Client.PublishAll(evs)
+}
+
+func (c *client) Close() error {
+	c.mtx.Lock()
+	ids := make([]string, 0, len(c.ids))
+	for id := range c.ids {
+		ids = append(ids, id)
+	}
+	c.mtx.Unlock()
+
+	if len(ids) > 0 {
+		for _, o := range c.observers {
+			o.Forget(ids)
+		}
+	}
+	return c.Client.Close()
+}
+
+func (c *client) observe(event *beat.Event) {
+	for _, handler := range c.handlers {
+		handler(event)
+	}
+
+	id, ok := SummaryID(*event)
+	if !ok || len(c.observers) == 0 {
+		return
+	}
+	c.mtx.Lock()
+	c.ids[id] = struct{}{}
+	c.mtx.Unlock()
+
+	for _, o := range c.observers {
+		o.Summary(id, *event)
+	}
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+type observer struct {
+	summaries []string
+	forgotten []string
+}
+
+func (o *observer) Summary(id string, event beat.Event) {
+	st, _ := event.Fields.GetValue("monitor.status")
+	o.summaries = append(o.summaries, id+":"+st.(string))
+}
+
+func (o *observer) Forget(ids []string) {
+	o.forgotten = append(o.forgotten, ids...)
+}
+
+func monitorEvent(id string, summary bool) beat.Event {
+	fields := common.MapStr{
+		"monitor": common.MapStr{"id": id, "status": "up"},
+	}
+	if summary {
+		fields["summary"] = common.MapStr{"up": 1, "down": 0}
+	}
+	return beat.Event{Fields: fields}
+}
+
+func TestPipeline(t *testing.T) {
+	output := &hbtest.MockPipeline{}
+	pipeline := status.NewPipeline(output)
+	o := &observer{}
+	pipeline.Subscribe(o)
+	// Handlers run before the observers are notified
+	pipeline.OnEvent(func(event *beat.Event) {
+		event.Fields.Put("monitor.status", "down")
+	})
+
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+	client.Publish(monitorEvent("foo", false))
+	client.PublishAll([]beat.Event{
+		monitorEvent("foo", true),
+		monitorEvent("bar", true),
+		monitorEvent("", true),
+	})
+	assert.Equal(t, []string{"foo:down", "bar:down"}, o.summaries)
+
+	// All events reach the output, modified by the handlers
+	published := output.Clients()[0].Published()
+	require.Len(t, published, 4)
+	for _, event := range published {
+		st, _ := event.Fields.GetValue("monitor.status")
+		assert.Equal(t, "down", st)
+	}
+
+	require.NoError(t, client.Close())
+	assert.ElementsMatch(t, []string{"foo", "bar"}, o.forgotten)
+}
+
+func TestPipelineACK(t *testing.T) {
+	output := &hbtest.MockPipeline{}
+	pipeline := status.NewPipeline(output)
+	var subscribed, configured int
+	pipeline.OnACK(acker.RawCounting(func(n int) { subscribed += n }))
+
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		ACKHandler: acker.RawCounting(func(n int) { configured += n }),
+	})
+	require.NoError(t, err)
+	client.Publish(monitorEvent("foo", true))
+	output.Clients()[0].ACK(1)
+	assert.Equal(t, 1, subscribed)
+	assert.Equal(t, 1, configured)
+}
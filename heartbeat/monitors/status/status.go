@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package status keeps the latest status of the monitors running in this
+// process, for monitors computing their status from other monitors.
+package status
+
+import (
+	"sync"
+	"time"
+)
+
+// Status is the status of a monitor summary.
+type Status struct {
+	Status string
+	Time   time.Time
+}
+
+// Registry holds the latest status of each monitor ID. It is safe for
+// concurrent use.
+type Registry struct {
+	mtx      sync.RWMutex
+	statuses map[string]Status
+}
+
+// Latest is the registry the summaries of all monitors are recorded into.
+var Latest = NewRegistry()
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{statuses: map[string]Status{}}
+}
+
+// Set records the status of a monitor summary.
+func (r *Registry) Set(id, status string, t time.Time) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.statuses[id] = Status{Status: status, Time: t}
+}
+
+// Get returns the latest status of the monitor, if any was recorded.
+func (r *Registry) Get(id string) (Status, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	s, found := r.statuses[id]
+	return s, found
+}
@@ -29,6 +29,14 @@ import (
 // ErrPluginDisabled is returned when the monitor plugin is marked as disabled.
 var ErrPluginDisabled = errors.New("Monitor not loaded, plugin is disabled")
 
+const (
+	// ExpectUp reports the monitor up if the target is reachable. This is the default.
+	ExpectUp = "up"
+	// ExpectDown inverts the monitor status, the monitor is up if the target is
+	// unreachable and down if it is reachable.
+	ExpectDown = "down"
+)
+
 // StdMonitorFields represents the generic configuration options around a monitor plugin.
 type StdMonitorFields struct {
 	ID          string             `config:"id"`
@@ -38,10 +46,50 @@ type StdMonitorFields struct {
 	Timeout     time.Duration      `config:"timeout"`
 	ServiceName string             `config:"service_name"`
 	Enabled     bool               `config:"enabled"`
+	Expect      string             `config:"expect"`
+
+	// MinUpIPs is the minimum number of IPs that must be up for the check to
+	// be up, when pinging all IPs of a host.
+	MinUpIPs uint `config:"min_up_ips"`
+	// RequireAllIPs requires all IPs of a host to be up for the check to be up.
+	RequireAllIPs bool `config:"require_all_ips"`
+
+	// Retries configures retrying failed checks before reporting them down.
+	Retries Retries `config:"retries"`
+
+	// RTTBaseline configures flagging runs slower than the usual latency.
+	RTTBaseline RTTBaseline `config:"rtt_baseline"`
+}
+
+// Retries configures how often a failed check is attempted within the same run
+// of the monitor, and how long to wait between attempts.
+type Retries struct {
+	// Attempts is the total number of attempts, including the first one.
+	Attempts uint          `config:"attempts" validate:"min=1"`
+	Backoff  time.Duration `config:"backoff" validate:"min=0"`
+}
+
+// RTTBaseline configures the rolling latency baseline of a monitor. Runs whose
+// deviation from the median latency of the last Window runs, in units of the
+// median absolute deviation, reaches Threshold are flagged as anomalies.
+type RTTBaseline struct {
+	Enabled    bool    `config:"enabled"`
+	Window     int     `config:"window" validate:"min=1"`
+	MinSamples int     `config:"min_samples" validate:"min=1"`
+	Threshold  float64 `config:"threshold"`
 }
 
 func ConfigToStdMonitorFields(config *common.Config) (StdMonitorFields, error) {
-	mpi := StdMonitorFields{Enabled: true}
+	mpi := StdMonitorFields{
+		Enabled: true,
+		Expect:  ExpectUp,
+		Retries: Retries{Attempts: 1, Backoff: time.Second},
+		RTTBaseline: RTTBaseline{
+			Window:     100,
+			MinSamples: 10,
+			Threshold:  3.5,
+		},
+	}
 
 	if err := config.Unpack(&mpi); err != nil {
 		return mpi, errors.Wrap(err, "error unpacking monitor plugin config")
@@ -53,3 +101,28 @@ func ConfigToStdMonitorFields(config *common.Config) (StdMonitorFields, error) {
 
 	return mpi, nil
 }
+
+// Validate checks the expected monitor status and the IP requirements.
+func (f *StdMonitorFields) Validate() error {
+	switch f.Expect {
+	case "", ExpectUp, ExpectDown:
+	default:
+		return errors.Errorf("unknown option for `expect`: '%s', please use one of '%s', '%s'", f.Expect, ExpectUp, ExpectDown)
+	}
+
+	if f.MinUpIPs > 0 && f.RequireAllIPs {
+		return errors.New("`min_up_ips` and `require_all_ips` can not be used together")
+	}
+	return nil
+}
+
+// Validate checks the baseline collects enough samples to flag anomalies.
+func (b *RTTBaseline) Validate() error {
+	if b.MinSamples > b.Window {
+		return errors.Errorf("`rtt_baseline.min_samples` (%d) must not be greater than `rtt_baseline.window` (%d)", b.MinSamples, b.Window)
+	}
+	if b.Threshold <= 0 {
+		return errors.Errorf("`rtt_baseline.threshold` must be positive, got %v", b.Threshold)
+	}
+	return nil
+}
@@ -64,8 +64,8 @@ func (e ProcessorsError) Error() string {
 }
 
 func (t *configuredJob) prepareSchedulerJob(job jobs.Job) scheduler.TaskFunc {
-	return func(_ context.Context) []scheduler.TaskFunc {
-		return runPublishJob(job, t.client)
+	return func(ctx context.Context) []scheduler.TaskFunc {
+		return runPublishJob(ctx, job, t.client)
 	}
 }
 
@@ -100,15 +100,21 @@ func (t *configuredJob) Stop() {
 	}
 }
 
-func runPublishJob(job jobs.Job, client beat.Client) []scheduler.TaskFunc {
+func runPublishJob(ctx context.Context, job jobs.Job, client beat.Client) []scheduler.TaskFunc {
 	event := &beat.Event{
 		Fields: common.MapStr{},
 	}
+	eventext.SetDone(event, ctx.Done())
 
 	conts, err := job(event)
 	if err != nil {
 		logp.Err("Job %v failed with: ", err)
 	}
+	eventext.TakeDone(event)
+
+	if until, ok := eventext.TakeRetryAfter(event); ok {
+		scheduler.DelayNextRun(ctx, until)
+	}
 
 	hasContinuations := len(conts) > 0
 
@@ -140,8 +146,8 @@ func runPublishJob(job jobs.Job, client beat.Client) []scheduler.TaskFunc {
 		// Without this only the last continuation will be executed len(conts) times
 		localCont := cont
 
-		contTasks[i] = func(_ context.Context) []scheduler.TaskFunc {
-			return runPublishJob(localCont, client)
+		contTasks[i] = func(ctx context.Context) []scheduler.TaskFunc {
+			return runPublishJob(ctx, localCont, client)
 		}
 	}
 	return contTasks
@@ -20,6 +20,7 @@ package monitors
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/elastic/go-lookslike/validator"
 
@@ -81,6 +82,16 @@ func Test_runPublishJob(t *testing.T) {
 				lookslike.MustCompile(map[string]interface{}{"blah": "blargh"}),
 			},
 		},
+		{
+			"retry after",
+			func(event *beat.Event) (j []jobs.Job, e error) {
+				eventext.SetRetryAfter(event, time.Now().Add(time.Minute))
+				return simpleJob(event)
+			},
+			[]validator.Validator{
+				lookslike.MustCompile(map[string]interface{}{"foo": "bar"}),
+			},
+		},
 		{
 			"cancelled cont",
 			func(event *beat.Event) (j []jobs.Job, e error) {
@@ -96,7 +107,7 @@ func Test_runPublishJob(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			client := &MockBeatClient{}
-			queue := runPublishJob(tc.job, client)
+			queue := runPublishJob(context.Background(), tc.job, client)
 			for {
 				if len(queue) == 0 {
 					break
@@ -113,6 +124,9 @@ func Test_runPublishJob(t *testing.T) {
 			require.Len(t, client.publishes, len(tc.validators))
 			for idx, event := range client.publishes {
 				testslike.Test(t, tc.validators[idx], event.Fields)
+				// The retry after marker is only for the scheduler
+				_, ok := eventext.TakeRetryAfter(&event)
+				require.False(t, ok)
 			}
 		})
 	}
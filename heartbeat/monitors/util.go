@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/eventext"
@@ -29,6 +30,7 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
 )
 
 // IPSettings provides common configuration settings for IP resolution and ping
@@ -37,6 +39,14 @@ type IPSettings struct {
 	IPv4 bool     `config:"ipv4"`
 	IPv6 bool     `config:"ipv6"`
 	Mode PingMode `config:"mode"`
+
+	// Family restricts the address family like the ipv4 and ipv6 settings,
+	// it is named network like the setting of the output transports
+	Family transport.IPFamily `config:"network"`
+
+	// Resolver lists the nameservers used to resolve hosts instead of the
+	// system resolver
+	Resolver []string `config:"resolver"`
 }
 
 // PingMode enumeration for configuring `any` or `all` IPs pinging.
@@ -62,17 +72,41 @@ var emptyTask = MakeSimpleCont(func(*beat.Event) error { return nil })
 // Network determines the Network type used for IP pluginName resolution, based on the
 // provided settings.
 func (s IPSettings) Network() string {
+	ipv4 := s.IPv4 && s.Family != transport.IPFamilyIPv6
+	ipv6 := s.IPv6 && s.Family != transport.IPFamilyIPv4
+
 	switch {
-	case s.IPv4 && !s.IPv6:
+	case ipv4 && !ipv6:
 		return "ip4"
-	case !s.IPv4 && s.IPv6:
+	case !ipv4 && ipv6:
 		return "ip6"
-	case s.IPv4 && s.IPv6:
+	case ipv4 && ipv6:
 		return "ip"
 	}
 	return ""
 }
 
+// IPFamily returns the address family the monitor connects to, for use with
+// the dialers of libbeat/common/transport.
+func (s IPSettings) IPFamily() transport.IPFamily {
+	switch s.Network() {
+	case "ip4":
+		return transport.IPFamilyIPv4
+	case "ip6":
+		return transport.IPFamilyIPv6
+	}
+	return transport.IPFamilyAuto
+}
+
+// MakeResolver returns the resolver for the configured nameservers, or the
+// system resolver if none are configured.
+func (s IPSettings) MakeResolver(timeout time.Duration) (Resolver, error) {
+	if len(s.Resolver) == 0 {
+		return NewStdResolver(), nil
+	}
+	return NewNameserverResolver(s.Resolver, timeout)
+}
+
 // MakeSimpleCont wraps a function that produces an event and error
 // into an executable Job.
 func MakeSimpleCont(f func(*beat.Event) error) jobs.Job {
@@ -109,11 +143,18 @@ func MakeByIPJob(
 		return nil, err
 	}
 
+	return makeByIPAddrJob(addr, pingFactory), nil
+}
+
+func makeByIPAddrJob(
+	addr *net.IPAddr,
+	pingFactory func(ip *net.IPAddr) jobs.Job,
+) jobs.Job {
 	fields := common.MapStr{
 		"monitor": common.MapStr{"ip": addr.String()},
 	}
 
-	return wrappers.WithFields(fields, pingFactory(addr)), nil
+	return wrappers.WithFields(fields, pingFactory(addr))
 }
 
 // MakeByHostJob creates a new Job including host lookup. The pingFactory will be used to
@@ -131,6 +172,14 @@ func MakeByHostJob(
 		return MakeByIPJob(ip, pingFactory)
 	}
 
+	// Literal IPv6 addresses with a zone identifier, like fe80::1%eth0, are
+	// used as is, as the zone is lost when resolving them.
+	if idx := strings.LastIndexByte(host, '%'); idx > 0 {
+		if ip := net.ParseIP(host[:idx]); ip != nil && ip.To4() == nil {
+			return makeByIPAddrJob(&net.IPAddr{IP: ip, Zone: host[idx+1:]}, pingFactory), nil
+		}
+	}
+
 	network := ipSettings.Network()
 	if network == "" {
 		return nil, errors.New("pinging hosts requires ipv4 or ipv6 mode enabled")
@@ -182,7 +231,7 @@ func makeByHostAllIPJob(
 		//         - The net.LookupIP drops ipv6 zone index
 		//
 		resolveStart := time.Now()
-		ips, err := net.LookupIP(host)
+		ips, err := resolver.LookupIP(host)
 		if err != nil {
 			return nil, err
 		}
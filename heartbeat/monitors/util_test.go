@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package monitors
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
+)
+
+func TestMakeByHostJobIPv6Zone(t *testing.T) {
+	var pinged *net.IPAddr
+	pingFactory := MakePingIPFactory(func(event *beat.Event, ip *net.IPAddr) error {
+		pinged = ip
+		return nil
+	})
+
+	job, err := MakeByHostJob("fe80::1%eth0", DefaultIPSettings, NewStdResolver(), pingFactory)
+	require.NoError(t, err)
+
+	events, err := jobs.ExecJobAndConts(t, job)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	if assert.NotNil(t, pinged) {
+		assert.Equal(t, "fe80::1", pinged.IP.String())
+		assert.Equal(t, "eth0", pinged.Zone)
+	}
+	ip, err := events[0].GetValue("monitor.ip")
+	require.NoError(t, err)
+	assert.Equal(t, "fe80::1%eth0", ip)
+}
+
+func TestIPSettingsNetwork(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		network  string
+		expected transport.IPFamily
+	}{
+		{"default", map[string]interface{}{}, "ip", transport.IPFamilyAuto},
+		{"ipv4 disabled", map[string]interface{}{"ipv4": false}, "ip6", transport.IPFamilyIPv6},
+		{"network ipv4", map[string]interface{}{"network": "ipv4"}, "ip4", transport.IPFamilyIPv4},
+		{"network ipv6", map[string]interface{}{"network": "ipv6"}, "ip6", transport.IPFamilyIPv6},
+		{"network auto", map[string]interface{}{"network": "auto", "ipv6": false}, "ip4", transport.IPFamilyIPv4},
+		{"network conflicts", map[string]interface{}{"network": "ipv6", "ipv6": false}, "", transport.IPFamilyAuto},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := DefaultIPSettings
+			require.NoError(t, common.MustNewConfigFrom(test.config).Unpack(&settings))
+			assert.Equal(t, test.network, settings.Network())
+			assert.Equal(t, test.expected, settings.IPFamily())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wrappers
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// madScale scales the median absolute deviation to be comparable to the
+// standard deviation of normally distributed latencies.
+const madScale = 0.6745
+
+// rttBaseline keeps the latencies of the last runs of a monitor, to flag runs
+// that are significantly slower than the median. The median and the median
+// absolute deviation are used as they are not skewed by the outliers we are
+// looking for. Not threadsafe.
+type rttBaseline struct {
+	config  stdfields.RTTBaseline
+	samples []time.Duration
+	next    int
+}
+
+func newRTTBaseline(config stdfields.RTTBaseline) *rttBaseline {
+	return &rttBaseline{config: config}
+}
+
+// observe compares the latency of a run with the baseline of the previous
+// runs and adds it to the baseline. The returned `monitor.rtt` fields are nil
+// while the baseline has fewer samples than required.
+func (b *rttBaseline) observe(rtt time.Duration) common.MapStr {
+	var fields common.MapStr
+	if len(b.samples) > 0 && len(b.samples) >= b.config.MinSamples {
+		median, mad := medianAbsDeviation(b.samples)
+		// Perfectly stable latencies would flag any slower run, allow for 1%
+		if min := median / 100; mad < min {
+			mad = min
+		}
+
+		deviation := 0.0
+		if mad > 0 {
+			deviation = madScale * float64(rtt-median) / float64(mad)
+		}
+		fields = common.MapStr{
+			"baseline":  look.RTT(median),
+			"deviation": math.Round(deviation*100) / 100,
+			"anomaly":   deviation >= b.config.Threshold,
+		}
+	}
+
+	if len(b.samples) < b.config.Window {
+		b.samples = append(b.samples, rtt)
+	} else {
+		b.samples[b.next] = rtt
+		b.next = (b.next + 1) % b.config.Window
+	}
+	return fields
+}
+
+// medianAbsDeviation returns the median of the samples and the median of the
+// absolute deviations from it.
+func medianAbsDeviation(samples []time.Duration) (median, mad time.Duration) {
+	sorted := append([]time.Duration{}, samples...)
+	median = medianOf(sorted)
+
+	for i, sample := range samples {
+		if sample < median {
+			sorted[i] = median - sample
+		} else {
+			sorted[i] = sample - median
+		}
+	}
+	return median, medianOf(sorted)
+}
+
+// medianOf sorts the durations in place and returns their median.
+func medianOf(durations []time.Duration) time.Duration {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
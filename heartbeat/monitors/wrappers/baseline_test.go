@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package wrappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestRTTBaseline(t *testing.T) {
+	baseline := newRTTBaseline(stdfields.RTTBaseline{Window: 5, MinSamples: 3, Threshold: 3.5})
+
+	for _, ms := range []time.Duration{10, 12, 11} {
+		assert.Nil(t, baseline.observe(ms*time.Millisecond))
+	}
+
+	// median 11ms, MAD 1ms
+	fields := baseline.observe(12 * time.Millisecond)
+	require.NotNil(t, fields)
+	assert.Equal(t, false, fields["anomaly"])
+	assert.Equal(t, 0.67, fields["deviation"])
+	assert.Equal(t, 11*time.Millisecond/time.Microsecond, fields["baseline"].(common.MapStr)["us"])
+
+	fields = baseline.observe(50 * time.Millisecond)
+	assert.Equal(t, true, fields["anomaly"])
+
+	// faster runs are not anomalies
+	fields = baseline.observe(time.Millisecond)
+	assert.Equal(t, false, fields["anomaly"])
+	assert.Len(t, baseline.samples, 5)
+}
+
+func TestRTTBaselineStable(t *testing.T) {
+	baseline := newRTTBaseline(stdfields.RTTBaseline{Window: 10, MinSamples: 1, Threshold: 3.5})
+	baseline.observe(100 * time.Millisecond)
+
+	// without deviation in the samples, the MAD is 1% of the median
+	assert.Equal(t, false, baseline.observe(101 * time.Millisecond)["anomaly"])
+	assert.Equal(t, true, baseline.observe(110 * time.Millisecond)["anomaly"])
+}
+
+func TestMedianAbsDeviation(t *testing.T) {
+	samples := []time.Duration{1, 2, 3, 4, 100}
+	median, mad := medianAbsDeviation(samples)
+	assert.Equal(t, time.Duration(3), median)
+	assert.Equal(t, time.Duration(1), mad)
+	assert.Equal(t, []time.Duration{1, 2, 3, 4, 100}, samples)
+
+	median, mad = medianAbsDeviation([]time.Duration{1, 2, 3, 5})
+	assert.Equal(t, time.Duration(2), median)
+	assert.Equal(t, time.Duration(1), mad)
+}
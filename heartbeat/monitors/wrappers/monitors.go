@@ -29,7 +29,9 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/eventext"
 	"github.com/elastic/beats/v7/heartbeat/look"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/reason"
 	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -41,12 +43,13 @@ func WrapCommon(js []jobs.Job, stdMonFields stdfields.StdMonitorFields) []jobs.J
 	return jobs.WrapAllSeparately(
 		jobs.WrapAll(
 			js,
-			addMonitorStatus,
+			addRetries(stdMonFields.Retries, stdMonFields.Expect),
+			addMonitorStatus(stdMonFields.Expect),
 			addMonitorDuration,
 		), func() jobs.JobWrapper {
 			return addMonitorMeta(stdMonFields, len(js) > 1)
 		}, func() jobs.JobWrapper {
-			return makeAddSummary()
+			return makeAddSummary(stdMonFields)
 		})
 }
 
@@ -103,26 +106,110 @@ func timespan(started time.Time, sched *schedule.Schedule, timeout time.Duration
 	}
 }
 
+// errExpectedDown is reported if a monitor expecting its target to be down
+// could reach it.
+var errExpectedDown = reason.ValidateFailed(errors.New("target is reachable, but is expected to be down"))
+
 // addMonitorStatus wraps the given Job's execution such that any error returned
 // by the original Job will be set as a field. The original error will not be
 // passed through as a return value. Errors may still be present but only if there
 // is an actual error wrapping the error.
-func addMonitorStatus(origJob jobs.Job) jobs.Job {
-	return func(event *beat.Event) ([]jobs.Job, error) {
-		cont, err := origJob(event)
-		fields := common.MapStr{
-			"monitor": common.MapStr{
+// If the monitor expects the target to be down the status is inverted, failed
+// checks are reported as up and successful checks as down.
+// Checks failing because the service throttles them are marked by the
+// `monitor.status_detail` field.
+func addMonitorStatus(expect string) jobs.JobWrapper {
+	return func(origJob jobs.Job) jobs.Job {
+		return func(event *beat.Event) ([]jobs.Job, error) {
+			cont, err := origJob(event)
+			if expect == stdfields.ExpectDown {
+				if err != nil {
+					err = nil
+				} else {
+					err = errExpectedDown
+				}
+			}
+
+			monitorFields := common.MapStr{
 				"status": look.Status(err),
-			},
+			}
+			fields := common.MapStr{
+				"monitor": monitorFields,
+			}
+			if err != nil {
+				fields["error"] = look.Reason(err)
+			}
+			if _, ok := err.(reason.ThrottledError); ok {
+				monitorFields["status_detail"] = look.StatusThrottled
+			}
+			eventext.MergeEventFields(event, fields)
+			return cont, nil
 		}
-		if err != nil {
-			fields["error"] = look.Reason(err)
+	}
+}
+
+// addRetries runs a job again while it reports its target down, up to the
+// configured number of attempts, waiting the backoff before each retry. Each
+// attempt starts from the fields the event had before the first one. The
+// attempt the job finished on is recorded for the summary.
+// Throttled checks are not retried, and the wait is interrupted when the
+// monitor is stopped.
+func addRetries(retries stdfields.Retries, expect string) jobs.JobWrapper {
+	return func(job jobs.Job) jobs.Job {
+		if retries.Attempts <= 1 {
+			return job
+		}
+
+		return func(event *beat.Event) ([]jobs.Job, error) {
+			fields, meta := event.Fields.Clone(), event.Meta.Clone()
+			for attempt := 1; ; attempt++ {
+				cont, err := job(event)
+
+				_, throttled := err.(reason.ThrottledError)
+				down := (err != nil) != (expect == stdfields.ExpectDown)
+				if !down || throttled || attempt >= int(retries.Attempts) || eventext.IsEventCancelled(event) {
+					eventext.SetAttempt(event, attempt)
+					return cont, err
+				}
+
+				if !waitBackoff(retries.Backoff, eventext.Done(event)) {
+					// The monitor was stopped, report the last attempt
+					eventext.SetAttempt(event, attempt)
+					return cont, err
+				}
+				event.Fields, event.Meta = fields.Clone(), meta.Clone()
+			}
 		}
-		eventext.MergeEventFields(event, fields)
-		return cont, nil
 	}
 }
 
+// waitBackoff waits for the backoff to elapse, and returns false if done is
+// closed first.
+func waitBackoff(backoff time.Duration, done <-chan struct{}) bool {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// checkUpIPs returns a validation error if fewer IPs are up than required by
+// `min_up_ips` or `require_all_ips`.
+func checkUpIPs(stdMonFields stdfields.StdMonitorFields, up, total int) reason.Reason {
+	required := int(stdMonFields.MinUpIPs)
+	if stdMonFields.RequireAllIPs {
+		required = total
+	}
+	if up >= required {
+		return nil
+	}
+	return reason.ValidateFailed(fmt.Errorf("%d of %d IPs are up, at least %d required", up, total, required))
+}
+
 // addMonitorDuration executes the given Job, checking the duration of its run.
 func addMonitorDuration(job jobs.Job) jobs.Job {
 	return func(event *beat.Event) ([]jobs.Job, error) {
@@ -144,7 +231,9 @@ func addMonitorDuration(job jobs.Job) jobs.Job {
 }
 
 // makeAddSummary summarizes the job, adding the `summary` field to the last event emitted.
-func makeAddSummary() jobs.JobWrapper {
+// The summary lists the IPs that were up and down. If the monitor requires a
+// minimum number of IPs to be up and they are not, the last event is marked as down.
+func makeAddSummary(stdMonFields stdfields.StdMonitorFields) jobs.JobWrapper {
 	// This is a tricky method. The way this works is that we track the state across jobs in the
 	// state struct here.
 	state := struct {
@@ -152,16 +241,30 @@ func makeAddSummary() jobs.JobWrapper {
 		remaining  uint16
 		up         uint16
 		down       uint16
+		ips        []string
+		ipsDown    map[string]bool
+		attempts   uint16
+		retries    uint16
 		checkGroup string
 		generation uint64
+		slowest    time.Duration
 	}{
 		mtx: sync.Mutex{},
 	}
+	var baseline *rttBaseline
+	if stdMonFields.RTTBaseline.Enabled {
+		baseline = newRTTBaseline(stdMonFields.RTTBaseline)
+	}
 	// Note this is not threadsafe, must be called from a mutex
 	resetState := func() {
 		state.remaining = 1
 		state.up = 0
 		state.down = 0
+		state.ips = nil
+		state.ipsDown = map[string]bool{}
+		state.attempts = 0
+		state.retries = 0
+		state.slowest = 0
 		state.generation++
 		u, err := uuid.NewV1()
 		if err != nil {
@@ -177,6 +280,13 @@ func makeAddSummary() jobs.JobWrapper {
 			state.mtx.Lock()
 			defer state.mtx.Unlock()
 
+			if attempt, ok := eventext.TakeAttempt(event); ok {
+				state.retries += uint16(attempt - 1)
+				if uint16(attempt) > state.attempts {
+					state.attempts = uint16(attempt)
+				}
+			}
+
 			// If the event is cancelled we don't record it as being either up or down since
 			// we discard the event anyway.
 			if !eventext.IsEventCancelled(event) {
@@ -184,9 +294,23 @@ func makeAddSummary() jobs.JobWrapper {
 				eventStatus, _ := event.GetValue("monitor.status")
 				if eventStatus == "up" {
 					state.up++
+					// monitor.duration.us holds a time.Duration counting microseconds
+					if us, err := event.GetValue("monitor.duration.us"); err == nil {
+						if us, ok := us.(time.Duration); ok && us*time.Microsecond > state.slowest {
+							state.slowest = us * time.Microsecond
+						}
+					}
 				} else {
 					state.down++
 				}
+
+				if ip, err := event.GetValue("monitor.ip"); err == nil {
+					ipStr := fmt.Sprint(ip)
+					if _, seen := state.ipsDown[ipStr]; !seen {
+						state.ips = append(state.ips, ipStr)
+					}
+					state.ipsDown[ipStr] = state.ipsDown[ipStr] || eventStatus != "up"
+				}
 			}
 
 			// No error check needed here
@@ -199,12 +323,54 @@ func makeAddSummary() jobs.JobWrapper {
 
 			// After last job
 			if state.remaining == 0 {
-				eventext.MergeEventFields(event, common.MapStr{
-					"summary": common.MapStr{
-						"up":   state.up,
-						"down": state.down,
-					},
-				})
+				upIPs, downIPs := []string{}, []string{}
+				for _, ip := range state.ips {
+					if state.ipsDown[ip] {
+						downIPs = append(downIPs, ip)
+					} else {
+						upIPs = append(upIPs, ip)
+					}
+				}
+
+				if err := checkUpIPs(stdMonFields, len(upIPs), len(state.ips)); err != nil {
+					if eventStatus, _ := event.GetValue("monitor.status"); eventStatus == "up" {
+						state.up--
+						state.down++
+						eventext.MergeEventFields(event, common.MapStr{
+							"monitor": common.MapStr{"status": look.Status(err)},
+							"error":   look.Reason(err),
+						})
+					}
+				}
+
+				summary := common.MapStr{
+					"up":   state.up,
+					"down": state.down,
+				}
+				if len(state.ips) > 0 {
+					summary["up_ips"] = upIPs
+					summary["down_ips"] = downIPs
+				}
+				if stdMonFields.Retries.Attempts > 1 {
+					summary["attempts"] = state.attempts
+					summary["retries"] = state.retries
+				}
+				eventext.MergeEventFields(event, common.MapStr{"summary": summary})
+
+				// Record the status for the composite monitors
+				if id, err := event.GetValue("monitor.id"); err == nil {
+					eventStatus, _ := event.GetValue("monitor.status")
+					status.Latest.Set(fmt.Sprint(id), fmt.Sprint(eventStatus), time.Now())
+				}
+
+				// Only runs with all checks up are comparable, failures are often fast
+				if baseline != nil && state.down == 0 && state.up > 0 {
+					if rttFields := baseline.observe(state.slowest); rttFields != nil {
+						eventext.MergeEventFields(event, common.MapStr{
+							"monitor": common.MapStr{"rtt": rttFields},
+						})
+					}
+				}
 				resetState()
 			}
 
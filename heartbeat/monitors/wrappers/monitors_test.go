@@ -30,7 +30,9 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/eventext"
 	"github.com/elastic/beats/v7/heartbeat/hbtestllext"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/reason"
 	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -164,6 +166,241 @@ func TestErrorJob(t *testing.T) {
 			)},
 		nil,
 	})
+
+	// The summary status is recorded for the composite monitors
+	latest, found := status.Latest.Get(testMonFields.ID)
+	require.True(t, found)
+	assert.Equal(t, "down", latest.Status)
+}
+
+func TestThrottledJob(t *testing.T) {
+	throttledJob := func(event *beat.Event) ([]jobs.Job, error) {
+		return nil, reason.Throttled(fmt.Errorf("429 Too Many Requests"), time.Time{})
+	}
+
+	testCommonWrap(t, testDef{
+		"job throttled",
+		testMonFields,
+		[]jobs.Job{throttledJob},
+		[]validator.Validator{
+			lookslike.Compose(
+				lookslike.MustCompile(map[string]interface{}{
+					"error": map[string]interface{}{"message": "429 Too Many Requests", "type": "validate"},
+					"monitor": map[string]interface{}{
+						"duration.us":   isdef.IsDuration,
+						"id":            testMonFields.ID,
+						"name":          testMonFields.Name,
+						"type":          testMonFields.Type,
+						"status":        "down",
+						"status_detail": "throttled",
+						"check_group":   isdef.IsString,
+					},
+				}),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(0, 1),
+			)},
+		nil,
+	})
+}
+
+func TestExpectDown(t *testing.T) {
+	fields := testMonFields
+	fields.Expect = stdfields.ExpectDown
+
+	testCommonWrap(t, testDef{
+		"reachable",
+		fields,
+		[]jobs.Job{makeURLJob(t, "tcp://foo.com:80")},
+		[]validator.Validator{
+			lookslike.Compose(
+				urlValidator(t, "tcp://foo.com:80"),
+				lookslike.MustCompile(map[string]interface{}{
+					"error": map[string]interface{}{
+						"message": "target is reachable, but is expected to be down",
+						"type":    "validate",
+					},
+					"monitor": map[string]interface{}{
+						"duration.us": isdef.IsDuration,
+						"id":          testMonFields.ID,
+						"name":        testMonFields.Name,
+						"type":        testMonFields.Type,
+						"status":      "down",
+						"check_group": isdef.IsString,
+					},
+				}),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(0, 1),
+			)},
+		nil,
+	})
+
+	errorJob := func(event *beat.Event) ([]jobs.Job, error) {
+		return nil, fmt.Errorf("myerror")
+	}
+
+	testCommonWrap(t, testDef{
+		"unreachable",
+		fields,
+		[]jobs.Job{errorJob},
+		[]validator.Validator{
+			lookslike.Compose(
+				lookslike.MustCompile(map[string]interface{}{
+					"monitor": map[string]interface{}{
+						"duration.us": isdef.IsDuration,
+						"id":          testMonFields.ID,
+						"name":        testMonFields.Name,
+						"type":        testMonFields.Type,
+						"status":      "up",
+						"check_group": isdef.IsString,
+					},
+				}),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(1, 0),
+			)},
+		nil,
+	})
+}
+
+func TestRetriedJob(t *testing.T) {
+	fields := testMonFields
+	fields.Retries = stdfields.Retries{Attempts: 3, Backoff: time.Millisecond}
+
+	// flakyJob fails the given number of times before succeeding
+	flakyJob := func(failures int) jobs.Job {
+		attempts := 0
+		return func(event *beat.Event) ([]jobs.Job, error) {
+			attempts++
+			if attempts <= failures {
+				eventext.MergeEventFields(event, common.MapStr{"failed_attempt": attempts})
+				return nil, fmt.Errorf("myerror")
+			}
+			return nil, nil
+		}
+	}
+
+	monitorValidator := func(status string) validator.Validator {
+		return lookslike.MustCompile(map[string]interface{}{
+			"monitor": map[string]interface{}{
+				"duration.us": isdef.IsDuration,
+				"id":          testMonFields.ID,
+				"name":        testMonFields.Name,
+				"type":        testMonFields.Type,
+				"status":      status,
+				"check_group": isdef.IsString,
+			},
+		})
+	}
+
+	retriesValidator := func(attempts, retries int) validator.Validator {
+		return lookslike.MustCompile(map[string]interface{}{
+			"summary": map[string]interface{}{
+				"attempts": uint16(attempts),
+				"retries":  uint16(retries),
+			},
+		})
+	}
+
+	testCommonWrap(t, testDef{
+		"up after retries",
+		fields,
+		[]jobs.Job{flakyJob(2)},
+		[]validator.Validator{
+			lookslike.Compose(
+				monitorValidator("up"),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(1, 0),
+				retriesValidator(3, 2),
+			)},
+		[]validator.Validator{lookslike.MustCompile(map[string]interface{}{})},
+	})
+
+	testCommonWrap(t, testDef{
+		"down after retries",
+		fields,
+		[]jobs.Job{flakyJob(10)},
+		[]validator.Validator{
+			lookslike.Compose(
+				monitorValidator("down"),
+				errorValidator("io", "myerror"),
+				lookslike.MustCompile(map[string]interface{}{"failed_attempt": 3}),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(0, 1),
+				retriesValidator(3, 2),
+			)},
+		nil,
+	})
+
+	throttledJob := func(event *beat.Event) ([]jobs.Job, error) {
+		return nil, reason.Throttled(fmt.Errorf("429 Too Many Requests"), time.Time{})
+	}
+
+	testCommonWrap(t, testDef{
+		"throttled jobs are not retried",
+		fields,
+		[]jobs.Job{throttledJob},
+		[]validator.Validator{
+			lookslike.Compose(
+				monitorValidator("down"),
+				lookslike.MustCompile(map[string]interface{}{"monitor.status_detail": "throttled"}),
+				errorValidator("validate", "429 Too Many Requests"),
+				hbtestllext.MonitorTimespanValidator,
+				summaryValidator(0, 1),
+				retriesValidator(1, 0),
+			)},
+		nil,
+	})
+}
+
+func TestRTTBaselineJob(t *testing.T) {
+	fields := testMonFields
+	fields.RTTBaseline = stdfields.RTTBaseline{Enabled: true, Window: 10, MinSamples: 2, Threshold: 3.5}
+
+	delay := time.Millisecond
+	wrapped := WrapCommon([]jobs.Job{func(event *beat.Event) ([]jobs.Job, error) {
+		time.Sleep(delay)
+		return nil, nil
+	}}, fields)
+
+	for run := 0; run < 3; run++ {
+		if run == 2 {
+			delay = 100 * time.Millisecond
+		}
+
+		results, err := jobs.ExecJobsAndConts(t, wrapped)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+
+		anomaly, err := results[0].Fields.GetValue("monitor.rtt.anomaly")
+		if run < 2 {
+			require.Error(t, err, "no baseline before min_samples runs")
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, true, anomaly)
+
+		deviation, _ := results[0].Fields.GetValue("monitor.rtt.deviation")
+		require.Greater(t, deviation, 3.5)
+	}
+}
+
+func TestRetriesStop(t *testing.T) {
+	calls := 0
+	job := addRetries(stdfields.Retries{Attempts: 3, Backoff: time.Hour}, stdfields.ExpectUp)(
+		func(event *beat.Event) ([]jobs.Job, error) {
+			calls++
+			return nil, fmt.Errorf("myerror")
+		})
+
+	done := make(chan struct{})
+	close(done)
+	event := &beat.Event{Fields: common.MapStr{}}
+	eventext.SetDone(event, done)
+
+	_, err := job(event)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	attempt, _ := eventext.TakeAttempt(event)
+	assert.Equal(t, 1, attempt)
 }
 
 func TestMultiJobNoConts(t *testing.T) {
@@ -321,6 +558,126 @@ func TestMultiJobContsCancelledEvents(t *testing.T) {
 	})
 }
 
+func TestMultiIPSummary(t *testing.T) {
+	// makeAllIPsJob mimics `mode: all`, continuing with one job per IP
+	makeAllIPsJob := func(downIP string, ips ...string) jobs.Job {
+		return func(event *beat.Event) ([]jobs.Job, error) {
+			eventext.CancelEvent(event)
+			var conts []jobs.Job
+			for _, ip := range ips {
+				ip := ip
+				conts = append(conts, func(event *beat.Event) ([]jobs.Job, error) {
+					eventext.MergeEventFields(event, common.MapStr{"monitor": common.MapStr{"ip": ip}})
+					if ip == downIP {
+						return nil, fmt.Errorf("down")
+					}
+					return nil, nil
+				})
+			}
+			return conts, nil
+		}
+	}
+
+	ipValidator := func(ip string, status string) validator.Validator {
+		return lookslike.MustCompile(map[string]interface{}{
+			"monitor": map[string]interface{}{
+				"duration.us": isdef.IsDuration,
+				"id":          testMonFields.ID,
+				"name":        testMonFields.Name,
+				"type":        testMonFields.Type,
+				"ip":          ip,
+				"status":      status,
+				"check_group": isdef.IsString,
+			},
+		})
+	}
+	ipsSummaryValidator := func(up, down int, upIPs, downIPs []string) validator.Validator {
+		return lookslike.MustCompile(map[string]interface{}{
+			"summary": map[string]interface{}{
+				"up":       uint16(up),
+				"down":     uint16(down),
+				"up_ips":   upIPs,
+				"down_ips": downIPs,
+			},
+		})
+	}
+	cancelled := lookslike.MustCompile(map[string]interface{}{
+		"monitor": map[string]interface{}{
+			"duration.us": isdef.IsDuration,
+			"id":          testMonFields.ID,
+			"name":        testMonFields.Name,
+			"type":        testMonFields.Type,
+			"status":      "up",
+			"check_group": isdef.IsString,
+		},
+	})
+
+	testCommonWrap(t, testDef{
+		"breakdown",
+		testMonFields,
+		[]jobs.Job{makeAllIPsJob("10.0.0.2", "10.0.0.1", "10.0.0.2", "10.0.0.3")},
+		[]validator.Validator{
+			lookslike.Compose(cancelled, hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.1", "up"), hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.2", "down"), hbtestllext.MonitorTimespanValidator, errorValidator("io", "down")),
+			lookslike.Compose(
+				ipValidator("10.0.0.3", "up"),
+				hbtestllext.MonitorTimespanValidator,
+				ipsSummaryValidator(2, 1, []string{"10.0.0.1", "10.0.0.3"}, []string{"10.0.0.2"}),
+			),
+		},
+		nil,
+	})
+
+	requireAll := testMonFields
+	requireAll.RequireAllIPs = true
+	testCommonWrap(t, testDef{
+		"require_all_ips",
+		requireAll,
+		[]jobs.Job{makeAllIPsJob("10.0.0.2", "10.0.0.1", "10.0.0.2", "10.0.0.3")},
+		[]validator.Validator{
+			lookslike.Compose(cancelled, hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.1", "up"), hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.2", "down"), hbtestllext.MonitorTimespanValidator, errorValidator("io", "down")),
+			lookslike.Compose(
+				ipValidator("10.0.0.3", "down"),
+				hbtestllext.MonitorTimespanValidator,
+				errorValidator("validate", "2 of 3 IPs are up, at least 3 required"),
+				ipsSummaryValidator(1, 2, []string{"10.0.0.1", "10.0.0.3"}, []string{"10.0.0.2"}),
+			),
+		},
+		nil,
+	})
+
+	minUp := testMonFields
+	minUp.MinUpIPs = 2
+	testCommonWrap(t, testDef{
+		"min_up_ips",
+		minUp,
+		[]jobs.Job{makeAllIPsJob("10.0.0.2", "10.0.0.1", "10.0.0.2", "10.0.0.3")},
+		[]validator.Validator{
+			lookslike.Compose(cancelled, hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.1", "up"), hbtestllext.MonitorTimespanValidator),
+			lookslike.Compose(ipValidator("10.0.0.2", "down"), hbtestllext.MonitorTimespanValidator, errorValidator("io", "down")),
+			lookslike.Compose(
+				ipValidator("10.0.0.3", "up"),
+				hbtestllext.MonitorTimespanValidator,
+				ipsSummaryValidator(2, 1, []string{"10.0.0.1", "10.0.0.3"}, []string{"10.0.0.2"}),
+			),
+		},
+		nil,
+	})
+}
+
+func errorValidator(typ string, message string) validator.Validator {
+	return lookslike.MustCompile(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":    typ,
+			"message": message,
+		},
+	})
+}
+
 func makeURLJob(t *testing.T, u string) jobs.Job {
 	parsed, err := url.Parse(u)
 	require.NoError(t, err)
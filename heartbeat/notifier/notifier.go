@@ -0,0 +1,211 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notifier
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// Publisher tracks the status of the summary events of the monitors. The
+// monitors whose status changed are periodically posted as a digest to the
+// webhooks.
+type Publisher struct {
+	config   config.Notifier
+	host     string
+	webhooks []*webhook
+	log      *logp.Logger
+
+	mtx      sync.Mutex
+	statuses map[string]string
+	changes  map[string]*Change
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Digest is the summary of the status changes of a period, posted to the
+// webhooks and available to their templates.
+type Digest struct {
+	// Host is the name of the host running Heartbeat.
+	Host    string    `json:"host"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Up      int       `json:"up"`
+	Down    int       `json:"down"`
+	Changes []Change  `json:"changes"`
+}
+
+// Change is the latest status change of a monitor during a period.
+type Change struct {
+	ID     string    `json:"id"`
+	Name   string    `json:"name,omitempty"`
+	Type   string    `json:"type,omitempty"`
+	URL    string    `json:"url,omitempty"`
+	From   string    `json:"from,omitempty"`
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+	Error  string    `json:"error,omitempty"`
+	// Flaps is the number of status changes of the period.
+	Flaps int `json:"flaps"`
+}
+
+// New creates a Publisher posting to the configured webhooks. It must be
+// subscribed to the monitors' status.Pipeline.
+func New(config config.Notifier, info beat.Info) (*Publisher, error) {
+	p := &Publisher{
+		config:   config,
+		host:     info.Hostname,
+		log:      logp.NewLogger("notifier"),
+		statuses: map[string]string{},
+		changes:  map[string]*Change{},
+		done:     make(chan struct{}),
+	}
+	for _, wc := range config.Webhooks {
+		wh, err := newWebhook(wc)
+		if err != nil {
+			return nil, err
+		}
+		p.webhooks = append(p.webhooks, wh)
+	}
+	return p, nil
+}
+
+// Start starts posting the digests every period.
+func (p *Publisher) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.config.Period)
+		defer ticker.Stop()
+		start := time.Now()
+		for {
+			select {
+			case <-p.done:
+				p.notify(start, time.Now())
+				return
+			case now := <-ticker.C:
+				p.notify(start, now)
+				start = now
+			}
+		}
+	}()
+}
+
+// Stop posts the pending changes.
+func (p *Publisher) Stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+// Summary implements status.Observer, tracking the status of the monitor.
+// Monitors first seen down are reported, and unknown statuses are ignored.
+func (p *Publisher) Summary(monitorID string, event beat.Event) {
+	status := stringField(event, "monitor.status")
+	if status != "up" && status != "down" {
+		return
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	previous, seen := p.statuses[monitorID]
+	p.statuses[monitorID] = status
+	if previous == status || (!seen && status == "up") {
+		return
+	}
+
+	change, found := p.changes[monitorID]
+	if !found {
+		change = &Change{ID: monitorID, From: previous}
+		p.changes[monitorID] = change
+	}
+	change.Name = stringField(event, "monitor.name")
+	change.Type = stringField(event, "monitor.type")
+	change.URL = stringField(event, "url.full")
+	change.Status = status
+	change.Time = event.Timestamp
+	change.Error = stringField(event, "error.message")
+	change.Flaps++
+}
+
+func stringField(event beat.Event, key string) string {
+	v, _ := event.Fields.GetValue(key)
+	s, _ := v.(string)
+	return s
+}
+
+// digest returns the digest of the changes since the last call. Monitors
+// which changed back to their status at the start of the period are omitted.
+func (p *Publisher) digest(start, end time.Time) Digest {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	d := Digest{Host: p.host, Start: start, End: end}
+	for id, change := range p.changes {
+		delete(p.changes, id)
+		if change.From == change.Status {
+			continue
+		}
+		if change.Status == "up" {
+			d.Up++
+		} else {
+			d.Down++
+		}
+		d.Changes = append(d.Changes, *change)
+	}
+
+	// Down monitors first, then by name
+	sort.Slice(d.Changes, func(i, j int) bool {
+		a, b := d.Changes[i], d.Changes[j]
+		if a.Status != b.Status {
+			return a.Status == "down"
+		}
+		return a.Name+a.ID < b.Name+b.ID
+	})
+	return d
+}
+
+func (p *Publisher) notify(start, end time.Time) {
+	d := p.digest(start, end)
+	if len(d.Changes) == 0 {
+		return
+	}
+
+	p.log.Debugf("posting %d status changes to %d webhooks", len(d.Changes), len(p.webhooks))
+	for _, wh := range p.webhooks {
+		if err := wh.post(d); err != nil {
+			p.log.Errorf("Failed to post the status changes to %v: %v", wh.url, err)
+		}
+	}
+}
+
+// Forget implements status.Observer, dropping the statuses of monitors that
+// are no longer running.
+func (p *Publisher) Forget(ids []string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, id := range ids {
+		delete(p.statuses, id)
+	}
+}
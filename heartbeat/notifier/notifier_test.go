@@ -0,0 +1,268 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// receiver records the bodies posted to it.
+type receiver struct {
+	*httptest.Server
+
+	mtx    sync.Mutex
+	bodies []map[string]interface{}
+	status int
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{status: http.StatusOK}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.bodies = append(r.bodies, body)
+		w.WriteHeader(r.status)
+	}))
+	return r
+}
+
+func (r *receiver) take() []map[string]interface{} {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	bodies := r.bodies
+	r.bodies = nil
+	return bodies
+}
+
+func monitorEvent(id string, status string, summary bool) beat.Event {
+	fields := common.MapStr{
+		"monitor": common.MapStr{"id": id, "name": id + "-name", "type": "http", "status": status},
+		"url":     common.MapStr{"full": "http://" + id},
+	}
+	if status == "down" {
+		fields["error"] = common.MapStr{"message": "connection refused"}
+	}
+	if summary {
+		fields["summary"] = common.MapStr{"up": 1, "down": 0}
+	}
+	return beat.Event{Timestamp: time.Now(), Fields: fields}
+}
+
+func newPublisher(t *testing.T, webhooks ...config.Webhook) (*Publisher, *status.Pipeline) {
+	p, err := New(config.Notifier{Enabled: true, Period: time.Hour, Webhooks: webhooks}, beat.Info{Hostname: "hb-host"})
+	require.NoError(t, err)
+	pipeline := status.NewPipeline(&hbtest.MockPipeline{})
+	pipeline.Subscribe(p)
+	return p, pipeline
+}
+
+func TestDigest(t *testing.T) {
+	p, pipeline := newPublisher(t)
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+
+	client.PublishAll([]beat.Event{
+		monitorEvent("foo", "up", true),
+		monitorEvent("bar", "down", false),
+		monitorEvent("bar", "down", true),
+		monitorEvent("baz", "up", true),
+	})
+
+	// Monitors first seen up are not reported.
+	d := p.digest(time.Now(), time.Now())
+	assert.Equal(t, "hb-host", d.Host)
+	assert.Equal(t, 0, d.Up)
+	assert.Equal(t, 1, d.Down)
+	require.Len(t, d.Changes, 1)
+	assert.Equal(t, Change{
+		ID:     "bar",
+		Name:   "bar-name",
+		Type:   "http",
+		URL:    "http://bar",
+		Status: "down",
+		Time:   d.Changes[0].Time,
+		Error:  "connection refused",
+		Flaps:  1,
+	}, d.Changes[0])
+
+	client.Publish(monitorEvent("foo", "down", true))
+	client.Publish(monitorEvent("foo", "up", true))
+	client.Publish(monitorEvent("bar", "up", true))
+	client.Publish(monitorEvent("baz", "down", true))
+
+	// foo flapped back to up, its change is omitted.
+	d = p.digest(time.Now(), time.Now())
+	assert.Equal(t, 1, d.Up)
+	assert.Equal(t, 1, d.Down)
+	require.Len(t, d.Changes, 2)
+	assert.Equal(t, "baz", d.Changes[0].ID)
+	assert.Equal(t, "bar", d.Changes[1].ID)
+	assert.Equal(t, "down", d.Changes[1].From)
+
+	assert.Len(t, p.digest(time.Now(), time.Now()).Changes, 0)
+
+	// The statuses of closed clients are forgotten.
+	require.NoError(t, client.Close())
+	client, err = pipeline.Connect()
+	require.NoError(t, err)
+	client.Publish(monitorEvent("bar", "up", true))
+	assert.Len(t, p.digest(time.Now(), time.Now()).Changes, 0)
+}
+
+func TestWebhookFormats(t *testing.T) {
+	r := newReceiver(t)
+	defer r.Close()
+
+	d := Digest{
+		Host: "hb-host",
+		Up:   1,
+		Down: 1,
+		Changes: []Change{
+			{ID: "bar", Name: "Bar", URL: "http://bar", Status: "down", Error: "connection refused", Flaps: 1},
+			{ID: "foo", Status: "up", From: "down", Flaps: 3},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config config.Webhook
+		check  func(t *testing.T, bodies []map[string]interface{})
+	}{
+		{
+			"json",
+			config.Webhook{},
+			func(t *testing.T, bodies []map[string]interface{}) {
+				require.Len(t, bodies, 1)
+				assert.Equal(t, "hb-host", bodies[0]["host"])
+				assert.Len(t, bodies[0]["changes"], 2)
+			},
+		},
+		{
+			"slack",
+			config.Webhook{Format: config.WebhookSlack},
+			func(t *testing.T, bodies []map[string]interface{}) {
+				require.Len(t, bodies, 1)
+				assert.Equal(t,
+					"*Heartbeat: 1 monitor down, 1 monitor up on hb-host*\n"+
+						"• Bar is down (http://bar): connection refused\n"+
+						"• foo is up, changed status 3 times",
+					bodies[0]["text"])
+			},
+		},
+		{
+			"teams",
+			config.Webhook{Format: config.WebhookTeams},
+			func(t *testing.T, bodies []map[string]interface{}) {
+				require.Len(t, bodies, 1)
+				assert.Equal(t, "MessageCard", bodies[0]["@type"])
+				assert.Equal(t, "Heartbeat: 1 monitor down, 1 monitor up on hb-host", bodies[0]["title"])
+				assert.Equal(t, "d9534f", bodies[0]["themeColor"])
+			},
+		},
+		{
+			"pagerduty",
+			config.Webhook{Format: config.WebhookPagerDuty, RoutingKey: "key"},
+			func(t *testing.T, bodies []map[string]interface{}) {
+				require.Len(t, bodies, 2)
+				assert.Equal(t, "trigger", bodies[0]["event_action"])
+				assert.Equal(t, "heartbeat-bar", bodies[0]["dedup_key"])
+				assert.Equal(t, "key", bodies[0]["routing_key"])
+				payload := bodies[0]["payload"].(map[string]interface{})
+				assert.Equal(t, "Bar is down", payload["summary"])
+				assert.Equal(t, "http://bar", payload["source"])
+
+				assert.Equal(t, "resolve", bodies[1]["event_action"])
+				assert.Equal(t, "heartbeat-foo", bodies[1]["dedup_key"])
+				assert.Nil(t, bodies[1]["payload"])
+			},
+		},
+		{
+			"template",
+			config.Webhook{Template: `{"count": {{len .Changes}}, "first": {{json (index .Changes 0).Name}}}`},
+			func(t *testing.T, bodies []map[string]interface{}) {
+				require.Len(t, bodies, 1)
+				assert.Equal(t, map[string]interface{}{"count": float64(2), "first": "Bar"}, bodies[0])
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.URL = r.URL
+			wh, err := newWebhook(test.config)
+			require.NoError(t, err)
+			require.NoError(t, wh.post(d))
+			test.check(t, r.take())
+		})
+	}
+}
+
+func TestWebhookErrors(t *testing.T) {
+	r := newReceiver(t)
+	defer r.Close()
+	r.status = http.StatusBadRequest
+
+	wh, err := newWebhook(config.Webhook{URL: r.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	require.NoError(t, err)
+	assert.Error(t, wh.post(Digest{Changes: []Change{{ID: "foo", Status: "down"}}}))
+
+	_, err = newWebhook(config.Webhook{URL: r.URL, Template: "{{.Missing"})
+	assert.Error(t, err)
+
+	wh, err = newWebhook(config.Webhook{URL: r.URL, Template: "{{.Missing}}"})
+	require.NoError(t, err)
+	assert.Error(t, wh.post(Digest{}))
+}
+
+func TestPublisherStop(t *testing.T) {
+	r := newReceiver(t)
+	defer r.Close()
+
+	p, pipeline := newPublisher(t, config.Webhook{URL: r.URL})
+	p.Start()
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+	client.Publish(monitorEvent("foo", "down", true))
+
+	// The pending changes are posted on stop.
+	p.Stop()
+	bodies := r.take()
+	require.Len(t, bodies, 1)
+	assert.Equal(t, float64(1), bodies[0]["down"])
+}
@@ -0,0 +1,260 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// webhook posts the digests to an URL, in a built-in format or rendered from a
+// template.
+type webhook struct {
+	url        string
+	format     string
+	template   *template.Template
+	headers    map[string]string
+	routingKey string
+	client     *http.Client
+}
+
+// templateFuncs are available to the webhook templates, json encodes a value
+// so that names and errors can be embedded in JSON strings.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+func newWebhook(wc config.Webhook) (*webhook, error) {
+	u, err := url.Parse(wc.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook url '%v': %v", wc.URL, err)
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSConfig(wc.TLS)
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.BuildModuleConfig(u.Hostname())
+	}
+	timeout := wc.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	wh := &webhook{
+		url:        wc.URL,
+		format:     wc.Format,
+		headers:    wc.Headers,
+		routingKey: wc.RoutingKey,
+		client:     &http.Client{Transport: transport, Timeout: timeout},
+	}
+	if wh.format == "" {
+		wh.format = config.WebhookJSON
+	}
+	if wc.Template != "" {
+		wh.template, err = template.New("webhook").Funcs(templateFuncs).Option("missingkey=error").Parse(wc.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template: %v", err)
+		}
+	}
+	return wh, nil
+}
+
+// post sends the digest to the webhook. PagerDuty receives an event per
+// change, the other formats a single request.
+func (w *webhook) post(d Digest) error {
+	bodies, err := w.bodies(d)
+	if err != nil {
+		return err
+	}
+	for _, body := range bodies {
+		if err := w.send(body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *webhook) send(body []byte) error {
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+func (w *webhook) bodies(d Digest) ([][]byte, error) {
+	if w.template != nil {
+		var buf bytes.Buffer
+		if err := w.template.Execute(&buf, d); err != nil {
+			return nil, fmt.Errorf("could not render the webhook template: %v", err)
+		}
+		return [][]byte{buf.Bytes()}, nil
+	}
+
+	var payloads []interface{}
+	switch w.format {
+	case config.WebhookSlack:
+		payloads = append(payloads, map[string]interface{}{
+			"text": "*" + title(d) + "*\n" + strings.Join(lines(d, "• "), "\n"),
+		})
+	case config.WebhookTeams:
+		color := "2eb886"
+		if d.Down > 0 {
+			color = "d9534f"
+		}
+		payloads = append(payloads, map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    title(d),
+			"title":      title(d),
+			"themeColor": color,
+			"text":       strings.Join(lines(d, "- "), "\n\n"),
+		})
+	case config.WebhookPagerDuty:
+		for _, change := range d.Changes {
+			payloads = append(payloads, w.pagerDutyEvent(d, change))
+		}
+	default:
+		payloads = append(payloads, d)
+	}
+
+	bodies := make([][]byte, len(payloads))
+	for i, payload := range payloads {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		bodies[i] = data
+	}
+	return bodies, nil
+}
+
+// pagerDutyEvent returns the PagerDuty Events API v2 event of the change,
+// triggering an alert for down monitors and resolving it once they are up.
+func (w *webhook) pagerDutyEvent(d Digest, change Change) map[string]interface{} {
+	event := map[string]interface{}{
+		"routing_key": w.routingKey,
+		"dedup_key":   "heartbeat-" + change.ID,
+	}
+	if change.Status == "up" {
+		event["event_action"] = "resolve"
+		return event
+	}
+
+	source := change.URL
+	if source == "" {
+		source = d.Host
+	}
+	event["event_action"] = "trigger"
+	event["payload"] = map[string]interface{}{
+		"summary":   monitorName(change) + " is down",
+		"source":    source,
+		"severity":  "error",
+		"timestamp": change.Time,
+		"component": monitorName(change),
+		"class":     change.Type,
+		"custom_details": map[string]interface{}{
+			"error": change.Error,
+			"flaps": change.Flaps,
+			"host":  d.Host,
+		},
+	}
+	return event
+}
+
+// title summarizes the changes, like "2 monitors down, 1 monitor up on host-1".
+func title(d Digest) string {
+	var parts []string
+	for _, count := range []struct {
+		n      int
+		status string
+	}{{d.Down, "down"}, {d.Up, "up"}} {
+		switch count.n {
+		case 0:
+		case 1:
+			parts = append(parts, "1 monitor "+count.status)
+		default:
+			parts = append(parts, fmt.Sprintf("%d monitors %v", count.n, count.status))
+		}
+	}
+	t := "Heartbeat: " + strings.Join(parts, ", ")
+	if d.Host != "" {
+		t += " on " + d.Host
+	}
+	return t
+}
+
+// lines describes each change on a line.
+func lines(d Digest, bullet string) []string {
+	ls := make([]string, len(d.Changes))
+	for i, change := range d.Changes {
+		line := fmt.Sprintf("%s%s is %s", bullet, monitorName(change), change.Status)
+		if change.URL != "" {
+			line += " (" + change.URL + ")"
+		}
+		if change.Error != "" {
+			line += ": " + change.Error
+		}
+		if change.Flaps > 1 {
+			line += fmt.Sprintf(", changed status %d times", change.Flaps)
+		}
+		ls[i] = line
+	}
+	return ls
+}
+
+func monitorName(change Change) string {
+	if change.Name != "" {
+		return change.Name
+	}
+	return change.ID
+}
@@ -17,7 +17,11 @@
 
 package reason
 
-import "github.com/elastic/beats/v7/libbeat/common"
+import (
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
 
 type Reason interface {
 	error
@@ -33,6 +37,14 @@ type IOError struct {
 	err error
 }
 
+// ThrottledError is a validation error caused by the service throttling the
+// check, like HTTP 429 responses.
+type ThrottledError struct {
+	err error
+	// RetryAfter is the time the service asked to retry at, if given.
+	RetryAfter time.Time
+}
+
 func ValidateFailed(err error) Reason {
 	if err == nil {
 		return nil
@@ -47,10 +59,22 @@ func IOFailed(err error) Reason {
 	return IOError{err}
 }
 
+// Throttled marks the validation error as caused by throttling.
+func Throttled(err error, retryAfter time.Time) Reason {
+	if err == nil {
+		return nil
+	}
+	return ThrottledError{err, retryAfter}
+}
+
 func (e ValidateError) Error() string { return e.err.Error() }
 func (e ValidateError) Unwrap() error { return e.err }
 func (ValidateError) Type() string    { return "validate" }
 
+func (e ThrottledError) Error() string { return e.err.Error() }
+func (e ThrottledError) Unwrap() error { return e.err }
+func (ThrottledError) Type() string    { return "validate" }
+
 func (e IOError) Error() string { return e.err.Error() }
 func (e IOError) Unwrap() error { return e.err }
 func (IOError) Type() string    { return "io" }
@@ -63,10 +87,14 @@ func FailError(typ string, err error) common.MapStr {
 }
 
 func Fail(r Reason) common.MapStr {
-	return common.MapStr{
+	fields := common.MapStr{
 		"type":    r.Type(),
 		"message": r.Error(),
 	}
+	if socketFields := socketErrorFields(r); socketFields != nil {
+		fields.DeepUpdate(socketFields)
+	}
+	return fields
 }
 
 func FailIO(err error) common.MapStr { return Fail(IOError{err}) }
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reason
+
+import (
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"syscall"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// socketErrnos are the socket errors reported by name in the error.code field.
+var socketErrnos = map[syscall.Errno]string{
+	syscall.ECONNREFUSED: "ECONNREFUSED",
+	syscall.ECONNRESET:   "ECONNRESET",
+	syscall.ECONNABORTED: "ECONNABORTED",
+	syscall.EHOSTUNREACH: "EHOSTUNREACH",
+	syscall.ENETUNREACH:  "ENETUNREACH",
+	syscall.ETIMEDOUT:    "ETIMEDOUT",
+	syscall.EPIPE:        "EPIPE",
+}
+
+// socketErrorFields classifies socket level failures, so network failures can
+// be told apart from application failures. It returns nil for other errors.
+func socketErrorFields(err error) common.MapStr {
+	if alert, ok := tlsAlert(err); ok {
+		return common.MapStr{
+			"code":      "tls_alert",
+			"tls_alert": alert,
+		}
+	}
+
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		if name, found := socketErrnos[errno]; found {
+			return common.MapStr{"code": name}
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return common.MapStr{"code": "timeout"}
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return common.MapStr{"code": "eof"}
+	}
+
+	return nil
+}
+
+// tlsAlert extracts the alert sent by the TLS peer. The TLS package reports
+// received alerts as remote errors wrapping its unexported alert type.
+func tlsAlert(err error) (common.MapStr, bool) {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) || opErr.Op != "remote error" || opErr.Err == nil {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(opErr.Err)
+	if v.Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	return common.MapStr{
+		"code":        v.Uint(),
+		"description": strings.TrimPrefix(opErr.Err.Error(), "tls: "),
+	}, true
+}
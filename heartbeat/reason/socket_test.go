@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package reason
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestSocketErrorFields(t *testing.T) {
+	opErr := func(err error) error {
+		return &net.OpError{Op: "read", Net: "tcp", Err: err}
+	}
+
+	tests := map[string]struct {
+		err      error
+		expected common.MapStr
+	}{
+		"connection reset": {
+			IOFailed(opErr(os.NewSyscallError("read", syscall.ECONNRESET))),
+			common.MapStr{"code": "ECONNRESET"},
+		},
+		"connection refused": {
+			IOFailed(fmt.Errorf("dial failed: %w", opErr(os.NewSyscallError("connect", syscall.ECONNREFUSED)))),
+			common.MapStr{"code": "ECONNREFUSED"},
+		},
+		"timeout": {
+			IOFailed(opErr(timeoutError{})),
+			common.MapStr{"code": "timeout"},
+		},
+		"eof": {
+			IOFailed(io.EOF),
+			common.MapStr{"code": "eof"},
+		},
+		"validation": {
+			ValidateFailed(errors.New("body mismatch")),
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, socketErrorFields(test.err))
+		})
+	}
+}
+
+func TestSocketErrorFieldsTLSAlert(t *testing.T) {
+	// A server without certificates aborts the handshake with an alert, the
+	// alert sent depends on the Go version
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tls.Server(conn, &tls.Config{}).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	if conn != nil {
+		conn.Close()
+	}
+	require.Error(t, err)
+
+	fields := Fail(IOFailed(err))
+	assert.Equal(t, "tls_alert", fields["code"])
+	require.IsType(t, common.MapStr{}, fields["tls_alert"])
+	alert := fields["tls_alert"].(common.MapStr)
+	assert.IsType(t, uint64(0), alert["code"])
+	assert.Contains(t, err.Error(), "tls: "+alert["description"].(string))
+}
@@ -54,6 +54,8 @@ type Scheduler struct {
 	ctx        context.Context
 	cancelCtx  context.CancelFunc
 	stats      schedulerStats
+	// paused reports whether the runs of all jobs are skipped, if set
+	paused func() bool
 }
 
 type schedulerStats struct {
@@ -62,6 +64,7 @@ type schedulerStats struct {
 	waitingTasks       *monitoring.Uint // number of tasks waiting to run, but constrained by scheduler limit
 	jobsPerSecond      *monitoring.Uint // rate of job processing computed over the past hour
 	jobsMissedDeadline *monitoring.Uint // counter for number of jobs that missed start deadline
+	jobsPaused         *monitoring.Uint // counter for number of job runs skipped while paused
 }
 
 // TaskFunc represents a single task in a job. Optionally returns continuation of tasks to
@@ -94,6 +97,7 @@ func NewWithLocation(limit int64, registry *monitoring.Registry, location *time.
 	activeJobsGauge := monitoring.NewUint(registry, "jobs.active")
 	activeTasksGauge := monitoring.NewUint(registry, "tasks.active")
 	waitingTasksGauge := monitoring.NewUint(registry, "tasks.waiting")
+	jobsPausedCounter := monitoring.NewUint(registry, "jobs.paused")
 
 	sched := &Scheduler{
 		limit:     limit,
@@ -110,6 +114,7 @@ func NewWithLocation(limit int64, registry *monitoring.Registry, location *time.
 			activeTasks:        activeTasksGauge,
 			waitingTasks:       waitingTasksGauge,
 			jobsMissedDeadline: jobsMissedDeadlineCounter,
+			jobsPaused:         jobsPausedCounter,
 		},
 	}
 
@@ -168,6 +173,13 @@ func (s *Scheduler) Stop() error {
 	return ErrInvalidTransition
 }
 
+// SetPause makes the scheduler skip the runs of all jobs while paused returns
+// true. Skipped runs are rescheduled as if the job ran. It must be called
+// before jobs are added.
+func (s *Scheduler) SetPause(paused func() bool) {
+	s.paused = paused
+}
+
 // ErrAlreadyStopped is returned when an Add operation is attempted after the scheduler
 // has already stopped.
 var ErrAlreadyStopped = errors.New("attempted to add job to already stopped scheduler")
@@ -180,6 +192,8 @@ func (s *Scheduler) Add(sched Schedule, id string, entrypoint TaskFunc) (removeF
 	}
 
 	jobCtx, jobCtxCancel := context.WithCancel(s.ctx)
+	delay := &nextRunDelay{}
+	jobCtx = context.WithValue(jobCtx, nextRunDelayKey{}, delay)
 
 	// lastRanAt stores the last runAt the task was invoked
 	// The initial value is runAt.Now() because we use it to get the next runAt a job is scheduled to run
@@ -194,10 +208,21 @@ func (s *Scheduler) Add(sched Schedule, id string, entrypoint TaskFunc) (removeF
 			return
 		default:
 		}
-		s.stats.activeJobs.Inc()
-		lastRanAt = s.runRecursiveJob(jobCtx, entrypoint)
-		s.stats.activeJobs.Dec()
-		s.runOnce(sched.Next(lastRanAt), taskFn)
+		if s.paused != nil && s.paused() {
+			debugf("Job '%v' skipped, the scheduler is paused", id)
+			s.stats.jobsPaused.Inc()
+			lastRanAt = time.Now().In(s.location)
+		} else {
+			s.stats.activeJobs.Inc()
+			lastRanAt = s.runRecursiveJob(jobCtx, entrypoint)
+			s.stats.activeJobs.Dec()
+		}
+		nextRunAt := sched.Next(lastRanAt)
+		if until := delay.take(); until.After(nextRunAt) {
+			debugf("Job '%v' next run delayed until %v", id, until)
+			nextRunAt = until
+		}
+		s.runOnce(nextRunAt, taskFn)
 		debugf("Job '%v' returned at %v", id, time.Now())
 	}
 
@@ -217,6 +242,41 @@ func (s *Scheduler) Add(sched Schedule, id string, entrypoint TaskFunc) (removeF
 	}, nil
 }
 
+type nextRunDelayKey struct{}
+
+// nextRunDelay holds the time requested by the tasks of a job run to delay the
+// next run to.
+type nextRunDelay struct {
+	mtx   sync.Mutex
+	until time.Time
+}
+
+// DelayNextRun asks the scheduler not to start the next run of the job, the
+// task executed with ctx belongs to, before the given time. The delay only
+// applies to the next run. Calls with contexts not created by the scheduler are
+// ignored.
+func DelayNextRun(ctx context.Context, until time.Time) {
+	delay, ok := ctx.Value(nextRunDelayKey{}).(*nextRunDelay)
+	if !ok {
+		return
+	}
+
+	delay.mtx.Lock()
+	defer delay.mtx.Unlock()
+	if until.After(delay.until) {
+		delay.until = until
+	}
+}
+
+// take returns the requested delay and resets it.
+func (d *nextRunDelay) take() time.Time {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	until := d.until
+	d.until = time.Time{}
+	return until
+}
+
 func (s *Scheduler) runOnce(runAt time.Time, taskFn timerqueue.TimerTaskFn) {
 	now := time.Now().In(s.location)
 	if runAt.Before(now) {
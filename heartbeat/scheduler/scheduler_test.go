@@ -175,6 +175,65 @@ func TestScheduler_Stop(t *testing.T) {
 	assert.Equal(t, ErrAlreadyStopped, err)
 }
 
+func TestScheduler_DelayNextRun(t *testing.T) {
+	s := New(10, monitoring.NewRegistry())
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	executed := make(chan time.Time, 2)
+	_, err := s.Add(testSchedule{}, "delayed", testTaskTimes(2, func(ctx context.Context) []TaskFunc {
+		executed <- time.Now()
+		cont := func(ctx context.Context) []TaskFunc {
+			// Continuations can delay the next run as well
+			DelayNextRun(ctx, time.Now().Add(200*time.Millisecond))
+			return nil
+		}
+		return []TaskFunc{cont}
+	}))
+	require.NoError(t, err)
+
+	first := <-executed
+	second := <-executed
+	assert.True(t, second.Sub(first) >= 200*time.Millisecond, "next run was not delayed: %v", second.Sub(first))
+}
+
+func TestScheduler_Pause(t *testing.T) {
+	s := New(10, monitoring.NewRegistry())
+	paused := batomic.MakeBool(true)
+	s.SetPause(paused.Load)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	executed := make(chan struct{}, 1)
+	_, err := s.Add(testSchedule{delay: 10 * time.Millisecond}, "paused", testTaskTimes(1, func(_ context.Context) []TaskFunc {
+		executed <- struct{}{}
+		return nil
+	}))
+	require.NoError(t, err)
+
+	// Runs are skipped, but still scheduled, while paused
+	for s.stats.jobsPaused.Get() < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-executed:
+		t.Fatal("job ran while the scheduler was paused")
+	default:
+	}
+
+	paused.Store(false)
+	select {
+	case <-executed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run after the scheduler was resumed")
+	}
+}
+
+func TestDelayNextRunWithoutScheduler(t *testing.T) {
+	// Must not panic for contexts not created by the scheduler
+	DelayNextRun(context.Background(), time.Now())
+}
+
 func TestScheduler_runRecursiveTask(t *testing.T) {
 	cancelledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
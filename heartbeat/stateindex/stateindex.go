@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stateindex
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// Publisher remembers the latest summary event of every monitor. The
+// remembered states are periodically
+// written to a separate index, using the monitor ID as the document ID so that
+// each monitor has exactly one, always current, document.
+type Publisher struct {
+	pipeline beat.Pipeline
+	config   config.StateIndex
+	log      *logp.Logger
+
+	mtx    sync.Mutex
+	states map[string]*monitorState
+
+	client beat.Client
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+type monitorState struct {
+	event beat.Event
+	dirty bool
+}
+
+// New creates a Publisher writing the states to the given pipeline. It must be
+// subscribed to the monitors' status.Pipeline, which must not be the pipeline
+// the states are written to.
+func New(pipeline beat.Pipeline, config config.StateIndex) *Publisher {
+	return &Publisher{
+		pipeline: pipeline,
+		config:   config,
+		log:      logp.NewLogger("stateindex"),
+		states:   map[string]*monitorState{},
+		done:     make(chan struct{}),
+	}
+}
+
+// Start connects to the pipeline and starts writing states every period.
+func (p *Publisher) Start() error {
+	client, err := p.pipeline.Connect()
+	if err != nil {
+		return err
+	}
+	p.client = client
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.config.Period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				p.flush()
+				return
+			case <-ticker.C:
+				p.flush()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop writes any pending states and closes the publisher's client.
+func (p *Publisher) Stop() {
+	close(p.done)
+	p.wg.Wait()
+	p.client.Close()
+}
+
+// Summary implements status.Observer, remembering the event as the latest
+// state of its monitor.
+func (p *Publisher) Summary(monitorID string, event beat.Event) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.states[monitorID] = &monitorState{
+		event: beat.Event{
+			Timestamp: event.Timestamp,
+			Fields:    event.Fields.Clone(),
+		},
+		dirty: true,
+	}
+}
+
+// Forget implements status.Observer, dropping the states of monitors that are
+// no longer running. Their last written documents are kept in the index.
+func (p *Publisher) Forget(ids []string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, id := range ids {
+		delete(p.states, id)
+	}
+}
+
+// pending returns the state documents that changed since the last call.
+func (p *Publisher) pending() []beat.Event {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var evs []beat.Event
+	for id, st := range p.states {
+		if !st.dirty {
+			continue
+		}
+		st.dirty = false
+		evs = append(evs, beat.Event{
+			Timestamp: st.event.Timestamp,
+			Meta: common.MapStr{
+				events.FieldMetaID:       id,
+				events.FieldMetaOpType:   "index",
+				events.FieldMetaRawIndex: p.config.Index,
+			},
+			Fields: st.event.Fields.Clone(),
+		})
+	}
+	return evs
+}
+
+func (p *Publisher) flush() {
+	evs := p.pending()
+	if len(evs) == 0 {
+		return
+	}
+	p.log.Debugf("writing %d monitor states to %s", len(evs), p.config.Index)
+	p.client.PublishAll(evs)
+}
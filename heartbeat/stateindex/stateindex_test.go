@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stateindex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func monitorEvent(id string, status string, summary bool) beat.Event {
+	fields := common.MapStr{
+		"monitor": common.MapStr{"id": id, "status": status},
+	}
+	if summary {
+		fields["summary"] = common.MapStr{"up": 1, "down": 0}
+	}
+	return beat.Event{Timestamp: time.Now(), Fields: fields}
+}
+
+func TestPublisher(t *testing.T) {
+	pipeline := &hbtest.MockPipeline{}
+	p := New(pipeline, config.StateIndex{Enabled: true, Index: "hb-state", Period: time.Hour})
+	require.NoError(t, p.Start())
+	stateClient := pipeline.Clients()[0]
+
+	monitors := status.NewPipeline(pipeline)
+	monitors.Subscribe(p)
+	client, err := monitors.Connect()
+	require.NoError(t, err)
+	monitorClient := pipeline.Clients()[1]
+
+	client.Publish(monitorEvent("foo", "up", false))
+	client.PublishAll([]beat.Event{
+		monitorEvent("foo", "down", true),
+		monitorEvent("bar", "up", true),
+	})
+	client.Publish(monitorEvent("foo", "up", true))
+
+	// All events still reach the wrapped client.
+	assert.Len(t, monitorClient.Take(), 4)
+
+	p.flush()
+	states := stateClient.Take()
+	require.Len(t, states, 2)
+	byID := map[string]beat.Event{}
+	for _, ev := range states {
+		id, err := ev.Meta.GetValue("_id")
+		require.NoError(t, err)
+		byID[id.(string)] = ev
+
+		opType, _ := ev.Meta.GetValue("op_type")
+		assert.Equal(t, "index", opType)
+		index, _ := ev.Meta.GetValue("raw_index")
+		assert.Equal(t, "hb-state", index)
+	}
+	st, _ := byID["foo"].Fields.GetValue("monitor.status")
+	assert.Equal(t, "up", st)
+	st, _ = byID["bar"].Fields.GetValue("monitor.status")
+	assert.Equal(t, "up", st)
+
+	// Unchanged states are not written again.
+	p.flush()
+	assert.Len(t, stateClient.Take(), 0)
+
+	client.Publish(monitorEvent("bar", "down", true))
+	require.NoError(t, client.Close())
+
+	// States of closed clients are dropped without being written.
+	p.Stop()
+	assert.Len(t, stateClient.Take(), 0)
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statuspage
+
+import (
+	"html/template"
+	"io"
+	"time"
+)
+
+var htmlTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"time": func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format("2006-01-02 15:04:05 MST")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Refresh}}">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Page.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 50em; color: #343741; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.5em; border-bottom: 1px solid #d3dae6; }
+.status { font-weight: bold; }
+.up { color: #017d73; }
+.down { color: #bd271e; }
+.degraded { color: #f5a700; }
+.unknown { color: #69707d; }
+</style>
+</head>
+<body>
+<h1>{{.Page.Title}}</h1>
+<p class="status {{.Page.Status}}">{{index .Summaries .Page.Status}}</p>
+<table>
+<tr><th>Service</th><th>Status</th><th>Since</th></tr>
+{{- range .Page.Monitors}}
+<tr><td>{{if .Name}}{{.Name}}{{else}}{{.ID}}{{end}}</td><td class="status {{.Status}}">{{.Status}}</td><td>{{time .Since}}</td></tr>
+{{- end}}
+</table>
+<p>Updated {{time .Updated}}</p>
+</body>
+</html>
+`))
+
+// summaries describe the status of the page.
+var summaries = map[string]string{
+	"up":       "All services are operational",
+	"degraded": "Some services are degraded",
+	"down":     "Some services are down",
+	"unknown":  "The status of some services is unknown",
+}
+
+// renderHTML writes the HTML status page, refreshed by browsers every period.
+func renderHTML(w io.Writer, page Page, period time.Duration) error {
+	refresh := int(period / time.Second)
+	if refresh < 1 {
+		refresh = 1
+	}
+	return htmlTemplate.Execute(w, struct {
+		Page      Page
+		Updated   *time.Time
+		Refresh   int
+		Summaries map[string]string
+	}{page, &page.Updated, refresh, summaries})
+}
@@ -0,0 +1,300 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/file"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/paths"
+)
+
+// Names of the files of the status page.
+const (
+	jsonFile = "status.json"
+	htmlFile = "index.html"
+)
+
+// Publisher tracks the status of the summary events of the monitors. The
+// statuses are periodically
+// rendered into a static status page, which can be served without access to
+// Elasticsearch.
+type Publisher struct {
+	config   config.StatusPage
+	path     string
+	uploader Uploader
+	log      *logp.Logger
+
+	mtx      sync.Mutex
+	monitors map[string]*Monitor
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Page is the document rendered into the status page.
+type Page struct {
+	Title   string    `json:"title"`
+	Updated time.Time `json:"updated"`
+	// Status is the worst status of the monitors.
+	Status   string    `json:"status"`
+	Monitors []Monitor `json:"monitors"`
+}
+
+// Monitor is the latest status of a monitor. The status is unknown, without
+// times, for the configured monitors which have not been checked yet.
+type Monitor struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status"`
+	// Since is the time of the first check with the current status.
+	Since *time.Time `json:"since,omitempty"`
+	// Checked is the time of the latest check.
+	Checked *time.Time `json:"checked,omitempty"`
+}
+
+// Statuses of the status page, from the worst to the best.
+var statusOrder = []string{"down", look.StatusDegraded, "unknown", "up"}
+
+// New creates a Publisher rendering the configured status page. It must be
+// subscribed to the monitors' status.Pipeline.
+func New(config config.StatusPage) (*Publisher, error) {
+	p := &Publisher{
+		config:   config,
+		path:     config.Path,
+		log:      logp.NewLogger("statuspage"),
+		monitors: map[string]*Monitor{},
+		done:     make(chan struct{}),
+	}
+	if config.S3 != nil {
+		u, err := newUploader("s3", config.S3, config.Period)
+		if err != nil {
+			return nil, err
+		}
+		p.uploader = u
+	} else if p.path == "" {
+		p.path = paths.Resolve(paths.Data, "status_page")
+	}
+	return p, nil
+}
+
+// Start creates the directory of the status page and starts rendering it
+// every period.
+func (p *Publisher) Start() error {
+	if p.path != "" {
+		if err := os.MkdirAll(p.path, 0755); err != nil {
+			return err
+		}
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.config.Period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				p.update(time.Now())
+				return
+			case now := <-ticker.C:
+				p.update(now)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop renders the status page a last time.
+func (p *Publisher) Stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+// Summary implements status.Observer, tracking the status of the monitor.
+// Unknown statuses, like the ones of checks whose output is blocked, are
+// ignored.
+func (p *Publisher) Summary(monitorID string, event beat.Event) {
+	status := stringField(event, "monitor.status")
+	if status != "up" && status != "down" {
+		return
+	}
+	if status == "up" && stringField(event, "monitor.status_detail") == look.StatusDegraded {
+		status = look.StatusDegraded
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	m, found := p.monitors[monitorID]
+	if !found {
+		m = &Monitor{ID: monitorID}
+		p.monitors[monitorID] = m
+	}
+	checked := event.Timestamp
+	if m.Status != status {
+		m.Status = status
+		m.Since = &checked
+	}
+	m.Name = stringField(event, "monitor.name")
+	m.Type = stringField(event, "monitor.type")
+	m.Checked = &checked
+}
+
+func stringField(event beat.Event, key string) string {
+	v, _ := event.Fields.GetValue(key)
+	s, _ := v.(string)
+	return s
+}
+
+// Forget implements status.Observer, dropping the statuses of monitors that
+// are no longer running.
+func (p *Publisher) Forget(ids []string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, id := range ids {
+		delete(p.monitors, id)
+	}
+}
+
+// page returns the current status page. The configured monitors are listed in
+// order, or else all monitors by name.
+func (p *Publisher) page(now time.Time) Page {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	page := Page{Title: p.config.Title, Updated: now, Monitors: []Monitor{}}
+	if len(p.config.Monitors) > 0 {
+		for _, id := range p.config.Monitors {
+			if m, found := p.monitors[id]; found {
+				page.Monitors = append(page.Monitors, *m)
+			} else {
+				page.Monitors = append(page.Monitors, Monitor{ID: id, Status: "unknown"})
+			}
+		}
+	} else {
+		for _, m := range p.monitors {
+			page.Monitors = append(page.Monitors, *m)
+		}
+		sort.Slice(page.Monitors, func(i, j int) bool {
+			a, b := page.Monitors[i], page.Monitors[j]
+			return a.Name+a.ID < b.Name+b.ID
+		})
+	}
+
+	page.Status = "unknown"
+	if len(page.Monitors) > 0 {
+		page.Status = "up"
+	}
+	for _, m := range page.Monitors {
+		if worse(m.Status, page.Status) {
+			page.Status = m.Status
+		}
+	}
+	return page
+}
+
+// worse returns whether status a is worse than status b.
+func worse(a, b string) bool {
+	for _, status := range statusOrder {
+		switch status {
+		case b:
+			return false
+		case a:
+			return true
+		}
+	}
+	return false
+}
+
+// update renders the status page, and writes and uploads its files.
+func (p *Publisher) update(now time.Time) {
+	page := p.page(now)
+	jsonData, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		p.log.Errorf("Failed to encode the status page: %v", err)
+		return
+	}
+	var html bytes.Buffer
+	if err := renderHTML(&html, page, p.config.Period); err != nil {
+		p.log.Errorf("Failed to render the status page: %v", err)
+		return
+	}
+
+	files := []struct {
+		name        string
+		contentType string
+		data        []byte
+	}{
+		{jsonFile, "application/json", jsonData},
+		{htmlFile, "text/html; charset=utf-8", html.Bytes()},
+	}
+	p.log.Debugf("writing the status page of %d monitors", len(page.Monitors))
+	for _, f := range files {
+		if p.path != "" {
+			if err := writeFile(filepath.Join(p.path, f.name), f.data); err != nil {
+				p.log.Errorf("Failed to write the status page: %v", err)
+			}
+		}
+		if p.uploader != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), p.config.Period)
+			err := p.uploader.Upload(ctx, f.name, f.contentType, f.data)
+			cancel()
+			if err != nil {
+				p.log.Errorf("Failed to upload the status page: %v", err)
+			}
+		}
+	}
+}
+
+// writeFile replaces the file with a temporary file, so that the file served
+// is never partially written.
+func writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// Temporary files are only readable by their owner.
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = file.SafeFileRotate(path, tmp.Name())
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+type mockUploader struct {
+	files map[string]string
+}
+
+func (u *mockUploader) Upload(_ context.Context, name, contentType string, data []byte) error {
+	u.files[name] = contentType
+	return nil
+}
+
+var t0 = time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func summaryEvent(id, name, status string, t time.Time) beat.Event {
+	return beat.Event{
+		Timestamp: t,
+		Fields: common.MapStr{
+			"monitor": common.MapStr{"id": id, "name": name, "type": "http", "status": status},
+			"summary": common.MapStr{"up": 1, "down": 0},
+		},
+	}
+}
+
+func newTestPublisher(t *testing.T, cfg config.StatusPage) *Publisher {
+	cfg.Period = time.Hour
+	if cfg.Title == "" {
+		cfg.Title = "Status"
+	}
+	p, err := New(cfg)
+	require.NoError(t, err)
+	return p
+}
+
+// connect returns a monitor client whose summary events are tracked by p.
+func connect(t *testing.T, p *Publisher) beat.Client {
+	pipeline := status.NewPipeline(&hbtest.MockPipeline{})
+	pipeline.Subscribe(p)
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+	return client
+}
+
+func TestPage(t *testing.T) {
+	p := newTestPublisher(t, config.StatusPage{Path: "unused"})
+	client := connect(t, p)
+
+	client.Publish(summaryEvent("web", "Website", "up", t0))
+	client.PublishAll([]beat.Event{
+		summaryEvent("api", "API", "down", t0),
+		// Not a summary
+		{Timestamp: t0, Fields: common.MapStr{"monitor": common.MapStr{"id": "db", "status": "down"}}},
+	})
+	client.Publish(summaryEvent("web", "Website", "up", t0.Add(time.Minute)))
+	client.Publish(summaryEvent("api", "API", "up", t0.Add(time.Minute)))
+	// Unknown statuses are ignored
+	client.Publish(summaryEvent("api", "API", "unknown", t0.Add(2*time.Minute)))
+
+	page := p.page(t0.Add(3 * time.Minute))
+	assert.Equal(t, "Status", page.Title)
+	assert.Equal(t, "up", page.Status)
+	require.Len(t, page.Monitors, 2)
+	api, web := page.Monitors[0], page.Monitors[1]
+	assert.Equal(t, "api", api.ID)
+	assert.Equal(t, "up", api.Status)
+	assert.Equal(t, t0.Add(time.Minute), *api.Since)
+	assert.Equal(t, t0.Add(time.Minute), *api.Checked)
+	assert.Equal(t, "web", web.ID)
+	assert.Equal(t, t0, *web.Since)
+	assert.Equal(t, t0.Add(time.Minute), *web.Checked)
+
+	degraded := summaryEvent("web", "Website", "up", t0.Add(2*time.Minute))
+	degraded.Fields.Put("monitor.status_detail", "degraded")
+	client.Publish(degraded)
+	page = p.page(t0.Add(3 * time.Minute))
+	assert.Equal(t, "degraded", page.Status)
+	assert.Equal(t, "degraded", page.Monitors[1].Status)
+
+	client.Publish(summaryEvent("api", "API", "down", t0.Add(3*time.Minute)))
+	assert.Equal(t, "down", p.page(t0.Add(3*time.Minute)).Status)
+
+	// Monitors of closed clients are dropped
+	require.NoError(t, client.Close())
+	page = p.page(t0.Add(3 * time.Minute))
+	assert.Equal(t, "unknown", page.Status)
+	assert.Len(t, page.Monitors, 0)
+}
+
+func TestPageMonitors(t *testing.T) {
+	p := newTestPublisher(t, config.StatusPage{Path: "unused", Monitors: []string{"web", "api"}})
+	client := connect(t, p)
+
+	client.Publish(summaryEvent("api", "API", "up", t0))
+	client.Publish(summaryEvent("internal", "Internal", "down", t0))
+
+	page := p.page(t0)
+	assert.Equal(t, []Monitor{
+		{ID: "web", Status: "unknown"},
+		{ID: "api", Name: "API", Type: "http", Status: "up", Since: &t0, Checked: &t0},
+	}, page.Monitors)
+	assert.Equal(t, "unknown", page.Status)
+}
+
+func TestUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statuspage")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "public")
+	p := newTestPublisher(t, config.StatusPage{Path: path, Title: "Example <Status>"})
+	uploader := &mockUploader{files: map[string]string{}}
+	p.uploader = uploader
+	require.NoError(t, p.Start())
+
+	client := connect(t, p)
+	client.Publish(summaryEvent("web", "Website", "down", t0))
+	p.Stop()
+
+	data, err := ioutil.ReadFile(filepath.Join(path, "status.json"))
+	require.NoError(t, err)
+	var page Page
+	require.NoError(t, json.Unmarshal(data, &page))
+	assert.Equal(t, "Example <Status>", page.Title)
+	assert.Equal(t, "down", page.Status)
+	require.Len(t, page.Monitors, 1)
+	assert.Equal(t, "Website", page.Monitors[0].Name)
+
+	data, err = ioutil.ReadFile(filepath.Join(path, "index.html"))
+	require.NoError(t, err)
+	html := string(data)
+	assert.Contains(t, html, "<title>Example &lt;Status&gt;</title>")
+	assert.Contains(t, html, `<meta http-equiv="refresh" content="3600">`)
+	assert.Contains(t, html, "Some services are down")
+	assert.Contains(t, html, `<td>Website</td><td class="status down">down</td><td>2020-09-01 12:00:00 UTC</td>`)
+
+	// No temporary files are left
+	files, err := ioutil.ReadDir(path)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.ElementsMatch(t, []string{"index.html", "status.json"}, names)
+
+	assert.Equal(t, map[string]string{
+		"status.json": "application/json",
+		"index.html":  "text/html; charset=utf-8",
+	}, uploader.files)
+}
+
+func TestDefaultPath(t *testing.T) {
+	p := newTestPublisher(t, config.StatusPage{})
+	assert.True(t, strings.HasSuffix(p.path, "status_page"), p.path)
+}
+
+func TestUploader(t *testing.T) {
+	conf := config.StatusPage{
+		Period: time.Minute,
+		S3:     common.MustNewConfigFrom(common.MapStr{"bucket": "status"}),
+	}
+
+	// the s3 uploader is registered by x-pack
+	_, err := New(conf)
+	assert.Error(t, err)
+
+	uploader := &mockUploader{files: map[string]string{}}
+	RegisterUploader("s3", func(cfg *common.Config, period time.Duration) (Uploader, error) {
+		assert.True(t, cfg.HasField("bucket"))
+		assert.Equal(t, time.Minute, period)
+		return uploader, nil
+	})
+	defer delete(uploaders, "s3")
+
+	p, err := New(conf)
+	require.NoError(t, err)
+	assert.Equal(t, uploader, p.uploader)
+	assert.Empty(t, p.path)
+}
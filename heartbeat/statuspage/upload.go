@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statuspage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Uploader stores the files of the status page.
+type Uploader interface {
+	Upload(ctx context.Context, name, contentType string, data []byte) error
+}
+
+// UploaderFactory creates an Uploader from its configuration. The files
+// uploaded are rendered again every period.
+type UploaderFactory func(config *common.Config, period time.Duration) (Uploader, error)
+
+var uploaders = map[string]UploaderFactory{}
+
+// RegisterUploader registers the factory of the uploaders configured by the
+// name setting of the status page, like s3. It must be called from init.
+func RegisterUploader(name string, factory UploaderFactory) {
+	if _, found := uploaders[name]; found {
+		panic(fmt.Sprintf("status page uploader '%v' is already registered", name))
+	}
+	uploaders[name] = factory
+}
+
+func newUploader(name string, config *common.Config, period time.Duration) (Uploader, error) {
+	factory, found := uploaders[name]
+	if !found {
+		return nil, fmt.Errorf("status_page.%v is not supported by this distribution of Heartbeat", name)
+	}
+	return factory(config, period)
+}
@@ -0,0 +1,257 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// Span kinds and status codes of the OTLP protocol.
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+
+	statusCodeOK    = 1
+	statusCodeError = 2
+)
+
+// The types below encode the OTLP trace export requests in the JSON encoding
+// of OTLP/HTTP, where the IDs are hex strings and 64 bit integers strings.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano int64      `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   int64      `json:"endTimeUnixNano,string"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            spanStatus `json:"status"`
+}
+
+type spanStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+// attribute returns the attribute of a field value, strings and integers are
+// supported.
+func attribute(key string, v interface{}) (keyValue, bool) {
+	switch v := v.(type) {
+	case string:
+		return keyValue{Key: key, Value: map[string]string{"stringValue": v}}, true
+	case fmt.Stringer:
+		return keyValue{Key: key, Value: map[string]string{"stringValue": v.String()}}, true
+	case int:
+		return keyValue{Key: key, Value: map[string]string{"intValue": strconv.Itoa(v)}}, true
+	case int64:
+		return keyValue{Key: key, Value: map[string]string{"intValue": strconv.FormatInt(v, 10)}}, true
+	}
+	return keyValue{}, false
+}
+
+// exporter sends the spans to the OTLP/HTTP endpoint in batches. Spans are
+// dropped if the queue is full, the checks never wait for the endpoint.
+type exporter struct {
+	url           string
+	headers       map[string]string
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+	resource      resource
+	scope         scope
+	log           *logp.Logger
+
+	queue chan span
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func newExporter(config config.Tracing, info beat.Info, log *logp.Logger) (*exporter, error) {
+	u, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint '%v': %v", config.Endpoint, err)
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.BuildModuleConfig(u.Hostname())
+	}
+
+	attrs := []keyValue{{Key: "service.name", Value: map[string]string{"stringValue": config.ServiceName}}}
+	if info.Hostname != "" {
+		attrs = append(attrs, keyValue{Key: "host.name", Value: map[string]string{"stringValue": info.Hostname}})
+	}
+	return &exporter{
+		url:           config.Endpoint,
+		headers:       config.Headers,
+		client:        &http.Client{Transport: transport, Timeout: config.Timeout},
+		batchSize:     config.BatchSize,
+		flushInterval: config.FlushInterval,
+		resource:      resource{Attributes: attrs},
+		scope:         scope{Name: "heartbeat", Version: info.Version},
+		log:           log,
+		queue:         make(chan span, config.QueueSize),
+		done:          make(chan struct{}),
+	}, nil
+}
+
+// enqueue adds the spans to the next batch.
+func (e *exporter) enqueue(spans ...span) {
+	for _, s := range spans {
+		select {
+		case e.queue <- s:
+		default:
+			e.log.Debugf("tracing queue full, dropping span %v of trace %v", s.Name, s.TraceID)
+		}
+	}
+}
+
+// start sends a batch when it is full, and the pending spans every flush
+// interval.
+func (e *exporter) start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.flushInterval)
+		defer ticker.Stop()
+		batch := make([]span, 0, e.batchSize)
+		for {
+			select {
+			case <-e.done:
+				// Send what was queued before stopping
+				for {
+					select {
+					case s := <-e.queue:
+						batch = append(batch, s)
+						if len(batch) == e.batchSize {
+							batch = e.flush(batch)
+						}
+					default:
+						e.flush(batch)
+						return
+					}
+				}
+			case s := <-e.queue:
+				batch = append(batch, s)
+				if len(batch) == e.batchSize {
+					batch = e.flush(batch)
+				}
+			case <-ticker.C:
+				batch = e.flush(batch)
+			}
+		}
+	}()
+}
+
+// stop sends the pending spans.
+func (e *exporter) stop() {
+	close(e.done)
+	e.wg.Wait()
+}
+
+// flush sends the batch and returns it emptied. Failed batches are dropped,
+// the traces of the checks are only useful while they are recent.
+func (e *exporter) flush(batch []span) []span {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := e.send(batch); err != nil {
+		e.log.Errorf("Failed to export %d spans to %v: %v", len(batch), e.url, err)
+	}
+	return batch[:0]
+}
+
+func (e *exporter) send(spans []span) error {
+	body, err := json.Marshal(exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   e.resource,
+		ScopeSpans: []scopeSpans{{Scope: e.scope, Spans: spans}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
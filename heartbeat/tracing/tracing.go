@@ -0,0 +1,186 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tracing exports the checks of the monitors as OpenTelemetry traces,
+// for the synthetic checks to show up in distributed tracing tools next to the
+// services they call.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// phases of a check, in the order they run. Each phase is recorded as a span
+// if the event has one of its round trip time fields.
+var phases = []struct {
+	name   string
+	fields []string
+}{
+	{"resolve", []string{"resolve.rtt.us"}},
+	{"connect", []string{"tcp.rtt.connect.us"}},
+	{"tls", []string{"tls.rtt.handshake.us"}},
+	{"request", []string{"http.rtt.response_header.us", "icmp.rtt.us"}},
+	{"validate", []string{"http.rtt.content.us", "tcp.rtt.validate.us"}},
+}
+
+// attributes of the check span, copied from the event fields.
+var attributes = []string{
+	"monitor.id",
+	"monitor.name",
+	"monitor.type",
+	"monitor.status",
+	"monitor.check_group",
+	"monitor.ip",
+	"url.full",
+	"http.response.status_code",
+	"error.type",
+	"error.message",
+}
+
+// Publisher exports every check published by the monitors as a span. The checks of a monitor run share a trace, whose ID is the check
+// group, and the phases of each check are child spans of its span.
+type Publisher struct {
+	exporter *exporter
+	log      *logp.Logger
+}
+
+// New creates a Publisher exporting to the configured endpoint. Its Export
+// method must be added as an event handler of the monitors' status.Pipeline.
+func New(config config.Tracing, info beat.Info) (*Publisher, error) {
+	log := logp.NewLogger("tracing")
+	exporter, err := newExporter(config, info, log)
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{exporter: exporter, log: log}, nil
+}
+
+// Start starts exporting the spans.
+func (p *Publisher) Start() {
+	p.exporter.start()
+}
+
+// Stop exports the pending spans.
+func (p *Publisher) Stop() {
+	p.exporter.stop()
+}
+
+// Export queues the spans of the event, if it reports a check.
+func (p *Publisher) Export(event *beat.Event) {
+	p.exporter.enqueue(spans(*event)...)
+}
+
+// spans returns the span of the check reported by the event, followed by the
+// spans of its phases. Events without a check group or duration are not
+// checks and have no spans.
+func spans(event beat.Event) []span {
+	traceID := strings.Replace(stringField(event, "monitor.check_group"), "-", "", -1)
+	if id, err := hex.DecodeString(traceID); err != nil || len(id) != 16 {
+		return nil
+	}
+	duration, ok := durationField(event, "monitor.duration.us")
+	if !ok {
+		return nil
+	}
+
+	start, end := event.Timestamp, event.Timestamp.Add(duration)
+	check := span{
+		TraceID:           traceID,
+		SpanID:            newSpanID(),
+		Name:              stringField(event, "monitor.type") + " check",
+		Kind:              spanKindClient,
+		StartTimeUnixNano: start.UnixNano(),
+		EndTimeUnixNano:   end.UnixNano(),
+		Status:            spanStatus{Code: statusCodeOK},
+	}
+	for _, key := range attributes {
+		if v, err := event.Fields.GetValue(key); err == nil {
+			if attr, ok := attribute(key, v); ok {
+				check.Attributes = append(check.Attributes, attr)
+			}
+		}
+	}
+	if stringField(event, "monitor.status") == "down" {
+		check.Status = spanStatus{Code: statusCodeError, Message: stringField(event, "error.message")}
+	}
+
+	result := []span{check}
+	// The phases are laid out one after the other from the start of the check,
+	// the events only record their durations.
+	phaseStart := start
+	for _, phase := range phases {
+		for _, key := range phase.fields {
+			d, ok := durationField(event, key)
+			if !ok {
+				continue
+			}
+			phaseEnd := phaseStart.Add(d)
+			if phaseEnd.After(end) {
+				phaseEnd = end
+			}
+			result = append(result, span{
+				TraceID:           traceID,
+				SpanID:            newSpanID(),
+				ParentSpanID:      check.SpanID,
+				Name:              phase.name,
+				Kind:              spanKindInternal,
+				StartTimeUnixNano: phaseStart.UnixNano(),
+				EndTimeUnixNano:   phaseEnd.UnixNano(),
+			})
+			phaseStart = phaseEnd
+			break
+		}
+	}
+	return result
+}
+
+func newSpanID() string {
+	var id [8]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+func stringField(event beat.Event, key string) string {
+	v, _ := event.Fields.GetValue(key)
+	s, _ := v.(string)
+	return s
+}
+
+// durationField returns the duration of a round trip time field, which holds
+// a time.Duration counting microseconds.
+func durationField(event beat.Event, key string) (time.Duration, bool) {
+	v, err := event.Fields.GetValue(key)
+	if err != nil {
+		return 0, false
+	}
+	switch us := v.(type) {
+	case time.Duration:
+		return us * time.Microsecond, true
+	case int64:
+		return time.Duration(us) * time.Microsecond, true
+	case int:
+		return time.Duration(us) * time.Microsecond, true
+	}
+	return 0, false
+}
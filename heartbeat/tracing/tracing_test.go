@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/config"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/status"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+const checkGroup = "0f8b5e7a-1c2d-11eb-9b7a-0242ac130002"
+
+func checkEvent(status string) beat.Event {
+	fields := common.MapStr{
+		"monitor": common.MapStr{
+			"id":          "shop",
+			"type":        "http",
+			"status":      status,
+			"check_group": checkGroup,
+			"duration":    look.RTT(100 * time.Millisecond),
+		},
+		"url":     common.MapStr{"full": "https://shop.example.net"},
+		"resolve": common.MapStr{"rtt": look.RTT(10 * time.Millisecond)},
+		"tcp":     common.MapStr{"rtt": common.MapStr{"connect": look.RTT(20 * time.Millisecond)}},
+		"tls":     common.MapStr{"rtt": common.MapStr{"handshake": look.RTT(30 * time.Millisecond)}},
+		"http": common.MapStr{
+			"response": common.MapStr{"status_code": 503},
+			"rtt": common.MapStr{
+				"response_header": look.RTT(25 * time.Millisecond),
+				"content":         look.RTT(5 * time.Millisecond),
+			},
+		},
+	}
+	if status == "down" {
+		fields["error"] = common.MapStr{"type": "validate", "message": "503 Service Unavailable"}
+	}
+	return beat.Event{Timestamp: time.Unix(1600000000, 0), Fields: fields}
+}
+
+func TestSpans(t *testing.T) {
+	spans := spans(checkEvent("down"))
+	require.Len(t, spans, 6)
+
+	check := spans[0]
+	assert.Equal(t, "0f8b5e7a1c2d11eb9b7a0242ac130002", check.TraceID)
+	assert.Len(t, check.SpanID, 16)
+	assert.Empty(t, check.ParentSpanID)
+	assert.Equal(t, "http check", check.Name)
+	assert.Equal(t, spanKindClient, check.Kind)
+	assert.Equal(t, int64(1600000000*time.Second), check.StartTimeUnixNano)
+	assert.Equal(t, int64(1600000000*time.Second+100*time.Millisecond), check.EndTimeUnixNano)
+	assert.Equal(t, spanStatus{Code: statusCodeError, Message: "503 Service Unavailable"}, check.Status)
+	assert.Contains(t, check.Attributes, keyValue{Key: "url.full", Value: map[string]string{"stringValue": "https://shop.example.net"}})
+	assert.Contains(t, check.Attributes, keyValue{Key: "http.response.status_code", Value: map[string]string{"intValue": "503"}})
+
+	// The phases follow each other from the start of the check
+	start := check.StartTimeUnixNano
+	for i, phase := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"resolve", 10 * time.Millisecond},
+		{"connect", 20 * time.Millisecond},
+		{"tls", 30 * time.Millisecond},
+		{"request", 25 * time.Millisecond},
+		{"validate", 5 * time.Millisecond},
+	} {
+		s := spans[i+1]
+		assert.Equal(t, phase.name, s.Name)
+		assert.Equal(t, check.TraceID, s.TraceID)
+		assert.Equal(t, check.SpanID, s.ParentSpanID)
+		assert.Equal(t, start, s.StartTimeUnixNano)
+		assert.Equal(t, start+int64(phase.duration), s.EndTimeUnixNano)
+		start = s.EndTimeUnixNano
+	}
+}
+
+func TestSpansPartial(t *testing.T) {
+	event := checkEvent("up")
+	event.Fields.Delete("tls")
+	event.Fields.Delete("http")
+	event.Fields.Put("monitor.duration", look.RTT(15*time.Millisecond))
+
+	spans := spans(event)
+	require.Len(t, spans, 3)
+	assert.Equal(t, spanStatus{Code: statusCodeOK}, spans[0].Status)
+	assert.Equal(t, "resolve", spans[1].Name)
+	// Phases are cut at the end of the check
+	assert.Equal(t, "connect", spans[2].Name)
+	assert.Equal(t, spans[0].EndTimeUnixNano, spans[2].EndTimeUnixNano)
+}
+
+func TestSpansNoCheck(t *testing.T) {
+	event := checkEvent("up")
+	event.Fields.Delete("monitor.check_group")
+	assert.Empty(t, spans(event))
+
+	event = checkEvent("up")
+	event.Fields.Delete("monitor.duration")
+	assert.Empty(t, spans(event))
+}
+
+// receiver records the export requests posted to it.
+type receiver struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	requests []exportRequest
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", req.Header.Get("Api-Key"))
+		data, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		var body exportRequest
+		require.NoError(t, json.Unmarshal(data, &body))
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.requests = append(r.requests, body)
+	}))
+	return r
+}
+
+func newPublisher(t *testing.T, endpoint string, batchSize, queueSize int) *Publisher {
+	cfg := config.DefaultConfig.Tracing
+	cfg.Enabled = true
+	cfg.Endpoint = endpoint
+	cfg.Headers = map[string]string{"Api-Key": "secret"}
+	cfg.FlushInterval = time.Hour
+	cfg.BatchSize = batchSize
+	cfg.QueueSize = queueSize
+	p, err := New(cfg, beat.Info{Hostname: "hb-host", Version: "7.10.0"})
+	require.NoError(t, err)
+	return p
+}
+
+func TestExport(t *testing.T) {
+	r := newReceiver(t)
+	defer r.Close()
+
+	p := newPublisher(t, r.URL+"/v1/traces", 4, 100)
+	p.Start()
+	pipeline := status.NewPipeline(&hbtest.MockPipeline{})
+	pipeline.OnEvent(p.Export)
+	client, err := pipeline.Connect()
+	require.NoError(t, err)
+	client.Publish(checkEvent("up"))
+	client.PublishAll([]beat.Event{checkEvent("down")})
+	p.Stop()
+
+	// 12 spans, sent in batches of 4
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	require.Len(t, r.requests, 3)
+	for _, req := range r.requests {
+		require.Len(t, req.ResourceSpans, 1)
+		rs := req.ResourceSpans[0]
+		assert.Equal(t, []keyValue{
+			{Key: "service.name", Value: map[string]string{"stringValue": "heartbeat"}},
+			{Key: "host.name", Value: map[string]string{"stringValue": "hb-host"}},
+		}, rs.Resource.Attributes)
+		require.Len(t, rs.ScopeSpans, 1)
+		assert.Equal(t, scope{Name: "heartbeat", Version: "7.10.0"}, rs.ScopeSpans[0].Scope)
+		assert.Len(t, rs.ScopeSpans[0].Spans, 4)
+	}
+	assert.Equal(t, "http check", r.requests[0].ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+}
+
+func TestQueueFull(t *testing.T) {
+	p := newPublisher(t, "http://localhost:4318/v1/traces", 10, 4)
+	event := checkEvent("up")
+	p.Export(&event)
+	assert.Len(t, p.exporter.queue, 4)
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded gzipped contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsvXtTHLmSOPr/fApdNuKHOdsUD4ONuXcjfgwwM8TamDH4zJ5Zb9DqKnW3DlVSjaQC92zsd7+hTEmlegCNTfkxy5zdGbq7SkqlUql857+Q3w7enZ6c/vz/kCNJhDSEZdwQM+eaTHnOSMYVS02+GBFuyA3VZMYEU9SwjEwWxMwZOT48J6WS/2SpGf3wL2RCNcuIFPD9NVOaS0G2kt1kM/nhX8hZzqhm5JprbsjcmFLvb2zMuJlXkySVxQbLqTY83WCpJkYSXc1mTBuSzqmYMfjKDjvlLM908sMP6+SKLfYJS/UPhBhucrZvH/iBkIzpVPHScCngK/KTe4e4t/d/IGSdCFqwfbL6fw0vmDa0KFd/IISQnF2zfJ+kUjH4rNgfFVcs2ydGVfiVWZRsn2TU4MfGfKtH1LANOya5mTMBaGLXTBgiFZ9xYdGX/ADvEXJhcc01PJSF99hHo2hq0TxVsqhHGNmJeUrzfEEUKxXTTBguZjCRG7GernfDtKxUysL8J9PoBfyNzKkmQnpocxLQM0LSuKZ5xQDoAEwpyyq307hh3WRTrrSB91tgKZYyfl1DVfKS5VzUcL1zOMf9IlOpCM1zHEEnuE/sIy1Ku+mr25tbL9Y3d9e3n19s7u1v7u4/30n2dp//vhptc04nLNe9G4y7KSeWiuEL/PMSv79iixupsp6NPqy0kYV9YANxUlKudFjDIRVkwkhlj4SRhGYZKZihhIupVAW1g9jv3ZrI+VxWeQbHMJXCUC6IYNpuHYID5Gv/Ochz3ANNqGJEG2kRRbWHNABw7BE0zmR6xdSYUJGR8dWeHjt0dDD53yu0LHOeAnQr+2RlKuX6hKqVEVlh4tp+UyqZVSn8/j8xggumNZ2xOzBs2EfTg8afpCK5nDlEAD24sdzuO3TgT/ZJ9/OIyNLwgv8Z6M7SyTVnN/ZMcEEoPG2/YCpgxU6njapSU1m85XKmyQ03c1kZQkVN9g0YRkSaOVOOfZAUtzaVIqWGiYjyjbRAFISSeVVQsa4YzegkZ0RXRUHVgsjoxMXHsKhyw8s8rF0T9pFre+TnbFFPWEy4YBnhwkgiRXi6vZG/sDyX5Dep8izaIkNnd52AmNL5TEjFLulEXrN9srW5vdPduddcG7se954OpG7ojDCazv0qmzT2nzEJIV1tr/xXTEp0xgRSimPrB+GLmZJVuU+2e+joYs7wzbBL7hg55koJndhNRjY4NTf29FgGauwFN3VbQcXC4pzaU5jn9tyNSMYM/iEVkRPN1LXdHiRXaclsLu1OSUUMvWKaFIzqSrHCPuCGDY+1T6cmXKR5lTHyI6OWD8BaNSnogtBcS6IqYd928yqdwI0GC03+5pbqhtRzyyQnrObHQNkWfspz7WkPkaQqIew5kYggC1u0PuWGvJkzFXPvOS1LZinQLhZOalgqcHaLAOGocSqlEdLYPfeL3ScnOF1qJQE5xUXDubUHcVTDl1hSIE4SmTBqkuj8Hpy9AZnE3ZzNBbkdp2W5YZfCU5aQmjZi7ptJ5lEHbBcEDcKnSC1cE3u/EjNXsprNyR8Vq+z4eqENKzTJ+RUj/06nV3RE3rGMI32USqZMay5mflPc47pK55ZLv5YzbaieE1wHOQd0O5ThQQQiRxQGcaU+Haycs4Ipml9yz3XceWYfDRNZzYs6p/rWc90+S8d+DsIze0SmnCkkH64dIp/xKXAgYFN6LdC1F2rsVaYKEA+8BEdTJbW9/bWhyp6nSWXIGLebZ2PYD7sTDhkR09ijO9Pdzc1pAxHt5Qd29llLfy/4H1a+efi6w31rSRQJG967gYt9wgiQMc9uXV7WWJ799xALdGILnK+YI3R2UBOKTyE7xCtoxq8ZyC1UuNfwaffznOXltMrtIbKH2q0wDGxuJPnJHWjChTZUpE6OafEjbScGpmSJxF2npL5OWUkVnOIwNtdEMJahAnIz5+m8O1U42aks7GRWvo7WfTK1kq/nPLBUZEn+Kzk1TJCcTQ1hRWkW3a2cStnYRbtRQ+zixaK8Y/s8t7MTEG3oQhOa39j/BNxaWVDPPWnitjpxHN+1t3lSo0YEnh2wWj+LJO6mmLD6EbjC+LSx8fWOtQmgsfkFTedWJ+iiOB7H49lpmwOg+u9Oj20iuwXTi2Qz2VxX6XYsxuiGDFMZKWQhK03O4Uq4R545EITWr+AtQp4dnK/hwXTSiQMslUIw0BhPhGFKMEPOlDQylbmD9NnJ2RpRsgJ9sVRsyj8yTSqRMbzIrbCkZG4Hs9xNKlJIxYhg5kaqKyJLq0dKZQUer+SxOc2n9gVK7H2XM0KzgguujT2Z1164smNlskBJjBri9FZcRFFIMSJpzqjKFwH7UxByA7Qy5+kCBMs5s6IvLDBZ+sIUVTEJAs1dV2Uuw63d2Ap3JeA4VhGVKQhXDqLONjl5I3wdCN7tohvo2cH56RqpYPB8Ud84GoXngHo8EyeNdUekt7W79eJVY8FSzajgfwJ7TLrXyOeICaCmXMZYjlid1+9IV+UjIGOpQu+TKc11fSNkbEqr3OCQzR8be/A2WhPM18HDz1JaGnz9+jA6g2nOW7rEYf3NHcrEgXvTHjZPj1Q7AuSG27OApO+3yR1BC95UempzSoJiM6oyEB6tbCiFHkXPo+A44Whu49Jqn9Nc3hDFUqtXNVTXi8MzNyreTDWYHdjsF/bxCDI4gJqJoDLYZ87/cUpKml4x80yvJTALarulYyGdqdCsZEW7xqRe11FgM2PawuGkcY8lo6jQFIBJyLksWJCPK416hmGqICveVibVSq1ZKzb13MqBIloL1Hj03M9OD8SdnbCgB4EeGCHAHUsLlpj5ba6niOFHjdYRkZ/A3l6VrixC3Ki1AsaFBe+flcANAH0MNSxvyewZrMavkKYzpBWscL/W4UR7E1IwPOF4G36eYCqEw4OiGs0yollBheEp8H720Tipjn1EeX2EQpTnCDrIdkaSa26Xy/9ktXJtF8oUKNyam4q67TiZkoWsVJhjSvPcE5+/ESw3nUm1GNlHvVCiDc9zwoRVLx3don3SCi4Z08aSh0WpRdiU53lgaLQslSwVp4bliwcoVjTLFNN6KJ0KqB21aEdbbkIn/wQ2U0z4rJKVzhdIzfBOYJg3Fi1aFgzssiTnGuxWJ2cjQv09KxWh9mL5SLS0dJIQ8o8as05MA8Nhza/njCh642HydD9O3BdjRFlTyhRWCa+FyKxC2yFejeOEl2MLyjhBsMYjkrGSicyJ+SijS1EDASq927Faikr+113gVCdPd3gE1WRhmL5HtI/2Hi08zdcagPxof0DrTvCwuDPpSAJZZ3er9nYagCFhD6B0OB6O4yeNOWdMJik3i8uBDASHVmbv3Z03VkdgNO+CI4XhggkzFEynkbEiTNaB71QqMycHBVM8pT1AVsKoxSXX8jKV2SCowynIyflbYqfoQHh4cCtYQ+2mA6l3Qw+poFkXU8Ae71emZ0xelpKHu6npHJBixk2V4X2dUwMfOhCs/jdZycHVtP7yefJia2fv+eaIrOTUrOyTnd1kd3P31dYe+Z/VDpCPyxNbNkDN1Lq/j6OfUOL36BkRZwNBKUxOyUxRUeVUcbOIL9YFSe0FD2JndIEe+nszWJiQwrlCiSpl9sZwwvc0l1K5i2cEFpU5r0Xb+oZC8HJSzhea2z+8hyP1x1pHIJxKE7lxwX/D0e5QwAU5Y9KvtmuHmUhtpFjP0s7eKDbjUgx50t7BDHcdtPVfD2+Da6Cj5mDqPWm/VmzCmoji5T0whAeaxHlyFoQ0zxHhsogpC42x3pDjXYsnZ9c79ouTs+sXtfDZkrcKmg6AmzcHh7dBTRo2b5O08dJ7rG/BzYVVL1FLOjmzEzmdAQNTTg8uggJOnrFkljhrEs1jQwFBbdMbmhqujXBWIp3TKrVgfhQzkkuakQnNqUjh6E65YjdW5QEdX8nKnugWxu2iS6nMwwRcL+Roo3i/1Btjw47/veADddsHyHuNVZ/h258k3W034ejsyTJC5+37ceb24Dbit9xJG6ZYdtknVz7e9WaVmzmfzZk20aQeRzj3CBZSlizzIOtq4sXRsP8/1T4evKai4ZwuOpUKwkiSGcj2SSqLFcI1WYk+t11PGE7jXEoZM0wVcBWXiqVcW10L7CgUtV9wxEIYUTXJeUp0NZ3yj2FEeObZ3Jhyf2MDH8EnrI61lpALtbCUaiQaDj5ye/Xh9TpZEM2LMl8QQ6/qXUVtOafagF8DY2lQMRfSEFD6bliew9ovXh/Vzt+VVCbV1Ur3Lq2R0SAJI8tL2P4vQBFsOrUH+JrZWZ1M4/bwGbt4fbQ2Qm/OlZA3wlvJGmARh/qRN0cCikpak70bD67ILvG05w3DWjzWGALq+b7JBkjmNoqpN2I52oHvG2RTaaaSYSkm1sjQcC0VmoPt5OijKhiYSeT0No5BBXl9dHAGoRC44qMwVEwqq93VsYLyfKDFWfGfwAReZkm6AEyrPO+RJL9Lw4xd8KomdkkwHSgY9JrynE7yrjB7kE+YMuSYC22YI7EGbsDO+tUIEGYfngJxkYPF4HTjUKYu5grX513lYJHcKHNqrATSQ6gI54DqcrwTOFkXiDnV88G0dcQU8B07j+XJqVSKWdG3EfA1RcM4MChBqJBiEYePohAXkcp7zVwwyxhWwTM0aMMHu7pxCDJMpZjiXtG8MScVmb2SakcO8VHBfUQ1SExTh5SCDgZzdqF4PAX5q7G087mVttGqAsGFXHQXHfE0Cjyt4TmWFS4vOI79F7f7jTHRgCDpBf8CDEXAGTpVNAQf12GV6ADCmCSvTkBkErk1jHJK3jCjeIrhTToOn6KCHB9uY/CUpb4pM+mcaTAqRaMTbrSLXK2BtJTbDLhuRM5yHcJymiC4cVUlXEisYoU0IYiHyMponrFopjZkCBMlLmbTL8gTmKhfdQaxZmw4DloPBMGpbnKv8tlhua5BdQh7iIswBXPtcFx/9aJGEM4FQbmx44RnIdDanegFyfh0ylSssIPZj0N4sb0H7TFcN0xQYQgT11xJUTRtRjVtHfx2Hibn2cg7ZYD+ydt3P5OTDEOhIUigajOXroD64sWLly9f7u3tvXrV8nOhiMFzbhaXf9aewMfG6kE0D7HzWKyg+xFoGo5KfYg6zKHS64xqs77VsuC5+LXhyOHExy2eHHnuBbD6Q9gGlK9vbT/f2X3xcu/VJp2kGZtu9kM8oDgQYI4jTLtQR/ZG+LIbKPloEL3xfCCKmbwTjWY7KVjGq6YyXip5zbOlHNGf7eOCs+YnTPzhjPN+6I0eEfpnpdiIzNJyFA6yVCTjM25oLlNGRfemu9GNZaFRfKBFOZv4Jx63+DqWGbvUfCaovTob97LMGDlv/HL7BX0xZ5q1E0Qa4hrcdBMuqFrApCRMqpcPOcTg8HtEqImUOaOiD20/4k8gydIShAWOcZYOFos+F9XT9akZVbHVMOwt8pIHVRtqqsGCXg6yjLuQti6WgdKZstdGakV1BKUnDr1COdyliczstZ2qRWnkTNFyzlPClJIK87g6o17TnGexR86qUarSxs9HXjN6zUgloqgtPIb+1foVfz7r8cOwN1STSqRzll6xnhj/43fv3r67fH968e79+cXx0eW7t28vlt6jCjMSB3JcnePwDYYdSD/wuzoMgKdKajk15FCqUjbC8O9dCqCRLXNf3nE8Vs+NVAzl03gre7aHpPOmyfrvdk8pRPrVr9/2HqRhYeKdD20ageRq+VitNYIo6uKgpMgXzRysyYIYKXONUWwUzAyQFcPSK5RNkQ47JPOwgwzE+pl47ec7aGKBK6XJga6ZsiJfRujMCuGRNjdnNQ8Vpilp9h432kD+PWdpGcTUFwcweUfG4c6Iv7wjDjg82Iz1dFGYnXzeKMOwZKldjQMyQIFE4Ozjzhsnp/EgUXJ4dFfNWV5GVg1QdNCLF4bWToUSC3uzGh7MVsvcWEMaHurF86wp/PGCzgYVRmOhCiYLIUQIkCW0ScVzY/XAHtAMnQ0EWU1ZDi46a5mZo5T1u6ePUtfvSF5vi+kwq8sDb8w74HbUi66jJIIcijQ7lCCKo5OCCjpD5s91TQgdIQpT5iM+EoUcx5zkqPX1HbwkevTu0HRkuNHTEHaEbvGNZuZ4z5hRNPp9cejIflwc+rcYKN2I814qWjrcMq7axCNFS4dhIWr6KVr6KVr6f3e0dHwwfVCNKy3T3q8vFTIds8KnuOmnuOnHAekpbnp5nD3FTT/FTX9PcdPRJfa9BU83QCfDRFDz0s4W3/T3hA2zRrxwqfg1NYwcvfl9rS9iGE4N6CHfVNA0ROlGxhm3UjDZ1LgxkkwWgIkjBiWGHn+FQ4RBP0Bs+3Kx0LfS8tcOiM46EuVTVPRTVPRTVPRTVPRTVPRTVHSb4J6iop+iop+iop+ior9llvbZUdFZjteL9369fg0f7y7Lu0zEFcSb5HyiqOJMk2whaIFqlEe5pJmvfOyKrIJJxv38hoqFq1IXF2l1JaMkWdFzCkmOjXlWXIFcHz6Lhh4fSzepQjV8CPBgBseDWvQ0zz3qpjLP5Q0Xs30Pzd/IES5gPefiys23IM/GSZbn4zVX+M6riFKQ37jI5I2u3z9HcN9iZM6zcaJl33vvBf+4DjJbZ+0dWBpgLHI+6RuwoOnb8+Vdgc2wvOQ7intrQf4UBvfth8G1t+yvExXXWtlTkNxQQXItRD/FzN2CJysxJkW2OxBDfHO0i1M8CB49p1sDAXT+y8HWp0G0vftiOJi2d198GlS7zn47CFS7W9sPg2ogDt3Qdp1w074261KaBS21N3rHPB1aHUlBMq6vusfmiinB8ufbiZd8l1huSc1Qat1PVZ4jxHaSztpbwB/uf3CC5QesOf18+8MnLQgsjCUVi4GWdRLKzuA0nQ0a+WSYjEBrjqLkOVuHGNdHvYhLlkSADb3alov8ExZ7RuM4gvsXZ4e/7K2V/viru24WTn/gyl4kz5NXLzY3k62XO1u7D1ii7+BzCWsdNNHNLfRziPX87ODk9CI5/o/jByzRNdAZel1ums9Z30o4jR8+Hhx7NRf+fhsUVuRNK3cjIFggRKOs/tHp+X0WiJ8asbZ2wqPTc/JHxcDSYAVVKvQNi1p32d9dYrYTWBmHZNdQSrmuee/HWpBScQm2hhkzWEkah3WDPhtnQkOa4z48P15zTXQWfpJ4dLA6+1LMaC6r2xm5EXHaEDqs0VlCdWybcDCgWH3DFKv3Di2nXOM4XSjx1fHaQyKDGyt+9Jj11QNBqFJ04ZGBWHbvo5uIpnMHBtGu6rliplIiMmj6ZniuDFgkMTAC1u0rtnAoq+N1/d7gFmjm+7I1wpEnC3J8eF63zXiHJdxxrLmV4aGtQmwEKOrl4I9+ckFu7FvHh+du+HYEkt1mS34Q9YR+fOxaAr80Q8rtc57MyYEhBRe8qIqR+7K2CrhFFVbjiztoje0sYwscpP53lsF17RsZWWErDEntaCkIK9z4No5Uk1JqzSfob8igIrm9+WltKnFGQx933A8o1STFjjaNOPYWRSZpTgeLWMecfYrROWFDfG5BhhTDofERxpRgYf8Oszw57QU9qtswiIsboI24I0YstDpFusPBKBZN8HF0+GrJRKa97wWyrIFheZTEA/q1dwTtrc3E/18vFoaMW7xoOuEtxUXpyi3QSYll7nWzcRB1xhA5JYenB2+O7YGYMIss+35+zbJRzJxWVzUZo7OkZjEmyl+QwjdekkoxXUqL4mDZiwaBc5mQk8CrhDTe094e0zc3HEN7Bh8sP7Y3D4PGpJ1tubm5SW4Jw/A7Y8wyLufbApUs7iEzB2LIrsFCajk3rBcQ0LsJ3uZE03nM2NkU+FIjz4LrlKqMZQn5nSnpc+gLsNnMXSgqstAaf5MaaThFT1x7P50OWMfgYl7XMPhEFgOk2bQYMJoxdTnNfXPIIczfcGfLKdkmOTOGKeCSODOBmRuFSEpsZVQXO9gnBwcjcnE4Iu+ORuTdwYgcHI3I4dGIHL3tkKz7uE7eHdV/NuPHB3NP2x2yS8PYvdhNTTWYjeuWt0rOFC2QAkOb3oAE+wiIZZhcEw0EWWslr/NxkDnoHg1qe2trq7FuWfbEFT/64p0nSgo0l6MYhemwzhx9xQUE0KEA25BpSWhpGkcvQS9G43FXN4fBwHIcBmVkwAw4CeMxb8XRr++P3/2jgaPAGb+YxODa/LjbAvWSe4WDBgMf8l6EC7EFWnzvBXNaqyCTkGK9VFwY6NeXzim0tFaaPJuwXN6Q59uQeGchIFvbL9ZGEe1L3Xij5uVBQ8J2TEyntLRnimpGtjbhCpnBHB+Ojo7WajH8R5peEZ1TPXca3x+VhKSmMLIbKiEXdKJHJKVKcTpjTnfQKKPmPEq/mzKWxSOkUlwz5YKDP5gR+aDwrQ8C6I85n8aD7tiwzV89FvYp/vWbiX8NRBGQPyQxhElAxastC26BdQvBDol2GYUbaA4qoUusAKCBEYaZRjVqdDXZtuvcShxWgDRGDZzXEDacjF57rcdYGSGJCEmMojyH7oJMcdkv+PYj/Sn6GNnfU/Txg6KPa/r5MgqC05PuFioODg6akrHXVS8/J4fooGOiy3NycmZlOAa1wMaxaWPcsjH4H8fe1Odoh0+nPK1ysCBVmo3IhKW00sEyfU0VZ2bhlaOYUAtqtFUK7VAOrIQcfzTKt/wD+KIKAx5Qg+3PJQGraISccS2uQst3boI5C3slZOyjfbuwVBIPjSIBvgS/M6o5hKiFEevmeiipWOF2Krt1FYN20zadNL/bam8wSMJfQhHwc/WnGp6+hVigBnQDno3V+HAEA78P2chGDtFWJgX6a15e0MOwLtcTOQgglGXGr5mG7oWRa6HRzhAeSxWLQ6UyocMoU4St7SNYFooaAG/wd+6ABhCt+aGNOWChZMqt/5ks0fqaL+wQWspwrzhtDU/HWkIORAb1WlMpasXVYbV59m93VHh7vtXjHE/o8NJg+A3V9dKGC+j48D4X0Btm6HpsrPbVmZw1evnCfve1mVbsj4orlkGhs0eIcDg+PA9+VLjHAn7tYjQxMiFjlurEPTTGCH8PRs0EQTAC1lNpg/UJIdo777QPJeS3ORO4Z7CB2LU/yGtcZDxlmqyvOyOpc2BYgCw+dc5nc5P3FaWNVgPvR8G1ObMs2upvyrUppdk/Lag+TTGds4K28E8873dL6BqVk81kM6YcpWSjENhx+GLpEGZoQ++dQS7iEsh3AXaNgMf32NC2QPkBn3NuoLJkUNAlZ1gC2aLZMwIIwk+pvYVu8PYJdgzce240y6e1ok0Fjv4AN91AyeWATDT6tNwJCOCdNrhhYvpDekgPBM7QdA8YUfB9z2K9saoxsDY0vbq00sVfIQ3qAoMvU2jenLLg+wGMWmItc/ARso+tfkZfSNANuzvCk+ZK5ZpgYovDF9jHlJV1pnHEKv5Jr2mSUzFLTqs8P5Pgjjj2j8c85LrVUfz4eomG4qGRb28hQd8duT84PJdeXcGag4qnDV4QWM6BfbTVstyyh/ad7G9iaAhWMDPHcxp4U60pvJaBM8HFwUWaV66OO3htqAmuMtC0xKweI9QUtxPVi3Dj+aGoT+ewVKaML2LvStPXDdadTR0VmpDW7sb0/m/Q/eLE7RGW9+rp0j5h5saK+TS0Y3byjLoObmaczDU4Z1DDP82ltms78DtxP7qxlIQ/x1JBbS0otpOTglFdKVZgFwAImu7DbPQYBPoaesUCDcdojsmjxnHBCgkRKkxDP203XFZj2rXVvuaBZxlWgCG/Uiwh5wz3fIzl5+xFN8Zlc+MKPANT0HUL/MiTH45wHJHgILXzamP19MYlvlw1/iWq7XyyroCjBwXBOx+a9feclSPUk8FCk3FYhIjeIidQ+hNIoBZB51R4vPpO6OPadB021zKMMSBknWbZeETG7tysw7lh8NWU52wdxfxsjL4j70Fp3AYg30dBK1gfs8yBwvpq+FeaqfWSam2RuY5hSU2ZwoE+zHZgAgwcpCmZWjXIypKHOKcvkoaBXqhhg5RKDe5IbQsDZcUZtNzW2IE88GTOmaIqncdxxO29qcU/3O6VCZ+RSQX1NlYsfNGInOmmUS2SyHPDlON2rSn23c6OycJdFkFMx94izsrlHgtjQtoENwvnO0PJmmvkWfki7kviZrSbMnad/l2KkWVj9YhEVxMPVpvqw/hejXPzgg2N5rm8sRBa3TJtbpS7d9ySIlMcNVYOga0J+kaEya5qWJm5FfWiulu3y7iPZ0o4cfJlGrk5QzQdLApyxUG/hoy4CHNRdUsfslVpFi6NjOlGZw8nYGpSiajU5YgoNqMqy+PdB+4PTxMrx1T2D6mIXR7ocaBP4UUjr5mCW8Zq8UFk8pIdj7eE+aBNlHPIyVF3G3Ze7Ow1kY8c6B5ekNXGiCZ+3WnAQTrtaNgG3I83VksNvBVuxSlXUUKNYhR4m6XOGeyJVPYzWFFKXrIcej/cQtMZtzJE6orn/F+oH2poUSLboCb+ysRtUE1sJQ+3OUNro5X3fDGeEI3TvlJOBCnslay5qVAZHrmQQ3MjSZjWHbQJ61G5kfX7j2kczSJ8pjVmLOUpJBS5Sjw5hNWgYBRbm1yEgou3RBKvmUQstsC2wKuAdNyTkLGbEW4cl2hBUkjBjazj++ohVldBLfY7Zj/6Xi5GkivGSlKV6EaAl+LD1cSqVasR0iYe7dWKJy6l+Sje2dq9G+Wmx1lV25tbL9Y3d9e3n19s7u1v7u4/30n2dl/+3oxCzKihmt1XQenzKz7gNK3ANNHACLpWwBFeYClbKjDYzOlTVoWQyl83WN+Lpo17JpezkdP/cjlbG8WTh1vESCfjLOratdF5TWURld/Ddlc12LDpiqWyKIBnQy62kCZYtmB4K/c05gZVLwTJFTKr8pr0sYYHJmuj1ENJJrH9legM03PZlDSdsyTCRdjeSi1T+LGnQlbrTS7Kylz6HwUV0kXCef2vMvEDVL/hec57n0EHG9DIVi/hHLmpGzY0Ap7AMG2TkpBPIdbtmcfPzKpNijkfpKmdfo24xj5e5BkNzC4yrwrYPeWd6iJMLBO0dduVUoPauU3aFwnSm704/fderAqA27sGfIZyAupiq6r9gGU9fqF6Tp6VTM1pqe3h08Z+M+VixhSE26yB84/euJvMSLsBFP1Ske2nkEIbZZcPJgMwvFrJsU30dT+pvr8Ofjw8+mJWvZMju5pQMj1Sxlow79Gd6e7mZtaETMxYN6l6eZnkItwJQBeBq1Kl+LWPwGRQfFTR3AWUGqk6EgbIFr7eBAgD4/rCiWXxFl16cSFfEJmmlVIsSxynrG/iXMvO6A1pKp6gYBR7ovu8ZUzwsfd1VImfBAGKaHrTqwOfCKdU2tOFSr9Vw7SuCisxCEns2kDbGQVJwd293jU1V1LIXM4aRT/sVSOvfFgA1/sNXJH/r724+hu/3eOl7uzdZGtz6/els6OveJsZfWN6rg/g+iRFF4076FG0A637Udq2SUhP8WJD/LPp1OH3XBcDcKDFFtrxIkecL1IdHKK13aRXg3bxwV5rQX6HYvus4npOaM6U8YIMnIWGdawVd4CXVnO0loyKayRzeePkcYsqgKCRLRZdcGRORZZDXOGcLcBVdmNVZWGiY6qYXTMYK+svUcwAhCiZ16vmBkaBkw5NYSAASxtLDDdzBmlqIaIdW4qCo8+AW3BW5VSFUPtadVRWuOoReXLm6n4Gp0ksUw0myOIsUY4JRD3DWtqSovOKO/UBFBTkVVVZSuVMNKkUKSsh5AmHRo0ir2YgCXQtKbVbnsJJEF56Rnn4AERBuH/XRv7c4MjjVvhZQxWsXRFgBrTP3yZnNrDuef8QeH9nmTr7aILxwJKzMFyF0/fekf8dUsMtSrSV2CEWhqF0l8n0MuphmHFtJZMMDKNYDgzUWWY5E8tqorfSv4vfgShgozi79rr0+BL3pofVn7OSbL0im3v72y/2tzbR0n14/NP+5v/5l63tnf/3nKWVXQB+ImZu7xFoEcMUfreVuEe3Nt0ftRRoeYGu4JxOK3svayPLkmX+BfyvVum/bW0m9n9bJNPm37aTrWQ72dal+bet7efNOruyMlYx+qYvF6s+ferd4tY39sF4GRMQiB1zLrwxIiMr9VgGX06tM1KeW6klGFRKpnyYdbg/oIo7GmwwnZllvSLMqTQuVQHFO5/eCzWfnSsgMvRnDRMlcgvM72pdfJZX+6ItEXev764WYkbQehctdngn8tomEi0wAv3AXgUiwO8FUYqhcXAJlLLy+hp5FtaGn12SGd7PYdA6PBdFMrdG0PXrimh1cmyoSxO0b7xP7ejRfahDxBUyZnkN1TniDV5qW6/jsBK3sXHI1k+VAnqq0SJcwqzj7GA6g4RcK91qLVPn4cN9uEXkMA3uVtcWsYPXKJi23LSWMvysZh6b3vetRDFu9G6lYhFEFlBCOeQMesBIJhny1YJe1bujmdA9V4lDa4PFDNzGdvU8xKf1nTM0IsOpwuvZh9KeL7SzPHVtzq/lLLKxFigsNS7WOijOK2b+TulpFEG0nJobqthd2VfusMB1f77QhZXO5saU2Ro2v56ib8T1OHIDt4vwhRGfYdmVUV2dZN0tcd3fQesHlVWdxGzttio0jW2ESoSROeXR9/Gdn4C8f/ea5Fxc+djqu4vZeRdIWyjwo2D1RPD58jT2ITscRiOQg0iCH4XrqJHIHykt+yCuWhaqGPK9QgrwrgAzDB4a7M3VQbLdXb2/seG6Wl0zkUmVpLLAnmsb/7K5CaaPZbVExfXVpY4u79uu82kuaW+M0TuurwiMAOKq4lJxjHBuU6h2RES0zCvQv6Psp/eaOWM+rAzM6c71gEx6zlS7GV+A/dJq9kvQ2K2LWD0F0wD/k2Uw7D0LGmFMgk4peKTCIjYt2WxtbvaYUwrKXQlLV5d2ISvY9qaB2x1VLDAH6Zg6Akg3/Rl2iBtnHtHMkpOol4FYc4GRcH1hyc2WyVKzP6olT+jDelScu4F9a7VbeC1EbrUehfBQhN87AsAUrjtuyRF4ZehVM4WcfaSpIVJlzncdVN/IPxl7J8OpDuazYJjuYOuaRR2AHqXNBGYwYrBNmKB5fhri1l3+o99CrniQ4sKIcU55lK+AT3kzt3f30ihc2jMnnTifR1V6U0gUjhF2AoJ33KzcKVGpFJprEwtEjjJjywdce/YK7K3r4C7fsJ4Js2iGvobjXM4SDb8n/vcklRkbJ573+q/rpIjYuFgHy2LNFTdFW8xtOqmQq/k2KfXRPDk6X0t8NlnjjSAXObIm3OrvNyLMiJHwVh6vQ9zDuKksMQjm9uVGURNhwd1L5GWTpg1dqkXN3W4L9Inc67hwYUCx6yKiCHRh1G7yW3wX9pz+WXeZHCAL427tobEkeyBqxmF3OCwILQsuGNHB3BRHcsVotnCU5C5rT+i1/Tm6JvEAeuIg0ioQN1w3VK00ZSVmNIdJfX4R1Cmg9vhLATL5yZGbfOW4UrJkGweFNkxltFiJsp3pZKLYNSof/vHzi5U11AXIL7/sF0XNTDjN/VPrm7v7m5sray022o26/cbMB2bO1SeGYEG0UtMy0IosWtHVZB1jsVbgph8hSWFcU3R3kFpR7cR3IXkiTx8RJux+6yhgy/HVDPydMrJI4KIg97BUdktB5nTatk/ravcb+4KhVE7hX5SdxmWVGqptyGpbexAwNhSY8xKZdM0pK3uEr5k2fOZX11S9l1AsBJxbPzSmUHCxnrHSzDuj45XUbNVO0L0GQlOIdXe5YgICb0mZ05Tdqp3copXUJ/6ztJNi4fSTYuGyrK2GAnNs7G6/3MpYNlmf7k4213e2t/bW915ON9d3aLqz93KTPt+bsru1F08PU+6M/C7G/Sf/+Y4Q9wMsTNqKh4bCHR3/EISaazKxclEzWMyFbNtfIXbOBynbsd3K/f7/BJVbXR0wJ3ZFphw44GDx9Vvko8D9ZyqyDanqxZJG1MvIVaIIdsPJAqc88XZv8qb2OvznTydv/suXTNR1vLe9ZHnK9FqCL7vwf2eF6Wn8TSHVmGWIzdZ6/HGMvMLO1PSguGmMxfoMwWT1NXVeYhJq6FrRwg/da1n1Jrh6KzWGbxlF0yswqaAVsCf8gxqj+KTqdDYeoEgR4j3MF1//4UtsFIHs+ZqqhaWN0G2G/MIUhqlBFRT2cU4rDeZLSGCXU3e3NLm1ZQvM1z7y8fTueNr7kF+zEdhyIZE4G9X9fewdBY0AYpcJ+8jSyrARmfMsY2IE4ZD4bynyxchxyBG5Udz0mA5X/3PFP7syIiv49Mp/fWql9afOEE+dIZ46Qzx1hnjqDGG+784QvaH9D5MdQA6CcUAYhLrRS4oLEFGHxNZ4vykspFH42mNJN7VA4GQuihE2kAnVL+/gb6GALQzjNhAlh6oEO864sFONncrH7VlhmoxhFeNIX8Vgf8zjwNrbwapnHx1ZTTMNw3lt0sMdV/Bu4auR9/fYVxw2SHa+ad3y1gWA2kSpW/31g7AzFJShwWHIug/qDLRyd1Eqjk3FebCZ4tdRdAQUuHRmh8gU0FnhxlwWbIPmHvNhpXa4SxzmcxfbS9xHCkRRLMR5x2qbhglgzIrl7JpGlua6dVlvNF2UPlGWTFlFFy+AhvkOrs+8r1X+4bJcCVAzYFMDYFlhks5els6uFJrmD1Zh9Ezxwl4E2O7y5Ig8+/nkaO3Oo7S6tbm51TzwtX44NITt3gE9LQbbB+CL9h76Sg2GvmIXoa/YKqiOxR8uOfPEjl3biL2gitxNhL+9Kal9VrZ3Xzzfe948LQUv2OWA1SzenLw5xjhqf7v47E+AFpTCZrciRbRRjELcyWRhIlNCpaEEgzMW3tzcJJwKmkg120CfNySAbhQs43QdLMHx38nHuSny/zw5OD2oWfx0ylNOc7Qb/9fIXRm+3FmC5YJ6csms/FGC3D9x1QTDmJjeGGK/o6X7TLtlGX8xHCW9sYQUo50LIlMrtgfqor2lRFY3X+xstkjoMyXSHoE0SJIUQolBdWgeswFLA5+2G2jhZR7q/fibso73N3FH6g7KfHHP9kUqb8RgkWpoPrYTrIIFRUHa3/330+O29/pqdX2glRh0EYv0k1FrI2FvsTRoR/ht6KdZJFQ+TPjduG3vn7qOPXUde+o69tR17Gt2HYtCefifDwzk6zF62UGsGAEyW6Qxv42Va+SeUMrHRTxwTVbsx55Cw1svnu/tNAA1VM2YufyL3FIXsBq8pyCYYlGAr/+LlZqDfQMJ9RlSYcYVeKgdJGsd6gvu5BBcMWi/ESu5gCHgPRgCVB0LHJVBfHbeshKg4HO7rSBYChhmjbs4gJ/dxzvCAH5mMq6VmVKlFpjEh04tWgv+YGrCDm2hMFGwpTdjPVwzVxleib1lobw4pmJjwCNL55A3XqcYWMhOzryLVCqnbKh1XVk9JdjGlyqhyc1iKP/Sod28XmH0jRRW72tmAmDsDBOD+btOG34uN1m3nrNUZk4OsLBdC8BKGLW45Fr2lJ1+HJThFOTk/G1/tenDg16QhtpBB07vJh5SQVvWbU/V94AyY/KylLHsFauIUsy4gYqKIiM5NfChe8L/m6zkUqzsk/WXz5MXWzt7zzdHZCWnZmWf7Owmu5u7r7b2yP+sfilVcvW9PYI+ZKglnNKAmpH3d2CQnZySmaKiyqmKXdfQTjOFCCvLbKIr9jAuRhLJFly5VGmItMZKS2SaS6lcyPwInXZxlb8wKIKXk3K+0JglB/mGI2APGCPS6tlYpzFBSCIXhFZGFsD9IvbWvegnUhsp1rO0sS+KzbgUQ56sdzDDXQdr/dfDPpgGOloOnt6T9WvFJiz9oc/O7e+v8MXtN5i9VNF4HZVq7Qlnh2d0HbzTco7EYe3LFxgftqdIo1hU8HiZsGDIDimYSyq5raUPFeT10cGZvUEPMC2z9p7F3USaLGQwIej2os+4KNeXEi2+GyFK60vxtxjnAFDyQ0+pIEefv/jP95QSnmPVHyDPmiLrnBP4neYzqbiZF6GyLFcu9CyKoWR55qLZsBIxhKXOsVUWhpq/OdodgQNjDei8VMxx64QcZJkHYxpCHjEC1w0xWUDCuEqp9kalJnDIjC2AaLvGehaQI6ZZSRU1MnQUproRXf1MC3qF8bMjgnlwc/r8cndr+yFNi7+0q+nLe5m+joPpS/qWwnmSulGb+xf/+c64ZQgSbsctu+xusDRUBsuoaENFlDx1fHgO7yZ/84fg1oz4bpwvTCpFXeQ51ntCEW1QNUGhua8YNKwVnTQtC+2cquyGKjYi11yZiuakoOmcC6ZH5EimV0yFTqLKpW78ezVhSjCIdJUZe1BVZpXOuWGpqe5NfP2UjX/bSrFuzNeRCD7uvbh8sfO1bli8C+U02jtPav6ave2OrQMrUPZMY/HVDrK6qm+7fcOIUpFTZn48eXve7fL1movqY8/YNdDRTGFEuPd9BYGeeI23pxdvz98GzNxjU5sxmXxDijSA860r0wjkN6dQx2B9I0q1BembV6wtkE/K9bepXNu9+RYV7Aiur6lkN6WugSBZ/cWNHd9IjUrBdT+DkCF941P1xx6yMSg29vy6hr5eK4T72IlD9yisj7Mep62iHBDHDR/ogEdfOo3mN3ShSQWvjCBX0FUaCEaHglHBxQwKX7i620xccyUh0KfRVt3tH/SerhSoiZUv+DaeMGqAEY3bWCjvwUJ/E0gQRnlZNz5s9V6i6QDI/cVt5m2zDkWjp3fSZ9R1EikzosqIGt8L/tEXEnGMEorK/VHRHIJ7wpiRLOfb20BlB9djPTT0qDRTiasCAl16M5byDKqtWXEUSKlm7tBVs7X5UidTWvB8qAiMt+cExyfPvJNGsQzStjM24VSMyFQxNtHZiNygONz1t+GTHbir/BFTmr+a/7Oj7uCuN6N0QsyD677WL/LS1OL7jfwnvWZtbEUFpgbY5fYacLYANqjbit64Qi4dyHeSnWRzfWtrex10cp62oX9cAepb2+s4gs6h7LbN/Y82Zry180vtrJ/PnWcr90k9ItWkEqa66wxTdcM7Z3jYkKEO8MvS49ZmsrWTNPvqDlZ2w5VXbl0rVoM/zGWVBWXc2wnqindOqsHgBSihPTbbScEyXhVjKKJzXbRKGzYsAcEm1Gish9XvwMIbu+BrOSSM2CePtKpOlEuGxd4WVXOObQpqSS4UFUAze3Pbnm/vNqe39+PXcrhA2MaQ/hZYHSsoH4qtW9WSwARe3kq6ANhr+JHD4b4af7YLXtUglvlreEroNeU5nfRkthzkE6YMOeZCG9ZiboAb9Ab9dT1+0SK/aedfBOeX9gO2gBiwc4hXPIHvgAcOyu4oDL1q8HJo3ugYlCBUSLEo+J9xN2lAYfj4PhReHMMqeDa2lIIfvPaN+k8qxRT3ql3wQGSuAngYttl0qYGnL9M8OCTEw5xdKB5PnfxqLO18LpUPtYXaEbXpv150Ixtigh0BgunHmEaAxS8XF2fw+XaH20/ebR1i/uxLUfNC1zmbjCuV+2pcmmEpThNh2AKpcg+vYn9UTD8g1MK/MJHZIomzqB5YqDN+tYncONq3BSaBWdvo3dt7eTuILuHnL3CRXjjjBm78nRj5heW5JDdSubYaHcwMsG8XEmsz3LF7zyywwLTmjFrpu6vSbO0879/Mgpm5HOo+XG2gFKdqpWZH5e2wqfOExcVtjQwBG1iV7I+KqYXVg0IX4EymVeHT38LYvvfvyomvXGp1q+PD856w9RkzI1JCh+eyMr1oggLXarDsr3du+LrwWoy5zm76jMpJLmeJz1hKZbHRgl2XUmj2xXkKTrssU4mB/Otylbtwcjtb8bj50nzFQftpjMUBjZVwehxVn19zuolTVy+o11+1s9mMtxjWiANw3WYV2wIjTZ11bpia0rRR2PCk8eXdQaFhgE4Pf4gLTaXKCBczqwljf0T8szkvaYi9kOqjWCmVK3VEhS/Mq9pFkImSFWRX5pJmZEJzKlKm1sKowWjDPoZ08TAW9KGC7kg9vfATaOFm6q4hbszQKSQMU6MAgfNjaSa0VK50e0kFsStaw6IhMRyJw08PKnpCp5aX5WjO6VA12gKJ4CzopKh3rFYvRz0OaL97gZuFst7Y2RdNaxaVXGiesRGRlXF/KJIVf4YWHzXqBS36zJLuxR/u4ZqDx+PW+Do5aiOrQd41ts5P35x1zgkhJ0c93G9z2QUOnYTp94LdThHdPHczvwf+OiVkFvOp1+7jHXGMR50Qw1BE2xcFLFg6p4LrgkSVAkMzlijZCjrL1GGN0Csl7Na9oY2d6dy4oes01BDz5VfD/FG8fNP8hPXYw0RYnd6PCZ7NuGz738aNhfi34laDnTr/rRUKaWARLIvH/1so4jupDFHUGcF9sd+/gdXDKtDww/HhuUPfA4IngVCbRPs4foS3vuOHRWSI8nGb1W3oOe2p04X4cv4GDeE5YSgFclwFnYh8uf1GkT9X+Qt7QFNDZpLV7QVgEHRJxE3HM8m0WF01oY+0FFEvJl/Nv6xMvJ+Bmizdh24DULIkNPOJex2sdXrzI9Uh0Y9vqBLjERkzpex/OPyrvrVo3tMDAIptNrfV0pIaYF8vWp2NcCJ3l0D5N6zAgrd8XS60AjKPS7LEo6Q51T5KALrzeNUwzAC3ky+5TNJKG1n0u52lmiUsp9rwFPv6JRMpjTaKlsmP/q8GsjCVHooGJDlfqhUBdCIMCO5gyI7S6pUSSqhQLrwb3ZEduNBdy3I8Ne3eUNGRaa12Z/vWpQx4HbWp4JEWF5UyNI5yLGM0XZrrL+0Vtjf5J72mvYipRDpgyYsOXtx0roLjXGYdVNyzv/Y09CxkmM6c/rgC44z5t+/USdv9zEH9jZ4IGzthU0ioKXNuMJfBkKpsNAcoqWr0xD3BqCUFlYcwl23shvVGWUReHN+E1f0VhSLWdsRmCX8WA9doJdhYhl/sqLMg39UtjIkt/FyvD+iEgLWQUideU8zsRv83E6mEoBmpiGA3wBes6FbI6/gQSJJC3daqbIP8uY1OiZauj6m91iYMbGtxaNfEx3mAde6z+51CAC04xt8sgkQZ8nPgIlzi6GGJffcVfrjsI+vO2XNXbSiW2uzzxWOxAvJY7NVdcBNzpGtO3TAJOcuZVU81Y+TdT4ea7O5s79itfL71YifpWVoypSnPfQOfx7aIrEYr9C2m/IQd2artKg7rO4jbINWrsjRkl+XOSLuaJhX+ygvdpTbDkPbd7edd4th+fieOBr6ffOcd9tGsT6hVBJZGVmsdQNQv+9biG8o9+la3tvmWxnWfvsWsHpJrskf+ViPnX4OkmjR5T93QzaobyN9D/wDXUgVYsqOeQCgw89arrZ5iMs93+9Da6IP1MNzee2LaTdnuPzF9zb9czy+L45phxKpKnRnbnrjmNIClts3t5Oh8bRRrJVat6ADvTuZM9jYJuxP00LfMKznU9bBPTat1mb0N7mpd1m7itlS/sl6eEDZ8yMyUb4EYmg38wqhLEQGYWW+hgEip/YqbH0HR7bbgdNRgLENDbmxyOo2+uicd3ZuBmzm0aI8uiko4cQzLOMlrFvoa1wm7BIWyqEGPy4HVDWuOe+KTMm796D7SwA3bbhkUOgg/IOe11rKHOi4HqMnM+DUTro9WNKuzw5RKGpnK3Kn6XkFXE24UVTwiHCwG65pVG3tYNMrIBZROc02LRiCQ0lxLmGyBikD9sL5alJFJhqd/jOzNxSZSXo2IubGynPKtzOL6rlbz0NxUTkqvq5Bj190wIpSzAljqIk/2FspCUae6uyUcqY2MaUNOzrC+lR6BI0KPSDTmDVe+qu436BmnvGiQVo8jcpmeqLc6IVfRC4neR5C4wQ8OOzKR9txAZJ/dliafHbvOofDmGISIsUW21Zu5FOF7xciVkDdiRMb+sLqfUFSJ+tnrqui5kV7sNRDgOIhZXA7msVg9wIg4aKaH5mAB2ZJ+ceTkDF16jpqoJjcszx2TC+vxx69OP2zyv9oCR6GnyTqdCamNvfkMFRlVQGO++nMYdpo36+u/ZlS5isvUhMiEGTfzagIxCZZAcj6bm42AvHWerdtLpkfo25+//Vd9uvPLv775effNPzb25ifqP87+SHd+//XPzX9rbEUgjQGsHStHfnB/+3t2bRSdTnmafBDvmF0P7Dmptev9D4J8CMj5QP5GuJjISmQfBCF/I7Iy0SfuykziJ9+JED9VAgj3g/ggfpszEY9Z0LKMWj8C08HLyykzRd0JzrlgR+FCiuwc8ZiBc0GSvSaQgAzdwTi7SRCGWyb2qJGKlEzxghmmEJAG0MvBVAPSgMD+F0QeN1k8cpg0WelayADbDbqZSnVDVcayy8/JJjw583HmdZtYd1yjn5y9rFTyYzfsY+vVdrKVbCVNKy2ngl6iOjUQgzk5OD0gZ547nKLm9uzeKu2en6wjcN0vsF571MP23PERuK98tzn/lnb8h+bQ+xw4GEg8p8z8lMsb4HAa/nLBmWHcXM68Q6By0Zl9a+rW020iWixXzfuTDE5OXE1gkthxSbPMcWPXa80yWX81XedUuIdjA6DPRkejJQwJNev//vrgFKnvj3Uu1v/ALwxFf2fUgo4c5FZWiGKmESDf9ITYiROO1kL4G0tznAD0EVQtz2SlozEBEM1E5ty4lk3ijgar7t7mdrL1B2EipaW2Jx/kLSs/tmI3WsrP74xdjchvXDE9p+oqWQsovy+swC4gcasb6DgB0rvBBY1Ak87RXzpuIFrBgPrvW6fM4WJuCyO4dTkPDPYYOq8B1ZLJgkhIqpMKaMzJvbquBuGPXXs5P0O46m98yhtglzS9Yve2jbzd3gSirhvkk4Rd926PuFv/0iPw+h9rzciJvv0i73YzYs7z6wGkrNXXLz2jrKVV5DzsYwKy5IjkwMv/SVOrw4XgjKBbfns6U0hCCHGmHuohUHjuzqrf7Eh8QH0ZEr6or2dnl/jvOE98DIkXc2sM53RhxYIqK0fEpOWI8PL6xTpPi3JEmEmTtW8P8yZtIX6gNFgXnvj2/ATasuQovt7E6aqerF9bLCYWdzuIwcg+UWqWjkjJC0Dot4dOC3QDn9/zPfpXuEGDm9+NAk87++jb+Lu76gtGMY+d5uglg95KjpeMQvF2LOzRMStip8YQSJcxw1Iz8uNjVA4G19074npTxncKpr3nsKG4btZeD6nhIdzHlxXEQSn0y1fQ8B2W2mryLsWUzypV77skqhLLI4BoOTV2usSXsmmXOfT2ej0iN2wCGiBn0JjfqAoS+xFdXIqNUsF6YVxfcsXLw7Xa/IM/wVZAdsPGIEUzgn87lxo0gM7QFqsHZ28canTyQ812An1GFm2KnT5vMWi7e8PHHPMpoWLhmRxgHdepA11oH2qJtKFr4f8OfMMqvA4WusyTNy725I+KVTgwOb54DVUypQAS8savUsmUaR1ZL8IwoZ6rYuD+SCUErFnJzOMDogOPD88fYIVncWj5o+uX/rgnLqx/LlGfqyPYwSQehWmjmg/tLmkRmcktY0Sa+FOKZuqtkQSj7/h04fMHvP2LkHOMxqeqaFic6qvG2cTbul0rLt/7TDA83+rzt4TnYywMNWwmFf+TBUiWvQFwAUlASfIUpv9gza2Dw7983H5nxd9nIH9nQd+zLBcv4TsX6TqLskx4KNuIY8PA5+U0+CKCse6O1REjw4GKeTCkNNSeKaoYBNa5y8KP7Oqh+65aI3LsXB31NXT05vcR+eXdiLxmM/uEVTHbGD2rJjlPL3EYtnTPt6fCvk+FfR8OUu+GPhX2fSrs+1TY969X2Ldd17d5qde+mC+j0/m07eGVOj/T96vVudGe1DryOdnXHST+5fW67pK/d8XOr+h71uwaa/jLqHZ+VV9Qt+MilUUciPFpul2dj05x1KZel3h21dHrQJ8Lo96j1x29+X1pVH5ayFYdklVXuem/44epBf/m4PB2ABrzDymlH9aZ0V0khM2qo0LhQbDhu3DnON47vNmI7p6zvJxWeVyjt77upnUkUHBWBAcCxWxJlteFbDCFU6oZFfxPlKkbcRFCxsnekPnIWMYypwBgKifClbOpIawozaIn5vQS4vPOf25sxFO1effDt1aB/Kna/FO1+adq848M/OdUmy+VzKr0EYv2ddJ13Qy33FwtEPX25mYDPs0Up/mwMdVed3eTOc28KVoMVpV/7srqt8usgXWeGkogYgLEwamSRTNmTrkGP1En1RCrXY+0KJlO+krS+Gh6Na7FvbG/3aE+TabhPyX8B25a+EPmOYMqNmg/sH/VQQk9OYIN7bku5xclaD0mUv8OAy9HcOeLggrTMlb1nt/H6TnpNyViiHUBkFpWgnd9dFD7+3tSKONxfCQIE4qncyQoCAFpVMwOeY2pLEoqvNRkxUCwpzaIsZXkGOdU6lDP0IqSkG1KlaJiBvE8U54b5qy9UH3ZC4lQ7gJCfgU86AXNAEa9nodUwPoKleKb4i4ZTDX4eld9TFteXKtvvgbZhmvqHK6pe0j3AoIyPf34kgP9ZCpbN+Dy1R2/S63gSSVo4eh2leA71gf+KhzikZWB71gT+ObVgDg5xtf4ctz7LPrqTqZd3/m382y447WhORauwuhbP6uH78TUpbt8x/Seofxro+DNQgKLGIfmf8ajQtGBMLQDBMd0gbD1WIb7/hVpdIkvVbjh1mblj7bjbk8e3Kd8UvE8uxyWGlcPXEpk767ZUw9Q1Ns0dfmQjiwCnwlUEb6JCriGlNFUFgU35PyXA4xSEBiFziCD2g/RUxBgujN9yfZeZdmLrcnmq729ydY2Y5ubm5NXe69evNh78fLl1mZaO3jvMWinc5Ze6Woo3nTohu8gy68Q5M5rpkKVum7W7N7k+farjL7ae/WcPd/ZfPUqfZnt0Ww3nbxKX+00de1o8oFWdNSMLoH06iYXCJC/LZkIdXiUnClagBKcUzGr7NqNdCSlwRW7oVjO6SRnG2w65SmvQ85JHfDf1A8QnZc6lW3d/hGdhxlsjZiRubyJFwx16sKOuiC7SjO1DiEtIzLL5YTmHbzg130LYcvoOxk1/S0PLOODLOBe+JqYy3nKhB7M1fEah3cFkzFXvI05f9ibzaMIJTr0IXI4hZglN2KssilZkPOzo/8gfrrXXBusH1MzI6k1n+SszrDXZfYRsuvdkHpjrctnDkqazlkYeDvZHFDS670ioilqypFNwYqaoTqEnVEzjyrx+H3jHYKKoNuotNoA0t84ZHlO1cZMbmwlW9vJq3ZnFCi5lQ6Fwl9kYUFGm0WYjLx/9zq4u7wEA50SuK5FEl6XKL296mAosyItL7PEtOx9YwWbJVb9oIqEnmIazUS698j29vP72pQ+YkE3ZxDtygLgrnThSV7ejEkM6hXbmUe+qrqZ0+YjBRW0rvBMXM6yzwTbJ6osRiQrr2YjMlHsZkSE/WLGihERFXz9T6q6Z16VxbLbOKwk5je0OUvcyWQ7eRUL/025/5j8Au1iPkXy/w2VI3ImlbGkT44/srTCP5+dHa+F+q3Li9VNi+QgsT1WZHXTNGzGlpZGvtpfRqiBp3jO1q2W0NVeodyZnBpyKFUpVTPZ8h6SGF70CkvNujLYA1d6RuMw6HtWZsceWPcIS2spFw9c1ovkefLqxeZmsvVyZ2t32fX5CtOXsNCh49DsKj+HRs/PDk5OL5Lj/zhedn3DOgjDovq8hA9c3Eo4gR8+Hhx7ZgR/t23RK3evPlp76qNdPX+MvrrbD7OUYcRP0e9FSamoPSl1h1WX+dps/wT1Jv1whGcbESm6Wl+N6udgcB/76UvotDo1VucydKF9EyicinCjWT4lVITdtasqOeaO2wdRLfFlwMB6i+DWwfTLWVFmQ4X/rh4oRReuihUgiaoZVFnQI7toBfQBeLQLohMt88owrDQaRdlB6dVwr0WyyRu6IBPm3FyImVJJw6ACq9Acuh1He9aRIdzHdZSFJ1xs6NDEd52s5+FPqyaGD1ubif3f1osOIi8h2+ZhAmNLE2NiZuZBVXfEYscGx96iv4q9C9uqsJlvXOHClZmzKLCfJlV6xQyhguYLzTWRwmrJYcjC3shhk8iN1ScCN4AWrlTFZ4i8gUKG4YUCNySq8c+dOo53hK50yVMuK123jO3IdTvLMspUZuxS85mgYJdjH7m+t97QRMqcUdGH+x/xJ4ywL+2QkJ9PwgxxjbA20KtGVWz1EyHHlnyDncL77IQpUwYNWr47YE98Y0RbvkVUqhalkTNFyzlPsXOOro9zPOo1zXkWZy1B66hKGz8fec3oNSOVqOsmuBYD/tX6FZ+nV48fhr2hmlQCjISh+XRcOPndu7fvLt+fXrx7f35xfHT57u3bi0/dsgrTVAbKsDnH4RuXM3jnoPKvelRJuLUyQPJSlq07ztLquZGKaVckqd7ons0j6ZzyOFT173bHUXaoX7/tPc9yrJwC5S9Yhpk8jQ5Wrg81arGQY9Mo0TFZQElXjdG7wJlYvkBjM9ofkEo7BPVZpx4o+zPR3M+zIHiEzzi2LI24F1qurWQ3o1xo07hiJ1xQtSCuqWyzZm33bNLGXtxz8B6Kp6KgIrtcsoHU1/HPNvfhpyrPPdzYsgpICe5L15jI3Zlt97uXesJcTvppST1I1DTP69u23fyscw1/ulzUkIfIOhRFVi25Z5kkfYhlGrD28+1xQW0pH6XvZgoZMhW83lyHwTrdA4OmwBuCleF0HM1XX2RTcgMh/40K6WCIhZxcDwgGIMDhef/+5Ghk1aJCCq/dkJ/fnxzpUXw/0qiudWGPn11qvgglprE0cKjcA0657qoPpdBGVanB/rGoNOQLN1yMOchhsCQsBSmVZYIpuHwKbvgsvmTPTo6IYpVmjVLade1rXxprCt1WcHnQN8DqkCNC7VWl2yFnxGdPWuxJbXqYbbqd7uzuZq+mr149f7m7tMuwPkPfLC9ZPtbjoKUjxbTe0JHuOM8t7HDzCU2nuzGQdiAUUZq6S51MjqXTmVVEoipVvSUpo25JEytuu0stBN/Wk/nzjl0nsP5tbESw/wAX7nEabble3EsQkT2KSZHtDsTI3hzt4hTdSfWcbg006/kvB1t3TLu9+2K4ibd3X9wx9e7W9nBT725t90z9FwkGW/UXCobxNSQEy381SV1AA3r4nYahiOYFz/vcLG2OUVJlj+3XsRsNYvx5uM1nGStujaYnq9CXtAo5xH+/xqH+BTzZiL59G9EtO/fXMRX1L/DJYjSUxagf30+Go/vQ9WQ/+kvYj9x+PpmRnsxIX92M5Gnx27cmDWMwegiKnkxKy2Pri1qWHgjWl7M9PRywL2idejhwX9B+tTxw37SF6wsZsZbHVjlbSt54UOT3SX1NOo4GsVmRpYvpBoOeMDu+vRYfutllG/plGs/eEbMeoty6ObbbO9sPBa4D3WNE1UNXcIe5VVL2g7r1QFCB0S8B661ZPlYf5QVrbKsT67t2ou3NrRfrm7vr288vNvf2N3f3n+8ke7vPf3+oBmTmitFsubKGD8LyBQxMTo4egwwclANG8Dpwe1Pacfb1pYsteqC5+V5kv8BGAeaWVGRpEb4foWKAfDXUlqM6UCumaxxSgXm9E1Y34d8PQ0YV7AglEyVvNJT3MaAxcOOA8BIoNPmhM0bSStmBcug+KCITwLL7UZUW8s8QNc9ZKkXW5Luh9VFVdpO5n28vHaruYLyR6oqL2SV2LJTqEZMrhqQfSyYOdBJAbzshOorDXBZsg+Y8XbrgZ8mS/yVJJyVL/rp5JyVL/uqpJyVL/vLZJyz535iAEiHgWxT8A3BfXqwPU39toT3k5H5DInm4ar+iwN2C4VsQpwNI37Sw/AlRNd+fJO3x8/XkZA/B9yMFL08YjyAi11UWZlwbhxWX+/gu/u725MefMHnRNYW1lOHzwv0AvoAfNEsnS6YGQt44VCcYiJ+svnXCFNZAIDeKG8NcauWEavZihzCRygyKaoXN+UmqsEDVXWBdW+qcmb/TvGLHH8H7+Y7Nfq2YWrjvRk2PP6RP6hJpXNbOO2hBhQ69cV5e2u/GSQh5kb41wqQyXm6px5wwY5giiqXymik64Tk3C4CldkfUznF78t8d/3z548npwbt/4MqZa2vd48j6/dcfq4PDzYO///rjxcHBwQF8xn/+bVlhB7YYb5/7gqM+rYY+xgRgnRu7vVA9DeZzVXLrbT0LiKCaWB4JUYB9b8K+uD3yBJAAWWjoxxOGdM8HIoEpyTOL5PPfR4Ds4/84Ozg9ujz/fQ3pIXYUBRh4KNxCoGSqq/OGU7I/KiZSbFTgJgQCtqO/ef/64gTmgrH9cNAjOIx4TRXUUSI5hPnhsKKCPnOw1pqi7ZhHv719d4QEffzz5a/2UwP0iPrabYixAWHKC5oTxVy4GnrOnrFkRsYrWyvjHrfW6n+uHO5/UIZ+UCy7NKb8MOHiQ7GgZZmwj2zlv5a22gDBDVTa+dxQkVGVNfcbL1THRXyQim6vEEli2VXM+fUQCziYTBS7xkq/oBV5V6Sdr3ON/PLvr98sC/AVWwwA7y/8mmErcn7tPMxyakfq3nnnb3+6+O3g3fGHWmPzLPz04sMhyi5/R5X+w0lhBZqfeKhnYgkUm9DoDzdcWEAt3S2t0nUKLz3K8iFox44dx+TYrRrZ4eCEAu/u27gPn42QcMx7EPPhiE2qWV1z5/4CORGcQzXWhDn8Hd/tarMUxLWwVPe/D7JS/dWddSJCfLRmxl7hBaPC2OtkSlN7QVPDSMmvJca6KOj5SknJWWqX4uGDmjruA4RPwQMa+/7UEbQuBltbIRliD8WClDlNoQO+vWGOD89d1AK5iEFwQ2sGtSfFzPOCYoSlvOvbSU4hrgumQFnB3Y1cRUJNrV/i4rkgY4fFZBxWcmAZZKqYCTFKFkNxP6CRKw/ng8uhYtxcahM61quRD3iqKcK3vB2RNOdMmBHxj0I3PmzHlPjq+NklLxNyMsV65mXJXOjayZnn20bW0PNyPMJ6HVh3SjikAcao68JzckaM4tec5vliRIQkBQXRLK4+xw1MRhXLRlbcC9Hy0VT7W6+2k81kO9naHT+gysac6qFKvx3kOd4RVM+ZRjKQwiJEecJykhWGDHryh7Y/NRepNKqXENBf48+NGuqicEE0N5VrwYcV5xayWlWWFHSlGMSx1fqWA4zQfCYVN/PC0tMzDLdlik0lvGEJyrJMuPQCAGvLtzUsl0Buf68riz7HoE7OetHXVKP1YE0x/EZCrKSd7XZo7uePVd4oMvbOf76DM9pnfB2c0FQqig8Gi4aLyMNAQbGoe16EvhJ0ZgV+C4CLjvYhi4TmTBlNpCISCsUJiYXKYGG1JuALw9kpovBJN9oNSOderkUVIAIcL2K273mKByoruAZ3gRUAlcxD1Wk9Cq05JTIycnJ0vnFydl7/ENpvjcgNm/ghSwwfx54P4YFK5S5wVo8IExmojyRjhqWYUiGsfGpZsmbk2fHRuzVXTTqEbTKTPqR+T2Xm7Z4ej9cnD4p6xj0WoLlmqVmVSbEIdXIRCAg3hb8sZ5AkVYyaqNBw2CtPWYEygCs16LuTpHVuqFp/HfeCva+KAPbmG8qneFA3/0MaQPHGDYVLdDHArqUHcliPhIAVy2Vr8vCxxL3IIAfGsKK06sFJJGO8ZvRqaf1rcPfjBTa5b3seYePdhns89C/yx1ymV0RZtVobkGVK6GRPjk7PMQL4l4uLs3OyQS5en0Ngukxlrpe+K4YKIz/ANZ4cIaPi2kdHW9XbVfeCysfIO5FRRlJTbWHwDLKXcB5EMFubSwc8DVtiOFYE8luqDd/OGwJqMCbXCu00Y3dUfHX1gH0d4CWWP6jbpNF/HdcJxiqfYbPcuXj99vDfL49Ozy/tIbi8eH2+7NqGLuC7+q5RtNdIqy7cnU8Y73XY3d77IPxq0WiHT6FpNkedDbtbiEyq1VVNMplWdV5GczZQKOzJXF2t6UlIU1PRyIq/aeSdoSTn4grWQwoZ9ilHhwuiYOKl6vqac7V0Qdzp2tJ8MWImkht+xUuWcQr1re2njU/aXitrsaH89actytXMjEgpc54uRiiboEyArlx/61pFAU72g25/DOgvWN0NLjYhOfPe5Zlj+Zc/oZy1LJ6q6hvh/WB5kCoEAQQcwZWg6ztBj1qXAWd6qeugyTC718LW5ib+/9IGokGDei6iPkQbRLFrrtuiw4TZVQPtgF7vctW7S0vuWVPU59B3E3ZK0nn9zR1q0oF7zm6y7wBItfNFgKnF/iailv+pFMJtzzSI6qj0EMVmVIHhUDNQUPQoeh73f8LRtYj8dJrLG/AoqazWmX6SilwcnrlRsaOvDmAibCnj13UAChfccJqT83+cQqFuZp7pNfejG9QOWMOCbgmkxSB0tWdyDDJfdPDxQ80FPF6MokJTNzjY0JwmRGhqKswvc91HDFMFWQnjrVj+AbdaNKyHQrQA1wnQl/vZ6YmOeTPfkKa+LLzhDVv8UJfypltTxOtwVpbzxgSoQcMq3IhRFiyoof+sBBIFuGbQLube7husRq2QpjPkFFiw3cZ1OJxtpfoQh9/wS2h6f9DAQ7OMaFZQYXiKjpKPxrWvZh/TORUzNmowda5DB2sjyTW3y/W90LF5oYBkX9qwGnnLngpzTK3q7McUvoc2XiRo2nNOOW14nhOGhibMkHUt10UWmxkBYVMedeigZalkqTg1LF88RL1Gu+dQghO2CIWrz21M3ffcriEwmGLCZ5WsdL5AaoZ3ApcHj6IO2THQkJQKcnI2IpRksrAbAMbQSvCPREtLJwkh/6gxS/MbutBoWm5e2fTGw+Tpfpy4L8aIsqaMJqwUVTtRs8pn2YPRNuHl2IIyThCs8YhkrGRgnybSyQyk7vwPVlmuW8EsVCdL96e9LZ7FJf3iOITm0ICqLq9MKyOFLGSlfctDwHv9dQDQd13DgZ4dnJ+uddJs7b3NaDqvbU2ISgyGZD039O7Wi1ftNTeaXX7T6VzLR9D09rdsoOJnKWc5I69fHzbw0ROYskwwZPxas8ILhKBAaihU7474vSMJZNHdrdprNv9Cwr4Hsk/ybyM0OH7TLD1jMkm5WQxVZOSQm0X/7ryRwijW6o8E4EhhuGBisMInp42CJ26yDnynUpk5OYBgCtoDZCWMWlxyLXtSlh8HdTgFOTl/C/nFHQgPD24Fa6jddCD1bughFTTrYsr357sHnBmTl6Cc9837WooZN1WG93VODXzoxtz+N1nJpVjZJ+svnycvtnb2nm+OyEpOzco+2dlNdjd3X23tkf9Z7QA5oBFn9b1mat3fxy0DJw3tC0eEoskBpTA5JTNFRZVTFZc2MnO2IClUdrBiZ6PQgrs3TdNoxF0b55QJdC1AtHwuMVJowlSdFO9F2/qGQvByUs4Xmts/0LA4Iqk/1nEc1qk0Fk/2QZTAsWt0ZWQBF+SMydCssWPdmEhtpFjP0s7eKDbjUgx50t7BDHcdtPVfD2+Da6Cj5mDqPWm/VmzS6oPedmR2YOh3Yq7WHvrQMst1X68pCx32rY7f5OTsesd+cXJ2/aIWPlvyVkHTAXDz5uDwNqhJwzJrks9w8K5eWDXTKV6QchErChPoX3l6cBH0b1fxgTvJrD6zkpSKX1PDyNGb39cimbd5VkCbyyXNyITmVKRwWiMHoVREycoe4haS7TpLuVRqw4NSCGIE2PG/YRSgBvsAqa7Th4uZT5PhWrkunW34zDwbh/bbSBwDFpli2WWf9PiIfd4gmHA2Z9pEk3oc4dwjWEhZsiyAXE280Bm2POoRO4oCcWE4p3FOpSIrUymTGUjwSSqLFcI1WYk+t6sIohfVBRdlDGu7QKUHlnJtNSrXdwd03JxfuTQe9BDqajrlH8OI8Aw0ktzf2MBH8AmrSa0l5ALDe4xE88BHXgRz9GSBXU4XxNCreldRJ86pNsTcSJLTCcs1qt9CGkgFwFpGdu0Xr490iNxdSWVSXa10b8waGQ2SMLK8hO3/AhTBplMGJezsrE5ycXv4jF28PloboUvkSsgb4W1hDbCIQ/3ImxsBRSWtyd6NhykwHeJpzxuGtXisMQTU832TDZDMbRRTb8RytAPfN8im0kwlw1JMrHfVOS8hcily4RA5vY1jUEFeHx2c2avgAFd8FIaKSWW1uzpWUJ4PtDgr5BOYwEsm3fCvZFrl+SNn/n4184td8KomdkkwHagRd/jV8wlThhxzoQ1rNd8H3IA19asRIDrUBqdAXORgzsTbyxE6h6HzJ4LdccMHsvUQKsI5oFIc7wRO1gViwNBXX7gR+A6EmRoZde2LIw8wFhgZlCBUSLEo+J9RcBqiMHx8j6WM+ZSMYRXQrU+5D3Z149BkMJViinvVjnYQUIO7dtcQX9mxj6juzex+FFIKmhbM2YXi8dTgr8bSzkM/coKFqLnoLjriaRR4Wssz7MuXRK5h/9XdTSj92x1Ho4l/w2BJ0FHq+KeMGuqAu6GapDLPWWqijuuNVpWhTeWUiwxpLVB+LmfakXyooennhrQU9LU/wA/GyjkrmKL5gGVYj/0cMevz8W0e/Gd8CjYMLOi+1qlCngHxgC6KLkvtS4UqBkn+Guuwjt2AcLIzybQVx7oS1h7dme5ubk4byBjkqPZUoQ3xD0JghABCjIFMNTVBa9CiVFxH/ExOMdlEyIw5c2FjybWHLmSqA8GAXJqxbnn3kLPaKSEbA+MyYwt6xTThpu7nH3PmWtK2dGoJ0jdYhYMhWIdqmykb9sBY3YKnVU4VwBuGZAU3vmRyO4LsVBrnNuaYWyKY62DAWP2CxnPZAAPiwmUD7XW8ZuSgxshvvKGpIWP7nrsu7O0BHy32QX6iPQWvs+cv2S6bTNkmZS/SnVcvt7MJezXd3Hq5Q7dePH85mext77ycvmhZjgaxXTYELU9s6NePuBNgqxWmJ3pehDKr7mTCPQyJOY5eaJ7LG9z+jGuj+KSKI8fdGC4FQFWQFBFMmFDot3n1o0HCR1toQyFBFyxd9QkRwcgegX+C36ZUwwqOrdLGU5cR0zhFXgpod8ZP80qbTrt7K3v+yKjRfYOg5uguOKifXIYqAuFRu5HjWl7BLK6pPRiA7rj6dJeuWLyOdXfcmkQkMzaoA8VTEw0kAVO2+ExECeZGIi8KpGRH8C97ruilYfsbHNMooDSusAFpteDEx7SjUbQJfumBLdb+j4mvmR0GdddJgMynmPnRlqOlFkuOQOhSVAsA+yzueRRd2CRUR4OJBcFO71O1GidZMi1WV2upa06vmfempqw0uLgwG0IMKPbClQPS5StFDWeipA8JJ5qLWcX1POxafSjhSNv7glRl46p395zUFlQSS9GuzoLDi2DaW6wDS6iHb3GhJtXUDMZTzxpZR64QcOwWVVCBIWma9YgJfr71TfdPqzm0jlI6H9WTi3nCOH5rrU3pfqCcexB5fcTzg+8JeDGiGggLBh23R55tyAnhho4Ec7+SaJJjv0EnUxxEqjAGVawFXfuE3sJ6b7zkNG5w1fE9XLexHb3xtI+zI39vFsbzGxKC8hq6RXdXah5sJMmlvCLUXkmYiccMNkNp6RZRLb7A3bvYeJ5sJzuxngWxew01q/7mDi0Ln7o/ktMHB2JPA3AObTRFwuZIUcjmPcGasfvMRWx+kyGFLjjyKaTwKaTwKaTwGwkpxDPpK0zVjOQrxhUiSE9xhU9xhY8D0lNc4fI4e4orfIor/K7iCuGy+O7iCh3UZMi4Qne13xNPR3MXhFafWhlC7Xpj6qJUNmIUBWVLzL75GMNb0ZF8Jj6+wRjD5YW6Lxho2EPzXz3QMBY1nwINnwINnwINnwINnwINnwIN2wT3FGj4FGj4FGj4FGj4LbO0zw40hJ4pCIxzgF3U39zhAHP9HiwN5lRrPl34yCVs8g5lNmmaSqwsA/WrcC5i6EcpZOFNRv7itzC/4UYxcnBx8X8O/51MFS0YFOXtDT6E+hpSwTqbgLjZQTWiobYqV6GKJ+h+bsyTo/MROf35p99GUPVyzQc0hA7iHlz0lOAaEgNdxZO/ARS+erMbMS5WavUPJ+yFslRufxw2UA9d4UVJU7Oy1pyFpXMg6uRvXv2q1x5qRvv5XA1bLkCXAXGNpnMoBBUqQYINzYDb1dM5TDWCHUpTWZQ51xhlNJM09+BFVUSFPfpWt0Yf68raA/yOYUu/AI92+A1TBu/+tFJQQSgUz0SbrSefhhiL+wy/h80IMZHMqs4Q5we7RX4KU7mxeMOuTLzMHnqLQcAVlM0Ss1CClTAr4GMTCkO4mFn9FRvOS0UUM0rqEiXnPAKWzma4PF91p3Xy35xcvDt2R6upfCEpD3bDW3rmqF4jMhvU6HH3D1c821dbijlBWOQbahT/SC5wnGbx01HctSghz9jHJNS5o8bQ9Cop7JhQ5w4h0RsXB5ubO5sbYYK1NtbwgT58fSFJI8S1LI+7Gl0xN/3yuEOW1oe7oYtBXsDp9PUgK5V/pxh80Ai1vOEvjS9xpANTbOIV97n/VIf1PjpePTB642Jr59Wru861/f0WtP1FtN1GEPR3uk23ix237N3X4SxLY7chWwzEXJbH7oPGCLh2ZfK8tuBqxD6kMxz9/9l79uc0cqR/v79C5fyw8RWMAQO296t8Ww7YG9/l9cXJ7tVtbRExI0DJzIhIMybev/4rtR6jecFgg51NJbV1Z2BGanW3Wt2tfkDVbLesY06xnzE/Fcbwz2rQmoKPiCaChDPQySh0UoKilOEtwjeMQv39dkCWycIW6MwUNgXCV2/QOTPKOuGJUtRU59ctetP5dLnYWyeGa9XFi8YBKJG62qqaUrFZkHL7tQ7BdVBaEngvrycXo/GLi8m76/PJ71fvX0zOL64n3d7pZPR8NLl+cd4bDP+xQcLYlasKFg7u9oSFtxev2qYHnUhwHLRxyGKSoxqD4Hpb6V7DBq5yy/pgA6moyihVdT3b5KsfpoLegID8WF7SxF9gGn9Egsa+9ni7LYqQuiZQOWC2ZGRIRTlO59XVlec1biRSB8meUHxuGvi4uHYmL0XH57CfmTYLiMasp8WdaJAFPBsq4ETff+STx2aUiyTHFiYTZmEDyio6OuQo074boRZYLLwoGOyJPqOcgIrnhC+5PBGzEsyvxgMUUDAT2QyNL95ZMuYjvCEhr8HOuVRZFYKKhMS+vk1SRXfB76gaPLWcs8xeSmVEUZ7BrJNiulwSDlkogK/iFulcngxHJ5e90WDw/HJ8Mj69OH1+etl/fvn8sjM6uxjdhSZigbuPRpTrF+fdvz1Vzi6Oz47HZ8fd49PT09Nx7/S0NxyOeuOz7qDX7Y+74+5odPG8d35H6mQnzqPQpzcYVlPI4tDJKbg/hbJRFaV2s2+GpyeXw+HwvDPoX1x2T847pxe9y1532Ls4f94fPR91xr3h4KI7Pjk9GTy/OOk/vzwenXR7o/Oz3vj8snFrCr1GKkS6N5VnnOVomeaTUt9Pp5+Ib6/WFQTmE2hyleeRLi1dolIRgaPXz17djtUV2DvGEjQ6b6E3H55dxTOORcJTH3yr7wmOWmg8ehbdmsCR8eiZiWNojsBP+Hhf57i+FILU4iw8X82r806lUr1gKxWjuSRcMptksuvrl0eZoo3QAseBWODP5TvRoE8G0+5pMJwOBv5Jt3fSOz077vW6/tlwinv9bfkpZskEz5JGLFXXS3+ME3L0nkbEVZahZa+uZ57TCgSKGcQzEb1ZA7mV3b1Z0f//p16n12135H/vO52f4T+v0+n8t3HPWWe9U0j9fMAFa92o8WK7ZyedXSxWVXTbcfBAoV2dYMjHYSjFZYyuX19pqZqQMMyVy1d3Iwsmklj39yt3BtHYowJh1eNKX1xpq8pDv0scO1JbPplr3FJofjwnEu1LqpOE3Jg8nSZUQv5qtfJ0xp7ns20RrkTlY4rnkkDOBLFFy0aBHN2aDp1vPjwb5/rp7EoOi3SpLm8myqTeVyqcta70NNW6Q86WV98sSBiyWrulxprvDYaTX0evpDV/fNqvePpiNG7w/E+e5zXf7CkvNqLetxNEzpi1YYGrSsh+VzhuKVmoeyNWBfYI4i97gyFv3HmGiARPQ2D8BiudMhYSHFct6Ln6Cc1CnFsWnRlnF4rJnCVUcfsKQ1ycT4SYpSHCsZPTznEsoL+V9qnFiMQ+v4XOfEkaxyRsbMjG5GsyMe61ByWl9emp1joKbhJ46C1RhNXNhJ0gScgvPH99nnVYf2r8mFJ4UhyrVlZYCDqPpeQQR0ko2rASqc3LNbTVuLU/eF8XSRQ+weEybhsY2zQQhwX7Svfaz9T3kK3gZlmUuU5CebSxNZAbJy3SaK8MR0XBEQsMp+eF8InM1xUrT5d8t8CljdlMV539Jr2GGrZtvYblJT2W17AOkn2fa3vwGrq0uBMNvmmvoQb3u/EaGmr9nb2GLk2+D6/hY1Jl117DAnW+E69hQwq5xvrfzmuo17hXr+H1Vv7Bkl8wOyqcmviP4B/U03/Cx3szRasdhLrL564chMdn/X6/i6fDwcmgT3q9zsm0S7rT/uBkejzsd4Mt8bELB+F7GkkDLlqW/GXaOfQtOAid9d7bQbjtgh/cQagXu19/1XVjz1RBJFeIAGlZmp3t+SzaiwjYb3/b1ynUCcnlKZqTaom5MPXH5PeM0zmNcajt2woO8HqNia0n2beD4TUU9qR/kUAZ4XD6Wf8CuCvdZW5aYrKpm7+Nh+LYN8mPJibK+ao+LmqcFRk1g1TXrIUwpr+IkcdYmTScpfMFS83uwSiiPme2wjL3FzQhijNxGErDRprAN5SsMssqC/jXm8ABHDmpE4iTLymRFms7YxLTvXdFpuZ3Yz7NOIuTNomDQm28tlzOl5RwefBA+3y9jqxmwxT7n903t4jHktDvMei1vjiymjjLpzpX3yhwRbY2nSCjMnKzxsPaVp4SeeqghM2J1P5AM7RDZpl8Kq/LIFwexKEinlN4MiG8rb06xMFkKaW2P52d9WbHg5OT6XE/wEN87JOz3lnQIR3SPzkeFtFrWyU/DpLt9AVUm+9NPrZJ+rd1aiAnIyJYpFyXbYAEH1vYWaTOVZDUoC1+IVpRnwsl9HU6s87wBOPOFJ91etMTRyqkPHQlwod3LzdIgw/vXpr4R1NaVN9RgJMb9ilJiG5zDxvvw7uXogVhkPpJI7EkDqacQFI2CtgqlizBkPAXJCItW/lgiZOFfp8h48drstH2m/GqlW2TxcbDVpYbnr8eO8jXuRUsIrrSLAZ8RvhWBetqB/nVW7naI4lCiVeVThvetoAjWJrYqoJ2VJXBf6Vv/eTYKoXfqUmjKnHOmam88VFf7ekigiWmqbjhs9cMxhO9L9S+X+ggW5PPKbQbTAonM3mFGqB3g0VLysNCFdXCEFSoGp2CQJ1zmmiPZ0tSMWaJFIX8FuKnF7Df8u8XBg8JhiTCJeGUBShKRQKDTKWs88M0IEFFmQVlI8PDU4IOlvH8IPNzyNcPPPldmUJLfQI6SWvzKCsOs3OqvGU8cYqlSqSAyaPY6clHh/8TtjwoIOfjk4/KaMmXoDBAF7JvZ2m4QwXs0XIbrmYqi1+KQEiGpJHc0johEhq7p4JkG/bW8ZVAMdDMxqEx+ij5WY73Ee4OwfcCG14XOBeIE2kdgaovjWRubAej8OTrlrpVbyrC7fMS4Od+//hIVef95cuzXLXeJwlb5qhnNuR3QMGfPsQRC6BSfCZngPUFEoTEOcyWK345bRRiW300YjFNmFTnlQRgUzi5A3sYTIkUNZpxWqoeORYuK2C4bIU6zWoM+SpkECQkRp9SKCWUGY4gu+Q5WqzRYjnHZuna1+ywGDT9FRYW0FbunK9sBnInJpKj1fyc468lFsLhmp3fy+nhC1aFV4Ah2VcJhbc4WRTmdmSrRtBBAZw9VCpzK2SV4Oj3j0uSo98/zgElTajbfSoJMIFmYltzEeBVv+h776o1uHr0QYHZSmfXL3B2wX1e4Dog3FmgBr9S6KzWEjP5LuxQJ1FN+e4c2E2bGq5itWC+aZrYp1rOZGqxSk2xI6pCSjEi0TLJ4AHQ1ZMf9duFAvK5jg9oSpIVIfkQhmTFlK5aOKAfuzqaFME/SqN9O6XRlNG2Lya4htHrZSKcNgeFc1dlQX78uVLvVPDWnFt5f8KPom/oR9G3OxV922NI8Qc9fIWO4kKQc+6Yzxu68oHjrtgxIldDyXaNgEeVeguZs+QGW/tC+xnyXSR0kq3kD2ihA+3poBC2WxBXfkOJ0CeqqSSFIgbVarByEdPAmMnGEYVjhCHeRyvccFoLxz8cbVEC5rut1/eYpfp+VOmrrNL3vRfo+xvU5nvssnw/KvJtrMj36MX4ftThU0rFBM+NG9FRLVD2bQMFQ41h1IysDy2LiC6Ih6acrZw7RLe63q12dIkFWyEpvGK43jW3ytC+zGeRVA6tra5v1VMLqrGTt9AJiG1E+QBSQs9WJAl9uzANmuoZcy8AZagrAXWNZ5jTHFDfvBO4IAcc/pjk+KO41lfsLxqG+GjgddBTRY3/QaO3HzRl0Jtr1O1Nusq4eYV9+cV/DtH5chmS38n03zQ5GnYGXtfrDix4T//94v2rly31zq/E/8wOkW5Od9TteR30ik1pSI66g4tu/1Sj+2jY6es8DYt04c1wRMN9ed3eXCM1PnpqbCJOggVOWiggU4rjFppxQqYiaKEVjQO2Eofl5Fx4sgT393Hl82ZJOHYKJRrdEKwRE59rQ285tEmpaeukWOcV+4RvSBFbnwmPyb7U+NIa1GwWbBV6gFd1O6Tv9b1Ou9vtteckJpz6Rei/ExOghtbmmt6hdB1x/1PEjNFOH4qyZj69n30SJ0y0UDpN4yRdt4cxX9HSHt5vaGAJ+Kb82O143aKk3C+ohcaia05OKd0d/eom1JJRa1a/vTx/3USnks/lm3MqD79tPH/a6XndLyjB86fi0O3zabwoWCj3FxaIxnOIGZGqOVF/wvhYCOarbDrVzjk2V4JgL4BBIVdtSww7fU/VZLoTsq3+pZ97rW5GPbn6qlVw4jMeyOFoPA/1ahM8h1KzcIWaQiACJA8a4jntpL+0adz+gkjs46VIFZSipc2dKshQ7rbTtuLSQ7uFcbG91hUkFozrSsT/JeRzC/1OORELzD8fwp0llMLV9XhNZ2WOZzPqlzBB45jwWqqqIZB6SC8uI7BAT40rTY+qf8uv/7BmkeuXlytKve0q1ywvV5MAgnLMPZW0RIOAas4y8OR4BdogBSpcWqMjwfM5yAI95JupyfJwmNtwr+dyuc7lreA/87ge0vK2a85C/LrdFTqU0hjBARU+J2B0F3eYHhMgcMaro4vTvkn3bmopi87t8rSFabM35wws6GqsNEVdiFrHsVvsl+X1PzYcxA9g+bxZqoKNagVgMm+zBpYmggZk/UKs1E/DmHA8paFpUWjEf+mH+nNAHgO5gRo48XHF1Kjk0TeJ+zf2AGtUd1IXkt8TfXLt1LVCIOW5G1EOC0lKeMFwu2Nrj5uC/Tr0xqhEbbu/n85cH+gYzBc51/WH64tD+QeouTiEB+2g2Qs4wVM4iTi61Pv2MHf3ltUG+JLi8FbMU8wDT/3t+Sw6+rIi0wUJl0czNoEIsvDoc8xWIQnmRA59lFvgxNRlJcJbJNEf/wcDWcDyyMie/fOwMjrIhCaa65Xy7ddPfxyYdR38uUX5nYri8/sohJufyCaV5LAgfMYzzTJHnMxId4OaIBkJKjj4N0IclYrWjn67vm6KCQfib9YqKmG10H+1jFLYfPrMEvYIxyGchu5sVW/XbA//hjj1f0GGHc3wF2Dz8Il/QyZwmzhxgBMTnxOckOCPETTKsNO6spUSdRZffF0yISXH6LcLd4V/luh7FaMI+2+ukUqDQz2v2/OGLTeMJ48OHSj47u1oiyx8EqcRGD173SBGijo3KE7ZGirWkKa8OapIVLE7LpqiYM/V4dWKtWh4ejU+NIETuqP8Mot6rj4skbrA9tCVe+ese9AXJ9CDmvupMl6Lp0dT1l8tcDKhYiK3AA0ONa8XedyOXuL1q/GfFTRq9zrds3an0+lsUQ5mv5XNzxEnpodonYDJ6c9a2qgMkogmdK7MH4sLQwzL/UGBLkXEVFPEn9P2lMbyW3Dn+XP6i/zjmcXjsNvdAo2S8SZ7ZX5tRTKOhI/jalYtLV6upNvpnnrbMIUcPybcuyFxwPaVYf8+3667dMADCEiBUK47TmI8DTeo6+6CGCee1LwaLGYWMlzZjP2nazmMCofhOJ7rq6+O15Ead7fjdZQzEf40tacWBEVMJEiQG8LdWPPnUsUUekQmrU+psQlBhIjgrg2k9jJkNDFIiUjCqS/QU1VaH93AVX6WfqLCvL9Co/Ilpzc0JHOik7n0LXFCuMpqO2zpTirZqO6drxzDjitfm3MYFtpwqagJgOlQp3r5bElqlIAK9cuo6sC67UDX4jssaaoDb7AdiUl8QzmD+lyNrrIeiNYXLlibiI7jW2STGIBLNIVa6C4UggtZygnULPsGSJSQaMn4t0Sd9xqiTYSBu58IJ6lCtERpoEvqwSpaufPa0Mrf3b5oiOH9+srBkH+NjbclJ7Wt6fz09W/jw+ywl6YxTXBCb9zKKDeEA3/i+DON5+CiPnjJVgctdPCKBDSNDhQ3H7yg88UBkECaaeimJ4lqxacdEThBFB2QqgSDnSuBqbKxjr2Ojsy9BR9iQGY0zidyyRGyh3M0crgInqACsVUMdWMDFOEYz5Xv6fLq3fV77w2ft9BV7HvoKXwhhSf6cN1WRVJiBlUBZ9Qxtfgcx7Zdy2rBpDCgwiRDJgwtSLgEuQ8edUF8YE6p2YKckNrXksVuixiCI4Gwz5lQivOK8TCoYdH4JvBiKhJvzm7AZ9HWogjYtSwM1OVIM1bVJNmjdmGpXqlhQFCrxB4ICnMImvYvPAuFQPIsZZwmmhCIkzlW/ScdEXA3DJaUeDmNb6euxGJbIuRnNFXtNHHsLxhXH9u+MZm1P/K5eiaHmf+FsUcm50W3o5xCU0N9dWGiImErhaHOlpPEACdclfdQ3ZaZSsg58uGQ2my4JU4W5mHnwQoA5b8xWXLiQ3OdNmReZi+C7xA+0fzFmRTb8yyG2cA3hcaXNCJ/mWicevCU47TwcETnyrD/GSU8JfnRFUZywzK3CI36MKli55qlW/qA3gZnyTzlQBQ1WdX6GqBeUsh9bu2yYNC70nTtyBK5Agp2eDQWCc4M0I04ghLl6l1k3kU0MNvCD1kaZDtgJD+ag4jLbY4DnODqTfFK/6q0CT/3Klis2UUCDoIJPDAxQ8onfSKEslbMHsmtGl7wlpxJbsgCbLMUcfVL++t63nCDvPQrcqf+CukeasXKYKqYnEZ4TiqmxhFt46kfdHvHlfI0m/1KjoCuxtYQV3gypNB8+QSdSxaBh1gYuDvEACQR51mUAJI38Fjlw2v5zJnDAJgZ6eunsQuyz289U4NtU5ir6f5xZouwv6AxAeHSaDL9gue80HQu166YNJCk699qOqvm8aaEK+2vpvNwMs/U5vVz5B6tHN/Io4D5n4FXtUAam88V20v9hkSC4RI6DFWlHZBG6je5r8WC8WSijoRMszJ6gJqvbYVRzXltwUIV14P5V3JCRB1Lbq/1amQ5CKt+pRJpNVNJibP9bCDpnA215ayFN5tNevfpdLIneoLevxm/+Rm9YCup10QYyhwL8ksJlpyGgdZrGaheniMr0xUInuFcefBnfPtCfaoY5CqeMZdb9bEgX0dG1jgMKr+vZE99blyMrt0YGmqiRjziC+820vXnn+hLYKw7okvjKXuzkKzBbJGZek6vJ00uo6K6OPom9M4yjMBVU0b28rxMeNOUhuUpyxS1p/dB93Tc7ZwdNAPnzTWCGVzHezUgPgtI5T5YB4tIOEn8RXNgzCwqJSu+tRz4OZ0SHpMEbkI0H/7b/a5i3Ox3q+zlNbdsUORy4Xqpmr20UbLmgF7Pc0WML1lQLXa22swOBpZMtVQpE1dOlVbI8LvO9JYF6MPVuDyR/F+xxP7uFpWNWJ6MBSWRf8/JTLx3eTItLv95b8Hs/DyJ8HJJ47l+9uCfDXeRA7E+SCK8LIMMeVvqPu2bg9uBrRp4TqD1iiDJbkmcjVtD6IAsQ3YbFezb+0+cjVszsVQEySwNd75kZ+CaqTfoQXed2A67cdpqpe/+86px9QGjZXl2ury1X1SMq3/MzhVr1FadA9nYaKtDgHxtqnbqGTzylfhp4tyHogrVU6/4EwvZZ4rbOE1YQAVcdWTL/5f6FY31L7fIfQ45lvdG70nFUO4prOGwQ9b5FfVznnIv5W82tnCnmQB/HdDBZhYAJ8y/ek66zhldM90F9hc6a1EVIrThJbplnK64QShUhbORwrphl0gwT9Kl8fGogVTJm0hFtliHYKILLeOIJHJhXN92Ad1IAiq5KswAX8iPLR0+AaCBjxyHUHJEKLf51duWcS0Bu9OgBXnIcP2VAwmc5YkAzFSjUEfbLjkLUj/ZHpEQD2j3rh5Gqol2beumvTO75Kb9SdjMlafOzIcbpnZCJ7acWb1rUJ0t3+EFgXgax6r1VTUcplTs1rN/ePdSF+uXpgpMp7kVIFmHdD/lzXtIZbP+bosjmvWtsLAsrk1KnCYLEic2KlQVsrNe38LFx4EOqPoXS3mMwynByUGzi5B73IH4jJMgjZa1Ir/2rNJ1ViCKb3qro1qDthnQJPQtSLjMYnzqDpA0psn9Ds5zRxVjMxQRIfA8O0Ul2xnQhJL2OqBbTu3Eaeey0B8DLLg8cYDKWBbYIrgzpdzcDjPYJrqU1OvytBsQYKdPGAoZpOpMyQKHM3Uo2PJwErw5x5HnvF2EyoUMp0GONvXAbQQQ6CSHMxuJzdxEhPXwuDBBsYNJ3kTNw+YUR3T/6XCXoJglmv1zElc6nYrfNy5Q1w+GcgwfrsZGUisCW7WsdmmCiFzPpd0v7PjuqwJeMCA2WZmVflGRVHVbfPNiMmfSUUinR1oemv9H7bbc2Afb8aU606NIKi0hjd0btPpFFYyffa5qy+W4l4CZAVC9ikoD4X5r2QAd1ETVwTfrINwOKfY8KUmF2o1zr1U03Nu2KNEDgfXrdmAtHwist9uBpSm8u2PnWkuHex48bBVLbWUfB09DEeyy3SrOFL4yrJtOkuqdvVNgM1iNdNZA1UNd0AQfHGQ36twALWFaA3GFAvttgG0U3LLSnSs5gr4ldVNVSnqoo0iiTttPauJc+ZY8ZCKdKsw+AnB27jXwqQVMxG0U0vizeCgoz7NcHT21uoRBS0bjRNd0zWpgqUsTGqOjgNysXYh8cOKUZn8QfLtALm3tdirMD40A36VeeCcG1nX+xYKthK6p5lAg4YRA66cVkqpUWTr4LCi7me8iG6yXKiA6r9q2YS8dv+uEwozuUDvNlErPOxLcP/IZJ0cqHpt7/h0sh5zwVfls0FwGmj2oKsVsjqgwOCBBNf/M0thPykf2Lpb6iU0nIZtPRIKTVEy0e+SeazXwar+1XZ1dsp6merXSztqN7ukU4Ckatw1WBPZe5vu3DNt4UdWXOuhbOlW/WSfO38qHo/f2d+jDWbeyHz6c3azqhw+nsQ9nZ16JTBJsz0vZvjeXnepECNl8ro+Dtefbzjw+u1mEqp+tlsBT3YZv7d7YmSdtNwsAc3Yb+H28VIHlpGgM3VvlznpKOJNUCpxqj9b2l0q2MNLGqyQa3zB1ETppFMNXj4xs6572B51Zd3jSC8iwP/RPT/2ge3yMcRD0Z73gpNMwogvKdFjw3DwRnsYJjQjyb/0wK1Ic08TdZ3D1mylkNK4wXYr6zH1WfQR1tEVIfQJ/tru9477+rA/Qds+D9PYtEOCzOOEs1BsSjEwa5xw3C0o45v7itry+Kgdk5a6sX98G8GCGnNZTdCdBlYk6f169DrQ9JTZA2sC7aKEJaaMg0yZcUeCELShvwZTv1XjmwJt4f3AbQgI0XQtOs4v5JniL5zT+6um67ltgbbNH9i6hBPul9JbuWGgo7bT9awY4hO5VwS1uRcjmDcGFXJK8aQtylhOf0JuqKIZGqRMNzjOT9rDpQJsyluzuKAuCU//spI9FMOt0gynpkVlvGJzM5Be9Yd9vmighySwhc08x+GyQWX1YOfpAyOb3Rd9Gz1ptNoHKWr+9+zFSqdRtwJeZ1YBv9Gd0rvEB1QpwQt2il+XtMsN+vmrXgwBvZr0n8Flhth0xtEi30b5KTe63WIZVslKRsCjHuzERiQ3vq4a6BrJzPqUJx9yWOHWbfWhVmhQz2jnBwQTyxBNciKmrS9nXlQP1L2vTPm1UZe32rNtW2Zaufq/qXff9BBctq3UW/KYIBXng6JIlUE/bRAeb9LL/DwAA//9S+v4S"
+	return "eJzsvftX20jWKPr7/BW6zFqHMMcYm1dIzp11rhtIhzUJoQPdPdOTWSBbZayOLLklGUJ/6/vf737VS5LBJCivj55HY1uq2rVr16793n8Nfh28PT46/vH/CQ6yIM3KQEVxGZSTuAjGcaKCKM7VqExuOgF8fR0WwaVKVR6WKgqGN/CcCg73T4NZnv0Oj3X+8tdgGBbwW5bS91cqL2L4u9/d6fa68OtJouD34CouYLhJWc6K5xsbl3E5mQ+7o2y6oZKwKOPRhhoVQZkFxfzyUhVlMJqEKfyBX+Gw41glUdH9y1/Wg/fq5nkAT/8lCMq4TNRzfAA+RKoY5fGshNnpq+CFvBPI28/hr/UgDafwyur/V8ZTmCeczlbh6yBI1JVKngejLFf0OVd/zAER0fOgzOf8VXkzgzcjwAR99OZbPYCvN3DM4HqiUkITjJiWQZbHl3GK6APoA/rnDHEN/8WHIvOe+lDm4QjRPM6zqR2hgxPHozBJbgCqWa4K+DJOL2kiGdFO17hhRTbPR8rMfzR2XuDfggm8l2Ya2iQw6OkwaVyFyVwR0AaYWTabJziNDCuTjeMc9o+W5IMFZKXiKwvVLJ6pJE4tXG8F57xfwTjLA5iIRyi6vE/qA8CEm7662evvrvd21je3znp7z3s7z7e2u3s7W7+tOtuchEOVFI0bzLuZDZGK6Qv+85y/ByK7zvKoYaP350UJ2wMPbDBOZiEs2KxhP0yDoQrmeCSAdsMoCqaqDIM4heVMQxwEv5c1BaeTbA5LxWM4ytIyjNMgBbzjeSJwiHzxnwEgguYrgjCHHS0zRBRgVSA1ABxqBF1E2ei9yi+CMI2Ci/d7xYWgo4bJ/1oJZ7MEdhWhW3kerIyzbH0Y5iudYEWlV/gNHPdoPqLf/9tFMBBJEV6qWzBcAl03oPEFbG6SXQoiiB5kLNl9QQf/hE/Kz50ggzGm8Z+G7pBOrmJ1jWcC8BfS0/iFyg1WcLoCTvKonCPe4IkiuAYmlM1LwI8lew8GmAomz4V9BCPeWgAMMKVSh/JhQ3F3YerJfBqm67kKo3AIvLSYT6dhfhNkzolzj+F0npQxbIKet4BdiQs88hN1YyecDuGYRLA4mChLzdPVjXypkiQLfs3yJHK2qAwvbzsBLqXHlyn8eB4Osyv4pd/b3K7v3CuAD9cj7xWG1GGeQIWjiV6lT2P/dkmI6Wpz5T8uKcGCUqYUYesD88Vlns1nz4PNBjo6A7TSm2aX5BgJcw0DWM28FDY4Lq/x9CADLfGCG8tWhOkN4jzEU5gkeO46ME/JfwDpZMNC5Ve4PUyuGZLZJMOdgl/L8D38NIV7Dohrig/IsOax6ukE9p+Oknmkgh9UiHyA1gpjhDfA8oosyOcpvi3zAn+hG40W2v2bLFWGLCbIJIFODD8mykb4wzgpNO0xkmDcFM9JxghC2Jz15TIk3Cy5y70nwB8UUiAulk6qWSpxdkRAKtQIvKMEdoZ7rhf7PDji6UYoCQA8tGg6t3gQOxa+LpJCIJLIEJ7qOud3cPKaZBK5Of0FyY4DoBu4lBiuu8DShst9o0xp1BHbJUEDSIGpBQbH+xUGA5q7nAR/zNUcxy9ugCtPiyCJ36vgH+H4fdiB+yqKmT6AtkdwJuFBvSnyeDGHAwEYegXrLMNiEvA6glNCt6CMDyIROaPQiCv2dKjZBPCdh8l5rLmOnGfgryqNLC+qneqF57p6lg71HEEc4REBOHImH8AKI/IJ4Ak5ELGpYs3QtRZq8CoDRKN4oCW4cJRnBd7+gIAcz9MQjuMFb3ccXdB+4E4IMhymsRduj3d6vbGHiOryDTv7pKX/nMZ/oHxz/3Wb+xZJlAmb3rumix2OJZFxHC1cXuQtD/+/jQWK2ELny+UItR2EFfNTzA75CroEuY3kFvjIr/HT8vNEJbPxPMFDhIdaVmgGLq8zEMb5QMNRBDpIRyLHVPhRgRMTU0Iikes0sNepmoU5nWIzNgCRKhWxAnI9ieG41aYyJxtuUpwM5Wtn3XAPg+SrOQ8tlVmS/gquDVh9osagK01n5U19K4HpebuIG9XGLp7Bq4u3T3M7nACknfAGcJxc478MblEWLCaaNHlbRRznd/E271rUpIZnG6zaZ5nEZQoYzjxCVxgQg7vxdseqBOBt/hQkCNQJ6ih2x9F4Fm2zBVT/Inqsj+wKTLug4vbW89GmK8YUngwzL7M0m2bzIjilK+EOeWYA58u+wrdI8GRwusYHU6QTAQxEnVSRxniUlipPVRmc5FmZwVMC6ZOjk7UAJiN9EVTHcfwB8D6H64IvchSW8izBwZC7wdmdAm7gRMHO5e9B0kY9MstR4NFKngJxY4wvhAHed3AqwwhOFbBFPJlXWrjCsaJsypIYkITorbyI6TSDIzZKVJgnNwb7YxJyDbQZaCQ3JFgCoLEssLv0hZnOp0Mj0Nx2VSaZubW9rZArgcdBRTQbkXAlENW2SeQN87UheNlFGQg283gNtgAHh1vS3DgFC88G9Xwmjrx1O6TX3+nvPvMWnOWXYRr/SeyxW79GPkVMIDXl3MWyw+q0fmdH9b5CeQAkwDHwBHsjwGaHMCYP6f/o7cEbZ000Xw0PP2YZ0uCrV/vOGRwlcUWX2Lff3KJMDORNPGyaHsNCCDAuYzwLTPp6m+QIInhw82ngWEnI1WWYRyQ8omyYpSD72OdZcBzGbG6DL0DsGifZNZpJUK/yVNez/RMZlW8mC2YNNvwCH3cgowMIp8+oDPjM6b+Og1k4eq/KJyDP0Cys7c6EhdSmYrMSinbepFrXyclmptAyoaVxjSVgDWkREjDd4DQDNq/lY7hu6Emg8mmwom1lWb5iNWvgWppbCShpZYEFHz35WfRA3lm4lbQeRHqggwA5lggWbJFss53ChZ81WiEiPQHeXvNijgiRUa0CBu8DeL/PU94A0sdYw9KWzIbBLH5BGq4NiYIV79c6nWhtQjKGJx5vQ89jTIV0eFhUQ2tUoUCkKuMR8X44qCLVqQ8sr3dYiNIcoTCyHTx2FeNy4z+VVa5xoSonhbuIy3ko2wEi1U02z80ccMATTXz6RkBuepnloHjDo1ooKcoYLX4pqpdCt2yfRMEFtrRE8kCUIsJAJEgMQwPNL89mOZAksNV7KFaAE8BT8XDM0mcpRO2sRQttyYQi/xg2Mx3Gl3O4NgB4omZ6xzDMa0RLAWORXRa00ILsVkcnHWBGcs+iuRQvlg/wINJJNwj+ZTErYhoZDi2/hony8FrDpOn+oitfXDDKfCkzRSXcCpHRnG2HfDVedOPZBYJy0WWwLtCSMgNsipjPMjpIG1YgRO4iO2alqO7/uAsc1vx4hztQDW9KVTmXNdHe2Xu28PiveYD8gD+wdcd4WORMCkkw66xv1d62BxgTdgtKh/BwHr/rzXmpsu4I5OrzlgwE+yizN+7Oa9QRVJjUwcnQDwUAtwXTsWOsMJPV4DvOcrhdB1OVAxdqAHIO4N+cx0V2PsqiVlDHUwRHp28CnKIG4f5gIVht7aaA1Lih+2EaRnVMEXu8W5mGR89nWWzuJt85AMcRxICI72sQXOhDDYLV/wpWEnI1rT/d6u72t/e2eh34Kizhq+2d7k5v51l/L/jv1RqQD8sTKzZAOP7r+j52fmKJX6MHLly2gbAUBr9dgnQLQloOJ8i9WNF/Axc8iZ3OBbqv701jYWIKj3OWqEYKbwwRvkEhgKuUL54OWVQmsRVt7Q3F4CXBbHJToHfWeDhG+lgXDgjHWem4ccl/E7PdYUoXJCBar7ZuhxlmIEKk69Gotjeg78AbbZ60tzTDbQdt/af9RXC1dNQEpsaT9tNcDZWPqHh2BwzmAZ84j06MkKY5Il0WLmWxMVYbcrRr8ejkahu/gH/vWuGzIm9Nw1ELuHk92F8EtTt5igby2RLHegFuzlC9ZC0J0AQTic7AgSnHgzOjgAdPVPeyK9YkOCaOoYBmyYyhyXNtmLPi6Jyo1JL5EcTaJAvhSIcJmjXx6I5Bp79GlYd0fLRooQtvtbboGVxd9xNwtZBTlHncLPW62MDxvxV8sG57D3nPW/UJv/1R0t2mD0dtT5YROhfvx4nswSLiR+4E6kWuovMmufLhrjdUbibx5QSjq+ykGkc8d4cWMpuhO4VBLuZDLY6a/X9hfTx8TTnDiS6K1goMI+lekmyPkV4raE1YcT5XXU8cTiMuJfS+51O6ime5GsUF6lpkRwlZ+yVHLIURzYegfwKg43H8wYxIzzzBeLPnGxv8CD+BOtYaqHr5DVIqGj/QcPAhxquPr9fhTVDEsLgb9GvbXWVtGaPVyK/BsTSsmKMfmZS+awWfcO1nrw6s83dllHXn71fqd6lFhkcSZTY7p+3/DBShxmM8wFcKZxWZRvbwiYJVrHXYm/M+za5TbSXzwAoE9R1tjiQUzUJL9jIeXZF14qnOa4ZFPFoMEfV822RDJLOIYuxGLEc79L1HNiDI5d12KcbVyNhwneVsDsbJ2Uc1VWQmycaLOAbs1KuDwQmFQvCKD8xQLqms1len4NekpcWh+B/QBFpm6dYBGM+TpEGS/CYNM7jg1SLAJdF0pGCEV4AAdLbX7slBAttaBofov1VCYh5uyM76xQiQZm+fAnmRrcXg1ONQxhJzxevTrnKySG7MQMxDCaSBUBnOFtVldyd4sjoQk7CYtEUJginiOzgP8mSQ1HKFoq8X8DVmwzgxKLjC0iy9ccNHWYhzSAXOhQSzXNAqMEgJDdr0AVd3YYIM4d9j3isMmnLmRPMHXEnWkRPoqOAmomolpqlGSkYHoznrUDycgvzFWNrpBKVttqpQcGGc1hft8LSQeNpfXM9xNuflGcex/mKx35gTDQImPeNfoKECcoaO89AEH9uwSnYAcUySVicoMolpsimMchy8ViCgjzi8qXDDp0LMv9jk4CmkvrEqRyDrk1HJGT2IYV6OXLVAIuX6Adde5GxcmLAcHwQZF6CQkNhcTQFm/XQArxdAf85MVcgYpjCQmE29IE1gqX1VDGJ+bDgPagei4FSZXKt8OGxcWFAFYfdxEY7IXNse1189swjiuSgo13WcxJEJtJYTDVdVDDJj7irsZPaLKbwY70E8husYqg4DqvQqzrN06tuMLG0Nfj01k8eAbXHKEP0Hb97+GBxFHApNQQLzKnOpC6i7u7tPnz7d29t79qzi52IRI07QnfGn9QQ+NFYHzjwBzoNYYfcj0TQdFXuIasxhXqwrOLfr/YoFT+LX2iOHIx23eHSguRfBqg9hFdB4vb+5tb2z+3TvWS8cjoBZ9pohblEcMDC7EaZ1qB17I31ZD5R8MIheaz7gxEzeisZysztVUTz3lXHQ8q6AzJdxRH+yj4vOmp6wqw+nm/cTXhcgvv4J90gnuBzNOuYgY7hdfBmXYZKNVJjWb7rrwlsWG8VbWpTYxD/yuLnXcRap8wImDfHq9O5l+CU49X5ZfEEDey1UNUHEE9fophvGKSbr4KSBmbRYPuSQg8PvEKGGWZbABjWh7Qf+iSTZcEbCQsxxlgILok+ieuo+NcxTXDXDLpCXNKhwWMt5a0EvgyiKJaStjmWidJC64NrAeAwBpSEOfc5yuKSJXOK1PcpvZmV2mYczoKtA5TnGppJ5pzoqnJk4cj1yqEblcyBBmS94pUJQ/OapE7XFx1C/al/R59OOb4bF9Jd5CvLM6L1qiPE/fPv2zdvzn4/P3v58enZ4cP72zZuzpfdozhmJLTmuTnl4j2Eb0jf8zoYBxJjHkY1LOHr5LPPC8O9cCqFRLXNf3nI8Vk8xdonlU3crG7YHk088k/UvuKchRfrZ1xe9R2lYnHinQ5s6JLkiH7NaI4miEgeVpcmNn4OFUfWwloKj2EIyM1BWDFAKy6ZMhzWSud9BJmL9RLw28x02sdCV4nOgK5WjyAey6iUK4Y42B28YHpqWvqTZeNxCD/l3nKVlEGMvDmLyQsbmznC/vCUO2Dzox3pKFGYtn9fJMJypEa5GgDRQMBGIfVy8cUB9ziBOcrhzV2HwpWPVIEWHvXhm6EJUqPQGb1YMD7yHZtOm4cEuPo584S+eYvLqZ7JN0WQmhIgBQkIbzuOkRD2wAbQyvGwJMktZAld4WTEzOynrt0/vpK7fkrxeFdNpVskD9+ZtcTvsom2UhJFDmWbbEkR5dGDoaXjJzD8uLCHUhChOmXf4iBNy7HKSg8rXt/AS59HbQ9OZ4TpPU9gRu8U3/MzxhjGdaPS74tCZ/Ugc+tcYKO3FeS8VLW1uGak28UDR0mZYipp+jJZ+jJb+nx0t7R5MHVQjpWWq+/W5QqZdVvgYN/0YN/0wID3GTS+Ps8e46ce46W8pbtq5xL614GkPdBeCB4ygjmc4m3vT3xE2rLx44VkeX6Gp4uD1b2tNEcN0akgP+aqCpilK1zHOyErJZGNxA+sb3hAmDhSVGHr4FbYRBn0Pse3zxUIvpOUvHRAd1STKx6jox6jox6jox6jox6jox6joKsE9RkU/RkU/RkU/RkV/zSztk6Oio4SvF+39evWKPt5elneZiCuKN0niYR7m6BKIbmA+VqM0ykGF0pWPpcgqmWTk59fo9uYqdW6RVikZlQUrxSSkJEdvnhUpkKvDZ9nQo2PphnNTDZ8CPFTJ41Eteiy1K6gbZ0mSYdHp5xqavwUHvID1JE7fy3w3wZOLLiDwYk0K32kVEZDwa5xG2XVh3z9lcN9wZA68WGRN7wERf1gnma229hosHhg38KFpwGk4enO6vCvQD8vrfkNxbxXIH8Pgvv4wuOqWfT9RcZWVPQbJtRUkV0H0Y8zcAjyhxNidRjstMcTXBzs8xb3ggSu83xJApy8H/Y+DaHNntz2YYPCPg2pH7LetQAWD3w+qlji0p+2KcFO9Nm0pzWk4K7TR2+Xp1OoILbxx8b5+bN6jXyPZ2uxqyXeJ5c7Csi217gUaIwhinKS29grw+8/fiWD5jmtOb22++6gFkYVxBiJ2S8s6MmVneJraBnV0MkwUUGuO6Qy+XKcY1we9iGGlDmBtr7biIv+IxZ6EbhzB3YvD4c8ba6U//Oqu/MLp91zZbner+2y31+v2n273d+6xRN3B55zW2mqimyz0U4j19GRwdHzWPfzn4T2WKA102l6XTPMp61sxp/Hdh8GhVnPp7zdGYWXetHI7AowFIvXK6h8cn95lgXjhxdrihPAStnMhSwMKqmFaXCundRf+LonZIrCqmJJdTSllW/Nej3WDDu+MbA2XquRK0jysDPrkAkCnNMfn9Dxo32y6vNGTuKOT1VmXYmZzmW1nJCPytCZ0uGBnSVi4tgmBgcXqa2zmY/aOLadwR9I4dSj51Yu1+0QGeyt+8Jj1VWyKkOfhjUYGY1neZzcRxpIyGEEhVc9zBcJ36hg0dTM8KQPmSAwYIA44BhgEZTZeV+8NbwG2wuC+bF44Mox8uH9q22a85RLuPNYEZXhqq+AaAaZ2OfyjnhxjfuEtbPfEw1cjkHCbkfwo6on9+Ny1hH7xQ8rxOU3mwaAMsFHDdD7tyJfWKiCLmqLG53bQusBZLhA4Sv2vLQM9C9o30kFhywwZ4mgjElbiUrdxhC9nWVHEQ/Y3RFSRHG/+0JpKxGio446bAYWBRtzRxotjr1Bkd5SErUWsc85+yNE5ZkN0bkHEFBNT4yOOKeHC/jVmeXTcCLpTt6EVFzdB63BHjliodIqUwwGKLsWj6zg6fhXD1Avte6Esa2JYGiXugHrtNUG7Dze9/LcRC23GLZ75TnikOCdduQI6XMBU5t49jUekjJMxBNa7fzx4fYgHYqgQWfh+coVGEYc5ra4WwQU7SyyLKZ38hSzVjZfQaVPMMkSxsew5g9C5hDNpeBX6zsXTXh1TNze8oPYMOlj+Am8eRY1Ja9tyfX3dXRCGoXemLJdxOS8KVELcU2YOxZBdkYUUOTetlxDQuAna5gQYdRm7GhNf8vIs4mIU5gBON/hN5ZnOoZ+SzWYioajMQi3+hhZpPEVDXHsznbZYx+BsYmsYfCSLIdL0LQYqjFR+Pk50c8g2zN90ZwPUmzB2CdtMXJJnDmhmrxDJjFsZ2WIHz4PBoBOc7XeCtwfwP/h7AP/eh/8dvKmRrHxch2ftn378eGvuadwhXBrH7rluamCFaDa2LW9ztNpPmQJNm16DBHyExDJOrnEGoqy1WWzzcZg5FA0a1Ga/3/fWnc0a4ooffPHiicpSNpezGMXpsGKOfg9qAJIDC7CeTBuYlqZu9BL1Yiw17mxzGA4s52FYRibMkJPQHXMhjn76+fDtvzwcGc742SQGafMjtwXrJXcKBx4Db/NepAuxApp77xlzWqUgU5ql66ARwSfs1wd3I7W0Bk3kyVBhc6OtTUq8QwiC/ubuWseh/azw3rC83GhI3I4JgA0xFhPjkmEVdIVc0hzvDg4O1qwY/gOcx6AAhE9E4/tjnlFSkxlZhgKqC4fYnQnUjBgTZFl3KFhGxR7VZsyxUpE7AqwctAkJDn5XdoJ3Ob/1LiX6U+LTuNcda7b5i8fCPsa/fjXxr4YoDPLbJAYzCal41rIgC7QtBGskWmcUMtCEVEJJrCCgiRGamToWNfDdJq6z3xWsEGl0PJxbCD0no9Ze7RgrHSaRNMNY/jih7oLA07JmwbcZ6Y/Rx8z+HqOP7xV9bOnn8ygIoifdLlQM4B8PSq2rnn9KDtGgZqIDLB6doAynqBbYhWvauKjYGPSPF9rUJ7QTw0aPgGng9s4LIM+hGoXzwlimrzCiq7zRypFLqFPsxRxzD2MBC2uqlblu+UfwORUGNKAltz/PArKKOsi5sOIqtXyHwbU5i3slROoDvj1FKnGHZpGAX6LfVVjEFKJmRrTN9VhSQeEWFrFY0amaTvzv+tUNJkn4cygCeq7mVMPjNxQL5EHX4tlYdQ+HMfDrkI2oI4hGmZToz7+8qIehLdfjOAgolAWNrgV1L3RcC147Q3pslCs3VApoyowyZtiqPoJlobAAaIO/uAM8ICrzUxtzwgJcgrL+J9mMra8ALgxRZJm5V0Rb49MB3HaAVlsx1ZgxBav+2V/sqND2fNTjhCfUeKkx/JrqeiPPBXS4f5cL6DXMvO4aq3V1JrFGL1/Y764209j4NAZyokJnDxDhgKZy7Uele8zgFxeDtvFucAH46MpDFxzhr8GwTJAEI2I9aNinOE2K9k5q7UOD4FesVUJ7RhuIDjxHXgPeFqOjYX1djKTiwECAEJ9FAtpDmTQVpXVWQ+87wbUJJiuS/pZLm9Iw+h1B1WmKI2CWYQX/geb9soS6URnbcruUgwGSHu2YL5YOYaY29NoZJBGXRL43ZNcwePyZG9pOWX7g58QNBAoUFXQBbFIJZESzZgQUhI+t1uGU8O1j7Bi893EJYtPYKtrYshVHv4ebrqXkckImG30q7gQG8FYbXDsx/SY9pAECMTTdAYYTfN+wWG2s8gYuynD0/hyli+8hDeqMgy9H1Lx5pIzvhzCKxDpLyEcIE/po+EyCrtndDp80KZVbGhObG76gPozUzGYaO6zi9/Aq7CZhetk9nifJSUbuiEP9uMtDTDtezUPMF7fzEDm/TYUEdXfk5uDwJNPqCtcczDHU2+EFhuUM8NFKy3JkD9U7Wd/E1BAMBpzwOTW8yWoKrzLDmejiiNNRMpc67uS1wagCcZWRpgUDmTFMTXGcyC5CxtNDhTqdA6ksL3UReylNbxusi02dFRqT1i5jav836X5u4naHy3s1dGkfwlco5oemHbPIMxgUIMPyZNLgXFEN/1GSYZYS4Fp24m50cykJfY7RQZXOudhOgm4o7HA95S4AFDTdhFnnMQr0BSVWGRp20eySh8XxVE0zilABNGMcsgwXWUxLW22sZaBPp5qSIR/jpINTxXt+weXn8KK74GXH7P0X8USHXFBOmfHkmyPsRiQIpDgvxnZXLvHlqvEvUW3no3UFHt0oCNr54NffEyuHqSdDj3hhEanzFjppsYUCkoAVQTHSQ/CqO6FfWNO12VxkGBeEkHXA7EUnuJBzs07nRtFXGJ61zmJ+dMG+I+1B8W4Dku+doBUCG+eZUvGcuiSF6WHrM2CniMx1DkvyZQoBvZ3t4AQYOkhjIC5Qg1CW3Oc5dZE0DvRiDZuk1LDkHbG2MFJWxKAlW4MDaeCDCcgMYT6auHHE1b2x4h9v98owvgyGc6q3sYLwOSOCGugb1RyJPAFEC7erTPFcdvYiuJHLwojp3FtErFzymBmT0ibi8kZ8ZyxZxwXzLNhwpy+JzIibAnTDjIZTjJCN2RHRuKrBqlK9GV+rcTIv2dCwbuA1Qoi65cjfKLl3ZEmOKQ543hjXiqFQWt9wMFlXDeewASDqOXW3Fsu4D2dKOBL5cuS4OU00HS2KcsVJv6aMOAdzTnVLHbKFRix9aUSq8Dp7iICJdnan1GUHje1hHiXu7hP3p6cDlGPm+AcAhcsjPY70Kb5oYHk53TKoxRuRSUt2sbslSgdtspwTHB3Ut2F7d3vPRz5zoDt4QWSNET5+5TTwILV2NGqD7sdr1FINb6VbcRznTkINvE68DanzkvYEsAGfyYoyi2cqod4PC2g6ilGGGEnxnP+P6oeWsF5mG0Cozlel2wa1dK3k5jZXbG1EeU8X4zHRONUr5QgDATFfKy7nrAx3JOQQHUtmWjloQ9WgcjPr1x9HlgOKNVV3kAGoR5RQxMgF/nBjBCPX2iQRChJvySRumYQrttC20KuEdN4Tk7ELqC2FS1QgmWYgSmQ2vs8OgVFOmd0x/Kh7ucB775WaBfMZuxHoJfdw+VhFtZoh9fGIVyufOMBHx91Z6951ctPdrKrNXn93vbezvrl11tt73tt5vrXd3dt5+psfhYgG6cKUx2otB0amqQSmpR5G2LVCjvApl7LFAFqnOwqqEFmurxuu7xWOvHsGHumI/gd/rnXcyc0tgqYgknFubO1a57yOgA862YHU7sqCTZuOLozplHg25WKjW0Zbtmh4lHu8uUnVM0Fy0yyaJ5b0uYYHJ2uz1IMlgLn9VVobpuGymWEkWNfBhdneuZdlco8KWZU343Q2B41cfkzDNJNIOK3/zUv3gbB4DRwgbnyGHWxEI/1GwjmQqT0bWkCeQDOtT0nMpxjreOb5s0K1CcOWyQdZWqefF9fYxIs0o6HZ00irArinca26iEor10njdb7oSrGg1m6T6kXC9IYXp/5ei1UGcLxryGeYDUldrFS1b7Gsx0us5PEERKoJZrPB4QN8wTcgx1+qnMJt1sj5F17LTYaF6rCEJvmlHNsPsNuixJgzNhmQ4RUlxyrR235STX8Nftg/+GxWvaMDXI0pme4oYxWY98Lt8U6vF/mQAYbqSdXLyyRn5k4gujBcFQOFrnQEpqLiozmmdlFAKWZgNyTy23oTJAxc2AvHlcUrdKnFBbjms9FonudohWBOaW9iDAaoju5JU+4EGAJbunnLnOCD97VTiT8wAlRQAFk16cBwtbJSiaeLlX5Uw4pijh0NKdwiRGMCfOgYSUHuXu2amuRZmmFFErfoB1412XsdFhAXzz1cBf9vdXH2G73dF0vd2Tvdfq//29LZ0Wj++Kr1XB3A9VGKLht32KOIA63rUaq2SUpP0WKD+3NZq8OvuS4H4FCLLbbjOY44Oj6c+V54l9ICDVrig7XWwvyOxfbLOUg4cBqwIJcIMnQWPOtYJe6ALy1/tIqMymsMJtm1yOOIKoLAyxZzLji4C1LQ0iKSx2/IVXaNqjL2NjDHNFe4ZjJW2i9ZzCCE5FliVx2XNAqddGoKQwFY2HIvQo5BaWomop1bipKjryS34CWWWTah9lZ1zFG4ahB5EiV1P43TxJWpWhNkeRYnx4SinmktVUlRvOKiPpCCwrxqPsOipYUmKzTkk4pMQ7NGkcwvSRKoW1KsWz6kk5Bq6Znl4QGJgnT/gvQr54ZHvqiEn3mqoHVFkBkQn18kZ3pY17y/Dby/RaaOzgdtPEByBnLMzen7Wcj/FqlhgRKNEjvFwiiW7qJsdO70MITDipJJRIZRLgdG6qxCzqQiS/Qo/Uv8DkUBwylWV1qXvjjnvWlg9aegG/afBcDlN3ef93ts6d4/fPG897/+2t/c/j+nCi5SWAB/gm3Be4RaxKicv+t35dF+T/6wUiDygmJO5xTTNW/wvsfYWP0C/7vIR3/v99AR3e0HUVH+fbPb7252N4tZ+XeQpPw6u8AZUTH6qi8XVJ8+9m6R9V3oYDxQuikQ2+VcfGM4RtZQY5l8OVZnDOMEpRZjUAEJQ4dZm/uDqrizwYbTmVXUKMIcZ6WkKrB4p9N7qeazuAIcQ3/kmSiZW3B+V+XiQ16ti7Y43N3eXRXEdKj1Llvs+E6MrU3EWaAD+gCvgtTArwXRkEPj6BKYZXOtrwVPzNr4sySZ8f1sBrXhuSySyRpJ17cV0WxyrKlLY7Rvvk9xdOc+LEzEFTNmNESiwdfZ4KW21bhcCS28sW7I1ot5TvRk0ZJKwqxwdjKdUUIuSrdFkY3Ew8f7sEDkKD3uZmuL4OAWBeOKmxYpQ88KyHFM789RorjwerdiHIkWWUgJjSlnUAMGLFQxX8UYQrM7cFCKhqtE0OqxmJbb2K6emvi0pnPGRmQ6VXw961Da05tCLE91mzN6oa2NdcrCknex2qA4rZjpO6WhUUSA9Qiuw1woozn7Sg4LXfcA2xSlM4w8jta4+fWYfSPS40gGrhbhMyM+4bIrHVudZF2WuK7voPXBHFWn9HJtURUabxupEqFjTnnwfXyrJwh+fvsKU1/e69jq24vZaRdIVSjQo3D1RPL5wgFxfMiCQ2cEYG1Wgu+Y68hL5HeUluckriILxa4hUtuQvCvEDI2Hhntz1ZCMu1s839iQrlYwbJTlGNzOPdc2/trrkeljWS0xj4v354VzeS+6zsdJFjbGGL2FEQIagcRVrC8Rc4RzlUILISIg7WRO+reT/YSRaGzMp5WROV1cD8ykMc6suwD2c9Tsl6CxhYtYPSbTAFaUpWHvWFCHYxIKOOt4Xs0iekg2IIQ1mFMwL4FLWEpdWgx5xW33DdxyVLnAHKVjFg5Ahe/PwCGuxTxSKCSn1C6DsSaBkXR9ccnNismyUH/Mlzyh9+tRcSoD69ZqC3gtRW5VHqXwUIZfOwLIFF7U3JId8sqE7/0UcjgtI7RFReK7Nqqv4590vZPmVBvzmTFM17CFpfHKuwII7ocpymDkYBszgX9+PHHrNv/RryZX3EhxZkQ3p9zJV+CntJlbu3tDJ1xaM6eiKz6P+UybQpxwDLMTFLwjs8aiRIHoVmDelSMQCWW6lg+69vAKbKzrIJevWQ+maKaX1NfwAn7oFvR7V//eRT/1RVfzXv21TYpwjYs2WJZg0FNUxVzfScVcTbdJsUfz6OB0rauzybw3jFwkZI2xcQF6JPSMHAmP8rgNcTfjjrIZB8EsXq4TNWEWXL9Envo0jd6MJY7/7W4L9onc6biQMCDXdeFQBLswrJt8ge8Cz+mftstkC1kYt2sP3pLwQFjGgTtsFsSWBQlGFJh9cSRB//+NUJJc1prQrf3ZuSb5AGriCDJUIK7jwlO1RmhGYm+KnlTnF1GdghCPf5aSTH50IJOvHM4xDGZjMMX0yCicrjjZzuFwmKsrVj7046dnK2usCwQvXz6fTi0zwTrq8tR6b+d5r7eyVmGj9ajbr8x8ACJY/pEhWBSt5FsGKpFFmOu5zrFYK3TTd5ikOK7JuTsCq6jW4ruYPJmng+qW4n4XTsCW8NWI/J2ZY5HgRVHuIcg2eERR5hRtW6d1VfuNfcZQKlH4AYtVUWWet9U2ZLWqPaQ0NhWY0xJZJs0pMU4xvcLg3Uu9Ol/1XkKxSOnc6qE5hSJO1yM4t5Pa6Hwl+a3aA3avkdBkYt0lVyylwFuMeB+phdrJAq3EnvhP0k6mN6KfTG8kyxo1FJpjY2fzaR/2bLg+3hn21rc3+3vre0/H8Fc42t572gu39sb2mrm1yh7GkdKTEuP+Qn++JcR9wIVJK/HQVLij5h+iUHMseQFn0w8Wk5Bt/JVi53SQMo4tK9f7/4Iqt0odMBG7HFMOHXCy+Oot0lHg+jMwqQ20OMXGo+FGvaCpCytRGLsh7CtNeaTt3tjZVXsd/v3i6PV/dMnEwsZ74yWL+VIgttDLEv4vVpig3vg7pFRjNCPh45X16OPoeIXF1HSvuGmOxfoEwWT1VSheYnEaJ1yAWg/daFnVJji7lQWHb2Fo3HsyqbAVsCH8IyxhkcN5rbNxC0WKGO9mPvf6N19yowhmz1dYshtow3SbCV4C06EwNaqCoj5MwnlB5ktKYIcp+G7xuTWyBaVrH+l4ejmeeB/C+x2y5VIicdSx/X3wjqJGAK7LRH1QI4C0A/dpFKm0Q+GQ/P+Yi9oRDgn3I1Byg+lw9d8r+tmVDtyrXKPzPx9baf2xM8RjZ4jHzhCPnSEeO0OwCemb7QzRGNp/P9mB5CAah4RBqhu9pLhAEXVMbN77vrAwcsLXHkq6sQKByFwhR9hQJlSzvMO/mQK2NIxsIEsO8xnZcS6mONWFqHxo90Pb3gWtwtrUdLA/53Fw7W1j1cNHO6hpjsxwWpvUcLsVvCv48vL+HvqK4wbJ4psuKt46A1CVKIso9EHUwk5bUJoGhybr3qgz1MpdolSETbl5sBEGgDo2ACxwKWYHxxRQW+HGBPR2UNw05s1KcbhzHuZTF9tI3Ac5iaJciPOW1fqGCWLMuQJQQsfSbFuXNUbTOekTs5nKUdHlC8Az39H1mRiHgFuudFmuRKhpsakBsSwzSW0vZ2JX0uBctlZh9CSPp3gRcLtLNDH+eHSwdutRWu33en3/wFv9sG0Iq70DGloMVg/AZ+099IUaDH3BLkJfsFWQjcVvLznzCMe2NmItqDJ3S83f2pRUPSuAq629Lf+0TOE6PW+xmsXro9eHHEetbxed/UnQklLodyvK0eepQoo7Gd6UjilhXlAJBjEWYmXROExDrI+3wT5vSgDdmKooDtfJEuz+3f0wKafJv48GxwPL4rHuGvod6In/dOTK0OXOulwuqCGXDOWPGcn9Q6kmaMbk9EYT++0sXWfaLcv4p+1R0mskJBftWN19hGK7oa6wsZTIam93u1choU+USBsEUiNJhhRKTKqDf8xaLA18XGHrcpmbej/6prTx/qz2iJBVQ5ku7lm9SLPrtLVINTYf4wSrZEHJKe3v7vvpYdt7fbG6PtRKjLqIOfpJp7KRtLdcGrQm/Hr6aeQIlfcTfjcW7f1j17HHrmP3X91j17HHrmMP13XMCeWJ/7xnIF+D0QsHQTGCZDZHY37jKtfMPamUj0Q8YHFl/NhQaLgP4uq2B2gZ5peqPP9ObqkzWg3fUxRMcTMlX/9nKzVH+0YS6hOmQhBiyEMtkKzVqM+4k01wRav9RlByIUPAz2QIyG0ssFMG8clpxUrAgs9iW4GxFCjOGpc4gB/l4y1hAPCIWysTOynccBIfO7VCK/iTqSmmDm2mMJGxpfuxHtLMNTOvuN4yU16cU7E54FGNJpQ3blMMELKjE+0ixWIwjL11zBVMYmMbX6qEJiC4Lf/SPm5eozD6GrNBVehnAnDsDCCrLXhcYd9MVq/nnOVwRgdc2K4C4BzAvjmPi6yh7PTDoIynCI5O3zRXm94fNILU1g4KOI2buA8KecW6ran6DlCA/s9nmSt7uSoi3DxxSRUVscQZjIcf6if8v4IVuKRWngfrT7e6u/3tva1eB74KS/hqe6e709t51t8L/tvXX9vsMvMzHkEdMlQRTkODmo72d3CQHfx2mYcppjO7rusSs6xHFGGFzMa5YvfdYiSObBHnkipNkdZcaQmTGTAlmkLmO+y0c6v8mUEZPJBZJjcFZ8lRvmGH2APHiFR6Nto0JgpJxMzseZlNifs57K1+0Q+zoszS9Wjk7Qv23MjSNk/WW5rhtoO1/tN+E0wtHS2Bp/Fk/TRXQzX6S5OdW99f5ovFNxheqmy8dkq1NoSz0zPaLZ2rqnPEDWtfvsB4uz1FvGJRxuNVmgVTdshUSVLJopY+cNu+Ohic4A064LRM6z1zu4n4LKQ1IWhx0WdelPSlZIvvhonS+lz8zcU5AdT9i2+Ed+nzpf58RynhCVf9IfK0FGlzTuj3MMFA4XIyNZVlgdlx6JkTQ4n+PY5m40rEFJY64VZZHGr++mCnQw6MNaJzmE24dTcYRJEGY2xCHjkCV4YY3lDCOPr+tFHJB46ZMQLItmuuZ0E5YoWahTn2edMcNyy86OonRYrhuOxW5Dy4Sbh1vtPfvE/T4s/tavr8XqYv42D6nL4lc56ywqvN/VJ/vjVumYKEq3HLkt1NloZ5yWVUsMyKkzyFeQv4bvdv+hAszIivx/nSpFlqizy7eo8pok2qJik0dxWDprWyk6ZioZ2EeYTpzp3gKs7LOVadDrHpAGY3HGSj9yo3nURzSd34x3yIKccU6Yo1Se8TXYyxqqB9YVhTC/f/m0qKtTdfTSL4sLd7vuvbRz7jDct3IXyye6dJTV+zi+5YG1jBsufIFV9xEIwvXnD7mhFhwGNV/nD05rTe5etVnM4/NIxtgXZmMiPSva8rCDTEa7w5Pntz+sZg5g6bGgi83a9IkSZwvnZlmoH86hRqF6yvRKlGkL56xRqBfFSuv07lGvfma1SwHbi+pJLtS10tQbL6UsZ2bySvUrDtZ2AypK91qv6FhuyCFBs8v9LQV2uFdB+LOHSHwvow6xFtleUAN254UBg86tJpYXId3mDsNr7SoVxBqTRgjA5olwBhiApfSN1tlYKYl1Ggj9dWXfYP1UcqHwrS41wXfLsYqrAkRnRRxcLsDiw0N4EkYTSe2caHld5L4agF5L6UzVw0a1s0enwrfTpdJ5kyHap0qBEI44MuJCKMkorK/YFZwxjcY8Z0ZDnd3gYB0D3WTUMP9G10pQoIdemN4IGIqq2hOEqkZJk7ddWsbH5WdMfhNE7aisAAwZTHB1VenDS5iihtO1LDOISLaZwrNSwiDCQicbjub+Mna3AD8h4O6i/m/6ypO7zrfpSOiXmQ7mvNIi+cC8D36+z38EpVseUUmGphl6tr4NkM2KRuY8lqLuRSg3y7u93trff7m+ukk8ejKvQPK0B9bXvtRtAJyhZt7j+rmNHWzs+1s3o+Oc8o92XAzeZDEN3nt53hML+Oa2e43ZChGvDL0iN21d3u+n11Wyu7IeWVK9cKavD7STaPjDKu7QS24p1INRy8QCW0L8rNLkb7zqcXVETnalopbehZAoxNyGusx9XvyMLruuCtHGJGbJJHKlUnZkuGxS6KqjnlNgVWkjNFBdjM7m/b1uaOPz3ej1/K4UJhG236W2h1Cn5ti62jahnQBFre6tYBwGv4gcPhvhh/xgWvFiSW6WsYlOgrQABGuNebqydDrA9yiGZjVWFuhBv2Bn2/Hj9nkV+188+B83P7AStAtNg5RCuexHfIA0dld3IOvfJ4OTVvFAaFnS6y9GaKdQ8NbTAKzcefTeHFC1pFHF0gpfAHrX2z/oN9onmvqgUP0kgqgJth/aZLHp4+T/NgkxBPc9aheDh18ouxtNNJlutQW6odYU3/dtFeNsSQOwIY009ZegEWL8/OTujzYofbC+22NjF/+JLTvFA6ZwMB5YmuxoVVhKjlk4NhBDJPNLzYGUoV9wi10C8Ms+im62ZR3bNQp/uqj1w32rcCZkCzVtG7t/d0MYiS8PMdXKRnYtzgjb8VIy9VkmRYal/aatQw08K+nWVcm+GW3XuCwBLTmqgQpe+6StPf3mreTGy4nLV1H656KOWpKqnZTnk7buoMjNYpbgunTAdscFUyGCq/QT3IdAGOstF8qtPfzNi69+/Kka5cirrV4f5pQ9j6pSo7mEKI/z8vG9FEBa7z1rK/3srwtvCai7nabuqMyiEWBtUZS1h5rQJ7McuwEvvn5ik87bJMxQXy++Uqt+FkMVvRuPncfEWg/TjGIkBzJZwGR9Wn15z2cSr1ghr9Vds9P96iXSMOwbXIKtYnI43NOi9VPg5HXmHDI+/L24NCzQBuYKjuDYXNKnP0OV6iJsz9EflPf97AE3sp1SdX2ApB6Wa1Upg3rxZBDgA4yq5MMmxsGyYYi5SvmVGN0Qab+eiGBHos6kNF3ZFMFUR4NGWW3aUWbvQ6dw2RMU2nEDOMRQEDp8fC/hNoF6LS7TNYBK5ojYuGuHB0BT8NqGgInVpeloO7K2yrRpshEZ6FnRR2x6x62WlwQOvdM9zMlPXmzr5sWkNUxmkBqkcHG33IH3kQTf80LT4s6mHJTWZJedEs4Y7gm9ZUcouvo4Mqsjzyttg6PX59UjsnWO27gfv1ll1gi7r8kbsXajFF1PPcy8kd8NuUkEuXT72Sj7fEMR7UQgxNEW1dFHCqsCZVXEwDp1KgacbiJFtRZxkb1ki9Usxu3RnaWJtOxjVdp6mGmC6/auZ34uV98xPXYzcTcXV6PSZ5Nt2y7X+78Bai33JbDdbq/FdWiM53XASA64z/N1PEF/uR5aEYwXWx37+R1QMVaPoBg0MZffcIniRC9Yn2YfwIb3THD0SkifKRzao39NR9cmqtfLS/oaDwHDNUTnLcnDoR6XL7XpE/qfzFPaCx6U6mbHsBGoRdEm7TcSz4nq6ulqaPNLxtHRa6mj/oD+5+GmpCujfdBqhkiWnm4/Y6WHOWfUqVtJnqmOgvrsM8vcAWf3mO/4rp/+ytFSYNPQCo2Ka/rUhLeQv7eubHW8lEcpdQ+TeuwMK3vC0XOicyd0uyuKOMkrDQUQLUnUerhmYGup10yeVgBFpkNm12O2c56EvYLDkecV8/UDuyErsIzro/6L88ZHEqPRUN6GLD9yV4OHUiNAiuYQhHqfRKMSVUwjjVbnQhO3KhS8tyPjXV3lDOkamsdntz4VJavI6qVPBAi3NKGZZCOcgYyzrNNZf2Mtvb/T28ChsRM09HLZa8qOFFppMKjpMsqqHijv3F09CwkHY6c+rjSozT5d+6U2dY7WdO6q/zhNnYoRpTQg0wg5JzGUpsNeM2B5iFudcT94ijlnKqPMS5bBcyrDbKMvLc+Cau7p+HVMQaR/RL+CsXOK+VoLcMvdhObUG6q5sZk1v4Sa8P6oTAtZBGIl6HnNnN/m+VAvlT26IcNJdr4gsouk1hH5xDAAIK1W3FXjw+yJ/a6BQglD6meK0B70TbmhvaNdRxHmSd++R+pxRAS47x1zdGojT5OXQRLnH0uMS+fMUfzpvIunb25Ko1xVL9Pl+xK1ZQHgte3VMgUAchV3Eow3SDE9gVND4oFbx9sV8EO9ub27iVW/3d7W7D0rogoceJbuDz0BaRVWeFusWUnrAmW1VdxWZ9A7cNkl0V0hAuS85ItZpmmOorz3SX6pkh8d3NrTpxbG7diqOW7yfdeQcGXR+GqAgsjazKOoionzatRTeUe/CtrmzzgsZ1H7/Fyg4Ju70X/M0i538bSbXr8x7b0A3VDebvpn+AtFQhlizUYwiFZu4/6zcUk9naaUKr1wfrfri988RUm7LdfWKamn9Jzy/EsWUYrqpiM2OrE1tOQ1iq2tyw4VjH1UpQragBLyfzMmtsEnYr6KZvmVZyQulhPyorrcvwNritdVm1idtS/coaeYLZ8DYzU74GYvAb+JlRlyICMrMuoABHqf2Cm+9AUe+2IDqqMZaxIdc1OR07X92Rjq7NwH4OLdujp9N5KuIYl3HCns+6r7FN2A1YKHMa9EgObOFZc+SJj8q41aPrSAMZttoyyHQQvkfOq9Wy2zouA9ZkLuMrlUofLWdWscMAkZfZKEtE1dcKej6MQbbKY4dwuBisNKsu8bAULCNPqXSaNC3qkEAaYoNxnOyGFQH7cPEeFmRNMvHojw7eXApU/Pdwr12jLJfrVmZufVfUPIq4nIuUbquQc9ddMyKVsyJYbJEnvIUiU9TJdrekI7URod/76ITrWxUdckQUncAZ8zrOdVXdr9AzHsZTj7QaHJHL9ERd6IRcZS8kex9J4iY/OO3IMMNzQ5F9uC0+n72QzqH05gUJEReIbNSb0bqkvwfkvk8B+53gQh9W+YlFFaeffTGfNtxIu3seAoSDlDfnrXkssAQARsRRMz02B6eULakXBwTFLj2hJqC7a4Wd+fzWhvr42fRDn/9ZC1xIPU3WQwAPLWPoUE2jMCca09WfzbDjxK+v/0qFuVRcxj5qEplwCbxrPqSYBCSQJL6clBsGeetxtI6XTIPQ93zy5n8Xx9sv//frH3de/2tjb3KU//Pkj9H2bz/92fu7txWGNFqwdqwc6MH17a/ZNRAplqDuvkvfKlwP7Xlgtevn79LgnUHOOxCe4xR4fhrB9/ABuL/zKZYyk/xJdyLkT/OUCPcd/AdrWrtjToH9Oa0fienw5SXKzNR2ghMXbMdcSI6dwx3TcC5Ksi8CSkCm7mCxuu4yDAsm1qjBJtpw34OCrXIGxAN6OZgsIB4E+G8SeWQyd2QzaXelSk6Ce49ugCldA3Gr6PxTsgmBqUucuW0TK8fV+UnsZXAUP9TDPvrPNrt9+I9vpcUK6eesTrXEYLCgenCiucMxa25P7qzSrvnJOgNX/4LrtTs9bE+Fj9B9pbvN6bcK4T9wnWHvc+JgJPGApPcCW4wihyvoLwnONOOCKKkdAnOJzmxaU72ero/odLlq3h9lcBJxtUuTuI5LoAzhxtJrDZmsvpqukjCVh10DoM5GZ6MlDUk16395NThm6vtjPU7X/+AvypD9nU4LumCATVvdmGkGSDc9CXDibszWQvqbS3McEfQOVBXP5LxwxiRAMLdT3LjIJnlHjVV3rwcU/wdaPsNZgSef5C2UHyuxGxXl5zelQIL7FXhyMQkBT2sG5XeFFeACurK6lo4TIb0eXOAFmtSO/tJxA84KWtR/34gyx4tZFEawcDn3DPZoO6+B1ZIh9lZXXLGLorVFkLTVIPSxqy7nRwpX/TUexx7YsxA7Od9D/G0SdWWQjxJ25d0Gcdf+0iDw6h+tZiSib7PIu+lHzGl+3YKUtfrqqWaUVlplzqM+dEmW7AQJ8fLfYQ0dJzjD6JZfn85kkhBMnKmGug0UnspZ1ZvtiA+sL1PCV6jr2eES/8HzuMcw0GKuxXAS3qBYMI9gD8oR/F88u9pdj0dT+FOVI+DBXx3mAUwf8S2lwUp44pvTI2rLkrD4eu2mq2qyfoVY7CLuthmDjn1iBmsDGTieEkK/PnQi0B4+v+V79Hu4QY2bX0ahp8U++sb97rb6gk7MY605Otp9sZkDE2/HFG/nwh41syJ3ajSBdJHCwncdPT5H5XBw3Z0jrvsyviiYeM9xQ3HX7uqmhptwH11WkAfFYGQuRiJLrTR5x8S/y3lu9z0L8nm6PAICbOeA03V1KZtqmUNtry86oNoMSQOM0YQJOm0+p8R+Rhf8BYoUrZfG1SVXtDxs1ea/6BOMArIM64LkzEj+7SQrSAOoDY1YHZy8FtQUXYNYhz4di3bInT4XGLTl3tAxx+gZSW80kyOs8zoLQxeFDrVk2iis8H8LvmkVWgczXeaD1xJ7AuxvzgMHh2evqEpmlhIJaeMXbAB2c3esF2YYU881V+T+AOrAs4iSmcYHRQcCl7yHFV65oeUPrl/q496VsP5JxvqcjWAnk7gTps1qPrW7xGxxcyMgY2Sa+BPjId0h4FRw9B06g2Qibf8C7Z6j8cN86lmc7FUjNvGqbleJy9c+Ew7PR31+QXg+x8IAxWCp3j+VgWTZG4AX0DUo6T6G6d9bc6vh8LuP26+t+NsM5K8t6FuW5dwlfOMiXW1RyITbso0IGyY+D/e29kUYY90tqwPeaw6Uy4MppcF6puAKpcA6uSz0yFIPXXfV6oDQJH+ZQQ9e/9YJXr7tBK/UJT6BKmYVoycYSzE652HU0j3fHgv7Phb2vT9IjRv6WNj3sbDvY2Hf76+wb7Wur3+pW1/M59HpdNp2+0qdnunb1epktEe1zjs9982+riHxu9fr6kv+1hU7vaJvWbPz1vDdqHZ6VZ9Rt4vTUTZ1AzE+Trez+eghj+rrdV3Nrmp6HelzZtQ79Dp4dmlUflzIlg3JslVumu/4dmrBvx7sLwbAm79NKX3fZkbXkWA2y0aF0oNkw5dwZzfe27zpRXdPVDLD8otOjV573Y1tJJBxVhgHQsjZkqAXmEI2nMKZ5ZdhGv/JMrUXF5FmbrI3ZT4qFWGbqtK4UAWuRI3LQE1n5U1DzOk5xeed/uhtxGO1efnha6tA/lht/rHafMPmPlab/xTgP6XaPHDPaG603TbSdWWGBTdXBcRis9fz4IPn4jBpN6Za6+4ymWjmvmjRWlX+iZTVr5ZZI+s8GsYoYoLEQYyu92Pmcmnw43RSNbHadiQYvug2laTR0fT5hRX3LvTtTvVpooL+NaN/0U1Lf2RJoqiKDdsP8C8blNCQI+hpz7acn5Og9ZBI/YUGXo7gTm+mIUjAowpkDef3YXpO6k1xGKItAGJlJXpXRwdVv78jhdIdR0eCqDTHiHsiKAoB8Spmm7xGjL0IUy01oRhI9lSPGCtJjm5OZWHqGaIoSdmmYZ6H6SXF84zjpFRi7aXqy1pIpHIXFPKb0oNa0DRg2PXcpwLWF6gU74u7LjDfy1Xv0pYW1+zN55GtuaZO6Zq6g3TPKChT048uOdBMplnlBly+uuM3qRU8qgQVHC1WCb5hfeB74RAPrAx8w5rAV68GuMkxusaXcO8T56tbmba98xfzbLrjixIEQCpcxdG3elYN31FpS3fpjukNQ+nXOsabxQTmMA6si+2MSkUHzNACCI8pgbB2LOwixRVNR84lvlThhoXNyh9sx2VP7t2nfDiPk+i8XWpcHUhKZOOu4aknKOw2jSUfUsjC8BlDFeYbp4CrSRnF7O24DE5fDjhKIeUodEUZ1HqIhoIA4+3xU7X3LIp2+8Pes729YX9TqV6vN3y292x3d2/36dN+b2QdvHcYtEcTNXpfzNviTfsyfA1ZeoUkd2KZFl2lrp41uzfc2nwWhbC8LbW13Xv2bPQ02gujndHw2ejZtq9rO5O3tKIDP7qE0qt9LmAgB/aWmjo8eXaZh1NSghNQJ+a49jITkirIFbuBhQqwps+GQu9GbEPOAxvw7+sHjM7zYpRVdfsHdB5GtDUA+CS7dhdMderMjkqQHXbKWaeQlk5wmWTDMKnhhb9uWohaRt8B7au55QEyPsoCboTPx1wSw7VYtObqeMXDS8FkzhWvYk4fdr95FEYxmD5EglOKWZIRXZUNSxWcnhz8M9DTvULDCdWPscwoK4oYaMpm2Bez6ANl18uQxcZanc8MANKJMgNvdv1z1qKbSF8RzhSWcjJfsArLtjqEnWBxJluJR+9bXCMoB7qNeZFvEOlv7Cu4n/ONy2yj3+1vdp9VO6NQya1RWyh8iXayWcg2CzNZ8PPbV8bdpSUY6pSAqfpaJIltidLFVQdNmZUMeRkS07L3DQo2S6z6XhUJNcV4zUTq98jm5tZdbUofsKCbGETrsgC5KyU8ScubLolRvWKcuaOrqpeT0H9kGqahrfAcSM6yzgQD+ppNQV+fvb/sBMNcXXeCFL+4xLCgdE5f/x7m9TMPry27je1KYnpD/VncTiZwpFzh35f7D4OX1C7mYyT/X1k5Ck6ABSPpA1bVaM5/Pjk5XDP1W5cXq32LZCuxPSiyyjSezRhpqaOr/UUY/opPwZfrqCXUtVcqdwasIdjP8lmW+8mWd5BE+6KXWWpUl8HuudKT0A2DvmNlOHbLuodZWkW5uOeydrtb3We7PdCOn273d5Zdn64wfU4LbTsODVf5KTR6ejI4Oj7rHv7zcNn1tesgNItq8hLec3Er5gS++zA41MyI/q7aolduX72z9pGOdtX80fnqdj/MUoYRPUWzFwXjX4wnxXZYlcxXv/0T1ZvUw4Ggu+GQotT68qqfk8H9Qk8/o06r4xJ1rjK8KXQTKJ4qiMtCJZgdbHYXVzWLOXccH2S1RJcBI+stg2uD6Zezoly2Ff67Osjz8EaqWBGSYDKqsoD2nzLMiT4Ij7igcFhkybxUXGnUibKj0qvmXnNkk9cw+lCJm4sxg5VOFFVgTYuYuh07e1aTIeTjOsvCwzjdKEwT3/VgPTF/oppoPvR7XfxPf7eGyHPKtrmfwFjRxFR6WU6Mqi7EgmOTY++muYq9hG3NuZmvW+FCyswhCvDTcI7FbYCswuSmgNeBjkFLNkNO8UY2mxRcoz5huAG1cMW2APYMBa+pkKF5Ycob4tT4j0Ud5zuimBezeBRn88K2jK3Jddu3swpXUonUOVZcC8kupz6AOnlXvaFhlmF/gCbc/8A/cYT9DIek/PzAzODWCKsCvVrmc7X6kZBzS77WTuFddsKRyks2aOnugA3xjQ5t6RZRo/xmVqKdaDYBjkWdcwp7nN1Rr8IkjtysJWodhYVaZD6si3mF9gdbN0FaDOhX7Ss6T8+Ob4ZFO8U8JSOhaT7tFk5++/bN2/Ofj8/e/nx6dnhw/vbNm7OP3bI5p6m0lGFzysN7lzN556jyb15d2CdJwpWVEZKXsmzdcpZWTzHcoJAiSXajGzYvGE3gqnYo7hfccZYd7OuL3tMsB+UUKn+Blj3M5PE6WEkfatZiKcfGK9GBEd6wloKjd4kzKXiG6IjtD0ylNYL6pFNPlP2JaG7mWRQ8AkIytyx1uBdbrlGyu0TXjOOTRHcBCNX5TSBNZf2atfWzGXp7ccfBuy+epnARRedLNpD6Mv5Zfx9eYK8bgZtbVhEp0X0pjYnkzqy637XUY+YS6aci9TBRY3EZc9tWm5/VruGPl4s8eQjkIJJ/KnLPMkn6FMvUYu3nxXFBVSmfpW8/hYyZCl9v0mHQpntw0BR5Q7gyXOFG89mLbBxcU8i/VyGdDLGUk6sB4QAEOjw//3x00EG1aAogiHYT/AhfFjYmkIox2brWUzx+uFTgSrrENJcGNpV7yClXX/V+Bsc8Bz2P+8ey0oBZdjXMUQ4DkjDW+scuUFisCihnCuRy6V6yJ0cHQa7QL+iW0ra1r3VprDF1W+HlUd8A1CGBjvGqKqohZ4HOnkTsZSDH1WlytDna3tmJno2fPdt6urO0y9Ceoa+Wlywf6zGo6EgurXs60i3nuYKduPyIptP1GEgciEUUX3exyeRcOl2hIuJUqWosSel0SxqiuC2Xmgm+tZPp885dJ7j+rWtEwH+ICzc4jfrSi3sJIsKj2J1GOy0xstcHOzxFfdJiEvZbmvX05aB/y7SbO7vtTQyD3zL1Tn+zvalh8Iapv5NgsFV9oXAYnychIP9FExcHNLCHXzQMDOGZxkmTm6XKMWYhtt/pfhm7USvGn/vbfJax4lo0PVqFPqdVSBD/7RqHmhfwaCP6+m1EC3bu+zEVNS/w0WLUlsWoGd+PhqO70PVoP/ou7Eeyn49mpEcz0hc3I2la/PqtSe0YjO6DokeT0vLY+qyWpXuC9flsT/cH7DNap+4P3Ge0Xy0P3Fdt4fpMRqzlsTW7XEreuFfk95G9JoWjUWyWY+lShceghwrHx2vxvps9q0K/TOPZW2LWTZRbPcd2c3vzvsDVoHuIqHrqCi6YWw1mzaD27wkqMfolYF2Y5YP6aDxV3raKWF+3E232+rvrvZ31za2z3t7z3s7zre3u3s7Wb/fVgMpJrsJoubKG98LyGQ0cHB08BBkIlC1G8Aq4jSntPPv60sUWNdAYlfqNsVGCuSIVIS3S9x1WDJivmtpyYWGoldM19rFpOeb1Yh/veExJOuVzM6RTwQ5EsmGeXRdU3qckjSEuBQgtgVKTH8yZGM1zHCih7oOpYwJYdj/mM4T8E0TNUzXK0sjnu6b10XxWT+be2lw6VF1gxFqTgIZz7liY5Q+YXNEm/SCZCOiBAb3qhKgpDhOgpo0Qk/WWxpLq/g9JOoGVfr95J7C47z31BJb43WefqO7/xAQUBwFfo+BvgPv8Yr2Z+ksL7SYn9ysSyc1V+wUF7goMX4M4bUD6qoXlj4iq+fYkaY2fLycnawi+HSl4ecJ4ABHZVlm4jIEPMFYk9/Gt+93i5McXnLwoTWGRMnReuB5AF/CjZukWEbenBlLeOFUnaImfrL4RYYprIATXeVxiQiTFhwzDQu1uByodZREV1TKbg+WJ9ALz+gJtbalTVf6CPaEPP5D3E5DxEwZAyXcd3+NP6ZPFjGk8s847akHFDr2LZHaO3110TchLplsjoG9P5BY75hBOrcKaFiP0XIXDOMEoFYTFuiOscxxP/tvDH89/ODoevP0Xr1xJW+sGR9ZvP/0wH+z3Br/89MPZAP6hz/zP35cVdmiL+fa5Kzjq42roc0wA17nB7aXqaTSfVMm123piEIH11FKObGt8k/ZF9kgTQJfIoqB+PGZIed4QCU0ZPEEkn/7WIWQf/vNkcHwAH9eYHlxHkYEhNoVbAiqZKnXeeEr1xxzrlZDvVCYkAsbRX//86uyI5qKx9XDUI9iMeBXmVEcJUI9hfjxsOqc+c7RWS9E45sGvb94eMEHDp5/wkwe6Q33VNsRE1GoUT4FgcyXhauw5Qz9XcLHSX7locGut/ntl//m7vAzf5So6L8vZu2GcvpvehLMZekRX/rO01YYIrqXSzqclICXMI3+/+UIVLqKDVIrqCpkkll3FJL5qYwGD4TBXV1zpl7Qi7YrE+WrXyMt/vHq9LMAATQvwvgSwuBU5BoSQhxnOAIxUv/NO37w4+3Xw9vCd1dg0Cz8+e7fPsssvrNK/O5qiQPMiNvVMkEC5CU3x7jpOEVCku6VVulrhpQdZPgXt4NhuTA5uVQeHoxNKvLtp4959MkLMMW9AzLsDNZxf2po7dxfIceBsq7EmzaHv+HpXm6UgtsIScTVfVrJf3VonwsRHg0yNV/hUhXBDwXUyDkd4QWNY2iy+yjjWJaeeryAGxGqES9HwUU0d+UDhU/RAwX1/bAStxGAXKCRT7GF6gwVP8UkuxX24fypRC8GZC4IMXSiqPYm16JkXTDtcytveThiyA4RIU7CsIHdjnDtCjdUvefEw+4VgsXthVjJABjnKVWlilBBDbj+gjpSH08HlVDEOQ21Mx/q8owOeLEXolredYJRgocBOoB+lbnzcjqmrq+NH5/Gsiy1rqJ75DNQZDl07OtF8GxZooI9nFx2u18F1p1JBGmEslC48sAS4moG3JslNB+M9YH9KqnVnq8/FJU0W5hgRCuKeiZZ3pnref7bZ7XU3u/2di3tU2UB/fUtC9AAQQ3cETAFbT2QAhAcIyTVhiWTFIYOa/Kntj+Ui84LVSwrot/iTUU1dFCCbIi7n0oKPK87BVKvYhCgtMFoU49isviWAwfZhn6xyMkV6esLhtvDuOKM3kKCQZdKlZwBYW9rpXe1z1Yjc5l5XiD5hUEBeTejz1eiitaYYeiMpVhJnWwzN3fxxnnhFxt7qz7dwRnxG18ExTaWc+GCyaEhEHgcKAi8zPS9MXwm4qYCdIgASHa1DFoEGVI4hhvAwFYpLMy5URguzmoAuDIdTOOGTMto1SedarmUVwAGcL2JYsfAUDVQ0jQtyF6AAmGeJqToNDE235syYkQVHB6cbRyen9gfTfquD5hY95IzDx+MsdR+Y54kEzsIHIAxSHwHTGD1LKRUpyqfIkgsVPDk8eLsm1aRN2Cb2fLtH/Z55Oan29Hi4PnlU1NPtsUDNNWeFmkdZemPq5DIQFG5KfyFnAMLJVWjzAgK7V5qyDGUQV/Lou5akBcJ9vv7K7QV7VxUB7s3Xlk9xYJv/MQ2weCND8RIlBlhaejCH1UgwWEEua8lDxxI3IiMYwJ01naF6cOTIGK9U+H5ZrLTvfjwjHbPmeaSNlw3XeGhe5A9JNnoPZwSu36IkWWZGneyDg+NTjgB+eXZ2chpsBGevTikwPRtlSbEsBloLIx/wGo8OmFFhPhRHR6PqLdW9qPIx805mlI7UZC0MmkE2Es69CKbfWzrgqd0Sw64ikCyoNryYNxjUcEwuCu0htn5fWPFV6gHrOsBLLL9Vt4nXf53XScYqnWGz3Ll49Wb/H+dwCM7xEJwD8S+7trYL+K6+9Yr2YsfLu/IJ3b02u9t4H5hfEY04/IiaZsess5FiSd2nVleLIMpGc5uX4c9GCgWeTHjQjAkiiKWiDoq/I8c7E2IqzntaTzDNzD4l7HBhFAy1VG2vOamlS+JO3ZamixGDDnwdv49nKopDqm+NnzY+antR1lJt+euPK5QLM3UA/3CIQZEi2YRlAnbl6lsXFQU62fe6/Tmgf6psNzjXhCTmvfMTYfnnL1jOWhZP8/lXwvvJ8gA400EABkd0JRT2Tig6lcsgVsVS14HPMOvXQr/X4/8tbSBqNajnbGL7EG0EaAMtqqLDUOGqiXZIr5dc9frSunesyYQR2G7CoiSd2m9uUZMG8hxusu4AGBbiiyBTC/6WYosMUR9A5Uhle8ZGVGelB03VQNLk21CkoMCW2+d5/4cxuxaZn46T7Jo8SnlkdSb0GJztn8io3NG3MGAybCMVX9kAlDgFaoLhTv91TIW6VfmkWJMfZVAc0MLCbgmmRSN0VWcSBpnc1PChaQTvQsFLmYdpEcrgZEMTTQgTauecXybdRzDHJ1gx460g/6BbzRlWQ5FWAC+6RF/ys+iJwryRi1NDGntZaMMbt/gJJeWtqEzhrkOsLKfeBKxB0ypkRCcLltTQ3+cpEwW5ZtguJm83DWZRC5dWbcgxsWDcxnU6nFWlep+H39BL8L0/bOCBSxt+xu6MIGyTo+RDKe2r1YfRBLsKdjymHhemgzU8BocWlqt7oXPzwpSSfUPPaqQte7mZY4yqsx4zDaSHNl8kbNoTp1xRoutMsaGJM2Sl5Tpo1Y6ZkRAGWrjt0AHaep7NcvStJDf3Ua/Z7tmW4MQtQunqk42xfc9xDYbBTIfx5TybFwA8UTO9Y7g8eRQLkx1DDUlDNHp2gA1F2RQ3gIyhcCt9gAeRTrpB8C+L2TC5xiLEZFr2r+zwWsOk6f6iK19cMMp8GS1FKco6UaO5zrInoy1aaxGUiy6DdYGd+tDAS1VJRGZAH6sZMsbrtBLMEhbdpfvTLopnkaRfHgfNy5mBUkwaWZpNsSiJtDwkvNuvDYC66xoP9GRwerxWS7PFe1uBSmJtTYxKDoZUDTf0Tn/3WXXNXrPLrzqda/kImsb+lh4qfsyySxAAXr3a9/DREJiyTDCk+5pf4YVCUCg1lKp3O/xeSIJZdH2r9vzmX0zYd0D2Uf5thobH983SlyrrjjBJvqUiI/toh2jcnddoT1WV/kgEDvwQY1J5WzC5iomZrAbfcZbD7TqgYIqwAcg5gH9zHhdZQ8ryw6COpwiOTt9QfnENwv3BQrDa2k0BqXFD90G0juqY0v357gAHHj0n5bxp3ldwHEEMiPi+Ro8UfqjH3P5XsAInd+V5sP50q7vb397b6nXgq7CEr7Z3uju9nWf9veC/V2tAtmjEWf0ZO4Xp+7hi4AxN+8IOxqyTkYukMPjtEqRcENJyt7QRPHADEg56cVHs9AotyL1Z+kajWNo4Y3dM0gspWj7JOFJoiJ5UnRSvRVt7QzF4STCbgMqEf7BhETQNfazdOKzjrEQ84YMsgXPXaLj4pnRBAqJNs8aadWOYgQiRrkej2t5gUE6WtnnS3tIMtx209Z/2F8HV0lETmBpP2k9zNaz0Qa86MmswNDsxV62H3rTMku7rlrLYYV/p+A0y4tU2fgH/3rXCZ0XemoajFnDzerC/CGp3chDpu5/g4F09QzVTFC9KuXAVhSH1rzwenBn9Wyo+xCKZ2TOLRXDiKzRYHbz+bc2Ref2zQtpckoVwisMkTEd0Wh0HIfY4gzMPX1eQjOucZUulNtwrhcBFAI7/FaOANdh7SHW1Plzw9kfJcJVcl9o2fGKejaB9EYlzwCLWWjpvkh4fsM8bBRNeTmD7nUk1jnjuDi1kNoPvNcjzoRY6zZY7PWI7TiAuDScaJ9okVsZZ1r0kCR6TPFfQZrDifK5WEWQvqgQXoVkTa7tQpQc1igvUqKTvDum4Sfxe0njYQ1jMx+P4gxmRnqFGks83NvgRfgI1qTVQ6Di8B00caB74EE+NOXp4w11Ob4IyfG93lXXiJIRxQY+DP4YqKVj9RlcCqXZUywjXfvbqoDCRuyujrDt/v1K/MS0yPJIos9k5bf9noAg1HisqYYeziuQie/hEwSrWOuwSeZ9m16m2hXlgBYL6jjY3EopmoSV7GY9TYGrEU53XDIt4tBgi6vm2yYZIZhHF2I1Yjnboe49sMHio2y7FuHqXzXkxkUuOCweeWsQxYKdeHQxO8CoY8IoPzFAuqazWV6fg16SlxaGQH9AEWjKph391x/MkeeDM3y9mfsEFrxYBLommIzXiFr96AttaBodYA1IJiXm4IWvqFyNAdqi1ToG8yNaciYvLEYrDUPyJZHfc0IFsDYTKcLaoFLs7wZPVgWgx9FUXbiS+Q2GmGK5ouva5kQccC8wMCoPwMHot/tMJTmMUmo8/cyljOAwXtArq1pfLB1zdhWkyCP8e815Vox1SqsFt3TWBruzYRFR3ZnY/CCkZTYvmrEPxcGrwF2Npp6YfecCFqOO0vmiHp4XE0/7ie4Z1+RLHNay/ur0JpX675mgs3d84WJJ0FBv/hAYeAQ7L4o6yJAF+5HRcP3NbVZo2leMYw+OR1gzlw6ILIXlTQ1PPTWkp7Gu/hx9MzSZqiv7HFsuwHuo5XNan49s0+E/gSKINgwu6r9WqkEdEPKSLssuy0KVC4TbHJP+C67BeyIB0sqNMYRHIhmidvXB7vNPrjT1ktHJUG6rQmviHNOUIAYaYA5ksNVFr0Clo5oXDz+BdSjZJs0iJudBbsvXQmUx1IhiSSyNVL+9uclZrJWRdYCQzdhq+xwwXUAEA8/GQ09UNfVpJG+kUCVI3WKWDkaoa1fopG3hgULeIR2hYJXjNkGqK+UWRG0dnfjvOSnEbx5xbkirpYKCUfaHgc+mBQXHhmYd2G6/pOKg58ptvaJjmAt+T6wJvD/qI2Cf5KWwoeB1tPVU7ajhWvVDtjrafPd2MhurZuNd/uh32d7eeDod7m9tPx37r0ZZsl56gpYmN/foOdyJsVcL00oYXqcyqnEy6hykxR+gF3a/XvP0Rpm7GcEQdYpYxJAUAzgNi2JgwqdCvf/WzQUJHW8C5pwRdsnTZE5IaI7sD/hF/O4JHcQWHqLQBZXJGjHeKtBSA+65lATYxYTn8art7lD1/UGFZNA3CmqNccFQ/eWaqCJhHcSMvrLzCWVxjPBiEbrf6dJ2ulLuOdTluPhGhybxNB4qmptCQBE1Z4TMOJaCFhXiRISUcQb+suaKWhvE3OqZOQKlbYYPSasmJz2lHHWcT9NINW7T+j6GumW0GlevEQKZTzPRoy9FShSU7INQpqgIAPst77kQX+oQqNNhFEHB6narlnWS49NLVVSt1TbBav3hTR2pW8uLMbAwxoVgLVwKk5Cs5DWecpI+MTjTs1DwGAV3vmj2UdKTxvghAtXGvernnsgJBDVwpWuosCF5S9B+wxdqwBDt8hQv5VGMZjKaeNTgPxBUMjmVRcJlySFqhGsQEPd96T/6pNIcunJTOB/Xkcp4wj19Zqy/dt5RzTyKvjni+9z1BLzpUQ2HBpOM2yLOenGBuaEcw1ytxJjnUGwSkRIOg60vGQPerD131hC5gvddacrrwuOrFHVzX247GeNqH2ZFf/MJ4ekNMUJ6nW9R3xfJg2Iwky96jRzuUTDyMW8ZmKBXdwqnFZ7h7HRtb3c3utqtnUeyep2bZb27RsvipuyM5dXAg9zQg59CGLxL6Izkhm3cEa7ruM4nY/CpDCiU48jGk8DGk8DGk8CsJKeQzqStMWUbyBeMKGaTHuMLHuMKHAekxrnB5nD3GFT7GFX5TcYV0WXxzcYUCtTv5g8cVytV+RzwdlqGnIDR7ajMTatcYU+eksmHOHClbINp+7TGGC9HR/UR8fIUxhssLdZ8x0LCB5r94oKEraj4GGj4GGj4GGj4GGj4GGj4GGlYJ7jHQ8DHQ8DHQ8DHQ8GtmaZ8caEg9UxgYcYCd2W9ucYBJvwekQbjqCwzBksglbvJOZTbDEZaI0fKDzAXSwgd0NmiTkb74EebXcZmrYHB29r/2/wGqDUBIRXkbgw+pvgYsGtfpAyKzk2oUmtqqcW6qeJLuJ2MeHZx2guMfX/zaoaqXazqgwXQQ1+Cyp4TX0C2pq3j3bwSFrt4sI7rFSlH/EGHPlKWS/RFssB66AoIUDLmy5s+iRhMi6u7ftPpl125qRuv5pIYthmKi3Q7FNfTNYCEoUwmSbGgluV01ndNUHdqhEcbwJRgjQUwugzMu4DlVRFM8+qhbs491Ze0efkezpZ+BRwt+zZTGuz+e5yVWEDLFM9lmq8nHE2N5n+l3sxkmJlKh6kxxfrRbwQszlYwVe3blQMvsprcYBVxR2SwMrZQSrHCOQMDnJhQl8NhL1F+54TwaFFSZZ+j0xls8cYANLy95ebrqTuXkvz46e3soR8tXvpiUW7vhkZ5jVq8ZmR41atz9S4pn62pLLicwi3wdgrL+ITjjcfzipx23axGadz50TZ27sISp33enOCbVuWNIio2zQa+33dswE6xVscYPNOHrM0kaJq5ledxZdLnc9PPjjllaE+7aLgZ5RqdT14PEcsjfJgbvNYKVN/Sl8TmOtGGKPl55n5tPtVnvg+NVAwOI6W8/e3bbucbfF6DtO9F2vSDob3SbFosdC/buy3CWpbHryRYtMZflsXuvMQyupUye1hakRux9OsOFVDXbLevoCfbjbDQvtOJva9Dqgo/Yf1AlY5LJYuqkFGNRygTk/qsspvr765GaoQVUCnRagY1B+NDd6T3TwjqoCiyocefXe/SmG8WzSWudGE65ixcI8yRESrVVnpLJLJrn5msJwXVQWmN4r07PD/cPXh6evz0dnP96dPbyfHB4et7f3Dvf/2H//PTlYHNn1wByV117rmDh4K4lLJwcvl7XPegwujlaDxP08rq7llFwval0L7CRqdyQPulAHFU5nXNdz3X1ASPU0RYG5HFRX9L5aIKRfNjPbSQWb7dFUcBuAs4BMyUj0ZzeIHofdbtLNxJZBElLKB7oBj4urp3Ja9HxHvatajOhaMzFe/FRe2ADnvUuACjs//CTx8ZxDlqSSxY6E2ZiAsoaOjp4O7P+cRuFtrjuNNppaX/2PQYF2mA+y/FGtCWYXx/sBFFMaiJg8eDwrdlGP8KbEvKWODkvOKuiQA9nOhJvEhfdJbsjN3iyuWf2aDibwpZB20lxPpupnLJQCF/VI9J78XR3/+mLzf2dnR9eHDw92Dvc+2HvxfYPL3540dt/drj/MXtSTML+F9sUYKj9b35Xnh1uPds6eLbV39qDfw429/Y2d3f3Nw+e9Xc2+9sH/YP+/v7hD5uDj9wde+N8kf2B6Zt3yODQySn49B2yo/JOPcy52d17+mJ3d3fQ29k+fNF/OujtHW6+2Ozvbh4OftiGm713sLm7c9g/eLr3dOeHw6dworb2n/Y39wfPNg8GL5ZuTSFrjIti3prIc2BztHTzSZT358PfQaYxNcMJAv2JJLnG+0hKS9d2qYrA/eO/v745YBfY2ywrg/1BJ3jz89+P0nEeFmU+H5Ft9UyF005wsP/36Y0OHIEPOo5heQT+Hm61dY+LU4hSi214Ps8reacoVE+ya47RBLpCYkMiOz19tWEFbczCSyM4n+/rPtFoW+0M+3vR7nBnZwR09HRz79nW5mZ/9Gx3GG5u35ee0qw8D8flUiQV2e31yQa+3ziLMf3UCsvUslfqmXtSASZNUjyTksMa4VF2z2Yc1aN2N3ub/fUe/ves13tO/+32er3flu4566x3SKmfn3HBIhstvdj+s6e9h1gsV3R74OCBSru6At0fWGOdyPj4SLhqqZLEK5fPvhHMriS+AkpovTOIYA896tzjShxXolV1g18Rxw7Xxie9xi2V5seXCtE+iyVJyI3JkzShGvKvr6+7krHXHWX3RTizyi/JnmsM2TJig5Y7GfL0RnfoBEZ84PXTeSg+XMC9Ss6bc1ap20qFM9qVTNMsO3i6PH8zAQrOFuotC7R5kGjOf9x/jdr81t52w9Pw/0s8vwpK0PKHfZ5XG1G3bQTBGW0bFnJVUvY747jDvFB6IzYF9hRqNIOl50t3nsGqLcOECH+JlQ6zLFFh2rSgH/inYJyE3rLisTZ2Bam6zLBQACV6hhQXN1JFgQEacCtbhoXBztTfSmxqKTYYz2+oM185B46VLK3IprCMc21e+6xbaWx63FqH4cYSCSeKN1aaCTtBkpRfODge2A7rT7QdE5lnHKbcygodsJcpco5io0yKdVoJSvO4hnUed+EP3Q+Tcpr8NUxm6bqGcT2OirWKfsVR0I74nmTX5Fku6lSHUG7c2RrIjZMugPG1SXCwAN8QSwQn81L4hLV1pWzpwncrVLo0mUnV2a/Saiiw3ddqWF/Sl7IaLoKk7XutBauhuxcftQdftdVQwP1urIZ6t75lq6G7J9+H1fBL7spDWw0ru/OdWA2X3CFXWf/mrIayxlathqf3sg/W7IL2qnBq4n8B+6BM/3u41Zoq2mwglC6fD2Ug3Hq2vb3dD4e7O093ttXmZu/psK/6w+2dp8Ot3e1+dE98PISBEE1lIAZOZzV7mRiHvgYDobPeTzYQ3nfBn91AKItt1151urRlqsKSG1gAapb6ZGNuXissoN3+tsdzqhPi5Snqmwq+K3T9Mfw+y+PLGLOiWb9toIDu5tKbLZO0bWA4psKemObCSjjdfsa+QOZKd5l3LbFM7ujpbOKh8nCkkx91TJTz1eK4qANbZFQP0lyzlsKY/lSaH4es0sC4l3D/69MTBtMYi0LqCsv5aBJjZDlSJuZAoJoFKvBVrK6tZmUD/uUQOIAHTupEkCsMBgONdd0Sie7ee62G+netPsEzaQlyUVSpjbeOy4Enc7x4pmFk1mFrNgzD0Xv3zXvEYyH0LQa9Li6OzBPbfKoBf8PgFnZtkiDDGbm28bDoykOFtw5g6FKh9EeSoRnSZvJxXpdGOF7ECW+eU3gS7st1seooB5O1lNrt4fjZ5nhr5ylczttRuBtujdSzzWdRT/XU9tOt3Sp6TavkL4NkM30F1fp7nY+tk/5NnRrKyZiqEHv2RjbBxxR2xhQka6DBjEqNX4pWlHuhhr5eb9zbfRqGvWH4rLc5fOpwhXmeuBzh57ev7uAG8ISOf9SlRcVHQUZuOqeqVNLmng4evFJ0KAxSntQcC3EwzBUlZQcRprEDSWRBMcLa5h1T+WAWlhN5Pwu0HW+Zg9ZuxqsI2zqLLU86Njfcd4+t+HVusVKgVJoNCZ/T8IaDdcVAjpVk0mgDUYh45XTa5KZDFIEFG8NqRj5n8B+J1w/H5hR+pyYNV+K8zHTljQtx7UkRwRrRNHj4jJtBW6LbQu3ZRIJsdT5nIWYwZE568gYxQE6DQQtsSqWKamUIjLlNuVAtmprhQmOLZwd3EUsBwMryG4qfntB589+vDJ6okJII4QKMswgYHZb/zZBjAmGPknmEHoNamQXWkelheHBlll6uWDsHvr7Sxe/qOzSTG9BJWruc2uIwD74rWDAlzlyKD0jlYXL664VD/2U2W6kgBx5gpcUvQaGBrmTfYu77w63ji+U2HI05ix9ZICVDxlM80pIQSY3dMZfBHNgbx1ZCxUCtjgOs5gLpGce7IN8h2V7owEuBc/SJoHZEoj4qybnWHbTA49ctdaveNITb+xzg+fb21gZX5/2/f/zdq9b7V9hub/f0gfwOdhAu+mkWUaV4y2eI9NEkgeVWHczWK345bRRSU310moFImqE4zxwgG9LNHZnLAHY/NITT4XrkYeGSQkjOVqrTzGPgq5RBADgOfp9TKSGrOBLvwnu0WqPFUI7J0jWvmWFDkvTR5aYB7Xj3fGMzkI8iIhxtwc8efc3ConCo5sH9cjJ8RavoVmAo2yqhcBKiadWb2+GtgqCVCjgtVCpzK2TV4ICdrHEO+M4DClWom5aQREICTSBEbGouErz8i/i9m9bgytErFWKr3V3/l+4u8udFrgHCnYVq8LNAZ6SWNMN36YQ6iWpsu3Ng121qco7Vovmw8Y5+quNMxotlMcWMyIWU0gCjwSw8BDo/eSFvVwrIex0f4IfyGlia5aWozlxnLKtWLugvXR0NWfBjabSvpzQaK21tEcEpjb6YJ9Jts1K5dzkL8uJ5o9zJ8C64t3x7wmPRN/znsejb/Yu+tRhS/LMM3yCjuBB4xh39+Y6ufGS4q3aM8Gooma4R9CiLt5Q5q65Co1+IncHvIiFJtkgf1EKH2tNRIWy3IC5+E8OVwzeqriQFcjNVqwnZRBxHWk3Whij4IaR4HxG46bYuHPvw9B4lYL7ben1fslTfY5W+xip933uBvm+gNt+XLsv3WJHvzop8X7wY32MdPhYqzsNLbUZ0RIvAfruEgMFjaDHD9qFF3wgXxAuGeXbt+BDd6no3YugqMAgImVdK7l3tVab2ZTAUCodGVxev+tyAqvXke8gEyjSi/AxcQmarbkl8MtENmhYTZisAWdTVgDoNx2Eee0B99UbgCh9w6OPco4/qWl9nf4LSEG7sdHvBE96N/xPsn/wsOxO8OQ36m+d9Vm5ehyP84p9rwWAGb/+qhv+Iy43d3k633+3vGPCe/OPl2etXHX7nRzV6n60F0pxuo78JE73OhnGiNvo7h/3tPUE3DLMteRoG6UV3HE7jpC2rGyyFxw+eaJ0oV9EECzNGahiHWAMoV2pYROitTCM4wWv15Fx6sgb39+HyeTNTeegUStSyIWkjOj7XhN7m1CaluTeJkM7r7PfwSlWx9R57PrQlxtfWwLMZsDn0ILxedEK2u9vd3nq/v7kO5wijuarQfycqwIK91m56Z6cXbe4/q5jR0unn2lk9n5xn7G2UFZ1gPpyn5fy2Mxzm13HtDLcbGlgDfll67Pe6/SqnbBfUSmPRW25O5O6OfHWVCGcUyeqXV4PjZWQqfM5vzskWftN4fq+32e3/gfVXnxRrbp9PbUUBJJL5C9196SXFjKBorvhPGj8simzE2XTczjnVLkHSF0ihwFWbEsNO31OeTDohm+pf8twxe0a7uPqmVaBfO49wOAAtkdXCUqjULLlQ5xSIQMmDevMmtp30H+txuv4HZp6Gs2LOUBYdUXeaIAs8b6dpxSVDu4VxQ+PWLVRawCBcifg3pd53gl9BOS4mYf5+jXyWVApX6vHqzsp5OAb01DARp9gqetGu8hABPySLsxtcBE+0KU1Gld/89a8tWOTty/OKUt93lbcsz6tJQEE52k+FmmgUxUJZGh6PVqgNUsTh0oIOLDRMvECGfDPUWR4OcWvq7bpULrm8DfSnH5chDW276izFr5tTIaGUWgmOYji3ipTu6gmTMQkCZ7xF++K0b5LeTR3W6NwuT/dQbVozztCCjg5YUpRC1BLHbrBf59dmpLtSwtvTfN7MuGAjr4BU5vusATYF0xRuX4jh+vMEzkMIIr5uUajZf+2HxfcAXgPeQEsY8cOGqYOaRV8n7l+ZC2ypupNSSL6l/fHaqYtAgPzcjSinhZQ1vITk3TG1x3XBfgm90SLRujnfT8auDfSA1Bec6/Tn08M1/IPEXKxCP26KhT4Iy3BIN1EevJBzu+b53mxtgD/mWAv0ch7mUZf/Rnfbxh/XajhRyWxjjL098zJMNtAHmKjoUuHQG94Cz3VdVlV0J+X03z/RQAYwHxn22f+sNUYH6dBE7V6pe79W/72i17Xyn3uU32koPt9GIVx/IpNU4mGhGGW5lSy9zbFKuhvURMlIVMFhdFUUG7Witfu/nJ4uiwkH4q9WK6phtdJ/tY5SOnxyZxXmCscehnAburM1vb3geIyulFP/l3jYxjj8g8g8+Sv8ek7exHMHuOJ8hMXTVfTvfWqUYaZ1eSsmeuBdfPhhlmH0PWzfobvC/9T29yjFlpygwnEaXAAC1mZ3t+OG8fjokEDBtyf798jCVymmQ7V9QDQXdTwoTtka9Oov3Jr64WjaoobTcbgsClquDs8rFtbw5OhgTQdOSEf5mY16br4sA3Zgd4Mj1+csPeirE8ig2j9Vx2v19liW9K/hsJ3HQOtwBOJoTWi9SuNm9BqtHx38p2GP1jf///aObblt4/rer8AwD7EzIkRKsixnps0othMrbWJP5bSPDAiAFCIQ4OAiWf367rnsBcDiRlKK2gkfbJHA7rns7tmz57az+ZvpTHxGlIN53MrmUFBH3iHaJmAq+jNLG8ogEQyM1nT8UbyQg6Fmf1Ablzpj7CPir6PpMkrgVzTnie/fwR9/VXw8n89HsBEm3uJRJz+fIgX5OQRkWKdqg3igZD6bX7hjJgX0LxjqCtYE6WNl2ANJZkhMfYNHFBxCoVl3XAzaMu5R102CBEIuaF4DiFmJU1lhw/jra+iGwmEyL1mz62vmzkDjnov/yZiIf8raU4KyTSo0shxyU8xY8+9Bxcy5xxROn6CxwVXS+QZ9bSi1t3EaFZIpm7DIIj93XlBpfcEccOXr9BMK8/6CF5Vvs+guisN1yMlc7CUWR0/Kant5xDep6F5Nny/0ofqFZusMu8VruChqAnF6yalefroNW5QAi/olVXWcutOAa/G9bGiqr9xX44Y4TO6iLMX6XINcWU801u9NtPoG3UvESMskBpwlPEJHzi4jhA5Zoe5jzbJnMERQAzPNntPofGaM+gYGfT9inyqJ0cDSgEvqIRV6ODBIgsfKP9y6GMjhx7WV40H+F09aWypSWx2dX/zyL6Gaqc0ejsYR1Nq80xooDgPOTy+BkE80UU/+kd5PjpzJz4Kz5WZCs3nyIVrfTHAI4Jjm3IGHdanEp+oRZ0JeN0BSCQYFq0BQuq9T0RdF5j6gDVHwGCJgjZWFPeiXK2NkzCJ8A3J67hOsGyu0Fy/x1mR7+uHqn9ef3Y/Z+kicRHzXeYE/gPB0fr2eUpGUJMWqgKvIOGplay9R17Xc36QgDKJcJkMK6sDKgHIfLep56OPkBM0W5QRoX1uhPRlXxITeBlL0szQnxVlMgDhomaLJXeAmUEVund6hzWLKogina1MYkHNk2FTlIXlE7UKNulXDwKBW4B4KCrkJyutfMh0KAQ6EKBUd8UBALoJH908aImA3DjaUeADjK9BWLk6BId8KqYay0Uv8mzSjr1NfHpnZHvk9vVPhzN+w77cy54Wvo1zipYbsupBRkbiU4piz5WAw0Ahnsx6St0xWQq4Mn1j6KhsO0rDky8aLFgTh8w6S23y8XGeKmZe6IdoO8VtUdZyB2F7rGGaJ3xIvvow24X9kNE47emQ4rb28idZ0sIeSBWVY7Z04Uuk2NYvQ0JeFbTq3kK7GB/U23EvWZYaDQsBs9A1gPYyQ+V4nWdjprmPa2TMwN8eCHS5E2Hr6ANrLIyxRTm0d2RasDlVDDA4h3XIJOU9h7D1UAFgy64KyYrmJEiFTF/Jh4iVC+IpxCPK+OStAYfqobiFTtYzU00IVU8JLQMGqA3Q1s36xWG9IRn12nQq8MONdlZ7w1jBVCs4skNeI+XHq36p+5BQiD91v+HCR34b3v7nORyOpHh848MDhS0Rpr6EDV+BK8SPeKwMtad7CV7nhZyBOvUAoA3bh8zM/Ja3NrzRFy4B22IgvC3xhIbuENyGblk6FUhZVRh8buOI9WHU6kFmn4tOT6ZfuoTSD6bgJ8O5HTKshiulgagEebcSCsID2NtHUW/rB/OTUum9p6FfQA7jipMGD+CSnPK//r5xLWIr4UhoHpiSSCAHjXMUSZHLPWra+3LmeDRgSQW0M6QajCFLvj4Y0QDzVYA2VUwa0jeffCM0QhfggYNzANRoMhWWe3xYDdqzuVkOh8hwfOnCN9TUUDuRyKjHbDaPyqrV/KY/EYekW5yoLpHfyu2V50TMo4ozO/jimikYojegZrOscgq8XJDe1Biv1LYI3VcKoRS9SaGlatRu22sRsxhEI5p32dmYZDLM3sTKtBRRInPHQUNIZC2ok1FrLYUB3B8dJtUJwfv747uO3zgeoS5mKsxqWk87D7xq4VDQ5+HRoc/BpkefwIZlOKKidFBQsPW8/0DdLJ1fJKjVnK28L0NyRssaYoPC7dXryvvH+7bUZqxTJ6Bw39HP3YcN1/r9iZ7vHN8/DIVW3rCXFpKqYT/tMbx+aSuaKvQh9H3tXmiPo0tPD3oSb5u6yjOImyOaIqt17Mr94N5+9mQxDB7yNAMF0cNgRAcuSdR104ZIXWVj4N8ORkVAo9S15UDPwtlxCxHCBHieeh383f7P0q58rZa+quelOHXMWdktV3ahXslaQ7p5zdY5v08AudkYtZoMDokOKbbKCKi0yfFdInwSkX6/eNQHBv/nW8w9HlO6xCUzMpMNyMJFx9U1gLC6/2VswG48XQt5vxfGQ3518M3AVGRjzRiI6aqKM+XHkt3x2eBu42ZHPQrziRpwHDzvEut+WgQ7EC+nDpmZH2B+w7rcFMCiCkBl5cJKNjltA9+hBuwJW3faCtSt9+8OlfnmDYVmud5dP6gdLv/xQ7yvqUGvbB3TfGv0hm0D4ZajayRBc0cIvC8PvDJ+66skU/57G6W3kTb2ySCGMOL0zDyc/0VMo1otPHhzzPcc4efdaTyxdmbsw46G6bLPf8nsumfGqHqQRZkuZSMGBM+lKIWCkU9hhRl1G/xZw7z1IasPsPyr4qMJ4+Go+rmwSRlh9T0Vk88VoYnlmBWSck95IHVFpoQ1FECnDK5rBtl4mEAdHi9C9yauI44Z2spDqQtEP8PWIw1QQNfRFeDGWdsnJPXH16UialnC6R6IF5Hujm7GCEjolxFEVOGNnIUc1i66C0i/GMxLjLtXa5W5ATVS0dYHdebpUwH6dqwyhFwbklz2gjRCVkZCprWS1Jt+YC2LXKpOErhiz4yFL8o6GDpXH6FIEOKogOJ6tiEkX0/0yq7nIOu7q0lD/rYpQSvrAuGwYhjH6oBSPk0JF31LBQGX1rTmYJhy49lNagscMHAYTu9SqO5z28DWB0zcoN9sKB0yR37pXcT0bjJaEkkMYPRxMZYcycRLcgjqWqm0DEbLGVOJ22DgvDVVMzEKhbufeWu+iMO0kajlJew6cB9BGPHwl2/+PQAudVAZSesritAh2Hikzh0Z21jcuDfW6CbaHAQp8AXd2YErUMrzx4hVtCqoMH6Andv+Na7SuY2Vi5pVBZWzaketFEMcJupMLSWBmJHx042PihEUlFtUjahU3w1VmfjisKKhn4+qPkSA0m1me9xLIdZqx7IU49EpJTQOs1LJW0iB4J0qTRyTsdHeqcC5IFIdQpqTfpj5UbUu8nxhtTDqOo+Uxy0P5vzOdwsKejJuXtKdvNqC0xGI/NTxo7UTVDj+PSdVIckwnoD4A2KmwHhD2o6UHO6w9y57hLgzHMUXtJw2p0Lpw9qJi4NpWxZ+eCK0fx6G1fSK0Po1Di0f4cNvONUuHPTceiJPLHmfjGSiCzWl3n2iFr4lr305iX9kHRVbjKqUzI9WOdU0TfHKUzeh+iTTg1IGxRYF9HmhLBbepdFdKu2hEn4G6SRWpnmorAtbx+YkAV8rkVDHLyyVx9g9ATsHuwI8IWOQPG6HB3OZPheWlzoli0OSEcbZplBRcO1fXGiOnidCNj8X3TkLgxYVRAv9J+G0iuVU18qNcPhiE+CH1wp0mMN+nAKXrcq5dZ4xAkYUhXrF174Aq1ZQOYMw7iGxQVirBTcpfV9fdN7bfLqEAV+I8gqbtusd55h+DXeCY4t4z19/h5FARvpQ3iJf44KUaVA1aSEgxiZgHYWCfP6sy8Yvmln0IUn9PlwuBxAKcO2W+YPPInrRKfNlurahTJDMYO7VwzjqM7mkUOqofbgdQhOc9bftXE3YwUXanjqbnGeyqz9aI8z9lw+G1/X9ow+mi7E8bzmGo+tOGM9iGczCrhJYE4+eSXvfS2Uk7ghALa94OOve3g1l8DkME1SknErKSrzvsXBsHs6QdhgA8zo7B3/e2FFge1g9De6vc+u4OA4hV4NgtWuOdSqoAVa8rKUruUnKELgbF8LUzQy/di7NXs9X8/PVJEJ6fnfsXF34wPz31vCA4W50Er2cDI7qwHIpCz8wTESOKuUT+gx/rYtBJVJjrDF2/WiGLEsvRpa7P7EP1MdYrz2NxaMI/p5AAw995A52euFhGYAQDwCOZpTEvSDxkRknFcHMTCQoz/+ahSZ/NAGldle309aCHECpaT92chNU82ux57TrQ+JHowXSAdVFhE0eDgkyHzIraTBgx8gpNaNdimUNr4v7oDsQEx7QTnWGO+SF8S4Q2/cXl+vkjuNZvkd0llOBxR3qkORYv7jauVxyGOIbu2fAWEIWSMhBdzCWpHm1RzmahH0Z3tiiGQakTA/YzmfbQt6Et07Q43FYWBBf+m9dnXh6sZvNgGZ6Eq5Pz4PUKfjg5P/OHJkrAMANm5i6G3yUz7ZuVoQ+Il/ZlX69lrTWbgKoDPDSYOngbsSp1PfySUCX6Un92LpkfWBVCkGIWF20ul5XnV6ujPQnyEuqeyOsCeAea0Hk5RvvS5ffGk6GUrDIv0k1l7iaYs/6XLqxbMLvMlpGQf5kqJWteqsKqdFivHJCFXrBQ6egVTNpKI3CFRn7Smfapoipbl2fbstJL2t7O1tZsX3j1k1XXCb4vQgE2HC4Ng3XLZXSwTC/7L7twdNQ="
 }
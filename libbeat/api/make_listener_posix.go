@@ -29,7 +29,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/api/npipe"
 )
 
-func makeListener(cfg Config) (net.Listener, error) {
+// MakeListener creates the listener for the host configured in cfg, using
+// the same rules as the HTTP endpoint of the API.
+func MakeListener(cfg Config) (net.Listener, error) {
 	if len(cfg.User) > 0 {
 		return nil, errors.New("specifying a user is not supported under this platform")
 	}
@@ -28,7 +28,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/api/npipe"
 )
 
-func makeListener(cfg Config) (net.Listener, error) {
+// MakeListener creates the listener for the host configured in cfg, using
+// the same rules as the HTTP endpoint of the API.
+func MakeListener(cfg Config) (net.Listener, error) {
 	if len(cfg.User) > 0 && len(cfg.SecurityDescriptor) > 0 {
 		return nil, errors.New("user and security_descriptor are mutually exclusive, define only one of them")
 	}
@@ -40,6 +40,15 @@ type Event struct {
 	TimeSeries bool        // true if the event contains timeseries data
 }
 
+// SourcePositioner can be implemented by the values stored in Event.Private to
+// report the position of the event within its source. Outputs use the
+// position to detect events that have already been published before a restart.
+type SourcePositioner interface {
+	// SourcePosition returns the unique ID of the source and the offset right
+	// after the event. Offsets must be increasing within a source.
+	SourcePosition() (source string, offset int64)
+}
+
 var (
 	errNoTimestamp = errors.New("value is no timestamp")
 	errNoMapStr    = errors.New("value is no map[string]interface{} type")
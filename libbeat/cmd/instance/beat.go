@@ -66,6 +66,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	svc "github.com/elastic/beats/v7/libbeat/service"
+	"github.com/elastic/beats/v7/libbeat/sharedmeta"
 	"github.com/elastic/beats/v7/libbeat/version"
 	sysinfo "github.com/elastic/go-sysinfo"
 	"github.com/elastic/go-sysinfo/types"
@@ -101,6 +102,7 @@ type beatConfig struct {
 	MetricLogging   *common.Config         `config:"logging.metrics"`
 	Keystore        *common.Config         `config:"keystore"`
 	Instrumentation instrumentation.Config `config:"instrumentation"`
+	SharedMetadata  *common.Config         `config:"shared_metadata"`
 
 	// output/publishing related configurations
 	Pipeline pipeline.Config `config:",inline"`
@@ -409,6 +411,16 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 		defer s.Stop()
 	}
 
+	// Same as for the API server, the shared metadata socket must be created
+	// before the Seccomp lock down.
+	if b.Config.SharedMetadata.Enabled() {
+		stop, err := sharedmeta.Serve()
+		if err != nil {
+			return errw.Wrap(err, "could not start the shared metadata server")
+		}
+		defer stop()
+	}
+
 	if err = seccomp.LoadFilter(b.Config.Seccomp); err != nil {
 		return err
 	}
@@ -663,6 +675,13 @@ func (b *Beat) configure(settings Settings) error {
 		return err
 	}
 
+	// Processors register or look up shared metadata when they are created.
+	if b.Config.SharedMetadata.Enabled() {
+		if err := sharedmeta.Configure(b.Config.SharedMetadata); err != nil {
+			return fmt.Errorf("error initializing shared metadata: %v", err)
+		}
+	}
+
 	processingFactory := settings.Processing
 	if processingFactory == nil {
 		processingFactory = processing.MakeDefaultBeatSupport(true)
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clockskew
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Source of the reference time used to detect clock skew.
+const (
+	SourceElasticsearch = "elasticsearch"
+	SourceNTP           = "ntp"
+)
+
+// Config configures clock skew detection and correction.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// Source selects the reference clock. With `elasticsearch` the `Date`
+	// header of responses is used, with `ntp` the configured NTP server is queried.
+	Source string `config:"source"`
+
+	NTP NTPConfig `config:"ntp"`
+
+	// Threshold is the minimum absolute skew before timestamps are adjusted.
+	Threshold time.Duration `config:"threshold" validate:"min=0"`
+
+	// AdjustTimestamp enables shifting @timestamp by the detected skew.
+	AdjustTimestamp bool `config:"adjust_timestamp"`
+}
+
+// NTPConfig configures the NTP server used if the source is `ntp`.
+type NTPConfig struct {
+	Host     string        `config:"host"`
+	Timeout  time.Duration `config:"timeout" validate:"min=0, nonzero"`
+	Interval time.Duration `config:"interval" validate:"min=0, nonzero"`
+}
+
+// DefaultConfig returns the default clock skew configuration. Detection is disabled by default.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:   false,
+		Source:    SourceElasticsearch,
+		Threshold: 5 * time.Second,
+		NTP: NTPConfig{
+			Timeout:  5 * time.Second,
+			Interval: 10 * time.Minute,
+		},
+	}
+}
+
+// Validate checks the configured source.
+func (c *Config) Validate() error {
+	switch c.Source {
+	case SourceElasticsearch:
+		// The Date header has a resolution of one second.
+		if c.Threshold < time.Second {
+			return fmt.Errorf("clock skew threshold must be at least 1s when using source '%v'", c.Source)
+		}
+	case SourceNTP:
+		if c.NTP.Host == "" {
+			return errors.New("clock skew source 'ntp' requires ntp.host to be set")
+		}
+	default:
+		return fmt.Errorf("unknown clock skew source '%v', expecting '%v' or '%v'",
+			c.Source, SourceElasticsearch, SourceNTP)
+	}
+	return nil
+}
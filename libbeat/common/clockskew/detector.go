@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package clockskew detects the offset between the local clock and a
+// reference clock and uses it to correct event timestamps.
+package clockskew
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// dateResolution is the resolution of the HTTP Date header. The header is
+// truncated to full seconds, so on average the server time is half a second
+// ahead of the reported value.
+const dateResolution = time.Second
+
+// Detector keeps track of the clock skew between the host and a reference
+// clock. A nil Detector is valid and never reports or corrects any skew.
+type Detector struct {
+	config Config
+	log    *logp.Logger
+
+	mu        sync.Mutex
+	offset    time.Duration
+	known     bool
+	warned    bool
+	lastCheck time.Time
+	querying  bool
+
+	queryNTP func(host string, timeout time.Duration) (time.Duration, error)
+}
+
+// NewDetector creates a new Detector. It returns nil if clock skew detection
+// is disabled.
+func NewDetector(config Config) *Detector {
+	if !config.Enabled {
+		return nil
+	}
+	return &Detector{
+		config:   config,
+		log:      logp.NewLogger("clockskew"),
+		queryNTP: QueryNTP,
+	}
+}
+
+// ObserveResponse updates the detected offset from the Date header of an HTTP
+// response. sent and received are the local times the request was sent and
+// the response was received. Responses are ignored if the source is not
+// `elasticsearch`.
+func (d *Detector) ObserveResponse(sent, received time.Time, resp *http.Response) {
+	if d == nil || d.config.Source != SourceElasticsearch || resp == nil {
+		return
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	// Compare the server time to the midpoint of the round trip, as the
+	// server generated the header at some point during the request.
+	local := sent.Add(received.Sub(sent) / 2)
+	d.update(date.Add(dateResolution / 2).Sub(local))
+}
+
+// Offset returns the last detected offset of the reference clock relative to
+// the local clock. A positive offset means the local clock is behind. The
+// second return value is false if no offset has been detected yet.
+func (d *Detector) Offset() (time.Duration, bool) {
+	if d == nil {
+		return 0, false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.offset, d.known
+}
+
+// Correct returns a copy of event with `event.ingested_delay` set to the
+// delay in nanoseconds between the (corrected) event timestamp and the
+// current reference time. If adjust_timestamp is enabled and the offset
+// exceeds the configured threshold, @timestamp is shifted by the offset.
+// The original event is not modified, such that it can safely be retried.
+func (d *Detector) Correct(event *beat.Event, now time.Time) *beat.Event {
+	if d == nil || event == nil {
+		return event
+	}
+
+	d.refreshNTP(now)
+
+	offset, known := d.Offset()
+	if !known {
+		return event
+	}
+
+	corrected := *event
+	if d.config.AdjustTimestamp && abs(offset) >= d.config.Threshold {
+		corrected.Timestamp = event.Timestamp.Add(offset)
+	}
+
+	corrected.Fields = make(common.MapStr, len(event.Fields)+1)
+	for k, v := range event.Fields {
+		corrected.Fields[k] = v
+	}
+
+	eventFields := common.MapStr{}
+	if m, ok := tryToMapStr(event.Fields["event"]); ok {
+		for k, v := range m {
+			eventFields[k] = v
+		}
+	}
+	eventFields["ingested_delay"] = now.Add(offset).Sub(corrected.Timestamp).Nanoseconds()
+	corrected.Fields["event"] = eventFields
+
+	return &corrected
+}
+
+func (d *Detector) update(offset time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.offset = offset
+	d.known = true
+
+	exceeded := abs(offset) >= d.config.Threshold
+	if exceeded && !d.warned {
+		d.log.Warnf("Detected clock skew of %v relative to %v (threshold: %v)",
+			offset, d.config.Source, d.config.Threshold)
+	} else if !exceeded && d.warned {
+		d.log.Infof("Clock skew relative to %v is back within threshold: %v", d.config.Source, offset)
+	}
+	d.warned = exceeded
+}
+
+// refreshNTP queries the NTP server in the background if the source is
+// `ntp` and the last check is older than the configured interval.
+func (d *Detector) refreshNTP(now time.Time) {
+	if d.config.Source != SourceNTP {
+		return
+	}
+
+	d.mu.Lock()
+	if d.querying || (!d.lastCheck.IsZero() && now.Sub(d.lastCheck) < d.config.NTP.Interval) {
+		d.mu.Unlock()
+		return
+	}
+	d.querying = true
+	d.lastCheck = now
+	d.mu.Unlock()
+
+	go func() {
+		offset, err := d.queryNTP(d.config.NTP.Host, d.config.NTP.Timeout)
+
+		d.mu.Lock()
+		d.querying = false
+		d.mu.Unlock()
+
+		if err != nil {
+			d.log.Warnf("Failed to query NTP server %v: %v", d.config.NTP.Host, err)
+			return
+		}
+		d.update(offset)
+	}()
+}
+
+func tryToMapStr(v interface{}) (common.MapStr, bool) {
+	switch m := v.(type) {
+	case common.MapStr:
+		return m, true
+	case map[string]interface{}:
+		return common.MapStr(m), true
+	default:
+		return nil, false
+	}
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
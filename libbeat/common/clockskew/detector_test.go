@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clockskew
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, config.Validate())
+
+	config.Source = SourceNTP
+	assert.Error(t, config.Validate())
+
+	config.NTP.Host = "pool.ntp.org"
+	assert.NoError(t, config.Validate())
+
+	config.Source = "gps"
+	assert.Error(t, config.Validate())
+}
+
+func TestNilDetector(t *testing.T) {
+	d := NewDetector(DefaultConfig())
+	require.Nil(t, d)
+
+	event := &beat.Event{Timestamp: time.Now()}
+	d.ObserveResponse(time.Now(), time.Now(), &http.Response{})
+	assert.Same(t, event, d.Correct(event, time.Now()))
+}
+
+func TestObserveResponse(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	d := NewDetector(config)
+
+	_, known := d.Offset()
+	assert.False(t, known)
+
+	sent := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Date", sent.Add(time.Hour).Format(http.TimeFormat))
+	d.ObserveResponse(sent, sent.Add(time.Second), resp)
+
+	offset, known := d.Offset()
+	require.True(t, known)
+	assert.Equal(t, time.Hour, offset)
+}
+
+func TestCorrect(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := now.Add(-time.Minute)
+
+	cases := map[string]struct {
+		adjust    bool
+		offset    time.Duration
+		timestamp time.Time
+		delay     time.Duration
+	}{
+		"no adjustment": {
+			offset:    time.Hour,
+			timestamp: ts,
+			delay:     time.Hour + time.Minute,
+		},
+		"adjust timestamp": {
+			adjust:    true,
+			offset:    time.Hour,
+			timestamp: ts.Add(time.Hour),
+			delay:     time.Minute,
+		},
+		"offset below threshold": {
+			adjust:    true,
+			offset:    time.Second,
+			timestamp: ts,
+			delay:     time.Minute + time.Second,
+		},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.Enabled = true
+			config.AdjustTimestamp = test.adjust
+			d := NewDetector(config)
+			d.update(test.offset)
+
+			event := &beat.Event{
+				Timestamp: ts,
+				Fields: common.MapStr{
+					"message": "hello",
+					"event":   common.MapStr{"kind": "event"},
+				},
+			}
+
+			corrected := d.Correct(event, now)
+			assert.Equal(t, test.timestamp, corrected.Timestamp)
+			assert.Equal(t, common.MapStr{
+				"message": "hello",
+				"event": common.MapStr{
+					"kind":           "event",
+					"ingested_delay": test.delay.Nanoseconds(),
+				},
+			}, corrected.Fields)
+
+			// the original event must not be modified
+			assert.Equal(t, ts, event.Timestamp)
+			assert.Equal(t, common.MapStr{"kind": "event"}, event.Fields["event"])
+		})
+	}
+}
+
+func TestNTPRefresh(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Source = SourceNTP
+	config.NTP.Host = "localhost"
+
+	queried := make(chan struct{}, 2)
+	d := NewDetector(config)
+	d.queryNTP = func(host string, timeout time.Duration) (time.Duration, error) {
+		queried <- struct{}{}
+		return -time.Minute, nil
+	}
+
+	now := time.Now()
+	event := &beat.Event{Timestamp: now}
+	d.Correct(event, now)
+	<-queried
+
+	assert.Eventually(t, func() bool {
+		_, known := d.Offset()
+		return known
+	}, time.Second, 10*time.Millisecond)
+
+	// within the interval, no new query is started
+	d.Correct(event, now.Add(time.Minute))
+	assert.Len(t, queried, 0)
+
+	offset, _ := d.Offset()
+	assert.Equal(t, -time.Minute, offset)
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package clockskew
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+)
+
+const (
+	ntpPacketSize  = 48
+	ntpDefaultPort = "123"
+
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+	// the unix epoch (1970).
+	ntpEpochOffset = 2208988800
+)
+
+// QueryNTP queries the NTP server at host using SNTP (RFC 4330) and returns
+// the offset of the server clock relative to the local clock. A positive offset
+// means the local clock is behind.
+func QueryNTP(host string, timeout time.Duration) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, ntpDefaultPort)
+	}
+
+	conn, err := net.DialTimeout("udp", host, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = 0x23 // LI = 0, VN = 4, Mode = 3 (client)
+
+	sent := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	received := time.Now()
+	if err != nil {
+		return 0, err
+	}
+	if n < ntpPacketSize {
+		return 0, errors.New("short NTP response")
+	}
+	if mode := resp[0] & 0x7; mode != 4 {
+		return 0, errors.New("invalid NTP response mode")
+	}
+	if stratum := resp[1]; stratum == 0 {
+		return 0, errors.New("NTP server sent kiss-of-death response")
+	}
+
+	serverReceived := ntpTime(resp[32:40])
+	serverSent := ntpTime(resp[40:48])
+
+	// offset = ((T2 - T1) + (T3 - T4)) / 2
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	return offset, nil
+}
+
+func ntpTime(b []byte) time.Time {
+	secs := int64(binary.BigEndian.Uint32(b[0:4])) - ntpEpochOffset
+	frac := int64(binary.BigEndian.Uint32(b[4:8]))
+	nanos := (frac * int64(time.Second)) >> 32
+	return time.Unix(secs, nanos)
+}
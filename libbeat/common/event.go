@@ -35,6 +35,13 @@ var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 
 type Float float64
 
+// Histogram is implemented by metrics published as Elasticsearch histogram
+// fields. HistogramValues returns the representative values in increasing
+// order, and the number of samples of each.
+type Histogram interface {
+	HistogramValues() (values []float64, counts []uint64)
+}
+
 // EventConverter is used to convert MapStr objects for publishing
 type EventConverter interface {
 	Convert(m MapStr) MapStr
@@ -172,6 +179,15 @@ func (e *GenericEventConverter) normalizeValue(value interface{}, keys ...string
 	}
 
 	switch value.(type) {
+	case Histogram:
+		if reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+			return nil, nil
+		}
+		values, counts := value.(Histogram).HistogramValues()
+		if values == nil {
+			values, counts = []float64{}, []uint64{}
+		}
+		return MapStr{"values": values, "counts": counts}, nil
 	case encoding.TextMarshaler:
 		if reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
 			return nil, nil
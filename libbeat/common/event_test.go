@@ -242,6 +242,13 @@ func TestConvertWithNullEmission(t *testing.T) {
 	}
 }
 
+type testHistogram struct {
+	values []float64
+	counts []uint64
+}
+
+func (h *testHistogram) HistogramValues() ([]float64, []uint64) { return h.values, h.counts }
+
 func TestNormalizeValue(t *testing.T) {
 	logp.TestingSetup()
 
@@ -272,6 +279,7 @@ func TestNormalizeValue(t *testing.T) {
 
 	var nilStringPtr *string
 	var nilTimePtr *time.Time
+	var nilHistogramPtr *testHistogram
 	someString := "foo"
 	uuidValue, err := uuid.NewV1()
 	if err != nil {
@@ -297,6 +305,14 @@ func TestNormalizeValue(t *testing.T) {
 		"string value":                      {"hello", "hello"},
 		"map to MapStr":                     {map[string]interface{}{"foo": "bar"}, MapStr{"foo": "bar"}},
 
+		// Histograms are converted to the Elasticsearch histogram format.
+		"histogram": {
+			&testHistogram{[]float64{0.5, 1.5}, []uint64{3, 7}},
+			MapStr{"values": []float64{0.5, 1.5}, "counts": []uint64{3, 7}},
+		},
+		"empty histogram":            {&testHistogram{}, MapStr{"values": []float64{}, "counts": []uint64{}}},
+		"drop nil histogram pointer": {nilHistogramPtr, nil},
+
 		// Other map types are converted using marshalUnmarshal which will lose
 		// type information for arrays which become []interface{} and numbers
 		// which all become float64.
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package failpolicy defines how the stages of the publishing pipeline, such
+// as processors and output codecs, handle events they fail to process.
+package failpolicy
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Policy selects how a failed event is handled.
+type Policy uint8
+
+const (
+	// DropEvent drops the event.
+	DropEvent Policy = iota
+	// Ignore continues processing the event as if the stage succeeded.
+	Ignore
+	// DeadLetter annotates the event with the error and publishes it without
+	// processing it any further.
+	DeadLetter
+	// FailPipeline stops processing the event and reports it as failed.
+	FailPipeline
+)
+
+var policyNames = map[Policy]string{
+	Ignore:       "ignore",
+	DropEvent:    "drop_event",
+	DeadLetter:   "dead_letter",
+	FailPipeline: "fail_pipeline",
+}
+
+func (p Policy) String() string {
+	if name, ok := policyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Policy(%d)", uint8(p))
+}
+
+// Unpack parses the policy name.
+func (p *Policy) Unpack(s string) error {
+	for policy, name := range policyNames {
+		if name == s {
+			*p = policy
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown failure policy '%v', use one of ignore, drop_event, dead_letter or fail_pipeline", s)
+}
+
+// MarkDeadLetter annotates the event with the error of the stage it failed in.
+// The error is recorded in `error.message`, and the stage and error in
+// `@metadata.dead_letter`, so that dead letter events can be routed, for
+// example to a dedicated index, with conditions on `@metadata.dead_letter`.
+func MarkDeadLetter(event *beat.Event, stage string, err error) {
+	if event.Fields == nil {
+		event.Fields = common.MapStr{}
+	}
+	event.Fields.Put("error.message", err.Error())
+
+	if event.Meta == nil {
+		event.Meta = common.MapStr{}
+	}
+	event.Meta["dead_letter"] = common.MapStr{
+		"stage": stage,
+		"error": err.Error(),
+	}
+}
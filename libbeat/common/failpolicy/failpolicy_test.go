@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package failpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestPolicyUnpack(t *testing.T) {
+	for _, policy := range []Policy{Ignore, DropEvent, DeadLetter, FailPipeline} {
+		config := common.MustNewConfigFrom(map[string]interface{}{"on_error": policy.String()})
+
+		var settings struct {
+			OnError Policy `config:"on_error"`
+		}
+		require.NoError(t, config.Unpack(&settings))
+		assert.Equal(t, policy, settings.OnError)
+	}
+
+	var p Policy
+	assert.Error(t, p.Unpack("retry"))
+}
+
+func TestMarkDeadLetter(t *testing.T) {
+	event := &beat.Event{Fields: common.MapStr{"message": "test"}}
+	MarkDeadLetter(event, "processor dissect", errors.New("parse failed"))
+
+	assert.Equal(t, common.MapStr{
+		"message": "test",
+		"error":   common.MapStr{"message": "parse failed"},
+	}, event.Fields)
+	assert.Equal(t, common.MapStr{
+		"dead_letter": common.MapStr{"stage": "processor dissect", "error": "parse failed"},
+	}, event.Meta)
+}
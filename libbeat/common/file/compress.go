@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package file
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// compressionExtensions maps the supported compressions of rotated files to
+// the extension added to the compressed files.
+var compressionExtensions = map[string]string{
+	"gzip": ".gz",
+	"zstd": ".zst",
+}
+
+// errCompressionStopped is returned when the compression of a file is stopped
+// before it completes.
+var errCompressionStopped = errors.New("compression stopped")
+
+// hasCompressionExtension returns true if the file has the extension of one of
+// the supported compressions.
+func hasCompressionExtension(name string) bool {
+	for _, ext := range compressionExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// stoppableReader is an io.Reader that fails with errCompressionStopped once
+// the stop channel is closed.
+type stoppableReader struct {
+	r    io.Reader
+	stop <-chan struct{}
+}
+
+func (s stoppableReader) Read(p []byte) (int, error) {
+	select {
+	case <-s.stop:
+		return 0, errCompressionStopped
+	default:
+		return s.r.Read(p)
+	}
+}
+
+// compressFile compresses the file to the same name with the extension of the
+// compression added, and removes the uncompressed file. The modification time
+// of the file is kept, so the age of compressed files is the age of the data.
+// When stop is closed the compression is aborted, keeping the uncompressed
+// file and removing the partially compressed one.
+func compressFile(name, compression string, perm os.FileMode, stop <-chan struct{}) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to open file to compress")
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to stat file to compress")
+	}
+
+	target := name + compressionExtensions[compression]
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create compressed file '%s'", target)
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(target)
+		}
+	}()
+
+	var w io.WriteCloser
+	switch compression {
+	case "gzip":
+		w = gzip.NewWriter(dst)
+	case "zstd":
+		if w, err = zstd.NewWriter(dst); err != nil {
+			return errors.Wrap(err, "failed to create zstd encoder")
+		}
+	default:
+		return errors.Errorf("unsupported compression '%s'", compression)
+	}
+
+	if _, err = io.Copy(w, stoppableReader{r: src, stop: stop}); err != nil {
+		w.Close()
+		return errors.Wrapf(err, "failed to compress '%s'", name)
+	}
+	if err = w.Close(); err != nil {
+		return errors.Wrapf(err, "failed to compress '%s'", name)
+	}
+	if err = dst.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close compressed file '%s'", target)
+	}
+	if err = os.Chtimes(target, info.ModTime(), info.ModTime()); err != nil {
+		return errors.Wrapf(err, "failed to set modification time of '%s'", target)
+	}
+
+	src.Close()
+	if err = os.Remove(name); err != nil {
+		return errors.Wrapf(err, "failed to remove uncompressed file '%s'", name)
+	}
+	return nil
+}
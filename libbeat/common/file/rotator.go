@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -60,21 +61,32 @@ func (rr rotateReason) String() string {
 // Rotator is a io.WriteCloser that automatically rotates the file it is
 // writing to when it reaches a maximum size and optionally on a time interval
 // basis. It also purges the oldest rotated files when the maximum number of
-// backups is reached.
+// backups is reached, and optionally when they reach a maximum age or total
+// size. Rotated files can be compressed, which is done in the background so
+// writes are not blocked by the compression.
 type Rotator struct {
-	filename        string
-	maxSizeBytes    uint
-	maxBackups      uint
-	permissions     os.FileMode
-	log             Logger // Optional Logger (may be nil).
-	interval        time.Duration
-	rotateOnStartup bool
-	intervalRotator *intervalRotator // Optional, may be nil
-	redirectStderr  bool
+	filename          string
+	maxSizeBytes      uint
+	maxBackups        uint
+	permissions       os.FileMode
+	log               Logger // Optional Logger (may be nil).
+	interval          time.Duration
+	rotateOnStartup   bool
+	intervalRotator   *intervalRotator // Optional, may be nil
+	redirectStderr    bool
+	compression       string
+	maxAge            time.Duration
+	maxTotalSizeBytes uint64
 
 	file  *os.File
 	size  uint
 	mutex sync.Mutex
+
+	// compressing is closed when the background compression of rotated files
+	// completes, it is nil when no compression is running. Closing stop aborts
+	// the compression, the aborted files are compressed on the next startup.
+	compressing chan struct{}
+	stop        chan struct{}
 }
 
 // Logger allows the rotator to write debug information.
@@ -142,6 +154,33 @@ func RedirectStderr(redirect bool) RotatorOption {
 	}
 }
 
+// Compression configures the compression of rotated files, "gzip" or "zstd".
+// Compressed files get the ".gz" or ".zst" extension. Rotated files left
+// uncompressed by a previous run are compressed on startup. The default is ""
+// for no compression.
+func Compression(c string) RotatorOption {
+	return func(r *Rotator) {
+		r.compression = c
+	}
+}
+
+// MaxAge configures the age after which rotated files are deleted, based on
+// their modification time. The default is 0 for no limit.
+func MaxAge(d time.Duration) RotatorOption {
+	return func(r *Rotator) {
+		r.maxAge = d
+	}
+}
+
+// MaxTotalSizeBytes configures the maximum total size of the rotated files,
+// not counting the active file. The oldest rotated files are deleted when the
+// limit is exceeded. The default is 0 for no limit.
+func MaxTotalSizeBytes(n uint64) RotatorOption {
+	return func(r *Rotator) {
+		r.maxTotalSizeBytes = n
+	}
+}
+
 // NewFileRotator returns a new Rotator.
 func NewFileRotator(filename string, options ...RotatorOption) (*Rotator, error) {
 	r := &Rotator{
@@ -166,12 +205,22 @@ func NewFileRotator(filename string, options ...RotatorOption) (*Rotator, error)
 	if r.permissions > os.ModePerm {
 		return nil, errors.Errorf("file rotator permissions mask of %o is invalid", r.permissions)
 	}
+	if _, found := compressionExtensions[r.compression]; r.compression != "" && !found {
+		return nil, errors.Errorf("file rotator compression '%s' is invalid, use gzip or zstd", r.compression)
+	}
+	if r.maxAge < 0 {
+		return nil, errors.New("file rotator max age must not be negative")
+	}
 	var err error
 	r.intervalRotator, err = newIntervalRotator(r.log, r.interval, r.rotateOnStartup, r.filename)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := r.compressLeftovers(); err != nil {
+		return nil, err
+	}
+
 	if r.log != nil {
 		r.log.Debugw("Initialized file rotator",
 			"filename", r.filename,
@@ -179,6 +228,9 @@ func NewFileRotator(filename string, options ...RotatorOption) (*Rotator, error)
 			"max_backups", r.maxBackups,
 			"permissions", r.permissions,
 			"interval", r.interval,
+			"compression", r.compression,
+			"max_age", r.maxAge,
+			"max_total_size_bytes", r.maxTotalSizeBytes,
 		)
 	}
 
@@ -242,10 +294,17 @@ func (r *Rotator) Rotate() error {
 	return r.rotate(rotateReasonManualTrigger)
 }
 
-// Close closes the currently open file.
+// Close closes the currently open file. A running compression of rotated
+// files is stopped, the files it didn't compress are compressed on the next
+// startup.
 func (r *Rotator) Close() error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.waitCompression()
 	return r.closeFile()
 }
 
@@ -253,7 +312,74 @@ func (r *Rotator) backupName(n uint) string {
 	if n == 0 {
 		return r.filename
 	}
-	return r.filename + "." + strconv.Itoa(int(n))
+	return r.filename + "." + strconv.Itoa(int(n)) + compressionExtensions[r.compression]
+}
+
+// compress starts the background compression of the rotated files, if
+// compression is enabled. Only one compression runs at a time, rotations wait
+// for the previous one to complete so it doesn't race with renaming and
+// purging the rotated files.
+func (r *Rotator) compress(names ...string) {
+	if r.compression == "" || len(names) == 0 {
+		return
+	}
+
+	r.waitCompression()
+	if r.stop == nil {
+		r.stop = make(chan struct{})
+	}
+	done, stop := make(chan struct{}), r.stop
+	r.compressing = done
+
+	go func() {
+		defer close(done)
+		for _, name := range names {
+			if err := compressFile(name, r.compression, r.permissions, stop); err != nil {
+				if r.log != nil {
+					r.log.Debugw("Failed to compress rotated file", "filename", name, "error", err)
+				}
+				return
+			}
+		}
+	}()
+}
+
+// waitCompression waits for the running compression, if any, to complete.
+func (r *Rotator) waitCompression() {
+	if r.compressing != nil {
+		<-r.compressing
+		r.compressing = nil
+	}
+}
+
+// compressLeftovers compresses the rotated files that a previous run didn't
+// compress, because it was stopped or crashed during the compression.
+func (r *Rotator) compressLeftovers() error {
+	if r.compression == "" {
+		return nil
+	}
+
+	var names []string
+	if r.intervalRotator != nil {
+		files, err := filepath.Glob(r.filename + "-*")
+		if err != nil {
+			return errors.Wrap(err, "failed to list uncompressed rotated files")
+		}
+		for _, f := range files {
+			if _, _, err := IntervalLogIndex(f); err == nil && !hasCompressionExtension(f) {
+				names = append(names, f)
+			}
+		}
+	} else {
+		for i := uint(1); i <= r.maxBackups; i++ {
+			name := strings.TrimSuffix(r.backupName(i), compressionExtensions[r.compression])
+			if _, err := os.Stat(name); err == nil {
+				names = append(names, name)
+			}
+		}
+	}
+	r.compress(names...)
+	return nil
 }
 
 func (r *Rotator) dir() string {
@@ -336,10 +462,91 @@ func (r *Rotator) closeFile() error {
 }
 
 func (r *Rotator) purgeOldBackups() error {
+	var err error
 	if r.intervalRotator != nil {
-		return r.purgeOldIntervalBackups()
+		err = r.purgeOldIntervalBackups()
+	} else {
+		err = r.purgeOldSizedBackups()
+	}
+	if err != nil {
+		return err
+	}
+	return r.purgeExpiredBackups()
+}
+
+// purgeExpiredBackups deletes the rotated files older than maxAge, and the
+// oldest rotated files while their total size exceeds maxTotalSizeBytes.
+func (r *Rotator) purgeExpiredBackups() error {
+	if r.maxAge == 0 && r.maxTotalSizeBytes == 0 {
+		return nil
+	}
+
+	backups, err := r.backups()
+	if err != nil {
+		return err
+	}
+
+	infos := make([]os.FileInfo, 0, len(backups))
+	var totalSize uint64
+	for _, name := range backups {
+		fi, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "failed on %v during rotation", name)
+		}
+		infos = append(infos, fi)
+		totalSize += uint64(fi.Size())
+	}
+
+	cutoff := time.Now().Add(-r.maxAge)
+	for _, fi := range infos {
+		expired := r.maxAge > 0 && fi.ModTime().Before(cutoff)
+		tooLarge := r.maxTotalSizeBytes > 0 && totalSize > r.maxTotalSizeBytes
+		if !expired && !tooLarge {
+			continue
+		}
+
+		name := filepath.Join(r.dir(), fi.Name())
+		if err := os.Remove(name); err != nil {
+			return errors.Wrapf(err, "failed to delete %v during rotation", name)
+		}
+		totalSize -= uint64(fi.Size())
+		if r.log != nil {
+			r.log.Debugw("Deleted rotated file", "filename", name, "expired", expired, "exceeds_total_size", tooLarge)
+		}
 	}
-	return r.purgeOldSizedBackups()
+	return nil
+}
+
+// backups returns the rotated files, ordered from oldest to newest.
+func (r *Rotator) backups() ([]string, error) {
+	if r.intervalRotator != nil {
+		files, err := filepath.Glob(r.filename + "-*")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list existing logs during rotation")
+		}
+		backups := files[:0]
+		for _, f := range files {
+			if _, _, err := IntervalLogIndex(f); err == nil {
+				backups = append(backups, f)
+			}
+		}
+		r.intervalRotator.SortIntervalLogs(backups)
+		return backups, nil
+	}
+
+	var backups []string
+	for i := uint(1); i <= MaxBackupsLimit; i++ {
+		name := r.backupName(i)
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed on %v during rotation", name)
+		}
+		backups = append([]string{name}, backups...)
+	}
+	return backups, nil
 }
 
 func (r *Rotator) purgeOldIntervalBackups() error {
@@ -397,34 +604,48 @@ func (r *Rotator) rotate(reason rotateReason) error {
 		return errors.Wrap(err, "error file closing current file")
 	}
 
+	r.waitCompression()
+
+	var rotated string
 	var err error
 	if r.intervalRotator != nil {
 		// Interval and size rotation use different filename patterns, so we use
 		// rotateByInterval if interval rotation is enabled, even if this specific
 		// rotation is triggered by size.
-		err = r.rotateByInterval(reason)
+		rotated, err = r.rotateByInterval(reason)
 	} else {
-		err = r.rotateBySize(reason)
+		rotated, err = r.rotateBySize(reason)
 	}
 	if err != nil {
 		return errors.Wrap(err, "failed to rotate backups")
 	}
 
-	return r.purgeOldBackups()
+	if err := r.purgeOldBackups(); err != nil {
+		return err
+	}
+	if rotated != "" {
+		// The rotated file may have been purged already.
+		if _, err := os.Stat(rotated); err == nil {
+			r.compress(rotated)
+		}
+	}
+	return nil
 }
 
-func (r *Rotator) rotateByInterval(reason rotateReason) error {
+// rotateByInterval renames the active file to the next name of its interval,
+// and returns the new name of the file, or "" if there is no file to rotate.
+func (r *Rotator) rotateByInterval(reason rotateReason) (string, error) {
 	fi, err := os.Stat(r.filename)
 	if os.IsNotExist(err) {
-		return nil
+		return "", nil
 	} else if err != nil {
-		return errors.Wrap(err, "failed to rotate backups")
+		return "", errors.Wrap(err, "failed to rotate backups")
 	}
 
 	logPrefix := r.intervalRotator.LogPrefix(r.filename, fi.ModTime())
 	files, err := filepath.Glob(logPrefix + "*")
 	if err != nil {
-		return errors.Wrap(err, "failed to list logs during rotation")
+		return "", errors.Wrap(err, "failed to list logs during rotation")
 	}
 
 	var targetFilename string
@@ -434,13 +655,13 @@ func (r *Rotator) rotateByInterval(reason rotateReason) error {
 		r.intervalRotator.SortIntervalLogs(files)
 		lastLogIndex, _, err := IntervalLogIndex(files[len(files)-1])
 		if err != nil {
-			return errors.Wrap(err, "failed to locate last log index during rotation")
+			return "", errors.Wrap(err, "failed to locate last log index during rotation")
 		}
 		targetFilename = logPrefix + strconv.Itoa(int(lastLogIndex)+1)
 	}
 
 	if err := os.Rename(r.filename, targetFilename); err != nil {
-		return errors.Wrap(err, "failed to rotate backups")
+		return "", errors.Wrap(err, "failed to rotate backups")
 	}
 
 	if r.log != nil {
@@ -449,31 +670,40 @@ func (r *Rotator) rotateByInterval(reason rotateReason) error {
 
 	r.intervalRotator.Rotate()
 
-	return nil
+	return targetFilename, nil
 }
 
-func (r *Rotator) rotateBySize(reason rotateReason) error {
+// rotateBySize shifts the backups and renames the active file to the first
+// backup, and returns the new name of the file, or "" if there is no file to
+// rotate.
+func (r *Rotator) rotateBySize(reason rotateReason) (string, error) {
+	var rotated string
 	for i := r.maxBackups + 1; i > 0; i-- {
 		old := r.backupName(i - 1)
 		older := r.backupName(i)
+		if i == 1 {
+			// The active file is compressed after being moved to its backup name
+			older = strings.TrimSuffix(older, compressionExtensions[r.compression])
+		}
 
 		if _, err := os.Stat(old); os.IsNotExist(err) {
 			continue
 		} else if err != nil {
-			return errors.Wrap(err, "failed to rotate backups")
+			return "", errors.Wrap(err, "failed to rotate backups")
 		}
 
 		if err := os.Remove(older); err != nil && !os.IsNotExist(err) {
-			return errors.Wrap(err, "failed to rotate backups")
+			return "", errors.Wrap(err, "failed to rotate backups")
 		}
 		if err := os.Rename(old, older); err != nil {
-			return errors.Wrap(err, "failed to rotate backups")
+			return "", errors.Wrap(err, "failed to rotate backups")
 		} else if i == 1 {
 			// Log when rotation of the main file occurs.
 			if r.log != nil {
 				r.log.Debugw("Rotating file", "filename", old, "reason", reason)
 			}
+			rotated = older
 		}
 	}
-	return nil
+	return rotated, nil
 }
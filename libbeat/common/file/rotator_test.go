@@ -18,6 +18,7 @@
 package file_test
 
 import (
+	"compress/gzip"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -214,6 +215,152 @@ func TestRotateOnStartup(t *testing.T) {
 	AssertDirContents(t, dir, logname, logname+".1")
 }
 
+func TestFileRotatorCompression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename, file.MaxBackups(2), file.Compression("gzip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContentsEventually(t, dir, "sample.log.1.gz")
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContentsEventually(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContentsEventually(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+
+	f, err := os.Open(filepath.Join(dir, "sample.log.1.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, logMessage, string(content))
+}
+
+func TestFileRotatorCompressLeftovers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	CreateFile(t, filename+".1")
+	CreateFile(t, filename+".2.gz")
+
+	r, err := file.NewFileRotator(filename, file.MaxBackups(2), file.Compression("gzip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	AssertDirContentsEventually(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+}
+
+func TestFileRotatorInvalidCompression(t *testing.T) {
+	_, err := file.NewFileRotator("sample.log", file.Compression("lz4"))
+	assert.Error(t, err)
+}
+
+func TestFileRotatorMaxAge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename, file.MaxBackups(5), file.MaxAge(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContents(t, dir, "sample.log.1", "sample.log.2")
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "sample.log.2"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContents(t, dir, "sample.log.1", "sample.log.2")
+}
+
+func TestFileRotatorMaxTotalSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename,
+		file.MaxBackups(5),
+		file.MaxTotalSizeBytes(uint64(2*len(logMessage))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := 0; i < 4; i++ {
+		WriteMsg(t, r)
+		Rotate(t, r)
+	}
+	AssertDirContents(t, dir, "sample.log.1", "sample.log.2")
+}
+
+func TestDailyRotationCompression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "daily_file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	today := time.Now().Format("2006-01-02")
+	CreateFile(t, filepath.Join(dir, "daily-"+today+"-1.zst"))
+
+	r, err := file.NewFileRotator(filepath.Join(dir, "daily"),
+		file.MaxBackups(2),
+		file.Interval(24*time.Hour),
+		file.Compression("zstd"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	AssertDirContentsEventually(t, dir, "daily-"+today+"-1.zst", "daily-"+today+"-2.zst")
+}
+
 func CreateFile(t *testing.T, filename string) {
 	t.Helper()
 	f, err := os.Create(filename)
@@ -244,6 +391,30 @@ func AssertDirContents(t *testing.T, dir string, files ...string) {
 	assert.EqualValues(t, files, names)
 }
 
+// AssertDirContentsEventually waits for the directory to contain the files,
+// for the rotated files compressed in the background.
+func AssertDirContentsEventually(t *testing.T, dir string, files ...string) {
+	t.Helper()
+
+	sort.Strings(files)
+	found := func() bool {
+		f, err := os.Open(dir)
+		if err != nil {
+			return false
+		}
+		defer f.Close()
+		names, err := f.Readdirnames(-1)
+		if err != nil {
+			return false
+		}
+		sort.Strings(names)
+		return assert.ObjectsAreEqualValues(files, names)
+	}
+	if !assert.Eventually(t, found, 5*time.Second, 10*time.Millisecond) {
+		AssertDirContents(t, dir, files...)
+	}
+}
+
 func WriteMsg(t *testing.T, r *file.Rotator) {
 	t.Helper()
 
@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package histogram provides histograms accumulating samples in events, to be
+// published as Elasticsearch histogram fields.
+package histogram
+
+import (
+	"math"
+)
+
+const (
+	// DefaultMaxSize is the default number of buckets of the positive and of
+	// the negative samples.
+	DefaultMaxSize = 160
+
+	// MaxScale is the scale histograms start at, the finest resolution.
+	MaxScale = 20
+)
+
+// Exponential is a base-2 exponential histogram, as defined by OpenTelemetry.
+// At scale s, bucket i holds the samples in (base^i, base^(i+1)], with
+// base = 2^(2^-s). The scale is lowered, merging adjacent buckets, whenever
+// the samples do not fit in the maximum number of buckets. Samples of zero
+// are counted separately, NaN and infinite samples are ignored.
+//
+// Exponential implements common.Histogram. It is not safe for concurrent use.
+type Exponential struct {
+	maxSize   int
+	scale     int
+	zeroCount uint64
+	positive  buckets
+	negative  buckets
+	count     uint64
+	sum       float64
+}
+
+// buckets are the counts of consecutive bucket indexes from offset.
+type buckets struct {
+	offset int
+	counts []uint64
+}
+
+// NewExponential returns an empty histogram with at most maxSize buckets for
+// the positive and for the negative samples. DefaultMaxSize is used if maxSize
+// is not positive.
+func NewExponential(maxSize int) *Exponential {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	return &Exponential{maxSize: maxSize, scale: MaxScale}
+}
+
+// Record adds a sample to the histogram.
+func (h *Exponential) Record(v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return
+	}
+	h.count++
+	h.sum += v
+
+	b := &h.positive
+	switch {
+	case v == 0:
+		h.zeroCount++
+		return
+	case v < 0:
+		b = &h.negative
+		v = -v
+	}
+
+	index := h.index(v)
+	if change := b.scaleChange(index, h.maxSize); change > 0 {
+		h.downscale(change)
+		index >>= change
+	}
+	b.increment(index)
+}
+
+// Count returns the number of samples recorded.
+func (h *Exponential) Count() uint64 { return h.count }
+
+// Sum returns the sum of the samples recorded.
+func (h *Exponential) Sum() float64 { return h.sum }
+
+// Scale returns the current scale of the histogram.
+func (h *Exponential) Scale() int { return h.scale }
+
+// HistogramValues returns the midpoint of each bucket with samples, in
+// increasing order, and the number of samples of each.
+func (h *Exponential) HistogramValues() (values []float64, counts []uint64) {
+	for i := len(h.negative.counts) - 1; i >= 0; i-- {
+		if c := h.negative.counts[i]; c > 0 {
+			values = append(values, -h.midpoint(h.negative.offset+i))
+			counts = append(counts, c)
+		}
+	}
+	if h.zeroCount > 0 {
+		values = append(values, 0)
+		counts = append(counts, h.zeroCount)
+	}
+	for i, c := range h.positive.counts {
+		if c > 0 {
+			values = append(values, h.midpoint(h.positive.offset+i))
+			counts = append(counts, c)
+		}
+	}
+	return values, counts
+}
+
+// index returns the index of the bucket of the positive value at the current
+// scale.
+func (h *Exponential) index(v float64) int {
+	return int(math.Ceil(math.Ldexp(math.Log2(v), h.scale))) - 1
+}
+
+// lowerBound returns the exclusive lower bound of the bucket.
+func (h *Exponential) lowerBound(index int) float64 {
+	return math.Exp2(math.Ldexp(float64(index), -h.scale))
+}
+
+func (h *Exponential) midpoint(index int) float64 {
+	return (h.lowerBound(index) + h.lowerBound(index+1)) / 2
+}
+
+// downscale lowers the scale, merging 2^change adjacent buckets into one.
+func (h *Exponential) downscale(change int) {
+	h.scale -= change
+	h.positive.downscale(change)
+	h.negative.downscale(change)
+}
+
+// scaleChange returns by how much the scale must be lowered for the index to
+// fit in the buckets.
+func (b *buckets) scaleChange(index, maxSize int) int {
+	if len(b.counts) == 0 {
+		return 0
+	}
+	low, high := b.offset, b.offset+len(b.counts)-1
+	if index < low {
+		low = index
+	}
+	if index > high {
+		high = index
+	}
+
+	change := 0
+	for (high>>change)-(low>>change)+1 > maxSize {
+		change++
+	}
+	return change
+}
+
+func (b *buckets) downscale(change int) {
+	if len(b.counts) == 0 {
+		return
+	}
+	offset := b.offset >> change
+	counts := make([]uint64, ((b.offset+len(b.counts)-1)>>change)-offset+1)
+	for i, c := range b.counts {
+		counts[((b.offset+i)>>change)-offset] += c
+	}
+	b.offset, b.counts = offset, counts
+}
+
+func (b *buckets) increment(index int) {
+	switch {
+	case len(b.counts) == 0:
+		b.offset = index
+		b.counts = []uint64{0}
+	case index < b.offset:
+		b.counts = append(make([]uint64, b.offset-index), b.counts...)
+		b.offset = index
+	case index >= b.offset+len(b.counts):
+		b.counts = append(b.counts, make([]uint64, index-b.offset-len(b.counts)+1)...)
+	}
+	b.counts[index-b.offset]++
+}
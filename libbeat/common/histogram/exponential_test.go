@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+var _ common.Histogram = (*Exponential)(nil)
+
+func TestExponentialBuckets(t *testing.T) {
+	h := NewExponential(0)
+	for _, v := range []float64{1, 1, 2, 0, -4, math.NaN(), math.Inf(1)} {
+		h.Record(v)
+	}
+	assert.Equal(t, uint64(5), h.Count())
+	assert.Equal(t, 0.0, h.Sum())
+	assert.Equal(t, MaxScale, h.Scale())
+
+	values, counts := h.HistogramValues()
+	require.Len(t, values, 4)
+	assert.Equal(t, []uint64{1, 1, 2, 1}, counts)
+	assert.InEpsilon(t, -4, values[0], 1e-5)
+	assert.Equal(t, 0.0, values[1])
+	assert.InEpsilon(t, 1, values[2], 1e-5)
+	assert.InEpsilon(t, 2, values[3], 1e-5)
+}
+
+func TestExponentialDownscale(t *testing.T) {
+	h := NewExponential(20)
+	var samples []float64
+	for v := 0.001; v < 1e6; v *= 1.3 {
+		samples = append(samples, v)
+		h.Record(v)
+	}
+	assert.Less(t, h.Scale(), MaxScale)
+	assert.LessOrEqual(t, len(h.positive.counts), 20)
+
+	values, counts := h.HistogramValues()
+	total := uint64(0)
+	for i, c := range counts {
+		total += c
+		if i > 0 {
+			assert.Greater(t, values[i], values[i-1])
+		}
+	}
+	assert.Equal(t, uint64(len(samples)), total)
+
+	// Every sample is counted in the bucket holding it
+	for _, v := range samples {
+		index := h.index(v)
+		assert.True(t, h.lowerBound(index) < v*(1+1e-9) && v <= h.lowerBound(index+1)*(1+1e-9), "sample %v", v)
+		assert.NotZero(t, h.positive.counts[index-h.positive.offset])
+	}
+}
+
+func TestExponentialEmpty(t *testing.T) {
+	values, counts := NewExponential(0).HistogramValues()
+	assert.Empty(t, values)
+	assert.Empty(t, counts)
+}
@@ -44,6 +44,7 @@ type Config struct {
 	TLS     *tlscommon.TLSConfig
 	Timeout time.Duration
 	Stats   IOStatser
+	Network IPFamily
 }
 
 func NewClient(c Config, network, host string, defaultPort int) (*Client, error) {
@@ -0,0 +1,231 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// IPFamily selects the address families used when connecting to a host
+// that resolves to multiple addresses.
+type IPFamily uint8
+
+const (
+	// IPFamilyAuto uses all resolved addresses. If a host resolves to both
+	// IPv4 and IPv6 addresses, connection attempts are raced (Happy Eyeballs).
+	IPFamilyAuto IPFamily = iota
+	// IPFamilyIPv4 only connects to IPv4 addresses.
+	IPFamilyIPv4
+	// IPFamilyIPv6 only connects to IPv6 addresses.
+	IPFamilyIPv6
+)
+
+// happyEyeballsDelay is the delay between two connection attempts when
+// racing IPv6 and IPv4 addresses, as recommended by RFC 8305.
+const happyEyeballsDelay = 250 * time.Millisecond
+
+var ipFamilyNames = map[IPFamily]string{
+	IPFamilyAuto: "auto",
+	IPFamilyIPv4: "ipv4",
+	IPFamilyIPv6: "ipv6",
+}
+
+// Unpack sets the IPFamily from one of `auto`, `ipv4`, or `ipv6`.
+func (f *IPFamily) Unpack(s string) error {
+	for family, name := range ipFamilyNames {
+		if strings.ToLower(s) == name {
+			*f = family
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid network '%v', expecting one of 'auto', 'ipv4', or 'ipv6'", s)
+}
+
+func (f IPFamily) String() string {
+	if name, ok := ipFamilyNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("<unknown network %d>", uint8(f))
+}
+
+// FilterAddresses returns the subset of addresses matching the address family.
+// Addresses that can not be parsed as IP are passed through unchanged.
+func (f IPFamily) FilterAddresses(addresses []string) []string {
+	if f == IPFamilyAuto {
+		return addresses
+	}
+
+	var filtered []string
+	for _, addr := range addresses {
+		ip := parseIPZone(addr)
+		switch {
+		case ip == nil:
+			filtered = append(filtered, addr)
+		case f == IPFamilyIPv4 && ip.To4() != nil:
+			filtered = append(filtered, addr)
+		case f == IPFamilyIPv6 && ip.To4() == nil:
+			filtered = append(filtered, addr)
+		}
+	}
+	return filtered
+}
+
+// DialHappyEyeballs dials one of a number of addresses of a host with the given
+// dialer. If the addresses contain IPv6 and IPv4 addresses, the connection attempts
+// alternate between the address families, starting with IPv6. A new attempt is
+// started if the previous one failed or did not complete within 250ms. The
+// first connection established is returned, all other attempts are cancelled.
+// See RFC 8305.
+//
+// If only one address family is available, DialHappyEyeballs behaves like DialWith.
+func DialHappyEyeballs(
+	dialer *net.Dialer,
+	network, host string,
+	addresses []string,
+	port string,
+) (net.Conn, error) {
+	return dialHappyEyeballs(dialer.DialContext, network, host, addresses, port)
+}
+
+// dialHappyEyeballs establishes all connections, including those of a single
+// address family, with dial.
+func dialHappyEyeballs(
+	dial func(ctx context.Context, network, address string) (net.Conn, error),
+	network, host string,
+	addresses []string,
+	port string,
+) (net.Conn, error) {
+	var ipv6, ipv4 []string
+	for _, addr := range addresses {
+		if ip := parseIPZone(addr); ip != nil && ip.To4() != nil {
+			ipv4 = append(ipv4, addr)
+		} else {
+			ipv6 = append(ipv6, addr)
+		}
+	}
+
+	if len(ipv6) == 0 || len(ipv4) == 0 {
+		dialer := DialerFunc(func(network, address string) (net.Conn, error) {
+			return dial(context.Background(), network, address)
+		})
+		return DialWith(dialer, network, host, addresses, port)
+	}
+
+	// Randomize the order within each family, for the same reasons as
+	// documented in DialWith.
+	ordered := make([]string, 0, len(addresses))
+	ipv6, ipv4 = shuffled(ipv6), shuffled(ipv4)
+	for i := 0; i < len(ipv6) || i < len(ipv4); i++ {
+		if i < len(ipv6) {
+			ordered = append(ordered, ipv6[i])
+		}
+		if i < len(ipv4) {
+			ordered = append(ordered, ipv4[i])
+		}
+	}
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan dialResult, len(ordered))
+	startAttempt := func(addr string) {
+		go func() {
+			conn, err := dial(ctx, network, net.JoinHostPort(addr, port))
+			results <- dialResult{conn, err}
+		}()
+	}
+
+	timer := time.NewTimer(happyEyeballsDelay)
+	defer timer.Stop()
+
+	startAttempt(ordered[0])
+	next, pending := 1, 1
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				// Close connections of attempts that succeed after we have
+				// already picked a winner.
+				go func(n int) {
+					for i := 0; i < n; i++ {
+						if late := <-results; late.conn != nil {
+							late.conn.Close()
+						}
+					}
+				}(pending)
+				return res.conn, nil
+			}
+			lastErr = res.err
+
+			if next < len(ordered) {
+				startAttempt(ordered[next])
+				next++
+				pending++
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(happyEyeballsDelay)
+			}
+
+		case <-timer.C:
+			if next < len(ordered) {
+				startAttempt(ordered[next])
+				next++
+				pending++
+				timer.Reset(happyEyeballsDelay)
+			}
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("unable to connect to '%v'", host)
+	}
+	return nil, lastErr
+}
+
+// parseIPZone parses an IP address, ignoring an optional IPv6 zone identifier.
+func parseIPZone(s string) net.IP {
+	if idx := strings.LastIndexByte(s, '%'); idx >= 0 {
+		s = s[:idx]
+	}
+	return net.ParseIP(s)
+}
+
+func shuffled(addresses []string) []string {
+	out := make([]string, len(addresses))
+	for i, j := range rand.Perm(len(addresses)) {
+		out[i] = addresses[j]
+	}
+	return out
+}
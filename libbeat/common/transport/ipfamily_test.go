@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFamilyUnpack(t *testing.T) {
+	tests := map[string]IPFamily{
+		"auto": IPFamilyAuto,
+		"ipv4": IPFamilyIPv4,
+		"IPv6": IPFamilyIPv6,
+	}
+
+	for in, expected := range tests {
+		var f IPFamily
+		require.NoError(t, f.Unpack(in))
+		assert.Equal(t, expected, f)
+	}
+
+	var f IPFamily
+	assert.Error(t, f.Unpack("ipx"))
+}
+
+func TestIPFamilyFilterAddresses(t *testing.T) {
+	addresses := []string{"10.0.0.1", "2001:db8::1", "fe80::1%eth0", "::ffff:10.0.0.2"}
+
+	assert.Equal(t, addresses, IPFamilyAuto.FilterAddresses(addresses))
+	assert.Equal(t, []string{"10.0.0.1", "::ffff:10.0.0.2"}, IPFamilyIPv4.FilterAddresses(addresses))
+	assert.Equal(t, []string{"2001:db8::1", "fe80::1%eth0"}, IPFamilyIPv6.FilterAddresses(addresses))
+}
+
+// fakeDialer records the connection attempts. Attempts to blackholed
+// addresses block until they are cancelled, attempts to unreachable
+// addresses fail right away.
+type fakeDialer struct {
+	mu          sync.Mutex
+	started     map[string]time.Time
+	cancelled   map[string]bool
+	blackholed  map[string]bool
+	unreachable map[string]bool
+}
+
+func newFakeDialer() *fakeDialer {
+	return &fakeDialer{
+		started:     map[string]time.Time{},
+		cancelled:   map[string]bool{},
+		blackholed:  map[string]bool{},
+		unreachable: map[string]bool{},
+	}
+}
+
+func (d *fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, _ := net.SplitHostPort(address)
+	d.mu.Lock()
+	d.started[host] = time.Now()
+	d.mu.Unlock()
+
+	switch {
+	case d.blackholed[host]:
+		<-ctx.Done()
+		d.mu.Lock()
+		d.cancelled[host] = true
+		d.mu.Unlock()
+		return nil, ctx.Err()
+	case d.unreachable[host]:
+		return nil, errors.New("network is unreachable")
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func (d *fakeDialer) startedAt(host string) (time.Time, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t, ok := d.started[host]
+	return t, ok
+}
+
+func (d *fakeDialer) wasCancelled(host string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancelled[host]
+}
+
+func TestDialHappyEyeballsFallback(t *testing.T) {
+	fake := newFakeDialer()
+	fake.blackholed["2001:db8::1"] = true
+
+	// The IPv6 attempt never completes, the IPv4 attempt must be started
+	// after the delay and win.
+	start := time.Now()
+	conn, err := dialHappyEyeballs(fake.DialContext, "tcp", "localhost", []string{"2001:db8::1", "127.0.0.1"}, "80")
+	require.NoError(t, err)
+	conn.Close()
+
+	ipv6Start, ok := fake.startedAt("2001:db8::1")
+	require.True(t, ok)
+	ipv4Start, ok := fake.startedAt("127.0.0.1")
+	require.True(t, ok)
+	assert.False(t, ipv6Start.Before(start))
+	assert.True(t, ipv4Start.Sub(ipv6Start) >= happyEyeballsDelay, "IPv4 attempt started %v after IPv6", ipv4Start.Sub(ipv6Start))
+
+	// The pending IPv6 attempt is cancelled once IPv4 succeeds.
+	for deadline := time.Now().Add(time.Second); !fake.wasCancelled("2001:db8::1") && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, fake.wasCancelled("2001:db8::1"))
+}
+
+func TestDialHappyEyeballsFailFast(t *testing.T) {
+	fake := newFakeDialer()
+	fake.unreachable["2001:db8::1"] = true
+
+	// A failed attempt starts the next one without waiting for the delay.
+	start := time.Now()
+	conn, err := dialHappyEyeballs(fake.DialContext, "tcp", "localhost", []string{"2001:db8::1", "127.0.0.1"}, "80")
+	require.NoError(t, err)
+	conn.Close()
+	assert.True(t, time.Since(start) < happyEyeballsDelay)
+}
+
+func TestDialHappyEyeballsAllFail(t *testing.T) {
+	fake := newFakeDialer()
+	fake.unreachable["2001:db8::1"] = true
+	fake.unreachable["192.0.2.1"] = true
+
+	_, err := dialHappyEyeballs(fake.DialContext, "tcp", "localhost", []string{"2001:db8::1", "192.0.2.1"}, "80")
+	assert.Error(t, err)
+}
+
+func TestDialHappyEyeballsSingleFamily(t *testing.T) {
+	fake := newFakeDialer()
+	fake.unreachable["192.0.2.1"] = true
+
+	// Without IPv6 addresses there is no race, but all attempts still use
+	// the given dial function.
+	conn, err := dialHappyEyeballs(fake.DialContext, "tcp", "localhost", []string{"192.0.2.1", "127.0.0.1"}, "80")
+	require.NoError(t, err)
+	conn.Close()
+
+	_, ok := fake.startedAt("127.0.0.1")
+	assert.True(t, ok)
+}
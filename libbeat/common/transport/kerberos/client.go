@@ -23,6 +23,7 @@ import (
 
 	krbclient "gopkg.in/jcmturner/gokrb5.v7/client"
 	krbconfig "gopkg.in/jcmturner/gokrb5.v7/config"
+	"gopkg.in/jcmturner/gokrb5.v7/credentials"
 	"gopkg.in/jcmturner/gokrb5.v7/keytab"
 	"gopkg.in/jcmturner/gokrb5.v7/spnego"
 )
@@ -32,7 +33,19 @@ type Client struct {
 }
 
 func NewClient(config *Config, httpClient *http.Client, esurl string) (*Client, error) {
-	var krbClient *krbclient.Client
+	krbClient, err := NewKrbClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		spClient: spnego.NewClient(krbClient, httpClient, ""),
+	}, nil
+}
+
+// NewKrbClient creates a Kerberos client logging in with the configured
+// credentials.
+func NewKrbClient(config *Config) (*krbclient.Client, error) {
 	krbConf, err := krbconfig.Load(config.ConfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Kerberos client: %+v", err)
@@ -44,16 +57,22 @@ func NewClient(config *Config, httpClient *http.Client, esurl string) (*Client,
 		if err != nil {
 			return nil, fmt.Errorf("cannot load keytab file %s: %+v", config.KeyTabPath, err)
 		}
-		krbClient = krbclient.NewClientWithKeytab(config.Username, config.Realm, kTab, krbConf)
+		return krbclient.NewClientWithKeytab(config.Username, config.Realm, kTab, krbConf), nil
 	case authPassword:
-		krbClient = krbclient.NewClientWithPassword(config.Username, config.Realm, config.Password, krbConf)
+		return krbclient.NewClientWithPassword(config.Username, config.Realm, config.Password, krbConf), nil
+	case authCCache:
+		cCache, err := credentials.LoadCCache(config.CCachePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load credential cache %s: %+v", config.CCachePath, err)
+		}
+		krbClient, err := krbclient.NewClientFromCCache(cCache, krbConf)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Kerberos client from credential cache: %+v", err)
+		}
+		return krbClient, nil
 	default:
 		return nil, InvalidAuthType
 	}
-
-	return &Client{
-		spClient: spnego.NewClient(krbClient, httpClient, ""),
-	}, nil
 }
 
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
@@ -27,9 +27,11 @@ type AuthType uint
 const (
 	authPassword = 1
 	authKeytab   = 2
+	authCCache   = 3
 
 	authPasswordStr = "password"
 	authKeytabStr   = "keytab"
+	authCCacheStr   = "ccache"
 )
 
 var (
@@ -38,6 +40,7 @@ var (
 	authTypes = map[string]AuthType{
 		authPasswordStr: authPassword,
 		authKeytabStr:   authKeytab,
+		authCCacheStr:   authCCache,
 	}
 )
 
@@ -45,11 +48,12 @@ type Config struct {
 	Enabled     *bool    `config:"enabled" yaml:"enabled,omitempty"`
 	AuthType    AuthType `config:"auth_type" validate:"required"`
 	KeyTabPath  string   `config:"keytab"`
+	CCachePath  string   `config:"ccache"`
 	ConfigPath  string   `config:"config_path" validate:"required"`
 	ServiceName string   `config:"service_name"`
 	Username    string   `config:"username"`
 	Password    string   `config:"password"`
-	Realm       string   `config:"realm" validate:"required"`
+	Realm       string   `config:"realm"`
 }
 
 // IsEnabled returns true if the `enable` field is set to true in the yaml.
@@ -57,6 +61,11 @@ func (c *Config) IsEnabled() bool {
 	return c != nil && (c.Enabled == nil || *c.Enabled)
 }
 
+// UsesCCache returns true if the credentials are read from a credential cache.
+func (c *Config) UsesCCache() bool {
+	return c.AuthType == authCCache
+}
+
 // Unpack validates and unpack "auth_type" config option
 func (t *AuthType) Unpack(value string) error {
 	authT, ok := authTypes[value]
@@ -83,9 +92,21 @@ func (c *Config) Validate() error {
 		if c.KeyTabPath == "" {
 			return fmt.Errorf("keytab authentication is selected for Kerberos, but path to keytab is not configured")
 		}
+
+	case authCCache:
+		// The principal and realm are read from the credential cache.
+		if c.CCachePath == "" {
+			return fmt.Errorf("ccache authentication is selected for Kerberos, but path to credential cache is not configured")
+		}
+		return nil
+
 	default:
 		return InvalidAuthType
 	}
 
+	if c.Realm == "" {
+		return fmt.Errorf("realm is not configured for Kerberos")
+	}
+
 	return nil
 }
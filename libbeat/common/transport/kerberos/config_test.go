@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kerberos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"password": {
+			config: map[string]interface{}{
+				"auth_type":   "password",
+				"username":    "elastic",
+				"password":    "changeme",
+				"realm":       "ELASTIC",
+				"config_path": "/etc/krb5.conf",
+			},
+			valid: true,
+		},
+		"password without realm": {
+			config: map[string]interface{}{
+				"auth_type":   "password",
+				"username":    "elastic",
+				"password":    "changeme",
+				"config_path": "/etc/krb5.conf",
+			},
+		},
+		"keytab": {
+			config: map[string]interface{}{
+				"auth_type":   "keytab",
+				"username":    "elastic",
+				"keytab":      "/etc/elastic.keytab",
+				"realm":       "ELASTIC",
+				"config_path": "/etc/krb5.conf",
+			},
+			valid: true,
+		},
+		"keytab without realm": {
+			config: map[string]interface{}{
+				"auth_type":   "keytab",
+				"username":    "elastic",
+				"keytab":      "/etc/elastic.keytab",
+				"config_path": "/etc/krb5.conf",
+			},
+		},
+		"ccache without realm": {
+			config: map[string]interface{}{
+				"auth_type":   "ccache",
+				"ccache":      "/tmp/krb5cc_1000",
+				"config_path": "/etc/krb5.conf",
+			},
+			valid: true,
+		},
+		"ccache without path": {
+			config: map[string]interface{}{
+				"auth_type":   "ccache",
+				"config_path": "/etc/krb5.conf",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			var config Config
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pkcs11
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrNotSupported is returned when PKCS#11 is not available on the platform
+// or in the build.
+var ErrNotSupported = errors.New("PKCS#11 is not supported by this build")
+
+// Config selects a private key (and optionally its certificate) stored in a
+// PKCS#11 token, like a hardware security module or a smart card.
+type Config struct {
+	Module     string `config:"module" yaml:"module,omitempty"`
+	Slot       *uint  `config:"slot" yaml:"slot,omitempty"`
+	TokenLabel string `config:"token_label" yaml:"token_label,omitempty"`
+	PIN        string `config:"pin" yaml:"pin,omitempty"`
+	KeyLabel   string `config:"key_label" yaml:"key_label,omitempty"`
+	KeyID      string `config:"key_id" yaml:"key_id,omitempty"`
+}
+
+// Validate validates the Config.
+func (c *Config) Validate() error {
+	if c.Module == "" {
+		return errors.New("pkcs11 module is not configured")
+	}
+	if c.Slot != nil && c.TokenLabel != "" {
+		return errors.New("pkcs11 slot and token_label can not be used together")
+	}
+	if c.KeyLabel == "" && c.KeyID == "" {
+		return errors.New("pkcs11 requires key_label or key_id to select the private key")
+	}
+	if _, err := c.keyID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// keyID returns the decoded CKA_ID of the key.
+func (c *Config) keyID() ([]byte, error) {
+	id, err := hex.DecodeString(c.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 key_id '%s' is not hex encoded: %v", c.KeyID, err)
+	}
+	return id, nil
+}
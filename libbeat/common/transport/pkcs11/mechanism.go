@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// PKCS#11 mechanisms and MGF types, see the PKCS#11 Cryptographic Token
+// Interface Base Specification.
+const (
+	ckmRSAPKCS    = 0x00000001
+	ckmRSAPKCSPSS = 0x0000000d
+	ckmECDSA      = 0x00001041
+
+	ckmSHA1   = 0x00000220
+	ckmSHA256 = 0x00000250
+	ckmSHA384 = 0x00000260
+	ckmSHA512 = 0x00000270
+
+	ckgMGF1SHA1   = 0x00000001
+	ckgMGF1SHA256 = 0x00000002
+	ckgMGF1SHA384 = 0x00000003
+	ckgMGF1SHA512 = 0x00000004
+)
+
+// mechanism describes how the token signs a digest.
+type mechanism struct {
+	typ uint
+
+	// RSA-PSS parameters
+	hashAlg, mgf, saltLength uint
+}
+
+// DER encoded DigestInfo prefixes prepended to the digest for PKCS #1 v1.5
+// signatures, as CKM_RSA_PKCS does not hash the data itself.
+var pkcs1Prefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	// TLS 1.0 and 1.1 sign the concatenated MD5 and SHA1 digests without prefix.
+	crypto.MD5SHA1: {},
+}
+
+var pssHashes = map[crypto.Hash]struct{ hashAlg, mgf uint }{
+	crypto.SHA1:   {ckmSHA1, ckgMGF1SHA1},
+	crypto.SHA256: {ckmSHA256, ckgMGF1SHA256},
+	crypto.SHA384: {ckmSHA384, ckgMGF1SHA384},
+	crypto.SHA512: {ckmSHA512, ckgMGF1SHA512},
+}
+
+// signMechanism returns the mechanism used to sign the digest with a key of
+// the given public key type, and the data passed to the token.
+func signMechanism(pub crypto.PublicKey, digest []byte, opts crypto.SignerOpts) (mechanism, []byte, error) {
+	hash := opts.HashFunc()
+	if hash != 0 && hash.Size() != len(digest) {
+		return mechanism{}, nil, fmt.Errorf("digest length %d does not match hash %v", len(digest), hash)
+	}
+
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			h, ok := pssHashes[hash]
+			if !ok {
+				return mechanism{}, nil, fmt.Errorf("unsupported hash %v for RSA-PSS", hash)
+			}
+			saltLength := pss.SaltLength
+			switch saltLength {
+			case rsa.PSSSaltLengthEqualsHash:
+				saltLength = hash.Size()
+			case rsa.PSSSaltLengthAuto:
+				return mechanism{}, nil, errors.New("RSA-PSS with automatic salt length is not supported")
+			}
+			m := mechanism{typ: ckmRSAPKCSPSS, hashAlg: h.hashAlg, mgf: h.mgf, saltLength: uint(saltLength)}
+			return m, digest, nil
+		}
+
+		prefix, ok := pkcs1Prefixes[hash]
+		if !ok {
+			return mechanism{}, nil, fmt.Errorf("unsupported hash %v for RSA PKCS #1 v1.5", hash)
+		}
+		data := make([]byte, 0, len(prefix)+len(digest))
+		data = append(append(data, prefix...), digest...)
+		return mechanism{typ: ckmRSAPKCS}, data, nil
+
+	case *ecdsa.PublicKey:
+		return mechanism{typ: ckmECDSA}, digest, nil
+
+	default:
+		return mechanism{}, nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+}
+
+// ecdsaSignature converts the raw r || s signature returned by CKM_ECDSA
+// into the ASN.1 encoding expected from a crypto.Signer.
+func ecdsaSignature(raw []byte) ([]byte, error) {
+	if len(raw) == 0 || len(raw)%2 != 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature length %d", len(raw))
+	}
+	n := len(raw) / 2
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(raw[:n]),
+		S: new(big.Int).SetBytes(raw[n:]),
+	})
+}
@@ -0,0 +1,688 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build cgo,!windows
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/coreos/pkg/dlopen"
+)
+
+/*
+#include <stdlib.h>
+#include <string.h>
+
+// Subset of the PKCS#11 v2.40 types. Structures are not packed on Unix.
+typedef unsigned long CK_ULONG;
+typedef unsigned char CK_BYTE;
+typedef unsigned char CK_BBOOL;
+typedef CK_ULONG CK_RV;
+typedef CK_ULONG CK_FLAGS;
+typedef CK_ULONG CK_SLOT_ID;
+typedef CK_ULONG CK_SESSION_HANDLE;
+typedef CK_ULONG CK_OBJECT_HANDLE;
+typedef CK_ULONG CK_OBJECT_CLASS;
+typedef CK_ULONG CK_ATTRIBUTE_TYPE;
+typedef CK_ULONG CK_MECHANISM_TYPE;
+
+typedef struct {
+	CK_ATTRIBUTE_TYPE type;
+	void *pValue;
+	CK_ULONG ulValueLen;
+} CK_ATTRIBUTE;
+
+typedef struct {
+	CK_MECHANISM_TYPE mechanism;
+	void *pParameter;
+	CK_ULONG ulParameterLen;
+} CK_MECHANISM;
+
+typedef struct {
+	CK_MECHANISM_TYPE hashAlg;
+	CK_ULONG mgf;
+	CK_ULONG sLen;
+} CK_RSA_PKCS_PSS_PARAMS;
+
+typedef struct {
+	CK_BYTE major;
+	CK_BYTE minor;
+} CK_VERSION;
+
+typedef struct {
+	CK_BYTE label[32];
+	CK_BYTE manufacturerID[32];
+	CK_BYTE model[16];
+	CK_BYTE serialNumber[16];
+	CK_FLAGS flags;
+	CK_ULONG ulMaxSessionCount;
+	CK_ULONG ulSessionCount;
+	CK_ULONG ulMaxRwSessionCount;
+	CK_ULONG ulRwSessionCount;
+	CK_ULONG ulMaxPinLen;
+	CK_ULONG ulMinPinLen;
+	CK_ULONG ulTotalPublicMemory;
+	CK_ULONG ulFreePublicMemory;
+	CK_ULONG ulTotalPrivateMemory;
+	CK_ULONG ulFreePrivateMemory;
+	CK_VERSION hardwareVersion;
+	CK_VERSION firmwareVersion;
+	CK_BYTE utcTime[16];
+} CK_TOKEN_INFO;
+
+typedef struct {
+	void *CreateMutex;
+	void *DestroyMutex;
+	void *LockMutex;
+	void *UnlockMutex;
+	CK_FLAGS flags;
+	void *pReserved;
+} CK_C_INITIALIZE_ARGS;
+
+#define CKF_OS_LOCKING_OK  0x00000002UL
+#define CKF_SERIAL_SESSION 0x00000004UL
+#define CKU_USER           1UL
+#define CKA_CLASS          0x00000000UL
+#define CKA_LABEL          0x00000003UL
+#define CKA_VALUE          0x00000011UL
+#define CKA_ID             0x00000102UL
+
+static CK_RV p11_initialize(void *f) {
+	CK_C_INITIALIZE_ARGS args;
+	memset(&args, 0, sizeof(args));
+	args.flags = CKF_OS_LOCKING_OK;
+	return ((CK_RV (*)(CK_C_INITIALIZE_ARGS *))f)(&args);
+}
+
+static CK_RV p11_get_slot_list(void *f, CK_SLOT_ID *slots, CK_ULONG *count) {
+	return ((CK_RV (*)(CK_BBOOL, CK_SLOT_ID *, CK_ULONG *))f)(1, slots, count);
+}
+
+static CK_RV p11_get_token_info(void *f, CK_SLOT_ID slot, CK_TOKEN_INFO *info) {
+	return ((CK_RV (*)(CK_SLOT_ID, CK_TOKEN_INFO *))f)(slot, info);
+}
+
+static CK_RV p11_open_session(void *f, CK_SLOT_ID slot, CK_SESSION_HANDLE *session) {
+	return ((CK_RV (*)(CK_SLOT_ID, CK_FLAGS, void *, void *, CK_SESSION_HANDLE *))f)(
+		slot, CKF_SERIAL_SESSION, NULL, NULL, session);
+}
+
+static CK_RV p11_close_session(void *f, CK_SESSION_HANDLE session) {
+	return ((CK_RV (*)(CK_SESSION_HANDLE))f)(session);
+}
+
+static CK_RV p11_login(void *f, CK_SESSION_HANDLE session, CK_BYTE *pin, CK_ULONG pin_len) {
+	return ((CK_RV (*)(CK_SESSION_HANDLE, CK_ULONG, CK_BYTE *, CK_ULONG))f)(
+		session, CKU_USER, pin, pin_len);
+}
+
+// p11_find_object looks up the first object of the class matching the label
+// and ID. Empty label or ID are not used for matching.
+static CK_RV p11_find_object(void *init, void *find, void *final,
+	CK_SESSION_HANDLE session, CK_OBJECT_CLASS class,
+	CK_BYTE *label, CK_ULONG label_len, CK_BYTE *id, CK_ULONG id_len,
+	CK_OBJECT_HANDLE *object, CK_ULONG *count)
+{
+	CK_ATTRIBUTE tmpl[3];
+	CK_ULONG n = 0;
+	CK_RV rv;
+
+	tmpl[n].type = CKA_CLASS;
+	tmpl[n].pValue = &class;
+	tmpl[n].ulValueLen = sizeof(class);
+	n++;
+	if (label_len > 0) {
+		tmpl[n].type = CKA_LABEL;
+		tmpl[n].pValue = label;
+		tmpl[n].ulValueLen = label_len;
+		n++;
+	}
+	if (id_len > 0) {
+		tmpl[n].type = CKA_ID;
+		tmpl[n].pValue = id;
+		tmpl[n].ulValueLen = id_len;
+		n++;
+	}
+
+	rv = ((CK_RV (*)(CK_SESSION_HANDLE, CK_ATTRIBUTE *, CK_ULONG))init)(session, tmpl, n);
+	if (rv != 0) {
+		return rv;
+	}
+	rv = ((CK_RV (*)(CK_SESSION_HANDLE, CK_OBJECT_HANDLE *, CK_ULONG, CK_ULONG *))find)(
+		session, object, 1, count);
+	((CK_RV (*)(CK_SESSION_HANDLE))final)(session);
+	return rv;
+}
+
+static CK_RV p11_get_value(void *f, CK_SESSION_HANDLE session, CK_OBJECT_HANDLE object,
+	CK_BYTE *value, CK_ULONG *value_len)
+{
+	CK_ATTRIBUTE attr;
+	CK_RV rv;
+
+	attr.type = CKA_VALUE;
+	attr.pValue = value;
+	attr.ulValueLen = *value_len;
+	rv = ((CK_RV (*)(CK_SESSION_HANDLE, CK_OBJECT_HANDLE, CK_ATTRIBUTE *, CK_ULONG))f)(
+		session, object, &attr, 1);
+	*value_len = attr.ulValueLen;
+	return rv;
+}
+
+static CK_RV p11_sign_init(void *f, CK_SESSION_HANDLE session, CK_OBJECT_HANDLE key,
+	CK_MECHANISM_TYPE type, CK_MECHANISM_TYPE hash_alg, CK_ULONG mgf, CK_ULONG salt_len)
+{
+	CK_MECHANISM mech;
+	CK_RSA_PKCS_PSS_PARAMS params;
+
+	mech.mechanism = type;
+	mech.pParameter = NULL;
+	mech.ulParameterLen = 0;
+	if (hash_alg != 0) {
+		params.hashAlg = hash_alg;
+		params.mgf = mgf;
+		params.sLen = salt_len;
+		mech.pParameter = &params;
+		mech.ulParameterLen = sizeof(params);
+	}
+	return ((CK_RV (*)(CK_SESSION_HANDLE, CK_MECHANISM *, CK_OBJECT_HANDLE))f)(session, &mech, key);
+}
+
+static CK_RV p11_sign(void *f, CK_SESSION_HANDLE session, CK_BYTE *data, CK_ULONG data_len,
+	CK_BYTE *sig, CK_ULONG *sig_len)
+{
+	return ((CK_RV (*)(CK_SESSION_HANDLE, CK_BYTE *, CK_ULONG, CK_BYTE *, CK_ULONG *))f)(
+		session, data, data_len, sig, sig_len);
+}
+*/
+import "C"
+
+// PKCS#11 return values and object classes used.
+const (
+	ckrOK                         = 0x00000000
+	ckrDeviceError                = 0x00000030
+	ckrDeviceRemoved              = 0x00000032
+	ckrSessionClosed              = 0x000000b0
+	ckrSessionHandleInvalid       = 0x000000b3
+	ckrTokenNotPresent            = 0x000000e0
+	ckrUserAlreadyLoggedIn        = 0x00000100
+	ckrUserNotLoggedIn            = 0x00000101
+	ckrCryptokiAlreadyInitialized = 0x00000191
+
+	ckoCertificate = 0x00000001
+	ckoPrivateKey  = 0x00000003
+)
+
+// ckError is an error returned by a PKCS#11 function.
+type ckError struct {
+	fn string
+	rv C.CK_RV
+}
+
+func (e *ckError) Error() string {
+	return fmt.Sprintf("pkcs11 %s failed: CKR 0x%08x", e.fn, uint64(e.rv))
+}
+
+func check(fn string, rv C.CK_RV) error {
+	if rv == ckrOK {
+		return nil
+	}
+	return &ckError{fn: fn, rv: rv}
+}
+
+// sessionLost returns true if the error is caused by a session that is not
+// usable anymore, e.g. because the token was reinserted or the HSM restarted.
+func sessionLost(err error) bool {
+	var ckErr *ckError
+	if !errors.As(err, &ckErr) {
+		return false
+	}
+	switch ckErr.rv {
+	case ckrSessionClosed, ckrSessionHandleInvalid, ckrDeviceRemoved, ckrDeviceError,
+		ckrTokenNotPresent, ckrUserNotLoggedIn:
+		return true
+	}
+	return false
+}
+
+// module is a loaded and initialized PKCS#11 module. Modules are loaded once
+// per process and never finalized, as other parts of the process might still
+// use them.
+type module struct {
+	path   string
+	handle *dlopen.LibHandle
+
+	getSlotList, getTokenInfo               unsafe.Pointer
+	openSession, closeSession, login        unsafe.Pointer
+	findObjectsInit, findObjects, findFinal unsafe.Pointer
+	getAttributeValue, signInit, sign       unsafe.Pointer
+}
+
+var (
+	modulesMu sync.Mutex
+	modules   = map[string]*module{}
+)
+
+func loadModule(path string) (*module, error) {
+	modulesMu.Lock()
+	defer modulesMu.Unlock()
+
+	if m := modules[path]; m != nil {
+		return m, nil
+	}
+
+	handle, err := dlopen.GetHandle([]string{path})
+	if err != nil {
+		return nil, fmt.Errorf("cannot load pkcs11 module %s: %v", path, err)
+	}
+
+	m := &module{path: path, handle: handle}
+	symbols := []struct {
+		name string
+		ptr  *unsafe.Pointer
+	}{
+		{"C_GetSlotList", &m.getSlotList},
+		{"C_GetTokenInfo", &m.getTokenInfo},
+		{"C_OpenSession", &m.openSession},
+		{"C_CloseSession", &m.closeSession},
+		{"C_Login", &m.login},
+		{"C_FindObjectsInit", &m.findObjectsInit},
+		{"C_FindObjects", &m.findObjects},
+		{"C_FindObjectsFinal", &m.findFinal},
+		{"C_GetAttributeValue", &m.getAttributeValue},
+		{"C_SignInit", &m.signInit},
+		{"C_Sign", &m.sign},
+	}
+	for _, sym := range symbols {
+		if *sym.ptr, err = handle.GetSymbolPointer(sym.name); err != nil {
+			handle.Close()
+			return nil, fmt.Errorf("pkcs11 module %s does not export %s: %v", path, sym.name, err)
+		}
+	}
+
+	initialize, err := handle.GetSymbolPointer("C_Initialize")
+	if err != nil {
+		handle.Close()
+		return nil, fmt.Errorf("pkcs11 module %s does not export C_Initialize: %v", path, err)
+	}
+	if rv := C.p11_initialize(initialize); rv != ckrOK && rv != ckrCryptokiAlreadyInitialized {
+		handle.Close()
+		return nil, check("C_Initialize", rv)
+	}
+
+	modules[path] = m
+	return m, nil
+}
+
+// findSlot returns the slot of the configured token. Without slot or
+// token_label, the first slot with a token is used.
+func (m *module) findSlot(config *Config) (C.CK_SLOT_ID, error) {
+	var count C.CK_ULONG
+	if err := check("C_GetSlotList", C.p11_get_slot_list(m.getSlotList, nil, &count)); err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("pkcs11 module %s has no slot with a token", m.path)
+	}
+	slots := make([]C.CK_SLOT_ID, count)
+	if err := check("C_GetSlotList", C.p11_get_slot_list(m.getSlotList, &slots[0], &count)); err != nil {
+		return 0, err
+	}
+	slots = slots[:count]
+
+	switch {
+	case config.Slot != nil:
+		for _, slot := range slots {
+			if uint(slot) == *config.Slot {
+				return slot, nil
+			}
+		}
+		return 0, fmt.Errorf("pkcs11 slot %d has no token", *config.Slot)
+
+	case config.TokenLabel != "":
+		for _, slot := range slots {
+			var info C.CK_TOKEN_INFO
+			if err := check("C_GetTokenInfo", C.p11_get_token_info(m.getTokenInfo, slot, &info)); err != nil {
+				return 0, err
+			}
+			label := C.GoBytes(unsafe.Pointer(&info.label[0]), C.int(len(info.label)))
+			if string(bytes.TrimRight(label, " \x00")) == config.TokenLabel {
+				return slot, nil
+			}
+		}
+		return 0, fmt.Errorf("pkcs11 token with label '%s' not found", config.TokenLabel)
+
+	default:
+		return slots[0], nil
+	}
+}
+
+// session is an open, logged in session to the token.
+type session struct {
+	m      *module
+	handle C.CK_SESSION_HANDLE
+}
+
+func (m *module) openLoggedIn(slot C.CK_SLOT_ID, pin []byte) (*session, error) {
+	var handle C.CK_SESSION_HANDLE
+	if err := check("C_OpenSession", C.p11_open_session(m.openSession, slot, &handle)); err != nil {
+		return nil, err
+	}
+	s := &session{m: m, handle: handle}
+
+	if len(pin) > 0 {
+		cPIN := C.CBytes(pin)
+		defer C.free(cPIN)
+		rv := C.p11_login(m.login, handle, (*C.CK_BYTE)(cPIN), C.CK_ULONG(len(pin)))
+		if rv != ckrOK && rv != ckrUserAlreadyLoggedIn {
+			s.close()
+			return nil, check("C_Login", rv)
+		}
+	}
+	return s, nil
+}
+
+func (s *session) close() {
+	C.p11_close_session(s.m.closeSession, s.handle)
+}
+
+func (s *session) findObject(class C.CK_OBJECT_CLASS, label, id []byte) (C.CK_OBJECT_HANDLE, bool, error) {
+	var object C.CK_OBJECT_HANDLE
+	var count C.CK_ULONG
+	rv := C.p11_find_object(s.m.findObjectsInit, s.m.findObjects, s.m.findFinal,
+		s.handle, class, cBytes(label), C.CK_ULONG(len(label)), cBytes(id), C.CK_ULONG(len(id)),
+		&object, &count)
+	if err := check("C_FindObjects", rv); err != nil {
+		return 0, false, err
+	}
+	return object, count > 0, nil
+}
+
+func (s *session) value(object C.CK_OBJECT_HANDLE) ([]byte, error) {
+	var n C.CK_ULONG
+	if err := check("C_GetAttributeValue", C.p11_get_value(s.m.getAttributeValue, s.handle, object, nil, &n)); err != nil {
+		return nil, err
+	}
+	value := make([]byte, n)
+	if n == 0 {
+		return value, nil
+	}
+	if err := check("C_GetAttributeValue", C.p11_get_value(s.m.getAttributeValue, s.handle, object, cBytes(value), &n)); err != nil {
+		return nil, err
+	}
+	return value[:n], nil
+}
+
+func (s *session) signData(key C.CK_OBJECT_HANDLE, mech mechanism, data []byte) ([]byte, error) {
+	rv := C.p11_sign_init(s.m.signInit, s.handle, key, C.CK_MECHANISM_TYPE(mech.typ),
+		C.CK_MECHANISM_TYPE(mech.hashAlg), C.CK_ULONG(mech.mgf), C.CK_ULONG(mech.saltLength))
+	if err := check("C_SignInit", rv); err != nil {
+		return nil, err
+	}
+
+	// The first call returns the signature length, the second one signs.
+	var n C.CK_ULONG
+	rv = C.p11_sign(s.m.sign, s.handle, cBytes(data), C.CK_ULONG(len(data)), nil, &n)
+	if err := check("C_Sign", rv); err != nil {
+		return nil, err
+	}
+	sig := make([]byte, n)
+	rv = C.p11_sign(s.m.sign, s.handle, cBytes(data), C.CK_ULONG(len(data)), cBytes(sig), &n)
+	if err := check("C_Sign", rv); err != nil {
+		return nil, err
+	}
+	return sig[:n], nil
+}
+
+func cBytes(b []byte) *C.CK_BYTE {
+	if len(b) == 0 {
+		return nil
+	}
+	return (*C.CK_BYTE)(unsafe.Pointer(&b[0]))
+}
+
+// signer signs data with a private key that never leaves the token. PKCS#11
+// sessions can only run one operation at a time, so signing is serialized.
+// Signers are shared by all certificates using the same key, such that only
+// one session is kept open per key.
+type signer struct {
+	m         *module
+	slot      C.CK_SLOT_ID
+	pin       []byte
+	label, id []byte
+
+	mu      sync.Mutex
+	session *session
+	key     C.CK_OBJECT_HANDLE
+}
+
+// signerKey identifies a private key in a token.
+type signerKey struct {
+	module    string
+	slot      C.CK_SLOT_ID
+	label, id string
+}
+
+var (
+	signersMu sync.Mutex
+	signers   = map[signerKey]*signer{}
+)
+
+// certificateKey implements crypto.Signer for the private key of a
+// certificate.
+type certificateKey struct {
+	signer *signer
+	public crypto.PublicKey
+}
+
+// LoadCertificate returns a TLS certificate whose private key is stored in
+// the token. The chain contains the DER encoded certificate followed by its
+// intermediates. If chain is empty, the certificate is read from the token,
+// using the key_label and key_id of the private key. Otherwise the private key
+// is checked to match the certificate.
+func LoadCertificate(config *Config, chain [][]byte) (*tls.Certificate, error) {
+	id, err := config.keyID()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := loadModule(config.Module)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := m.findSlot(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := getSigner(m, slot, config, id)
+	if err != nil {
+		return nil, err
+	}
+
+	verify := len(chain) > 0
+	if !verify {
+		der, err := s.certificate()
+		if err != nil {
+			return nil, err
+		}
+		chain = [][]byte{der}
+	}
+
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate for pkcs11 key: %v", err)
+	}
+	key := &certificateKey{signer: s, public: leaf.PublicKey}
+	if verify {
+		if err := checkKeyMatches(key, leaf); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Certificate{
+		Certificate: chain,
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+// getSigner returns the signer of the configured key, opening a session to
+// the token if there is no signer for the key yet.
+func getSigner(m *module, slot C.CK_SLOT_ID, config *Config, id []byte) (*signer, error) {
+	signersMu.Lock()
+	defer signersMu.Unlock()
+
+	k := signerKey{module: m.path, slot: slot, label: config.KeyLabel, id: string(id)}
+	if s := signers[k]; s != nil {
+		return s, nil
+	}
+
+	s := &signer{m: m, slot: slot, pin: []byte(config.PIN), label: []byte(config.KeyLabel), id: id}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	signers[k] = s
+	return s, nil
+}
+
+// checkKeyMatches signs a test message with the key and verifies the
+// signature with the public key of the certificate.
+func checkKeyMatches(key crypto.Signer, cert *x509.Certificate) error {
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		algorithm = x509.SHA256WithRSA
+	case x509.ECDSA:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return fmt.Errorf("unsupported public key algorithm %v in certificate", cert.PublicKeyAlgorithm)
+	}
+
+	message := []byte("pkcs11 key check")
+	digest := sha256.Sum256(message)
+	sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err == nil {
+		err = cert.CheckSignature(algorithm, message, sig)
+	}
+	if err != nil {
+		return fmt.Errorf("pkcs11 private key does not match the certificate: %v", err)
+	}
+	return nil
+}
+
+// open opens a new session and looks up the private key.
+func (s *signer) open() error {
+	if s.session != nil {
+		s.session.close()
+		s.session = nil
+	}
+
+	session, err := s.m.openLoggedIn(s.slot, s.pin)
+	if err != nil {
+		return err
+	}
+	key, found, err := session.findObject(ckoPrivateKey, s.label, s.id)
+	if err != nil {
+		session.close()
+		return err
+	}
+	if !found {
+		session.close()
+		return errors.New("pkcs11 private key not found in token")
+	}
+
+	s.session, s.key = session, key
+	return nil
+}
+
+// certificate reads the certificate with the label and ID of the key from the
+// token.
+func (s *signer) certificate() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == nil {
+		if err := s.open(); err != nil {
+			return nil, err
+		}
+	}
+	cert, found, err := s.session.findObject(ckoCertificate, s.label, s.id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("pkcs11 certificate matching the key not found in token")
+	}
+	return s.session.value(cert)
+}
+
+// sign signs data with the private key in the token. If the session was lost,
+// it is opened again once.
+func (s *signer) sign(mech mechanism, data []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == nil {
+		if err := s.open(); err != nil {
+			return nil, err
+		}
+	}
+	sig, err := s.session.signData(s.key, mech, data)
+	if sessionLost(err) {
+		if err := s.open(); err != nil {
+			return nil, err
+		}
+		sig, err = s.session.signData(s.key, mech, data)
+	}
+	return sig, err
+}
+
+// Public returns the public key of the certificate.
+func (k *certificateKey) Public() crypto.PublicKey {
+	return k.public
+}
+
+// Sign signs the digest with the private key in the token.
+func (k *certificateKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	mech, data, err := signMechanism(k.public, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := k.signer.sign(mech, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if mech.typ == ckmECDSA {
+		return ecdsaSignature(sig)
+	}
+	return sig, nil
+}
@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build integration,cgo,!windows
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// softHSMModules are the default install locations of the SoftHSM module.
+var softHSMModules = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+}
+
+// setupSoftHSM initializes a SoftHSM token in a temporary directory and
+// imports key into it. It returns the path of the module.
+func setupSoftHSM(t *testing.T, key crypto.PrivateKey) string {
+	module := os.Getenv("SOFTHSM2_MODULE")
+	if module == "" {
+		for _, path := range softHSMModules {
+			if _, err := os.Stat(path); err == nil {
+				module = path
+				break
+			}
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM module not found, set SOFTHSM2_MODULE")
+	}
+	util, err := exec.LookPath("softhsm2-util")
+	if err != nil {
+		t.Skip("softhsm2-util not found")
+	}
+
+	dir, err := ioutil.TempDir("", "pkcs11")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	tokens := filepath.Join(dir, "tokens")
+	require.NoError(t, os.Mkdir(tokens, 0700))
+	conf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, ioutil.WriteFile(conf, []byte("directories.tokendir = "+tokens+"\n"), 0600))
+	os.Setenv("SOFTHSM2_CONF", conf)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	commands := [][]string{
+		{"--init-token", "--free", "--label", "beats", "--pin", "1234", "--so-pin", "5678"},
+		{"--import", keyFile, "--token", "beats", "--label", "client", "--id", "0a1b", "--pin", "1234"},
+	}
+	for _, args := range commands {
+		out, err := exec.Command(util, args...).CombinedOutput()
+		require.NoError(t, err, "softhsm2-util failed: %s", out)
+	}
+	return module
+}
+
+func selfSignedCertificate(t *testing.T, key crypto.Signer) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "beats"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return der
+}
+
+func TestLoadCertificateSoftHSM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	module := setupSoftHSM(t, key)
+
+	config := &Config{Module: module, TokenLabel: "beats", PIN: "1234", KeyLabel: "client", KeyID: "0a1b"}
+	require.NoError(t, config.Validate())
+
+	cert, err := LoadCertificate(config, [][]byte{selfSignedCertificate(t, key)})
+	require.NoError(t, err)
+
+	t.Run("signs with the token key", func(t *testing.T) {
+		digest := sha256.Sum256([]byte("message"))
+		sig, err := cert.PrivateKey.(crypto.Signer).Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.NoError(t, cert.Leaf.CheckSignature(x509.ECDSAWithSHA256, []byte("message"), sig))
+	})
+
+	t.Run("shares the session of the key", func(t *testing.T) {
+		other, err := LoadCertificate(config, [][]byte{selfSignedCertificate(t, key)})
+		require.NoError(t, err)
+		assert.Equal(t, cert.PrivateKey.(*certificateKey).signer, other.PrivateKey.(*certificateKey).signer)
+	})
+
+	t.Run("rejects certificate of another key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		_, err = LoadCertificate(config, [][]byte{selfSignedCertificate(t, otherKey)})
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong key label", func(t *testing.T) {
+		_, err := LoadCertificate(&Config{Module: module, TokenLabel: "beats", PIN: "1234", KeyLabel: "missing"}, nil)
+		assert.Error(t, err)
+	})
+}
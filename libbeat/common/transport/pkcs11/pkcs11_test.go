@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMechanism(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("message"))
+
+	t.Run("RSA PKCS #1 v1.5 prepends DigestInfo", func(t *testing.T) {
+		mech, data, err := signMechanism(&rsaKey.PublicKey, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.Equal(t, mechanism{typ: ckmRSAPKCS}, mech)
+		assert.Equal(t, append(pkcs1Prefixes[crypto.SHA256], digest[:]...), data)
+	})
+
+	t.Run("RSA-PSS", func(t *testing.T) {
+		opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+		mech, data, err := signMechanism(&rsaKey.PublicKey, digest[:], opts)
+		require.NoError(t, err)
+		assert.Equal(t, mechanism{typ: ckmRSAPKCSPSS, hashAlg: ckmSHA256, mgf: ckgMGF1SHA256, saltLength: 32}, mech)
+		assert.Equal(t, digest[:], data)
+
+		opts.SaltLength = rsa.PSSSaltLengthAuto
+		_, _, err = signMechanism(&rsaKey.PublicKey, digest[:], opts)
+		assert.Error(t, err)
+	})
+
+	t.Run("ECDSA", func(t *testing.T) {
+		mech, data, err := signMechanism(&ecKey.PublicKey, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.Equal(t, mechanism{typ: ckmECDSA}, mech)
+		assert.Equal(t, digest[:], data)
+	})
+
+	t.Run("digest length mismatch", func(t *testing.T) {
+		_, _, err := signMechanism(&ecKey.PublicKey, digest[:20], crypto.SHA256)
+		assert.Error(t, err)
+	})
+}
+
+func TestECDSASignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("message"))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	// Tokens return r and s as fixed size big-endian integers.
+	raw := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(raw[32-len(rb):32], rb)
+	copy(raw[64-len(sb):], sb)
+
+	sig, err := ecdsaSignature(raw)
+	require.NoError(t, err)
+
+	cert := &x509.Certificate{PublicKey: &key.PublicKey, PublicKeyAlgorithm: x509.ECDSA}
+	assert.NoError(t, cert.CheckSignature(x509.ECDSAWithSHA256, []byte("message"), sig))
+
+	_, err = ecdsaSignature(raw[:63])
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	slot := uint(1)
+	tests := map[string]struct {
+		config Config
+		valid  bool
+	}{
+		"key label":          {Config{Module: "/usr/lib/softhsm/libsofthsm2.so", KeyLabel: "heartbeat"}, true},
+		"token label and id": {Config{Module: "/usr/lib/softhsm/libsofthsm2.so", TokenLabel: "hsm", KeyID: "0a1b"}, true},
+		"missing module":     {Config{KeyLabel: "heartbeat"}, false},
+		"missing key":        {Config{Module: "/usr/lib/softhsm/libsofthsm2.so"}, false},
+		"slot and token":     {Config{Module: "/usr/lib/softhsm/libsofthsm2.so", Slot: &slot, TokenLabel: "hsm", KeyLabel: "k"}, false},
+		"invalid key id":     {Config{Module: "/usr/lib/softhsm/libsofthsm2.so", KeyID: "xyz"}, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -32,6 +32,18 @@ func NetDialer(timeout time.Duration) Dialer {
 }
 
 func TestNetDialer(d testing.Driver, timeout time.Duration) Dialer {
+	return TestFamilyNetDialer(d, timeout, IPFamilyAuto)
+}
+
+// FamilyNetDialer creates a Dialer that only connects to addresses of the
+// given IP family.
+func FamilyNetDialer(timeout time.Duration, family IPFamily) Dialer {
+	return TestFamilyNetDialer(testing.NullDriver, timeout, family)
+}
+
+// TestFamilyNetDialer creates a test Dialer that only connects to addresses
+// of the given IP family.
+func TestFamilyNetDialer(d testing.Driver, timeout time.Duration, family IPFamily) Dialer {
 	return DialerFunc(func(network, address string) (net.Conn, error) {
 		switch network {
 		case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
@@ -53,8 +65,20 @@ func TestNetDialer(d testing.Driver, timeout time.Duration) Dialer {
 			return nil, err
 		}
 
-		// dial via host IP by randomized iteration of known IPs
+		addresses = family.FilterAddresses(addresses)
+		if len(addresses) == 0 {
+			err = fmt.Errorf("no %v address found for host %v", family, host)
+			d.Fatal("network", err)
+			return nil, err
+		}
+
 		dialer := &net.Dialer{Timeout: timeout}
+		if family == IPFamilyAuto && network == "tcp" {
+			// race IPv6 and IPv4 addresses, if the host has both
+			return DialHappyEyeballs(dialer, network, host, addresses, port)
+		}
+
+		// dial via host IP by randomized iteration of known IPs
 		return DialWith(dialer, network, host, addresses, port)
 	})
 }
@@ -19,6 +19,7 @@ package tlscommon
 
 import (
 	"crypto/tls"
+	"fmt"
 
 	"github.com/joeshaw/multierror"
 )
@@ -34,6 +35,11 @@ type Config struct {
 	CurveTypes       []tlsCurveType          `config:"curve_types" yaml:"curve_types,omitempty"`
 	Renegotiation    tlsRenegotiationSupport `config:"renegotiation" yaml:"renegotiation"`
 	CASha256         []string                `config:"ca_sha256" yaml:"ca_sha256,omitempty"`
+
+	// Client only: the server name sent via SNI and the hostname the server
+	// certificate is verified against, instead of the host connected to.
+	ServerName           string `config:"server_name" yaml:"server_name,omitempty"`
+	VerificationHostname string `config:"verification_hostname" yaml:"verification_hostname,omitempty"`
 }
 
 // LoadTLSConfig will load a certificate from config with all TLS based keys
@@ -90,12 +96,18 @@ func LoadTLSConfig(config *Config) (*TLSConfig, error) {
 		CurvePreferences: curves,
 		Renegotiation:    tls.RenegotiationSupport(config.Renegotiation),
 		CASha256:         config.CASha256,
+
+		ServerName:           config.ServerName,
+		VerificationHostname: config.VerificationHostname,
 	}, nil
 }
 
 // Validate values the TLSConfig struct making sure certificate sure we have both a certificate and
 // a key.
 func (c *Config) Validate() error {
+	if c.VerificationHostname != "" && c.VerificationMode != VerifyFull {
+		return fmt.Errorf("verification_hostname requires verification_mode 'full', got '%v'", c.VerificationMode)
+	}
 	return c.Certificate.Validate()
 }
 
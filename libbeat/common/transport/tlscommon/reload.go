@@ -0,0 +1,199 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlscommon
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/joeshaw/multierror"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// reloadCheckInterval is the minimum time between two checks of the
+// certificate, key and certificate authority files for changes.
+var reloadCheckInterval = 10 * time.Second
+
+// fileStat holds the file attributes used to detect that a file changed.
+type fileStat struct {
+	modTime time.Time
+	size    int64
+}
+
+// reloader keeps the certificate and certificate authorities of a TLSConfig up
+// to date with the files they were loaded from.
+type reloader struct {
+	config *Config
+	log    *logp.Logger
+
+	mu          sync.Mutex
+	lastCheck   time.Time
+	stats       map[string]fileStat
+	certificate *tls.Certificate
+	rootCAs     *x509.CertPool
+}
+
+// LoadTLSConfigWithReload loads the TLS configuration like LoadTLSConfig, but
+// the returned TLSConfig reloads the certificate, key and certificate
+// authorities when their files change. Changes are picked up by new
+// connections, established connections are not affected.
+func LoadTLSConfigWithReload(config *Config) (*TLSConfig, error) {
+	tlsConfig, err := LoadTLSConfig(config)
+	if err != nil || tlsConfig == nil {
+		return tlsConfig, err
+	}
+
+	r := &reloader{
+		config:    config,
+		log:       logp.NewLogger(logSelector),
+		lastCheck: time.Now(),
+		stats:     statFiles(config),
+		rootCAs:   tlsConfig.RootCAs,
+	}
+	if len(tlsConfig.Certificates) > 0 {
+		r.certificate = &tlsConfig.Certificates[0]
+	}
+	tlsConfig.reloader = r
+	return tlsConfig, nil
+}
+
+// watchedFiles returns the paths of the files the TLS configuration is loaded from.
+func watchedFiles(config *Config) []string {
+	var paths []string
+	if config.Certificate.Certificate != "" {
+		paths = append(paths, config.Certificate.Certificate)
+	}
+	if config.Certificate.Key != "" {
+		paths = append(paths, config.Certificate.Key)
+	}
+	return append(paths, config.CAs...)
+}
+
+func statFiles(config *Config) map[string]fileStat {
+	stats := map[string]fileStat{}
+	for _, path := range watchedFiles(config) {
+		// Missing files are recorded as the zero value, so the files are reloaded
+		// once they are back.
+		var stat fileStat
+		if info, err := os.Stat(path); err == nil {
+			stat = fileStat{modTime: info.ModTime(), size: info.Size()}
+		}
+		stats[path] = stat
+	}
+	return stats
+}
+
+// refresh reloads the certificate and certificate authorities if any of their
+// files changed since they were last loaded. On error the previously loaded
+// values are kept and the files are read again on the next check.
+func (r *reloader) refresh() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.lastCheck) < reloadCheckInterval {
+		return
+	}
+	r.lastCheck = now
+
+	stats := statFiles(r.config)
+	if sameStats(stats, r.stats) {
+		return
+	}
+
+	cert, err := LoadCertificate(&r.config.Certificate)
+	if err != nil {
+		r.log.Errorf("Failed to reload TLS certificate, keeping the previous one: %+v", err)
+		return
+	}
+
+	cas, errs := LoadCertificateAuthorities(r.config.CAs)
+	if err := multierror.Errors(errs).Err(); err != nil {
+		r.log.Errorf("Failed to reload TLS certificate authorities, keeping the previous ones: %+v", err)
+		return
+	}
+
+	r.certificate = cert
+	r.rootCAs = cas
+	r.stats = stats
+	r.log.Info("Reloaded TLS certificate and certificate authorities after file change")
+}
+
+func sameStats(a, b map[string]fileStat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stat := range a {
+		other, ok := b[path]
+		if !ok || !stat.modTime.Equal(other.modTime) || stat.size != other.size {
+			return false
+		}
+	}
+	return true
+}
+
+// clientCertificate is used as tls.Config.GetClientCertificate, returning the
+// current certificate.
+func (r *reloader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.refresh()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.certificate == nil {
+		// No certificate is sent to the server.
+		return &tls.Certificate{}, nil
+	}
+	return r.certificate, nil
+}
+
+func (r *reloader) currentRootCAs() *x509.CertPool {
+	r.refresh()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rootCAs
+}
+
+// apply configures config to use the current certificate and certificate
+// authorities of the reloader.
+func (r *reloader) apply(config *tls.Config) {
+	if r.config.Certificate.Certificate != "" || r.config.Certificate.PKCS11 != nil {
+		config.Certificates = nil
+		config.GetClientCertificate = r.clientCertificate
+	}
+	if len(r.config.CAs) > 0 {
+		config.RootCAs = r.currentRootCAs()
+	}
+}
+
+// applyVerification makes config verify the server certificate of host
+// against the current certificate authorities. As the certificate authorities
+// can't be replaced in the tls.Config, the standard verification is disabled
+// and the server certificate is verified by VerifyPeerCertificate instead.
+// It returns false if the certificate authorities are not reloaded.
+func (r *reloader) applyVerification(config *tls.Config, c *TLSConfig, host string) bool {
+	if len(r.config.CAs) == 0 || c.Verification == VerifyNone {
+		return false
+	}
+	verifyPeer(config, c, host, r.currentRootCAs)
+	return true
+}
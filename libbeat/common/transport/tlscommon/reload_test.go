@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tlscommon
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadClientCertificate(t *testing.T) {
+	dir := tempDir(t)
+	setReloadCheckInterval(t, 0)
+
+	ca, err := genCA()
+	require.NoError(t, err)
+	first, err := genSignedCert(ca, x509.KeyUsageDigitalSignature, false)
+	require.NoError(t, err)
+	second, err := genSignedCert(ca, x509.KeyUsageDigitalSignature, false)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	writeKeyPair(t, certFile, keyFile, first, time.Now().Add(-time.Hour))
+
+	tlsCfg, err := LoadTLSConfigWithReload(&Config{
+		Certificate: CertificateConfig{Certificate: certFile, Key: keyFile},
+	})
+	require.NoError(t, err)
+
+	config := tlsCfg.ToConfig()
+	require.NotNil(t, config.GetClientCertificate)
+	assert.Empty(t, config.Certificates)
+
+	cert, err := config.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.Certificate, cert.Certificate)
+
+	writeKeyPair(t, certFile, keyFile, second, time.Now())
+	cert, err = config.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.Certificate, cert.Certificate)
+
+	// An invalid certificate keeps the previous one in use.
+	writeFile(t, certFile, []byte("not a certificate"), time.Now().Add(time.Hour))
+	cert, err = config.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.Certificate, cert.Certificate)
+}
+
+func TestReloadCertificateAuthorities(t *testing.T) {
+	dir := tempDir(t)
+	setReloadCheckInterval(t, 0)
+
+	oldCA, err := genCA()
+	require.NoError(t, err)
+	newCA, err := genCA()
+	require.NoError(t, err)
+	serverCert := genServerCert(t, newCA, "localhost")
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, encodeCertificate(oldCA), time.Now().Add(-time.Hour))
+
+	tlsCfg, err := LoadTLSConfigWithReload(&Config{CAs: []string{caFile}})
+	require.NoError(t, err)
+
+	// The same tls.Config is used for all connections, like the cached
+	// configuration of a dialer.
+	config := tlsCfg.BuildModuleConfig("localhost")
+	dial := func(config *tls.Config) error {
+		conn, err := tls.Dial("tcp", l.Addr().String(), config)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	require.Error(t, dial(config))
+
+	writeFile(t, caFile, encodeCertificate(newCA), time.Now())
+	require.NoError(t, dial(config))
+
+	t.Run("server name is verified", func(t *testing.T) {
+		require.Error(t, dial(tlsCfg.BuildModuleConfig("example.com")))
+	})
+
+	t.Run("server name is not verified in certificate mode", func(t *testing.T) {
+		tlsCfg.Verification = VerifyCertificate
+		defer func() { tlsCfg.Verification = VerifyFull }()
+		require.NoError(t, dial(tlsCfg.BuildModuleConfig("example.com")))
+	})
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tlscommon")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func setReloadCheckInterval(t *testing.T, interval time.Duration) {
+	old := reloadCheckInterval
+	reloadCheckInterval = interval
+	t.Cleanup(func() { reloadCheckInterval = old })
+}
+
+func genServerCert(t *testing.T, ca tls.Certificate, dnsName string) tls.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: serial(),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Leaf, &key.PublicKey, ca.PrivateKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func encodeCertificate(cert tls.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+}
+
+func writeKeyPair(t *testing.T, certFile, keyFile string, cert tls.Certificate, modTime time.Time) {
+	key := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(cert.PrivateKey.(*rsa.PrivateKey)),
+	})
+	writeFile(t, keyFile, key, modTime)
+	writeFile(t, certFile, encodeCertificate(cert), modTime)
+}
+
+// writeFile writes the file and sets its modification time, so changes are
+// detected independently of the file system timestamp resolution.
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
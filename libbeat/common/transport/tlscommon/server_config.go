@@ -117,7 +117,7 @@ func (c *ServerConfig) Validate() error {
 		// c.Certificate.Validate() ensures that both a certificate and key
 		// are specified, or neither are specified. For server-side TLS we
 		// require both to be specified.
-		if c.Certificate.Certificate == "" {
+		if c.Certificate.Certificate == "" && c.Certificate.PKCS11 == nil {
 			return ErrCertificateUnspecified
 		}
 	}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"github.com/elastic/beats/v7/libbeat/common/transport/pkcs11"
 	"github.com/elastic/beats/v7/libbeat/logp"
 )
 
@@ -37,6 +38,10 @@ func LoadCertificate(config *CertificateConfig) (*tls.Certificate, error) {
 		return nil, err
 	}
 
+	if config.PKCS11 != nil {
+		return loadPKCS11Certificate(config)
+	}
+
 	certificate := config.Certificate
 	key := config.Key
 	if certificate == "" {
@@ -67,6 +72,44 @@ func LoadCertificate(config *CertificateConfig) (*tls.Certificate, error) {
 	return &cert, nil
 }
 
+// loadPKCS11Certificate loads a certificate whose private key is stored in a
+// PKCS#11 token. The certificate and its chain are read from the certificate
+// file if configured, otherwise from the token.
+func loadPKCS11Certificate(config *CertificateConfig) (*tls.Certificate, error) {
+	log := logp.NewLogger(logSelector)
+
+	var chain [][]byte
+	if config.Certificate != "" {
+		certPEM, err := ReadPEMFile(log, config.Certificate, config.Passphrase)
+		if err != nil {
+			log.Errorf("Failed reading certificate file %v: %+v", config.Certificate, err)
+			return nil, fmt.Errorf("%v %v", err, config.Certificate)
+		}
+		for len(certPEM) > 0 {
+			var block *pem.Block
+			block, certPEM = pem.Decode(certPEM)
+			if block == nil {
+				break
+			}
+			if block.Type == "CERTIFICATE" {
+				chain = append(chain, block.Bytes)
+			}
+		}
+		if len(chain) == 0 {
+			return nil, fmt.Errorf("%v %v", ErrNotACertificate, config.Certificate)
+		}
+	}
+
+	cert, err := pkcs11.LoadCertificate(config.PKCS11, chain)
+	if err != nil {
+		log.Errorf("Failed loading client certificate from pkcs11 module %v: %+v", config.PKCS11.Module, err)
+		return nil, err
+	}
+
+	log.Debugf("loading certificate with key from pkcs11 module: %v", config.PKCS11.Module)
+	return cert, nil
+}
+
 // ReadPEMFile reads a PEM format file on disk and decrypt it with the privided password and
 // return the raw content.
 func ReadPEMFile(log *logp.Logger, path, passphrase string) ([]byte, error) {
@@ -20,6 +20,7 @@ package tlscommon
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -70,9 +71,32 @@ type TLSConfig struct {
 	// the server certificate.
 	CASha256 []string
 
+	// NextProtos is the list of application protocols advertised during the
+	// handshake (ALPN), in order of preference. If empty, ALPN is not used.
+	NextProtos []string
+
+	// ServerName is sent to the server via SNI instead of the host of the
+	// connection, if set. The server certificate is verified against it.
+	ServerName string
+
+	// VerificationHostname is the hostname the server certificate is verified
+	// against in full verification mode, if set. It defaults to the server name.
+	VerificationHostname string
+
+	// ClientSessionCache caches TLS sessions for resumption by clients. If
+	// nil, sessions are not resumed.
+	ClientSessionCache tls.ClientSessionCache
+
 	// time returns the current time as the number of seconds since the epoch.
 	// If time is nil, TLS uses time.Now.
 	time func() time.Time
+
+	// reloader keeps the certificate and certificate authorities up to date
+	// with their files, if set by LoadTLSConfigWithReload. Configurations
+	// created by ToConfig use the certificate authorities loaded at the time
+	// they are created, only BuildModuleConfig verifies the server against the
+	// reloaded certificate authorities.
+	reloader *reloader
 }
 
 // ToConfig generates a tls.Config object. Note, you must use BuildModuleConfig to generate a config with
@@ -93,7 +117,7 @@ func (c *TLSConfig) ToConfig() *tls.Config {
 		logp.NewLogger("tls").Warn("SSL/TLS verifications disabled.")
 	}
 
-	return &tls.Config{
+	config := &tls.Config{
 		MinVersion:            minVersion,
 		MaxVersion:            maxVersion,
 		Certificates:          c.Certificates,
@@ -105,8 +129,14 @@ func (c *TLSConfig) ToConfig() *tls.Config {
 		Renegotiation:         c.Renegotiation,
 		ClientAuth:            c.ClientAuth,
 		VerifyPeerCertificate: verifyPeerCertFn,
+		NextProtos:            c.NextProtos,
+		ClientSessionCache:    c.ClientSessionCache,
 		Time:                  c.time,
 	}
+	if c.reloader != nil {
+		c.reloader.apply(config)
+	}
+	return config
 }
 
 // BuildModuleConfig takes the TLSConfig and transform it into a `tls.Config`.
@@ -118,9 +148,50 @@ func (c *TLSConfig) BuildModuleConfig(host string) *tls.Config {
 
 	config := c.ToConfig()
 	config.ServerName = host
+	if c.ServerName != "" {
+		config.ServerName = c.ServerName
+	}
+
+	verifiedHost := config.ServerName
+	if c.VerificationHostname != "" {
+		verifiedHost = c.VerificationHostname
+	}
+	if c.reloader != nil && c.reloader.applyVerification(config, c, verifiedHost) {
+		return config
+	}
+	if verifiedHost != config.ServerName && c.Verification == VerifyFull {
+		verifyPeer(config, c, verifiedHost, func() *x509.CertPool { return c.RootCAs })
+	}
 	return config
 }
 
+// verifyPeer disables the standard verification of config, the server
+// certificate is verified by VerifyPeerCertificate against the certificate
+// authorities returned by roots and, in full verification mode, against host
+// instead of the server name sent to the server.
+func verifyPeer(config *tls.Config, c *TLSConfig, host string, roots func() *x509.CertPool) {
+	var serverName string
+	if c.Verification == VerifyFull {
+		serverName = host
+	}
+
+	config.InsecureSkipVerify = true
+	config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if c.Verification == VerifyFull && serverName == "" {
+			return errors.New("tls: server name is required to verify the server certificate")
+		}
+
+		_, chains, err := verifyCertificateChain(rawCerts, roots(), serverName, c.time)
+		if err != nil {
+			return err
+		}
+		if len(c.CASha256) > 0 {
+			return verifyCAPin(c.CASha256, chains)
+		}
+		return nil
+	}
+}
+
 // makeVerifyPeerCertificate creates the verification combination of checking certificate pins and skipping host name validation depending on the config
 func makeVerifyPeerCertificate(cfg *TLSConfig) verifyPeerCertFunc {
 	pin := len(cfg.CASha256) > 0
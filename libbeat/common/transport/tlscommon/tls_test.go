@@ -21,8 +21,11 @@ package tlscommon
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,6 +142,19 @@ func TestApplyEmptyConfig(t *testing.T) {
 	assert.Len(t, cfg.CipherSuites, 0)
 	assert.Len(t, cfg.CurvePreferences, 0)
 	assert.Equal(t, tls.RenegotiateNever, cfg.Renegotiation)
+	assert.Nil(t, cfg.ClientSessionCache)
+}
+
+func TestApplyClientSessionCache(t *testing.T) {
+	tmp, err := LoadTLSConfig(&Config{})
+	require.NoError(t, err)
+
+	cache := tls.NewLRUClientSessionCache(1)
+	tmp.ClientSessionCache = cache
+
+	// all connections share the cache to resume sessions
+	assert.Equal(t, cache, tmp.BuildModuleConfig("a").ClientSessionCache)
+	assert.Equal(t, cache, tmp.BuildModuleConfig("b").ClientSessionCache)
 }
 
 func TestApplyWithConfig(t *testing.T) {
@@ -169,6 +185,55 @@ func TestApplyWithConfig(t *testing.T) {
 	assert.Equal(t, tls.RenegotiateOnceAsClient, cfg.Renegotiation)
 }
 
+func TestApplyServerName(t *testing.T) {
+	tmp, err := LoadTLSConfig(mustLoad(t, `
+    server_name: sni.example.com
+  `))
+	require.NoError(t, err)
+
+	// the server name is sent and verified instead of the host
+	cfg := tmp.BuildModuleConfig("10.0.0.1")
+	assert.Equal(t, "sni.example.com", cfg.ServerName)
+	assert.False(t, cfg.InsecureSkipVerify)
+	assert.Nil(t, cfg.VerifyPeerCertificate)
+}
+
+func TestApplyVerificationHostname(t *testing.T) {
+	ca := x509.NewCertPool()
+	ca.AppendCertsFromPEM(loadFileBytes("ca.crt"))
+	block, _ := pem.Decode(loadFileBytes("tls.crt"))
+	rawCerts := [][]byte{block.Bytes}
+	valid := func() time.Time { return time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	tmp, err := LoadTLSConfig(mustLoad(t, `
+    server_name: sni.example.com
+    verification_hostname: elasticsearch-sample-es-http.default.svc
+  `))
+	require.NoError(t, err)
+	tmp.RootCAs = ca
+	tmp.time = valid
+
+	// the certificate is verified against the verification hostname, not
+	// the server name sent via SNI
+	cfg := tmp.BuildModuleConfig("10.0.0.1")
+	assert.Equal(t, "sni.example.com", cfg.ServerName)
+	assert.True(t, cfg.InsecureSkipVerify)
+	require.NotNil(t, cfg.VerifyPeerCertificate)
+	assert.NoError(t, cfg.VerifyPeerCertificate(rawCerts, nil))
+
+	tmp.VerificationHostname = "other.example.com"
+	cfg = tmp.BuildModuleConfig("10.0.0.1")
+	assert.Error(t, cfg.VerifyPeerCertificate(rawCerts, nil))
+}
+
+func TestVerificationHostnameRequiresFullVerification(t *testing.T) {
+	_, err := load(`
+    verification_mode: certificate
+    verification_hostname: example.com
+  `)
+	assert.Error(t, err)
+}
+
 func TestServerConfigDefaults(t *testing.T) {
 	t.Run("when CA is not explicitly set", func(t *testing.T) {
 		var c ServerConfig
@@ -299,6 +364,14 @@ func TestCertificateFails(t *testing.T) {
 			"unknown renegotiation type",
 			"renegotiation: always",
 		},
+		{
+			"key file and pkcs11",
+			"{certificate: mycert.pem, key: mycert.key, pkcs11: {module: /usr/lib/softhsm/libsofthsm2.so, key_label: client}}",
+		},
+		{
+			"pkcs11 without module",
+			"pkcs11.key_label: client",
+		},
 	}
 
 	for i, test := range tests {
@@ -21,6 +21,8 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/transport/pkcs11"
 )
 
 var (
@@ -33,6 +35,10 @@ var (
 
 	// ErrKeyNoCertificate indicate a configuration error with missing certificate file
 	ErrCertificateUnspecified = errors.New("certificate file not configured")
+
+	// ErrKeyAndPKCS11 indicates a configuration error with the key configured
+	// both as a file and in a PKCS#11 token
+	ErrKeyAndPKCS11 = errors.New("key file and pkcs11 can not be configured together")
 )
 
 var tlsCipherSuites = map[string]tlsCipherSuite{
@@ -245,9 +251,10 @@ func (r tlsRenegotiationSupport) MarshalText() ([]byte, error) {
 
 // CertificateConfig define a common set of fields for a certificate.
 type CertificateConfig struct {
-	Certificate string `config:"certificate" yaml:"certificate,omitempty"`
-	Key         string `config:"key" yaml:"key,omitempty"`
-	Passphrase  string `config:"key_passphrase" yaml:"key_passphrase,omitempty"`
+	Certificate string         `config:"certificate" yaml:"certificate,omitempty"`
+	Key         string         `config:"key" yaml:"key,omitempty"`
+	Passphrase  string         `config:"key_passphrase" yaml:"key_passphrase,omitempty"`
+	PKCS11      *pkcs11.Config `config:"pkcs11" yaml:"pkcs11,omitempty"`
 }
 
 // Validate validates the CertificateConfig
@@ -255,6 +262,15 @@ func (c *CertificateConfig) Validate() error {
 	hasCertificate := c.Certificate != ""
 	hasKey := c.Key != ""
 
+	// With PKCS#11 the key is kept in the token, and the certificate can be
+	// read from the token too.
+	if c.PKCS11 != nil {
+		if hasKey {
+			return ErrKeyAndPKCS11
+		}
+		return nil
+	}
+
 	switch {
 	case hasCertificate && !hasKey:
 		return ErrKeyUnspecified
@@ -61,6 +61,17 @@ import (
 func verifyCertificateExceptServerName(
 	rawCerts [][]byte,
 	c *TLSConfig,
+) ([]*x509.Certificate, [][]*x509.Certificate, error) {
+	return verifyCertificateChain(rawCerts, c.RootCAs, "", c.time)
+}
+
+// verifyCertificateChain verifies that the provided certificate chain is valid and is signed by one of roots.
+// The certificate is only verified to match serverName if serverName is not empty.
+func verifyCertificateChain(
+	rawCerts [][]byte,
+	roots *x509.CertPool,
+	serverName string,
+	now func() time.Time,
 ) ([]*x509.Certificate, [][]*x509.Certificate, error) {
 	// this is where we're a bit suboptimal, as we have to re-parse the certificates that have been presented
 	// during the handshake.
@@ -73,17 +84,21 @@ func verifyCertificateExceptServerName(
 		}
 		certs[i] = cert
 	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("tls: no certificate presented by the server")
+	}
 
 	var t time.Time
-	if c.time != nil {
-		t = c.time()
+	if now != nil {
+		t = now()
 	} else {
 		t = time.Now()
 	}
 
-	// DNSName omitted in VerifyOptions in order to skip ServerName verification
+	// An empty DNSName in VerifyOptions skips ServerName verification
 	opts := x509.VerifyOptions{
-		Roots:         c.RootCAs,
+		DNSName:       serverName,
+		Roots:         roots,
 		CurrentTime:   t,
 		Intermediates: x509.NewCertPool(),
 	}
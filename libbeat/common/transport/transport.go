@@ -48,7 +48,7 @@ func Dial(c Config, network, address string) (net.Conn, error) {
 
 func MakeDialer(c Config) (Dialer, error) {
 	var err error
-	dialer := NetDialer(c.Timeout)
+	dialer := FamilyNetDialer(c.Timeout, c.Network)
 	dialer, err = ProxyDialer(logp.NewLogger(logSelector), c.Proxy, dialer)
 	if err != nil {
 		return nil, err
@@ -39,7 +39,7 @@ func MakeURL(defaultScheme string, defaultPath string, rawURL string, defaultPor
 		rawURL = fmt.Sprintf("%v://%v", defaultScheme, rawURL)
 	}
 
-	addr, err := url.Parse(rawURL)
+	addr, err := url.Parse(escapeIPv6Host(rawURL))
 	if err != nil {
 		return "", err
 	}
@@ -76,6 +76,49 @@ func MakeURL(defaultScheme string, defaultPath string, rawURL string, defaultPor
 	return addr.String(), nil
 }
 
+// escapeIPv6Host brackets a bare IPv6 address in the host part of rawURL and
+// escapes the zone identifier delimiter (e.g. `fe80::1%eth0`), such that the
+// URL can be parsed by url.Parse.
+func escapeIPv6Host(rawURL string) string {
+	scheme := hasScheme.FindString(rawURL)
+	rest := rawURL[len(scheme):]
+
+	hostEnd := strings.IndexAny(rest, "/?#")
+	if hostEnd < 0 {
+		hostEnd = len(rest)
+	}
+	host, rest := rest[:hostEnd], rest[hostEnd:]
+
+	userinfo := ""
+	if idx := strings.LastIndex(host, "@"); idx >= 0 {
+		userinfo, host = host[:idx+1], host[idx+1:]
+	}
+
+	if strings.HasPrefix(host, "[") {
+		if end := strings.Index(host, "]"); end > 0 {
+			host = "[" + escapeIPv6Zone(host[1:end]) + host[end:]
+		}
+	} else if strings.Contains(host, ":") {
+		ip := host
+		if idx := strings.IndexByte(ip, '%'); idx >= 0 {
+			ip = ip[:idx]
+		}
+		if net.ParseIP(ip) != nil {
+			host = "[" + escapeIPv6Zone(host) + "]"
+		}
+	}
+
+	return scheme + userinfo + host + rest
+}
+
+func escapeIPv6Zone(host string) string {
+	idx := strings.IndexByte(host, '%')
+	if idx < 0 || strings.HasPrefix(host[idx:], "%25") {
+		return host
+	}
+	return host[:idx] + "%25" + host[idx+1:]
+}
+
 func EncodeURLParams(url string, params url.Values) string {
 	if len(params) == 0 {
 		return url
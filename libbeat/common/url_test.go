@@ -77,6 +77,14 @@ func TestGetUrl(t *testing.T) {
 		"2001:db8::1/hello":      "http://[2001:db8::1]:9200/hello",
 		"[2001:db8::1]:80/hello": "http://[2001:db8::1]:80/hello",
 		"[2001:db8::1]/hello":    "http://[2001:db8::1]:9200/hello",
+
+		// ipv6 + zone
+		"fe80::1%eth0":                    "http://[fe80::1%25eth0]:9200",
+		"fe80::1%eth0/hello":              "http://[fe80::1%25eth0]:9200/hello",
+		"[fe80::1%eth0]:80":               "http://[fe80::1%25eth0]:80",
+		"[fe80::1%25eth0]:80":             "http://[fe80::1%25eth0]:80",
+		"https://[fe80::1%eth0]:9200":     "https://[fe80::1%25eth0]:9200",
+		"http://user:pass@[fe80::1%eth0]": "http://user:pass@[fe80::1%25eth0]:9200",
 	}
 
 	for input, output := range inputOutput {
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
@@ -36,8 +37,9 @@ type config struct {
 	TLS      *tlscommon.Config `config:"ssl"`
 	Kerberos *kerberos.Config  `config:"kerberos"`
 
-	ProxyURL     string `config:"proxy_url"`
-	ProxyDisable bool   `config:"proxy_disable"`
+	ProxyURL     string             `config:"proxy_url"`
+	ProxyDisable bool               `config:"proxy_disable"`
+	Network      transport.IPFamily `config:"network"`
 
 	Username string `config:"username"`
 	Password string `config:"password"`
@@ -46,6 +48,11 @@ type config struct {
 	CompressionLevel int           `config:"compression_level" validate:"min=0, max=9"`
 	EscapeHTML       bool          `config:"escape_html"`
 	Timeout          time.Duration `config:"timeout"`
+
+	HTTP2              bool          `config:"http2"`
+	IdleConnTimeout    time.Duration `config:"idle_connection_timeout" validate:"min=0"`
+	ConnMaxLifetime    time.Duration `config:"connection_max_lifetime" validate:"min=0"`
+	DNSRefreshInterval time.Duration `config:"dns_refresh_interval" validate:"min=0"`
 }
 
 func defaultConfig() config {
@@ -76,5 +83,9 @@ func (c *config) Validate() error {
 		return fmt.Errorf("cannot set both api_key and username/password")
 	}
 
+	if c.Kerberos.IsEnabled() && c.Kerberos.UsesCCache() {
+		return fmt.Errorf("kerberos auth_type ccache is not supported by Elasticsearch connections")
+	}
+
 	return nil
 }
@@ -28,8 +28,10 @@ import (
 	"time"
 
 	"go.elastic.co/apm/module/apmelasticsearch"
+	"golang.org/x/net/http2"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/clockskew"
 	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
@@ -51,6 +53,7 @@ type Connection struct {
 
 	apiKeyAuthHeader string // Authorization HTTP request header with base64-encoded API key
 	version          common.Version
+	rotation         *connRotation
 	log              *logp.Logger
 }
 
@@ -59,6 +62,7 @@ type ConnectionSettings struct {
 	URL          string
 	Proxy        *url.URL
 	ProxyDisable bool
+	Network      transport.IPFamily
 
 	Username string
 	Password string
@@ -71,12 +75,27 @@ type ConnectionSettings struct {
 	OnConnectCallback func() error
 	Observer          transport.IOStatser
 
+	// ClockSkew, if set, is updated with the Date header of every response.
+	ClockSkew *clockskew.Detector
+
 	Parameters       map[string]string
 	CompressionLevel int
 	EscapeHTML       bool
 
 	Timeout         time.Duration
 	IdleConnTimeout time.Duration
+
+	// HTTP2 enables HTTP/2 on TLS connections if the server negotiates it
+	// during the handshake, HTTP/1.1 is used otherwise.
+	HTTP2 bool
+
+	// ConnMaxLifetime, if set, is how long connections are reused before
+	// the idle ones are closed and dialed again.
+	ConnMaxLifetime time.Duration
+
+	// DNSRefreshInterval, if set, is how often the host name is resolved
+	// again. The idle connections are closed if the addresses changed.
+	DNSRefreshInterval time.Duration
 }
 
 // NewConnection returns a new Elasticsearch client
@@ -101,13 +120,24 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 	// TODO: add socks5 proxy support
 	var dialer, tlsDialer transport.Dialer
 
-	dialer = transport.NetDialer(s.Timeout)
-	tlsDialer, err = transport.TLSDialer(dialer, s.TLS, s.Timeout)
+	tlsConfig := s.TLS
+	if s.HTTP2 {
+		tlsConfig = http2TLSConfig(s.TLS)
+	}
+
+	dialer = transport.FamilyNetDialer(s.Timeout, s.Network)
+	if st := s.Observer; st != nil && s.HTTP2 {
+		// net/http negotiates HTTP/2 only on connections dialed as *tls.Conn,
+		// so the statistics are collected below the TLS layer.
+		dialer = transport.StatsDialer(dialer, st)
+	}
+
+	tlsDialer, err = transport.TLSDialer(dialer, tlsConfig, s.Timeout)
 	if err != nil {
 		return nil, err
 	}
 
-	if st := s.Observer; st != nil {
+	if st := s.Observer; st != nil && !s.HTTP2 {
 		dialer = transport.StatsDialer(dialer, st)
 		tlsDialer = transport.StatsDialer(tlsDialer, st)
 	}
@@ -133,13 +163,19 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 
 	// when dropping the legacy client in favour of the official Go client, it should be instrumented
 	// eg, like in https://github.com/elastic/apm-server/blob/7.7/elasticsearch/client.go
-	transp := apmelasticsearch.WrapRoundTripper(&http.Transport{
+	httpTransport := &http.Transport{
 		Dial:            dialer.Dial,
 		DialTLS:         tlsDialer.Dial,
-		TLSClientConfig: s.TLS.ToConfig(),
+		TLSClientConfig: tlsConfig.ToConfig(),
 		Proxy:           proxy,
 		IdleConnTimeout: s.IdleConnTimeout,
-	})
+	}
+	if s.HTTP2 {
+		if err := http2.ConfigureTransport(httpTransport); err != nil {
+			return nil, fmt.Errorf("failed to enable HTTP/2: %v", err)
+		}
+	}
+	transp := apmelasticsearch.WrapRoundTripper(httpTransport)
 
 	var httpClient esHTTPClient
 	httpClient = &http.Client{
@@ -163,6 +199,7 @@ func NewConnection(s ConnectionSettings) (*Connection, error) {
 		ConnectionSettings: s,
 		HTTP:               httpClient,
 		Encoder:            encoder,
+		rotation:           newConnRotation(u.Hostname(), s.ConnMaxLifetime, s.DNSRefreshInterval),
 		log:                logp.NewLogger("esclientleg"),
 	}
 
@@ -182,6 +219,17 @@ func settingsWithDefaults(s ConnectionSettings) ConnectionSettings {
 	return settings
 }
 
+// http2TLSConfig returns a copy of config that advertises HTTP/2 during the
+// handshake, with HTTP/1.1 as fallback.
+func http2TLSConfig(config *tlscommon.TLSConfig) *tlscommon.TLSConfig {
+	var c tlscommon.TLSConfig
+	if config != nil {
+		c = *config
+	}
+	c.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+	return &c
+}
+
 // NewClients returns a list of Elasticsearch clients based on the given
 // configuration. It accepts the same configuration parameters as the Elasticsearch
 // output, except for the output specific configuration options.  If multiple hosts
@@ -222,18 +270,23 @@ func NewClients(cfg *common.Config) ([]Connection, error) {
 		}
 
 		client, err := NewConnection(ConnectionSettings{
-			URL:              esURL,
-			Proxy:            proxyURL,
-			ProxyDisable:     config.ProxyDisable,
-			TLS:              tlsConfig,
-			Kerberos:         config.Kerberos,
-			Username:         config.Username,
-			Password:         config.Password,
-			APIKey:           config.APIKey,
-			Parameters:       params,
-			Headers:          config.Headers,
-			Timeout:          config.Timeout,
-			CompressionLevel: config.CompressionLevel,
+			URL:                esURL,
+			Proxy:              proxyURL,
+			ProxyDisable:       config.ProxyDisable,
+			Network:            config.Network,
+			TLS:                tlsConfig,
+			Kerberos:           config.Kerberos,
+			Username:           config.Username,
+			Password:           config.Password,
+			APIKey:             config.APIKey,
+			Parameters:         params,
+			Headers:            config.Headers,
+			Timeout:            config.Timeout,
+			CompressionLevel:   config.CompressionLevel,
+			HTTP2:              config.HTTP2,
+			IdleConnTimeout:    config.IdleConnTimeout,
+			ConnMaxLifetime:    config.ConnMaxLifetime,
+			DNSRefreshInterval: config.DNSRefreshInterval,
 		})
 		if err != nil {
 			return clients, err
@@ -329,7 +382,7 @@ func (conn *Connection) Test(d testing.Driver) {
 		address := u.Host
 
 		d.Run("connection", func(d testing.Driver) {
-			netDialer := transport.TestNetDialer(d, conn.Timeout)
+			netDialer := transport.TestFamilyNetDialer(d, conn.Timeout, conn.Network)
 			_, err = netDialer.Dial("tcp", address)
 			d.Fatal("dial up", err)
 		})
@@ -338,7 +391,7 @@ func (conn *Connection) Test(d testing.Driver) {
 			d.Warn("TLS", "secure connection disabled")
 		} else {
 			d.Run("TLS", func(d testing.Driver) {
-				netDialer := transport.NetDialer(conn.Timeout)
+				netDialer := transport.FamilyNetDialer(conn.Timeout, conn.Network)
 				tlsDialer, err := transport.TestTLSDialer(d, netDialer, conn.TLS, conn.Timeout)
 				_, err = tlsDialer.Dial("tcp", address)
 				d.Fatal("dial up", err)
@@ -459,11 +512,18 @@ func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error)
 		req.Host = host
 	}
 
+	if conn.rotation.expired() {
+		conn.log.Debugf("Closing idle connections to %v to dial them again", conn.URL)
+		conn.HTTP.CloseIdleConnections()
+	}
+
+	sent := time.Now()
 	resp, err := conn.HTTP.Do(req)
 	if err != nil {
 		return 0, nil, err
 	}
 	defer closing(resp.Body, conn.log)
+	conn.ClockSkew.ObserveResponse(sent, time.Now(), resp)
 
 	status := resp.StatusCode
 	obj, err := ioutil.ReadAll(resp.Body)
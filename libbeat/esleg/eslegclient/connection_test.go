@@ -21,10 +21,15 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/base64"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
 
 func TestAPIKeyEncoding(t *testing.T) {
@@ -48,8 +53,62 @@ func TestAPIKeyEncoding(t *testing.T) {
 	require.Equal(t, "ApiKey "+encoded, httpClient.Req.Header.Get("Authorization"))
 }
 
+func TestHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Proto)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	for _, enabled := range []bool{false, true} {
+		conn, err := NewConnection(ConnectionSettings{
+			URL:   server.URL,
+			TLS:   &tlscommon.TLSConfig{Verification: tlscommon.VerifyNone},
+			HTTP2: enabled,
+		})
+		require.NoError(t, err)
+
+		status, body, err := conn.execRequest("GET", server.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+		if enabled {
+			require.Equal(t, "HTTP/2.0", string(body))
+		} else {
+			require.Equal(t, "HTTP/1.1", string(body))
+		}
+		conn.Close()
+	}
+}
+
+func TestConnMaxLifetime(t *testing.T) {
+	conn, err := NewConnection(ConnectionSettings{
+		URL:             "http://fakehost",
+		ConnMaxLifetime: time.Minute,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	conn.rotation.now = func() time.Time { return now }
+	conn.rotation.rotated = now
+
+	httpClient := newMockClient()
+	conn.HTTP = httpClient
+
+	_, _, err = conn.execRequest("GET", "http://fakehost/", nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, httpClient.idleClosed)
+
+	now = now.Add(time.Minute)
+	_, _, err = conn.execRequest("GET", "http://fakehost/", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, httpClient.idleClosed)
+}
+
 type mockClient struct {
 	Req *http.Request
+
+	idleClosed int
 }
 
 func (c *mockClient) Do(req *http.Request) (*http.Response, error) {
@@ -59,7 +118,9 @@ func (c *mockClient) Do(req *http.Request) (*http.Response, error) {
 	return http.ReadResponse(bufio.NewReader(r), req)
 }
 
-func (c *mockClient) CloseIdleConnections() {}
+func (c *mockClient) CloseIdleConnections() {
+	c.idleClosed++
+}
 
 func newMockClient() *mockClient {
 	return &mockClient{}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// connRotation decides when the idle connections of a Connection are closed,
+// so that the next requests dial again and are balanced across the nodes
+// behind a host name instead of staying pinned to the node they first
+// connected to.
+type connRotation struct {
+	host        string
+	maxLifetime time.Duration
+	dnsInterval time.Duration
+
+	lookupHost func(host string) ([]string, error)
+	now        func() time.Time
+
+	mu       sync.Mutex
+	rotated  time.Time
+	resolved time.Time
+	addrs    []string
+}
+
+// newConnRotation returns nil if connections are never rotated. The DNS
+// refresh interval is ignored for IP addresses.
+func newConnRotation(host string, maxLifetime, dnsInterval time.Duration) *connRotation {
+	if net.ParseIP(host) != nil {
+		dnsInterval = 0
+	}
+	if maxLifetime <= 0 && dnsInterval <= 0 {
+		return nil
+	}
+
+	return &connRotation{
+		host:        host,
+		maxLifetime: maxLifetime,
+		dnsInterval: dnsInterval,
+		lookupHost:  net.LookupHost,
+		now:         time.Now,
+		rotated:     time.Now(),
+	}
+}
+
+// expired reports whether the idle connections must be closed before the
+// next request, because the maximum lifetime has passed since they were last
+// closed or because the host resolves to different addresses.
+func (r *connRotation) expired() bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	expired := r.maxLifetime > 0 && now.Sub(r.rotated) >= r.maxLifetime
+	if r.dnsInterval > 0 && now.Sub(r.resolved) >= r.dnsInterval {
+		r.resolved = now
+		if r.addressesChanged() {
+			expired = true
+		}
+	}
+
+	if expired {
+		r.rotated = now
+	}
+	return expired
+}
+
+func (r *connRotation) addressesChanged() bool {
+	addrs, err := r.lookupHost(r.host)
+	if err != nil || len(addrs) == 0 {
+		// Keep the current connections, dialing again is likely to fail too.
+		return false
+	}
+
+	sort.Strings(addrs)
+	changed := r.addrs != nil && !reflect.DeepEqual(r.addrs, addrs)
+	r.addrs = addrs
+	return changed
+}
@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eslegclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnRotation(t *testing.T) {
+	assert.Nil(t, newConnRotation("es.example.com", 0, 0))
+	assert.Nil(t, newConnRotation("10.0.0.1", 0, time.Minute), "IP addresses are not resolved")
+	assert.NotNil(t, newConnRotation("10.0.0.1", time.Minute, 0))
+	assert.NotNil(t, newConnRotation("es.example.com", 0, time.Minute))
+
+	var r *connRotation
+	assert.False(t, r.expired())
+}
+
+func TestConnRotationMaxLifetime(t *testing.T) {
+	now := time.Now()
+	r := newConnRotation("es.example.com", 10*time.Minute, 0)
+	r.now = func() time.Time { return now }
+	r.rotated = now
+
+	now = now.Add(9 * time.Minute)
+	assert.False(t, r.expired())
+
+	now = now.Add(time.Minute)
+	assert.True(t, r.expired())
+
+	now = now.Add(time.Minute)
+	assert.False(t, r.expired(), "the lifetime starts again after a rotation")
+}
+
+func TestConnRotationDNSRefresh(t *testing.T) {
+	now := time.Now()
+	addrs := []string{"10.0.0.2", "10.0.0.1"}
+	var lookupErr error
+	lookups := 0
+
+	r := newConnRotation("es.example.com", 0, time.Minute)
+	r.now = func() time.Time { return now }
+	r.lookupHost = func(host string) ([]string, error) {
+		assert.Equal(t, "es.example.com", host)
+		lookups++
+		return append([]string(nil), addrs...), lookupErr
+	}
+
+	assert.False(t, r.expired(), "the first lookup records the addresses")
+	assert.Equal(t, 1, lookups)
+
+	now = now.Add(30 * time.Second)
+	assert.False(t, r.expired())
+	assert.Equal(t, 1, lookups, "the host is resolved once per interval")
+
+	now = now.Add(30 * time.Second)
+	addrs = []string{"10.0.0.1", "10.0.0.2"}
+	assert.False(t, r.expired(), "the order of the addresses is ignored")
+
+	now = now.Add(time.Minute)
+	lookupErr = errors.New("no such host")
+	addrs = nil
+	assert.False(t, r.expired(), "connections are kept if the lookup fails")
+
+	now = now.Add(time.Minute)
+	lookupErr = nil
+	addrs = []string{"10.0.0.3"}
+	assert.True(t, r.expired())
+	assert.Equal(t, 4, lookups)
+}
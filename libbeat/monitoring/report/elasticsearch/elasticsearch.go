@@ -320,6 +320,7 @@ func makeClient(
 	esClient, err := eslegclient.NewConnection(eslegclient.ConnectionSettings{
 		URL:              url,
 		Proxy:            proxyURL,
+		Network:          config.Network,
 		TLS:              tlsConfig,
 		Username:         config.Username,
 		Password:         config.Password,
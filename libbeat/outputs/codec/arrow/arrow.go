@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package arrow provides a codec encoding batches of events as Arrow IPC
+// streams, holding a single record batch with one column per field.
+package arrow
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+)
+
+// Encoder for serializing batches of events to Arrow IPC streams.
+type Encoder struct {
+	converter *columnar.Converter
+	mem       memory.Allocator
+}
+
+// Config is used to pass encoding parameters to New.
+type Config struct {
+	columnar.Config `config:",inline"`
+}
+
+var defaultConfig = Config{
+	Config: columnar.DefaultConfig,
+}
+
+var errNoColumns = errors.New("no columns to encode, the events have no included fields")
+
+func init() {
+	codec.RegisterType("arrow", func(info beat.Info, cfg *common.Config) (codec.Codec, error) {
+		config := defaultConfig
+		if cfg != nil {
+			if err := cfg.Unpack(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		return New(info, config)
+	})
+}
+
+// New creates a new Arrow Encoder.
+func New(info beat.Info, config Config) (*Encoder, error) {
+	converter, err := columnar.NewConverter(info, config.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		converter: converter,
+		mem:       memory.NewGoAllocator(),
+	}, nil
+}
+
+// Encode serializes a beat event to an Arrow IPC stream holding a single row.
+func (e *Encoder) Encode(index string, event *beat.Event) ([]byte, error) {
+	return e.EncodeBatch(index, []*beat.Event{event})
+}
+
+// EncodeBatch serializes the events to an Arrow IPC stream holding a single
+// record batch.
+func (e *Encoder) EncodeBatch(_ string, events []*beat.Event) ([]byte, error) {
+	schema := e.converter.Schema(events)
+	if len(schema.Columns) == 0 {
+		return nil, errNoColumns
+	}
+
+	fields := make([]arrow.Field, len(schema.Columns))
+	for i, col := range schema.Columns {
+		fields[i] = toField(col)
+	}
+	arrowSchema := arrow.NewSchema(fields, nil)
+
+	b := array.NewRecordBuilder(e.mem, arrowSchema)
+	defer b.Release()
+	for _, event := range events {
+		row := schema.Row(event)
+		for i, col := range schema.Columns {
+			value, found := row[col.Name]
+			appendValue(b.Field(i), col, value, found)
+		}
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(arrowSchema), ipc.WithAllocator(e.mem))
+	if err := w.Write(rec); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Extension returns the extension of Arrow IPC streams.
+func (e *Encoder) Extension() string {
+	return ".arrows"
+}
+
+// toField returns the Arrow field of a column. Groups are structs, and
+// repeated columns lists.
+func toField(col *columnar.Column) arrow.Field {
+	var typ arrow.DataType
+	switch {
+	case col.Type == columnar.TypeGroup:
+		fields := make([]arrow.Field, len(col.Columns))
+		for i, nested := range col.Columns {
+			fields[i] = toField(nested)
+		}
+		typ = arrow.StructOf(fields...)
+	case col.Repeated:
+		typ = arrow.ListOf(dataType(col.Type))
+	default:
+		typ = dataType(col.Type)
+	}
+	return arrow.Field{Name: col.Name, Type: typ, Nullable: true}
+}
+
+func dataType(typ columnar.Type) arrow.DataType {
+	switch typ {
+	case columnar.TypeLong:
+		return arrow.PrimitiveTypes.Int64
+	case columnar.TypeDouble:
+		return arrow.PrimitiveTypes.Float64
+	case columnar.TypeBoolean:
+		return arrow.FixedWidthTypes.Boolean
+	case columnar.TypeTimestamp:
+		return arrow.FixedWidthTypes.Timestamp_us
+	}
+	return arrow.BinaryTypes.String
+}
+
+func appendValue(b array.Builder, col *columnar.Column, value interface{}, found bool) {
+	if !found {
+		appendNull(b, col)
+		return
+	}
+
+	switch {
+	case col.Type == columnar.TypeGroup:
+		sb := b.(*array.StructBuilder)
+		sb.Append(true)
+		row := value.(columnar.Row)
+		for i, nested := range col.Columns {
+			v, ok := row[nested.Name]
+			appendValue(sb.FieldBuilder(i), nested, v, ok)
+		}
+	case col.Repeated:
+		lb := b.(*array.ListBuilder)
+		lb.Append(true)
+		for _, elem := range value.([]interface{}) {
+			appendScalar(lb.ValueBuilder(), col.Type, elem)
+		}
+	default:
+		appendScalar(b, col.Type, value)
+	}
+}
+
+// appendNull appends a null value, null structs also need a value in each of
+// their fields.
+func appendNull(b array.Builder, col *columnar.Column) {
+	b.AppendNull()
+	if col.Type != columnar.TypeGroup {
+		return
+	}
+	sb := b.(*array.StructBuilder)
+	for i, nested := range col.Columns {
+		appendNull(sb.FieldBuilder(i), nested)
+	}
+}
+
+func appendScalar(b array.Builder, typ columnar.Type, value interface{}) {
+	switch typ {
+	case columnar.TypeLong:
+		b.(*array.Int64Builder).Append(value.(int64))
+	case columnar.TypeDouble:
+		b.(*array.Float64Builder).Append(value.(float64))
+	case columnar.TypeBoolean:
+		b.(*array.BooleanBuilder).Append(value.(bool))
+	case columnar.TypeTimestamp:
+		micros := value.(time.Time).UnixNano() / int64(time.Microsecond)
+		b.(*array.TimestampBuilder).Append(arrow.Timestamp(micros))
+	default:
+		b.(*array.StringBuilder).Append(value.(string))
+	}
+}
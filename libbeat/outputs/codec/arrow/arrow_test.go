@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestEncodeBatch(t *testing.T) {
+	enc, err := New(beat.Info{Beat: "filebeat", Version: "7.10.0"}, defaultConfig)
+	require.NoError(t, err)
+
+	ts := time.Unix(1586960586, 0)
+	events := []*beat.Event{
+		{Timestamp: ts, Fields: common.MapStr{"message": "hello", "http": common.MapStr{"status": 200}}},
+		{Timestamp: ts, Fields: common.MapStr{"message": "world", "tags": []string{"a", "b"}}},
+	}
+	data, err := enc.EncodeBatch("test", events)
+	require.NoError(t, err)
+
+	r, err := ipc.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	defer r.Release()
+
+	var names []string
+	for _, field := range r.Schema().Fields() {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"@timestamp", "http", "message", "tags"}, names)
+
+	require.True(t, r.Next())
+	rec := r.Record()
+	require.EqualValues(t, 2, rec.NumRows())
+
+	timestamps := rec.Column(0).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(1586960586000000), timestamps.Value(1))
+
+	http := rec.Column(1).(*array.Struct)
+	assert.True(t, http.IsValid(0))
+	assert.True(t, http.IsNull(1))
+	status := http.Field(0).(*array.Int64)
+	assert.Equal(t, 2, status.Len())
+	assert.Equal(t, int64(200), status.Value(0))
+	assert.True(t, status.IsNull(1))
+
+	messages := rec.Column(2).(*array.String)
+	assert.Equal(t, "hello", messages.Value(0))
+	assert.Equal(t, "world", messages.Value(1))
+
+	tags := rec.Column(3).(*array.List)
+	assert.True(t, tags.IsNull(0))
+	assert.Equal(t, []int32{0, 0, 2}, tags.Offsets())
+	values := tags.ListValues().(*array.String)
+	assert.Equal(t, "a", values.Value(0))
+	assert.Equal(t, "b", values.Value(1))
+
+	assert.False(t, r.Next())
+}
+
+func TestEncodeNoColumns(t *testing.T) {
+	config := defaultConfig
+	config.IncludeFields = []string{"missing"}
+	enc, err := New(beat.Info{Beat: "filebeat"}, config)
+	require.NoError(t, err)
+
+	_, err = enc.Encode("test", &beat.Event{Fields: common.MapStr{"message": "hello"}})
+	assert.Equal(t, errNoColumns, err)
+	assert.Equal(t, ".arrows", enc.Extension())
+}
@@ -22,3 +22,15 @@ import "github.com/elastic/beats/v7/libbeat/beat"
 type Codec interface {
 	Encode(index string, event *beat.Event) ([]byte, error)
 }
+
+// BatchCodec is implemented by codecs encoding batches of events into a single
+// document, like the columnar formats. Encode returns a document holding a
+// single event.
+type BatchCodec interface {
+	Codec
+	EncodeBatch(index string, events []*beat.Event) ([]byte, error)
+
+	// Extension returns the file name extension of the documents, including
+	// the leading dot.
+	Extension() string
+}
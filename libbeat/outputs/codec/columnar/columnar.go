@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package columnar provides the schemas of the columnar codecs. The schema is
+// either derived from the fields.yml of the Beat, or inferred from the events
+// of each batch. Events are converted into rows following the schema.
+package columnar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/asset"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+)
+
+// Config selects the schema of the columnar codecs.
+type Config struct {
+	// Schema is inferred from the events of each batch, or derived from the
+	// fields.yml of the Beat.
+	Schema string `config:"schema"`
+
+	// IncludeFields restricts the columns to these fields and their children.
+	IncludeFields []string `config:"include_fields"`
+}
+
+const (
+	SchemaInferred = "inferred"
+	SchemaFields   = "fields"
+)
+
+// DefaultConfig infers the schema from the events.
+var DefaultConfig = Config{
+	Schema: SchemaInferred,
+}
+
+func (c *Config) Validate() error {
+	switch c.Schema {
+	case SchemaInferred, SchemaFields:
+	default:
+		return fmt.Errorf("unsupported schema '%v', use %v or %v", c.Schema, SchemaInferred, SchemaFields)
+	}
+	if c.Schema == SchemaFields && len(c.IncludeFields) == 0 {
+		return fmt.Errorf("include_fields is required with the %v schema", SchemaFields)
+	}
+	return nil
+}
+
+// Converter provides the schema of batches of events.
+type Converter struct {
+	fixed   *Schema
+	include []string
+}
+
+// NewConverter returns a converter for the configured schema. The fields
+// schema is loaded from the fields.yml assets of the Beat.
+func NewConverter(info beat.Info, config Config) (*Converter, error) {
+	c := &Converter{include: config.IncludeFields}
+	if config.Schema != SchemaFields {
+		return c, nil
+	}
+
+	data, err := asset.GetFields(info.Beat)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := mapping.LoadFields(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the fields of %v: %v", info.Beat, err)
+	}
+	return newFieldsConverter(fields, config.IncludeFields), nil
+}
+
+func newFieldsConverter(fields mapping.Fields, include []string) *Converter {
+	return &Converter{
+		fixed:   FromFields(fields).filter(include),
+		include: include,
+	}
+}
+
+// Schema returns the schema of the batch, either the schema of the fields, or
+// the schema inferred from the events.
+func (c *Converter) Schema(events []*beat.Event) *Schema {
+	if c.fixed != nil {
+		return c.fixed
+	}
+	return Infer(events).filter(c.include)
+}
+
+// included returns whether the field is one of the included fields or one of
+// their children.
+func included(path string, include []string) bool {
+	if len(include) == 0 {
+		return true
+	}
+	for _, inc := range include {
+		if path == inc || strings.HasPrefix(path, inc+".") {
+			return true
+		}
+	}
+	return false
+}
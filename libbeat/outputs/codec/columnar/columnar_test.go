@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package columnar
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+)
+
+func TestInfer(t *testing.T) {
+	ts := time.Unix(1586960586, 0)
+	events := []*beat.Event{
+		{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"message":  "hello",
+				"http":     common.MapStr{"status": 200, "duration": 1.5, "empty": common.MapStr{}},
+				"tags":     []string{"a", "b"},
+				"mixed":    1,
+				"conflict": common.MapStr{"nested": true},
+			},
+		},
+		{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"http":     common.MapStr{"status": 404.5, "duration": 2},
+				"ip":       net.ParseIP("10.0.0.1"),
+				"mixed":    "one",
+				"conflict": "flat",
+				"ports":    []interface{}{80, 443},
+				"nothing":  nil,
+			},
+		},
+	}
+
+	schema := Infer(events)
+	assert.Equal(t, &Schema{Columns: []*Column{
+		{Name: "@timestamp", Type: TypeTimestamp},
+		{Name: "conflict", Type: TypeString},
+		{Name: "http", Type: TypeGroup, Columns: []*Column{
+			{Name: "duration", Type: TypeDouble},
+			{Name: "status", Type: TypeDouble},
+		}},
+		{Name: "ip", Type: TypeString},
+		{Name: "message", Type: TypeString},
+		{Name: "mixed", Type: TypeString},
+		{Name: "ports", Type: TypeLong, Repeated: true},
+		{Name: "tags", Type: TypeString, Repeated: true},
+	}}, schema)
+}
+
+func TestFromFields(t *testing.T) {
+	fields := mapping.Fields{
+		{Name: "@timestamp", Type: "date"},
+		{Name: "http", Type: "group", Fields: mapping.Fields{
+			{Name: "response.status_code", Type: "long"},
+			{Name: "request.method", Type: "keyword"},
+		}},
+		{Name: "event.duration", Type: "long"},
+		{Name: "event.ratio", Type: "scaled_float"},
+		{Name: "event.alias", Type: "alias"},
+		{Name: "labels", Type: "object", ObjectType: "keyword"},
+		{Name: "source.ip", Type: "ip"},
+		{Name: "tls.established", Type: "boolean"},
+	}
+
+	schema := FromFields(fields)
+	assert.Equal(t, &Schema{Columns: []*Column{
+		{Name: "@timestamp", Type: TypeTimestamp},
+		{Name: "event", Type: TypeGroup, Columns: []*Column{
+			{Name: "duration", Type: TypeLong},
+			{Name: "ratio", Type: TypeDouble},
+		}},
+		{Name: "http", Type: TypeGroup, Columns: []*Column{
+			{Name: "request", Type: TypeGroup, Columns: []*Column{{Name: "method", Type: TypeString}}},
+			{Name: "response", Type: TypeGroup, Columns: []*Column{{Name: "status_code", Type: TypeLong}}},
+		}},
+		{Name: "labels", Type: TypeString},
+		{Name: "source", Type: TypeGroup, Columns: []*Column{{Name: "ip", Type: TypeString}}},
+		{Name: "tls", Type: TypeGroup, Columns: []*Column{{Name: "established", Type: TypeBoolean}}},
+	}}, schema)
+
+	c := newFieldsConverter(fields, []string{"@timestamp", "http.response"})
+	assert.Equal(t, &Schema{Columns: []*Column{
+		{Name: "@timestamp", Type: TypeTimestamp},
+		{Name: "http", Type: TypeGroup, Columns: []*Column{
+			{Name: "response", Type: TypeGroup, Columns: []*Column{{Name: "status_code", Type: TypeLong}}},
+		}},
+	}}, c.Schema(nil))
+}
+
+func TestRow(t *testing.T) {
+	ts := time.Unix(1586960586, 0).UTC()
+	schema := &Schema{Columns: []*Column{
+		{Name: "@timestamp", Type: TypeTimestamp},
+		{Name: "http", Type: TypeGroup, Columns: []*Column{
+			{Name: "status", Type: TypeLong},
+			{Name: "duration", Type: TypeDouble},
+		}},
+		{Name: "ok", Type: TypeBoolean},
+		{Name: "labels", Type: TypeString},
+		{Name: "ports", Type: TypeLong, Repeated: true},
+		{Name: "started", Type: TypeTimestamp},
+		{Name: "missing", Type: TypeGroup, Columns: []*Column{{Name: "value", Type: TypeString}}},
+	}}
+
+	row := schema.Row(&beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"http":    common.MapStr{"status": "200", "duration": 3},
+			"ok":      "true",
+			"labels":  common.MapStr{"env": "prod"},
+			"ports":   []int{80, 443},
+			"started": "2020-04-15T14:23:06Z",
+			"missing": "not an object",
+			"ignored": "not in the schema",
+		},
+	})
+	assert.Equal(t, Row{
+		"@timestamp": ts,
+		"http":       Row{"status": int64(200), "duration": float64(3)},
+		"ok":         true,
+		"labels":     `{"env":"prod"}`,
+		"ports":      []interface{}{int64(80), int64(443)},
+		"started":    ts,
+	}, row)
+
+	// Values which can't be converted are omitted
+	row = schema.Row(&beat.Event{
+		Fields: common.MapStr{
+			"http":  common.MapStr{"status": 1.5, "duration": "slow"},
+			"ports": "http",
+		},
+	})
+	assert.Equal(t, Row{}, row)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig
+	require.NoError(t, cfg.Validate())
+
+	cfg.Schema = "fields"
+	assert.Error(t, cfg.Validate())
+
+	cfg.IncludeFields = []string{"message"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Schema = "avro"
+	assert.Error(t, cfg.Validate())
+}
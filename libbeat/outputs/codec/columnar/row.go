@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package columnar
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Row holds the values of an event for the columns of a schema. Groups are
+// nested rows, and values are converted to the type of their column: string,
+// int64, float64, bool or time.Time, or slices of them for repeated columns.
+// Missing values are absent from the row.
+type Row map[string]interface{}
+
+// Row returns the values of the event for the columns of the schema. Values
+// which can't be converted to the type of their column are omitted.
+func (s *Schema) Row(event *beat.Event) Row {
+	row := groupRow(s.Columns, event.Fields)
+	if _, exists := row[timestampField]; !exists && !event.Timestamp.IsZero() {
+		for _, col := range s.Columns {
+			if col.Name == timestampField && col.Type != TypeGroup {
+				row.set(col, event.Timestamp)
+			}
+		}
+	}
+	return row
+}
+
+func groupRow(columns []*Column, fields map[string]interface{}) Row {
+	row := Row{}
+	for _, col := range columns {
+		value, found := fields[col.Name]
+		if !found || value == nil {
+			continue
+		}
+
+		if col.Type != TypeGroup {
+			row.set(col, value)
+			continue
+		}
+		if nested, ok := asMap(value); ok {
+			if values := groupRow(col.Columns, nested); len(values) > 0 {
+				row[col.Name] = values
+			}
+		}
+	}
+	return row
+}
+
+func (r Row) set(col *Column, value interface{}) {
+	if !col.Repeated {
+		if v, ok := convert(value, col.Type); ok {
+			r[col.Name] = v
+		}
+		return
+	}
+
+	var values []interface{}
+	for _, elem := range asSlice(value) {
+		if v, ok := convert(elem, col.Type); ok {
+			values = append(values, v)
+		}
+	}
+	if len(values) > 0 {
+		r[col.Name] = values
+	}
+}
+
+// asMap returns the value as a map of fields if it is an object.
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case common.MapStr:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
+
+// asSlice returns the elements of a list, or the value itself as a single
+// element.
+func asSlice(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case []byte:
+		return []interface{}{v}
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{value}
+	}
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+	return elems
+}
+
+// convert converts the value to the type of a column.
+func convert(value interface{}, typ Type) (interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	switch typ {
+	case TypeString:
+		return toString(value), true
+	case TypeLong:
+		return toLong(value)
+	case TypeDouble:
+		return toDouble(value)
+	case TypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, true
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, err == nil
+		}
+	case TypeTimestamp:
+		return toTimestamp(value)
+	}
+	return nil, false
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case net.IP:
+		return v.String()
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case common.Time:
+		return time.Time(v).UTC().Format(time.RFC3339Nano)
+	case bool:
+		return strconv.FormatBool(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		// Objects and lists which don't fit the schema are stored as JSON
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func toLong(value interface{}) (interface{}, bool) {
+	if s, ok := value.(string); ok {
+		i, err := strconv.ParseInt(s, 10, 64)
+		return i, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, false
+		}
+		return int64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		// Only whole numbers are longs, like JSON decoded integers
+		f := rv.Float()
+		if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 {
+			return nil, false
+		}
+		return int64(f), true
+	}
+	return nil, false
+}
+
+func toDouble(value interface{}) (interface{}, bool) {
+	if s, ok := value.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return nil, false
+}
+
+func toTimestamp(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case common.Time:
+		return time.Time(v), true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	return nil, false
+}
@@ -0,0 +1,325 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package columnar
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+)
+
+// Type is the type of the values of a column.
+type Type uint8
+
+const (
+	// TypeGroup is the type of columns grouping nested columns.
+	TypeGroup Type = iota
+	TypeString
+	TypeLong
+	TypeDouble
+	TypeBoolean
+	TypeTimestamp
+)
+
+var typeNames = map[Type]string{
+	TypeGroup:     "group",
+	TypeString:    "string",
+	TypeLong:      "long",
+	TypeDouble:    "double",
+	TypeBoolean:   "boolean",
+	TypeTimestamp: "timestamp",
+}
+
+func (t Type) String() string {
+	return typeNames[t]
+}
+
+// Column is a column of the schema, or a group of nested columns.
+type Column struct {
+	Name string
+	Type Type
+	// Repeated columns hold a list of values in each row. Only columns of
+	// values can be repeated, lists of objects are stored as JSON strings.
+	Repeated bool
+	// Columns are the nested columns of a group, sorted by name.
+	Columns []*Column
+}
+
+// Schema is the list of the top level columns of the rows, sorted by name.
+type Schema struct {
+	Columns []*Column
+}
+
+// timestampField is the name of the column of the timestamp of the events.
+const timestampField = "@timestamp"
+
+// fieldTypes maps the types of fields.yml to the type of their columns.
+// Fields of other types are stored as strings.
+var fieldTypes = map[string]Type{
+	"long":          TypeLong,
+	"integer":       TypeLong,
+	"short":         TypeLong,
+	"byte":          TypeLong,
+	"unsigned_long": TypeLong,
+	"double":        TypeDouble,
+	"float":         TypeDouble,
+	"half_float":    TypeDouble,
+	"scaled_float":  TypeDouble,
+	"boolean":       TypeBoolean,
+	"date":          TypeTimestamp,
+	"date_nanos":    TypeTimestamp,
+}
+
+// FromFields returns the schema of the fields of a fields.yml. Aliases are
+// skipped, and dotted field names are split into groups.
+func FromFields(fields mapping.Fields) *Schema {
+	root := &Column{Type: TypeGroup}
+	addFields(root, fields)
+	sortColumns(root)
+	return &Schema{Columns: filterColumns(root.Columns, "", nil)}
+}
+
+func addFields(group *Column, fields mapping.Fields) {
+	for _, field := range fields {
+		if field.Type == "alias" {
+			continue
+		}
+
+		// Dotted names are nested groups
+		parent := group
+		names := strings.Split(field.Name, ".")
+		for _, name := range names[:len(names)-1] {
+			parent = parent.group(name)
+		}
+		name := names[len(names)-1]
+
+		switch field.Type {
+		case "group", "nested":
+			addFields(parent.group(name), field.Fields)
+		case "object":
+			if field.ObjectType == "" && len(field.Fields) > 0 {
+				addFields(parent.group(name), field.Fields)
+			} else {
+				parent.leaf(name, TypeString, false)
+			}
+		case "array":
+			parent.leaf(name, TypeString, true)
+		default:
+			typ, found := fieldTypes[field.Type]
+			if !found {
+				typ = TypeString
+			}
+			parent.leaf(name, typ, false)
+		}
+	}
+}
+
+// Infer returns the schema of the fields of the events. Fields with values of
+// different types in the events are stored as strings, except for integers
+// and floats which are stored as doubles.
+func Infer(events []*beat.Event) *Schema {
+	root := &Column{Type: TypeGroup}
+	for _, event := range events {
+		if !event.Timestamp.IsZero() {
+			root.leaf(timestampField, TypeTimestamp, false)
+		}
+		inferGroup(root, event.Fields)
+	}
+	sortColumns(root)
+	return &Schema{Columns: filterColumns(root.Columns, "", nil)}
+}
+
+func inferGroup(group *Column, fields common.MapStr) {
+	for name, value := range fields {
+		if value == nil {
+			continue
+		}
+		if nested, ok := asMap(value); ok {
+			if col := group.find(name); col != nil && col.Type != TypeGroup {
+				col.Type, col.Repeated = TypeString, false
+				continue
+			}
+			inferGroup(group.group(name), nested)
+			continue
+		}
+
+		typ, repeated := inferType(value)
+		group.leaf(name, typ, repeated)
+	}
+}
+
+// inferType returns the type of the column of a value, and whether the value
+// is a list of values.
+func inferType(value interface{}) (Type, bool) {
+	switch v := value.(type) {
+	case string:
+		return TypeString, false
+	case bool:
+		return TypeBoolean, false
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return TypeLong, false
+	case float32, float64:
+		return TypeDouble, false
+	case time.Time, common.Time:
+		return TypeTimestamp, false
+	case net.IP:
+		return TypeString, false
+	case []string:
+		return TypeString, true
+	case []interface{}:
+		typ := Type(0)
+		for _, elem := range v {
+			if elem == nil {
+				continue
+			}
+			elemType, repeated := inferType(elem)
+			if repeated {
+				return TypeString, false
+			}
+			typ = mergeTypes(typ, elemType)
+		}
+		if typ == TypeGroup {
+			typ = TypeString
+		}
+		return typ, true
+	}
+
+	// Other slices, like []int64, are lists of their element type, lists of
+	// objects are stored as JSON strings
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i] = rv.Index(i).Interface()
+		}
+		return inferType(elems)
+	}
+	return TypeString, false
+}
+
+// mergeTypes returns the type of a column holding values of both types.
+// TypeGroup, the zero value, stands for no type yet.
+func mergeTypes(a, b Type) Type {
+	switch {
+	case a == TypeGroup || a == b:
+		return b
+	case a == TypeLong && b == TypeDouble, a == TypeDouble && b == TypeLong:
+		return TypeDouble
+	}
+	return TypeString
+}
+
+// group returns the nested group of the name, creating it if needed. Leaves
+// of the same name are replaced by the group.
+func (c *Column) group(name string) *Column {
+	if col := c.find(name); col != nil {
+		if col.Type != TypeGroup {
+			col.Type, col.Repeated = TypeGroup, false
+		}
+		return col
+	}
+	col := &Column{Name: name, Type: TypeGroup}
+	c.Columns = append(c.Columns, col)
+	return col
+}
+
+// leaf adds the column of values to the group, merging its type with the type
+// of an existing column of the same name.
+func (c *Column) leaf(name string, typ Type, repeated bool) {
+	col := c.find(name)
+	if col == nil {
+		c.Columns = append(c.Columns, &Column{Name: name, Type: typ, Repeated: repeated})
+		return
+	}
+	if col.Type == TypeGroup || col.Repeated != repeated {
+		// Objects and values of the same field are stored as JSON strings
+		col.Type, col.Repeated, col.Columns = TypeString, false, nil
+		return
+	}
+	col.Type = mergeTypes(col.Type, typ)
+}
+
+func (c *Column) find(name string) *Column {
+	for _, col := range c.Columns {
+		if col.Name == name {
+			return col
+		}
+	}
+	return nil
+}
+
+func sortColumns(c *Column) {
+	sort.Slice(c.Columns, func(i, j int) bool { return c.Columns[i].Name < c.Columns[j].Name })
+	for _, col := range c.Columns {
+		sortColumns(col)
+	}
+}
+
+// filter returns the schema restricted to the included fields.
+func (s *Schema) filter(include []string) *Schema {
+	if len(include) == 0 {
+		return s
+	}
+	return &Schema{Columns: filterColumns(s.Columns, "", include)}
+}
+
+// filterColumns returns the included columns. Groups without any included
+// column are removed.
+func filterColumns(columns []*Column, prefix string, include []string) []*Column {
+	var filtered []*Column
+	for _, col := range columns {
+		path := prefix + col.Name
+		if col.Type != TypeGroup {
+			if included(path, include) {
+				filtered = append(filtered, col)
+			}
+			continue
+		}
+
+		nested := filterColumns(col.Columns, path+".", include)
+		if len(nested) > 0 {
+			filtered = append(filtered, &Column{Name: col.Name, Type: TypeGroup, Columns: nested})
+		}
+	}
+	return filtered
+}
+
+// Leaves returns the columns of values of the schema in depth first order,
+// along with their path.
+func (s *Schema) Leaves() (leaves []*Column, paths [][]string) {
+	var walk func(columns []*Column, path []string)
+	walk = func(columns []*Column, path []string) {
+		for _, col := range columns {
+			colPath := append(append([]string(nil), path...), col.Name)
+			if col.Type == TypeGroup {
+				walk(col.Columns, colPath)
+				continue
+			}
+			leaves = append(leaves, col)
+			paths = append(paths, colPath)
+		}
+	}
+	walk(s.Columns, nil)
+	return leaves, paths
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codec
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
+)
+
+// CheckFailurePolicy returns an error if the policy is not supported for
+// events failing to be encoded. Such events can not be published as is, so
+// they are either dropped or replaced by a dead letter event.
+func CheckFailurePolicy(policy failpolicy.Policy) error {
+	switch policy {
+	case failpolicy.DropEvent, failpolicy.DeadLetter:
+		return nil
+	}
+	return fmt.Errorf("on_serialization_error does not support %v, use drop_event or dead_letter", policy)
+}
+
+// WithFailurePolicy returns a codec applying the policy to events failing to
+// be encoded. With the dead_letter policy, the event is replaced by a dead
+// letter event with the original fields formatted in `message`.
+func WithFailurePolicy(codec Codec, policy failpolicy.Policy) Codec {
+	if policy != failpolicy.DeadLetter {
+		return codec
+	}
+	return &deadLetterCodec{Codec: codec}
+}
+
+type deadLetterCodec struct {
+	Codec
+}
+
+func (c *deadLetterCodec) Encode(index string, event *beat.Event) ([]byte, error) {
+	serialized, err := c.Codec.Encode(index, event)
+	if err == nil {
+		return serialized, nil
+	}
+
+	return c.Codec.Encode(index, deadLetterEvent(event, err))
+}
+
+// EncodeBatchWithFailurePolicy encodes the events with the batch codec, and
+// applies the policy to each event failing to be encoded. When the batch can
+// not be encoded, each event is encoded on its own to find the failed ones,
+// which are dropped or replaced by dead letter events, and the batch is
+// encoded again. It returns the number of dropped events. The document is nil
+// if all events are dropped.
+func EncodeBatchWithFailurePolicy(
+	codec BatchCodec,
+	policy failpolicy.Policy,
+	index string,
+	events []*beat.Event,
+) ([]byte, int, error) {
+	serialized, err := codec.EncodeBatch(index, events)
+	if err == nil {
+		return serialized, 0, nil
+	}
+
+	kept := make([]*beat.Event, 0, len(events))
+	for _, event := range events {
+		if _, err := codec.Encode(index, event); err != nil {
+			if policy == failpolicy.DeadLetter {
+				kept = append(kept, deadLetterEvent(event, err))
+			}
+			continue
+		}
+		kept = append(kept, event)
+	}
+
+	dropped := len(events) - len(kept)
+	if len(kept) == 0 {
+		return nil, dropped, nil
+	}
+	serialized, err = codec.EncodeBatch(index, kept)
+	return serialized, dropped, err
+}
+
+// deadLetterEvent returns the dead letter event replacing an event failing to
+// be encoded.
+func deadLetterEvent(event *beat.Event, err error) *beat.Event {
+	// Format the plain map, MapStr formats itself as JSON which may fail the same way
+	deadLetter := &beat.Event{
+		Timestamp: event.Timestamp,
+		Fields: common.MapStr{
+			"message": fmt.Sprintf("%v", map[string]interface{}(event.Fields)),
+		},
+	}
+	failpolicy.MarkDeadLetter(deadLetter, "output", err)
+	return deadLetter
+}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package codec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
+)
+
+// pickyCodec fails to encode events with an `invalid` field.
+type pickyCodec struct{}
+
+func (pickyCodec) Encode(_ string, event *beat.Event) ([]byte, error) {
+	if _, ok := event.Fields["invalid"]; ok {
+		return nil, errors.New("unsupported value")
+	}
+	return []byte(event.Fields.String()), nil
+}
+
+func TestWithFailurePolicy(t *testing.T) {
+	event := &beat.Event{Fields: common.MapStr{"invalid": 1}}
+
+	_, err := WithFailurePolicy(pickyCodec{}, failpolicy.DropEvent).Encode("test", event)
+	assert.Error(t, err)
+
+	serialized, err := WithFailurePolicy(pickyCodec{}, failpolicy.DeadLetter).Encode("test", event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"error": {"message": "unsupported value"}, "message": "map[invalid:1]"}`, string(serialized))
+}
+
+// pickyBatchCodec encodes batches as the list of their events, and fails for
+// the batches holding an event with an `invalid` field.
+type pickyBatchCodec struct {
+	pickyCodec
+}
+
+func (c pickyBatchCodec) EncodeBatch(index string, events []*beat.Event) ([]byte, error) {
+	var docs []string
+	for _, event := range events {
+		doc, err := c.Encode(index, event)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(doc))
+	}
+	return []byte("[" + strings.Join(docs, ",") + "]"), nil
+}
+
+func (pickyBatchCodec) Extension() string {
+	return ".json"
+}
+
+func TestEncodeBatchWithFailurePolicy(t *testing.T) {
+	events := []*beat.Event{
+		{Fields: common.MapStr{"message": "first"}},
+		{Fields: common.MapStr{"invalid": 1}},
+		{Fields: common.MapStr{"message": "last"}},
+	}
+
+	serialized, dropped, err := EncodeBatchWithFailurePolicy(pickyBatchCodec{}, failpolicy.DropEvent, "test", events[:1])
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.JSONEq(t, `[{"message": "first"}]`, string(serialized))
+
+	serialized, dropped, err = EncodeBatchWithFailurePolicy(pickyBatchCodec{}, failpolicy.DropEvent, "test", events)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.JSONEq(t, `[{"message": "first"}, {"message": "last"}]`, string(serialized))
+
+	serialized, dropped, err = EncodeBatchWithFailurePolicy(pickyBatchCodec{}, failpolicy.DeadLetter, "test", events)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.JSONEq(t, `[
+		{"message": "first"},
+		{"error": {"message": "unsupported value"}, "message": "map[invalid:1]"},
+		{"message": "last"}
+	]`, string(serialized))
+
+	serialized, dropped, err = EncodeBatchWithFailurePolicy(pickyBatchCodec{}, failpolicy.DropEvent, "test", events[1:2])
+	require.NoError(t, err)
+	assert.Equal(t, 1, dropped)
+	assert.Nil(t, serialized)
+}
+
+func TestCheckFailurePolicy(t *testing.T) {
+	assert.NoError(t, CheckFailurePolicy(failpolicy.DropEvent))
+	assert.NoError(t, CheckFailurePolicy(failpolicy.DeadLetter))
+	assert.Error(t, CheckFailurePolicy(failpolicy.Ignore))
+	assert.Error(t, CheckFailurePolicy(failpolicy.FailPipeline))
+}
@@ -0,0 +1,292 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package otel provides a codec encoding events as OpenTelemetry log records,
+// in the OTLP/JSON format of an ExportLogsServiceRequest.
+package otel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+)
+
+// Encoder for serializing a beat.Event to an OpenTelemetry log record.
+type Encoder struct {
+	scope  scope
+	config Config
+}
+
+// Config is used to pass encoding parameters to New.
+type Config struct {
+	// ResourceFields lists the top level fields describing the source of the
+	// events, which are moved to the resource of the log records.
+	ResourceFields []string `config:"resource_fields"`
+}
+
+var defaultConfig = Config{
+	ResourceFields: []string{
+		"agent", "cloud", "container", "host", "kubernetes", "orchestrator", "service",
+	},
+}
+
+// The types below follow the JSON mapping of the OTLP protobuf messages, see
+// opentelemetry/proto/logs/v1/logs.proto and common/v1/common.proto.
+
+type logsData struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano,omitempty"`
+	SeverityNumber       int        `json:"severityNumber,omitempty"`
+	SeverityText         string     `json:"severityText,omitempty"`
+	Body                 *anyValue  `json:"body,omitempty"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+	TraceID              string     `json:"traceId,omitempty"`
+	SpanID               string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue holds exactly one of its fields. 64 bits integers are encoded as
+// strings, like the JSON mapping of protobuf does.
+type anyValue struct {
+	StringValue *string       `json:"stringValue,omitempty"`
+	BoolValue   *bool         `json:"boolValue,omitempty"`
+	IntValue    *string       `json:"intValue,omitempty"`
+	DoubleValue *float64      `json:"doubleValue,omitempty"`
+	BytesValue  []byte        `json:"bytesValue,omitempty"`
+	ArrayValue  *arrayValue   `json:"arrayValue,omitempty"`
+	KvlistValue *keyValueList `json:"kvlistValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+type keyValueList struct {
+	Values []keyValue `json:"values"`
+}
+
+func init() {
+	codec.RegisterType("otel", func(info beat.Info, cfg *common.Config) (codec.Codec, error) {
+		config := defaultConfig
+		if cfg != nil {
+			if err := cfg.Unpack(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		return New(info.Beat, info.Version, config), nil
+	})
+}
+
+// New creates a new OpenTelemetry log record Encoder. The name and version of
+// the beat are reported as the instrumentation scope of the records.
+func New(name, version string, config Config) *Encoder {
+	return &Encoder{
+		scope:  scope{Name: name, Version: version},
+		config: config,
+	}
+}
+
+// Encode serializes a beat event to an OTLP/JSON ExportLogsServiceRequest
+// holding a single log record. The message becomes the body of the record,
+// log.level its severity, and the other fields the attributes of its resource
+// and of the record, using flattened keys.
+func (e *Encoder) Encode(_ string, event *beat.Event) ([]byte, error) {
+	fields := event.Fields.Clone()
+	rec := logRecord{
+		TimeUnixNano: unixNano(event.Timestamp),
+	}
+
+	if created, err := fields.GetValue("event.created"); err == nil {
+		if t, ok := created.(time.Time); ok {
+			rec.ObservedTimeUnixNano = unixNano(t)
+			fields.Delete("event.created")
+		}
+	}
+	if message := pop(fields, "message"); message != nil {
+		body := toAnyValue(message)
+		rec.Body = &body
+	}
+	if level, ok := pop(fields, "log.level").(string); ok {
+		rec.SeverityText = strings.ToUpper(level)
+		rec.SeverityNumber = severityNumber(level)
+	}
+	rec.TraceID, _ = pop(fields, "trace.id").(string)
+	rec.SpanID, _ = pop(fields, "span.id").(string)
+
+	resourceFields := common.MapStr{}
+	for _, name := range e.config.ResourceFields {
+		if value := pop(fields, name); value != nil {
+			resourceFields[name] = value
+		}
+	}
+
+	rec.Attributes = toKeyValues(fields)
+	return json.Marshal(logsData{
+		ResourceLogs: []resourceLogs{{
+			Resource: resource{Attributes: toKeyValues(resourceFields)},
+			ScopeLogs: []scopeLogs{{
+				Scope:      e.scope,
+				LogRecords: []logRecord{rec},
+			}},
+		}},
+	})
+}
+
+// pop removes a field from the event and returns its value, or nil if the
+// field doesn't exist.
+func pop(fields common.MapStr, key string) interface{} {
+	value, err := fields.GetValue(key)
+	if err != nil {
+		return nil
+	}
+	fields.Delete(key)
+	return value
+}
+
+// toKeyValues flattens the fields to attributes, sorted by key.
+func toKeyValues(fields common.MapStr) []keyValue {
+	flat := fields.Flatten()
+	if len(flat) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]keyValue, len(keys))
+	for i, k := range keys {
+		kvs[i] = keyValue{Key: k, Value: toAnyValue(flat[k])}
+	}
+	return kvs
+}
+
+// toAnyValue converts a field value to an OTLP AnyValue. Values of unknown
+// types are encoded as strings, nil as an empty AnyValue.
+func toAnyValue(v interface{}) anyValue {
+	switch v := v.(type) {
+	case nil:
+		return anyValue{}
+	case string:
+		return anyValue{StringValue: &v}
+	case bool:
+		return anyValue{BoolValue: &v}
+	case []byte:
+		return anyValue{BytesValue: v}
+	case time.Time:
+		s := v.UTC().Format(time.RFC3339Nano)
+		return anyValue{StringValue: &s}
+	case common.MapStr:
+		return anyValue{KvlistValue: &keyValueList{Values: toKeyValues(v)}}
+	case map[string]interface{}:
+		return anyValue{KvlistValue: &keyValueList{Values: toKeyValues(v)}}
+	case fmt.Stringer:
+		s := v.String()
+		return anyValue{StringValue: &s}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s := strconv.FormatInt(rv.Int(), 10)
+		return anyValue{IntValue: &s}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s := strconv.FormatUint(rv.Uint(), 10)
+		return anyValue{IntValue: &s}
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return anyValue{DoubleValue: &f}
+	case reflect.Slice, reflect.Array:
+		values := make([]anyValue, rv.Len())
+		for i := range values {
+			values[i] = toAnyValue(rv.Index(i).Interface())
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	}
+
+	s := fmt.Sprint(v)
+	return anyValue{StringValue: &s}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// severityNumber maps a log level to the severity number of the OpenTelemetry
+// logs data model, or 0 (unspecified) if the level is unknown.
+func severityNumber(level string) int {
+	switch strings.ToLower(level) {
+	case "trace":
+		return 1
+	case "debug":
+		return 5
+	case "info", "informational":
+		return 9
+	case "notice":
+		return 10
+	case "warn", "warning":
+		return 13
+	case "error", "err":
+		return 17
+	case "crit", "critical":
+		return 18
+	case "alert":
+		return 19
+	case "fatal", "emerg", "emergency", "panic":
+		return 21
+	default:
+		return 0
+	}
+}
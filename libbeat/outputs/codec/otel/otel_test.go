@@ -0,0 +1,126 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestOTelCodec(t *testing.T) {
+	type testCase struct {
+		config   Config
+		in       common.MapStr
+		expected string
+	}
+
+	ts := time.Unix(1586960586, 0)
+	cases := map[string]testCase{
+		"message only": testCase{
+			config: defaultConfig,
+			in:     common.MapStr{"message": "hello"},
+			expected: `{"resourceLogs":[{"resource":{},"scopeLogs":[{
+				"scope":{"name":"filebeat","version":"7.10.0"},
+				"logRecords":[{"timeUnixNano":"1586960586000000000","body":{"stringValue":"hello"}}]}]}]}`,
+		},
+		"module event": testCase{
+			config: defaultConfig,
+			in: common.MapStr{
+				"message": "connection refused",
+				"log":     common.MapStr{"level": "warning", "file": common.MapStr{"path": "/var/log/nginx/error.log"}},
+				"event":   common.MapStr{"module": "nginx", "dataset": "nginx.error", "created": ts.Add(time.Second)},
+				"host":    common.MapStr{"name": "web-1", "os": common.MapStr{"family": "debian"}},
+				"trace":   common.MapStr{"id": "4bf92f3577b34da6a3ce929d0e0e4736"},
+				"span":    common.MapStr{"id": "00f067aa0ba902b7"},
+			},
+			expected: `{"resourceLogs":[{
+				"resource":{"attributes":[
+					{"key":"host.name","value":{"stringValue":"web-1"}},
+					{"key":"host.os.family","value":{"stringValue":"debian"}}]},
+				"scopeLogs":[{
+					"scope":{"name":"filebeat","version":"7.10.0"},
+					"logRecords":[{
+						"timeUnixNano":"1586960586000000000",
+						"observedTimeUnixNano":"1586960587000000000",
+						"severityNumber":13,
+						"severityText":"WARNING",
+						"body":{"stringValue":"connection refused"},
+						"attributes":[
+							{"key":"event.dataset","value":{"stringValue":"nginx.error"}},
+							{"key":"event.module","value":{"stringValue":"nginx"}},
+							{"key":"log.file.path","value":{"stringValue":"/var/log/nginx/error.log"}}],
+						"traceId":"4bf92f3577b34da6a3ce929d0e0e4736",
+						"spanId":"00f067aa0ba902b7"}]}]}]}`,
+		},
+		"unknown level": testCase{
+			config: defaultConfig,
+			in:     common.MapStr{"message": "hello", "log": common.MapStr{"level": "verbose"}},
+			expected: `{"resourceLogs":[{"resource":{},"scopeLogs":[{
+				"scope":{"name":"filebeat","version":"7.10.0"},
+				"logRecords":[{"timeUnixNano":"1586960586000000000","severityText":"VERBOSE","body":{"stringValue":"hello"}}]}]}]}`,
+		},
+		"attribute types": testCase{
+			config: Config{},
+			in: common.MapStr{
+				"http":  common.MapStr{"response": common.MapStr{"status_code": 503, "bytes": uint64(1024)}},
+				"event": common.MapStr{"duration": 0.25, "ingested": true},
+				"tags":  []string{"a", "b"},
+				"user":  nil,
+			},
+			expected: `{"resourceLogs":[{"resource":{},"scopeLogs":[{
+				"scope":{"name":"filebeat","version":"7.10.0"},
+				"logRecords":[{"timeUnixNano":"1586960586000000000","attributes":[
+					{"key":"event.duration","value":{"doubleValue":0.25}},
+					{"key":"event.ingested","value":{"boolValue":true}},
+					{"key":"http.response.bytes","value":{"intValue":"1024"}},
+					{"key":"http.response.status_code","value":{"intValue":"503"}},
+					{"key":"tags","value":{"arrayValue":{"values":[{"stringValue":"a"},{"stringValue":"b"}]}}},
+					{"key":"user","value":{}}]}]}]}]}`,
+		},
+		"custom resource fields": testCase{
+			config: Config{ResourceFields: []string{"service"}},
+			in:     common.MapStr{"message": "hello", "service": common.MapStr{"name": "api"}, "host": common.MapStr{"name": "web-1"}},
+			expected: `{"resourceLogs":[{
+				"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},
+				"scopeLogs":[{
+					"scope":{"name":"filebeat","version":"7.10.0"},
+					"logRecords":[{
+						"timeUnixNano":"1586960586000000000",
+						"body":{"stringValue":"hello"},
+						"attributes":[{"key":"host.name","value":{"stringValue":"web-1"}}]}]}]}]}`,
+		},
+	}
+
+	for name, test := range cases {
+		cfg, fields, expected := test.config, test.in, test.expected
+
+		t.Run(name, func(t *testing.T) {
+			codec := New("filebeat", "7.10.0", cfg)
+			actual, err := codec.Encode("test", &beat.Event{Fields: fields, Timestamp: ts})
+
+			if assert.NoError(t, err) {
+				assert.JSONEq(t, expected, string(actual))
+			}
+		})
+	}
+}
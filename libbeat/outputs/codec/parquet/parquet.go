@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package parquet provides a codec encoding batches of events as parquet
+// files, with one column per field.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+)
+
+// Encoder for serializing batches of events to parquet files.
+type Encoder struct {
+	converter *columnar.Converter
+	comp      compression
+	createdBy string
+}
+
+// Config is used to pass encoding parameters to New.
+type Config struct {
+	columnar.Config `config:",inline"`
+
+	// Compression of the pages: snappy, gzip, zstd or none.
+	Compression string `config:"compression"`
+}
+
+var defaultConfig = Config{
+	Config:      columnar.DefaultConfig,
+	Compression: "snappy",
+}
+
+var errNoColumns = errors.New("no columns to encode, the events have no included fields")
+
+// compression compresses the pages with the codec of the column chunks.
+type compression struct {
+	codec    int32
+	compress func([]byte) ([]byte, error)
+}
+
+var compressions = map[string]compression{
+	"none": {codec: 0, compress: func(data []byte) ([]byte, error) {
+		return data, nil
+	}},
+	"snappy": {codec: 1, compress: func(data []byte) ([]byte, error) {
+		return snappy.Encode(nil, data), nil
+	}},
+	"gzip": {codec: 2, compress: func(data []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}},
+	"zstd": {codec: 6, compress: func(data []byte) ([]byte, error) {
+		w, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer w.Close()
+		return w.EncodeAll(data, nil), nil
+	}},
+}
+
+func (c *Config) Validate() error {
+	if _, found := compressions[c.Compression]; !found {
+		return fmt.Errorf("unsupported compression '%v', use snappy, gzip, zstd or none", c.Compression)
+	}
+	return c.Config.Validate()
+}
+
+func init() {
+	codec.RegisterType("parquet", func(info beat.Info, cfg *common.Config) (codec.Codec, error) {
+		config := defaultConfig
+		if cfg != nil {
+			if err := cfg.Unpack(&config); err != nil {
+				return nil, err
+			}
+		}
+
+		return New(info, config)
+	})
+}
+
+// New creates a new parquet Encoder. The name and version of the beat are
+// reported as the writer of the files.
+func New(info beat.Info, config Config) (*Encoder, error) {
+	converter, err := columnar.NewConverter(info, config.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		converter: converter,
+		comp:      compressions[config.Compression],
+		createdBy: fmt.Sprintf("%v version %v", info.Beat, info.Version),
+	}, nil
+}
+
+// Encode serializes a beat event to a parquet file holding a single row.
+func (e *Encoder) Encode(index string, event *beat.Event) ([]byte, error) {
+	return e.EncodeBatch(index, []*beat.Event{event})
+}
+
+// EncodeBatch serializes the events to a parquet file with a single row group.
+func (e *Encoder) EncodeBatch(_ string, events []*beat.Event) ([]byte, error) {
+	schema := e.converter.Schema(events)
+	if len(schema.Columns) == 0 {
+		return nil, errNoColumns
+	}
+
+	rows := make([]columnar.Row, len(events))
+	for i, event := range events {
+		rows[i] = schema.Row(event)
+	}
+	return writeFile(schema, rows, e.comp, e.createdBy)
+}
+
+// Extension returns the extension of parquet files.
+func (e *Encoder) Extension() string {
+	return ".parquet"
+}
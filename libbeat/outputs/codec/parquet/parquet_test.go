@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+)
+
+func TestShred(t *testing.T) {
+	rows := []columnar.Row{
+		{"http": columnar.Row{"status": int64(200)}, "tags": []interface{}{"a", "b"}},
+		{},
+		{"http": columnar.Row{"method": "GET"}},
+	}
+
+	status := shred(rows, []string{"http", "status"}, &columnar.Column{Name: "status", Type: columnar.TypeLong})
+	assert.Equal(t, 0, status.maxRep)
+	assert.Equal(t, 2, status.maxDef)
+	assert.Equal(t, []int{0, 0, 0}, status.rep)
+	assert.Equal(t, []int{2, 0, 1}, status.def)
+	assert.Equal(t, []interface{}{int64(200)}, status.values)
+
+	tags := shred(rows, []string{"tags"}, &columnar.Column{Name: "tags", Type: columnar.TypeString, Repeated: true})
+	assert.Equal(t, 1, tags.maxRep)
+	assert.Equal(t, 2, tags.maxDef)
+	assert.Equal(t, []int{0, 1, 0, 0}, tags.rep)
+	assert.Equal(t, []int{2, 2, 0, 0}, tags.def)
+	assert.Equal(t, []interface{}{"a", "b"}, tags.values)
+}
+
+func TestPageEncode(t *testing.T) {
+	p := &page{maxDef: 2, def: []int{2, 2, 0}, rep: []int{0, 0, 0}, values: []interface{}{"a", "bc"}}
+	assert.Equal(t, []byte{
+		4, 0, 0, 0, 4, 2, 2, 0, // definition levels: 2 x 2, 1 x 0
+		1, 0, 0, 0, 'a',
+		2, 0, 0, 0, 'b', 'c',
+	}, p.encode(&columnar.Column{Type: columnar.TypeString}))
+
+	p = &page{maxDef: 1, def: []int{1, 1, 1}, rep: []int{0, 0, 0}, values: []interface{}{true, false, true}}
+	assert.Equal(t, []byte{2, 0, 0, 0, 6, 1, 0x05}, p.encode(&columnar.Column{Type: columnar.TypeBoolean}))
+
+	ts := time.Unix(1, 500000)
+	p = &page{maxDef: 1, def: []int{1}, rep: []int{0}, values: []interface{}{ts}}
+	assert.Equal(t, []byte{2, 0, 0, 0, 2, 1, 0x34, 0x44, 0x0f, 0, 0, 0, 0, 0}, p.encode(&columnar.Column{Type: columnar.TypeTimestamp}))
+}
+
+func TestPageHeader(t *testing.T) {
+	assert.Equal(t, []byte{
+		0x15, 0x00, 0x15, 0x14, 0x15, 0x10, // type, uncompressed and compressed sizes
+		0x2c, 0x15, 0x06, 0x15, 0x00, 0x15, 0x06, 0x15, 0x06, 0x00, // data page header
+		0x00,
+	}, pageHeader(3, 10, 8))
+}
+
+func TestEncodeBatch(t *testing.T) {
+	info := beat.Info{Beat: "filebeat", Version: "7.10.0"}
+	ts := time.Unix(1586960586, 0)
+	events := []*beat.Event{
+		{Timestamp: ts, Fields: common.MapStr{"message": "hello", "http": common.MapStr{"status": 200}}},
+		{Timestamp: ts, Fields: common.MapStr{"message": "world", "tags": []string{"a"}}},
+	}
+
+	for name := range compressions {
+		t.Run(name, func(t *testing.T) {
+			enc, err := New(info, Config{Config: columnar.DefaultConfig, Compression: name})
+			require.NoError(t, err)
+
+			data, err := enc.EncodeBatch("test", events)
+			require.NoError(t, err)
+
+			require.True(t, bytes.HasPrefix(data, magic))
+			require.True(t, bytes.HasSuffix(data, magic))
+			footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+			require.True(t, footerLen < len(data)-12)
+			footer := data[len(data)-8-footerLen : len(data)-8]
+			for _, s := range []string{"schema", "@timestamp", "http", "status", "message", "tags", "list", "element", "filebeat version 7.10.0"} {
+				assert.Contains(t, string(footer), s)
+			}
+			if name == "none" {
+				assert.Contains(t, string(data), "hello")
+				assert.Contains(t, string(data), "world")
+			}
+		})
+	}
+}
+
+func TestEncodeNoColumns(t *testing.T) {
+	config := defaultConfig
+	config.IncludeFields = []string{"missing"}
+	enc, err := New(beat.Info{Beat: "filebeat"}, config)
+	require.NoError(t, err)
+
+	_, err = enc.Encode("test", &beat.Event{Fields: common.MapStr{"message": "hello"}})
+	assert.Equal(t, errNoColumns, err)
+	assert.Equal(t, ".parquet", enc.Extension())
+}
+
+func TestSnappyCompression(t *testing.T) {
+	data := bytes.Repeat([]byte("hello "), 100)
+	compressed, err := compressions["snappy"].compress(data)
+	require.NoError(t, err)
+
+	decoded, err := snappy.Decode(nil, compressed)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
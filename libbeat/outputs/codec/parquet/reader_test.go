@@ -0,0 +1,495 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/bits"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+)
+
+// The reader below reads the files back in the tests. It is written from the
+// parquet-format and thrift compact protocol specifications, and shares no
+// code with the writer, so that both can not agree on the same mistake.
+
+// parquetFile holds the content of a parquet file. The schema lists the
+// elements as `<path> <repetition> <type>`, and the rows map the paths of the
+// columns to their values, without the levels of lists.
+type parquetFile struct {
+	createdBy string
+	numRows   int64
+	schema    []string
+	rows      []map[string]interface{}
+}
+
+type leafColumn struct {
+	path           []string
+	name           string
+	physical       int64
+	converted      int64
+	maxDef, maxRep int
+}
+
+func readParquet(t *testing.T, data []byte) (file *parquetFile) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("invalid parquet file: %v", r)
+		}
+	}()
+
+	require.True(t, len(data) > 12, "file too short")
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta := (&thriftReader{data: data[len(data)-8-footerLen : len(data)-8]}).readStruct()
+
+	file = &parquetFile{createdBy: meta[6].(string), numRows: meta[3].(int64)}
+	elements := meta[2].([]interface{})
+	root := elements[0].(thriftFields)
+	leaves := file.readSchema(elements[1:], int(root[5].(int64)))
+
+	for i := int64(0); i < file.numRows; i++ {
+		file.rows = append(file.rows, map[string]interface{}{})
+	}
+	base := 0
+	for _, rg := range meta[4].([]interface{}) {
+		rowGroup := rg.(thriftFields)
+		for i, c := range rowGroup[1].([]interface{}) {
+			md := c.(thriftFields)[3].(thriftFields)
+			leaf := leaves[i]
+			var path []string
+			for _, name := range md[3].([]interface{}) {
+				path = append(path, name.(string))
+			}
+			require.Equal(t, leaf.path, path)
+			require.Equal(t, leaf.physical, md[1].(int64))
+			file.readColumn(data, leaf, md, base)
+		}
+		base += int(rowGroup[3].(int64))
+	}
+	return file
+}
+
+// readSchema reads n elements of the flattened schema tree, and returns the
+// leaf columns.
+func (f *parquetFile) readSchema(elements []interface{}, n int) []leafColumn {
+	var leaves []leafColumn
+	pos := 0
+	var walk func(n int, path, logical []string, def, rep int, skip int)
+	walk = func(n int, path, logical []string, def, rep int, skip int) {
+		for ; n > 0; n-- {
+			el := elements[pos].(thriftFields)
+			pos++
+
+			name := el[4].(string)
+			p := append(append([]string(nil), path...), name)
+			l := logical
+			if skip == 0 {
+				l = append(append([]string(nil), logical...), name)
+			}
+			d, r := def, rep
+			repetition := "required"
+			switch el[3].(int64) {
+			case 1:
+				repetition = "optional"
+				d++
+			case 2:
+				repetition = "repeated"
+				d++
+				r++
+			}
+			converted, hasConverted := el[6].(int64)
+			if !hasConverted {
+				converted = -1
+			}
+
+			if children, ok := el[5].(int64); ok {
+				desc := "group"
+				childSkip := 0
+				if skip > 0 {
+					childSkip = skip - 1
+				}
+				if converted == 3 {
+					desc += " (LIST)"
+					childSkip = 2
+				}
+				f.schema = append(f.schema, fmt.Sprintf("%s %s %s", strings.Join(p, "."), repetition, desc))
+				walk(int(children), p, l, d, r, childSkip)
+				continue
+			}
+
+			desc := map[int64]string{0: "boolean", 2: "int64", 5: "double", 6: "binary"}[el[1].(int64)]
+			switch converted {
+			case 0:
+				desc += " (UTF8)"
+			case 10:
+				desc += " (TIMESTAMP_MICROS)"
+			}
+			f.schema = append(f.schema, fmt.Sprintf("%s %s %s", strings.Join(p, "."), repetition, desc))
+			leaves = append(leaves, leafColumn{
+				path:      p,
+				name:      strings.Join(l, "."),
+				physical:  el[1].(int64),
+				converted: converted,
+				maxDef:    d,
+				maxRep:    r,
+			})
+		}
+	}
+	walk(n, nil, nil, 0, 0, 0)
+	return leaves
+}
+
+// readColumn reads the data pages of a column chunk into the rows, starting at
+// the row base.
+func (f *parquetFile) readColumn(data []byte, leaf leafColumn, md thriftFields, base int) {
+	remaining := int(md[5].(int64))
+	r := &thriftReader{data: data, pos: int(md[9].(int64))}
+	record := base - 1
+	for remaining > 0 {
+		header := r.readStruct()
+		if header[1].(int64) != 0 {
+			panic("only data pages are supported")
+		}
+		size := int(header[3].(int64))
+		page := decompress(md[4].(int64), data[r.pos:r.pos+size])
+		r.pos += size
+		if len(page) != int(header[2].(int64)) {
+			panic("unexpected uncompressed page size")
+		}
+
+		dph := header[5].(thriftFields)
+		n := int(dph[1].(int64))
+		if dph[2].(int64) != 0 {
+			panic("only PLAIN values are supported")
+		}
+
+		pos := 0
+		rep := make([]int, n)
+		if leaf.maxRep > 0 {
+			rep = readLevels(page, &pos, leaf.maxRep, n)
+		}
+		def := make([]int, n)
+		if leaf.maxDef > 0 {
+			def = readLevels(page, &pos, leaf.maxDef, n)
+		}
+
+		count := 0
+		for _, d := range def {
+			if d == leaf.maxDef {
+				count++
+			}
+		}
+		values := readPlain(page[pos:], leaf, count)
+
+		for i := 0; i < n; i++ {
+			if rep[i] == 0 {
+				record++
+			}
+			if def[i] != leaf.maxDef {
+				continue
+			}
+			value := values[0]
+			values = values[1:]
+			if leaf.maxRep == 0 {
+				f.rows[record][leaf.name] = value
+				continue
+			}
+			list, _ := f.rows[record][leaf.name].([]interface{})
+			f.rows[record][leaf.name] = append(list, value)
+		}
+		remaining -= n
+	}
+}
+
+func decompress(codec int64, data []byte) []byte {
+	switch codec {
+	case 0:
+		return data
+	case 1:
+		decoded, err := snappy.Decode(nil, data)
+		if err != nil {
+			panic(err)
+		}
+		return decoded
+	case 2:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			panic(err)
+		}
+		decoded, err := ioutil.ReadAll(r)
+		if err != nil {
+			panic(err)
+		}
+		return decoded
+	case 6:
+		d, err := zstd.NewReader(nil)
+		if err != nil {
+			panic(err)
+		}
+		defer d.Close()
+		decoded, err := d.DecodeAll(data, nil)
+		if err != nil {
+			panic(err)
+		}
+		return decoded
+	}
+	panic(fmt.Sprintf("unsupported compression codec %d", codec))
+}
+
+// readLevels reads n levels encoded with the RLE/bit-packing hybrid encoding,
+// prefixed by their length.
+func readLevels(page []byte, pos *int, max, n int) []int {
+	length := int(binary.LittleEndian.Uint32(page[*pos:]))
+	data := page[*pos+4 : *pos+4+length]
+	*pos += 4 + length
+
+	width := bits.Len(uint(max))
+	var levels []int
+	for i := 0; len(levels) < n; {
+		header, k := binary.Uvarint(data[i:])
+		if k <= 0 {
+			panic("invalid run header")
+		}
+		i += k
+
+		if header&1 == 0 {
+			value := 0
+			for b := 0; b < (width+7)/8; b++ {
+				value |= int(data[i]) << uint(8*b)
+				i++
+			}
+			for j := uint64(0); j < header>>1; j++ {
+				levels = append(levels, value)
+			}
+			continue
+		}
+
+		count := int(header>>1) * 8
+		for j := 0; j < count; j++ {
+			value := 0
+			for b := 0; b < width; b++ {
+				bit := j*width + b
+				value |= int(data[i+bit/8]>>uint(bit%8)&1) << uint(b)
+			}
+			levels = append(levels, value)
+		}
+		i += count * width / 8
+	}
+	return levels[:n]
+}
+
+func readPlain(data []byte, leaf leafColumn, count int) []interface{} {
+	values := make([]interface{}, count)
+	pos := 0
+	for i := range values {
+		switch leaf.physical {
+		case 0:
+			values[i] = data[i/8]>>uint(i%8)&1 == 1
+		case 2:
+			v := int64(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+			if leaf.converted == 10 {
+				values[i] = time.Unix(0, v*int64(time.Microsecond)).UTC()
+			} else {
+				values[i] = v
+			}
+		case 5:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		case 6:
+			n := int(binary.LittleEndian.Uint32(data[pos:]))
+			values[i] = string(data[pos+4 : pos+4+n])
+			pos += 4 + n
+		default:
+			panic(fmt.Sprintf("unsupported physical type %d", leaf.physical))
+		}
+	}
+	return values
+}
+
+// thriftFields maps the ids of the fields of a struct to their values.
+type thriftFields map[int16]interface{}
+
+// thriftReader decodes the thrift compact protocol. Integers are returned as
+// int64, binaries as strings, lists as []interface{} and structs as
+// thriftFields.
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) readByte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) readUvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		panic("invalid varint")
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) readZigzag() int64 {
+	v := r.readUvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) readStruct() thriftFields {
+	fields := thriftFields{}
+	var id int16
+	for {
+		b := r.readByte()
+		if b == 0 {
+			return fields
+		}
+		if delta := b >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			id = int16(r.readZigzag())
+		}
+
+		switch typ := b & 0x0f; typ {
+		case 1:
+			fields[id] = true
+		case 2:
+			fields[id] = false
+		default:
+			fields[id] = r.readValue(typ)
+		}
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) interface{} {
+	switch typ {
+	case 1, 2:
+		return r.readByte() == 1
+	case 3:
+		return int64(int8(r.readByte()))
+	case 4, 5, 6:
+		return r.readZigzag()
+	case 7:
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return v
+	case 8:
+		n := int(r.readUvarint())
+		v := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return v
+	case 9, 10:
+		header := r.readByte()
+		n := int(header >> 4)
+		if n == 15 {
+			n = int(r.readUvarint())
+		}
+		elems := make([]interface{}, n)
+		for i := range elems {
+			elems[i] = r.readValue(header & 0x0f)
+		}
+		return elems
+	case 12:
+		return r.readStruct()
+	}
+	panic(fmt.Sprintf("unsupported thrift type %d", typ))
+}
+
+func TestReadBack(t *testing.T) {
+	info := beat.Info{Beat: "filebeat", Version: "7.10.0"}
+	ts := time.Date(2020, 4, 15, 14, 23, 6, 123456000, time.UTC)
+	events := []*beat.Event{
+		{Timestamp: ts, Fields: common.MapStr{
+			"message": "hello",
+			"http":    common.MapStr{"status": 200, "ok": true},
+			"tags":    []string{"a", "b"},
+		}},
+		{Timestamp: ts.Add(time.Second), Fields: common.MapStr{
+			"message":  "world",
+			"duration": 1.5,
+		}},
+		{Timestamp: ts.Add(2 * time.Second), Fields: common.MapStr{
+			"http": common.MapStr{"ok": false},
+			"tags": []string{"c"},
+		}},
+	}
+
+	for name := range compressions {
+		t.Run(name, func(t *testing.T) {
+			enc, err := New(info, Config{Config: columnar.DefaultConfig, Compression: name})
+			require.NoError(t, err)
+
+			data, err := enc.EncodeBatch("test", events)
+			require.NoError(t, err)
+			file := readParquet(t, data)
+
+			assert.Equal(t, "filebeat version 7.10.0", file.createdBy)
+			assert.Equal(t, int64(3), file.numRows)
+			assert.Equal(t, []string{
+				"@timestamp optional int64 (TIMESTAMP_MICROS)",
+				"duration optional double",
+				"http optional group",
+				"http.ok optional boolean",
+				"http.status optional int64",
+				"message optional binary (UTF8)",
+				"tags optional group (LIST)",
+				"tags.list repeated group",
+				"tags.list.element required binary (UTF8)",
+			}, file.schema)
+			assert.Equal(t, []map[string]interface{}{
+				{
+					"@timestamp":  ts,
+					"http.ok":     true,
+					"http.status": int64(200),
+					"message":     "hello",
+					"tags":        []interface{}{"a", "b"},
+				},
+				{
+					"@timestamp": ts.Add(time.Second),
+					"duration":   1.5,
+					"message":    "world",
+				},
+				{
+					"@timestamp": ts.Add(2 * time.Second),
+					"http.ok":    false,
+					"tags":       []interface{}{"c"},
+				},
+			}, file.rows)
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+)
+
+// Values of the enums of the parquet metadata, see parquet.thrift in the
+// parquet-format repository.
+const (
+	typeBoolean   = 0
+	typeInt64     = 2
+	typeDouble    = 5
+	typeByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+	repetitionRepeated = 2
+
+	convertedUTF8            = 0
+	convertedList            = 3
+	convertedTimestampMicros = 10
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageTypeData = 0
+)
+
+var magic = []byte("PAR1")
+
+// writeFile writes the rows as a parquet file with a single row group, holding
+// one data page per column.
+func writeFile(schema *columnar.Schema, rows []columnar.Row, comp compression, createdBy string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(magic)
+
+	leaves, paths := schema.Leaves()
+	chunks := make([]columnMeta, len(leaves))
+	var totalSize int64
+	for i, leaf := range leaves {
+		page := shred(rows, paths[i], leaf)
+		data := page.encode(leaf)
+		compressed, err := comp.compress(data)
+		if err != nil {
+			return nil, err
+		}
+
+		header := pageHeader(page.numValues(), len(data), len(compressed))
+		chunks[i] = columnMeta{
+			leaf:             leaf,
+			path:             schemaPath(paths[i], leaf),
+			numValues:        int64(page.numValues()),
+			uncompressedSize: int64(len(header) + len(data)),
+			compressedSize:   int64(len(header) + len(compressed)),
+			offset:           int64(buf.Len()),
+		}
+		totalSize += chunks[i].uncompressedSize
+
+		buf.Write(header)
+		buf.Write(compressed)
+	}
+
+	footer := fileMetadata(schema, chunks, int64(len(rows)), totalSize, comp.codec, createdBy)
+	buf.Write(footer)
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	buf.Write(length[:])
+	buf.Write(magic)
+	return buf.Bytes(), nil
+}
+
+// page holds the levels and values of a column, following the record
+// shredding of Dremel. Groups and values are optional, and repeated values are
+// stored as 3-level lists, adding a definition level for the list and one for
+// its elements.
+type page struct {
+	maxRep, maxDef int
+	rep, def       []int
+	values         []interface{}
+}
+
+func shred(rows []columnar.Row, path []string, leaf *columnar.Column) *page {
+	p := &page{maxDef: len(path)}
+	if leaf.Repeated {
+		p.maxRep = 1
+		p.maxDef++
+	}
+	for _, row := range rows {
+		p.add(row, path, leaf, 0)
+	}
+	return p
+}
+
+func (p *page) add(row columnar.Row, path []string, leaf *columnar.Column, def int) {
+	value, found := row[path[0]]
+	if !found {
+		p.levels(0, def)
+		return
+	}
+	if len(path) > 1 {
+		p.add(value.(columnar.Row), path[1:], leaf, def+1)
+		return
+	}
+
+	if !leaf.Repeated {
+		p.levels(0, def+1)
+		p.values = append(p.values, value)
+		return
+	}
+	for i, elem := range value.([]interface{}) {
+		rep := 1
+		if i == 0 {
+			rep = 0
+		}
+		p.levels(rep, def+2)
+		p.values = append(p.values, elem)
+	}
+}
+
+func (p *page) levels(rep, def int) {
+	p.rep = append(p.rep, rep)
+	p.def = append(p.def, def)
+}
+
+func (p *page) numValues() int {
+	return len(p.def)
+}
+
+// encode returns the content of a data page v1: the repetition levels, the
+// definition levels and the PLAIN encoded values.
+func (p *page) encode(leaf *columnar.Column) []byte {
+	var buf bytes.Buffer
+	if p.maxRep > 0 {
+		writeLevels(&buf, p.rep, p.maxRep)
+	}
+	writeLevels(&buf, p.def, p.maxDef)
+
+	var scratch [8]byte
+	switch leaf.Type {
+	case columnar.TypeBoolean:
+		packed := make([]byte, (len(p.values)+7)/8)
+		for i, v := range p.values {
+			if v.(bool) {
+				packed[i/8] |= 1 << uint(i%8)
+			}
+		}
+		buf.Write(packed)
+	case columnar.TypeLong:
+		for _, v := range p.values {
+			binary.LittleEndian.PutUint64(scratch[:], uint64(v.(int64)))
+			buf.Write(scratch[:])
+		}
+	case columnar.TypeDouble:
+		for _, v := range p.values {
+			binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(v.(float64)))
+			buf.Write(scratch[:])
+		}
+	case columnar.TypeTimestamp:
+		for _, v := range p.values {
+			micros := v.(time.Time).UnixNano() / int64(time.Microsecond)
+			binary.LittleEndian.PutUint64(scratch[:], uint64(micros))
+			buf.Write(scratch[:])
+		}
+	default:
+		for _, v := range p.values {
+			s := v.(string)
+			binary.LittleEndian.PutUint32(scratch[:4], uint32(len(s)))
+			buf.Write(scratch[:4])
+			buf.WriteString(s)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeLevels writes the levels using the RLE hybrid encoding, prefixed by
+// their length. Only RLE runs are used.
+func writeLevels(buf *bytes.Buffer, levels []int, max int) {
+	width := (bits.Len(uint(max)) + 7) / 8
+	var runs bytes.Buffer
+	var header [binary.MaxVarintLen64]byte
+	for i := 0; i < len(levels); {
+		j := i + 1
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		n := binary.PutUvarint(header[:], uint64(j-i)<<1)
+		runs.Write(header[:n])
+		for b := 0; b < width; b++ {
+			runs.WriteByte(byte(levels[i] >> uint(8*b)))
+		}
+		i = j
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(runs.Len()))
+	buf.Write(length[:])
+	buf.Write(runs.Bytes())
+}
+
+func pageHeader(numValues, uncompressedSize, compressedSize int) []byte {
+	var w compactWriter
+	w.beginStruct()
+	w.i32(1, pageTypeData)
+	w.i32(2, int32(uncompressedSize))
+	w.i32(3, int32(compressedSize))
+	w.structField(5)
+	w.i32(1, int32(numValues))
+	w.i32(2, encodingPlain)
+	w.i32(3, encodingRLE)
+	w.i32(4, encodingRLE)
+	w.endStruct()
+	w.endStruct()
+	return w.buf.Bytes()
+}
+
+type columnMeta struct {
+	leaf             *columnar.Column
+	path             []string
+	numValues        int64
+	uncompressedSize int64
+	compressedSize   int64
+	offset           int64
+}
+
+// schemaPath returns the path of the column of values of a leaf, repeated
+// leaves are lists of elements.
+func schemaPath(path []string, leaf *columnar.Column) []string {
+	if leaf.Repeated {
+		return append(append([]string(nil), path...), "list", "element")
+	}
+	return path
+}
+
+func fileMetadata(schema *columnar.Schema, chunks []columnMeta, numRows, totalSize int64, codec int32, createdBy string) []byte {
+	var w compactWriter
+	w.beginStruct()
+	w.i32(1, 1)
+
+	var elements int
+	countElements(schema.Columns, &elements)
+	w.list(2, thriftStruct, elements+1)
+	w.beginStruct()
+	w.string(4, "schema")
+	w.i32(5, int32(len(schema.Columns)))
+	w.endStruct()
+	writeSchema(&w, schema.Columns)
+
+	w.i64(3, numRows)
+
+	w.list(4, thriftStruct, 1)
+	w.beginStruct()
+	w.list(1, thriftStruct, len(chunks))
+	for _, chunk := range chunks {
+		w.beginStruct()
+		w.i64(2, chunk.offset)
+		w.structField(3)
+		w.i32(1, physicalType(chunk.leaf.Type))
+		w.list(2, thriftI32, 2)
+		w.i32Elem(encodingPlain)
+		w.i32Elem(encodingRLE)
+		w.list(3, thriftBinary, len(chunk.path))
+		for _, name := range chunk.path {
+			w.stringElem(name)
+		}
+		w.i32(4, codec)
+		w.i64(5, chunk.numValues)
+		w.i64(6, chunk.uncompressedSize)
+		w.i64(7, chunk.compressedSize)
+		w.i64(9, chunk.offset)
+		w.endStruct()
+		w.endStruct()
+	}
+	w.i64(2, totalSize)
+	w.i64(3, numRows)
+	w.endStruct()
+
+	w.string(6, createdBy)
+	w.endStruct()
+	return w.buf.Bytes()
+}
+
+func countElements(columns []*columnar.Column, n *int) {
+	for _, col := range columns {
+		switch {
+		case col.Type == columnar.TypeGroup:
+			*n++
+			countElements(col.Columns, n)
+		case col.Repeated:
+			*n += 3
+		default:
+			*n++
+		}
+	}
+}
+
+// writeSchema writes the schema elements of the columns in depth first order.
+func writeSchema(w *compactWriter, columns []*columnar.Column) {
+	for _, col := range columns {
+		switch {
+		case col.Type == columnar.TypeGroup:
+			w.beginStruct()
+			w.i32(3, repetitionOptional)
+			w.string(4, col.Name)
+			w.i32(5, int32(len(col.Columns)))
+			w.endStruct()
+			writeSchema(w, col.Columns)
+		case col.Repeated:
+			w.beginStruct()
+			w.i32(3, repetitionOptional)
+			w.string(4, col.Name)
+			w.i32(5, 1)
+			w.i32(6, convertedList)
+			w.endStruct()
+			w.beginStruct()
+			w.i32(3, repetitionRepeated)
+			w.string(4, "list")
+			w.i32(5, 1)
+			w.endStruct()
+			writeLeaf(w, "element", col.Type, repetitionRequired)
+		default:
+			writeLeaf(w, col.Name, col.Type, repetitionOptional)
+		}
+	}
+}
+
+func writeLeaf(w *compactWriter, name string, typ columnar.Type, repetition int32) {
+	w.beginStruct()
+	w.i32(1, physicalType(typ))
+	w.i32(3, repetition)
+	w.string(4, name)
+	switch typ {
+	case columnar.TypeString:
+		w.i32(6, convertedUTF8)
+	case columnar.TypeTimestamp:
+		w.i32(6, convertedTimestampMicros)
+	}
+	w.endStruct()
+}
+
+func physicalType(typ columnar.Type) int32 {
+	switch typ {
+	case columnar.TypeBoolean:
+		return typeBoolean
+	case columnar.TypeLong, columnar.TypeTimestamp:
+		return typeInt64
+	case columnar.TypeDouble:
+		return typeDouble
+	}
+	return typeByteArray
+}
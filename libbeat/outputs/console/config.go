@@ -17,15 +17,72 @@
 
 package console
 
-import "github.com/elastic/beats/v7/libbeat/outputs/codec"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+)
 
 type Config struct {
 	Codec codec.Config `config:"codec"`
 
+	// OnSerializationError selects how events failing to be encoded are handled.
+	OnSerializationError failpolicy.Policy `config:"on_serialization_error"`
+
 	// old pretty settings to use if no codec is configured
 	Pretty bool `config:"pretty"`
 
+	// Format selects the ndjson, pretty or table output, instead of a codec.
+	Format string `config:"format"`
+
+	// IncludeFields and ExcludeFields select the fields written. The included
+	// fields are the columns of the table format.
+	IncludeFields []string `config:"include_fields"`
+	ExcludeFields []string `config:"exclude_fields"`
+
+	// Color highlights error events: auto, always or never.
+	Color string `config:"color"`
+
 	BatchSize int
 }
 
-var defaultConfig = Config{}
+const (
+	formatNDJSON = "ndjson"
+	formatPretty = "pretty"
+	formatTable  = "table"
+
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+var defaultConfig = Config{
+	Color:                colorAuto,
+	OnSerializationError: failpolicy.DropEvent,
+}
+
+func (c *Config) Validate() error {
+	switch c.Format {
+	case "", formatNDJSON, formatPretty, formatTable:
+	default:
+		return fmt.Errorf("unsupported format '%v', use %v, %v or %v", c.Format, formatNDJSON, formatPretty, formatTable)
+	}
+
+	if c.Format != "" && c.Codec.Namespace.IsSet() {
+		return errors.New("format can not be combined with codec")
+	}
+
+	if c.Format == formatTable && len(c.IncludeFields) == 0 {
+		return errors.New("the table format requires include_fields to select the columns")
+	}
+
+	switch c.Color {
+	case colorAuto, colorAlways, colorNever:
+	default:
+		return fmt.Errorf("unsupported color '%v', use %v, %v or %v", c.Color, colorAuto, colorAlways, colorNever)
+	}
+
+	return codec.CheckFailurePolicy(c.OnSerializationError)
+}
@@ -25,6 +25,9 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/fatih/color"
+	"github.com/mattn/go-colorable"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -41,6 +44,10 @@ type console struct {
 	writer   *bufio.Writer
 	codec    codec.Codec
 	index    string
+
+	fields    fieldSelector
+	table     *tableCodec  // Set if the table format is used, may be nil.
+	highlight *color.Color // Optional color of error events, may be nil.
 }
 
 type consoleEvent struct {
@@ -67,24 +74,38 @@ func makeConsole(
 	}
 
 	var enc codec.Codec
-	if config.Codec.Namespace.IsSet() {
+	switch {
+	case config.Codec.Namespace.IsSet():
 		enc, err = codec.CreateEncoder(beat, config.Codec)
 		if err != nil {
 			return outputs.Fail(err)
 		}
-	} else {
+	case config.Format == formatTable:
+		enc = newTableCodec(config.IncludeFields)
+	default:
 		enc = json.New(beat.Version, json.Config{
-			Pretty:     config.Pretty,
+			Pretty:     config.Format == formatPretty || (config.Format == "" && config.Pretty),
 			EscapeHTML: false,
 		})
 	}
 
+	enc = codec.WithFailurePolicy(enc, config.OnSerializationError)
+
 	index := beat.Beat
 	c, err := newConsole(index, observer, enc)
 	if err != nil {
 		return outputs.Fail(fmt.Errorf("console output initialization failed with: %v", err))
 	}
 
+	c.fields = fieldSelector{include: config.IncludeFields, exclude: config.ExcludeFields}
+	c.table, _ = enc.(*tableCodec)
+	if config.Color == colorAlways || (config.Color == colorAuto && !color.NoColor) {
+		c.highlight = color.New(color.FgRed)
+		c.highlight.EnableColor()
+		// translate the color codes on Windows
+		c.writer = bufio.NewWriterSize(colorable.NewColorable(c.out), 8*1024)
+	}
+
 	// check stdout actually being available
 	if runtime.GOOS != "windows" {
 		if _, err = c.out.Stat(); err != nil {
@@ -128,7 +149,10 @@ func (c *console) Publish(_ context.Context, batch publisher.Batch) error {
 var nl = []byte("\n")
 
 func (c *console) publishEvent(event *publisher.Event) bool {
-	serializedEvent, err := c.codec.Encode(c.index, &event.Content)
+	content := event.Content
+	content.Fields = c.fields.apply(content.Fields)
+
+	serializedEvent, err := c.codec.Encode(c.index, &content)
 	if err != nil {
 		if !event.Guaranteed() {
 			return false
@@ -139,6 +163,20 @@ func (c *console) publishEvent(event *publisher.Event) bool {
 		return false
 	}
 
+	if c.table != nil {
+		if header := c.table.pendingHeader(); header != nil {
+			if err := c.writeBuffer(append(header, '\n')); err != nil {
+				c.observer.WriteError(err)
+				c.log.Errorf("Unable to write table header to console: %+v", err)
+				return false
+			}
+		}
+	}
+
+	if c.highlight != nil && isErrorEvent(event.Content.Fields) {
+		serializedEvent = []byte(c.highlight.Sprint(string(serializedEvent)))
+	}
+
 	if err := c.writeBuffer(serializedEvent); err != nil {
 		c.observer.WriteError(err)
 		c.log.Errorf("Unable to publish events to console: %+v", err)
@@ -22,10 +22,13 @@ package console
 import (
 	"bytes"
 	"context"
+	stdjson "encoding/json"
 	"io"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -127,9 +130,118 @@ func TestConsoleOutput(t *testing.T) {
 	}
 }
 
+func TestConsoleOutputTable(t *testing.T) {
+	table := newTableCodec([]string{"@timestamp", "monitor.id", "monitor.status", "error.message"})
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	batch := outest.NewBatch(
+		beat.Event{Timestamp: ts, Fields: common.MapStr{
+			"monitor": common.MapStr{"id": "a", "status": "up"},
+		}},
+		beat.Event{Timestamp: ts, Fields: common.MapStr{
+			"monitor": common.MapStr{"id": "long-id", "status": "down"},
+			"error":   common.MapStr{"message": "connection refused"},
+		}},
+	)
+
+	lines, err := runWith(func(c *console) { c.table = table }, table, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"@timestamp                monitor.id  monitor.status  error.message\n"+
+		"2020-01-02T03:04:05.000Z  a           up              -\n"+
+		"@timestamp                monitor.id  monitor.status  error.message\n"+
+		"2020-01-02T03:04:05.000Z  long-id     down            connection refused\n",
+		lines)
+}
+
+func TestConsoleOutputFields(t *testing.T) {
+	enc := json.New("1.2.3", json.Config{})
+	fields := common.MapStr{
+		"message": "hello",
+		"host":    common.MapStr{"name": "a", "ip": "10.0.0.1"},
+		"agent":   common.MapStr{"id": "x"},
+	}
+
+	expected := map[string]interface{}{
+		"message": "hello",
+		"host":    map[string]interface{}{"name": "a"},
+	}
+	tests := map[string]fieldSelector{
+		"include":             {include: []string{"message", "host.name"}},
+		"exclude":             {exclude: []string{"agent", "host.ip"}},
+		"include and exclude": {include: []string{"message", "host"}, exclude: []string{"host.ip"}},
+	}
+
+	for name, selector := range tests {
+		selector := selector
+		t.Run(name, func(t *testing.T) {
+			batch := outest.NewBatch(beat.Event{Fields: fields})
+			lines, err := runWith(func(c *console) { c.fields = selector }, enc, batch)
+			assert.NoError(t, err)
+
+			var written map[string]interface{}
+			assert.NoError(t, stdjson.Unmarshal([]byte(lines), &written))
+			delete(written, "@timestamp")
+			delete(written, "@metadata")
+			assert.Equal(t, expected, written)
+		})
+	}
+
+	// The events are not modified
+	assert.Equal(t, "10.0.0.1", fields["host"].(common.MapStr)["ip"])
+}
+
+func TestConsoleOutputHighlight(t *testing.T) {
+	highlight := color.New(color.FgRed)
+	highlight.EnableColor()
+	enc := format.New(fmtstr.MustCompileEvent("%{[message]}"))
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"message": "ok"}},
+		beat.Event{Fields: common.MapStr{"message": "failed", "error": common.MapStr{"message": "boom"}}},
+		beat.Event{Fields: common.MapStr{"message": "crashed", "log": common.MapStr{"level": "ERROR"}}},
+	)
+
+	lines, err := runWith(func(c *console) { c.highlight = highlight }, enc, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok\n"+highlight.Sprint("failed")+"\n"+highlight.Sprint("crashed")+"\n", lines)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		ok     bool
+	}{
+		"default":             {map[string]interface{}{}, true},
+		"pretty":              {map[string]interface{}{"format": "pretty"}, true},
+		"table":               {map[string]interface{}{"format": "table", "include_fields": []string{"message"}}, true},
+		"table without cols":  {map[string]interface{}{"format": "table"}, false},
+		"unknown format":      {map[string]interface{}{"format": "yaml"}, false},
+		"format and codec":    {map[string]interface{}{"format": "pretty", "codec.format.string": "%{[message]}"}, false},
+		"color never":         {map[string]interface{}{"color": "never"}, true},
+		"unknown color value": {map[string]interface{}{"color": "rainbow"}, false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func run(codec codec.Codec, batches ...publisher.Batch) (string, error) {
+	return runWith(func(*console) {}, codec, batches...)
+}
+
+func runWith(configure func(*console), codec codec.Codec, batches ...publisher.Batch) (string, error) {
 	return withStdout(func() {
 		c, _ := newConsole("test", outputs.NewNilObserver(), codec)
+		configure(c)
 		for _, b := range batches {
 			c.Publish(context.Background(), b)
 		}
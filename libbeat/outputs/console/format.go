@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// fieldSelector selects the fields of the events written.
+type fieldSelector struct {
+	include []string
+	exclude []string
+}
+
+// apply returns the selected fields. The given fields are not modified.
+func (s fieldSelector) apply(fields common.MapStr) common.MapStr {
+	if len(s.include) == 0 && len(s.exclude) == 0 {
+		return fields
+	}
+
+	selected := fields
+	if len(s.include) > 0 {
+		selected = common.MapStr{}
+		for _, name := range s.include {
+			if v, err := fields.GetValue(name); err == nil {
+				selected.Put(name, v)
+			}
+		}
+	}
+
+	if len(s.exclude) > 0 {
+		// included objects are shared with the event, clone them before deleting
+		selected = selected.Clone()
+		for _, name := range s.exclude {
+			selected.Delete(name)
+		}
+	}
+	return selected
+}
+
+// isErrorEvent returns true if the event has an `error` field, or a
+// `log.level` of error or higher.
+func isErrorEvent(fields common.MapStr) bool {
+	if v, err := fields.GetValue("error"); err == nil && v != nil {
+		return true
+	}
+
+	if level, err := fields.GetValue("log.level"); err == nil {
+		switch strings.ToLower(fmt.Sprint(level)) {
+		case "error", "critical", "fatal", "alert", "emergency":
+			return true
+		}
+	}
+	return false
+}
+
+// tableCodec encodes events as table rows with a column per field. Columns
+// are padded to the widest value written so far. The header is written again
+// when columns get wider, to keep it aligned with the rows.
+type tableCodec struct {
+	columns       []string
+	widths        []int
+	headerPending bool
+}
+
+func newTableCodec(columns []string) *tableCodec {
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = utf8.RuneCountInString(column)
+	}
+	return &tableCodec{columns: columns, widths: widths, headerPending: true}
+}
+
+// pendingHeader returns the header row if it has not been written yet, or if
+// columns got wider since it was written. It returns nil otherwise.
+func (t *tableCodec) pendingHeader() []byte {
+	if !t.headerPending {
+		return nil
+	}
+	t.headerPending = false
+	return t.render(t.columns)
+}
+
+func (t *tableCodec) Encode(_ string, event *beat.Event) ([]byte, error) {
+	cells := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		var v interface{}
+		if column == "@timestamp" {
+			v = event.Timestamp
+		} else {
+			v, _ = event.Fields.GetValue(column)
+		}
+
+		cell, err := tableCell(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format field %v: %v", column, err)
+		}
+		cells[i] = cell
+
+		if width := utf8.RuneCountInString(cell); width > t.widths[i] {
+			t.widths[i] = width
+			t.headerPending = true
+		}
+	}
+	return t.render(cells), nil
+}
+
+func (t *tableCodec) render(cells []string) []byte {
+	var b strings.Builder
+	for i, cell := range cells {
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", t.widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+	}
+	return []byte(b.String())
+}
+
+// tableCell formats a value to fit on one line. Objects and arrays are JSON
+// encoded.
+func tableCell(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "-", nil
+	case string:
+		return strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(v), nil
+	case time.Time:
+		return common.Time(v).String(), nil
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
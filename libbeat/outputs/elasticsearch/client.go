@@ -30,6 +30,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/clockskew"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/outputs"
@@ -81,19 +82,25 @@ func NewClient(
 	}
 
 	conn, err := eslegclient.NewConnection(eslegclient.ConnectionSettings{
-		URL:              s.URL,
-		Username:         s.Username,
-		Password:         s.Password,
-		APIKey:           s.APIKey,
-		Headers:          s.Headers,
-		TLS:              s.TLS,
-		Kerberos:         s.Kerberos,
-		Proxy:            s.Proxy,
-		ProxyDisable:     s.ProxyDisable,
-		Parameters:       s.Parameters,
-		CompressionLevel: s.CompressionLevel,
-		EscapeHTML:       s.EscapeHTML,
-		Timeout:          s.Timeout,
+		URL:                s.URL,
+		Username:           s.Username,
+		Password:           s.Password,
+		APIKey:             s.APIKey,
+		Headers:            s.Headers,
+		TLS:                s.TLS,
+		Kerberos:           s.Kerberos,
+		Proxy:              s.Proxy,
+		ProxyDisable:       s.ProxyDisable,
+		Network:            s.Network,
+		ClockSkew:          s.ClockSkew,
+		Parameters:         s.Parameters,
+		CompressionLevel:   s.CompressionLevel,
+		EscapeHTML:         s.EscapeHTML,
+		Timeout:            s.Timeout,
+		IdleConnTimeout:    s.IdleConnTimeout,
+		HTTP2:              s.HTTP2,
+		ConnMaxLifetime:    s.ConnMaxLifetime,
+		DNSRefreshInterval: s.DNSRefreshInterval,
 	})
 	if err != nil {
 		return nil, err
@@ -152,19 +159,25 @@ func (client *Client) Clone() *Client {
 				// Without the following nil check on proxyURL, a nil Proxy field will try
 				// reloading proxy settings from the environment instead of leaving them
 				// empty.
-				ProxyDisable:      client.conn.Proxy == nil,
-				TLS:               client.conn.TLS,
-				Kerberos:          client.conn.Kerberos,
-				Username:          client.conn.Username,
-				Password:          client.conn.Password,
-				APIKey:            client.conn.APIKey,
-				Parameters:        nil, // XXX: do not pass params?
-				Headers:           client.conn.Headers,
-				Timeout:           client.conn.Timeout,
-				CompressionLevel:  client.conn.CompressionLevel,
-				OnConnectCallback: nil,
-				Observer:          nil,
-				EscapeHTML:        false,
+				ProxyDisable:       client.conn.Proxy == nil,
+				Network:            client.conn.Network,
+				ClockSkew:          client.conn.ClockSkew,
+				TLS:                client.conn.TLS,
+				Kerberos:           client.conn.Kerberos,
+				Username:           client.conn.Username,
+				Password:           client.conn.Password,
+				APIKey:             client.conn.APIKey,
+				Parameters:         nil, // XXX: do not pass params?
+				Headers:            client.conn.Headers,
+				Timeout:            client.conn.Timeout,
+				CompressionLevel:   client.conn.CompressionLevel,
+				OnConnectCallback:  nil,
+				Observer:           nil,
+				EscapeHTML:         false,
+				HTTP2:              client.conn.HTTP2,
+				IdleConnTimeout:    client.conn.IdleConnTimeout,
+				ConnMaxLifetime:    client.conn.ConnMaxLifetime,
+				DNSRefreshInterval: client.conn.DNSRefreshInterval,
 			},
 			Index:    client.index,
 			Pipeline: client.pipeline,
@@ -206,7 +219,7 @@ func (client *Client) publishEvents(ctx context.Context, data []publisher.Event)
 	// events slice
 	origCount := len(data)
 	span.Context.SetLabel("events_original", origCount)
-	data, bulkItems := bulkEncodePublishRequest(client.log, client.conn.GetVersion(), client.index, client.pipeline, data)
+	data, bulkItems := bulkEncodePublishRequest(client.log, client.conn.GetVersion(), client.index, client.pipeline, client.conn.ClockSkew, data)
 	newCount := len(data)
 	span.Context.SetLabel("events_encoded", newCount)
 	if st != nil && origCount > newCount {
@@ -265,18 +278,23 @@ func (client *Client) publishEvents(ctx context.Context, data []publisher.Event)
 
 // bulkEncodePublishRequest encodes all bulk requests and returns slice of events
 // successfully added to the list of bulk items and the list of bulk items.
+// If clockSkew is set, events are corrected for the detected clock skew before
+// the index and pipeline are selected. The original events are not modified,
+// so they can be corrected again when being retried.
 func bulkEncodePublishRequest(
 	log *logp.Logger,
 	version common.Version,
 	index outputs.IndexSelector,
 	pipeline *outil.Selector,
+	clockSkew *clockskew.Detector,
 	data []publisher.Event,
 ) ([]publisher.Event, []interface{}) {
 
+	now := time.Now()
 	okEvents := data[:0]
 	bulkItems := []interface{}{}
 	for i := range data {
-		event := &data[i].Content
+		event := clockSkew.Correct(&data[i].Content, now)
 		meta, err := createEventBulkMeta(log, version, index, pipeline, event)
 		if err != nil {
 			log.Errorf("Failed to encode event meta data: %+v", err)
@@ -21,7 +21,9 @@ package elasticsearch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -34,6 +36,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	e "github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/clockskew"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/beats/v7/libbeat/idxmgmt"
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -249,6 +253,77 @@ func TestClientWithHeaders(t *testing.T) {
 	assert.Equal(t, 2, requestCount)
 }
 
+func TestClientPublishCorrectsClockSkew(t *testing.T) {
+	// Elasticsearch clock is one hour ahead of the local clock.
+	skew := time.Hour
+	var bulkBody []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(skew).UTC().Format(http.TimeFormat))
+
+		var response string
+		if r.URL.Path == "/" {
+			response = `{ "version": { "number": "7.6.0" } }`
+		} else {
+			bulkBody, _ = ioutil.ReadAll(r.Body)
+			response = `{"items":[{"index":{}}]}`
+		}
+		fmt.Fprintln(w, response)
+	}))
+	defer ts.Close()
+
+	indexSel, err := outil.FmtSelectorExpr(fmtstr.MustCompileEvent("test-%{+yyyy.MM.dd}"), "", outil.SelectorLowerCase)
+	require.NoError(t, err)
+
+	client, err := NewClient(ClientSettings{
+		ConnectionSettings: eslegclient.ConnectionSettings{
+			URL: ts.URL,
+			ClockSkew: clockskew.NewDetector(clockskew.Config{
+				Enabled:         true,
+				Source:          clockskew.SourceElasticsearch,
+				Threshold:       5 * time.Second,
+				AdjustTimestamp: true,
+			}),
+		},
+		Index: outil.MakeSelector(indexSel),
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Connect())
+
+	// The local timestamp is on the day before the corrected timestamp.
+	timestamp := time.Date(2020, 1, 1, 23, 30, 0, 0, time.UTC)
+	event := beat.Event{
+		Timestamp: timestamp,
+		Fields:    common.MapStr{"message": "Test message from libbeat"},
+	}
+
+	batch := outest.NewBatch(event)
+	require.NoError(t, client.Publish(context.Background(), batch))
+
+	lines := strings.Split(strings.TrimSpace(string(bulkBody)), "\n")
+	require.Len(t, lines, 2)
+
+	var meta struct {
+		Index struct {
+			Index string `json:"_index"`
+		} `json:"index"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &meta))
+	assert.Equal(t, "test-2020.01.02", meta.Index.Index)
+
+	var doc struct {
+		Timestamp time.Time `json:"@timestamp"`
+		Event     struct {
+			IngestedDelay int64 `json:"ingested_delay"`
+		} `json:"event"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &doc))
+	assert.True(t, doc.Timestamp.Sub(timestamp) > skew-5*time.Second)
+	assert.True(t, doc.Event.IngestedDelay > 0)
+
+	// The original event is left untouched for retries.
+	assert.Equal(t, timestamp, batch.Events()[0].Content.Timestamp)
+}
+
 func TestBulkEncodeEvents(t *testing.T) {
 	cases := map[string]struct {
 		version string
@@ -295,7 +370,7 @@ func TestBulkEncodeEvents(t *testing.T) {
 				}
 			}
 
-			encoded, bulkItems := bulkEncodePublishRequest(logp.L(), *common.MustNewVersion(test.version), index, pipeline, events)
+			encoded, bulkItems := bulkEncodePublishRequest(logp.L(), *common.MustNewVersion(test.version), index, pipeline, nil, events)
 			assert.Equal(t, len(events), len(encoded), "all events should have been encoded")
 			assert.Equal(t, 2*len(events), len(bulkItems), "incomplete bulk")
 
@@ -361,7 +436,7 @@ func TestBulkEncodeEventsWithOpType(t *testing.T) {
 		}
 	}
 
-	encoded, bulkItems := bulkEncodePublishRequest(logp.L(), *common.MustNewVersion(version.GetDefaultVersion()), index, pipeline, events)
+	encoded, bulkItems := bulkEncodePublishRequest(logp.L(), *common.MustNewVersion(version.GetDefaultVersion()), index, pipeline, nil, events)
 	require.Equal(t, len(events)-1, len(encoded), "all events should have been encoded")
 	require.Equal(t, 9, len(bulkItems), "incomplete bulk")
 
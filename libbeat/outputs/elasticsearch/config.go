@@ -22,29 +22,39 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/clockskew"
+	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 )
 
 type elasticsearchConfig struct {
-	Protocol         string            `config:"protocol"`
-	Path             string            `config:"path"`
-	Params           map[string]string `config:"parameters"`
-	Headers          map[string]string `config:"headers"`
-	Username         string            `config:"username"`
-	Password         string            `config:"password"`
-	APIKey           string            `config:"api_key"`
-	ProxyURL         string            `config:"proxy_url"`
-	ProxyDisable     bool              `config:"proxy_disable"`
-	LoadBalance      bool              `config:"loadbalance"`
-	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
-	EscapeHTML       bool              `config:"escape_html"`
-	TLS              *tlscommon.Config `config:"ssl"`
-	Kerberos         *kerberos.Config  `config:"kerberos"`
-	BulkMaxSize      int               `config:"bulk_max_size"`
-	MaxRetries       int               `config:"max_retries"`
-	Timeout          time.Duration     `config:"timeout"`
-	Backoff          Backoff           `config:"backoff"`
+	Protocol         string             `config:"protocol"`
+	Path             string             `config:"path"`
+	Params           map[string]string  `config:"parameters"`
+	Headers          map[string]string  `config:"headers"`
+	Username         string             `config:"username"`
+	Password         string             `config:"password"`
+	APIKey           string             `config:"api_key"`
+	ProxyURL         string             `config:"proxy_url"`
+	ProxyDisable     bool               `config:"proxy_disable"`
+	Network          transport.IPFamily `config:"network"`
+	LoadBalance      bool               `config:"loadbalance"`
+	Ordered          bool               `config:"ordered"`
+	CompressionLevel int                `config:"compression_level" validate:"min=0, max=9"`
+	EscapeHTML       bool               `config:"escape_html"`
+	TLS              *tlscommon.Config  `config:"ssl"`
+	Kerberos         *kerberos.Config   `config:"kerberos"`
+	BulkMaxSize      int                `config:"bulk_max_size"`
+	MaxRetries       int                `config:"max_retries"`
+	Timeout          time.Duration      `config:"timeout"`
+	Backoff          Backoff            `config:"backoff"`
+	ClockSkew        clockskew.Config   `config:"clock_skew"`
+
+	HTTP2              bool          `config:"http2"`
+	IdleConnTimeout    time.Duration `config:"idle_connection_timeout" validate:"min=0"`
+	ConnMaxLifetime    time.Duration `config:"connection_max_lifetime" validate:"min=0"`
+	DNSRefreshInterval time.Duration `config:"dns_refresh_interval" validate:"min=0"`
 }
 
 type Backoff struct {
@@ -77,6 +87,8 @@ var (
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
+		ClockSkew:       clockskew.DefaultConfig(),
+		IdleConnTimeout: 1 * time.Minute,
 	}
 )
 
@@ -91,5 +103,11 @@ func (c *elasticsearchConfig) Validate() error {
 		return fmt.Errorf("cannot set both api_key and username/password")
 	}
 
+	if c.Kerberos.IsEnabled() && c.Kerberos.UsesCCache() {
+		// The tickets of a credential cache are renewed by external tools,
+		// but connections keep the client created on startup.
+		return fmt.Errorf("kerberos auth_type ccache is not supported by the elasticsearch output")
+	}
+
 	return nil
 }
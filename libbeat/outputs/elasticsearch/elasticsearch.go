@@ -22,6 +22,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/clockskew"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -82,6 +83,9 @@ func makeES(
 		params = nil
 	}
 
+	// All clients share the detector, as they connect to the same cluster.
+	clockSkew := clockskew.NewDetector(config.ClockSkew)
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		esURL, err := common.MakeURL(config.Protocol, config.Path, host, 9200)
@@ -93,20 +97,26 @@ func makeES(
 		var client outputs.NetworkClient
 		client, err = NewClient(ClientSettings{
 			ConnectionSettings: eslegclient.ConnectionSettings{
-				URL:              esURL,
-				Proxy:            proxyURL,
-				ProxyDisable:     config.ProxyDisable,
-				TLS:              tlsConfig,
-				Kerberos:         config.Kerberos,
-				Username:         config.Username,
-				Password:         config.Password,
-				APIKey:           config.APIKey,
-				Parameters:       params,
-				Headers:          config.Headers,
-				Timeout:          config.Timeout,
-				CompressionLevel: config.CompressionLevel,
-				Observer:         observer,
-				EscapeHTML:       config.EscapeHTML,
+				URL:                esURL,
+				Proxy:              proxyURL,
+				ProxyDisable:       config.ProxyDisable,
+				Network:            config.Network,
+				ClockSkew:          clockSkew,
+				TLS:                tlsConfig,
+				Kerberos:           config.Kerberos,
+				Username:           config.Username,
+				Password:           config.Password,
+				APIKey:             config.APIKey,
+				Parameters:         params,
+				Headers:            config.Headers,
+				Timeout:            config.Timeout,
+				CompressionLevel:   config.CompressionLevel,
+				Observer:           observer,
+				EscapeHTML:         config.EscapeHTML,
+				HTTP2:              config.HTTP2,
+				IdleConnTimeout:    config.IdleConnTimeout,
+				ConnMaxLifetime:    config.ConnMaxLifetime,
+				DNSRefreshInterval: config.DNSRefreshInterval,
 			},
 			Index:    index,
 			Pipeline: pipeline,
@@ -120,6 +130,10 @@ func makeES(
 		clients[i] = client
 	}
 
+	if config.Ordered {
+		// Bulk requests are synchronous, only one batch is in flight.
+		return outputs.SuccessOrdered(1, config.BulkMaxSize, config.MaxRetries, clients)
+	}
 	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
 }
 
@@ -131,3 +131,18 @@ func TestPipelineSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigKerberosCCacheUnsupported(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"kerberos": map[string]interface{}{
+			"auth_type":   "ccache",
+			"ccache":      "/tmp/krb5cc_1000",
+			"config_path": "/etc/krb5.conf",
+		},
+	})
+
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err == nil {
+		t.Fatal("Expected ccache to be rejected by the elasticsearch output")
+	}
+}
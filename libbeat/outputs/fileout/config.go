@@ -19,25 +19,35 @@ package fileout
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
 	"github.com/elastic/beats/v7/libbeat/common/file"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 )
 
 type config struct {
-	Path          string       `config:"path"`
-	Filename      string       `config:"filename"`
-	RotateEveryKb uint         `config:"rotate_every_kb" validate:"min=1"`
-	NumberOfFiles uint         `config:"number_of_files"`
-	Codec         codec.Config `config:"codec"`
-	Permissions   uint32       `config:"permissions"`
+	Path           string        `config:"path"`
+	Filename       string        `config:"filename"`
+	RotateEveryKb  uint          `config:"rotate_every_kb" validate:"min=1"`
+	RotateInterval time.Duration `config:"rotate_interval"`
+	NumberOfFiles  uint          `config:"number_of_files"`
+	Compression    string        `config:"compression"`
+	MaxAge         time.Duration `config:"max_age"`
+	MaxTotalSizeKb uint64        `config:"max_total_size_kb"`
+	Codec          codec.Config  `config:"codec"`
+	Permissions    uint32        `config:"permissions"`
+
+	// OnSerializationError selects how events failing to be encoded are handled.
+	OnSerializationError failpolicy.Policy `config:"on_serialization_error"`
 }
 
 var (
 	defaultConfig = config{
-		NumberOfFiles: 7,
-		RotateEveryKb: 10 * 1024,
-		Permissions:   0600,
+		NumberOfFiles:        7,
+		RotateEveryKb:        10 * 1024,
+		Permissions:          0600,
+		OnSerializationError: failpolicy.DropEvent,
 	}
 )
 
@@ -47,5 +57,19 @@ func (c *config) Validate() error {
 			file.MaxBackupsLimit)
 	}
 
-	return nil
+	if c.RotateInterval != 0 && c.RotateInterval < time.Second {
+		return fmt.Errorf("The rotate_interval must be at least 1s")
+	}
+
+	switch c.Compression {
+	case "", "gzip", "zstd":
+	default:
+		return fmt.Errorf("Unsupported compression '%v', use gzip or zstd", c.Compression)
+	}
+
+	if c.MaxAge < 0 {
+		return fmt.Errorf("The max_age must not be negative")
+	}
+
+	return codec.CheckFailurePolicy(c.OnSerializationError)
 }
@@ -19,11 +19,16 @@ package fileout
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
 	"github.com/elastic/beats/v7/libbeat/common/file"
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/outputs"
@@ -42,6 +47,12 @@ type fileOutput struct {
 	observer outputs.Observer
 	rotator  *file.Rotator
 	codec    codec.Codec
+	// batchCodec is set if the codec encodes whole batches, each batch is
+	// written to its own file instead of the rotated file
+	batchCodec  codec.BatchCodec
+	batchPolicy failpolicy.Policy
+	permissions os.FileMode
+	seq         uint64
 }
 
 // makeFileout instantiates a new file output instance.
@@ -82,10 +93,32 @@ func (out *fileOutput) init(beat beat.Info, c config) error {
 	out.filePath = path
 
 	var err error
+	out.codec, err = codec.CreateEncoder(beat, c.Codec)
+	if err != nil {
+		return err
+	}
+	if bc, ok := out.codec.(codec.BatchCodec); ok {
+		out.batchCodec = bc
+		out.batchPolicy = c.OnSerializationError
+		out.permissions = os.FileMode(c.Permissions)
+		if err := os.MkdirAll(filepath.Dir(path), dirMode(out.permissions)); err != nil {
+			return err
+		}
+
+		out.log.Infof("Initialized file output. path=%v format=%v permissions=%v",
+			path, c.Codec.Namespace.Name(), out.permissions)
+		return nil
+	}
+	out.codec = codec.WithFailurePolicy(out.codec, c.OnSerializationError)
+
 	out.rotator, err = file.NewFileRotator(
 		path,
 		file.MaxSizeBytes(c.RotateEveryKb*1024),
 		file.MaxBackups(c.NumberOfFiles),
+		file.Interval(c.RotateInterval),
+		file.Compression(c.Compression),
+		file.MaxAge(c.MaxAge),
+		file.MaxTotalSizeBytes(c.MaxTotalSizeKb*1024),
 		file.Permissions(os.FileMode(c.Permissions)),
 		file.WithLogger(logp.NewLogger("rotator").With(logp.Namespace("rotator"))),
 	)
@@ -93,20 +126,20 @@ func (out *fileOutput) init(beat beat.Info, c config) error {
 		return err
 	}
 
-	out.codec, err = codec.CreateEncoder(beat, c.Codec)
-	if err != nil {
-		return err
-	}
-
 	out.log.Infof("Initialized file output. "+
-		"path=%v max_size_bytes=%v max_backups=%v permissions=%v",
-		path, c.RotateEveryKb*1024, c.NumberOfFiles, os.FileMode(c.Permissions))
+		"path=%v max_size_bytes=%v max_backups=%v rotate_interval=%v compression=%v "+
+		"max_age=%v max_total_size_bytes=%v permissions=%v",
+		path, c.RotateEveryKb*1024, c.NumberOfFiles, c.RotateInterval, c.Compression,
+		c.MaxAge, c.MaxTotalSizeKb*1024, os.FileMode(c.Permissions))
 
 	return nil
 }
 
 // Implement Outputer
 func (out *fileOutput) Close() error {
+	if out.rotator == nil {
+		return nil
+	}
 	return out.rotator.Close()
 }
 
@@ -117,6 +150,11 @@ func (out *fileOutput) Publish(_ context.Context, batch publisher.Batch) error {
 	events := batch.Events()
 	st.NewBatch(len(events))
 
+	if out.batchCodec != nil {
+		out.publishBatch(events)
+		return nil
+	}
+
 	dropped := 0
 	for i := range events {
 		event := &events[i]
@@ -156,6 +194,72 @@ func (out *fileOutput) Publish(_ context.Context, batch publisher.Batch) error {
 	return nil
 }
 
+// publishBatch writes the events encoded by the batch codec to a file of their
+// own. The failure policy is applied to each event failing to be encoded.
+func (out *fileOutput) publishBatch(events []publisher.Event) {
+	st := out.observer
+	contents := make([]*beat.Event, len(events))
+	for i := range events {
+		contents[i] = &events[i].Content
+	}
+
+	serialized, dropped, err := codec.EncodeBatchWithFailurePolicy(out.batchCodec, out.batchPolicy, out.beat.Beat, contents)
+	if err != nil {
+		out.log.Errorf("Failed to serialize %d events: %+v", len(events), err)
+		st.Dropped(len(events))
+		return
+	}
+	if dropped > 0 {
+		out.log.Warnf("Dropped %d of %d events failing to be serialized", dropped, len(events))
+		st.Dropped(dropped)
+	}
+	if serialized == nil {
+		return
+	}
+
+	if err := out.writeBatchFile(serialized); err != nil {
+		st.WriteError(err)
+		out.log.Errorf("Writing %d events to file failed with: %+v", len(events)-dropped, err)
+		st.Dropped(len(events) - dropped)
+		return
+	}
+
+	st.WriteBytes(len(serialized))
+	st.Acked(len(events) - dropped)
+}
+
+// writeBatchFile writes an encoded batch to a new file, named after the path
+// of the output with the time and a sequence number, and the extension of the
+// codec. The file is written under a temporary name and renamed once complete,
+// so that readers never see partial files. The files are not rotated or
+// removed by the output.
+func (out *fileOutput) writeBatchFile(data []byte) error {
+	ext := out.batchCodec.Extension()
+	out.seq++
+	name := fmt.Sprintf("%s-%s-%06d%s",
+		strings.TrimSuffix(out.filePath, ext), time.Now().UTC().Format("20060102T150405.000"), out.seq, ext)
+
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, out.permissions); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// dirMode returns the permissions of the directory of the batch files, like
+// the rotator does for the rotated files.
+func dirMode(permissions os.FileMode) os.FileMode {
+	mode := os.FileMode(0700)
+	if permissions&0070 > 0 {
+		mode |= 0050
+	}
+	if permissions&0007 > 0 {
+		mode |= 0005
+	}
+	return mode
+}
+
 func (out *fileOutput) String() string {
 	return "file(" + out.filePath + ")"
 }
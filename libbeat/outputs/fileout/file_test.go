@@ -18,3 +18,137 @@
 // +build !integration
 
 package fileout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	_ "github.com/elastic/beats/v7/libbeat/outputs/codec/parquet"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+)
+
+func TestPublishBatchCodec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileout")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"path":     dir,
+		"filename": "events.parquet",
+		"codec.parquet": map[string]interface{}{
+			"compression": "none",
+		},
+	})
+	config := defaultConfig
+	require.NoError(t, cfg.Unpack(&config))
+
+	info := beat.Info{Beat: "testbeat", Version: "7.10.0"}
+	out := &fileOutput{log: logp.NewLogger("file"), beat: info, observer: outputs.NewNilObserver()}
+	require.NoError(t, out.init(info, config))
+
+	for _, message := range []string{"hello", "world"} {
+		batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"message": message}})
+		require.NoError(t, out.Publish(context.Background(), batch))
+		assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	}
+	require.NoError(t, out.Close())
+
+	// Each batch is written to its own parquet file
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for i, path := range files {
+		assert.Regexp(t, fmt.Sprintf(`^events-\d{8}T\d{6}\.\d{3}-%06d\.parquet$`, i+1), filepath.Base(path))
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("PAR1")), path)
+	}
+}
+
+// lineBatchCodec encodes batches as lines, and fails to encode events with an
+// `invalid` field.
+type lineBatchCodec struct{}
+
+func (lineBatchCodec) Encode(_ string, event *beat.Event) ([]byte, error) {
+	if _, ok := event.Fields["invalid"]; ok {
+		return nil, errors.New("unsupported value")
+	}
+	return []byte(event.Fields.String()), nil
+}
+
+func (c lineBatchCodec) EncodeBatch(index string, events []*beat.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := c.Encode(index, event)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	return buf.Bytes(), nil
+}
+
+func (lineBatchCodec) Extension() string {
+	return ".lines"
+}
+
+func init() {
+	codec.RegisterType("test_lines", func(_ beat.Info, _ *common.Config) (codec.Codec, error) {
+		return lineBatchCodec{}, nil
+	})
+}
+
+func TestPublishBatchCodecFailurePolicy(t *testing.T) {
+	for policy, expected := range map[string]string{
+		"drop_event":  "{\"message\":\"first\"}\n{\"message\":\"last\"}\n",
+		"dead_letter": "{\"message\":\"first\"}\n{\"error\":{\"message\":\"unsupported value\"},\"message\":\"map[invalid:1]\"}\n{\"message\":\"last\"}\n",
+	} {
+		t.Run(policy, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "fileout")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			cfg := common.MustNewConfigFrom(map[string]interface{}{
+				"path":                   dir,
+				"codec.test_lines":       map[string]interface{}{},
+				"on_serialization_error": policy,
+			})
+			config := defaultConfig
+			require.NoError(t, cfg.Unpack(&config))
+
+			info := beat.Info{Beat: "testbeat", Version: "7.10.0"}
+			out := &fileOutput{log: logp.NewLogger("file"), beat: info, observer: outputs.NewNilObserver()}
+			require.NoError(t, out.init(info, config))
+
+			batch := outest.NewBatch(
+				beat.Event{Fields: common.MapStr{"message": "first"}},
+				beat.Event{Fields: common.MapStr{"invalid": 1}},
+				beat.Event{Fields: common.MapStr{"message": "last"}},
+			)
+			require.NoError(t, out.Publish(context.Background(), batch))
+			assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+			require.NoError(t, out.Close())
+
+			files, err := filepath.Glob(filepath.Join(dir, "testbeat-*.lines"))
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			data, err := ioutil.ReadFile(files[0])
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(data))
+		})
+	}
+}
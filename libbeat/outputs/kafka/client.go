@@ -27,6 +27,7 @@ import (
 
 	"github.com/Shopify/sarama"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -50,6 +51,15 @@ type client struct {
 
 	producer sarama.AsyncProducer
 
+	exactlyOnce   exactlyOnceConfig
+	published     publishedPositions
+	readPositions func(*logp.Logger, []string, *sarama.Config, string, exactlyOnceConfig) (publishedPositions, error)
+
+	// positionsStale is set to 1 if events failed to be published. They might
+	// have been written nonetheless, so the published positions are read
+	// again before the events are retried.
+	positionsStale int32
+
 	wg sync.WaitGroup
 }
 
@@ -75,6 +85,7 @@ func newKafkaClient(
 	topic outil.Selector,
 	writer codec.Codec,
 	cfg *sarama.Config,
+	exactlyOnce exactlyOnceConfig,
 ) (*client, error) {
 	c := &client{
 		log:      logp.NewLogger(logSelector),
@@ -85,6 +96,9 @@ func newKafkaClient(
 		index:    strings.ToLower(index),
 		codec:    writer,
 		config:   *cfg,
+
+		exactlyOnce:   exactlyOnce,
+		readPositions: readPublishedPositions,
 	}
 	return c, nil
 }
@@ -95,6 +109,16 @@ func (c *client) Connect() error {
 
 	c.log.Debugf("connect: %v", c.hosts)
 
+	if c.exactlyOnce.Enabled {
+		// Events that have been published, but not ACKed before a restart or
+		// reconnect are sent again by the pipeline. Find them in the topic so
+		// they can be skipped.
+		if err := c.readPublished(); err != nil {
+			return err
+		}
+		atomic.StoreInt32(&c.positionsStale, 0)
+	}
+
 	// try to connect
 	producer, err := sarama.NewAsyncProducer(c.hosts, &c.config)
 	if err != nil {
@@ -111,6 +135,23 @@ func (c *client) Connect() error {
 	return nil
 }
 
+// readPublished reads back the positions of the events that have already
+// been published to the topic.
+func (c *client) readPublished() error {
+	topic, err := c.topic.Select(&beat.Event{})
+	if err != nil {
+		return err
+	}
+	published, err := c.readPositions(c.log, c.hosts, &c.config, topic, c.exactlyOnce)
+	if err != nil {
+		c.log.Errorf("Kafka reading published events fails with: %+v", err)
+		return err
+	}
+	c.log.Debugf("found %v published events in topic %v", len(published), topic)
+	c.published = published
+	return nil
+}
+
 func (c *client) Close() error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
@@ -128,6 +169,14 @@ func (c *client) Close() error {
 }
 
 func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
+	if c.exactlyOnce.Enabled && atomic.CompareAndSwapInt32(&c.positionsStale, 1, 0) {
+		if err := c.readPublished(); err != nil {
+			atomic.StoreInt32(&c.positionsStale, 1)
+			batch.Retry()
+			return err
+		}
+	}
+
 	events := batch.Events()
 	c.observer.NewBatch(len(events))
 
@@ -139,9 +188,19 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		batch:  batch,
 	}
 
+	skipped := 0
 	ch := c.producer.Input()
 	for i := range events {
 		d := &events[i]
+		if c.exactlyOnce.Enabled {
+			if source, offset, ok := eventPosition(&d.Content); ok && c.published.isPublished(source, offset) {
+				c.log.Debugf("Skipping event already published (source=%v, offset=%v)", source, offset)
+				skipped++
+				ref.done()
+				continue
+			}
+		}
+
 		msg, err := c.getEventMessage(d)
 		if err != nil {
 			c.log.Errorf("Dropping event: %+v", err)
@@ -155,6 +214,9 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		ch <- &msg.msg
 	}
 
+	if skipped > 0 {
+		c.log.Warnf("Skipped %v events that have already been published to Kafka", skipped)
+	}
 	return nil
 }
 
@@ -223,6 +285,15 @@ func (c *client) getEventMessage(data *publisher.Event) (*message, error) {
 		}
 	}
 
+	if c.exactlyOnce.Enabled {
+		// Key events by their source, such that all events of a source are
+		// written to the same partition in order.
+		if source, offset, ok := eventPosition(event); ok {
+			msg.key = []byte(source)
+			msg.headers = positionHeaders(source, offset)
+		}
+	}
+
 	return msg, nil
 }
 
@@ -282,6 +353,9 @@ func (r *msgRef) dec() {
 	if err != nil {
 		failed := len(r.failed)
 		success := r.total - failed
+		if r.client.exactlyOnce.Enabled {
+			atomic.StoreInt32(&r.client.positionsStale, 1)
+		}
 		r.batch.RetryEvents(r.failed)
 
 		stats.Failed(failed)
@@ -29,6 +29,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/common/kafka"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
@@ -69,6 +70,10 @@ type kafkaConfig struct {
 	Password           string                    `config:"password"`
 	Codec              codec.Config              `config:"codec"`
 	Sasl               saslConfig                `config:"sasl"`
+	ExactlyOnce        exactlyOnceConfig         `config:"exactly_once"`
+
+	// OnSerializationError selects how events failing to be encoded are handled.
+	OnSerializationError failpolicy.Policy `config:"on_serialization_error"`
 }
 
 type saslConfig struct {
@@ -138,6 +143,9 @@ func defaultConfig() kafkaConfig {
 		ChanBufferSize: 256,
 		Username:       "",
 		Password:       "",
+		ExactlyOnce:    defaultExactlyOnceConfig,
+
+		OnSerializationError: failpolicy.DropEvent,
 	}
 }
 
@@ -192,13 +200,21 @@ func (c *kafkaConfig) Validate() error {
 		return fmt.Errorf("password must be set when username is configured")
 	}
 
+	if c.Kerberos.IsEnabled() && c.Kerberos.UsesCCache() {
+		return fmt.Errorf("kerberos auth_type ccache is not supported by the kafka output")
+	}
+
 	if c.Compression == "gzip" {
 		lvl := c.CompressionLevel
 		if lvl != sarama.CompressionLevelDefault && !(0 <= lvl && lvl <= 9) {
 			return fmt.Errorf("compression_level must be between 0 and 9")
 		}
 	}
-	return nil
+
+	if err := c.ExactlyOnce.validate(c); err != nil {
+		return err
+	}
+	return codec.CheckFailurePolicy(c.OnSerializationError)
 }
 
 func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, error) {
@@ -305,6 +321,8 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 	k.Version = version
 
 	k.Producer.Partitioner = partitioner
+	config.ExactlyOnce.configureSarama(k)
+
 	k.MetricRegistry = adapter.GetGoMetrics(
 		monitoring.Default,
 		"libbeat.outputs.kafka",
@@ -40,6 +40,14 @@ func TestConfigAcceptValid(t *testing.T) {
 			"compression": "lz4",
 			"version":     "1.0.0",
 		},
+		"exactly_once with defaults": common.MapStr{
+			"exactly_once.enabled": true,
+		},
+		"exactly_once with hash partitioner": common.MapStr{
+			"exactly_once.enabled": true,
+			"required_acks":        -1,
+			"partition.hash":       common.MapStr{},
+		},
 		"Kerberos with keytab": common.MapStr{
 			"kerberos": common.MapStr{
 				"auth_type":    "keytab",
@@ -80,6 +88,22 @@ func TestConfigAcceptValid(t *testing.T) {
 
 func TestConfigInvalid(t *testing.T) {
 	tests := map[string]common.MapStr{
+		"exactly_once with old version": common.MapStr{
+			"exactly_once.enabled": true,
+			"version":              "0.10.2",
+		},
+		"exactly_once without acks from all replicas": common.MapStr{
+			"exactly_once.enabled": true,
+			"required_acks":        1,
+		},
+		"exactly_once with round_robin partitioner": common.MapStr{
+			"exactly_once.enabled":  true,
+			"partition.round_robin": common.MapStr{},
+		},
+		"exactly_once with key": common.MapStr{
+			"exactly_once.enabled": true,
+			"key":                  "%{[fields.id]}",
+		},
 		"Kerberos with invalid auth_type": common.MapStr{
 			"kerberos": common.MapStr{
 				"auth_type":    "invalid_auth_type",
@@ -88,6 +112,14 @@ func TestConfigInvalid(t *testing.T) {
 				"realm":        "ELASTIC",
 			},
 		},
+		"Kerberos with ccache": common.MapStr{
+			"kerberos": common.MapStr{
+				"auth_type":    "ccache",
+				"ccache":       "/tmp/krb5cc_1000",
+				"config_path":  "/etc/path/config",
+				"service_name": "kafka",
+			},
+		},
 	}
 
 	for name, test := range tests {
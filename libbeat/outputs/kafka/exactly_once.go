@@ -0,0 +1,271 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// Record headers storing the position of an event within its source.
+const (
+	headerSource = "beat.source"
+	headerOffset = "beat.offset"
+)
+
+type exactlyOnceConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// Lookback is the number of messages per partition that are read back on
+	// connect to find events published before a restart. It must be at least
+	// the number of events the beat can have in flight.
+	Lookback int `config:"lookback" validate:"min=1"`
+
+	// Timeout for reading back the published events of a partition.
+	Timeout time.Duration `config:"timeout" validate:"min=1"`
+}
+
+var defaultExactlyOnceConfig = exactlyOnceConfig{
+	Enabled:  false,
+	Lookback: 8192,
+	Timeout:  30 * time.Second,
+}
+
+// validate checks that the output settings guarantee the constraints of
+// exactly-once publishing: the idempotent producer must be supported and
+// enabled, and all events of a source must be written to the same partition in
+// order.
+func (c *exactlyOnceConfig) validate(config *kafkaConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if version, ok := config.Version.Get(); !ok || !version.IsAtLeast(sarama.V0_11_0_0) {
+		return errors.New("exactly_once requires version 0.11.0 or newer")
+	}
+	if config.RequiredACKs != nil && *config.RequiredACKs != int(sarama.WaitForAll) {
+		return errors.New("exactly_once requires required_acks to be -1 (wait for all replicas)")
+	}
+	if config.MaxRetries == 0 {
+		return errors.New("exactly_once requires max_retries to be -1 or greater than 0")
+	}
+	if config.Key != nil {
+		return errors.New("exactly_once can not be used with key, events are keyed by their source")
+	}
+
+	for name, cfg := range config.Partition {
+		if name != "hash" {
+			return fmt.Errorf("exactly_once requires the hash partitioner, found '%v'", name)
+		}
+
+		partition := struct {
+			Hash      []string `config:"hash"`
+			Reachable bool     `config:"reachable_only"`
+		}{}
+		if err := cfg.Unpack(&partition); err != nil {
+			return err
+		}
+		if len(partition.Hash) > 0 || partition.Reachable {
+			return errors.New("exactly_once can not be used with partition.hash.hash or partition.hash.reachable_only")
+		}
+	}
+	return nil
+}
+
+// configureSarama enables the idempotent producer, which prevents duplicates
+// when sarama retries a produce request.
+func (c *exactlyOnceConfig) configureSarama(k *sarama.Config) {
+	if !c.Enabled {
+		return
+	}
+
+	k.Producer.Idempotent = true
+	k.Producer.RequiredAcks = sarama.WaitForAll
+	k.Net.MaxOpenRequests = 1
+	if k.Producer.Retry.Max == 0 {
+		k.Producer.Retry.Max = 1
+	}
+}
+
+// publishedPositions holds the positions of the events that have been found
+// in the topic. Events at these positions have already been published and are
+// not sent again. Positions are matched exactly, such that the result does not
+// depend on the order events are published in.
+type publishedPositions map[sourcePosition]struct{}
+
+type sourcePosition struct {
+	source string
+	offset int64
+}
+
+// eventPosition returns the position of the event within its source, if the
+// input reports it.
+func eventPosition(event *beat.Event) (string, int64, bool) {
+	pos, ok := event.Private.(beat.SourcePositioner)
+	if !ok {
+		return "", 0, false
+	}
+	source, offset := pos.SourcePosition()
+	return source, offset, source != ""
+}
+
+// isPublished checks if the event at the given position has already been
+// published.
+func (p publishedPositions) isPublished(source string, offset int64) bool {
+	_, found := p[sourcePosition{source, offset}]
+	return found
+}
+
+func (p publishedPositions) add(source string, offset int64) {
+	p[sourcePosition{source, offset}] = struct{}{}
+}
+
+// positionHeaders returns the record headers storing the position of an event.
+func positionHeaders(source string, offset int64) []sarama.RecordHeader {
+	return []sarama.RecordHeader{
+		{Key: []byte(headerSource), Value: []byte(source)},
+		{Key: []byte(headerOffset), Value: []byte(strconv.FormatInt(offset, 10))},
+	}
+}
+
+// readPublishedPositions reads back the last messages of every partition of
+// topic and returns the positions of the events found.
+func readPublishedPositions(
+	log *logp.Logger,
+	hosts []string,
+	cfg *sarama.Config,
+	topic string,
+	config exactlyOnceConfig,
+) (publishedPositions, error) {
+	client, err := sarama.NewClient(hosts, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := publishedPositions{}
+	for _, partition := range partitions {
+		newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+
+		start := newest - int64(config.Lookback)
+		if start < oldest {
+			start = oldest
+		}
+		if start >= newest {
+			continue
+		}
+
+		pc, err := consumer.ConsumePartition(topic, partition, start)
+		if err != nil {
+			return nil, err
+		}
+		last, err := readPartitionPositions(pc, newest, config.Timeout, positions)
+		pc.Close()
+		if err == errReadTimeout {
+			// The last records of a partition are not delivered if they are
+			// control records of transactions or have been removed.
+			log.Warnf("Reading published events from partition %v stopped at offset %v before the end offset %v. "+
+				"Events published after offset %v may be sent again.", partition, last, newest, last)
+		} else if err != nil {
+			return nil, fmt.Errorf("reading published events from partition %v failed: %v", partition, err)
+		}
+	}
+	return positions, nil
+}
+
+// partitionMessages is the subset of sarama.PartitionConsumer used to read
+// back the published events of a partition.
+type partitionMessages interface {
+	Messages() <-chan *sarama.ConsumerMessage
+	Errors() <-chan *sarama.ConsumerError
+}
+
+var errReadTimeout = errors.New("timeout")
+
+// readPartitionPositions adds the positions of the messages read from pc to
+// positions, until the message before end has been read. It returns the offset
+// of the last message read. errReadTimeout is returned if the message before
+// end has not been read within timeout.
+func readPartitionPositions(
+	pc partitionMessages,
+	end int64,
+	timeout time.Duration,
+	positions publishedPositions,
+) (int64, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	last := int64(-1)
+	for {
+		select {
+		case msg := <-pc.Messages():
+			if source, offset, ok := messagePosition(msg); ok {
+				positions.add(source, offset)
+			}
+			last = msg.Offset
+			if msg.Offset >= end-1 {
+				return last, nil
+			}
+		case err := <-pc.Errors():
+			return last, err
+		case <-timer.C:
+			return last, errReadTimeout
+		}
+	}
+}
+
+func messagePosition(msg *sarama.ConsumerMessage) (string, int64, bool) {
+	var source string
+	var offset int64
+	var hasSource, hasOffset bool
+	for _, h := range msg.Headers {
+		switch string(h.Key) {
+		case headerSource:
+			source, hasSource = string(h.Value), true
+		case headerOffset:
+			v, err := strconv.ParseInt(string(h.Value), 10, 64)
+			offset, hasOffset = v, err == nil
+		}
+	}
+	return source, offset, hasSource && hasOffset
+}
@@ -0,0 +1,252 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+)
+
+type testPosition struct {
+	source string
+	offset int64
+}
+
+func (p testPosition) SourcePosition() (string, int64) { return p.source, p.offset }
+
+func TestPublishedPositions(t *testing.T) {
+	published := publishedPositions{}
+	published.add("a", 100)
+	published.add("a", 120)
+
+	assert.True(t, published.isPublished("a", 100))
+	assert.True(t, published.isPublished("a", 120))
+	assert.False(t, published.isPublished("a", 110))
+	assert.False(t, published.isPublished("b", 100))
+
+	// positions are matched independent of the order events are published in
+	assert.True(t, published.isPublished("a", 100))
+}
+
+func TestEventPosition(t *testing.T) {
+	source, offset, ok := eventPosition(&beat.Event{Private: testPosition{"inode-1", 42}})
+	assert.True(t, ok)
+	assert.Equal(t, "inode-1", source)
+	assert.Equal(t, int64(42), offset)
+
+	_, _, ok = eventPosition(&beat.Event{})
+	assert.False(t, ok)
+}
+
+func TestMessagePosition(t *testing.T) {
+	msg := &sarama.ConsumerMessage{}
+	for _, h := range positionHeaders("inode-1", 42) {
+		h := h
+		msg.Headers = append(msg.Headers, &h)
+	}
+
+	source, offset, ok := messagePosition(msg)
+	assert.True(t, ok)
+	assert.Equal(t, "inode-1", source)
+	assert.Equal(t, int64(42), offset)
+
+	_, _, ok = messagePosition(&sarama.ConsumerMessage{})
+	assert.False(t, ok)
+}
+
+type testPartitionMessages struct {
+	messages chan *sarama.ConsumerMessage
+	errors   chan *sarama.ConsumerError
+}
+
+func newTestPartitionMessages(offsets ...int64) *testPartitionMessages {
+	pc := &testPartitionMessages{
+		messages: make(chan *sarama.ConsumerMessage, len(offsets)),
+		errors:   make(chan *sarama.ConsumerError, 1),
+	}
+	for _, offset := range offsets {
+		msg := &sarama.ConsumerMessage{Offset: offset}
+		for _, h := range positionHeaders("inode-1", offset*10) {
+			h := h
+			msg.Headers = append(msg.Headers, &h)
+		}
+		pc.messages <- msg
+	}
+	return pc
+}
+
+func (pc *testPartitionMessages) Messages() <-chan *sarama.ConsumerMessage { return pc.messages }
+func (pc *testPartitionMessages) Errors() <-chan *sarama.ConsumerError     { return pc.errors }
+
+func TestReadPartitionPositions(t *testing.T) {
+	t.Run("reads until end", func(t *testing.T) {
+		positions := publishedPositions{}
+		last, err := readPartitionPositions(newTestPartitionMessages(5, 6, 7), 8, time.Second, positions)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), last)
+		assert.Equal(t, publishedPositions{{"inode-1", 50}: {}, {"inode-1", 60}: {}, {"inode-1", 70}: {}}, positions)
+	})
+
+	t.Run("last message never delivered", func(t *testing.T) {
+		// offset 7 is a control record or has been removed
+		positions := publishedPositions{}
+		last, err := readPartitionPositions(newTestPartitionMessages(5, 6), 8, 50*time.Millisecond, positions)
+		assert.Equal(t, errReadTimeout, err)
+		assert.Equal(t, int64(6), last)
+		assert.Len(t, positions, 2)
+	})
+
+	t.Run("consumer error", func(t *testing.T) {
+		pc := newTestPartitionMessages()
+		pc.errors <- &sarama.ConsumerError{Err: sarama.ErrOutOfBrokers}
+		_, err := readPartitionPositions(pc, 8, time.Second, publishedPositions{})
+		assert.Error(t, err)
+	})
+}
+
+type testAsyncProducer struct {
+	input     chan *sarama.ProducerMessage
+	successes chan *sarama.ProducerMessage
+	errors    chan *sarama.ProducerError
+}
+
+func newTestAsyncProducer() *testAsyncProducer {
+	return &testAsyncProducer{
+		input:     make(chan *sarama.ProducerMessage, 10),
+		successes: make(chan *sarama.ProducerMessage),
+		errors:    make(chan *sarama.ProducerError),
+	}
+}
+
+func (p *testAsyncProducer) AsyncClose() {
+	close(p.successes)
+	close(p.errors)
+}
+
+func (p *testAsyncProducer) Close() error {
+	p.AsyncClose()
+	return nil
+}
+
+func (p *testAsyncProducer) Input() chan<- *sarama.ProducerMessage     { return p.input }
+func (p *testAsyncProducer) Successes() <-chan *sarama.ProducerMessage { return p.successes }
+func (p *testAsyncProducer) Errors() <-chan *sarama.ProducerError      { return p.errors }
+
+func TestPublishExactlyOnce(t *testing.T) {
+	logp.TestingSetup()
+
+	reads := 0
+	client, err := newKafkaClient(
+		outputs.NewNilObserver(),
+		[]string{"localhost:9092"},
+		"testbeat",
+		nil,
+		outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorKeepCase)),
+		json.New("1.2.3", json.Config{}),
+		sarama.NewConfig(),
+		exactlyOnceConfig{Enabled: true},
+	)
+	require.NoError(t, err)
+	client.readPositions = func(*logp.Logger, []string, *sarama.Config, string, exactlyOnceConfig) (publishedPositions, error) {
+		reads++
+		published := publishedPositions{}
+		published.add("a", 10)
+		published.add("a", 20)
+		return published, nil
+	}
+	require.NoError(t, client.readPublished())
+	reads = 0
+
+	producer := newTestAsyncProducer()
+	client.producer = producer
+	client.wg.Add(2)
+	go client.successWorker(producer.Successes())
+	go client.errorWorker(producer.Errors())
+	defer client.Close()
+
+	signals := make(chan outest.BatchSignal, 1)
+	newBatch := func(events ...beat.Event) *outest.Batch {
+		batch := outest.NewBatch(events...)
+		batch.OnSignal = func(sig outest.BatchSignal) { signals <- sig }
+		return batch
+	}
+	newEvent := func(offset int64) beat.Event {
+		return beat.Event{
+			Fields:  common.MapStr{"message": "test"},
+			Private: testPosition{"a", offset},
+		}
+	}
+
+	// The event at offset 10 is skipped, the others are sent.
+	batch := newBatch(newEvent(10), newEvent(30), beat.Event{Fields: common.MapStr{"message": "test"}})
+	require.NoError(t, client.Publish(context.Background(), batch))
+	require.Len(t, producer.input, 2)
+
+	msg := <-producer.input
+	assert.Equal(t, sarama.ByteEncoder("a"), msg.Key)
+	source, offset, ok := messagePosition(&sarama.ConsumerMessage{Headers: recordHeaderPointers(msg.Headers)})
+	assert.True(t, ok)
+	assert.Equal(t, "a", source)
+	assert.Equal(t, int64(30), offset)
+
+	// The event at offset 30 fails and is retried by the pipeline.
+	producer.errors <- &sarama.ProducerError{Msg: msg, Err: sarama.ErrOutOfBrokers}
+	producer.successes <- <-producer.input
+	sig := <-signals
+	assert.Equal(t, outest.BatchRetryEvents, sig.Tag)
+	require.Len(t, sig.Events, 1)
+
+	// The event has been written before the failure was reported. It is found
+	// when reading back the topic before the retry and is not sent again.
+	client.readPositions = func(*logp.Logger, []string, *sarama.Config, string, exactlyOnceConfig) (publishedPositions, error) {
+		reads++
+		published := publishedPositions{}
+		published.add("a", 30)
+		return published, nil
+	}
+	require.NoError(t, client.Publish(context.Background(), newBatch(sig.Events[0].Content)))
+	assert.Equal(t, 1, reads)
+	assert.Len(t, producer.input, 0)
+	assert.Equal(t, outest.BatchACK, (<-signals).Tag)
+
+	// Positions are only read again after a failure.
+	require.NoError(t, client.Publish(context.Background(), newBatch(newEvent(30))))
+	assert.Equal(t, 1, reads)
+	assert.Equal(t, outest.BatchACK, (<-signals).Tag)
+}
+
+func recordHeaderPointers(headers []sarama.RecordHeader) []*sarama.RecordHeader {
+	ptrs := make([]*sarama.RecordHeader, len(headers))
+	for i := range headers {
+		ptrs[i] = &headers[i]
+	}
+	return ptrs
+}
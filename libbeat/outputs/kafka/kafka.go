@@ -71,6 +71,10 @@ func makeKafka(
 		return outputs.Fail(err)
 	}
 
+	if config.ExactlyOnce.Enabled && !topic.IsConst() {
+		return outputs.Fail(errors.New("exactly_once requires a single static topic"))
+	}
+
 	libCfg, err := newSaramaConfig(log, config)
 	if err != nil {
 		return outputs.Fail(err)
@@ -81,12 +85,13 @@ func makeKafka(
 		return outputs.Fail(err)
 	}
 
-	codec, err := codec.CreateEncoder(beat, config.Codec)
+	enc, err := codec.CreateEncoder(beat, config.Codec)
 	if err != nil {
 		return outputs.Fail(err)
 	}
+	enc = codec.WithFailurePolicy(enc, config.OnSerializationError)
 
-	client, err := newKafkaClient(observer, hosts, beat.IndexPrefix, config.Key, topic, codec, libCfg)
+	client, err := newKafkaClient(observer, hosts, beat.IndexPrefix, config.Key, topic, enc, libCfg, config.ExactlyOnce)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -34,6 +34,8 @@ type message struct {
 	ref   *msgRef
 	ts    time.Time
 
+	headers []sarama.RecordHeader
+
 	hash      uint32
 	partition int32
 
@@ -49,5 +51,6 @@ func (m *message) initProducerMessage() {
 		Key:       sarama.ByteEncoder(m.key),
 		Value:     sarama.ByteEncoder(m.value),
 		Timestamp: m.ts,
+		Headers:   m.headers,
 	}
 }
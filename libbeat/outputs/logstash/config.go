@@ -18,6 +18,7 @@
 package logstash
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 type Config struct {
 	Index            string                `config:"index"`
 	LoadBalance      bool                  `config:"loadbalance"`
+	Ordered          bool                  `config:"ordered"`
 	BulkMaxSize      int                   `config:"bulk_max_size"`
 	SlowStart        bool                  `config:"slow_start"`
 	Timeout          time.Duration         `config:"timeout"`
@@ -41,8 +43,13 @@ type Config struct {
 	MaxRetries       int                   `config:"max_retries"       validate:"min=-1"`
 	TLS              *tlscommon.Config     `config:"ssl"`
 	Proxy            transport.ProxyConfig `config:",inline"`
+	Network          transport.IPFamily    `config:"network"`
 	Backoff          Backoff               `config:"backoff"`
 	EscapeHTML       bool                  `config:"escape_html"`
+	Window           Window                `config:"window"`
+
+	// SSLSessionCacheSize enables TLS session resumption on reconnects if > 0
+	SSLSessionCacheSize int `config:"ssl_session_cache_size" validate:"min=0"`
 }
 
 type Backoff struct {
@@ -50,6 +57,21 @@ type Backoff struct {
 	Max  time.Duration
 }
 
+// Window configures how the number of events sent per transaction grows with
+// slow_start, from InitialSize by GrowthFactor after each successful send.
+type Window struct {
+	InitialSize  int     `config:"initial_size" validate:"min=1"`
+	GrowthFactor float64 `config:"growth_factor"`
+}
+
+// Validate checks the window grows.
+func (w *Window) Validate() error {
+	if w.GrowthFactor <= 1 {
+		return fmt.Errorf("window.growth_factor must be greater than 1, got %v", w.GrowthFactor)
+	}
+	return nil
+}
+
 func defaultConfig() Config {
 	return Config{
 		LoadBalance:      false,
@@ -65,6 +87,10 @@ func defaultConfig() Config {
 			Max:  60 * time.Second,
 		},
 		EscapeHTML: false,
+		Window: Window{
+			InitialSize:  defaultStartMaxWindowSize,
+			GrowthFactor: defaultWindowGrowthFactor,
+		},
 	}
 }
 
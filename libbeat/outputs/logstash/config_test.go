@@ -51,6 +51,7 @@ func TestConfig(t *testing.T) {
 					Max:  60 * time.Second,
 				},
 				EscapeHTML: false,
+				Window:     Window{InitialSize: 10, GrowthFactor: 1.5},
 				Index:      "bar",
 			},
 		},
@@ -75,9 +76,17 @@ func TestConfig(t *testing.T) {
 					Max:  60 * time.Second,
 				},
 				EscapeHTML: false,
+				Window:     Window{InitialSize: 10, GrowthFactor: 1.5},
 				Index:      "beat-index",
 			},
 		},
+		"invalid window growth factor": {
+			config: common.MustNewConfigFrom(common.MapStr{
+				"window.growth_factor": 1,
+			}),
+			expectedConfig: nil,
+			err:            true,
+		},
 		"removed config setting": {
 			config: common.MustNewConfigFrom(common.MapStr{
 				"port": "8080",
@@ -18,6 +18,8 @@
 package logstash
 
 import (
+	cryptoTLS "crypto/tls"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/transport"
@@ -28,6 +30,7 @@ import (
 const (
 	minWindowSize             int = 1
 	defaultStartMaxWindowSize int = 10
+	defaultWindowGrowthFactor     = 1.5
 	defaultPort                   = 5044
 )
 
@@ -55,12 +58,17 @@ func makeLogstash(
 	if err != nil {
 		return outputs.Fail(err)
 	}
+	if tls != nil && config.SSLSessionCacheSize > 0 {
+		// shared by all hosts, sessions are cached per server
+		tls.ClientSessionCache = cryptoTLS.NewLRUClientSessionCache(config.SSLSessionCacheSize)
+	}
 
 	transp := transport.Config{
 		Timeout: config.Timeout,
 		Proxy:   &config.Proxy,
 		TLS:     tls,
 		Stats:   observer,
+		Network: config.Network,
 	}
 
 	clients := make([]outputs.NetworkClient, len(hosts))
@@ -85,5 +93,8 @@ func makeLogstash(
 		clients[i] = client
 	}
 
+	if config.Ordered {
+		return outputs.SuccessOrdered(config.Pipelining, config.BulkMaxSize, config.MaxRetries, clients)
+	}
 	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
 }
@@ -54,7 +54,7 @@ func newSyncClient(
 	}
 
 	if config.SlowStart {
-		c.win = newWindower(defaultStartMaxWindowSize, config.BulkMaxSize)
+		c.win = newWindower(config.Window, config.BulkMaxSize)
 	}
 	if c.ttl > 0 {
 		c.ticker = time.NewTicker(c.ttl)
@@ -125,7 +125,7 @@ func (c *syncClient) Publish(_ context.Context, batch publisher.Batch) error {
 
 				// reset window size on reconnect
 				if c.win != nil {
-					c.win.windowSize = int32(defaultStartMaxWindowSize)
+					c.win.reset()
 				}
 			default:
 			}
@@ -26,26 +26,43 @@ type window struct {
 	windowSize      int32
 	maxOkWindowSize int // max window size sending was successful for
 	maxWindowSize   int
+	startWindowSize int
+	growthFactor    float64
 }
 
-func newWindower(start, max int) *window {
+func newWindower(config Window, max int) *window {
+	start := config.InitialSize
+	if start < minWindowSize {
+		start = defaultStartMaxWindowSize
+	}
+
 	w := &window{}
 	w.init(start, max)
+	if config.GrowthFactor > 1 {
+		w.growthFactor = config.GrowthFactor
+	}
 	return w
 }
 
 func (w *window) init(start, max int) {
 	*w = window{
-		windowSize:    int32(start),
-		maxWindowSize: max,
+		windowSize:      int32(start),
+		maxWindowSize:   max,
+		startWindowSize: start,
+		growthFactor:    defaultWindowGrowthFactor,
 	}
 }
 
+// reset restarts growing the window from its initial size.
+func (w *window) reset() {
+	atomic.StoreInt32(&w.windowSize, int32(w.startWindowSize))
+}
+
 func (w *window) get() int {
 	return int(atomic.LoadInt32(&w.windowSize))
 }
 
-// Increase window size by factor 1.5 until max window size
+// Increase window size by the growth factor until max window size
 // (window size grows exponentially)
 // TODO: use duration until ACK to estimate an ok max window size value
 func (w *window) tryGrowWindow(batchSize int) {
@@ -55,7 +72,7 @@ func (w *window) tryGrowWindow(batchSize int) {
 		if w.maxOkWindowSize < windowSize {
 			w.maxOkWindowSize = windowSize
 
-			newWindowSize := int(math.Ceil(1.5 * float64(windowSize)))
+			newWindowSize := int(math.Ceil(w.growthFactor * float64(windowSize)))
 
 			if windowSize <= batchSize && batchSize < newWindowSize {
 				newWindowSize = batchSize
@@ -66,7 +83,7 @@ func (w *window) tryGrowWindow(batchSize int) {
 
 			windowSize = newWindowSize
 		} else if windowSize < w.maxOkWindowSize {
-			windowSize = int(math.Ceil(1.5 * float64(windowSize)))
+			windowSize = int(math.Ceil(w.growthFactor * float64(windowSize)))
 			if windowSize > w.maxOkWindowSize {
 				windowSize = w.maxOkWindowSize
 			}
@@ -79,3 +79,21 @@ func testGrowWindowSize(t *testing.T,
 	assert.Equal(t, expected, int(w.windowSize))
 	assert.Equal(t, expected, int(w.maxOkWindowSize))
 }
+
+func TestGrowWindowSizeByFactor(t *testing.T) {
+	w := newWindower(Window{InitialSize: 4, GrowthFactor: 2}, 100)
+	w.tryGrowWindow(100)
+	assert.Equal(t, 8, w.get())
+	w.tryGrowWindow(100)
+	assert.Equal(t, 16, w.get())
+
+	w.reset()
+	assert.Equal(t, 4, w.get())
+}
+
+func TestWindowDefaults(t *testing.T) {
+	w := newWindower(Window{}, 100)
+	assert.Equal(t, defaultStartMaxWindowSize, w.get())
+	w.tryGrowWindow(100)
+	assert.Equal(t, 15, w.get())
+}
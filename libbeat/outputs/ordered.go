@@ -0,0 +1,308 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/testing"
+)
+
+// orderedClient publishes batches in the order they have been received from
+// the pipeline. Up to window batches are in flight at the same time. Failed
+// batches are retried by the client itself, before any later batch is sent
+// again. Batches are ACKed to the pipeline in order.
+type orderedClient struct {
+	client NetworkClient
+	window int
+	log    *logp.Logger
+
+	in     chan publisher.Batch
+	signal chan struct{}
+	done   chan struct{}
+	once   sync.Once
+	wg     sync.WaitGroup
+
+	connected bool
+
+	mu       sync.Mutex
+	pending  []*orderedBatch
+	draining bool
+	closed   bool
+}
+
+type orderedBatch struct {
+	client   *orderedClient
+	original publisher.Batch
+	events   []publisher.Event
+	state    orderedBatchState
+}
+
+type orderedBatchState uint8
+
+const (
+	batchUnsent orderedBatchState = iota
+	batchInFlight
+	batchACKed
+	batchDropped
+)
+
+var errOrderedClientClosed = errors.New("ordered client closed")
+
+// NewOrderedClient wraps a NetworkClient, such that events are published in
+// order. Up to window batches are pipelined. If publishing a batch fails, all
+// batches in flight are returned before the failed batches are published
+// again in their original order.
+// Batches are retried until they have been published, or until the client is
+// closed. The wrapped client should be created using WithBackoff.
+func NewOrderedClient(client NetworkClient, window int) Client {
+	if window < 1 {
+		window = 1
+	}
+
+	c := &orderedClient{
+		client: client,
+		window: window,
+		log:    logp.NewLogger("publisher_ordered"),
+		in:     make(chan publisher.Batch),
+		signal: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.run()
+	return c
+}
+
+func (c *orderedClient) Publish(_ context.Context, batch publisher.Batch) error {
+	select {
+	case <-c.done:
+		batch.Cancelled()
+		return errOrderedClientClosed
+	case c.in <- batch:
+		return nil
+	}
+}
+
+func (c *orderedClient) Close() error {
+	var err error
+	c.once.Do(func() {
+		close(c.done)
+		err = c.client.Close()
+		c.wg.Wait()
+
+		c.mu.Lock()
+		pending := c.pending
+		c.pending = nil
+		c.closed = true
+		c.mu.Unlock()
+
+		// Return unpublished batches to the pipeline, so they can be published
+		// by the next output.
+		for _, batch := range pending {
+			switch batch.state {
+			case batchACKed:
+				batch.original.ACK()
+			case batchDropped:
+				batch.original.Drop()
+			default:
+				batch.original.Cancelled()
+			}
+		}
+	})
+	return err
+}
+
+func (c *orderedClient) Test(d testing.Driver) {
+	t, ok := c.client.(testing.Testable)
+	if !ok {
+		d.Fatal("output", errors.New("client doesn't support testing"))
+	}
+
+	t.Test(d)
+}
+
+func (c *orderedClient) String() string {
+	return "ordered(" + c.client.String() + ")"
+}
+
+func (c *orderedClient) run() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+
+		next, accept := c.update()
+		if next != nil {
+			c.publish(next)
+			continue
+		}
+
+		var in chan publisher.Batch
+		if accept {
+			in = c.in
+		}
+
+		select {
+		case <-c.done:
+			return
+		case batch := <-in:
+			c.mu.Lock()
+			c.pending = append(c.pending, &orderedBatch{
+				client:   c,
+				original: batch,
+				events:   batch.Events(),
+			})
+			c.mu.Unlock()
+		case <-c.signal:
+		}
+	}
+}
+
+// update forwards the ACKs of completed batches in order and returns the next
+// batch to be published. If no batch can be published yet, accept reports
+// whether a new batch fits into the window.
+func (c *orderedClient) update() (next *orderedBatch, accept bool) {
+	c.mu.Lock()
+
+	var completed []*orderedBatch
+	for len(c.pending) > 0 && c.pending[0].isCompleted() {
+		completed = append(completed, c.pending[0])
+		c.pending[0] = nil
+		c.pending = c.pending[1:]
+	}
+
+	// After a failure, wait for all batches in flight to be resolved, before
+	// publishing the failed batches again. Otherwise a later batch might be
+	// published before an earlier batch that is about to fail.
+	inFlight := false
+	for _, batch := range c.pending {
+		if batch.state == batchInFlight {
+			inFlight = true
+		} else if batch.state == batchUnsent && next == nil {
+			next = batch
+		}
+	}
+	if c.draining {
+		if inFlight {
+			next = nil
+		} else {
+			c.draining = false
+		}
+	}
+	accept = len(c.pending) < c.window
+
+	c.mu.Unlock()
+
+	for _, batch := range completed {
+		if batch.state == batchACKed {
+			batch.original.ACK()
+		} else {
+			batch.original.Drop()
+		}
+	}
+	return next, accept
+}
+
+func (c *orderedClient) publish(batch *orderedBatch) {
+	if !c.connected {
+		if err := c.client.Connect(); err != nil {
+			c.log.Errorf("Failed to connect to %v: %v", c.client, err)
+			return
+		}
+		c.log.Infof("Connection to %v established", c.client)
+		c.connected = true
+	}
+
+	c.mu.Lock()
+	batch.state = batchInFlight
+	c.mu.Unlock()
+
+	// Publish calls the batch signal handlers synchronously or asynchronously.
+	// The handlers only update the batch state, without blocking.
+	if err := c.client.Publish(context.Background(), batch); err != nil {
+		c.log.Errorf("Failed to publish events: %v", err)
+		c.connected = false
+
+		c.mu.Lock()
+		c.draining = true
+		c.mu.Unlock()
+	}
+}
+
+func (c *orderedClient) setState(batch *orderedBatch, state orderedBatchState, events []publisher.Event) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	if state == batchUnsent {
+		if len(events) == 0 {
+			state = batchACKed
+		} else {
+			batch.events = events
+			c.draining = true
+		}
+	}
+	batch.state = state
+	c.mu.Unlock()
+
+	select {
+	case c.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (b *orderedBatch) isCompleted() bool {
+	return b.state == batchACKed || b.state == batchDropped
+}
+
+func (b *orderedBatch) Events() []publisher.Event {
+	return b.events
+}
+
+func (b *orderedBatch) ACK() {
+	b.client.setState(b, batchACKed, nil)
+}
+
+func (b *orderedBatch) Drop() {
+	b.client.setState(b, batchDropped, nil)
+}
+
+func (b *orderedBatch) Retry() {
+	b.client.setState(b, batchUnsent, b.events)
+}
+
+func (b *orderedBatch) RetryEvents(events []publisher.Event) {
+	b.client.setState(b, batchUnsent, events)
+}
+
+func (b *orderedBatch) Cancelled() {
+	b.client.setState(b, batchUnsent, b.events)
+}
+
+func (b *orderedBatch) CancelledEvents(events []publisher.Event) {
+	b.client.setState(b, batchUnsent, events)
+}
@@ -0,0 +1,160 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// mockNetworkClient simulates a pipelining network client. Events are
+// received by the sink in the order they are published, ACKs are reported
+// asynchronously. Once publishing fails, the connection is broken until the
+// client reconnects.
+type mockNetworkClient struct {
+	mu        sync.Mutex
+	calls     int
+	failOn    map[int]bool
+	connected bool
+	received  []int
+}
+
+func (m *mockNetworkClient) Connect() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = true
+	return nil
+}
+
+func (m *mockNetworkClient) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.connected = false
+	return nil
+}
+
+func (m *mockNetworkClient) Publish(_ context.Context, batch publisher.Batch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.failOn[m.calls] {
+		m.connected = false
+	}
+	if !m.connected {
+		go batch.Retry()
+		return errors.New("connection failed")
+	}
+
+	for _, event := range batch.Events() {
+		n, _ := event.Content.Fields.GetValue("n")
+		m.received = append(m.received, n.(int))
+	}
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+		batch.ACK()
+	}()
+	return nil
+}
+
+func (m *mockNetworkClient) String() string {
+	return "mock"
+}
+
+func TestOrderedClient(t *testing.T) {
+	tests := map[string]map[int]bool{
+		"no failures":       nil,
+		"single failure":    {4: true},
+		"multiple failures": {2: true, 3: true, 7: true, 12: true},
+	}
+
+	for name, failOn := range tests {
+		failOn := failOn
+		t.Run(name, func(t *testing.T) {
+			const numBatches = 20
+
+			mock := &mockNetworkClient{failOn: failOn}
+			client := NewOrderedClient(mock, 4)
+
+			var mu sync.Mutex
+			var acked []int
+			var wg sync.WaitGroup
+			wg.Add(numBatches)
+
+			for i := 0; i < numBatches; i++ {
+				i := i
+				batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"n": i}})
+				batch.OnSignal = func(sig outest.BatchSignal) {
+					assert.Equal(t, outest.BatchACK, sig.Tag)
+					mu.Lock()
+					acked = append(acked, i)
+					mu.Unlock()
+					wg.Done()
+				}
+				assert.NoError(t, client.Publish(context.Background(), batch))
+			}
+
+			wg.Wait()
+			assert.NoError(t, client.Close())
+
+			expected := make([]int, numBatches)
+			for i := range expected {
+				expected[i] = i
+			}
+			assert.Equal(t, expected, acked)
+
+			// Events published before a failure can be received again, but
+			// the sink never receives an event before all earlier events.
+			last := -1
+			for _, n := range mock.received {
+				assert.True(t, n <= last+1, "event %v received after %v", n, last)
+				if n > last {
+					last = n
+				}
+			}
+			assert.Equal(t, numBatches-1, last)
+		})
+	}
+}
+
+func TestOrderedClientCloseCancelsPending(t *testing.T) {
+	mock := &mockNetworkClient{failOn: map[int]bool{1: true}}
+	client := NewOrderedClient(WithBackoff(mock, time.Hour, time.Hour), 2)
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"n": 0}})
+	signals := make(chan outest.BatchSignal, 1)
+	batch.OnSignal = func(sig outest.BatchSignal) { signals <- sig }
+
+	assert.NoError(t, client.Publish(context.Background(), batch))
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, client.Close())
+
+	sig := <-signals
+	assert.Equal(t, outest.BatchCancelled, sig.Tag)
+}
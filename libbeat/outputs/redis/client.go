@@ -52,12 +52,16 @@ type client struct {
 	observer outputs.Observer
 	index    string
 	dataType redisDataType
+	stream   streamConfig
 	db       int
 	key      outil.Selector
 	password string
 	publish  publishFn
 	codec    codec.Codec
 	timeout  time.Duration
+
+	// connection of the client, used by the cluster client to query the slots
+	conn redis.Conn
 }
 
 type redisDataType uint16
@@ -65,6 +69,7 @@ type redisDataType uint16
 const (
 	redisListType redisDataType = iota
 	redisChannelType
+	redisStreamType
 )
 
 func newClient(
@@ -72,7 +77,7 @@ func newClient(
 	observer outputs.Observer,
 	timeout time.Duration,
 	pass string,
-	db int, key outil.Selector, dt redisDataType,
+	db int, key outil.Selector, dt redisDataType, stream streamConfig,
 	index string, codec codec.Codec,
 ) *client {
 	return &client{
@@ -84,6 +89,7 @@ func newClient(
 		index:    strings.ToLower(index),
 		db:       db,
 		dataType: dt,
+		stream:   stream,
 		key:      key,
 		codec:    codec,
 	}
@@ -107,6 +113,9 @@ func (c *client) Connect() error {
 	if err = initRedisConn(conn, c.password, c.db); err == nil {
 		c.publish, err = c.makePublish(conn)
 	}
+	if err == nil {
+		c.conn = conn
+	}
 	return err
 }
 
@@ -163,8 +172,11 @@ func (c *client) String() string {
 func (c *client) makePublish(
 	conn redis.Conn,
 ) (publishFn, error) {
-	if c.dataType == redisChannelType {
+	switch c.dataType {
+	case redisChannelType:
 		return c.makePublishPUBLISH(conn)
+	case redisStreamType:
+		return c.makePublishXADD(conn)
 	}
 	return c.makePublishRPUSH(conn)
 }
@@ -220,6 +232,28 @@ func (c *client) makePublishPUBLISH(conn redis.Conn) (publishFn, error) {
 	return c.publishEventsPipeline(conn, "PUBLISH"), nil
 }
 
+func (c *client) makePublishXADD(conn redis.Conn) (publishFn, error) {
+	return c.publishEventsPipeline(conn, "XADD"), nil
+}
+
+// commandArgs returns the arguments of the command adding the serialized event
+// to the list, channel or stream at key.
+func (c *client) commandArgs(key string, event interface{}) []interface{} {
+	if c.dataType != redisStreamType {
+		return []interface{}{key, event}
+	}
+
+	args := []interface{}{key}
+	if c.stream.MaxLen > 0 {
+		args = append(args, "MAXLEN")
+		if c.stream.Approximate {
+			args = append(args, "~")
+		}
+		args = append(args, c.stream.MaxLen)
+	}
+	return append(args, "*", c.stream.Field, event)
+}
+
 func (c *client) publishEventsBulk(conn redis.Conn, command string) publishFn {
 	// XXX: requires key.IsConst() == true
 	dest, _ := c.key.Select(&beat.Event{Fields: common.MapStr{}})
@@ -267,7 +301,7 @@ func (c *client) publishEventsPipeline(conn redis.Conn, command string) publishF
 			}
 
 			data = append(data, okEvents[i])
-			if err := conn.Send(command, eventKey, serializedEvent); err != nil {
+			if err := conn.Send(command, c.commandArgs(eventKey, serializedEvent)...); err != nil {
 				c.log.Errorf("Failed to execute %v: %+v", command, err)
 				return okEvents, err
 			}
@@ -0,0 +1,304 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// clusterSlots is the number of hash slots of a Redis Cluster.
+const clusterSlots = 16384
+
+// clusterClient publishes events to the master nodes of a Redis Cluster. Each
+// event is sent to the node serving the hash slot of its key. The slots are
+// queried from the seed node on connect, and again after a node redirected
+// events because slots were moved.
+type clusterClient struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	key      outil.Selector
+
+	seed     *client
+	seedHost string
+	dial     func(addr string) (*client, error)
+
+	nodes   map[string]*client
+	slots   [clusterSlots]string
+	refresh bool
+}
+
+// slotRange is a range of hash slots served by a node.
+type slotRange struct {
+	start, end int
+	addr       string
+}
+
+func newClusterClient(
+	seed *client,
+	seedHost string,
+	dial func(addr string) (*client, error),
+) *clusterClient {
+	return &clusterClient{
+		log:      logp.NewLogger("redis"),
+		observer: seed.observer,
+		key:      seed.key,
+		seed:     seed,
+		seedHost: seedHost,
+		dial:     dial,
+		nodes:    map[string]*client{},
+	}
+}
+
+func (c *clusterClient) Connect() error {
+	if err := c.seed.Connect(); err != nil {
+		return err
+	}
+	return c.refreshSlots()
+}
+
+// refreshSlots queries the nodes serving each slot from the seed node, and
+// closes the connections to nodes not serving any slot anymore.
+func (c *clusterClient) refreshSlots() error {
+	ranges, err := parseClusterSlots(c.seed.conn.Do("CLUSTER", "SLOTS"))
+	if err != nil {
+		return err
+	}
+
+	var slots [clusterSlots]string
+	for _, r := range ranges {
+		addr := r.addr
+		if strings.HasPrefix(addr, ":") {
+			// nodes announce an empty IP if they don't know their own address
+			addr = net.JoinHostPort(c.seedHost, addr[1:])
+		}
+		for slot := r.start; slot <= r.end; slot++ {
+			slots[slot] = addr
+		}
+	}
+
+	serving := map[string]bool{}
+	for _, addr := range slots {
+		serving[addr] = true
+	}
+	for addr, node := range c.nodes {
+		if !serving[addr] {
+			node.Close()
+			delete(c.nodes, addr)
+		}
+	}
+
+	c.slots = slots
+	c.refresh = false
+	return nil
+}
+
+// node returns the client connected to the node at addr. Events of slots not
+// served by any node are sent to the seed node, which reports the error.
+func (c *clusterClient) node(addr string) (*client, error) {
+	if addr == "" {
+		return c.seed, nil
+	}
+	if node, ok := c.nodes[addr]; ok {
+		return node, nil
+	}
+
+	node, err := c.dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.Connect(); err != nil {
+		return nil, err
+	}
+	c.nodes[addr] = node
+	return node, nil
+}
+
+func (c *clusterClient) Close() error {
+	for addr, node := range c.nodes {
+		node.Close()
+		delete(c.nodes, addr)
+	}
+	return c.seed.Close()
+}
+
+func (c *clusterClient) Publish(_ context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	if c.refresh {
+		if err := c.refreshSlots(); err != nil {
+			c.observer.Failed(len(events))
+			batch.Retry()
+			return err
+		}
+	}
+
+	byNode := map[string][]publisher.Event{}
+	dropped := 0
+	for _, event := range events {
+		key, err := c.key.Select(&event.Content)
+		if err != nil {
+			c.log.Errorf("Failed to set redis key: %+v", err)
+			dropped++
+			continue
+		}
+		addr := c.slots[keySlot(key)]
+		byNode[addr] = append(byNode[addr], event)
+	}
+	c.observer.Dropped(dropped)
+
+	var rest []publisher.Event
+	var lastErr error
+	for addr, events := range byNode {
+		node, err := c.node(addr)
+		if err != nil {
+			c.log.Errorf("Failed to connect to redis cluster node %v: %+v", addr, err)
+			rest = append(rest, events...)
+			lastErr = err
+			continue
+		}
+
+		failed, err := node.publish(c.key, events)
+		rest = append(rest, failed...)
+		if isClusterRedirect(err) {
+			// retry the events once the slots are refreshed
+			c.refresh = true
+		} else if err != nil {
+			lastErr = err
+		}
+	}
+
+	if rest != nil {
+		c.observer.Failed(len(rest))
+		batch.RetryEvents(rest)
+		return lastErr
+	}
+
+	batch.ACK()
+	return lastErr
+}
+
+func (c *clusterClient) String() string {
+	return "redis-cluster(" + c.seed.Client.String() + ")"
+}
+
+// isClusterRedirect returns true if the error redirects the command to the
+// node serving the slot, because the slot was moved or is being migrated.
+func isClusterRedirect(err error) bool {
+	redisErr, ok := err.(redis.Error)
+	if !ok {
+		return false
+	}
+	msg := string(redisErr)
+	return strings.HasPrefix(msg, "MOVED ") || strings.HasPrefix(msg, "ASK ")
+}
+
+// parseClusterSlots parses the reply of CLUSTER SLOTS, a list of slot ranges
+// with the address of their master followed by their replicas.
+func parseClusterSlots(reply interface{}, err error) ([]slotRange, error) {
+	entries, err := redis.Values(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]slotRange, 0, len(entries))
+	for _, entry := range entries {
+		fields, err := redis.Values(entry, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid CLUSTER SLOTS entry with %v fields", len(fields))
+		}
+
+		start, err := redis.Int(fields[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		end, err := redis.Int(fields[1], nil)
+		if err != nil {
+			return nil, err
+		}
+		if start < 0 || end >= clusterSlots || start > end {
+			return nil, fmt.Errorf("invalid CLUSTER SLOTS range %v-%v", start, end)
+		}
+
+		master, err := redis.Values(fields[2], nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(master) < 2 {
+			return nil, errors.New("CLUSTER SLOTS entry without master address")
+		}
+		host, err := redis.String(master[0], nil)
+		if err != nil {
+			return nil, err
+		}
+		port, err := redis.Int(master[1], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ranges = append(ranges, slotRange{
+			start: start,
+			end:   end,
+			addr:  net.JoinHostPort(host, strconv.Itoa(port)),
+		})
+	}
+	return ranges, nil
+}
+
+// keySlot returns the hash slot of the key. Only the hash tag of keys with a
+// non-empty tag in braces is hashed, so that keys with the same tag are served
+// by the same node.
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % clusterSlots)
+}
+
+// crc16 is the CRC16-XMODEM checksum used by Redis Cluster to hash keys.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySlot(t *testing.T) {
+	assert.Equal(t, uint16(0x31c3), crc16("123456789"))
+
+	assert.Equal(t, 12182, keySlot("foo"))
+	assert.Equal(t, 5061, keySlot("bar"))
+	assert.Equal(t, 866, keySlot("hello"))
+
+	// keys with the same hash tag are served by the same node
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.following"))
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.followers"))
+
+	// empty tags hash the whole key
+	assert.Equal(t, int(crc16("foo{}{bar}")%clusterSlots), keySlot("foo{}{bar}"))
+}
+
+func TestParseClusterSlots(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{int64(0), int64(5460),
+			[]interface{}{[]byte("10.0.0.1"), int64(7000), []byte("id1")},
+			[]interface{}{[]byte("10.0.0.4"), int64(7003), []byte("id4")},
+		},
+		[]interface{}{int64(5461), int64(16383),
+			[]interface{}{[]byte(""), int64(7001)},
+		},
+	}
+
+	ranges, err := parseClusterSlots(reply, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []slotRange{
+		{start: 0, end: 5460, addr: "10.0.0.1:7000"},
+		{start: 5461, end: 16383, addr: ":7001"},
+	}, ranges)
+
+	_, err = parseClusterSlots([]interface{}{
+		[]interface{}{int64(0), int64(16384), []interface{}{[]byte("10.0.0.1"), int64(7000)}},
+	}, nil)
+	assert.Error(t, err)
+
+	_, err = parseClusterSlots([]interface{}{[]interface{}{int64(0), int64(10)}}, nil)
+	assert.Error(t, err)
+}
+
+func TestStreamCommandArgs(t *testing.T) {
+	event := []byte(`{"message":"test"}`)
+
+	c := &client{dataType: redisListType}
+	assert.Equal(t, []interface{}{"key", event}, c.commandArgs("key", event))
+
+	c = &client{dataType: redisStreamType, stream: streamConfig{Field: "event"}}
+	assert.Equal(t, []interface{}{"key", "*", "event", event}, c.commandArgs("key", event))
+
+	c.stream.MaxLen = 1000
+	assert.Equal(t, []interface{}{"key", "MAXLEN", 1000, "*", "event", event}, c.commandArgs("key", event))
+
+	c.stream.Approximate = true
+	assert.Equal(t, []interface{}{"key", "MAXLEN", "~", 1000, "*", "event", event}, c.commandArgs("key", event))
+}
+
+func TestIsClusterRedirect(t *testing.T) {
+	assert.True(t, isClusterRedirect(redis.Error("MOVED 3999 127.0.0.1:6381")))
+	assert.True(t, isClusterRedirect(redis.Error("ASK 3999 127.0.0.1:6381")))
+	assert.False(t, isClusterRedirect(redis.Error("ERR unknown command")))
+	assert.False(t, isClusterRedirect(nil))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
 	"github.com/elastic/beats/v7/libbeat/common/transport"
 	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
@@ -36,10 +37,26 @@ type redisConfig struct {
 	MaxRetries  int                   `config:"max_retries"`
 	TLS         *tlscommon.Config     `config:"ssl"`
 	Proxy       transport.ProxyConfig `config:",inline"`
+	Network     transport.IPFamily    `config:"network"`
 	Codec       codec.Config          `config:"codec"`
 	Db          int                   `config:"db"`
 	DataType    string                `config:"datatype"`
+	Stream      streamConfig          `config:"stream"`
+	Cluster     bool                  `config:"cluster"`
 	Backoff     backoff               `config:"backoff"`
+
+	// OnSerializationError selects how events failing to be encoded are handled.
+	OnSerializationError failpolicy.Policy `config:"on_serialization_error"`
+}
+
+// streamConfig configures the entries added to a Redis stream with XADD.
+type streamConfig struct {
+	// entry field holding the encoded event
+	Field string `config:"field" validate:"required"`
+
+	// trim the stream to about MaxLen entries if > 0, exactly unless Approximate
+	MaxLen      int  `config:"maxlen" validate:"min=0"`
+	Approximate bool `config:"approximate"`
 }
 
 type backoff struct {
@@ -56,19 +73,28 @@ var (
 		TLS:         nil,
 		Db:          0,
 		DataType:    "list",
+		Stream: streamConfig{
+			Field:       "event",
+			Approximate: true,
+		},
 		Backoff: backoff{
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
+		OnSerializationError: failpolicy.DropEvent,
 	}
 )
 
 func (c *redisConfig) Validate() error {
 	switch c.DataType {
-	case "", "list", "channel":
+	case "", "list", "channel", "stream":
 	default:
 		return fmt.Errorf("redis data type %v not supported", c.DataType)
 	}
 
-	return nil
+	if c.Cluster && c.Db != 0 {
+		return fmt.Errorf("redis cluster only supports db 0, got db %v", c.Db)
+	}
+
+	return codec.CheckFailurePolicy(c.OnSerializationError)
 }
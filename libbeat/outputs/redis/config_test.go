@@ -37,6 +37,9 @@ func TestValidate(t *testing.T) {
 		{"Invalid Datatype", redisConfig{Key: "test", DataType: "something"}, false},
 		{"List Datatype", redisConfig{Key: "test", DataType: "list"}, true},
 		{"Channel Datatype", redisConfig{Key: "test", DataType: "channel"}, true},
+		{"Stream Datatype", redisConfig{Key: "test", DataType: "stream"}, true},
+		{"Cluster", redisConfig{Key: "test", Cluster: true}, true},
+		{"Cluster with db", redisConfig{Key: "test", Cluster: true, Db: 1}, false},
 	}
 
 	for _, test := range tests {
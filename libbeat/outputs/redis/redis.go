@@ -88,6 +88,8 @@ func makeRedis(
 		dataType = redisListType
 	case "channel":
 		dataType = redisChannelType
+	case "stream":
+		dataType = redisStreamType
 	default:
 		return outputs.Fail(errors.New("Bad Redis data type"))
 	}
@@ -133,6 +135,7 @@ func makeRedis(
 			Proxy:   &config.Proxy,
 			TLS:     tls,
 			Stats:   observer,
+			Network: config.Network,
 		}
 
 		switch hostUrl.Scheme {
@@ -146,11 +149,6 @@ func makeRedis(
 			}
 		}
 
-		conn, err := transport.NewClient(transp, "tcp", hostUrl.Host, defaultPort)
-		if err != nil {
-			return outputs.Fail(err)
-		}
-
 		pass := config.Password
 		hostPass, passSet := hostUrl.User.Password()
 		if passSet {
@@ -161,10 +159,29 @@ func makeRedis(
 		if err != nil {
 			return outputs.Fail(err)
 		}
+		enc = codec.WithFailurePolicy(enc, config.OnSerializationError)
+
+		// dial creates the client of a host, or of a node of the cluster
+		dial := func(addr string) (*client, error) {
+			conn, err := transport.NewClient(transp, "tcp", addr, defaultPort)
+			if err != nil {
+				return nil, err
+			}
+			return newClient(conn, observer, config.Timeout,
+				pass, config.Db, key, dataType, config.Stream, config.Index, enc), nil
+		}
+
+		client, err := dial(hostUrl.Host)
+		if err != nil {
+			return outputs.Fail(err)
+		}
 
-		client := newClient(conn, observer, config.Timeout,
-			pass, config.Db, key, dataType, config.Index, enc)
-		clients[i] = newBackoffClient(client, config.Backoff.Init, config.Backoff.Max)
+		if config.Cluster {
+			cluster := newClusterClient(client, hostUrl.Hostname(), dial)
+			clients[i] = newBackoffClient(cluster, config.Backoff.Init, config.Backoff.Max)
+		} else {
+			clients[i] = newBackoffClient(client, config.Backoff.Init, config.Backoff.Max)
+		}
 	}
 
 	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
@@ -47,7 +47,7 @@ func clientsLen(required int) checker {
 func clientPassword(index int, pass string) checker {
 	return func(t *testing.T, group outputs.Group) {
 		redisClient := group.Clients[index].(*backoffClient)
-		assert.Equal(t, redisClient.client.password, pass)
+		assert.Equal(t, redisClient.client.(*client).password, pass)
 	}
 }
 
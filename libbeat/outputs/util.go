@@ -47,3 +47,10 @@ func SuccessNet(loadbalance bool, batchSize, retry int, netclients []NetworkClie
 	clients := NetworkClients(netclients)
 	return Success(batchSize, retry, clients...)
 }
+
+// SuccessOrdered creates a group publishing all events in the order they have
+// been received, using one client at a time. Up to window batches are
+// published before they have been ACKed.
+func SuccessOrdered(window, batchSize, retry int, netclients []NetworkClient) (Group, error) {
+	return Success(batchSize, retry, NewOrderedClient(NewFailoverClient(netclients), window))
+}
@@ -29,12 +29,16 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
+	"github.com/elastic/beats/v7/libbeat/sharedmeta"
 )
 
 const (
 	// metadataHost is the IP that each of the cloud providers supported here
 	// use for their metadata service.
 	metadataHost = "169.254.169.254"
+
+	// sharedKind is the kind of metadata shared with other beats on the host.
+	sharedKind = "cloud"
 )
 
 // init registers the add_cloud_metadata processor.
@@ -73,6 +77,11 @@ func New(c *common.Config) (processors.Processor, error) {
 		logger:   logp.NewLogger("add_cloud_metadata"),
 	}
 
+	sharedmeta.Register(sharedKind, func(string) (common.MapStr, bool) {
+		meta := p.getMeta()
+		return meta, len(meta) > 0
+	})
+
 	go p.init()
 	return p, nil
 }
@@ -84,6 +93,17 @@ func (r result) String() string {
 
 func (p *addCloudMetadata) init() {
 	p.initOnce.Do(func() {
+		meta, found, err := p.lookupShared()
+		if err == nil {
+			if !found {
+				p.logger.Info("add_cloud_metadata: hosting provider type not detected by the beat sharing metadata.")
+				return
+			}
+			p.metadata = meta
+			p.logger.Infof("add_cloud_metadata: using metadata shared by another beat, metadata=%v", meta.String())
+			return
+		}
+
 		result := p.fetchMetadata()
 		if result == nil {
 			p.logger.Info("add_cloud_metadata: hosting provider type not detected.")
@@ -95,6 +115,16 @@ func (p *addCloudMetadata) init() {
 	})
 }
 
+// lookupShared returns the cloud metadata shared by another beat on the host,
+// an error is returned if there is none and it must be fetched.
+func (p *addCloudMetadata) lookupShared() (common.MapStr, bool, error) {
+	meta, found, err := sharedmeta.Lookup(sharedKind, "")
+	if err != nil && err != sharedmeta.ErrUnavailable {
+		p.logger.Debugf("add_cloud_metadata: fetching metadata locally: %v", err)
+	}
+	return meta, found, err
+}
+
 func (p *addCloudMetadata) getMeta() common.MapStr {
 	p.init()
 	return p.metadata.Clone()
@@ -31,6 +31,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/processors"
 	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor"
 	"github.com/elastic/beats/v7/libbeat/processors/util"
+	"github.com/elastic/beats/v7/libbeat/sharedmeta"
 	"github.com/elastic/go-sysinfo"
 )
 
@@ -45,6 +46,7 @@ type addHostMetadata struct {
 		sync.Mutex
 	}
 	data    common.MapStrPointer
+	shared  common.MapStrPointer // host metadata shared with other beats, without the name override
 	geoData common.MapStr
 	config  Config
 	logger  *logp.Logger
@@ -52,6 +54,9 @@ type addHostMetadata struct {
 
 const (
 	processorName = "add_host_metadata"
+
+	// sharedKind is the kind of metadata shared with other beats on the host.
+	sharedKind = "host"
 )
 
 // New constructs a new add_host_metadata processor.
@@ -64,10 +69,19 @@ func New(cfg *common.Config) (processors.Processor, error) {
 	p := &addHostMetadata{
 		config: config,
 		data:   common.NewMapStrPointer(nil),
+		shared: common.NewMapStrPointer(nil),
 		logger: logp.NewLogger("add_host_metadata"),
 	}
 	p.loadData()
 
+	sharedmeta.Register(sharedKind, func(string) (common.MapStr, bool) {
+		if err := p.loadData(); err != nil {
+			p.logger.Debugf("Error loading shared host metadata: %v", err)
+		}
+		data := p.shared.Get()
+		return data.Clone(), len(data) > 0
+	})
+
 	if config.Geo != nil {
 		geoFields, err := util.GeoConfigToMap(*config.Geo)
 		if err != nil {
@@ -119,26 +133,11 @@ func (p *addHostMetadata) loadData() error {
 		return nil
 	}
 
-	h, err := sysinfo.Host()
+	data, err := p.hostData()
 	if err != nil {
 		return err
 	}
-
-	data := host.MapHostInfo(h.Info())
-	if p.config.NetInfoEnabled {
-		// IP-address and MAC-address
-		var ipList, hwList, err = util.GetNetInfo()
-		if err != nil {
-			p.logger.Infof("Error when getting network information %v", err)
-		}
-
-		if len(ipList) > 0 {
-			data.Put("host.ip", ipList)
-		}
-		if len(hwList) > 0 {
-			data.Put("host.mac", hwList)
-		}
-	}
+	p.shared.Set(data.Clone())
 
 	if p.config.Name != "" {
 		data.Put("host.name", p.config.Name)
@@ -147,6 +146,53 @@ func (p *addHostMetadata) loadData() error {
 	return nil
 }
 
+// hostData returns the host metadata shared by another beat on the host, or
+// collects it if none is shared.
+func (p *addHostMetadata) hostData() (common.MapStr, error) {
+	data, found, err := sharedmeta.Lookup(sharedKind, "")
+	switch {
+	case err == sharedmeta.ErrUnavailable:
+	case err != nil:
+		p.logger.Debugf("Collecting host metadata locally: %v", err)
+	case found:
+		data.Delete("host.ip")
+		data.Delete("host.mac")
+		if p.config.NetInfoEnabled {
+			addNetInfo(data, p.logger)
+		}
+		return data, nil
+	}
+	return collectHostData(p.config.NetInfoEnabled, p.logger)
+}
+
+func collectHostData(netInfo bool, logger *logp.Logger) (common.MapStr, error) {
+	h, err := sysinfo.Host()
+	if err != nil {
+		return nil, err
+	}
+
+	data := host.MapHostInfo(h.Info())
+	if netInfo {
+		addNetInfo(data, logger)
+	}
+	return data, nil
+}
+
+// addNetInfo adds the IP and MAC addresses of the host to data.
+func addNetInfo(data common.MapStr, logger *logp.Logger) {
+	var ipList, hwList, err = util.GetNetInfo()
+	if err != nil {
+		logger.Infof("Error when getting network information %v", err)
+	}
+
+	if len(ipList) > 0 {
+		data.Put("host.ip", ipList)
+	}
+	if len(hwList) > 0 {
+		data.Put("host.mac", hwList)
+	}
+}
+
 func (p *addHostMetadata) String() string {
 	return fmt.Sprintf("%v=[netinfo.enabled=[%v], cache.ttl=[%v]]",
 		processorName, p.config.NetInfoEnabled, p.config.CacheTTL)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -42,6 +43,7 @@ const (
 )
 
 type kubernetesAnnotator struct {
+	version             uint64 // changes with the indexed pods, accessed atomically
 	log                 *logp.Logger
 	watcher             kubernetes.Watcher
 	indexers            *Indexers
@@ -132,73 +134,82 @@ func newProcessorConfig(cfg *common.Config, register *Register) (kubeAnnotatorCo
 
 func (k *kubernetesAnnotator) init(config kubeAnnotatorConfig, cfg *common.Config) {
 	k.initOnce.Do(func() {
-		client, err := kubernetes.GetKubernetesClient(config.KubeConfig)
-		if err != nil {
-			if kubernetes.IsInCluster(config.KubeConfig) {
-				k.log.Debugf("Could not create kubernetes client using in_cluster config: %+v", err)
-			} else if config.KubeConfig == "" {
-				k.log.Debugf("Could not create kubernetes client using config: %v: %+v", os.Getenv("KUBECONFIG"), err)
-			} else {
-				k.log.Debugf("Could not create kubernetes client using config: %v: %+v", config.KubeConfig, err)
-			}
-			return
+		if !k.initShared(config, cfg) {
+			k.initLocal(config, cfg)
 		}
+	})
+}
 
-		if !isKubernetesAvailableWithRetry(client) {
-			return
+// initLocal sets up the processor to watch the pods of the node itself.
+func (k *kubernetesAnnotator) initLocal(config kubeAnnotatorConfig, cfg *common.Config) {
+	client, err := kubernetes.GetKubernetesClient(config.KubeConfig)
+	if err != nil {
+		if kubernetes.IsInCluster(config.KubeConfig) {
+			k.log.Debugf("Could not create kubernetes client using in_cluster config: %+v", err)
+		} else if config.KubeConfig == "" {
+			k.log.Debugf("Could not create kubernetes client using config: %v: %+v", os.Getenv("KUBECONFIG"), err)
+		} else {
+			k.log.Debugf("Could not create kubernetes client using config: %v: %+v", config.KubeConfig, err)
 		}
+		return
+	}
 
-		matchers := NewMatchers(config.Matchers)
+	if !isKubernetesAvailableWithRetry(client) {
+		return
+	}
 
-		if matchers.Empty() {
-			k.log.Debugf("Could not initialize kubernetes plugin with zero matcher plugins")
-			return
-		}
+	matchers := NewMatchers(config.Matchers)
 
-		k.matchers = matchers
+	if matchers.Empty() {
+		k.log.Debugf("Could not initialize kubernetes plugin with zero matcher plugins")
+		return
+	}
 
-		config.Host = kubernetes.DiscoverKubernetesNode(k.log, config.Host, kubernetes.IsInCluster(config.KubeConfig), client)
+	k.matchers = matchers
 
-		k.log.Debugf("Initializing a new Kubernetes watcher using host: %s", config.Host)
+	config.Host = kubernetes.DiscoverKubernetesNode(k.log, config.Host, kubernetes.IsInCluster(config.KubeConfig), client)
 
-		watcher, err := kubernetes.NewWatcher(client, &kubernetes.Pod{}, kubernetes.WatchOptions{
-			SyncTimeout: config.SyncPeriod,
-			Node:        config.Host,
-			Namespace:   config.Namespace,
-		}, nil)
-		if err != nil {
-			k.log.Errorf("Couldn't create kubernetes watcher for %T", &kubernetes.Pod{})
-			return
-		}
+	k.log.Debugf("Initializing a new Kubernetes watcher using host: %s", config.Host)
 
-		metaGen := metadata.NewPodMetadataGenerator(cfg, watcher.Store(), nil, nil)
-		k.indexers = NewIndexers(config.Indexers, metaGen)
-		k.watcher = watcher
-		k.kubernetesAvailable = true
-
-		watcher.AddEventHandler(kubernetes.ResourceEventHandlerFuncs{
-			AddFunc: func(obj interface{}) {
-				pod := obj.(*kubernetes.Pod)
-				k.log.Debugf("Adding kubernetes pod: %s/%s", pod.GetNamespace(), pod.GetName())
-				k.addPod(pod)
-			},
-			UpdateFunc: func(obj interface{}) {
-				pod := obj.(*kubernetes.Pod)
-				k.log.Debugf("Updating kubernetes pod: %s/%s", pod.GetNamespace(), pod.GetName())
-				k.updatePod(pod)
-			},
-			DeleteFunc: func(obj interface{}) {
-				pod := obj.(*kubernetes.Pod)
-				k.log.Debugf("Removing pod: %s/%s", pod.GetNamespace(), pod.GetName())
-				k.removePod(pod)
-			},
-		})
-
-		if err := watcher.Start(); err != nil {
-			k.log.Debugf("add_kubernetes_metadata", "Couldn't start watcher: %v", err)
-			return
-		}
+	watcher, err := kubernetes.NewWatcher(client, &kubernetes.Pod{}, kubernetes.WatchOptions{
+		SyncTimeout: config.SyncPeriod,
+		Node:        config.Host,
+		Namespace:   config.Namespace,
+	}, nil)
+	if err != nil {
+		k.log.Errorf("Couldn't create kubernetes watcher for %T", &kubernetes.Pod{})
+		return
+	}
+
+	metaGen := metadata.NewPodMetadataGenerator(cfg, watcher.Store(), nil, nil)
+	k.indexers = NewIndexers(config.Indexers, metaGen)
+	k.watcher = watcher
+	k.kubernetesAvailable = true
+
+	k.sharePods(watcher.Store())
+
+	watcher.AddEventHandler(kubernetes.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod := obj.(*kubernetes.Pod)
+			k.log.Debugf("Adding kubernetes pod: %s/%s", pod.GetNamespace(), pod.GetName())
+			k.addPod(pod)
+		},
+		UpdateFunc: func(obj interface{}) {
+			pod := obj.(*kubernetes.Pod)
+			k.log.Debugf("Updating kubernetes pod: %s/%s", pod.GetNamespace(), pod.GetName())
+			k.updatePod(pod)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod := obj.(*kubernetes.Pod)
+			k.log.Debugf("Removing pod: %s/%s", pod.GetNamespace(), pod.GetName())
+			k.removePod(pod)
+		},
 	})
+
+	if err := watcher.Start(); err != nil {
+		k.log.Debugf("add_kubernetes_metadata", "Couldn't start watcher: %v", err)
+		return
+	}
 }
 
 func (k *kubernetesAnnotator) Run(event *beat.Event) (*beat.Event, error) {
@@ -231,6 +242,7 @@ func (k *kubernetesAnnotator) addPod(pod *kubernetes.Pod) {
 		k.log.Debugf("Created index %s for pod %s/%s", m.Index, pod.GetNamespace(), pod.GetName())
 		k.cache.set(m.Index, m.Data)
 	}
+	atomic.AddUint64(&k.version, 1)
 }
 
 func (k *kubernetesAnnotator) updatePod(pod *kubernetes.Pod) {
@@ -250,6 +262,7 @@ func (k *kubernetesAnnotator) removePod(pod *kubernetes.Pod) {
 	for _, idx := range indexes {
 		k.cache.delete(idx)
 	}
+	atomic.AddUint64(&k.version, 1)
 }
 
 func (*kubernetesAnnotator) String() string {
@@ -0,0 +1,182 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux darwin windows
+
+package add_kubernetes_metadata
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	k8scache "k8s.io/client-go/tools/cache"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/kubernetes"
+	"github.com/elastic/beats/v7/libbeat/common/kubernetes/metadata"
+	"github.com/elastic/beats/v7/libbeat/sharedmeta"
+)
+
+const (
+	// sharedKind is the kind of metadata shared with other beats on the host.
+	sharedKind = "kubernetes"
+
+	// sharedSyncPeriod is how often the pods shared by another beat are synced.
+	sharedSyncPeriod = time.Second
+)
+
+// sharePods shares the pods watched by the processor with the other beats on
+// the host. The pods are shared instead of their metadata, so that every beat
+// indexes them with its own indexers and matches them with its own matchers.
+//
+// The lookup key is the version of the pods known by the client, nothing is
+// returned if it is still up to date.
+func (k *kubernetesAnnotator) sharePods(store k8scache.Store) {
+	sharedmeta.Register(sharedKind, func(version string) (common.MapStr, bool) {
+		current := strconv.FormatUint(atomic.LoadUint64(&k.version), 10)
+		if version == current {
+			return nil, false
+		}
+		return common.MapStr{"version": current, "pods": store.List()}, true
+	})
+}
+
+// initShared sets up the processor to index the pods shared by another beat
+// on the host instead of watching them itself. It returns false if no pods
+// are shared.
+func (k *kubernetesAnnotator) initShared(config kubeAnnotatorConfig, cfg *common.Config) bool {
+	snapshot, _, err := sharedmeta.Fetch(sharedKind, "")
+	if err != nil {
+		if err != sharedmeta.ErrUnavailable {
+			k.log.Debugf("Watching kubernetes pods locally: %v", err)
+		}
+		return false
+	}
+
+	matchers := NewMatchers(config.Matchers)
+	if matchers.Empty() {
+		k.log.Debugf("Could not initialize kubernetes plugin with zero matcher plugins")
+		return false
+	}
+
+	store := k8scache.NewStore(k8scache.MetaNamespaceKeyFunc)
+	k.matchers = matchers
+	k.indexers = NewIndexers(config.Indexers, metadata.NewPodMetadataGenerator(cfg, store, nil, nil))
+
+	pods := &sharedPods{store: store}
+	if err := k.syncShared(pods, snapshot); err != nil {
+		k.log.Debugf("Watching kubernetes pods locally: %v", err)
+		return false
+	}
+
+	k.log.Info("Indexing kubernetes pods shared by another beat")
+	k.kubernetesAvailable = true
+	go k.runShared(pods, config, cfg)
+	return true
+}
+
+// sharedPods are the pods shared by another beat, as of version.
+type sharedPods struct {
+	version string
+	store   k8scache.Store
+}
+
+// runShared keeps the indexed pods in sync with the pods shared by another
+// beat. Events are annotated from the local cache, so they never wait for the
+// serving beat. If the serving beat cannot be reached anymore, the processor
+// falls back to watching the pods itself.
+func (k *kubernetesAnnotator) runShared(pods *sharedPods, config kubeAnnotatorConfig, cfg *common.Config) {
+	ticker := time.NewTicker(sharedSyncPeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		snapshot, changed, err := sharedmeta.Fetch(sharedKind, pods.version)
+		if err != nil {
+			k.log.Infof("Shared kubernetes pods not available anymore, watching pods locally: %v", err)
+			for _, obj := range pods.store.List() {
+				k.removePod(obj.(*kubernetes.Pod))
+			}
+			k.initLocal(config, cfg)
+			return
+		}
+		if !changed {
+			continue
+		}
+		if err := k.syncShared(pods, snapshot); err != nil {
+			k.log.Debugf("Error syncing shared kubernetes pods: %v", err)
+		}
+	}
+}
+
+// syncShared indexes the pods of a shared snapshot, and removes the pods that
+// are not shared anymore.
+func (k *kubernetesAnnotator) syncShared(pods *sharedPods, snapshot common.MapStr) error {
+	version, _ := snapshot["version"].(string)
+	shared, err := decodePods(snapshot["pods"])
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]bool, len(shared))
+	for _, pod := range shared {
+		key, err := k8scache.MetaNamespaceKeyFunc(pod)
+		if err != nil {
+			return err
+		}
+		current[key] = true
+
+		if old, exists, _ := pods.store.GetByKey(key); exists &&
+			old.(*kubernetes.Pod).GetResourceVersion() == pod.GetResourceVersion() {
+			continue
+		}
+		if err := pods.store.Update(pod); err != nil {
+			return err
+		}
+		k.log.Debugf("Updating shared kubernetes pod: %s", key)
+		k.updatePod(pod)
+	}
+
+	for _, obj := range pods.store.List() {
+		pod := obj.(*kubernetes.Pod)
+		key, _ := k8scache.MetaNamespaceKeyFunc(pod)
+		if current[key] {
+			continue
+		}
+		if err := pods.store.Delete(pod); err != nil {
+			return err
+		}
+		k.log.Debugf("Removing shared kubernetes pod: %s", key)
+		k.removePod(pod)
+	}
+
+	pods.version = version
+	return nil
+}
+
+// decodePods converts the pods of a snapshot, decoded as generic JSON, back
+// to kubernetes pods.
+func decodePods(v interface{}) ([]*kubernetes.Pod, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var pods []*kubernetes.Pod
+	err = json.Unmarshal(raw, &pods)
+	return pods, err
+}
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux darwin windows
+
+package add_kubernetes_metadata
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8scache "k8s.io/client-go/tools/cache"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/kubernetes"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func TestSyncShared(t *testing.T) {
+	podIndexer, err := NewPodNameIndexer(*common.NewConfig(), metagen)
+	require.NoError(t, err)
+
+	k := &kubernetesAnnotator{
+		log:      logp.NewLogger(selector),
+		cache:    newCache(10 * time.Second),
+		indexers: &Indexers{indexers: []Indexer{podIndexer}},
+	}
+	pods := &sharedPods{store: k8scache.NewStore(k8scache.MetaNamespaceKeyFunc)}
+
+	pod := func(name, resourceVersion string) *kubernetes.Pod {
+		return &kubernetes.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "testns",
+				ResourceVersion: resourceVersion,
+			},
+		}
+	}
+
+	// snapshot returns the pods as decoded from the serving beat.
+	snapshot := func(version string, pods ...*kubernetes.Pod) common.MapStr {
+		raw, err := json.Marshal(common.MapStr{"version": version, "pods": pods})
+		require.NoError(t, err)
+		var s common.MapStr
+		require.NoError(t, json.Unmarshal(raw, &s))
+		return s
+	}
+
+	require.NoError(t, k.syncShared(pods, snapshot("1", pod("a", "1"), pod("b", "1"))))
+	assert.Equal(t, "1", pods.version)
+	assert.Len(t, pods.store.List(), 2)
+	name, _ := k.cache.get("testns/a").GetValue("pod.name")
+	assert.Equal(t, "a", name)
+	assert.NotNil(t, k.cache.get("testns/b"))
+
+	require.NoError(t, k.syncShared(pods, snapshot("2", pod("a", "2"))))
+	assert.Equal(t, "2", pods.version)
+	assert.Len(t, pods.store.List(), 1)
+	assert.NotContains(t, k.cache.deleted, "testns/a")
+	assert.Contains(t, k.cache.deleted, "testns/b")
+}
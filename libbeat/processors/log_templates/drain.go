@@ -0,0 +1,223 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log_templates
+
+import (
+	"container/list"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// wildcard replaces the variable parts of a template.
+const wildcard = "<*>"
+
+// drain learns log templates using a fixed depth parse tree, as described in
+// "Drain: An Online Log Parsing Approach with Fixed Depth Tree" (He et al.).
+// Lines are routed by their number of tokens and their first tokens to a leaf
+// holding a small number of clusters. A line joins the most similar cluster of
+// its leaf if the similarity exceeds the threshold, otherwise a new cluster is
+// created. drain is not safe for concurrent use.
+type drain struct {
+	depth       int
+	similarity  float64
+	maxChildren int
+	maxClusters int
+
+	root map[int]*drainNode
+
+	// lru orders the clusters by last use, the least recently used cluster is
+	// evicted when maxClusters is exceeded.
+	lru *list.List
+}
+
+type drainNode struct {
+	children map[string]*drainNode
+	clusters []*cluster
+}
+
+type cluster struct {
+	id     string
+	tokens []string
+	count  uint64
+
+	leaf *drainNode
+	elem *list.Element
+}
+
+func newDrain(depth int, similarity float64, maxChildren, maxClusters int) *drain {
+	return &drain{
+		depth:       depth,
+		similarity:  similarity,
+		maxChildren: maxChildren,
+		maxClusters: maxClusters,
+		root:        map[int]*drainNode{},
+		lru:         list.New(),
+	}
+}
+
+func newDrainNode() *drainNode {
+	return &drainNode{children: map[string]*drainNode{}}
+}
+
+// pattern returns the template of the cluster.
+func (c *cluster) pattern() string {
+	return strings.Join(c.tokens, " ")
+}
+
+// add adds a line to the matching cluster, or creates a new cluster. novel is
+// true if a new cluster has been created.
+func (d *drain) add(line string) (c *cluster, novel bool) {
+	tokens := strings.Fields(line)
+	leaf := d.leaf(tokens)
+
+	if c = d.match(leaf, tokens); c != nil {
+		for i, token := range tokens {
+			if c.tokens[i] != token {
+				c.tokens[i] = wildcard
+			}
+		}
+		c.count++
+		d.lru.MoveToFront(c.elem)
+		return c, false
+	}
+
+	c = &cluster{
+		id:     templateID(tokens),
+		tokens: tokens,
+		count:  1,
+		leaf:   leaf,
+	}
+	c.elem = d.lru.PushFront(c)
+	leaf.clusters = append(leaf.clusters, c)
+
+	if d.lru.Len() > d.maxClusters {
+		d.evict(d.lru.Back().Value.(*cluster))
+	}
+	return c, true
+}
+
+// len returns the number of clusters.
+func (d *drain) len() int {
+	return d.lru.Len()
+}
+
+// leaf walks the parse tree by token count and the first depth tokens. Tokens
+// containing digits are likely variable and share the wildcard child, as do
+// all tokens seen after a node reached maxChildren.
+func (d *drain) leaf(tokens []string) *drainNode {
+	node, ok := d.root[len(tokens)]
+	if !ok {
+		node = newDrainNode()
+		d.root[len(tokens)] = node
+	}
+
+	for i := 0; i < d.depth && i < len(tokens); i++ {
+		key := tokens[i]
+		if hasDigit(key) {
+			key = wildcard
+		}
+
+		child, ok := node.children[key]
+		if !ok {
+			if len(node.children) >= d.maxChildren {
+				key = wildcard
+				child = node.children[key]
+			}
+			if child == nil {
+				child = newDrainNode()
+				node.children[key] = child
+			}
+		}
+		node = child
+	}
+	return node
+}
+
+// match returns the most similar cluster of the leaf, if its similarity
+// reaches the threshold. Ties are broken by the number of wildcards, such
+// that the more general template wins.
+func (d *drain) match(leaf *drainNode, tokens []string) *cluster {
+	var (
+		best          *cluster
+		bestSim       = -1.0
+		bestWildcards = -1
+	)
+	for _, c := range leaf.clusters {
+		sim, wildcards := similarity(c.tokens, tokens)
+		if sim > bestSim || (sim == bestSim && wildcards > bestWildcards) {
+			best, bestSim, bestWildcards = c, sim, wildcards
+		}
+	}
+
+	if best == nil || bestSim < d.similarity {
+		return nil
+	}
+	return best
+}
+
+func (d *drain) evict(c *cluster) {
+	d.lru.Remove(c.elem)
+
+	clusters := c.leaf.clusters
+	for i := range clusters {
+		if clusters[i] == c {
+			c.leaf.clusters = append(clusters[:i], clusters[i+1:]...)
+			break
+		}
+	}
+}
+
+// similarity returns the share of tokens equal to the template and the number
+// of wildcards in the template. Both have the same number of tokens.
+func similarity(template, tokens []string) (float64, int) {
+	if len(tokens) == 0 {
+		return 1, 0
+	}
+
+	same, wildcards := 0, 0
+	for i, token := range template {
+		switch {
+		case token == wildcard:
+			wildcards++
+		case token == tokens[i]:
+			same++
+		}
+	}
+	return float64(same) / float64(len(tokens)), wildcards
+}
+
+// templateID returns a stable ID for the template a cluster has been created
+// with. Tokens containing digits are masked, so the same kind of line gets the
+// same ID on every host and after restarts.
+func templateID(tokens []string) string {
+	h := fnv.New64a()
+	for _, token := range tokens {
+		if hasDigit(token) {
+			token = wildcard
+		}
+		h.Write([]byte(token))
+		h.Write([]byte{' '})
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
@@ -0,0 +1,78 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log_templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain(t *testing.T) {
+	d := newDrain(1, 0.4, 100, 10)
+
+	c, novel := d.add("Connected to 10.0.0.1 port 22")
+	assert.True(t, novel)
+	id := c.id
+
+	c, novel = d.add("Connected to 10.0.0.2 port 22")
+	assert.False(t, novel)
+	assert.Equal(t, id, c.id)
+	assert.Equal(t, "Connected to <*> port 22", c.pattern())
+
+	c, novel = d.add("Connected to 10.0.0.3 port 2222")
+	assert.False(t, novel)
+	assert.Equal(t, "Connected to <*> port <*>", c.pattern())
+	assert.Equal(t, uint64(3), c.count)
+
+	c, novel = d.add("User alice logged in")
+	assert.True(t, novel)
+	c, novel = d.add("User bob logged in")
+	assert.False(t, novel)
+	assert.Equal(t, "User <*> logged in", c.pattern())
+
+	// lines with a different number of tokens never match
+	_, novel = d.add("Connected to 10.0.0.4")
+	assert.True(t, novel)
+
+	assert.Equal(t, 3, d.len())
+}
+
+func TestDrainEvictsLeastRecentlyUsed(t *testing.T) {
+	d := newDrain(1, 0.4, 100, 2)
+
+	d.add("first template line")
+	d.add("second kind of line here")
+	d.add("first template line")
+	d.add("a third one")
+	assert.Equal(t, 2, d.len())
+
+	_, novel := d.add("first template line")
+	assert.False(t, novel)
+	_, novel = d.add("second kind of line here")
+	assert.True(t, novel)
+}
+
+func TestTemplateIDStable(t *testing.T) {
+	a := newDrain(1, 0.4, 100, 10)
+	b := newDrain(1, 0.4, 100, 10)
+
+	ca, _ := a.add("request 1234 took 5ms")
+	cb, _ := b.add("request 987 took 12ms")
+	assert.Equal(t, ca.id, cb.id)
+}
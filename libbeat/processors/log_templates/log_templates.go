@@ -0,0 +1,202 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log_templates
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/processors"
+)
+
+const (
+	processorName = "log_templates"
+
+	modeTag    = "tag"
+	modeSample = "sample"
+)
+
+type config struct {
+	Field         string  `config:"field"`
+	SourceField   string  `config:"source_field"`
+	TargetField   string  `config:"target_field"`
+	Mode          string  `config:"mode"`
+	SampleRate    float64 `config:"sample_rate" validate:"min=0, max=1"`
+	Similarity    float64 `config:"similarity_threshold" validate:"min=0, max=1"`
+	Depth         int     `config:"depth" validate:"min=1"`
+	MaxChildren   int     `config:"max_children" validate:"min=1"`
+	MaxTemplates  int     `config:"max_templates" validate:"min=1"`
+	MaxSources    int     `config:"max_sources" validate:"min=1"`
+	IgnoreMissing bool    `config:"ignore_missing"`
+}
+
+func defaultConfig() config {
+	return config{
+		Field:        "message",
+		SourceField:  "log.file.path",
+		TargetField:  "log.template",
+		Mode:         modeTag,
+		SampleRate:   0.1,
+		Similarity:   0.4,
+		Depth:        1,
+		MaxChildren:  100,
+		MaxTemplates: 1000,
+		MaxSources:   1000,
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Mode {
+	case modeTag, modeSample:
+	default:
+		return fmt.Errorf("unknown mode '%v', expecting '%v' or '%v'", c.Mode, modeTag, modeSample)
+	}
+	return nil
+}
+
+type logTemplates struct {
+	config config
+	log    *logp.Logger
+
+	// sampleEvery is the number of seen-before lines of a template out of
+	// which one is forwarded in sample mode. Zero drops all of them.
+	sampleEvery uint64
+
+	mu      sync.Mutex
+	sources map[string]*list.Element // of *source
+	lru     *list.List
+}
+
+type source struct {
+	name  string
+	drain *drain
+}
+
+func init() {
+	processors.RegisterPlugin(processorName, New)
+}
+
+// New constructs a new log_templates processor.
+func New(c *common.Config) (processors.Processor, error) {
+	config := defaultConfig()
+	if err := c.Unpack(&config); err != nil {
+		return nil, errors.Wrapf(err, "fail to unpack the %v configuration", processorName)
+	}
+
+	var sampleEvery uint64
+	if config.SampleRate > 0 {
+		sampleEvery = uint64(math.Round(1 / config.SampleRate))
+	}
+
+	return &logTemplates{
+		config:      config,
+		log:         logp.NewLogger(processorName),
+		sampleEvery: sampleEvery,
+		sources:     map[string]*list.Element{},
+		lru:         list.New(),
+	}, nil
+}
+
+// Run assigns the event to a template of its source. Events creating a new
+// template are always forwarded and marked as novel. In sample mode, only a
+// share of the events matching an existing template is forwarded.
+func (p *logTemplates) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.config.Field)
+	if err != nil {
+		if p.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "could not fetch value for field %s", p.config.Field)
+	}
+	line, ok := v.(string)
+	if !ok {
+		return event, errors.Errorf("field %s is not of string type", p.config.Field)
+	}
+
+	// Events without source share the empty source.
+	var sourceName string
+	if v, err := event.GetValue(p.config.SourceField); err == nil {
+		sourceName = fmt.Sprint(v)
+	}
+
+	p.mu.Lock()
+	c, novel := p.source(sourceName).drain.add(line)
+	id, pattern, count := c.id, c.pattern(), c.count
+	p.mu.Unlock()
+
+	if p.config.Mode == modeSample && !novel && !p.sampled(count) {
+		return nil, nil
+	}
+
+	fields := common.MapStr{
+		"id":      id,
+		"pattern": pattern,
+		"novel":   novel,
+		"count":   count,
+	}
+	if p.config.Mode == modeSample && !novel {
+		fields["sample_rate"] = p.config.SampleRate
+	}
+	if _, err := event.PutValue(p.config.TargetField, fields); err != nil {
+		return event, errors.Wrapf(err, "could not set field %s", p.config.TargetField)
+	}
+	return event, nil
+}
+
+// sampled checks if the n-th event of a template is forwarded. The first
+// event of a template is novel, so counting starts with the second.
+func (p *logTemplates) sampled(n uint64) bool {
+	if p.sampleEvery == 0 {
+		return false
+	}
+	return (n-2)%p.sampleEvery == 0
+}
+
+// source returns the templates of a source. If max_sources is exceeded, the
+// least recently used source is forgotten.
+func (p *logTemplates) source(name string) *source {
+	if elem, ok := p.sources[name]; ok {
+		p.lru.MoveToFront(elem)
+		return elem.Value.(*source)
+	}
+
+	s := &source{
+		name:  name,
+		drain: newDrain(p.config.Depth, p.config.Similarity, p.config.MaxChildren, p.config.MaxTemplates),
+	}
+	p.sources[name] = p.lru.PushFront(s)
+
+	if p.lru.Len() > p.config.MaxSources {
+		oldest := p.lru.Remove(p.lru.Back()).(*source)
+		delete(p.sources, oldest.name)
+		p.log.Debugf("Forgetting %v templates of source '%v'", oldest.drain.len(), oldest.name)
+	}
+	return s
+}
+
+func (p *logTemplates) String() string {
+	return fmt.Sprintf("%v=[field=%v, source_field=%v, mode=%v]",
+		processorName, p.config.Field, p.config.SourceField, p.config.Mode)
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package log_templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func newTestEvent(source, message string) *beat.Event {
+	return &beat.Event{
+		Fields: common.MapStr{
+			"message": message,
+			"log":     common.MapStr{"file": common.MapStr{"path": source}},
+		},
+	}
+}
+
+func TestTagMode(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{}))
+	require.NoError(t, err)
+
+	event, err := p.Run(newTestEvent("/var/log/a.log", "User alice logged in"))
+	require.NoError(t, err)
+	novel, _ := event.GetValue("log.template.novel")
+	assert.Equal(t, true, novel)
+
+	event, err = p.Run(newTestEvent("/var/log/a.log", "User bob logged in"))
+	require.NoError(t, err)
+	template, err := event.GetValue("log.template")
+	require.NoError(t, err)
+	assert.Equal(t, false, template.(common.MapStr)["novel"])
+	assert.Equal(t, "User <*> logged in", template.(common.MapStr)["pattern"])
+	assert.Equal(t, uint64(2), template.(common.MapStr)["count"])
+
+	// templates are learned per source
+	event, err = p.Run(newTestEvent("/var/log/b.log", "User carol logged in"))
+	require.NoError(t, err)
+	novel, _ = event.GetValue("log.template.novel")
+	assert.Equal(t, true, novel)
+}
+
+func TestSampleMode(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{
+		"mode":        "sample",
+		"sample_rate": 0.25,
+	}))
+	require.NoError(t, err)
+
+	var forwarded int
+	for i := 0; i < 9; i++ {
+		event, err := p.Run(newTestEvent("/var/log/a.log", "request handled in 10ms"))
+		require.NoError(t, err)
+		if event != nil {
+			forwarded++
+		}
+	}
+	// the novel event and every 4th of the remaining 8 events
+	assert.Equal(t, 3, forwarded)
+
+	// novel patterns are always forwarded
+	event, err := p.Run(newTestEvent("/var/log/a.log", "connection reset by peer"))
+	require.NoError(t, err)
+	assert.NotNil(t, event)
+}
+
+func TestMissingField(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(common.MapStr{"ignore_missing": true}))
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.NotNil(t, event)
+
+	p, err = New(common.MustNewConfigFrom(common.MapStr{}))
+	require.NoError(t, err)
+	_, err = p.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.Error(t, err)
+}
+
+func TestInvalidMode(t *testing.T) {
+	_, err := New(common.MustNewConfigFrom(common.MapStr{"mode": "drop"}))
+	assert.Error(t, err)
+}
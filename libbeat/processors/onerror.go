@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// DeadLetterError is returned by processors configured with the `dead_letter`
+// error policy. The event has been marked as dead letter, it is published
+// without running the remaining processors.
+type DeadLetterError struct {
+	Err error
+}
+
+func (e *DeadLetterError) Error() string { return e.Err.Error() }
+func (e *DeadLetterError) Unwrap() error { return e.Err }
+
+// onErrorProcessor applies the `on_error` policy of a processor to its errors.
+type onErrorProcessor struct {
+	Processor
+	policy failpolicy.Policy
+	log    *logp.Logger
+}
+
+// withErrorPolicy wraps the processor, unless errors are ignored which is the
+// default behavior of processors.
+func withErrorPolicy(p Processor, policy failpolicy.Policy, log *logp.Logger) Processor {
+	if policy == failpolicy.Ignore {
+		return p
+	}
+	return &onErrorProcessor{Processor: p, policy: policy, log: log}
+}
+
+func (p *onErrorProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	result, err := p.Processor.Run(event)
+	if err == nil {
+		return result, nil
+	}
+
+	switch p.policy {
+	case failpolicy.DropEvent:
+		p.log.Debugf("Dropping event, processor %v failed: %v", p.Processor, err)
+		return nil, nil
+	case failpolicy.DeadLetter:
+		// processors may not return the event on error
+		if result == nil {
+			result = event
+		}
+		failpolicy.MarkDeadLetter(result, "processor "+p.Processor.String(), err)
+		return result, &DeadLetterError{Err: err}
+	case failpolicy.FailPipeline:
+		return nil, errors.Wrapf(err, "failed applying processor %v", p.Processor)
+	}
+	return result, err
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+type failingProcessor struct{}
+
+func (failingProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	event.Fields.Put("failed", true)
+	return event, errors.New("processing failed")
+}
+
+func (failingProcessor) String() string { return "test_fail" }
+
+func init() {
+	RegisterPlugin("test_fail", func(*common.Config) (Processor, error) {
+		return failingProcessor{}, nil
+	})
+}
+
+func newOnErrorProcessors(t *testing.T, onError string) *Processors {
+	config := common.MustNewConfigFrom(map[string]interface{}{"test_fail": nil})
+	if onError != "" {
+		config.SetString("on_error", -1, onError)
+	}
+
+	procs, err := New(PluginConfig{config})
+	require.NoError(t, err)
+	require.Len(t, procs.List, 1)
+	return procs
+}
+
+func TestOnErrorPolicy(t *testing.T) {
+	t.Run("ignore", func(t *testing.T) {
+		procs := newOnErrorProcessors(t, "")
+		event, err := procs.List[0].Run(&beat.Event{Fields: common.MapStr{}})
+		assert.Error(t, err)
+		assert.Equal(t, common.MapStr{"failed": true}, event.Fields)
+	})
+
+	t.Run("drop_event", func(t *testing.T) {
+		procs := newOnErrorProcessors(t, "drop_event")
+		event, err := procs.List[0].Run(&beat.Event{Fields: common.MapStr{}})
+		assert.NoError(t, err)
+		assert.Nil(t, event)
+	})
+
+	t.Run("dead_letter", func(t *testing.T) {
+		procs := newOnErrorProcessors(t, "dead_letter")
+		event, err := procs.List[0].Run(&beat.Event{Fields: common.MapStr{}})
+
+		var deadLetter *DeadLetterError
+		require.True(t, errors.As(err, &deadLetter))
+		assert.Equal(t, "processing failed", event.Fields["error"].(common.MapStr)["message"])
+		assert.Equal(t, "processor test_fail", event.Meta["dead_letter"].(common.MapStr)["stage"])
+	})
+
+	t.Run("fail_pipeline", func(t *testing.T) {
+		procs := newOnErrorProcessors(t, "fail_pipeline")
+		event, err := procs.List[0].Run(&beat.Event{Fields: common.MapStr{}})
+		assert.Error(t, err)
+		assert.Nil(t, event)
+	})
+}
+
+func TestOnErrorInvalid(t *testing.T) {
+	config := common.MustNewConfigFrom(map[string]interface{}{"test_fail": nil, "on_error": "retry"})
+	_, err := New(PluginConfig{config})
+	assert.Error(t, err)
+
+	config = common.MustNewConfigFrom(map[string]interface{}{"on_error": "drop_event"})
+	_, err = New(PluginConfig{config})
+	assert.Error(t, err)
+}
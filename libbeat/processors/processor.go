@@ -24,6 +24,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/failpolicy"
 	"github.com/elastic/beats/v7/libbeat/logp"
 )
 
@@ -54,24 +55,38 @@ func New(config PluginConfig) (*Processors, error) {
 	procs := NewList(nil)
 
 	for _, procConfig := range config {
+		// The on_error policy is set next to the action of the processor.
+		onError := struct {
+			Policy failpolicy.Policy `config:"on_error"`
+		}{failpolicy.Ignore}
+		if err := procConfig.Unpack(&onError); err != nil {
+			return nil, err
+		}
+
 		// Handle if/then/else processor which has multiple top-level keys.
 		if procConfig.HasField("if") {
 			p, err := NewIfElseThenProcessor(procConfig)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to make if/then/else processor")
 			}
-			procs.AddProcessor(p)
+			procs.AddProcessor(withErrorPolicy(p, onError.Policy, procs.log))
 			continue
 		}
 
-		if len(procConfig.GetFields()) != 1 {
+		var actions []string
+		for _, field := range procConfig.GetFields() {
+			if field != "on_error" {
+				actions = append(actions, field)
+			}
+		}
+		if len(actions) != 1 {
 			return nil, errors.Errorf("each processor must have exactly one "+
 				"action, but found %d actions (%v)",
-				len(procConfig.GetFields()),
-				strings.Join(procConfig.GetFields(), ","))
+				len(actions),
+				strings.Join(actions, ","))
 		}
 
-		actionName := procConfig.GetFields()[0]
+		actionName := actions[0]
 		actionCfg, err := procConfig.Child(actionName, -1)
 		if err != nil {
 			return nil, err
@@ -94,7 +109,7 @@ func New(config PluginConfig) (*Processors, error) {
 			return nil, err
 		}
 
-		procs.AddProcessor(plugin)
+		procs.AddProcessor(withErrorPolicy(plugin, onError.Policy, procs.log))
 	}
 
 	if len(procs.List) > 0 {
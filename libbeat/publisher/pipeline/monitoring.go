@@ -43,6 +43,7 @@ type clientObserver interface {
 
 type queueObserver interface {
 	queueACKed(n int)
+	queueMaxEvents(n int)
 }
 
 type outputObserver interface {
@@ -72,7 +73,7 @@ type metricsObserver struct {
 	activeEvents                        *monitoring.Uint
 
 	// queue metrics
-	ackedQueue *monitoring.Uint
+	ackedQueue, maxQueueEvents *monitoring.Uint
 }
 
 func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
@@ -92,7 +93,8 @@ func newMetricsObserver(metrics *monitoring.Registry) *metricsObserver {
 		dropped:   monitoring.NewUint(reg, "events.dropped"),
 		retry:     monitoring.NewUint(reg, "events.retry"),
 
-		ackedQueue: monitoring.NewUint(reg, "queue.acked"),
+		ackedQueue:     monitoring.NewUint(reg, "queue.acked"),
+		maxQueueEvents: monitoring.NewUint(reg, "queue.max_events"),
 
 		activeEvents: monitoring.NewUint(reg, "events.active"),
 	}
@@ -154,6 +156,14 @@ func (o *metricsObserver) queueACKed(n int) {
 	o.activeEvents.Sub(uint64(n))
 }
 
+// (pipeline) capacity of the queue in use, 0 if not limited by number of events
+func (o *metricsObserver) queueMaxEvents(n int) {
+	if n < 0 {
+		n = 0
+	}
+	o.maxQueueEvents.Set(uint64(n))
+}
+
 //
 // pipeline output events
 //
@@ -193,6 +203,7 @@ func (*emptyObserver) filteredEvent()      {}
 func (*emptyObserver) publishedEvent()     {}
 func (*emptyObserver) failedPublishEvent() {}
 func (*emptyObserver) queueACKed(n int)    {}
+func (*emptyObserver) queueMaxEvents(int)  {}
 func (*emptyObserver) updateOutputGroup()  {}
 func (*emptyObserver) eventsFailed(int)    {}
 func (*emptyObserver) eventsDropped(int)   {}
@@ -177,6 +177,7 @@ func New(
 	}
 
 	maxEvents := p.queue.BufferConfig().MaxEvents
+	p.observer.queueMaxEvents(maxEvents)
 	if maxEvents <= 0 {
 		// Maximum number of events until acker starts blocking.
 		// Only active if pipeline can drop events.
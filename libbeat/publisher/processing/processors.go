@@ -18,6 +18,7 @@
 package processing
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -103,6 +104,14 @@ func (p *group) Run(event *beat.Event) (*beat.Event, error) {
 		var err error
 
 		event, err = sub.Run(event)
+
+		// Dead letter events are published without running the remaining processors
+		var deadLetter *processors.DeadLetterError
+		if errors.As(err, &deadLetter) && event != nil {
+			p.log.Debugf("Publish dead letter event, processor %s failed: %s", sub, err)
+			return event, nil
+		}
+
 		if err != nil {
 			// XXX: We don't drop the event, but continue filtering here if the most
 			//      recent processor did return an event.
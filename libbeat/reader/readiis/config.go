@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package readiis
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Log formats supported by the reader.
+const (
+	FormatW3C  = "w3c"  // W3C extended log file format, the IIS default.
+	FormatNCSA = "ncsa" // NCSA common log file format.
+)
+
+// Config holds the options of the IIS reader.
+type Config struct {
+	Format string `config:"format"`
+	Target string `config:"target"`
+
+	// Fields used to parse W3C lines until a #Fields directive is read.
+	Fields []string `config:"fields"`
+}
+
+// DefaultConfig returns the default configuration of the IIS reader.
+func DefaultConfig() Config {
+	return Config{
+		Format: FormatW3C,
+		Target: "iis.fields",
+	}
+}
+
+// Unpack sets the defaults before unpacking the configuration, so that
+// `iis: {}` enables the reader with the default settings.
+func (c *Config) Unpack(from *common.Config) error {
+	type tmpConfig Config
+	tmp := tmpConfig(DefaultConfig())
+	if err := from.Unpack(&tmp); err != nil {
+		return err
+	}
+	*c = Config(tmp)
+	return nil
+}
+
+// Validate validates the Config option for the IIS reader.
+func (c *Config) Validate() error {
+	switch c.Format {
+	case FormatW3C, FormatNCSA:
+	default:
+		return fmt.Errorf("invalid IIS log format '%s', expected '%s' or '%s'", c.Format, FormatW3C, FormatNCSA)
+	}
+	if c.Target == "" {
+		return fmt.Errorf("the IIS reader target can not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package readiis
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/reader"
+)
+
+var fieldsDirective = []byte("#Fields:")
+
+// ncsaLine matches the NCSA common log format:
+// host rfc931 username [date] "method uri version" status bytes
+var ncsaLine = regexp.MustCompile(`^(\S+) (\S+) (\S+) \[([^\]]+)\] "(\S+) (\S+)(?: (\S+))?" (\S+) (\S+)`)
+
+const ncsaTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// Reader parses the lines of IIS log files into fields, named after the
+// W3C extended log format identifiers (c-ip, cs-method, sc-status...).
+//
+// In the W3C format the fields of the lines are declared by the #Fields
+// directive of the file, that IIS writes again whenever the logging
+// configuration changes, so the fields are taken from the last directive
+// read. NCSA lines always have the same fields, and are converted to the
+// same identifiers, with the date and time in UTC like in W3C files.
+//
+// Lines that can not be parsed, like the lines of W3C files whose
+// #Fields directive is unknown, are returned unchanged so they can still be
+// parsed later. Directive lines are returned unchanged too, the offset of the
+// file must include them.
+type Reader struct {
+	reader reader.Reader
+	cfg    *Config
+	fields []string
+	logger *logp.Logger
+}
+
+// New creates a new IIS reader. fields are the fields declared by the last
+// #Fields directive of the file before the reading started, if any.
+func New(r reader.Reader, cfg *Config, fields []string) *Reader {
+	if len(fields) == 0 {
+		fields = cfg.Fields
+	}
+	return &Reader{
+		reader: r,
+		cfg:    cfg,
+		fields: fields,
+		logger: logp.NewLogger("reader_iis"),
+	}
+}
+
+// Next returns the next line, with the parsed fields added under the target.
+func (r *Reader) Next() (reader.Message, error) {
+	message, err := r.reader.Next()
+	if err != nil {
+		return message, err
+	}
+
+	var values common.MapStr
+	if r.cfg.Format == FormatNCSA {
+		values = parseNCSA(message.Content)
+	} else {
+		values = r.parseW3C(message.Content)
+	}
+	if values == nil {
+		return message, nil
+	}
+
+	fields := common.MapStr{}
+	fields.Put(r.cfg.Target, values)
+	message.AddFields(fields)
+	return message, nil
+}
+
+// Close closes the underlying reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}
+
+func (r *Reader) parseW3C(line []byte) common.MapStr {
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] == '#' {
+		if fields, ok := parseFieldsDirective(line); ok {
+			r.logger.Debugf("Fields changed to %v", fields)
+			r.fields = fields
+		}
+		return nil
+	}
+	if len(r.fields) == 0 {
+		return nil
+	}
+
+	values := splitW3C(string(line))
+	if len(values) != len(r.fields) {
+		r.logger.Debugf("Line has %d values but %d fields are declared, not parsing it", len(values), len(r.fields))
+		return nil
+	}
+
+	parsed := common.MapStr{}
+	for i, value := range values {
+		if value == "-" {
+			continue
+		}
+		parsed[r.fields[i]] = value
+	}
+	return parsed
+}
+
+// parseFieldsDirective returns the fields declared by a #Fields directive.
+func parseFieldsDirective(line []byte) ([]string, bool) {
+	if !bytes.HasPrefix(line, fieldsDirective) {
+		return nil, false
+	}
+	fields := strings.Fields(string(line[len(fieldsDirective):]))
+	return fields, len(fields) > 0
+}
+
+// splitW3C splits a W3C line on spaces, keeping quoted values together.
+func splitW3C(line string) []string {
+	var values []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return values
+		}
+		if line[0] == '"' {
+			if end := strings.IndexByte(line[1:], '"'); end >= 0 {
+				values = append(values, line[1:end+1])
+				line = line[end+2:]
+				continue
+			}
+		}
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			return append(values, line)
+		}
+		values = append(values, line[:end])
+		line = line[end:]
+	}
+}
+
+func parseNCSA(line []byte) common.MapStr {
+	m := ncsaLine.FindStringSubmatch(string(line))
+	if m == nil {
+		return nil
+	}
+
+	parsed := common.MapStr{}
+	set := func(name, value string) {
+		if value != "" && value != "-" {
+			parsed[name] = value
+		}
+	}
+	set("c-ip", m[1])
+	set("cs-username", m[3])
+	if ts, err := time.Parse(ncsaTimeLayout, m[4]); err == nil {
+		ts = ts.UTC()
+		parsed["date"] = ts.Format("2006-01-02")
+		parsed["time"] = ts.Format("15:04:05")
+	}
+	set("cs-method", m[5])
+	uri := m[6]
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		set("cs-uri-query", uri[i+1:])
+		uri = uri[:i]
+	}
+	set("cs-uri-stem", uri)
+	set("cs-version", m[7])
+	set("sc-status", m[8])
+	set("sc-bytes", m[9])
+	return parsed
+}
+
+// ScanFields returns the fields declared by the last #Fields directive read
+// from r. It is used to recover the fields of a W3C file when reading resumes
+// from an offset after its header.
+func ScanFields(r io.Reader) ([]string, error) {
+	var fields []string
+	br := bufio.NewReader(r)
+	lineStart := true
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err == io.EOF {
+			return fields, nil
+		}
+		if err != nil {
+			return fields, err
+		}
+		// Only the beginning of long lines is checked, directives are short.
+		if lineStart {
+			if f, ok := parseFieldsDirective(chunk); ok {
+				fields = f
+			}
+		}
+		lineStart = !isPrefix
+	}
+}
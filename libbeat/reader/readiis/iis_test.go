@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package readiis
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/reader"
+)
+
+func TestW3C(t *testing.T) {
+	lines := []string{
+		"#Software: Microsoft Internet Information Services 10.0",
+		"#Fields: date time c-ip cs-method cs-uri-stem sc-status",
+		"2018-01-01 08:09:10 85.181.35.98 GET / 200",
+		"2018-01-01 08:09:11 85.181.35.98 GET /missing -",
+		// Logging fields changed by the administrator.
+		"#Fields: date time cs-method sc-status cs(User-Agent) time-taken",
+		`2018-01-01 08:10:00 POST 500 "Mozilla/5.0 (X11)" 12`,
+		"2018-01-01 08:10:01 too few values",
+	}
+	expected := []common.MapStr{
+		nil,
+		nil,
+		{"date": "2018-01-01", "time": "08:09:10", "c-ip": "85.181.35.98", "cs-method": "GET", "cs-uri-stem": "/", "sc-status": "200"},
+		{"date": "2018-01-01", "time": "08:09:11", "c-ip": "85.181.35.98", "cs-method": "GET", "cs-uri-stem": "/missing"},
+		nil,
+		{"date": "2018-01-01", "time": "08:10:00", "cs-method": "POST", "sc-status": "500", "cs(User-Agent)": "Mozilla/5.0 (X11)", "time-taken": "12"},
+		nil,
+	}
+
+	config := DefaultConfig()
+	r := New(&mockReader{lines: lines}, &config, nil)
+	for i, line := range lines {
+		message, err := r.Next()
+		require.NoError(t, err)
+		assert.Equal(t, line, string(message.Content), "line %d", i)
+		assertFields(t, expected[i], message, i)
+	}
+}
+
+func TestW3CResume(t *testing.T) {
+	header := "#Fields: date time sc-status\n#Date: 2018-01-01\n2018-01-01 08:09:10 200\n"
+	fields, err := ScanFields(strings.NewReader(header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"date", "time", "sc-status"}, fields)
+
+	config := DefaultConfig()
+	r := New(&mockReader{lines: []string{"2018-01-01 08:09:11 404"}}, &config, fields)
+	message, err := r.Next()
+	require.NoError(t, err)
+	assertFields(t, common.MapStr{"date": "2018-01-01", "time": "08:09:11", "sc-status": "404"}, message, 0)
+}
+
+func TestW3CDefaultFields(t *testing.T) {
+	config := DefaultConfig()
+	config.Fields = []string{"date", "time", "sc-status"}
+	r := New(&mockReader{lines: []string{"2018-01-01 08:09:11 404"}}, &config, nil)
+	message, err := r.Next()
+	require.NoError(t, err)
+	assertFields(t, common.MapStr{"date": "2018-01-01", "time": "08:09:11", "sc-status": "404"}, message, 0)
+}
+
+func TestNCSA(t *testing.T) {
+	lines := []string{
+		`172.21.13.45 - Microsoft\fred [08/Apr/2001:17:39:04 -0800] "GET /scripts/iisadmin/ism.dll?http/serv HTTP/1.0" 200 3401`,
+		"not a NCSA line",
+	}
+	expected := []common.MapStr{
+		{
+			"c-ip":         "172.21.13.45",
+			"cs-username":  `Microsoft\fred`,
+			"date":         "2001-04-09",
+			"time":         "01:39:04",
+			"cs-method":    "GET",
+			"cs-uri-stem":  "/scripts/iisadmin/ism.dll",
+			"cs-uri-query": "http/serv",
+			"cs-version":   "HTTP/1.0",
+			"sc-status":    "200",
+			"sc-bytes":     "3401",
+		},
+		nil,
+	}
+
+	config := DefaultConfig()
+	config.Format = FormatNCSA
+	config.Target = "ncsa"
+	r := New(&mockReader{lines: lines}, &config, nil)
+	for i := range lines {
+		message, err := r.Next()
+		require.NoError(t, err)
+		if expected[i] == nil {
+			assert.Nil(t, message.Fields, "line %d", i)
+			continue
+		}
+		assert.Equal(t, common.MapStr{"ncsa": expected[i]}, message.Fields, "line %d", i)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	var config Config
+	require.NoError(t, common.MustNewConfigFrom(map[string]interface{}{}).Unpack(&config))
+	assert.Equal(t, DefaultConfig(), config)
+
+	err := common.MustNewConfigFrom(map[string]interface{}{"format": "apache"}).Unpack(&config)
+	assert.Error(t, err)
+}
+
+func assertFields(t *testing.T, expected common.MapStr, message reader.Message, line int) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, message.Fields, "line %d", line)
+		return
+	}
+	values, err := message.Fields.GetValue("iis.fields")
+	require.NoError(t, err, "line %d", line)
+	assert.Equal(t, expected, values, "line %d", line)
+}
+
+type mockReader struct {
+	lines []string
+}
+
+func (m *mockReader) Next() (reader.Message, error) {
+	if len(m.lines) == 0 {
+		return reader.Message{}, io.EOF
+	}
+	line := m.lines[0]
+	m.lines = m.lines[1:]
+	return reader.Message{
+		Content: []byte(line),
+		Bytes:   len(line) + 1,
+	}, nil
+}
+
+func (m *mockReader) Close() error { return nil }
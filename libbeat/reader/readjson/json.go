@@ -35,6 +35,8 @@ type JSONReader struct {
 	reader reader.Reader
 	cfg    *Config
 	logger *logp.Logger
+
+	onDecodeError func()
 }
 
 // NewJSONReader creates a new reader that can decode JSON.
@@ -46,6 +48,12 @@ func NewJSONReader(r reader.Reader, cfg *Config) *JSONReader {
 	}
 }
 
+// OnDecodeError sets a function called for each line that can not be decoded,
+// for example to count the decoding errors.
+func (r *JSONReader) OnDecodeError(f func()) {
+	r.onDecodeError = f
+}
+
 // decodeJSON unmarshals the text parameter into a MapStr and
 // returns the new text column if one was requested.
 func (r *JSONReader) decode(text []byte) ([]byte, common.MapStr) {
@@ -53,6 +61,9 @@ func (r *JSONReader) decode(text []byte) ([]byte, common.MapStr) {
 
 	err := unmarshal(text, &jsonFields)
 	if err != nil || jsonFields == nil {
+		if r.onDecodeError != nil {
+			r.onDecodeError()
+		}
 		if !r.cfg.IgnoreDecodingError {
 			r.logger.Errorf("Error decoding JSON: %v", err)
 		}
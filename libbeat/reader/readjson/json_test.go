@@ -194,6 +194,20 @@ func TestDecodeJSON(t *testing.T) {
 	}
 }
 
+func TestDecodeJSONErrorCallback(t *testing.T) {
+	p := NewJSONReader(nil, &Config{MessageKey: "message", IgnoreDecodingError: true})
+	errors := 0
+	p.OnDecodeError(func() { errors++ })
+
+	p.decode([]byte(`{"message": "test"}`))
+	p.decode([]byte(`{"value": "test"}`))
+	assert.Equal(t, 0, errors)
+
+	p.decode([]byte(`{"message": "test`))
+	p.decode([]byte(`null`))
+	assert.Equal(t, 2, errors)
+}
+
 func TestAddJSONFields(t *testing.T) {
 	type io struct {
 	}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sharedmeta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+type client struct {
+	log   *logp.Logger
+	http  http.Client
+	cache *common.Cache // nil if caching is disabled
+}
+
+func newClient(log *logp.Logger, config Config) (*client, error) {
+	dial, err := makeDialer(config.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{
+		log: log,
+		http: http.Client{
+			Timeout:   config.Timeout,
+			Transport: &http.Transport{DialContext: dial},
+		},
+	}
+	if config.CacheTTL > 0 {
+		c.cache = common.NewCacheWithExpireOnAdd(config.CacheTTL, 8)
+		c.cache.StartJanitor(config.CacheTTL)
+	}
+	return c, nil
+}
+
+// lookup asks the serving beat for the metadata of the given kind and key.
+// Metadata that is found is cached if cached is set, misses are never cached
+// so metadata that appears on the server is picked up by the next lookup.
+func (c *client) lookup(kind, key string, cached bool) (common.MapStr, bool, error) {
+	cacheKey := kind + "/" + key
+	if cached && c.cache != nil {
+		if metadata, ok := c.cache.Get(cacheKey).(common.MapStr); ok {
+			return metadata.Clone(), true, nil
+		}
+	}
+
+	u := url.URL{
+		Scheme:   "http",
+		Host:     "beat",
+		Path:     pathPrefix + kind,
+		RawQuery: url.Values{"key": []string{key}}.Encode(),
+	}
+	resp, err := c.http.Get(u.String())
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to look up shared %v metadata", kind)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("failed to look up shared %v metadata: %v", kind, resp.Status)
+	}
+
+	var r response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to decode shared %v metadata", kind)
+	}
+
+	c.log.Debugf("Looked up shared %v metadata for key '%v': found=%v", kind, key, r.Found)
+	if !r.Found {
+		return nil, false, nil
+	}
+	if r.Metadata == nil {
+		r.Metadata = common.MapStr{}
+	}
+	if cached && c.cache != nil {
+		c.cache.Put(cacheKey, r.Metadata)
+	}
+	return r.Metadata.Clone(), true, nil
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sharedmeta
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/api/npipe"
+)
+
+const (
+	// ModeServer makes the beat answer metadata lookups from other beats.
+	ModeServer = "server"
+
+	// ModeClient makes the beat look up metadata from the serving beat before
+	// collecting it itself.
+	ModeClient = "client"
+)
+
+// Config is the configuration of the shared metadata cache.
+type Config struct {
+	Enabled            bool          `config:"enabled"`
+	Mode               string        `config:"mode"`
+	Host               string        `config:"host"`
+	Timeout            time.Duration `config:"timeout"`   // Client timeout for a single lookup.
+	CacheTTL           time.Duration `config:"cache.ttl"` // How long clients keep lookup results.
+	User               string        `config:"named_pipe.user"`
+	SecurityDescriptor string        `config:"named_pipe.security_descriptor"`
+}
+
+func defaultConfig() Config {
+	host := "unix:///var/run/beats-metadata.sock"
+	if runtime.GOOS == "windows" {
+		host = "npipe:///beats-metadata"
+	}
+	return Config{
+		Mode:     ModeClient,
+		Host:     host,
+		Timeout:  5 * time.Second,
+		CacheTTL: 30 * time.Second,
+	}
+}
+
+// Validate checks that the mode is known and that the host is local to the
+// machine, metadata must never be shared over the network.
+func (c *Config) Validate() error {
+	switch c.Mode {
+	case ModeServer, ModeClient:
+	default:
+		return fmt.Errorf("unknown shared metadata mode '%v', must be one of %v or %v",
+			c.Mode, ModeServer, ModeClient)
+	}
+
+	if !strings.HasPrefix(c.Host, "unix://") && !npipe.IsNPipe(c.Host) {
+		return fmt.Errorf("shared metadata host '%v' must be a unix socket or a named pipe", c.Host)
+	}
+
+	if c.Timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0, got %v", c.Timeout)
+	}
+	return nil
+}
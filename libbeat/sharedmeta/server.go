@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sharedmeta
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/api"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+const pathPrefix = "/metadata/"
+
+// response is the body returned for a lookup of a shared metadata kind.
+type response struct {
+	Found    bool          `json:"found"`
+	Metadata common.MapStr `json:"metadata,omitempty"`
+}
+
+type server struct {
+	log  *logp.Logger
+	host string
+	l    net.Listener
+}
+
+func newServer(log *logp.Logger, config Config) (*server, error) {
+	l, err := api.MakeListener(api.Config{
+		Host:               config.Host,
+		User:               config.User,
+		SecurityDescriptor: config.SecurityDescriptor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &server{log: log, host: config.Host, l: l}, nil
+}
+
+func (s *server) start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pathPrefix, s.handle)
+
+	go func() {
+		s.log.Infof("Sharing metadata on: %v", s.host)
+		err := http.Serve(s.l, mux)
+		s.log.Infof("Shared metadata server (%v) finished: %v", s.host, err)
+	}()
+}
+
+func (s *server) stop() error {
+	return s.l.Close()
+}
+
+func (s *server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	kind := strings.TrimPrefix(r.URL.Path, pathPrefix)
+	lookup := registered(kind)
+	if lookup == nil {
+		http.Error(w, "metadata kind not shared: "+kind, http.StatusNotFound)
+		return
+	}
+
+	var resp response
+	resp.Metadata, resp.Found = lookup(r.URL.Query().Get("key"))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Debugf("Error writing shared %v metadata: %v", kind, err)
+	}
+}
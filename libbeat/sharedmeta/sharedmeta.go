@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package sharedmeta lets beats running on the same host share the host, cloud
+// and kubernetes metadata they collect. One beat runs in server mode and
+// answers lookups over a local unix socket or named pipe, the other beats run
+// in client mode and ask it before collecting the metadata themselves. This
+// avoids every beat querying the same metadata services and keeps the
+// metadata added by the different beats consistent.
+package sharedmeta
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+const logSelector = "shared_metadata"
+
+// ErrUnavailable is returned by Lookup when the beat does not run in client
+// mode.
+var ErrUnavailable = errors.New("shared metadata not available")
+
+// LookupFunc returns the metadata known for the given key, and false if there
+// is none.
+type LookupFunc func(key string) (common.MapStr, bool)
+
+var shared struct {
+	sync.RWMutex
+	config  Config
+	lookups map[string]LookupFunc // set in server mode
+	client  *client               // set in client mode
+}
+
+// Configure sets up the shared metadata mode of the beat. It must be called
+// before the processors are created, so they register or look up their
+// metadata in the configured mode.
+func Configure(cfg *common.Config) error {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return err
+	}
+
+	shared.Lock()
+	defer shared.Unlock()
+
+	shared.config = config
+	switch config.Mode {
+	case ModeServer:
+		shared.lookups = map[string]LookupFunc{}
+	case ModeClient:
+		c, err := newClient(logp.NewLogger(logSelector), config)
+		if err != nil {
+			return err
+		}
+		shared.client = c
+	}
+	return nil
+}
+
+// Serve starts answering lookups from the other beats on the host if the beat
+// runs in server mode. The returned function stops the server.
+func Serve() (func(), error) {
+	shared.RLock()
+	config, isServer := shared.config, shared.lookups != nil
+	shared.RUnlock()
+
+	if !isServer {
+		return func() {}, nil
+	}
+
+	log := logp.NewLogger(logSelector)
+	s, err := newServer(log, config)
+	if err != nil {
+		return nil, err
+	}
+	s.start()
+
+	return func() {
+		if err := s.stop(); err != nil {
+			log.Errorf("Error stopping the shared metadata server: %v", err)
+		}
+	}, nil
+}
+
+// Register makes the metadata of the given kind available to the other beats
+// on the host. It does nothing unless the beat runs in server mode. A later
+// registration of the same kind replaces the previous one.
+func Register(kind string, lookup LookupFunc) {
+	shared.Lock()
+	defer shared.Unlock()
+
+	if shared.lookups != nil {
+		shared.lookups[kind] = lookup
+	}
+}
+
+// Lookup asks the serving beat for the metadata of the given kind and key. It
+// returns false if the serving beat has no metadata for the key. An error is
+// returned if the beat does not run in client mode, or if the serving beat
+// cannot be reached or does not share this kind of metadata, callers are
+// expected to collect the metadata themselves in this case.
+func Lookup(kind, key string) (common.MapStr, bool, error) {
+	c, err := sharedClient()
+	if err != nil {
+		return nil, false, err
+	}
+	return c.lookup(kind, key, true)
+}
+
+// Fetch is the same as Lookup, but always asks the serving beat instead of
+// using the metadata cached by previous lookups.
+func Fetch(kind, key string) (common.MapStr, bool, error) {
+	c, err := sharedClient()
+	if err != nil {
+		return nil, false, err
+	}
+	return c.lookup(kind, key, false)
+}
+
+func sharedClient() (*client, error) {
+	shared.RLock()
+	defer shared.RUnlock()
+
+	if shared.client == nil {
+		return nil, ErrUnavailable
+	}
+	return shared.client, nil
+}
+
+func registered(kind string) LookupFunc {
+	shared.RLock()
+	defer shared.RUnlock()
+	return shared.lookups[kind]
+}
@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sharedmeta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+func TestConfig(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		err    bool
+	}{
+		"default": {
+			config: map[string]interface{}{},
+		},
+		"server": {
+			config: map[string]interface{}{"mode": "server", "host": "unix:///tmp/meta.sock"},
+		},
+		"named pipe": {
+			config: map[string]interface{}{"host": "npipe:///beats-metadata"},
+		},
+		"unknown mode": {
+			config: map[string]interface{}{"mode": "proxy"},
+			err:    true,
+		},
+		"tcp host": {
+			config: map[string]interface{}{"host": "http://localhost:5067"},
+			err:    true,
+		},
+		"no timeout": {
+			config: map[string]interface{}{"timeout": 0},
+			err:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig()
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestModes(t *testing.T) {
+	defer reset()
+
+	Register("host", func(string) (common.MapStr, bool) { return nil, false })
+	assert.Nil(t, registered("host"), "lookups must only be registered in server mode")
+
+	_, _, err := Lookup("host", "")
+	assert.Equal(t, ErrUnavailable, err)
+
+	require.NoError(t, Configure(common.MustNewConfigFrom(map[string]interface{}{"mode": "server"})))
+	Register("host", func(string) (common.MapStr, bool) { return nil, false })
+	assert.NotNil(t, registered("host"))
+
+	_, _, err = Lookup("host", "")
+	assert.Equal(t, ErrUnavailable, err, "lookups must only be done in client mode")
+}
+
+func TestLookup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix Sockets don't work under windows")
+		return
+	}
+	defer reset()
+
+	tmpDir, err := ioutil.TempDir("", "sharedmeta")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	config := defaultConfig()
+	config.Mode = ModeServer
+	config.Host = "unix://" + filepath.Join(tmpDir, "meta.sock")
+
+	var calls int32
+	shared.lookups = map[string]LookupFunc{}
+	Register("host", func(key string) (common.MapStr, bool) {
+		atomic.AddInt32(&calls, 1)
+		if key != "" {
+			return nil, false
+		}
+		return common.MapStr{"host": common.MapStr{"name": "shared", "ip": []string{"10.0.0.1"}}}, true
+	})
+
+	s, err := newServer(logp.NewLogger(""), config)
+	require.NoError(t, err)
+	s.start()
+	defer s.stop()
+
+	c, err := newClient(logp.NewLogger(""), config)
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		meta, found, err := c.lookup("host", "", true)
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		name, _ := meta.GetValue("host.name")
+		assert.Equal(t, "shared", name)
+		ip, _ := meta.GetValue("host.ip")
+		assert.Equal(t, []interface{}{"10.0.0.1"}, ip)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		meta, found, err := c.lookup("host", "", true)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.NotEmpty(t, meta)
+		assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		meta, found, err := c.lookup("host", "", false)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.NotEmpty(t, meta)
+		assert.Equal(t, before+1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("misses are not cached", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		for i := 0; i < 2; i++ {
+			meta, found, err := c.lookup("host", "other", true)
+			require.NoError(t, err)
+			assert.False(t, found)
+			assert.Nil(t, meta)
+		}
+		assert.Equal(t, before+2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("kind not shared", func(t *testing.T) {
+		_, _, err := c.lookup("cloud", "", true)
+		assert.Error(t, err)
+	})
+
+	t.Run("server not running", func(t *testing.T) {
+		config := config
+		config.Host = "unix://" + filepath.Join(tmpDir, "missing.sock")
+		c, err := newClient(logp.NewLogger(""), config)
+		require.NoError(t, err)
+
+		_, _, err = c.lookup("host", "", true)
+		assert.Error(t, err)
+	})
+}
+
+func reset() {
+	shared.Lock()
+	defer shared.Unlock()
+	shared.config = Config{}
+	shared.lookups = nil
+	shared.client = nil
+}
@@ -97,6 +97,9 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/conntrack"
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/ksm"
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/pageinfo"
+	_ "github.com/elastic/beats/v7/metricbeat/module/linux/qdisc"
+	_ "github.com/elastic/beats/v7/metricbeat/module/linux/sockstat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/linux/softnet"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node_stats"
@@ -142,6 +145,8 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/info"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/keyspace"
+	_ "github.com/elastic/beats/v7/metricbeat/module/smartctl"
+	_ "github.com/elastic/beats/v7/metricbeat/module/smartctl/device"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/core"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/cpu"
@@ -161,6 +166,10 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/socket_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/uptime"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/users"
+	_ "github.com/elastic/beats/v7/metricbeat/module/timesync"
+	_ "github.com/elastic/beats/v7/metricbeat/module/timesync/status"
+	_ "github.com/elastic/beats/v7/metricbeat/module/tls"
+	_ "github.com/elastic/beats/v7/metricbeat/module/tls/certificate"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/health"
 	_ "github.com/elastic/beats/v7/metricbeat/module/uwsgi"
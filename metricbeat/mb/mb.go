@@ -388,6 +388,9 @@ type ModuleConfig struct {
 	Raw         bool          `config:"raw"`
 	Query       QueryParams   `config:"query"`
 	ServiceName string        `config:"service.name"`
+
+	Backpressure BackpressureConfig `config:"backpressure"`
+	Watchdog     WatchdogConfig     `config:"watchdog"`
 }
 
 func (c ModuleConfig) String() string {
@@ -427,10 +430,64 @@ func (q QueryParams) String() (s string) {
 	return u.Encode()
 }
 
+// Backpressure modes.
+const (
+	BackpressureReduce = "reduce" // Fetch only every few periods.
+	BackpressureSkip   = "skip"   // Skip all fetches.
+)
+
+// BackpressureConfig configures how the collection of the MetricSets of a
+// module is degraded while the publisher queue is congested, so that
+// low-priority MetricSets give way to the rest.
+type BackpressureConfig struct {
+	Enabled          bool    `config:"enabled"`
+	Mode             string  `config:"mode"`
+	HighWatermark    float64 `config:"high_watermark"`
+	LowWatermark     float64 `config:"low_watermark"`
+	PeriodMultiplier int     `config:"period_multiplier" validate:"min=2"`
+}
+
+// Validate validates the BackpressureConfig.
+func (c *BackpressureConfig) Validate() error {
+	switch c.Mode {
+	case BackpressureReduce, BackpressureSkip:
+	default:
+		return fmt.Errorf("invalid backpressure mode '%s', expected '%s' or '%s'",
+			c.Mode, BackpressureReduce, BackpressureSkip)
+	}
+	if c.HighWatermark <= 0 || c.HighWatermark > 1 {
+		return errors.New("backpressure high_watermark must be greater than 0 and at most 1")
+	}
+	if c.LowWatermark < 0 || c.LowWatermark >= c.HighWatermark {
+		return errors.New("backpressure low_watermark must be at least 0 and less than high_watermark")
+	}
+	return nil
+}
+
+// WatchdogConfig configures the hard timeout of the fetches of the MetricSets
+// of a module. A fetch still running after the timeout is cancelled and
+// reported, and the goroutines are dumped to the log once the same MetricSet
+// has been stuck for DumpAfter consecutive periods (0 disables the dump).
+// The timeout defaults to the period of the module.
+type WatchdogConfig struct {
+	Enabled   bool          `config:"enabled"`
+	Timeout   time.Duration `config:"timeout"    validate:"positive"`
+	DumpAfter int           `config:"dump_after" validate:"min=0"`
+}
+
 // defaultModuleConfig contains the default values for ModuleConfig instances.
 var defaultModuleConfig = ModuleConfig{
 	Enabled: true,
 	Period:  time.Second * 10,
+	Backpressure: BackpressureConfig{
+		Mode:             BackpressureReduce,
+		HighWatermark:    0.8,
+		LowWatermark:     0.5,
+		PeriodMultiplier: 4,
+	},
+	Watchdog: WatchdogConfig{
+		DumpAfter: 3,
+	},
 }
 
 // DefaultModuleConfig returns a ModuleConfig with the default values populated.
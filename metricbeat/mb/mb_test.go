@@ -100,6 +100,15 @@ func TestModuleConfig(t *testing.T) {
 				Period:     time.Second * 10,
 				Timeout:    0,
 				Query:      nil,
+				Backpressure: BackpressureConfig{
+					Mode:             BackpressureReduce,
+					HighWatermark:    0.8,
+					LowWatermark:     0.5,
+					PeriodMultiplier: 4,
+				},
+				Watchdog: WatchdogConfig{
+					DumpAfter: 3,
+				},
 			},
 		},
 		{
@@ -120,6 +129,35 @@ func TestModuleConfig(t *testing.T) {
 			},
 			err: "negative value accessing 'timeout'",
 		},
+		{
+			name: "backpressure low_watermark above high_watermark",
+			in: map[string]interface{}{
+				"module":                      "example",
+				"metricsets":                  []string{"test"},
+				"backpressure.enabled":        true,
+				"backpressure.low_watermark":  0.9,
+				"backpressure.high_watermark": 0.7,
+			},
+			err: "low_watermark must be at least 0 and less than high_watermark",
+		},
+		{
+			name: "invalid backpressure mode",
+			in: map[string]interface{}{
+				"module":            "example",
+				"metricsets":        []string{"test"},
+				"backpressure.mode": "drop",
+			},
+			err: "invalid backpressure mode 'drop'",
+		},
+		{
+			name: "negative watchdog timeout",
+			in: map[string]interface{}{
+				"module":           "example",
+				"metricsets":       []string{"test"},
+				"watchdog.timeout": -1,
+			},
+			err: "negative value accessing 'watchdog.timeout'",
+		},
 	}
 
 	for i, test := range tests {
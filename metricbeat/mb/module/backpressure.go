@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// Backpressure states reported in metricset.backpressure.state.
+const (
+	backpressureDegraded  = "degraded"
+	backpressureRecovered = "recovered"
+)
+
+// backpressure degrades the collection of a periodic MetricSet while the
+// publisher queue is filled above the high watermark, until it drains below
+// the low watermark again. Instead of dropping arbitrary events when the
+// queue is full, low-priority MetricSets fetch less often or not at all.
+type backpressure struct {
+	config mb.BackpressureConfig
+	fill   func() (float64, bool)
+	log    *logp.Logger
+
+	degraded bool
+	ticks    int // periods since the last fetch while degraded
+	skipped  int // fetches skipped since the MetricSet was degraded
+
+	unknownFillLogged bool
+}
+
+func newBackpressure(msw *metricSetWrapper) *backpressure {
+	config := msw.Module().Config().Backpressure
+	if !config.Enabled {
+		return nil
+	}
+	return &backpressure{
+		config: config,
+		fill:   msw.module.queueFill,
+		log:    logp.NewLogger("module").With("metricset", msw.ID()),
+	}
+}
+
+// tick is called every period, before the MetricSet is fetched. It returns
+// whether the MetricSet must be fetched, and an event if the MetricSet
+// changed between the degraded and normal state.
+func (b *backpressure) tick() (fetch bool, event *mb.Event) {
+	if b == nil {
+		return true, nil
+	}
+
+	fill, ok := b.fill()
+	if !ok {
+		// The capacity of spool and disk queues is not reported in events,
+		// so their fill level can not be computed.
+		if !b.unknownFillLogged {
+			b.log.Warn("Backpressure is enabled, but the fill level of the publisher queue is unknown. " +
+				"Backpressure only works with the memory queue and has no effect.")
+			b.unknownFillLogged = true
+		}
+		fill = 0
+	}
+
+	switch {
+	case !b.degraded && fill >= b.config.HighWatermark:
+		b.log.Warnf("Publisher queue is %.0f%% full, degrading collection (mode=%s)",
+			fill*100, b.config.Mode)
+		b.degraded, b.ticks, b.skipped = true, 0, 0
+		event = b.stateEvent(backpressureDegraded, fill)
+	case b.degraded && fill <= b.config.LowWatermark:
+		b.log.Infof("Publisher queue is %.0f%% full, collection recovered after %d skipped fetches",
+			fill*100, b.skipped)
+		b.degraded = false
+		return true, b.stateEvent(backpressureRecovered, fill)
+	}
+
+	if !b.degraded {
+		return true, event
+	}
+
+	b.ticks++
+	if b.config.Mode == mb.BackpressureReduce && b.ticks >= b.config.PeriodMultiplier {
+		b.ticks = 0
+		return true, event
+	}
+	b.skipped++
+	return false, event
+}
+
+func (b *backpressure) stateEvent(state string, fill float64) *mb.Event {
+	fields := common.MapStr{
+		"state":      state,
+		"mode":       b.config.Mode,
+		"queue_fill": fill,
+	}
+	if state == backpressureRecovered {
+		fields["skipped"] = b.skipped
+	}
+	return &mb.Event{
+		RootFields: common.MapStr{
+			"metricset": common.MapStr{"backpressure": fields},
+		},
+	}
+}
+
+// pipelineQueueFill returns the fraction of the publisher queue in use. It
+// returns false if the capacity of the queue is not known.
+func pipelineQueueFill() (float64, bool) {
+	reg := monitoring.Default.GetRegistry("libbeat")
+	if reg == nil {
+		return 0, false
+	}
+	reg = reg.GetRegistry("pipeline")
+	if reg == nil {
+		return 0, false
+	}
+
+	active, ok := reg.Get("events.active").(*monitoring.Uint)
+	if !ok {
+		return 0, false
+	}
+	max, ok := reg.Get("queue.max_events").(*monitoring.Uint)
+	if !ok || max.Get() == 0 {
+		return 0, false
+	}
+	return float64(active.Get()) / float64(max.Get()), true
+}
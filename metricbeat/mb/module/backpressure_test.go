@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestBackpressure(t *testing.T) {
+	tests := map[string]struct {
+		mode      string
+		fills     []float64
+		fetches   []bool
+		states    []string
+		recovered int // skipped fetches reported when recovering
+	}{
+		"reduce": {
+			mode:    mb.BackpressureReduce,
+			fills:   []float64{0.5, 0.9, 0.9, 0.7, 0.9, 0.6, 0.4, 0.9},
+			fetches: []bool{true, false, false, true, false, false, true, false},
+			states:  []string{"", backpressureDegraded, "", "", "", "", backpressureRecovered, backpressureDegraded},
+			// 5 ticks degraded, one of which fetched.
+			recovered: 4,
+		},
+		"skip": {
+			mode:      mb.BackpressureSkip,
+			fills:     []float64{0.85, 0.9, 0.3, 0.3},
+			fetches:   []bool{false, false, true, true},
+			states:    []string{backpressureDegraded, "", backpressureRecovered, ""},
+			recovered: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var fill float64
+			b := &backpressure{
+				config: mb.BackpressureConfig{
+					Enabled:          true,
+					Mode:             test.mode,
+					HighWatermark:    0.8,
+					LowWatermark:     0.5,
+					PeriodMultiplier: 3,
+				},
+				fill: func() (float64, bool) { return fill, true },
+				log:  logp.NewLogger("test"),
+			}
+
+			for i, f := range test.fills {
+				fill = f
+				fetch, event := b.tick()
+				assert.Equal(t, test.fetches[i], fetch, "tick %d", i)
+
+				if test.states[i] == "" {
+					assert.Nil(t, event, "tick %d", i)
+					continue
+				}
+				if assert.NotNil(t, event, "tick %d", i) {
+					state, _ := event.RootFields.GetValue("metricset.backpressure.state")
+					assert.Equal(t, test.states[i], state, "tick %d", i)
+					if state == backpressureRecovered {
+						skipped, _ := event.RootFields.GetValue("metricset.backpressure.skipped")
+						assert.Equal(t, test.recovered, skipped)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBackpressureDisabled(t *testing.T) {
+	var b *backpressure
+	fetch, event := b.tick()
+	assert.True(t, fetch)
+	assert.Nil(t, event)
+}
+
+func TestBackpressureUnknownQueueSize(t *testing.T) {
+	b := &backpressure{
+		config: mb.BackpressureConfig{Mode: mb.BackpressureSkip, HighWatermark: 0.8, LowWatermark: 0.5},
+		fill:   func() (float64, bool) { return 0, false },
+		log:    logp.NewLogger("test"),
+	}
+	for i := 0; i < 2; i++ {
+		fetch, event := b.tick()
+		assert.True(t, fetch)
+		assert.Nil(t, event)
+	}
+	assert.True(t, b.unknownFillLogged, "a warning must be logged if the queue fill level is unknown")
+}
@@ -45,6 +45,9 @@ type connectorConfig struct {
 	// KeepNull determines whether published events will keep null values or omit them.
 	KeepNull bool `config:"keep_null"`
 
+	// Derive configures counters for which rates or deltas are computed.
+	Derive []*common.Config `config:"derive"`
+
 	common.EventMetadata `config:",inline"` // Fields and tags to add to events.
 }
 
@@ -125,6 +128,16 @@ func processorsForConfig(
 		procs.AddProcessor(indexProcessor)
 	}
 
+	// Derived fields are added before the user processors, so they can be
+	// processed like any other field.
+	for _, c := range config.Derive {
+		p, err := newDeriveProcessor(c)
+		if err != nil {
+			return nil, err
+		}
+		procs.AddProcessor(p)
+	}
+
 	userProcs, err := processors.New(config.Processors)
 	if err != nil {
 		return nil, err
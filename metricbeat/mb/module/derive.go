@@ -0,0 +1,267 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+const (
+	deriveModeRate  = "rate"
+	deriveModeDelta = "delta"
+)
+
+// deriveConfig configures counter fields for which per-interval rates or
+// deltas are computed.
+type deriveConfig struct {
+	// Fields to derive. Patterns as supported by path.Match are accepted, e.g.
+	// `prometheus.metrics.*_total`.
+	Fields []string `config:"fields" validate:"required"`
+
+	// Mode is either `rate` (change per second) or `delta` (change per interval).
+	Mode string `config:"mode"`
+
+	// Suffix is appended to the name of the derived field. Defaults to `_rate`
+	// or `_delta`.
+	Suffix string `config:"suffix"`
+
+	// Dimensions identifying a time series. Patterns as supported by
+	// path.Match are accepted. Defaults to defaultDeriveDimensions.
+	Dimensions []string `config:"dimensions"`
+
+	// TTL after which the last value of a time series is forgotten.
+	TTL time.Duration `config:"ttl" validate:"min=0"`
+}
+
+// defaultDeriveDimensions identify the time series of an event if no
+// dimensions are configured. Besides the metricset and service, these are the
+// labels and the identifiers of the module namespace, like
+// prometheus.labels.job, system.process.pid or system.network.name, so that
+// metricsets reporting several objects per fetch keep one series per object.
+// Other fields, like states or messages, may change on every fetch and would
+// start a new time series each time.
+var defaultDeriveDimensions = []string{
+	"metricset.name",
+	"service.address",
+	"labels.*",
+	"*.labels.*",
+	"*.*.id",
+	"*.*.pid",
+	"*.*.name",
+	"*.*.mount_point",
+}
+
+var defaultDeriveConfig = deriveConfig{
+	Mode: deriveModeRate,
+	TTL:  15 * time.Minute,
+}
+
+func (c *deriveConfig) Validate() error {
+	switch c.Mode {
+	case deriveModeRate, deriveModeDelta:
+	default:
+		return fmt.Errorf("unknown derive mode '%v', expecting '%v' or '%v'", c.Mode, deriveModeRate, deriveModeDelta)
+	}
+	for _, pattern := range c.Fields {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid derive field pattern '%v'", pattern)
+		}
+	}
+	for _, pattern := range c.Dimensions {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid derive dimension pattern '%v'", pattern)
+		}
+	}
+	return nil
+}
+
+// deriveProcessor computes rates or deltas of monotonic counters between two
+// consecutive events of the same time series. If a counter decreases, it is
+// assumed to have been reset, and its current value is used as delta. No
+// derived value is added for the first event of a time series.
+type deriveProcessor struct {
+	config deriveConfig
+	suffix string
+
+	mu        sync.Mutex
+	series    map[string]*deriveSeries
+	lastClean time.Time
+}
+
+type deriveSeries struct {
+	timestamp time.Time
+	values    map[string]float64
+}
+
+func newDeriveProcessor(c *common.Config) (*deriveProcessor, error) {
+	config := defaultDeriveConfig
+	if err := c.Unpack(&config); err != nil {
+		return nil, errors.Wrap(err, "failed to unpack derive configuration")
+	}
+
+	suffix := config.Suffix
+	if suffix == "" {
+		suffix = "_" + config.Mode
+	}
+	if len(config.Dimensions) == 0 {
+		config.Dimensions = defaultDeriveDimensions
+	}
+
+	return &deriveProcessor{
+		config: config,
+		suffix: suffix,
+		series: map[string]*deriveSeries{},
+	}, nil
+}
+
+func (p *deriveProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	flat := event.Fields.Flatten()
+
+	counters := map[string]float64{}
+	for k, v := range flat {
+		if !p.matches(k) {
+			continue
+		}
+		if f, ok := toFloat(v); ok {
+			counters[k] = f
+		}
+	}
+	if len(counters) == 0 {
+		return event, nil
+	}
+
+	key := p.seriesKey(flat)
+	ts := event.Timestamp
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cleanup(ts)
+
+	prev, found := p.series[key]
+	p.series[key] = &deriveSeries{timestamp: ts, values: counters}
+	if !found {
+		return event, nil
+	}
+
+	interval := ts.Sub(prev.timestamp).Seconds()
+	if interval <= 0 {
+		return event, nil
+	}
+
+	for k, current := range counters {
+		last, ok := prev.values[k]
+		if !ok {
+			continue
+		}
+
+		delta := current - last
+		if delta < 0 {
+			// counter reset
+			delta = current
+		}
+
+		value := delta
+		if p.config.Mode == deriveModeRate {
+			value = delta / interval
+		}
+		if _, err := event.PutValue(k+p.suffix, value); err != nil {
+			return event, errors.Wrapf(err, "failed to set derived field %v", k+p.suffix)
+		}
+	}
+	return event, nil
+}
+
+func (p *deriveProcessor) String() string {
+	return fmt.Sprintf("derive=[fields=%v, mode=%v]", p.config.Fields, p.config.Mode)
+}
+
+func (p *deriveProcessor) matches(field string) bool {
+	return matchAny(p.config.Fields, field)
+}
+
+// seriesKey builds a key from the dimensions of the event.
+func (p *deriveProcessor) seriesKey(flat common.MapStr) string {
+	var dims []string
+	for k, v := range flat {
+		if matchAny(p.config.Dimensions, k) {
+			dims = append(dims, fmt.Sprintf("%v=%v", k, v))
+		}
+	}
+	sort.Strings(dims)
+	return strings.Join(dims, "\x00")
+}
+
+func matchAny(patterns []string, field string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanup removes time series that have not been updated within the TTL. It
+// runs at most once per TTL.
+func (p *deriveProcessor) cleanup(now time.Time) {
+	if p.config.TTL == 0 || now.Sub(p.lastClean) < p.config.TTL {
+		return
+	}
+	p.lastClean = now
+
+	for k, s := range p.series {
+		if now.Sub(s.timestamp) > p.config.TTL {
+			delete(p.series, k)
+		}
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case common.Float:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
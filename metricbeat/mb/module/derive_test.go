@@ -0,0 +1,236 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func newDeriveTestEvent(ts time.Time, iface string, in uint64) *beat.Event {
+	return &beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"system": common.MapStr{
+				"network": common.MapStr{
+					"name": iface,
+					"in":   common.MapStr{"bytes": in},
+				},
+			},
+		},
+	}
+}
+
+func TestDeriveRate(t *testing.T) {
+	p, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields":     []string{"system.network.*.bytes"},
+		"dimensions": []string{"system.network.name"},
+	}))
+	require.NoError(t, err)
+
+	start := time.Now()
+	cases := []struct {
+		ts       time.Time
+		iface    string
+		in       uint64
+		expected interface{}
+	}{
+		{start, "eth0", 1000, nil},                         // first value of the series
+		{start, "eth1", 50, nil},                           // other series
+		{start.Add(10 * time.Second), "eth0", 3000, 200.0}, // (3000 - 1000) / 10s
+		{start.Add(20 * time.Second), "eth0", 500, 50.0},   // counter reset, 500 / 10s
+		{start.Add(20 * time.Second), "eth1", 50, 0.0},     // unchanged
+		{start.Add(20 * time.Second), "eth0", 800, nil},    // no time passed
+	}
+
+	for i, c := range cases {
+		event, err := p.Run(newDeriveTestEvent(c.ts, c.iface, c.in))
+		require.NoError(t, err)
+
+		v, _ := event.GetValue("system.network.in.bytes_rate")
+		assert.Equal(t, c.expected, v, "case %d", i)
+	}
+}
+
+func TestDeriveDefaultDimensions(t *testing.T) {
+	p, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"system.process.cpu.total.ticks"},
+		"mode":   "delta",
+	}))
+	require.NoError(t, err)
+
+	newEvent := func(ts time.Time, address, state string, ticks uint64) *beat.Event {
+		return &beat.Event{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"metricset": common.MapStr{"name": "process"},
+				"service":   common.MapStr{"address": address},
+				"labels":    common.MapStr{"env": "test"},
+				"system": common.MapStr{
+					"process": common.MapStr{
+						"state": state,
+						"cpu":   common.MapStr{"total": common.MapStr{"ticks": ticks}},
+					},
+				},
+			},
+		}
+	}
+
+	start := time.Now()
+	cases := []struct {
+		event    *beat.Event
+		expected interface{}
+	}{
+		{newEvent(start, "host-a", "running", 100), nil},
+		{newEvent(start, "host-b", "running", 1000), nil},
+		// state is not a dimension, changing it keeps the time series
+		{newEvent(start.Add(10*time.Second), "host-a", "sleeping", 150), 50.0},
+		{newEvent(start.Add(10*time.Second), "host-b", "sleeping", 1200), 200.0},
+	}
+
+	for i, c := range cases {
+		event, err := p.Run(c.event)
+		require.NoError(t, err)
+
+		v, _ := event.GetValue("system.process.cpu.total.ticks_delta")
+		assert.Equal(t, c.expected, v, "case %d", i)
+	}
+}
+
+func TestDeriveDefaultDimensionsSeriesOfSameHost(t *testing.T) {
+	p, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"prometheus.metrics.*_total", "system.process.cpu.total.ticks"},
+		"mode":   "delta",
+	}))
+	require.NoError(t, err)
+
+	newPrometheusEvent := func(ts time.Time, path string, requests uint64) *beat.Event {
+		return &beat.Event{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"metricset": common.MapStr{"name": "collector"},
+				"service":   common.MapStr{"address": "localhost:9090"},
+				"prometheus": common.MapStr{
+					"labels":  common.MapStr{"job": "web", "path": path},
+					"metrics": common.MapStr{"http_requests_total": requests},
+				},
+			},
+		}
+	}
+	newProcessEvent := func(ts time.Time, pid int, ticks uint64) *beat.Event {
+		return &beat.Event{
+			Timestamp: ts,
+			Fields: common.MapStr{
+				"metricset": common.MapStr{"name": "process"},
+				"service":   common.MapStr{"address": "localhost"},
+				"system": common.MapStr{
+					"process": common.MapStr{
+						"pid": pid,
+						"cpu": common.MapStr{"total": common.MapStr{"ticks": ticks}},
+					},
+				},
+			},
+		}
+	}
+
+	start := time.Now()
+	next := start.Add(10 * time.Second)
+	cases := []struct {
+		event    *beat.Event
+		field    string
+		expected interface{}
+	}{
+		{newPrometheusEvent(start, "/a", 100), "prometheus.metrics.http_requests_total_delta", nil},
+		{newPrometheusEvent(start, "/b", 5000), "prometheus.metrics.http_requests_total_delta", nil},
+		{newPrometheusEvent(next, "/a", 110), "prometheus.metrics.http_requests_total_delta", 10.0},
+		{newPrometheusEvent(next, "/b", 5100), "prometheus.metrics.http_requests_total_delta", 100.0},
+		{newProcessEvent(start, 1, 100), "system.process.cpu.total.ticks_delta", nil},
+		{newProcessEvent(start, 2, 3000), "system.process.cpu.total.ticks_delta", nil},
+		{newProcessEvent(next, 1, 120), "system.process.cpu.total.ticks_delta", 20.0},
+		{newProcessEvent(next, 2, 3500), "system.process.cpu.total.ticks_delta", 500.0},
+	}
+
+	for i, c := range cases {
+		event, err := p.Run(c.event)
+		require.NoError(t, err)
+
+		v, _ := event.GetValue(c.field)
+		assert.Equal(t, c.expected, v, "case %d", i)
+	}
+}
+
+func TestDeriveDelta(t *testing.T) {
+	p, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields":     []string{"system.network.in.bytes"},
+		"mode":       "delta",
+		"suffix":     "_diff",
+		"dimensions": []string{"system.network.name"},
+	}))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = p.Run(newDeriveTestEvent(start, "eth0", 100))
+	require.NoError(t, err)
+
+	event, err := p.Run(newDeriveTestEvent(start.Add(time.Minute), "eth0", 250))
+	require.NoError(t, err)
+	v, err := event.GetValue("system.network.in.bytes_diff")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, v)
+}
+
+func TestDeriveTTL(t *testing.T) {
+	p, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"system.network.in.bytes"},
+		"mode":   "delta",
+		"ttl":    "1m",
+	}))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = p.Run(newDeriveTestEvent(start, "eth0", 100))
+	require.NoError(t, err)
+
+	event, err := p.Run(newDeriveTestEvent(start.Add(5*time.Minute), "eth0", 200))
+	require.NoError(t, err)
+	_, err = event.GetValue("system.network.in.bytes_delta")
+	assert.Error(t, err, "expired series must not be derived")
+}
+
+func TestDeriveConfigValidate(t *testing.T) {
+	_, err := newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields": []string{"a"},
+		"mode":   "integral",
+	}))
+	assert.Error(t, err)
+
+	_, err = newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{}))
+	assert.Error(t, err)
+
+	_, err = newDeriveProcessor(common.MustNewConfigFrom(common.MapStr{
+		"fields":     []string{"a"},
+		"dimensions": []string{"[labels"},
+	}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// watchdog enforces a hard timeout on the fetches of a periodic MetricSet.
+// Without it a hung fetch blocks the worker of the MetricSet silently until
+// it returns. A fetch exceeding the timeout gets its context cancelled and
+// its events dropped, and an error event is reported for every period the
+// MetricSet stays stuck. Further fetches are skipped until the stuck one
+// returns, so a MetricSet never has more than one fetch in flight.
+type watchdog struct {
+	timeout   time.Duration
+	dumpAfter int
+	stats     *stats
+	log       *logp.Logger
+
+	running <-chan struct{} // closed when the abandoned fetch returns
+	stuck   int             // consecutive periods the MetricSet has been stuck
+	dumped  bool            // goroutines already dumped for the current stuck fetch
+
+	dumpGoroutines func() string
+}
+
+func newWatchdog(msw *metricSetWrapper) *watchdog {
+	config := msw.Module().Config()
+	if !config.Watchdog.Enabled {
+		return nil
+	}
+	timeout := config.Watchdog.Timeout
+	if timeout <= 0 {
+		timeout = config.Period
+	}
+	return &watchdog{
+		timeout:        timeout,
+		dumpAfter:      config.Watchdog.DumpAfter,
+		stats:          msw.stats,
+		log:            logp.NewLogger("module").With("metricset", msw.ID()),
+		dumpGoroutines: dumpGoroutines,
+	}
+}
+
+// fetch runs fn with a context cancelled after the timeout, and waits for it
+// to return or time out. Events reported by fn after the timeout are dropped.
+func (w *watchdog) fetch(ctx context.Context, r reporter, fn func(context.Context, reporter)) {
+	if w == nil {
+		fn(ctx, r)
+		return
+	}
+
+	if w.running != nil {
+		select {
+		case <-w.running:
+			w.log.Infof("Stuck fetch returned after %d periods", w.stuck)
+			w.reset()
+		default:
+			w.stuck++
+			w.report(r, fmt.Errorf("skipping fetch, previous fetch still running after %d periods", w.stuck))
+			return
+		}
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, w.timeout)
+	fetchReporter := r.fork()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer cancel()
+		defer logp.Recover("recovered from panic while fetching with watchdog")
+		fn(fetchCtx, fetchReporter)
+	}()
+
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+		// Shutting down, the output must not be written anymore.
+		fetchReporter.abandon()
+		return
+	case <-timer.C:
+	}
+
+	// Abandoning waits for an event being written, so the fetch may have
+	// completed in the meantime.
+	fetchReporter.abandon()
+	select {
+	case <-done:
+		return
+	default:
+	}
+
+	w.running = done
+	w.stuck = 1
+	w.stats.timeouts.Add(1)
+	w.report(r, fmt.Errorf("fetch timed out after %v", w.timeout))
+}
+
+// report publishes an error event for the stuck MetricSet, and dumps the
+// goroutines once it has been stuck for dumpAfter periods.
+func (w *watchdog) report(r reporter, err error) {
+	w.log.Warnf("MetricSet is stuck: %v", err)
+
+	r.StartFetchTimer()
+	r.V2().Event(mb.Event{
+		Error: err,
+		RootFields: common.MapStr{
+			"metricset": common.MapStr{
+				"watchdog": common.MapStr{"stuck": w.stuck},
+			},
+		},
+	})
+
+	if w.dumpAfter > 0 && w.stuck >= w.dumpAfter && !w.dumped {
+		w.log.Warnf("MetricSet stuck for %d periods, goroutines:\n%s", w.stuck, w.dumpGoroutines())
+		w.dumped = true
+	}
+}
+
+func (w *watchdog) reset() {
+	w.running = nil
+	w.stuck = 0
+	w.dumped = false
+}
+
+func dumpGoroutines() string {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		return fmt.Sprintf("failed to dump goroutines: %v", err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package module
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/monitoring"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func TestWatchdog(t *testing.T) {
+	w, events := newTestWatchdog()
+	r := &fakeReporter{events: events}
+
+	// Fetch completing in time.
+	w.fetch(context.Background(), r, func(_ context.Context, r reporter) {
+		r.V2().Event(mb.Event{})
+	})
+	require.Len(t, events.all(), 1)
+	assert.NoError(t, events.all()[0].Error)
+
+	// Hung fetch, cancelled and abandoned.
+	release := make(chan struct{})
+	var cancelled bool
+	w.fetch(context.Background(), r, func(ctx context.Context, r reporter) {
+		<-ctx.Done()
+		cancelled = true
+		<-release
+		r.V2().Event(mb.Event{})
+	})
+	require.Len(t, events.all(), 2)
+	assertStuck(t, events.all()[1], 1)
+	assert.Equal(t, int64(1), w.stats.timeouts.Get())
+
+	// Following fetches are skipped while the hung one is running.
+	for i := 2; i <= 3; i++ {
+		w.fetch(context.Background(), r, func(context.Context, reporter) {
+			t.Error("fetch must be skipped while the previous one is running")
+		})
+		assertStuck(t, events.all()[i], i)
+	}
+	assert.Equal(t, 1, w.dumps, "goroutines must be dumped once")
+
+	close(release)
+	<-w.running
+	assert.True(t, cancelled)
+
+	// The hung fetch returned, its event is dropped and fetching resumes.
+	w.fetch(context.Background(), r, func(_ context.Context, r reporter) {
+		r.V2().Event(mb.Event{})
+	})
+	require.Len(t, events.all(), 5)
+	assert.NoError(t, events.all()[4].Error)
+	assert.Equal(t, 0, w.stuck)
+}
+
+func TestWatchdogDisabled(t *testing.T) {
+	var w *watchdog
+	called := false
+	w.fetch(context.Background(), &fakeReporter{}, func(context.Context, reporter) {
+		called = true
+	})
+	assert.True(t, called)
+}
+
+func assertStuck(t *testing.T, event mb.Event, stuck int) {
+	t.Helper()
+	assert.Error(t, event.Error)
+	value, err := event.RootFields.GetValue("metricset.watchdog.stuck")
+	require.NoError(t, err)
+	assert.Equal(t, stuck, value)
+}
+
+type testWatchdog struct {
+	*watchdog
+	dumps int
+}
+
+func newTestWatchdog() (*testWatchdog, *fakeEvents) {
+	w := &testWatchdog{
+		watchdog: &watchdog{
+			timeout:   50 * time.Millisecond,
+			dumpAfter: 3,
+			stats:     &stats{timeouts: &monitoring.Int{}},
+			log:       logp.NewLogger("test"),
+		},
+	}
+	w.dumpGoroutines = func() string {
+		w.dumps++
+		return ""
+	}
+	return w, &fakeEvents{}
+}
+
+type fakeEvents struct {
+	mtx    sync.Mutex
+	events []mb.Event
+}
+
+func (e *fakeEvents) add(event mb.Event) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *fakeEvents) all() []mb.Event {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return append([]mb.Event(nil), e.events...)
+}
+
+// fakeReporter implements reporter, collecting events unless abandoned.
+type fakeReporter struct {
+	events *fakeEvents
+
+	mtx       sync.Mutex
+	abandoned bool
+}
+
+func (r *fakeReporter) StartFetchTimer()      {}
+func (r *fakeReporter) V1() mb.PushReporter   { return nil }
+func (r *fakeReporter) V2() mb.PushReporterV2 { return fakeReporterV2{r} }
+func (r *fakeReporter) fork() reporter        { return &fakeReporter{events: r.events} }
+func (r *fakeReporter) abandon() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.abandoned = true
+}
+
+type fakeReporterV2 struct {
+	*fakeReporter
+}
+
+func (r fakeReporterV2) Done() <-chan struct{} { return nil }
+func (r fakeReporterV2) Error(err error) bool  { return r.Event(mb.Event{Error: err}) }
+func (r fakeReporterV2) Event(event mb.Event) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.abandoned {
+		return false
+	}
+	r.events.add(event)
+	return true
+}
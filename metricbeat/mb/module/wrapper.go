@@ -37,6 +37,7 @@ const (
 	successesKey = "success"
 	failuresKey  = "failures"
 	eventsKey    = "events"
+	timeoutsKey  = "timeouts"
 )
 
 var (
@@ -57,6 +58,9 @@ type Wrapper struct {
 	// Options
 	maxStartDelay  time.Duration
 	eventModifiers []mb.EventModifier
+
+	// queueFill returns the fraction of the publisher queue in use.
+	queueFill func() (float64, bool)
 }
 
 // metricSetWrapper contains the MetricSet and the private data associated with
@@ -76,6 +80,7 @@ type stats struct {
 	success  *monitoring.Int // Total success events.
 	failures *monitoring.Int // Total error events.
 	events   *monitoring.Int // Total events published.
+	timeouts *monitoring.Int // Total fetches cancelled by the watchdog.
 }
 
 // NewWrapper creates a new module and its associated metricsets based on the given configuration.
@@ -96,6 +101,7 @@ func createWrapper(module mb.Module, metricSets []mb.MetricSet, options ...Optio
 	wrapper := &Wrapper{
 		Module:     module,
 		metricSets: make([]*metricSetWrapper, len(metricSets)),
+		queueFill:  pipelineQueueFill,
 	}
 
 	for _, applyOption := range options {
@@ -220,8 +226,12 @@ func (msw *metricSetWrapper) startPeriodicFetching(ctx context.Context, reporter
 	// Indicate that it has been started as periodic fetcher
 	msw.periodic = true
 
+	wd := newWatchdog(msw)
+
 	// Fetch immediately.
-	msw.fetch(ctx, reporter)
+	wd.fetch(ctx, reporter, msw.fetch)
+
+	bp := newBackpressure(msw)
 
 	// Start timer for future fetches.
 	t := time.NewTicker(msw.Module().Config().Period)
@@ -231,7 +241,14 @@ func (msw *metricSetWrapper) startPeriodicFetching(ctx context.Context, reporter
 		case <-reporter.V2().Done():
 			return
 		case <-t.C:
-			msw.fetch(ctx, reporter)
+			fetch, event := bp.tick()
+			if event != nil {
+				reporter.StartFetchTimer()
+				reporter.V2().Event(*event)
+			}
+			if fetch {
+				wd.fetch(ctx, reporter, msw.fetch)
+			}
 		}
 	}
 }
@@ -315,6 +332,12 @@ type reporter interface {
 	StartFetchTimer()
 	V1() mb.PushReporter
 	V2() mb.PushReporterV2
+
+	// fork returns a reporter publishing to the same output, for a fetch
+	// that may be abandoned without affecting the following ones.
+	fork() reporter
+	// abandon drops all the events reported after the call.
+	abandon()
 }
 
 // eventReporter implements the Reporter interface which is a callback interface
@@ -325,6 +348,9 @@ type eventReporter struct {
 	done  <-chan struct{}
 	out   chan<- beat.Event
 	start time.Time // Start time of the current fetch (or zero for push sources).
+
+	mtx       sync.Mutex
+	abandoned bool // Set when the watchdog gave up on the fetch using this reporter.
 }
 
 // startFetchTimer demarcates the start of a new fetch. The elapsed time of a
@@ -335,6 +361,16 @@ func (r *eventReporter) V1() mb.PushReporter {
 }
 func (r *eventReporter) V2() mb.PushReporterV2 { return reporterV2{r} }
 
+func (r *eventReporter) fork() reporter {
+	return &eventReporter{msw: r.msw, done: r.done, out: r.out}
+}
+
+func (r *eventReporter) abandon() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.abandoned = true
+}
+
 // channelContext implements context.Context by wrapping a channel
 type channelContext struct {
 	done <-chan struct{}
@@ -376,6 +412,14 @@ type reporterV2 struct {
 func (r reporterV2) Done() <-chan struct{} { return r.done }
 func (r reporterV2) Error(err error) bool  { return r.Event(mb.Event{Error: err}) }
 func (r reporterV2) Event(event mb.Event) bool {
+	// Events of abandoned fetches are dropped, their error has already been
+	// reported by the watchdog.
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.abandoned {
+		return false
+	}
+
 	if event.Took == 0 && !r.start.IsZero() {
 		event.Took = time.Since(r.start)
 	}
@@ -442,6 +486,7 @@ func getMetricSetStats(module, name string) *stats {
 		success:  monitoring.NewInt(reg, successesKey),
 		failures: monitoring.NewInt(reg, failuresKey),
 		events:   monitoring.NewInt(reg, eventsKey),
+		timeouts: monitoring.NewInt(reg, timeoutsKey),
 	}
 
 	fetches[key] = s
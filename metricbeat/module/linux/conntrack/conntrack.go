@@ -43,7 +43,8 @@ func init() {
 // interface methods except for Fetch.
 type MetricSet struct {
 	mb.BaseMetricSet
-	fs procfs.FS
+	fs       procfs.FS
+	procPath string
 }
 
 // New creates a new instance of the MetricSet. New is responsible for unpacking
@@ -64,6 +65,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	return &MetricSet{
 		BaseMetricSet: base,
 		fs:            newFS,
+		procPath:      path,
 	}, nil
 }
 
@@ -88,20 +90,55 @@ func (m *MetricSet) Fetch(report mb.ReporterV2) error {
 		summedEvents.SearchRestart += conn.SearchRestart
 	}
 
-	report.Event(mb.Event{
-		MetricSetFields: common.MapStr{
-			"summary": common.MapStr{
-				"entries":        summedEvents.Entries,
-				"found":          summedEvents.Found,
-				"invalid":        summedEvents.Invalid,
-				"ignore":         summedEvents.Ignore,
-				"insert_failed":  summedEvents.InsertFailed,
-				"drop":           summedEvents.Drop,
-				"early_drop":     summedEvents.EarlyDrop,
-				"search_restart": summedEvents.SearchRestart,
-			},
+	fields := common.MapStr{
+		"summary": common.MapStr{
+			"entries":        summedEvents.Entries,
+			"found":          summedEvents.Found,
+			"invalid":        summedEvents.Invalid,
+			"ignore":         summedEvents.Ignore,
+			"insert_failed":  summedEvents.InsertFailed,
+			"drop":           summedEvents.Drop,
+			"early_drop":     summedEvents.EarlyDrop,
+			"search_restart": summedEvents.SearchRestart,
 		},
+	}
+
+	// The table size is only available in the network namespace of the host
+	// if the nf_conntrack module is loaded.
+	if table, err := m.fetchTableUsage(); err == nil {
+		fields["table"] = table
+	} else {
+		m.Logger().Debugf("conntrack table usage not available: %v", err)
+	}
+
+	report.Event(mb.Event{
+		MetricSetFields: fields,
 	})
 
 	return nil
 }
+
+// fetchTableUsage returns the number of entries in the conntrack table and
+// its maximum size.
+func (m *MetricSet) fetchTableUsage() (common.MapStr, error) {
+	dir := filepath.Join(m.procPath, "sys/net/netfilter")
+	count, err := linux.ReadIntFromFile(filepath.Join(dir, "nf_conntrack_count"), 10)
+	if err != nil {
+		return nil, err
+	}
+	max, err := linux.ReadIntFromFile(filepath.Join(dir, "nf_conntrack_max"), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	table := common.MapStr{
+		"count": count,
+		"max":   max,
+	}
+	if max > 0 {
+		table["used"] = common.MapStr{
+			"pct": common.Round(float64(count)/float64(max), common.DefaultDecimalPlacesCount),
+		}
+	}
+	return table, nil
+}
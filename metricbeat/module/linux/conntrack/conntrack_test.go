@@ -57,6 +57,13 @@ func TestFetch(t *testing.T) {
 	rawEvent := events[0].BeatEvent("linux", "conntrack").Fields["linux"].(common.MapStr)["conntrack"].(common.MapStr)["summary"]
 
 	assert.Equal(t, testConn, rawEvent)
+
+	table := events[0].MetricSetFields["table"]
+	assert.Equal(t, common.MapStr{
+		"count": int64(16),
+		"max":   int64(262144),
+		"used":  common.MapStr{"pct": 0.0001},
+	}, table)
 }
 
 func getConfig() map[string]interface{} {
@@ -32,5 +32,5 @@ func init() {
 // AssetLinux returns asset data.
 // This is the base64 encoded gzipped contents of module/linux.
 func AssetLinux() string {
-	return "eJy8l0tv4zYQx+/+FIMcF91Hsm8fCgTdRVEUKYIGe+mhwogcWawpUuWQybqfviBl2YoiOUpTiZcgIfWfn/6ch/IStrRbg1YmfF8BeOU1reEs/X62AnCkCZnWkJPHFYAkFk7VXlmzhh9XANA8C5WVQdMKoFCkJa/T1kswWNFRPi6/q2kNG2dDvf/LgOZ9ma6UsMZ4h2J72BmSjKuP3q7BcM0aEu+DdGE4VBW63b29MZxHQse1lwNbgCmyAwywR6/YK8E/pDMkAYWzzPDT9TcQ1hH3tIagu+DS2T7bkVxbsxnYfAQ+rhrFljwn+ZokyEDg7dFWKFBpFRyNghE6vctmwjtykPFO0RHUW6hwS+CsraCwDgzdgTUPfO2ANgozULZsyoAvqQPtMdfjzhU2GDkDDgchiLkIWu+ACZ0oSY6+fkujNsYOXPP/l2JMZAC1I5S75BEJ31wk9u65X54dSMPkfBaTkuaw7rdQ5eRiObd3eleSI9CK/T54+6NhgBOot6jVMyEfyj9w1JfoQaAx1kNOkFwc8ObQAFM+ZI7Yo/MzWJhyHrS121BH+5QoocR0zznBPu6x0zTHHbH6p5OcLe2Wqzmmxn3Zk/PCo+/XzH+eFr/eXHUmwxMHQI0b4oxLdLNk/k0SbqLERhaYXk1gUYPxng2jPDHs9Ztoj8EEM5s134z6O9AJjAoPGLdWo1cDTf/5GNfpakSJZhNd8dZCgez3hdW8/bhLcRpkLNDMMQCPbdOrihgqcptU1006lXhLkMdmFQHMKUxO/SAzVlImSlSz4DZOpsaZ0BxhGpEVfs8icZvZ0zBlqOf1VIZaK4GxacYO0svDbhkoU9g5+uWA9qmmmQcpd1nvgXGgCYZ8QY9QOFvB69pZ8TpFiAEasVgPgQ/tK9+BdZLcEzvsl6vL0XscYp7AndivLhNXeomBE2NYXbSzN2eD+4+k2UTCROGIQJTBbDnm3MWfb15cX/78Nbv55Y+vp9HOF0c7n4p2sTjaxVS0t4ujvZ2K9m5xtHdT0d4vjvZ+KtqHxdE+TEX7uDjax6lonxZH+zQV7fPiaJ8nt9zlx8H52DxooeK3EL96MTjxbf4Xif4/mxNIfsc7QK2twHgK0sDvfAXEqRoD3PvSWP0bAAD//4Pi/m8="
+	return "eJzNml9z0zgQwN/vU2j6yJRCSynQh5vpAHfDMDAdejzcPZyr2OtERJZcSU4In/5Wkh07tpyYJg6XBzok9u5Pq9X+s5+SOayuCWei+P4bIYYZDtfkxP3/BL9QwIFq/GoChuL/E9CxYrlhUlyT3/EL4u8lmUwKDvhFyoAn+tr99JQImkEt3n7MKsdvpkoWeflNQOammKaoWAphFI3n619CIu2njV59gur8JyS8DdKE0UWWUbXa+K0PZ4dq+ynFEZkSkUZrGKINNUwbFutTdw0khMZKak3e3n5FaAW6JSsE3QRPlGyz1eRcimngxx3w9pMjLRjtxOdImRRAjKzNSlLKOCsU9IIBVXwVjYRXcwD+ZVCDImRG50CUlBlJpSIClkSKjl0boF7CCJQVGxPEzKABbeiE91sulYVIRsDRRRyD1mnB+Ypo3J94hgbrW35Fw6ZCBrb5cC6mAQShXAFNVs5GEBu/kbS1z+3j2YAUGpSJrFPCGKb7XGQTUPY4V3u6nIECDIfalMqrP56BbEFdUM72hOyK71jUzKghMRVCGoybxFkxYJt1AHT+EGEAMlSZEUzofB7vl/Mit+Zj8YzMqNtnxCv11pHGX45fsx8t56yIQ4fo0QG70HQKdn8DB/UU4weeGLrAnXVUzF+2Edl91iRM4xJp0jH0rjAe45kfw+qPDEEZ/T4CDEplWZERu6c9tu4lKjQkZ3ncbyMdUzx3UYrmD12EqSCj5prkoGIImnpIyPI397uKtTOSnrqsU8YylKcJxWBRpSgpYnA3l7doYkNypz6a62yMymhT7NaaCIuVdl549AH7ePepUf385OnI0eI60jO04hjR/c4J9lrKHTwbwMKC+vaGYQYpSvle2y6YQoxmmq+CPWBA7sfI6BpjITnub+AM749x67YmnlExtVYxUmKexdTrk4dffb+V7PGKMD6IMYq8ujQwLEPIDNTUnWvvTjO6AGTEhGwBxDZM7eJBJGQCES6VjYLrLemKA4eGZZcrAzE2R5a48uxhmAnm8lFtigo4i6ktDGwEaflh8xgwkcox4mVA9ragOSmSZBW1bugHGmCQd9RQkipsZ57lSsbPnAarwAuz58Emx9LfJth0qgTUT0bYd59uevcxxDyA27F/unFcbhGhrNyD1UQ7eX4S/H2Hmw0kdBQKMJPPCjHX1ucu/n3+5Pbmz/fR3Yd/3m9HOz862vlQtIujo10MRXtxdLQXQ9Euj452ORTt5dHRXg5Fuzo62tVQtFdHR3s1FO310dFeD0V7c3S0N4ND7vHTwXlfPqigbC2kz54EM76cfINO2zqA5AtdEsq5xOIHryIu4TeqAJtVrYKNSqNTGD0kTMdjVEVtwdtKIiYMqJTGBxvWCDBLqea1YNdNY59SgK2GLBrDslG43poa4ytc067IdtVETCQwxhikxg5rWHsV/turfg4rtEGo5fspgo6O9fSBdWbQ2xTvUGpvtWcpsEenhLM5kPs8ZamMbFd3jxUsuU8fohiPFb8P4mEnmPRM/x4F6OVVU50gJtXkPqPfpLrOmJAqzJVjJ9qZLh2My0s/dfZRUpoww2RlOvP8Xp+t5mKhm3awuVuIbq+3NoWbRg8F2aGsnm33qLPTtUMrKyd2QX1yAYqzjB1shX580BfJ8CTQlR0eqvUcsaKcQEyxA7RO4p6YpGxa2HmWowuyK3AqDm2uUmzYXg8cxIH1lUNtpzR8EvBCLtvSxzsLQaD13ETGczsBHSMdB2Rvy8gmbmfcR+fiv97eOu12P8ooNZO6HQF/1eMPsR4kNTj7E36+uDwbH+XD7eJyKM/VcXiuBvFYD9vyoHyPOVFwn+zjFq8zcM+QyRG4USXTs+BovAbfv2VoaGo+9tlKp1ci6mSzw6M1n0ItKTM2r9j3Iii5+/vz05u3H3dCKogXvwjShpOUCcoJgq7jC9ZEekbnIbdoDP2ZiKyo8yOix1zaSaxt2jhfnXaW8seHz+UjC0xMQi45JNMe32wv4+J/tAwFmTRANEv8S0H28q2LsKWNW8XYi/Dgfd7EMZT0lKWbvP0rOjhrX8zt4BzFfl0nmKzaW971B+cohOb+UY2dVgzyCo69XrT5it7Yi+qPLTTFlthBhx/rNl+B1KZTyh4a2iuxqEMdpA97bO+YSDNzfoG9GcsKbqgAWWjefVmrSSxgGR0pt+QgEmvIGt83KtpXrP2Wrgv31AgYqW5vi95Wtsd5caAOyg2eqnxqXz21f+vXM3AFVmZ7ZLbu7JW0LxJ2Mte+/dxabhV1qkEfegmwBThzMfXQ2/+3e/WDTQDWPbalKrtK3+Y1jbikrVd5mnwuB2q8B36E51b7TAxKwxBFBZGFsVCTAmsLY+cF9jKyZHhMS2NmlIl2uKhHA87USaQONk2pMSsjLeUcBClymyfKTa8HPAZAeRf4cnsXZEy5XEZuuhGFuqOD7bl3QqRwGst5yn8lGZA9"
 }
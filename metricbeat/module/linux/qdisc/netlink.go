@@ -0,0 +1,233 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package qdisc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+// Attributes of traffic control messages, from linux/rtnetlink.h and
+// linux/gen_stats.h.
+const (
+	tcaKind   = 1 // TCA_KIND
+	tcaStats  = 3 // TCA_STATS
+	tcaStats2 = 7 // TCA_STATS2
+
+	tcaStatsBasic = 1 // TCA_STATS_BASIC
+	tcaStatsQueue = 3 // TCA_STATS_QUEUE
+	tcaStatsPkt64 = 8 // TCA_STATS_PKT64
+
+	// sizeofTcmsg is the size of struct tcmsg.
+	sizeofTcmsg = 20
+
+	// tcHRoot is the parent handle of root qdiscs.
+	tcHRoot = 0xFFFFFFFF
+)
+
+var byteOrder = machineEndian()
+
+// machineEndian returns the byte order of the host, used by netlink.
+func machineEndian() binary.ByteOrder {
+	var i uint16 = 1
+	if (*[2]byte)(unsafe.Pointer(&i))[0] == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+// qdisc holds the statistics of a queueing discipline.
+type qdisc struct {
+	ifindex    int32
+	kind       string
+	handle     uint32
+	parent     uint32
+	bytes      uint64
+	packets    uint64
+	drops      uint32
+	overlimits uint32
+	requeues   uint32
+	qlen       uint32
+	backlog    uint32
+}
+
+// dumpQdiscs requests the queueing disciplines of all the interfaces in the
+// current network namespace.
+func dumpQdiscs() ([]qdisc, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_ROUTE)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening netlink socket")
+	}
+	defer syscall.Close(fd)
+
+	addr := &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}
+	if err := syscall.Bind(fd, addr); err != nil {
+		return nil, errors.Wrap(err, "error binding netlink socket")
+	}
+
+	const seq = 1
+	req := make([]byte, syscall.NLMSG_HDRLEN+sizeofTcmsg)
+	byteOrder.PutUint32(req[0:4], uint32(len(req)))
+	byteOrder.PutUint16(req[4:6], syscall.RTM_GETQDISC)
+	byteOrder.PutUint16(req[6:8], syscall.NLM_F_REQUEST|syscall.NLM_F_DUMP)
+	byteOrder.PutUint32(req[8:12], seq)
+	req[syscall.NLMSG_HDRLEN] = syscall.AF_UNSPEC
+	if err := syscall.Sendto(fd, req, 0, addr); err != nil {
+		return nil, errors.Wrap(err, "error sending netlink request")
+	}
+
+	var qdiscs []qdisc
+	buf := make([]byte, 32*1024)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "error receiving netlink response")
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing netlink response")
+		}
+		for _, msg := range msgs {
+			if msg.Header.Seq != seq {
+				continue
+			}
+			switch msg.Header.Type {
+			case syscall.NLMSG_DONE:
+				return qdiscs, nil
+			case syscall.NLMSG_ERROR:
+				if len(msg.Data) < 4 {
+					return nil, errors.New("truncated netlink error")
+				}
+				if errno := -int32(byteOrder.Uint32(msg.Data[:4])); errno != 0 {
+					return nil, errors.Wrap(syscall.Errno(errno), "netlink request failed")
+				}
+			case syscall.RTM_NEWQDISC:
+				q, err := parseQdisc(msg.Data)
+				if err != nil {
+					return nil, err
+				}
+				qdiscs = append(qdiscs, q)
+			}
+		}
+	}
+}
+
+// parseQdisc parses the body of a RTM_NEWQDISC message, made of a struct
+// tcmsg followed by attributes.
+func parseQdisc(b []byte) (qdisc, error) {
+	if len(b) < sizeofTcmsg {
+		return qdisc{}, fmt.Errorf("qdisc message too short: %d bytes", len(b))
+	}
+	q := qdisc{
+		ifindex: int32(byteOrder.Uint32(b[4:8])),
+		handle:  byteOrder.Uint32(b[8:12]),
+		parent:  byteOrder.Uint32(b[12:16]),
+	}
+
+	attrs, err := parseAttrs(b[sizeofTcmsg:])
+	if err != nil {
+		return q, err
+	}
+	if kind, ok := attrs[tcaKind]; ok {
+		q.kind = cString(kind)
+	}
+
+	if stats2, ok := attrs[tcaStats2]; ok {
+		nested, err := parseAttrs(stats2)
+		if err != nil {
+			return q, errors.Wrap(err, "error parsing TCA_STATS2")
+		}
+		// struct gnet_stats_basic { __u64 bytes; __u32 packets; }
+		if basic := nested[tcaStatsBasic]; len(basic) >= 12 {
+			q.bytes = byteOrder.Uint64(basic[0:8])
+			q.packets = uint64(byteOrder.Uint32(basic[8:12]))
+		}
+		// Packet counter not truncated to 32 bits, on newer kernels.
+		if pkt64 := nested[tcaStatsPkt64]; len(pkt64) >= 8 {
+			q.packets = byteOrder.Uint64(pkt64[0:8])
+		}
+		// struct gnet_stats_queue { qlen, backlog, drops, requeues, overlimits }
+		if queue := nested[tcaStatsQueue]; len(queue) >= 20 {
+			q.qlen = byteOrder.Uint32(queue[0:4])
+			q.backlog = byteOrder.Uint32(queue[4:8])
+			q.drops = byteOrder.Uint32(queue[8:12])
+			q.requeues = byteOrder.Uint32(queue[12:16])
+			q.overlimits = byteOrder.Uint32(queue[16:20])
+		}
+		return q, nil
+	}
+
+	// Legacy struct tc_stats { __u64 bytes; __u32 packets, drops, overlimits,
+	// bps, pps, qlen, backlog; }
+	if stats := attrs[tcaStats]; len(stats) >= 36 {
+		q.bytes = byteOrder.Uint64(stats[0:8])
+		q.packets = uint64(byteOrder.Uint32(stats[8:12]))
+		q.drops = byteOrder.Uint32(stats[12:16])
+		q.overlimits = byteOrder.Uint32(stats[16:20])
+		q.qlen = byteOrder.Uint32(stats[28:32])
+		q.backlog = byteOrder.Uint32(stats[32:36])
+	}
+	return q, nil
+}
+
+// parseAttrs parses a list of netlink attributes, indexed by type.
+func parseAttrs(b []byte) (map[uint16][]byte, error) {
+	attrs := map[uint16][]byte{}
+	for len(b) >= syscall.SizeofRtAttr {
+		length := int(byteOrder.Uint16(b[0:2]))
+		typ := byteOrder.Uint16(b[2:4]) & 0x3FFF // Strip NLA_F_NESTED and NLA_F_NET_BYTEORDER
+		if length < syscall.SizeofRtAttr || length > len(b) {
+			return nil, fmt.Errorf("invalid netlink attribute length %d", length)
+		}
+		attrs[typ] = b[syscall.SizeofRtAttr:length]
+
+		aligned := (length + syscall.RTA_ALIGNTO - 1) &^ (syscall.RTA_ALIGNTO - 1)
+		if aligned > len(b) {
+			break
+		}
+		b = b[aligned:]
+	}
+	return attrs, nil
+}
+
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+// formatHandle formats a traffic control handle the way tc does, as
+// major:minor in hexadecimal, omitting the minor number when it is zero.
+func formatHandle(h uint32) string {
+	if h == tcHRoot {
+		return "root"
+	}
+	if h&0xFFFF == 0 {
+		return fmt.Sprintf("%x:", h>>16)
+	}
+	return fmt.Sprintf("%x:%x", h>>16, h&0xFFFF)
+}
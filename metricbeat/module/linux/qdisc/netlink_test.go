@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package qdisc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attr(typ uint16, value []byte) []byte {
+	b := make([]byte, 4, 4+len(value)+3)
+	byteOrder.PutUint16(b[0:2], uint16(4+len(value)))
+	byteOrder.PutUint16(b[2:4], typ)
+	b = append(b, value...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func u32s(values ...uint32) []byte {
+	b := make([]byte, 4*len(values))
+	for i, v := range values {
+		byteOrder.PutUint32(b[4*i:], v)
+	}
+	return b
+}
+
+func tcmsg(ifindex int32, handle, parent uint32) []byte {
+	b := make([]byte, sizeofTcmsg)
+	byteOrder.PutUint32(b[4:8], uint32(ifindex))
+	byteOrder.PutUint32(b[8:12], handle)
+	byteOrder.PutUint32(b[12:16], parent)
+	return b
+}
+
+func TestParseQdiscStats2(t *testing.T) {
+	basic := make([]byte, 16)
+	byteOrder.PutUint64(basic[0:8], 123456789)
+	byteOrder.PutUint32(basic[8:12], 1000)
+
+	stats2 := attr(tcaStatsBasic, basic)
+	stats2 = append(stats2, attr(tcaStatsQueue, u32s(2, 3000, 4, 5, 6))...)
+
+	msg := tcmsg(2, 0x80010000, tcHRoot)
+	msg = append(msg, attr(tcaKind, []byte("fq_codel\x00"))...)
+	msg = append(msg, attr(tcaStats2|0x8000, stats2)...)
+
+	q, err := parseQdisc(msg)
+	require.NoError(t, err)
+	assert.Equal(t, qdisc{
+		ifindex:    2,
+		kind:       "fq_codel",
+		handle:     0x80010000,
+		parent:     tcHRoot,
+		bytes:      123456789,
+		packets:    1000,
+		qlen:       2,
+		backlog:    3000,
+		drops:      4,
+		requeues:   5,
+		overlimits: 6,
+	}, q)
+
+	fields := q.toMapStr("eth0")
+	assert.Equal(t, "8001:", fields["handle"])
+	assert.Equal(t, "root", fields["parent"])
+	name, _ := fields.GetValue("interface.name")
+	assert.Equal(t, "eth0", name)
+}
+
+func TestParseQdiscLegacyStats(t *testing.T) {
+	stats := make([]byte, 8, 36)
+	byteOrder.PutUint64(stats[0:8], 4096)
+	stats = append(stats, u32s(10, 1, 2, 0, 0, 3, 1500)...)
+
+	msg := tcmsg(1, 0, tcHRoot)
+	msg = append(msg, attr(tcaKind, []byte("noqueue\x00"))...)
+	msg = append(msg, attr(tcaStats, stats)...)
+
+	q, err := parseQdisc(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "noqueue", q.kind)
+	assert.EqualValues(t, 4096, q.bytes)
+	assert.EqualValues(t, 10, q.packets)
+	assert.EqualValues(t, 1, q.drops)
+	assert.EqualValues(t, 2, q.overlimits)
+	assert.EqualValues(t, 3, q.qlen)
+	assert.EqualValues(t, 1500, q.backlog)
+	assert.Equal(t, "0:", q.toMapStr("")["handle"])
+	assert.Equal(t, "1:10", formatHandle(0x10010))
+}
+
+func TestParseQdiscInvalid(t *testing.T) {
+	_, err := parseQdisc([]byte{0, 1, 2})
+	assert.Error(t, err)
+
+	msg := append(tcmsg(1, 0, 0), 0xFF, 0, 1, 0)
+	_, err = parseQdisc(msg)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package qdisc
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("linux", "qdisc", New)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The linux qdisc metricset is beta.")
+	return &MetricSet{
+		BaseMetricSet: base,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	qdiscs, err := dumpQdiscs()
+	if err != nil {
+		return errors.Wrap(err, "error dumping queueing disciplines")
+	}
+
+	for _, q := range qdiscs {
+		name := ""
+		if iface, err := net.InterfaceByIndex(int(q.ifindex)); err == nil {
+			name = iface.Name
+		} else {
+			m.Logger().Debugf("error getting name of interface %d: %v", q.ifindex, err)
+		}
+		if !report.Event(mb.Event{MetricSetFields: q.toMapStr(name)}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q qdisc) toMapStr(iface string) common.MapStr {
+	fields := common.MapStr{
+		"interface": common.MapStr{
+			"index": q.ifindex,
+		},
+		"kind":       q.kind,
+		"handle":     formatHandle(q.handle),
+		"parent":     formatHandle(q.parent),
+		"bytes":      q.bytes,
+		"packets":    q.packets,
+		"drops":      q.drops,
+		"overlimits": q.overlimits,
+		"requeues":   q.requeues,
+		"qlen":       q.qlen,
+		"backlog":    q.backlog,
+	}
+	if iface != "" {
+		fields.Put("interface.name", iface)
+	}
+	return fields
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package sockstat
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	sock "github.com/elastic/beats/v7/metricbeat/helper/socket"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/gosigar/sys/linux"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("linux", "sockstat", New)
+}
+
+// socketLister lists the sockets of the host, it is implemented by
+// sock.NetlinkSession.
+type socketLister interface {
+	GetSocketList() ([]*linux.InetDiagMsg, error)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	sockets socketLister
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The linux sockstat metricset is beta.")
+	return &MetricSet{
+		BaseMetricSet: base,
+		sockets:       sock.NewNetlinkSession(),
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	sockets, err := m.sockets.GetSocketList()
+	if err != nil {
+		return errors.Wrap(err, "error listing sockets")
+	}
+
+	report.Event(mb.Event{
+		MetricSetFields: countSockets(sockets),
+	})
+	return nil
+}
+
+// tcpStates are the names of the TCP states, indexed by their number in
+// include/net/tcp_states.h.
+var tcpStates = []string{
+	1:  "established",
+	2:  "syn_sent",
+	3:  "syn_recv",
+	4:  "fin_wait1",
+	5:  "fin_wait2",
+	6:  "time_wait",
+	7:  "close",
+	8:  "close_wait",
+	9:  "last_ack",
+	10: "listen",
+	11: "closing",
+	12: "new_syn_recv",
+}
+
+// countSockets counts the TCP sockets per address family and per state.
+func countSockets(sockets []*linux.InetDiagMsg) common.MapStr {
+	var ipv4, ipv6 int64
+	states := make([]int64, len(tcpStates))
+	for _, s := range sockets {
+		switch linux.AddressFamily(s.Family) {
+		case linux.AF_INET:
+			ipv4++
+		case linux.AF_INET6:
+			ipv6++
+		}
+		if int(s.State) < len(states) {
+			states[s.State]++
+		}
+	}
+
+	stateFields := common.MapStr{}
+	for state, name := range tcpStates {
+		if name != "" {
+			stateFields[name] = states[state]
+		}
+	}
+	return common.MapStr{
+		"tcp": common.MapStr{
+			"count":  ipv4 + ipv6,
+			"ipv4":   common.MapStr{"count": ipv4},
+			"ipv6":   common.MapStr{"count": ipv6},
+			"states": stateFields,
+		},
+	}
+}
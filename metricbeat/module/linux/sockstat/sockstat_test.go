@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package sockstat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/gosigar/sys/linux"
+)
+
+type fakeSockets []*linux.InetDiagMsg
+
+func (f fakeSockets) GetSocketList() ([]*linux.InetDiagMsg, error) {
+	return f, nil
+}
+
+var testSockets = fakeSockets{
+	{Family: uint8(linux.AF_INET), State: uint8(linux.TCP_LISTEN)},
+	{Family: uint8(linux.AF_INET), State: uint8(linux.TCP_LISTEN)},
+	{Family: uint8(linux.AF_INET6), State: uint8(linux.TCP_LISTEN)},
+	{Family: uint8(linux.AF_INET), State: uint8(linux.TCP_ESTABLISHED)},
+	{Family: uint8(linux.AF_INET), State: uint8(linux.TCP_CLOSE_WAIT)},
+	{Family: uint8(linux.AF_INET6), State: uint8(linux.TCP_TIME_WAIT)},
+	{Family: uint8(linux.AF_INET), State: uint8(linux.TCP_TIME_WAIT)},
+	{Family: uint8(linux.AF_INET6), State: 12},
+}
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	f.(*MetricSet).sockets = testSockets
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	f.(*MetricSet).sockets = testSockets
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	if !assert.NotEmpty(t, events) {
+		t.FailNow()
+	}
+
+	tcp := events[0].MetricSetFields["tcp"].(common.MapStr)
+	assert.Equal(t, int64(8), tcp["count"])
+	assert.Equal(t, common.MapStr{"count": int64(5)}, tcp["ipv4"])
+	assert.Equal(t, common.MapStr{"count": int64(3)}, tcp["ipv6"])
+	assert.Equal(t, common.MapStr{
+		"established":  int64(1),
+		"syn_sent":     int64(0),
+		"syn_recv":     int64(0),
+		"fin_wait1":    int64(0),
+		"fin_wait2":    int64(0),
+		"time_wait":    int64(2),
+		"close":        int64(0),
+		"close_wait":   int64(1),
+		"last_ack":     int64(0),
+		"listen":       int64(3),
+		"closing":      int64(0),
+		"new_syn_recv": int64(1),
+	}, tcp["states"])
+}
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "linux",
+		"metricsets": []string{"sockstat"},
+	}
+}
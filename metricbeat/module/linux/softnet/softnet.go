@@ -0,0 +1,153 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package softnet
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/linux"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("linux", "softnet", New)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	path string
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The linux softnet metricset is beta.")
+	linuxModule, ok := base.Module().(*linux.Module)
+	if !ok {
+		return nil, errors.New("unexpected module type")
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		path:          filepath.Join(linuxModule.HostFS, "/proc/net/softnet_stat"),
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	f, err := os.Open(m.path)
+	if err != nil {
+		return errors.Wrap(err, "error opening softnet_stat")
+	}
+	defer f.Close()
+
+	stats, err := readSoftnetStat(f)
+	if err != nil {
+		return errors.Wrap(err, "error reading softnet_stat")
+	}
+
+	for _, stat := range stats {
+		isOpen := report.Event(mb.Event{
+			MetricSetFields: common.MapStr{
+				"cpu":              stat.CPU,
+				"processed":        stat.Processed,
+				"dropped":          stat.Dropped,
+				"time_squeeze":     stat.TimeSqueeze,
+				"received_rps":     stat.ReceivedRPS,
+				"flow_limit_count": stat.FlowLimitCount,
+			},
+		})
+		if !isOpen {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// softnetStat holds the per CPU statistics of the network receive processing.
+type softnetStat struct {
+	CPU            int64
+	Processed      uint64
+	Dropped        uint64
+	TimeSqueeze    uint64
+	ReceivedRPS    uint64
+	FlowLimitCount uint64
+}
+
+// readSoftnetStat parses /proc/net/softnet_stat. Each line holds the hex encoded
+// counters of one CPU. The index of the CPU is only included by kernels 5.10
+// and later, older kernels omit offline CPUs, so the line number is used.
+func readSoftnetStat(r io.Reader) ([]softnetStat, error) {
+	var stats []softnetStat
+
+	scanner := bufio.NewScanner(r)
+	for line := int64(0); scanner.Scan(); line++ {
+		columns := strings.Fields(scanner.Text())
+		if len(columns) < 3 {
+			return nil, errors.Errorf("unexpected number of columns in line %d", line)
+		}
+
+		values := make([]uint64, len(columns))
+		for i, column := range columns {
+			v, err := strconv.ParseUint(column, 16, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error parsing column %d in line %d", i, line)
+			}
+			values[i] = v
+		}
+
+		stat := softnetStat{
+			CPU:         line,
+			Processed:   values[0],
+			Dropped:     values[1],
+			TimeSqueeze: values[2],
+		}
+		if len(values) > 9 {
+			stat.ReceivedRPS = values[9]
+		}
+		if len(values) > 10 {
+			stat.FlowLimitCount = values[10]
+		}
+		if len(values) > 12 {
+			stat.CPU = int64(values[12])
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, scanner.Err()
+}
@@ -0,0 +1,71 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package softnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	if !assert.Len(t, events, 2) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, common.MapStr{
+		"cpu":              int64(0),
+		"processed":        uint64(41969),
+		"dropped":          uint64(0),
+		"time_squeeze":     uint64(2),
+		"received_rps":     uint64(0),
+		"flow_limit_count": uint64(0),
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, common.MapStr{
+		"cpu":              int64(1),
+		"processed":        uint64(72763),
+		"dropped":          uint64(31),
+		"time_squeeze":     uint64(224),
+		"received_rps":     uint64(5),
+		"flow_limit_count": uint64(1),
+	}, events[1].MetricSetFields)
+}
+
+func getConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "linux",
+		"metricsets": []string{"softnet"},
+		"hostfs":     "./_meta/testdata",
+	}
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package device
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("smartctl", "device", New,
+		mb.DefaultMetricSet(),
+	)
+}
+
+// deviceConfig is a device to read with smartctl.
+type deviceConfig struct {
+	Name string `config:"name" validate:"required"`
+	Type string `config:"type"`
+}
+
+type config struct {
+	Path           string         `config:"smartctl.path"`
+	Devices        []deviceConfig `config:"smartctl.devices"`
+	ExcludeDevices []string       `config:"smartctl.exclude_devices"`
+}
+
+var defaultConfig = config{
+	Path: "smartctl",
+}
+
+// MetricSet reports the S.M.A.R.T. health of the disks of the host.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config  config
+	exclude map[string]bool
+	timeout time.Duration
+
+	// run executes a command and returns its output, it is replaced in tests
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The smartctl device metricset is beta.")
+
+	config := defaultConfig
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	exclude := make(map[string]bool, len(config.ExcludeDevices))
+	for _, name := range config.ExcludeDevices {
+		exclude[name] = true
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+		exclude:       exclude,
+		timeout:       base.Module().Config().Timeout,
+		run:           runCommand,
+	}, nil
+}
+
+// Fetch reports an event per device. Devices that can not be read are
+// reported as errors without failing the other devices.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	devices := m.config.Devices
+	if len(devices) == 0 {
+		var err error
+		if devices, err = m.scan(); err != nil {
+			return errors.Wrap(err, "failed to discover devices")
+		}
+	}
+
+	for _, device := range devices {
+		fields, err := m.device(device)
+		if err != nil {
+			r.Error(errors.Wrapf(err, "failed to read the S.M.A.R.T. data of %v", device.Name))
+			continue
+		}
+		if !r.Event(mb.Event{MetricSetFields: fields}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// scan discovers the devices that smartctl can open.
+func (m *MetricSet) scan() ([]deviceConfig, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	out, err := m.run(ctx, m.config.Path, "--json", "--scan-open")
+	if err != nil {
+		return nil, err
+	}
+	found, err := parseScan(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []deviceConfig
+	for _, device := range found {
+		if m.exclude[device.Name] {
+			continue
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func (m *MetricSet) device(device deviceConfig) (common.MapStr, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	args := []string{"--json", "--info", "--health", "--attributes"}
+	if device.Type != "" {
+		args = append(args, "--device", device.Type)
+	}
+	out, err := m.run(ctx, m.config.Path, append(args, device.Name)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDevice(out)
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		// smartctl reports the health of the device in its exit status, the
+		// JSON output is still complete and contains the exit status
+		if len(out) > 0 {
+			return out, nil
+		}
+		if len(exitErr.Stderr) > 0 {
+			return nil, errors.Errorf("%v failed: %s", name, exitErr.Stderr)
+		}
+	}
+	return out, err
+}
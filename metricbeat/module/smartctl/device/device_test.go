@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package device
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestParseATADevice(t *testing.T) {
+	out, err := ioutil.ReadFile("_meta/testdata/sda.json")
+	require.NoError(t, err)
+
+	fields, err := parseDevice(out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/dev/sda", fields["name"])
+	assert.Equal(t, "ATA", fields["protocol"])
+	assert.Equal(t, 24, fields["exit_status"])
+	assert.Equal(t, common.MapStr{"passed": false}, fields["health"])
+	assert.Equal(t, common.MapStr{"bytes": int64(4000787030016)}, fields["capacity"])
+	assert.Equal(t, common.MapStr{"celsius": int64(36)}, fields["temperature"])
+	assert.Equal(t, int64(42), fields["power_cycles"])
+	assert.Equal(t, common.MapStr{
+		"reallocated":   int64(2048),
+		"pending":       int64(8),
+		"uncorrectable": int64(0),
+	}, fields["sectors"])
+
+	attributes, err := fields.GetValue("ata.attributes")
+	require.NoError(t, err)
+	assert.Len(t, attributes, 8)
+	assert.Equal(t, common.MapStr{
+		"id": 5, "value": int64(1), "worst": int64(1), "threshold": int64(140), "raw": int64(2048),
+	}, attributes.(common.MapStr)["reallocated_sector_ct"])
+	assert.Contains(t, attributes, "unknown_attribute_241")
+
+	failing, err := fields.GetValue("ata.failing_attributes")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reallocated_sector_ct"}, failing)
+	assert.NotContains(t, fields, "nvme")
+}
+
+func TestParseNVMeDevice(t *testing.T) {
+	out, err := ioutil.ReadFile("_meta/testdata/nvme0.json")
+	require.NoError(t, err)
+
+	fields, err := parseDevice(out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "NVMe", fields["protocol"])
+	assert.Equal(t, common.MapStr{"passed": true}, fields["health"])
+	assert.Equal(t, common.MapStr{
+		"critical_warning":          int64(0),
+		"percentage_used":           common.MapStr{"pct": 0.03},
+		"available_spare":           common.MapStr{"pct": 1.0},
+		"available_spare_threshold": common.MapStr{"pct": 0.1},
+		"media_errors":              int64(0),
+		"unsafe_shutdowns":          int64(52),
+		"error_log_entries":         int64(7),
+		"data": common.MapStr{
+			"read":    common.MapStr{"bytes": int64(10240000000)},
+			"written": common.MapStr{"bytes": int64(20480000000)},
+		},
+	}, fields["nvme"])
+	assert.NotContains(t, fields, "ata")
+	assert.NotContains(t, fields, "sectors")
+}
+
+func TestParseFailures(t *testing.T) {
+	out, err := ioutil.ReadFile("_meta/testdata/open_failed.json")
+	require.NoError(t, err)
+	_, err = parseDevice(out)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Permission denied")
+	}
+
+	_, err = parseDevice([]byte("smartctl 6.6 2016-05-31\n"))
+	assert.Error(t, err)
+
+	_, err = parseDevice([]byte(`{"device": {"name": "/dev/sda"}}`))
+	assert.Error(t, err)
+}
+
+func TestFetchDiscovery(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, map[string]interface{}{
+		"module":                   "smartctl",
+		"metricsets":               []string{"device"},
+		"smartctl.exclude_devices": []string{"/dev/sdb"},
+	})
+	m := f.(*MetricSet)
+	var read []string
+	m.run = func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		name := args[len(args)-1]
+		if name == "--scan-open" {
+			return ioutil.ReadFile("_meta/testdata/scan.json")
+		}
+		read = append(read, name)
+		return ioutil.ReadFile(filepath.Join("_meta/testdata", filepath.Base(name)+".json"))
+	}
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+	assert.Equal(t, []string{"/dev/sda", "/dev/nvme0"}, read)
+	assert.Equal(t, "/dev/sda", events[0].MetricSetFields["name"])
+	assert.Equal(t, "/dev/nvme0", events[1].MetricSetFields["name"])
+}
+
+func TestFetchConfiguredDevices(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, map[string]interface{}{
+		"module":     "smartctl",
+		"metricsets": []string{"device"},
+		"smartctl.devices": []map[string]interface{}{
+			{"name": "/dev/sda", "type": "sat"},
+			{"name": "/dev/sdb"},
+		},
+	})
+	m := f.(*MetricSet)
+	var calls [][]string
+	m.run = func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		if args[len(args)-1] == "/dev/sdb" {
+			return ioutil.ReadFile("_meta/testdata/open_failed.json")
+		}
+		return ioutil.ReadFile("_meta/testdata/sda.json")
+	}
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Len(t, events, 1)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, [][]string{
+		{"--json", "--info", "--health", "--attributes", "--device", "sat", "/dev/sda"},
+		{"--json", "--info", "--health", "--attributes", "/dev/sdb"},
+	}, calls)
+}
@@ -0,0 +1,231 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package device
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Bits of the smartctl exit status reporting that the device could not be
+// read, the higher bits report the health of the device.
+const (
+	exitCommandLine = 1 << 0
+	exitDeviceOpen  = 1 << 1
+)
+
+// ATA attributes reported as sector counts.
+const (
+	attrReallocatedSectors   = 5
+	attrPendingSectors       = 197
+	attrUncorrectableSectors = 198
+)
+
+// nvmeDataUnit is the size of the data units of the NVMe health log.
+const nvmeDataUnit = 512 * 1000
+
+// smartctlOutput is the part of the JSON output of smartctl that is reported.
+type smartctlOutput struct {
+	JSONFormatVersion []int `json:"json_format_version"`
+	Smartctl          struct {
+		ExitStatus int `json:"exit_status"`
+		Messages   []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Devices         []smartctlDevice `json:"devices"`
+	Device          smartctlDevice   `json:"device"`
+	ModelName       string           `json:"model_name"`
+	SerialNumber    string           `json:"serial_number"`
+	FirmwareVersion string           `json:"firmware_version"`
+	UserCapacity    *struct {
+		Bytes int64 `json:"bytes"`
+	} `json:"user_capacity"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount    *int64 `json:"power_cycle_count"`
+	ATASmartAttributes *struct {
+		Table []struct {
+			ID         int    `json:"id"`
+			Name       string `json:"name"`
+			Value      int64  `json:"value"`
+			Worst      int64  `json:"worst"`
+			Thresh     int64  `json:"thresh"`
+			WhenFailed string `json:"when_failed"`
+			Raw        struct {
+				Value int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeHealth *struct {
+		CriticalWarning         int64 `json:"critical_warning"`
+		AvailableSpare          int64 `json:"available_spare"`
+		AvailableSpareThreshold int64 `json:"available_spare_threshold"`
+		PercentageUsed          int64 `json:"percentage_used"`
+		DataUnitsRead           int64 `json:"data_units_read"`
+		DataUnitsWritten        int64 `json:"data_units_written"`
+		UnsafeShutdowns         int64 `json:"unsafe_shutdowns"`
+		MediaErrors             int64 `json:"media_errors"`
+		NumErrLogEntries        int64 `json:"num_err_log_entries"`
+	} `json:"nvme_smart_health_information_log"`
+	SCSIGrownDefectList *int64 `json:"scsi_grown_defect_list"`
+}
+
+type smartctlDevice struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Protocol string `json:"protocol"`
+}
+
+func unmarshalOutput(out []byte) (*smartctlOutput, error) {
+	var o smartctlOutput
+	if err := json.Unmarshal(out, &o); err != nil {
+		return nil, errors.Wrap(err, "failed to parse smartctl output")
+	}
+	if len(o.JSONFormatVersion) == 0 {
+		return nil, errors.New("smartctl output has no JSON format version, smartctl 7.0 or later is required")
+	}
+	if o.Smartctl.ExitStatus&(exitCommandLine|exitDeviceOpen) != 0 {
+		var messages []string
+		for _, m := range o.Smartctl.Messages {
+			messages = append(messages, m.String)
+		}
+		return nil, errors.Errorf("smartctl failed with exit status %v: %v", o.Smartctl.ExitStatus, strings.Join(messages, "; "))
+	}
+	return &o, nil
+}
+
+// parseScan parses the output of `smartctl --json --scan-open`.
+func parseScan(out []byte) ([]deviceConfig, error) {
+	o, err := unmarshalOutput(out)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]deviceConfig, 0, len(o.Devices))
+	for _, d := range o.Devices {
+		devices = append(devices, deviceConfig{Name: d.Name, Type: d.Type})
+	}
+	return devices, nil
+}
+
+// parseDevice parses the output of `smartctl --json --info --health --attributes`.
+func parseDevice(out []byte) (common.MapStr, error) {
+	o, err := unmarshalOutput(out)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := common.MapStr{
+		"name":        o.Device.Name,
+		"type":        o.Device.Type,
+		"protocol":    o.Device.Protocol,
+		"exit_status": o.Smartctl.ExitStatus,
+	}
+	putString := func(key, value string) {
+		if value != "" {
+			fields.Put(key, value)
+		}
+	}
+	putString("model", o.ModelName)
+	putString("serial_number", o.SerialNumber)
+	putString("firmware_version", o.FirmwareVersion)
+	if o.UserCapacity != nil {
+		fields.Put("capacity.bytes", o.UserCapacity.Bytes)
+	}
+	if o.SmartStatus != nil {
+		fields.Put("health.passed", o.SmartStatus.Passed)
+	}
+	if o.Temperature != nil {
+		fields.Put("temperature.celsius", o.Temperature.Current)
+	}
+	if o.PowerOnTime != nil {
+		fields.Put("power_on.hours", o.PowerOnTime.Hours)
+	}
+	if o.PowerCycleCount != nil {
+		fields.Put("power_cycles", *o.PowerCycleCount)
+	}
+	if o.SCSIGrownDefectList != nil {
+		fields.Put("sectors.reallocated", *o.SCSIGrownDefectList)
+	}
+
+	if o.ATASmartAttributes != nil {
+		attributes := common.MapStr{}
+		var failing []string
+		for _, a := range o.ATASmartAttributes.Table {
+			name := strings.ToLower(a.Name)
+			if _, found := attributes[name]; found {
+				// Vendors reuse names like Unknown_Attribute
+				name += "_" + strconv.Itoa(a.ID)
+			}
+			attributes[name] = common.MapStr{
+				"id":        a.ID,
+				"value":     a.Value,
+				"worst":     a.Worst,
+				"threshold": a.Thresh,
+				"raw":       a.Raw.Value,
+			}
+			if a.WhenFailed == "now" {
+				failing = append(failing, name)
+			}
+
+			switch a.ID {
+			case attrReallocatedSectors:
+				fields.Put("sectors.reallocated", a.Raw.Value)
+			case attrPendingSectors:
+				fields.Put("sectors.pending", a.Raw.Value)
+			case attrUncorrectableSectors:
+				fields.Put("sectors.uncorrectable", a.Raw.Value)
+			}
+		}
+		fields.Put("ata.attributes", attributes)
+		if len(failing) > 0 {
+			fields.Put("ata.failing_attributes", failing)
+		}
+	}
+
+	if h := o.NVMeHealth; h != nil {
+		fields.Put("nvme", common.MapStr{
+			"critical_warning":          h.CriticalWarning,
+			"percentage_used":           common.MapStr{"pct": float64(h.PercentageUsed) / 100},
+			"available_spare":           common.MapStr{"pct": float64(h.AvailableSpare) / 100},
+			"available_spare_threshold": common.MapStr{"pct": float64(h.AvailableSpareThreshold) / 100},
+			"media_errors":              h.MediaErrors,
+			"unsafe_shutdowns":          h.UnsafeShutdowns,
+			"error_log_entries":         h.NumErrLogEntries,
+			"data": common.MapStr{
+				"read":    common.MapStr{"bytes": h.DataUnitsRead * nvmeDataUnit},
+				"written": common.MapStr{"bytes": h.DataUnitsWritten * nvmeDataUnit},
+			},
+		})
+	}
+
+	return fields, nil
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded gzipped contents of module/system.
 func AssetSystem() string {
-	return "eJzsff+PGzey5+/5KwgfFhm/m5E93mxe3vxwgON5uRvAWQ9sB+8Bh4NMdZck7rDJDsmWrPz1BxbZ39nqbqmlkYMMFtlkRiI/VSwW6xuLN+QJdndE77SB5DtCDDMc7siLT/iLF98REoOOFEsNk+KO/K/vCCHE/ZFoQ02mSQJGsUhfE86egLx7/I1QEZMEEql2JNN0BdfErKkhVAGJJOcQGYjJUsmEmDUQmYKihomVRzH7jhC9lsrMIymWbHVHjMrgO0IUcKAa7siKfkfIkgGP9R0CuiGCJlAhw/6YXWo/q2SW+t8ESLE/X9zXvpBICkOZ0ITLiHI/Wk7fzH++Om917kgqKH4Zmn0PggqKGztOBYrlp0dAllIRSjQTKw44H5FLQkmSccPwexUO5j91puU/TSKqhLC49uucFC7FqvGHPdTYHwv9nUUlsmQBqkRV++T/II+gIhCGrkAHAWUa1CyNTBCWjiiHeL7kkjY/sJQqoeaOpG78ceA/ryH/Il0hoy05hiVAdArCECYQGNEpjaCDthoFhkVPehrWWnA0kZkwRwLz8nKJzH0CJYCPoWJCBvdyeAQ6wSK4PA5LQbjc3qSKScXMjqRKRqA16CHUnI3Th6JkMb9AniOqAcDPJ8gDAMktZeYCeSmIBUaupCAx008vh9FxTh0xDp/6/fKYrEFtWGRNM2vSramIuf2PNVXx1lpzTBhQKktN735Uv5+P9ZOh1nJpvqV1sXgPo/C51+YA5AYov7yVYYIwsZE8E4aqnVMBix36ORumTEY5fmO7Zhzwt+tdalmipWpNtqW6xi9p1qDyI1CqWesLbzeUcbrgQKTgO3t4/ibY10GMPKdevFwGFb5cmh3lykVp1vImLVXWY9bHeWfWzZtyoZxvli8Ujk5SBdpbX7gCUpuZ+7AUN8LuH87+gKabSCo7Q5Mt45ys6Qasg0q/siRLyIbyDDfNl9vXr/9G/s1N9wXHbg1WzlMbl3IFNN4RQ5+sfDDtR2XCSEKjCMXO6ZZNe9AAFgvlT+2akg+iHSLQ161hdzIjERVu0aosL4I3KwXUgLK/EI5v5BepCHylScrhmrAl+XtrWCdS9uvUkB9f/81Cu7Zy5YTLhz1mUZrNcm5+cdKzAHL7U+fi/Llc2D+Xk/jtul9/Fm/nG7Ja/7LLAxT+Zd1OY90aaS6UkdYWBE0c2XiiPsQcUHAePvyX1UJdRsk/S8tokH1iLamLZMHYMPXFEjL2oL9MQo467S+TpOFH/oXiP+Dcv0xKJj/8vykyD7UALpPIb9UMuDRuDrECrvNAiIY4Z3IZs0HnOkB7w2L43IrufSuZ6UvO6X4bWdALTCZedBLuuVMhh5+Iz4380EPur9xDlSdWTpn8rsmKMekHO0Ql/2D/kzx8KMrIBtbg5T/jcxT2n8H1fILdVqpm4sDHj++Ijunt+OVG8uyUfcIGilE+d4fnCHgDIXyv/Qx5uRv5vGaaJHRHhDRkAVY4Nix2xzjlvGR6a0wfo+8hSAGNZ5jwmHDzoKVUsTDsJFZk7ApZkdFZZCV8mXG+68G3VczAyQHiLAciRA4udmZ4Ri03BUNfOgA8DoMw6rDJB0HeM5F9dSku1pyKNOxADZGRyo+EyZ6UMy9pglCts8RyBj9FNPsD7dB/3L4ZtILPzyCLw4CYhkf5YAPZ1Bq1n20oVvbcOaHYJ4xbnyCSItb+ePNqBXfsoIV9Nohuz/Yai6cGGMYYS3sOPrz60A/Qem8zXG0Fv2egzSwBtQI9T0HNNURB7CEPswd8M1WP29xPqQnOiVly4ihxGdstKCC/Z5BBTIzEzRDDhvX6Np4sJyLnpQvnPDVhtfU660KV6JnWLfQVOg9YoPOuzLSU4Ip4AvacNhOQ8XN53ha2bwtz03YPH2m9BFHrX0xLCN2Aoiuo+jRLqRpSFlwRI60Fah0WiMfs/zOuihOxUy6LI+l869LYNBMtTL7j6WY1tzbKaUhB6+eKCcfel3aZLOqBGmAYJajDT0wHzkE4iJVZn4SIc27zaQXJhS9g3mllHS9EbgZHiBWmqrn1Eol6ePVh2vVYZHo3HTWP4ch9nClrJG7XLFrXSeg+FK8WVMRbFps1yQzj7A9qp0UmlJ96OSP37uOamky5j8goyqzj4mrmypJHTSIuNS59vYoxZwkIo2S6OyaYVIat/HXI9pjjA0Q0H3S+YGbS0F+B1g5sl6wNt4Tx/KmgEq/HeW25SQ3bQC49qZS8cNl/eP0fP7ZWeck41G6+koOihuUwrdrl8k9TlDAXRJ8ppoABQszqVPhtpHX5M5EqtmEcrJ+Buan8xJsFobtNOh8Z4BwVxKyW1N6RL69i2Lyyf739EkRk5z0BFDtGEwp8NT+EQWDAfZ5K1hHpOxgLDmw1LY7d4k0YDUrrCcMGdnwiZAzaSovdo/ibduS8AknBs0r7fqm26OZTc63CLwVwCNOQ72fimlvjCu/2cyzTcN7AsZ1wJLznP90aoPfVKRSiqO0Bc9Q55kXKjVQ5yiqHWJ4Jo6uVghUtUmGUc6dyGpdbyq8efXvn0GTIP+vqx6MhS5k1PePa9jliW38OqL0OeXNTBZy4fVIfXtk24aBxfUqqSSwj3YoGBLhO9mvgvazoQ9/CGfIeiGciasDGHmgCtJvl2QDiTu0BGFLH50Po1N4VAk15ppGnL9suD5c0PkZ9WBfPjpH7sEdu+Be3L8YqYfsnJlbzJY2MVHfWtRuniN9X4BfuJafakISJzEB4D7/4xyUh/YfH2qFwXtxeFNrbANwwbixBfC6ZCMgCiVlRkzCstLBNznMtRVhgpqDo2aSrQ6qOpAk/FKbotJeGW9rZdQU7yrxzQ7RCFL7f2AThibO5HXiuOdz9/aPO5278Zo/YQbDO6NU6/6ys7EOLyq954Qu5iisXHI0laCy8YiLiWVx8OJLCVXksdrk5GdFoDZpQ0ba/FtlyCUqTKw2Fr+pZQyOTUT5rmCEX744NWlhH22H2ehvJWxyt7AgIMdaNWs71WfF7reXgjiBnsEg9QRV+VmTwwRAFXhlqF9lnVohAREAWYLbgb757kcaqhmqsxq9QsCmC/Wl+ksSQgoh1rnk/fHJxskQqIDEYyri+JimqQRKtIXoqfOSKDH/pEAny/D6UZ3d4yz8YzINQHmUcHfkFtctS4UW9TMxph7y/wK+QlAkODAG8SpWMXiWQMLGU121e2B+pqhPi16rg0D0plUqhRNiyProFbjVUsaBt38v+fBDkw6f/JgwJpURnSVMB5jLEBI0wdZCL0AdB/ouJWG71tf8+/N7e2H4VZSEW/utDxaJDvZEhKo70qjky0Ets51Zau7SvQHhLm5qtW+elCpbs6x158X+RrP/XNK/qoRQreThKabZYS4VpwyLtUj5lvtDiqPVPzaU5FCztj3w8s99eEjNUlJ5LraPhMw7vc2nEMik7Cq7MzCxtXRYfgLmGKcqNMBwKIaRW5WamHwETpwPARP/8CmhM11hvdjQM5H0xIKkPOAABHhGjY377IOCIZF3NqX9rWjsbtgmLFD5dwRydvsOs1fzIxmqVQiOP1LDpSkdUzJ8s7AMFK+dm8HZKC7WX+4gK4TwZN3UfwJgpiA7UAMcAdPPyZllOFR86A2cDZmcrgimt0okW7wxQfsbVLaMrDq2CiFOWDF1pRHu+pe5G27vs7gNzWC5ZxEBEuzPqIzd3jpaUGCr6aEbeEi63oKo6iomYRXhpuxQea1lro7LVCi9CGlmM21RiTRa45XweFri5z86CoB5fZysICevZDXALxEvyc9vew/Zf+GAt08UVgnzhRSolv3Rb/NdKsIgJQjmXES5RSc4UnmkPAUfZNvXS0ZpcdVbokol8i2lEp4w0HSxEClxF8vMSkqMgi8y4kEtAnkZSpjOV8uzEh2sfYXIDKpJJwkZvjRiWNOMmVLQxmIYj9ve9m94Vti6lGgfeHlyzqr/ZBB46MFrIKksf8mEr9HZoedJwREK8IH3MbMEagqiiJSjnCxo9TTL1u9yxrrAGa/KTTOMVdp1yZv9liZ1ktzStwiuu/4PZSlVFND7L58eopPn8b6qNDGrv4eR/x+YTy0Yly/l6GIBZj0z8YkK1CX5IQwOZmbPWIDZvZWsQXU0KK+Ge50SoIALWfx/GhcWiJ5j0LkLVMcKxBzLsdEhUgWQgY5iYgVJSnYYtbmjfbsUhYmI1YK3OhUmDiPsRMTGLlbTK+iSImIhkgiXwfu3KS1J+2gEcOyVAmZmV3A+wmphnmlC+pbv2Yfna+lr3VG2twS9i8vOne7KAiGYafPbKmm4KUqlMGb7pbl3TOI/mOksSOqD6pDgsFmDosPPqV38iucs7zv9dcbmgvFDtmJpjZjfw/GHp7N+CyyUX/4KWR9OzYA+PLmYOKtwFzkRTzvb5Xc90WTzldL/d908358zAxHO+Zwb2T8yiZNJVfPdrgNLCAHWtp46yuvwYFavL/8aaXDSmhl5XHyS8rr702HgmkUxrdVHOaFNjpNSsC7pnga8mbOVuUBZPSLZnxAaMIwy9IVU3nmc4dOPO0guVCcHE6kW4rjXteHyxn/z2N4dQnx4x4YEzrg6fsf3VITNGScyZmHiNlxnnxHreVMQ3dngXqjLSrroyLpDgcF/7EjQ8FgI1PVStsgSLhTSkVFF/tgWr8dlKSAVzupAbuCNvXv/wU1jjaVAHbCXXLfywfRRtD11WezoysfIpi3p56NDZQWyGq1n3y/mREgBiw5QUduXIhipGF9zH9oJS4B7QsSo01KmKVpoDkl8UwM+f7q9d2ZJTsh8+kf8Oq4z6W0Vkupj5u8ffbnQKEVuyqBosT8s+h2PD4Z3dZsmorHd3LjnQ+tFUNfK+NrRNsK5nMBqtJ0JbvEFkwbpsg2YiAic9Xl908boJ9PJS+Y3um95eL9YCKS2K3bM0xtPywVQcBc0SxqnyhVHBaf9mZykYWZ0gZjrldFd6CkamucrO22+2Oy2GmdvROfqb4jBsauGH+shV96zy8lbrvkFZ72+5yAxRVHQFPrEw8nW7O0WTxXtaPZMz64VwC+gmYCcTp8TraoP3Lu8eflrtEerqUqKL20bvGHQW0zZ/wStnInbEtVNDx4XU1uUPMrxOx+UDx55Hfedd33n1TMmRUgLytsTex6qye011tcDXVTc3Ks/fYWqIvFtTtQJyZQIXKYqRqTNXcm+OCroCZWchLsGEpc4YcPcuTI7kZfECn4+6uktMTPdLqtL62TLMlskfQbPYbq1PYMgn9gfMGtoiwHcZRVnKXFo6ofYf7jNXH9/++rJ3RaJMKTuhN3qJBpcDu+640d/k1uWdQaNZ1L3b1lQ913bDueMQMZnubJsR9ngOuCHzC+NQfEYqbwvmERV3PFtJQXa7SCPTFadhGWhQ7u482FPa+xNTK0eZgjjq+Gt0CanzQOP4g888zhJmZlouR9d6DBUQuTRulrwiqAd6YT0Fh6x5hZWxIyrIAki0tmZV3LToqCFU7PD87WPFmrac2qlYYYc+FSsqY1tWYKv8BRBF8/dPlJSmwxEObbyDt2Qe0bcbCPHosjWlmwnbomIDODxXqX5yF3QSwPbvrRH9t4reIwrKXEbLmLLnrhtIr1mKJVCtAYUUN5YdfmRkoIbaBMi/WnAB1cJYv70VdyM9EbQBDCZemh7u0cCwkiSxAYujRhOqtYwYhsO2zKzdcWrZHPZhHtD7w+Z74ntDaD7qw70LyvjW0/noOBrSnV8GC45KF3uStqRW/2HWp2OSHT2/HuTlqNknzv9aZwvnT32vXSsb1zlrFMtwtnMwrR27IuNKeLo5FqVZyQuiozXEGQeNPhXFLvLOTqX6qaj88vsoOOZb951cP0thlOTca7atLGK3xVRKX5N3v3xCBfLxc3hQ+3dtqIgdmPwNA74jS8pUOZTXM6mSVl8wKSgPlFUjd7BRgLf+c/cxv3WaL2NxRXILbLU2M/LxcwVGcFwFlHtftAFKg9GVd7WDnnbQHiXlO0b1BUAm+3vaeadNSlZsA8Lankzuq54cVq0VVGhkwH4lTQl8uM/jTk3p2QugQ10cBCG8CezP4yFqo3O0kDrZS2S01DO/YMFCSTK6QG0PqTgProV/Wi1hkZJ5a38sMZRbomCVcarsqdg5lGPJ9zrXE0aiLCvQMlMRaKLXMuMx2iVQVJKO4MnvmTT09Cz53HD1OxnjNjLl4YvBCClXk7S6R1Um8v0pBfi9Sa6oJjEsmTP7urlcFY6uDgoh7qGrdmrevRVYi7cC5aMbGCDx4SewCq/YSIinqvA6B611H82NxhpbZ5W8QD5Z7LVjNyfTzDPFmd95seYbYoWerdZVa3Qve5W54P1a7Mtu/nbsVwzDjN2oysxUJtDVugRmYBhfihVog9YHE5nMtN9znQMz0XBR6pt4TTfQxbWBbHINdxyMU7OprHv3qgbLZTeUa1Q6tQ1jN0VdxXQrN7u1kRXAabr/ckabdLNW0hgO8dmZYGVFd63qwrUZ8djIFRLJ9HXnuPm1iK1LYFvdnhffmTXsPIO+rmmG/RjxAbPlXr1UUXdWqmsr5OIBTBE8C4eq/ybHxcmP0CJinrd9dx3Zr5ggggpZa2Xvd1qxHj0GRmidhvlMNNoTBR7kN3kvKO+uHKjdyn/+sqaLn2e2pn0m+jxWY/WN4oqg19pnWRVQ9Z975H3UHnelSNPQWtBSBV8Ax1KQRMZdty3C+HzO+nwIr1xq+uUYqCmocISF7C+Qyn9qhVLHS1ZBZUt7FmRLQYBGa/xoQ8L2HN9M94vY3iQ0Gac9/eVUHxZ2NbB/KdATKdDxijKBZIYZtM7cMhmyQ/syiyMIr7Z69Mm9xa4z/FW+ujSa4IR+vRyi11BEBau9/6am3OW7LpHqMvTiDpl67zpLbV4XbP327uOTRmt46Us02vFqTPRULPdMDz0fLPeWlPHs9PGUeq7Xey6NmhOX9rtqrOlLsm0VEJc/CrCJ0nCC9fbSdMMa8p5+eXFITVFgN0FsiORaoPs91DnelHurYNal6pVWpY49jNvMqjFljyFxLLMuXhXlkSQvcE2m4WLvj5NMrYD09pJUUHOz4YJ2jnjVWnVUViOV0tOl2iu+GPZkZsvT5dstTRb0mi+do47nzMUrE7ls8GefguiOEh6iOJ4u03RprtuUtosde26i9CJVRU1H2EPm87vHsu9xq7J1DKGXqhqqOqFJcUBH9ATHDtOeyKZvQU94ZjX51FIYfVw62NIouHWZSqO5kN3JqvH2hQtYus7gcypkuCXLYAZMKCtvhRS7RGa6tEBdB1spiO9kzoFqc6MgAmH47gZ329X7j791M4gzbWpXbpN0qcmVXieQvAyV2Q9nnvXSz8y8XxiHmwWNnsri9JI57z/+VpB7AFXI6zPT82gPCJx46jVaM1BURWsWUT53rJpflmqsho0LTyyH7a2novFCRU843deduZ2EXXp7mdwqPbLBfOscss7Pw/iWv7Hw7WjS4lWIqrqo7bxuB7e5Iw/i1DOozW5OhRVqkEcHSEeCTfsui+JP/u1wR+2Ng0j8/+Gjnt2qeFqdg83ase/j2YtkrI6gxe2KundqFFutQEFsP7EvAIbQR8rDv6SafwN0I9AewsmLX+2nXrj/1GRtRUiUd1d8MMC9u8J3eIfFyH3ur3u2Bpti4OWamFVvdwyUKD3vDLucoPAMe2Laf2L1may81sTcpby8Q9MBdHT1+jw1ITKrOGnHkrLvTu8gUs5xLPrUm90higqdUsy7FE3IX14TIbvjvtMarkrruZ35Yrj2z0YXTbkktGBkkF/jSme2NL0YWj8VaY8DVy8TsGGRwee7LoWoXyvh2IgKIY27q+AfZhhEaU7lgj+xkA4fUS7zM5dRtW/vX1UyU1fJHFAk46oJL0Vim2/NO8WDumYJSrlon3s00j/Tv0Chiu3m65yc7EnWjGITk+epuiwZ8PDqQ97ZVAos87fcdhVylvzDCcc6bCiv1vvaY2zoITmLdu0GqvkN7UADVWY43JFHb19+GthhdQ9T/BC1Ht+Vi9Ggi/YjwYeGT/7gb19nuMY6lrARLtMtvJUbJ46wiZBUXj+o92sZhIXFrYKk44HYQUeh0BwgPQVL8oHHoTFTNlGugHHjjsLyh0wWbPoVcsOOQhIDnZ4lMT6oF0ZBHsz3mmxA7UgmOHsC7k0dZtytdOuWUoVvfTBBtEz8XTrKiWYm8yqVGZLQnXdiw6Rl4knIbdO5PJ66krDKtZG1e4AOWwrzWHzvbTajGGys3lfWJfOI2ipa0Zp1NFrtNr5/8vcS+nhFk6KfnzvqurYkNa3beUfMmzfpvnFLMQABhw2ED4+DO4vatXDj1gGEObAT0dzClmE5PQjFO1+IaAcnbvBrwhyWj28f7glViu7cvco4EzEVJtx7Pmb6KU+fTbSNqu8TuZitm2TP/Kc84HGGyiLhXQamjXWb92FCH3p6luCwcT9LlpTxyY6yyvxu3P75cX/pMc3Rj+/aSxKKTXsU3SIKp2/DrdvRvZhWciphFRy8KjRryWMMw5Pb129+uLHuTw5hHzy7P09gkHh83sD2EF0oWeGNMDtvD9pCP4Fq6K7JHl2ough5nxc3mz7DoYUVHpVjqk1o5ZCQNJ4f1Wn+M17/pjGpHUz75jx6uvwsHDFltjieSp0tbsYROcdGt8E5A31OWxNipsTQJM0nxG653hbDPmwz3ykph4LBcXf2UBHn/tW1s1Ht/4wmWdru0lZ0K/8K0TyS8VF8+vTwv9/9n/f3xI5TtibzCL/XrvFi+1GIismY3/QPouhtmeZ3XNFtrNWtK7xy/c3GojTzpX/B25XDO9iVXbXr9w07Z/YJkP0FlsPnrxVFtjLozcmxDG6GGZejZq1UnWFh3fCFab2Y04ljUNg3f/gml7PO2wWDYr/nrrVwEciOzGIFVfi1rtPhyh9Z6kHW/aTZYGjBafueI+x6k+6wWX3+qTJn276QFtgxru/nd49+FF0aOU69HxdXdA9adDlm3Q9j+I0z6/p+14MYQRBLmrBWr7ihCOznjpmcy4jyGQs35Wz9ungk5/Y/3sxez97MbolU5M3r17d3r+9//unu7c//eX/30z/+/uPd3e040/a9xUEeHgmNY+V7jbKimR8V5OFx84Od7OFx82PxoSG0pVI1N0SniBf0vXlzCHw7VQ8mBYk0cAEM/4hAJua4p+4sLPcEDOf5Wuowqp73Qv/9x5s3t7c3t7f/fvP3H2diO/N/mUWy9dp4D+bHzx+JgkiqOHjoq3xNZuQBX9OTC0OxS9uGUaJgA0q3j+eHR8KlfOpMmDXYAIbH85Rnei5HPblUvp96KPn4Js9yCZFPlKY3LoQWS7SEr+Dz+/uXuYnveWEXzVWYSgEkke1rSpwugNfe8LrGAexo//MWXc8XSylnC6pmK8mpWM2kWs1eWP6+qP6ilfQungOyY8RgQCVM5G++2OFJJBPwXYepIJAsII4hJpFMd0VgkJpWmyH8wtqY9O7VqzRbcBbpbLlkXxHHYFme40uYhzoobeH8Tzuc/9AiJ9O1lyrWBCXQixvxFzV6EHc/f9Z3xo1/OG0vAP+wzIEgAoGIw1BM/dbZL5V3zkht6L044Ouhz/hZ3zjDcppj+IHtg0aLRPhb4yfuDCv1TL3MOJ+PEIW6Ddydnv+EfydD3z8dkZ2XS9emP7efWZmT9wGCoyzodkvSg/u5v0U5FsJZ1M1F6I1J7HXLfafQPoc4XP1hgSEPu9FVe/trA4EigQmxFFOg8dP5XuyU6+IejD10bXo6OnUzZIKnQ36tXwyvupJ5wOe6bLddhmaKZqS+DhfLU11ALXXPwP0BM/JOKgU6xcZrRub9pjRgXvuV1Ziv9E6/EmBesXTzwysTpfMEkhn50NH2v7vML9z89+hO7P2rSwYGgKRK13R/nXf3Sg9Ei4jdXveL5KeF2Ip8vrTd/N1LQZcOmZqAXJ/0832YXjkBPgttn55pwgNtLQKm161k1wkAlnmwyrSjuBlxqWG+pZ2tQ06CtoHQ6oh5iWQeTAjVcRuWXAbsAsgQ1Hon5jr8oNVZQec4hmJWEDUfrX0WzBbHEMxLJnBNmqGgs4MugIxB3Yz/PBvqN0NQc6rNnEahDMxZQec4hmC2uuYsJ0i/ymNiFUJcOGnxpObrb/d/EvPVEvKM5msWX6L5un91yUDz9dzGXxfqPf9S7I608YjF6CjBFzfEl3o3A3+dQaxyUXGf8rGEI1NtvjH7LAlXMwRSA/n2yb/a+DMTaWbm+YcSxjkLlw8MKOj88CmnFV92KIdql0tlGpTu5f0BxVLv5WoF8U3x9jlozaRoBpD38bgjnHZwmWt5CcuDCc6qofV41BHzvhXV1AiXK2Y1V3OKPfe9jqT5/udM+0pG98raAA4EkrBHorBfz2euSkPHAoRqRY5Zg0L4hpam1NMTQSQLKTm04gO9SOzXCBMxi5xmonlmaC9HjilxC69I3vm1Ufi2B0Mkp5aKymo4BR0HZinL3mncOqwOrqleA77rSR6H6QS3RvORKdfeI/RtLS3oc9Jly9QGoPJf/n8AAAD//y766bE="
+	return "eJztXeuP3DaS/35/hTCHRey9mbbH62Sz8+EAx97sDWDHhsfGLnA4tNkSu5s7alHRY9qTv/6qiqRESVTr0eqHgzQSJ+6WyF8VyWK9WLzy7vnjjZc+phnf/IfnZSIL+Y13cUdfXMA3AU/9RMSZkNGN99/wheepH700Y1meehueJcJPL71Q3HPv9YfPHosC+HYjk0cvT9mKX3rZmmUeS7jnyzDkfsYDb5nIDXzPPRnzhGUiWmkUM+gjXcskm/syWorVjZclOYcvEx5ylgK6FYO/LQUPg/SGAF15Edtwiwz8ZI8xPpvIPNbfOEjBzxf12hcAF2VMRKkXSp+FujVD30w/b/dr9+3LhBdfunrfgcBCcYXtWFCQnxqBt5SJx7wUWBVy6s+TS/hik4eZoPcsDppPlWnmUyfCJkQEla8NKaGMVrUfdlCDH4T+GlFF+WbBkxJV5cn/9D7wxOdA7oqnTkB5Ci/HfuaElcJI8WC+DCWrPwDs2rDsxotV+8PAf4KZGRfAkNFITiY2wOQYvvRERMDgb8znLbRVKMiEf59Ow1oExzYyBxj7AdPz5RyZe8+TiIdDqJiQwZ0cHoAuEvDI2XFYRsCV7VWcCJmI7NGLE+nzNOVpH2qOxumxKEUQniHPCVUP4MebyD0AyS0T2RnyMvIQmPcEpkgg0vun/eg4powYhi/59fyYDNvIg/BRNUOVbg1/hPiXNUuCLWpzIsp4kuRx1rkegbqjsX4y1KlcZt/SuCDecRSeemxGIM84C89vZGDZi+hBhkA9A/OHRMDikeycB5FkOZgV+MZ2LUCFx2/XgBdYksqk0dmWpRV+SXg+MVugTGaNF149MBGyBbQso/ARN8/Pkfjai5HHlIvny6DClovzvUw5eL9hTSJVaDGn+1lnaOZNOVDKNjMDRa0DB3mqtS8aAZlmM/WwjK4iXD+h+I3XzUTPWhmptxVhCOv7gaOByr6KTb7xHliY06L5cv38+Z+8P6vuvlDbjcbKfirtsjDhLIAJw+5xfohUtwqCQ3rM92naKdny0GzUgQWh/K5NU+991HQRpJeNZh9l7vksUoNms7xw3qyA9SCe8YtI8c37WSYe/8o2ccgvPbH0/tJoVk0pfB1a+QGGHaChQ4iryaXdHjNYMjPDzS9q9iy4d/1j6+D8vkzY35eR+O2aX78Xa+cb0lr/0MsdFP6h3U6j3WYyO1NGoi4IG6Mim3bUW5BPNHFu3/8TpVCbUvJLqRn10k9QkzpLFgx1U58tIUM3+vMkZK/d/jxJ6r/lnyn+Efv+eVIy+eb/TZE5VgM4TyK/VTXg3LjZRwu4NI6QFLZ7zeTSZ0PGtYP2msbwqeHd+1Yi0+cc0/02oqBnGEw86yDcqUMh43fEUyMfu8n9EXuweYLzVEjr9eHhB2zCij/gX2E3KdLIeubgmc/wGAX+6RzPe/64lUk9cKD9xzAdAnY9fLiJPOyya7LxRLBwrjbPAfB6Qvgu1T2YdDf4RaTehj3CDp6hMxsmx4MI1DbOwrBkeqNN7aPvIAgDITMKeEy4eEhTsjQM7ASnDI4QTpk093GGL/MwfOzAt4XNih8cIPUyEiFxcPGY9Y+oGVXQ9dII8NQMwajCxpjNWxHlX1WIS9S78mp6YArrWSa6JQr2xKHQMy3yWJrmG+QMPeWl4jfSQ7+/ftFrBE/PIMSR8WgaHpnGerKp0Wo322ha4b5zwGm/ESHaBCDkYXmq7U2LFVqxvQb2ZBDVmu1UFg8N0I0xkLgP3j573w0QrbcZjXbCf815ms02PFnxdA6SfQ6tOrG7LMwO8PVQPS1z3SUm4EOfFCX3FCUqYrvloIrBIzn8lklaDAF/EJ22jSZLTZHj0kV9HpqwyngddaBK9AJETR29ReeIATruyExLCY2IJmDHbjMBGT+V+22h+zYw13V395bWSRBD+2JaQtgDaIwrbts0eCakOsucIwJ/Aw0UDRYeDFn/RxwVNcUOOSyKpOONS23RTDQwZsWzh9UcdZTDkELazxOwmIm9T3GYEHVPCdCPEpLhB6aD+vBCHq2y9UGIOOYyn3YiKfcFn7dqWftPItWDIgQnk61uPSWiQM+adjwWefo4HTUf3J77IE9QSdyuhb+uktC+KT5ZsCjYigDmYZ6JUPzGsFtiQvnU05n3Rj2esixP1CPS93M0XFTOXJnyCFZvKFMa+moWo2EJIE9kbLNjsDOpdFvp45DNNoc7iJhpdL4Q2aSuvwItNoxD1oRbwjh9KKjEq3FeIjdh1B+4mT2xlGFhsr98/rcfGqO8FCGvnHwtKRniNSybaeQulz9NkcJcEH0knwI5CCmqY/Ebs4UjL4/iRDwAdWhnUGzK7HgzJ3S1SOcDHZyDnJh2Su2N9+UZdPkMf73+4kSE/R4ACrZRh8K/Zi/dIMjhPo/BcHYvqNFYqGGUtNR2gzduNDRbD+g2wPa9SMKbOFtwjdI3Tc+5BSnhJ53tu2c1optPzTWLX9D8GKYR34/ENTXGFu92cwxExXEdx9jhQHin391qoLvyFGz0ajpOHjwoJyVhGzEpLXBnwuCSiJn3i8xgQ45lQtE9EJYWPUq9brQayOg785LdlpN69DTMMX7pJHwBqgpn0UCqElRll3Xuo3ZL0p8H1OsV9noJw+SHeYA7gk1Zo9GEN9712BLPrvAkkUkLdXEi/839bP5rDtLASWFdn+pB32esgEKB8lBstFIKmse/fr7zaDPLXpp+Per3shxAzA/iMQMroHmSiT/ANNA2gaW6zSo0fEEu8gi3gGDmvVX9Y4TeMYSe1S8GS7KGv8Wh0tnca9QNKdnmWKw9OIcfmvOaPbdvmlF107kShrgehoLYJZgHgLRkc26lahnsbnndpABzKOY0UU5EBwJQM9V4Szp3nSYVmMNyUioQwN5UNOV7CX+HjLfJcMv5AYRU5X3WRRpOvUuUK/onHE1n26oBWOiRtFsUqWvl11TrUcusJ71OLduxoLogjl9Ho9eJC3oXzPELZfRCGAPzW1gJtfky5SoomJGi92MvJ4vepVVLlp/F8rCYNC22WiV8xYo8LQyca62m0mb56t5Hy8cq279U55TRN5fQYLDDttvD5vzksMlbtDrVlSPCsGuv6anu/cLTzKjgevgC6TfWU5fq5HAP7GRFF/oGzpZlRUwk87xmoNUBnkqxIoAk/jsAunwFx0OoNt8nBDQO85R4+rQpQEBG2mwcLD4w/oBtmADLngv+4vpiqAGLP4H1NV8yzJG6wbjDMHPorQW/iH2ELM28jYjyjLvX8MX354T0e421ReBcXJ8V2msHXDduOh9zqjnhmAug2RYJs/3OvTTJOdVQuCfMFBSdbHa1zKo9aaKH3BQdtqJNQzqrkrV7qXeqiUb8TBfDnSB2djSfOO1rCnd3cdPj+cI/4xbbC9YRQy4qeFAeOyGNSo954WxQxwFU5D6QMCfxVIByZxYPw7RRKciwlLQ66TN/jfVzoqb+tciXS56k3pOUFz5rzRpY5zkLZzU1pH34zsSV3WtgFW3j9PUmklfUWlmuGlCgrxY516XF79SWnSuiRHo4jVQTZPHTmoO35OlXwjDVziOcRDwC/XXBsy3XZZn0lKaUWzuQqEfIWbELP/UnAXHMMbdaS973dyqIu8FSVAEHIRmml6Azoxj0YKb794WNbM3hLy1Twmb6iWwozW73kr8lXwKsIT8PyZBfMBwWixfVMwxKOpjiV+/4psy+IRfAMzxf9gy6EdFSXjZ5gR9grtUhvWaDI/OkFCqFEBHLausIHCVUMaBN2ws/gP/93b885TRhHkivugA0c0hEMKCY12KmELz6TxEFcgsTQL3Pf20ubD2KspgW+vW+06JFvFGjJ/RfscYq7Tq9tmV1ydYu82CFL8VXUK7+l8j6v7p6VXWl4MyjVkq1BTUVkWbohqJ8pDKZDXFUivub2ewKmnZ7Pk5st5fE9J1KpxLrpPgMw3sqiVhmDA6CK/NsFjcqGfXAXMHkGyWMmiIIMYrcPOtGIKLDARBRd/8Yq2ZrOgyxNwzifdGgV22wBwLaIvaL8tQgUIve2k74/Nakdt5vERb5BPDanIy+cdqq2bIplbqQyAMlbLwCXkXze4Q9cmIZbjqPTjdQ63kPnUbKklFddwEMRIJxvaMDVP060iFKfGQMHA0Y9lY4Uxp5vQ3eYQmFI45u6V1RaIF5IRObviNNaI831O1oO4ddPTDny6XwBdhHj0eUR6pvg9YrMVjyaOa9wqowWHSilFGgTwufKgqVk4fCmVmSr1ZUpQP0ONNuXYjVWaCG8zQsUH0fnQVOOb7OV9w1WY+ugCMQPZNPrXv3W3/ujbUMF1sE6QRMPO5w7rr4O8tZBLBZiF5eHKKSnCks0w4C9tJtqueaKvOq9fiYDX4f22KaqVN6mkZPIvSD4XG50xJiUHiLPFMuF8d8GkhZmifo6DktYfIBJpncbMTgpRHwJcvDzJW00ZuGPdb3G9W9OnWFUa9B4HHjmtn2Zh24a8NoILOG3mXDWvS2SHkbUpumVWJqYWYDVh9ElpSAqbxg/v0kXb82hrXFGjowuslTqq+UxiEW9OJLuuYAINrwitpUPNvKxEY0PMqn27DCfPobu8pW5bJG8ztVRlvWMlmOV2CLZ+uBgV8KqNbB96m2hd6cYx6QqZcMwghH14Hh6KQIQQXlovuwtnKL+fd80oOytmFEbfdk2OGQJAWSnowBNOowx0HYoprWtQAVIljKPcbqWJhgvIJuRMCkIJEorA+CSESwwdP5TD125Ql+3W0Pjh0SILS/krsB2oF5DGKFW/bY3Cyfo631hiVbVPijwPvp7g1sNT4DfVlHryL77JV237TXVaztR/M032xYj+yTYrNY8MoZpR1cead3JHWyXNm/q1AuMIFWi3YKzYnM7n7nFcHx7M/O4ZILTM4fNmC3H5TPnNdWTWGm+lP29ul1R3d5MGV3n990dzcPsSzNtH2+xcozOzsW/mbSUXz9zkGpdbIOC/ftpXXpNiytS3+DKhcLGB6ds27LvrSvIa/d4Y2fCbUuFgpWlxgxy9YF3TPHqxuxUuU9ivvNmz1SdfABil6frBvNM2q6dqD+IsmjCATERcvxyJabwbvJb77Zh/p4jw5H9rga32Pz1T49+husnTzxGGN9TCyIuoEVcIXNK1cViH0Y9SQz56gQt32iNnPk9LBklW8oWcicRFXJaa5sfLGKwPKaswUY/jfei+cvf3RLvJQnI5aSuspm3Dryt2OHFXdHzJxVHvlqemjf3nn00F/Mqi/ne84A6FIkMsKRA80mEegnS9tngbrdEUWoq4wqsypXez8nnIPuc6nSlpSQfX/n/cstMqoXaZak7+0zB0F/lcbcF0vh287yuCzCPdQd3noVQgl7v7N4jrrkmS2Rd92RUAerLrQgpfVAaIsLMhGsijakAtMEafZoedHG6zrQ8wvl10rDl6dL1VgQpUWyex4HtFveZpahkIoNLItEJ0Y5u/0T9lIw0u4gEGkcssfSUshkbES2qQ3fLAPuZm7LtSbfFIepnIE7xbBqnlnXwjbOG5T5/shFsMoSFrU5Pikx8nmzdFqdxTvuISkZfBy54L6fpA5YzYlD4lW5wTuHdwc/UXq4Sg6W6AJXvYv+6BDT1lwva5hI1zVg17zlQGrj8EeJpzNPR8UDh+5HXftd1351ouBIOQPMnRnaxrLZvWapneCrsptrmeevKTTkvV6Djsm9J1bWebEeipaZUleMNccikDEJ9uKpAJMpd8M8bcIYJE+L66G111UdYhJp90xN4MdTRZiRyR95KgJcWncc/oXBntWkhYPvWCkyFiosvWH4h3rmycdX7552joifJwl2qJVePARPs+yyR8kM5Nb57UGDWdS+2mCanmq5Ud+Bi5g8bTuEunRbPCNOyPyM3mbzDKwepQsaj4rannGmELuVpxHdqOXJBcftOerMA+7S2p6YWjhK2JT22v4cxUhKHqTUfu89j6pKzFqqUExdjCRyVfmoQy+0J2eTFavQahtvh1/gMR1Uq4K6RoeXxEePtP92sQJrbRyIFXZRkKlZYbWNrKCqYsCPhJnL+RIpsxZD2LXwRi9J49G3qooVddNVT1Szn6oT077K0nt1QAdkCN5N1GhRv1XUHkl4GctoKFO476qG0rWIKQWq0WAkoytkh26ZGJjySgeqKpvtXCCxMGHdMbf/pAeD8YMjfvuGFAycSZIKsChqUrwrRvqC3GFbka3Vdopsdtswt2T9UWVorLbHTKvQviluZLdOrRHd5jCYs1W22BG0tVmEPq3DMQlbN8eD9DyqFzHWX6f5QtlT3+nifqqs6yCWUW/HYFrTd1XyrFcKTzvHoOmSF6AarXmQo4MObSpGVxwpPRXmU5H5pdeRs81X6h0jnyXWkg5DLdm2svDdFl0l6aX3+uc7EiAfP7kbxd/BksHDmwjGXLAFa3bJRFI2peUMCAeUF0A5DEozrZq4Q4UCtPZvzEdz6tQMY3FEcsvFap3NAJ8Fw9luwsFqULZoDVSKIV+Qeuyr2OQbt6Xt1EcLyc9rA0BM1ue0TRl45q1grkeoewq5K3uyX7aWU6Dhp2u9Eo/tGQjiRfud6rNnJ4AWcTEKgnsR4OfDGLHR2ppLnOwk0l+mMz1gzkTJktq+CWo7SKV+aCz0vb8b4SfS3DtFKYZyC+Sv8pAluCu2NqVYAvJTywngFM5lMONkDpYPqOJrmYMIRL2EF5mkA3hChUMPz5JPNVO/lTFqIQMlrU0VYpLZazTJI7M+ZcT12vSegOoSgGmh1L52LtuTo62CgvnY3CNT7dC8exVRLh66QJR3gxwk2v2E3s9yIREeW+C1NlopjW+UxgpbZ1ZcwHQWaOnYzsk410xR6rdJ1nyBGV9rkPC2NrqTvUl2xuu1WJft/G1Zr+SGGbpQgRcJVsYHU+scmEFufIm+9oy0D1DjZZ7qNdfaMN7OXDFRqot4zWD+tnCtJ5tUwR0F49BsKvPetaihdFlYLSkJncqCwUVRFTHtwg2XNrEClnS8+3BGk/Rsncgsw+rPx2YCzpW0bVQXqsyIxoa+X4YpdJet7ZpjEVsVwEbZbpLvYPI8agZ9XcM3aJLR7brLnXLJEnc4qysjpPwBoOPSXthX/Nc5Hh18Cy085uZOInVdEF60FLFIVu5Z0iutGI8OBcM1Tv1sJlixex590FaQufrDkbtlPn9o08XnxNq0jkQfR2ssjLfqRK+Uz0IRYNvPHfN90BpXqUjT0FrQYoMvgFMqyEYGbact3Ph0zPp4CJ+o0PTTIVAxUOP0sJQInQlS5lNJlNp/ZhVUNqRnQTaIVs5AiOKjtRm2Y/vGAlBdU2xnELrkRy/pqQ+narewyoH9Q4AeSIAOF5QwxDOKoLXGlku6dszqrsjiAMLtUo86uAezu839VV4JOpjgDft6PkRjNoH2Ctq1/6amXMW7zpHq0vWiNplq7Tqk1r5pp337xKo+T3WKRtNfTYEeS3OHvgZwbwk6en54f0o11qstl1rOiQr7PamN6VNMLGptN+FURKk/wen23GQDBoBVTT+THFIRFFRNkAoiVa6+aW1vyrVVMOtc5UojUwc34yazKkzZoUjsy6yzF0XGk6QnXJ1pNNi7/SRTCyDg2hmJoPpia7s3Rn2eNEadhNVAoXR/rvqKToY9mNpyf/56S50FnepLa6vDOXP2wgRWUZU/uwREu5dwjOC4P0/VpT5uU+ou2PYcD+qeo6ioyAjcZPAMcFH3uJHZOoTQcxUNtkyoU+yQER3OsXHSk9j0LcgJzaw6nxoCo4tLozWNglvnKTTqA9kerBquXyiHpaoMPmeRdJdk6c2ACefKKwDzuMEwZqGBqgq2MvJ0JXM8QJ5dYXGQKAsfr2i1PXn78XM7g0KRZpUjt5t4iRc4rIHNT11p9v2Zh1b6kZmHmeFXWN+pTE4vmQN8KMgdQRXx+sj0fMANgjqeeozWgics8dfCZ+FcsWp+XqLRdhsXlpiBrbWnovCCJSeU7GuP3E7CrnR7ntwqLbLefGttssrPcXwzdyx8O5K0uBXCFheVlddu4NZX5ChOnUBstnPKLVCdPBoxOzZUtO+8KMZzbaUOdqUgevo/dKlnuyieVuZQsXaq+3j0JBmUEaw4XVG1TrNErFYcj38xqnnY2ipBHzgf/i2T+TdANwHtINy7eIdPXai/4hmVGI9oFWdXtDNA3bsSPtIZlkzuMn/VtTVUFIMO1+A124NnVDpvdbscIPGMamLin5R9Jq3bmoQ6lGcqNI2go63W56EJgX5LAbEvKbvO9PYi5Rjbog694QpJWJTGjOIuRRFy2PEi2e73nVZxBYbNseez4dovtSqaWAm6YKSTX8NSZ7B477nQeleEPUaOXh7xB+FndH3XuRD1znLH4hUcMlNnFfTFDL0oNVQuwnvhkuED0mV+CqVv1+39I0tm6iyZEUkyKpvwXGZs/a55JXhI1ix5kihvn7o0EtQETAtb0KQKcPG1du7tCNYMYpOQx8m6LBlw++y9qWwqI0rzR26rDDkkfzzhlIfNy6P1OveYCnrIUPjWTR210pIzRwFVkWFVww9av7zrWWF1B1N0E5Ua39bBaCzqrMuPOC8aPviFv12V4WrjWMImuKBV1fFaJ04UYRMhsW4/qNZr6YVFBI2EpP2BYKODUKQh5/EhWGIaHoYmm7KIsgVGtTsIy29ysxDTj5BqdhCSAMy4yXEEdKGeG4V3m32Xeg8cg29RKO7xuLQ63ZKpU+lolrKE7voAyZfKjT5Lh7dRCrBNlUiFpzfsURuxbtLy6D6S27pxuT91JWHWsZG1uoCOSgqHAZ7HJ50NpCDHm0vgQWCARtQU0QmraEeDxW7t/YPfl9DFK2jNqGhqq2tbkixrnM7bo19TpPtKDUUPBCGMjnvzGF1ZFMdCtVsF4ObAY+TPEbZ0z9NRKF7rRERs3FONX+L1xYjl4yvQnlmSwNqhc5VBHgUMnnULB5Hem/DZRMvIvp9I+WxVJzv6P+QGTz1Yg0RnGUSaodm8CxPZ0NOzhJoNulmCYfDJtjKrf9Vud/+0vtIhxdH3r9qLPm/Sh9iWUCh56y7dTubFtDPHcqtQ4/akWUsQrGSXXz9/8fIKzR8DYRc8XJ8HUEg0Pq1ga4jKlZzQiTDstwNtIZ94UpNdk126YJsIps6L6s0e00NtWpThYW1TTUKtTUKyYL5XpflPdPwblKLKxrSrz727M3vhgC7zxf5UQiNXw4icU6FbZ5+OOqeNDilSkrFNbDqkarlaF6M6bDNdKclAIee42nvQJ6Htq0ulo+I/IP3zuFmlrahW/pX7c18Ge/Hp7vYfr//nLRbPCnhZmkwjxGNTWHixeSmEpTKak/5OFJ0l0/SKK6qNNap1uUeuu9gYntxXqX/O05X9K9iVVbWr5w1be9YBkN0Jlv37ryRFNiLo9c4pDW5GEZe9erWyziixrv/ANG7MacXRy+1rLr4x86z1dEEv3++xcy2UB7Ilsmihct/WdThc5pKlDmTtV5r1hubstus6wrY76cb1quNPVp9N/UIisH1MX0xIVa2kpZKjxPt+fkV1oUWbYdZ+MYZeOLO299suxHCCWLKNaNSK64sAn9unc6y4E86Euyhn4+vikpzrv72YPZ+9mF2j1+PF8+fXN8/f/PTjzauf/v7m5sfv//LDzc31MNX2LeLAW7BYECS61qgoivnBn7cfHl5iZ/DfH4qH+tCG9fj66t8FfS9ejIGPXXVgSmDHyfgZMPwjAZmY45q6o7BcE9Cf5+iaHKDOFcD++sPVi+vrq+vrv1795YdZtJ3pX0ATaNw23oH5w6ePGOSFzpybfmLGBD2oqKbKBaZMwiMPAsuZPfAkbW7PMIShlPetAbMaG3gWBnPM2ZzLQVculfenjiUfdWK+XHJfB0rjK+VCCyRpwk/4p7dvnhoVX/MCB01lmGL9OtDWGvSHbMHDyh1el9QAtvZf12R6XiylnC1YMlvJEKyFmUxWswvk74X9RSPo/anIfII2Ap7xZCMic+cLNo/lQLmuOgwLBosBBwGMli/jx8IxyLJGmSF6YZ1l8c2zZ3G+CEE7z5dL8ZVw9J7Lc7oJc6yB0pycf8fm9EMLQ6YqL1WMCc1APd08fVCjA3H79Wdde9zwi9N2AtAXy4wE4XBEjEMx9V1nP1v3nHmVpnfiAAt3JCfQNs4pnWYfflD5oMFTwv3W8I5b3UodXeOlcvMBU6GqA7eH5+/od6/v/acDovN4/SvmgBr9WZQxee0g2EuDbpYkHV3P/RXN4yhSGnV9EDp9EjvNcl0ptMsgdmd/IDDiYTs6u7Y/8NSRJDAhlqILUn5a74udclzUhbFjx6ajolM7Qya4OuRd9WC4bUoah89lWW67dM0UxUh1Hi6lpyqHWqyugcO7aF7LBNTimAqvZdLUm8KLQKDxZygxn8FLz8Baeybih5fPYFjwKA9eQuMu+9+e5ucu/rt3Jfbu0aWB6OEAkkm8ZrvzvNtHuidaQqzWuh4k3S3mn/mxGdp2/u6koE2GTE2AkSfdfO8nVw6AD6HtkjN1eOifB0U2XTeCXQcAWMbBrG4HcdMPZcrnW9ZaOuQgaGsIUUbMSyRzZ0CoihsjFmcBuwDSB3X6GM1T94VWRwVtcPTFDKZ6/dLak2BGHH0wL0VEY1J3BR0ddAFkCOq6/+dkqF/0QY0hyDnzXRGYo4I2OPpgRllzlB2kW+ThddIOxIWRFkyqvn5+8ztRX5GQE6qvMCxnqL7uHl0aiB7q67GVvzbUO/6nWB1x7RKLwV6CL6qJL9VqBvo4A156qaaKekr7EvYMtenC7LONO5vBERowy8e8WvtZRHGezc1DGxGCHuhMH+iR0Pn+ztBKNzuUTTXTpdAPlHbyfkSy1Fu5WvHgqrj7nKcpPFV3IO/icYs7bXSaa3kIS4Nx9pryxuVRe/T7KrJDI6FcCZRc9S52nPfak+Y3P2GsgTIZ1S1rPTjgCMLuiQJfNz3bs6FlAFy5IvuMQTH5+qamVMMTTiQLKWFJ1BdxJxJ8DRZlIHwlmZiJDO3kyD4pbu4RMZVfa4lvOzD4cupZYY2GEtCBo5cy7Z0Fjc1qdE41Rn/wIssP/WSCGqP5wJBr5xb6qhIW1DHpsmRqDVD5P/8Plu6vDA=="
 }
@@ -20,6 +20,7 @@
 package filesystem
 
 import (
+	"runtime"
 	"strings"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
@@ -56,6 +57,9 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	if len(config.IgnoreTypes) > 0 {
 		logp.Info("Ignoring filesystem types: %s", strings.Join(config.IgnoreTypes, ", "))
 	}
+	if config.ProjectQuota && runtime.GOOS != "linux" {
+		return nil, errors.New("filesystem.project_quota is only supported on Linux")
+	}
 
 	return &MetricSet{
 		BaseMetricSet: base,
@@ -90,6 +94,22 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		if !r.Event(event) {
 			return nil
 		}
+
+		if m.config.ProjectQuota && supportsProjectQuota(fsStat.SysTypeName) {
+			quotas, err := GetProjectQuotas(fsStat.DevName)
+			if err != nil {
+				debugf("error getting project quotas for '%s': %v", fs.DirName, err)
+				continue
+			}
+			for _, quota := range quotas {
+				event := mb.Event{
+					MetricSetFields: GetProjectQuotaEvent(fsStat, quota),
+				}
+				if !r.Event(event) {
+					return nil
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -36,7 +36,8 @@ import (
 
 // Config stores the metricset-local config
 type Config struct {
-	IgnoreTypes []string `config:"filesystem.ignore_types"`
+	IgnoreTypes  []string `config:"filesystem.ignore_types"`
+	ProjectQuota bool     `config:"filesystem.project_quota"`
 }
 
 // FSStat contains filesystem metrics
@@ -46,7 +47,22 @@ type FSStat struct {
 	Mount       string  `json:"mount_point"`
 	UsedPercent float64 `json:"used_p"`
 	SysTypeName string  `json:"type"`
+	ReadOnly    bool    `json:"read_only"`
 	ctime       time.Time
+
+	UsedFilesPercent float64 `json:"used_files_p"`
+}
+
+// ProjectQuota contains the usage and limits of an XFS or ext4 project quota.
+// Limits of zero mean no limit is set.
+type ProjectQuota struct {
+	ID             uint32
+	Bytes          uint64
+	BytesSoftLimit uint64
+	BytesHardLimit uint64
+	Files          uint64
+	FilesSoftLimit uint64
+	FilesHardLimit uint64
 }
 
 // GetFileSystemList retreves overall filesystem stats
@@ -128,6 +144,7 @@ func GetFileSystemStat(fs sigar.FileSystem) (*FSStat, error) {
 		DevName:         fs.DevName,
 		Mount:           fs.DirName,
 		SysTypeName:     t,
+		ReadOnly:        isReadOnly(fs.Options),
 	}
 
 	return &filesystem, nil
@@ -141,19 +158,44 @@ func AddFileSystemUsedPercentage(f *FSStat) {
 
 	perc := float64(f.Used) / float64(f.Used+f.Avail)
 	f.UsedPercent = common.Round(perc, common.DefaultDecimalPlacesCount)
+
+	if f.Files > 0 && f.Files >= f.FreeFiles {
+		perc = float64(f.Files-f.FreeFiles) / float64(f.Files)
+		f.UsedFilesPercent = common.Round(perc, common.DefaultDecimalPlacesCount)
+	}
+}
+
+// isReadOnly returns true if the mount options contain `ro`, this is also the
+// case for filesystems remounted read-only after errors.
+func isReadOnly(options string) bool {
+	for _, opt := range strings.Split(options, ",") {
+		if opt == "ro" {
+			return true
+		}
+	}
+	return false
 }
 
 // GetFilesystemEvent turns a stat struct into a MapStr
 func GetFilesystemEvent(fsStat *FSStat) common.MapStr {
+	// Some filesystems, like btrfs, don't report file nodes
+	usedFiles := common.MapStr{"count": uint64(0)}
+	if fsStat.Files > 0 {
+		usedFiles["count"] = fsStat.Files - fsStat.FreeFiles
+		usedFiles["pct"] = fsStat.UsedFilesPercent
+	}
+
 	return common.MapStr{
 		"type":        fsStat.SysTypeName,
 		"device_name": fsStat.DevName,
 		"mount_point": fsStat.Mount,
+		"read_only":   fsStat.ReadOnly,
 		"total":       fsStat.Total,
 		"free":        fsStat.Free,
 		"available":   fsStat.Avail,
 		"files":       fsStat.Files,
 		"free_files":  fsStat.FreeFiles,
+		"used_files":  usedFiles,
 		"used": common.MapStr{
 			"pct":   fsStat.UsedPercent,
 			"bytes": fsStat.Used,
@@ -161,6 +203,50 @@ func GetFilesystemEvent(fsStat *FSStat) common.MapStr {
 	}
 }
 
+// GetProjectQuotaEvent turns a project quota of a filesystem into a MapStr.
+// The usage percentages are relative to the hard limit, or to the soft limit
+// if no hard limit is set.
+func GetProjectQuotaEvent(fsStat *FSStat, quota ProjectQuota) common.MapStr {
+	bytes := common.MapStr{"used": quota.Bytes}
+	addQuotaLimits(bytes, quota.Bytes, quota.BytesSoftLimit, quota.BytesHardLimit)
+	files := common.MapStr{"used": quota.Files}
+	addQuotaLimits(files, quota.Files, quota.FilesSoftLimit, quota.FilesHardLimit)
+
+	return common.MapStr{
+		"type":        fsStat.SysTypeName,
+		"device_name": fsStat.DevName,
+		"mount_point": fsStat.Mount,
+		"project_quota": common.MapStr{
+			"id":    quota.ID,
+			"bytes": bytes,
+			"files": files,
+		},
+	}
+}
+
+func addQuotaLimits(fields common.MapStr, used, soft, hard uint64) {
+	if soft > 0 {
+		fields["soft_limit"] = soft
+	}
+	if hard > 0 {
+		fields["hard_limit"] = hard
+	}
+
+	limit := hard
+	if limit == 0 {
+		limit = soft
+	}
+	if limit > 0 {
+		fields["pct"] = common.Round(float64(used)/float64(limit), common.DefaultDecimalPlacesCount)
+	}
+}
+
+// supportsProjectQuota returns true for the filesystem types with project
+// quotas.
+func supportsProjectQuota(fsType string) bool {
+	return fsType == "xfs" || fsType == "ext4"
+}
+
 // Predicate is a function predicate for use with filesystems. It returns true
 // if the argument matches the predicate.
 type Predicate func(*sigar.FileSystem) bool
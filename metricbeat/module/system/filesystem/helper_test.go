@@ -171,3 +171,69 @@ func TestFilter(t *testing.T) {
 		assert.Equal(t, "ext4", out[0].SysTypeName)
 	}
 }
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, isReadOnly("ro,relatime"))
+	assert.True(t, isReadOnly("rw,errors=remount-ro,ro"))
+	assert.False(t, isReadOnly("rw,relatime,errors=remount-ro"))
+	assert.False(t, isReadOnly(""))
+}
+
+func TestGetFilesystemEventUsedFiles(t *testing.T) {
+	fsStat := &FSStat{
+		FileSystemUsage: sigar.FileSystemUsage{Files: 1000, FreeFiles: 250, Total: 100, Used: 50, Avail: 50},
+		ReadOnly:        true,
+	}
+	AddFileSystemUsedPercentage(fsStat)
+
+	event := GetFilesystemEvent(fsStat)
+	assert.Equal(t, true, event["read_only"])
+	count, _ := event.GetValue("used_files.count")
+	assert.Equal(t, uint64(750), count)
+	pct, _ := event.GetValue("used_files.pct")
+	assert.Equal(t, 0.75, pct)
+
+	// Filesystems without file nodes don't report a percentage
+	event = GetFilesystemEvent(&FSStat{})
+	count, _ = event.GetValue("used_files.count")
+	assert.Equal(t, uint64(0), count)
+	_, err := event.GetValue("used_files.pct")
+	assert.Error(t, err)
+}
+
+func TestGetProjectQuotaEvent(t *testing.T) {
+	fsStat := &FSStat{DevName: "/dev/sdb1", Mount: "/data", SysTypeName: "xfs"}
+
+	event := GetProjectQuotaEvent(fsStat, ProjectQuota{
+		ID:             42,
+		Bytes:          512,
+		BytesSoftLimit: 1024,
+		BytesHardLimit: 2048,
+		Files:          10,
+		FilesSoftLimit: 40,
+	})
+
+	assert.Equal(t, "/data", event["mount_point"])
+	for field, expected := range map[string]interface{}{
+		"project_quota.id":               uint32(42),
+		"project_quota.bytes.used":       uint64(512),
+		"project_quota.bytes.soft_limit": uint64(1024),
+		"project_quota.bytes.hard_limit": uint64(2048),
+		"project_quota.bytes.pct":        0.25,
+		"project_quota.files.used":       uint64(10),
+		"project_quota.files.soft_limit": uint64(40),
+		"project_quota.files.pct":        0.25,
+	} {
+		value, err := event.GetValue(field)
+		if assert.NoError(t, err, field) {
+			assert.Equal(t, expected, value, field)
+		}
+	}
+	_, err := event.GetValue("project_quota.files.hard_limit")
+	assert.Error(t, err)
+
+	// Usage without limits has no percentage
+	event = GetProjectQuotaEvent(fsStat, ProjectQuota{Bytes: 512})
+	_, err = event.GetValue("project_quota.bytes.pct")
+	assert.Error(t, err)
+}
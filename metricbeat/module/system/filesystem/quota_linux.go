@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package filesystem
+
+import (
+	"path/filepath"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/beats/v7/metricbeat/module/system"
+)
+
+// Constants of the quotactl syscall, from linux/quota.h.
+const (
+	qGetNextQuota = 0x800009 // Q_GETNEXTQUOTA, available since Linux 4.6
+	prjQuota      = 2        // PRJQUOTA
+	qifBlockSize  = 1024     // QIF_DQBLKSIZE, unit of the block limits
+	subCmdShift   = 8        // SUBCMDSHIFT
+)
+
+// ifNextDqblk is struct if_nextdqblk.
+type ifNextDqblk struct {
+	BHardLimit uint64
+	BSoftLimit uint64
+	CurSpace   uint64
+	IHardLimit uint64
+	ISoftLimit uint64
+	CurInodes  uint64
+	BTime      uint64
+	ITime      uint64
+	Valid      uint32
+	ID         uint32
+}
+
+// GetProjectQuotas returns the project quotas of the filesystem mounted from
+// the given device. No quotas are returned if project quotas are not enabled.
+func GetProjectQuotas(device string) ([]ProjectQuota, error) {
+	special, err := unix.BytePtrFromString(filepath.Join(*system.HostFS, device))
+	if err != nil {
+		return nil, err
+	}
+
+	var quotas []ProjectQuota
+	cmd := uintptr(qGetNextQuota<<subCmdShift | prjQuota)
+	for id := uint32(0); ; {
+		var dq ifNextDqblk
+		_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, cmd, uintptr(unsafe.Pointer(special)),
+			uintptr(id), uintptr(unsafe.Pointer(&dq)), 0, 0)
+		switch errno {
+		case 0:
+		case unix.ENOENT:
+			// No more quotas
+			return quotas, nil
+		case unix.ESRCH:
+			// Project quotas are not enabled
+			return nil, nil
+		default:
+			return nil, errors.Wrapf(errno, "error getting project quota %d of '%s'", id, device)
+		}
+
+		quotas = append(quotas, ProjectQuota{
+			ID:             dq.ID,
+			Bytes:          dq.CurSpace,
+			BytesSoftLimit: dq.BSoftLimit * qifBlockSize,
+			BytesHardLimit: dq.BHardLimit * qifBlockSize,
+			Files:          dq.CurInodes,
+			FilesSoftLimit: dq.ISoftLimit,
+			FilesHardLimit: dq.IHardLimit,
+		})
+
+		if dq.ID == ^uint32(0) {
+			return quotas, nil
+		}
+		id = dq.ID + 1
+	}
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// chronyLeapStatus maps the leap status of chronyc to the reported values.
+var chronyLeapStatus = map[string]string{
+	"Normal":           "normal",
+	"Insert second":    "insert_second",
+	"Delete second":    "delete_second",
+	"Not synchronised": "not_synchronized",
+}
+
+// parseChronyTracking parses the CSV output of `chronyc -c tracking`.
+func parseChronyTracking(out []byte) (common.MapStr, error) {
+	records, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse chronyc output")
+	}
+	if len(records) != 1 || len(records[0]) < 14 {
+		return nil, errors.Errorf("unexpected chronyc output: %q", out)
+	}
+	record := records[0]
+
+	var values [5]float64
+	// stratum, system time, frequency, root delay and root dispersion
+	for i, column := range []int{2, 4, 7, 10, 11} {
+		if values[i], err = strconv.ParseFloat(record[column], 64); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse chronyc output")
+		}
+	}
+	stratum, offset, frequency, rootDelay, rootDispersion := values[0], values[1], values[2], values[3], values[4]
+
+	leap, found := chronyLeapStatus[record[13]]
+	if !found {
+		leap = strings.ToLower(record[13])
+	}
+
+	return common.MapStr{
+		"synchronized": leap != "not_synchronized",
+		// chrony reports the correction of the system clock, which is positive
+		// if the clock is slow
+		"offset":    common.MapStr{"sec": offset},
+		"stratum":   int64(stratum),
+		"frequency": common.MapStr{"ppm": frequency},
+		"root": common.MapStr{
+			"delay":      common.MapStr{"sec": rootDelay},
+			"dispersion": common.MapStr{"sec": rootDispersion},
+		},
+		"reference": common.MapStr{
+			"id":   record[0],
+			"name": record[1],
+		},
+		"leap": leap,
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Status bits of the NTP kernel API, see adjtimex(2)
+const (
+	staUnsync = 0x0040
+	staNano   = 0x2000
+)
+
+// timeError is returned by adjtimex if the clock is not synchronized.
+const timeError = 5
+
+// kernelStatus reads the synchronization status with adjtimex.
+func kernelStatus() (common.MapStr, error) {
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The offset is in microseconds, or nanoseconds with STA_NANO. The
+	// kernel reports the offset of the system clock, which is negative if
+	// the clock is slow.
+	offset := float64(tx.Offset) / 1e6
+	if tx.Status&staNano != 0 {
+		offset = float64(tx.Offset) / 1e9
+	}
+
+	return common.MapStr{
+		"synchronized": state != timeError && tx.Status&staUnsync == 0,
+		"offset":       common.MapStr{"sec": -offset},
+		// the frequency is in ppm with a 16 bit fraction
+		"frequency": common.MapStr{"ppm": float64(tx.Freq) / 65536},
+		"root": common.MapStr{
+			"dispersion": common.MapStr{"sec": float64(tx.Maxerror) / 1e6},
+		},
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// ntpLeapIndicator maps the leap indicator bits to the reported values.
+var ntpLeapIndicator = map[string]string{
+	"00": "normal",
+	"01": "insert_second",
+	"10": "delete_second",
+	"11": "not_synchronized",
+}
+
+// parseNTPQVariables parses the system variables printed by `ntpq -c rv`,
+// a comma separated list of name=value pairs which may span multiple lines.
+func parseNTPQVariables(out []byte) (common.MapStr, error) {
+	vars := map[string]string{}
+	for _, pair := range strings.Split(strings.Replace(string(out), "\n", ",", -1), ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		vars[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	// ntpq reports times in milliseconds
+	var values [5]float64
+	for i, name := range []string{"stratum", "offset", "frequency", "rootdelay", "rootdisp"} {
+		value, found := vars[name]
+		if !found {
+			return nil, errors.Errorf("ntpq output is missing %v", name)
+		}
+		var err error
+		if values[i], err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %v in ntpq output", name)
+		}
+	}
+	stratum, offset, frequency, rootDelay, rootDispersion := values[0], values[1], values[2], values[3], values[4]
+
+	leap, found := ntpLeapIndicator[vars["leap"]]
+	if !found {
+		leap = vars["leap"]
+	}
+
+	fields := common.MapStr{
+		// stratum 16 means unsynchronized
+		"synchronized": leap != "not_synchronized" && stratum < 16,
+		"offset":       common.MapStr{"sec": offset / 1000},
+		"stratum":      int64(stratum),
+		"frequency":    common.MapStr{"ppm": frequency},
+		"root": common.MapStr{
+			"delay":      common.MapStr{"sec": rootDelay / 1000},
+			"dispersion": common.MapStr{"sec": rootDispersion / 1000},
+		},
+		"leap": leap,
+	}
+	if refid := vars["refid"]; refid != "" {
+		fields["reference"] = common.MapStr{"id": refid}
+	}
+	return fields, nil
+}
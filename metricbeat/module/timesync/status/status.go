@@ -0,0 +1,147 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("timesync", "status", New,
+		mb.DefaultMetricSet(),
+	)
+}
+
+const (
+	sourceAuto   = "auto"
+	sourceChrony = "chrony"
+	sourceNTPD   = "ntpd"
+	sourceKernel = "kernel"
+)
+
+type config struct {
+	Source      string `config:"timesync.source"`
+	ChronycPath string `config:"timesync.chronyc_path"`
+	NTPQPath    string `config:"timesync.ntpq_path"`
+}
+
+var defaultConfig = config{
+	Source:      sourceAuto,
+	ChronycPath: "chronyc",
+	NTPQPath:    "ntpq",
+}
+
+// Validate checks the source is known.
+func (c *config) Validate() error {
+	switch c.Source {
+	case sourceAuto, sourceChrony, sourceNTPD, sourceKernel:
+		return nil
+	}
+	return errors.Errorf("unknown timesync.source '%v', expecting one of auto, chrony, ntpd or kernel", c.Source)
+}
+
+// MetricSet reports the time synchronization status of the host.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config  config
+	timeout time.Duration
+
+	// run executes a command and returns its output, it is replaced in tests
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+	// kernel reads the status of the NTP kernel API, it is replaced in tests
+	kernel func() (common.MapStr, error)
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The timesync status metricset is beta.")
+
+	config := defaultConfig
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+		timeout:       base.Module().Config().Timeout,
+		run:           runCommand,
+		kernel:        kernelStatus,
+	}, nil
+}
+
+// Fetch reads the synchronization status from the configured source. With
+// the auto source, chrony, ntpd and the kernel are tried in this order.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	sources := []string{m.config.Source}
+	if m.config.Source == sourceAuto {
+		sources = []string{sourceChrony, sourceNTPD, sourceKernel}
+	}
+
+	var err error
+	for _, source := range sources {
+		var fields common.MapStr
+		fields, err = m.status(source)
+		if err != nil {
+			m.Logger().Debugf("Failed to read the %v time synchronization status: %v", source, err)
+			continue
+		}
+		fields["source"] = source
+		r.Event(mb.Event{MetricSetFields: fields})
+		return nil
+	}
+	return errors.Wrap(err, "failed to read the time synchronization status")
+}
+
+func (m *MetricSet) status(source string) (common.MapStr, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	switch source {
+	case sourceChrony:
+		out, err := m.run(ctx, m.config.ChronycPath, "-c", "-n", "tracking")
+		if err != nil {
+			return nil, err
+		}
+		return parseChronyTracking(out)
+	case sourceNTPD:
+		out, err := m.run(ctx, m.config.NTPQPath, "-n", "-c", "rv 0 leap,stratum,refid,offset,frequency,rootdelay,rootdisp")
+		if err != nil {
+			return nil, err
+		}
+		return parseNTPQVariables(out)
+	default:
+		return m.kernel()
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return nil, errors.Errorf("%v failed: %s", name, exitErr.Stderr)
+	}
+	return out, err
+}
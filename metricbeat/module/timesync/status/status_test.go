@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestParseChronyTracking(t *testing.T) {
+	out, err := ioutil.ReadFile("_meta/testdata/chronyc_tracking.csv")
+	require.NoError(t, err)
+
+	fields, err := parseChronyTracking(out)
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"synchronized": true,
+		"offset":       common.MapStr{"sec": -0.000012345},
+		"stratum":      int64(3),
+		"frequency":    common.MapStr{"ppm": -12.345},
+		"root": common.MapStr{
+			"delay":      common.MapStr{"sec": 0.001234567},
+			"dispersion": common.MapStr{"sec": 0.000456789},
+		},
+		"reference": common.MapStr{"id": "C0A80101", "name": "192.168.1.1"},
+		"leap":      "normal",
+	}, fields)
+
+	_, err = parseChronyTracking([]byte("506 Cannot talk to daemon\n"))
+	assert.Error(t, err)
+}
+
+func TestParseNTPQVariables(t *testing.T) {
+	out, err := ioutil.ReadFile("_meta/testdata/ntpq_rv.txt")
+	require.NoError(t, err)
+
+	fields, err := parseNTPQVariables(out)
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{
+		"synchronized": true,
+		"offset":       common.MapStr{"sec": -0.0005},
+		"stratum":      int64(2),
+		"frequency":    common.MapStr{"ppm": -12.345},
+		"root": common.MapStr{
+			"delay":      common.MapStr{"sec": 0.0125},
+			"dispersion": common.MapStr{"sec": 0.02375},
+		},
+		"reference": common.MapStr{"id": "192.168.1.1"},
+		"leap":      "normal",
+	}, fields)
+
+	fields, err = parseNTPQVariables([]byte("leap=11, stratum=16, refid=INIT, offset=0.000, frequency=0.000, rootdelay=0.000, rootdisp=0.000"))
+	require.NoError(t, err)
+	assert.Equal(t, false, fields["synchronized"])
+
+	_, err = parseNTPQVariables([]byte("leap=00, stratum=2"))
+	assert.Error(t, err)
+}
+
+func TestFetchAuto(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("auto"))
+	m := f.(*MetricSet)
+	m.run = func(_ context.Context, name string, _ ...string) ([]byte, error) {
+		if name == "chronyc" {
+			return nil, exec.ErrNotFound
+		}
+		return ioutil.ReadFile("_meta/testdata/ntpq_rv.txt")
+	}
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assert.Equal(t, "ntpd", events[0].MetricSetFields["source"])
+}
+
+func TestFetchNoSource(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("auto"))
+	m := f.(*MetricSet)
+	m.run = func(context.Context, string, ...string) ([]byte, error) { return nil, exec.ErrNotFound }
+	m.kernel = func() (common.MapStr, error) { return nil, errors.New("not supported") }
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Empty(t, events)
+	assert.Len(t, errs, 1)
+}
+
+func getConfig(source string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":          "timesync",
+		"metricsets":      []string{"status"},
+		"timesync.source": source,
+	}
+}
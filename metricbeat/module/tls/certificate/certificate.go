@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("tls", "certificate", New,
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet reports the certificates served by the configured host and the
+// endpoints of the configured networks.
+type MetricSet struct {
+	mb.BaseMetricSet
+	targets     []target
+	concurrency int
+	rootCAs     *x509.CertPool
+	timeout     time.Duration
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The tls certificate metricset is beta.")
+
+	config := defaultConfig
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	targets, err := makeTargets(base.Host(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	var rootCAs *x509.CertPool
+	if config.TLS != nil {
+		tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load certificate authorities")
+		}
+		if tlsConfig != nil {
+			rootCAs = tlsConfig.RootCAs
+		}
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		targets:       targets,
+		concurrency:   config.Concurrency,
+		rootCAs:       rootCAs,
+		timeout:       base.Module().Config().Timeout,
+	}, nil
+}
+
+type scanResult struct {
+	target target
+	fields common.MapStr
+	err    error
+}
+
+// Fetch scans the endpoints in parallel and reports an event per served
+// certificate.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	targets := make(chan target)
+	results := make(chan scanResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < m.concurrency && i < len(m.targets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range targets {
+				fields, err := m.scan(t, time.Now())
+				results <- scanResult{t, fields, err}
+			}
+		}()
+	}
+	go func() {
+		defer close(targets)
+		for _, t := range m.targets {
+			targets <- t
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if result.err != nil {
+			if result.target.required {
+				r.Error(errors.Wrapf(result.err, "failed to scan %v", result.target.address))
+			} else {
+				m.Logger().Debugf("Failed to scan %v: %v", result.target.address, result.err)
+			}
+			continue
+		}
+		r.Event(mb.Event{MetricSetFields: result.fields})
+	}
+	return nil
+}
+
+// scan connects to the endpoint and returns the fields of the certificate it
+// serves.
+func (m *MetricSet) scan(t target, now time.Time) (common.MapStr, error) {
+	dialer := &net.Dialer{Timeout: m.timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", t.address, &tls.Config{
+		ServerName: t.serverName,
+		// The certificate is verified below, to report untrusted
+		// certificates instead of failing the handshake
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	chain := conn.ConnectionState().PeerCertificates
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate served")
+	}
+	return certificateFields(t, chain, m.rootCAs, now), nil
+}
+
+func certificateFields(t target, chain []*x509.Certificate, rootCAs *x509.CertPool, now time.Time) common.MapStr {
+	leaf := chain[0]
+
+	var names []string
+	names = append(names, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		names = append(names, ip.String())
+	}
+
+	chainNotAfter := leaf.NotAfter
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+		if cert.NotAfter.Before(chainNotAfter) {
+			chainNotAfter = cert.NotAfter
+		}
+	}
+
+	fingerprint := sha256.Sum256(leaf.Raw)
+	expiresIn := leaf.NotAfter.Sub(now)
+	fields := common.MapStr{
+		"endpoint": t.address,
+		"subject": common.MapStr{
+			"common_name":        leaf.Subject.CommonName,
+			"distinguished_name": leaf.Subject.String(),
+		},
+		"issuer": common.MapStr{
+			"common_name":        leaf.Issuer.CommonName,
+			"distinguished_name": leaf.Issuer.String(),
+		},
+		"serial_number": leaf.SerialNumber.Text(16),
+		"sha256":        hex.EncodeToString(fingerprint[:]),
+		"not_before":    common.Time(leaf.NotBefore),
+		"not_after":     common.Time(leaf.NotAfter),
+		"expires_in": common.MapStr{
+			"sec":  int64(expiresIn / time.Second),
+			"days": int64(expiresIn / (24 * time.Hour)),
+		},
+		"chain": common.MapStr{
+			"length":    len(chain),
+			"not_after": common.Time(chainNotAfter),
+		},
+	}
+	if len(names) > 0 {
+		fields["alternative_names"] = names
+	}
+	if t.serverName != "" {
+		fields["server_name"] = t.serverName
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       t.serverName,
+		Roots:         rootCAs,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	fields["trusted"] = err == nil
+	if err != nil {
+		fields["trust_error"] = err.Error()
+	}
+
+	return fields
+}
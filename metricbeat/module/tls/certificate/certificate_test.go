@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+func TestMakeTargets(t *testing.T) {
+	c := defaultConfig
+	c.Ports = []uint16{443, 8443}
+	c.Networks = []string{"10.0.0.4/31"}
+	c.ServerName = "internal.example.com"
+
+	targets, err := makeTargets("example.com", c)
+	require.NoError(t, err)
+	assert.Equal(t, []target{
+		{address: "example.com:443", serverName: "example.com", required: true},
+		{address: "example.com:8443", serverName: "example.com", required: true},
+		{address: "10.0.0.4:443", serverName: "internal.example.com"},
+		{address: "10.0.0.4:8443", serverName: "internal.example.com"},
+		{address: "10.0.0.5:443", serverName: "internal.example.com"},
+		{address: "10.0.0.5:8443", serverName: "internal.example.com"},
+	}, targets)
+
+	targets, err = makeTargets("127.0.0.1:9443", defaultConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []target{{address: "127.0.0.1:9443", required: true}}, targets)
+
+	_, err = makeTargets("", defaultConfig)
+	assert.Error(t, err)
+
+	c = defaultConfig
+	c.Networks = []string{"10.0.0.0/8"}
+	_, err = makeTargets("", c)
+	assert.Error(t, err)
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile, err := ioutil.TempFile("", "mb_tls_certificate")
+	require.NoError(t, err)
+	defer os.Remove(caFile.Name())
+	require.NoError(t, pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	require.NoError(t, caFile.Close())
+
+	config := map[string]interface{}{
+		"module":                      "tls",
+		"metricsets":                  []string{"certificate"},
+		"hosts":                       []string{server.Listener.Addr().String()},
+		"ssl.certificate_authorities": []string{caFile.Name()},
+	}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	assert.Equal(t, server.Listener.Addr().String(), fields["endpoint"])
+	assert.Equal(t, true, fields["trusted"])
+	assert.Equal(t, 1, fields["chain"].(common.MapStr)["length"])
+	assert.Contains(t, fields["alternative_names"], "127.0.0.1")
+}
+
+func TestFetchUnreachable(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	addr := server.Listener.Addr().String()
+	server.Close()
+
+	config := map[string]interface{}{
+		"module":     "tls",
+		"metricsets": []string{"certificate"},
+		"hosts":      []string{addr},
+	}
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	assert.Empty(t, events)
+	assert.Len(t, errs, 1)
+}
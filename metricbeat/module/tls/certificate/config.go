@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificate
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/elastic/beats/v7/libbeat/common/transport/tlscommon"
+)
+
+// maxTargets bounds the number of endpoints scanned by a metricset.
+const maxTargets = 65536
+
+type config struct {
+	Networks    []string          `config:"certificate.networks"`
+	Ports       []uint16          `config:"certificate.ports"`
+	ServerName  string            `config:"certificate.server_name"`
+	Concurrency int               `config:"certificate.concurrency" validate:"min=1"`
+	TLS         *tlscommon.Config `config:"ssl"`
+}
+
+var defaultConfig = config{
+	Ports:       []uint16{443},
+	Concurrency: 16,
+}
+
+// Validate checks the networks are in CIDR notation and a port is configured.
+func (c *config) Validate() error {
+	for _, network := range c.Networks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			return fmt.Errorf("invalid network '%v': %v", network, err)
+		}
+	}
+	if len(c.Ports) == 0 {
+		return fmt.Errorf("no ports configured in certificate.ports")
+	}
+	return nil
+}
+
+// target is an endpoint to scan.
+type target struct {
+	address    string
+	serverName string
+	// errors connecting to hosts are reported, addresses of the scanned
+	// networks which are not listening are skipped
+	required bool
+}
+
+// makeTargets returns the endpoints of the host, if any, and of the configured
+// networks.
+func makeTargets(host string, c config) ([]target, error) {
+	var targets []target
+
+	if host != "" {
+		name, port, err := net.SplitHostPort(host)
+		ports := []string{port}
+		if err != nil {
+			name, ports = host, portStrings(c.Ports)
+		}
+		serverName := name
+		if net.ParseIP(name) != nil {
+			serverName = c.ServerName
+		}
+		for _, port := range ports {
+			targets = append(targets, target{
+				address:    net.JoinHostPort(name, port),
+				serverName: serverName,
+				required:   true,
+			})
+		}
+	}
+
+	for _, network := range c.Networks {
+		ip, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, err
+		}
+		for ip := ip.Mask(ipNet.Mask); ipNet.Contains(ip); ip = nextIP(ip) {
+			for _, port := range portStrings(c.Ports) {
+				if len(targets) >= maxTargets {
+					return nil, fmt.Errorf("too many endpoints to scan, the maximum is %d", maxTargets)
+				}
+				targets = append(targets, target{
+					address:    net.JoinHostPort(ip.String(), port),
+					serverName: c.ServerName,
+				})
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no hosts or certificate.networks configured")
+	}
+	return targets, nil
+}
+
+func portStrings(ports []uint16) []string {
+	s := make([]string, len(ports))
+	for i, port := range ports {
+		s[i] = strconv.Itoa(int(port))
+	}
+	return s
+}
+
+// nextIP returns the address following ip. The address wraps around after
+// the last address, which is outside of any network the loop started in.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/elastic/beats/v7/packetbeat/protos/udp"
 	"github.com/elastic/beats/v7/packetbeat/publish"
 	"github.com/elastic/beats/v7/packetbeat/sniffer"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 
 	// Add packetbeat default processors
 	_ "github.com/elastic/beats/v7/packetbeat/processor/add_kubernetes_metadata"
@@ -57,6 +58,7 @@ type packetbeat struct {
 	pipeline beat.Pipeline
 	transPub *publish.TransactionPublisher
 	flows    *flows.Flows
+	tunnels  *tunnel.Table
 }
 
 type flags struct {
@@ -87,6 +89,7 @@ func New(b *beat.Beat, rawConfig *common.Config) (beat.Beater, error) {
 			TopSpeed:   *cmdLineArgs.topSpeed,
 			OneAtATime: *cmdLineArgs.oneAtAtime,
 			Dumpfile:   *cmdLineArgs.dumpfile,
+			Tunnels:    tunnel.DefaultConfig(),
 		},
 	}
 	err := rawConfig.Unpack(&config)
@@ -133,6 +136,11 @@ func (pb *packetbeat) init(b *beat.Beat) error {
 		return err
 	}
 
+	if cfg.Interfaces.Tunnels.Enabled() {
+		pb.tunnels = tunnel.NewTable(cfg.Interfaces.Tunnels.Timeout)
+		pb.transPub.SetTunnels(pb.tunnels)
+	}
+
 	logp.Debug("main", "Initializing protocol plugins")
 	err = protos.Protos.Init(false, pb.transPub, cfg.Protocols, cfg.ProtocolsList)
 	if err != nil {
@@ -160,6 +168,7 @@ func (pb *packetbeat) setupSniffer() error {
 	filter := config.Interfaces.BpfFilter
 	if filter == "" && !config.Flows.IsEnabled() {
 		filter = protos.Protos.BpfFilter(withVlans, withICMP)
+		filter = config.Interfaces.Tunnels.BpfFilter(filter)
 	}
 
 	pb.sniff, err = sniffer.New(false, filter, pb.createWorker, config.Interfaces)
@@ -284,6 +293,9 @@ func (pb *packetbeat) createWorker(dl layers.LinkType) (sniffer.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	if pb.config.Interfaces.Tunnels.Enabled() {
+		worker.SetTunnels(pb.config.Interfaces.Tunnels, pb.tunnels)
+	}
 
 	return worker, nil
 }
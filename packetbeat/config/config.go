@@ -18,11 +18,14 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 )
 
 type Config struct {
@@ -48,6 +51,9 @@ type InterfacesConfig struct {
 	Dumpfile              string
 	OneAtATime            bool
 	Loop                  int
+
+	// Tunnels selects the overlay network tunnels to decapsulate.
+	Tunnels tunnel.Config `config:"tunnels"`
 }
 
 type Flows struct {
@@ -60,10 +66,52 @@ type Flows struct {
 }
 
 type ProtocolCommon struct {
-	Ports              []int         `config:"ports"`
-	SendRequest        bool          `config:"send_request"`
-	SendResponse       bool          `config:"send_response"`
-	TransactionTimeout time.Duration `config:"transaction_timeout"`
+	Ports              []int          `config:"ports"`
+	SendRequest        bool           `config:"send_request"`
+	SendResponse       bool           `config:"send_response"`
+	TransactionTimeout time.Duration  `config:"transaction_timeout"`
+	Capture            *CaptureConfig `config:"capture"`
+}
+
+// CaptureConfig configures the capture of request and response payload
+// excerpts for a subset of the transactions of a protocol.
+type CaptureConfig struct {
+	// SamplePercentage is the percentage of transactions whose payloads are
+	// captured.
+	SamplePercentage float64 `config:"sample_percentage" validate:"min=0,max=100"`
+	// OnError captures the payloads of all failed transactions.
+	OnError bool `config:"on_error"`
+	// MaxBytes limits the size of each captured payload.
+	MaxBytes int `config:"max_bytes" validate:"min=1"`
+	// Redact is a list of regular expressions whose matches are removed from
+	// the captured payloads.
+	Redact []string `config:"redact"`
+}
+
+// DefaultCaptureConfig returns the default payload capture settings.
+func DefaultCaptureConfig() CaptureConfig {
+	return CaptureConfig{MaxBytes: 1024}
+}
+
+// Unpack sets the defaults of the payload capture settings before unpacking.
+func (c *CaptureConfig) Unpack(cfg *common.Config) error {
+	type captureConfig CaptureConfig
+	tmp := captureConfig(DefaultCaptureConfig())
+	if err := cfg.Unpack(&tmp); err != nil {
+		return err
+	}
+	*c = CaptureConfig(tmp)
+	return nil
+}
+
+// Validate checks that the redaction patterns are valid regular expressions.
+func (c *CaptureConfig) Validate() error {
+	for _, pattern := range c.Redact {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid redact pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
 }
 
 func (f *Flows) IsEnabled() bool {
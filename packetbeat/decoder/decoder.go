@@ -26,6 +26,7 @@ import (
 	"github.com/elastic/beats/v7/packetbeat/protos/icmp"
 	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
 	"github.com/elastic/beats/v7/packetbeat/protos/udp"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 
 	"github.com/tsg/gopacket"
 	"github.com/tsg/gopacket/layers"
@@ -48,6 +49,9 @@ type Decoder struct {
 	icmp6     layers.ICMPv6
 	tcp       layers.TCP
 	udp       layers.UDP
+	vxlan     vxlanLayer
+	geneve    geneveLayer
+	gre       greLayer
 	truncated bool
 
 	stD1Q, stIP4, stIP6 multiLayer
@@ -63,6 +67,11 @@ type Decoder struct {
 	icmpV4TypeCode *flows.Uint
 	icmpV6TypeCode *flows.Uint
 
+	// tunnel decapsulation
+	tunnels     tunnel.Config
+	tunnelTable *tunnel.Table
+	tunnel      tunnel.Info // innermost tunnel of the current packet
+
 	// hold current flow ID
 	flowID              *flows.FlowID // buffer flowID among many calls
 	flowIDBufferBacking [flows.SizeFlowIDMax]byte
@@ -144,6 +153,14 @@ func New(
 	return &d, nil
 }
 
+// SetTunnels enables the decapsulation of the tunnels selected by config. The
+// tunnel of the inner connections is recorded in table, if not nil.
+func (d *Decoder) SetTunnels(config tunnel.Config, table *tunnel.Table) {
+	d.tunnels = config
+	d.tunnelTable = table
+	d.AddLayers([]gopacket.DecodingLayer{&d.vxlan, &d.geneve, &d.gre})
+}
+
 func (d *Decoder) SetTruncated() {
 	d.truncated = true
 }
@@ -164,6 +181,7 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 	defer logp.Recover("packet decoding failed")
 
 	d.truncated = false
+	d.tunnel = tunnel.Info{}
 
 	current := d.linkLayerDecoder
 	currentType := d.linkLayerType
@@ -191,7 +209,12 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 		nextType := current.NextLayerType()
 		data = current.LayerPayload()
 
-		processed, err = d.process(&packet, currentType)
+		tunnelType, isTunnel := d.tunnelLayer(currentType)
+		if isTunnel {
+			nextType = tunnelType
+		}
+
+		processed, err = d.process(&packet, currentType, isTunnel)
 		if err != nil {
 			logp.Info("Error processing packet: %v", err)
 			break
@@ -226,6 +249,7 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 func (d *Decoder) process(
 	packet *protos.Packet,
 	layerType gopacket.LayerType,
+	isTunnel bool,
 ) (bool, error) {
 	withFlow := d.flowID != nil
 
@@ -279,6 +303,10 @@ func (d *Decoder) process(
 		return true, nil
 
 	case layers.LayerTypeUDP:
+		if isTunnel {
+			debugf("UDP tunnel packet")
+			return false, nil
+		}
 		debugf("UDP packet")
 		d.onUDP(packet)
 		return true, nil
@@ -287,11 +315,77 @@ func (d *Decoder) process(
 		debugf("TCP packet")
 		d.onTCP(packet)
 		return true, nil
+
+	case layerTypeVXLAN:
+		debugf("VXLAN packet")
+		d.onTunnel(packet, tunnel.VXLAN, d.vxlan.vni, true)
+
+	case layerTypeGeneve:
+		debugf("GENEVE packet")
+		d.onTunnel(packet, tunnel.Geneve, d.geneve.vni, true)
+
+	case layerTypeGRE:
+		debugf("GRE packet")
+		d.onTunnel(packet, tunnel.GRE, d.gre.key, d.gre.hasKey)
 	}
 
 	return false, nil
 }
 
+// tunnelLayer returns the tunnel layer type to decode the payload of the
+// current layer with, if it carries an enabled tunnel.
+func (d *Decoder) tunnelLayer(layerType gopacket.LayerType) (gopacket.LayerType, bool) {
+	if !d.tunnels.Enabled() {
+		return layerType, false
+	}
+
+	switch layerType {
+	case layers.LayerTypeUDP:
+		port := uint16(d.udp.DstPort)
+		if d.tunnels.VXLAN && containsPort(d.tunnels.VXLANPorts, port) {
+			return layerTypeVXLAN, true
+		}
+		if d.tunnels.Geneve && containsPort(d.tunnels.GenevePorts, port) {
+			return layerTypeGeneve, true
+		}
+
+	case layers.LayerTypeIPv4:
+		if d.tunnels.GRE && d.ip4[d.stIP4.i].Protocol == ipProtocolGRE {
+			return layerTypeGRE, true
+		}
+
+	case layers.LayerTypeIPv6:
+		if d.tunnels.GRE && d.ip6[d.stIP6.i].NextHeader == ipProtocolGRE {
+			return layerTypeGRE, true
+		}
+	}
+	return layerType, false
+}
+
+// onTunnel records the tunnel of the packet. The addresses of the current
+// packet tuple are the tunnel endpoints, they are overwritten by the inner
+// IP layer.
+func (d *Decoder) onTunnel(packet *protos.Packet, typ tunnel.Type, id uint32, hasID bool) {
+	d.tunnel = tunnel.Info{
+		Type:        typ,
+		ID:          id,
+		HasID:       hasID,
+		Source:      packet.Tuple.SrcIP,
+		Destination: packet.Tuple.DstIP,
+	}
+	if d.flowID != nil {
+		d.flowID.AddTunnel(&d.tunnel)
+	}
+}
+
+// recordTunnel remembers the tunnel of the inner connection of the packet, to
+// be added to its transactions.
+func (d *Decoder) recordTunnel(packet *protos.Packet) {
+	if d.tunnelTable != nil && d.tunnel.Type != tunnel.None {
+		d.tunnelTable.Put(&packet.Tuple, &d.tunnel, packet.Ts)
+	}
+}
+
 func (d *Decoder) onICMPv4(packet *protos.Packet) {
 	if d.flowID != nil {
 		flow := d.flows.Get(d.flowID)
@@ -301,6 +395,7 @@ func (d *Decoder) onICMPv4(packet *protos.Packet) {
 	if d.icmp4Proc != nil {
 		packet.Payload = d.icmp4.Payload
 		packet.Tuple.ComputeHashables()
+		d.recordTunnel(packet)
 		d.icmp4Proc.ProcessICMPv4(d.flowID, &d.icmp4, packet)
 	}
 }
@@ -314,6 +409,7 @@ func (d *Decoder) onICMPv6(packet *protos.Packet) {
 	if d.icmp6Proc != nil {
 		packet.Payload = d.icmp6.Payload
 		packet.Tuple.ComputeHashables()
+		d.recordTunnel(packet)
 		d.icmp6Proc.ProcessICMPv6(d.flowID, &d.icmp6, packet)
 	}
 }
@@ -331,6 +427,7 @@ func (d *Decoder) onUDP(packet *protos.Packet) {
 	packet.Tuple.DstPort = dst
 	packet.Payload = d.udp.Payload
 	packet.Tuple.ComputeHashables()
+	d.recordTunnel(packet)
 
 	d.udpProc.Process(id, packet)
 }
@@ -354,5 +451,6 @@ func (d *Decoder) onTCP(packet *protos.Packet) {
 		return
 	}
 	packet.Tuple.ComputeHashables()
+	d.recordTunnel(packet)
 	d.tcpProc.Process(id, &d.tcp, packet)
 }
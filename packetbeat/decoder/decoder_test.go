@@ -22,10 +22,12 @@ package decoder
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/packetbeat/flows"
 	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tsg/gopacket"
@@ -197,6 +199,96 @@ func TestDecodePacketData_ipv6Udp(t *testing.T) {
 	assert.NotEqual(t, -1, strings.Index(string(p.Data()), string(udp.pkt.Payload)))
 }
 
+// outerEthIPv4 wraps payload in Ethernet and IPv4 headers from 192.168.1.1 to
+// 192.168.1.2 with the given IP protocol.
+func outerEthIPv4(protocol byte, payload []byte) []byte {
+	total := 20 + len(payload)
+	frame := []byte{
+		0x00, 0x0c, 0x29, 0x00, 0x00, 0x02, 0x00, 0x0c, 0x29, 0x00, 0x00, 0x01, 0x08, 0x00,
+		0x45, 0x00, byte(total >> 8), byte(total), 0x00, 0x00, 0x40, 0x00, 0x40, protocol, 0x00, 0x00,
+		192, 168, 1, 1, 192, 168, 1, 2,
+	}
+	return append(frame, payload...)
+}
+
+// outerUDP wraps payload in a UDP header with the given destination port.
+func outerUDP(port uint16, payload []byte) []byte {
+	length := 8 + len(payload)
+	header := []byte{0xc0, 0x00, byte(port >> 8), byte(port), byte(length >> 8), byte(length), 0x00, 0x00}
+	return append(header, payload...)
+}
+
+// ipv4UdpDNS encapsulated in VXLAN with the VNI 42.
+var vxlanUdpDNS = outerEthIPv4(17, outerUDP(4789, append([]byte{0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x2a, 0x00}, ipv4UdpDNS...)))
+
+// ipv4UdpDNS encapsulated in GENEVE with the VNI 7 and a 4 bytes option.
+var geneveUdpDNS = outerEthIPv4(17, outerUDP(6081, append([]byte{
+	0x01, 0x00, 0x65, 0x58, 0x00, 0x00, 0x07, 0x00,
+	0x01, 0x02, 0x03, 0x00,
+}, ipv4UdpDNS...)))
+
+// The IPv4 packet of ipv4TcpDNS encapsulated in GRE with the key 1000.
+var greTcpDNS = outerEthIPv4(47, append([]byte{0x20, 0x00, 0x08, 0x00, 0x00, 0x00, 0x03, 0xe8}, ipv4TcpDNS[14:]...))
+
+func TestDecodePacketData_tunnels(t *testing.T) {
+	cases := []struct {
+		name   string
+		packet []byte
+		typ    string
+		id     interface{}
+		tcp    bool
+	}{
+		{name: "vxlan", packet: vxlanUdpDNS, typ: "vxlan", id: uint32(42)},
+		{name: "geneve", packet: geneveUdpDNS, typ: "geneve", id: uint32(7)},
+		{name: "gre", packet: greTcpDNS, typ: "gre", id: uint32(1000), tcp: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d, tcp, udp := newTestDecoder(t)
+			table := tunnel.NewTable(time.Minute)
+			d.SetTunnels(tunnel.Config{VXLAN: true, VXLANPorts: []uint16{4789}, Geneve: true, GenevePorts: []uint16{6081}, GRE: true}, table)
+			d.OnPacket(c.packet, &gopacket.CaptureInfo{Timestamp: time.Now()})
+
+			pkt := udp.pkt
+			if c.tcp {
+				pkt = tcp.pkt
+			}
+			if !assert.NotNil(t, pkt, "inner packet not received") {
+				return
+			}
+			if c.tcp {
+				assert.Equal(t, "172.16.16.164", pkt.Tuple.SrcIP.String())
+				assert.Equal(t, uint16(53), pkt.Tuple.DstPort)
+			} else {
+				assert.Equal(t, "192.168.170.8", pkt.Tuple.SrcIP.String())
+				assert.Equal(t, uint16(53), pkt.Tuple.DstPort)
+			}
+
+			info, ok := table.Lookup(pkt.Tuple.DstIP, pkt.Tuple.DstPort, pkt.Tuple.SrcIP, pkt.Tuple.SrcPort)
+			if assert.True(t, ok) {
+				fields := info.Fields()
+				assert.Equal(t, c.typ, fields["type"])
+				assert.Equal(t, c.id, fields["id"])
+				v, _ := fields.GetValue("source.ip")
+				assert.Equal(t, "192.168.1.1", v)
+				v, _ = fields.GetValue("destination.ip")
+				assert.Equal(t, "192.168.1.2", v)
+			}
+		})
+	}
+}
+
+// Test that tunnels are not decapsulated unless enabled.
+func TestDecodePacketData_tunnelDisabled(t *testing.T) {
+	d, _, udp := newTestDecoder(t)
+	d.OnPacket(vxlanUdpDNS, &gopacket.CaptureInfo{Timestamp: time.Now()})
+
+	assert.NotNil(t, udp.pkt, "UDP packet not received")
+	assert.Equal(t, "192.168.1.1", udp.pkt.Tuple.SrcIP.String())
+	assert.Equal(t, uint16(4789), udp.pkt.Tuple.DstPort)
+}
+
 // Creates a new TestDecoder that handles ethernet packets.
 func newTestDecoder(t *testing.T) (*Decoder, *TestTCPProcessor, *TestUDPProcessor) {
 	icmp4Layer := &TestIcmp4Processor{}
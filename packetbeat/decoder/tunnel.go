@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// Layer types of the tunnel headers. They are only decoded by the Decoder
+// when tunnel decapsulation is enabled.
+var (
+	layerTypeVXLAN  = gopacket.RegisterLayerType(2100, gopacket.LayerTypeMetadata{Name: "VXLAN"})
+	layerTypeGeneve = gopacket.RegisterLayerType(2101, gopacket.LayerTypeMetadata{Name: "GENEVE"})
+	layerTypeGRE    = gopacket.RegisterLayerType(2102, gopacket.LayerTypeMetadata{Name: "GRE"})
+)
+
+const (
+	ipProtocolGRE = layers.IPProtocol(47)
+
+	// etherTypeTransparentBridging is the protocol type of Ethernet frames
+	// carried by GENEVE and GRE.
+	etherTypeTransparentBridging = 0x6558
+
+	vxlanHeaderSize  = 8
+	geneveHeaderSize = 8
+	greHeaderSize    = 4
+
+	vxlanFlagVNI   = 0x08
+	greFlagCsum    = 0x8000
+	greFlagRouting = 0x4000
+	greFlagKey     = 0x2000
+	greFlagSeq     = 0x1000
+	greVersionMask = 0x0007
+)
+
+var errTunnelTruncated = errors.New("tunnel header truncated")
+
+// vxlanLayer decodes the VXLAN header (RFC 7348).
+type vxlanLayer struct {
+	vni     uint32
+	payload []byte
+}
+
+func (l *vxlanLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < vxlanHeaderSize {
+		return errTunnelTruncated
+	}
+	if data[0]&vxlanFlagVNI == 0 {
+		return errors.New("VXLAN header without a valid VNI")
+	}
+	l.vni = uint32(data[4])<<16 | uint32(data[5])<<8 | uint32(data[6])
+	l.payload = data[vxlanHeaderSize:]
+	return nil
+}
+
+func (l *vxlanLayer) CanDecode() gopacket.LayerClass    { return layerTypeVXLAN }
+func (l *vxlanLayer) NextLayerType() gopacket.LayerType { return layers.LayerTypeEthernet }
+func (l *vxlanLayer) LayerPayload() []byte              { return l.payload }
+
+// geneveLayer decodes the GENEVE header (RFC 8926), skipping its options.
+type geneveLayer struct {
+	vni      uint32
+	protocol uint16
+	payload  []byte
+}
+
+func (l *geneveLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < geneveHeaderSize {
+		return errTunnelTruncated
+	}
+	if version := data[0] >> 6; version != 0 {
+		return fmt.Errorf("unsupported GENEVE version %d", version)
+	}
+	size := geneveHeaderSize + int(data[0]&0x3f)*4
+	if len(data) < size {
+		return errTunnelTruncated
+	}
+	l.protocol = binary.BigEndian.Uint16(data[2:4])
+	l.vni = uint32(data[4])<<16 | uint32(data[5])<<8 | uint32(data[6])
+	l.payload = data[size:]
+	return nil
+}
+
+func (l *geneveLayer) CanDecode() gopacket.LayerClass    { return layerTypeGeneve }
+func (l *geneveLayer) NextLayerType() gopacket.LayerType { return tunnelPayloadType(l.protocol) }
+func (l *geneveLayer) LayerPayload() []byte              { return l.payload }
+
+// greLayer decodes the GRE header (RFC 2784 and RFC 2890).
+type greLayer struct {
+	key      uint32
+	hasKey   bool
+	protocol uint16
+	payload  []byte
+}
+
+func (l *greLayer) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < greHeaderSize {
+		return errTunnelTruncated
+	}
+	flags := binary.BigEndian.Uint16(data[0:2])
+	if version := flags & greVersionMask; version != 0 {
+		return fmt.Errorf("unsupported GRE version %d", version)
+	}
+	if flags&greFlagRouting != 0 {
+		return errors.New("GRE source routing is not supported")
+	}
+
+	size := greHeaderSize
+	if flags&greFlagCsum != 0 {
+		size += 4
+	}
+	keyOffset := size
+	if flags&greFlagKey != 0 {
+		size += 4
+	}
+	if flags&greFlagSeq != 0 {
+		size += 4
+	}
+	if len(data) < size {
+		return errTunnelTruncated
+	}
+
+	l.protocol = binary.BigEndian.Uint16(data[2:4])
+	l.hasKey = flags&greFlagKey != 0
+	l.key = 0
+	if l.hasKey {
+		l.key = binary.BigEndian.Uint32(data[keyOffset:])
+	}
+	l.payload = data[size:]
+	return nil
+}
+
+func (l *greLayer) CanDecode() gopacket.LayerClass    { return layerTypeGRE }
+func (l *greLayer) NextLayerType() gopacket.LayerType { return tunnelPayloadType(l.protocol) }
+func (l *greLayer) LayerPayload() []byte              { return l.payload }
+
+// tunnelPayloadType returns the layer type of the payload of a GENEVE or GRE
+// header given its protocol type.
+func tunnelPayloadType(protocol uint16) gopacket.LayerType {
+	switch layers.EthernetType(protocol) {
+	case etherTypeTransparentBridging:
+		return layers.LayerTypeEthernet
+	case layers.EthernetTypeIPv4:
+		return layers.LayerTypeIPv4
+	case layers.EthernetTypeIPv6:
+		return layers.LayerTypeIPv6
+	case layers.EthernetTypeDot1Q:
+		return layers.LayerTypeDot1Q
+	default:
+		return gopacket.LayerTypePayload
+	}
+}
+
+func containsPort(ports []uint16, port uint16) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
@@ -22,6 +22,8 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	"net"
+
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 )
 
 type FlowID struct {
@@ -51,6 +53,7 @@ type flowIDMeta struct {
 	offUDP        uint8
 	offTCP        uint8
 	offID         uint8
+	offTunnel     uint8
 
 	cntEth  uint8
 	cntVlan uint8
@@ -72,6 +75,7 @@ const (
 	UDPFlow
 	TCPFlow
 	ConnectionID
+	TunnelFlow
 )
 
 const (
@@ -90,13 +94,15 @@ const (
 	SizeTCPFlowID    = 2 * SizePortNumber // source + dest port
 	SizeUDPFlowID    = 2 * SizePortNumber // source + dest port
 	SizeConnectionID = 8                  // 64bit internal connection id
+	SizeTunnelFlowID = 1 + 1 + 4          // tunnel type + id present + tunnel id
 
 	SizeFlowIDMax int = SizeEthFlowID +
 		2*(SizeVlanFlowID+SizeIPv4FlowID+SizeIPv6FlowID) +
 		SizeICMPFlowID +
 		SizeTCPFlowID +
 		SizeUDPFlowID +
-		SizeConnectionID
+		SizeConnectionID +
+		SizeTunnelFlowID
 )
 
 const offUnset uint8 = 0xff
@@ -116,6 +122,7 @@ var flowIDEmptyMeta = flowIDMeta{
 	offUDP:        offUnset,
 	offTCP:        offUnset,
 	offID:         offUnset,
+	offTunnel:     offUnset,
 
 	cntEth:  0,
 	cntVlan: 0,
@@ -240,6 +247,20 @@ func (f *FlowID) AddConnectionID(id uint64) {
 	f.addID(&f.offID, ConnectionID, tmp[:], nil, flowDirUnset)
 }
 
+// AddTunnel adds the type and the ID, like the VXLAN network identifier, of
+// the tunnel the inner layers were decapsulated from.
+func (f *FlowID) AddTunnel(info *tunnel.Info) {
+	debugf("flowid: add tunnel")
+
+	var tmp [SizeTunnelFlowID]byte
+	tmp[0] = uint8(info.Type)
+	if info.HasID {
+		tmp[1] = 1
+		binary.LittleEndian.PutUint32(tmp[2:], info.ID)
+	}
+	f.addID(&f.offTunnel, TunnelFlow, tmp[:], nil, flowDirUnset)
+}
+
 func (f *FlowID) addMultLayerID(
 	off, outerOff *uint8,
 	flag, outerFlag FlowIDFlag,
@@ -364,6 +385,8 @@ func (f *rawFlowID) Get(i FlowIDFlag) []byte {
 		return f.UDP()
 	case TCPFlow:
 		return f.TCP()
+	case TunnelFlow:
+		return f.Tunnel()
 	default:
 		return nil
 	}
@@ -392,6 +415,9 @@ func (f *rawFlowID) Serialize() []byte {
 		f.cntVlan,
 		f.cntIP,
 	})
+	if f.flags&TunnelFlow != 0 {
+		enc.Write([]byte{f.offTunnel})
+	}
 	enc.Write(f.flowID)
 	enc.Close()
 
@@ -474,6 +500,23 @@ func (f *rawFlowID) ConnectionID() []byte {
 	return f.extractID(f.offID, SizeConnectionID)
 }
 
+func (f *rawFlowID) Tunnel() []byte {
+	return f.extractID(f.offTunnel, SizeTunnelFlowID)
+}
+
+// TunnelInfo returns the type and the ID of the tunnel of the flow.
+func (f *rawFlowID) TunnelInfo() (tunnel.Info, bool) {
+	raw := f.Tunnel()
+	if raw == nil {
+		return tunnel.Info{}, false
+	}
+	return tunnel.Info{
+		Type:  tunnel.Type(raw[0]),
+		ID:    binary.LittleEndian.Uint32(raw[2:]),
+		HasID: raw[1] == 1,
+	}, true
+}
+
 func (f *rawFlowID) extractID(off, sz uint8) []byte {
 	if off == offUnset {
 		return nil
@@ -26,6 +26,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 )
 
 type applyAddr func(f *FlowID)
@@ -297,3 +299,33 @@ func TestSimilarWithOffsets(t *testing.T) {
 	assert.Equal(t, id1.flags, id2.flags)
 	assert.NotEqual(t, id1.flowIDMeta, id2.flowIDMeta)
 }
+
+func TestTunnelFlowID(t *testing.T) {
+	ip1 := []byte{10, 0, 0, 1}
+	ip2 := []byte{10, 0, 0, 2}
+	vni1 := &tunnel.Info{Type: tunnel.VXLAN, ID: 1, HasID: true}
+	vni2 := &tunnel.Info{Type: tunnel.VXLAN, ID: 2, HasID: true}
+
+	id1 := newFlowID()
+	id1.AddTunnel(vni1)
+	addIP(ip1, ip2)(id1)
+	id2 := newFlowID()
+	id2.AddTunnel(vni2)
+	addIP(ip1, ip2)(id2)
+
+	// The same inner addresses in different virtual networks are different flows.
+	assert.False(t, FlowIDsEqual(id1, id2))
+
+	info, ok := id1.TunnelInfo()
+	assert.True(t, ok)
+	assert.Equal(t, *vni1, info)
+
+	gre := newFlowID()
+	gre.AddTunnel(&tunnel.Info{Type: tunnel.GRE})
+	info, ok = gre.TunnelInfo()
+	assert.True(t, ok)
+	assert.Equal(t, tunnel.Info{Type: tunnel.GRE}, info)
+
+	_, ok = newFlowID().TunnelInfo()
+	assert.False(t, ok)
+}
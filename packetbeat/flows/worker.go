@@ -248,6 +248,11 @@ func createEvent(
 		putOrAppendUint64(flow, "vlan", vlanID)
 	}
 
+	// add tunnel
+	if tunnel, ok := f.id.TunnelInfo(); ok {
+		fields["tunnel"] = tunnel.Fields()
+	}
+
 	// ipv4 layer meta data
 	if src, dst, ok := f.id.OutterIPv4Addr(); ok {
 		srcIP, dstIP := net.IP(src), net.IP(dst)
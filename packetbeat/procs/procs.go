@@ -18,7 +18,9 @@
 package procs
 
 import (
+	"errors"
 	"net"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -71,11 +73,22 @@ type processWatcherImpl interface {
 	GetLocalIPs() ([]net.IP, error)
 }
 
+// socketTracer reports the process that owns a local TCP endpoint as observed
+// by the kernel when the socket connected or started listening.
+type socketTracer interface {
+	// Lookup returns the process that last connected from or listened on
+	// the given local address and port.
+	Lookup(address net.IP, port uint16) (p *process, found bool)
+}
+
 type ProcessesWatcher struct {
 	portProcMap  map[applayer.Transport]map[endpoint]portProcMapping
 	localAddrs   []net.IP
 	processCache map[int]*process
 
+	// tracer is only set when eBPF socket tracing is enabled and supported.
+	tracer socketTracer
+
 	// config
 	enabled    bool
 	procConfig []ProcConfig
@@ -86,6 +99,15 @@ type ProcessesWatcher struct {
 var ProcWatcher ProcessesWatcher
 
 func (proc *ProcessesWatcher) Init(config ProcsConfig) error {
+	if config.Enabled && config.EBPF {
+		tracer, err := newSocketTracer(proc.GetProcess)
+		if err != nil {
+			logp.Warn("Unable to start eBPF socket tracing, falling back to /proc scanning: %v", err)
+		} else {
+			logp.Info("Process watcher eBPF socket tracing enabled")
+			proc.tracer = tracer
+		}
+	}
 	return proc.initWithImpl(config, proc)
 }
 
@@ -178,6 +200,12 @@ func (proc *ProcessesWatcher) findProc(address net.IP, port uint16, transport ap
 		return nil
 	}
 
+	if transport == applayer.TransportTCP && proc.tracer != nil {
+		if p := proc.findTracedProc(address, port); p != nil {
+			return p
+		}
+	}
+
 	p, exists := lookupMapping(address, port, procMap)
 	if exists {
 		return p.proc
@@ -211,6 +239,45 @@ func lookupMapping(address net.IP, port uint16, procMap map[endpoint]portProcMap
 	return
 }
 
+// findTracedProc looks up the owner of a local TCP endpoint using the kernel
+// socket tracer. As the tracer records the process at the time the socket was
+// created, it takes precedence over port mappings read earlier, which could
+// belong to a previous user of the same port.
+func (proc *ProcessesWatcher) findTracedProc(address net.IP, port uint16) *process {
+	e := endpoint{address.String(), port}
+	traced, found := proc.tracer.Lookup(address, port)
+	if !found {
+		nullAddr := net.IPv4zero
+		if asIPv4 := address.To4(); asIPv4 == nil {
+			nullAddr = net.IPv6unspecified
+		}
+		e = endpoint{nullAddr.String(), port}
+		if traced, found = proc.tracer.Lookup(nullAddr, port); !found {
+			return nil
+		}
+	}
+
+	procMap := proc.portProcMap[applayer.TransportTCP]
+	if prev, ok := procMap[e]; ok && prev.pid == traced.pid {
+		return prev.proc
+	}
+
+	p := proc.getProcessInfo(traced.pid)
+	if p == nil {
+		// The process has already exited, which is common for short-lived
+		// clients. Report what the tracer recorded about it.
+		p = traced
+		proc.applyMonitoredName(p)
+	}
+	procMap[e] = portProcMapping{endpoint: e, pid: p.pid, proc: p}
+
+	if logp.IsDebug("procsdetailed") {
+		logp.Debug("procsdetailed", "findTracedProc(): local=%s:%d pid=%d process='%s'",
+			e.address, e.port, p.pid, p.name)
+	}
+	return p
+}
+
 func (proc *ProcessesWatcher) updateMap(transport applayer.Transport) {
 	if logp.HasSelector("procsdetailed") {
 		start := time.Now()
@@ -279,16 +346,20 @@ func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 		return nil
 	}
 
-	// The packetbeat.procs.monitored*.cmdline_grep allows you to overwrite
-	// the process name with an alias.
+	proc.applyMonitoredName(p)
+	proc.processCache[pid] = p
+	return p
+}
+
+// applyMonitoredName replaces the name of the process with the alias
+// configured in packetbeat.procs.monitored*.cmdline_grep, if any.
+func (proc *ProcessesWatcher) applyMonitoredName(p *process) {
 	for _, match := range proc.procConfig {
 		if strings.Contains(strings.Join(p.args, " "), match.CmdlineGrep) {
 			p.name = match.Process
 			break
 		}
 	}
-	proc.processCache[pid] = p
-	return p
 }
 
 func (proc *ProcessesWatcher) expireProcessCache() {
@@ -308,13 +379,13 @@ func (proc *ProcessesWatcher) GetProcess(pid int) *process {
 
 	p, err := sysinfo.Process(pid)
 	if err != nil {
-		logp.Err("Unable to get command-line for PID %d: %v", pid, err)
+		logProcessError(pid, err)
 		return nil
 	}
 
 	info, err := p.Info()
 	if err != nil {
-		logp.Err("Unable to get command-line for PID %d: %v", pid, err)
+		logProcessError(pid, err)
 		return nil
 	}
 
@@ -335,6 +406,17 @@ func (proc *ProcessesWatcher) GetProcess(pid int) *process {
 	}
 }
 
+// logProcessError logs a failure to read the metadata of a process. The
+// processes reported by the socket tracer often exit before they are looked
+// up, which is only logged at debug level.
+func logProcessError(pid int, err error) {
+	if errors.Is(err, os.ErrNotExist) {
+		logp.Debug("procs", "Process with PID %d exited before its metadata could be read: %v", pid, err)
+		return
+	}
+	logp.Err("Unable to get command-line for PID %d: %v", pid, err)
+}
+
 // GetLocalIPs returns the list of local addresses.
 func (proc *ProcessesWatcher) GetLocalIPs() ([]net.IP, error) {
 	return common.LocalIPAddrs()
@@ -304,3 +304,99 @@ func TestFindProcessTuple(t *testing.T) {
 		})
 	}
 }
+
+type testingTracer map[endpoint]*process
+
+func (t testingTracer) Lookup(address net.IP, port uint16) (*process, bool) {
+	p, found := t[endpoint{address.String(), port}]
+	return p, found
+}
+
+func TestFindProcessTupleTraced(t *testing.T) {
+	logp.TestingSetup()
+	config := ProcsConfig{
+		Enabled: true,
+		Monitored: []ProcConfig{
+			{Process: "Curl", CmdlineGrep: "curl"},
+		},
+	}
+	impl := newTestingImpl(
+		[]net.IP{net.ParseIP("192.168.1.1")},
+		[]runningProcess{
+			{
+				process: process{
+					name: "old_client",
+					args: strings.Fields("/usr/bin/old_client"),
+					pid:  201,
+				},
+				ports: []endpoint{
+					{address: "192.168.1.1", port: 40000},
+				},
+				proto: applayer.TransportTCP,
+			},
+			{
+				process: process{
+					name: "new_client",
+					args: strings.Fields("/usr/bin/new_client"),
+					exe:  "/usr/bin/new_client",
+					pid:  202,
+				},
+				proto: applayer.TransportTCP,
+			},
+			{
+				process: process{
+					name: "nginx",
+					args: strings.Fields("nginx: worker process"),
+					pid:  203,
+				},
+				ports: []endpoint{
+					{address: anyIPv4, port: 8080},
+				},
+				proto: applayer.TransportTCP,
+			},
+		})
+	procs := ProcessesWatcher{
+		tracer: testingTracer{
+			// Exited before its traffic was processed, metadata resolved by the tracer.
+			{"192.168.1.1", 41000}: {pid: 301, name: "curl", exe: "/usr/bin/curl", args: strings.Fields("curl http://example.net/")},
+			// Exited before its metadata could be read.
+			{"192.168.1.1", 42000}: {pid: 302, name: "short_lived"},
+			// Port previously used by old_client.
+			{"192.168.1.1", 40000}: {pid: 202, name: "new_client"},
+			{anyIPv4, 9090}:        {pid: 203, name: "nginx"},
+		},
+	}
+	err := procs.initWithImpl(config, impl)
+	assert.NoError(t, err)
+
+	for _, testCase := range []struct {
+		name    string
+		port    uint16
+		proto   applayer.Transport
+		pid     int
+		process string
+		exe     string
+	}{
+		{name: "Exited process with metadata", port: 41000, proto: applayer.TransportTCP, pid: 301, process: "Curl", exe: "/usr/bin/curl"},
+		{name: "Exited process without metadata", port: 42000, proto: applayer.TransportTCP, pid: 302, process: "short_lived"},
+		{name: "Reused port", port: 40000, proto: applayer.TransportTCP, pid: 202, process: "new_client", exe: "/usr/bin/new_client"},
+		{name: "Listening on any address", port: 9090, proto: applayer.TransportTCP, pid: 203, process: "nginx"},
+		{name: "Not traced", port: 8080, proto: applayer.TransportTCP, pid: 203, process: "nginx"},
+		{name: "UDP is not traced", port: 41000, proto: applayer.TransportUDP},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			input := common.IPPortTuple{
+				BaseTuple: common.BaseTuple{
+					SrcIP:   net.ParseIP("192.168.1.1"),
+					SrcPort: testCase.port,
+					DstIP:   net.ParseIP("1.1.1.1"),
+					DstPort: 80,
+				},
+			}
+			result := procs.FindProcessesTuple(&input, testCase.proto)
+			assert.Equal(t, testCase.pid, result.Src.PID)
+			assert.Equal(t, testCase.process, result.Src.Name)
+			assert.Equal(t, testCase.exe, result.Src.Exe)
+		})
+	}
+}
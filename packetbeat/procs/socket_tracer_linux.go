@@ -0,0 +1,694 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package procs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"golang.org/x/sys/cpu"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// The socket tracer attaches an eBPF program to the sock:inet_sock_set_state
+// tracepoint. When a TCP socket connects or starts listening, the program
+// stores its local address and port together with the PID and command name
+// of the process that called connect(2) or listen(2) in an LRU hash map. The
+// information is accurate even for connections and processes that are gone
+// by the time their packets are processed.
+//
+// The PID is also pushed to a queue map, which is drained periodically so the
+// process metadata (executable, arguments...) can be read from /proc while
+// the process is still alive.
+
+var tracingPaths = []string{
+	"/sys/kernel/tracing",
+	"/sys/kernel/debug/tracing",
+}
+
+const (
+	tracepointCategory = "sock"
+	tracepointName     = "inet_sock_set_state"
+
+	// Maximum number of local endpoints tracked by the kernel map. Older
+	// entries are evicted first.
+	tracerMapEntries = 16384
+
+	// Maximum number of sockets waiting for their connection to be
+	// established.
+	tracerConnectingEntries = 4096
+
+	// Maximum number of PIDs waiting to be resolved. When full, the oldest
+	// ones are dropped.
+	tracerQueueEntries = 4096
+
+	// How often new PIDs are resolved.
+	tracerResolveInterval = 100 * time.Millisecond
+)
+
+// Names the eBPF program uses to reference the maps.
+const (
+	endpointsMap  = "endpoints"
+	connectingMap = "connecting"
+	queueMap      = "queue"
+)
+
+// Values found in the tracepoint fields.
+const (
+	tcpEstablished = 1
+	tcpSynSent     = 2
+	tcpListen      = 10
+	ipprotoTCP     = 6
+	afInet         = 2
+	afInet6        = 10
+)
+
+// bpfExist makes bpf_map_push_elem drop the oldest element of a full queue.
+const bpfExist = 2
+
+// nativeEndian is the byte order of the kernel maps.
+var nativeEndian binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	if cpu.IsBigEndian {
+		nativeEndian = binary.BigEndian
+	}
+}
+
+// Sizes of the keys and values of the endpoints map. Their layout must match
+// the one built by the eBPF program in the stack.
+const (
+	tracerKeySize   = 24
+	tracerValueSize = 24
+)
+
+// tracerKey is the key of the endpoints map, a local address and port.
+type tracerKey struct {
+	addr   [16]byte
+	port   uint16
+	family uint16
+}
+
+func newTracerKey(address net.IP, port uint16) tracerKey {
+	key := tracerKey{port: port}
+	if ipv4 := address.To4(); ipv4 != nil {
+		key.family = afInet
+		copy(key.addr[:], ipv4)
+	} else {
+		key.family = afInet6
+		copy(key.addr[:], address.To16())
+	}
+	return key
+}
+
+// MarshalBinary encodes the key as expected by the endpoints map.
+func (k tracerKey) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, tracerKeySize)
+	copy(buf, k.addr[:])
+	nativeEndian.PutUint16(buf[16:], k.port)
+	nativeEndian.PutUint16(buf[18:], k.family)
+	return buf, nil
+}
+
+// tracerValue is the value of the endpoints and connecting maps, the process
+// that created the socket.
+type tracerValue struct {
+	pid  uint32
+	comm [16]byte
+}
+
+// UnmarshalBinary decodes a value read from the endpoints map.
+func (v *tracerValue) UnmarshalBinary(buf []byte) error {
+	if len(buf) != tracerValueSize {
+		return fmt.Errorf("unexpected size of eBPF map value: %d", len(buf))
+	}
+	v.pid = nativeEndian.Uint32(buf)
+	copy(v.comm[:], buf[8:])
+	return nil
+}
+
+// process returns what the kernel recorded about the process, its PID and
+// command name.
+func (v *tracerValue) process() *process {
+	comm := v.comm[:]
+	if n := bytes.IndexByte(comm, 0); n >= 0 {
+		comm = comm[:n]
+	}
+	return &process{
+		pid:        int(v.pid),
+		name:       string(comm),
+		expiration: time.Now().Add(processCacheExpiration),
+	}
+}
+
+type ebpfSocketTracer struct {
+	// endpoints maps local endpoints to the process that owns them.
+	endpoints *ebpf.Map
+	// connecting maps sockets to the process that called connect until the
+	// local port is known.
+	connecting *ebpf.Map
+	// queue receives the PIDs to resolve. Optional, nil if not available.
+	queue *ebpf.Map
+
+	prog    *ebpf.Program
+	eventFD int
+
+	// getProcess reads the metadata of a running process.
+	getProcess func(pid int) *process
+
+	// processes holds the metadata of the processes seen by the tracer,
+	// read as soon as possible after they created a socket.
+	mutex     sync.Mutex
+	processes map[int]*process
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// tracepointField is the location of a field inside a tracepoint record.
+type tracepointField struct {
+	offset, size int
+}
+
+type tracepointFormat struct {
+	id     int
+	fields map[string]tracepointField
+}
+
+func newSocketTracer(getProcess func(pid int) *process) (socketTracer, error) {
+	format, err := loadTracepointFormat(tracepointCategory, tracepointName)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := &ebpfSocketTracer{
+		eventFD:    -1,
+		getProcess: getProcess,
+		processes:  make(map[int]*process),
+		done:       make(chan struct{}),
+	}
+	endpointsSpec := &ebpf.MapSpec{
+		Type:       ebpf.LRUHash,
+		KeySize:    tracerKeySize,
+		ValueSize:  tracerValueSize,
+		MaxEntries: tracerMapEntries,
+	}
+	tracer.endpoints, err = ebpf.NewMap(endpointsSpec)
+	if errors.Is(err, unix.EPERM) && raiseMemlockLimit() {
+		tracer.endpoints, err = ebpf.NewMap(endpointsSpec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating eBPF map: %v", err)
+	}
+	tracer.connecting, err = ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  tracerValueSize,
+		MaxEntries: tracerConnectingEntries,
+	})
+	if err != nil {
+		tracer.Close()
+		return nil, fmt.Errorf("creating eBPF map: %v", err)
+	}
+
+	// Queue maps require Linux 4.20. Without them, metadata is only
+	// available for processes still running when their traffic is seen.
+	tracer.queue, err = ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.Queue,
+		ValueSize:  4,
+		MaxEntries: tracerQueueEntries,
+	})
+	if err != nil {
+		logp.Debug("procs", "eBPF queue maps not available: %v", err)
+		tracer.queue = nil
+	}
+
+	insns, err := socketTracerProgram(format, tracer.queue != nil)
+	if err != nil {
+		tracer.Close()
+		return nil, err
+	}
+	maps := map[string]*ebpf.Map{endpointsMap: tracer.endpoints, connectingMap: tracer.connecting}
+	if tracer.queue != nil {
+		maps[queueMap] = tracer.queue
+	}
+	for name, m := range maps {
+		if err := insns.AssociateMap(name, m); err != nil {
+			tracer.Close()
+			return nil, err
+		}
+	}
+	tracer.prog, err = ebpf.NewProgram(&ebpf.ProgramSpec{
+		Type:         ebpf.TracePoint,
+		Instructions: insns,
+		License:      "Apache-2.0",
+	})
+	if err != nil {
+		tracer.Close()
+		return nil, fmt.Errorf("loading eBPF program: %v", err)
+	}
+	if tracer.eventFD, err = attachTracepoint(format.id, tracer.prog); err != nil {
+		tracer.Close()
+		return nil, err
+	}
+	if tracer.queue != nil {
+		tracer.wg.Add(1)
+		go tracer.resolveLoop()
+	}
+	return tracer, nil
+}
+
+// raiseMemlockLimit removes RLIMIT_MEMLOCK for the whole process. Kernels
+// before 5.11 account the memory of eBPF maps against this limit, which is
+// usually too low for them. It returns whether the limit was raised.
+func raiseMemlockLimit() bool {
+	var limit unix.Rlimit
+	if err := unix.Getrlimit(unix.RLIMIT_MEMLOCK, &limit); err != nil {
+		logp.Warn("Unable to read RLIMIT_MEMLOCK: %v", err)
+		return false
+	}
+	if limit.Cur == unix.RLIM_INFINITY {
+		return false
+	}
+	logp.Info("Raising RLIMIT_MEMLOCK from %d bytes to unlimited to create the eBPF socket tracer maps", limit.Cur)
+	limit = unix.Rlimit{Cur: unix.RLIM_INFINITY, Max: unix.RLIM_INFINITY}
+	if err := unix.Setrlimit(unix.RLIMIT_MEMLOCK, &limit); err != nil {
+		logp.Warn("Unable to raise RLIMIT_MEMLOCK: %v", err)
+		return false
+	}
+	return true
+}
+
+// Lookup returns the process that last connected from or listened on the
+// given local address and port. When the process metadata couldn't be read
+// while it was running, only its PID and command name are known.
+func (t *ebpfSocketTracer) Lookup(address net.IP, port uint16) (p *process, found bool) {
+	var value tracerValue
+	if err := t.endpoints.Lookup(newTracerKey(address, port), &value); err != nil {
+		if !errors.Is(err, ebpf.ErrKeyNotExist) {
+			logp.Debug("procs", "Error reading eBPF map: %v", err)
+		}
+		return nil, false
+	}
+	if value.pid == 0 {
+		return nil, false
+	}
+	pid := int(value.pid)
+
+	t.mutex.Lock()
+	p, found = t.processes[pid]
+	t.mutex.Unlock()
+	if found {
+		return p, true
+	}
+	return value.process(), true
+}
+
+// Close detaches the program and releases the kernel resources.
+func (t *ebpfSocketTracer) Close() error {
+	if t.queue != nil && t.eventFD >= 0 {
+		close(t.done)
+		t.wg.Wait()
+	}
+	if t.eventFD >= 0 {
+		unix.Close(t.eventFD)
+	}
+	if t.prog != nil {
+		t.prog.Close()
+	}
+	for _, m := range []*ebpf.Map{t.queue, t.connecting, t.endpoints} {
+		if m != nil {
+			m.Close()
+		}
+	}
+	return nil
+}
+
+// resolveLoop periodically reads the metadata of the processes that created
+// sockets since the last run.
+func (t *ebpfSocketTracer) resolveLoop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(tracerResolveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.done:
+			return
+		case <-ticker.C:
+			t.resolve()
+		}
+	}
+}
+
+func (t *ebpfSocketTracer) resolve() {
+	now := time.Now()
+	t.mutex.Lock()
+	for pid, p := range t.processes {
+		if now.After(p.expiration) {
+			delete(t.processes, pid)
+		}
+	}
+	t.mutex.Unlock()
+
+	for {
+		var pid uint32
+		if err := t.queue.LookupAndDelete(nil, &pid); err != nil {
+			if !errors.Is(err, ebpf.ErrKeyNotExist) {
+				logp.Debug("procs", "Error reading eBPF queue: %v", err)
+			}
+			return
+		}
+
+		t.mutex.Lock()
+		_, known := t.processes[int(pid)]
+		t.mutex.Unlock()
+		if known {
+			continue
+		}
+		if p := t.getProcess(int(pid)); p != nil {
+			t.mutex.Lock()
+			t.processes[int(pid)] = p
+			t.mutex.Unlock()
+		}
+	}
+}
+
+// attachTracepoint runs the program on every event of the tracepoint with the
+// given ID. The tracepoint is opened by ID instead of through the link package
+// of cilium/ebpf, which only knows about tracefs under /sys/kernel/debug.
+func attachTracepoint(id int, prog *ebpf.Program) (int, error) {
+	attr := unix.PerfEventAttr{
+		Type:   unix.PERF_TYPE_TRACEPOINT,
+		Size:   unix.PERF_ATTR_SIZE_VER0,
+		Config: uint64(id),
+		Sample: 1,
+		Wakeup: 1,
+	}
+	// The program is attached to the tracepoint itself, so a single event
+	// on any CPU is enough to trace all of them.
+	fd, err := unix.PerfEventOpen(&attr, -1, 0, -1, unix.PERF_FLAG_FD_CLOEXEC)
+	if err != nil {
+		return -1, fmt.Errorf("opening tracepoint %d: %v", id, err)
+	}
+	if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_SET_BPF, prog.FD()); err != nil {
+		unix.Close(fd)
+		return -1, fmt.Errorf("attaching eBPF program: %v", err)
+	}
+	if err := unix.IoctlSetInt(fd, unix.PERF_EVENT_IOC_ENABLE, 0); err != nil {
+		unix.Close(fd)
+		return -1, fmt.Errorf("enabling tracepoint: %v", err)
+	}
+	return fd, nil
+}
+
+func loadTracepointFormat(category, name string) (*tracepointFormat, error) {
+	for _, base := range tracingPaths {
+		f, err := os.Open(filepath.Join(base, "events", category, name, "format"))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+		return parseTracepointFormat(f)
+	}
+	return nil, fmt.Errorf("tracepoint %s:%s not found, tracefs must be mounted in one of %v",
+		category, name, tracingPaths)
+}
+
+// parseTracepointFormat parses the format file of a tracepoint as found in
+// tracefs.
+func parseTracepointFormat(r io.Reader) (*tracepointFormat, error) {
+	format := &tracepointFormat{fields: map[string]tracepointField{}}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "ID:") {
+			id, err := strconv.Atoi(strings.TrimSpace(line[3:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid tracepoint ID: %v", err)
+			}
+			format.id = id
+			continue
+		}
+		if !strings.HasPrefix(line, "field:") {
+			continue
+		}
+		var name string
+		var field tracepointField
+		for _, part := range strings.Split(line, ";") {
+			kv := strings.SplitN(strings.TrimSpace(part), ":", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			var err error
+			switch kv[0] {
+			case "field":
+				decl := strings.Fields(kv[1])
+				name = decl[len(decl)-1]
+				if n := strings.IndexByte(name, '['); n >= 0 {
+					name = name[:n]
+				}
+			case "offset":
+				field.offset, err = strconv.Atoi(kv[1])
+			case "size":
+				field.size, err = strconv.Atoi(kv[1])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid tracepoint field '%s': %v", line, err)
+			}
+		}
+		format.fields[name] = field
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if format.id == 0 {
+		return nil, fmt.Errorf("tracepoint ID not found")
+	}
+	return format, nil
+}
+
+// field returns the offset of a tracepoint field, checking that it has the
+// expected size and can be read with aligned loads of the given width.
+func (f *tracepointFormat) field(name string, size, align int) (int16, error) {
+	field, ok := f.fields[name]
+	if !ok {
+		return 0, fmt.Errorf("tracepoint field '%s' not found", name)
+	}
+	if field.size != size || field.offset%align != 0 {
+		return 0, fmt.Errorf("unexpected layout of tracepoint field '%s' (offset=%d size=%d)",
+			name, field.offset, field.size)
+	}
+	return int16(field.offset), nil
+}
+
+// socketTracerProgram builds the eBPF program attached to the
+// sock:inet_sock_set_state tracepoint. The maps are referenced by name, the
+// queue only if withQueue is set.
+//
+// Sockets that start listening are recorded right away. Connecting sockets
+// move to SYN_SENT before the kernel picks their local port, so the calling
+// process is kept in the connecting map until the connection is established,
+// which often happens outside of the context of the process.
+func socketTracerProgram(format *tracepointFormat, withQueue bool) (asm.Instructions, error) {
+	var offsets struct {
+		skaddr, oldstate, newstate, protocol, family, sport, saddr, saddrV6 int16
+	}
+	for _, f := range []struct {
+		dst         *int16
+		name        string
+		size, align int
+	}{
+		{&offsets.skaddr, "skaddr", 8, 8},
+		{&offsets.oldstate, "oldstate", 4, 4},
+		{&offsets.newstate, "newstate", 4, 4},
+		{&offsets.protocol, "protocol", 2, 2},
+		{&offsets.family, "family", 2, 2},
+		{&offsets.sport, "sport", 2, 2},
+		{&offsets.saddr, "saddr", 4, 4},
+		{&offsets.saddrV6, "saddr_v6", 16, 4},
+	} {
+		var err error
+		if *f.dst, err = format.field(f.name, f.size, f.align); err != nil {
+			return nil, err
+		}
+	}
+
+	// Stack layout: the endpoint (tracerKey) at fp-24, the process
+	// (tracerValue) at fp-48 and the socket address at fp-56.
+	const (
+		keyOff    = -24
+		portOff   = keyOff + 16
+		familyOff = keyOff + 18
+		valueOff  = -48
+		commOff   = valueOff + 8
+		sockOff   = -56
+	)
+
+	var insns asm.Instructions
+	var label string
+	emit := func(ins ...asm.Instruction) {
+		if label != "" {
+			ins[0] = ins[0].WithSymbol(label)
+			label = ""
+		}
+		insns = append(insns, ins...)
+	}
+
+	// currentProcess stores the PID and command name of the calling process
+	// in the stack and pushes the PID to the queue.
+	currentProcess := func() {
+		for off := int16(valueOff); off < keyOff; off += 8 {
+			emit(asm.StoreImm(asm.RFP, off, 0, asm.DWord))
+		}
+		emit(
+			asm.FnGetCurrentPidTgid.Call(),
+			asm.RSh.Imm(asm.R0, 32),
+			asm.StoreMem(asm.RFP, valueOff, asm.R0, asm.Word),
+			asm.Mov.Reg(asm.R1, asm.RFP),
+			asm.Add.Imm(asm.R1, commOff),
+			asm.Mov.Imm(asm.R2, 16),
+			asm.FnGetCurrentComm.Call(),
+		)
+		if withQueue {
+			emit(
+				asm.LoadMapPtr(asm.R1, 0).WithReference(queueMap),
+				asm.Mov.Reg(asm.R2, asm.RFP),
+				asm.Add.Imm(asm.R2, valueOff),
+				asm.Mov.Imm(asm.R3, bpfExist),
+				asm.FnMapPushElem.Call(),
+			)
+		}
+	}
+
+	// socketKey stores the address of the socket in the stack and leaves a
+	// pointer to it in r2.
+	socketKey := func() {
+		emit(
+			asm.LoadMem(asm.R2, asm.R6, offsets.skaddr, asm.DWord),
+			asm.StoreMem(asm.RFP, sockOff, asm.R2, asm.DWord),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, sockOff),
+		)
+	}
+
+	emit(
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R2, asm.R6, offsets.protocol, asm.Half),
+		asm.JNE.Imm(asm.R2, ipprotoTCP, "exit"),
+		asm.LoadMem(asm.R2, asm.R6, offsets.newstate, asm.Word),
+		asm.JEq.Imm(asm.R2, tcpSynSent, "connect"),
+		asm.JEq.Imm(asm.R2, tcpListen, "listen"),
+		asm.JNE.Imm(asm.R2, tcpEstablished, "exit"),
+		asm.LoadMem(asm.R2, asm.R6, offsets.oldstate, asm.Word),
+		asm.JNE.Imm(asm.R2, tcpSynSent, "exit"),
+	)
+
+	// Connection established: take the process saved by connect.
+	emit(asm.LoadMapPtr(asm.R1, 0).WithReference(connectingMap))
+	socketKey()
+	emit(
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+	)
+	for i := int16(0); i < keyOff-valueOff; i += 8 {
+		emit(
+			asm.LoadMem(asm.R2, asm.R0, i, asm.DWord),
+			asm.StoreMem(asm.RFP, valueOff+i, asm.R2, asm.DWord),
+		)
+	}
+	emit(asm.LoadMapPtr(asm.R1, 0).WithReference(connectingMap))
+	socketKey()
+	emit(
+		asm.FnMapDeleteElem.Call(),
+		asm.Ja.Label("endpoint"),
+	)
+
+	// Connecting: save the calling process until the port is known.
+	label = "connect"
+	currentProcess()
+	emit(asm.LoadMapPtr(asm.R1, 0).WithReference(connectingMap))
+	socketKey()
+	emit(
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, valueOff),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnMapUpdateElem.Call(),
+		asm.Ja.Label("exit"),
+	)
+
+	// Listening: the calling process owns the socket.
+	label = "listen"
+	currentProcess()
+
+	label = "endpoint"
+	for off := int16(keyOff); off < 0; off += 8 {
+		emit(asm.StoreImm(asm.RFP, off, 0, asm.DWord))
+	}
+	emit(
+		asm.LoadMem(asm.R2, asm.R6, offsets.sport, asm.Half),
+		asm.StoreMem(asm.RFP, portOff, asm.R2, asm.Half),
+		asm.LoadMem(asm.R2, asm.R6, offsets.family, asm.Half),
+		asm.StoreMem(asm.RFP, familyOff, asm.R2, asm.Half),
+		asm.JEq.Imm(asm.R2, afInet6, "ipv6"),
+		asm.JNE.Imm(asm.R2, afInet, "exit"),
+		asm.LoadMem(asm.R2, asm.R6, offsets.saddr, asm.Word),
+		asm.StoreMem(asm.RFP, keyOff, asm.R2, asm.Word),
+		asm.Ja.Label("update"),
+	)
+	label = "ipv6"
+	for i := int16(0); i < 16; i += 4 {
+		emit(
+			asm.LoadMem(asm.R2, asm.R6, offsets.saddrV6+i, asm.Word),
+			asm.StoreMem(asm.RFP, keyOff+i, asm.R2, asm.Word),
+		)
+	}
+	label = "update"
+	emit(
+		asm.LoadMapPtr(asm.R1, 0).WithReference(endpointsMap),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, keyOff),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, valueOff),
+		asm.Mov.Imm(asm.R4, 0),
+		asm.FnMapUpdateElem.Call(),
+	)
+
+	label = "exit"
+	emit(
+		asm.Mov.Imm(asm.R0, 0),
+		asm.Return(),
+	)
+	return insns, nil
+}
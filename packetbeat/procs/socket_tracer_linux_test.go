@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package procs
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cilium/ebpf/asm"
+	"github.com/stretchr/testify/assert"
+)
+
+const inetSockSetStateFormat = `name: inet_sock_set_state
+ID: 1382
+format:
+	field:unsigned short common_type;	offset:0;	size:2;	signed:0;
+	field:unsigned char common_flags;	offset:2;	size:1;	signed:0;
+	field:unsigned char common_preempt_count;	offset:3;	size:1;	signed:0;
+	field:int common_pid;	offset:4;	size:4;	signed:1;
+
+	field:const void * skaddr;	offset:8;	size:8;	signed:0;
+	field:int oldstate;	offset:16;	size:4;	signed:1;
+	field:int newstate;	offset:20;	size:4;	signed:1;
+	field:__u16 sport;	offset:24;	size:2;	signed:0;
+	field:__u16 dport;	offset:26;	size:2;	signed:0;
+	field:__u16 family;	offset:28;	size:2;	signed:0;
+	field:__u16 protocol;	offset:30;	size:2;	signed:0;
+	field:__u8 saddr[4];	offset:32;	size:4;	signed:0;
+	field:__u8 daddr[4];	offset:36;	size:4;	signed:0;
+	field:__u8 saddr_v6[16];	offset:40;	size:16;	signed:0;
+	field:__u8 daddr_v6[16];	offset:56;	size:16;	signed:0;
+
+print fmt: "family=%s protocol=%s sport=%hu dport=%hu"
+`
+
+func TestParseTracepointFormat(t *testing.T) {
+	format, err := parseTracepointFormat(strings.NewReader(inetSockSetStateFormat))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 1382, format.id)
+	assert.Equal(t, tracepointField{offset: 8, size: 8}, format.fields["skaddr"])
+	assert.Equal(t, tracepointField{offset: 20, size: 4}, format.fields["newstate"])
+	assert.Equal(t, tracepointField{offset: 40, size: 16}, format.fields["saddr_v6"])
+
+	_, err = parseTracepointFormat(strings.NewReader("format:\n"))
+	assert.Error(t, err)
+}
+
+func TestSocketTracerProgram(t *testing.T) {
+	format, err := parseTracepointFormat(strings.NewReader(inetSockSetStateFormat))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, withQueue := range []bool{false, true} {
+		insns, err := socketTracerProgram(format, withQueue)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, asm.Return(), insns[len(insns)-1])
+
+		// All the jumps must resolve to a label.
+		var buf bytes.Buffer
+		assert.NoError(t, insns.Marshal(&buf, nativeEndian))
+
+		refs := insns.ReferenceOffsets()
+		assert.Contains(t, refs, endpointsMap)
+		assert.Contains(t, refs, connectingMap)
+		if withQueue {
+			assert.Contains(t, refs, queueMap)
+		} else {
+			assert.NotContains(t, refs, queueMap)
+		}
+	}
+
+	// Kernels without the family and protocol fields are not supported.
+	delete(format.fields, "family")
+	_, err = socketTracerProgram(format, false)
+	assert.Error(t, err)
+
+	format.fields["family"] = tracepointField{offset: 29, size: 2}
+	_, err = socketTracerProgram(format, false)
+	assert.Error(t, err)
+}
+
+func TestTracerKey(t *testing.T) {
+	for _, test := range []struct {
+		address string
+		family  uint16
+		addr    []byte
+	}{
+		{"10.1.2.3", afInet, []byte{10, 1, 2, 3}},
+		{"::ffff:10.1.2.3", afInet, []byte{10, 1, 2, 3}},
+		{"2001:db8::1", afInet6, net.ParseIP("2001:db8::1")},
+	} {
+		buf, err := newTracerKey(net.ParseIP(test.address), 8080).MarshalBinary()
+		if !assert.NoError(t, err, test.address) {
+			continue
+		}
+		if !assert.Len(t, buf, tracerKeySize, test.address) {
+			continue
+		}
+		addr := make([]byte, 16)
+		copy(addr, test.addr)
+		assert.Equal(t, addr, buf[:16], test.address)
+		assert.Equal(t, uint16(8080), nativeEndian.Uint16(buf[16:]), test.address)
+		assert.Equal(t, test.family, nativeEndian.Uint16(buf[18:]), test.address)
+		assert.Equal(t, make([]byte, 4), buf[20:], test.address)
+	}
+}
+
+func TestTracerValue(t *testing.T) {
+	buf := make([]byte, tracerValueSize)
+	nativeEndian.PutUint32(buf, 4242)
+	copy(buf[8:], "curl\x00garbage")
+
+	var value tracerValue
+	if !assert.NoError(t, value.UnmarshalBinary(buf)) {
+		return
+	}
+	p := value.process()
+	assert.Equal(t, 4242, p.pid)
+	assert.Equal(t, "curl", p.name)
+	assert.True(t, p.expiration.After(time.Now()))
+
+	// Command names of 16 characters are not terminated.
+	copy(buf[8:], "0123456789abcdef")
+	if assert.NoError(t, value.UnmarshalBinary(buf)) {
+		assert.Equal(t, "0123456789abcdef", value.process().name)
+	}
+
+	assert.Error(t, value.UnmarshalBinary(buf[:16]))
+}
+
+func TestSocketTracer(t *testing.T) {
+	tracer, err := newSocketTracer(ProcWatcher.GetProcess)
+	if err != nil {
+		t.Skipf("eBPF socket tracing not available: %v", err)
+	}
+	defer tracer.(*ebpfSocketTracer).Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	serverAddr := listener.Addr().(*net.TCPAddr)
+	p, found := tracer.Lookup(serverAddr.IP, uint16(serverAddr.Port))
+	if assert.True(t, found, "listening socket not traced") {
+		assert.Equal(t, os.Getpid(), p.pid)
+	}
+
+	conn, err := net.Dial("tcp", serverAddr.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	clientAddr := conn.LocalAddr().(*net.TCPAddr)
+	conn.Close()
+
+	p, found = tracer.Lookup(clientAddr.IP, uint16(clientAddr.Port))
+	if assert.True(t, found, "client socket not traced") {
+		assert.Equal(t, os.Getpid(), p.pid)
+	}
+
+	if tracer.(*ebpfSocketTracer).queue == nil {
+		return
+	}
+	exe, err := os.Executable()
+	if !assert.NoError(t, err) {
+		return
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(tracerResolveInterval) {
+		if p, _ = tracer.Lookup(clientAddr.IP, uint16(clientAddr.Port)); p.exe != "" {
+			break
+		}
+	}
+	assert.Equal(t, exe, p.exe)
+}
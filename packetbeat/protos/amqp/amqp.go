@@ -40,6 +40,7 @@ type amqpPlugin struct {
 	ports                     []int
 	sendRequest               bool
 	sendResponse              bool
+	capture                   *protos.PayloadCapture
 	maxBodyLength             int
 	parseHeaders              bool
 	parseArguments            bool
@@ -84,6 +85,12 @@ func (amqp *amqpPlugin) init(results protos.Reporter, config *amqpConfig) error
 	amqp.initMethodMap()
 	amqp.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	amqp.capture = capture
+
 	if amqp.hideConnectionInformation == false {
 		amqp.addConnectionMethods()
 	}
@@ -452,7 +459,8 @@ func (amqp *amqpPlugin) publishTransaction(t *amqpTransaction) {
 	fields["amqp"] = t.amqp
 
 	//let's try to convert request/response to a readable format
-	if amqp.sendRequest {
+	capture := amqp.capture.Selected(fields)
+	if amqp.sendRequest || capture {
 		if t.method == "basic.publish" {
 			if t.toString {
 				if uint64(len(t.body)) < t.bytesIn {
@@ -471,7 +479,7 @@ func (amqp *amqpPlugin) publishTransaction(t *amqpTransaction) {
 			fields["request"] = t.request
 		}
 	}
-	if amqp.sendResponse {
+	if amqp.sendResponse || capture {
 		if t.method == "basic.deliver" || t.method == "basic.return" ||
 			t.method == "basic.get" {
 			if t.toString {
@@ -491,6 +499,9 @@ func (amqp *amqpPlugin) publishTransaction(t *amqpTransaction) {
 			fields["response"] = t.response
 		}
 	}
+	if capture {
+		amqp.capture.Excerpt(fields, amqp.sendRequest, amqp.sendResponse)
+	}
 
 	amqp.results(evt)
 }
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protos
+
+import (
+	"math/rand"
+	"regexp"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+// PayloadCapture selects transactions whose request and response payloads
+// are published as size capped, redacted excerpts. A nil PayloadCapture
+// selects no transaction.
+type PayloadCapture struct {
+	samplePercentage float64
+	onError          bool
+	maxBytes         int
+	redact           []*regexp.Regexp
+
+	// random returns a number in [0, 100).
+	random func() float64
+}
+
+// NewPayloadCapture creates a PayloadCapture from the protocol capture
+// settings. It returns nil if no transaction is to be captured.
+func NewPayloadCapture(cfg *config.CaptureConfig) (*PayloadCapture, error) {
+	if cfg == nil || (cfg.SamplePercentage <= 0 && !cfg.OnError) {
+		return nil, nil
+	}
+
+	redact := make([]*regexp.Regexp, 0, len(cfg.Redact))
+	for _, pattern := range cfg.Redact {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		redact = append(redact, re)
+	}
+
+	return &PayloadCapture{
+		samplePercentage: cfg.SamplePercentage,
+		onError:          cfg.OnError,
+		maxBytes:         cfg.MaxBytes,
+		redact:           redact,
+		random:           func() float64 { return rand.Float64() * 100 },
+	}, nil
+}
+
+// Selected reports whether the payloads of the transaction published in
+// fields are captured. Failed transactions are identified by their status
+// field.
+func (c *PayloadCapture) Selected(fields common.MapStr) bool {
+	if c == nil {
+		return false
+	}
+	if status, _ := fields["status"].(string); c.onError && status == common.ERROR_STATUS {
+		return true
+	}
+	return c.samplePercentage > 0 && c.random() < c.samplePercentage
+}
+
+// Excerpt replaces the request and response fields of a captured
+// transaction with their redacted excerpts. Payloads that are published in
+// full because of send_request or send_response are left untouched, as are
+// fields that are not strings.
+func (c *PayloadCapture) Excerpt(fields common.MapStr, sendRequest, sendResponse bool) {
+	if !sendRequest {
+		c.excerptField(fields, "request")
+	}
+	if !sendResponse {
+		c.excerptField(fields, "response")
+	}
+}
+
+func (c *PayloadCapture) excerptField(fields common.MapStr, key string) {
+	var payload string
+	switch v := fields[key].(type) {
+	case string:
+		payload = v
+	case common.NetString:
+		payload = string(v)
+	case []byte:
+		payload = string(v)
+	default:
+		return
+	}
+	fields[key] = c.excerpt(payload)
+}
+
+func (c *PayloadCapture) excerpt(payload string) string {
+	// Redact before truncating, so partial matches are not left at the end.
+	for _, re := range c.redact {
+		payload = re.ReplaceAllString(payload, "REDACTED")
+	}
+	if len(payload) > c.maxBytes {
+		payload = payload[:c.maxBytes] + " [...]"
+	}
+	return payload
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package protos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+func TestPayloadCaptureDisabled(t *testing.T) {
+	for name, cfg := range map[string]*config.CaptureConfig{
+		"not configured": nil,
+		"no sampling":    {MaxBytes: 10},
+	} {
+		t.Run(name, func(t *testing.T) {
+			capture, err := NewPayloadCapture(cfg)
+			require.NoError(t, err)
+			assert.Nil(t, capture)
+			assert.False(t, capture.Selected(common.MapStr{"status": common.ERROR_STATUS}))
+		})
+	}
+}
+
+func TestPayloadCaptureSelected(t *testing.T) {
+	capture, err := NewPayloadCapture(&config.CaptureConfig{SamplePercentage: 25, OnError: true, MaxBytes: 10})
+	require.NoError(t, err)
+
+	capture.random = func() float64 { return 50 }
+	assert.False(t, capture.Selected(common.MapStr{"status": common.OK_STATUS}))
+	assert.True(t, capture.Selected(common.MapStr{"status": common.ERROR_STATUS}))
+
+	capture.random = func() float64 { return 10 }
+	assert.True(t, capture.Selected(common.MapStr{"status": common.OK_STATUS}))
+}
+
+func TestPayloadCaptureExcerpt(t *testing.T) {
+	capture, err := NewPayloadCapture(&config.CaptureConfig{
+		SamplePercentage: 100,
+		MaxBytes:         20,
+		Redact:           []string{`password=\S+`},
+	})
+	require.NoError(t, err)
+
+	fields := common.MapStr{
+		"request":  "user=admin password=secret",
+		"response": "HTTP/1.1 200 OK with a long body",
+	}
+	capture.Excerpt(fields, false, false)
+	assert.Equal(t, "user=admin REDACTED", fields["request"])
+	assert.Equal(t, "HTTP/1.1 200 OK with [...]", fields["response"])
+
+	t.Run("full payloads are kept", func(t *testing.T) {
+		fields := common.MapStr{"request": "password=secret", "response": "ok"}
+		capture.Excerpt(fields, true, false)
+		assert.Equal(t, "password=secret", fields["request"])
+		assert.Equal(t, "ok", fields["response"])
+	})
+
+	t.Run("non string values are kept", func(t *testing.T) {
+		fields := common.MapStr{"request": common.MapStr{"password": "secret"}}
+		capture.Excerpt(fields, false, false)
+		assert.Equal(t, common.MapStr{"password": "secret"}, fields["request"])
+	})
+}
+
+func TestPayloadCaptureInvalidPattern(t *testing.T) {
+	_, err := NewPayloadCapture(&config.CaptureConfig{OnError: true, MaxBytes: 10, Redact: []string{"("}})
+	assert.Error(t, err)
+}
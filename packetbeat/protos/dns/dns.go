@@ -46,6 +46,7 @@ type dnsPlugin struct {
 	ports              []int
 	sendRequest        bool
 	sendResponse       bool
+	capture            *protos.PayloadCapture
 	includeAuthorities bool
 	includeAdditionals bool
 
@@ -238,6 +239,12 @@ func New(
 
 func (dns *dnsPlugin) init(results protos.Reporter, config *dnsConfig) error {
 	dns.setFromConfig(config)
+
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	dns.capture = capture
 	dns.transactions = common.NewCacheWithRemovalListener(
 		dns.transactionTimeout,
 		protos.DefaultTransactionHashSize,
@@ -437,6 +444,16 @@ func (dns *dnsPlugin) publishTransaction(t *dnsTransaction) {
 		}
 	}
 
+	if dns.capture.Selected(fields) {
+		if !dns.sendRequest && t.request != nil {
+			fields["request"] = dnsToString(t.request.data)
+		}
+		if !dns.sendResponse && t.response != nil {
+			fields["response"] = dnsToString(t.response.data)
+		}
+		dns.capture.Excerpt(fields, dns.sendRequest, dns.sendResponse)
+	}
+
 	dns.results(evt)
 }
 
@@ -85,6 +85,7 @@ type httpPlugin struct {
 	ports               []int
 	sendRequest         bool
 	sendResponse        bool
+	capture             *protos.PayloadCapture
 	splitCookie         bool
 	hideKeywords        []string
 	redactAuthorization bool
@@ -131,6 +132,12 @@ func New(
 func (http *httpPlugin) init(results protos.Reporter, config *httpConfig) error {
 	http.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	http.capture = capture
+
 	isDebug = logp.IsDebug("http")
 	isDetailed = logp.IsDebug("httpdetailed")
 	http.results = results
@@ -536,6 +543,7 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 	fields := evt.Fields
 	fields["type"] = pbf.Network.Protocol
 	fields["status"] = status
+	capture := http.capture.Selected(fields)
 
 	var httpFields ProtocolFields
 	if requ != nil {
@@ -580,7 +588,7 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 		pb.MarshalStruct(evt.Fields, "user_agent", userAgent)
 
 		// packetbeat root fields
-		if http.sendRequest {
+		if http.sendRequest || capture {
 			fields["request"] = string(http.makeRawMessage(requ))
 		}
 		fields["method"] = httpFields.RequestMethod
@@ -606,11 +614,15 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 		httpFields.ResponseHeaders = http.collectHeaders(resp)
 
 		// packetbeat root fields
-		if http.sendResponse {
+		if http.sendResponse || capture {
 			fields["response"] = string(http.makeRawMessage(resp))
 		}
 	}
 
+	if capture {
+		http.capture.Excerpt(fields, http.sendRequest, http.sendResponse)
+	}
+
 	pb.MarshalStruct(evt.Fields, "http", httpFields)
 	return evt
 }
@@ -1296,6 +1296,52 @@ func testCreateTCPTuple() *common.TCPTuple {
 	return t
 }
 
+func TestHttpParser_captureOnError(t *testing.T) {
+	req := "POST / HTTP/1.1\r\n" +
+		"\r\n"
+	resp := "HTTP/1.1 404 Not Found\r\n" +
+		"Content-Length: 9\r\n" +
+		"\r\n" +
+		"not found"
+
+	var store eventStore
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"capture": map[string]interface{}{
+			"on_error":  true,
+			"max_bytes": 10,
+			"redact":    []string{"POST"},
+		},
+	})
+	plugin, err := New(false, store.publish, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	http := plugin.(*httpPlugin)
+
+	tcptuple := testCreateTCPTuple()
+	packet := protos.Packet{Payload: []byte(req)}
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = http.Parse(&packet, tcptuple, 0, private)
+	http.ReceivedFin(tcptuple, 0, private)
+
+	packet.Payload = []byte(resp)
+	private = http.Parse(&packet, tcptuple, 1, private)
+	http.ReceivedFin(tcptuple, 1, private)
+
+	trans := expectTransaction(t, &store)
+	assert.NotNil(t, trans)
+	contents, err := trans.GetValue("request")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "REDACTED / [...]", contents)
+	contents, err = trans.GetValue("response")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "HTTP/1.1 4 [...]", contents)
+}
+
 // Helper function to read from the Publisher Queue
 func expectTransaction(t *testing.T, e *eventStore) common.MapStr {
 	if len(e.events) == 0 {
@@ -39,6 +39,7 @@ type mongodbPlugin struct {
 	ports        []int
 	sendRequest  bool
 	sendResponse bool
+	capture      *protos.PayloadCapture
 	maxDocs      int
 	maxDocLength int
 
@@ -85,6 +86,12 @@ func (mongodb *mongodbPlugin) init(results protos.Reporter, config *mongodbConfi
 	debugf("Init a MongoDB protocol parser")
 	mongodb.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	mongodb.capture = capture
+
 	mongodb.requests = common.NewCache(
 		mongodb.transactionTimeout,
 		protos.DefaultTransactionHashSize)
@@ -401,10 +408,11 @@ func (mongodb *mongodbPlugin) publishTransaction(t *transaction) {
 	fields["resource"] = t.resource
 	fields["query"] = reconstructQuery(t, false)
 
-	if mongodb.sendRequest {
+	capture := mongodb.capture.Selected(fields)
+	if mongodb.sendRequest || capture {
 		fields["request"] = reconstructQuery(t, true)
 	}
-	if mongodb.sendResponse {
+	if mongodb.sendResponse || capture {
 		if len(t.documents) > 0 {
 			// response field needs to be a string
 			docs := make([]string, 0, len(t.documents))
@@ -426,6 +434,9 @@ func (mongodb *mongodbPlugin) publishTransaction(t *transaction) {
 			fields["response"] = strings.Join(docs, "\n")
 		}
 	}
+	if capture {
+		mongodb.capture.Excerpt(fields, mongodb.sendRequest, mongodb.sendResponse)
+	}
 
 	mongodb.results(evt)
 }
@@ -149,6 +149,7 @@ type mysqlPlugin struct {
 	maxRowLength int
 	sendRequest  bool
 	sendResponse bool
+	capture      *protos.PayloadCapture
 
 	transactions       *common.Cache
 	transactionTimeout time.Duration
@@ -190,6 +191,12 @@ func New(
 func (mysql *mysqlPlugin) init(results protos.Reporter, config *mysqlConfig) error {
 	mysql.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	mysql.capture = capture
+
 	mysql.transactions = common.NewCache(
 		mysql.transactionTimeout,
 		protos.DefaultTransactionHashSize)
@@ -1184,12 +1191,16 @@ func (mysql *mysqlPlugin) publishTransaction(t *mysqlTransaction) {
 		fields["status"] = common.OK_STATUS
 	}
 
-	if mysql.sendRequest {
+	capture := mysql.capture.Selected(fields)
+	if mysql.sendRequest || capture {
 		fields["request"] = t.requestRaw
 	}
-	if mysql.sendResponse {
+	if mysql.sendResponse || capture {
 		fields["response"] = t.responseRaw
 	}
+	if capture {
+		mysql.capture.Excerpt(fields, mysql.sendRequest, mysql.sendResponse)
+	}
 
 	mysql.results(evt)
 }
@@ -44,6 +44,7 @@ type pgsqlPlugin struct {
 	maxRowLength int
 	sendRequest  bool
 	sendResponse bool
+	capture      *protos.PayloadCapture
 
 	transactions       *common.Cache
 	transactionTimeout time.Duration
@@ -159,6 +160,12 @@ func New(
 func (pgsql *pgsqlPlugin) init(results protos.Reporter, config *pgsqlConfig) error {
 	pgsql.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	pgsql.capture = capture
+
 	pgsql.log = logp.NewLogger("pgsql")
 	pgsql.debug = logp.NewLogger("pgsql", zap.AddCallerSkip(1))
 	pgsql.detail = logp.NewLogger("pgsqldetailed", zap.AddCallerSkip(1))
@@ -497,12 +504,16 @@ func (pgsql *pgsqlPlugin) publishTransaction(t *pgsqlTransaction) {
 	} else {
 		fields["status"] = common.OK_STATUS
 	}
-	if pgsql.sendRequest {
+	capture := pgsql.capture.Selected(fields)
+	if pgsql.sendRequest || capture {
 		fields["request"] = t.requestRaw
 	}
-	if pgsql.sendResponse {
+	if pgsql.sendResponse || capture {
 		fields["response"] = t.responseRaw
 	}
+	if capture {
+		pgsql.capture.Excerpt(fields, pgsql.sendRequest, pgsql.sendResponse)
+	}
 
 	pgsql.results(evt)
 }
@@ -52,6 +52,7 @@ type redisPlugin struct {
 	ports              []int
 	sendRequest        bool
 	sendResponse       bool
+	capture            *protos.PayloadCapture
 	transactionTimeout time.Duration
 	queueConfig        MessageQueueConfig
 
@@ -94,6 +95,12 @@ func New(
 func (redis *redisPlugin) init(results protos.Reporter, config *redisConfig) error {
 	redis.setFromConfig(config)
 
+	capture, err := protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
+	redis.capture = capture
+
 	redis.results = results
 	isDebug = logp.IsDebug("redis")
 
@@ -320,12 +327,16 @@ func (redis *redisPlugin) newTransaction(requ, resp *redisMessage) beat.Event {
 		evt.PutValue("redis.return_value", resp.message)
 	}
 
-	if redis.sendRequest {
+	capture := redis.capture.Selected(fields)
+	if redis.sendRequest || capture {
 		fields["request"] = requ.message
 	}
-	if redis.sendResponse {
+	if redis.sendResponse || capture {
 		fields["response"] = resp.message
 	}
+	if capture {
+		redis.capture.Excerpt(fields, redis.sendRequest, redis.sendResponse)
+	}
 
 	pbf.Event.Action = "redis." + strings.ToLower(string(requ.method))
 	if resp.isError {
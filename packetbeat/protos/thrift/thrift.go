@@ -48,6 +48,7 @@ type thriftPlugin struct {
 	obfuscateStrings       bool
 	sendRequest            bool
 	sendResponse           bool
+	capture                *protos.PayloadCapture
 
 	TransportType byte
 	ProtocolType  byte
@@ -252,6 +253,10 @@ func (thrift *thriftPlugin) readConfig(config *thriftConfig) error {
 	thrift.ports = config.Ports
 	thrift.sendRequest = config.SendRequest
 	thrift.sendResponse = config.SendResponse
+	thrift.capture, err = protos.NewPayloadCapture(config.Capture)
+	if err != nil {
+		return err
+	}
 
 	thrift.stringMaxSize = config.StringMaxSize
 	thrift.collectionMaxSize = config.CollectionMaxSize
@@ -1101,6 +1106,7 @@ func (thrift *thriftPlugin) publishTransactions() {
 		fields := evt.Fields
 		fields["type"] = pbf.Event.Dataset
 		fields["status"] = status
+		capture := thrift.capture.Selected(fields)
 		thriftFields := common.MapStr{}
 		fields["thrift"] = thriftFields
 
@@ -1116,7 +1122,7 @@ func (thrift *thriftPlugin) publishTransactions() {
 				thriftFields["service"] = t.request.service
 			}
 
-			if thrift.sendRequest {
+			if thrift.sendRequest || capture {
 				fields["request"] = query
 			}
 		}
@@ -1129,7 +1135,7 @@ func (thrift *thriftPlugin) publishTransactions() {
 				thriftFields["exceptions"] = t.reply.exceptions
 			}
 
-			if thrift.sendResponse {
+			if thrift.sendResponse || capture {
 				if !t.reply.hasException {
 					fields["response"] = t.reply.returnValue
 				} else {
@@ -1140,6 +1146,9 @@ func (thrift *thriftPlugin) publishTransactions() {
 
 			pbf.Error.Message = t.reply.notes
 		}
+		if capture {
+			thrift.capture.Excerpt(fields, thrift.sendRequest, thrift.sendResponse)
+		}
 
 		if thrift.results != nil {
 			thrift.results(evt)
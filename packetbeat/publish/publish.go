@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 )
 
 type TransactionPublisher struct {
@@ -40,6 +41,7 @@ type transProcessor struct {
 	ignoreOutgoing bool
 	localIPs       []net.IP // TODO: Periodically update this list.
 	name           string
+	tunnels        *tunnel.Table
 }
 
 var debugf = logp.MakeDebug("publish")
@@ -74,6 +76,12 @@ func NewTransactionPublisher(
 	return p, nil
 }
 
+// SetTunnels sets the table of the tunnels decapsulated by the decoders. The
+// tunnel of the connection of each transaction is added to its event.
+func (p *TransactionPublisher) SetTunnels(tunnels *tunnel.Table) {
+	p.processor.tunnels = tunnels
+}
+
 func (p *TransactionPublisher) Stop() {
 	close(p.done)
 }
@@ -157,11 +165,32 @@ func (p *transProcessor) Run(event *beat.Event) (*beat.Event, error) {
 				fields.Source.IP, fields.Destination.IP)
 			return nil, nil
 		}
+
+		if p.tunnels != nil {
+			p.addTunnel(event, fields)
+		}
 	}
 
 	return event, nil
 }
 
+// addTunnel adds the tunnel the transaction was decapsulated from.
+func (p *transProcessor) addTunnel(event *beat.Event, fields *pb.Fields) {
+	if fields.Source == nil || fields.Destination == nil {
+		return
+	}
+	srcIP, dstIP := net.ParseIP(fields.Source.IP), net.ParseIP(fields.Destination.IP)
+	if srcIP == nil || dstIP == nil {
+		return
+	}
+	info, ok := p.tunnels.Lookup(
+		srcIP, uint16(fields.Source.Port),
+		dstIP, uint16(fields.Destination.Port))
+	if ok {
+		event.Fields["tunnel"] = info.Fields()
+	}
+}
+
 // filterEvent validates an event for common required fields with types.
 // If event is to be filtered out the reason is returned as error.
 func validateEvent(event *beat.Event) error {
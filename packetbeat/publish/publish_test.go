@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/tunnel"
 	"github.com/elastic/ecs/code/go/ecs"
 )
 
@@ -185,4 +186,27 @@ func TestPublish(t *testing.T) {
 			assert.Nil(t, res)
 		}
 	})
+
+	t.Run("tunnel", func(t *testing.T) {
+		tunnels := tunnel.NewTable(time.Minute)
+		inner := common.NewIPPortTuple(4,
+			net.ParseIP(dstIP).To4(), 32232,
+			net.ParseIP(srcIP).To4(), 3267)
+		tunnels.Put(&inner, &tunnel.Info{Type: tunnel.VXLAN, ID: 42, HasID: true}, time.Now())
+
+		processor := transProcessor{
+			name:    "test",
+			tunnels: tunnels,
+		}
+
+		res, _ := processor.Run(event())
+		if res == nil {
+			t.Fatalf("event has been filtered out")
+		}
+
+		typ, _ := res.GetValue("tunnel.type")
+		assert.Equal(t, "vxlan", typ)
+		id, _ := res.GetValue("tunnel.id")
+		assert.Equal(t, uint32(42), id)
+	})
 }
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tunnel
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Table remembers the tunnel of the inner connections seen by the decoders,
+// so the transaction publisher can add it to the transaction events.
+type Table struct {
+	mu        sync.Mutex
+	timeout   time.Duration
+	entries   map[common.HashableIPPortTuple]*entry
+	lastSweep time.Time
+}
+
+type entry struct {
+	info Info
+	ts   time.Time
+}
+
+// NewTable creates a table that forgets the tunnel of a connection once no
+// packet was seen for timeout.
+func NewTable(timeout time.Duration) *Table {
+	return &Table{
+		timeout: timeout,
+		entries: map[common.HashableIPPortTuple]*entry{},
+	}
+}
+
+// Put records the tunnel of the inner connection of a packet. ts is the
+// packet timestamp.
+func (t *Table) Put(tuple *common.IPPortTuple, info *Info, ts time.Time) {
+	inner := newTuple(tuple.SrcIP, tuple.SrcPort, tuple.DstIP, tuple.DstPort)
+	key := inner.Hashable()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entries[key]; ok {
+		e.ts = ts
+		if e.info.Type == info.Type && e.info.ID == info.ID {
+			return
+		}
+		e.info = info.clone()
+	} else {
+		t.entries[key] = &entry{info: info.clone(), ts: ts}
+	}
+
+	if ts.Sub(t.lastSweep) >= t.timeout {
+		t.sweep(ts)
+	}
+}
+
+// Lookup returns the tunnel of a connection, in any direction.
+func (t *Table) Lookup(srcIP net.IP, srcPort uint16, dstIP net.IP, dstPort uint16) (Info, bool) {
+	tuple := newTuple(srcIP, srcPort, dstIP, dstPort)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if e, ok := t.entries[tuple.Hashable()]; ok {
+		return e.info, true
+	}
+	if e, ok := t.entries[tuple.RevHashable()]; ok {
+		return e.info, true
+	}
+	return Info{}, false
+}
+
+func (t *Table) sweep(now time.Time) {
+	for key, e := range t.entries {
+		if now.Sub(e.ts) > t.timeout {
+			delete(t.entries, key)
+		}
+	}
+	t.lastSweep = now
+}
+
+// newTuple creates a tuple with the IPv4 addresses in their 4 bytes form, so
+// the keys of the addresses read from packets and from events are the same.
+func newTuple(srcIP net.IP, srcPort uint16, dstIP net.IP, dstPort uint16) common.IPPortTuple {
+	ipLength := net.IPv6len
+	if src, dst := srcIP.To4(), dstIP.To4(); src != nil && dst != nil {
+		srcIP, dstIP, ipLength = src, dst, net.IPv4len
+	}
+	return common.NewIPPortTuple(ipLength, srcIP, srcPort, dstIP, dstPort)
+}
+
+// clone copies the endpoint addresses, which may point into packet buffers.
+func (i *Info) clone() Info {
+	c := *i
+	c.Source = append([]byte(nil), i.Source...)
+	c.Destination = append([]byte(nil), i.Destination...)
+	return c
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package tunnel holds the metadata of the overlay network tunnels decapsulated
+// by the packet decoder, so it can be added to the transactions of the inner
+// traffic when they are published.
+package tunnel
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// Type is the encapsulation protocol of a tunnel.
+type Type uint8
+
+const (
+	None Type = iota
+	VXLAN
+	Geneve
+	GRE
+)
+
+func (t Type) String() string {
+	switch t {
+	case VXLAN:
+		return "vxlan"
+	case Geneve:
+		return "geneve"
+	case GRE:
+		return "gre"
+	default:
+		return "none"
+	}
+}
+
+// Info describes the innermost tunnel a packet was decapsulated from.
+type Info struct {
+	Type Type
+
+	// ID is the VXLAN or GENEVE virtual network identifier, or the GRE key.
+	ID    uint32
+	HasID bool
+
+	// Source and Destination are the IP addresses of the tunnel endpoints.
+	Source, Destination net.IP
+}
+
+// Fields returns the tunnel fields to add to an event.
+func (i *Info) Fields() common.MapStr {
+	fields := common.MapStr{
+		"type": i.Type.String(),
+	}
+	if i.HasID {
+		fields["id"] = i.ID
+	}
+	if i.Source != nil {
+		fields["source"] = common.MapStr{"ip": i.Source.String()}
+	}
+	if i.Destination != nil {
+		fields["destination"] = common.MapStr{"ip": i.Destination.String()}
+	}
+	return fields
+}
+
+// Config selects the tunnels to decapsulate.
+type Config struct {
+	VXLAN       bool     `config:"vxlan"`
+	VXLANPorts  []uint16 `config:"vxlan_ports"`
+	Geneve      bool     `config:"geneve"`
+	GenevePorts []uint16 `config:"geneve_ports"`
+	GRE         bool     `config:"gre"`
+
+	// Timeout is how long the tunnel of an inner connection is remembered
+	// after its last packet, to be added to its transactions.
+	Timeout time.Duration `config:"timeout" validate:"min=0,nonzero"`
+}
+
+// DefaultConfig returns the default tunnel settings, with no decapsulation.
+func DefaultConfig() Config {
+	return Config{
+		VXLANPorts:  []uint16{4789},
+		GenevePorts: []uint16{6081},
+		Timeout:     5 * time.Minute,
+	}
+}
+
+// Enabled returns true if any tunnel is decapsulated.
+func (c *Config) Enabled() bool {
+	return c.VXLAN || c.Geneve || c.GRE
+}
+
+// BpfFilter extends a BPF filter to also capture the enabled tunnels.
+func (c *Config) BpfFilter(filter string) string {
+	if filter == "" || !c.Enabled() {
+		return filter
+	}
+
+	expressions := []string{filter}
+	if c.VXLAN {
+		for _, port := range c.VXLANPorts {
+			expressions = append(expressions, fmt.Sprintf("udp port %d", port))
+		}
+	}
+	if c.Geneve {
+		for _, port := range c.GenevePorts {
+			expressions = append(expressions, fmt.Sprintf("udp port %d", port))
+		}
+	}
+	if c.GRE {
+		expressions = append(expressions, "ip proto gre", "ip6 proto gre")
+	}
+	return strings.Join(expressions, " or ")
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tunnel
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestTable(t *testing.T) {
+	ts := time.Now()
+	table := NewTable(time.Minute)
+
+	inner := common.NewIPPortTuple(4,
+		net.IPv4(10, 0, 0, 1).To4(), 34567,
+		net.IPv4(10, 0, 0, 2).To4(), 80)
+	outer := []byte{192, 168, 1, 1}
+	table.Put(&inner, &Info{
+		Type:        VXLAN,
+		ID:          42,
+		HasID:       true,
+		Source:      outer,
+		Destination: net.IPv4(192, 168, 1, 2).To4(),
+	}, ts)
+
+	// The addresses of the packet buffer must not be referenced.
+	outer[3] = 99
+
+	info, ok := table.Lookup(net.ParseIP("10.0.0.2"), 80, net.ParseIP("10.0.0.1"), 34567)
+	if assert.True(t, ok) {
+		assert.Equal(t, common.MapStr{
+			"type":        "vxlan",
+			"id":          uint32(42),
+			"source":      common.MapStr{"ip": "192.168.1.1"},
+			"destination": common.MapStr{"ip": "192.168.1.2"},
+		}, info.Fields())
+	}
+
+	_, ok = table.Lookup(net.ParseIP("10.0.0.2"), 80, net.ParseIP("10.0.0.3"), 34567)
+	assert.False(t, ok)
+
+	// Adding another connection after the timeout expires the first one.
+	other := common.NewIPPortTuple(4,
+		net.IPv4(10, 0, 0, 3).To4(), 34567,
+		net.IPv4(10, 0, 0, 2).To4(), 80)
+	table.Put(&other, &Info{Type: GRE}, ts.Add(2*time.Minute))
+
+	_, ok = table.Lookup(net.ParseIP("10.0.0.1"), 34567, net.ParseIP("10.0.0.2"), 80)
+	assert.False(t, ok)
+	info, ok = table.Lookup(net.ParseIP("10.0.0.3"), 34567, net.ParseIP("10.0.0.2"), 80)
+	assert.True(t, ok)
+	assert.Equal(t, common.MapStr{"type": "gre"}, info.Fields())
+}
+
+func TestConfigBpfFilter(t *testing.T) {
+	config := DefaultConfig()
+	assert.Equal(t, "tcp port 80", config.BpfFilter("tcp port 80"))
+
+	config.VXLAN = true
+	config.GRE = true
+	assert.Equal(t, "tcp port 80 or udp port 4789 or ip proto gre or ip6 proto gre", config.BpfFilter("tcp port 80"))
+	assert.Equal(t, "", config.BpfFilter(""))
+}
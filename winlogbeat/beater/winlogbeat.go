@@ -85,10 +85,20 @@ func New(b *beat.Beat, _ *common.Config) (beat.Beater, error) {
 func (eb *Winlogbeat) init(b *beat.Beat) error {
 	config := &eb.config
 
+	// Split sharded event logs into one configuration per shard.
+	var eventLogConfigs []*common.Config
+	for _, config := range config.EventLogs {
+		shards, err := eventlog.Shards(config)
+		if err != nil {
+			return fmt.Errorf("Failed to create new event log. %v", err)
+		}
+		eventLogConfigs = append(eventLogConfigs, shards...)
+	}
+
 	// Create the event logs. This will validate the event log specific
 	// configuration.
-	eb.eventLogs = make([]*eventLogger, 0, len(config.EventLogs))
-	for _, config := range config.EventLogs {
+	eb.eventLogs = make([]*eventLogger, 0, len(eventLogConfigs))
+	for _, config := range eventLogConfigs {
 		eventLog, err := eventlog.New(config)
 		if err != nil {
 			return fmt.Errorf("Failed to create new event log. %v", err)
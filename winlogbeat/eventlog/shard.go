@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package eventlog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+// shardConfigKeys are the options a shard can set. They override the options
+// of the event log.
+var shardConfigKeys = common.MakeStringSet("id", "event_id", "level", "provider")
+
+type shardsConfig struct {
+	Name   string           `config:"name"`
+	Shards []*common.Config `config:"shards"`
+}
+
+// Shards splits the configuration of an event log into one configuration per
+// shard listed in its `shards` option. Each shard is read by its own reader
+// with the query options of the shard, so that a busy channel can be read
+// concurrently. The readers are identified by the `id` of the shard, which
+// defaults to the name of the event log followed by the index of the shard.
+// The configuration is returned as is if it has no shards.
+func Shards(options *common.Config) ([]*common.Config, error) {
+	var config shardsConfig
+	if err := options.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("failed unpacking config. %v", err)
+	}
+	if len(config.Shards) == 0 {
+		return []*common.Config{options}, nil
+	}
+
+	ids := map[string]bool{}
+	shards := make([]*common.Config, 0, len(config.Shards))
+	for i, shard := range config.Shards {
+		for _, k := range shard.GetFields() {
+			if !shardConfigKeys.Has(k) {
+				return nil, fmt.Errorf("invalid shard key '%s' found in event log %s. "+
+					"Valid keys are %s", k, config.Name, strings.Join(shardConfigKeys.ToSlice(), ", "))
+			}
+		}
+
+		c := common.NewConfig()
+		if err := c.Merge(options); err != nil {
+			return nil, err
+		}
+		if _, err := c.Remove("shards", -1); err != nil {
+			return nil, err
+		}
+		if err := c.SetString("id", -1, fmt.Sprintf("%s/%d", config.Name, i)); err != nil {
+			return nil, err
+		}
+		if err := c.Merge(shard); err != nil {
+			return nil, err
+		}
+
+		id, err := c.String("id", -1)
+		if err != nil {
+			return nil, err
+		}
+		if ids[id] {
+			return nil, fmt.Errorf("duplicate shard id '%s' found in event log %s", id, config.Name)
+		}
+		ids[id] = true
+
+		shards = append(shards, c)
+	}
+	return shards, nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package eventlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestShards(t *testing.T) {
+	t.Run("no shards", func(t *testing.T) {
+		c := common.MustNewConfigFrom(map[string]interface{}{"name": "Security"})
+		shards, err := Shards(c)
+		require.NoError(t, err)
+		assert.Equal(t, []*common.Config{c}, shards)
+	})
+
+	t.Run("shards", func(t *testing.T) {
+		c := common.MustNewConfigFrom(map[string]interface{}{
+			"name":  "Security",
+			"level": "info",
+			"tags":  []string{"dc"},
+			"shards": []map[string]interface{}{
+				{"event_id": "4624, 4625"},
+				{"event_id": "4688", "id": "process-creation"},
+				{"event_id": "-4624, -4625, -4688", "level": "warning"},
+			},
+		})
+		shards, err := Shards(c)
+		require.NoError(t, err)
+		require.Len(t, shards, 3)
+
+		var configs []map[string]interface{}
+		for _, shard := range shards {
+			var m map[string]interface{}
+			require.NoError(t, shard.Unpack(&m))
+			configs = append(configs, m)
+		}
+
+		assert.Equal(t, []map[string]interface{}{
+			{"name": "Security", "id": "Security/0", "level": "info", "tags": []interface{}{"dc"}, "event_id": "4624, 4625"},
+			{"name": "Security", "id": "process-creation", "level": "info", "tags": []interface{}{"dc"}, "event_id": "4688"},
+			{"name": "Security", "id": "Security/2", "level": "warning", "tags": []interface{}{"dc"}, "event_id": "-4624, -4625, -4688"},
+		}, configs)
+	})
+
+	t.Run("invalid shard key", func(t *testing.T) {
+		c := common.MustNewConfigFrom(map[string]interface{}{
+			"name":   "Security",
+			"shards": []map[string]interface{}{{"batch_read_size": 10}},
+		})
+		_, err := Shards(c)
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate shard ids", func(t *testing.T) {
+		c := common.MustNewConfigFrom(map[string]interface{}{
+			"name":   "Security",
+			"shards": []map[string]interface{}{{"id": "a"}, {"id": "a"}},
+		})
+		_, err := Shards(c)
+		assert.Error(t, err)
+	})
+}
@@ -49,10 +49,11 @@ const (
 
 var winEventLogConfigKeys = common.MakeStringSet(append(commonConfigKeys,
 	"batch_read_size", "ignore_older", "include_xml", "event_id", "forwarded",
-	"level", "provider", "no_more_events")...)
+	"level", "provider", "no_more_events", "id")...)
 
 type winEventLogConfig struct {
 	ConfigCommon  `config:",inline"`
+	ID            string             `config:"id"`              // Identifier of the reader, used to persist its state. Defaults to the name.
 	BatchReadSize int                `config:"batch_read_size"` // Maximum number of events that Read will return.
 	IncludeXML    bool               `config:"include_xml"`
 	Forwarded     *bool              `config:"forwarded"`
@@ -124,6 +125,7 @@ var _ EventLog = &winEventLog{}
 type winEventLog struct {
 	config       winEventLogConfig
 	query        string
+	id           string                   // Identifier of the reader (i.e. the channel name or shard ID).
 	channelName  string                   // Name of the channel from which to read.
 	file         bool                     // Reading from file rather than channel.
 	subscription win.EvtHandle            // Handle to the subscription.
@@ -138,9 +140,10 @@ type winEventLog struct {
 	logPrefix string // String to prefix on log messages.
 }
 
-// Name returns the name of the event log (i.e. Application, Security, etc.).
+// Name returns the name of the event log (i.e. Application, Security, etc.),
+// or the ID of the reader if one is configured.
 func (l *winEventLog) Name() string {
-	return l.channelName
+	return l.id
 }
 
 func (l *winEventLog) Open(state checkpoint.EventLogState) error {
@@ -263,7 +266,7 @@ func (l *winEventLog) Read() ([]Record, error) {
 
 		r, _ := l.buildRecordFromXML(l.outputBuf.Bytes(), err)
 		r.Offset = checkpoint.EventLogState{
-			Name:         l.channelName,
+			Name:         l.id,
 			RecordNumber: r.RecordID,
 			Timestamp:    r.TimeCreated.SystemTime,
 		}
@@ -401,16 +404,22 @@ func newWinEventLog(options *common.Config) (EventLog, error) {
 		c.Name = filepath.Clean(c.Name)
 	}
 
+	id := c.ID
+	if id == "" {
+		id = c.Name
+	}
+
 	l := &winEventLog{
 		config:      c,
 		query:       query,
+		id:          id,
 		channelName: c.Name,
 		file:        filepath.IsAbs(c.Name),
 		maxRead:     c.BatchReadSize,
 		renderBuf:   make([]byte, renderBufferSize),
 		outputBuf:   sys.NewByteBuffer(renderBufferSize),
 		cache:       newMessageFilesCache(c.Name, eventMetadataHandle, freeHandle),
-		logPrefix:   fmt.Sprintf("WinEventLog[%s]", c.Name),
+		logPrefix:   fmt.Sprintf("WinEventLog[%s]", id),
 	}
 
 	// Forwarded events should be rendered using RenderEventXML. It is more
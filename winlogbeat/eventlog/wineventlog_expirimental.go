@@ -46,6 +46,7 @@ const (
 type winEventLogExp struct {
 	config      winEventLogConfig
 	query       string
+	id          string                   // Identifier of the reader (i.e. the channel name or shard ID).
 	channelName string                   // Name of the channel from which to read.
 	file        bool                     // Reading from file rather than channel.
 	maxRead     int                      // Maximum number returned in one Read.
@@ -56,9 +57,10 @@ type winEventLogExp struct {
 	renderer *win.Renderer
 }
 
-// Name returns the name of the event log (i.e. Application, Security, etc.).
+// Name returns the name of the event log (i.e. Application, Security, etc.),
+// or the ID of the reader if one is configured.
 func (l *winEventLogExp) Name() string {
-	return l.channelName
+	return l.id
 }
 
 func (l *winEventLogExp) Open(state checkpoint.EventLogState) error {
@@ -208,7 +210,7 @@ func (l *winEventLogExp) processHandle(h win.EvtHandle) (*Record, error) {
 	}
 
 	r.Offset = checkpoint.EventLogState{
-		Name:         l.channelName,
+		Name:         l.id,
 		RecordNumber: r.RecordID,
 		Timestamp:    r.TimeCreated.SystemTime,
 	}
@@ -269,7 +271,12 @@ func newWinEventLogExp(options *common.Config) (EventLog, error) {
 		return nil, err
 	}
 
-	log := logp.NewLogger("wineventlog").With("channel", c.Name)
+	id := c.ID
+	if id == "" {
+		id = c.Name
+	}
+
+	log := logp.NewLogger("wineventlog").With("channel", c.Name, "id", id)
 
 	renderer, err := win.NewRenderer(win.NilHandle, log)
 	if err != nil {
@@ -279,6 +286,7 @@ func newWinEventLogExp(options *common.Config) (EventLog, error) {
 	l := &winEventLogExp{
 		config:      c,
 		query:       query,
+		id:          id,
 		channelName: c.Name,
 		file:        isFile,
 		maxRead:     c.BatchReadSize,
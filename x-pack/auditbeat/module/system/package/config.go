@@ -7,6 +7,7 @@
 package pkg
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -14,6 +15,23 @@ import (
 type config struct {
 	StatePeriod        time.Duration `config:"state.period"`
 	PackageStatePeriod time.Duration `config:"package.state.period"`
+
+	// LanguageManagers lists the language package managers (pip, npm) whose
+	// globally installed packages are reported too.
+	LanguageManagers []string `config:"package.language_managers"`
+}
+
+// Validate validates the package metricset's configuration options.
+func (c *config) Validate() error {
+	for _, manager := range c.LanguageManagers {
+		switch manager {
+		case languageManagerPip, languageManagerNpm:
+		default:
+			return fmt.Errorf("invalid package.language_managers value '%v', expected '%v' or '%v'",
+				manager, languageManagerPip, languageManagerNpm)
+		}
+	}
+	return nil
 }
 
 func (c *config) effectiveStatePeriod() time.Duration {
@@ -50,6 +50,8 @@ var (
 	rpmPath            = "/var/lib/rpm"
 	dpkgPath           = "/var/lib/dpkg"
 	homebrewCellarPath = "/usr/local/Cellar"
+	apkDatabasePath    = "/lib/apk/db/installed"
+	snapPath           = "/snap"
 )
 
 type eventAction uint8
@@ -523,9 +525,55 @@ func (ms *MetricSet) getPackages() (packages []*Package, err error) {
 		return nil, errors.Wrapf(err, "error opening %v", homebrewCellarPath)
 	}
 
+	_, err = os.Stat(apkDatabasePath)
+	if err == nil {
+		foundPackageManager = true
+
+		apkPackages, err := listAPKPackages()
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting APK packages")
+		}
+		ms.log.Debugf("APK packages: %v", len(apkPackages))
+
+		packages = append(packages, apkPackages...)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "error opening %v", apkDatabasePath)
+	}
+
+	_, err = os.Stat(snapPath)
+	if err == nil {
+		foundPackageManager = true
+
+		snapPackages, err := listSnapPackages()
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting snap packages")
+		}
+		ms.log.Debugf("Snap packages: %v", len(snapPackages))
+
+		packages = append(packages, snapPackages...)
+	} else if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "error opening %v", snapPath)
+	}
+
+	for _, manager := range ms.config.LanguageManagers {
+		var languagePackages []*Package
+		switch manager {
+		case languageManagerPip:
+			languagePackages, err = listPipPackages()
+		case languageManagerNpm:
+			languagePackages, err = listNpmPackages()
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "error getting %v packages", manager)
+		}
+		ms.log.Debugf("%v packages: %v", manager, len(languagePackages))
+
+		packages = append(packages, languagePackages...)
+	}
+
 	if !foundPackageManager && !ms.suppressNoPackageWarnings {
-		ms.log.Warnf("No supported package managers found. None of %v, %v, %v exist.",
-			rpmPath, dpkgPath, homebrewCellarPath)
+		ms.log.Warnf("No supported package managers found. None of %v, %v, %v, %v, %v exist.",
+			rpmPath, dpkgPath, homebrewCellarPath, apkDatabasePath, snapPath)
 
 		// Only warn once at the start of Auditbeat.
 		ms.suppressNoPackageWarnings = true
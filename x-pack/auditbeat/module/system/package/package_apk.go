@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// listAPKPackages reads the packages from the database of the Alpine package
+// manager. Each package is a block of single letter keys and values separated
+// by an empty line.
+func listAPKPackages() ([]*Package, error) {
+	file, err := os.Open(apkDatabasePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening %s", apkDatabasePath)
+	}
+	defer file.Close()
+
+	var packages []*Package
+	var pkg *Package
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			// empty line signals new package
+			if pkg != nil {
+				packages = append(packages, pkg)
+			}
+			pkg = nil
+			continue
+		}
+
+		if len(line) < 2 || line[1] != ':' {
+			continue
+		}
+		value := line[2:]
+
+		if pkg == nil {
+			pkg = &Package{
+				Type: "apk",
+			}
+		}
+
+		switch line[0] {
+		case 'P':
+			pkg.Name = value
+		case 'V':
+			pkg.Version = value
+		case 'A':
+			pkg.Arch = value
+		case 'L':
+			pkg.License = value
+		case 'T':
+			pkg.Summary = value
+		case 'U':
+			pkg.URL = value
+		case 'I':
+			// Installed size in bytes.
+			if size, err := strconv.ParseUint(value, 10, 64); err == nil {
+				pkg.Size = size
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "error scanning file %v", apkDatabasePath)
+	}
+
+	// Append last package if file ends without newline
+	if pkg != nil {
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
@@ -0,0 +1,38 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPK(t *testing.T) {
+	oldPath := apkDatabasePath
+	defer func() {
+		apkDatabasePath = oldPath
+	}()
+	apkDatabasePath = "testdata/apk/installed"
+
+	packages, err := listAPKPackages()
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 2) {
+		assert.Equal(t, &Package{
+			Name:    "musl",
+			Version: "1.1.24-r9",
+			Arch:    "x86_64",
+			License: "MIT",
+			Size:    614400,
+			Summary: "the musl c library (libc) implementation",
+			URL:     "https://musl.libc.org/",
+			Type:    "apk",
+		}, packages[0])
+		assert.Equal(t, "busybox", packages[1].Name)
+		assert.Equal(t, "1.31.1-r19", packages[1].Version)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Language package managers whose globally installed packages can be listed.
+const (
+	languageManagerPip = "pip"
+	languageManagerNpm = "npm"
+)
+
+var (
+	// pipSitePackagesGlobs match the system wide site-packages directories
+	// of Python.
+	pipSitePackagesGlobs = []string{
+		"/usr/lib/python*/site-packages",
+		"/usr/lib/python*/dist-packages",
+		"/usr/lib64/python*/site-packages",
+		"/usr/local/lib/python*/site-packages",
+		"/usr/local/lib/python*/dist-packages",
+	}
+
+	// npmGlobalModulesPaths are the directories of the global packages of npm.
+	npmGlobalModulesPaths = []string{
+		"/usr/lib/node_modules",
+		"/usr/local/lib/node_modules",
+	}
+)
+
+// listPipPackages reads the metadata of the Python distributions installed in
+// the system wide site-packages directories.
+func listPipPackages() ([]*Package, error) {
+	var packages []*Package
+	for _, pattern := range pipSitePackagesGlobs {
+		dirs, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %v", pattern)
+		}
+		for _, dir := range dirs {
+			metadataFiles, err := filepath.Glob(filepath.Join(dir, "*.dist-info", "METADATA"))
+			if err != nil {
+				return nil, err
+			}
+			eggInfoFiles, err := filepath.Glob(filepath.Join(dir, "*.egg-info", "PKG-INFO"))
+			if err != nil {
+				return nil, err
+			}
+			for _, metadataPath := range append(metadataFiles, eggInfoFiles...) {
+				packages = append(packages, readPipMetadata(metadataPath))
+			}
+		}
+	}
+	return packages, nil
+}
+
+// readPipMetadata reads the headers of the METADATA or PKG-INFO file of a
+// Python distribution.
+func readPipMetadata(metadataPath string) *Package {
+	pkg := &Package{
+		Type: languageManagerPip,
+	}
+
+	infoDir := filepath.Dir(metadataPath)
+	if info, err := os.Stat(infoDir); err == nil {
+		pkg.InstallTime = info.ModTime()
+	}
+
+	file, err := os.Open(metadataPath)
+	if err != nil {
+		pkg.Name = strings.SplitN(filepath.Base(infoDir), "-", 2)[0]
+		pkg.error = errors.Wrapf(err, "error reading %v", metadataPath)
+		return pkg
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// The headers end at the first empty line, the description follows.
+			break
+		}
+		words := strings.SplitN(line, ":", 2)
+		if len(words) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(words[1])
+
+		switch strings.ToLower(words[0]) {
+		case "name":
+			pkg.Name = value
+		case "version":
+			pkg.Version = value
+		case "summary":
+			pkg.Summary = value
+		case "home-page":
+			pkg.URL = value
+		case "license":
+			pkg.License = value
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		pkg.error = errors.Wrapf(err, "error parsing %v", metadataPath)
+	}
+	return pkg
+}
+
+// npmPackageJSON represents the fields of the package.json of an npm package.
+type npmPackageJSON struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Description string          `json:"description"`
+	Homepage    string          `json:"homepage"`
+	License     json.RawMessage `json:"license"`
+}
+
+// listNpmPackages reads the package.json of the packages installed globally
+// with npm, including scoped packages like @angular/cli.
+func listNpmPackages() ([]*Package, error) {
+	var packages []*Package
+	for _, modulesPath := range npmGlobalModulesPaths {
+		if _, err := os.Stat(modulesPath); os.IsNotExist(err) {
+			continue
+		}
+
+		var packageDirs []string
+		entries, err := ioutil.ReadDir(modulesPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if !strings.HasPrefix(entry.Name(), "@") {
+				packageDirs = append(packageDirs, filepath.Join(modulesPath, entry.Name()))
+				continue
+			}
+			scoped, err := ioutil.ReadDir(filepath.Join(modulesPath, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			for _, scopedEntry := range scoped {
+				if scopedEntry.IsDir() {
+					packageDirs = append(packageDirs, filepath.Join(modulesPath, entry.Name(), scopedEntry.Name()))
+				}
+			}
+		}
+
+		for _, dir := range packageDirs {
+			packages = append(packages, readNpmPackage(dir))
+		}
+	}
+	return packages, nil
+}
+
+func readNpmPackage(dir string) *Package {
+	pkg := &Package{
+		Name: filepath.Base(dir),
+		Type: languageManagerNpm,
+	}
+
+	packageJSONPath := filepath.Join(dir, "package.json")
+	if info, err := os.Stat(packageJSONPath); err == nil {
+		pkg.InstallTime = info.ModTime()
+	}
+	contents, err := ioutil.ReadFile(packageJSONPath)
+	if err != nil {
+		pkg.error = errors.Wrapf(err, "error reading %v", packageJSONPath)
+		return pkg
+	}
+
+	var packageJSON npmPackageJSON
+	if err = json.Unmarshal(contents, &packageJSON); err != nil {
+		pkg.error = errors.Wrapf(err, "error unmarshalling JSON in %v", packageJSONPath)
+		return pkg
+	}
+	if packageJSON.Name != "" {
+		pkg.Name = packageJSON.Name
+	}
+	pkg.Version = packageJSON.Version
+	pkg.Summary = packageJSON.Description
+	pkg.URL = packageJSON.Homepage
+	pkg.License = npmLicense(packageJSON.License)
+	return pkg
+}
+
+// npmLicense returns the license of a package.json, which is an SPDX
+// expression, or an object with a type in old packages.
+func npmLicense(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var license string
+	if err := json.Unmarshal(raw, &license); err == nil {
+		return license
+	}
+	var legacy struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &legacy); err == nil {
+		return legacy.Type
+	}
+	return ""
+}
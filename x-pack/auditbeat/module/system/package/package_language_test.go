@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPip(t *testing.T) {
+	oldGlobs := pipSitePackagesGlobs
+	defer func() {
+		pipSitePackagesGlobs = oldGlobs
+	}()
+	pipSitePackagesGlobs = []string{"testdata/pip/python*/site-packages"}
+
+	packages, err := listPipPackages()
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 2) {
+		requests := packages[0]
+		assert.NoError(t, requests.error)
+		assert.Equal(t, "requests", requests.Name)
+		assert.Equal(t, "2.24.0", requests.Version)
+		assert.Equal(t, "Python HTTP for Humans.", requests.Summary)
+		assert.Equal(t, "https://requests.readthedocs.io", requests.URL)
+		assert.Equal(t, "Apache 2.0", requests.License)
+		assert.Equal(t, "pip", requests.Type)
+
+		six := packages[1]
+		assert.Equal(t, "six", six.Name)
+		assert.Equal(t, "1.15.0", six.Version)
+		assert.Equal(t, "MIT", six.License)
+	}
+}
+
+func TestNpm(t *testing.T) {
+	oldPaths := npmGlobalModulesPaths
+	defer func() {
+		npmGlobalModulesPaths = oldPaths
+	}()
+	npmGlobalModulesPaths = []string{"testdata/npm/node_modules", "/does/not/exist"}
+
+	packages, err := listNpmPackages()
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 2) {
+		angular := packages[0]
+		assert.NoError(t, angular.error)
+		assert.Equal(t, "@angular/cli", angular.Name)
+		assert.Equal(t, "10.0.5", angular.Version)
+		assert.Equal(t, "MIT", angular.License)
+		assert.Equal(t, "npm", angular.Type)
+
+		npm := packages[1]
+		assert.Equal(t, "npm", npm.Name)
+		assert.Equal(t, "6.14.6", npm.Version)
+		assert.Equal(t, "a package manager for JavaScript", npm.Summary)
+		assert.Equal(t, "https://docs.npmjs.com/", npm.URL)
+		assert.Equal(t, "Artistic-2.0", npm.License)
+	}
+}
+
+func TestLanguageManagersConfig(t *testing.T) {
+	c := defaultConfig()
+	c.LanguageManagers = []string{"pip", "gem"}
+	assert.Error(t, c.Validate())
+
+	c.LanguageManagers = []string{"pip", "npm"}
+	assert.NoError(t, c.Validate())
+}
@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// snapMetadata represents the fields of a snap's meta/snap.yaml.
+type snapMetadata struct {
+	Name          string   `yaml:"name"`
+	Version       string   `yaml:"version"`
+	Summary       string   `yaml:"summary"`
+	License       string   `yaml:"license"`
+	Architectures []string `yaml:"architectures"`
+}
+
+// listSnapPackages reads the metadata of the current revision of the snaps
+// mounted under the snap directory.
+func listSnapPackages() ([]*Package, error) {
+	snapDirs, err := ioutil.ReadDir(snapPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*Package
+	for _, snapDir := range snapDirs {
+		if !snapDir.IsDir() {
+			continue
+		}
+		// The current symlink points to the revision in use, snaps without
+		// it (like the bin directory) are not installed.
+		currentPath := filepath.Join(snapPath, snapDir.Name(), "current")
+		current, err := os.Lstat(currentPath)
+		if err != nil {
+			continue
+		}
+		revision, err := os.Readlink(currentPath)
+		if err != nil {
+			continue
+		}
+
+		pkg := &Package{
+			Name:        snapDir.Name(),
+			Release:     revision,
+			InstallTime: current.ModTime(),
+			Type:        "snap",
+		}
+
+		metadataPath := filepath.Join(currentPath, "meta", "snap.yaml")
+		contents, err := ioutil.ReadFile(metadataPath)
+		if err != nil {
+			pkg.error = errors.Wrapf(err, "error reading %v", metadataPath)
+		} else {
+			var metadata snapMetadata
+			if err = yaml.Unmarshal(contents, &metadata); err != nil {
+				pkg.error = errors.Wrapf(err, "error unmarshalling YAML in %v", metadataPath)
+			} else {
+				pkg.Version = metadata.Version
+				pkg.Summary = metadata.Summary
+				pkg.License = metadata.License
+				if len(metadata.Architectures) == 1 {
+					pkg.Arch = metadata.Architectures[0]
+				}
+			}
+		}
+
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
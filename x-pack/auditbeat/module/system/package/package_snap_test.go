@@ -0,0 +1,36 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !windows
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnap(t *testing.T) {
+	oldPath := snapPath
+	defer func() {
+		snapPath = oldPath
+	}()
+	snapPath = "testdata/snap"
+
+	packages, err := listSnapPackages()
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 1) {
+		pkg := packages[0]
+		assert.NoError(t, pkg.error)
+		assert.Equal(t, "core18", pkg.Name)
+		assert.Equal(t, "20200724", pkg.Version)
+		assert.Equal(t, "1885", pkg.Release)
+		assert.Equal(t, "amd64", pkg.Arch)
+		assert.Equal(t, "GPL-3.0", pkg.License)
+		assert.Equal(t, "Runtime environment based on Ubuntu 18.04", pkg.Summary)
+		assert.Equal(t, "snap", pkg.Type)
+		assert.False(t, pkg.InstallTime.IsZero())
+	}
+}
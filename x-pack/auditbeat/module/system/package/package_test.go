@@ -59,13 +59,19 @@ func TestDpkg(t *testing.T) {
 	rpmPathOld := rpmPath
 	dpkgPathOld := dpkgPath
 	brewPathOld := homebrewCellarPath
+	apkPathOld := apkDatabasePath
+	snapPathOld := snapPath
 	defer func() {
 		rpmPath = rpmPathOld
 		dpkgPath = dpkgPathOld
 		homebrewCellarPath = brewPathOld
+		apkDatabasePath = apkPathOld
+		snapPath = snapPathOld
 	}()
 	rpmPath = "/does/not/exist"
 	homebrewCellarPath = "/does/not/exist"
+	apkDatabasePath = "/does/not/exist"
+	snapPath = "/does/not/exist"
 
 	var err error
 	dpkgPath, err = filepath.Abs("testdata/dpkg/")
@@ -118,13 +124,19 @@ func TestDpkgInstalledSize(t *testing.T) {
 	rpmPathOld := rpmPath
 	dpkgPathOld := dpkgPath
 	brewPathOld := homebrewCellarPath
+	apkPathOld := apkDatabasePath
+	snapPathOld := snapPath
 	defer func() {
 		rpmPath = rpmPathOld
 		dpkgPath = dpkgPathOld
 		homebrewCellarPath = brewPathOld
+		apkDatabasePath = apkPathOld
+		snapPath = snapPathOld
 	}()
 	rpmPath = "/does/not/exist"
 	homebrewCellarPath = "/does/not/exist"
+	apkDatabasePath = "/does/not/exist"
+	snapPath = "/does/not/exist"
 
 	var err error
 	dpkgPath, err = filepath.Abs("testdata/dpkg-size/")
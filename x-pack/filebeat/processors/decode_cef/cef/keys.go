@@ -697,3 +697,12 @@ func init() {
 		extensionMappingLowerCase[strings.ToLower(k)] = v
 	}
 }
+
+// FullExtensionName returns the full name of a CEF extension key, like
+// sourceAddress for src. Unknown keys are returned unchanged.
+func FullExtensionName(key string) string {
+	if mapping, found := extensionMappingLowerCase[strings.ToLower(key)]; found {
+		return mapping.Target
+	}
+	return key
+}
@@ -4,19 +4,59 @@
 
 package decode_cef
 
+import (
+	"github.com/pkg/errors"
+)
+
+// Message formats supported by the processor.
+const (
+	formatCEF  = "cef"
+	formatLEEF = "leef"
+	formatAuto = "auto" // CEF or LEEF, whichever header comes first.
+)
+
 type config struct {
-	Field         string `config:"field"`          // Source field containing the CEF message.
-	TargetField   string `config:"target_field"`   // Target field for the CEF object.
-	IgnoreMissing bool   `config:"ignore_missing"` // Ignore missing source field.
-	IgnoreFailure bool   `config:"ignore_failure"` // Ignore failures when the source field does not contain a CEF message. Parse errors do not cause failures, but are added to error.message.
-	ID            string `config:"id"`             // Instance ID for debugging purposes.
-	ECS           bool   `config:"ecs"`            // Generate ECS fields.
+	Field             string             `config:"field"`              // Source field containing the CEF message.
+	TargetField       string             `config:"target_field"`       // Target field for the CEF object.
+	IgnoreMissing     bool               `config:"ignore_missing"`     // Ignore missing source field.
+	IgnoreFailure     bool               `config:"ignore_failure"`     // Ignore failures when the source field does not contain a CEF message. Parse errors do not cause failures, but are added to error.message.
+	ID                string             `config:"id"`                 // Instance ID for debugging purposes.
+	ECS               bool               `config:"ecs"`                // Generate ECS fields.
+	Format            string             `config:"format"`             // Message format, cef, leef or auto.
+	ExtensionMappings []extensionMapping `config:"extension_mappings"` // Vendor specific mappings of extensions to fields.
+
+	// Target field for the LEEF object, the target_field if it is set.
+	leefTargetField string
+}
+
+// extensionMapping copies the extensions of the messages of a vendor, like
+// the custom strings of a firewall, to fields.
+type extensionMapping struct {
+	Vendor  string   `config:"vendor"`  // Device vendor of the messages, any vendor if empty.
+	Product string   `config:"product"` // Device product of the messages, any product if empty.
+	Fields  []fromTo `config:"fields" validate:"required"`
+}
+
+type fromTo struct {
+	From string `config:"from" validate:"required"` // CEF extension or LEEF attribute.
+	To   string `config:"to" validate:"required"`
 }
 
 func defaultConfig() config {
 	return config{
-		Field:       "message",
-		TargetField: "cef",
-		ECS:         true,
+		Field:           "message",
+		TargetField:     "cef",
+		ECS:             true,
+		Format:          formatCEF,
+		leefTargetField: "leef",
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Format {
+	case formatCEF, formatLEEF, formatAuto:
+	default:
+		return errors.Errorf("invalid format '%v', expected cef, leef or auto", c.Format)
 	}
+	return nil
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/logp"
 	"github.com/elastic/beats/v7/libbeat/processors"
 	"github.com/elastic/beats/v7/x-pack/filebeat/processors/decode_cef/cef"
+	"github.com/elastic/beats/v7/x-pack/filebeat/processors/decode_cef/leef"
 )
 
 const (
@@ -39,6 +40,9 @@ func New(cfg *common.Config) (processors.Processor, error) {
 	if err := cfg.Unpack(&c); err != nil {
 		return nil, errors.Wrap(err, "fail to unpack the "+procName+" processor configuration")
 	}
+	if cfg.HasField("target_field") {
+		c.leefTargetField = c.TargetField
+	}
 
 	return newDecodeCEF(c)
 }
@@ -66,7 +70,7 @@ func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
 		return event, errors.Wrapf(err, "decode_cef field [%v] not found", p.Field)
 	}
 
-	cefData, ok := v.(string)
+	data, ok := v.(string)
 	if !ok {
 		if p.IgnoreFailure {
 			return event, nil
@@ -74,23 +78,69 @@ func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
 		return event, errors.Wrapf(err, "decode_cef field [%v] is not a string", p.Field)
 	}
 
-	// Ignore any leading data before the CEF header.
-	idx := strings.Index(cefData, "CEF:")
+	// Ignore any leading data before the header.
+	format, idx := p.findHeader(data)
 	if idx == -1 {
 		if p.IgnoreFailure {
 			return event, nil
 		}
-		return event, errors.Errorf("decode_cef field [%v] does not contain a CEF header", p.Field)
+		return event, errors.Errorf("decode_cef field [%v] does not contain a %v header", p.Field, headerNames[p.Format])
 	}
-	cefData = cefData[idx:]
 
-	// If the version < 0 after parsing then none of the data is valid so return here.
-	var ce cef.Event
-	if err = ce.Unpack(cefData, cef.WithFullExtensionNames()); ce.Version < 0 && err != nil {
+	var decodeErrors []error
+	if format == formatLEEF {
+		decodeErrors, err = p.decodeLEEF(data[idx:], event)
+	} else {
+		decodeErrors, err = p.decodeCEF(data[idx:], event)
+	}
+	if err != nil {
 		if p.IgnoreFailure {
 			return event, nil
 		}
-		return event, errors.Wrap(err, "decode_cef failed to parse message")
+		return event, err
+	}
+
+	// Add all parsing/conversion errors to error.message.
+	for _, decodeError := range decodeErrors {
+		if err := appendErrorMessage(event.Fields, decodeError.Error()); err != nil {
+			p.log.Warn("Failed adding CEF errors to event.", "error", err)
+			break
+		}
+	}
+
+	return event, nil
+}
+
+var headerNames = map[string]string{
+	formatCEF:  "CEF",
+	formatLEEF: "LEEF",
+	formatAuto: "CEF or LEEF",
+}
+
+// findHeader returns the format of the message and the position of its
+// header, -1 if it has none.
+func (p *processor) findHeader(data string) (string, int) {
+	cefIdx, leefIdx := -1, -1
+	if p.Format != formatLEEF {
+		cefIdx = strings.Index(data, "CEF:")
+	}
+	if p.Format != formatCEF {
+		leefIdx = strings.Index(data, leef.Header)
+	}
+	if leefIdx != -1 && (cefIdx == -1 || leefIdx < cefIdx) {
+		return formatLEEF, leefIdx
+	}
+	return formatCEF, cefIdx
+}
+
+// decodeCEF writes the CEF object of the message to the event. It returns
+// the errors of the fields that could not be parsed or converted.
+func (p *processor) decodeCEF(data string, event *beat.Event) ([]error, error) {
+	// If the version < 0 after parsing then none of the data is valid so return here.
+	var ce cef.Event
+	err := ce.Unpack(data, cef.WithFullExtensionNames())
+	if ce.Version < 0 && err != nil {
+		return nil, errors.Wrap(err, "decode_cef failed to parse message")
 	}
 
 	cefErrors := multierr.Errors(err)
@@ -125,15 +175,88 @@ func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
 		}
 	}
 
-	// Add all parsing/conversion errors to error.message.
-	for _, cefError := range cefErrors {
-		if err := appendErrorMessage(event.Fields, cefError.Error()); err != nil {
-			p.log.Warn("Failed adding CEF errors to event.", "error", err)
-			break
+	p.mapExtensions(event, ce.DeviceVendor, ce.DeviceProduct, func(name string) interface{} {
+		field, found := ce.Extensions[cef.FullExtensionName(name)]
+		if !found || field.String == "" {
+			return nil
 		}
+		if field.Interface != nil {
+			return field.Interface
+		}
+		return field.String
+	})
+
+	return cefErrors, nil
+}
+
+// decodeLEEF writes the LEEF object of the message to the event. It returns
+// the errors of the attributes that could not be parsed or converted.
+func (p *processor) decodeLEEF(data string, event *beat.Event) ([]error, error) {
+	var le leef.Event
+	err := le.Unpack(data)
+	if le.Version == "" {
+		return nil, errors.Wrap(err, "decode_cef failed to parse LEEF message")
 	}
 
-	return event, nil
+	leefErrors := multierr.Errors(err)
+	event.PutValue(p.leefTargetField, toLEEFObject(&le))
+
+	// Map LEEF predefined attributes to ECS fields.
+	if p.ECS {
+		writeLEEFHeaderToECS(&le, event)
+
+		for key, value := range le.Attributes {
+			mapping, found := ecsLEEFAttributeMapping[key]
+			if !found {
+				continue
+			}
+			translatedValue, err := mapping.Translate(&cef.Field{String: value})
+			if err != nil {
+				leefErrors = append(leefErrors, errors.Wrap(err, key))
+				continue
+			}
+			if translatedValue != nil {
+				event.PutValue(mapping.Target, translatedValue)
+			}
+		}
+
+		if devTime, found := le.Attributes["devTime"]; found {
+			ts, err := leefTime(devTime, le.Attributes["devTimeFormat"])
+			if err != nil {
+				leefErrors = append(leefErrors, errors.Wrap(err, "devTime"))
+			} else {
+				event.PutValue("@timestamp", ts)
+			}
+		}
+	}
+
+	p.mapExtensions(event, le.Vendor, le.Product, func(name string) interface{} {
+		if value := le.Attributes[name]; value != "" {
+			return value
+		}
+		return nil
+	})
+
+	return leefErrors, nil
+}
+
+// mapExtensions copies the extensions of the extension mappings matching the
+// vendor and product of the message to their target fields, in the order of
+// the configuration. Missing and empty extensions are skipped.
+func (p *processor) mapExtensions(event *beat.Event, vendor, product string, lookup func(name string) interface{}) {
+	for _, mapping := range p.ExtensionMappings {
+		if mapping.Vendor != "" && !strings.EqualFold(mapping.Vendor, vendor) {
+			continue
+		}
+		if mapping.Product != "" && !strings.EqualFold(mapping.Product, product) {
+			continue
+		}
+		for _, field := range mapping.Fields {
+			if value := lookup(field.From); value != nil {
+				event.PutValue(field.To, value)
+			}
+		}
+	}
 }
 
 func toCEFObject(cefEvent *cef.Event) common.MapStr {
@@ -198,6 +321,60 @@ func writeCEFHeaderToECS(cefEvent *cef.Event, event *beat.Event) {
 	}
 }
 
+func toLEEFObject(leefEvent *leef.Event) common.MapStr {
+	// Add LEEF header fields.
+	leefObject := common.MapStr{"version": leefEvent.Version}
+	if leefEvent.Vendor != "" {
+		leefObject.Put("device.vendor", leefEvent.Vendor)
+	}
+	if leefEvent.Product != "" {
+		leefObject.Put("device.product", leefEvent.Product)
+	}
+	if leefEvent.ProductVersion != "" {
+		leefObject.Put("device.version", leefEvent.ProductVersion)
+	}
+	if leefEvent.EventID != "" {
+		leefObject.Put("event_id", leefEvent.EventID)
+	}
+
+	// Add LEEF attributes (key-value pairs).
+	if len(leefEvent.Attributes) > 0 {
+		attributes := make(common.MapStr, len(leefEvent.Attributes))
+		leefObject.Put("attributes", attributes)
+		for k, v := range leefEvent.Attributes {
+			attributes.Put(k, v)
+		}
+	}
+
+	return leefObject
+}
+
+func writeLEEFHeaderToECS(leefEvent *leef.Event, event *beat.Event) {
+	if leefEvent.Vendor != "" {
+		event.PutValue("observer.vendor", leefEvent.Vendor)
+	}
+	if leefEvent.Product != "" {
+		event.PutValue("observer.product", leefEvent.Product)
+	}
+	if leefEvent.ProductVersion != "" {
+		event.PutValue("observer.version", leefEvent.ProductVersion)
+	}
+	if leefEvent.EventID != "" {
+		event.PutValue("event.code", leefEvent.EventID)
+	}
+}
+
+// leefTime parses the devTime attribute, with the devTimeFormat attribute if
+// set, or else as milliseconds since the epoch or in one of the formats of
+// CEF timestamps.
+func leefTime(value, format string) (interface{}, error) {
+	if format != "" {
+		ts, err := leef.ParseTime(value, format)
+		return common.Time(ts), err
+	}
+	return cef.ToType(value, cef.TimestampType)
+}
+
 func appendErrorMessage(m common.MapStr, msg string) error {
 	const field = "error.message"
 	list, _ := m.GetValue(field)
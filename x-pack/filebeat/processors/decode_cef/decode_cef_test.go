@@ -9,8 +9,10 @@ import (
 	"encoding/json"
 	"flag"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/pmezard/go-difflib/difflib"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,8 @@ import (
 
 var updateGolden = flag.Bool("update", false, "update golden test files")
 
+const leefMessage = "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^srcPort=81^dstPort=21^proto=TCP^usrName=jane^cat=anomaly"
+
 func TestProcessorRun(t *testing.T) {
 	type testCase struct {
 		config  func() config
@@ -104,6 +108,85 @@ func TestProcessorRun(t *testing.T) {
 				"message":                       "CEF:0|Trend Micro|Deep Security Manager|1.2.3|600|User Signed In|3|src=10.52.116.160 suser=admin target=admin msg=User signed in from 2001:db8::5",
 			},
 		},
+		"extension_mappings": {
+			config: func() config {
+				c := defaultConfig()
+				c.ExtensionMappings = []extensionMapping{
+					{
+						Vendor: "forcepoint",
+						Fields: []fromTo{
+							{From: "cs1", To: "rule.id"},
+							{From: "deviceCustomString3", To: "vulnerability.reference"},
+							{From: "deviceCustomString4", To: "forcepoint.virus_id"},
+						},
+					},
+					{
+						Vendor: "Check Point",
+						Fields: []fromTo{{From: "cs1", To: "rule.name"}},
+					},
+				}
+				return c
+			},
+			message: "CEF:0|FORCEPOINT|Firewall|6.6.1|70018|Connection_Allowed|0|cs1Label=RuleID cs1=2097166 cs3=CVE-2020-1472",
+			fields: common.MapStr{
+				"cef.version":               "0",
+				"cef.device.event_class_id": "70018",
+				"cef.device.product":        "Firewall",
+				"cef.device.vendor":         "FORCEPOINT",
+				"cef.device.version":        "6.6.1",
+				"cef.name":                  "Connection_Allowed",
+				"cef.severity":              "0",
+				"cef.extensions.deviceCustomString1Label": "RuleID",
+				"cef.extensions.deviceCustomString1":      "2097166",
+				"cef.extensions.deviceCustomString3":      "CVE-2020-1472",
+				// ECS
+				"event.code":       "70018",
+				"event.severity":   0,
+				"message":          "Connection_Allowed",
+				"observer.product": "Firewall",
+				"observer.vendor":  "FORCEPOINT",
+				"observer.version": "6.6.1",
+				// Extension mappings
+				"rule.id":                 "2097166",
+				"vulnerability.reference": "CVE-2020-1472",
+			},
+		},
+		"leef": {
+			config: func() config {
+				c := defaultConfig()
+				c.Format = formatLEEF
+				return c
+			},
+			message: leefMessage,
+			fields: common.MapStr{
+				"leef.version":            "2.0",
+				"leef.device.vendor":      "Lancope",
+				"leef.device.product":     "StealthWatch",
+				"leef.device.version":     "1.0",
+				"leef.event_id":           "41",
+				"leef.attributes.src":     "10.0.1.8",
+				"leef.attributes.dst":     "10.0.0.5",
+				"leef.attributes.sev":     "5",
+				"leef.attributes.srcPort": "81",
+				"leef.attributes.dstPort": "21",
+				"leef.attributes.proto":   "TCP",
+				"leef.attributes.usrName": "jane",
+				"leef.attributes.cat":     "anomaly",
+				"message":                 leefMessage,
+				// ECS
+				"event.code":        "41",
+				"event.severity":    int32(5),
+				"observer.product":  "StealthWatch",
+				"observer.vendor":   "Lancope",
+				"observer.version":  "1.0",
+				"source.ip":         "10.0.1.8",
+				"source.port":       int32(81),
+				"destination.ip":    "10.0.0.5",
+				"destination.port":  int32(21),
+				"network.transport": "tcp",
+				"user.name":         "jane",
+			},
+		},
 	}
 
 	dec, err := newDecodeCEF(defaultConfig())
@@ -166,36 +249,163 @@ func TestProcessorRun(t *testing.T) {
 		version, _ := evt.GetValue("cef.version")
 		assert.EqualValues(t, "1", version)
 	})
-}
 
-func TestGolden(t *testing.T) {
-	const source = "testdata/samples.log"
+	t.Run("auto", func(t *testing.T) {
+		c := defaultConfig()
+		c.Format = formatAuto
+		dec, err := newDecodeCEF(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evt := &beat.Event{
+			Fields: common.MapStr{
+				"message": "<13>Jan 18 11:07:53 fw01 " + leefMessage + "^devTime=18/01/2021 10:51:09.123^devTimeFormat=dd/MM/yyyy HH:mm:ss.SSS",
+			},
+		}
+		evt, err = dec.Run(evt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		version, _ := evt.GetValue("leef.version")
+		assert.EqualValues(t, "2.0", version)
+		assert.Equal(t, time.Date(2021, 1, 18, 10, 51, 9, 123000000, time.UTC), evt.Timestamp)
+
+		evt = &beat.Event{
+			Fields: common.MapStr{
+				"message": testCases["custom_target_root"].message,
+			},
+		}
+		evt, err = dec.Run(evt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		version, _ = evt.GetValue("cef.version")
+		assert.EqualValues(t, "1", version)
+
+		_, err = dec.Run(&beat.Event{Fields: common.MapStr{"message": "hello world!"}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not contain a CEF or LEEF header")
+		}
+	})
+
+	t.Run("leef_errors", func(t *testing.T) {
+		c := defaultConfig()
+		c.Format = formatLEEF
+		dec, err := newDecodeCEF(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evt := &beat.Event{
+			Fields: common.MapStr{
+				"message": "LEEF:1.0|Vendor|Product|1.0|login|src=10.0.1.800\tgarbage\tdevTime=yesterday",
+			},
+		}
+		evt, err = dec.Run(evt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errorMessage, _ := evt.GetValue("error.message")
+		assert.ElementsMatch(t, []string{
+			"malformed attribute 'garbage'",
+			"src: value is not a valid IP address",
+			"devTime: value is not a valid timestamp",
+		}, errorMessage)
+
+		_, err = dec.Run(&beat.Event{Fields: common.MapStr{"message": testCases["custom_target_root"].message}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not contain a LEEF header")
+		}
+	})
+}
 
-	events := readCEFSamples(t, source)
+func TestNew(t *testing.T) {
+	p, err := New(common.MustNewConfigFrom(map[string]interface{}{
+		"format":       "auto",
+		"target_field": "parsed",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	if *updateGolden {
-		writeGoldenJSON(t, source, events)
-		return
+	evt, err := p.Run(&beat.Event{Fields: common.MapStr{"message": leefMessage}})
+	if err != nil {
+		t.Fatal(err)
 	}
+	version, _ := evt.GetValue("parsed.version")
+	assert.EqualValues(t, "2.0", version)
 
-	expected := readGoldenJSON(t, source)
-	if !assert.Len(t, events, len(expected)) {
-		return
+	_, err = New(common.MustNewConfigFrom(map[string]interface{}{"format": "syslog"}))
+	assert.Error(t, err)
+}
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		source    string
+		configure func(*config)
+	}{
+		{source: "testdata/samples.log"},
+		{
+			source: "testdata/leef.log",
+			configure: func(c *config) {
+				c.Format = formatAuto
+				c.ExtensionMappings = []extensionMapping{
+					{
+						Vendor:  "forcepoint",
+						Product: "firewall",
+						Fields: []fromTo{
+							{From: "cs1", To: "rule.id"},
+							{From: "deviceCustomString2", To: "rule.id"},
+							{From: "cs3", To: "vulnerability.reference"},
+							{From: "deviceCustomString4", To: "cef.forcepoint.virus_id"},
+						},
+					},
+					{
+						Vendor: "lancope",
+						Fields: []fromTo{
+							{From: "cat", To: "rule.category"},
+						},
+					},
+				}
+			},
+		},
 	}
-	for i, e := range events {
-		assertEqual(t, expected[i], normalize(t, e))
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(filepath.Base(tc.source), func(t *testing.T) {
+			conf := defaultConfig()
+			conf.Field = "event.original"
+			if tc.configure != nil {
+				tc.configure(&conf)
+			}
+
+			events := readCEFSamples(t, tc.source, conf)
+
+			if *updateGolden {
+				writeGoldenJSON(t, tc.source, events)
+				return
+			}
+
+			expected := readGoldenJSON(t, tc.source)
+			if !assert.Len(t, events, len(expected)) {
+				return
+			}
+			for i, e := range events {
+				assertEqual(t, expected[i], normalize(t, e))
+			}
+		})
 	}
 }
 
-func readCEFSamples(t testing.TB, source string) []common.MapStr {
+func readCEFSamples(t testing.TB, source string, conf config) []common.MapStr {
 	f, err := os.Open(source)
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer f.Close()
 
-	conf := defaultConfig()
-	conf.Field = "event.original"
 	dec, err := newDecodeCEF(conf)
 	if err != nil {
 		t.Fatal(err)
@@ -19,5 +19,5 @@ func init() {
 // AssetDecodeCef returns asset data.
 // This is the base64 encoded gzipped contents of processors/decode_cef.
 func AssetDecodeCef() string {
-	return "eJzsPWlz28aS3/0rurQf7FRRiuTr7dNW3paeaMXcsmyujmRra6uSIdAkZzWYQWYGpJBf/2ouEOABUgDoxGL0wQlJoM+Znu5Gd+MYHjA/hwjHLwA01QzPoY+RiBEuP1xBKkWESgkJY4osVi8AYlSRpKmmgp/DP14AAFyKJBEcPsyQa7gSMiEaXl1+uPoOYqLJyQvwd5/bq4+BkwQDTvOn8xTPYSJFlvpv1iAxf//MIcYxyZgGPUX4NbaU/hLh+NcSqXNJNSogjFn8MJYisddffrgqQCWoFJkgaAF6ShX8aoGI0f9jpE9goCESXBPKVbgTpkhiDIIAwmPzSwEPHzVyRQUveDZ/Zb7LvM9QmmuL74MMHjCfCxmXvt8gCfP3kwMCYlzQqFKM6JhGxFwPmcIYRrn91fN78mKFlhhnNMKTGfJYyLYUGRiBIAcY9JRoo504izDejRZ3tW5HzNABaU/NPpTlOWxCDppt9kvEiFK/0LgdVfec/pYh0Bi5pmOKhe4sEgtxDSEKZyipznfAjY8kSY1R+QllfvyRTqa7ETZIUiE14RFWKDqBuynCjDAag9KS8on5kJndLhHu+QMXc96DT2Leq4C7xphmSQ8MAT27dwt6yiAp1zhBWYZ5evzmhxVwb4/f/xBA/u0Y/v2HBdy/H5+d/rAAvio88287pd1OhdTlC6oiWkVZ2Ca1grhsdLegvRSMYRTwPWB+bMUEKaFSQUSkpGhEWBijhUm0ZvCkgsZa8V/sD+cwJkyVhbJsNsvMkAlyfRHHEpWqXBBYounS1xWejLIHQyAOQJDchYxu6WRqrT7HSAtZ7ExzqPituSzfFbL6XPVFQihfS9iqktdS1/98C7GFYkF3TOJHofTn6hJ8MoVTofQeSBssI34SURYEDPodE3VNorrFtiNx1xeXe1pyn3WrFVcD+QYjpKm+oxsWS0z08g8rfGuaIBAN8ymNpkD52DqHxiSQkch06aiZEwXSYJwtfJZVEdVJwhD6v4K3WtluEVmqfxe86wV+JwlXjGiMG9uvnaAbKXx41Cg5YYN+9yujiun+ZrAHFHnahSLztIkOa+j6acUXfDJps6on2NHa2rfO96LpNGU+VhlKoUUkWFPBXixAAcMZMiM/C7EXfNCyV/fx7m7Ys//e9uD29uPsdQ/ukHHUPRh+GfZgcD28MP9emAuMX6cECL5BASOi0Aa/lyLjei0LTPBJLf0QmXuBKCUiarYWzKmeutjUu74fxRwSwnNrn5S1mPZnZY5iZ0XFSKGcYfyfYEmBiHAYIYiEagOSjoFqoArONnGSa1SD9ct7Gwufs2TkAggLBbSxEmOU0nqFI5HxuAcSGdF05uNuBCUyGdlPMSpNudOgu0pwNaVpDxIk3Hj6dlvYiN6wPmZibr610f1aMHU8fsma6amOSZFpy2UDJk/gSsiwUHv2JgMfeIHOZTLKPLugdgGvgmwD71GmtEhQ7slSBPBdG4oSm809/0EIctWK6IJrZlfYwieROEapjGApB8JN3MBRz4V8cEGj82bMfrI/zt4GQBukX0LZdaSQEllkOiJhVpFGGGeM5fBbRphhO65EFa+u/rv/+bvthP6I4hPRVGfxBi9QZCO21Q9kDgTlhDkz7JawcwuXtPFSlQO0EZotuYNEDaGCT1pT6mHsk9S2MVjNSrYrmPCnLWA1FRmLzUFBdlgza86oKg1cxNiD+RQ5EPvB7pAZoYyM2CbLVALQPuS6pY8QCSb4scIUpSV1io8kxogmhHmzuoOmWkZXVsg/Ux6LuVoX06+xQdtpGgrZ7Oxa5LlSIXUQgvWHRqjniBxOra/z/t27N+92IMR5qBvC9m3UDKWY0dgv4UXYXhaJd4HXLjjvE1WOTTpeLP0ifR+ADPpwdvquB0dnp++OzIr0aVj/487stk2elPVvSX2p1jG9nZ5blDMaYVt6lAMDmsgJ6hB8F17nVjI6CGqXDZouQK7atp1O5ycQ3Xg3mRuBjDVK41NbZ7wA+h/Gsi7WJYExlTgnjJ3AT+s34KnhY8d991UC/Y34mvt2drnd3wyscIweF4DBQF4ouGIXlUINU2LOV7Qf6YSbqNjqPUTP26V2r1A2zzDWHLmZQmk2zaC/bI443H8e/I9z56UQ2l1KFUyQoyTmoF02bfaKQR9Od2NoT15EYMngNJ4CVcFg2l822uOqKTuBDwmhLJxsPvQWY40cEpKmNi70UUtgxqf+nXsiMaIpNXvdOBAmmI1pTLSNpNJMh7upCg8YdhHZUNIZZTjBVgldnac0Ch6iY+zoIk4op0pLooU86sGRQXfk0gdHP2ao9FEQZr3oX5pTK1B5AoOwjqpGJdJ0RnUO+IhRZnQh+NJKmwe3rsDnFbYkEljIxJxMS4xsF+vXMUMdG5+9WRxzml7Y52SNk1nuKZsmD8hDLtw//K1F2l1obD2CSlS8S0xRR9ylTQ1cMUE05ZOhoFyffSIjbJ7xY8znG0JJxpTMTAQTCSlRpYLHlE+AGRzePsAHEk2976WCqXG2xT9MNlvVYRl5WaSZTIUqPLYyxqfy++bA+H37/PntE41njZ6OffFPl+bCpXM1SdKitijEymaXJaT43iVABXChbe48N78TnoPQU+NYcHcYxtTaDyLzXTk4DEW9/uYV9fr5K6p6QjRM5HmdjUUmYewBQmog/hGaq7C0YRF+0ywd2Kp88/xU+PZZsDQYzt57J3i95aj3gsvMGFCFA/wHs/L8t1eJ2/X28ZtU3AHYxRK3663iN6m4AwjUStyut/3fpOIOIOJ0BSjrj7dtjyxCKDOVWJSWdK4wuFcIKiWGe5a72inEByCQCIlFh0qvdA+oLE0ZxdhhdY+OU6EUrXtevCKTQ1H++iPysJV/AGetY3T9MXvYyj+A8/rWgl1v9nfg0etf0UdP4Deudy+OQ9H7eot/sHo/AGPvGF1v7A9W7wdj59eHZAer9wMI6xyj7/7Se0Uch6L393/pvSKO56z3PpW4uTpoa58Yz9e00c6tbgNgS0/oBINIJEnGQ2/clChXVxQaAJhvKSoVshkdCqkxhmM4Oj2yFVO+gQuEhKMz91Vod6rn9k/e52JodK17RONEyLwpnTeYSlTItVsdkQe3qB7zdVxC0oktNOOTUNMFfftf5UsjM4XmSipBzHkARH93+lPRFBNitq4dAELHeaiE/+C7HI++vxacaiG/71P18P0NknhzCZ9lP1TuNS6OvXCithYmsxNFWL5a42hrylzlq3btfFuLzM0tVySirDps5MkLaOxhbEBfLdu9zRUTE7tTCAd8TBmNqF7AWK5+xRnKfBdGGD52kb2dCyDMqsz2GD6LbE5JNs/Z9i/YbJfDfd5L4FmH913MX+mybc9a5YYde+begXMMBlyjHJOoeTdCAACCl5otUxI9oDZuh22ARnOVH4mwQ0V0x52E5OmdhLZDa99NhOVq7VpKvniX7Stpi+FY76qqIcmZIHFzJ2R1lFmo9E8d6Jo2wlrC9tHlGPoOvbO+zjl6Am1t7IkH4VZUTaPlBS/maCR2XMkIK5ws0a6cE4Vcyzw0QtWy0v20oXKnoGsy871GRedMeebQckf/9fU1xDHkeZ7Dx4/nSXJu1CUhoYxRhZHgsQJFeYSAqYim8Oq/CIczpeHs7387/a6O1S4GFS1GFIVV3nQN7bt/c7WVpFULZ5XkvXYeraL6Or2PTmLtm5D2Lpz2IqlvyWovCORxxxalMB5LRsUtaORxh6bEWpKK6S06+iSmQi52OY/NyUJAoVKbJ99YGhseZKEJlPgI3xxmVlXF/CqnGbub0fjTdabH/vYl05FofnL1qUoZyZ3JEQ5WDzKV+cZaeKmyyJxNL42wX44JZZnEl5soap0FuXMnfBi6S/jaXM/dFHObZQuEUh5JJMpc453J3roUA9ebXLsxZXgpkWhsvtTNMrfuv5GlAWjPxshC3RTPmMs+EjVtKi9zr1u1BlINkhaJKW40sixNhxCisJcMz9REPTU0XIu4mKvctZgZURoSi6BW2LyFm/e5FDBYxIKzHF4ZeYhMA9UKUqKnmxKl5pYh0Y11fZUxZhEEc2kA9szaZ5kNxy1N1gelWiEb19GBMqGqzRS+BQRVlkkNzlv6+3rRbzOb5sYdcbQaeegHHVoxvkppij1QwgRjPUAdnWzwR8cMH5u3MF4sN8Stpp5I6RKff0K1KQMV45jak30J6qbMFGHMchASNl1mqoydXqSCjL32Hf/MnPz+0YrxW8LIM5u2t3hdVsbwOEIwvssoBzdcXUHGmQ220JxLm8ubC720yoS5wVtFmutpCa2FYGto7KYq7at0D3Wey/zjV0g3tWF/Sb+N9L+NHdouo75fKv37Dlq4eESOqJZmyYSXekzozBBn11aMmlCmlgdOn8B1xjQ9ZpSjTVNRVGFcavE+hlEOmfXM/88EnvZmjnOw9/h88MaZ1ILFV1165oLFu3rmHnUXzrmNrTzmemxde+llzLv76p6Yjt31iux3cdc9GW0Ss2WPfTcNdOqgB5RlJ70IHrY76oGkffjqu0mjE5d9N1RdeO6V5b67By/J3JavdJrdu0Gi2s04lxYCEA4ki6kuzfj3+WmMK1UXcDQiMaREKQP9yJYZZe5tLnaUVRic5ewAYUrYx54cUEohzeUSdSY5RMY0LGphTh/PXr95u6n+ReJvGSp9yShyXZog3irHq1AeX9jp8+ssmke5hSDBNT421Sn0Fx8WpVJcG5KWRtl6fEWiCmN4VRky9vHubgg3aAdcy01LMBAtHmjzoWr+9uZCu0Y9Fa2Sd5bZxIJxWTzj1UZuAinc33yqx38vGztYA+5rxpzbRLgjxQPulUbcVd9Kdn/zyRMYks7mG+/g+ivDQzo7C984MXNkbAMnbnR4Z4+BwtjzduPDHJQ/dx2ho7H1qOxtwNuNt66D3rYq5R9LP0LrtbAMr9u6F09Oue5lBWOyUgcTzhV1Di+nQmn3YMH834nDexKJZNMDBoeyUUVK8RK1o9PT89P4/P3pORmfn43O358d1XsybYpXHMH7Kl4Jz/y8JuqrV9xF+xh8vXXurkfdqgjkrlL74TnePtx6B4L2MYy6Sl8tFV2PoJ66B6auTM8meuw6eUrRsCNsv0UNZWPWophhmdTG6/uivK79TOoRWnEtiB7lywNkO5hKvczCHksO1qH6pmZROwY6HUPtF2J5AvVOw5r9fdU3a+59dvVCAB2OrV4SAbcTq9fNnbZB405jpxXy2PHaeOb0gtU/eNz0QC83YpQE1nDOtO9k2WHO9G6TpB1Fezcde50fHdyYVgZCE9lBAWRRYFBobumMko4gi+4rFT7ac8gcL23f87a0+z+RHOXx20WAmxWhcHhUVN4z4TIFKoumJhq+uxwa5u77ww1K0Ztye9sO5VP73jJlXw7npN+DM/8dmUwkTozl7MFr/51tT3BvBHN7+E242D4R85bdZwGqr3UzS3KBRsErQyGcLker/7ao1fLmbi3HoXXNdaW1yW32/Su387QUO8ExDKV4zHsw6N/24GccgXEjUa6Xf6Dmi30ze1NK3N3LHhv1T4WqqUhavDaPcBBp8DxVrjHp2cJ2I+uez46jjjasnED5P3FKZrTyavUn0e6nwQsJBEYe1mpNEw/H+MD48HPvSTsvMLamyuf13Rvu60m+w2hqq/waG8sAwFNg02mv8GRyAt/3xe2mPEp16f249G7qJ1Hwo3NTirJoAwsYfUC4Cl5vPQ23dMLtMyTevE3ickokiTQW7Zve46Ib3m6+ZQu0q5f0t1ff8O50ojIT6vXA10j27GLTGpNUb1JVQjiZoGxbtv9zOKwuZKSsafpwe714EfCyaKon+okPHE9KlXHrxFHxEAIZt0tB5ya/uIq4/HqTrrG7NzAtYf9XAAAA///5hi9V"
+	return "eJztPWtz2ziS3+dXoLwfkqmSNXZee+ur2S2PHxNtxYkvdmaurq4qoUhIwpoiNABoWfPrt7sBUCQlUjJJZTbW+ENi89HoFxrdzUbjkN3xxQkL+eg7xowwMT9h5zyUEWdnF5dspmTItZaKjQSPIw0PRVyHSsyMkMkJ+ztcYOxMTqcyYRf3PDHsUqppYNhzeP17FiQReyfH7tbFg+FJxKPsmXcX+FAUmKAPgOwQJwTykCXBlHvE8McsZvD3WMl05q6swQR/flrAnVGQxoaZCWdfIiLnM0D6kqNnroThmgVxTOOzkZJTeh7wzkBN4dlgzJmRcEto9oWAyOG/eGj6bGBYKBMTiET7N9mEBxH33CLy4U4GjyMDNOCb0Yw/ebrztN9zhc9m1z0PQGJzqaLc9QpO4M8vFgiTowxHPeOhGIkwwOdZqkEgwwXddfT2v1vBJeL3IuR9EGMkVVuMEIZHyAKGX0EfQDpRGvJoO1zs06YdMtcWSHtsdiEsR2ETdDhOuM9hHGj9WUTtsPqUiN9SzkQEIEFveCY7GoQgrkFEw12YY4stxuYPwXSGlgfoXxy+FePJdogNpjOpTJCAxPIY9dkt/HofxCJi2iiRjPGPFGe74kDNXSLnSQ/s0rxXAHfFI5FOewwR6NHczfDJgxSJ4WNgQg7m0eHLH1fAvTp886MH+ddD9l8/LuH+7fD46Mcl8FXm4b/thHYzAdbkHyiyaHXIzDbplYHzRnfDsGcyjsE4uvEA40NiE5sFQmkWBkoJjizMjNHSJJIZ7BeGISv+mW6csFEQ6zxTymYzTwzMjcScRpGCeVJ4wJMkZqXLBZpQ2INrFlgAnnOnKrwBcZHVT4BIMGJ+ZuKi4qZmmb8raJ0n+lxOYdVYi9iqkNdid/7+hkUEhUB3jOJbqc37ogo+GsMJwNgBaoPywI9CikCwwXnHSF0FYZ2ybYnc1enZjlTuvWmlcTWQP/KQw1O3okJZwM8p31ih28DLDIiaT0Q4AdswIu8QTUIwlKnJLTXzQDOFI94vfZZVFtVxAhH9P5m00myrRIT17wCqY1ndqiDRMbAtamy/toKOXECHXCVBPDjvXjOKI336ONjBEACiA0HCKw1kWIPXLyu+4KNRuy96gh3p1q5lvhNJz2axi1XAWTcylHFTxp4uQbEYmBUj/whiz/ugea/u7e3tdY/+vemxm5u39y967JbHCTc9dv0Bbg2urk/x31N8AP06LZlMKgQwDDSnMPhMpolZS0Isk3Et/iB+eJeBUy9DgVOLzYWZ2NjUub5v5ZxNg2RB9kmTxaTbGpdia0XlUHMFFvQfjFABryxhQ5gCU2EQpBgxYRi8clxFyQIi58F69d5Ewvt0OrQBBEFhBq3EiCtFXiGY+yTqgYkHuwE23sbdHNiaqpD+AlhGJFaC9inwVydi1oOIKEjQ06dpQRE9kj6K5RyvUnS/FkwdjR/SZnKqIxIWNKKyAZF9zJt4Re3RSwifJdlwNpORp9kGtUt4hcEqaA9TbeSUqx1ZCg++a0ORI7O55z/wQa5eYZ13zUjDlj6J4iBZjYwFXxxmEsQNYCGAgjsbNFpvBucT3bx/5QFVcD83ZNeRwixQWaYjlKhFoD2jNI4X7LcUYtsRxmb5qOL55f+cv/9+M6I/c/kOfjFpVOEFynQYb/QDYwsCYMbWDFsVtm5hSRrPdD5AG3KckltwFBGFJ1tj6mDsEtW2MViNJpMGgzY+SoH1RKZxhAtFsIXOrFmjijgkMgIbNp9wGJP+oBlyH4g4AAFsZk/7kOtGPMAsAGkcaj7jilCdgHWNeCimIFdrVreQVMvoipj8q0giOdfrYvo1NmgzTtdSNVu7lnkuzK15JpA/NASt4CCvI/J13rx+/fL1FohYD7UibN+EDbx9LyKnwsuwPc8S5wKvVTjnExWWTXBvMtXP0vceCAxxfPS6xw7g3wPUSJeGdTe3Jrdt8iQvf0IVjMgaojfjcwOengh5W3y0BcNMoMbc+OA78zo3otFBUFs2aCYDuWrbtlqdH4F049mEL7JgBH4U+tTkjGdA/xst61IvwaoKxedBHPfZL+sn4BHSseW8+yqBfuV4zX07Ujd4nZiDclwCZgh5KeCCXYQY2LBJgOsrpz/FOMGomOTuo+fNXPsEit48w1iz5KYAGCcN2JCSOUrYp/eD/7XuvJLS2EdhakFADasSLrRl00ZPgKk62o6gHXkRniQcEz0FQNkZTLpTaY+LpqzPLmDFi/3K5kJvCVMmgTh2NqO40EUtnhiX+rfuiYLleiZwrqMDgcFsJDDriLKfpca/DTfdB4ZtWHatxL2I+Zi3SujCwyL0HqIl7OA0mopEaDADRqoDWGlwuAObPjj4GR4zB56Z9ax/hquWx7LPBl6PikYlhBBTmAVc4WGKspBJSdPm3q3LxnMCK7GELXmCK1OJkM1s/TpmqGPjszOLg6vpKX0na5zMsl/ZTHAHw7tcuPv4Wztod6ExeQSFqHibmKIOuTNKDVzGEnicjK8lzN3jdwGETI2ZFMcu3+BLMibBPUYwoVSA9kyCsUjGEHnCGM4+sIsAYjjre2lvaqxtcR+TcaraUYaOF7NUzaTOPLb8iI+l9+We0fvq6dN7DqvRcaOvYx/c16W5tOlcA2Y9qy3ysTLOMlgp/XWbAJUQUxvKnS/wPiaEJaCu7Kd3oDYSZD8CtdiWgv0Q1ItvXlAvnr6giitEw0Sek9lIpgpz5gQQYi6A+EdIrkBShRJ+0yTtmVa+fHoifPUkSBpc379xTvB6y1HvBeeJQVCZA/wHk/L0p1eO2vX28ZsU3B7YxRy1663iNym4PQjUctSut/3fpOD2IOK0BSjrl7dNnyx8KDNRPCst6VxgmMplehYg9Zjgxtopzu+AI1OpeLZDpZd7h+kUy7d4ZEe1n46BR1rUfS9e4cm+CH/9Ernfwt+DtdYSun6Z3W/h78F6fUNg15v9LWh08tfiwSH4jcvdsWNf5L7e4u+t3PfA2FtC1xv7vZX73tj59SHZ3sp9D8I6S+jrP+VeYMe+yP3Nn3IvsOMpy/1cKF5dHbRxnxgIbHUb7Zxk6wETPn4nGO4GmaaJ3xuHVU1UV+Q3AMRuS1GukA1lKBVWlB2yg6MDqphyG7gY/HpwbC/57U711P6H73NBHO3WvcDwsVSLpnh+5DNQLgBktSN04JbVY66OSyoxpkIz4Lmr6WLn9L92pZGpxro0LoDD88QDEr9b+elwwqcBTl1qACJGC18Jf+F2OR78cCUTYaT64Vzoux8+8iCqLuEj8n3lXuPi2FPLarIwKXUUAXGs1DhSTZmtfDV2O9/GInN85TIIRVxsNvJoBRo5GBXDF8t2bxY6lmOaKVj09oAbS4VZwihXv2IzlMU2hMT8oYvs7RzMdkwioz2GTyKbk+PNU7b9SzLb5XCftgo86fC+i/4rXW7bI6vccMcevjuwjsEgAXUEE9l8N4IHgGXsy82WsyC84wbdDtoAzfEp1xJhi4rojncSBo/fSUg7tHa9iTBfrV2LyQfnsn0lacV8ZLYV1XWwiGUQNXdCVluZ+Ur/mQVds42wFrFd7HL0+w6ds77OOXoEbm3siQNhNapmo+VpkvXRmFK7kiEvUFLCXVsnCl5VC78RqpaU7rsNZcjbTgt2AwdezHbO5HsOlXf0X11dsShiC/hhb9+eTKcnKC4FxMex0DyEZURD3I0N6vhMwnDP/wne4rE27Phvfz36vo7ULhoVLVsUeS1vqkO73r+5upUkL5hGe0m+0gbI1aG+zt5Hy7H2m5B2zpz2LKnfktWeETyJOrYomfEoGRWr0NQDtzNTQpakYHqzHX2KY4omm+WYmRmhuwqTTFR2viEcGy5kfhNo4CJ8XMxIVFn/KisZms0c/ek600P3wBsJZfOV61zoWRwsrMmRFlaPpTp1G2vZM52GuDY9Q2Y/G4E3myr+rAqj1lmQW7vC+6a7IMJ1uR54akFZNo8oyF3xQOMzzpnsrUsxJKbKtRuJmJ8BCMObqzqqObn/yEsESGtjSFCr4hl87G2gJ035he9arUVINYO0SEwlKJEyN+2A2HDLziWkWWDUU4PDlYyyvspdszkOwGuY0gC1zE5auHnvcwEDDSwT0L3nyA9MIAujwU02k6pEKb5yDfebDn8J4SkN4M0lAsQd8mGcUjhOOJEPCqjweFSHB1dTodt04VtC0Hme1Ix5I35fz/pNZhNf3HKMVi0PXaNDYuPzmZgBc0Hp77CfHTdhv8IfHcX8ofkWxtPyhrjV1FOQe8Tln8BHrMhARXwkaGUvQa3KTGGDd6TAJ2y6zFShnV6mgtBeux3/Ma787tMK+i2+5Rml7Wlcm5VBGofYXcXgYmCbq2tYO2MKtjiuS9XlzZlcWmXCcEHKpbkel9BaMrYGx26q0r7K7qHOc5l/vIZ0Uxv2J/fbcP/bmKHtMuq7xdKdd9DCxQvUUBiFKuMP9RhDiAbIkW5F3IAW63LD6T67SmMjDmNYcihNhckL1y41O48BNCMlz/z/MfCklxM+Z/SOywdX9qSWcXTZpWcO8Lb1zN3QXTjnFFu5ketH69pLz4+8va/ukOnYXS/wfht33aHRJjGb99i3k0CnDrofMu+kZ8HDZkfdo7QLX307bnTism83VBeee0Hdt/fgVTCn8pVOs3sfeaDb9ThXBAFzIAGojsn1+Hf5acyS5aou2MEwiED/tEboB1RmlNrTXKiVlW+cZe1AEGtJnz0TxpXCUiQFI5pUJQzPg8rVwhw9HL94+aqq/kXx37Ar11mMPcZyHcRb5XgB28NT6j6/zqK5ITcgJBPDH5rKlJ0v/1iWSgFEdOyKrWzdeFmiCjB9Xmgyhh3SQR2owbWqUkGPtLwTzZuqudebM+2Km4lslbwjYqcExmbx0KsNbQdS9unju/rxP6nGDtYgcTVj1m0CtSZUHOAeW7a4K55KBjg5BH3SGa84B9c96T/SUS98dGLmPI4rKLGtwzv7DOTbnrdrH2ah/GfXEVocW7fK3gS8XXvrOuhtq1L+XrrJWutCGV63dS8OnXzdy8qI05U6GL+u6BP2DM9Ash8W8Le+HbcPqlT1gcEO2agiJTtE7eDo6OQoOnlzdBKMTo6HJ2+OD+o9mTbFKxbhXRWv+G9+ThL11Sv2oV00vt7Yd9cN3aoI5LZQ++Eo3tzceguE2laS5euIfAfXIn61WHTdgnpiP5jaMj1K9JCePKZo2CK226KGvDFrUcxQRrWxfp/m9dr1pB5yYtcS6eGi3ECWte9KXSZhhyUH64b6pnpRWwI6bUPtFDHfgXqrZs3uveLJmjvvXb1kQBuDUc8CHGl932kKGrdqO62xikO16jm9JPUPbjc9MOWNGDmGNewz7XaybNFnertO0hajnZuOnfaP9m5MKwNhIChpX66UFRhkkiutUcoiRMN9pcJHWodweWl7zltp9r8LFlwdvloGuGkWCvtPRfk54x8DAlLAHMR0e3aNxH06v64QiqnK7W1alI/o3DJNh8NZ7vfYsbsWjMeKj9Fy9tgLd422J9gTwewcfukfpi9izrK7LEDxWDdUyeUwmj1HDNlROVr9y7JWy5m7tRT7rWt2V1qb3Oa5O3Ibnl/GTjAOKMEDeCOD85se+5UPGbqRXK3nv8fmA53M3hQT+3bZYxPuq1AxFSmyY/OwhGvmPU+9MHzao8J25HXPZccxN1uP+U98EtyLwtHqj8LddYMHDANAysJarWlK/DI+QB9+7jxp6wVGZKpcXt+ecF+P8i0PJ1Tl19hYegAOA0qnPef9cZ/9cC5vqvIoRdX7uXQ29aMw+Nm6KVlZNMJisbjj7NJ7vfU4wERJ6BtS0nybxNkEou4Q1jS/fdN5XKLidPMNU6BdvaR7vXjCu5UJWENwlEClbY1kj5QNTMt0ZqpENQ2SYMxV27L9X/1iBaZJk2m6uLlaHgRcZo0fPeZ89F1+nPw55hWZqZ8WzB07TmC/RBw/FnwO+eiLD3aB7LkSeK4j1hbZIxcxY/7u4uIyg+M+MmvmHcEviM0XP7PQ5SpkafFlNuFBlNuilywlRSu1MUoMwbnKTLJ9cHkCuif8fuX82Sr51+TnfimeQEsI+hKM7BSSzM9z9Ob0oFgF3rfFE21RQhilLU3+HFz7MX4rXOzTph0y1xZIe2x2IS1H4aPQoSn0WUTtEFndYVUwJbTMr4691O2V0WV5TbcXPlfjRtP3M02OE7BWsebboX4m49g1RwCcAfKhPdpxFgiFZR9KCe4KDd18yE/Jgulx56u6nFU/V5S7Du1CcOKxuSnlu6pC8uLAuURE56Pbw99Ko/8bDo+Kww=="
 }
@@ -5,6 +5,7 @@
 package decode_cef
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -118,3 +119,45 @@ var ecsExtensionMapping = map[string]mappedField{
 	},
 	"type": {Target: "event.kind"},
 }
+
+// ecsLEEFAttributeMapping maps the predefined LEEF attributes to ECS fields.
+// The devTime attribute is handled separately because its format is given by
+// the devTimeFormat attribute.
+var ecsLEEFAttributeMapping = map[string]mappedField{
+	"dst":            {Target: "destination.ip", Translate: toType(cef.IPType)},
+	"dstBytes":       {Target: "destination.bytes", Translate: toType(cef.LongType)},
+	"dstMAC":         {Target: "destination.mac", Translate: toType(cef.MACAddressType)},
+	"dstPackets":     {Target: "destination.packets", Translate: toType(cef.LongType)},
+	"dstPort":        {Target: "destination.port", Translate: toType(cef.IntegerType)},
+	"dstPostNAT":     {Target: "destination.nat.ip", Translate: toType(cef.IPType)},
+	"dstPostNATPort": {Target: "destination.nat.port", Translate: toType(cef.IntegerType)},
+	"policy":         {Target: "rule.name", Translate: toType(cef.StringType)},
+	"proto": {
+		Target: "network.transport",
+		Translate: func(in *cef.Field) (interface{}, error) {
+			// Protocol numbers are not transports.
+			if _, err := strconv.Atoi(in.String); err == nil {
+				return nil, nil
+			}
+			return strings.ToLower(in.String), nil
+		},
+	},
+	"sev":            {Target: "event.severity", Translate: toType(cef.IntegerType)},
+	"src":            {Target: "source.ip", Translate: toType(cef.IPType)},
+	"srcBytes":       {Target: "source.bytes", Translate: toType(cef.LongType)},
+	"srcMAC":         {Target: "source.mac", Translate: toType(cef.MACAddressType)},
+	"srcPackets":     {Target: "source.packets", Translate: toType(cef.LongType)},
+	"srcPort":        {Target: "source.port", Translate: toType(cef.IntegerType)},
+	"srcPostNAT":     {Target: "source.nat.ip", Translate: toType(cef.IPType)},
+	"srcPostNATPort": {Target: "source.nat.port", Translate: toType(cef.IntegerType)},
+	"totalPackets":   {Target: "network.packets", Translate: toType(cef.LongType)},
+	"url":            {Target: "url.original", Translate: toType(cef.StringType)},
+	"usrName":        {Target: "user.name", Translate: toType(cef.StringType)},
+}
+
+// toType returns a translation converting the value to a CEF data type.
+func toType(typ cef.DataType) func(in *cef.Field) (interface{}, error) {
+	return func(in *cef.Field) (interface{}, error) {
+		return cef.ToType(in.String, typ)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leef
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// Header is the prefix of LEEF messages.
+const Header = "LEEF:"
+
+const defaultDelimiter = "\t"
+
+// Event is a single LEEF message.
+type Event struct {
+	// LEEF version, 1.0 or 2.0.
+	Version string `json:"version"`
+
+	// Vendor of the sending device.
+	Vendor string `json:"vendor"`
+
+	// Product of the sending device.
+	Product string `json:"product"`
+
+	// Version of the sending device.
+	ProductVersion string `json:"product_version"`
+
+	// Event ID identifies the type of event reported.
+	EventID string `json:"event_id"`
+
+	// Delimiter of the attributes, a tab unless the LEEF 2.0 header sets
+	// another one.
+	Delimiter string `json:"delimiter"`
+
+	// Attributes is a collection of key-value pairs. The keys are either
+	// predefined by the LEEF specification, like src or devTime, or custom
+	// keys of the vendor.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Unpack unpacks a Log Event Extended Format (LEEF) message. The data must
+// begin with the LEEF header (e.g. starts with "LEEF:").
+//
+// The LEEF message consists of a pipe delimited header followed by attributes.
+//
+//    LEEF:1.0|Vendor|Product|Version|EventID|[Attributes]
+//    LEEF:2.0|Vendor|Product|Version|EventID|[DelimiterCharacter|][Attributes]
+//
+// Each attribute is a key and a value separated by an equals sign (=), like
+// "src=10.0.0.1". Attributes are separated by a tab, or with LEEF 2.0 by the
+// delimiter character of the header, given as a character or as its hex value
+// (e.g. "^" or "x5E").
+//
+// If the header is invalid the Version is empty. Malformed attributes are
+// skipped and reported in the returned error.
+func (e *Event) Unpack(data string) error {
+	*e = Event{}
+
+	if !strings.HasPrefix(data, Header) {
+		return errors.New("missing LEEF header")
+	}
+	data = strings.TrimRight(data[len(Header):], "\r\n")
+
+	parts := strings.SplitN(data, "|", 6)
+	if len(parts) < 5 {
+		return errors.New("incomplete LEEF header")
+	}
+	version := parts[0]
+	switch version {
+	case "1.0", "1", "2.0", "2":
+	default:
+		return errors.Errorf("unsupported LEEF version '%v'", version)
+	}
+
+	e.Vendor = parts[1]
+	e.Product = parts[2]
+	e.ProductVersion = parts[3]
+	e.EventID = parts[4]
+	e.Delimiter = defaultDelimiter
+	if len(parts) == 5 {
+		e.Version = version
+		return nil
+	}
+
+	attributes := parts[5]
+	if version[0] == '2' {
+		if idx := strings.IndexByte(attributes, '|'); idx != -1 {
+			if delimiter, ok := parseDelimiter(attributes[:idx]); ok {
+				e.Delimiter = delimiter
+				attributes = attributes[idx+1:]
+			}
+		}
+	}
+	e.Version = version
+
+	var errs []error
+	for _, attribute := range strings.Split(attributes, e.Delimiter) {
+		if attribute == "" {
+			continue
+		}
+		idx := strings.IndexByte(attribute, '=')
+		if idx <= 0 {
+			errs = append(errs, errors.Errorf("malformed attribute '%v'", truncate(attribute)))
+			continue
+		}
+		if e.Attributes == nil {
+			e.Attributes = map[string]string{}
+		}
+		e.Attributes[attribute[:idx]] = attribute[idx+1:]
+	}
+	return multierr.Combine(errs...)
+}
+
+// parseDelimiter parses the delimiter of a LEEF 2.0 header, a single
+// character or its hex value prefixed by x or 0x. An empty delimiter is the
+// default tab.
+func parseDelimiter(s string) (string, bool) {
+	if s == "" {
+		return defaultDelimiter, true
+	}
+	if len(s) == 1 {
+		return s, s != "="
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(s), "0"), "x")
+	if len(hex) == len(s) || len(hex) < 2 || len(hex) > 4 {
+		return "", false
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || code == 0 || code == '=' {
+		return "", false
+	}
+	return string(rune(code)), true
+}
+
+func truncate(s string) string {
+	if len(s) > 32 {
+		return s[:32] + "..."
+	}
+	return s
+}
@@ -0,0 +1,180 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leef
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventUnpack(t *testing.T) {
+	testCases := map[string]struct {
+		message    string
+		expected   Event
+		errMessage string
+	}{
+		"version_1": {
+			message: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tmsg=there are spaces = here\n",
+			expected: Event{
+				Version:        "1.0",
+				Vendor:         "Microsoft",
+				Product:        "MSExchange",
+				ProductVersion: "4.0 SP1",
+				EventID:        "15345",
+				Delimiter:      "\t",
+				Attributes: map[string]string{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"sev": "5",
+					"cat": "anomaly",
+					"msg": "there are spaces = here",
+				},
+			},
+		},
+		"version_2_delimiter": {
+			message: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^srcPort=81^dstPort=21",
+			expected: Event{
+				Version:        "2.0",
+				Vendor:         "Lancope",
+				Product:        "StealthWatch",
+				ProductVersion: "1.0",
+				EventID:        "41",
+				Delimiter:      "^",
+				Attributes: map[string]string{
+					"src":     "10.0.1.8",
+					"dst":     "10.0.0.5",
+					"sev":     "5",
+					"srcPort": "81",
+					"dstPort": "21",
+				},
+			},
+		},
+		"version_2_hex_delimiter": {
+			message: "LEEF:2.0|Vendor|Product|1.0|login|0x7C|usrName=jane|src=10.0.1.8",
+			expected: Event{
+				Version:        "2.0",
+				Vendor:         "Vendor",
+				Product:        "Product",
+				ProductVersion: "1.0",
+				EventID:        "login",
+				Delimiter:      "|",
+				Attributes: map[string]string{
+					"usrName": "jane",
+					"src":     "10.0.1.8",
+				},
+			},
+		},
+		"version_2_default_delimiter": {
+			message: "LEEF:2.0|Vendor|Product|1.0|login|src=10.0.1.8\turl=https://example.com/?a|b",
+			expected: Event{
+				Version:        "2.0",
+				Vendor:         "Vendor",
+				Product:        "Product",
+				ProductVersion: "1.0",
+				EventID:        "login",
+				Delimiter:      "\t",
+				Attributes: map[string]string{
+					"src": "10.0.1.8",
+					"url": "https://example.com/?a|b",
+				},
+			},
+		},
+		"header_only": {
+			message: "LEEF:1.0|Vendor|Product|1.0|login",
+			expected: Event{
+				Version:        "1.0",
+				Vendor:         "Vendor",
+				Product:        "Product",
+				ProductVersion: "1.0",
+				EventID:        "login",
+				Delimiter:      "\t",
+			},
+		},
+		"malformed_attribute": {
+			message: "LEEF:1.0|Vendor|Product|1.0|login|src=10.0.1.8\t=jane\tgarbage",
+			expected: Event{
+				Version:        "1.0",
+				Vendor:         "Vendor",
+				Product:        "Product",
+				ProductVersion: "1.0",
+				EventID:        "login",
+				Delimiter:      "\t",
+				Attributes:     map[string]string{"src": "10.0.1.8"},
+			},
+			errMessage: "malformed attribute '=jane'; malformed attribute 'garbage'",
+		},
+		"unsupported_version": {
+			message:    "LEEF:3.0|Vendor|Product|1.0|login|src=10.0.1.8",
+			errMessage: "unsupported LEEF version '3.0'",
+		},
+		"incomplete_header": {
+			message:    "LEEF:1.0|Vendor|Product",
+			errMessage: "incomplete LEEF header",
+		},
+		"not_leef": {
+			message:    "CEF:0|Vendor|Product|1.0|100|name|1|src=10.0.1.8",
+			errMessage: "missing LEEF header",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var e Event
+			err := e.Unpack(tc.message)
+			if tc.errMessage != "" {
+				assert.EqualError(t, err, tc.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, e)
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	for s, expected := range map[string]string{
+		"":     "\t",
+		"^":    "^",
+		"x09":  "\t",
+		"0x5E": "^",
+		"xa6":  "¦",
+	} {
+		delimiter, ok := parseDelimiter(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, expected, delimiter, s)
+	}
+
+	for _, s := range []string{"=", "src", "x3D", "0x", "x00", "xyz"} {
+		_, ok := parseDelimiter(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	year := time.Now().UTC().Year()
+	for _, tc := range []struct {
+		value, format string
+		expected      time.Time
+	}{
+		{"Jan 18 2021 10:51:09", "MMM dd yyyy HH:mm:ss", time.Date(2021, 1, 18, 10, 51, 9, 0, time.UTC)},
+		{"2021-01-18T10:51:09.123+0100", "yyyy-MM-dd'T'HH:mm:ss.SSSZ", time.Date(2021, 1, 18, 9, 51, 9, 123000000, time.UTC)},
+		{"18/01/21 10:51:09 PM", "dd/MM/yy hh:mm:ss a", time.Date(2021, 1, 18, 22, 51, 9, 0, time.UTC)},
+		{"Jan 8 10:51:09", "MMM d HH:mm:ss", time.Date(year, 1, 8, 10, 51, 9, 0, time.UTC)},
+	} {
+		ts, err := ParseTime(tc.value, tc.format)
+		if assert.NoError(t, err, tc.format) {
+			assert.True(t, tc.expected.Equal(ts), "%v: %v", tc.format, ts)
+		}
+	}
+
+	_, err := ParseTime("Jan 18 2021", "MMM dd yyyy HH:mm:ss")
+	assert.Error(t, err)
+
+	_, err = ParseTime("2021", "uuuu")
+	assert.EqualError(t, err, "unsupported letter 'u' in time format 'uuuu'")
+}
@@ -0,0 +1,89 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leef
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// javaLayouts maps the letters of Java SimpleDateFormat patterns, by number of
+// repetitions, to Go layouts.
+var javaLayouts = map[byte][]string{
+	'y': {"2006", "06", "2006", "2006"},
+	'M': {"1", "01", "Jan", "January"},
+	'd': {"2", "02"},
+	'H': {"15", "15"},
+	'h': {"3", "03"},
+	'm': {"4", "04"},
+	's': {"5", "05"},
+	'S': {"0", "00", "000", "0000", "00000", "000000", "0000000", "00000000", "000000000"},
+	'a': {"PM"},
+	'E': {"Mon", "Mon", "Mon", "Monday"},
+	'z': {"MST", "MST", "MST", "MST"},
+	'Z': {"-0700"},
+	'X': {"Z07", "Z0700", "Z07:00"},
+}
+
+// ParseTime parses the devTime attribute with the devTimeFormat attribute, a
+// Java SimpleDateFormat pattern like "MMM dd yyyy HH:mm:ss". Times without a
+// time zone are in UTC, and times without a year in the current year.
+func ParseTime(value, format string) (time.Time, error) {
+	layout, err := toLayout(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	ts, err := time.ParseInLocation(layout, value, time.UTC)
+	if err != nil {
+		return time.Time{}, errors.Errorf("value is not a valid timestamp for format '%v'", format)
+	}
+	if ts.Year() == 0 {
+		ts = ts.AddDate(time.Now().In(ts.Location()).Year(), 0, 0)
+	}
+	return ts, nil
+}
+
+// toLayout converts a Java SimpleDateFormat pattern to a Go time layout.
+func toLayout(format string) (string, error) {
+	var layout strings.Builder
+	for i := 0; i < len(format); {
+		c := format[i]
+		switch {
+		case c == '\'':
+			// Quoted text, '' is a single quote
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end == -1 {
+				return "", errors.Errorf("unterminated quote in time format '%v'", format)
+			}
+			if end == 0 {
+				layout.WriteByte('\'')
+			} else {
+				layout.WriteString(format[i+1 : i+1+end])
+			}
+			i += end + 2
+		case ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+			n := 1
+			for i+n < len(format) && format[i+n] == c {
+				n++
+			}
+			layouts, found := javaLayouts[c]
+			if !found {
+				return "", errors.Errorf("unsupported letter '%c' in time format '%v'", c, format)
+			}
+			if n <= len(layouts) {
+				layout.WriteString(layouts[n-1])
+			} else {
+				layout.WriteString(layouts[len(layouts)-1])
+			}
+			i += n
+		default:
+			layout.WriteByte(c)
+			i++
+		}
+	}
+	return layout.String(), nil
+}
@@ -7,6 +7,9 @@ package cmd
 import (
 	"github.com/elastic/beats/v7/heartbeat/cmd"
 	xpackcmd "github.com/elastic/beats/v7/x-pack/libbeat/cmd"
+
+	// Register the x-pack features of Heartbeat.
+	_ "github.com/elastic/beats/v7/x-pack/heartbeat/include"
 )
 
 // RootCmd to handle beats cli
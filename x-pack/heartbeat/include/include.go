@@ -0,0 +1,11 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package include
+
+import (
+	// Import packages that need to register themselves.
+	_ "github.com/elastic/beats/v7/x-pack/heartbeat/monitors/active/http/aws"
+	_ "github.com/elastic/beats/v7/x-pack/heartbeat/statuspage/s3"
+)
@@ -0,0 +1,135 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/pkg/errors"
+
+	hbhttp "github.com/elastic/beats/v7/heartbeat/monitors/active/http"
+	"github.com/elastic/beats/v7/libbeat/common"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+func init() {
+	hbhttp.RegisterAuthorizer("aws", newAuthorizer)
+}
+
+// config configures signing of check requests with AWS Signature Version 4.
+type config struct {
+	Service   string              `config:"service" validate:"required"`
+	Region    string              `config:"region" validate:"required"`
+	AwsConfig awscommon.ConfigAWS `config:",inline"`
+}
+
+func (c *config) Validate() error {
+	if (c.AwsConfig.AccessKeyID == "") != (c.AwsConfig.SecretAccessKey == "") {
+		return fmt.Errorf("both access_key_id and secret_access_key must be set")
+	}
+	return nil
+}
+
+// authorizer signs every request with SigV4.
+type authorizer struct {
+	credentials *credentials
+	service     string
+	region      string
+}
+
+func newAuthorizer(cfg *common.Config) (hbhttp.Authorizer, error) {
+	var config config
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	awsConfig, err := awscommon.GetAWSCredentials(config.AwsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "getAWSCredentials failed")
+	}
+
+	return &authorizer{
+		credentials: &credentials{provider: awsConfig.Credentials},
+		service:     config.Service,
+		region:      config.Region,
+	}, nil
+}
+
+// Authorize signs the request. The signature includes the current time, so
+// requests must be signed again on every check. The request headers are
+// copied, as the request is shared between check runs.
+func (a *authorizer) Authorize(req *http.Request, body []byte) error {
+	creds, err := a.credentials.retrieve(req.Context())
+	if err != nil {
+		return fmt.Errorf("could not get AWS credentials: %w", err)
+	}
+
+	// Passing an empty body would make the signer attach it to the request,
+	// which would then be sent chunked.
+	var payload io.ReadSeeker
+	if len(body) > 0 {
+		payload = bytes.NewReader(body)
+	}
+
+	signer := v4.NewSigner(awssdk.StaticCredentialsProvider{Value: creds})
+	req.Header = req.Header.Clone()
+	if _, err := signer.Sign(req, payload, a.service, a.region, time.Now()); err != nil {
+		return fmt.Errorf("could not sign request: %w", err)
+	}
+	return nil
+}
+
+// credentials retrieves the credentials of a provider, which can call STS,
+// running at most one retrieval at a time. The providers do not take a
+// context, so a check that is canceled stops waiting for the retrieval, but
+// the retrieval goes on, and the following checks wait for it instead of
+// starting new ones. Its goroutine exits when the provider returns.
+type credentials struct {
+	provider awssdk.CredentialsProvider
+
+	mu      sync.Mutex
+	pending *retrieval
+}
+
+type retrieval struct {
+	done  chan struct{}
+	creds awssdk.Credentials
+	err   error
+}
+
+func (c *credentials) retrieve(ctx context.Context) (awssdk.Credentials, error) {
+	c.mu.Lock()
+	r := c.pending
+	if r == nil {
+		r = &retrieval{done: make(chan struct{})}
+		c.pending = r
+		go c.run(r)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.creds, r.err
+	case <-ctx.Done():
+		return awssdk.Credentials{}, ctx.Err()
+	}
+}
+
+func (c *credentials) run(r *retrieval) {
+	r.creds, r.err = c.provider.Retrieve()
+
+	c.mu.Lock()
+	c.pending = nil
+	c.mu.Unlock()
+	close(r.done)
+}
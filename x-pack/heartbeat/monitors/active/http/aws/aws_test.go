@@ -0,0 +1,129 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestConfigValidate(t *testing.T) {
+	for name, test := range map[string]struct {
+		config common.MapStr
+		err    bool
+	}{
+		"default credentials": {
+			config: common.MapStr{"service": "execute-api", "region": "us-east-1"},
+		},
+		"missing region": {
+			config: common.MapStr{"service": "execute-api"},
+			err:    true,
+		},
+		"missing secret access key": {
+			config: common.MapStr{"service": "execute-api", "region": "us-east-1", "access_key_id": "AKID"},
+			err:    true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var c config
+			err := common.MustNewConfigFrom(test.config).Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	auth, err := newAuthorizer(common.MustNewConfigFrom(common.MapStr{
+		"service":           "execute-api",
+		"region":            "eu-west-1",
+		"access_key_id":     "AKID",
+		"secret_access_key": "SECRET",
+		"session_token":     "TOKEN",
+	}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "https://abc123.execute-api.eu-west-1.amazonaws.com/prod/health", nil)
+	require.NoError(t, err)
+	orig := req.Header
+
+	signed := req.WithContext(req.Context())
+	require.NoError(t, auth.Authorize(signed, []byte(`{"ping":true}`)))
+
+	authHeader := signed.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(authHeader, "AWS4-HMAC-SHA256 Credential=AKID/"), authHeader)
+	assert.Contains(t, authHeader, "/eu-west-1/execute-api/aws4_request")
+	assert.NotEmpty(t, signed.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "TOKEN", signed.Header.Get("X-Amz-Security-Token"))
+
+	// the shared request must not be modified
+	assert.Empty(t, orig.Get("Authorization"))
+}
+
+// blockingProvider does not return credentials until it is released.
+type blockingProvider struct {
+	release chan struct{}
+	calls   int32
+}
+
+func (p *blockingProvider) Retrieve() (awssdk.Credentials, error) {
+	atomic.AddInt32(&p.calls, 1)
+	<-p.release
+	return awssdk.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+}
+
+func TestAuthorizeCanceled(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	defer close(provider.release)
+	auth := &authorizer{credentials: &credentials{provider: provider}, service: "es", region: "us-east-1"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://search.example.com/_cluster/health", nil)
+	require.NoError(t, err)
+
+	err = auth.Authorize(req, nil)
+	assert.True(t, errors.Is(err, context.Canceled), err)
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestRetrieveOnce(t *testing.T) {
+	provider := &blockingProvider{release: make(chan struct{})}
+	creds := &credentials{provider: provider}
+
+	// canceled checks do not start new retrievals
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		_, err := creds.retrieve(ctx)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&provider.calls))
+
+	close(provider.release)
+	got, err := creds.retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "AKID", got.AccessKeyID)
+	assert.LessOrEqual(t, atomic.LoadInt32(&provider.calls), int32(2))
+
+	// the retrieval is done, the next one calls the provider again
+	creds.mu.Lock()
+	assert.Nil(t, creds.pending)
+	creds.mu.Unlock()
+}
@@ -0,0 +1,27 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"fmt"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+// config defines the bucket the status page is uploaded to, and the
+// credentials used.
+type config struct {
+	Bucket    string              `config:"bucket" validate:"required"`
+	Prefix    string              `config:"prefix"`
+	Region    string              `config:"region"`
+	AwsConfig awscommon.ConfigAWS `config:",inline"`
+}
+
+func (c *config) Validate() error {
+	if (c.AwsConfig.AccessKeyID == "") != (c.AwsConfig.SecretAccessKey == "") {
+		return fmt.Errorf("both access_key_id and secret_access_key must be set")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/heartbeat/statuspage"
+	"github.com/elastic/beats/v7/libbeat/common"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+func init() {
+	statuspage.RegisterUploader("s3", newUploader)
+}
+
+// uploader puts the files of the status page in a bucket, cached by clients
+// for at most a period.
+type uploader struct {
+	svc          s3iface.ClientAPI
+	bucket       string
+	prefix       string
+	cacheControl string
+}
+
+func newUploader(cfg *common.Config, period time.Duration) (statuspage.Uploader, error) {
+	var config config
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	awsConfig, err := awscommon.GetAWSCredentials(config.AwsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "getAWSCredentials failed")
+	}
+	if config.Region != "" {
+		awsConfig.Region = config.Region
+	}
+
+	return &uploader{
+		svc:          s3.New(awscommon.EnrichAWSConfigWithEndpoint(config.AwsConfig.Endpoint, "s3", awsConfig.Region, awsConfig)),
+		bucket:       config.Bucket,
+		prefix:       config.Prefix,
+		cacheControl: fmt.Sprintf("max-age=%d", int(period/time.Second)),
+	}, nil
+}
+
+func (u *uploader) Upload(ctx context.Context, name, contentType string, data []byte) error {
+	key := path.Join(u.prefix, name)
+	_, err := u.svc.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:       awssdk.String(u.bucket),
+		Key:          awssdk.String(key),
+		Body:         bytes.NewReader(data),
+		ContentType:  awssdk.String(contentType),
+		CacheControl: awssdk.String(u.cacheControl),
+	}).Send(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to put object '%s' in bucket '%s': %w", key, u.bucket, err)
+	}
+	return nil
+}
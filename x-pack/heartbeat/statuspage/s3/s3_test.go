@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+func TestNewUploader(t *testing.T) {
+	for name, test := range map[string]struct {
+		config common.MapStr
+		err    bool
+	}{
+		"static credentials": {
+			config: common.MapStr{
+				"bucket":            "status",
+				"prefix":            "public/",
+				"region":            "eu-west-1",
+				"access_key_id":     "AKIAEXAMPLE",
+				"secret_access_key": "secret",
+			},
+		},
+		"missing bucket": {
+			config: common.MapStr{"region": "eu-west-1"},
+			err:    true,
+		},
+		"missing secret access key": {
+			config: common.MapStr{
+				"bucket":        "status",
+				"access_key_id": "AKIAEXAMPLE",
+			},
+			err: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			u, err := newUploader(common.MustNewConfigFrom(test.config), time.Minute)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, "status", u.(*uploader).bucket)
+				assert.Equal(t, "public/", u.(*uploader).prefix)
+				assert.Equal(t, "max-age=60", u.(*uploader).cacheControl)
+			}
+		})
+	}
+}
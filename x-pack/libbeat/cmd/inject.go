@@ -19,6 +19,9 @@ import (
 	// register autodiscover providers
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/ec2"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/aws/elb"
+
+	// register outputs
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/outputs/s3"
 )
 
 // AddXPack extends the given root folder with XPack features
@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+// minPartSize is the minimum size of all but the last part of a multipart
+// upload accepted by S3.
+const minPartSize = 5 * 1024 * 1024
+
+type config struct {
+	Bucket string `config:"bucket" validate:"required"`
+	Region string `config:"region"`
+	// EndpointURL is the URL of an S3 compatible store, replacing the AWS endpoint
+	EndpointURL string `config:"endpoint_url"`
+	// PathStyle addresses the bucket in the path instead of the host name
+	PathStyle bool                `config:"path_style"`
+	AWSConfig awscommon.ConfigAWS `config:",inline"`
+
+	// Prefix is the key prefix of the objects, events with different prefixes
+	// are written to different objects
+	Prefix        *fmtstr.EventFormatString `config:"prefix"`
+	Compression   string                    `config:"compression"`
+	Codec         codec.Config              `config:"codec"`
+	FlushInterval time.Duration             `config:"flush_interval" validate:"positive"`
+	MaxObjectSize cfgtype.ByteSize          `config:"max_object_size" validate:"min=1"`
+	PartSize      cfgtype.ByteSize          `config:"part_size"`
+	PartRetries   int                       `config:"part_max_retries" validate:"min=0"`
+	Timeout       time.Duration             `config:"timeout" validate:"positive"`
+
+	MaxRetries  int `config:"max_retries" validate:"min=-1"`
+	BulkMaxSize int `config:"bulk_max_size"`
+}
+
+const (
+	compressionNone = "none"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+var defaultConfig = config{
+	Prefix:        fmtstr.MustCompileEvent("%{[agent.name]}/%{+yyyy}/%{+MM}/%{+dd}/%{+HH}/"),
+	Compression:   compressionGzip,
+	FlushInterval: 5 * time.Minute,
+	MaxObjectSize: 128 * 1024 * 1024,
+	PartSize:      16 * 1024 * 1024,
+	PartRetries:   3,
+	Timeout:       5 * time.Minute,
+	MaxRetries:    3,
+	BulkMaxSize:   2048,
+}
+
+func (c *config) Validate() error {
+	switch c.Compression {
+	case compressionNone, compressionGzip, compressionZstd:
+	default:
+		return fmt.Errorf("unsupported compression '%v', use none, gzip or zstd", c.Compression)
+	}
+
+	if c.PartSize < minPartSize {
+		return fmt.Errorf("part_size must be at least 5MiB, got %d bytes", c.PartSize)
+	}
+
+	if c.EndpointURL != "" {
+		if _, err := url.Parse(c.EndpointURL); err != nil {
+			return fmt.Errorf("invalid endpoint_url '%v': %v", c.EndpointURL, err)
+		}
+		if c.AWSConfig.Endpoint != "" {
+			return fmt.Errorf("endpoint_url can not be combined with endpoint")
+		}
+	}
+	return nil
+}
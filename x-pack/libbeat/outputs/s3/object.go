@@ -0,0 +1,125 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+// extensions maps the compressions to the extension of the object keys.
+var extensions = map[string]string{
+	compressionNone: ".ndjson",
+	compressionGzip: ".ndjson.gz",
+	compressionZstd: ".ndjson.zst",
+}
+
+// object buffers the encoded events of an object until it is uploaded.
+type object struct {
+	key     string
+	created time.Time
+
+	buf bytes.Buffer
+	// w compresses the lines into buf, it is nil without compression
+	w io.WriteCloser
+	// size is the size of the uncompressed lines
+	size int
+	// contents are the events of objects encoded by a batch codec on upload
+	contents []*beat.Event
+
+	events []eventRef
+}
+
+// eventRef references an event of a pending batch.
+type eventRef struct {
+	batch *pendingBatch
+	index int
+}
+
+func newObject(key, compression string, now time.Time) (*object, error) {
+	o := &object{key: key, created: now}
+	switch compression {
+	case compressionGzip:
+		o.w = gzip.NewWriter(&o.buf)
+	case compressionZstd:
+		w, err := zstd.NewWriter(&o.buf)
+		if err != nil {
+			return nil, err
+		}
+		o.w = w
+	}
+	return o, nil
+}
+
+// append adds the encoded event as a line.
+func (o *object) append(line []byte, ref eventRef) error {
+	var w io.Writer = &o.buf
+	if o.w != nil {
+		w = o.w
+	}
+	if _, err := w.Write(line); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{'\n'}); err != nil {
+		return err
+	}
+	o.size += len(line) + 1
+	o.events = append(o.events, ref)
+	return nil
+}
+
+// appendEvent adds the event to an object encoded by a batch codec, size is
+// the estimated size of the encoded event.
+func (o *object) appendEvent(event *beat.Event, size int, ref eventRef) {
+	o.contents = append(o.contents, event)
+	o.size += size
+	o.events = append(o.events, ref)
+}
+
+// body finishes the compression and returns the content of the object.
+func (o *object) body() ([]byte, error) {
+	if o.w != nil {
+		if err := o.w.Close(); err != nil {
+			return nil, err
+		}
+		o.w = nil
+	}
+	return o.buf.Bytes(), nil
+}
+
+// pendingBatch tracks the events of a batch until all objects holding them
+// are uploaded. Events of failed uploads are retried.
+type pendingBatch struct {
+	batch     publisher.Batch
+	events    []publisher.Event
+	remaining int
+	failed    []publisher.Event
+}
+
+// done records the outcome of the upload of one event. It returns true once
+// the outcome of all events is known.
+func (b *pendingBatch) done(index int, err error) bool {
+	if err != nil {
+		b.failed = append(b.failed, b.events[index])
+	}
+	b.remaining--
+	return b.remaining == 0
+}
+
+// finish acknowledges the batch, or returns the failed events to the pipeline.
+func (b *pendingBatch) finish() {
+	if len(b.failed) == 0 {
+		b.batch.ACK()
+		return
+	}
+	b.batch.RetryEvents(b.failed)
+}
@@ -0,0 +1,308 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+func init() {
+	outputs.RegisterType("s3", makeS3)
+}
+
+// makeS3 instantiates a new S3 output instance.
+func makeS3(
+	_ outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *common.Config,
+) (outputs.Group, error) {
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	awsConfig, err := awscommon.GetAWSCredentials(config.AWSConfig)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+	if config.Region != "" {
+		awsConfig.Region = config.Region
+	}
+	awsConfig = awscommon.EnrichAWSConfigWithEndpoint(config.AWSConfig.Endpoint, "s3", awsConfig.Region, awsConfig)
+	if config.EndpointURL != "" {
+		awsConfig.EndpointResolver = aws.ResolveWithEndpointURL(config.EndpointURL)
+	}
+	svc := s3.New(awsConfig)
+	svc.ForcePathStyle = config.PathStyle
+
+	enc, err := codec.CreateEncoder(beat, config.Codec)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+	format := "ndjson"
+	if _, ok := enc.(codec.BatchCodec); ok {
+		if cfg.HasField("compression") {
+			return outputs.Fail(fmt.Errorf("compression can not be set with the %v codec, the codec compresses the objects", config.Codec.Namespace.Name()))
+		}
+		format = config.Codec.Namespace.Name()
+	}
+
+	log := logp.NewLogger("s3")
+	up := &s3Uploader{
+		svc:         svc,
+		bucket:      config.Bucket,
+		partSize:    int(config.PartSize),
+		partRetries: config.PartRetries,
+		log:         log,
+	}
+	client := newClient(log, beat, observer, config, enc, up)
+	log.Infof("Initialized S3 output. bucket=%v format=%v compression=%v flush_interval=%v max_object_size=%v",
+		config.Bucket, format, config.Compression, config.FlushInterval, int64(config.MaxObjectSize))
+
+	return outputs.Success(config.BulkMaxSize, config.MaxRetries, client)
+}
+
+// client writes the events into objects, one per key prefix. An object is
+// uploaded once it reaches the maximum size or the flush interval elapsed
+// since it was opened. Batches are acknowledged once all objects holding their
+// events are uploaded.
+type client struct {
+	log      *logp.Logger
+	beat     beat.Info
+	observer outputs.Observer
+	config   config
+	codec    codec.Codec
+	uploader uploader
+	now      func() time.Time
+
+	// batchCodec is set if the objects are encoded as a whole on upload,
+	// instead of holding one encoded event per line
+	batchCodec codec.BatchCodec
+	extension  string
+
+	mtx     sync.Mutex
+	objects map[string]*object
+	seq     uint64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newClient(
+	log *logp.Logger,
+	beat beat.Info,
+	observer outputs.Observer,
+	config config,
+	enc codec.Codec,
+	up uploader,
+) *client {
+	c := &client{
+		log:      log,
+		beat:     beat,
+		observer: observer,
+		config:   config,
+		codec:    enc,
+		uploader: up,
+		now:      time.Now,
+		objects:  map[string]*object{},
+		done:     make(chan struct{}),
+	}
+	c.extension = extensions[config.Compression]
+	if bc, ok := enc.(codec.BatchCodec); ok {
+		c.batchCodec = bc
+		c.extension = bc.Extension()
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		ticker := time.NewTicker(c.config.FlushInterval / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.upload(context.Background(), c.take(false))
+			}
+		}
+	}()
+	return c
+}
+
+// Close uploads the open objects.
+func (c *client) Close() error {
+	close(c.done)
+	c.wg.Wait()
+	c.upload(context.Background(), c.take(true))
+	return nil
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	pending := &pendingBatch{batch: batch, events: events}
+	var full []*object
+	dropped := 0
+
+	c.mtx.Lock()
+	for i := range events {
+		event := &events[i]
+		if err := c.add(event, eventRef{batch: pending, index: i}, &full); err != nil {
+			if event.Guaranteed() {
+				c.log.Errorf("Failed to write the event: %+v", err)
+			} else {
+				c.log.Warnf("Failed to write the event: %+v", err)
+			}
+			dropped++
+			continue
+		}
+		pending.remaining++
+	}
+	c.mtx.Unlock()
+
+	c.observer.Dropped(dropped)
+	if pending.remaining == 0 {
+		batch.ACK()
+	}
+	c.upload(ctx, full)
+	return nil
+}
+
+// add encodes the event into the open object of its prefix. Objects reaching
+// the maximum size are closed and appended to full.
+func (c *client) add(event *publisher.Event, ref eventRef, full *[]*object) error {
+	prefix, err := c.config.Prefix.Run(&event.Content)
+	if err != nil {
+		return fmt.Errorf("failed to format the object prefix: %v", err)
+	}
+	var line []byte
+	if c.batchCodec == nil {
+		line, err = c.codec.Encode(c.beat.Beat, &event.Content)
+		if err != nil {
+			return fmt.Errorf("failed to serialize the event: %v", err)
+		}
+	}
+
+	o, found := c.objects[prefix]
+	if !found {
+		now := c.now()
+		c.seq++
+		key := fmt.Sprintf("%s%s-%d-%s-%d%s", prefix, c.beat.Beat, now.Unix(), c.beat.EphemeralID, c.seq, c.extension)
+		compression := c.config.Compression
+		if c.batchCodec != nil {
+			compression = compressionNone
+		}
+		if o, err = newObject(key, compression, now); err != nil {
+			return err
+		}
+		c.objects[prefix] = o
+	}
+
+	if c.batchCodec != nil {
+		// The size of batch encoded objects is only known once encoded, the
+		// size of the JSON encoded event is used instead
+		o.appendEvent(&event.Content, len(event.Content.Fields.String()), ref)
+	} else if err := o.append(line, ref); err != nil {
+		return err
+	}
+	if o.size >= int(c.config.MaxObjectSize) {
+		delete(c.objects, prefix)
+		*full = append(*full, o)
+	}
+	return nil
+}
+
+// take closes and returns the objects opened at least the flush interval
+// ago, or all objects.
+func (c *client) take(all bool) []*object {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	var objects []*object
+	for prefix, o := range c.objects {
+		if all || now.Sub(o.created) >= c.config.FlushInterval {
+			delete(c.objects, prefix)
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+func (c *client) upload(ctx context.Context, objects []*object) {
+	for _, o := range objects {
+		body, err := c.body(o)
+		if err != nil {
+			// Encoding the same events again would fail the same way
+			c.log.Errorf("Failed to encode %d events of '%s': %v", len(o.events), o.key, err)
+			c.observer.Dropped(len(o.events))
+			c.finish(o, nil)
+			continue
+		}
+
+		uploadCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+		err = c.uploader.upload(uploadCtx, o.key, body)
+		cancel()
+
+		if err != nil {
+			c.log.Errorf("Failed to upload %d events to '%s': %v", len(o.events), o.key, err)
+			c.observer.WriteError(err)
+			c.observer.Failed(len(o.events))
+		} else {
+			c.log.Debugf("Uploaded %d events to '%s'", len(o.events), o.key)
+			c.observer.WriteBytes(len(body))
+			c.observer.Acked(len(o.events))
+		}
+		c.finish(o, err)
+	}
+}
+
+// body returns the content of the object, encoding its events with the batch
+// codec if set.
+func (c *client) body(o *object) ([]byte, error) {
+	if c.batchCodec != nil {
+		return c.batchCodec.EncodeBatch(c.beat.Beat, o.contents)
+	}
+	return o.body()
+}
+
+// finish records the outcome of the upload of the object for its events, and
+// completes the batches whose events are all uploaded or failed.
+func (c *client) finish(o *object, err error) {
+	var finished []*pendingBatch
+	c.mtx.Lock()
+	for _, ref := range o.events {
+		if ref.batch.done(ref.index, err) {
+			finished = append(finished, ref.batch)
+		}
+	}
+	c.mtx.Unlock()
+
+	for _, b := range finished {
+		b.finish()
+	}
+}
+
+func (c *client) String() string {
+	return "s3(" + c.config.Bucket + ")"
+}
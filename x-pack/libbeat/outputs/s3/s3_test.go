@@ -0,0 +1,264 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/logp"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/columnar"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/parquet"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+)
+
+type mockUploader struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+	fail    func(key string) bool
+}
+
+func (u *mockUploader) upload(_ context.Context, key string, body []byte) error {
+	if u.fail != nil && u.fail(key) {
+		return errors.New("upload failed")
+	}
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	u.objects[key] = append([]byte(nil), body...)
+	return nil
+}
+
+func (u *mockUploader) keys() []string {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	var keys []string
+	for key := range u.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newTestClient(t *testing.T, modify func(*config)) (*client, *mockUploader) {
+	info := beat.Info{Beat: "testbeat", Version: "7.10.0"}
+	return newTestClientWithCodec(t, json.New(info.Version, json.Config{}), modify)
+}
+
+func newTestClientWithCodec(t *testing.T, enc codec.Codec, modify func(*config)) (*client, *mockUploader) {
+	cfg := defaultConfig
+	cfg.Bucket = "archive"
+	cfg.Prefix = fmtstr.MustCompileEvent("%{[service]}/")
+	cfg.Compression = compressionNone
+	cfg.FlushInterval = time.Hour
+	if modify != nil {
+		modify(&cfg)
+	}
+
+	up := &mockUploader{objects: map[string][]byte{}}
+	info := beat.Info{Beat: "testbeat", Version: "7.10.0"}
+	c := newClient(logp.NewLogger("s3"), info, outputs.NewNilObserver(), cfg, enc, up)
+	return c, up
+}
+
+func serviceEvent(service, message string) beat.Event {
+	return beat.Event{
+		Timestamp: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Fields:    common.MapStr{"service": service, "message": message},
+	}
+}
+
+func lines(body []byte) []string {
+	return strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+}
+
+func TestPublishPartitionsByPrefix(t *testing.T) {
+	c, up := newTestClient(t, nil)
+
+	batch := outest.NewBatch(serviceEvent("a", "1"), serviceEvent("b", "2"), serviceEvent("a", "3"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	// The batch is acknowledged once its objects are uploaded
+	assert.Empty(t, batch.Signals)
+	require.NoError(t, c.Close())
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	keys := up.keys()
+	require.Len(t, keys, 2)
+	assert.True(t, strings.HasPrefix(keys[0], "a/testbeat-"), keys[0])
+	assert.True(t, strings.HasSuffix(keys[0], ".ndjson"), keys[0])
+	assert.True(t, strings.HasPrefix(keys[1], "b/testbeat-"), keys[1])
+
+	a := lines(up.objects[keys[0]])
+	require.Len(t, a, 2)
+	assert.Contains(t, a[0], `"message":"1"`)
+	assert.Contains(t, a[1], `"message":"3"`)
+	assert.Len(t, lines(up.objects[keys[1]]), 1)
+}
+
+func TestPublishUploadsFullObjects(t *testing.T) {
+	c, up := newTestClient(t, func(cfg *config) { cfg.MaxObjectSize = 1 })
+	defer c.Close()
+
+	batch := outest.NewBatch(serviceEvent("a", "1"), serviceEvent("a", "2"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	// Each event fills an object
+	assert.Len(t, up.keys(), 2)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishFlushInterval(t *testing.T) {
+	c, up := newTestClient(t, nil)
+	defer c.Close()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	batch := outest.NewBatch(serviceEvent("a", "1"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	c.upload(context.Background(), c.take(false))
+	assert.Empty(t, up.keys())
+
+	now = now.Add(time.Hour)
+	c.upload(context.Background(), c.take(false))
+	assert.Len(t, up.keys(), 1)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishRetriesEventsOfFailedObjects(t *testing.T) {
+	c, up := newTestClient(t, nil)
+	up.fail = func(key string) bool { return strings.HasPrefix(key, "b/") }
+
+	batch := outest.NewBatch(serviceEvent("a", "1"), serviceEvent("b", "2"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+	require.NoError(t, c.Close())
+
+	assert.Len(t, up.keys(), 1)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	require.Len(t, batch.Signals[0].Events, 1)
+	assert.Equal(t, "b", batch.Signals[0].Events[0].Content.Fields["service"])
+}
+
+func TestPublishDropsEventsWithoutPrefix(t *testing.T) {
+	c, up := newTestClient(t, nil)
+	defer c.Close()
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"message": "no service"}})
+	require.NoError(t, c.Publish(context.Background(), batch))
+
+	assert.Empty(t, up.keys())
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishGzip(t *testing.T) {
+	c, up := newTestClient(t, func(cfg *config) { cfg.Compression = compressionGzip })
+
+	batch := outest.NewBatch(serviceEvent("a", "1"), serviceEvent("a", "2"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+	require.NoError(t, c.Close())
+
+	keys := up.keys()
+	require.Len(t, keys, 1)
+	assert.True(t, strings.HasSuffix(keys[0], ".ndjson.gz"), keys[0])
+
+	r, err := gzip.NewReader(bytes.NewReader(up.objects[keys[0]]))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	assert.Len(t, lines(body), 2)
+}
+
+func TestPublishBatchCodec(t *testing.T) {
+	enc, err := parquet.New(beat.Info{Beat: "testbeat"}, parquet.Config{Config: columnar.DefaultConfig, Compression: "snappy"})
+	require.NoError(t, err)
+	c, up := newTestClientWithCodec(t, enc, nil)
+
+	batch := outest.NewBatch(serviceEvent("a", "1"), serviceEvent("a", "2"), serviceEvent("b", "3"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+	require.NoError(t, c.Close())
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	// One parquet file is written per prefix
+	keys := up.keys()
+	require.Len(t, keys, 2)
+	for _, key := range keys {
+		assert.True(t, strings.HasSuffix(key, ".parquet"), key)
+		assert.True(t, bytes.HasPrefix(up.objects[key], []byte("PAR1")), key)
+	}
+}
+
+func TestPublishBatchCodecDropsFailedObjects(t *testing.T) {
+	encConfig := parquet.Config{Config: columnar.DefaultConfig, Compression: "snappy"}
+	encConfig.IncludeFields = []string{"missing"}
+	enc, err := parquet.New(beat.Info{Beat: "testbeat"}, encConfig)
+	require.NoError(t, err)
+	c, up := newTestClientWithCodec(t, enc, nil)
+
+	batch := outest.NewBatch(serviceEvent("a", "1"))
+	require.NoError(t, c.Publish(context.Background(), batch))
+	require.NoError(t, c.Close())
+
+	// Events failing to be encoded are dropped, not retried
+	assert.Empty(t, up.keys())
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		settings map[string]interface{}
+		err      bool
+	}{
+		"defaults":            {map[string]interface{}{}, false},
+		"minio":               {map[string]interface{}{"endpoint_url": "http://minio:9000", "path_style": true, "compression": "zstd"}, false},
+		"unknown compression": {map[string]interface{}{"compression": "lz4"}, true},
+		"small parts":         {map[string]interface{}{"part_size": "1MiB"}, true},
+		"both endpoints":      {map[string]interface{}{"endpoint_url": "http://minio:9000", "endpoint": "amazonaws.com"}, true},
+		"missing bucket":      {map[string]interface{}{"bucket": ""}, true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := map[string]interface{}{"bucket": "archive"}
+			for k, v := range test.settings {
+				settings[k] = v
+			}
+			cfg, err := common.NewConfigFrom(settings)
+			require.NoError(t, err)
+
+			c := defaultConfig
+			err = cfg.Unpack(&c)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
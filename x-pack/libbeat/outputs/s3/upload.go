@@ -0,0 +1,119 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/s3iface"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/v7/libbeat/logp"
+)
+
+// uploader stores objects in the bucket.
+type uploader interface {
+	upload(ctx context.Context, key string, body []byte) error
+}
+
+// s3Uploader stores small objects with a single request, and larger objects
+// with a multipart upload retrying each part on failure.
+type s3Uploader struct {
+	svc         s3iface.ClientAPI
+	bucket      string
+	partSize    int
+	partRetries int
+	log         *logp.Logger
+}
+
+func (u *s3Uploader) upload(ctx context.Context, key string, body []byte) error {
+	if len(body) <= u.partSize {
+		_, err := u.svc.PutObjectRequest(&s3.PutObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(body),
+		}).Send(ctx)
+		return errors.Wrapf(err, "failed to put object '%s'", key)
+	}
+	return u.multipartUpload(ctx, key, body)
+}
+
+// multipartUpload uploads the body in parts of partSize. A failed upload is
+// aborted, so the uploaded parts are not kept and billed.
+func (u *s3Uploader) multipartUpload(ctx context.Context, key string, body []byte) error {
+	created, err := u.svc.CreateMultipartUploadRequest(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+	}).Send(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create multipart upload of '%s'", key)
+	}
+	uploadID := created.UploadId
+
+	var parts []s3.CompletedPart
+	for offset, number := 0, int64(1); offset < len(body); offset, number = offset+u.partSize, number+1 {
+		end := offset + u.partSize
+		if end > len(body) {
+			end = len(body)
+		}
+
+		etag, err := u.uploadPart(ctx, key, uploadID, number, body[offset:end])
+		if err != nil {
+			u.abort(key, uploadID)
+			return err
+		}
+		parts = append(parts, s3.CompletedPart{ETag: etag, PartNumber: aws.Int64(number)})
+	}
+
+	_, err = u.svc.CompleteMultipartUploadRequest(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(u.bucket),
+		Key:             aws.String(key),
+		UploadId:        uploadID,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	}).Send(ctx)
+	if err != nil {
+		u.abort(key, uploadID)
+		return errors.Wrapf(err, "failed to complete multipart upload of '%s'", key)
+	}
+	return nil
+}
+
+func (u *s3Uploader) uploadPart(ctx context.Context, key string, uploadID *string, number int64, part []byte) (*string, error) {
+	var err error
+	for attempt := 0; attempt <= u.partRetries; attempt++ {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+
+		var resp *s3.UploadPartResponse
+		resp, err = u.svc.UploadPartRequest(&s3.UploadPartInput{
+			Bucket:     aws.String(u.bucket),
+			Key:        aws.String(key),
+			UploadId:   uploadID,
+			PartNumber: aws.Int64(number),
+			Body:       bytes.NewReader(part),
+		}).Send(ctx)
+		if err == nil {
+			return resp.ETag, nil
+		}
+		u.log.Debugf("Failed to upload part %d of '%s', attempt %d: %v", number, key, attempt+1, err)
+	}
+	return nil, errors.Wrapf(err, "failed to upload part %d of '%s'", number, key)
+}
+
+func (u *s3Uploader) abort(key string, uploadID *string) {
+	_, err := u.svc.AbortMultipartUploadRequest(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(u.bucket),
+		Key:      aws.String(key),
+		UploadId: uploadID,
+	}).Send(context.Background())
+	if err != nil {
+		u.log.Warnf("Failed to abort multipart upload of '%s': %v", key, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/cloudwatch"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/ec2"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/metric_stream"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/rds"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/s3_daily_storage"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/s3_request"
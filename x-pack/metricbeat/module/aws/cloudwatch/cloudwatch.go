@@ -6,6 +6,7 @@ package cloudwatch
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -35,6 +36,13 @@ var (
 	labelSeparator         = "|"
 	dimensionSeparator     = ","
 	dimensionValueWildcard = "*"
+
+	// defaultPricePerThousandMetrics is the price of GetMetricData in most
+	// regions, in USD per 1000 metrics requested.
+	defaultPricePerThousandMetrics = 0.01
+	// costEstimationWindow is the duration the cost of the requests is
+	// estimated for.
+	costEstimationWindow = 30 * 24 * time.Hour
 )
 
 // init registers the MetricSet with the central registry as soon as the program
@@ -55,6 +63,12 @@ type MetricSet struct {
 	*aws.MetricSet
 	logger            *logp.Logger
 	CloudwatchConfigs []Config `config:"metrics" validate:"nonzero,required"`
+
+	pricePerThousandMetrics float64
+	// budgets are the maximum monthly costs of the namespaces, and capped the
+	// namespaces whose metrics are currently dropped to stay under budget.
+	budgets map[string]float64
+	capped  map[string]bool
 }
 
 // Dimension holds name and value for cloudwatch metricset dimension config.
@@ -72,6 +86,9 @@ type Config struct {
 	ResourceType       string      `config:"resource_type"`
 	Statistic          []string    `config:"statistic"`
 	Tags               []aws.Tag   `config:"tags"` // Deprecated.
+	// MaxMonthlyCost caps the estimated monthly cost of the GetMetricData
+	// requests for the namespace, in USD. Not capped if 0.
+	MaxMonthlyCost float64 `config:"max_monthly_cost" validate:"min=0"`
 }
 
 // Validate checks for deprecated config options
@@ -97,6 +114,13 @@ type listMetricWithDetail struct {
 	resourceTypeFilters map[string][]aws.Tag
 }
 
+// metricsGroup is a set of metrics of a region whose events are created
+// together, with the same resource type and tags filters.
+type metricsGroup struct {
+	metricsWithStats    []metricsWithStatistics
+	resourceTypeFilters map[string][]aws.Tag
+}
+
 // namespaceDetail collects configuration details for each namespace
 type namespaceDetail struct {
 	resourceTypeFilter string
@@ -116,8 +140,11 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	}
 
 	config := struct {
-		CloudwatchMetrics []Config `config:"metrics" validate:"nonzero,required"`
-	}{}
+		CloudwatchMetrics       []Config `config:"metrics" validate:"nonzero,required"`
+		PricePerThousandMetrics float64  `config:"price_per_thousand_metrics" validate:"min=0"`
+	}{
+		PricePerThousandMetrics: defaultPricePerThousandMetrics,
+	}
 
 	err = base.Module().UnpackConfig(&config)
 	if err != nil {
@@ -130,12 +157,30 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	}
 
 	return &MetricSet{
-		MetricSet:         metricSet,
-		logger:            logger,
-		CloudwatchConfigs: config.CloudwatchMetrics,
+		MetricSet:               metricSet,
+		logger:                  logger,
+		CloudwatchConfigs:       config.CloudwatchMetrics,
+		pricePerThousandMetrics: config.PricePerThousandMetrics,
+		budgets:                 readBudgets(config.CloudwatchMetrics),
+		capped:                  map[string]bool{},
 	}, nil
 }
 
+// readBudgets returns the maximum monthly cost of each namespace, the lowest
+// one if several configs of a namespace have one.
+func readBudgets(configs []Config) map[string]float64 {
+	budgets := map[string]float64{}
+	for _, config := range configs {
+		if config.MaxMonthlyCost == 0 {
+			continue
+		}
+		if budget, ok := budgets[config.Namespace]; !ok || config.MaxMonthlyCost < budget {
+			budgets[config.Namespace] = config.MaxMonthlyCost
+		}
+	}
+	return budgets
+}
+
 // Fetch methods implements the data gathering and data conversion to the right
 // format. It publishes the event which is then forwarded to the output. In case
 // of an error set the Error field of mb.Event or simply call report.Error().
@@ -154,25 +199,28 @@ func (m *MetricSet) Fetch(report mb.ReporterV2) error {
 	m.logger.Debugf("listMetricDetailTotal = %s", listMetricDetailTotal)
 	m.logger.Debugf("namespaceDetailTotal = %s", namespaceDetailTotal)
 
-	// Create events based on listMetricDetailTotal from configuration
-	if len(listMetricDetailTotal.metricsWithStats) != 0 {
-		for _, regionName := range m.MetricSet.RegionsList {
-			m.logger.Debugf("Collecting metrics from AWS region %s", regionName)
-			awsConfig := m.MetricSet.AwsConfig.Copy()
-			awsConfig.Region = regionName
-
-			svcCloudwatch := cloudwatch.New(awscommon.EnrichAWSConfigWithEndpoint(
-				m.Endpoint, "monitoring", regionName, awsConfig))
+	// List the metrics of all regions first, so that the budgets apply to
+	// the metrics of a namespace across regions.
+	regionsGroups := make([][]metricsGroup, len(m.MetricSet.RegionsList))
+	for i, regionName := range m.MetricSet.RegionsList {
+		m.logger.Debugf("Listing metrics from AWS region %s", regionName)
+		svcCloudwatch, _ := m.regionClients(regionName)
+		regionsGroups[i] = m.listMetricsGroups(svcCloudwatch, regionName, listMetricDetailTotal, namespaceDetailTotal)
+	}
+	m.applyBudgets(regionsGroups)
 
-			svcResourceAPI := resourcegroupstaggingapi.New(awscommon.EnrichAWSConfigWithEndpoint(
-				m.Endpoint, "tagging", regionName, awsConfig))
+	for i, regionName := range m.MetricSet.RegionsList {
+		m.logger.Debugf("Collecting metrics from AWS region %s", regionName)
+		svcCloudwatch, svcResourceAPI := m.regionClients(regionName)
 
-			eventsWithIdentifier, err := m.createEvents(svcCloudwatch, svcResourceAPI, listMetricDetailTotal.metricsWithStats, listMetricDetailTotal.resourceTypeFilters, regionName, startTime, endTime)
-			if err != nil {
-				return errors.Wrap(err, "createEvents failed for region "+regionName)
-			}
+		groupsResults, err := m.getGroupsResults(svcCloudwatch, regionsGroups[i], startTime, endTime)
+		if err != nil {
+			return errors.Wrap(err, "getGroupsResults failed for region "+regionName)
+		}
 
-			m.logger.Debugf("Collected metrics of metrics = %d", len(eventsWithIdentifier))
+		for g, group := range regionsGroups[i] {
+			eventsWithIdentifier := m.createEventsFromResults(svcResourceAPI, groupsResults[g], group.resourceTypeFilters, regionName)
+			m.logger.Debugf("Collected number of metrics = %d", len(eventsWithIdentifier))
 
 			err = reportEvents(eventsWithIdentifier, report)
 			if err != nil {
@@ -180,51 +228,178 @@ func (m *MetricSet) Fetch(report mb.ReporterV2) error {
 			}
 		}
 	}
+	return nil
+}
 
-	for _, regionName := range m.MetricSet.RegionsList {
-		m.logger.Debugf("Collecting metrics from AWS region %s", regionName)
-		awsConfig := m.MetricSet.AwsConfig.Copy()
-		awsConfig.Region = regionName
+func (m *MetricSet) regionClients(regionName string) (cloudwatchiface.ClientAPI, resourcegroupstaggingapiiface.ClientAPI) {
+	awsConfig := m.MetricSet.AwsConfig.Copy()
+	awsConfig.Region = regionName
 
-		svcCloudwatch := cloudwatch.New(awscommon.EnrichAWSConfigWithEndpoint(
-			m.Endpoint, "monitoring", regionName, awsConfig))
+	svcCloudwatch := cloudwatch.New(awscommon.EnrichAWSConfigWithEndpoint(
+		m.Endpoint, "monitoring", regionName, awsConfig))
 
-		svcResourceAPI := resourcegroupstaggingapi.New(awscommon.EnrichAWSConfigWithEndpoint(
-			m.Endpoint, "tagging", regionName, awsConfig))
+	svcResourceAPI := resourcegroupstaggingapi.New(awscommon.EnrichAWSConfigWithEndpoint(
+		m.Endpoint, "tagging", regionName, awsConfig))
+	return svcCloudwatch, svcResourceAPI
+}
 
-		// Create events based on namespaceDetailTotal from configuration
-		for namespace, namespaceDetails := range namespaceDetailTotal {
-			m.logger.Debugf("Collected metrics from namespace %s", namespace)
+// listMetricsGroups returns the groups of metrics to collect in a region: the
+// metrics given with their name and dimensions in the configuration, and the
+// metrics listed for each namespace.
+func (m *MetricSet) listMetricsGroups(svcCloudwatch cloudwatchiface.ClientAPI, regionName string, listMetricDetailTotal listMetricWithDetail, namespaceDetailTotal map[string][]namespaceDetail) []metricsGroup {
+	var groups []metricsGroup
+	if len(listMetricDetailTotal.metricsWithStats) != 0 {
+		groups = append(groups, metricsGroup{
+			metricsWithStats:    listMetricDetailTotal.metricsWithStats,
+			resourceTypeFilters: listMetricDetailTotal.resourceTypeFilters,
+		})
+	}
 
-			listMetricsOutput, err := aws.GetListMetricsOutput(namespace, regionName, svcCloudwatch)
-			if err != nil {
-				m.logger.Info(err.Error())
-				continue
-			}
+	// Sort the namespaces so that the same metrics are kept at each period
+	// when a budget is exceeded.
+	namespaces := make([]string, 0, len(namespaceDetailTotal))
+	for namespace := range namespaceDetailTotal {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
 
-			if listMetricsOutput == nil || len(listMetricsOutput) == 0 {
-				continue
-			}
+	for _, namespace := range namespaces {
+		namespaceDetails := namespaceDetailTotal[namespace]
+		m.logger.Debugf("Collected metrics from namespace %s", namespace)
 
+		listMetricsOutput, err := aws.GetListMetricsOutput(namespace, regionName, svcCloudwatch)
+		if err != nil {
+			m.logger.Info(err.Error())
+			continue
+		}
+
+		if listMetricsOutput == nil || len(listMetricsOutput) == 0 {
+			continue
+		}
+
+		groups = append(groups, metricsGroup{
 			// filter listMetricsOutput by detailed configuration per each namespace
-			filteredMetricWithStatsTotal := filterListMetricsOutput(listMetricsOutput, namespaceDetails)
+			metricsWithStats: filterListMetricsOutput(listMetricsOutput, namespaceDetails),
 			// get resource type filters and tags filters for each namespace
-			resourceTypeTagFilters := constructTagsFilters(namespaceDetails)
+			resourceTypeFilters: constructTagsFilters(namespaceDetails),
+		})
+	}
+	return groups
+}
 
-			eventsWithIdentifier, err := m.createEvents(svcCloudwatch, svcResourceAPI, filteredMetricWithStatsTotal, resourceTypeTagFilters, regionName, startTime, endTime)
-			if err != nil {
-				return errors.Wrap(err, "createEvents failed for region "+regionName)
+// monthlyCostPerMetric returns the estimated cost of requesting a metric
+// every period for a month.
+func (m *MetricSet) monthlyCostPerMetric() float64 {
+	requestsPerMonth := float64(costEstimationWindow) / float64(m.Period)
+	return m.pricePerThousandMetrics / 1000 * requestsPerMonth
+}
+
+// budget returns the maximum monthly cost of a namespace. The budget of the
+// "*" namespace applies to each namespace without its own budget.
+func (m *MetricSet) budget(namespace string) (float64, bool) {
+	if budget, ok := m.budgets[namespace]; ok {
+		return budget, true
+	}
+	budget, ok := m.budgets[dimensionValueWildcard]
+	return budget, ok
+}
+
+// applyBudgets estimates the monthly cost of the metrics of each namespace
+// across regions, and drops the metrics exceeding the budget of their
+// namespace. Each statistic of a metric is billed as a metric. Metrics are
+// kept in the order they are listed.
+func (m *MetricSet) applyBudgets(regionsGroups [][]metricsGroup) {
+	counts := map[string]int{}
+	for _, groups := range regionsGroups {
+		for _, group := range groups {
+			for _, metric := range group.metricsWithStats {
+				counts[*metric.cloudwatchMetric.Namespace] += len(metric.statistic)
 			}
+		}
+	}
 
-			m.logger.Debugf("Collected number of metrics = %d", len(eventsWithIdentifier))
+	costPerMetric := m.monthlyCostPerMetric()
+	allowed := map[string]int{}
+	for namespace, count := range counts {
+		cost := float64(count) * costPerMetric
+		m.logger.Debugf("Estimated monthly cost of namespace %s: %.2f USD for %d metrics", namespace, cost, count)
+
+		budget, ok := m.budget(namespace)
+		if !ok || cost <= budget {
+			if m.capped[namespace] {
+				m.logger.Infof("Collecting all metrics of namespace %s again, estimated monthly cost of %.2f USD is within budget", namespace, cost)
+				delete(m.capped, namespace)
+			}
+			continue
+		}
 
-			err = reportEvents(eventsWithIdentifier, report)
-			if err != nil {
-				return errors.Wrap(err, "reportEvents failed")
+		allowed[namespace] = int(budget / costPerMetric)
+		if !m.capped[namespace] {
+			m.logger.Warnf("Estimated monthly cost of namespace %s is %.2f USD, over the budget of %.2f USD, "+
+				"only %d of %d metrics are collected", namespace, cost, budget, allowed[namespace], count)
+			m.capped[namespace] = true
+		}
+	}
+	if len(allowed) == 0 {
+		return
+	}
+
+	used := map[string]int{}
+	for _, groups := range regionsGroups {
+		for g := range groups {
+			var kept []metricsWithStatistics
+			for _, metric := range groups[g].metricsWithStats {
+				namespace := *metric.cloudwatchMetric.Namespace
+				if max, ok := allowed[namespace]; ok {
+					if used[namespace]+len(metric.statistic) > max {
+						continue
+					}
+					used[namespace] += len(metric.statistic)
+				}
+				kept = append(kept, metric)
 			}
+			groups[g].metricsWithStats = kept
 		}
 	}
-	return nil
+}
+
+// getGroupsResults gets the results of the metrics of all the groups of a
+// region with as few GetMetricData requests as possible, and returns the
+// results of each group.
+func (m *MetricSet) getGroupsResults(svcCloudwatch cloudwatchiface.ClientAPI, groups []metricsGroup, startTime time.Time, endTime time.Time) ([][]cloudwatch.MetricDataResult, error) {
+	var metricDataQueries []cloudwatch.MetricDataQuery
+	groupOfQuery := map[string]int{}
+	for g, group := range groups {
+		for _, query := range createMetricDataQueries(group.metricsWithStats, m.Period) {
+			// The IDs must be unique across the queries of a request
+			id := "q" + strconv.Itoa(len(metricDataQueries))
+			query.Id = &id
+			groupOfQuery[id] = g
+			metricDataQueries = append(metricDataQueries, query)
+		}
+	}
+
+	groupsResults := make([][]cloudwatch.MetricDataResult, len(groups))
+	m.logger.Debugf("Number of MetricDataQueries = %d", len(metricDataQueries))
+	if len(metricDataQueries) == 0 {
+		return groupsResults, nil
+	}
+
+	metricDataResults, err := aws.GetMetricDataResults(metricDataQueries, svcCloudwatch, startTime, endTime)
+	m.logger.Debugf("Number of metricDataResults = %d", len(metricDataResults))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMetricDataResults failed")
+	}
+
+	for _, result := range metricDataResults {
+		if result.Id == nil {
+			continue
+		}
+		if g, ok := groupOfQuery[*result.Id]; ok {
+			groupsResults[g] = append(groupsResults[g], result)
+		}
+	}
+	return groupsResults, nil
 }
 
 // filterListMetricsOutput compares config details with listMetricsOutput and filter out the ones don't match
@@ -479,22 +654,27 @@ func insertRootFields(event mb.Event, metricValue float64, labels []string) mb.E
 }
 
 func (m *MetricSet) createEvents(svcCloudwatch cloudwatchiface.ClientAPI, svcResourceAPI resourcegroupstaggingapiiface.ClientAPI, listMetricWithStatsTotal []metricsWithStatistics, resourceTypeTagFilters map[string][]aws.Tag, regionName string, startTime time.Time, endTime time.Time) (map[string]mb.Event, error) {
-	// Initialize events for each identifier.
-	events := map[string]mb.Event{}
-
 	// Construct metricDataQueries
 	metricDataQueries := createMetricDataQueries(listMetricWithStatsTotal, m.Period)
 	m.logger.Debugf("Number of MetricDataQueries = %d", len(metricDataQueries))
 	if len(metricDataQueries) == 0 {
-		return events, nil
+		return map[string]mb.Event{}, nil
 	}
 
 	// Use metricDataQueries to make GetMetricData API calls
 	metricDataResults, err := aws.GetMetricDataResults(metricDataQueries, svcCloudwatch, startTime, endTime)
 	m.logger.Debugf("Number of metricDataResults = %d", len(metricDataResults))
 	if err != nil {
-		return events, errors.Wrap(err, "GetMetricDataResults failed")
+		return map[string]mb.Event{}, errors.Wrap(err, "GetMetricDataResults failed")
 	}
+	return m.createEventsFromResults(svcResourceAPI, metricDataResults, resourceTypeTagFilters, regionName), nil
+}
+
+// createEventsFromResults creates the events of the results of a group of
+// metrics.
+func (m *MetricSet) createEventsFromResults(svcResourceAPI resourcegroupstaggingapiiface.ClientAPI, metricDataResults []cloudwatch.MetricDataResult, resourceTypeTagFilters map[string][]aws.Tag, regionName string) map[string]mb.Event {
+	// Initialize events for each identifier.
+	events := map[string]mb.Event{}
 
 	// Find a timestamp for all metrics in output
 	timestamp := aws.FindTimestamp(metricDataResults)
@@ -528,7 +708,7 @@ func (m *MetricSet) createEvents(svcCloudwatch cloudwatchiface.ClientAPI, svcRes
 				}
 			}
 		}
-		return events, nil
+		return events
 	}
 
 	// Create events with tags
@@ -597,7 +777,7 @@ func (m *MetricSet) createEvents(svcCloudwatch cloudwatchiface.ClientAPI, svcRes
 			}
 		}
 	}
-	return events, nil
+	return events
 }
 
 func reportEvents(eventsWithIdentifier map[string]mb.Event, report mb.ReporterV2) error {
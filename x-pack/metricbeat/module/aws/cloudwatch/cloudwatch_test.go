@@ -8,6 +8,7 @@ package cloudwatch
 
 import (
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -1569,3 +1570,121 @@ func TestConfigDimensionValueContainsWildcard(t *testing.T) {
 		})
 	}
 }
+
+// MockCloudWatchClientWithQueries returns a result per query, and records the
+// number of queries of each request.
+type MockCloudWatchClientWithQueries struct {
+	cloudwatchiface.ClientAPI
+	requestSizes []int
+}
+
+func (m *MockCloudWatchClientWithQueries) GetMetricDataRequest(input *cloudwatch.GetMetricDataInput) cloudwatch.GetMetricDataRequest {
+	m.requestSizes = append(m.requestSizes, len(input.MetricDataQueries))
+	var results []cloudwatch.MetricDataResult
+	for _, query := range input.MetricDataQueries {
+		results = append(results, cloudwatch.MetricDataResult{
+			Id:         query.Id,
+			Label:      query.Label,
+			Values:     []float64{value1},
+			Timestamps: []time.Time{timestamp},
+		})
+	}
+
+	httpReq, _ := http.NewRequest("", "", nil)
+	return cloudwatch.GetMetricDataRequest{
+		Request: &awssdk.Request{
+			Data:        &cloudwatch.GetMetricDataOutput{MetricDataResults: results},
+			HTTPRequest: httpReq,
+		},
+	}
+}
+
+func instancesMetrics(namespace string, count int, statistic []string) []metricsWithStatistics {
+	var metrics []metricsWithStatistics
+	for i := 0; i < count; i++ {
+		metrics = append(metrics, metricsWithStatistics{
+			cloudwatch.Metric{
+				Dimensions: []cloudwatch.Dimension{{
+					Name:  awssdk.String("InstanceId"),
+					Value: awssdk.String("i-" + strconv.Itoa(i)),
+				}},
+				MetricName: awssdk.String("CPUUtilization"),
+				Namespace:  awssdk.String(namespace),
+			},
+			statistic,
+			nil,
+		})
+	}
+	return metrics
+}
+
+func TestGetGroupsResults(t *testing.T) {
+	m := MetricSet{}
+	m.MetricSet = &aws.MetricSet{Period: 5 * time.Minute, AccountID: accountID}
+	m.logger = logp.NewLogger("test")
+
+	groups := []metricsGroup{
+		{metricsWithStats: instancesMetrics("AWS/EC2", 300, []string{"Average"})},
+		{metricsWithStats: instancesMetrics("AWS/EBS", 150, []string{"Average", "Maximum"})},
+	}
+	mockCloudwatchSvc := &MockCloudWatchClientWithQueries{}
+	startTime, endTime := aws.GetStartTimeEndTime(m.MetricSet.Period)
+
+	groupsResults, err := m.getGroupsResults(mockCloudwatchSvc, groups, startTime, endTime)
+	assert.NoError(t, err)
+
+	// The 600 queries of both groups are sent in two requests
+	assert.Equal(t, []int{500, 100}, mockCloudwatchSvc.requestSizes)
+	assert.Len(t, groupsResults, 2)
+	assert.Len(t, groupsResults[0], 300)
+	assert.Len(t, groupsResults[1], 300)
+	assert.Equal(t, "CPUUtilization|AWS/EBS|Maximum|InstanceId|i-149", *groupsResults[1][299].Label)
+
+	events := m.createEventsFromResults(&MockResourceGroupsTaggingClient{}, groupsResults[1], nil, regionName)
+	assert.Len(t, events, 150)
+	metricValue, err := events["i-0"].RootFields.GetValue("aws.ebs.metrics.CPUUtilization.max")
+	assert.NoError(t, err)
+	assert.Equal(t, value1, metricValue)
+}
+
+func TestReadBudgets(t *testing.T) {
+	budgets := readBudgets([]Config{
+		{Namespace: "AWS/EC2", MaxMonthlyCost: 20},
+		{Namespace: "AWS/EC2", MaxMonthlyCost: 10},
+		{Namespace: "AWS/EBS"},
+		{Namespace: "*", MaxMonthlyCost: 50},
+	})
+	assert.Equal(t, map[string]float64{"AWS/EC2": 10, "*": 50}, budgets)
+}
+
+func TestApplyBudgets(t *testing.T) {
+	m := MetricSet{}
+	// Each metric costs 0.01 / 1000 * 8640 = 0.0864 USD a month.
+	m.MetricSet = &aws.MetricSet{Period: 5 * time.Minute}
+	m.logger = logp.NewLogger("test")
+	m.pricePerThousandMetrics = defaultPricePerThousandMetrics
+	m.budgets = map[string]float64{"AWS/EC2": 1, "*": 0.5}
+	m.capped = map[string]bool{}
+
+	ec2Metrics := instancesMetrics("AWS/EC2", 10, []string{"Average"})
+	ebsMetrics := instancesMetrics("AWS/EBS", 4, []string{"Average", "Maximum"})
+	regionsGroups := [][]metricsGroup{
+		{{metricsWithStats: ec2Metrics[:8]}, {metricsWithStats: ebsMetrics}},
+		{{metricsWithStats: ec2Metrics[8:]}},
+	}
+	m.applyBudgets(regionsGroups)
+
+	// The 10 EC2 metrics fit in 1 USD, only 5 EBS metrics fit in 0.5 USD and
+	// metrics are kept whole with all their statistics.
+	assert.Equal(t, ec2Metrics[:8], regionsGroups[0][0].metricsWithStats)
+	assert.Equal(t, ebsMetrics[:2], regionsGroups[0][1].metricsWithStats)
+	assert.Equal(t, ec2Metrics[8:], regionsGroups[1][0].metricsWithStats)
+	assert.Equal(t, map[string]bool{"AWS/EBS": true}, m.capped)
+
+	regionsGroups = [][]metricsGroup{
+		{{metricsWithStats: instancesMetrics("AWS/EC2", 20, []string{"Average"})}},
+	}
+	m.applyBudgets(regionsGroups)
+	assert.Len(t, regionsGroups[0][0].metricsWithStats, 11)
+	assert.Equal(t, map[string]bool{"AWS/EBS": true, "AWS/EC2": true}, m.capped)
+}
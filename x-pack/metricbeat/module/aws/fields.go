@@ -19,5 +19,5 @@ func init() {
 // AssetAws returns asset data.
 // This is the base64 encoded gzipped contents of module/aws.
 func AssetAws() string {
-	return "eJzsfVtzGzfy73s+BWpfIqckrmPHW6fycKokUd7o/GVZEeV13rjgTJNEhAHGAIYSU/vhT6EBzI3Dy5AzlLz198PWRiSBX1/QaDQa3WfkEZa/EvqkfyDEMMPhV/K386+jv/1ASAw6Uiw1TIpfyf/9gRBC/k2f9L9JIuOMA4kk5xAZTc6/jkgiBTNSMTEjCRjFIk2mSib42SWXWfxETTQf/ECIAg5Uw69kRn8gZMqAx/pXHP2MCJpAQGP/mWVqv6hklvq/NICqDlIeyNCZHvyU/zmMJyd/QmRKf3Z/GLtPH2H5JFXc/PE4oWnKxMx/928//a30vUZs7t8DndmByYLyDEhKmfL8oU+aKNAyUxHowQoF+v1gkkWPYAb2v1coWcW6AcMtTYDIKaFk9J74UVcmjFkCQjMpXgnjPqEylWGtQP7xp4FXucFPg59+bIk6ltmEQx+gNTFzaogCkykBsZN3sRbI+d01+ZaBWq6SNGGcMzFbIaW8ErZg+Lcf498kksJQJiwcIKANS6iBmERzqmagyVQqspSZwqVKo0hmwhAmaqs2/MtX7wQMLf29vgTL1HjpVD5bR9G6scrjXQUaLh0Jg4Q+r3w5TMBlhY+NnPtEn1mSJWuY4/mCjFkVVZSz6SBpFcPUBJaUdekJFBAdKZoGfcrt61fUqac5i+bFAA1WWYMwZLIkMZtOQdn/sHTolFbsT91M7yLmfJxGQa9ahy0ssf8e5lAMS3QKEZsyiMnTHIRbOyX+E5qyBoO2FDSR8eQg6YRBjiQb+8MhTjm8eG2Lb5RFEWg9zfg9fMtAmxtqQETLAV00rbM1RnYHpod/VgfoAhSdAeFuLruL6RwHUQ6IJkbmbCN24Sb0LymKP42MAprUWeGBZCgJK9VCzQxLgKSgmIwH7RmyySodypDEW6zXyJDPgjMB1yKG5ztQEQhDZ3Cn5EyB1r2qSZpPZxkSySTlYH/j7AUlAp7IjMsJ5URDJEVM1ZIwC5QwTSZgCaZxbAmVhBJDJxzW03mn5IJZnwTir4oZuKQpjZhZfhHM9EunyJIJKEtjWmAgTxYEiTwKklkYuIF5Sgj+32b6d6LyHmj80kQqoHHnNF5KobPk2AQGo1YQ2kRc5LERuQC1fjmeNk6jpXXtSEQFMYpGj2Qun0iSRXM7Gzp9Zd6auZLZbJ5mxi6HTMOGRb6eZTpLDnLG1jNMZ8l3yqUj24dVzWq0Dd8f03rXre+JT/eQchZRS9kxfTDgNNWB8gmYJ7B7qyBZGuPRiRlICE1ToOhAMIEcy30OjT6HtdmNM0kBRDnCnEU/JVTEzsNeHZkKaeag8l/4ybz937J/N/DvGC7bfw3/HhQVmkaW7kspppxFpjcFPPfKp+BPiAKXzjgsoOTtxhlYx80UuCi3ixeh6ZzXkRRRpuzRt3GqfDjpmKFpAjidbseKnmyVNJS/VjacuyDJOpfRMM7+wvV2FENVPQ1scyIzRAexPX9bemk94LOd2OqG9WqobdzTWpM7WmoDyZVSUvW5D7c8ujrDNgMBipomLhJrWn97eLgjH96+JdpQk9kNPYYDDriXUsTMravLOUSPHynjVtUd8h6ZU/hzU5ySUGMgSR23UlBTqRK7rgM6J/oNC/YORMzErLQTXqIWHIME3I3cpufFSBUgYgPCErS6lTWOOsmM+/mcLoAIacgSDJlYE1ca7EBPgcYPcyWN4XC1ANGbkO+btB+Jg+cI0D+E9ZascciOjsiB/L7VvDUHSh4zZwkzzdEsKQjN79nIibb+N9UVlgjHgjfreYD2/XXqQdXG96kIftv7RJ/tqjj87mW7w7w5PoJcsaerCeB5yW5oVKzfz9zoTDttIbEEjUaDpilfOrNzFkOCTrPlkrZsambSJstasOnBjnJjXbRXzLBCIxypzUfZWsxUTsucJh+lWmWeKVgd0dRfmzica9xOGgcnwAPeQV2RnkxvMOFN8vjqdsdjCqTRF3vdEnGQexXJqxbEy9uST/S5dMpA/V13ruozgHHYeWrOZnPQzWfNlbFqur9Fz9swbu0Z7WU4V1fDZqaVf7Jhje7JtcAtmJR9p/aX5DDRR7wfv7oYHZau0PXF+L8kzxJcmBdLa80OP/SHoJdmf6HiAI3mbn3I1J53mRTlU6yPQqOLmBrr8i4QkrbHRBrNw7XmLTNKnk2oNXBMaENFBKfkaW7lY0oRBQWpAstyXflzQxB824HZsQaXXq+8ccvgu2SO1ZvPaRecsQbHYJSw5gfmfNEY+l2BaL9oWLJhxy7JsT+sNSEeCPb3DDK4ATEz847w1rhqN/e63uVBrCfKDGqgtC6FT0hAzTqApIf8xFukV3REW3Wjuv7757IcUlB+SyEn15/vRm9IDJwtQIGDnsvSfljZ5abufO1jeFcXI7/4BuSLXWdPzMzLeQZugNFomK9RKfhyG1vKN9K9qChNMDVyg+A1ORFSJdTt4UaSdx/+8T81x+hNcZ24WQu64c1FprS5oNzasQ64UWD6J8ZcObnLVCo1IKSTWfruzSkpFJR8Tg1LkBu/DYfkRJuf37gLqUvJw9+in99UiXH0xmCX/tTyExcVnUiM9DVpaaQgtk7nidU0C8J6QaXIUOVzbX5GCDixgoQyUbpom1iGraRHN6scXsZgcNAKbFMoaH9z6FactnrinB/K+Yo9dweXjsyLBeBCXUemamU1dUnWdcyPQdBGjC4PTUgvP7VKsXOSs0nCjCnf/ec+evTuMB89endMH/3y3WE+epRmA+T0II3qR0dHvI4oh3g85ZLWv7BDbnHVklDOZYR38FeX71DvMgPl0ABVQPydKbeHKpJpCPejwVkcrCXEGaFxpumsOUW6IcaxS350roOXd19yS5cvrDI23Ijtt7LSwXcb3onbPHpBDBRfRpSBO0aLAvOcantmVRnERDP7F2bIE9WE00yg4442nSpTT5YpE6MzlfJMj49AlJ+qShFeTuGlVGHyBMkERo5KZw1nIuzPLu++XOIIfvf2b4eYJn+BkrtSqsfuKUM9btARqUhLI8F2rQhpSEpZTGL5JCzJq/J23oAzK2aeWQMaZegt0ji/xnQkNJMswDxJ9ThgYpBSu2k3n+j3o7Ru5f0MREEEbGFVT+DO5UEQJgyoKY1Aryw9JsKDOevMNB0K11M0TkGNNUQ9WMBV2kpuPtpy63XtTOZmimRmjiik9uj3EFKJpP8WKTExmCwN7Cwi56L/Spp+tIf4cJijrTCc7SiSc3SV5NaexO2q+PKCO9qqe0HJdbXiYqYfmRzY08DxJIdSC4uMejffUpHLQxupoIiPLijjeLNg5L5yWyG0J7ldFGSVxLU3hRuJwbPbi4itnNZ0FLmVSO1VcIGwkuz2pHG7Gsq0Mx8ERVOEKerBmWMvMJlullJ7Ci/X0tbFKmsT12lUzP5EuRqROu6S61OUK7Qdvur2kaRLyR1Ec4gexy6ttSNS7yGVymh7osbUzwrSOdUkpRqTPKSZVz8MacIWk38+AURjAnT1Mx8z5lQbkjCRmd2JHLvxjkxrH4SEeV6AlGaJ7UpMvllEUtn/yVaeQjgarFs3g/p7m/ahOal8RYntO1X+KUvoDAaseU3sXVPhehhu7HB8l+xkpA+ptcFXRIAHVgYd1n64FjGLMDk8aEIMxqW9l8LOTBMQ1hatMac50FSxBTUwiIUe12rpdMBQPzoZ3o5w4sDelZPBjihZPfvEa2L9zy2gXd8tfiE0jhVoTajWMmIY68bbvL2wZhPOor4YioOv8HNHrfTQOuRiYJzHcWWNC4vI9V3+yYll8BsykZnbQPdhKS6hQSTjZm7ubYhw3DoPT10G/M//OJswQzKh2UxgJBon2Qlp93JvREpOUvdQhfyHqEwI9//0PDOGidkZRpf/QwyohAnU6f9YjwVLN4X/C/GbLRSZuXVunb9lTXVfW4GfB92tsC00XPTxwyrWAD9msZqrm+Y6NS+WjHdBo0cQ8aUUwvncHT1cq4oyyocvs1VIUyrGwpcEtKETzvTcOpv+9SU6KJLGxN9EqdzPVDBj2mBWTdDNDbnBvz083F3KGMae4vG7P/7omEp8Pffujz+IAp1KocG9nwuP7jBZ9UDQ7/sB/b5X0L/0A/qXXkF/6Af0h15AX91c9MnliDNrw8CaBgStq6hX1uiOkHvksQa1ANUJZP/GrJsHn/XESJ//WERSEG5hLRO67gUuukoLyje8RE4Z53IBqjvoq/my4f1dbtXzJ/cTiGimXTawztQMyLcM3MW8NfcbdAQoN/PlbzIw/dD3LlWmz93wxQIrrzp08rHayI7aMbKUlZNnuwC7ls0nqODcohWg3tS15eThsvxpnl8QvEIls5BmS1f4sJ7GL6JnkWSiW6F0V+alkAbmpfmaJKeEiZDJdurcQszqtV9ZdVjQATTFm33H/gZTTzJhGF8J2Cjjgg4acs/HbyBzoDGoDTtEXgH0/ObiPDJsAYWn5wTZDYuKgqAVp8/ngBGrlmU9pQjFMc5tLjqcBFd9vZy91Y/s96magdmR/JD2fHP5pat05yaqqyBrb71Obi6/vCm/mDtP84IC5Mb+8mKrbpdpuoWn48lTwNOKIMse+/GkeaekPTRAZw+I1pHsL7TDdLsLLa/QXXz10INqdagjnllL5L6642uzTevD03kF1uwSx364Gd3CTBpG8+N6H67pw82oQiQTzLCy9+wPBahxMYvxNJ+bA0KJBq2xpGgIm1YJ9sWXKE6EbvrmQ8P4I3uGeHzvt75xHzRP7RRn+e5KVyIWRbRiC9h7iJmCyPQCU/nBOwH4RfHxDUuYGV9hxQyIj4g5khmPxY+m+uirfHD4cn8TrqlyuWDyuVUt5/7YAwW3a0fZQQX5P/+z4/Hz/R9/9EJrKaTiiLZY3RkUqZaKzTD+usYY7H7g7w/+mmN/l/g/9Il/TQygU/xv3/aI/+3bHoG/6xP4ux6Bv+8T+Psegf/SJ/BfugR+fbf4R83B7sOfanCtV50EfCVuAW2G22OEzg5fhF/yTOR2EcSGY1ofLH3xA9prU5tfkKDN+nPvw5V9CGjbBVhjqLRKyhyrPLm6C8zohgI9paFfNoZdCKUV/zMOVwvKM5dc1zW4jG9XlxlbgCt758JzyppNX6jCE0MFmctswxLvIbq0V0xpU5S09jjg0IBEMcwRgxG3btJXGoj4yOVTl2G4DUGIKZdPmpxULwDerNr4bTa7Bnz8cHnXP3i7S/VGwM3oCATcjHoj4MvwCBL4MuxOAt+D7VvB3H8src59qzNzKmI9p4/BTfflif0FryiwFAXvwzHcbqUuWhYu+DY6nIUp6svVXKM+Gz1Op0ohorNTEekyLbi4e3Od16/prml6JY7yKWEi4hleDT9c3v39+m77jWIVem8CaYBfVv1NLQZQHt/Fyi5T5Ne306YN1F3ejZ3tGt+Dhi4DzKtJBxoMObkfPbypPhV3D5jyCwC5I+yrm4sXwbxv3o/F7JTpxVnt2OtY7dj+YtkzwdwVdV6k0CzGPAafxPGCiSSb0OVJJqsnIk6TSUwPOg25IY54ErrBCRtPQS/Y9fJaLPztTPeuoN1atfPyppkorlXwbcszRJlxmTlhSys1enQfu9taEZf/07ce1hk37lVePvSWS0mfKN01kaxg4P7YhkDjGzAGVGcoP0pFqF6KaK6kkFh/JgA9dU84anJy+lnpvIEJTFQQWOQbRww0PuMI1acHTjK3eW7Y4oegDRM499BVVlx+pIxnqpNkkN4ozUHvRGOmuuqJg89y8pqMPkmNmqaVpFMQce53YZtPT8T2jhd9roVaoinFIrO+0caGuwFjN36pzjup+2n1wsnTd8fwTc/zdRpqRLjOY9opiy+Zlr+zVBBJFYfTwhbWXuYH9qvcYnXO5VwDitzLInG0UARwfTw27O3Wi1ELDKX3gfqjL476dUTuYdawGh3CArzrU1s5QwRa/bdiKX70JccC+CJIEm1wZEqFRhup7daraSUhIkWlpuze9EQ7l33fhSKUHlmAwqQg+x+cUb9GXME2Od3GVhKzBYsLR75ebnYN2UW9wrYMKHsz3V5M7OLLdCjJ/CnAy1NkNTimqioh13ea87UiZNqXkWy4zqBmRg080eVh1xn5MGuceLTtl0UXfdc6MHokWKjSsuD2/IH4MawzTl0hELdb6NfmqWP85lp8VDIp+VMdK0WtRplft2U+5dfNJf9oQ5XmAvQI2foyeEP4jwmn8P+6u9yC+XNmHmTffM7LbfmKzivg/aX/7qxG2D1y2j+N2Ii2FbOLa/xz5473e5tfOP34UGsNJbvAvSpCzH0nIJSj2a0R44HyTipzzkMeZi8bSV0ZMFXUNQ3yuzmhwRFPpdrgRIeC1jLrWRm8V4b9ZLEiK5SyaXySuHu2H8oBxdz/ZcN+7sKBQyXTPtCHaGOs8O1/g8XbCq3vPWSlluzBu0gFeC/WbWfMrYybx93zXrJSFraL3aQMvVeOd76jND8x6eOVZ+l63luLejbfVmsdQKv4sBZVKj5mi6r74YEtqg4qfx+KTfoa9z9skNouxfHblo3/3zL3xy5zH1NDJ1TDuLS0eiEnTFR7SLXa7TFHNsmLxA2oEo2g9qoY5DsK3YfGvrc0gZPz+9s3qAKuX1qst4OKONXNvNoL1mXZwpTLV4WeElTEJIFEqmWR+oMYwheHF9tKmZbQsxiEYVO2UpeoCxKoFas601macgZxIfxi1oFrgln8gTBHeibYtwwsAKfv+TfssK1IdPX9uiNv5OtNOJxheyoVn2I6p3R9nc4xXu2MY0jNvBHbwaWnZWYwsGS3mOvPmpwooPHfKy1Z9ZtymzGKd4POgWH6sRl7qED5jY/dY6IxnYEw4z/lpB+L4bNGRr/fkJF7vXRuJyR2wnIZkK0lG6cKgE44jN3qOWq98yIgWxRAVVTEMglc96DWIh9rIxWdHbFq9BrYHgfR6dqadD4jf5xpiMd49nNPHMcs7lJHQuJ/aQZyPQw9U7RrmWIxDNyDbcB7yDupzUzB6PebZvCSW+997Jv9I2zNpRlzOhskkw7hczqb4Z28b0PpHnTirPln6GZKjXfdBlSCRv7r+Q0amPwo1Yo+awXGTG6tD7yn/QndLEtbPtOP7ipwbVPAdUiRGcj5FlWMg87H/rr4ELXHm2FK7i36ey+b0uZj5WT1bM5CoV/nS5T3p7JsPi1Hv9+ckk9UMTq8cP1rCnlVplnjeegnmjr/+IUMgQXg1r5LMvYtrCoU45buTjV2O8cIVW4/rHdVGPNmKss2g8uZHvuEtVVpHrIAUTFLpNijQMmU2IlbrSzcWo+/tNyO3nJtfctAsd3VZy90fo7iqmsbqBhozGX02C+sfJaQcZC7pdvwuSLmuK291Orzm28lv/88U1JV7BIWY8LJNhHiSv6zFiXwD6SjdNXBOA8tAWqam6fSZtqA8lBP7WYgseoTNeTDmfPz8oJvm8l09fBfhE63NnGZ1sjM426Hk4nuIZcR5S/sJAbtrBp7A0kqFVVL12TepRta47pNS7mcMYGV4jPVs6nyhwycsbjA2mYPii6xg0gmCWuOs3Vm7d0cbax8CWAMHNaUWO9uO8I5crvfBl3M+4U2HN6UnuW2AJb0DIwJDcroU5KlMTXgmxo6TrZC6gY6Bth9BOxfxnYKL7c7oVR6qWszNubIr5rcnmJ9dOvfuQa51gKHWw9sLxnNK81IrHX2Oyu67XZ/9da6MFx7sGDsUXXJCiYimdjz4sm9G/xNwRNFp1MWNfjp5bxwZFeUaSMTUIVDFH5sWRfipcNR/mf0QqyJL11mUOxOl5+dd+ZKkEyXbJGZmUlky4Mf/fvhi3WN+ljM9axn+uibZq06KVsxauCw5nKpM5Pj5tjH5DiD2i86N8c+6NAz7BfcSsc3FPE2jNwXim3p0XQZdfEQcAmtOD1ofBPGOfPVZjeT0caz6IsGDNbFMMWCgVIQTsUss7I6GQ5v3uR+SVvKWrgmfVG20XtpSU9LB6ZfksKSbklDK6vdAQVdGfWAv6VF70sGVaPfUgYt7X5fNFS3hpY0tNsdXqEitTxu9mZ5KyfSHYWA17M+xs4wAP1C8ZRSgFpGUZYyF/SbMEHVEkMowX1NqD2XrN41uAib2nilUCK3funV7YVXQ7y9NCGxE5Ip49Au6l6CX7826B3+QdcFpR/rgctu6zXGFTIVyvOGZ79ihv2oRTjxFpka4US81bUtUzPhMnrsrBlnMzkVMuqR/OLBm0Oy/eqhlDAST8b+oD/uIz1mz4SXECn27U4iyrmzcf4AWtwC+G9uJ1TJlSeGB9A1vCB2QE04ewTy9f764eqeSEXur86HV/enXQIHMWMCOm4deEWjeeVyV2XC897Nd+ooq1/ili5w8SG9iZoJoEjn2G8p49LtdpfrpH51rYpb66BBoQ1ewXssSO42jEgmKTVswjgzyw332xtl5UmdcTmhfBxP8o0F4nF+S9pqT91C+nXZeP0TpyVDbwzqb2Ib70sLgEXifKpYYjfa4nlt862Nb12M1qX6/R25Y82WC4BNQR2ZL4XCKIil3cXccTXAUWWOODejxpCDSC97HJhh0xXl4Wn0TqRzOnPvLXM4YhaOtJv0YUeH0lPtBx/0SKdPHjmMvsot8j7UjRP63B2F5VSvKknlhoh18M4WW5O+ej0e3IVaRH8/UpnomFQmXgOpExo94lvecTSnYgZjV6VBDyIFbrmqdafsQzM+86mJm9oXiNAEpw71Z6dsAT7f03XGxlyIbTvTWrKwTX2nHmtksmr9tnVkVZI5difgiYlYPg3cPJ2ec6ZTUGCVp6x1vuBWQYWbP+896umtf74rFXxd7O9QbQpvJ6nZBNN64TqhnIeWGZtInmLhBlcj2dU1DBOtSdpzeRE+cYhGj1k6VmCsfy/F2FdG7HLbf2ioBOHmzXM08hvM0L9dZ2kqlWNSKpkwZ0ycoROpABcHmQI1mQL0FqsXpIXS/qjDRDmBGxWhwhotaKrn0rwYLyJfthW7WnEeyAu4nJ2hDUcWTLZnMWBB8lYMiGg0h/GcmTG6ooNJZldfh7RXn2KtFg3yNV78Oyg3vUO1G2BXjGusocvl2w70PULQYDbh9mfGLMV12iKfuP2pKzc2lRdamI7uz17lbolrkp9jPTZy7D2O1J0x9Tc+3jMrumUIdVYC2MJ1vB+OyufhnH4jiTRzUERgPw5vPbZudFkaMtrGLmNw7J40vpR9sMvfveNcyszFl1wiY3lH2DGe4SXrc0o5TE1PxClIKMMDf+kRB4YxMTuvIQkxAaoz14Wznp+X2+3345gyvgzy+aGOtc3T2vpgtXe2+FkujD5f3Y7eH/bodpJFj2AGmv31UimYeHbP9dU5tS4+4bE14nbe0lhOx3LyJ0Sm+7VVepbmZmjA5lYR57mo8VnjGu3ze8KheueHKWlcaHnxmvUsbIjuhXePwsJuSvn26wq6SPSAXOx29N7L7pQomFEVc/APUZfpmn04xz7r1GOoYf7n1UMNt1WuoHtMNNGwBW+a9Yj37kvneDdcwXYCeXh1c/Vw1TXq+boMik4w/3Z1PtxJn7fpgtR9KsPnUV0b9kK5IZvjUJwFktHVzdXlA/mMQse339bQdawVjpKxjqgQR358U8+nC5usx+LuTnZmxyHUKzCZei3kBzDHoJ+zPldb9XRp5/L1FhA6UrzZe4rlk+CSxi8jGSeWAgMutt227Kc5KKg2knWpz3jnPJHxmvfoWfrS5AYEoWcuul2lfmUW+2l7ywmuNPgvz/VKRh2q2y/Pz9U2sq4+hSsMuovc3IqjRYlYYHi0fkukIj9vJOxDn4R9eH6u9pc9BmEh32zKlDZjqxwtbmMOzzpLQZ0FncPQTx4RCb2bC5XE0svl6mdNLDDSRVsqixJL92BO0QRyw7uZH+jIh9PNUVkCnKbaZdysYQ3KChdywY7Qe5OGT3SoEr9p7ebnQXFYbS8tjlnba3TbXNvrBSvf3mVY/XLE/uqiLLxVg1DVIgGt6Qw0STNfYHN9XbnRp9HINai4p6YrIMrX5Sm1vhh9GgVcJHbdElj9EWoZ1y0aus/TT56Wu5yUbgv2rfLKrgD3xtuvgdsRMTJl0Q5ob6XBdCtMcPFNIfqDXLCXLwNTw2pppsBdOk3s0BOs1S5ivHdqS9pHfLvbF11oAErY/UthIwORbdEybixnPmdd1y2tQq51010GVuNPyRRRkFRyFu2k+utoOLsWC8pZfG6MYpOsq9ZtnVBV6SEcxvmR0BwqRvCZI4Ccubpvz9Tu26eV3+a/IP9v9PnWFV6PpFIQGZfKmFCzsZT+Vi7eSm9bvhs+uhYRQpbY2ZL+e4gVW4B4kEP+rVdqESpevyXSOxsNbXb2MjsP0pPRPxVY7ln8aD3JPekYfRp9ksLMH+SQGhilIMyX0bAT0NGcqplrduDYXa1Hibmj1ovNqxn6ZPSIchAxxaeyZu4f/7iadaVduukK4NuBLt+3o7p8vx9YztVXJfP8GNNZqyvsDp7XpKmSzyzBIuNF/x4Hiwgpzly4Oc4dK3/H26CShRPrhRsDp8vukq/WLKIyoCKTwM+NaUyrhaoUUNRFliQQM2qArwmJ5LQIacYLptmqd9rNUbtqE9wGRqaczeZrYho5sqOgqrPPKAYLyovD3476YFWpX6RBX1shC+fVfqHlsdXJ0hpInlcL8tUdvK9A3OuXLZD1agHnrmUex2Ez2sBDSFKzDMUv+ikVWmPP+d11YB92tmJuhTvuEhoIWJOYBqIwt0e/0F85Pe/GY/dRt89iRr+PvM2sjFt598U6aTdUHWrvlkN+mO+u7dBR+vbUmLPeVwy9bvrrcZMb3p0x5U0qjtSaoi2w7tlVaeGwH6q8TcoFp9HjXPK+2kzk/VKK0+KSJHaRWveKTML0RMmVGs0bYN/Ke/z+EUGHnQLBE1oHnF+D6UMT33CEvQ2dlgng6eLVWrZLynkfLXr8U1KIcY+vFsSzO6/LK8OwI40iBLAWY2gA0AdOPPbmWHMx5S8w6yB9vmb4mjufTJkoTFLMEhDadW3WWkYMtza8OCuUZ1VVF6k4SFEXqdhbTf91d/v69+CHTAjgI9PdvUOpIQAQg8MP8LWe/YBFli36lLwlTMT48FST4eevt3gO/bn0xy937lcX/7zzPyl/ejV6OL+4uR79djXEX74lTBflxyjnPu0awWwI0Dnyh9TQLZvr7vTX/I9ynx6rEZ4jOyDatqu2hbTSDqkM5/8HAAD//3P8lfo="
+	return "eJztXVtz4zayfj+/gpWX2ClbO5nL1qk8nCqP7Ul81uPxWp5N3hiIhCTsUKSGF3u8tT/+9AUgwatEiZQ9WycPu4ksAV83Gt2NRqP71Pkin35xxGPyX46TqjSQvzg/nP0+/QH+05eJF6t1qqLwF+d/4APH+RO++KezivwskI4XBYH00sSB78NnoUqjWIULZyXTWHmJM4+jFf3tPIgy/1Gk3nICo8QykCKBeRYC/muuZOAnv9Dop04oVtKgwX/SpzV+MY6ytf6kAVR5EHugVCySyU/5x2a8aPZPwG19zB+4/FdgyGMU+81/dldivQYi9Xd/+OkH63uN2Pife7HAgZ0HEWTSWQsVa/4ArcCRJMpiTyaTGgXJm8ks877IdIL/XaOkjrUDww2M4ERzRzjTN44etTahr1YyTODXL4RxH0mYbFg1yD/+NNEiN/lp8tOPPVH7UTYL5BigEyddihRWN83iUPq83sVecM5ur5yvmYyf6iTNVBDAvDVS7J2wAcOfeow/YZ+GqVAhwpGOTFK1EinA8ZYiXkgQwyh2nkD+aKsKz4uyMHVUWNm15p98985kKqzPq1vQpkavTulvbRS1jWWPd2loOGcSJivxrfZlM0EQlfjYyLmP4ptaZasW5mi+EGPqS+XlbNprtYphKgu2smXpUcbSgUHE2shTrl9/J5l6XCr433yABq2cSFje2RPsqPkcRoP/QDqStSjpn6qa3maZ83EaF7quHTawBP+5BwbkwzrJWnoKEPhApgx571j8d8RaNSi0J/j/yJ/ttTpmkAOtDf7wgqa8eP/SNt8080BSknkW3ElYgSS9hg0Tek8T8dC0z1qU7BZMN/+gDIgHGYuFdAKeC61YkuMAthAQWIcoZ5uDG3cl/hWFxUfTNJZiVWWFBpLRSuCqFmIG2gCMtYxV5E/6M6RLK+3LkJXWWC+RIZ9CsDvyKvTlt1sJbg3I+0LextEC/JxkVDFZ59MhQ7xotQ4k/ob1hXBC+egsgmgmAthqsBF9AQpEIVBHJbCpkGDh+0hoBF9PBaBppxNIelDok0j/91il8lyAllLp02fY1+PSGWarmYyRxnWBwXlEEI6nUTgZwiADpilx6F+b6d+Kyjsp/OcmEgTWH5zGc/Ars9WhCTRKrSC0iThPY3Mi+Hr7djxpnCaJ0LWDIUMnjYX3xVlGj84qAzMEs5HTZ/M2XYI9WCzXWYrbIUtkxyZvZxl8vJcz1s4wGPk75dKB9UNdshp1w/fHtNFl63vi051cB8oTSNkhfTAZiHViKAdH9FGibQ2dbO3T0QkYuAJXfC0FORBwkkSO5T5HQj4H6uzGmYALsMGJMNboJzC2zx52fWQRRjB4nP9CT6b1/wb73cC/Q7hs/zH8u49FmAgP6YYtO4cB0tEE8EwLXywxOKO5dBrIB2l5u34m0XFLC1xAhKehJTmv4RMvi/Ho2zhVPlzEzEgwbIbTJf1YMZKuilIRvFQ2nHGQpM1lTFWg/kX77SCKqnwa2OREZoQO/gjnb6RXVAM+m4ktG6wXQ22jTetN7vQpgcW/jOMoHtMO9zy6smJbyBCYkDZx0UHV+tv9/a3z7tUrUJ4izdCg+3KPAy5scV/xvjpfSu/LB6ECFHVGPiJzCn9uTlM6IoU1WTO3ADUYhRXua4OOl75jw95K+G64sCzhOUnBIUgga8RGTy+jiCUhBluMBNVNWeOoM/CV6OdL2AoOGDTnSaag5WAQa7A9PQXh34NnlqaBvHyAXTbWIt81ST8RJ795kvxD2a7JGocc6IhsyB9bzHtzwPKYA7UCWlvcIqDZ3LM5Rwn63yIpsSRkFhy384D0+8uUg7KOH1MQtNn7KL7hrtj/7mWzw9wdHyGu4OlqJum8hAYN/qvVnvHocLgiaQHzC9oHlQb4xcETq51TX67IaUYuJcimZiZ1adaCTfc4yjW6aC+YYYVEMKnNR9lKzBTvkgtOOx/g1zXmpQWrAYe+NmGcLW6n8I0ToAFvIa5EDxDTbz1+Z+t4yAVp9MVe9oow5FGX5EUvxPPrEuCQdcog+W07V40ZwNjvPLVUiyUIUOPotbEqsr9BzvswrvWM9jycq4phM9Psn3Ts0R25ZrglZ7bv1P+SHH5/wPvxy/fT/dIVhr4Y/0cUZCvamO+fUJvtf+g3Qa8ERAIXTwrgD+2PaI3nXbzZtE6xOgpNLuI6RZf3gSAleEyEn5przRuVxtHpTKCCA0anIvTkCV6RwvqkVkQBjkrgMaOXW/q4IQi+6cDMrKGtNypveBt8l8xBufm0HoIzqHBSihJW/MCcLwmFfmsQ8YsY/9hqHcfDWlnEPcH+PZMZeHvhIl0OhLfCVTTuVbnLg1iPQqUkgRG6FDohgSRrD5Lu8xNvkV4xEG1lQ3X1l0/2OsC/aZPiHF19up0ew+8DBQIvGXq+lvjHkpWb8/lax/BAc+vNN3E+4z57VOnSzjPgAabTi3yPRmHwtIkt9o30KCIKp3BMjexY+MQ5CqN4JdiGw6K/fvfXv1Uco+PiOrFbCobhzfssTtL3IkA9NgA3Cky/Usw1cG6zeB0lkiAdLdavj0+cQkCdT/C7FXHjtwv4e5L+fMwXUudRYD7zfj4uE8P0wrzAIQxp8qYSs4gifU1S6oEMotN5hJKGINALsiJDpb8DCIJAE8fgnqvQumibIcNq6dHNIkeXMRQcxAXrCgXtrg55xyUoJ+z8iCCo6XM+uAykXhAAh7oOTFVtNw1J1pUfHIKgToychxZGev3iOsXsJGezFQau/QYf3Xu9n4/uvT6kj37+ej8f3VtnE+L0ZO1Vj45MfOKJQPruPIhE9Qtb5BaXNQnIYOTRHTwAJ7nLYHWs0ABeUOg70wAPVRgkMPejxlmctBLCSsjNEpiukZaGGMc2+dG5DJ7ffs41Xb6xbGxkiPFbmXXw3YR3xsZjFMRS0MsIGzgzOiwwLzFO73lxBl9MFH6iQFDhw0BkITnupNNFnFaTZWxiEjBTQZa4ByBKT1WmiC6n6FKqUHkgPyFFjqyzBqsI/Bkw5ZxG0NZbvx1SifMvGUfbUgr/T08ZqnGDgUglWhoJxr2CsbC1UD7o1ccQSa6vN3sDrFbSZYYKFHYYxSn8/BqTSWgmOZTpYxR/mahwAm4WGO3mE/1ulFa1vJ4BNJknwfP16V4JLJcGAeBTGc/xKUVt66nQPJhDZ6bpUNhOkQtfd8HCjKAB67RZbj7pcvS6tiazmyIY6oCL1B/9DotkkfSfskogdzOM0my7ROyi/+I0/WiH5aNhDrbDaLaDrBzTZa1bfxI3i+LzL9zBdt0zrtxQO85XyRcVTfA0cLiVo1Uzm0xoNx+pyNcjAZdeFvHRB6ECulnApMLd1q1G6Ejr9r4gy1qunSnsJIbObs+ybHZa00HWzSJ11IUzhFlrtyONm8UwWg/mg9DSFGGKanDm0BsMKOtcpf4UnrfSNsQu6xPXaRTM8ZayHpE67JYbcylrtO2/63ZZSU7JnXiYSOtyWutApN7JdRTjJcxSUupnCSlGFdYioSSPKF2W/2jShBGTfj4BH1ICdPlvOmYciCR1VirM0u2JdHm8A9M6BiFmnmcgpXnFtiUmNxYeSPeEsiIaaUC3biGr7236h+ZgFk7A3Gyp8r+qFd7uqeY9sXNNhasLc2NH43OyE2xmDqn1wVdEgCe4BgPWfrgKfcxJl4Uk+DLltHcr7KwSR4aoi1rUaQ50HasHGG3ih4lbqaUzAEP16M7FzZQmNuytnQy2RKmq2SdaEqsf94B2dfvwFoNq+ArfgS0UeYpi3XSbtxPWbBYobyyG0uA1fm4plRragFw0jNM4LlG5AL6r2/wvR8jgY7AmGRvQXVhKW2iCz1OGVUQ0bpWHJ5wB//NfT2cKEzsTtQgpEk2TbIV0+HVvROocrfmhivNvJ87CkP8tWWYpZlecUnT53w6wGLQ9yfS/0WOh0k3mX6V/vIGidInOLftbqKrHMgV6HnK3jFlouOgL9qtYA78/5EXfdXOdmmdLxnuPYdLQP4/CkH3ugR6ulZfSy4e32Yq3HkUxluAJy0qBhVIJ3lWZ15fkoERwmNI3UXHuZ8ZyocBBi61LoY7cYHzadg76wtUUu6//+GNgKun1HAyL72fWQKrk93Pm0R0lq+4J+s04oN+MCvrtOKDfjgr63Tig340CGtTKmFz2AoU6TKJqINBJGXVtj24JeUQeJzLGDNMhIOs3ZsM8+KwmRur8xyKSQnALbbkSbS9wyVV6EEHHS+S1CgJMtB0Oej1f1ry/y7V6/uR+Jj2BeR8EO4vh9PQVc0zxJILqvkNGpAjS5dNvkWH6vu9dykxf8vDFBrN3HTn5VG1kS+mYImV28uwQYFvZfEQCHiBaEObjqrQc3Z/bf83zC4xXCA6CSbMVNT600/g5HHlJsnDYRRmuzEuxGpSXpmuSnGDoRGeynbBbSFm9+JW6w0IOYFq82Wf2N6h64EOqglrABhN28DcwjvF8tAEBrvky7rAQeQXQs+v3ZyAkD7Lw9Hghh2FRURC05PTpHDAHxdKWU0FQmHFsXBJzEqz7ejl7y3/C72O2S7ol+Sbt+fr881Dpzk1Ul0FW3nodweTH9ou5s3VeUMC5xl++3yjbNk038vFw64lFAasLaXvsh1vN2zjCQ4Mc7AFRG8n6QttMt/2i5RW6i6/ue1AtD3XAM6tF7os7vjbrtDE8nRegzc5p7Pvr6Y1cRKkS+XF9DNcUpikRqUBGlO0960MBSZyvfDrN5+oAb7dgy+AOycOmZYJ18SVBE5Gb3n1ocD+ob9J377Tpc8egeY5TnObWVdQiFkW0YgPYO+mrGKujj3Nq4MEHAfg5DtxrzK11L6liBvD4cJi9KAv88Me0/OjLPjh8vrs211T5ulDyOYoWuz94oAhw7+B9Eajn//7blsfPN3/8MQqtVkiFiUasfAYlqkHVLij+2qIMtj/wjwe/5dg/JP53Y+JviQEMiv/VqxHxv3o1IvDXYwJ/PSLwN2MCfzMi8LdjAn87JPCr24e/VhzsMfypBte67iTQK3EE1A13xAgdDl+EX/JM5H4RxIZj2hgsffYD2ksTm7dEULf83Olw5RgLtOkCrDFUWiZlSVWeuO4CRhDqBXqsoZ83hl0sSi/+Z1giTgQZJ9cNDS4LNovLArY0l73j8BxeEphCFZoYcCuXUdaxxUeILu0UU+qKklYeB+wbkCiGOWAw4oYnfaGBiA9B9DhkGK4jCDGHqWDxyxcAx3Udv0lnV4C7YEDGB49WajQCrqcHIOB6OhoBny8OsAIwyWAEfA+6r4Z5/FhalfsoM0swiMlSfDFuui5PrC94wwJLUfDeHMPRlHK0zFzwdTqchSoay9VsEZ9Oj5NFyUR0tioibdNCm3s017l9Tw9N0wtxlPEa0wsyuhoGlfyXq9vNN4pl6KMtSAN8W/S7WgzQenwXO9umSO9vlqYO6s5vXdZdGAqXQwaY60kHML5zdDe9Py4/FecHTPkFQLQlbAyEPAfmXfN+EDML07OzmtnLrGa2P1v2jFF3RZ0X0JDKpzwGncTxjIkkXejyJJP6iSgQq5lvHx36n4Z4iAOehK5pwsZT0DN2vbwKH/TtzPCuIJrWhL28eRYW1yr0tuWb9LKUM3OMSbMaPfKf+bYW9Kz1n7r1cJIFKb/Ky4fecCmpE6WHJlIVDNwd2wVY8WuZpjIeDCWWyBbJU+gt4yiMqP6MAXrCTzgq68TyWeq8QQlMAtA/5IbDB6SnAUHV6YHYpgKNZ4eJv8BcjZDmvuDKik/YWiSLB0kGGY3SHPRWNGbxUD1x6FlOXpNRJ6mBZDXsJKxT5Od+F7X51ERs7ngx5l6oJJoKKjKrG2103A2kaPij+GyQup8oF7yeujuGbnqe71NTI4I7jyUsLLpkWv7OMpZeFPvmtLCBtef5gf0y11iDczmXgCL3skgcLQRBch+PDtuOXkz8QKH0MVB/0MVRwfbdyUXDbmSEBXjuU1s6Qxha9bf8CJMPqOSYAV8ESbwOR8YqNNpI7bBeTa8Vwlordk3Znenxti77vg1FtHoOqGpKCsL/CJTQe4QLtgGhG9jq+ArLneaOfLXcbAvZRb3CvgywvZlhLya28WUGXMn8KcDzU4QS7Iu4vELcdzoIWpdQJbqMZN15ZxfWZa27lw9fGqnFlV9n/DztyfbcP9IfrV5rVFDX+ZsKZQLIL0QqnA8qlsuo3OdjF499+Kek9rthJtM0z7QJ1zeW+gIcDV3DzZJIF7DPHsXTXutQDNOyCGRmif+PxH/u4uh9cahmKNJyc3bv6DGQy4JrsrDhTl7aoYlCaVfhB6DKcm0H3p+VcnFahdp8ym/+LVe1o2B2AXpKbH0evCYSC9qBdM8/bs83YP6UpffR2HzOK58ZXVAFr/Mvtmc1wR6R0/qVSifaXswuMirO+GQ0bmJFcf6iN3MtlGwD97KI9o+dC2JfLPRGTGf72yhOzwKTEjuKTa8KA2Xtcv8m7VhhujefibAkTceBTNcWh41xkCwbau1LxXGlldik8/W5goKpzATI+JMO14ojsxdxtB4DvQn8+jGVYWjQeBuhjW1DamV997YiJeCjaLetMfdSbhr3yLakVqF3CGtiQx+V44NblObXPmM8uLUyJbS2qCZWbtTWBnTs79ctDH5/wFuFu4s9u4Xt1YnA1P3U7Qb6H2XKfQr6VvD//44Dh+444MPJGHuIudbWGoUcM1HlTVu98WaObJbX65sAK4Y7cevmTnemx/INTHd0dndzTCLArev8ZDMoLxBJM692gnVuaxg7ImDae+C92UquovipyMIiDOaLF+83VZW10CsfNgEWsqiWiBqCBIHLGp8mGb5cxSQMs/jFrBPuR1p8gBEmpCIL1ddMIgCW9/wbOGwvErnU4nDkTXXpD8ZpzJNVBwwoMJS2l0x16ZbN9eU6XTZi27sKOPgpFONDE3P1KXGO8GbkL6XuuMmx3fFN0DUtOzCAsBm7KQb6NXD5XZcLujpM3X9Gs3E0hk7gmf792pnyQ7IznNDBCe2KLBurZ85jKbHwosu756Cl54vYeFGLFs5EPmaVMNc1qFbkLpa0xdKazw1b4wDz2VoeUD+OcDEh1KWzH782dZU/pIyYNxjWDFgoVLevSbh7DWKY8Nt5SVfCt1GSLmIJ8tQMPgrQe3djmb+2d5MgSt1ALCar2YDwYcAFpUfojqD8tpZmzf9GbiYApntKGa9Iyf9+dk0KJj9K9aIPtYCroo2lmnfUP6axqGXyQZfwrWxrf8Y2pMQM4nyPgtJG5n19c7+P2NMlvXCwZR7W+aW1sYwPrhPK2VKZmsvsS9j2yV6bj0+wLCfORxErcfGeWwkV61WapsXzSB7Fmv3jZ1IECID3Pud7625iJYrJpOsbDKU7jOf6A72rQpk3U2nrjCBaJK7OHayv5j4bkATTIgWPApYqwYl77SwyrYffWmzRe+4tMP+x2l58dkKn5yhuHTeBwiSkIPK+jAsrn8Ukf+Ru6SZ8XE+ezNpz7T5tfEtPLc6yGD61tyDVxaLJugjh7guqRzeCPemwrjpUEJjuDBXJzbOaM6zKpaGeoDGIqAAXGPd3p+zn5bX3usnk1gTPQifvTdqmFTLzuNv+ZJJ7iBcAwTM7iUY6y8oeb4Pgcziv4h2L5MxPVK6bpBS+o0Iq2o8ZhaPqBH3IoBmLC6xN+qBo2DuB0/lKNcfZBtP2PEcfLW8B9CUWdRnZHNEcud7vg84PxoV2cXFtvZDuAWw1MjBQ2TLGtpLZ2sfmAewUMid7IeWBDgF2lwXWj5QHhZfrHVO13mqgTT1SSlkyXK8a/TvuVYwa2Nx6UKdPb1nqC4PaWVtWctvRvmptXSiuHVjgalRDskKFoBvwvHh0x4MfFzyJxXwOfnjdT7dT9IldHhAXrbAmmHGIzI+RdSZeejHNPyYvBFW8dZkhqFFgfnbemitmZYZkS5Sli4jYcq9H/374gq7RGJu5moAuvuj+ZXUnZSPGRGKDqHFVDs+xi8phhTouOp5jF3TkGY4LrtZ8j5Z4E8ZA1+zt6dEMGXXREGgL1ZweUr4rOD8oXfi3m4w+nsVYNFCwzpdzqt2IkQURLjJcqyNwS45zv6QvZT1ck7Eo6/ReetLT04EZlySzpXvS0EtrD0DBUErd4O+p0cdag7LS77kGPfX+WDSUTUNPGvpZhxcoSD2Pm6Np3tKJdMtFoOtZHWNXFIB+pniKFaCOPC9bKw76ASiMpmAIxbivK4HnkvpdA0fY4s4rBYvc6qXXsBdeDfF2a0IHJ3TmCh8w9om6W/Cr1wajw9/rusD6cTLh7LZRY1wmU8Ge17zAhgMKZm6H5sRbZGqYE/FG19amZobx9cH6ojaTUyKjGskv3h4yks1XD1bCCPy7Pui7Y6TH7JjwYiLFuvOMJ7BTF+o4fQAtbgH0NzcTGke115570AUz44CJE6gv0vn97ur+8g4Tz+4uzy4u706GBC7DhQrlwF0cLzECZF/uxlmoec/znTBl1Utc6wKXahqkXjMBguh0tUlxrdvtIfdJ9eo6Lm6tjQSZjoQF76k2PBsMTDMDfTxTASaWtd9vd66VJnURRDMRuP4sNyzSd/Nb0l42dQPpV7by+pWmpdd+qAyqz5Mb70sLgEXi/DpWKzS0xUvn5lsb3UWatEv5+1tyB9UWB8DmwNHD8qUQmFj6EVoxPq4aOLHNEXYzKgzZi3Tb46AMm6EoN6/UtyIdZuanrzkc2B76SNslD1s6lJpqPfhkRDp18sh+9JVukXehzl2Jb8NRaKd6lUmye1NWwbMuRpVevx437kIlor8bqSocmFQVvgRSZ8L7Qm95XW8pwoV0uWBGgjnvvF3jtlP2vhmf+dQOT61rdSQOTW1KAc/xNQhfkHOTcsqF2GSZWsnCu+thPVYvzcql9NrIKiVzbE/AIxjl6HHC8wx6zpmD3pcoPLbU6dpnBRU8f94GVtNb/fu2VARtsb99pcm8nRRpF0z0wpMVuKZSdy/pInlONTS4XDWXmDQTtSTtcV6EThyCgbI1yF2K/j3sI12kckizXzylKrQIz5vnaOQ3mCR9WCopW2PqCWuYSIXpqQpPyYmMJW0OZw67L4P/R2+xfEFaCO2PiZkoJ7BTEEqsSUKxTpZR+my88HQFXWowFgSGPIOL9YxoOLJQsr3CIhtpTwZ4WFPeXarUJVd0Mstw9w1Ie/kpVr1+ky63o99B8fSMajvAXBfNTeSQ27cf6DuCgKUbO3DrM2O2pn3aI5+4/6krVzalF1qUjq7PXnbjypbkZz9x08jVHseaz5j46mLHrOieIdSFBbCH64i34NZ5OKcf9EsE34udkFqjaO2x0dCBnjQKgjMGXX7S+Fz6Abc/v+MEW83xJU5ktC3ClvEMvbI6pzSQ83Qk4mK5EooO/NYjDgpjUnZeQxLiSook44ao1fy8XG+/cX0Y7MmsjzVz/6e11cEq72zpb/lijPnqdvpmv0e3swxfXE/wRcdzXRng2T2XV3ZqOT6hsTXiZm/JjeZuNMNWKMPvLetZGs/QgI13EZhds9T0rLFF+rRN2Ffu9DCWxJnuIy9ZzoxB5BfeIy4WNbbKzS8XdInIA+LY7fSNXjvMXF6I2A+kfogKINpe9Wjsi0E9hgrmXy/vK7hRuIzsqbCJhg1419mIeG8/D4634wp2EMgXl9eX95dDo162ZVAMgvm3y7OLreR5kyxEyZjC8GlalYadUHZkc+yLs0AyBTE4v3c+0aLT229UdANLBVPiJp4IwwM/vqnm0xkjq7Hw3cnW7NiHejhQZvFLId+AOQT92JthvN1WPl3iXLreAkEniru9Jzjbh9jL4HlWhpelwECbbTuTDee6WOp3x7qnL6c+053zLPJb3qNn6+cm1yBwdPticrus1nGI/aS/5pRcpf3tt2olowHFDQYvd/Tl+hRco3WbdeMdJ4pqvVLR0foVXrb/3EnYuzEJg8HLrX4PQZjJN5urOEldFI4etzH7Z52tZXxqZI5CP3lExLTRLkSSqmDb1c+aWACcoWhLaVNS6R7KKZrJXPF284MceXO6OShLZCDWCWfctLCG1oo2csEO0wZVmL8kpmB/197Nz4OlakU7HATDQ9b2mt401/Z6xsq3txlVv5xi4GKYuvamqsUKDoRwlseCzbrAZntduenH6ZR7hdxhAchhgMS6Lo/VhQTmMbgwQxQbV6jqI1Qb1w0puk/zj5qW25yUYQv21XmFO4DfeOs9cDOFj9aw9JvR3kQppVtRgovuzzEe5IK9wZNhqtktzRTwpdMMh55R2fzQp3unvqR9oLe7Y9FFCsDCrl8K42saJrIvWhWkyJlP2dB1S8uQK42Nnwyr6aeYZosB33UUKG8r0W+j4fQqBHOt/LMUFNIsG6qL3iBUldo5m3F+xOfdGipF8BUTABRT3bdvAu32Sem3+S+c/51+uuEa+F4Ug/FKOZVxhU/Gu3onbuLiTaR1y3fDR+7WEUYWO3vSfyf9GC9M7qOL4Ouo1BJUun5bRdrZaOh4tJPauY80GeNTQeWesU/KTO5IB9i9j+CjLAErGMUp1r/8PL0YBLS3xFwJ6jvB7C7Xo6TcUfRi82qGOhkda5Bi6iD6TOD88+MfrllnWemmK4Cve7p8Xw/q8v19z3KuuiqZ5gfW3DtwSaz1Oo6+qRUVGS9aKTEsUAPhKYeb/dyx0ne8DSJZOLF6ceGn4mm45KuWTWQDKjIJ9NyUxlQvVIXPZ3BN1WolfQXEBy0hkZwWGMPFzil173SYo3ZZJ7ABc+aBWixbYho5soOgqrIPtoIE+1oc/raUBxSlcZEaee2FzJxXx4WWx1ZnWOM1CPJqQbq6g/YVHH79sgFyUi/gPPSa+74xRh08xJI6T6b4xTilQivsObu9MuyjJmOKdzhzF8BqAloS07CoqVG3B7/Qr52et+Mx/2nYZzFgurTOLI1bevelBmk3VB5q55ZDepjvru3QQfr2VJjT7iuaXjfj9bjJFe/WmPImFQdqTdEX2PDsKrVw2A1V3iblfQD/vYyCsdpM5P1SitPik7PCTYrulTMz0zuwRTa3d8lh30R39P0DgjaWgsDjrVIZcH4NVmlT0F/j0Qg7K7okWkk6XbxYzXYO1neMFj36KSmsG9r4ckE8tLycV0ZhRziHEoBWjKYBwBg46dibY82XKX+BWQWp8zXN1/h8MldhoZJ8tZJhQrSKJIk8RaaNLs4K4amL6sM63EtQ4fc7i+k/bm9evg2+hxWRwTQd7t7BaggAaoWGn9BrPfyD8pAtyYnzCmTAp4eniXPx6fcbOof+bH34+ZZ/9f7XW/0T+6+X0/uz99dX098uL+iXrzA4mZcfw1xKTrsmMB0BOiYfn0duMK7b01/xP+w+PSgRmiNbINpkVftCqrVDsuH8H5B7CMI="
 }
@@ -0,0 +1,139 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+)
+
+// metric is a metric of a stream with the json output format, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-metric-streams-formats-json.html
+type metric struct {
+	MetricStreamName string             `json:"metric_stream_name"`
+	AccountID        string             `json:"account_id"`
+	Region           string             `json:"region"`
+	Namespace        string             `json:"namespace"`
+	MetricName       string             `json:"metric_name"`
+	Dimensions       map[string]string  `json:"dimensions"`
+	Timestamp        int64              `json:"timestamp"`
+	Value            map[string]float64 `json:"value"`
+}
+
+// statistics maps the statistics of the streams to the names used by the
+// cloudwatch metricset, percentiles keep their name.
+var statistics = map[string]string{
+	"max":   "max",
+	"min":   "min",
+	"sum":   "sum",
+	"count": "count",
+}
+
+// createEvents decodes the metrics of the Firehose records, each holding
+// newline delimited metrics, and creates an event per resource and timestamp
+// like the cloudwatch metricset does. It returns the number of metrics that
+// could not be decoded.
+func createEvents(records [][]byte) ([]mb.Event, int) {
+	var keys []string
+	events := map[string]mb.Event{}
+	skipped := 0
+	for _, record := range records {
+		dec := json.NewDecoder(bytes.NewReader(record))
+		for {
+			var m metric
+			err := dec.Decode(&m)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				// The rest of the record can't be decoded
+				skipped++
+				break
+			}
+			if m.Namespace == "" || m.MetricName == "" || len(m.Value) == 0 {
+				skipped++
+				continue
+			}
+
+			key := eventKey(m)
+			event, ok := events[key]
+			if !ok {
+				event = initEvent(m)
+				keys = append(keys, key)
+			}
+			insertMetric(event, m)
+			events[key] = event
+		}
+	}
+
+	result := make([]mb.Event, len(keys))
+	for i, key := range keys {
+		result[i] = events[key]
+	}
+	return result, skipped
+}
+
+// eventKey identifies the event of a metric: the metrics of a resource at
+// the same time are reported together.
+func eventKey(m metric) string {
+	names := make([]string, 0, len(m.Dimensions))
+	for name := range m.Dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	key := []string{m.AccountID, m.Region, m.Namespace, strconv.FormatInt(m.Timestamp, 10)}
+	for _, name := range names {
+		key = append(key, name+"="+m.Dimensions[name])
+	}
+	return strings.Join(key, "|")
+}
+
+func initEvent(m metric) mb.Event {
+	event := aws.InitEvent(m.Region, "", m.AccountID)
+	event.Timestamp = time.Unix(0, m.Timestamp*int64(time.Millisecond)).UTC()
+	if m.MetricStreamName != "" {
+		event.MetricSetFields.Put("name", m.MetricStreamName)
+	}
+	event.RootFields.Put("aws.cloudwatch.namespace", m.Namespace)
+	for name, value := range m.Dimensions {
+		event.RootFields.Put("aws.dimensions."+name, value)
+	}
+	return event
+}
+
+// insertMetric adds the statistics of a metric to its event, with the same
+// field names as the cloudwatch metricset.
+func insertMetric(event mb.Event, m metric) {
+	prefix := "aws." + stripNamespace(m.Namespace) + ".metrics." + common.DeDot(m.MetricName) + "."
+	for stat, value := range m.Value {
+		name, ok := statistics[stat]
+		if !ok {
+			name = common.DeDot(stat)
+		}
+		event.RootFields.Put(prefix+name, value)
+	}
+	if count := m.Value["count"]; count > 0 {
+		if sum, ok := m.Value["sum"]; ok {
+			event.RootFields.Put(prefix+"avg", sum/count)
+		}
+	}
+}
+
+// stripNamespace converts Cloudwatch namespace into the root field we will use for metrics
+// example AWS/EC2 -> ec2
+func stripNamespace(namespace string) string {
+	parts := strings.Split(namespace, "/")
+	return strings.ToLower(parts[len(parts)-1])
+}
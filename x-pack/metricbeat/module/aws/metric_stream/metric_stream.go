@@ -0,0 +1,176 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_stream
+
+import (
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	serverhelper "github.com/elastic/beats/v7/metricbeat/helper/server"
+	httpserver "github.com/elastic/beats/v7/metricbeat/helper/server/http"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+)
+
+var metricsetName = "metric_stream"
+
+const accessKeyHeader = "X-Amz-Firehose-Access-Key"
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. The
+// metricset then receives the metrics pushed by CloudWatch Metric Streams.
+func init() {
+	mb.Registry.MustAddMetricSet(aws.ModuleName, metricsetName, New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// Config holds the configuration specific for the metric_stream metricset.
+type Config struct {
+	// AccessKey is the access key of the HTTP endpoint destination of the
+	// Firehose delivery stream, requests without it are rejected.
+	AccessKey string `config:"access_key"`
+}
+
+// MetricSet receives the metrics of CloudWatch Metric Streams, delivered by
+// Kinesis Data Firehose to an HTTP endpoint.
+type MetricSet struct {
+	mb.BaseMetricSet
+	server    serverhelper.Server
+	events    chan mb.Event
+	accessKey string
+}
+
+// New creates a new instance of the MetricSet. Unlike the other aws
+// metricsets, it doesn't call the AWS APIs and needs no credentials.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := Config{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		events:        make(chan mb.Event),
+		accessKey:     config.AccessKey,
+	}
+	if m.accessKey == "" {
+		base.Logger().Warn("no access_key set, the metrics of any Firehose delivery stream are accepted")
+	}
+
+	svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
+	if err != nil {
+		return nil, err
+	}
+	m.server = svc
+	return m, nil
+}
+
+// Run starts the HTTP endpoint and reports the received metrics.
+func (m *MetricSet) Run(reporter mb.PushReporterV2) {
+	m.server.Start()
+
+	for {
+		select {
+		case <-reporter.Done():
+			m.server.Stop()
+			return
+		case e := <-m.events:
+			reporter.Event(e)
+		}
+	}
+}
+
+// firehoseRequest is the body of the requests of Firehose, see
+// https://docs.aws.amazon.com/firehose/latest/dev/httpdeliveryrequestresponse.html
+type firehoseRequest struct {
+	RequestID string `json:"requestId"`
+	Timestamp int64  `json:"timestamp"`
+	Records   []struct {
+		Data []byte `json:"data"`
+	} `json:"records"`
+}
+
+type firehoseResponse struct {
+	RequestID    string `json:"requestId"`
+	Timestamp    int64  `json:"timestamp"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+func (m *MetricSet) handleFunc(writer http.ResponseWriter, req *http.Request) {
+	requestID := req.Header.Get("X-Amz-Firehose-Request-Id")
+	if req.Method != http.MethodPost {
+		m.respond(writer, requestID, http.StatusMethodNotAllowed, errors.New("only POST requests are accepted"))
+		return
+	}
+	if m.accessKey != "" && subtle.ConstantTimeCompare([]byte(req.Header.Get(accessKeyHeader)), []byte(m.accessKey)) != 1 {
+		m.respond(writer, requestID, http.StatusUnauthorized, errors.New("invalid access key"))
+		return
+	}
+
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			m.respond(writer, requestID, http.StatusBadRequest, errors.Wrap(err, "invalid gzip body"))
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	var request firehoseRequest
+	if err := json.NewDecoder(body).Decode(&request); err != nil {
+		m.respond(writer, requestID, http.StatusBadRequest, errors.Wrap(err, "invalid request body"))
+		return
+	}
+	if requestID == "" {
+		requestID = request.RequestID
+	}
+
+	records := make([][]byte, len(request.Records))
+	for i, record := range request.Records {
+		records[i] = record.Data
+	}
+	events, skipped := createEvents(records)
+	if skipped > 0 {
+		m.Logger().Warnf("Skipped %d metrics of Firehose request %s that could not be decoded, "+
+			"the output format of the metric stream must be json", skipped, requestID)
+	}
+
+	for _, e := range events {
+		select {
+		case <-req.Context().Done():
+			return
+		case m.events <- e:
+		}
+	}
+	m.respond(writer, requestID, http.StatusOK, nil)
+}
+
+// respond writes the response expected by Firehose, which retries the
+// requests that fail.
+func (m *MetricSet) respond(writer http.ResponseWriter, requestID string, status int, err error) {
+	response := firehoseResponse{
+		RequestID: requestID,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if err != nil {
+		m.Logger().Debugf("Rejected Firehose request %s: %v", requestID, err)
+		response.ErrorMessage = err.Error()
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(response)
+}
@@ -0,0 +1,167 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// +build !integration
+
+package metric_stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+)
+
+const (
+	ec2Metrics = `{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-1"},"timestamp":1611929698000,"value":{"max":3.0,"min":1.0,"sum":6.0,"count":3.0},"unit":"Percent"}
+{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"DiskWriteOps","dimensions":{"InstanceId":"i-1"},"timestamp":1611929698000,"value":{"max":2.0,"min":0.0,"sum":2.0,"count":2.0,"p99":1.9},"unit":"Count"}
+{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-2"},"timestamp":1611929698000,"value":{"max":0.0,"min":0.0,"sum":0.0,"count":0.0},"unit":"Percent"}
+`
+	billingMetric = `{"metric_stream_name":"stream","account_id":"123456789012","region":"us-east-1","namespace":"AWS/Billing","metric_name":"EstimatedCharges","dimensions":{"Currency":"USD","ServiceName":"AmazonEC2"},"timestamp":1611929640000,"value":{"max":12.5,"min":12.5,"sum":12.5,"count":1.0},"unit":"None"}
+`
+)
+
+func newTestMetricSet(t *testing.T, accessKey string) *MetricSet {
+	config := map[string]interface{}{
+		"module":     "aws",
+		"metricsets": []string{"metric_stream"},
+		"period":     "5m",
+		"access_key": accessKey,
+	}
+	m := mbtest.NewPushMetricSetV2(t, config).(*MetricSet)
+	m.events = make(chan mb.Event, 10)
+	return m
+}
+
+func firehoseBody(t *testing.T, records ...string) []byte {
+	request := firehoseRequest{RequestID: "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", Timestamp: 1611929700000}
+	for _, record := range records {
+		request.Records = append(request.Records, struct {
+			Data []byte `json:"data"`
+		}{Data: []byte(record)})
+	}
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	return body
+}
+
+func post(m *MetricSet, body []byte, header http.Header) (*httptest.ResponseRecorder, firehoseResponse) {
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header = header
+	rec := httptest.NewRecorder()
+	m.handleFunc(rec, req)
+
+	var response firehoseResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	return rec, response
+}
+
+func TestHandleFunc(t *testing.T) {
+	m := newTestMetricSet(t, "secret")
+
+	rec, response := post(m, firehoseBody(t, ec2Metrics, billingMetric), http.Header{
+		"X-Amz-Firehose-Access-Key": {"secret"},
+		"X-Amz-Firehose-Request-Id": {"ed4acda5-034f-9f42-bba1-f29aea6d7d8f"},
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", response.RequestID)
+	assert.Empty(t, response.ErrorMessage)
+
+	require.Len(t, m.events, 3)
+	event := <-m.events
+	assert.Equal(t, time.Unix(1611929698, 0).UTC(), event.Timestamp)
+	assert.Equal(t, "stream", event.MetricSetFields["name"])
+	for field, value := range map[string]interface{}{
+		"cloud.provider":                       "aws",
+		"cloud.region":                         "us-east-1",
+		"cloud.account.id":                     "123456789012",
+		"aws.cloudwatch.namespace":             "AWS/EC2",
+		"aws.dimensions.InstanceId":            "i-1",
+		"aws.ec2.metrics.CPUUtilization.max":   3.0,
+		"aws.ec2.metrics.CPUUtilization.min":   1.0,
+		"aws.ec2.metrics.CPUUtilization.sum":   6.0,
+		"aws.ec2.metrics.CPUUtilization.count": 3.0,
+		"aws.ec2.metrics.CPUUtilization.avg":   2.0,
+		"aws.ec2.metrics.DiskWriteOps.avg":     1.0,
+		"aws.ec2.metrics.DiskWriteOps.p99":     1.9,
+	} {
+		v, err := event.RootFields.GetValue(field)
+		if assert.NoError(t, err, field) {
+			assert.Equal(t, value, v, field)
+		}
+	}
+
+	// No average without data points
+	event = <-m.events
+	_, err := event.RootFields.GetValue("aws.ec2.metrics.CPUUtilization.avg")
+	assert.Error(t, err)
+
+	event = <-m.events
+	v, err := event.RootFields.GetValue("aws.billing.metrics.EstimatedCharges.avg")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.5, v)
+	v, err = event.RootFields.GetValue("aws.dimensions.ServiceName")
+	assert.NoError(t, err)
+	assert.Equal(t, "AmazonEC2", v)
+}
+
+func TestHandleFuncGzip(t *testing.T) {
+	m := newTestMetricSet(t, "")
+
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	gz.Write(firehoseBody(t, billingMetric))
+	gz.Close()
+
+	rec, response := post(m, body.Bytes(), http.Header{"Content-Encoding": {"gzip"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// The request ID is taken from the body without the header
+	assert.Equal(t, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", response.RequestID)
+	assert.Len(t, m.events, 1)
+}
+
+func TestHandleFuncErrors(t *testing.T) {
+	m := newTestMetricSet(t, "secret")
+
+	rec, response := post(m, firehoseBody(t, ec2Metrics), http.Header{
+		"X-Amz-Firehose-Access-Key": {"wrong"},
+		"X-Amz-Firehose-Request-Id": {"a"},
+	})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "a", response.RequestID)
+	assert.Equal(t, "invalid access key", response.ErrorMessage)
+
+	rec, response = post(m, []byte("{"), http.Header{"X-Amz-Firehose-Access-Key": {"secret"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, response.ErrorMessage, "invalid request body")
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rec = httptest.NewRecorder()
+	m.handleFunc(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	assert.Len(t, m.events, 0)
+}
+
+func TestCreateEventsSkipped(t *testing.T) {
+	records := [][]byte{
+		[]byte(billingMetric + `{"namespace":"AWS/EC2","metric_name":"CPUUtilization","value":{}}` + "\n"),
+		// Metrics in the OpenTelemetry format can't be decoded
+		[]byte("\x0a\x8b\x01" + strings.Repeat("x", 10)),
+	}
+	events, skipped := createEvents(records)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 2, skipped)
+}
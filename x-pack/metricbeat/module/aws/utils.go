@@ -6,7 +6,6 @@ package aws
 
 import (
 	"context"
-	"math"
 	"strings"
 	"time"
 
@@ -75,31 +74,35 @@ func getMetricDataPerRegion(metricDataQueries []cloudwatch.MetricDataQuery, next
 	return getMetricDataResponse.GetMetricDataOutput, nil
 }
 
+// MaxMetricDataQueries is the maximum number of queries of a GetMetricData
+// request.
+const MaxMetricDataQueries = 500
+
 // GetMetricDataResults function uses MetricDataQueries to get metric data output.
+// The queries are sent in batches of MaxMetricDataQueries, and the results of
+// each batch are paginated with NextToken.
 func GetMetricDataResults(metricDataQueries []cloudwatch.MetricDataQuery, svc cloudwatchiface.ClientAPI, startTime time.Time, endTime time.Time) ([]cloudwatch.MetricDataResult, error) {
-	init := true
-	maxQuerySize := 100
-	getMetricDataOutput := &cloudwatch.GetMetricDataOutput{NextToken: nil}
-	for init || getMetricDataOutput.NextToken != nil {
-		init = false
-		// Split metricDataQueries into smaller slices that length no longer than 100.
-		// 100 is defined in maxQuerySize.
-		// To avoid ValidationError: The collection MetricDataQueries must not have a size greater than 100.
-		for i := 0; i < len(metricDataQueries); i += maxQuerySize {
-			metricDataQueriesPartial := metricDataQueries[i:int(math.Min(float64(i+maxQuerySize), float64(len(metricDataQueries))))]
-			if len(metricDataQueriesPartial) == 0 {
-				return getMetricDataOutput.MetricDataResults, nil
-			}
+	var metricDataResults []cloudwatch.MetricDataResult
+	for i := 0; i < len(metricDataQueries); i += MaxMetricDataQueries {
+		// To avoid ValidationError: The collection MetricDataQueries must not
+		// have a size greater than 500.
+		end := i + MaxMetricDataQueries
+		if end > len(metricDataQueries) {
+			end = len(metricDataQueries)
+		}
+		metricDataQueriesPartial := metricDataQueries[i:end]
 
-			output, err := getMetricDataPerRegion(metricDataQueriesPartial, getMetricDataOutput.NextToken, svc, startTime, endTime)
+		var nextToken *string
+		for init := true; init || nextToken != nil; init = false {
+			output, err := getMetricDataPerRegion(metricDataQueriesPartial, nextToken, svc, startTime, endTime)
 			if err != nil {
-				return getMetricDataOutput.MetricDataResults, errors.Wrap(err, "getMetricDataPerRegion failed")
+				return metricDataResults, errors.Wrap(err, "getMetricDataPerRegion failed")
 			}
-
-			getMetricDataOutput.MetricDataResults = append(getMetricDataOutput.MetricDataResults, output.MetricDataResults...)
+			metricDataResults = append(metricDataResults, output.MetricDataResults...)
+			nextToken = output.NextToken
 		}
 	}
-	return getMetricDataOutput.MetricDataResults, nil
+	return metricDataResults, nil
 }
 
 // EventMapping maps data in input to a predefined schema.
@@ -244,6 +244,61 @@ func TestGetMetricDataResults(t *testing.T) {
 	assert.Equal(t, 0.0, getMetricDataResults[3].Values[0])
 }
 
+// MockCloudWatchClientPaged returns a result per query over two pages, and
+// records the requests.
+type MockCloudWatchClientPaged struct {
+	cloudwatchiface.ClientAPI
+	requests []*cloudwatch.GetMetricDataInput
+}
+
+func (m *MockCloudWatchClientPaged) GetMetricDataRequest(input *cloudwatch.GetMetricDataInput) cloudwatch.GetMetricDataRequest {
+	m.requests = append(m.requests, input)
+	output := &cloudwatch.GetMetricDataOutput{}
+	if input.NextToken == nil {
+		output.NextToken = awssdk.String("page2")
+	}
+	for _, query := range input.MetricDataQueries {
+		output.MetricDataResults = append(output.MetricDataResults, cloudwatch.MetricDataResult{Id: query.Id})
+	}
+
+	httpReq, _ := http.NewRequest("", "", nil)
+	return cloudwatch.GetMetricDataRequest{
+		Request: &awssdk.Request{
+			Data:        output,
+			HTTPRequest: httpReq,
+		},
+	}
+}
+
+func TestGetMetricDataResultsBatches(t *testing.T) {
+	startTime, endTime := GetStartTimeEndTime(10 * time.Minute)
+
+	mockSvc := &MockCloudWatchClientPaged{}
+	var metricDataQueries []cloudwatch.MetricDataQuery
+	for i := 0; i < 600; i++ {
+		metricDataQueries = append(metricDataQueries, cloudwatch.MetricDataQuery{
+			Id: awssdk.String(fmt.Sprintf("q%d", i)),
+		})
+	}
+	getMetricDataResults, err := GetMetricDataResults(metricDataQueries, mockSvc, startTime, endTime)
+	assert.NoError(t, err)
+
+	// Both batches are paginated
+	assert.Len(t, mockSvc.requests, 4)
+	for i, request := range mockSvc.requests {
+		if i%2 == 0 {
+			assert.Nil(t, request.NextToken)
+		} else {
+			assert.Equal(t, "page2", *request.NextToken)
+		}
+	}
+	assert.Len(t, mockSvc.requests[0].MetricDataQueries, MaxMetricDataQueries)
+	assert.Len(t, mockSvc.requests[2].MetricDataQueries, 100)
+
+	assert.Len(t, getMetricDataResults, 1200)
+	assert.Equal(t, "q599", *getMetricDataResults[1199].Id)
+}
+
 func TestCheckTimestampInArray(t *testing.T) {
 	timestamp1 := time.Now()
 	timestamp2 := timestamp1.Add(5 * time.Minute)
@@ -6,6 +6,7 @@ package server
 
 import (
 	"bytes"
+	"math"
 	"strconv"
 	"time"
 
@@ -33,9 +34,13 @@ type statsdMetric struct {
 func splitTags(rawTags []byte, kvSep []byte) map[string]string {
 	tags := map[string]string{}
 	for _, kv := range bytes.Split(rawTags, []byte(",")) {
+		if len(kv) == 0 {
+			continue
+		}
+		// DogStatsD tags without value are kept with an empty value
 		kvSplit := bytes.SplitN(kv, kvSep, 2)
 		if len(kvSplit) != 2 {
-			logger.Warnf("could not parse tags")
+			tags[string(kv)] = ""
 			continue
 		}
 		tags[string(kvSplit[0])] = string(kvSplit[1])
@@ -43,28 +48,38 @@ func splitTags(rawTags []byte, kvSep []byte) map[string]string {
 	return tags
 }
 
+func mergeTags(tags, other map[string]string) map[string]string {
+	if tags == nil {
+		return other
+	}
+	for k, v := range other {
+		tags[k] = v
+	}
+	return tags
+}
+
 func parseSingle(b []byte) (statsdMetric, error) {
-	// format: <metric name>:<value>|<type>[|@samplerate][|#<k>:<v>,<k>:<v>]
+	// format: <metric name>:<value>|<type>[|@samplerate][|#<k>:<v>,<k>:<v>][|c:<container id>][|T<timestamp>]
 	// alternative: <metric name>[,<k>=<v>,<k>=<v>]:<value>|<type>[|@samplerate]
 	s := statsdMetric{}
 
-	parts := bytes.SplitN(b, []byte("|"), 4)
+	parts := bytes.Split(b, []byte("|"))
 	if len(parts) < 2 {
 		return s, errInvalidPacket
 	}
 
-	if len(parts) > 2 && len(parts[2]) > 0 && parts[2][0] == '@' {
-		s.sampleRate = string(parts[2][1:])
-
-		if len(parts) > 3 {
-			parts = [][]byte{parts[0], parts[1], parts[3]}
-		} else {
-			parts = [][]byte{parts[0], parts[1]}
+	// Optional sections can be given in any order, unsupported DogStatsD
+	// extensions like the container ID are ignored.
+	for _, part := range parts[2:] {
+		if len(part) == 0 {
+			continue
+		}
+		switch part[0] {
+		case '@':
+			s.sampleRate = string(part[1:])
+		case '#':
+			s.tags = mergeTags(s.tags, splitTags(part[1:], []byte(":")))
 		}
-	}
-
-	if len(parts) > 2 && len(parts[2]) > 0 && parts[2][0] == '#' {
-		s.tags = splitTags(parts[2][1:], []byte(":"))
 	}
 
 	nameSplit := bytes.SplitN(parts[0], []byte{':'}, 2)
@@ -75,7 +90,7 @@ func parseSingle(b []byte) (statsdMetric, error) {
 	nameTagsSplit := bytes.SplitN(nameSplit[0], []byte(","), 2)
 	s.name = string(nameTagsSplit[0])
 	if len(nameTagsSplit) > 1 {
-		s.tags = splitTags(nameTagsSplit[1], []byte("="))
+		s.tags = mergeTags(s.tags, splitTags(nameTagsSplit[1], []byte("=")))
 	}
 
 	s.value = string(nameSplit[1])
@@ -99,9 +114,9 @@ func parse(b []byte) ([]statsdMetric, error) {
 	return metrics, nil
 }
 
-func newMetricProcessor(ttl time.Duration) *metricProcessor {
+func newMetricProcessor(ttl time.Duration, buckets []float64) *metricProcessor {
 	return &metricProcessor{
-		registry: &registry{metrics: map[string]map[string]*metric{}, ttl: ttl},
+		registry: &registry{metrics: map[string]map[string]*metric{}, ttl: ttl, buckets: buckets},
 	}
 }
 
@@ -155,13 +170,15 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 			return errors.Wrapf(err, "failed to process timer `%s` with value `%s`", m.name, m.value)
 		}
 		c.SampledUpdate(time.Duration(v), sampleRate)
-	case "h": // TODO: can these be floats?
+		p.updateBuckets(m, v, sampleRate)
+	case "h", "d": // DogStatsD distributions are aggregated like histograms
 		c := p.registry.GetOrNewHistogram(m.name, m.tags)
-		v, err := strconv.ParseInt(m.value, 10, 64)
+		v, err := strconv.ParseFloat(m.value, 64)
 		if err != nil {
 			return errors.Wrapf(err, "failed to process histogram `%s` with value `%s`", m.name, m.value)
 		}
-		c.Update(v)
+		c.Update(int64(v))
+		p.updateBuckets(m, v, sampleRate)
 	case "s":
 		c := p.registry.GetOrNewSet(m.name, m.tags)
 		c.Add(m.value)
@@ -171,6 +188,20 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 	return nil
 }
 
+// updateBuckets records a timer or histogram value in the buckets of the
+// metric, if histogram buckets are configured.
+func (p *metricProcessor) updateBuckets(m statsdMetric, v float64, sampleRate float64) {
+	b := p.registry.GetOrNewBuckets(m.name, m.tags)
+	if b == nil {
+		return
+	}
+	n := uint64(math.Round(1 / sampleRate))
+	if n < 1 {
+		n = 1
+	}
+	b.Update(v, n)
+}
+
 func (p *metricProcessor) Process(event server.Event) error {
 	bytesRaw, ok := event.GetEvent()[server.EventDataKey]
 	if !ok {
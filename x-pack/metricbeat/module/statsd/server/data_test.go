@@ -156,6 +156,35 @@ func TestParseMetrics(t *testing.T) {
 				},
 			},
 		},
+		{ // DogStatsD tags without value and extensions
+			input: "tags3:1|c|#k1:v1,canary|c:container|T1600000000",
+			expected: []statsdMetric{
+				{
+					name:       "tags3",
+					metricType: "c",
+					value:      "1",
+					tags: map[string]string{
+						"k1":     "v1",
+						"canary": "",
+					},
+				},
+			},
+		},
+		{ // Influx and DogStatsD tags combined
+			input: "tags4,k1=v1:1.5|d|#k2:v2|@0.5",
+			expected: []statsdMetric{
+				{
+					name:       "tags4",
+					metricType: "d",
+					value:      "1.5",
+					sampleRate: "0.5",
+					tags: map[string]string{
+						"k1": "v1",
+						"k2": "v2",
+					},
+				},
+			},
+		},
 		/// errors
 		{
 			input:    "meter1-1.4|m",
@@ -172,7 +201,7 @@ func TestParseMetrics(t *testing.T) {
 		assert.Equal(t, test.err, err, test.input)
 		assert.Equal(t, test.expected, actual, test.input)
 
-		processor := newMetricProcessor(time.Second)
+		processor := newMetricProcessor(time.Second, nil)
 		for _, e := range actual {
 			err := processor.processSingle(e)
 
@@ -483,3 +512,44 @@ func BenchmarkIngest(b *testing.B) {
 	}
 
 }
+
+func TestHistogramBuckets(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{
+		"module":            "statsd",
+		"histogram_buckets": []float64{10, 100, 1000},
+	}).(*MetricSet)
+	testData := []string{
+		"metric01:5|ms",
+		"metric01:50|ms|@0.5",
+		"metric01:80|ms",
+		"metric01:5000|ms",
+		"metric02:12.5|d",
+		"metric03:1|c",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	require.Len(t, events, 1)
+
+	metric01 := events[0].MetricSetFields["metric01"].(map[string]interface{})
+	assert.Equal(t, common.MapStr{
+		"values": []float64{5, 55, 1900},
+		"counts": []uint64{1, 3, 1},
+	}, metric01["histogram"])
+
+	metric02 := events[0].MetricSetFields["metric02"].(map[string]interface{})
+	assert.Equal(t, common.MapStr{
+		"values": []float64{55},
+		"counts": []uint64{1},
+	}, metric02["histogram"])
+
+	metric03 := events[0].MetricSetFields["metric03"].(map[string]interface{})
+	assert.NotContains(t, metric03, "histogram")
+
+	// buckets are reset on every report
+	events = ms.getEvents()
+	require.Len(t, events, 1)
+	metric01 = events[0].MetricSetFields["metric01"].(map[string]interface{})
+	assert.NotContains(t, metric01, "histogram")
+}
@@ -5,6 +5,7 @@
 package server
 
 import (
+	"sort"
 	"time"
 
 	"github.com/rcrowley/go-metrics"
@@ -22,11 +23,13 @@ type metric struct {
 	lastSeen   time.Time
 	sampleRate float32
 	metric     interface{}
+	buckets    *bucketHistogram
 }
 
 type registry struct {
 	metrics    map[string]map[string]*metric
 	ttl        time.Duration
+	buckets    []float64
 	lastReport time.Time
 }
 
@@ -52,6 +55,61 @@ func newSetMetric() *setMetric {
 	return &s
 }
 
+// bucketHistogram counts the values recorded since the last report into
+// buckets with fixed upper bounds, and values larger than the last bound into
+// an overflow bucket.
+type bucketHistogram struct {
+	bounds []float64
+	counts []uint64
+}
+
+func newBucketHistogram(bounds []float64) *bucketHistogram {
+	return &bucketHistogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// Update counts a value n times.
+func (b *bucketHistogram) Update(val float64, n uint64) {
+	b.counts[sort.SearchFloat64s(b.bounds, val)] += n
+}
+
+// Flush returns the non-empty buckets in the format of the Elasticsearch
+// histogram field type and resets the counts. Buckets are reported by their
+// centroid, the overflow bucket is interpolated using the width of the
+// previous bucket. Flush returns nil if no values have been recorded.
+func (b *bucketHistogram) Flush() common.MapStr {
+	var values []float64
+	var counts []uint64
+
+	var lower, width float64
+	for i, count := range b.counts {
+		var value float64
+		if i < len(b.bounds) {
+			width = b.bounds[i] - lower
+			value = lower + width/2
+			lower = b.bounds[i]
+		} else {
+			value = lower + width
+		}
+
+		if count > 0 {
+			values = append(values, value)
+			counts = append(counts, count)
+		}
+		b.counts[i] = 0
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+	return common.MapStr{
+		"values": values,
+		"counts": counts,
+	}
+}
+
 type deltaGaugeMetric struct {
 	value float64
 }
@@ -257,7 +315,13 @@ func (r *registry) GetAll() []metricsGroup {
 			// all the .tags are the same for this metricsMap
 			// we just need one
 			tags = m.tags
-			fields[m.name] = r.getMetric(m.metric)
+			values := r.getMetric(m.metric)
+			if m.buckets != nil {
+				if histogram := m.buckets.Flush(); histogram != nil {
+					values["histogram"] = histogram
+				}
+			}
+			fields[m.name] = values
 		}
 
 		// cleanup the tag group if it's empty
@@ -373,6 +437,23 @@ func (r *registry) GetOrNewSet(name string, tags map[string]string) *setMetric {
 	return r.GetOrNewSet(name, tags)
 }
 
+// GetOrNewBuckets returns the bucket histogram of an existing metric. It
+// returns nil if the metric doesn't exist or no buckets are configured.
+func (r *registry) GetOrNewBuckets(name string, tags map[string]string) *bucketHistogram {
+	if len(r.buckets) == 0 {
+		return nil
+	}
+
+	m, ok := r.metrics[r.metricHash(tags)][name]
+	if !ok {
+		return nil
+	}
+	if m.buckets == nil {
+		m.buckets = newBucketHistogram(r.buckets)
+	}
+	return m.buckets
+}
+
 func (r *registry) metricHash(tags map[string]string) string {
 	mapstrTags := common.MapStr{}
 	for k, v := range tags {
@@ -5,6 +5,7 @@
 package server
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/common"
@@ -22,6 +23,23 @@ func init() {
 // Config for the statsd server metricset.
 type Config struct {
 	TTL time.Duration `config:"ttl"`
+
+	// HistogramBuckets are the upper bounds of the buckets timers and
+	// histograms are counted into.
+	HistogramBuckets []float64 `config:"histogram_buckets"`
+}
+
+// Validate checks the histogram buckets are positive and increasing.
+func (c *Config) Validate() error {
+	for i, bound := range c.HistogramBuckets {
+		if bound <= 0 {
+			return fmt.Errorf("histogram bucket %v must be greater than 0", bound)
+		}
+		if i > 0 && bound <= c.HistogramBuckets[i-1] {
+			return fmt.Errorf("histogram buckets must be in increasing order, got %v after %v", bound, c.HistogramBuckets[i-1])
+		}
+	}
+	return nil
 }
 
 func defaultConfig() Config {
@@ -54,7 +72,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		return nil, err
 	}
 
-	processor := newMetricProcessor(config.TTL)
+	processor := newMetricProcessor(config.TTL, config.HistogramBuckets)
 	return &MetricSet{
 		BaseMetricSet: base,
 		server:        svc,